@@ -18,6 +18,8 @@ package telemetrygen // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"errors"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -26,7 +28,7 @@ import (
 var rootCmd = &cobra.Command{
 	Use:     "telemetrygen",
 	Short:   "Telemetrygen simulates a client generating traces and metrics",
-	Example: "telemetrygen metrics\ntelemetrygen traces",
+	Example: "telemetrygen metrics\ntelemetrygen traces\ntelemetrygen load --format statsd",
 }
 
 // tracesCmd is the command responsible for sending traces
@@ -51,8 +53,36 @@ var metricsCmd = &cobra.Command{
 	},
 }
 
+var loadCfg loadConfig
+
+// loadCmd is the command responsible for sending load in the formats of contrib receivers
+var loadCmd = &cobra.Command{
+	Use:     "load",
+	Short:   "Sends load in the formats of contrib receivers: " + strings.Join(formatNames(), ", "),
+	Example: "telemetrygen load --format syslog --rate 1000 --duration 1m\ntelemetrygen load --format splunk_hec --header \"Authorization=Splunk <token>\" --messages 100",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		start := time.Now()
+		result, err := runLoad(cmd.Context(), loadCfg)
+		if result != nil {
+			elapsed := time.Since(start)
+			cmd.Printf("sent %d messages (%d failed) in %s, %.1f messages/s\n",
+				result.sent, result.failed, elapsed.Round(time.Millisecond), float64(result.sent)/elapsed.Seconds())
+		}
+		return err
+	},
+}
+
 func init() {
-	rootCmd.AddCommand(tracesCmd, metricsCmd)
+	rootCmd.AddCommand(tracesCmd, metricsCmd, loadCmd)
+
+	loadCmd.Flags().StringVar(&loadCfg.Format, "format", "", "Format of the messages: "+strings.Join(formatNames(), ", "))
+	loadCmd.Flags().StringVar(&loadCfg.Endpoint, "endpoint", "", "Endpoint of the receiver, defaults to the receiver's default endpoint for the format")
+	loadCmd.Flags().IntVar(&loadCfg.WorkerCount, "workers", 1, "Number of workers (goroutines) sending messages, each with its own connection")
+	loadCmd.Flags().IntVar(&loadCfg.NumMessages, "messages", 0, "Number of messages sent by each worker, zero means until the duration elapsed")
+	loadCmd.Flags().Float64Var(&loadCfg.Rate, "rate", 0, "Messages per second sent by each worker, zero means no throttling")
+	loadCmd.Flags().DurationVar(&loadCfg.Duration, "duration", 0, "For how long to send messages")
+	loadCmd.Flags().StringArrayVar(&loadCfg.Headers, "header", nil, "HTTP header of the format key=value sent with each message, can be repeated")
+	_ = loadCmd.MarkFlagRequired("format")
 
 	// Disabling completion command for end user
 	// https://github.com/spf13/cobra/blob/master/shell_completions.md
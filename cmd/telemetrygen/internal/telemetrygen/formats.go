@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetrygen // import "github.com/open-telemetry/opentelemetry-collector-contrib/telemetrygen/internal/telemetrygen"
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	transportUDP  = "udp"
+	transportTCP  = "tcp"
+	transportHTTP = "http"
+)
+
+// format generates the messages of one of the supported formats.
+type format struct {
+	// transport is the protocol the messages are sent with
+	transport string
+	// defaultEndpoint is the endpoint of the receiver with its default configuration
+	defaultEndpoint string
+	// path and contentType are used by formats sent over http
+	path        string
+	contentType string
+	// generate returns the i-th message of a worker
+	generate func(r *rand.Rand, worker int, i int, now time.Time) ([]byte, error)
+}
+
+var formats = map[string]format{
+	"statsd": {
+		transport:       transportUDP,
+		defaultEndpoint: "localhost:8125",
+		generate:        generateStatsd,
+	},
+	"syslog": {
+		transport:       transportTCP,
+		defaultEndpoint: "localhost:54526",
+		generate:        generateSyslog,
+	},
+	"zipkin": {
+		transport:       transportHTTP,
+		defaultEndpoint: "localhost:9411",
+		path:            "/api/v2/spans",
+		contentType:     "application/json",
+		generate:        generateZipkin,
+	},
+	"splunk_hec": {
+		transport:       transportHTTP,
+		defaultEndpoint: "localhost:8088",
+		path:            "/services/collector",
+		contentType:     "application/json",
+		generate:        generateSplunkHEC,
+	},
+	"xray": {
+		transport:       transportUDP,
+		defaultEndpoint: "localhost:2000",
+		generate:        generateXRay,
+	},
+}
+
+// formatNames returns the sorted names of the supported formats.
+func formatNames() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var (
+	services   = []string{"frontend", "checkout", "cart", "payment", "shipping"}
+	operations = []string{"GET /", "GET /cart", "POST /checkout", "GET /product", "POST /payment"}
+	severities = []string{"info", "warning", "error", "debug"}
+)
+
+func pick(r *rand.Rand, values []string) string {
+	return values[r.Intn(len(values))]
+}
+
+func randomID(r *rand.Rand, size int) string {
+	b := make([]byte, size)
+	_, _ = r.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// generateStatsd returns a counter, gauge, timer or histogram line in the
+// DogStatsD format.
+func generateStatsd(r *rand.Rand, worker int, i int, _ time.Time) ([]byte, error) {
+	tags := fmt.Sprintf("|#service:%s,worker:%d", pick(r, services), worker)
+	var line string
+	switch i % 4 {
+	case 0:
+		line = fmt.Sprintf("telemetrygen.requests:1|c%s", tags)
+	case 1:
+		line = fmt.Sprintf("telemetrygen.queue.size:%d|g%s", r.Intn(100), tags)
+	case 2:
+		line = fmt.Sprintf("telemetrygen.request.duration:%d|ms%s", r.Intn(1000), tags)
+	default:
+		line = fmt.Sprintf("telemetrygen.payload.size:%d|h%s", r.Intn(10000), tags)
+	}
+	return []byte(line), nil
+}
+
+// generateSyslog returns a newline terminated RFC5424 message.
+func generateSyslog(r *rand.Rand, worker int, i int, now time.Time) ([]byte, error) {
+	severity := r.Intn(8)
+	// facility 16 is local0
+	priority := 16*8 + severity
+	msg := fmt.Sprintf("<%d>1 %s telemetrygen-%d %s %d ID%d [telemetrygen@32473 worker=\"%d\" sequence=\"%d\"] %s request handled\n",
+		priority, now.UTC().Format(time.RFC3339Nano), worker, pick(r, services), 1000+worker, i%100, worker, i, pick(r, operations))
+	return []byte(msg), nil
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type zipkinSpan struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name"`
+	Kind          string            `json:"kind"`
+	Timestamp     int64             `json:"timestamp"`
+	Duration      int64             `json:"duration"`
+	LocalEndpoint zipkinEndpoint    `json:"localEndpoint"`
+	Tags          map[string]string `json:"tags"`
+}
+
+// generateZipkin returns a JSON v2 array with a server span and a client child span.
+func generateZipkin(r *rand.Rand, worker int, _ int, now time.Time) ([]byte, error) {
+	traceID := randomID(r, 16)
+	parentID := randomID(r, 8)
+	service := pick(r, services)
+	operation := pick(r, operations)
+	duration := int64(r.Intn(100000) + 1000)
+	spans := []zipkinSpan{
+		{
+			TraceID:       traceID,
+			ID:            parentID,
+			Name:          operation,
+			Kind:          "SERVER",
+			Timestamp:     now.UnixMicro(),
+			Duration:      duration,
+			LocalEndpoint: zipkinEndpoint{ServiceName: service},
+			Tags:          map[string]string{"http.method": strings.Fields(operation)[0], "telemetrygen.worker": fmt.Sprint(worker)},
+		},
+		{
+			TraceID:       traceID,
+			ID:            randomID(r, 8),
+			ParentID:      parentID,
+			Name:          "query",
+			Kind:          "CLIENT",
+			Timestamp:     now.UnixMicro() + duration/4,
+			Duration:      duration / 2,
+			LocalEndpoint: zipkinEndpoint{ServiceName: service},
+			Tags:          map[string]string{"db.system": "postgresql"},
+		},
+	}
+	return json.Marshal(spans)
+}
+
+type splunkEvent struct {
+	Time       float64           `json:"time"`
+	Host       string            `json:"host"`
+	Source     string            `json:"source"`
+	SourceType string            `json:"sourcetype"`
+	Index      string            `json:"index,omitempty"`
+	Event      string            `json:"event"`
+	Fields     map[string]string `json:"fields"`
+}
+
+// generateSplunkHEC returns a HEC event.
+func generateSplunkHEC(r *rand.Rand, worker int, i int, now time.Time) ([]byte, error) {
+	return json.Marshal(splunkEvent{
+		Time:       float64(now.UnixNano()) / float64(time.Second),
+		Host:       fmt.Sprintf("telemetrygen-%d", worker),
+		Source:     pick(r, services),
+		SourceType: "telemetrygen",
+		Event:      fmt.Sprintf("%s request %d handled", pick(r, operations), i),
+		Fields:     map[string]string{"severity": pick(r, severities)},
+	})
+}
+
+type xrayHTTP struct {
+	Request  map[string]interface{} `json:"request"`
+	Response map[string]interface{} `json:"response"`
+}
+
+type xraySegment struct {
+	Name      string   `json:"name"`
+	ID        string   `json:"id"`
+	TraceID   string   `json:"trace_id"`
+	StartTime float64  `json:"start_time"`
+	EndTime   float64  `json:"end_time"`
+	HTTP      xrayHTTP `json:"http"`
+	Error     bool     `json:"error,omitempty"`
+}
+
+// xrayHeader is sent before each segment to the X-Ray daemon.
+const xrayHeader = `{"format": "json", "version": 1}` + "\n"
+
+// generateXRay returns a segment prefixed with the X-Ray daemon header.
+func generateXRay(r *rand.Rand, _ int, _ int, now time.Time) ([]byte, error) {
+	start := float64(now.UnixNano()) / float64(time.Second)
+	status := 200
+	if r.Intn(20) == 0 {
+		status = 500
+	}
+	operation := strings.Fields(pick(r, operations))
+	segment, err := json.Marshal(xraySegment{
+		Name:      pick(r, services),
+		ID:        randomID(r, 8),
+		TraceID:   fmt.Sprintf("1-%08x-%s", now.Unix(), randomID(r, 12)),
+		StartTime: start,
+		EndTime:   start + float64(r.Intn(1000))/1000,
+		HTTP: xrayHTTP{
+			Request:  map[string]interface{}{"method": operation[0], "url": "http://localhost" + operation[1]},
+			Response: map[string]interface{}{"status": status},
+		},
+		Error: status >= 500,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xrayHeader), segment...), nil
+}
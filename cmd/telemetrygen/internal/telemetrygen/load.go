@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetrygen // import "github.com/open-telemetry/opentelemetry-collector-contrib/telemetrygen/internal/telemetrygen"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var errNoLimit = errors.New("either a number of messages or a duration is required")
+
+// loadConfig describes a load test.
+type loadConfig struct {
+	Format      string
+	Endpoint    string
+	WorkerCount int
+	NumMessages int
+	Rate        float64
+	Duration    time.Duration
+	Headers     []string
+}
+
+// loadResult counts the messages sent by all the workers of a load test.
+type loadResult struct {
+	sent   int64
+	failed int64
+}
+
+func (c *loadConfig) validate() (format, error) {
+	f, ok := formats[c.Format]
+	if !ok {
+		return format{}, fmt.Errorf("unknown format %q, must be one of %s", c.Format, strings.Join(formatNames(), ", "))
+	}
+	if c.WorkerCount < 1 {
+		return format{}, errors.New("at least one worker is required")
+	}
+	if c.NumMessages <= 0 && c.Duration <= 0 {
+		return format{}, errNoLimit
+	}
+	if c.Rate < 0 {
+		return format{}, errors.New("the rate must not be negative")
+	}
+	for _, header := range c.Headers {
+		if !strings.Contains(header, "=") {
+			return format{}, fmt.Errorf("header %q should be of the format key=value", header)
+		}
+	}
+	return f, nil
+}
+
+// runLoad sends messages of the configured format from each worker until the
+// duration elapsed, or each worker sent its number of messages.
+func runLoad(ctx context.Context, cfg loadConfig) (*loadResult, error) {
+	f, err := cfg.validate()
+	if err != nil {
+		return nil, err
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = f.defaultEndpoint
+	}
+
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	result := &loadResult{}
+	var wg sync.WaitGroup
+	errs := make(chan error, cfg.WorkerCount)
+	for i := 0; i < cfg.WorkerCount; i++ {
+		s, err := newSender(f, endpoint, cfg.Headers)
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			defer s.Close()
+			errs <- runWorker(ctx, cfg, f, s, worker, result)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func runWorker(ctx context.Context, cfg loadConfig, f format, s sender, worker int, result *loadResult) error {
+	r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(worker))) // #nosec G404 -- not used for security
+
+	var tick <-chan time.Time
+	if cfg.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.Rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for i := 0; cfg.NumMessages <= 0 || i < cfg.NumMessages; i++ {
+		if tick != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-tick:
+			}
+		} else if ctx.Err() != nil {
+			return nil
+		}
+
+		msg, err := f.generate(r, worker, i, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := s.Send(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			atomic.AddInt64(&result.failed, 1)
+			continue
+		}
+		atomic.AddInt64(&result.sent, 1)
+	}
+	return nil
+}
+
+// sender sends messages to a receiver.
+type sender interface {
+	Send(ctx context.Context, msg []byte) error
+	Close() error
+}
+
+func newSender(f format, endpoint string, headers []string) (sender, error) {
+	switch f.transport {
+	case transportUDP, transportTCP:
+		conn, err := net.Dial(f.transport, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to %s: %w", endpoint, err)
+		}
+		return &connSender{conn: conn}, nil
+	case transportHTTP:
+		url := endpoint
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			url = "http://" + url
+		}
+		s := &httpSender{
+			client:      &http.Client{Timeout: 10 * time.Second},
+			url:         strings.TrimSuffix(url, "/") + f.path,
+			contentType: f.contentType,
+			headers:     http.Header{},
+		}
+		for _, header := range headers {
+			key, value, _ := strings.Cut(header, "=")
+			s.headers.Add(key, value)
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unknown transport %q", f.transport)
+	}
+}
+
+type connSender struct {
+	conn net.Conn
+}
+
+func (s *connSender) Send(_ context.Context, msg []byte) error {
+	_, err := s.conn.Write(msg)
+	return err
+}
+
+func (s *connSender) Close() error {
+	return s.conn.Close()
+}
+
+type httpSender struct {
+	client      *http.Client
+	url         string
+	contentType string
+	headers     http.Header
+}
+
+func (s *httpSender) Send(ctx context.Context, msg []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(msg))
+	if err != nil {
+		return err
+	}
+	for key, values := range s.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", s.contentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s returned %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+func (s *httpSender) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
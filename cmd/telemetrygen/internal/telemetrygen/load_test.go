@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetrygen
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rfc5424 = regexp.MustCompile(`^<\d{1,3}>1 \S+ \S+ \S+ \d+ \S+ \[.+\] .+\n$`)
+
+func TestFormats(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	now := time.Now()
+	for name, f := range formats {
+		for i := 0; i < 4; i++ {
+			msg, err := f.generate(r, 1, i, now)
+			require.NoError(t, err, name)
+
+			switch name {
+			case "statsd":
+				assert.Regexp(t, `^[a-z.]+:\d+\|(c|g|ms|h)\|#`, string(msg))
+			case "syslog":
+				assert.Regexp(t, rfc5424, string(msg))
+			case "xray":
+				header, segment, ok := strings.Cut(string(msg), "\n")
+				assert.True(t, ok)
+				assert.Equal(t, xrayHeader, header+"\n")
+				assert.True(t, json.Valid([]byte(segment)), segment)
+			default:
+				assert.True(t, json.Valid(msg), string(msg))
+			}
+		}
+	}
+}
+
+func TestLoadConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  loadConfig
+		err  string
+	}{
+		{name: "unknown format", cfg: loadConfig{Format: "gelf", WorkerCount: 1, NumMessages: 1}, err: `unknown format "gelf"`},
+		{name: "no worker", cfg: loadConfig{Format: "statsd", NumMessages: 1}, err: "at least one worker is required"},
+		{name: "no limit", cfg: loadConfig{Format: "statsd", WorkerCount: 1}, err: errNoLimit.Error()},
+		{name: "negative rate", cfg: loadConfig{Format: "statsd", WorkerCount: 1, NumMessages: 1, Rate: -1}, err: "the rate must not be negative"},
+		{name: "invalid header", cfg: loadConfig{Format: "zipkin", WorkerCount: 1, NumMessages: 1, Headers: []string{"key"}}, err: `header "key" should be of the format key=value`},
+		{name: "valid", cfg: loadConfig{Format: "zipkin", WorkerCount: 1, Duration: time.Second, Headers: []string{"key=value"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.cfg.validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, strings.HasPrefix(err.Error(), tt.err), err.Error())
+		})
+	}
+}
+
+func TestRunLoadHTTP(t *testing.T) {
+	var mu sync.Mutex
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/collector" || r.Header.Get("Authorization") != "Splunk token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	result, err := runLoad(context.Background(), loadConfig{
+		Format:      "splunk_hec",
+		Endpoint:    server.URL,
+		WorkerCount: 2,
+		NumMessages: 5,
+		Headers:     []string{"Authorization=Splunk token"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), result.sent)
+	assert.Equal(t, int64(0), result.failed)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, bodies, 10)
+}
+
+func TestRunLoadTCPDuration(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	lines := make(chan string, 1000)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text() + "\n"
+		}
+		close(lines)
+	}()
+
+	result, err := runLoad(context.Background(), loadConfig{
+		Format:      "syslog",
+		Endpoint:    listener.Addr().String(),
+		WorkerCount: 1,
+		Rate:        100,
+		Duration:    200 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	// the rate limits the worker to about 20 messages
+	assert.Greater(t, result.sent, int64(0))
+	assert.LessOrEqual(t, result.sent, int64(25))
+
+	var received int64
+	for line := range lines {
+		assert.Regexp(t, rfc5424, line)
+		received++
+	}
+	assert.Equal(t, result.sent, received)
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alertmanagerexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/alertmanagerexporter"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for the Alertmanager exporter.
+type Config struct {
+	config.ExporterSettings       `mapstructure:",squash"`
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// AlertNameAttribute is the attribute holding the name of the alert. The log records and the
+	// metric data points which don't have it, nor their resource, are not alerts and are ignored.
+	AlertNameAttribute string `mapstructure:"alertname_attribute"`
+
+	// SeverityAttribute is the attribute holding the severity of the alert, set in the severity label.
+	// Default: the severity text of the log records.
+	SeverityAttribute string `mapstructure:"severity_attribute"`
+
+	// LabelAttributes are the attributes set as labels of the alerts.
+	// Default: all the attributes of the events and of their resource.
+	LabelAttributes []string `mapstructure:"label_attributes"`
+
+	// GroupBy are the labels by which the alerts are grouped, each group being sent in its own request.
+	// Default: all the alerts of a batch are sent in the same request.
+	GroupBy []string `mapstructure:"group_by"`
+
+	// ResolveTimeout is the time after which Alertmanager resolves an alert which isn't sent again.
+	ResolveTimeout time.Duration `mapstructure:"resolve_timeout"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	if cfg.AlertNameAttribute == "" {
+		return errors.New("alertname_attribute must be set")
+	}
+	if cfg.ResolveTimeout <= 0 {
+		return errors.New("resolve_timeout must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alertmanagerexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	defaultRetrySettings := exporterhelper.NewDefaultRetrySettings()
+	defaultQueueSettings := exporterhelper.NewDefaultQueueSettings()
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Exporter
+		expectedErr string
+	}{
+		{
+			id:       config.NewComponentID(typeStr),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "allsettings"),
+			expected: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://alertmanager:9093",
+					Timeout:  10 * time.Second,
+				},
+				RetrySettings: exporterhelper.RetrySettings{
+					Enabled:         false,
+					InitialInterval: defaultRetrySettings.InitialInterval,
+					MaxInterval:     defaultRetrySettings.MaxInterval,
+					MaxElapsedTime:  defaultRetrySettings.MaxElapsedTime,
+				},
+				QueueSettings: exporterhelper.QueueSettings{
+					Enabled:      false,
+					NumConsumers: defaultQueueSettings.NumConsumers,
+					QueueSize:    defaultQueueSettings.QueueSize,
+				},
+				AlertNameAttribute: "alert.name",
+				SeverityAttribute:  "alert.severity",
+				LabelAttributes:    []string{"service.name", "deployment.environment"},
+				GroupBy:            []string{"service_name"},
+				ResolveTimeout:     10 * time.Minute,
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "noendpoint"),
+			expectedErr: "endpoint must be set",
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "noalertname"),
+			expectedErr: "alertname_attribute must be set",
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidresolvetimeout"),
+			expectedErr: "resolve_timeout must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExporter(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alertmanagerexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/alertmanagerexporter"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const (
+	alertsPath = "/api/v2/alerts"
+
+	alertNameLabel = "alertname"
+	severityLabel  = "severity"
+)
+
+// alert is an alert of the body of a request posting alerts, as documented at
+// https://github.com/prometheus/alertmanager/blob/main/api/v2/openapi.yaml
+type alert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+}
+
+type alertExporter struct {
+	config   *Config
+	settings component.TelemetrySettings
+	client   *http.Client
+	now      func() time.Time
+}
+
+func newAlertExporter(config *Config, settings component.TelemetrySettings) *alertExporter {
+	return &alertExporter{
+		config:   config,
+		settings: settings,
+		now:      time.Now,
+	}
+}
+
+func (e *alertExporter) start(_ context.Context, host component.Host) (err error) {
+	e.client, err = e.config.HTTPClientSettings.ToClient(host, e.settings)
+	return err
+}
+
+// exportLogs sends the log records which have an alert name as alerts.
+func (e *alertExporter) exportLogs(ctx context.Context, ld plog.Logs) error {
+	var alerts []alert
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				a, ok := e.newAlert(rl.Resource().Attributes(), lr.Attributes(), lr.SeverityText(), lr.Timestamp())
+				if !ok {
+					continue
+				}
+				if description := lr.Body().AsString(); description != "" {
+					a.Annotations["description"] = description
+				}
+				alerts = append(alerts, a)
+			}
+		}
+	}
+	return e.sendAlerts(ctx, alerts)
+}
+
+// exportMetrics sends the gauge and sum data points which have an alert name as alerts.
+func (e *alertExporter) exportMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var alerts []alert
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				var dps pmetric.NumberDataPointSlice
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					dps = metric.Gauge().DataPoints()
+				case pmetric.MetricTypeSum:
+					dps = metric.Sum().DataPoints()
+				default:
+					continue
+				}
+				for l := 0; l < dps.Len(); l++ {
+					dp := dps.At(l)
+					a, ok := e.newAlert(rm.Resource().Attributes(), dp.Attributes(), "", dp.Timestamp())
+					if !ok {
+						continue
+					}
+					a.Annotations["metric"] = metric.Name()
+					a.Annotations["value"] = formatValue(dp)
+					if metric.Description() != "" {
+						a.Annotations["description"] = metric.Description()
+					}
+					alerts = append(alerts, a)
+				}
+			}
+		}
+	}
+	return e.sendAlerts(ctx, alerts)
+}
+
+// newAlert creates the alert of an event, and tells whether the event is an alert.
+func (e *alertExporter) newAlert(resource, attributes pcommon.Map, severityText string, timestamp pcommon.Timestamp) (alert, bool) {
+	name, ok := lookup(e.config.AlertNameAttribute, resource, attributes)
+	if !ok || name == "" {
+		return alert{}, false
+	}
+
+	labels := make(map[string]string)
+	if len(e.config.LabelAttributes) == 0 {
+		for _, m := range []pcommon.Map{resource, attributes} {
+			m.Range(func(k string, v pcommon.Value) bool {
+				labels[prometheus.NormalizeLabel(k)] = v.AsString()
+				return true
+			})
+		}
+	} else {
+		for _, key := range e.config.LabelAttributes {
+			if value, ok := lookup(key, resource, attributes); ok {
+				labels[prometheus.NormalizeLabel(key)] = value
+			}
+		}
+	}
+
+	delete(labels, prometheus.NormalizeLabel(e.config.AlertNameAttribute))
+	labels[alertNameLabel] = name
+	severity := severityText
+	if e.config.SeverityAttribute != "" {
+		delete(labels, prometheus.NormalizeLabel(e.config.SeverityAttribute))
+		if value, ok := lookup(e.config.SeverityAttribute, resource, attributes); ok {
+			severity = value
+		}
+	}
+	if severity != "" {
+		labels[severityLabel] = strings.ToLower(severity)
+	}
+
+	now := e.now()
+	startsAt := timestamp.AsTime()
+	if timestamp == 0 || startsAt.After(now) {
+		startsAt = now
+	}
+	return alert{
+		Labels:      labels,
+		Annotations: make(map[string]string),
+		StartsAt:    startsAt,
+		EndsAt:      now.Add(e.config.ResolveTimeout),
+	}, true
+}
+
+// sendAlerts sends the alerts grouped by the group_by labels, in one request per group. When the same
+// alert is found several times, only its last occurrence is sent. Since Alertmanager merges the alerts
+// it already knows, all the alerts are sent again when one of the requests fails with a transient error.
+func (e *alertExporter) sendAlerts(ctx context.Context, alerts []alert) error {
+	var groupKeys []string
+	groups := make(map[string][]alert)
+	seen := make(map[string]int)
+	for _, a := range alerts {
+		groupKey := e.groupKey(a)
+		if _, ok := groups[groupKey]; !ok {
+			groupKeys = append(groupKeys, groupKey)
+		}
+		fingerprint := groupKey + "\xff" + fingerprint(a)
+		if i, ok := seen[fingerprint]; ok {
+			groups[groupKey][i] = a
+			continue
+		}
+		seen[fingerprint] = len(groups[groupKey])
+		groups[groupKey] = append(groups[groupKey], a)
+	}
+
+	var errs, permanentErrs error
+	for _, groupKey := range groupKeys {
+		err := e.postAlerts(ctx, groups[groupKey])
+		if err == nil {
+			continue
+		}
+		if consumererror.IsPermanent(err) {
+			permanentErrs = multierr.Append(permanentErrs, err)
+			continue
+		}
+		errs = multierr.Append(errs, err)
+	}
+
+	if errs != nil {
+		return multierr.Append(errs, permanentErrs)
+	}
+	if permanentErrs != nil {
+		return consumererror.NewPermanent(permanentErrs)
+	}
+	return nil
+}
+
+func (e *alertExporter) postAlerts(ctx context.Context, alerts []alert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	endpoint := strings.TrimSuffix(e.config.Endpoint, "/") + alertsPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post %d alerts: %w", len(alerts), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		e.settings.Logger.Debug("failed to post the alerts", zap.String("response", string(respBody)))
+		err = fmt.Errorf("failed to post %d alerts: got http status %s", len(alerts), resp.Status)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return consumererror.NewPermanent(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// groupKey returns the values of the group_by labels of the alert.
+func (e *alertExporter) groupKey(a alert) string {
+	values := make([]string, len(e.config.GroupBy))
+	for i, label := range e.config.GroupBy {
+		values[i] = a.Labels[label]
+	}
+	return strings.Join(values, "\xff")
+}
+
+// fingerprint identifies an alert by its labels, as Alertmanager does.
+func fingerprint(a alert) string {
+	names := make([]string, 0, len(a.Labels))
+	for name := range a.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte('\xfe')
+		b.WriteString(a.Labels[name])
+		b.WriteByte('\xff')
+	}
+	return b.String()
+}
+
+func formatValue(dp pmetric.NumberDataPoint) string {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return strconv.FormatInt(dp.IntValue(), 10)
+	}
+	return strconv.FormatFloat(dp.DoubleValue(), 'g', -1, 64)
+}
+
+// lookup returns the value of the attribute of the event, or of its resource.
+func lookup(key string, resource, attributes pcommon.Map) (string, bool) {
+	if value, ok := attributes.Get(key); ok {
+		return value.AsString(), true
+	}
+	if value, ok := resource.Get(key); ok {
+		return value.AsString(), true
+	}
+	return "", false
+}
@@ -0,0 +1,310 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alertmanagerexporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var (
+	testTime = time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	testNow  = testTime.Add(time.Minute)
+)
+
+func newTestServer(t *testing.T, status int, received *[][]alert) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, alertsPath, r.URL.Path)
+		var alerts []alert
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&alerts))
+		*received = append(*received, alerts)
+		w.WriteHeader(status)
+	}))
+}
+
+func newTestExporter(t *testing.T, endpoint string, configure func(*Config)) *alertExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	if configure != nil {
+		configure(cfg)
+	}
+
+	exp := newAlertExporter(cfg, componenttest.NewNopTelemetrySettings())
+	exp.now = func() time.Time { return testNow }
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func testLogs() plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+
+	diskFull := records.AppendEmpty()
+	diskFull.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	diskFull.SetSeverityText("ERROR")
+	diskFull.Body().SetStr("disk is full")
+	diskFull.Attributes().PutStr("alertname", "DiskFull")
+	diskFull.Attributes().PutStr("device", "/dev/sda1")
+
+	other := records.AppendEmpty()
+	other.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	other.Body().SetStr("request served")
+
+	highLatency := records.AppendEmpty()
+	highLatency.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	highLatency.Attributes().PutStr("alertname", "HighLatency")
+	highLatency.Attributes().PutStr("severity", "Warning")
+
+	rl = logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "cart")
+	records = rl.ScopeLogs().AppendEmpty().LogRecords()
+
+	// The same alert is sent once.
+	for i := 0; i < 2; i++ {
+		cartDiskFull := records.AppendEmpty()
+		cartDiskFull.Body().SetStr("disk is still full")
+		cartDiskFull.Attributes().PutStr("alertname", "DiskFull")
+		cartDiskFull.Attributes().PutStr("severity", "critical")
+	}
+	return logs
+}
+
+func TestExportLogs(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*Config)
+		expected  [][]alert
+	}{
+		{
+			name: "default",
+			expected: [][]alert{{
+				{
+					Labels: map[string]string{
+						"alertname":    "DiskFull",
+						"severity":     "error",
+						"service_name": "checkout",
+						"device":       "/dev/sda1",
+					},
+					Annotations: map[string]string{"description": "disk is full"},
+					StartsAt:    testTime,
+					EndsAt:      testNow.Add(defaultResolveTimeout),
+				},
+				{
+					Labels: map[string]string{
+						"alertname":    "HighLatency",
+						"severity":     "warning",
+						"service_name": "checkout",
+					},
+					StartsAt: testTime,
+					EndsAt:   testNow.Add(defaultResolveTimeout),
+				},
+				{
+					Labels: map[string]string{
+						"alertname":    "DiskFull",
+						"severity":     "critical",
+						"service_name": "cart",
+					},
+					Annotations: map[string]string{"description": "disk is still full"},
+					StartsAt:    testNow,
+					EndsAt:      testNow.Add(defaultResolveTimeout),
+				},
+			}},
+		},
+		{
+			name: "label attributes and grouping",
+			configure: func(cfg *Config) {
+				cfg.LabelAttributes = []string{"service.name"}
+				cfg.GroupBy = []string{"alertname"}
+				cfg.ResolveTimeout = time.Hour
+			},
+			expected: [][]alert{
+				{
+					{
+						Labels: map[string]string{
+							"alertname":    "DiskFull",
+							"severity":     "error",
+							"service_name": "checkout",
+						},
+						Annotations: map[string]string{"description": "disk is full"},
+						StartsAt:    testTime,
+						EndsAt:      testNow.Add(time.Hour),
+					},
+					{
+						Labels: map[string]string{
+							"alertname":    "DiskFull",
+							"severity":     "critical",
+							"service_name": "cart",
+						},
+						Annotations: map[string]string{"description": "disk is still full"},
+						StartsAt:    testNow,
+						EndsAt:      testNow.Add(time.Hour),
+					},
+				},
+				{
+					{
+						Labels: map[string]string{
+							"alertname":    "HighLatency",
+							"severity":     "warning",
+							"service_name": "checkout",
+						},
+						StartsAt: testTime,
+						EndsAt:   testNow.Add(time.Hour),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received [][]alert
+			server := newTestServer(t, http.StatusOK, &received)
+			defer server.Close()
+
+			exp := newTestExporter(t, server.URL, tt.configure)
+			require.NoError(t, exp.exportLogs(context.Background(), testLogs()))
+			assert.Equal(t, tt.expected, received)
+		})
+	}
+}
+
+func TestExportMetrics(t *testing.T) {
+	var received [][]alert
+	server := newTestServer(t, http.StatusOK, &received)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.AlertNameAttribute = "alert.name"
+	})
+
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", "node-1")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := ms.AppendEmpty()
+	gauge.SetName("system.cpu.utilization")
+	gauge.SetDescription("CPU utilization is high")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	dp.SetDoubleValue(0.95)
+	dp.Attributes().PutStr("alert.name", "HighCPU")
+	dp = gauge.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	dp.SetDoubleValue(0.1)
+
+	sum := ms.AppendEmpty()
+	sum.SetName("http.server.errors")
+	dp = sum.SetEmptySum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	dp.SetIntValue(42)
+	dp.Attributes().PutStr("alert.name", "HTTPErrors")
+	dp.Attributes().PutStr("severity", "page")
+
+	histogram := ms.AppendEmpty()
+	histogram.SetName("http.server.duration")
+	histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("alert.name", "HighLatency")
+
+	require.NoError(t, exp.exportMetrics(context.Background(), metrics))
+	assert.Equal(t, [][]alert{{
+		{
+			Labels: map[string]string{
+				"alertname": "HighCPU",
+				"host_name": "node-1",
+			},
+			Annotations: map[string]string{
+				"metric":      "system.cpu.utilization",
+				"value":       "0.95",
+				"description": "CPU utilization is high",
+			},
+			StartsAt: testTime,
+			EndsAt:   testNow.Add(defaultResolveTimeout),
+		},
+		{
+			Labels: map[string]string{
+				"alertname": "HTTPErrors",
+				"severity":  "page",
+				"host_name": "node-1",
+			},
+			Annotations: map[string]string{
+				"metric": "http.server.errors",
+				"value":  "42",
+			},
+			StartsAt: testTime,
+			EndsAt:   testNow.Add(defaultResolveTimeout),
+		},
+	}}, received)
+}
+
+func TestExportNoAlerts(t *testing.T) {
+	var received [][]alert
+	server := newTestServer(t, http.StatusOK, &received)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL, nil)
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("request served")
+	require.NoError(t, exp.exportLogs(context.Background(), logs))
+	assert.Empty(t, received)
+}
+
+func TestExportErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantPermanent bool
+	}{
+		{
+			name:          "bad request",
+			status:        http.StatusBadRequest,
+			wantPermanent: true,
+		},
+		{
+			name:   "too many requests",
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name:   "server error",
+			status: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received [][]alert
+			server := newTestServer(t, tt.status, &received)
+			defer server.Close()
+
+			exp := newTestExporter(t, server.URL, nil)
+			err := exp.exportLogs(context.Background(), testLogs())
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+		})
+	}
+}
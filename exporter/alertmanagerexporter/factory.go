@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alertmanagerexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/alertmanagerexporter"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	typeStr = "alertmanager"
+	// The stability level of the exporter.
+	stability = component.StabilityLevelAlpha
+
+	defaultEndpoint           = "http://localhost:9093"
+	defaultTimeout            = 5 * time.Second
+	defaultAlertNameAttribute = "alertname"
+	defaultSeverityAttribute  = "severity"
+	defaultResolveTimeout     = 5 * time.Minute
+)
+
+// NewFactory creates a factory for the Alertmanager exporter.
+func NewFactory() component.ExporterFactory {
+	return component.NewExporterFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithLogsExporter(createLogsExporter, stability),
+		component.WithMetricsExporter(createMetricsExporter, stability),
+	)
+}
+
+func createDefaultConfig() config.Exporter {
+	return &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: defaultEndpoint,
+			Timeout:  defaultTimeout,
+		},
+		RetrySettings:      exporterhelper.NewDefaultRetrySettings(),
+		QueueSettings:      exporterhelper.NewDefaultQueueSettings(),
+		AlertNameAttribute: defaultAlertNameAttribute,
+		SeverityAttribute:  defaultSeverityAttribute,
+		ResolveTimeout:     defaultResolveTimeout,
+	}
+}
+
+func createLogsExporter(ctx context.Context, settings component.ExporterCreateSettings, cfg config.Exporter) (component.LogsExporter, error) {
+	eCfg := cfg.(*Config)
+	exp := newAlertExporter(eCfg, settings.TelemetrySettings)
+
+	return exporterhelper.NewLogsExporter(
+		ctx,
+		settings,
+		cfg,
+		exp.exportLogs,
+		// explicitly disable since we rely on http.Client timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(eCfg.RetrySettings),
+		exporterhelper.WithQueue(eCfg.QueueSettings),
+		exporterhelper.WithStart(exp.start),
+	)
+}
+
+func createMetricsExporter(ctx context.Context, settings component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+	eCfg := cfg.(*Config)
+	exp := newAlertExporter(eCfg, settings.TelemetrySettings)
+
+	return exporterhelper.NewMetricsExporter(
+		ctx,
+		settings,
+		cfg,
+		exp.exportMetrics,
+		// explicitly disable since we rely on http.Client timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(eCfg.RetrySettings),
+		exporterhelper.WithQueue(eCfg.QueueSettings),
+		exporterhelper.WithStart(exp.start),
+	)
+}
@@ -15,6 +15,10 @@
 package awsemfexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"go.opentelemetry.io/collector/config"
 	"go.uber.org/zap"
 
@@ -55,6 +59,14 @@ type Config struct {
 	// MetricDeclarations is the list of rules to be used to set dimensions for exported metrics.
 	MetricDeclarations []*MetricDeclaration `mapstructure:"metric_declarations"`
 
+	// MetricDeclarationsFile is the path of a YAML file holding the metric declarations under a
+	// "metric_declarations" key. The file is reloaded when it changes, so that metric declarations
+	// can be updated without restarting the collector. It cannot be used with MetricDeclarations.
+	MetricDeclarationsFile string `mapstructure:"metric_declarations_file"`
+
+	// MetricDeclarationsReloadInterval is how often MetricDeclarationsFile is checked for changes.
+	MetricDeclarationsReloadInterval time.Duration `mapstructure:"metric_declarations_reload_interval"`
+
 	// MetricDescriptors is the list of override metric descriptors that are sent to the CloudWatch
 	MetricDescriptors []MetricDescriptor `mapstructure:"metric_descriptors"`
 
@@ -90,16 +102,19 @@ type MetricDescriptor struct {
 
 // Validate filters out invalid metricDeclarations and metricDescriptors
 func (config *Config) Validate() error {
-	var validDeclarations []*MetricDeclaration
-	for _, declaration := range config.MetricDeclarations {
-		err := declaration.init(config.logger)
-		if err != nil {
-			config.logger.Warn("Dropped metric declaration.", zap.Error(err))
-		} else {
-			validDeclarations = append(validDeclarations, declaration)
+	if !isValidDimensionRollupOption(config.DimensionRollupOption) {
+		return fmt.Errorf("invalid dimension_rollup_option %q", config.DimensionRollupOption)
+	}
+	if config.MetricDeclarationsFile != "" {
+		if len(config.MetricDeclarations) > 0 {
+			return errors.New("metric_declarations and metric_declarations_file cannot be used together")
+		}
+		if config.MetricDeclarationsReloadInterval <= 0 {
+			return errors.New("metric_declarations_reload_interval must be greater than 0")
 		}
 	}
-	config.MetricDeclarations = validDeclarations
+
+	config.MetricDeclarations = initMetricDeclarations(config.MetricDeclarations, config.logger)
 
 	var validDescriptors []MetricDescriptor
 	for _, descriptor := range config.MetricDescriptors {
@@ -116,6 +131,15 @@ func (config *Config) Validate() error {
 	return nil
 }
 
+// isValidDimensionRollupOption returns true if the option is empty or one of the supported dimension rollup options.
+func isValidDimensionRollupOption(option string) bool {
+	switch option {
+	case "", zeroAndSingleDimensionRollup, singleDimensionRollupOnly, noDimensionRollup:
+		return true
+	}
+	return false
+}
+
 func newEMFSupportedUnits() map[string]interface{} {
 	unitIndexer := map[string]interface{}{}
 	for _, unit := range []string{"Seconds", "Microseconds", "Milliseconds", "Bytes", "Kilobytes", "Megabytes",
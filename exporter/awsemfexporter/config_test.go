@@ -17,6 +17,7 @@ package awsemfexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -60,10 +61,11 @@ func TestLoadConfig(t *testing.T) {
 				Region:                "us-west-2",
 				RoleARN:               "arn:aws:iam::123456789:role/monitoring-EKS-NodeInstanceRole",
 			},
-			LogGroupName:          "",
-			LogStreamName:         "",
-			DimensionRollupOption: "ZeroAndSingleDimensionRollup",
-			OutputDestination:     "cloudwatch",
+			LogGroupName:                     "",
+			LogStreamName:                    "",
+			DimensionRollupOption:            "ZeroAndSingleDimensionRollup",
+			MetricDeclarationsReloadInterval: time.Minute,
+			OutputDestination:                "cloudwatch",
 		}, r1)
 
 	r2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "resource_attr_to_label")].(*Config)
@@ -81,11 +83,12 @@ func TestLoadConfig(t *testing.T) {
 				Region:                "",
 				RoleARN:               "",
 			},
-			LogGroupName:                "",
-			LogStreamName:               "",
-			DimensionRollupOption:       "ZeroAndSingleDimensionRollup",
-			OutputDestination:           "cloudwatch",
-			ResourceToTelemetrySettings: resourcetotelemetry.Settings{Enabled: true},
+			LogGroupName:                     "",
+			LogStreamName:                    "",
+			DimensionRollupOption:            "ZeroAndSingleDimensionRollup",
+			MetricDeclarationsReloadInterval: time.Minute,
+			OutputDestination:                "cloudwatch",
+			ResourceToTelemetrySettings:      resourcetotelemetry.Settings{Enabled: true},
 		})
 }
 
@@ -115,3 +118,43 @@ func TestConfigValidate(t *testing.T) {
 		{unit: "Megabytes", metricName: "memory_usage"},
 	}, cfg.MetricDescriptors)
 }
+
+func TestConfigValidateErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(cfg *Config)
+		errorText string
+	}{
+		{
+			name: "invalid dimension rollup option",
+			modify: func(cfg *Config) {
+				cfg.DimensionRollupOption = "AllDimensionRollup"
+			},
+			errorText: `invalid dimension_rollup_option "AllDimensionRollup"`,
+		},
+		{
+			name: "metric declarations and metric declarations file",
+			modify: func(cfg *Config) {
+				cfg.MetricDeclarationsFile = "declarations.yaml"
+				cfg.MetricDeclarations = []*MetricDeclaration{{MetricNameSelectors: []string{"a"}}}
+			},
+			errorText: "metric_declarations and metric_declarations_file cannot be used together",
+		},
+		{
+			name: "invalid reload interval",
+			modify: func(cfg *Config) {
+				cfg.MetricDeclarationsFile = "declarations.yaml"
+				cfg.MetricDeclarationsReloadInterval = 0
+			},
+			errorText: "metric_declarations_reload_interval must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.logger = zap.NewNop()
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.errorText)
+		})
+	}
+}
@@ -52,6 +52,7 @@ type emfExporter struct {
 	logger                 *zap.Logger
 
 	metricTranslator metricTranslator
+	declarations     *metricDeclarationsLoader
 
 	pusherMapLock sync.Mutex
 	retryCnt      int
@@ -85,6 +86,7 @@ func newEmfPusher(
 		svcStructuredLog: svcStructuredLog,
 		config:           config,
 		metricTranslator: newMetricTranslator(*expConfig),
+		declarations:     newMetricDeclarationsLoader(expConfig, logger),
 		retryCnt:         *awsConfig.MaxRetries,
 		logger:           logger,
 		collectorID:      collectorIdentifier.String(),
@@ -109,6 +111,7 @@ func newEmfExporter(
 		set,
 		config,
 		exp.(*emfExporter).pushMetricsData,
+		exporterhelper.WithStart(exp.(*emfExporter).Start),
 		exporterhelper.WithShutdown(exp.(*emfExporter).Shutdown),
 	)
 	if err != nil {
@@ -133,7 +136,10 @@ func (emf *emfExporter) pushMetricsData(_ context.Context, md pmetric.Metrics) e
 	emf.logger.Info("Start processing resource metrics", zap.Any("labels", labels))
 
 	groupedMetrics := make(map[interface{}]*groupedMetric)
-	expConfig := emf.config.(*Config)
+	// Use the latest metric declarations, which may have been reloaded from the metric declarations file.
+	cfg := *emf.config.(*Config)
+	cfg.MetricDeclarations = emf.declarations.current()
+	expConfig := &cfg
 	defaultLogStream := fmt.Sprintf("otel-stream-%s", emf.collectorID)
 	outputDestination := expConfig.OutputDestination
 
@@ -224,6 +230,8 @@ func (emf *emfExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics)
 
 // Shutdown stops the exporter and is invoked during shutdown.
 func (emf *emfExporter) Shutdown(ctx context.Context) error {
+	emf.declarations.shutdown()
+
 	for _, emfPusher := range emf.listPushers() {
 		returnError := emfPusher.ForceFlush()
 		if returnError != nil {
@@ -241,9 +249,9 @@ func (emf *emfExporter) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
-// Start
+// Start loads the metric declarations file, if any, and starts watching it for changes.
 func (emf *emfExporter) Start(ctx context.Context, host component.Host) error {
-	return nil
+	return emf.declarations.start()
 }
 
 func wrapErrorIfBadRequest(err error) error {
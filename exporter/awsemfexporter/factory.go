@@ -16,6 +16,7 @@ package awsemfexporter // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -28,6 +29,8 @@ const (
 	typeStr = "awsemf"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
+	// The default interval at which the metric declarations file is checked for changes.
+	defaultMetricDeclarationsReloadInterval = time.Minute
 )
 
 // NewFactory creates a factory for AWS EMF exporter.
@@ -41,14 +44,15 @@ func NewFactory() component.ExporterFactory {
 // CreateDefaultConfig creates the default configuration for exporter.
 func createDefaultConfig() config.Exporter {
 	return &Config{
-		ExporterSettings:      config.NewExporterSettings(config.NewComponentID(typeStr)),
-		AWSSessionSettings:    awsutil.CreateDefaultSessionConfig(),
-		LogGroupName:          "",
-		LogStreamName:         "",
-		Namespace:             "",
-		DimensionRollupOption: "ZeroAndSingleDimensionRollup",
-		OutputDestination:     "cloudwatch",
-		logger:                nil,
+		ExporterSettings:                 config.NewExporterSettings(config.NewComponentID(typeStr)),
+		AWSSessionSettings:               awsutil.CreateDefaultSessionConfig(),
+		LogGroupName:                     "",
+		LogStreamName:                    "",
+		Namespace:                        "",
+		DimensionRollupOption:            "ZeroAndSingleDimensionRollup",
+		MetricDeclarationsReloadInterval: defaultMetricDeclarationsReloadInterval,
+		OutputDestination:                "cloudwatch",
+		logger:                           nil,
 	}
 }
 
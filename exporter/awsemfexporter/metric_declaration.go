@@ -17,6 +17,7 @@ package awsemfexporter // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"regexp"
 	"sort"
 	"strings"
@@ -37,6 +38,9 @@ type MetricDeclaration struct {
 	// (Optional) List of label matchers that define matching rules to filter against
 	// the labels of incoming metrics.
 	LabelMatchers []*LabelMatcher `mapstructure:"label_matchers"`
+	// (Optional) DimensionRollupOption overrides the dimension rollup option of the exporter
+	// for the metrics matching this metric declaration.
+	DimensionRollupOption string `mapstructure:"dimension_rollup_option"`
 
 	// metricRegexList is a list of compiled regexes for metric name selectors.
 	metricRegexList []*regexp.Regexp
@@ -83,6 +87,9 @@ func (m *MetricDeclaration) init(logger *zap.Logger) (err error) {
 	if len(m.MetricNameSelectors) == 0 {
 		return errors.New("invalid metric declaration: no metric name selectors defined")
 	}
+	if !isValidDimensionRollupOption(m.DimensionRollupOption) {
+		return fmt.Errorf("invalid metric declaration: invalid dimension_rollup_option %q", m.DimensionRollupOption)
+	}
 
 	// Filter out duplicate dimension sets and those with more than 10 elements
 	validDims := make([][]string, 0, len(m.Dimensions))
@@ -128,6 +135,29 @@ func (m *MetricDeclaration) init(logger *zap.Logger) (err error) {
 	return
 }
 
+// initMetricDeclarations initializes the metric declarations, dropping the invalid ones.
+func initMetricDeclarations(declarations []*MetricDeclaration, logger *zap.Logger) []*MetricDeclaration {
+	var validDeclarations []*MetricDeclaration
+	for _, declaration := range declarations {
+		err := declaration.init(logger)
+		if err != nil {
+			logger.Warn("Dropped metric declaration.", zap.Error(err))
+		} else {
+			validDeclarations = append(validDeclarations, declaration)
+		}
+	}
+	return validDeclarations
+}
+
+// dimensionRollupOption returns the dimension rollup option of the metric declaration, falling
+// back to the given exporter option if not set.
+func (m *MetricDeclaration) dimensionRollupOption(defaultOption string) string {
+	if m.DimensionRollupOption != "" {
+		return m.DimensionRollupOption
+	}
+	return defaultOption
+}
+
 // MatchesName returns true if the given OTLP Metric's name matches any of the Metric
 // Declaration's metric name selectors.
 func (m *MetricDeclaration) MatchesName(metricName string) bool {
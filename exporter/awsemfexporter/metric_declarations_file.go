@@ -0,0 +1,154 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// metricDeclarationsLoader holds the metric declarations of the exporter and, when a
+// metric declarations file is configured, reloads them whenever the file changes.
+type metricDeclarationsLoader struct {
+	path     string
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu           sync.RWMutex
+	declarations []*MetricDeclaration
+	modTime      time.Time
+	size         int64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newMetricDeclarationsLoader(config *Config, logger *zap.Logger) *metricDeclarationsLoader {
+	return &metricDeclarationsLoader{
+		path:         config.MetricDeclarationsFile,
+		interval:     config.MetricDeclarationsReloadInterval,
+		logger:       logger,
+		declarations: config.MetricDeclarations,
+		done:         make(chan struct{}),
+	}
+}
+
+// start loads the metric declarations file and starts watching it for changes.
+// It does nothing if no metric declarations file is configured.
+func (l *metricDeclarationsLoader) start() error {
+	if l.path == "" {
+		return nil
+	}
+	if _, err := l.reload(); err != nil {
+		return err
+	}
+
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		ticker := time.NewTicker(l.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				reloaded, err := l.reload()
+				if err != nil {
+					l.logger.Warn("Failed to reload metric declarations, keeping the previous ones.", zap.String("file", l.path), zap.Error(err))
+				} else if reloaded {
+					l.logger.Info("Reloaded metric declarations.", zap.String("file", l.path))
+				}
+			case <-l.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// shutdown stops watching the metric declarations file.
+func (l *metricDeclarationsLoader) shutdown() {
+	if l.path == "" {
+		return
+	}
+	select {
+	case <-l.done:
+	default:
+		close(l.done)
+	}
+	l.wg.Wait()
+}
+
+// current returns the metric declarations in use.
+func (l *metricDeclarationsLoader) current() []*MetricDeclaration {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.declarations
+}
+
+// reload reads the metric declarations file if it changed since it was last read.
+// It returns true if the metric declarations were replaced.
+func (l *metricDeclarationsLoader) reload() (bool, error) {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return false, err
+	}
+
+	l.mu.RLock()
+	unchanged := info.ModTime().Equal(l.modTime) && info.Size() == l.size
+	l.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	declarations, err := readMetricDeclarationsFile(l.path, l.logger)
+	if err != nil {
+		return false, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.declarations = declarations
+	l.modTime = info.ModTime()
+	l.size = info.Size()
+	return true, nil
+}
+
+// readMetricDeclarationsFile reads and initializes the metric declarations defined under
+// the "metric_declarations" key of the given YAML file. Invalid metric declarations are dropped.
+func readMetricDeclarationsFile(path string, logger *zap.Logger) ([]*MetricDeclaration, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err = yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse metric declarations file: %w", err)
+	}
+
+	var parsed struct {
+		MetricDeclarations []*MetricDeclaration `mapstructure:"metric_declarations"`
+	}
+	if err = confmap.NewFromStringMap(raw).Unmarshal(&parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode metric declarations file: %w", err)
+	}
+	return initMetricDeclarations(parsed.MetricDeclarations, logger), nil
+}
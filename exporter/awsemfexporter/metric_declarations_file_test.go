@@ -0,0 +1,97 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsemfexporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func writeMetricDeclarationsFile(t *testing.T, path string, content string, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestMetricDeclarationsLoaderReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "declarations.yaml")
+	now := time.Now()
+	writeMetricDeclarationsFile(t, path, `
+metric_declarations:
+  - dimensions: [[a, b]]
+    metric_name_selectors: ["^metric1$"]
+    dimension_rollup_option: NoDimensionRollup
+  - metric_name_selectors: []
+`, now)
+
+	loader := newMetricDeclarationsLoader(&Config{
+		MetricDeclarationsFile:           path,
+		MetricDeclarationsReloadInterval: time.Minute,
+	}, zap.NewNop())
+	require.NoError(t, loader.start())
+	defer loader.shutdown()
+
+	// The declaration without metric name selectors is dropped.
+	declarations := loader.current()
+	require.Len(t, declarations, 1)
+	assert.Equal(t, [][]string{{"a", "b"}}, declarations[0].Dimensions)
+	assert.Equal(t, noDimensionRollup, declarations[0].DimensionRollupOption)
+	assert.True(t, declarations[0].MatchesName("metric1"))
+
+	reloaded, err := loader.reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	writeMetricDeclarationsFile(t, path, `
+metric_declarations:
+  - dimensions: [[c]]
+    metric_name_selectors: ["^metric2$"]
+`, now.Add(time.Second))
+	reloaded, err = loader.reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	declarations = loader.current()
+	require.Len(t, declarations, 1)
+	assert.Equal(t, [][]string{{"c"}}, declarations[0].Dimensions)
+	assert.True(t, declarations[0].MatchesName("metric2"))
+
+	// An invalid file keeps the previous metric declarations.
+	writeMetricDeclarationsFile(t, path, "metric_declarations: [", now.Add(2*time.Second))
+	_, err = loader.reload()
+	assert.Error(t, err)
+	assert.Equal(t, declarations, loader.current())
+}
+
+func TestMetricDeclarationsLoaderStartError(t *testing.T) {
+	loader := newMetricDeclarationsLoader(&Config{
+		MetricDeclarationsFile:           filepath.Join(t.TempDir(), "missing.yaml"),
+		MetricDeclarationsReloadInterval: time.Minute,
+	}, zap.NewNop())
+	assert.Error(t, loader.start())
+	loader.shutdown()
+}
+
+func TestMetricDeclarationsLoaderWithoutFile(t *testing.T) {
+	declarations := []*MetricDeclaration{{MetricNameSelectors: []string{"a"}}}
+	loader := newMetricDeclarationsLoader(&Config{MetricDeclarations: declarations}, zap.NewNop())
+	require.NoError(t, loader.start())
+	assert.Equal(t, declarations, loader.current())
+	loader.shutdown()
+}
@@ -35,6 +35,7 @@ const (
 	// DimensionRollupOptions
 	zeroAndSingleDimensionRollup = "ZeroAndSingleDimensionRollup"
 	singleDimensionRollupOnly    = "SingleDimensionRollupOnly"
+	noDimensionRollup            = "NoDimensionRollup"
 
 	prometheusReceiver        = "prometheus"
 	attributeReceiver         = "receiver"
@@ -307,19 +308,28 @@ func groupedMetricToCWMeasurementsWithFilters(groupedMetric *groupedMetric, conf
 		return
 	}
 
-	// Apply single/zero dimension rollup to labels
-	rollupDimensionArray := dimensionRollup(config.DimensionRollupOption, labels)
+	// Apply single/zero dimension rollup to labels, once per rollup option of the matched metric declarations
+	rollupDimensionArrays := make(map[string][][]string)
 
 	// Translate each group into a CW Measurement
 	cWMeasurements = make([]cWMeasurement, 0, len(metricDeclGroups))
 	for _, group := range metricDeclGroups {
-		var dimensions [][]string
+		var dimensions, rollupDimensions [][]string
 		// Extract dimensions from matched metric declarations
 		for _, metricDeclIdx := range group.metricDeclIdxList {
-			dims := metricDeclarations[metricDeclIdx].ExtractDimensions(labels)
+			metricDeclaration := metricDeclarations[metricDeclIdx]
+			dims := metricDeclaration.ExtractDimensions(labels)
 			dimensions = append(dimensions, dims...)
+
+			rollupOption := metricDeclaration.dimensionRollupOption(config.DimensionRollupOption)
+			rollupDimensionArray, ok := rollupDimensionArrays[rollupOption]
+			if !ok {
+				rollupDimensionArray = dimensionRollup(rollupOption, labels)
+				rollupDimensionArrays[rollupOption] = rollupDimensionArray
+			}
+			rollupDimensions = append(rollupDimensions, rollupDimensionArray...)
 		}
-		dimensions = append(dimensions, rollupDimensionArray...)
+		dimensions = append(dimensions, rollupDimensions...)
 
 		// De-duplicate dimensions
 		dimensions = dedupDimensions(dimensions)
@@ -2038,6 +2038,54 @@ func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 			"",
 			nil,
 		},
+		{
+			"metric declaration w/ rollup override",
+			map[string]string{
+				"a":                   "foo",
+				"b":                   "bar",
+				"c":                   "car",
+				(oTellibDimensionKey): instrLibName,
+			},
+			[]*MetricDeclaration{
+				{
+					Dimensions:            [][]string{{"a", "b"}},
+					MetricNameSelectors:   []string{metricName},
+					DimensionRollupOption: noDimensionRollup,
+				},
+			},
+			zeroAndSingleDimensionRollup,
+			[][]string{
+				{"a", "b"},
+			},
+		},
+		{
+			"multiple metric declarations w/ different rollups",
+			map[string]string{
+				"a":                   "foo",
+				"b":                   "bar",
+				"c":                   "car",
+				(oTellibDimensionKey): instrLibName,
+			},
+			[]*MetricDeclaration{
+				{
+					Dimensions:          [][]string{{"a", "b"}},
+					MetricNameSelectors: []string{metricName},
+				},
+				{
+					Dimensions:            [][]string{{"a", "c"}},
+					MetricNameSelectors:   []string{metricName},
+					DimensionRollupOption: singleDimensionRollupOnly,
+				},
+			},
+			"",
+			[][]string{
+				{"a", "b"},
+				{"a", "c"},
+				{oTellibDimensionKey, "a"},
+				{oTellibDimensionKey, "b"},
+				{oTellibDimensionKey, "c"},
+			},
+		},
 		{
 			"no labels",
 			map[string]string{},
@@ -15,6 +15,7 @@
 package awskinesisexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter"
 
 import (
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/collector/config"
@@ -34,6 +35,14 @@ type Encoding struct {
 	Compression string `mapstructure:"compression"`
 }
 
+// Aggregation contains the options to pack several records into a single
+// Kinesis record, in the aggregation format of the Kinesis Producer Library.
+type Aggregation struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxRecords is the maximum number of records packed into a Kinesis record.
+	MaxRecords int `mapstructure:"max_records"`
+}
+
 // Config contains the main configuration options for the awskinesis exporter
 type Config struct {
 	config.ExporterSettings        `mapstructure:",squash"`
@@ -42,9 +51,14 @@ type Config struct {
 	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
 
 	Encoding           `mapstructure:"encoding"`
-	AWS                AWSConfig `mapstructure:"aws"`
-	MaxRecordsPerBatch int       `mapstructure:"max_records_per_batch"`
-	MaxRecordSize      int       `mapstructure:"max_record_size"`
+	AWS                AWSConfig   `mapstructure:"aws"`
+	MaxRecordsPerBatch int         `mapstructure:"max_records_per_batch"`
+	MaxRecordSize      int         `mapstructure:"max_record_size"`
+	Aggregation        Aggregation `mapstructure:"aggregation"`
+	// PartitionKeyAttribute is the resource attribute whose value is the
+	// partition key of the data of the resource. The keys are randomized
+	// when empty.
+	PartitionKeyAttribute string `mapstructure:"partition_key_attribute"`
 }
 
 // Validate checks if the exporter configuration is valid
@@ -53,6 +67,10 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("queue settings has invalid configuration: %w", err)
 	}
 
+	if cfg.Aggregation.Enabled && cfg.Aggregation.MaxRecords <= 0 {
+		return errors.New("aggregation max_records must be positive when aggregation is enabled")
+	}
+
 	return nil
 }
 
@@ -57,6 +57,9 @@ func TestDefaultConfig(t *testing.T) {
 			},
 			MaxRecordsPerBatch: batch.MaxBatchedRecords,
 			MaxRecordSize:      batch.MaxRecordSize,
+			Aggregation: Aggregation{
+				MaxRecords: 100,
+			},
 		},
 	)
 }
@@ -97,10 +100,24 @@ func TestConfig(t *testing.T) {
 			},
 			MaxRecordSize:      1000,
 			MaxRecordsPerBatch: 10,
+			Aggregation: Aggregation{
+				Enabled:    true,
+				MaxRecords: 50,
+			},
+			PartitionKeyAttribute: "service.name",
 		},
 	)
 }
 
+func TestConfigValidateAggregation(t *testing.T) {
+	cfg := (NewFactory()).CreateDefaultConfig().(*Config)
+	cfg.Aggregation = Aggregation{Enabled: true}
+	assert.EqualError(t, cfg.Validate(), "aggregation max_records must be positive when aggregation is enabled")
+
+	cfg.Aggregation.MaxRecords = 10
+	assert.NoError(t, cfg.Validate())
+}
+
 func TestConfigCheck(t *testing.T) {
 	cfg := (NewFactory()).CreateDefaultConfig()
 	assert.NoError(t, configtest.CheckConfigStruct(cfg))
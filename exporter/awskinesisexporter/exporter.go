@@ -32,6 +32,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/batch"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/compress"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/key"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/producer"
 )
 
@@ -89,12 +90,26 @@ func createExporter(ctx context.Context, c config.Exporter, log *zap.Logger) (*E
 		return nil, err
 	}
 
-	encoder, err := batch.NewEncoder(
-		conf.Encoding.Name,
+	batchOptions := []batch.Option{
 		batch.WithMaxRecordSize(conf.MaxRecordSize),
 		batch.WithMaxRecordsPerBatch(conf.MaxRecordsPerBatch),
 		batch.WithCompression(compressor),
-	)
+	}
+	if conf.Aggregation.Enabled {
+		// the records are tagged with their encoding, as it can't be told
+		// from the aggregated records
+		batchOptions = append(batchOptions, batch.WithAggregation(conf.Aggregation.MaxRecords, map[string]string{
+			"content-type":     batch.ContentType(conf.Encoding.Name),
+			"content-encoding": conf.Encoding.Compression,
+		}))
+	}
+
+	var partitioner key.Partition
+	if conf.PartitionKeyAttribute != "" {
+		partitioner = key.ResourceAttribute(conf.PartitionKeyAttribute)
+	}
+
+	encoder, err := batch.NewEncoder(conf.Encoding.Name, partitioner, batchOptions...)
 
 	if err != nil {
 		return nil, err
@@ -32,6 +32,8 @@ const (
 
 	defaultEncoding    = "otlp"
 	defaultCompression = "none"
+
+	defaultAggregationMaxRecords = 100
 )
 
 // NewFactory creates a factory for Kinesis exporter.
@@ -60,6 +62,9 @@ func createDefaultConfig() config.Exporter {
 		},
 		MaxRecordsPerBatch: batch.MaxBatchedRecords,
 		MaxRecordSize:      batch.MaxRecordSize,
+		Aggregation: Aggregation{
+			MaxRecords: defaultAggregationMaxRecords,
+		},
 	}
 }
 
@@ -0,0 +1,117 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/batch"
+
+import (
+	"crypto/md5" // #nosec G501 -- MD5 is the checksum of the KPL aggregation format
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// aggregationMagic prefixes the records in the aggregation format of the
+// Kinesis Producer Library (KPL), so that consumers can tell them apart.
+var aggregationMagic = []byte{0xF3, 0x89, 0x9A, 0xC2}
+
+// Field numbers of the AggregatedRecord, Record and Tag messages of the KPL
+// aggregation format.
+const (
+	aggregatedPartitionKeyTable protowire.Number = 1
+	aggregatedRecords           protowire.Number = 3
+
+	recordPartitionKeyIndex protowire.Number = 1
+	recordData              protowire.Number = 3
+	recordTags              protowire.Number = 4
+
+	tagKey   protowire.Number = 1
+	tagValue protowire.Number = 2
+)
+
+// aggregate packs the records sharing a partition key into a single Kinesis
+// record in the KPL aggregation format.
+type aggregate struct {
+	key string
+	// records are the encoded Record messages
+	records [][]byte
+	size    int
+}
+
+func newAggregate(key string) *aggregate {
+	return &aggregate{key: key}
+}
+
+// encodeRecord encodes the data as a Record message, with the tags sorted by key.
+func encodeRecord(data []byte, tags map[string]string) []byte {
+	var b []byte
+	// the index of the partition key, which is the only one of the aggregate
+	b = protowire.AppendTag(b, recordPartitionKeyIndex, protowire.VarintType)
+	b = protowire.AppendVarint(b, 0)
+	b = protowire.AppendTag(b, recordData, protowire.BytesType)
+	b = protowire.AppendBytes(b, data)
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var tag []byte
+		tag = protowire.AppendTag(tag, tagKey, protowire.BytesType)
+		tag = protowire.AppendString(tag, k)
+		tag = protowire.AppendTag(tag, tagValue, protowire.BytesType)
+		tag = protowire.AppendString(tag, tags[k])
+
+		b = protowire.AppendTag(b, recordTags, protowire.BytesType)
+		b = protowire.AppendBytes(b, tag)
+	}
+	return b
+}
+
+// sizeWith returns the size of the aggregated record with the encoded record added.
+func (a *aggregate) sizeWith(record []byte) int {
+	size := a.size + protowire.SizeTag(aggregatedRecords) + protowire.SizeBytes(len(record))
+	if len(a.records) == 0 {
+		size += len(aggregationMagic) + md5.Size +
+			protowire.SizeTag(aggregatedPartitionKeyTable) + protowire.SizeBytes(len(a.key))
+	}
+	return size
+}
+
+func (a *aggregate) add(record []byte) {
+	a.size = a.sizeWith(record)
+	a.records = append(a.records, record)
+}
+
+func (a *aggregate) len() int {
+	return len(a.records)
+}
+
+// bytes returns the aggregated record: the magic number, followed by the
+// AggregatedRecord message and its MD5 checksum.
+func (a *aggregate) bytes() []byte {
+	var msg []byte
+	msg = protowire.AppendTag(msg, aggregatedPartitionKeyTable, protowire.BytesType)
+	msg = protowire.AppendString(msg, a.key)
+	for _, record := range a.records {
+		msg = protowire.AppendTag(msg, aggregatedRecords, protowire.BytesType)
+		msg = protowire.AppendBytes(msg, record)
+	}
+
+	checksum := md5.Sum(msg) // #nosec G401 -- MD5 is the checksum of the KPL aggregation format
+	out := make([]byte, 0, len(aggregationMagic)+len(msg)+len(checksum))
+	out = append(out, aggregationMagic...)
+	out = append(out, msg...)
+	return append(out, checksum[:]...)
+}
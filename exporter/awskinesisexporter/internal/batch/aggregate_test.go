@@ -0,0 +1,203 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch_test
+
+import (
+	"bytes"
+	"crypto/md5" // #nosec G501 -- MD5 is the checksum of the KPL aggregation format
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/batch"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/key"
+)
+
+type userRecord struct {
+	partitionKey string
+	data         []byte
+	tags         map[string]string
+}
+
+// deaggregate decodes a record in the KPL aggregation format.
+func deaggregate(t *testing.T, record []byte) []userRecord {
+	t.Helper()
+
+	magic := []byte{0xF3, 0x89, 0x9A, 0xC2}
+	require.True(t, bytes.HasPrefix(record, magic), "Must start with the KPL magic number")
+	msg := record[len(magic) : len(record)-md5.Size]
+	checksum := md5.Sum(msg) // #nosec G401
+	require.Equal(t, checksum[:], record[len(record)-md5.Size:], "Must end with the MD5 checksum of the message")
+
+	var (
+		keys    []string
+		records []userRecord
+	)
+	for len(msg) > 0 {
+		num, _, n := protowire.ConsumeTag(msg)
+		require.Greater(t, n, 0)
+		msg = msg[n:]
+		value, n := protowire.ConsumeBytes(msg)
+		require.Greater(t, n, 0)
+		msg = msg[n:]
+
+		switch num {
+		case 1:
+			keys = append(keys, string(value))
+		case 3:
+			records = append(records, decodeRecord(t, value, keys))
+		}
+	}
+	return records
+}
+
+func decodeRecord(t *testing.T, msg []byte, keys []string) userRecord {
+	t.Helper()
+
+	r := userRecord{tags: map[string]string{}}
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		require.Greater(t, n, 0)
+		msg = msg[n:]
+		if typ == protowire.VarintType {
+			index, n := protowire.ConsumeVarint(msg)
+			require.Greater(t, n, 0)
+			msg = msg[n:]
+			r.partitionKey = keys[index]
+			continue
+		}
+		value, n := protowire.ConsumeBytes(msg)
+		require.Greater(t, n, 0)
+		msg = msg[n:]
+
+		switch num {
+		case 3:
+			r.data = value
+		case 4:
+			var k, v string
+			for len(value) > 0 {
+				num, _, n := protowire.ConsumeTag(value)
+				value = value[n:]
+				s, n := protowire.ConsumeString(value)
+				value = value[n:]
+				if num == 1 {
+					k = s
+				} else {
+					v = s
+				}
+			}
+			r.tags[k] = v
+		}
+	}
+	return r
+}
+
+func TestAggregation(t *testing.T) {
+	t.Parallel()
+
+	tags := map[string]string{"content-type": "application/x-protobuf", "content-encoding": "none"}
+	b := batch.New(batch.WithAggregation(3, tags))
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, b.AddRecord([]byte{byte(i)}, "tenant-a"), "Must not error when aggregating records")
+	}
+	assert.NoError(t, b.AddRecord([]byte("b"), "tenant-b"), "Must not error when aggregating records")
+
+	chunks := b.Chunk()
+	require.Len(t, chunks, 1)
+	records := chunks[0]
+	require.Len(t, records, 3, "Must aggregate up to 3 records sharing a partition key")
+
+	assert.Equal(t, "tenant-a", *records[0].PartitionKey)
+	first := deaggregate(t, records[0].Data)
+	require.Len(t, first, 3)
+	for i, r := range first {
+		assert.Equal(t, "tenant-a", r.partitionKey)
+		assert.Equal(t, []byte{byte(i)}, r.data)
+		assert.Equal(t, tags, r.tags)
+	}
+
+	// the pending aggregates are flushed in order of appearance of their keys
+	assert.Equal(t, "tenant-a", *records[1].PartitionKey)
+	assert.Len(t, deaggregate(t, records[1].Data), 1)
+	assert.Equal(t, "tenant-b", *records[2].PartitionKey)
+	assert.Equal(t, []byte("b"), deaggregate(t, records[2].Data)[0].data)
+
+	assert.Len(t, b.Chunk()[0], 3, "Must not modify the stored data within the batch")
+}
+
+func TestAggregationRecordSize(t *testing.T) {
+	t.Parallel()
+
+	b := batch.New(
+		batch.WithAggregation(100, nil),
+		batch.WithMaxRecordSize(100),
+	)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, b.AddRecord(bytes.Repeat([]byte{'a'}, 20), "fixed-string"), "Must not error when aggregating records")
+	}
+	records := b.Chunk()[0]
+	assert.Greater(t, len(records), 1, "Must flush the aggregates reaching the record size")
+	total := 0
+	for _, record := range records {
+		assert.LessOrEqual(t, len(record.Data), 100, "Must not exceed the record size")
+		total += len(deaggregate(t, record.Data))
+	}
+	assert.Equal(t, 10, total)
+
+	assert.ErrorIs(t, b.AddRecord(bytes.Repeat([]byte{'a'}, 90), "fixed-string"), batch.ErrRecordLength, "Must error when the aggregated record is too large")
+}
+
+func TestEncoderPartitionKeyAttribute(t *testing.T) {
+	t.Parallel()
+
+	logs := plog.NewLogs()
+	for _, tenant := range []string{"acme", "globex", "acme"} {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("tenant", tenant)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(tenant)
+	}
+
+	encoder, err := batch.NewEncoder("otlp_proto", key.ResourceAttribute("tenant"), batch.WithAggregation(10, nil))
+	require.NoError(t, err)
+	bt, err := encoder.Logs(logs)
+	require.NoError(t, err)
+
+	records := bt.Chunk()[0]
+	require.Len(t, records, 2, "Must aggregate the resources of each tenant")
+	assert.Equal(t, "acme", *records[0].PartitionKey)
+	assert.Len(t, deaggregate(t, records[0].Data), 2)
+	assert.Equal(t, "globex", *records[1].PartitionKey)
+	assert.Len(t, deaggregate(t, records[1].Data), 1)
+}
+
+func TestEncoderRandomPartitionKeyAggregation(t *testing.T) {
+	t.Parallel()
+
+	encoder, err := batch.NewEncoder("otlp_proto", nil, batch.WithAggregation(10, nil))
+	require.NoError(t, err)
+	bt, err := encoder.Logs(NewTestLogs(3))
+	require.NoError(t, err)
+
+	records := bt.Chunk()[0]
+	require.Len(t, records, 1, "Must aggregate the resources of a batch under a single key")
+	assert.Len(t, deaggregate(t, records[0].Data), 3)
+
+	other, err := encoder.Logs(NewTestLogs(1))
+	require.NoError(t, err)
+	assert.NotEqual(t, *records[0].PartitionKey, *other.Chunk()[0][0].PartitionKey, "Must have a random key per batch")
+}
@@ -43,6 +43,19 @@ type Batch struct {
 	compression compress.Compressor
 
 	records []types.PutRecordsRequestEntry
+
+	// aggregation is set when the records sharing a partition key are
+	// packed into aggregated records
+	aggregation *aggregation
+	// aggregates are the aggregated records being filled, by partition key
+	aggregates map[string]*aggregate
+	// keys are the partition keys of the aggregates, in order of appearance
+	keys []string
+}
+
+type aggregation struct {
+	maxRecords int
+	tags       map[string]string
 }
 
 type Option func(bt *Batch)
@@ -73,6 +86,21 @@ func WithCompression(compressor compress.Compressor) Option {
 	}
 }
 
+// WithAggregation packs up to maxRecords records sharing a partition key into
+// a single Kinesis record, in the aggregation format of the Kinesis Producer
+// Library (KPL), each record having the given tags.
+func WithAggregation(maxRecords int, tags map[string]string) Option {
+	return func(bt *Batch) {
+		if maxRecords > 0 {
+			bt.aggregation = &aggregation{
+				maxRecords: maxRecords,
+				tags:       tags,
+			}
+			bt.aggregates = make(map[string]*aggregate)
+		}
+	}
+}
+
 func New(opts ...Option) *Batch {
 	bt := &Batch{
 		maxBatchSize:  MaxBatchedRecords,
@@ -102,6 +130,10 @@ func (b *Batch) AddRecord(raw []byte, key string) error {
 		return ErrRecordLength
 	}
 
+	if b.aggregation != nil {
+		return b.aggregateRecord(record, key)
+	}
+
 	b.records = append(b.records, types.PutRecordsRequestEntry{
 		Data:         record,
 		PartitionKey: aws.String(key),
@@ -109,9 +141,46 @@ func (b *Batch) AddRecord(raw []byte, key string) error {
 	return nil
 }
 
+// aggregateRecord adds the record to the aggregate of its partition key, which
+// is first flushed if the record doesn't fit in it.
+func (b *Batch) aggregateRecord(record []byte, key string) error {
+	encoded := encodeRecord(record, b.aggregation.tags)
+
+	agg, ok := b.aggregates[key]
+	if !ok {
+		agg = newAggregate(key)
+		b.aggregates[key] = agg
+		b.keys = append(b.keys, key)
+	}
+
+	if agg.len() > 0 && (agg.len() >= b.aggregation.maxRecords || agg.sizeWith(encoded) > b.maxRecordSize) {
+		b.flushAggregate(agg)
+	}
+	if agg.sizeWith(encoded) > b.maxRecordSize {
+		return ErrRecordLength
+	}
+	agg.add(encoded)
+	return nil
+}
+
+func (b *Batch) flushAggregate(agg *aggregate) {
+	b.records = append(b.records, types.PutRecordsRequestEntry{
+		Data:         agg.bytes(),
+		PartitionKey: aws.String(agg.key),
+	})
+	agg.records = nil
+	agg.size = 0
+}
+
 // Chunk breaks up the iternal queue into blocks that can be used
 // to be written to he kinesis.PutRecords endpoint
 func (b *Batch) Chunk() (chunks [][]types.PutRecordsRequestEntry) {
+	for _, key := range b.keys {
+		if agg := b.aggregates[key]; agg.len() > 0 {
+			b.flushAggregate(agg)
+		}
+	}
+
 	// Using local copies to avoid mutating internal data
 	var (
 		slice = b.records
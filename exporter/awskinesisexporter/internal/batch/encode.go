@@ -42,10 +42,25 @@ type Encoder interface {
 	Logs(ld plog.Logs) (*Batch, error)
 }
 
-func NewEncoder(named string, batchOptions ...Option) (Encoder, error) {
+// NewEncoder returns the encoder of the named format, using the partitioner to
+// choose the partition key of the data of each resource. The partition keys
+// are randomized when the partitioner is nil, with a single key per batch when
+// the records are aggregated.
+func NewEncoder(named string, partitioner key.Partition, batchOptions ...Option) (Encoder, error) {
+	batchKey := false
+	if partitioner == nil {
+		partitioner = key.Randomized
+
+		options := &Batch{}
+		for _, op := range batchOptions {
+			op(options)
+		}
+		batchKey = options.aggregation != nil
+	}
 	bm := &batchMarshaller{
 		batchOptions:      batchOptions,
-		partitioner:       key.Randomized,
+		partitioner:       partitioner,
+		batchKey:          batchKey,
 		logsMarshaller:    unsupported{},
 		tracesMarshaller:  unsupported{},
 		metricsMarshaller: unsupported{},
@@ -76,3 +91,13 @@ func NewEncoder(named string, batchOptions ...Option) (Encoder, error) {
 	}
 	return bm, nil
 }
+
+// ContentType returns the media type of the named format.
+func ContentType(named string) string {
+	switch named {
+	case "otlp_json", "zipkin_json":
+		return "application/json"
+	default:
+		return "application/x-protobuf"
+	}
+}
@@ -29,6 +29,9 @@ import (
 type batchMarshaller struct {
 	batchOptions []Option
 	partitioner  key.Partition
+	// batchKey is set when the records of each batch share a random
+	// partition key, for them to be aggregated
+	batchKey bool
 
 	logsMarshaller    plog.Marshaler
 	tracesMarshaller  ptrace.Marshaler
@@ -37,8 +40,18 @@ type batchMarshaller struct {
 
 var _ Encoder = (*batchMarshaller)(nil)
 
+// partition returns the partitioner of a batch.
+func (bm *batchMarshaller) partition() key.Partition {
+	if !bm.batchKey {
+		return bm.partitioner
+	}
+	k := key.Randomized(nil)
+	return func(interface{}) string { return k }
+}
+
 func (bm *batchMarshaller) Logs(ld plog.Logs) (*Batch, error) {
 	bt := New(bm.batchOptions...)
+	partition := bm.partition()
 
 	// Due to kinesis limitations of only allowing 1Mb of data per record,
 	// the resource data is copied to the export variable then marshaled
@@ -61,7 +74,7 @@ func (bm *batchMarshaller) Logs(ld plog.Logs) (*Batch, error) {
 			continue
 		}
 
-		if err := bt.AddRecord(data, bm.partitioner(export)); err != nil {
+		if err := bt.AddRecord(data, partition(line)); err != nil {
 			errs = multierr.Append(errs, consumererror.NewLogs(err, export))
 		}
 	}
@@ -71,6 +84,7 @@ func (bm *batchMarshaller) Logs(ld plog.Logs) (*Batch, error) {
 
 func (bm *batchMarshaller) Traces(td ptrace.Traces) (*Batch, error) {
 	bt := New(bm.batchOptions...)
+	partition := bm.partition()
 
 	// Due to kinesis limitations of only allowing 1Mb of data per record,
 	// the resource data is copied to the export variable then marshaled
@@ -93,7 +107,7 @@ func (bm *batchMarshaller) Traces(td ptrace.Traces) (*Batch, error) {
 			continue
 		}
 
-		if err := bt.AddRecord(data, bm.partitioner(span)); err != nil {
+		if err := bt.AddRecord(data, partition(span)); err != nil {
 			errs = multierr.Append(errs, consumererror.NewTraces(err, export))
 		}
 	}
@@ -103,6 +117,7 @@ func (bm *batchMarshaller) Traces(td ptrace.Traces) (*Batch, error) {
 
 func (bm *batchMarshaller) Metrics(md pmetric.Metrics) (*Batch, error) {
 	bt := New(bm.batchOptions...)
+	partition := bm.partition()
 
 	// Due to kinesis limitations of only allowing 1Mb of data per record,
 	// the resource data is copied to the export variable then marshaled
@@ -125,7 +140,7 @@ func (bm *batchMarshaller) Metrics(md pmetric.Metrics) (*Batch, error) {
 			continue
 		}
 
-		if err := bt.AddRecord(data, bm.partitioner(export)); err != nil {
+		if err := bt.AddRecord(data, partition(datapoint)); err != nil {
 			errs = multierr.Append(errs, consumererror.NewMetrics(err, export))
 		}
 	}
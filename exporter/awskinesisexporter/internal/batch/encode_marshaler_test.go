@@ -97,6 +97,7 @@ func TestMarshalEncoder_Metrics(t *testing.T) {
 		t.Run(tc.scenario, func(t *testing.T) {
 			encoder, err := batch.NewEncoder(
 				tc.encoding,
+				nil,
 				batch.WithMaxRecordSize(tc.recordSize),
 				batch.WithMaxRecordsPerBatch(tc.batchSize),
 			)
@@ -190,6 +191,7 @@ func TestMarshalEncoder_Traces(t *testing.T) {
 		t.Run(tc.scenario, func(t *testing.T) {
 			encoder, err := batch.NewEncoder(
 				tc.encoding,
+				nil,
 				batch.WithMaxRecordSize(tc.recordSize),
 				batch.WithMaxRecordsPerBatch(tc.batchSize),
 			)
@@ -282,6 +284,7 @@ func TestMarshalEncoder_Logs(t *testing.T) {
 		t.Run(tc.scenario, func(t *testing.T) {
 			encoder, err := batch.NewEncoder(
 				tc.encoding,
+				nil,
 				batch.WithMaxRecordSize(tc.recordSize),
 				batch.WithMaxRecordsPerBatch(tc.batchSize),
 			)
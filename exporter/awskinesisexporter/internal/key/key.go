@@ -16,6 +16,10 @@ package key // import "github.com/open-telemetry/opentelemetry-collector-contrib
 
 import (
 	"github.com/google/uuid"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 // Partition allows for switching our partitioning behavior
@@ -25,3 +29,26 @@ type Partition func(v interface{}) string
 func Randomized(_ interface{}) string {
 	return uuid.NewString()
 }
+
+// ResourceAttribute uses the value of the resource attribute as partition key,
+// so that the data of a resource is written to the same shard in order.
+// The key is randomized when the attribute is missing or empty.
+func ResourceAttribute(name string) Partition {
+	return func(v interface{}) string {
+		var resource pcommon.Resource
+		switch r := v.(type) {
+		case plog.ResourceLogs:
+			resource = r.Resource()
+		case ptrace.ResourceSpans:
+			resource = r.Resource()
+		case pmetric.ResourceMetrics:
+			resource = r.Resource()
+		default:
+			return Randomized(v)
+		}
+		if value, ok := resource.Attributes().Get(name); ok && value.AsString() != "" {
+			return value.AsString()
+		}
+		return Randomized(v)
+	}
+}
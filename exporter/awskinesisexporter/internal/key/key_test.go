@@ -18,6 +18,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awskinesisexporter/internal/key"
 )
@@ -29,3 +32,25 @@ func TestEnsureDifferentKeys(t *testing.T) {
 	assert.NotEmpty(t, k, "Must have a string that has a value")
 	assert.NotEqual(t, k, key.Randomized(nil), "Must have different string values")
 }
+
+func TestResourceAttribute(t *testing.T) {
+	t.Parallel()
+
+	partition := key.ResourceAttribute("tenant")
+
+	rl := plog.NewResourceLogs()
+	rl.Resource().Attributes().PutStr("tenant", "acme")
+	assert.Equal(t, "acme", partition(rl), "Must use the attribute of the resource logs")
+
+	rs := ptrace.NewResourceSpans()
+	rs.Resource().Attributes().PutInt("tenant", 42)
+	assert.Equal(t, "42", partition(rs), "Must use the attribute of the resource spans")
+
+	rm := pmetric.NewResourceMetrics()
+	rm.Resource().Attributes().PutStr("tenant", "globex")
+	assert.Equal(t, "globex", partition(rm), "Must use the attribute of the resource metrics")
+
+	k := partition(plog.NewResourceLogs())
+	assert.NotEmpty(t, k, "Must randomize the key when the attribute is missing")
+	assert.NotEqual(t, k, partition(plog.NewResourceLogs()), "Must have different string values")
+}
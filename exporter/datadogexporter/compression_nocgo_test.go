@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cgo
+// +build !cgo
+
+package datadogexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateZstdWithoutCgo(t *testing.T) {
+	cfg := &Config{
+		API:     APIConfig{Key: "notnull"},
+		Metrics: MetricsConfig{Compression: CompressionModeZstd},
+	}
+	assert.ErrorIs(t, cfg.Validate(), errZstdUnsupported)
+
+	cfg.Metrics.Compression = CompressionModeGzip
+	assert.NoError(t, cfg.Validate())
+}
@@ -18,8 +18,11 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -28,28 +31,137 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata/valid"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
 )
 
 var (
 	errUnsetAPIKey = errors.New("api.key is not set")
 	errNoMetadata  = errors.New("only_metadata can't be enabled when host_metadata::enabled = false or host_metadata::hostname_source != first_resource")
+	// errInvalidBatchSize is returned when metrics::batch_size is negative
+	errInvalidBatchSize = errors.New("metrics::batch_size can't be negative")
+	// errZstdUnsupported is returned when metrics::compression is zstd in a build without cgo
+	errZstdUnsupported = errors.New("metrics::compression 'zstd' is only available in builds with cgo enabled")
+	// errKeyAndKeyFile is returned when both api::key and api::key_file are set
+	errKeyAndKeyFile = errors.New("api::key and api::key_file can't both be set")
+	// errInvalidKeyReloadInterval is returned when api::key_reload_interval is not positive
+	errInvalidKeyReloadInterval = errors.New("api::key_reload_interval must be positive")
+	// errInvalidMetadataRetryInterval is returned when host_metadata::retry_interval is not positive
+	errInvalidMetadataRetryInterval = errors.New("host_metadata::retry_interval must be positive")
 )
 
 const (
 	// DefaultSite is the default site of the Datadog intake to send data to
 	DefaultSite = "datadoghq.com"
+
+	// defaultBatchSize is the default maximum number of series in a metrics payload
+	defaultBatchSize = 1000
 )
 
+// siteNames maps the names of the Datadog sites to their domains,
+// see https://docs.datadoghq.com/getting_started/site/
+var siteNames = map[string]string{
+	"us1":     "datadoghq.com",
+	"us3":     "us3.datadoghq.com",
+	"us5":     "us5.datadoghq.com",
+	"eu":      "datadoghq.eu",
+	"eu1":     "datadoghq.eu",
+	"gov":     "ddog-gov.com",
+	"us1-fed": "ddog-gov.com",
+}
+
+// resolveSite returns the domain of a site given by its name or its domain.
+func resolveSite(site string) string {
+	site = strings.ToLower(strings.TrimSpace(site))
+	if domain, ok := siteNames[site]; ok {
+		return domain
+	}
+	return site
+}
+
+// isKnownSite reports whether the site is the domain of a known Datadog site.
+func isKnownSite(site string) bool {
+	for _, domain := range siteNames {
+		if site == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// hostOf returns the host of an endpoint given as a URL or as a host and port.
+func hostOf(endpoint string) string {
+	host := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
+
+// inDomain reports whether the host of the endpoint is the domain or one of its subdomains.
+func inDomain(endpoint string, domain string) bool {
+	host := hostOf(endpoint)
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// knownSiteOf returns the known Datadog site an endpoint belongs to,
+// or an empty string if the endpoint isn't a Datadog intake, such as a proxy.
+func knownSiteOf(endpoint string) string {
+	var site string
+	for _, domain := range siteNames {
+		// the longest domain wins, us3.datadoghq.com is more specific than datadoghq.com
+		if inDomain(endpoint, domain) && len(domain) > len(site) {
+			site = domain
+		}
+	}
+	return site
+}
+
+// siteEndpoints are the endpoints of the intakes of a Datadog site.
+type siteEndpoints struct {
+	metrics string
+	traces  string
+	logs    string
+}
+
+func endpointsFromSite(site string) siteEndpoints {
+	return siteEndpoints{
+		metrics: fmt.Sprintf("https://api.%s", site),
+		traces:  fmt.Sprintf("https://trace.agent.%s", site),
+		logs:    fmt.Sprintf("https://http-intake.logs.%s", site),
+	}
+}
+
 // APIConfig defines the API configuration options
 type APIConfig struct {
 	// Key is the Datadog API key to associate your Agent's data with your organization.
 	// Create a new API key here: https://app.datadoghq.com/account/settings
 	Key string `mapstructure:"key"`
 
-	// Site is the site of the Datadog intake to send data to.
+	// KeyFile is the path of a file holding the API key, such as a mounted secret,
+	// to use instead of Key. The key is reloaded when the file changes, so that it
+	// can be rotated without restarting the collector.
+	KeyFile string `mapstructure:"key_file"`
+
+	// KeyReloadInterval is the interval at which KeyFile is checked for changes.
+	// The default value is 1 minute.
+	KeyReloadInterval time.Duration `mapstructure:"key_reload_interval"`
+
+	// Site is the site of the Datadog intake to send data to, given by its domain, such as "datadoghq.eu",
+	// or by its name, such as "eu". The endpoints of the metrics, traces and logs intakes are derived from it.
 	// The default value is "datadoghq.com".
 	Site string `mapstructure:"site"`
 
+	// FailoverSite is the site the metrics and logs are sent to when the intake of Site fails,
+	// such as the site of a Multi-Region Failover (MRF) organization. It's disabled when unset.
+	FailoverSite string `mapstructure:"failover_site"`
+
+	// FailoverKey is the API key of the organization of FailoverSite.
+	// The default value is Key.
+	FailoverKey string `mapstructure:"failover_key"`
+
 	// FailOnInvalidKey states whether to exit at startup on invalid API key.
 	// The default value is false.
 	FailOnInvalidKey bool `mapstructure:"fail_on_invalid_key"`
@@ -75,6 +187,44 @@ type MetricsConfig struct {
 
 	// SummaryConfig defines the export for OTLP Summaries.
 	SummaryConfig SummaryConfig `mapstructure:"summaries"`
+
+	// Compression is the compression of the metrics payloads.
+	// Valid values are 'gzip', 'deflate', 'zstd' or 'none'. The default is 'gzip'.
+	Compression CompressionMode `mapstructure:"compression"`
+
+	// BatchSize is the maximum number of series sent in a single payload, 0 meaning no limit.
+	// The payloads rejected by the intake for being too large are split in halves and sent again.
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// CompressionMode is the compression of the metrics payloads.
+type CompressionMode string
+
+const (
+	// CompressionModeNone sends the payloads uncompressed.
+	CompressionModeNone CompressionMode = "none"
+	// CompressionModeGzip compresses the payloads with gzip.
+	CompressionModeGzip CompressionMode = "gzip"
+	// CompressionModeDeflate compresses the payloads with deflate.
+	CompressionModeDeflate CompressionMode = "deflate"
+	// CompressionModeZstd compresses the payloads with zstd, which requires a build with cgo.
+	CompressionModeZstd CompressionMode = "zstd"
+)
+
+var _ encoding.TextUnmarshaler = (*CompressionMode)(nil)
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (cm *CompressionMode) UnmarshalText(in []byte) error {
+	switch mode := CompressionMode(in); mode {
+	case CompressionModeNone,
+		CompressionModeGzip,
+		CompressionModeDeflate,
+		CompressionModeZstd:
+		*cm = mode
+		return nil
+	default:
+		return fmt.Errorf("invalid compression mode %q", mode)
+	}
 }
 
 type HistogramMode string
@@ -237,6 +387,11 @@ type TracesConfig struct {
 	// The default value is `false`.
 	SpanNameAsResourceName bool `mapstructure:"span_name_as_resource_name"`
 
+	// If set to true, the exceptions recorded as span events are grouped and sent to the logs
+	// intake as Datadog Error Tracking items, so that Error Tracking works for traces sent
+	// through the collector. The default value is `false`.
+	ErrorTracking bool `mapstructure:"error_tracking"`
+
 	// flushInterval defines the interval in seconds at which the writer flushes traces
 	// to the intake; used in tests.
 	flushInterval float64
@@ -313,6 +468,15 @@ type HostMetadataConfig struct {
 	// These tags will be attached to telemetry signals that have the host metadata hostname.
 	// To attach tags to telemetry signals regardless of the host, use a processor instead.
 	Tags []string `mapstructure:"tags"`
+
+	// RetryInterval is the interval between the attempts to send host metadata
+	// which could not be sent, until it is sent. Host metadata is then sent again
+	// every 30 minutes.
+	RetryInterval time.Duration `mapstructure:"retry_interval"`
+
+	// Storage is the ID of the storage extension the host metadata which could not
+	// be sent is saved in, so that it is sent after a restart of the Collector.
+	Storage *config.ComponentID `mapstructure:"storage"`
 }
 
 // LimitedTLSClientSetting is a subset of TLSClientSetting, see LimitedHTTPClientSettings for more details
@@ -374,10 +538,22 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("hostname field is invalid: %w", err)
 	}
 
-	if c.API.Key == "" {
+	if c.API.Key == "" && c.API.KeyFile == "" {
 		return errUnsetAPIKey
 	}
 
+	if c.API.Key != "" && c.API.KeyFile != "" {
+		return errKeyAndKeyFile
+	}
+
+	if c.API.KeyFile != "" && c.API.KeyReloadInterval <= 0 {
+		return errInvalidKeyReloadInterval
+	}
+
+	if c.HostMetadata.Enabled && c.HostMetadata.RetryInterval <= 0 {
+		return errInvalidMetadataRetryInterval
+	}
+
 	if c.Traces.IgnoreResources != nil {
 		for _, entry := range c.Traces.IgnoreResources {
 			_, err := regexp.Compile(entry)
@@ -403,9 +579,88 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if c.Metrics.BatchSize < 0 {
+		return errInvalidBatchSize
+	}
+
+	if c.Metrics.Compression == CompressionModeZstd && !zstdSupported {
+		return errZstdUnsupported
+	}
+
+	return c.validateSite()
+}
+
+func (c *Config) validateSite() error {
+	if c.API.Site != "" && c.API.FailoverSite == c.API.Site {
+		return fmt.Errorf("api::failover_site can't be the same as api::site %q", c.API.Site)
+	}
+	if !isKnownSite(c.API.Site) {
+		// the endpoints of a new site aren't checked, see siteWarnings
+		return nil
+	}
+	for _, e := range c.endpoints() {
+		if site := knownSiteOf(e.endpoint); site != "" && site != c.API.Site {
+			return fmt.Errorf("%s %q doesn't belong to api::site %q", e.name, e.endpoint, c.API.Site)
+		}
+	}
 	return nil
 }
 
+// siteWarnings returns the warnings about the sites which aren't known Datadog sites. They are
+// still used, in case they are sites opened after this version of the exporter was released.
+func (c *Config) siteWarnings() []error {
+	if c.API.Site == "" {
+		return nil
+	}
+
+	var warnings []error
+	for _, s := range []struct {
+		name string
+		site string
+	}{
+		{"api::site", c.API.Site},
+		{"api::failover_site", c.API.FailoverSite},
+	} {
+		if s.site != "" && !isKnownSite(s.site) {
+			warnings = append(warnings, fmt.Errorf("%s %q is not a known Datadog site name or domain", s.name, s.site))
+		}
+	}
+	if isKnownSite(c.API.Site) {
+		return warnings
+	}
+	for _, e := range c.endpoints() {
+		if knownSiteOf(e.endpoint) != "" && !inDomain(e.endpoint, c.API.Site) {
+			warnings = append(warnings, fmt.Errorf("%s %q doesn't belong to api::site %q", e.name, e.endpoint, c.API.Site))
+		}
+	}
+	return warnings
+}
+
+type namedEndpoint struct {
+	name     string
+	endpoint string
+}
+
+func (c *Config) endpoints() []namedEndpoint {
+	return []namedEndpoint{
+		{"metrics::endpoint", c.Metrics.Endpoint},
+		{"traces::endpoint", c.Traces.Endpoint},
+		{"logs::endpoint", c.Logs.Endpoint},
+	}
+}
+
+// failover returns the endpoints and the API key of the failover site, if any.
+// The API key of the failover site defaults to apiKey.
+func (c *Config) failover(apiKey *utils.APIKey) (siteEndpoints, *utils.APIKey, bool) {
+	if c.API.FailoverSite == "" {
+		return siteEndpoints{}, nil, false
+	}
+	if c.API.FailoverKey != "" {
+		apiKey = utils.NewAPIKey(c.API.FailoverKey)
+	}
+	return endpointsFromSite(c.API.FailoverSite), apiKey, true
+}
+
 var _ error = (*renameError)(nil)
 
 // renameError is an error related to a renamed setting.
@@ -485,16 +740,20 @@ func (c *Config) Unmarshal(configMap *confmap.Conf) error {
 	}
 
 	c.API.Key = strings.TrimSpace(c.API.Key)
+	c.API.FailoverKey = strings.TrimSpace(c.API.FailoverKey)
+	c.API.Site = resolveSite(c.API.Site)
+	c.API.FailoverSite = resolveSite(c.API.FailoverSite)
 
 	// If an endpoint is not explicitly set, override it based on the site.
+	endpoints := endpointsFromSite(c.API.Site)
 	if !configMap.IsSet("metrics::endpoint") {
-		c.Metrics.TCPAddr.Endpoint = fmt.Sprintf("https://api.%s", c.API.Site)
+		c.Metrics.TCPAddr.Endpoint = endpoints.metrics
 	}
 	if !configMap.IsSet("traces::endpoint") {
-		c.Traces.TCPAddr.Endpoint = fmt.Sprintf("https://trace.agent.%s", c.API.Site)
+		c.Traces.TCPAddr.Endpoint = endpoints.traces
 	}
 	if !configMap.IsSet("logs::endpoint") {
-		c.Logs.TCPAddr.Endpoint = fmt.Sprintf("https://http-intake.logs.%s", c.API.Site)
+		c.Logs.TCPAddr.Endpoint = endpoints.logs
 	}
 	return nil
 }
@@ -16,9 +16,13 @@ package datadogexporter
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
 )
 
 func TestValidate(t *testing.T) {
@@ -33,6 +37,26 @@ func TestValidate(t *testing.T) {
 			cfg:  &Config{},
 			err:  errUnsetAPIKey.Error(),
 		},
+		{
+			name: "api::key_file",
+			cfg: &Config{
+				API: APIConfig{KeyFile: "/etc/datadog/api_key", KeyReloadInterval: time.Minute},
+			},
+		},
+		{
+			name: "api::key and api::key_file",
+			cfg: &Config{
+				API: APIConfig{Key: "notnull", KeyFile: "/etc/datadog/api_key", KeyReloadInterval: time.Minute},
+			},
+			err: errKeyAndKeyFile.Error(),
+		},
+		{
+			name: "invalid api::key_reload_interval",
+			cfg: &Config{
+				API: APIConfig{KeyFile: "/etc/datadog/api_key"},
+			},
+			err: errInvalidKeyReloadInterval.Error(),
+		},
 		{
 			name: "invalid hostname",
 			cfg: &Config{
@@ -50,6 +74,14 @@ func TestValidate(t *testing.T) {
 			},
 			err: errNoMetadata.Error(),
 		},
+		{
+			name: "invalid host_metadata::retry_interval",
+			cfg: &Config{
+				API:          APIConfig{Key: "notnull"},
+				HostMetadata: HostMetadataConfig{Enabled: true},
+			},
+			err: errInvalidMetadataRetryInterval.Error(),
+		},
 		{
 			name: "span name remapping valid",
 			cfg: &Config{
@@ -101,6 +133,14 @@ func TestValidate(t *testing.T) {
 			},
 			err: "'nobuckets' mode and `send_count_sum_metrics` set to false will send no histogram metrics",
 		},
+		{
+			name: "negative batch size",
+			cfg: &Config{
+				API:     APIConfig{Key: "notnull"},
+				Metrics: MetricsConfig{BatchSize: -1},
+			},
+			err: errInvalidBatchSize.Error(),
+		},
 		{
 			name: "TLS settings are valid",
 			cfg: &Config{
@@ -112,6 +152,46 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "unknown site",
+			cfg: &Config{
+				API:     APIConfig{Key: "notnull", Site: "us9.datadoghq.com"},
+				Metrics: MetricsConfig{TCPAddr: confignet.TCPAddr{Endpoint: "https://api.us9.datadoghq.com"}},
+				Traces:  TracesConfig{TCPAddr: confignet.TCPAddr{Endpoint: "https://trace.agent.datadoghq.eu"}},
+			},
+		},
+		{
+			name: "endpoint of another site",
+			cfg: &Config{
+				API:     APIConfig{Key: "notnull", Site: "datadoghq.eu"},
+				Metrics: MetricsConfig{TCPAddr: confignet.TCPAddr{Endpoint: "https://api.datadoghq.com"}},
+			},
+			err: "metrics::endpoint \"https://api.datadoghq.com\" doesn't belong to api::site \"datadoghq.eu\"",
+		},
+		{
+			name: "endpoint of a site within the domain of the site",
+			cfg: &Config{
+				API:  APIConfig{Key: "notnull", Site: "datadoghq.com"},
+				Logs: LogsConfig{TCPAddr: confignet.TCPAddr{Endpoint: "https://http-intake.logs.us3.datadoghq.com"}},
+			},
+			err: "logs::endpoint \"https://http-intake.logs.us3.datadoghq.com\" doesn't belong to api::site \"datadoghq.com\"",
+		},
+		{
+			name: "endpoints of the site or a proxy",
+			cfg: &Config{
+				API:     APIConfig{Key: "notnull", Site: "us3.datadoghq.com", FailoverSite: "us5.datadoghq.com"},
+				Metrics: MetricsConfig{TCPAddr: confignet.TCPAddr{Endpoint: "https://api.us3.datadoghq.com"}},
+				Traces:  TracesConfig{TCPAddr: confignet.TCPAddr{Endpoint: "proxy:8443"}},
+				Logs:    LogsConfig{TCPAddr: confignet.TCPAddr{Endpoint: "https://http-intake.logs.us3.datadoghq.com"}},
+			},
+		},
+		{
+			name: "failover site is the site",
+			cfg: &Config{
+				API: APIConfig{Key: "notnull", Site: "datadoghq.eu", FailoverSite: "datadoghq.eu"},
+			},
+			err: "api::failover_site can't be the same as api::site \"datadoghq.eu\"",
+		},
 	}
 	for _, testInstance := range tests {
 		t.Run(testInstance.name, func(t *testing.T) {
@@ -125,6 +205,52 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestSiteWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      *Config
+		warnings []string
+	}{
+		{
+			name: "known sites",
+			cfg: &Config{
+				API:     APIConfig{Site: "us3.datadoghq.com", FailoverSite: "us5.datadoghq.com"},
+				Metrics: MetricsConfig{TCPAddr: confignet.TCPAddr{Endpoint: "https://api.us3.datadoghq.com"}},
+			},
+		},
+		{
+			name: "unknown site name",
+			cfg: &Config{
+				API: APIConfig{Site: "us9", FailoverSite: "datadoghq.eu"},
+			},
+			warnings: []string{"api::site \"us9\" is not a known Datadog site name or domain"},
+		},
+		{
+			name: "endpoints of an unknown site",
+			cfg: &Config{
+				API:     APIConfig{Site: "us9.datadoghq.com", FailoverSite: "ap1.datadoghq.com"},
+				Metrics: MetricsConfig{TCPAddr: confignet.TCPAddr{Endpoint: "https://api.us9.datadoghq.com"}},
+				Traces:  TracesConfig{TCPAddr: confignet.TCPAddr{Endpoint: "https://trace.agent.datadoghq.eu"}},
+				Logs:    LogsConfig{TCPAddr: confignet.TCPAddr{Endpoint: "proxy:8443"}},
+			},
+			warnings: []string{
+				"api::site \"us9.datadoghq.com\" is not a known Datadog site name or domain",
+				"api::failover_site \"ap1.datadoghq.com\" is not a known Datadog site name or domain",
+				"traces::endpoint \"https://trace.agent.datadoghq.eu\" doesn't belong to api::site \"us9.datadoghq.com\"",
+			},
+		},
+	}
+	for _, testInstance := range tests {
+		t.Run(testInstance.name, func(t *testing.T) {
+			var warnings []string
+			for _, warning := range testInstance.cfg.siteWarnings() {
+				warnings = append(warnings, warning.Error())
+			}
+			assert.Equal(t, testInstance.warnings, warnings)
+		})
+	}
+}
+
 func TestUnmarshal(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -163,6 +289,15 @@ func TestUnmarshal(t *testing.T) {
 			}),
 			err: "1 error(s) decoding:\n\n* error decoding 'metrics.summaries.mode': invalid summary mode \"invalid_mode\"",
 		},
+		{
+			name: "invalid compression mode",
+			configMap: confmap.NewFromStringMap(map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"compression": "lz4",
+				},
+			}),
+			err: "1 error(s) decoding:\n\n* error decoding 'metrics.compression': invalid compression mode \"lz4\"",
+		},
 		{
 			name: "metrics::send_monotonic_counter custom error",
 			configMap: confmap.NewFromStringMap(map[string]interface{}{
@@ -226,3 +361,24 @@ func TestUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestFailover(t *testing.T) {
+	cfg := &Config{API: APIConfig{Key: "key", Site: "datadoghq.com"}}
+	apiKey := utils.NewAPIKey(cfg.API.Key)
+	_, _, ok := cfg.failover(apiKey)
+	assert.False(t, ok)
+
+	cfg.API.FailoverSite = "us5.datadoghq.com"
+	endpoints, key, ok := cfg.failover(apiKey)
+	assert.True(t, ok)
+	assert.Same(t, apiKey, key)
+	assert.Equal(t, siteEndpoints{
+		metrics: "https://api.us5.datadoghq.com",
+		traces:  "https://trace.agent.us5.datadoghq.com",
+		logs:    "https://http-intake.logs.us5.datadoghq.com",
+	}, endpoints)
+
+	cfg.API.FailoverKey = "failover_key"
+	_, key, _ = cfg.failover(apiKey)
+	assert.Equal(t, "failover_key", key.Get())
+}
@@ -31,8 +31,10 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/resourcetotelemetry"
 )
 
@@ -94,7 +96,8 @@ func (f *factory) createDefaultConfig() config.Exporter {
 		QueueSettings:    exporterhelper.NewDefaultQueueSettings(),
 
 		API: APIConfig{
-			Site: "datadoghq.com",
+			Site:              "datadoghq.com",
+			KeyReloadInterval: time.Minute,
 		},
 
 		Metrics: MetricsConfig{
@@ -116,6 +119,8 @@ func (f *factory) createDefaultConfig() config.Exporter {
 			SummaryConfig: SummaryConfig{
 				Mode: SummaryModeGauges,
 			},
+			Compression: CompressionModeGzip,
+			BatchSize:   defaultBatchSize,
 		},
 
 		Traces: TracesConfig{
@@ -134,27 +139,53 @@ func (f *factory) createDefaultConfig() config.Exporter {
 		HostMetadata: HostMetadataConfig{
 			Enabled:        true,
 			HostnameSource: hostnameSource,
+			RetryInterval:  time.Minute,
 		},
 	}
 }
 
 // checkAndCastConfig checks the configuration type and its warnings, and casts it to
 // the Datadog Config struct.
-func checkAndCastConfig(c config.Exporter) *Config {
+func checkAndCastConfig(c config.Exporter, logger *zap.Logger) *Config {
 	cfg, ok := c.(*Config)
 	if !ok {
 		panic("programming error: config structure is not of type *datadogexporter.Config")
 	}
+	for _, warning := range cfg.siteWarnings() {
+		logger.Warn(warning.Error())
+	}
 	return cfg
 }
 
+// newAPIKey returns the API key of the exporter. The key read from api::key_file is
+// watched until ctx is cancelled, and a rotated key is validated before being used.
+func newAPIKey(ctx context.Context, set component.ExporterCreateSettings, cfg *Config) (*utils.APIKey, error) {
+	if cfg.API.KeyFile == "" {
+		return utils.NewAPIKey(cfg.API.Key), nil
+	}
+	apiKey, err := utils.NewAPIKeyFromFile(cfg.API.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	client := utils.CreateAPIClient(
+		set.BuildInfo,
+		cfg.Metrics.TCPAddr.Endpoint,
+		cfg.TimeoutSettings,
+		cfg.LimitedHTTPClientSettings.TLSSetting.InsecureSkipVerify,
+	)
+	go apiKey.Watch(ctx, cfg.API.KeyReloadInterval, set.Logger, func(ctx context.Context, key string) error {
+		return utils.ValidateAPIKey(ctx, key, set.Logger, client)
+	})
+	return apiKey, nil
+}
+
 // createMetricsExporter creates a metrics exporter based on this config.
 func (f *factory) createMetricsExporter(
 	ctx context.Context,
 	set component.ExporterCreateSettings,
 	c config.Exporter,
 ) (component.MetricsExporter, error) {
-	cfg := checkAndCastConfig(c)
+	cfg := checkAndCastConfig(c, set.Logger)
 
 	hostProvider, err := f.SourceProvider(set.TelemetrySettings, cfg.Hostname)
 	if err != nil {
@@ -162,6 +193,12 @@ func (f *factory) createMetricsExporter(
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
+	apiKey, err := newAPIKey(ctx, set, cfg)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	metaStorage := newMetadataStorage(cfg, config.MetricsDataType)
 	var pushMetricsFn consumer.ConsumeMetricsFunc
 
 	if cfg.OnlyMetadata {
@@ -172,13 +209,13 @@ func (f *factory) createMetricsExporter(
 				if md.ResourceMetrics().Len() > 0 {
 					attrs = md.ResourceMetrics().At(0).Resource().Attributes()
 				}
-				go metadata.Pusher(ctx, set, newMetadataConfigfromConfig(cfg), hostProvider, attrs)
+				metaStorage.goPusher(ctx, set, newMetadataConfigfromConfig(cfg, apiKey, metaStorage), hostProvider, attrs)
 			})
 
 			return nil
 		}
 	} else {
-		exp, metricsErr := newMetricsExporter(ctx, set, cfg, &f.onceMetadata, hostProvider)
+		exp, metricsErr := newMetricsExporter(ctx, set, cfg, apiKey, &f.onceMetadata, metaStorage, hostProvider)
 		if metricsErr != nil {
 			cancel()
 			return nil, metricsErr
@@ -196,9 +233,10 @@ func (f *factory) createMetricsExporter(
 		// We use our own custom mechanism for retries, since we hit several endpoints.
 		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
 		exporterhelper.WithQueue(cfg.QueueSettings),
-		exporterhelper.WithShutdown(func(context.Context) error {
+		exporterhelper.WithStart(metaStorage.start),
+		exporterhelper.WithShutdown(func(ctx context.Context) error {
 			cancel()
-			return nil
+			return metaStorage.shutdown(ctx)
 		}),
 	)
 	if err != nil {
@@ -214,7 +252,7 @@ func (f *factory) createTracesExporter(
 	set component.ExporterCreateSettings,
 	c config.Exporter,
 ) (component.TracesExporter, error) {
-	cfg := checkAndCastConfig(c)
+	cfg := checkAndCastConfig(c, set.Logger)
 
 	var (
 		pusher consumer.ConsumeTracesFunc
@@ -227,6 +265,12 @@ func (f *factory) createTracesExporter(
 	}
 	ctx, cancel := context.WithCancel(ctx) // nolint:govet
 	// cancel() runs on shutdown
+	apiKey, err := newAPIKey(ctx, set, cfg)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	metaStorage := newMetadataStorage(cfg, config.TracesDataType)
 	if cfg.OnlyMetadata {
 		// only host metadata needs to be sent, once.
 		pusher = func(_ context.Context, td ptrace.Traces) error {
@@ -235,25 +279,25 @@ func (f *factory) createTracesExporter(
 				if td.ResourceSpans().Len() > 0 {
 					attrs = td.ResourceSpans().At(0).Resource().Attributes()
 				}
-				go metadata.Pusher(ctx, set, newMetadataConfigfromConfig(cfg), hostProvider, attrs)
+				metaStorage.goPusher(ctx, set, newMetadataConfigfromConfig(cfg, apiKey, metaStorage), hostProvider, attrs)
 			})
 			return nil
 		}
-		stop = func(context.Context) error {
+		stop = func(ctx context.Context) error {
 			cancel()
-			return nil
+			return metaStorage.shutdown(ctx)
 		}
 	} else {
-		tracex, err2 := newTracesExporter(ctx, set, cfg, &f.onceMetadata, hostProvider)
+		tracex, err2 := newTracesExporter(ctx, set, cfg, apiKey, &f.onceMetadata, metaStorage, hostProvider)
 		if err2 != nil {
 			cancel()
 			return nil, err2
 		}
 		pusher = tracex.consumeTraces
-		stop = func(context.Context) error {
+		stop = func(ctx context.Context) error {
 			cancel()              // first cancel context
 			tracex.waitShutdown() // then wait for shutdown
-			return nil
+			return metaStorage.shutdown(ctx)
 		}
 	}
 
@@ -267,6 +311,7 @@ func (f *factory) createTracesExporter(
 		// We don't do retries on traces because of deduping concerns on APM Events.
 		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
 		exporterhelper.WithQueue(cfg.QueueSettings),
+		exporterhelper.WithStart(metaStorage.start),
 		exporterhelper.WithShutdown(stop),
 	)
 }
@@ -277,7 +322,7 @@ func (f *factory) createLogsExporter(
 	set component.ExporterCreateSettings,
 	c config.Exporter,
 ) (component.LogsExporter, error) {
-	cfg := checkAndCastConfig(c)
+	cfg := checkAndCastConfig(c, set.Logger)
 
 	var pusher consumer.ConsumeLogsFunc
 	hostProvider, err := f.SourceProvider(set.TelemetrySettings, cfg.Hostname)
@@ -286,17 +331,23 @@ func (f *factory) createLogsExporter(
 	}
 	ctx, cancel := context.WithCancel(ctx)
 	// cancel() runs on shutdown
+	apiKey, err := newAPIKey(ctx, set, cfg)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	metaStorage := newMetadataStorage(cfg, config.LogsDataType)
 	if cfg.OnlyMetadata {
 		// only host metadata needs to be sent, once.
 		pusher = func(_ context.Context, td plog.Logs) error {
 			f.onceMetadata.Do(func() {
 				attrs := pcommon.NewMap()
-				go metadata.Pusher(ctx, set, newMetadataConfigfromConfig(cfg), hostProvider, attrs)
+				metaStorage.goPusher(ctx, set, newMetadataConfigfromConfig(cfg, apiKey, metaStorage), hostProvider, attrs)
 			})
 			return nil
 		}
 	} else {
-		exp, err := newLogsExporter(ctx, set, cfg, &f.onceMetadata, hostProvider)
+		exp, err := newLogsExporter(ctx, set, cfg, apiKey, &f.onceMetadata, metaStorage, hostProvider)
 		if err != nil {
 			cancel()
 			return nil, err
@@ -312,9 +363,10 @@ func (f *factory) createLogsExporter(
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0 * time.Second}),
 		exporterhelper.WithRetry(cfg.RetrySettings),
 		exporterhelper.WithQueue(cfg.QueueSettings),
-		exporterhelper.WithShutdown(func(context.Context) error {
+		exporterhelper.WithStart(metaStorage.start),
+		exporterhelper.WithShutdown(func(ctx context.Context) error {
 			cancel()
-			return nil
+			return metaStorage.shutdown(ctx)
 		}),
 	)
 }
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -46,7 +47,8 @@ func TestCreateDefaultConfig(t *testing.T) {
 		QueueSettings:    exporterhelper.NewDefaultQueueSettings(),
 
 		API: APIConfig{
-			Site: "datadoghq.com",
+			Site:              "datadoghq.com",
+			KeyReloadInterval: time.Minute,
 		},
 
 		Metrics: MetricsConfig{
@@ -64,6 +66,8 @@ func TestCreateDefaultConfig(t *testing.T) {
 			SummaryConfig: SummaryConfig{
 				Mode: SummaryModeGauges,
 			},
+			Compression: CompressionModeGzip,
+			BatchSize:   1000,
 		},
 
 		Traces: TracesConfig{
@@ -81,6 +85,7 @@ func TestCreateDefaultConfig(t *testing.T) {
 		HostMetadata: HostMetadataConfig{
 			Enabled:        true,
 			HostnameSource: HostnameSourceConfigOrSystem,
+			RetryInterval:  time.Minute,
 		},
 		OnlyMetadata: false,
 	}, cfg, "failed to create default config")
@@ -109,9 +114,10 @@ func TestLoadConfig(t *testing.T) {
 		Hostname: "customhostname",
 	}, apiConfig.TagsConfig)
 	assert.Equal(t, APIConfig{
-		Key:              "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
-		Site:             "datadoghq.eu",
-		FailOnInvalidKey: true,
+		Key:               "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		KeyReloadInterval: time.Minute,
+		Site:              "datadoghq.eu",
+		FailOnInvalidKey:  true,
 	}, apiConfig.API)
 	assert.Equal(t, MetricsConfig{
 		TCPAddr: confignet.TCPAddr{
@@ -128,6 +134,8 @@ func TestLoadConfig(t *testing.T) {
 		SummaryConfig: SummaryConfig{
 			Mode: SummaryModeGauges,
 		},
+		Compression: CompressionModeGzip,
+		BatchSize:   1000,
 	}, apiConfig.Metrics)
 	assert.Equal(t, TracesConfig{
 		TCPAddr: confignet.TCPAddr{
@@ -138,6 +146,7 @@ func TestLoadConfig(t *testing.T) {
 			"old_name2": "new_name2",
 		},
 		SpanNameAsResourceName: true,
+		ErrorTracking:          true,
 		IgnoreResources:        []string{},
 	}, apiConfig.Traces)
 	assert.False(t, apiConfig.OnlyMetadata)
@@ -149,9 +158,10 @@ func TestLoadConfig(t *testing.T) {
 		RetrySettings:    exporterhelper.NewDefaultRetrySettings(),
 		QueueSettings:    exporterhelper.NewDefaultQueueSettings(),
 		API: APIConfig{
-			Key:              "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
-			Site:             "datadoghq.com",
-			FailOnInvalidKey: false,
+			Key:               "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			KeyReloadInterval: time.Minute,
+			Site:              "datadoghq.com",
+			FailOnInvalidKey:  false,
 		},
 
 		Metrics: MetricsConfig{
@@ -169,6 +179,8 @@ func TestLoadConfig(t *testing.T) {
 			SummaryConfig: SummaryConfig{
 				Mode: SummaryModeGauges,
 			},
+			Compression: CompressionModeGzip,
+			BatchSize:   1000,
 		},
 
 		Traces: TracesConfig{
@@ -186,6 +198,7 @@ func TestLoadConfig(t *testing.T) {
 		HostMetadata: HostMetadataConfig{
 			Enabled:        true,
 			HostnameSource: HostnameSourceConfigOrSystem,
+			RetryInterval:  time.Minute,
 		},
 
 		OnlyMetadata: false,
@@ -203,9 +216,10 @@ func TestLoadConfig(t *testing.T) {
 			Hostname: "customhostname",
 		},
 		API: APIConfig{
-			Key:              "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
-			Site:             "datadoghq.eu",
-			FailOnInvalidKey: false,
+			Key:               "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			KeyReloadInterval: time.Minute,
+			Site:              "datadoghq.eu",
+			FailOnInvalidKey:  false,
 		},
 		Metrics: MetricsConfig{
 			TCPAddr: confignet.TCPAddr{
@@ -222,6 +236,8 @@ func TestLoadConfig(t *testing.T) {
 			SummaryConfig: SummaryConfig{
 				Mode: SummaryModeGauges,
 			},
+			Compression: CompressionModeGzip,
+			BatchSize:   1000,
 		},
 		Traces: TracesConfig{
 			TCPAddr: confignet.TCPAddr{
@@ -241,6 +257,7 @@ func TestLoadConfig(t *testing.T) {
 		HostMetadata: HostMetadataConfig{
 			Enabled:        true,
 			HostnameSource: HostnameSourceConfigOrSystem,
+			RetryInterval:  time.Minute,
 			Tags:           []string{"example:tag"},
 		},
 	}, api2Config)
@@ -290,6 +307,13 @@ func TestOverrideEndpoints(t *testing.T) {
 			expectedLogsEndpoint:    "logsendpoint:1234",
 		},
 
+		{
+			componentID:             "sitenameandnoendpoints",
+			expectedSite:            "us3.datadoghq.com",
+			expectedMetricsEndpoint: "https://api.us3.datadoghq.com",
+			expectedTracesEndpoint:  "https://trace.agent.us3.datadoghq.com",
+			expectedLogsEndpoint:    "https://http-intake.logs.us3.datadoghq.com",
+		},
 		{
 			componentID:             "siteandnoendpoints",
 			expectedSite:            "datadoghq.eu",
@@ -15,19 +15,106 @@
 package datadogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter"
 
 import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/DataDog/datadog-agent/pkg/otlp/model/source"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
 )
 
 // newMetadataConfigfromConfig creates a new metadata pusher config from the main
-func newMetadataConfigfromConfig(cfg *Config) metadata.PusherConfig {
+func newMetadataConfigfromConfig(cfg *Config, apiKey *utils.APIKey, metadataStorage *metadataStorage) metadata.PusherConfig {
 	return metadata.PusherConfig{
 		ConfigHostname:      cfg.Hostname,
 		ConfigTags:          cfg.HostMetadata.Tags,
 		MetricsEndpoint:     cfg.Metrics.Endpoint,
-		APIKey:              cfg.API.Key,
+		APIKey:              apiKey,
 		UseResourceMetadata: cfg.HostMetadata.HostnameSource == HostnameSourceFirstResource,
 		InsecureSkipVerify:  cfg.TLSSetting.InsecureSkipVerify,
 		TimeoutSettings:     cfg.TimeoutSettings,
 		RetrySettings:       cfg.RetrySettings,
+		RetryInterval:       cfg.HostMetadata.RetryInterval,
+		Storage:             metadataStorage.getClient(),
+	}
+}
+
+// metadataStorage gives the host metadata pusher access to the storage extension
+// set in host_metadata::storage, whose client is only available once the exporter
+// has started.
+type metadataStorage struct {
+	storageID   *config.ComponentID
+	componentID config.ComponentID
+	signal      config.DataType
+	client      storage.Client
+	pushers     sync.WaitGroup // pushers waits for the pushers using the client
+}
+
+func newMetadataStorage(cfg *Config, signal config.DataType) *metadataStorage {
+	return &metadataStorage{
+		storageID:   cfg.HostMetadata.Storage,
+		componentID: cfg.ID(),
+		signal:      signal,
+	}
+}
+
+// start gets a client of the storage extension, if any, one per signal.
+func (s *metadataStorage) start(ctx context.Context, host component.Host) error {
+	if s.storageID == nil {
+		return nil
+	}
+
+	extension, ok := host.GetExtensions()[*s.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", s.storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", s.storageID)
+	}
+
+	client, err := storageExtension.GetClient(ctx, component.KindExporter, s.componentID, string(s.signal))
+	if err != nil {
+		return err
+	}
+	s.client = client
+	return nil
+}
+
+// goPusher runs the host metadata pusher until ctx is cancelled.
+func (s *metadataStorage) goPusher(ctx context.Context, params component.ExporterCreateSettings, pcfg metadata.PusherConfig, p source.Provider, attrs pcommon.Map) {
+	if s == nil {
+		go metadata.Pusher(ctx, params, pcfg, p, attrs)
+		return
+	}
+	s.pushers.Add(1)
+	go func() {
+		defer s.pushers.Done()
+		metadata.Pusher(ctx, params, pcfg, p, attrs)
+	}()
+}
+
+// shutdown closes the client once the pushers have stopped, so the context of the
+// pushers must have been cancelled beforehand.
+func (s *metadataStorage) shutdown(ctx context.Context) error {
+	s.pushers.Wait()
+	if s.client == nil {
+		return nil
+	}
+	return s.client.Close(ctx)
+}
+
+// getClient returns the client of the storage extension, or nil if there is none.
+func (s *metadataStorage) getClient() storage.Client {
+	if s == nil {
+		return nil
 	}
+	return s.client
 }
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datadogexporter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
+)
+
+// closeTrackingStorage is a storage.Client recording the writes made after it was closed.
+type closeTrackingStorage struct {
+	mu                sync.Mutex
+	closed            bool
+	writesAfterClosed int
+}
+
+var _ storage.Client = (*closeTrackingStorage)(nil)
+
+func (s *closeTrackingStorage) write() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		s.writesAfterClosed++
+	}
+	return nil
+}
+
+func (s *closeTrackingStorage) Get(context.Context, string) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *closeTrackingStorage) Set(context.Context, string, []byte) error {
+	return s.write()
+}
+
+func (s *closeTrackingStorage) Delete(context.Context, string) error {
+	return s.write()
+}
+
+func (s *closeTrackingStorage) Batch(context.Context, ...storage.Operation) error {
+	return s.write()
+}
+
+func (s *closeTrackingStorage) Close(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func TestMetadataStorageShutdownWaitsForPusher(t *testing.T) {
+	// the intake holds the payload until it is released, while the exporter shuts down
+	received := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.NewServeMux()
+	handler.HandleFunc("/intake", func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release
+	})
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	client := &closeTrackingStorage{}
+	s := &metadataStorage{client: client}
+	params := componenttest.NewNopExporterCreateSettings()
+	hostProvider, err := metadata.GetSourceProvider(componenttest.NewNopTelemetrySettings(), "hostname")
+	require.NoError(t, err)
+	pcfg := metadata.PusherConfig{
+		ConfigHostname:  "hostname",
+		MetricsEndpoint: ts.URL,
+		APIKey:          utils.NewAPIKey("apikey"),
+		RetryInterval:   time.Minute,
+		Storage:         client,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.goPusher(ctx, params, pcfg, hostProvider, pcommon.NewMap())
+	<-received
+
+	cancel()
+	shutdown := make(chan error)
+	go func() { shutdown <- s.shutdown(context.Background()) }()
+	select {
+	case <-shutdown:
+		require.FailNow(t, "the storage was closed while the pusher was running")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-shutdown)
+	assert.Zero(t, client.writesAfterClosed)
+}
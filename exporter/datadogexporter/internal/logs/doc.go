@@ -13,6 +13,7 @@
 // limitations under the License.
 
 // Package logs provides utils for transforming OTLP LogRecord to Datadog format
+// and for extracting Datadog Error Tracking items from exception span events
 // it also provides sender for submitting transformed logs to datadog backend
 // This uses datadog-api-client-go for submitting logs
 package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/logs"
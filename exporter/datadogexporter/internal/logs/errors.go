@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/logs"
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/otlp/model/attributes"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	// exceptionEventName is the name of the span events recording an exception.
+	// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/trace/semantic_conventions/exceptions.md
+	exceptionEventName = "exception"
+
+	// This set of constants specify the keys of the attributes recognized by Datadog Error Tracking.
+	// https://docs.datadoghq.com/logs/error_tracking/backend/?tab=serilog#attributes
+	errorKind        = "error.kind"
+	errorMessage     = "error.message"
+	errorStack       = "error.stack"
+	errorFingerprint = "error.fingerprint"
+	// errorCount is the number of occurrences of the exception grouped in a single item.
+	errorCount = "error.count"
+	spanName   = "span.name"
+)
+
+// errorGroup holds the occurrences of an exception sharing the same fingerprint.
+type errorGroup struct {
+	item  datadogV2.HTTPLogItem
+	count int
+}
+
+// TransformErrors extracts the exceptions recorded as span events in td and converts them
+// to Datadog Error Tracking log items. Occurrences of the same exception, identified by the
+// service, the exception type and the stack trace (or the message when there is no stack trace),
+// are grouped into a single item, with the number of occurrences in the "error.count" attribute.
+// The trace and span IDs of the item are the ones of the first occurrence.
+func TransformErrors(td ptrace.Traces) []datadogV2.HTTPLogItem {
+	var fingerprints []string
+	groups := make(map[string]*errorGroup)
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		res := rs.Resource()
+		host, service := extractHostNameAndServiceName(res.Attributes(), pcommon.NewMap())
+		var ddtags string
+		if tags := attributes.TagsFromAttributes(res.Attributes()); len(tags) > 0 {
+			ddtags = strings.Join(tags, ",")
+		}
+
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				events := span.Events()
+				for l := 0; l < events.Len(); l++ {
+					event := events.At(l)
+					if event.Name() != exceptionEventName {
+						continue
+					}
+					kind, message, stack := exceptionFromAttributes(event.Attributes())
+					if kind == "" && message == "" {
+						// not a valid exception event, see the semantic conventions
+						continue
+					}
+					fingerprint := errorFingerprintOf(service, kind, message, stack)
+					if g, ok := groups[fingerprint]; ok {
+						g.count++
+						continue
+					}
+					item := newErrorItem(span, event, kind, message, stack)
+					item.AdditionalProperties[errorFingerprint] = fingerprint
+					if host != "" {
+						item.Hostname = datadog.PtrString(host)
+					}
+					if service != "" {
+						item.Service = datadog.PtrString(service)
+					}
+					if ddtags != "" {
+						item.Ddtags = datadog.PtrString(ddtags)
+					}
+					groups[fingerprint] = &errorGroup{item: item, count: 1}
+					fingerprints = append(fingerprints, fingerprint)
+				}
+			}
+		}
+	}
+
+	payload := make([]datadogV2.HTTPLogItem, 0, len(fingerprints))
+	for _, fingerprint := range fingerprints {
+		g := groups[fingerprint]
+		g.item.AdditionalProperties[errorCount] = strconv.Itoa(g.count)
+		payload = append(payload, g.item)
+	}
+	return payload
+}
+
+func exceptionFromAttributes(attrs pcommon.Map) (kind, message, stack string) {
+	if v, ok := attrs.Get(conventions.AttributeExceptionType); ok {
+		kind = v.AsString()
+	}
+	if v, ok := attrs.Get(conventions.AttributeExceptionMessage); ok {
+		message = v.AsString()
+	}
+	if v, ok := attrs.Get(conventions.AttributeExceptionStacktrace); ok {
+		stack = v.AsString()
+	}
+	return kind, message, stack
+}
+
+// errorFingerprintOf computes the key exceptions are grouped by. The message is only used
+// when there is no stack trace, since it often contains variable parts (IDs, values...).
+func errorFingerprintOf(service, kind, message, stack string) string {
+	h := fnv.New64a()
+	for _, part := range []string{service, kind, stack} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	if stack == "" {
+		h.Write([]byte(message))
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+func newErrorItem(span ptrace.Span, event ptrace.SpanEvent, kind, message, stack string) datadogV2.HTTPLogItem {
+	l := datadogV2.HTTPLogItem{
+		AdditionalProperties: map[string]string{
+			ddStatus: logLevelError,
+			spanName: span.Name(),
+		},
+	}
+	switch {
+	case kind != "" && message != "":
+		l.Message = kind + ": " + message
+	case kind != "":
+		l.Message = kind
+	default:
+		l.Message = message
+	}
+	if kind != "" {
+		l.AdditionalProperties[errorKind] = kind
+	}
+	if message != "" {
+		l.AdditionalProperties[errorMessage] = message
+	}
+	if stack != "" {
+		l.AdditionalProperties[errorStack] = stack
+	}
+	if !span.TraceID().IsEmpty() {
+		l.AdditionalProperties[ddTraceID] = strconv.FormatUint(traceIDToUint64(span.TraceID()), 10)
+		l.AdditionalProperties[otelTraceID] = span.TraceID().HexString()
+	}
+	if !span.SpanID().IsEmpty() {
+		l.AdditionalProperties[ddSpanID] = strconv.FormatUint(spanIDToUint64(span.SpanID()), 10)
+		l.AdditionalProperties[otelSpanID] = span.SpanID().HexString()
+	}
+	ts := event.Timestamp()
+	if ts == 0 {
+		ts = span.EndTimestamp()
+	}
+	if ts != 0 {
+		l.AdditionalProperties[otelTimestamp] = strconv.FormatInt(ts.AsTime().UnixNano(), 10)
+		l.AdditionalProperties[ddTimestamp] = ts.AsTime().Format(time.RFC3339)
+	}
+	return l
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+func addException(span ptrace.Span, ts time.Time, kind, message, stack string) {
+	event := span.Events().AppendEmpty()
+	event.SetName(exceptionEventName)
+	event.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	if kind != "" {
+		event.Attributes().PutStr(conventions.AttributeExceptionType, kind)
+	}
+	if message != "" {
+		event.Attributes().PutStr(conventions.AttributeExceptionMessage, message)
+	}
+	if stack != "" {
+		event.Attributes().PutStr(conventions.AttributeExceptionStacktrace, stack)
+	}
+}
+
+func TestTransformErrors(t *testing.T) {
+	traceID := [16]byte{0x08, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x0, 0x0, 0x0, 0x0, 0x0a}
+	spanID := [8]byte{0x0, 0x0, 0x0, 0x0, 0x0a, 0x0, 0x0, 0x01}
+	ts := time.Date(2022, 10, 4, 10, 20, 30, 0, time.UTC)
+	stack := "Traceback (most recent call last):\n  File \"app.py\", line 3, in <module>\nZeroDivisionError: division by zero"
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(conventions.AttributeServiceName, "billing")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+
+	span := spans.AppendEmpty()
+	span.SetName("charge")
+	span.SetTraceID(traceID)
+	span.SetSpanID(spanID)
+	addException(span, ts, "ZeroDivisionError", "division by zero", stack)
+	span.Events().AppendEmpty().SetName("cache.miss")
+	// same stack trace, different message: grouped with the first one
+	addException(span, ts.Add(time.Second), "ZeroDivisionError", "integer division by zero", stack)
+
+	other := spans.AppendEmpty()
+	other.SetName("refund")
+	addException(other, ts, "KeyError", "'amount'", "")
+	addException(other, ts, "KeyError", "'currency'", "")
+	// missing both the type and the message: ignored
+	addException(other, ts, "", "", stack)
+
+	payload := TransformErrors(td)
+	require.Len(t, payload, 3)
+
+	assert.Equal(t, datadogV2.HTTPLogItem{
+		Message: "ZeroDivisionError: division by zero",
+		Service: datadog.PtrString("billing"),
+		Ddtags:  datadog.PtrString("service:billing"),
+		AdditionalProperties: map[string]string{
+			ddStatus:         logLevelError,
+			spanName:         "charge",
+			errorKind:        "ZeroDivisionError",
+			errorMessage:     "division by zero",
+			errorStack:       stack,
+			errorFingerprint: errorFingerprintOf("billing", "ZeroDivisionError", "division by zero", stack),
+			errorCount:       "2",
+			ddTraceID:        strconv.FormatUint(traceIDToUint64(traceID), 10),
+			otelTraceID:      "0802030405060708000000000a000000",
+			ddSpanID:         strconv.FormatUint(spanIDToUint64(spanID), 10),
+			otelSpanID:       "000000000a000001",
+			otelTimestamp:    strconv.FormatInt(ts.UnixNano(), 10),
+			ddTimestamp:      "2022-10-04T10:20:30Z",
+		},
+	}, payload[0])
+
+	for i, message := range []string{"'amount'", "'currency'"} {
+		item := payload[i+1]
+		assert.Equal(t, "KeyError: "+message, item.Message)
+		assert.Equal(t, "refund", item.AdditionalProperties[spanName])
+		assert.Equal(t, "1", item.AdditionalProperties[errorCount])
+		assert.NotContains(t, item.AdditionalProperties, errorStack)
+		assert.NotContains(t, item.AdditionalProperties, ddTraceID)
+	}
+	assert.NotEqual(t, payload[1].AdditionalProperties[errorFingerprint], payload[2].AdditionalProperties[errorFingerprint])
+}
+
+func TestTransformErrorsWithoutExceptions(t *testing.T) {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Events().AppendEmpty().SetName("message")
+	assert.Empty(t, TransformErrors(td))
+}
+
+func TestErrorFingerprintOf(t *testing.T) {
+	// the message is ignored when there is a stack trace
+	assert.Equal(t, errorFingerprintOf("svc", "Error", "id 1", "stack"), errorFingerprintOf("svc", "Error", "id 2", "stack"))
+	assert.NotEqual(t, errorFingerprintOf("svc", "Error", "id 1", ""), errorFingerprintOf("svc", "Error", "id 2", ""))
+	assert.NotEqual(t, errorFingerprintOf("svc", "Error", "", "stack"), errorFingerprintOf("other", "Error", "", "stack"))
+	assert.Len(t, errorFingerprintOf("svc", "Error", "", ""), 16)
+}
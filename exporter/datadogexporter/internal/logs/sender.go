@@ -30,6 +30,7 @@ type Sender struct {
 	logger *zap.Logger
 	api    *datadogV2.LogsApi
 	opts   datadogV2.SubmitLogOptionalParameters
+	apiKey *utils.APIKey
 }
 
 // logsV2 is the key in datadog ServerConfiguration
@@ -38,7 +39,7 @@ type Sender struct {
 const logsV2 = "v2.LogsApi.SubmitLog"
 
 // NewSender creates a new Sender
-func NewSender(endpoint string, logger *zap.Logger, s exporterhelper.TimeoutSettings, insecureSkipVerify bool, apiKey string) *Sender {
+func NewSender(endpoint string, logger *zap.Logger, s exporterhelper.TimeoutSettings, insecureSkipVerify bool, apiKey *utils.APIKey) *Sender {
 	cfg := datadog.NewConfiguration()
 	logger.Info("Logs sender initialized", zap.String("endpoint", endpoint))
 	cfg.OperationServers[logsV2] = datadog.ServerConfigurations{
@@ -47,7 +48,6 @@ func NewSender(endpoint string, logger *zap.Logger, s exporterhelper.TimeoutSett
 		},
 	}
 	cfg.HTTPClient = utils.NewHTTPClient(s, insecureSkipVerify)
-	cfg.AddDefaultHeader("DD-API-KEY", apiKey)
 	apiClient := datadog.NewAPIClient(cfg)
 	// enable sending gzip
 	opts := *datadogV2.NewSubmitLogOptionalParameters().WithContentEncoding(datadogV2.CONTENTENCODING_GZIP)
@@ -55,14 +55,20 @@ func NewSender(endpoint string, logger *zap.Logger, s exporterhelper.TimeoutSett
 		api:    datadogV2.NewLogsApi(apiClient),
 		logger: logger,
 		opts:   opts,
+		apiKey: apiKey,
 	}
 }
 
 // SubmitLogs submits the logs contained in payload to the Datadog intake
 func (s *Sender) SubmitLogs(ctx context.Context, payload []datadogV2.HTTPLogItem) error {
 	s.logger.Debug("Submitting logs", zap.Any("payload", payload))
-	_, r, err := s.api.SubmitLog(ctx, payload, s.opts)
+	_, r, err := s.api.SubmitLog(utils.GetRequestContext(ctx, s.apiKey.Get()), payload, s.opts)
 	if err != nil {
+		if r == nil {
+			// the request did not reach the intake
+			s.logger.Error("Failed to send logs", zap.Error(err))
+			return err
+		}
 		b := make([]byte, 1024) // 1KB message max
 		n, _ := r.Body.Read(b)  // ignore any error
 		s.logger.Error("Failed to send logs", zap.Error(err), zap.String("msg", string(b[:n])), zap.String("status_code", r.Status))
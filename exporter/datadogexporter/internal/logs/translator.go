@@ -47,6 +47,8 @@ const (
 	ddSpanID    = ddNamespace + ".span_id"
 	ddStatus    = "status"
 	ddTimestamp = "@timestamp"
+	ddTags      = "ddtags"
+	ddSource    = "ddsource"
 )
 
 const (
@@ -76,6 +78,7 @@ func Transform(lr plog.LogRecord, res pcommon.Resource) datadogV2.HTTPLogItem {
 	// we need to set log attributes as AdditionalProperties
 	// AdditionalProperties are treated as Datadog Log Attributes
 	var status string
+	var tags []string
 	lr.Attributes().Range(func(k string, v pcommon.Value) bool {
 		switch strings.ToLower(k) {
 		// set of remapping are taken from Datadog Backend
@@ -83,6 +86,10 @@ func Transform(lr plog.LogRecord, res pcommon.Resource) datadogV2.HTTPLogItem {
 			l.Message = v.AsString()
 		case "status", "severity", "level", "syslog.severity":
 			status = v.AsString()
+		case ddTags:
+			tags = append(tags, strings.Split(v.AsString(), ",")...)
+		case ddSource:
+			l.Ddsource = datadog.PtrString(v.AsString())
 		default:
 			l.AdditionalProperties[k] = v.AsString()
 		}
@@ -123,7 +130,8 @@ func Transform(lr plog.LogRecord, res pcommon.Resource) datadogV2.HTTPLogItem {
 		l.Message = lr.Body().AsString()
 	}
 
-	var tags = attributes.TagsFromAttributes(res.Attributes())
+	// the tags derived from the resource come first, followed by the ones set on the log
+	tags = append(attributes.TagsFromAttributes(res.Attributes()), tags...)
 	if len(tags) > 0 {
 		tagStr := strings.Join(tags, ",")
 		l.Ddtags = datadog.PtrString(tagStr)
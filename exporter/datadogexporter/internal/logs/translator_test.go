@@ -251,6 +251,36 @@ func TestTransform(t *testing.T) {
 				},
 			},
 		},
+		{
+			// tags and source from log
+			name: "ddtags",
+			args: args{
+				lr: func() plog.LogRecord {
+					l := plog.NewLogRecord()
+					l.Attributes().PutStr("app", "test")
+					l.Attributes().PutStr("ddtags", "team:backend,tier:web")
+					l.Attributes().PutStr("ddsource", "nginx")
+					l.SetSeverityNumber(13)
+					return l
+				}(),
+				res: func() pcommon.Resource {
+					r := pcommon.NewResource()
+					r.Attributes().PutStr(conventions.AttributeServiceName, "otlp_col")
+					return r
+				}(),
+			},
+			want: datadogV2.HTTPLogItem{
+				Ddsource: datadog.PtrString("nginx"),
+				Ddtags:   datadog.PtrString("service:otlp_col,team:backend,tier:web"),
+				Message:  *datadog.PtrString(""),
+				Service:  datadog.PtrString("otlp_col"),
+				AdditionalProperties: map[string]string{
+					"app":              "test",
+					"status":           "warn",
+					otelSeverityNumber: "13",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
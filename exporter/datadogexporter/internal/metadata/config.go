@@ -15,7 +15,12 @@
 package metadata // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
 )
 
 // PusherConfig is the configuration for the metadata pusher goroutine.
@@ -26,8 +31,8 @@ type PusherConfig struct {
 	ConfigTags []string
 	// MetricsEndpoint is the metrics endpoint.
 	MetricsEndpoint string
-	// APIKey is the API key set in configuration.
-	APIKey string
+	// APIKey is the API key set in configuration, which may be rotated.
+	APIKey *utils.APIKey
 	// UseResourceMetadata is the value of 'use_resource_metadata' on the top-level configuration.
 	UseResourceMetadata bool
 	// InsecureSkipVerify is the value of `tls.insecure_skip_verify` on the configuration.
@@ -36,4 +41,8 @@ type PusherConfig struct {
 	TimeoutSettings exporterhelper.TimeoutSettings
 	// RetrySettings of exporter.
 	RetrySettings exporterhelper.RetrySettings
+	// RetryInterval is the interval between the attempts to send host metadata which could not be sent.
+	RetryInterval time.Duration
+	// Storage persists the host metadata which could not be sent across restarts (nil if unset).
+	Storage storage.Client
 }
@@ -179,7 +179,7 @@ func pushMetadata(pcfg PusherConfig, params component.ExporterCreateSettings, me
 	path := pcfg.MetricsEndpoint + "/intake"
 	buf, _ := json.Marshal(metadata)
 	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(buf))
-	utils.SetDDHeaders(req.Header, params.BuildInfo, pcfg.APIKey)
+	utils.SetDDHeaders(req.Header, params.BuildInfo, pcfg.APIKey.Get())
 	utils.SetExtraHeaders(req.Header, utils.JSONHeaders)
 	client := utils.NewHTTPClient(pcfg.TimeoutSettings, pcfg.InsecureSkipVerify)
 	resp, err := client.Do(req)
@@ -201,10 +201,10 @@ func pushMetadata(pcfg PusherConfig, params component.ExporterCreateSettings, me
 	return nil
 }
 
-func pushMetadataWithRetry(retrier *utils.Retrier, params component.ExporterCreateSettings, pcfg PusherConfig, hostMetadata *HostMetadata) {
+func pushMetadataWithRetry(ctx context.Context, retrier *utils.Retrier, params component.ExporterCreateSettings, pcfg PusherConfig, hostMetadata *HostMetadata) error {
 	params.Logger.Debug("Sending host metadata payload", zap.Any("payload", hostMetadata))
 
-	err := retrier.DoWithRetries(context.Background(), func(context.Context) error {
+	err := retrier.DoWithRetries(ctx, func(context.Context) error {
 		return pushMetadata(pcfg, params, hostMetadata)
 	})
 
@@ -213,14 +213,23 @@ func pushMetadataWithRetry(retrier *utils.Retrier, params component.ExporterCrea
 	} else {
 		params.Logger.Info("Sent host metadata")
 	}
+	return err
+}
 
+// pushPendingMetadata sends the pending host metadata payloads in order and
+// returns the ones which could not be sent.
+func pushPendingMetadata(ctx context.Context, retrier *utils.Retrier, params component.ExporterCreateSettings, pcfg PusherConfig, pending []*HostMetadata) []*HostMetadata {
+	var failed []*HostMetadata
+	for _, hostMetadata := range pending {
+		if err := pushMetadataWithRetry(ctx, retrier, params, pcfg, hostMetadata); err != nil {
+			failed = append(failed, hostMetadata)
+		}
+	}
+	return failed
 }
 
 // Pusher pushes host metadata payloads periodically to Datadog intake
 func Pusher(ctx context.Context, params component.ExporterCreateSettings, pcfg PusherConfig, p source.Provider, attrs pcommon.Map) {
-	// Push metadata every 30 minutes
-	ticker := time.NewTicker(30 * time.Minute)
-	defer ticker.Stop()
 	defer params.Logger.Debug("Shut down host metadata routine")
 	retrier := utils.NewRetrier(params.Logger, pcfg.RetrySettings, scrub.NewScrubber())
 
@@ -237,15 +246,45 @@ func Pusher(ctx context.Context, params component.ExporterCreateSettings, pcfg P
 	}
 	fillHostMetadata(params, pcfg, p, hostMetadata)
 
-	// Run one first time at startup
-	pushMetadataWithRetry(retrier, params, pcfg, hostMetadata)
+	// The payloads which could not be sent before a restart are sent first,
+	// unless they are superseded by the current one.
+	var pending []*HostMetadata
+	if pcfg.Storage != nil {
+		stored, err := loadPendingMetadata(ctx, pcfg.Storage)
+		if err != nil {
+			params.Logger.Warn("Failed to load pending host metadata", zap.Error(err))
+		}
+		for _, storedMetadata := range stored {
+			if storedMetadata.Meta != nil && storedMetadata.Meta.Hostname != hostMetadata.Meta.Hostname {
+				pending = append(pending, storedMetadata)
+			}
+		}
+	}
+	pending = append(pending, hostMetadata)
 
 	for {
+		// Run one first time at startup, then every 30 minutes, or every
+		// retry interval until all the pending payloads are sent.
+		pending = pushPendingMetadata(ctx, retrier, params, pcfg, pending)
+		interval := 30 * time.Minute
+		if len(pending) > 0 {
+			interval = pcfg.RetryInterval
+		}
+		if pcfg.Storage != nil {
+			if err := savePendingMetadata(ctx, pcfg.Storage, pending); err != nil {
+				params.Logger.Warn("Failed to save pending host metadata", zap.Error(err))
+			}
+		}
+
+		timer := time.NewTimer(interval)
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return
-		case <-ticker.C: // Send host metadata
-			pushMetadataWithRetry(retrier, params, pcfg, hostMetadata)
+		case <-timer.C:
+		}
+		if len(pending) == 0 {
+			pending = []*HostMetadata{hostMetadata}
 		}
 	}
 }
@@ -21,7 +21,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/DataDog/datadog-agent/pkg/otlp/model/attributes"
 	"github.com/DataDog/datadog-agent/pkg/otlp/model/attributes/azure"
@@ -29,11 +32,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/testutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils/cache"
 )
 
@@ -256,7 +261,7 @@ func TestMetadataFromAttributes(t *testing.T) {
 
 func TestPushMetadata(t *testing.T) {
 	pcfg := PusherConfig{
-		APIKey: "apikey",
+		APIKey: utils.NewAPIKey("apikey"),
 	}
 
 	handler := http.NewServeMux()
@@ -283,7 +288,7 @@ func TestPushMetadata(t *testing.T) {
 
 func TestFailPushMetadata(t *testing.T) {
 	pcfg := PusherConfig{
-		APIKey: "apikey",
+		APIKey: utils.NewAPIKey("apikey"),
 	}
 	handler := http.NewServeMux()
 	handler.Handle("/intake", http.NotFoundHandler())
@@ -298,7 +303,7 @@ func TestFailPushMetadata(t *testing.T) {
 
 func TestPusher(t *testing.T) {
 	pcfg := PusherConfig{
-		APIKey:              "apikey",
+		APIKey:              utils.NewAPIKey("apikey"),
 		UseResourceMetadata: true,
 	}
 	params := componenttest.NewNopExporterCreateSettings()
@@ -331,3 +336,152 @@ func TestPusher(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, recvMetadata.Meta.SocketHostname, hostname)
 }
+
+// memoryStorage is a storage.Client keeping the data in memory.
+type memoryStorage struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+var _ storage.Client = (*memoryStorage)(nil)
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{data: map[string][]byte{}}
+}
+
+func (s *memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data[key], nil
+}
+
+func (s *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+	return nil
+}
+
+func (s *memoryStorage) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+	return nil
+}
+
+func (s *memoryStorage) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value, _ = s.Get(ctx, op.Key)
+		case storage.Set:
+			_ = s.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			_ = s.Delete(ctx, op.Key)
+		}
+	}
+	return nil
+}
+
+func (s *memoryStorage) Close(context.Context) error {
+	return nil
+}
+
+func TestPusherRetry(t *testing.T) {
+	store := newMemoryStorage()
+	pcfg := PusherConfig{
+		APIKey:              utils.NewAPIKey("apikey"),
+		UseResourceMetadata: true,
+		RetryInterval:       10 * time.Millisecond,
+		Storage:             store,
+	}
+	params := componenttest.NewNopExporterCreateSettings()
+	params.BuildInfo = mockBuildInfo
+
+	hostProvider, err := GetSourceProvider(componenttest.NewNopTelemetrySettings(), "")
+	require.NoError(t, err)
+
+	attrs := testutils.NewAttributeMap(map[string]string{
+		attributes.AttributeDatadogHostname: "datadog-hostname",
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var available int32
+	received := make(chan []byte, 1)
+	handler := http.NewServeMux()
+	handler.HandleFunc("/intake", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&available) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		received <- body
+	})
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+	pcfg.MetricsEndpoint = ts.URL
+
+	go Pusher(ctx, params, pcfg, hostProvider, attrs)
+
+	// The payload which could not be sent is saved until it is sent.
+	assert.Eventually(t, func() bool {
+		data, _ := store.Get(ctx, pendingMetadataKey)
+		return data != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	pending, err := loadPendingMetadata(ctx, store)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "datadog-hostname", pending[0].InternalHostname)
+
+	atomic.StoreInt32(&available, 1)
+	var recvMetadata HostMetadata
+	require.NoError(t, json.Unmarshal(<-received, &recvMetadata))
+	assert.Equal(t, "datadog-hostname", recvMetadata.InternalHostname)
+
+	assert.Eventually(t, func() bool {
+		data, _ := store.Get(ctx, pendingMetadataKey)
+		return data == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestPusherPendingMetadata(t *testing.T) {
+	store := newMemoryStorage()
+	require.NoError(t, savePendingMetadata(context.Background(), store, []*HostMetadata{&mockMetadata}))
+	pcfg := PusherConfig{
+		APIKey:              utils.NewAPIKey("apikey"),
+		UseResourceMetadata: true,
+		RetryInterval:       time.Minute,
+		Storage:             store,
+	}
+	params := componenttest.NewNopExporterCreateSettings()
+	params.BuildInfo = mockBuildInfo
+
+	hostProvider, err := GetSourceProvider(componenttest.NewNopTelemetrySettings(), "")
+	require.NoError(t, err)
+
+	attrs := testutils.NewAttributeMap(map[string]string{
+		attributes.AttributeDatadogHostname: "datadog-hostname",
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := testutils.DatadogServerMock()
+	defer server.Close()
+	pcfg.MetricsEndpoint = server.URL
+
+	go Pusher(ctx, params, pcfg, hostProvider, attrs)
+
+	// The payload saved before the restart is sent first.
+	var recvMetadata HostMetadata
+	require.NoError(t, json.Unmarshal(<-server.MetadataChan, &recvMetadata))
+	assert.Equal(t, "hostname", recvMetadata.InternalHostname)
+	require.NoError(t, json.Unmarshal(<-server.MetadataChan, &recvMetadata))
+	assert.Equal(t, "datadog-hostname", recvMetadata.InternalHostname)
+
+	assert.Eventually(t, func() bool {
+		data, _ := store.Get(ctx, pendingMetadataKey)
+		return data == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
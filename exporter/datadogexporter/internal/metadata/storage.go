@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+// pendingMetadataKey is the storage key of the host metadata payloads which could not be sent.
+const pendingMetadataKey = "pending_host_metadata"
+
+// loadPendingMetadata returns the payloads saved by savePendingMetadata, or nil
+// when none were saved.
+func loadPendingMetadata(ctx context.Context, client storage.Client) ([]*HostMetadata, error) {
+	data, err := client.Get(ctx, pendingMetadataKey)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var pending []*HostMetadata
+	if err = json.Unmarshal(data, &pending); err != nil {
+		return nil, fmt.Errorf("invalid pending host metadata: %w", err)
+	}
+	return pending, nil
+}
+
+// savePendingMetadata saves the payloads which could not be sent, or deletes
+// the saved ones when all of them were sent.
+func savePendingMetadata(ctx context.Context, client storage.Client, pending []*HostMetadata) error {
+	if len(pending) == 0 {
+		return client.Delete(ctx, pendingMetadataKey)
+	}
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	return client.Set(ctx, pendingMetadataKey, data)
+}
@@ -19,8 +19,8 @@ import (
 
 	"github.com/DataDog/datadog-agent/pkg/otlp/model/translator"
 	"github.com/DataDog/datadog-agent/pkg/quantile"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"go.opentelemetry.io/collector/component"
-	"gopkg.in/zorkian/go-datadog-api.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/sketches"
 )
@@ -31,13 +31,13 @@ var _ translator.TagsConsumer = (*Consumer)(nil)
 
 // Consumer is the metrics Consumer.
 type Consumer struct {
-	ms        []datadog.Metric
+	ms        []datadogV2.MetricSeries
 	sl        sketches.SketchSeriesList
 	seenHosts map[string]struct{}
 	seenTags  map[string]struct{}
 }
 
-// NewConsumer creates a new zorkian consumer.
+// NewConsumer creates a new metrics consumer.
 func NewConsumer() *Consumer {
 	return &Consumer{
 		seenHosts: make(map[string]struct{}),
@@ -45,9 +45,9 @@ func NewConsumer() *Consumer {
 	}
 }
 
-// toDataType maps translator datatypes to zorkian's datatypes.
+// toDataType maps translator datatypes to the Datadog API datatypes.
 func (c *Consumer) toDataType(dt translator.MetricDataType) (out MetricType) {
-	out = MetricType("unknown")
+	out = Unspecified
 
 	switch dt {
 	case translator.Count:
@@ -60,7 +60,7 @@ func (c *Consumer) toDataType(dt translator.MetricDataType) (out MetricType) {
 }
 
 // runningMetrics gets the running metrics for the exporter.
-func (c *Consumer) runningMetrics(timestamp uint64, buildInfo component.BuildInfo) (series []datadog.Metric) {
+func (c *Consumer) runningMetrics(timestamp uint64, buildInfo component.BuildInfo) (series []datadogV2.MetricSeries) {
 	for host := range c.seenHosts {
 		// Report the host as running
 		runningMetric := DefaultMetrics("metrics", host, timestamp, buildInfo)
@@ -79,7 +79,7 @@ func (c *Consumer) runningMetrics(timestamp uint64, buildInfo component.BuildInf
 }
 
 // All gets all metrics (consumed metrics and running metrics).
-func (c *Consumer) All(timestamp uint64, buildInfo component.BuildInfo, tags []string) ([]datadog.Metric, sketches.SketchSeriesList) {
+func (c *Consumer) All(timestamp uint64, buildInfo component.BuildInfo, tags []string) ([]datadogV2.MetricSeries, sketches.SketchSeriesList) {
 	series := c.ms
 	series = append(series, c.runningMetrics(timestamp, buildInfo)...)
 	if len(tags) == 0 {
@@ -104,7 +104,7 @@ func (c *Consumer) ConsumeTimeSeries(
 ) {
 	dt := c.toDataType(typ)
 	met := NewMetric(dims.Name(), dt, timestamp, value, dims.Tags())
-	met.SetHost(dims.Host())
+	SetHost(&met, dims.Host())
 	c.ms = append(c.ms, met)
 }
 
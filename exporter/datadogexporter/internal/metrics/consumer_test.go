@@ -74,8 +74,8 @@ func TestRunningMetrics(t *testing.T) {
 
 	var runningHostnames []string
 	for _, metric := range consumer.runningMetrics(0, component.BuildInfo{}) {
-		if metric.Host != nil {
-			runningHostnames = append(runningHostnames, *metric.Host)
+		if host := Host(metric); host != "" {
+			runningHostnames = append(runningHostnames, host)
 		}
 	}
 
@@ -121,12 +121,13 @@ func TestTagsMetrics(t *testing.T) {
 	var runningHostnames []string
 	for _, metric := range runningMetrics {
 		runningTags = append(runningTags, metric.Tags...)
-		if metric.Host != nil {
-			runningHostnames = append(runningHostnames, *metric.Host)
+		if host := Host(metric); host != "" {
+			runningHostnames = append(runningHostnames, host)
 		}
 	}
 
-	assert.ElementsMatch(t, runningHostnames, []string{"", "", ""})
+	// the running metrics of the tags have no host
+	assert.Empty(t, runningHostnames)
 	assert.Len(t, runningMetrics, 3)
 	assert.ElementsMatch(t, runningTags, []string{"task_arn:task-arn-1", "task_arn:task-arn-2", "task_arn:task-arn-3"})
 }
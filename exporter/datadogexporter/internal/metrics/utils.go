@@ -17,56 +17,88 @@ package metrics // import "github.com/open-telemetry/opentelemetry-collector-con
 import (
 	"fmt"
 
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"go.opentelemetry.io/collector/component"
-	"gopkg.in/zorkian/go-datadog-api.v2"
 )
 
-type MetricType string
+type MetricType = datadogV2.MetricIntakeType
 
 const (
 	// Gauge is the Datadog Gauge metric type
-	Gauge MetricType = "gauge"
+	Gauge MetricType = datadogV2.METRICINTAKETYPE_GAUGE
 	// Count is the Datadog Count metric type
-	Count MetricType = "count"
+	Count MetricType = datadogV2.METRICINTAKETYPE_COUNT
+	// Unspecified is the Datadog metric type of the metrics of unknown type
+	Unspecified MetricType = datadogV2.METRICINTAKETYPE_UNSPECIFIED
 )
 
+// hostResourceType is the type of the resource holding the host of a series
+const hostResourceType = "host"
+
 // newMetric creates a new Datadog metric given a name, a Unix nanoseconds timestamp
 // a value and a slice of tags
-func newMetric(name string, ts uint64, value float64, tags []string) datadog.Metric {
+func newMetric(name string, ts uint64, value float64, tags []string) datadogV2.MetricSeries {
 	// Transform UnixNano timestamp into Unix timestamp
 	// 1 second = 1e9 ns
-	timestamp := float64(ts / 1e9)
-
-	metric := datadog.Metric{
-		Points: []datadog.DataPoint{[2]*float64{&timestamp, &value}},
-		Tags:   tags,
+	timestamp := int64(ts / 1e9)
+
+	metric := datadogV2.MetricSeries{
+		Metric: name,
+		Points: []datadogV2.MetricPoint{
+			{
+				Timestamp: datadog.PtrInt64(timestamp),
+				Value:     datadog.PtrFloat64(value),
+			},
+		},
+		Tags: tags,
 	}
-	metric.SetMetric(name)
 	return metric
 }
 
 // NewMetric creates a new Datadog metric given a name, a type, a Unix nanoseconds timestamp
 // a value and a slice of tags
-func NewMetric(name string, dt MetricType, ts uint64, value float64, tags []string) datadog.Metric {
+func NewMetric(name string, dt MetricType, ts uint64, value float64, tags []string) datadogV2.MetricSeries {
 	metric := newMetric(name, ts, value, tags)
-	metric.SetType(string(dt))
+	metric.SetType(dt)
 	return metric
 }
 
 // NewGauge creates a new Datadog Gauge metric given a name, a Unix nanoseconds timestamp
 // a value and a slice of tags
-func NewGauge(name string, ts uint64, value float64, tags []string) datadog.Metric {
+func NewGauge(name string, ts uint64, value float64, tags []string) datadogV2.MetricSeries {
 	return NewMetric(name, Gauge, ts, value, tags)
 }
 
 // NewCount creates a new Datadog count metric given a name, a Unix nanoseconds timestamp
 // a value and a slice of tags
-func NewCount(name string, ts uint64, value float64, tags []string) datadog.Metric {
+func NewCount(name string, ts uint64, value float64, tags []string) datadogV2.MetricSeries {
 	return NewMetric(name, Count, ts, value, tags)
 }
 
+// SetHost sets the host of a Datadog metric, if any
+func SetHost(metric *datadogV2.MetricSeries, host string) {
+	if host == "" {
+		return
+	}
+	metric.Resources = append(metric.Resources, datadogV2.MetricResource{
+		Name: datadog.PtrString(host),
+		Type: datadog.PtrString(hostResourceType),
+	})
+}
+
+// Host returns the host of a Datadog metric, empty if unset
+func Host(metric datadogV2.MetricSeries) string {
+	for _, resource := range metric.Resources {
+		if resource.GetType() == hostResourceType {
+			return resource.GetName()
+		}
+	}
+	return ""
+}
+
 // DefaultMetrics creates built-in metrics to report that an exporter is running
-func DefaultMetrics(exporterType string, hostname string, timestamp uint64, buildInfo component.BuildInfo) []datadog.Metric {
+func DefaultMetrics(exporterType string, hostname string, timestamp uint64, buildInfo component.BuildInfo) []datadogV2.MetricSeries {
 	var tags []string
 
 	if buildInfo.Version != "" {
@@ -77,12 +109,12 @@ func DefaultMetrics(exporterType string, hostname string, timestamp uint64, buil
 		tags = append(tags, "command:"+buildInfo.Command)
 	}
 
-	metrics := []datadog.Metric{
+	metrics := []datadogV2.MetricSeries{
 		NewGauge(fmt.Sprintf("otel.datadog_exporter.%s.running", exporterType), timestamp, 1.0, tags),
 	}
 
 	for i := range metrics {
-		metrics[i].SetHost(hostname)
+		SetHost(&metrics[i], hostname)
 	}
 
 	return metrics
@@ -29,11 +29,11 @@ func TestNewMetric(t *testing.T) {
 
 	metric := newMetric(name, ts, value, tags)
 
-	assert.Equal(t, "test.metric", *metric.Metric)
-	// Assert timestamp conversion from uint64 ns to float64 s
-	assert.Equal(t, 1.0, *metric.Points[0][0])
+	assert.Equal(t, "test.metric", metric.Metric)
+	// Assert timestamp conversion from uint64 ns to int64 s
+	assert.Equal(t, int64(1), *metric.Points[0].Timestamp)
 	// Assert value
-	assert.Equal(t, 2.0, *metric.Points[0][1])
+	assert.Equal(t, 2.0, *metric.Points[0].Value)
 	// Assert tags
 	assert.Equal(t, []string{"tag:value"}, metric.Tags)
 }
@@ -45,13 +45,25 @@ func TestNewType(t *testing.T) {
 	tags := []string{"tag:value"}
 
 	gauge := NewGauge(name, ts, value, tags)
-	assert.Equal(t, gauge.GetType(), string(Gauge))
+	assert.Equal(t, gauge.GetType(), Gauge)
 
 	count := NewCount(name, ts, value, tags)
-	assert.Equal(t, count.GetType(), string(Count))
+	assert.Equal(t, count.GetType(), Count)
 
 }
 
+func TestSetHost(t *testing.T) {
+	metric := NewGauge("test.metric", uint64(1e9), 2.0, nil)
+	SetHost(&metric, "")
+	assert.Empty(t, metric.Resources)
+	assert.Equal(t, "", Host(metric))
+
+	SetHost(&metric, "test-host")
+	assert.Len(t, metric.Resources, 1)
+	assert.Equal(t, "host", metric.Resources[0].GetType())
+	assert.Equal(t, "test-host", Host(metric))
+}
+
 func TestDefaultMetrics(t *testing.T) {
 	buildInfo := component.BuildInfo{
 		Version: "1.0",
@@ -60,15 +72,15 @@ func TestDefaultMetrics(t *testing.T) {
 
 	ms := DefaultMetrics("metrics", "test-host", uint64(2e9), buildInfo)
 
-	assert.Equal(t, "otel.datadog_exporter.metrics.running", *ms[0].Metric)
+	assert.Equal(t, "otel.datadog_exporter.metrics.running", ms[0].Metric)
 	// Assert metrics list length (should be 1)
 	assert.Equal(t, 1, len(ms))
 	// Assert timestamp
-	assert.Equal(t, 2.0, *ms[0].Points[0][0])
+	assert.Equal(t, int64(2), *ms[0].Points[0].Timestamp)
 	// Assert value (should always be 1.0)
-	assert.Equal(t, 1.0, *ms[0].Points[0][1])
+	assert.Equal(t, 1.0, *ms[0].Points[0].Value)
 	// Assert hostname tag is set
-	assert.Equal(t, "test-host", *ms[0].Host)
+	assert.Equal(t, "test-host", Host(ms[0]))
 	// Assert no other tags are set
 	assert.ElementsMatch(t, []string{"version:1.0", "command:otelcontribcol"}, ms[0].Tags)
 }
@@ -48,7 +48,7 @@ func DatadogServerMock(overwriteHandlerFuncs ...OverwriteHandleFunc) *DatadogSer
 
 	handlers := map[string]http.HandlerFunc{
 		"/api/v1/validate": validateAPIKeyEndpoint,
-		"/api/v1/series":   metricsEndpoint,
+		"/api/v2/series":   metricsEndpoint,
 		"/intake":          newMetadataEndpoint(metadataChan),
 		"/":                func(w http.ResponseWriter, r *http.Request) {},
 	}
@@ -15,27 +15,50 @@
 package utils // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
 
 import (
+	"context"
 	"errors"
 
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadog"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV1"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.uber.org/zap"
-	"gopkg.in/zorkian/go-datadog-api.v2"
 )
 
-// CreateClient creates a new Datadog client
-func CreateClient(apiKey string, endpoint string) *datadog.Client {
-	client := datadog.NewClient(apiKey, "")
-	client.SetBaseUrl(endpoint)
+// CreateAPIClient creates a new Datadog API client sending the requests to endpoint
+func CreateAPIClient(buildInfo component.BuildInfo, endpoint string, settings exporterhelper.TimeoutSettings, insecureSkipVerify bool) *datadog.APIClient {
+	cfg := datadog.NewConfiguration()
+	cfg.UserAgent = UserAgent(buildInfo)
+	cfg.HTTPClient = NewHTTPClient(settings, insecureSkipVerify)
+	cfg.Servers = datadog.ServerConfigurations{
+		datadog.ServerConfiguration{
+			URL: endpoint,
+		},
+	}
+	return datadog.NewAPIClient(cfg)
+}
 
-	return client
+// GetRequestContext returns the context of the requests authenticated with apiKey
+func GetRequestContext(ctx context.Context, apiKey string) context.Context {
+	return context.WithValue(
+		ctx,
+		datadog.ContextAPIKeys,
+		map[string]datadog.APIKey{
+			"apiKeyAuth": {
+				Key: apiKey,
+			},
+		},
+	)
 }
 
 var ErrInvalidAPI = errors.New("API Key validation failed")
 
 // ValidateAPIKey checks that the provided client was given a correct API key.
-func ValidateAPIKey(logger *zap.Logger, client *datadog.Client) error {
+func ValidateAPIKey(ctx context.Context, apiKey string, logger *zap.Logger, apiClient *datadog.APIClient) error {
 	logger.Info("Validating API key.")
-	valid, err := client.Validate()
-	if err == nil && valid {
+	authAPI := datadogV1.NewAuthenticationApi(apiClient)
+	res, _, err := authAPI.Validate(GetRequestContext(ctx, apiKey))
+	if err == nil && res.GetValid() {
 		logger.Info("API key validation successful.")
 		return nil
 	}
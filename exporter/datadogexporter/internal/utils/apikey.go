@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// APIKey holds a Datadog API key. A key read from a file is reloaded by Watch when
+// the file changes, so that the key can be rotated without restarting the collector.
+type APIKey struct {
+	path string
+
+	mu        sync.RWMutex
+	key       string
+	rejected  string // rejected is the last key of the file which failed validation
+	listeners []func(key string)
+}
+
+// NewAPIKey returns a static API key.
+func NewAPIKey(key string) *APIKey {
+	return &APIKey{key: key}
+}
+
+// NewAPIKeyFromFile returns the API key read from the file at path.
+func NewAPIKeyFromFile(path string) (*APIKey, error) {
+	key, err := readAPIKey(path)
+	if err != nil {
+		return nil, err
+	}
+	return &APIKey{path: path, key: key}, nil
+}
+
+func readAPIKey(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key file: %w", err)
+	}
+	key := strings.TrimSpace(string(b))
+	if key == "" {
+		return "", fmt.Errorf("API key file %q is empty", path)
+	}
+	return key, nil
+}
+
+// Get returns the current API key.
+func (k *APIKey) Get() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.key
+}
+
+// OnChange registers f to be called with the new key after each rotation.
+func (k *APIKey) OnChange(f func(key string)) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.listeners = append(k.listeners, f)
+}
+
+// Watch polls the file of the key every interval until ctx is cancelled. A changed key
+// replaces the current one once validate accepts it; a rejected key is not retried
+// until the file changes again. Watch returns immediately for static keys.
+func (k *APIKey) Watch(ctx context.Context, interval time.Duration, logger *zap.Logger, validate func(context.Context, string) error) {
+	if k.path == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			k.reload(ctx, logger, validate)
+		}
+	}
+}
+
+func (k *APIKey) reload(ctx context.Context, logger *zap.Logger, validate func(context.Context, string) error) {
+	key, err := readAPIKey(k.path)
+	if err != nil {
+		logger.Warn("Failed to reload API key, keeping the current key", zap.Error(err))
+		return
+	}
+
+	k.mu.RLock()
+	unchanged := key == k.key || key == k.rejected
+	k.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	if err = validate(ctx, key); err != nil {
+		logger.Error("The rotated API key is invalid, keeping the current key", zap.Error(err))
+		k.mu.Lock()
+		k.rejected = key
+		k.mu.Unlock()
+		return
+	}
+
+	k.mu.Lock()
+	k.key = key
+	k.rejected = ""
+	listeners := k.listeners
+	k.mu.Unlock()
+
+	logger.Info("API key reloaded", zap.String("path", k.path))
+	for _, f := range listeners {
+		f(key)
+	}
+}
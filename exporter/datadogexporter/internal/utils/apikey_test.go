@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewAPIKeyFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_key")
+
+	_, err := NewAPIKeyFromFile(path)
+	assert.ErrorContains(t, err, "failed to read API key file")
+
+	require.NoError(t, os.WriteFile(path, []byte("  \n"), 0600))
+	_, err = NewAPIKeyFromFile(path)
+	assert.EqualError(t, err, "API key file \""+path+"\" is empty")
+
+	require.NoError(t, os.WriteFile(path, []byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\n"), 0600))
+	key, err := NewAPIKeyFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", key.Get())
+}
+
+func TestAPIKeyReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_key")
+	require.NoError(t, os.WriteFile(path, []byte("key1"), 0600))
+	key, err := NewAPIKeyFromFile(path)
+	require.NoError(t, err)
+
+	var changes []string
+	key.OnChange(func(k string) { changes = append(changes, k) })
+	var validated []string
+	validate := func(_ context.Context, k string) error {
+		validated = append(validated, k)
+		if k == "invalid" {
+			return ErrInvalidAPI
+		}
+		return nil
+	}
+	ctx := context.Background()
+
+	// unchanged file
+	key.reload(ctx, zap.NewNop(), validate)
+	assert.Equal(t, "key1", key.Get())
+	assert.Empty(t, validated)
+
+	// rotated key
+	require.NoError(t, os.WriteFile(path, []byte("key2"), 0600))
+	key.reload(ctx, zap.NewNop(), validate)
+	assert.Equal(t, "key2", key.Get())
+	assert.Equal(t, []string{"key2"}, changes)
+
+	// invalid key, which is only validated once
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0600))
+	key.reload(ctx, zap.NewNop(), validate)
+	key.reload(ctx, zap.NewNop(), validate)
+	assert.Equal(t, "key2", key.Get())
+	assert.Equal(t, []string{"key2", "invalid"}, validated)
+
+	// missing file
+	require.NoError(t, os.Remove(path))
+	key.reload(ctx, zap.NewNop(), validate)
+	assert.Equal(t, "key2", key.Get())
+	assert.Equal(t, []string{"key2"}, changes)
+}
+
+func TestAPIKeyWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_key")
+	require.NoError(t, os.WriteFile(path, []byte("key1"), 0600))
+	key, err := NewAPIKeyFromFile(path)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		key.Watch(ctx, time.Millisecond, zap.NewNop(), func(context.Context, string) error { return nil })
+	}()
+
+	require.NoError(t, os.WriteFile(path, []byte("key2"), 0600))
+	assert.Eventually(t, func() bool { return key.Get() == "key2" }, 5*time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	// static keys are not watched
+	NewAPIKey("key").Watch(context.Background(), time.Millisecond, zap.NewNop(), nil)
+}
@@ -24,9 +24,9 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/logs"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/scrub"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
 )
@@ -36,32 +36,46 @@ type logsExporter struct {
 	cfg            *Config
 	ctx            context.Context // ctx triggers shutdown upon cancellation
 	scrubber       scrub.Scrubber  // scrubber scrubs sensitive information from error messages
+	apiKey         *utils.APIKey
 	sender         *logs.Sender
+	failoverSender *logs.Sender // failoverSender sends the logs to the failover site when the site fails; nil when disabled
 	onceMetadata   *sync.Once
+	metaStorage    *metadataStorage // metaStorage persists the host metadata which could not be sent
 	sourceProvider source.Provider
 }
 
 // newLogsExporter creates a new instance of logsExporter
-func newLogsExporter(ctx context.Context, params component.ExporterCreateSettings, cfg *Config, onceMetadata *sync.Once, sourceProvider source.Provider) (*logsExporter, error) {
+func newLogsExporter(ctx context.Context, params component.ExporterCreateSettings, cfg *Config, apiKey *utils.APIKey, onceMetadata *sync.Once, metadataStorage *metadataStorage, sourceProvider source.Provider) (*logsExporter, error) {
 	// create Datadog client
 	// validation endpoint is provided by Metrics
-	client := utils.CreateClient(cfg.API.Key, cfg.Metrics.TCPAddr.Endpoint)
+	client := utils.CreateAPIClient(
+		params.BuildInfo,
+		cfg.Metrics.TCPAddr.Endpoint,
+		cfg.TimeoutSettings,
+		cfg.LimitedHTTPClientSettings.TLSSetting.InsecureSkipVerify,
+	)
 	// validate the apiKey
-	if err := utils.ValidateAPIKey(params.Logger, client); err != nil && cfg.API.FailOnInvalidKey {
+	if err := utils.ValidateAPIKey(ctx, apiKey.Get(), params.Logger, client); err != nil && cfg.API.FailOnInvalidKey {
 		return nil, err
 	}
 
-	s := logs.NewSender(cfg.Logs.TCPAddr.Endpoint, params.Logger, cfg.TimeoutSettings, cfg.LimitedHTTPClientSettings.TLSSetting.InsecureSkipVerify, cfg.API.Key)
+	s := logs.NewSender(cfg.Logs.TCPAddr.Endpoint, params.Logger, cfg.TimeoutSettings, cfg.LimitedHTTPClientSettings.TLSSetting.InsecureSkipVerify, apiKey)
 
-	return &logsExporter{
+	exp := &logsExporter{
 		params:         params,
 		cfg:            cfg,
 		ctx:            ctx,
+		apiKey:         apiKey,
 		sender:         s,
 		onceMetadata:   onceMetadata,
+		metaStorage:    metadataStorage,
 		scrubber:       scrub.NewScrubber(),
 		sourceProvider: sourceProvider,
-	}, nil
+	}
+	if endpoints, key, ok := cfg.failover(apiKey); ok {
+		exp.failoverSender = logs.NewSender(endpoints.logs, params.Logger, cfg.TimeoutSettings, cfg.LimitedHTTPClientSettings.TLSSetting.InsecureSkipVerify, key)
+	}
+	return exp, nil
 }
 
 var _ consumer.ConsumeLogsFunc = (*logsExporter)(nil).consumeLogs
@@ -77,7 +91,7 @@ func (exp *logsExporter) consumeLogs(ctx context.Context, ld plog.Logs) (err err
 			if ld.ResourceLogs().Len() > 0 {
 				attrs = ld.ResourceLogs().At(0).Resource().Attributes()
 			}
-			go metadata.Pusher(exp.ctx, exp.params, newMetadataConfigfromConfig(exp.cfg), exp.sourceProvider, attrs)
+			exp.metaStorage.goPusher(exp.ctx, exp.params, newMetadataConfigfromConfig(exp.cfg, exp.apiKey, exp.metaStorage), exp.sourceProvider, attrs)
 		})
 	}
 
@@ -98,5 +112,10 @@ func (exp *logsExporter) consumeLogs(ctx context.Context, ld plog.Logs) (err err
 			}
 		}
 	}
-	return exp.sender.SubmitLogs(exp.ctx, payload)
+	err = exp.sender.SubmitLogs(exp.ctx, payload)
+	if err != nil && exp.failoverSender != nil {
+		exp.params.Logger.Warn("Failed to send logs, sending them to the failover site", zap.Error(exp.scrubber.Scrub(err)))
+		return exp.failoverSender.SubmitLogs(exp.ctx, payload)
+	}
+	return err
 }
@@ -18,6 +18,8 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -27,7 +29,9 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/pdata/plog"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/testutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
 )
 
@@ -133,3 +137,36 @@ func traceIDToUint64(b [16]byte) uint64 {
 func spanIDToUint64(b [8]byte) uint64 {
 	return binary.BigEndian.Uint64(b[:])
 }
+
+func TestLogsExporterFailover(t *testing.T) {
+	failing := func() (string, http.HandlerFunc) {
+		return "/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+	server := testutils.DatadogLogServerMock(failing)
+	defer server.Close()
+	failoverServer := testutils.DatadogLogServerMock()
+	defer failoverServer.Close()
+
+	cfg := &Config{
+		Metrics: MetricsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: server.URL,
+			},
+		},
+		Logs: LogsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: server.URL,
+			},
+		},
+	}
+	params := componenttest.NewNopExporterCreateSettings()
+	ctx := context.Background()
+	exp, err := newLogsExporter(ctx, params, cfg, utils.NewAPIKey(""), &sync.Once{}, nil, nil)
+	require.NoError(t, err)
+	exp.failoverSender = logs.NewSender(failoverServer.URL, params.Logger, cfg.TimeoutSettings, false, utils.NewAPIKey("failover_key"))
+
+	require.NoError(t, exp.consumeLogs(ctx, testdata.GenerateLogsOneLogRecord()))
+	assert.Len(t, failoverServer.LogsData, 1)
+}
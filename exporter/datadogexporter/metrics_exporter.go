@@ -24,13 +24,13 @@ import (
 
 	"github.com/DataDog/datadog-agent/pkg/otlp/model/source"
 	"github.com/DataDog/datadog-agent/pkg/otlp/model/translator"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
-	"gopkg.in/zorkian/go-datadog-api.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metrics"
@@ -43,12 +43,19 @@ type metricsExporter struct {
 	params         component.ExporterCreateSettings
 	cfg            *Config
 	ctx            context.Context
-	client         *datadog.Client
+	apiKey         *utils.APIKey
+	metricsAPI     *datadogV2.MetricsApi
+	httpClient     *http.Client
 	tr             *translator.Translator
 	scrubber       scrub.Scrubber
 	retrier        *utils.Retrier
 	onceMetadata   *sync.Once
+	metaStorage    *metadataStorage // metaStorage persists the host metadata which could not be sent
 	sourceProvider source.Provider
+	// failoverMetricsAPI sends the metrics to the failover site when the site fails; nil when disabled
+	failoverMetricsAPI *datadogV2.MetricsApi
+	failoverEndpoint   string
+	failoverKey        *utils.APIKey
 	// getPushTime returns a Unix time in nanoseconds, representing the time pushing metrics.
 	// It will be overwritten in tests.
 	getPushTime func() uint64
@@ -96,12 +103,14 @@ func translatorFromConfig(logger *zap.Logger, cfg *Config, sourceProvider source
 	return translator.New(logger, options...)
 }
 
-func newMetricsExporter(ctx context.Context, params component.ExporterCreateSettings, cfg *Config, onceMetadata *sync.Once, sourceProvider source.Provider) (*metricsExporter, error) {
-	client := utils.CreateClient(cfg.API.Key, cfg.Metrics.TCPAddr.Endpoint)
-	client.ExtraHeader["User-Agent"] = utils.UserAgent(params.BuildInfo)
-	client.HttpClient = utils.NewHTTPClient(cfg.TimeoutSettings, cfg.LimitedHTTPClientSettings.TLSSetting.InsecureSkipVerify)
-
-	if err := utils.ValidateAPIKey(params.Logger, client); err != nil && cfg.API.FailOnInvalidKey {
+func newMetricsExporter(ctx context.Context, params component.ExporterCreateSettings, cfg *Config, apiKey *utils.APIKey, onceMetadata *sync.Once, metadataStorage *metadataStorage, sourceProvider source.Provider) (*metricsExporter, error) {
+	client := utils.CreateAPIClient(
+		params.BuildInfo,
+		cfg.Metrics.TCPAddr.Endpoint,
+		cfg.TimeoutSettings,
+		cfg.LimitedHTTPClientSettings.TLSSetting.InsecureSkipVerify,
+	)
+	if err := utils.ValidateAPIKey(ctx, apiKey.Get(), params.Logger, client); err != nil && cfg.API.FailOnInvalidKey {
 		return nil, err
 	}
 
@@ -111,18 +120,77 @@ func newMetricsExporter(ctx context.Context, params component.ExporterCreateSett
 	}
 
 	scrubber := scrub.NewScrubber()
-	return &metricsExporter{
+	exp := &metricsExporter{
 		params:         params,
 		cfg:            cfg,
 		ctx:            ctx,
-		client:         client,
+		apiKey:         apiKey,
+		metricsAPI:     datadogV2.NewMetricsApi(client),
+		httpClient:     client.GetConfig().HTTPClient,
 		tr:             tr,
 		scrubber:       scrubber,
 		retrier:        utils.NewRetrier(params.Logger, cfg.RetrySettings, scrubber),
 		onceMetadata:   onceMetadata,
+		metaStorage:    metadataStorage,
 		sourceProvider: sourceProvider,
 		getPushTime:    func() uint64 { return uint64(time.Now().UTC().UnixNano()) },
-	}, nil
+	}
+	if endpoints, key, ok := cfg.failover(apiKey); ok {
+		exp.setFailover(endpoints.metrics, key)
+	}
+	return exp, nil
+}
+
+// setFailover sets the intake the metrics are sent to when the intake of the site fails.
+func (exp *metricsExporter) setFailover(endpoint string, apiKey *utils.APIKey) {
+	client := utils.CreateAPIClient(
+		exp.params.BuildInfo,
+		endpoint,
+		exp.cfg.TimeoutSettings,
+		exp.cfg.LimitedHTTPClientSettings.TLSSetting.InsecureSkipVerify,
+	)
+	exp.failoverMetricsAPI = datadogV2.NewMetricsApi(client)
+	exp.failoverEndpoint = endpoint
+	exp.failoverKey = apiKey
+}
+
+func (exp *metricsExporter) postMetrics(ctx context.Context, ms []datadogV2.MetricSeries) error {
+	err := exp.submitMetrics(ctx, exp.metricsAPI, exp.apiKey.Get(), ms)
+	if err != nil && exp.failoverMetricsAPI != nil {
+		exp.params.Logger.Warn("Failed to send metrics, sending them to the failover site", zap.Error(exp.scrubber.Scrub(err)))
+		return exp.submitMetrics(ctx, exp.failoverMetricsAPI, exp.failoverKey.Get(), ms)
+	}
+	return err
+}
+
+// submitMetrics sends the series with api. The payloads rejected for being too large
+// are split in halves, which are sent separately.
+func (exp *metricsExporter) submitMetrics(ctx context.Context, api *datadogV2.MetricsApi, apiKey string, ms []datadogV2.MetricSeries) error {
+	var opts []datadogV2.SubmitMetricsOptionalParameters
+	if encoding, ok := contentEncodings[exp.cfg.Metrics.Compression]; ok {
+		opts = append(opts, *datadogV2.NewSubmitMetricsOptionalParameters().WithContentEncoding(encoding))
+	}
+	_, resp, err := api.SubmitMetrics(utils.GetRequestContext(ctx, apiKey), datadogV2.MetricPayload{Series: ms}, opts...)
+	if resp != nil && resp.StatusCode == http.StatusRequestEntityTooLarge && len(ms) > 1 {
+		exp.params.Logger.Debug("Metrics payload too large, splitting it", zap.Int("series", len(ms)))
+		half := len(ms) / 2
+		return multierr.Append(
+			exp.submitMetrics(ctx, api, apiKey, ms[:half]),
+			exp.submitMetrics(ctx, api, apiKey, ms[half:]),
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to submit metrics: %w", err)
+	}
+	return nil
+}
+
+// contentEncodings maps the compression modes to the encodings of the metrics payloads.
+// The payloads are not compressed with CompressionModeNone.
+var contentEncodings = map[CompressionMode]datadogV2.MetricContentEncoding{
+	CompressionModeGzip:    datadogV2.METRICCONTENTENCODING_GZIP,
+	CompressionModeDeflate: datadogV2.METRICCONTENTENCODING_DEFLATE,
+	CompressionModeZstd:    datadogV2.METRICCONTENTENCODING_ZSTD1,
 }
 
 func (exp *metricsExporter) pushSketches(ctx context.Context, sl sketches.SketchSeriesList) error {
@@ -131,18 +199,27 @@ func (exp *metricsExporter) pushSketches(ctx context.Context, sl sketches.Sketch
 		return fmt.Errorf("failed to marshal sketches: %w", err)
 	}
 
+	err = exp.pushSketchesPayload(ctx, exp.cfg.Metrics.TCPAddr.Endpoint, exp.apiKey.Get(), payload)
+	if err != nil && exp.failoverMetricsAPI != nil {
+		exp.params.Logger.Warn("Failed to send sketches, sending them to the failover site", zap.Error(exp.scrubber.Scrub(err)))
+		return exp.pushSketchesPayload(ctx, exp.failoverEndpoint, exp.failoverKey.Get(), payload)
+	}
+	return err
+}
+
+func (exp *metricsExporter) pushSketchesPayload(ctx context.Context, endpoint string, apiKey string, payload []byte) error {
 	req, err := http.NewRequestWithContext(ctx,
 		http.MethodPost,
-		exp.cfg.Metrics.TCPAddr.Endpoint+sketches.SketchSeriesEndpoint,
+		endpoint+sketches.SketchSeriesEndpoint,
 		bytes.NewBuffer(payload),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to build sketches HTTP request: %w", err)
 	}
 
-	utils.SetDDHeaders(req.Header, exp.params.BuildInfo, exp.cfg.API.Key)
+	utils.SetDDHeaders(req.Header, exp.params.BuildInfo, apiKey)
 	utils.SetExtraHeaders(req.Header, utils.ProtobufHeaders)
-	resp, err := exp.client.HttpClient.Do(req)
+	resp, err := exp.httpClient.Do(req)
 
 	if err != nil {
 		return fmt.Errorf("failed to do sketches HTTP request: %w", err)
@@ -168,7 +245,7 @@ func (exp *metricsExporter) PushMetricsData(ctx context.Context, md pmetric.Metr
 			if md.ResourceMetrics().Len() > 0 {
 				attrs = md.ResourceMetrics().At(0).Resource().Attributes()
 			}
-			go metadata.Pusher(exp.ctx, exp.params, newMetadataConfigfromConfig(exp.cfg), exp.sourceProvider, attrs)
+			exp.metaStorage.goPusher(exp.ctx, exp.params, newMetadataConfigfromConfig(exp.cfg, exp.apiKey, exp.metaStorage), exp.sourceProvider, attrs)
 		})
 	}
 	consumer := metrics.NewConsumer()
@@ -188,12 +265,17 @@ func (exp *metricsExporter) PushMetricsData(ctx context.Context, md pmetric.Metr
 	ms = metrics.PrepareSystemMetrics(ms)
 
 	err = nil
-	if len(ms) > 0 {
-		exp.params.Logger.Debug("exporting payload", zap.Any("metric", ms))
+	for len(ms) > 0 {
+		batch := ms
+		if size := exp.cfg.Metrics.BatchSize; size > 0 && len(batch) > size {
+			batch = batch[:size]
+		}
+		ms = ms[len(batch):]
+		exp.params.Logger.Debug("exporting payload", zap.Any("metric", batch))
 		err = multierr.Append(
 			err,
-			exp.retrier.DoWithRetries(ctx, func(context.Context) error {
-				return exp.client.PostMetrics(ms)
+			exp.retrier.DoWithRetries(ctx, func(ctx context.Context) error {
+				return exp.postMetrics(ctx, batch)
 			}),
 		)
 	}
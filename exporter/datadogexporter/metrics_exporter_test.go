@@ -15,16 +15,21 @@
 package datadogexporter
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/DataDog/agent-payload/v5/gogen"
 	"github.com/DataDog/datadog-agent/pkg/otlp/model/source"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -35,6 +40,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/testutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/utils"
 )
 
 func TestNewExporter(t *testing.T) {
@@ -57,6 +63,8 @@ func TestNewExporter(t *testing.T) {
 			SumConfig: SumConfig{
 				CumulativeMonotonicMode: CumulativeMonotonicSumModeToDelta,
 			},
+			Compression: CompressionModeGzip,
+			BatchSize:   defaultBatchSize,
 		},
 	}
 	params := componenttest.NewNopExporterCreateSettings()
@@ -120,47 +128,47 @@ func Test_metricsExporter_PushMetricsData(t *testing.T) {
 			expectedSeries: map[string]interface{}{
 				"series": []interface{}{
 					map[string]interface{}{
-						"metric": "int.gauge",
-						"points": []interface{}{[]interface{}{float64(0), float64(222)}},
-						"type":   "gauge",
-						"host":   "test-host",
-						"tags":   []interface{}{"env:dev"},
+						"metric":    "int.gauge",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(222)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"env:dev"},
 					},
 					map[string]interface{}{
-						"metric": "otel.system.filesystem.utilization",
-						"points": []interface{}{[]interface{}{float64(0), float64(333)}},
-						"type":   "gauge",
-						"host":   "test-host",
-						"tags":   []interface{}{"env:dev"},
+						"metric":    "otel.system.filesystem.utilization",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(333)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"env:dev"},
 					},
 					map[string]interface{}{
-						"metric": "double.histogram.bucket",
-						"points": []interface{}{[]interface{}{float64(0), float64(2)}},
-						"type":   "count",
-						"host":   "test-host",
-						"tags":   []interface{}{"lower_bound:-inf", "upper_bound:0", "env:dev"},
+						"metric":    "double.histogram.bucket",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(2)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_COUNT),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"lower_bound:-inf", "upper_bound:0", "env:dev"},
 					},
 					map[string]interface{}{
-						"metric": "double.histogram.bucket",
-						"points": []interface{}{[]interface{}{float64(0), float64(18)}},
-						"type":   "count",
-						"host":   "test-host",
-						"tags":   []interface{}{"lower_bound:0", "upper_bound:inf", "env:dev"},
+						"metric":    "double.histogram.bucket",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(18)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_COUNT),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"lower_bound:0", "upper_bound:inf", "env:dev"},
 					},
 					map[string]interface{}{
-						"metric": "otel.datadog_exporter.metrics.running",
-						"points": []interface{}{[]interface{}{float64(0), float64(1)}},
-						"type":   "gauge",
-						"host":   "test-host",
-						"tags":   []interface{}{"version:latest", "command:otelcol"},
+						"metric":    "otel.datadog_exporter.metrics.running",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(1)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"version:latest", "command:otelcol"},
 					},
 					map[string]interface{}{
-						"metric":   "system.disk.in_use",
-						"points":   []interface{}{[]interface{}{float64(0), float64(333)}},
-						"type":     "gauge",
-						"host":     "test-host",
-						"interval": float64(1),
-						"tags":     []interface{}{"env:dev"},
+						"metric":    "system.disk.in_use",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(333)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"interval":  float64(1),
+						"tags":      []interface{}{"env:dev"},
 					},
 				},
 			},
@@ -178,33 +186,33 @@ func Test_metricsExporter_PushMetricsData(t *testing.T) {
 			expectedSeries: map[string]interface{}{
 				"series": []interface{}{
 					map[string]interface{}{
-						"metric": "int.gauge",
-						"points": []interface{}{[]interface{}{float64(0), float64(222)}},
-						"type":   "gauge",
-						"host":   "test-host",
-						"tags":   []interface{}{"env:dev"},
+						"metric":    "int.gauge",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(222)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"env:dev"},
 					},
 					map[string]interface{}{
-						"metric": "otel.system.filesystem.utilization",
-						"points": []interface{}{[]interface{}{float64(0), float64(333)}},
-						"type":   "gauge",
-						"host":   "test-host",
-						"tags":   []interface{}{"env:dev"},
+						"metric":    "otel.system.filesystem.utilization",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(333)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"env:dev"},
 					},
 					map[string]interface{}{
-						"metric": "otel.datadog_exporter.metrics.running",
-						"points": []interface{}{[]interface{}{float64(0), float64(1)}},
-						"type":   "gauge",
-						"host":   "test-host",
-						"tags":   []interface{}{"version:latest", "command:otelcol"},
+						"metric":    "otel.datadog_exporter.metrics.running",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(1)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"version:latest", "command:otelcol"},
 					},
 					map[string]interface{}{
-						"metric":   "system.disk.in_use",
-						"points":   []interface{}{[]interface{}{float64(0), float64(333)}},
-						"type":     "gauge",
-						"host":     "test-host",
-						"interval": float64(1),
-						"tags":     []interface{}{"env:dev"},
+						"metric":    "system.disk.in_use",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(333)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"interval":  float64(1),
+						"tags":      []interface{}{"env:dev"},
 					},
 				},
 			},
@@ -239,47 +247,47 @@ func Test_metricsExporter_PushMetricsData(t *testing.T) {
 			expectedSeries: map[string]interface{}{
 				"series": []interface{}{
 					map[string]interface{}{
-						"metric": "int.gauge",
-						"points": []interface{}{[]interface{}{float64(0), float64(222)}},
-						"type":   "gauge",
-						"host":   "test-host",
-						"tags":   []interface{}{"env:dev", "key1:value1", "key2:value2"},
+						"metric":    "int.gauge",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(222)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"env:dev", "key1:value1", "key2:value2"},
 					},
 					map[string]interface{}{
-						"metric": "otel.system.filesystem.utilization",
-						"points": []interface{}{[]interface{}{float64(0), float64(333)}},
-						"type":   "gauge",
-						"host":   "test-host",
-						"tags":   []interface{}{"env:dev", "key1:value1", "key2:value2"},
+						"metric":    "otel.system.filesystem.utilization",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(333)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"env:dev", "key1:value1", "key2:value2"},
 					},
 					map[string]interface{}{
-						"metric": "double.histogram.bucket",
-						"points": []interface{}{[]interface{}{float64(0), float64(2)}},
-						"type":   "count",
-						"host":   "test-host",
-						"tags":   []interface{}{"lower_bound:-inf", "upper_bound:0", "env:dev", "key1:value1", "key2:value2"},
+						"metric":    "double.histogram.bucket",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(2)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_COUNT),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"lower_bound:-inf", "upper_bound:0", "env:dev", "key1:value1", "key2:value2"},
 					},
 					map[string]interface{}{
-						"metric": "double.histogram.bucket",
-						"points": []interface{}{[]interface{}{float64(0), float64(18)}},
-						"type":   "count",
-						"host":   "test-host",
-						"tags":   []interface{}{"lower_bound:0", "upper_bound:inf", "env:dev", "key1:value1", "key2:value2"},
+						"metric":    "double.histogram.bucket",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(18)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_COUNT),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"lower_bound:0", "upper_bound:inf", "env:dev", "key1:value1", "key2:value2"},
 					},
 					map[string]interface{}{
-						"metric": "otel.datadog_exporter.metrics.running",
-						"points": []interface{}{[]interface{}{float64(0), float64(1)}},
-						"type":   "gauge",
-						"host":   "test-host",
-						"tags":   []interface{}{"version:latest", "command:otelcol", "key1:value1", "key2:value2"},
+						"metric":    "otel.datadog_exporter.metrics.running",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(1)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"tags":      []interface{}{"version:latest", "command:otelcol", "key1:value1", "key2:value2"},
 					},
 					map[string]interface{}{
-						"metric":   "system.disk.in_use",
-						"points":   []interface{}{[]interface{}{float64(0), float64(333)}},
-						"type":     "gauge",
-						"host":     "test-host",
-						"interval": float64(1),
-						"tags":     []interface{}{"env:dev", "key1:value1", "key2:value2"},
+						"metric":    "system.disk.in_use",
+						"points":    []interface{}{map[string]interface{}{"timestamp": float64(0), "value": float64(333)}},
+						"type":      float64(datadogV2.METRICINTAKETYPE_GAUGE),
+						"resources": []interface{}{map[string]interface{}{"name": "test-host", "type": "host"}},
+						"interval":  float64(1),
+						"tags":      []interface{}{"env:dev", "key1:value1", "key2:value2"},
 					},
 				},
 			},
@@ -289,7 +297,7 @@ func Test_metricsExporter_PushMetricsData(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("kind=%s,histgramMode=%s", tt.source.Kind, tt.histogramMode), func(t *testing.T) {
-			seriesRecorder := &testutils.HTTPRequestRecorder{Pattern: "/api/v1/series"}
+			seriesRecorder := &testutils.HTTPRequestRecorder{Pattern: "/api/v2/series"}
 			sketchRecorder := &testutils.HTTPRequestRecorder{Pattern: "/api/beta/sketches"}
 			server := testutils.DatadogServerMock(
 				seriesRecorder.HandlerFunc,
@@ -302,7 +310,9 @@ func Test_metricsExporter_PushMetricsData(t *testing.T) {
 				context.Background(),
 				componenttest.NewNopExporterCreateSettings(),
 				newTestConfig(t, server.URL, tt.hostTags, tt.histogramMode),
+				utils.NewAPIKey(""),
 				&once,
+				nil,
 				&testutils.MockSourceProvider{Src: tt.source},
 			)
 			if tt.expectedErr == nil {
@@ -326,8 +336,13 @@ func Test_metricsExporter_PushMetricsData(t *testing.T) {
 				assert.Equal(t, "application/json", seriesRecorder.Header.Get("Content-Type"))
 				assert.Equal(t, "otelcol/latest", seriesRecorder.Header.Get("User-Agent"))
 				assert.NoError(t, err)
+				assert.Equal(t, "gzip", seriesRecorder.Header.Get("Content-Encoding"))
+				reader, err := gzip.NewReader(bytes.NewReader(seriesRecorder.ByteBody))
+				require.NoError(t, err)
+				body, err := io.ReadAll(reader)
+				require.NoError(t, err)
 				var actual map[string]interface{}
-				assert.NoError(t, json.Unmarshal(seriesRecorder.ByteBody, &actual))
+				assert.NoError(t, json.Unmarshal(body, &actual))
 				assert.EqualValues(t, tt.expectedSeries, actual)
 			}
 			if tt.expectedSketchPayload == nil {
@@ -416,10 +431,80 @@ func newTestConfig(t *testing.T, endpoint string, hostTags []string, histogramMo
 				Mode: histogramMode,
 			},
 			// Set values to avoid errors. No particular intention in value selection.
-			DeltaTTL: 3600,
+			DeltaTTL:    3600,
+			Compression: CompressionModeGzip,
+			BatchSize:   defaultBatchSize,
 			SumConfig: SumConfig{
 				CumulativeMonotonicMode: CumulativeMonotonicSumModeRawValue,
 			},
 		},
 	}
 }
+
+func TestPushMetricsDataFailover(t *testing.T) {
+	failing := func() (string, http.HandlerFunc) {
+		return "/api/v2/series", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+	server := testutils.DatadogServerMock(failing)
+	defer server.Close()
+
+	seriesRecorder := &testutils.HTTPRequestRecorder{Pattern: "/api/v2/series"}
+	failoverServer := testutils.DatadogServerMock(seriesRecorder.HandlerFunc)
+	defer failoverServer.Close()
+
+	var once sync.Once
+	exp, err := newMetricsExporter(
+		context.Background(),
+		componenttest.NewNopExporterCreateSettings(),
+		newTestConfig(t, server.URL, nil, HistogramModeCounters),
+		utils.NewAPIKey(""),
+		&once,
+		nil,
+		&testutils.MockSourceProvider{Src: source.Source{Kind: source.HostnameKind, Identifier: "test-host"}},
+	)
+	require.NoError(t, err)
+	exp.setFailover(failoverServer.URL, utils.NewAPIKey("failover_key"))
+
+	require.NoError(t, exp.PushMetricsData(context.Background(), createTestMetrics(nil)))
+	assert.NotEmpty(t, seriesRecorder.ByteBody)
+	assert.Equal(t, "otelcol/latest", seriesRecorder.Header.Get("User-Agent"))
+}
+
+func TestPushMetricsDataSplitsLargePayloads(t *testing.T) {
+	var batches []int
+	tooLarge := func() (string, http.HandlerFunc) {
+		return "/api/v2/series", func(w http.ResponseWriter, r *http.Request) {
+			reader, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			var payload datadogV2.MetricPayload
+			require.NoError(t, json.NewDecoder(reader).Decode(&payload))
+			if len(payload.Series) > 1 {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+			batches = append(batches, len(payload.Series))
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}
+	server := testutils.DatadogServerMock(tooLarge)
+	defer server.Close()
+
+	var once sync.Once
+	cfg := newTestConfig(t, server.URL, nil, HistogramModeCounters)
+	cfg.Metrics.BatchSize = 3
+	exp, err := newMetricsExporter(
+		context.Background(),
+		componenttest.NewNopExporterCreateSettings(),
+		cfg,
+		utils.NewAPIKey(""),
+		&once,
+		nil,
+		&testutils.MockSourceProvider{Src: source.Source{Kind: source.HostnameKind, Identifier: "test-host"}},
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, exp.PushMetricsData(context.Background(), createTestMetrics(nil)))
+	assert.Equal(t, []int{1, 1, 1, 1, 1, 1}, batches)
+}
@@ -25,14 +25,15 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/trace/agent"
 	traceconfig "github.com/DataDog/datadog-agent/pkg/trace/config"
 	tracelog "github.com/DataDog/datadog-agent/pkg/trace/log"
+	"github.com/DataDog/datadog-api-client-go/v2/api/datadogV2"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
-	"gopkg.in/zorkian/go-datadog-api.v2"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/metrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/datadogexporter/internal/scrub"
@@ -42,60 +43,104 @@ import (
 type traceExporter struct {
 	params         component.ExporterCreateSettings
 	cfg            *Config
-	ctx            context.Context // ctx triggers shutdown upon cancellation
-	client         *datadog.Client // client sends runnimg metrics to backend & performs API validation
-	scrubber       scrub.Scrubber  // scrubber scrubs sensitive information from error messages
-	onceMetadata   *sync.Once      // onceMetadata ensures that metadata is sent only once across all exporters
-	wg             sync.WaitGroup  // wg waits for graceful shutdown
-	agent          *agent.Agent    // agent processes incoming traces
-	sourceProvider source.Provider // is able to source the origin of a trace (hostname, container, etc)
+	ctx            context.Context       // ctx triggers shutdown upon cancellation
+	apiKey         *utils.APIKey         // apiKey authenticates the requests; it may be rotated
+	metricsAPI     *datadogV2.MetricsApi // metricsAPI sends runnimg metrics to backend
+	scrubber       scrub.Scrubber        // scrubber scrubs sensitive information from error messages
+	onceMetadata   *sync.Once            // onceMetadata ensures that metadata is sent only once across all exporters
+	metaStorage    *metadataStorage      // metaStorage persists the host metadata which could not be sent
+	wg             sync.WaitGroup        // wg waits for graceful shutdown
+	mu             sync.RWMutex          // mu guards agent and stopAgent, which are replaced on API key rotations
+	agent          *agent.Agent          // agent processes incoming traces
+	stopAgent      context.CancelFunc    // stopAgent stops agent, flushing its payloads
+	hostname       string                // hostname of the agent; empty when the source is not a hostname
+	sourceProvider source.Provider       // is able to source the origin of a trace (hostname, container, etc)
+	errorSender    *logs.Sender          // errorSender submits Error Tracking items; nil when disabled
 }
 
-func newTracesExporter(ctx context.Context, params component.ExporterCreateSettings, cfg *Config, onceMetadata *sync.Once, sourceProvider source.Provider) (*traceExporter, error) {
+func newTracesExporter(ctx context.Context, params component.ExporterCreateSettings, cfg *Config, apiKey *utils.APIKey, onceMetadata *sync.Once, metadataStorage *metadataStorage, sourceProvider source.Provider) (*traceExporter, error) {
 	// client to send running metric to the backend & perform API key validation
-	client := utils.CreateClient(cfg.API.Key, cfg.Metrics.TCPAddr.Endpoint)
-	if err := utils.ValidateAPIKey(params.Logger, client); err != nil && cfg.API.FailOnInvalidKey {
+	client := utils.CreateAPIClient(
+		params.BuildInfo,
+		cfg.Metrics.TCPAddr.Endpoint,
+		cfg.TimeoutSettings,
+		cfg.LimitedHTTPClientSettings.TLSSetting.InsecureSkipVerify,
+	)
+	if err := utils.ValidateAPIKey(ctx, apiKey.Get(), params.Logger, client); err != nil && cfg.API.FailOnInvalidKey {
 		return nil, err
 	}
-	acfg := traceconfig.New()
 	src, err := sourceProvider.Source(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if src.Kind == source.HostnameKind {
-		acfg.Hostname = src.Identifier
-	}
-	acfg.OTLPReceiver.SpanNameRemappings = cfg.Traces.SpanNameRemappings
-	acfg.OTLPReceiver.SpanNameAsResourceName = cfg.Traces.SpanNameAsResourceName
-	acfg.OTLPReceiver.UsePreviewHostnameLogic = featuregate.GetRegistry().IsEnabled(metadata.HostnamePreviewFeatureGate)
-	acfg.Endpoints[0].APIKey = cfg.API.Key
-	acfg.Ignore["resource"] = cfg.Traces.IgnoreResources
-	acfg.ReceiverPort = 0 // disable HTTP receiver
-	acfg.AgentVersion = fmt.Sprintf("datadogexporter-%s-%s", params.BuildInfo.Command, params.BuildInfo.Version)
-	if v := cfg.Traces.flushInterval; v > 0 {
-		acfg.TraceWriter.FlushPeriodSeconds = v
-	}
-	if addr := cfg.Traces.Endpoint; addr != "" {
-		acfg.Endpoints[0].Host = addr
-	}
-	tracelog.SetLogger(&zaplogger{params.Logger})
-	agnt := agent.NewAgent(ctx, acfg)
 	exp := &traceExporter{
 		params:         params,
 		cfg:            cfg,
 		ctx:            ctx,
-		client:         client,
-		agent:          agnt,
+		apiKey:         apiKey,
+		metricsAPI:     datadogV2.NewMetricsApi(client),
 		onceMetadata:   onceMetadata,
+		metaStorage:    metadataStorage,
 		scrubber:       scrub.NewScrubber(),
 		sourceProvider: sourceProvider,
 	}
+	if src.Kind == source.HostnameKind {
+		exp.hostname = src.Identifier
+	}
+	if cfg.Traces.ErrorTracking {
+		exp.errorSender = logs.NewSender(cfg.Logs.TCPAddr.Endpoint, params.Logger, cfg.TimeoutSettings, cfg.LimitedHTTPClientSettings.TLSSetting.InsecureSkipVerify, apiKey)
+	}
+	tracelog.SetLogger(&zaplogger{params.Logger})
+	exp.startAgent(apiKey.Get())
+	// the agent can't change its API key, so it is replaced on rotations
+	apiKey.OnChange(func(key string) {
+		if exp.ctx.Err() == nil {
+			exp.startAgent(key)
+		}
+	})
+	return exp, nil
+}
+
+// agentConfig returns the configuration of a trace agent authenticated with apiKey.
+func (exp *traceExporter) agentConfig(apiKey string) *traceconfig.AgentConfig {
+	acfg := traceconfig.New()
+	if exp.hostname != "" {
+		acfg.Hostname = exp.hostname
+	}
+	acfg.OTLPReceiver.SpanNameRemappings = exp.cfg.Traces.SpanNameRemappings
+	acfg.OTLPReceiver.SpanNameAsResourceName = exp.cfg.Traces.SpanNameAsResourceName
+	acfg.OTLPReceiver.UsePreviewHostnameLogic = featuregate.GetRegistry().IsEnabled(metadata.HostnamePreviewFeatureGate)
+	acfg.Endpoints[0].APIKey = apiKey
+	acfg.Ignore["resource"] = exp.cfg.Traces.IgnoreResources
+	acfg.ReceiverPort = 0 // disable HTTP receiver
+	acfg.AgentVersion = fmt.Sprintf("datadogexporter-%s-%s", exp.params.BuildInfo.Command, exp.params.BuildInfo.Version)
+	if v := exp.cfg.Traces.flushInterval; v > 0 {
+		acfg.TraceWriter.FlushPeriodSeconds = v
+	}
+	if addr := exp.cfg.Traces.Endpoint; addr != "" {
+		acfg.Endpoints[0].Host = addr
+	}
+	return acfg
+}
+
+// startAgent starts a trace agent authenticated with apiKey. It replaces the running agent,
+// if any, which is stopped once the traces being received by it are handed over.
+func (exp *traceExporter) startAgent(apiKey string) {
+	ctx, cancel := context.WithCancel(exp.ctx)
+	agnt := agent.NewAgent(ctx, exp.agentConfig(apiKey))
 	exp.wg.Add(1)
 	go func() {
 		defer exp.wg.Done()
 		agnt.Run()
 	}()
-	return exp, nil
+
+	exp.mu.Lock()
+	stop := exp.stopAgent
+	exp.agent, exp.stopAgent = agnt, cancel
+	exp.mu.Unlock()
+	if stop != nil {
+		stop()
+	}
 }
 
 var _ consumer.ConsumeTracesFunc = (*traceExporter)(nil).consumeTraces
@@ -113,13 +158,14 @@ func (exp *traceExporter) consumeTraces(
 			if td.ResourceSpans().Len() > 0 {
 				attrs = td.ResourceSpans().At(0).Resource().Attributes()
 			}
-			go metadata.Pusher(exp.ctx, exp.params, newMetadataConfigfromConfig(exp.cfg), exp.sourceProvider, attrs)
+			exp.metaStorage.goPusher(exp.ctx, exp.params, newMetadataConfigfromConfig(exp.cfg, exp.apiKey, exp.metaStorage), exp.sourceProvider, attrs)
 		})
 	}
 	rspans := td.ResourceSpans()
 	hosts := make(map[string]struct{})
 	tags := make(map[string]struct{})
 	now := pcommon.NewTimestampFromTime(time.Now())
+	exp.mu.RLock()
 	for i := 0; i < rspans.Len(); i++ {
 		rspan := rspans.At(i)
 		src := exp.agent.OTLPReceiver.ReceiveResourceSpans(ctx, rspan, http.Header{}, "otlp-exporter")
@@ -130,7 +176,8 @@ func (exp *traceExporter) consumeTraces(
 			tags[src.Tag()] = struct{}{}
 		}
 	}
-	series := make([]datadog.Metric, 0, len(hosts)+len(tags))
+	exp.mu.RUnlock()
+	series := make([]datadogV2.MetricSeries, 0, len(hosts)+len(tags))
 	for host := range hosts {
 		series = append(series, metrics.DefaultMetrics("traces", host, uint64(now), exp.params.BuildInfo)...)
 	}
@@ -141,12 +188,28 @@ func (exp *traceExporter) consumeTraces(
 		}
 		series = append(series, ms...)
 	}
-	if err := exp.client.PostMetrics(series); err != nil {
+	payload := datadogV2.MetricPayload{Series: series}
+	if _, _, err := exp.metricsAPI.SubmitMetrics(utils.GetRequestContext(ctx, exp.apiKey.Get()), payload); err != nil {
 		exp.params.Logger.Error("Error posting hostname/tags series", zap.Error(err))
 	}
+	if exp.errorSender != nil {
+		exp.submitErrors(ctx, td)
+	}
 	return nil
 }
 
+// submitErrors sends the exceptions recorded as span events in td to Datadog Error Tracking.
+// Failures are only logged: the traces themselves were already handed over to the agent.
+func (exp *traceExporter) submitErrors(ctx context.Context, td ptrace.Traces) {
+	payload := logs.TransformErrors(td)
+	if len(payload) == 0 {
+		return
+	}
+	if err := exp.errorSender.SubmitLogs(ctx, payload); err != nil {
+		exp.params.Logger.Error("Error posting error tracking payload", zap.Error(err))
+	}
+}
+
 func (exp *traceExporter) waitShutdown() {
 	exp.wg.Wait()
 }
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -112,7 +113,7 @@ func (testlogger) Flush() {}
 func TestTracesSource(t *testing.T) {
 	reqs := make(chan []byte, 1)
 	metricsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/api/v1/series" {
+		if r.URL.Path != "/api/v2/series" {
 			// we only want to capture series payloads
 			return
 		}
@@ -161,7 +162,10 @@ func TestTracesSource(t *testing.T) {
 	// Payload specifies a sub-set of a metrics series payload.
 	type Payload struct {
 		Series []struct {
-			Host string   `json:"host,omitempty"`
+			Resources []struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"resources,omitempty"`
 			Tags []string `json:"tags,omitempty"`
 		} `json:"series"`
 	}
@@ -171,7 +175,12 @@ func TestTracesSource(t *testing.T) {
 		var p Payload
 		assert.NoError(json.Unmarshal(data, &p))
 		assert.Len(p.Series, 1)
-		return p.Series[0].Host, p.Series[0].Tags
+		for _, res := range p.Series[0].Resources {
+			if res.Type == "host" {
+				host = res.Name
+			}
+		}
+		return host, p.Series[0].Tags
 	}
 	for _, tt := range []struct {
 		attrs map[string]interface{}
@@ -272,6 +281,129 @@ func TestTraceExporter(t *testing.T) {
 	require.NoError(t, exporter.Shutdown(context.Background()))
 }
 
+func TestTraceExporterAPIKeyRotation(t *testing.T) {
+	metricsServer := testutils.DatadogServerMock()
+	defer metricsServer.Close()
+
+	keys := make(chan string, 100)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/v0.2/traces" {
+			keys <- req.Header.Get("DD-Api-Key")
+		}
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	keyFile := filepath.Join(t.TempDir(), "api_key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\n"), 0600))
+	cfg := Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		API: APIConfig{
+			KeyFile:           keyFile,
+			KeyReloadInterval: 10 * time.Millisecond,
+		},
+		TagsConfig: TagsConfig{
+			Hostname: "test-host",
+		},
+		Metrics: MetricsConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: metricsServer.URL,
+			},
+		},
+		Traces: TracesConfig{
+			TCPAddr: confignet.TCPAddr{
+				Endpoint: server.URL,
+			},
+			IgnoreResources: []string{},
+			flushInterval:   0.1,
+		},
+	}
+
+	params := componenttest.NewNopExporterCreateSettings()
+	f := NewFactory()
+	exporter, err := f.CreateTracesExporter(context.Background(), params, &cfg)
+	require.NoError(t, err)
+
+	waitForKey := func(key string) {
+		timeout := time.After(5 * time.Second)
+		for {
+			require.NoError(t, exporter.ConsumeTraces(context.Background(), simpleTraces()))
+			select {
+			case got := <-keys:
+				if got == key {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("Timed out waiting for traces sent with key %q", key)
+			}
+		}
+	}
+	waitForKey("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+
+	require.NoError(t, os.WriteFile(keyFile, []byte("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb\n"), 0600))
+	waitForKey("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	require.NoError(t, exporter.Shutdown(context.Background()))
+}
+
+func TestTraceExporterErrorTracking(t *testing.T) {
+	metricsServer := testutils.DatadogServerMock()
+	defer metricsServer.Close()
+	logsServer := testutils.DatadogLogServerMock()
+	defer logsServer.Close()
+	tracesServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer tracesServer.Close()
+
+	cfg := Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		API: APIConfig{
+			Key: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		},
+		TagsConfig: TagsConfig{
+			Hostname: "test-host",
+		},
+		Metrics: MetricsConfig{
+			TCPAddr: confignet.TCPAddr{Endpoint: metricsServer.URL},
+		},
+		Traces: TracesConfig{
+			TCPAddr:         confignet.TCPAddr{Endpoint: tracesServer.URL},
+			IgnoreResources: []string{},
+			ErrorTracking:   true,
+		},
+		Logs: LogsConfig{
+			TCPAddr: confignet.TCPAddr{Endpoint: logsServer.URL},
+		},
+	}
+
+	params := componenttest.NewNopExporterCreateSettings()
+	f := NewFactory()
+	exporter, err := f.CreateTracesExporter(context.Background(), params, &cfg)
+	require.NoError(t, err)
+
+	traces := simpleTracesWithAttributes(map[string]interface{}{
+		semconv.AttributeServiceName: "checkout",
+	})
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	for i := 0; i < 2; i++ {
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		event.Attributes().PutStr(semconv.AttributeExceptionType, "java.lang.NullPointerException")
+		event.Attributes().PutStr(semconv.AttributeExceptionStacktrace, "at Checkout.pay(Checkout.java:42)")
+	}
+
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), traces))
+	require.Len(t, logsServer.LogsData, 1)
+	assert.Equal(t, "checkout", logsServer.LogsData[0]["service"])
+	assert.Equal(t, "java.lang.NullPointerException", logsServer.LogsData[0]["error.kind"])
+	assert.Equal(t, "2", logsServer.LogsData[0]["error.count"])
+
+	// spans without exceptions do not produce any Error Tracking item
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), simpleTraces()))
+	assert.Len(t, logsServer.LogsData, 1)
+	require.NoError(t, exporter.Shutdown(context.Background()))
+}
+
 func TestNewTracesExporter(t *testing.T) {
 	metricsServer := testutils.DatadogServerMock()
 	defer metricsServer.Close()
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/debugexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
+)
+
+// Config defines configuration for the debug exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"`
+
+	// Logs selects the fields printed for each log record.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Traces selects the fields printed for each span.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// Metrics selects the fields printed for each data point.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// MaxRecordsPerSecond is the highest number of records printed each second, over all the signals,
+	// the other records being dropped. 0 prints all the records.
+	MaxRecordsPerSecond int `mapstructure:"max_records_per_second"`
+
+	// Path is the file the records are appended to as JSON lines. By default, they are printed in the
+	// logs of the collector.
+	Path string `mapstructure:"path"`
+}
+
+// SignalConfig selects the records of a signal to print, and their fields.
+type SignalConfig struct {
+	// Fields are the fields printed for each record, in this order. By default, the severity and
+	// the body of the log records, the IDs and the name of the spans, and the metric name and the
+	// attributes of the data points are printed.
+	Fields []FieldConfig `mapstructure:"fields"`
+
+	// Condition is the OTTL condition the records must match to be printed, all the records being
+	// printed when empty.
+	Condition string `mapstructure:"condition"`
+}
+
+// FieldConfig is a printed field.
+type FieldConfig struct {
+	// Name is the name of the field in the output.
+	Name string `mapstructure:"name"`
+
+	// Value is the OTTL value expression of the field, such as a path or a function invocation.
+	Value string `mapstructure:"value"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MaxRecordsPerSecond < 0 {
+		return errors.New("max_records_per_second must not be negative")
+	}
+
+	var errs error
+	for _, signal := range []struct {
+		name string
+		cfg  SignalConfig
+	}{{"logs", cfg.Logs}, {"traces", cfg.Traces}, {"metrics", cfg.Metrics}} {
+		if err := signal.cfg.validate(); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("%s: %w", signal.name, err))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	settings := component.TelemetrySettings{Logger: zap.NewNop()}
+	if _, err := newSelector(ottllogs.NewParser(functions[ottllogs.TransformContext](), settings), cfg.Logs.withDefaultFields(defaultLogsFields)); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("logs: %w", err))
+	}
+	if _, err := newSelector(ottltraces.NewParser(functions[ottltraces.TransformContext](), settings), cfg.Traces.withDefaultFields(defaultTracesFields)); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("traces: %w", err))
+	}
+	if _, err := newSelector(ottldatapoints.NewParser(functions[ottldatapoints.TransformContext](), settings), cfg.Metrics.withDefaultFields(defaultMetricsFields)); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("metrics: %w", err))
+	}
+	return errs
+}
+
+func (cfg *SignalConfig) validate() error {
+	names := map[string]bool{}
+	for i, field := range cfg.Fields {
+		switch {
+		case field.Name == "":
+			return fmt.Errorf("field %d: name is required", i)
+		case field.Value == "":
+			return fmt.Errorf("field %q: value is required", field.Name)
+		case names[field.Name]:
+			return fmt.Errorf("field %q is duplicated", field.Name)
+		}
+		names[field.Name] = true
+	}
+	return nil
+}
+
+// withDefaultFields returns the configuration with the default fields when none are configured.
+func (cfg SignalConfig) withDefaultFields(defaultFields []FieldConfig) SignalConfig {
+	if len(cfg.Fields) == 0 {
+		cfg.Fields = defaultFields
+	}
+	return cfg
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Exporter
+		expectedErr string
+	}{
+		{
+			id:       config.NewComponentID(typeStr),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "custom"),
+			expected: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+				Logs: SignalConfig{
+					Fields: []FieldConfig{
+						{Name: "service", Value: `resource.attributes["service.name"]`},
+						{Name: "body", Value: "body"},
+					},
+					Condition: "severity_number >= SEVERITY_NUMBER_WARN",
+				},
+				Traces: SignalConfig{
+					Fields: []FieldConfig{{Name: "name", Value: "name"}},
+				},
+				Metrics: SignalConfig{
+					Fields: []FieldConfig{{Name: "name", Value: "metric.name"}},
+				},
+				MaxRecordsPerSecond: 100,
+				Path:                "/var/log/debug.json",
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "duplicated_field"),
+			expectedErr: `logs: field "body" is duplicated`,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalid_expression"),
+			expectedErr: "traces: ",
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalid_condition"),
+			expectedErr: "traces: ",
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "negative_max_records"),
+			expectedErr: "max_records_per_second must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExporter(sub, cfg))
+			if tt.expectedErr != "" {
+				err = cfg.Validate()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/debugexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
+)
+
+type logsExporter struct {
+	selector *selector[ottllogs.TransformContext]
+	printer  *printer
+}
+
+func (e *logsExporter) pushLogs(_ context.Context, ld plog.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				ctx := ottllogs.NewTransformContext(lrs.At(k), sl.Scope(), rl.Resource())
+				if fields, ok := e.selector.selectFields(ctx); ok {
+					e.printer.print("Log record", fields)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type tracesExporter struct {
+	selector *selector[ottltraces.TransformContext]
+	printer  *printer
+}
+
+func (e *tracesExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				ctx := ottltraces.NewTransformContext(spans.At(k), ss.Scope(), rs.Resource())
+				if fields, ok := e.selector.selectFields(ctx); ok {
+					e.printer.print("Span", fields)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type metricsExporter struct {
+	selector *selector[ottldatapoints.TransformContext]
+	printer  *printer
+}
+
+func (e *metricsExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				e.printDataPoints(metrics.At(k), metrics, sm, rm)
+			}
+		}
+	}
+	return nil
+}
+
+func (e *metricsExporter) printDataPoints(metric pmetric.Metric, metrics pmetric.MetricSlice, sm pmetric.ScopeMetrics, rm pmetric.ResourceMetrics) {
+	var dps []interface{}
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Gauge().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Sum().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Histogram().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			dps = append(dps, metric.ExponentialHistogram().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Summary().DataPoints().At(i))
+		}
+	}
+
+	for _, dp := range dps {
+		ctx := ottldatapoints.NewTransformContext(dp, metric, metrics, sm.Scope(), rm.Resource())
+		if fields, ok := e.selector.selectFields(ctx); ok {
+			e.printer.print("Data point", fields)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
+)
+
+func observedPrinter(cfg *Config) (*printer, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	return newPrinter(cfg, zap.New(core)), logs
+}
+
+func testLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for severity, text := range map[plog.SeverityNumber]string{plog.SeverityNumberInfo: "INFO", plog.SeverityNumberError: "ERROR"} {
+		lr := lrs.AppendEmpty()
+		lr.SetSeverityNumber(severity)
+		lr.SetSeverityText(text)
+		lr.Body().SetStr("payment " + text)
+		lr.Attributes().PutInt("attempt", 2)
+	}
+	return ld
+}
+
+func TestPushLogs(t *testing.T) {
+	p, observed := observedPrinter(&Config{})
+	s, err := newSelector(ottllogs.NewParser(functions[ottllogs.TransformContext](), componenttest.NewNopTelemetrySettings()), SignalConfig{
+		Fields: []FieldConfig{
+			{Name: "service", Value: `resource.attributes["service.name"]`},
+			{Name: "message", Value: `Concat(": ", severity_text, body)`},
+			{Name: "attributes", Value: "attributes"},
+		},
+		Condition: "severity_number >= SEVERITY_NUMBER_WARN",
+	})
+	require.NoError(t, err)
+
+	exp := &logsExporter{selector: s, printer: p}
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs()))
+
+	require.Equal(t, 1, observed.Len())
+	entry := observed.All()[0]
+	assert.Equal(t, "Log record", entry.Message)
+	assert.Equal(t, map[string]interface{}{
+		"service":    "checkout",
+		"message":    "ERROR: payment ERROR",
+		"attributes": map[string]interface{}{"attempt": int64(2)},
+	}, entry.ContextMap())
+}
+
+func TestPushTraces(t *testing.T) {
+	p, observed := observedPrinter(&Config{})
+	s, err := newSelector(ottltraces.NewParser(functions[ottltraces.TransformContext](), componenttest.NewNopTelemetrySettings()), SignalConfig{
+		Fields: []FieldConfig{
+			{Name: "trace_id", Value: "trace_id"},
+			{Name: "name", Value: "name"},
+		},
+	})
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetName("GET /cart")
+
+	exp := &tracesExporter{selector: s, printer: p}
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+
+	require.Equal(t, 1, observed.Len())
+	assert.Equal(t, "Span", observed.All()[0].Message)
+	assert.Equal(t, map[string]interface{}{
+		"trace_id": "0102030405060708090a0b0c0d0e0f10",
+		"name":     "GET /cart",
+	}, observed.All()[0].ContextMap())
+}
+
+func TestPushMetrics(t *testing.T) {
+	p, observed := observedPrinter(&Config{})
+	s, err := newSelector(ottldatapoints.NewParser(functions[ottldatapoints.TransformContext](), componenttest.NewNopTelemetrySettings()), SignalConfig{
+		Fields: []FieldConfig{
+			{Name: "name", Value: "metric.name"},
+			{Name: "count", Value: "count"},
+		},
+		Condition: `metric.name == "http.server.duration"`,
+	})
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("http.server.duration")
+	histogram.SetEmptyHistogram().DataPoints().AppendEmpty().SetCount(3)
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("memory.usage")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+
+	exp := &metricsExporter{selector: s, printer: p}
+	require.NoError(t, exp.pushMetrics(context.Background(), md))
+
+	require.Equal(t, 1, observed.Len())
+	assert.Equal(t, "Data point", observed.All()[0].Message)
+	assert.Equal(t, map[string]interface{}{
+		"name":  "http.server.duration",
+		"count": int64(3),
+	}, observed.All()[0].ContextMap())
+}
+
+func TestMaxRecordsPerSecond(t *testing.T) {
+	p, observed := observedPrinter(&Config{MaxRecordsPerSecond: 2})
+
+	now := time.Now()
+	assert.True(t, p.allow(now))
+	assert.True(t, p.allow(now.Add(100*time.Millisecond)))
+	assert.False(t, p.allow(now.Add(200*time.Millisecond)))
+	assert.False(t, p.allow(now.Add(999*time.Millisecond)))
+	assert.Equal(t, 0, observed.Len())
+
+	// the number of records dropped in the previous second is reported
+	assert.True(t, p.allow(now.Add(time.Second)))
+	require.Equal(t, 1, observed.Len())
+	assert.Equal(t, map[string]interface{}{"dropped": int64(2)}, observed.All()[0].ContextMap())
+}
+
+func TestPrintToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.json")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = path
+
+	exp, err := createLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testLogs()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 2)
+
+	severities := map[string]string{}
+	for _, line := range lines {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		assert.Equal(t, "Log record", record["msg"])
+		severities[record["severity"].(string)] = record["body"].(string)
+	}
+	assert.Equal(t, map[string]string{"INFO": "payment INFO", "ERROR": "payment ERROR"}, severities)
+}
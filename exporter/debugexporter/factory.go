@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/debugexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "debug"
+	// The stability level of the exporter.
+	stability = component.StabilityLevelInDevelopment
+
+	defaultMaxRecordsPerSecond = 10
+)
+
+// NewFactory creates a factory for the debug exporter.
+func NewFactory() component.ExporterFactory {
+	return component.NewExporterFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithLogsExporter(createLogsExporter, stability),
+		component.WithTracesExporter(createTracesExporter, stability),
+		component.WithMetricsExporter(createMetricsExporter, stability),
+	)
+}
+
+func createDefaultConfig() config.Exporter {
+	return &Config{
+		ExporterSettings:    config.NewExporterSettings(config.NewComponentID(typeStr)),
+		MaxRecordsPerSecond: defaultMaxRecordsPerSecond,
+	}
+}
+
+// The fields printed for the signals whose fields are not configured.
+var (
+	defaultLogsFields = []FieldConfig{
+		{Name: "severity", Value: "severity_text"},
+		{Name: "body", Value: "body"},
+	}
+	defaultTracesFields = []FieldConfig{
+		{Name: "trace_id", Value: "trace_id.string"},
+		{Name: "span_id", Value: "span_id.string"},
+		{Name: "name", Value: "name"},
+	}
+	defaultMetricsFields = []FieldConfig{
+		{Name: "name", Value: "metric.name"},
+		{Name: "attributes", Value: "attributes"},
+	}
+)
+
+// printers holds the printer shared by the exporters of the different signals, the records of all
+// of them counting against the same limit.
+var printers = sharedcomponent.NewSharedComponents()
+
+func getPrinter(cfg *Config, set component.ExporterCreateSettings) *sharedcomponent.SharedComponent {
+	return printers.GetOrAdd(cfg, func() component.Component {
+		return newPrinter(cfg, set.Logger)
+	})
+}
+
+func createLogsExporter(ctx context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.LogsExporter, error) {
+	oCfg := cfg.(*Config)
+	s, err := newSelector(ottllogs.NewParser(functions[ottllogs.TransformContext](), set.TelemetrySettings), oCfg.Logs.withDefaultFields(defaultLogsFields))
+	if err != nil {
+		return nil, err
+	}
+
+	p := getPrinter(oCfg, set)
+	exp := &logsExporter{selector: s, printer: p.Unwrap().(*printer)}
+	return exporterhelper.NewLogsExporter(
+		ctx,
+		set,
+		cfg,
+		exp.pushLogs,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithStart(p.Start),
+		exporterhelper.WithShutdown(p.Shutdown),
+	)
+}
+
+func createTracesExporter(ctx context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.TracesExporter, error) {
+	oCfg := cfg.(*Config)
+	s, err := newSelector(ottltraces.NewParser(functions[ottltraces.TransformContext](), set.TelemetrySettings), oCfg.Traces.withDefaultFields(defaultTracesFields))
+	if err != nil {
+		return nil, err
+	}
+
+	p := getPrinter(oCfg, set)
+	exp := &tracesExporter{selector: s, printer: p.Unwrap().(*printer)}
+	return exporterhelper.NewTracesExporter(
+		ctx,
+		set,
+		cfg,
+		exp.pushTraces,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithStart(p.Start),
+		exporterhelper.WithShutdown(p.Shutdown),
+	)
+}
+
+func createMetricsExporter(ctx context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+	oCfg := cfg.(*Config)
+	s, err := newSelector(ottldatapoints.NewParser(functions[ottldatapoints.TransformContext](), set.TelemetrySettings), oCfg.Metrics.withDefaultFields(defaultMetricsFields))
+	if err != nil {
+		return nil, err
+	}
+
+	p := getPrinter(oCfg, set)
+	exp := &metricsExporter{selector: s, printer: p.Unwrap().(*printer)}
+	return exporterhelper.NewMetricsExporter(
+		ctx,
+		set,
+		cfg,
+		exp.pushMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithStart(p.Start),
+		exporterhelper.WithShutdown(p.Shutdown),
+	)
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestCreateExporters(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	set := componenttest.NewNopExporterCreateSettings()
+
+	logs, err := createLogsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	traces, err := createTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	metrics, err := createMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+
+	for _, exp := range []component.Component{logs, traces, metrics} {
+		require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+		require.NoError(t, exp.Shutdown(context.Background()))
+	}
+}
+
+func TestCreateExporterInvalidExpression(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Fields = []FieldConfig{{Name: "body", Value: "unknown_path"}}
+
+	_, err := createLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	assert.Error(t, err)
+}
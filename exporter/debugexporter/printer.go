@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/debugexporter"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var _ component.Component = (*printer)(nil)
+
+// printer prints the records of all the signals, at most MaxRecordsPerSecond each second.
+type printer struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	// output is the logger the records are printed with, either the logger of the collector or one
+	// writing to the file.
+	output *zap.Logger
+	file   *os.File
+
+	mu          sync.Mutex
+	windowStart time.Time
+	printed     int
+	dropped     int
+}
+
+func newPrinter(cfg *Config, logger *zap.Logger) *printer {
+	return &printer{
+		cfg:    cfg,
+		logger: logger,
+		output: logger,
+	}
+}
+
+func (p *printer) Start(context.Context, component.Host) error {
+	if p.cfg.Path == "" {
+		return nil
+	}
+	file, err := os.OpenFile(p.cfg.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %w", p.cfg.Path, err)
+	}
+	p.file = file
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	p.output = zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.Lock(file), zapcore.InfoLevel))
+	return nil
+}
+
+func (p *printer) Shutdown(context.Context) error {
+	if p.file == nil {
+		return nil
+	}
+	_ = p.output.Sync()
+	return p.file.Close()
+}
+
+// allow returns whether a record can be printed in the current second.
+func (p *printer) allow(now time.Time) bool {
+	if p.cfg.MaxRecordsPerSecond == 0 {
+		return true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if now.Sub(p.windowStart) >= time.Second {
+		if p.dropped > 0 {
+			p.output.Info("Records not printed, exceeding max_records_per_second", zap.Int("dropped", p.dropped))
+		}
+		p.windowStart = now
+		p.printed = 0
+		p.dropped = 0
+	}
+	if p.printed >= p.cfg.MaxRecordsPerSecond {
+		p.dropped++
+		return false
+	}
+	p.printed++
+	return true
+}
+
+func (p *printer) print(message string, fields []zap.Field) {
+	if p.allow(time.Now()) {
+		p.output.Info(message, fields...)
+	}
+}
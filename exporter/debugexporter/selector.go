@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/debugexporter"
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// functions returns the OTTL functions which can be used in the field values. The value and match
+// functions are used to evaluate the expressions of the fields and the conditions, the parsing of
+// standalone expressions not being implemented by OTTL yet.
+func functions[K any]() map[string]interface{} {
+	return map[string]interface{}{
+		"Concat":  ottlfuncs.Concat[K],
+		"Int":     ottlfuncs.Int[K],
+		"IsMatch": ottlfuncs.IsMatch[K],
+		"SpanID":  ottlfuncs.SpanID[K],
+		"Split":   ottlfuncs.Split[K],
+		"TraceID": ottlfuncs.TraceID[K],
+		"value": func(target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+			return target.Get, nil
+		},
+		"match": func(_ []string) (ottl.ExprFunc[K], error) {
+			return func(K) interface{} {
+				return true
+			}, nil
+		},
+	}
+}
+
+type field[K any] struct {
+	name  string
+	value ottl.ExprFunc[K]
+}
+
+// selector evaluates the fields of the records matching the condition.
+type selector[K any] struct {
+	fields    []field[K]
+	condition ottl.Statement[K]
+}
+
+func newSelector[K any](parser ottl.Parser[K], cfg SignalConfig) (*selector[K], error) {
+	statements := make([]string, 0, len(cfg.Fields)+1)
+	for _, f := range cfg.Fields {
+		statements = append(statements, fmt.Sprintf("value(%s)", f.Value))
+	}
+	statements = append(statements, "match()")
+	if cfg.Condition != "" {
+		statements[len(statements)-1] += " where " + cfg.Condition
+	}
+
+	parsed, err := parser.ParseStatements(statements)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &selector[K]{condition: parsed[len(parsed)-1]}
+	for i, f := range cfg.Fields {
+		s.fields = append(s.fields, field[K]{name: f.Name, value: parsed[i].Function})
+	}
+	return s, nil
+}
+
+// selectFields returns the fields of the record, and whether it matches the condition.
+func (s *selector[K]) selectFields(ctx K) ([]zap.Field, bool) {
+	if !s.condition.Condition(ctx) {
+		return nil, false
+	}
+	fields := make([]zap.Field, 0, len(s.fields))
+	for _, f := range s.fields {
+		fields = append(fields, zap.Any(f.name, printable(f.value(ctx))))
+	}
+	return fields, true
+}
+
+// printable converts the values returned by the OTTL paths to values printed as they are displayed
+// in the OTLP JSON encoding.
+func printable(value interface{}) interface{} {
+	switch v := value.(type) {
+	case pcommon.Value:
+		return v.AsRaw()
+	case pcommon.Map:
+		return v.AsRaw()
+	case pcommon.Slice:
+		return v.AsRaw()
+	case pcommon.TraceID:
+		return hex.EncodeToString(v[:])
+	case pcommon.SpanID:
+		return hex.EncodeToString(v[:])
+	case []byte:
+		return hex.EncodeToString(v)
+	default:
+		return v
+	}
+}
@@ -45,6 +45,16 @@ type Config struct {
 	// String to prefix all metric names
 	Prefix string `mapstructure:"prefix"`
 
+	// EnrichWithDynatraceMetadata adds the dimensions found in the metadata
+	// files written by Dynatrace OneAgent and the Dynatrace Operator to all
+	// exported metrics.
+	EnrichWithDynatraceMetadata bool `mapstructure:"enrich_with_dynatrace_metadata"`
+
+	// ResourceDimensions lists the resource attributes added as dimensions to
+	// the metrics of a resource. Resource attributes identifying a Dynatrace
+	// entity (dt.entity.*) are always added.
+	ResourceDimensions []string `mapstructure:"resource_dimensions"`
+
 	// Tags will be added to all exported metrics
 	// Deprecated: Please use DefaultDimensions instead
 	Tags []string `mapstructure:"tags"`
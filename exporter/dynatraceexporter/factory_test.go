@@ -104,6 +104,9 @@ func TestLoadConfig(t *testing.T) {
 
 			Prefix: "myprefix",
 
+			EnrichWithDynatraceMetadata: true,
+			ResourceDimensions:          []string{"k8s.cluster.name"},
+
 			Tags: []string{},
 			DefaultDimensions: map[string]string{
 				"dimension_example": "dimension_value",
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package enrichment reads the metadata files written by Dynatrace OneAgent
+// and the Dynatrace Operator, so that metrics exported from a monitored host
+// or container are linked to the corresponding Dynatrace entities.
+package enrichment // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/dynatraceexporter/internal/enrichment"
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/dynatrace-oss/dynatrace-metric-utils-go/metric/dimensions"
+	"go.uber.org/zap"
+)
+
+const (
+	// indirectionFile is provided by OneAgent to monitored processes. It does
+	// not exist on disk, reading it returns the path of the actual metadata file.
+	indirectionFile = "dt_metadata_e617c525669e072eebe3d0f08212e8f2.properties"
+
+	enrichmentDir = "/var/lib/dynatrace/enrichment/"
+)
+
+// Files lists the metadata files read by ReadDimensions. The first one is an
+// indirection file resolved to the path of the actual file.
+type Files struct {
+	Indirection string
+	Direct      []string
+}
+
+// DefaultFiles are the locations used by OneAgent and the Dynatrace Operator.
+var DefaultFiles = Files{
+	Indirection: indirectionFile,
+	Direct: []string{
+		enrichmentDir + "dt_metadata.properties",
+		enrichmentDir + "dt_host_metadata.properties",
+	},
+}
+
+// ReadDimensions returns the dimensions found in the metadata files. Missing
+// files are ignored, as they only exist when the collector runs on a host or
+// in a container monitored by Dynatrace. When a dimension is defined in
+// several files, the last one read wins.
+func ReadDimensions(logger *zap.Logger, files Files) []dimensions.Dimension {
+	paths := make([]string, 0, len(files.Direct)+1)
+	if files.Indirection != "" {
+		if path, err := readIndirection(files.Indirection); err == nil && path != "" {
+			paths = append(paths, path)
+		} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Warn("Failed to read Dynatrace metadata indirection file", zap.String("file", files.Indirection), zap.Error(err))
+		}
+	}
+	paths = append(paths, files.Direct...)
+
+	var dims []dimensions.Dimension
+	for _, path := range paths {
+		fileDims, err := readProperties(path)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				logger.Warn("Failed to read Dynatrace metadata file", zap.String("file", path), zap.Error(err))
+			}
+			continue
+		}
+		logger.Debug("Read Dynatrace metadata file", zap.String("file", path), zap.Int("dimensions", len(fileDims)))
+		dims = append(dims, fileDims...)
+	}
+	return dims
+}
+
+func readIndirection(path string) (string, error) {
+	content, err := os.ReadFile(path) // #nosec G304 - path is a well known location
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// readProperties parses the key=value lines of a metadata file. Lines that
+// are not valid properties are skipped.
+func readProperties(path string) ([]dimensions.Dimension, error) {
+	f, err := os.Open(path) // #nosec G304 - path is a well known location
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var dims []dimensions.Dimension
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			continue
+		}
+		dims = append(dims, dimensions.NewDimension(key, value))
+	}
+	return dims, scanner.Err()
+}
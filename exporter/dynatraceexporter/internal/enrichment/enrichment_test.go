@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enrichment
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace-oss/dynatrace-metric-utils-go/metric/dimensions"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestReadDimensions(t *testing.T) {
+	dims := ReadDimensions(zap.NewNop(), Files{
+		Indirection: filepath.Join("testdata", "indirection.properties"),
+		Direct: []string{
+			filepath.Join("testdata", "missing.properties"),
+			filepath.Join("testdata", "dt_host_metadata.properties"),
+		},
+	})
+
+	assert.Equal(t, []dimensions.Dimension{
+		dimensions.NewDimension("dt.entity.process_group_instance", "PROCESS_GROUP_INSTANCE-95C5FBF859599282"),
+		dimensions.NewDimension("dt.entity.host", "HOST-1A2B3C4D5E6F7A8B"),
+		dimensions.NewDimension("dt.entity.host", "HOST-0123456789ABCDEF"),
+		dimensions.NewDimension("host.name", "node-1"),
+	}, dims)
+}
+
+func TestReadDimensionsWithoutFiles(t *testing.T) {
+	dims := ReadDimensions(zap.NewNop(), Files{
+		Indirection: filepath.Join("testdata", "missing-indirection.properties"),
+		Direct:      []string{filepath.Join("testdata", "missing.properties")},
+	})
+	assert.Empty(t, dims)
+}
@@ -28,10 +28,12 @@ import (
 	"github.com/dynatrace-oss/dynatrace-metric-utils-go/metric/dimensions"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/dynatraceexporter/config"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/dynatraceexporter/internal/enrichment"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/dynatraceexporter/internal/serialization"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/ttlmap"
 )
@@ -53,17 +55,32 @@ func newMetricsExporter(params component.ExporterCreateSettings, cfg *config.Con
 		dimensions.NewNormalizedDimensionList(confDefaultDims...),
 	)
 
-	staticDimensions := dimensions.NewNormalizedDimensionList(dimensions.NewDimension("dt.metrics.source", "opentelemetry"))
+	// Dimensions read from the Dynatrace metadata files identify the entities
+	// the metrics are linked to and take precedence over data point attributes.
+	var enrichmentDims []dimensions.Dimension
+	if cfg.EnrichWithDynatraceMetadata {
+		enrichmentDims = enrichment.ReadDimensions(params.Logger, enrichment.DefaultFiles)
+	}
+	staticDimensions := dimensions.MergeLists(
+		dimensions.NewNormalizedDimensionList(enrichmentDims...),
+		dimensions.NewNormalizedDimensionList(dimensions.NewDimension("dt.metrics.source", "opentelemetry")),
+	)
+
+	resourceDimensions := make(map[string]bool, len(cfg.ResourceDimensions))
+	for _, key := range cfg.ResourceDimensions {
+		resourceDimensions[key] = true
+	}
 
 	prevPts := ttlmap.New(cSweepIntervalSeconds, cMaxAgeSeconds)
 	prevPts.Start()
 
 	return &exporter{
-		settings:          params.TelemetrySettings,
-		cfg:               cfg,
-		defaultDimensions: defaultDimensions,
-		staticDimensions:  staticDimensions,
-		prevPts:           prevPts,
+		settings:           params.TelemetrySettings,
+		cfg:                cfg,
+		defaultDimensions:  defaultDimensions,
+		staticDimensions:   staticDimensions,
+		resourceDimensions: resourceDimensions,
+		prevPts:            prevPts,
 	}
 }
 
@@ -74,8 +91,9 @@ type exporter struct {
 	client     *http.Client
 	isDisabled bool
 
-	defaultDimensions dimensions.NormalizedDimensionList
-	staticDimensions  dimensions.NormalizedDimensionList
+	defaultDimensions  dimensions.NormalizedDimensionList
+	staticDimensions   dimensions.NormalizedDimensionList
+	resourceDimensions map[string]bool
 
 	prevPts *ttlmap.TTLMap
 }
@@ -126,6 +144,7 @@ func (e *exporter) serializeMetrics(md pmetric.Metrics) []string {
 
 	for i := 0; i < resourceMetrics.Len(); i++ {
 		resourceMetric := resourceMetrics.At(i)
+		defaultDimensions := e.defaultDimensionsForResource(resourceMetric.Resource().Attributes())
 		libraryMetrics := resourceMetric.ScopeMetrics()
 		for j := 0; j < libraryMetrics.Len(); j++ {
 			libraryMetric := libraryMetrics.At(j)
@@ -133,7 +152,7 @@ func (e *exporter) serializeMetrics(md pmetric.Metrics) []string {
 			for k := 0; k < metrics.Len(); k++ {
 				metric := metrics.At(k)
 
-				metricLines, err := serialization.SerializeMetric(e.settings.Logger, e.cfg.Prefix, metric, e.defaultDimensions, e.staticDimensions, e.prevPts)
+				metricLines, err := serialization.SerializeMetric(e.settings.Logger, e.cfg.Prefix, metric, defaultDimensions, e.staticDimensions, e.prevPts)
 
 				if err != nil {
 					e.settings.Logger.Warn(
@@ -160,6 +179,22 @@ func (e *exporter) serializeMetrics(md pmetric.Metrics) []string {
 	return lines
 }
 
+// defaultDimensionsForResource adds the dimensions taken from the resource
+// attributes to the configured default dimensions.
+func (e *exporter) defaultDimensionsForResource(attrs pcommon.Map) dimensions.NormalizedDimensionList {
+	var dims []dimensions.Dimension
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		if e.resourceDimensions[k] || strings.HasPrefix(k, "dt.entity.") {
+			dims = append(dims, dimensions.NewDimension(k, v.AsString()))
+		}
+		return true
+	})
+	if len(dims) == 0 {
+		return e.defaultDimensions
+	}
+	return dimensions.MergeLists(e.defaultDimensions, dimensions.NewNormalizedDimensionList(dims...))
+}
+
 var lastLog int64
 
 // send sends a serialized metric batch to Dynatrace.
@@ -27,6 +27,7 @@ import (
 
 	"github.com/dynatrace-oss/dynatrace-metric-utils-go/metric/dimensions"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -700,6 +701,43 @@ func Test_exporter_new_with_default_dimensions_override_tag(t *testing.T) {
 	assert.Equal(t, dimensions.NewNormalizedDimensionList(dimensions.NewDimension("from", "default_dimensions")), exp.defaultDimensions)
 }
 
+func Test_exporter_serialize_resource_dimensions(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	rm.Resource().Attributes().PutStr("dt.entity.host", "HOST-1A2B3C4D5E6F7A8B")
+	rm.Resource().Attributes().PutStr("ignored", "value")
+
+	intGaugeMetric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	intGaugeMetric.SetName("int_gauge")
+	intGaugeDataPoint := intGaugeMetric.SetEmptyGauge().DataPoints().AppendEmpty()
+	intGaugeDataPoint.SetIntValue(10)
+	intGaugeDataPoint.SetTimestamp(testTimestamp)
+
+	// A second resource without attributes only gets the default dimensions.
+	otherMetric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	otherMetric.SetName("other_gauge")
+	otherDataPoint := otherMetric.SetEmptyGauge().DataPoints().AppendEmpty()
+	otherDataPoint.SetIntValue(5)
+	otherDataPoint.SetTimestamp(testTimestamp)
+
+	exp := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), &config.Config{
+		DefaultDimensions:  map[string]string{"env": "prod"},
+		ResourceDimensions: []string{"service.name"},
+	})
+
+	lines := exp.serializeMetrics(md)
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "int_gauge,")
+	assert.Contains(t, lines[0], "env=prod")
+	assert.Contains(t, lines[0], "service.name=checkout")
+	assert.Contains(t, lines[0], "dt.entity.host=HOST-1A2B3C4D5E6F7A8B")
+	assert.NotContains(t, lines[0], "ignored")
+	assert.Contains(t, lines[1], "other_gauge,")
+	assert.Contains(t, lines[1], "env=prod")
+	assert.NotContains(t, lines[1], "service.name")
+}
+
 func Test_LineTooLong(t *testing.T) {
 	numDims := 50_000 / 9
 	dims := make(map[string]string, numDims)
@@ -64,6 +64,12 @@ type Config struct {
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest.html
 	Pipeline string `mapstructure:"pipeline"`
 
+	// DeadLetterIndex configures the index that receives the documents
+	// permanently rejected by Elasticsearch, e.g. because of a mapping
+	// conflict, together with the rejection reason. Rejected documents are
+	// dropped when not set.
+	DeadLetterIndex string `mapstructure:"dead_letter_index"`
+
 	HTTPClientSettings `mapstructure:",squash"`
 	Discovery          DiscoverySettings `mapstructure:"discover"`
 	Retry              RetrySettings     `mapstructure:"retry"`
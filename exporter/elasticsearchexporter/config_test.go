@@ -141,6 +141,7 @@ func TestLoadConfig(t *testing.T) {
 		LogsIndex:        "my_log_index",
 		TracesIndex:      "traces-generic-default",
 		Pipeline:         "mypipeline",
+		DeadLetterIndex:  "logs-dead-letter",
 		HTTPClientSettings: HTTPClientSettings{
 			Authentication: AuthenticationSettings{
 				User:     "elastic",
@@ -21,9 +21,12 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -32,6 +35,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/sanitize"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/throttle"
 )
 
 type esClientCurrent = elasticsearch7.Client
@@ -81,7 +85,7 @@ func newElasticsearchClient(logger *zap.Logger, config *Config) (*esClientCurren
 		return nil, err
 	}
 
-	transport := newTransport(config, tlsCfg)
+	var transport http.RoundTripper = newTransport(config, tlsCfg)
 
 	var headers http.Header
 	for k, v := range config.Headers {
@@ -98,6 +102,8 @@ func newElasticsearchClient(logger *zap.Logger, config *Config) (*esClientCurren
 	retryDisabled := !config.Retry.Enabled || maxRetries <= 0
 	if retryDisabled {
 		maxRetries = 0
+	} else {
+		transport = &throttledTransport{RoundTripper: transport, maxRetries: maxRetries, after: time.After}
 	}
 
 	return elasticsearch7.NewClient(esConfigCurrent{
@@ -144,6 +150,45 @@ func newTransport(config *Config, tlsCfg *tls.Config) *http.Transport {
 	return transport
 }
 
+// throttledTransport delays the retries of the bulk requests throttled with a 429 response, by Elasticsearch
+// or by a proxy in front of it, as long as asked by the Retry-After or RateLimit-Reset header of the response.
+// The backoff of the client still applies on top of that delay. The items rejected with a 429 status in a
+// successful bulk response have no such hint, and are retried with the backoff only.
+type throttledTransport struct {
+	http.RoundTripper
+	maxRetries int
+	// attempts counts the throttled attempts of the requests being retried by the client, which sends the same
+	// request again, so that the last attempt isn't delayed.
+	attempts sync.Map
+	after    func(time.Duration) <-chan time.Time
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		t.attempts.Delete(req)
+		return resp, err
+	}
+
+	attempts := 1
+	if previous, ok := t.attempts.Load(req); ok {
+		attempts += previous.(int)
+	}
+	delay := throttle.RetryAfter(resp.Header)
+	if attempts > t.maxRetries || delay == 0 {
+		t.attempts.Delete(req)
+		return resp, nil
+	}
+	t.attempts.Store(req, attempts)
+
+	select {
+	case <-req.Context().Done():
+		t.attempts.Delete(req)
+	case <-t.after(delay):
+	}
+	return resp, nil
+}
+
 func newBulkIndexer(logger *zap.Logger, client *elasticsearch7.Client, config *Config) (esBulkIndexerCurrent, error) {
 	// TODO: add debug logger
 	return esutil7.NewBulkIndexer(esutil7.BulkIndexerConfig{
@@ -192,30 +237,207 @@ func shouldRetryEvent(status int) bool {
 	return false
 }
 
-func pushDocuments(ctx context.Context, logger *zap.Logger, index string, document []byte, bulkIndexer esBulkIndexerCurrent, maxAttempts int) error {
+// bulkItemSettings defines how the items rejected by Elasticsearch in a bulk
+// response are handled.
+type bulkItemSettings struct {
+	// maxAttempts is the maximum number of attempts to index an item, the first
+	// one included.
+	maxAttempts int
+	// initialInterval and maxInterval bound the exponential backoff applied
+	// before an item is retried.
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	// deadLetterIndex receives the items permanently rejected by Elasticsearch.
+	// Such items are dropped when empty.
+	deadLetterIndex string
+}
+
+func newBulkItemSettings(cfg *Config) bulkItemSettings {
+	settings := bulkItemSettings{
+		maxAttempts:     1,
+		deadLetterIndex: cfg.DeadLetterIndex,
+	}
+	if cfg.Retry.Enabled {
+		settings.maxAttempts = cfg.Retry.MaxRequests
+		settings.initialInterval = cfg.Retry.InitialInterval
+		settings.maxInterval = cfg.Retry.MaxInterval
+	}
+	return settings
+}
+
+// retryDelay returns the time to wait before the given attempt of an item is
+// retried.
+func (s bulkItemSettings) retryDelay(attempt int) time.Duration {
+	delay := s.initialInterval
+	for i := 1; i < attempt && (s.maxInterval <= 0 || delay < s.maxInterval); i++ {
+		delay *= 2
+	}
+	if s.maxInterval > 0 && delay > s.maxInterval {
+		delay = s.maxInterval
+	}
+	return delay
+}
+
+// shouldDeadLetter reports whether an item rejected with the given status must
+// be routed to the dead letter index. Only the items permanently rejected by
+// Elasticsearch, e.g. because of a mapping conflict, are routed.
+func (s bulkItemSettings) shouldDeadLetter(index string, status int) bool {
+	return s.deadLetterIndex != "" && index != s.deadLetterIndex && status != 0 && !shouldRetryEvent(status)
+}
+
+// deadLetterDocument is the document indexed into the dead letter index for
+// every item permanently rejected by Elasticsearch.
+type deadLetterDocument struct {
+	Timestamp time.Time        `json:"@timestamp"`
+	Index     string           `json:"index"`
+	Status    int              `json:"status"`
+	Error     deadLetterReason `json:"error"`
+	Document  string           `json:"document"`
+}
+
+type deadLetterReason struct {
+	Type   string `json:"type,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func newDeadLetterDocument(index string, document []byte, resp esBulkIndexerResponseItem) ([]byte, error) {
+	return json.Marshal(deadLetterDocument{
+		Timestamp: time.Now().UTC(),
+		Index:     index,
+		Status:    resp.Status,
+		Error: deadLetterReason{
+			Type:   resp.Error.Type,
+			Reason: resp.Error.Reason,
+		},
+		Document: string(document),
+	})
+}
+
+// bulkItemHandler adds documents to the bulk indexer and handles the items
+// rejected in the bulk responses. Retries and dead letter documents are added
+// back to the bulk indexer from their own goroutine, so that the bulk indexer
+// worker reporting the failures is neither blocked by the backoff nor by adding
+// items to its own queue. The pending retries are dropped on stop.
+type bulkItemHandler struct {
+	logger      *zap.Logger
+	bulkIndexer esBulkIndexerCurrent
+	settings    bulkItemSettings
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+func newBulkItemHandler(logger *zap.Logger, bulkIndexer esBulkIndexerCurrent, settings bulkItemSettings) *bulkItemHandler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &bulkItemHandler{
+		logger:      logger,
+		bulkIndexer: bulkIndexer,
+		settings:    settings,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// stop drops the pending retries and waits for the items being added back to
+// the bulk indexer. It must be called before the bulk indexer is closed.
+func (h *bulkItemHandler) stop() {
+	h.mu.Lock()
+	h.stopped = true
+	h.cancel()
+	h.mu.Unlock()
+	h.wg.Wait()
+}
+
+// later adds the item to the bulk indexer once the delay elapsed. It reports
+// false when the handler is stopped.
+func (h *bulkItemHandler) later(item esBulkIndexerItem, delay time.Duration) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		return false
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-h.ctx.Done():
+			h.logger.Error("Drop docs: exporter stopped before retrying to index",
+				zap.String("name", item.Index))
+			return
+		case <-timer.C:
+		}
+		if err := h.bulkIndexer.Add(h.ctx, item); err != nil {
+			h.logger.Error("Drop docs: failed to add docs to the bulk request buffer.",
+				zap.String("name", item.Index),
+				zap.NamedError("reason", err))
+		}
+	}()
+	return true
+}
+
+func (h *bulkItemHandler) pushDocument(ctx context.Context, index string, document []byte) error {
+	return h.bulkIndexer.Add(ctx, h.newItem(index, document))
+}
+
+func (h *bulkItemHandler) newItem(index string, document []byte) esBulkIndexerItem {
+	logger, settings := h.logger, h.settings
 	attempts := 1
 	body := bytes.NewReader(document)
 	item := esBulkIndexerItem{Action: createAction, Index: index, Body: body}
 	// Setup error handler. The handler handles the per item response status based on the
 	// selective ACKing in the bulk response.
-	item.OnFailure = func(ctx context.Context, item esBulkIndexerItem, resp esBulkIndexerResponseItem, err error) {
+	item.OnFailure = func(_ context.Context, item esBulkIndexerItem, resp esBulkIndexerResponseItem, err error) {
 		switch {
-		case attempts < maxAttempts && shouldRetryEvent(resp.Status):
+		case attempts < settings.maxAttempts && shouldRetryEvent(resp.Status):
 			logger.Debug("Retrying to index",
 				zap.String("name", index),
 				zap.Int("attempt", attempts),
 				zap.Int("status", resp.Status),
 				zap.NamedError("reason", err))
 
+			// Back off before retrying, so that an overloaded cluster (429) is
+			// given time to recover.
+			delay := settings.retryDelay(attempts)
 			attempts++
 			body.Seek(0, io.SeekStart)
-			bulkIndexer.Add(ctx, item)
+			if !h.later(item, delay) {
+				logger.Error("Drop docs: exporter stopped before retrying to index",
+					zap.String("name", index),
+					zap.Int("attempt", attempts-1),
+					zap.Int("status", resp.Status))
+			}
 
 		case resp.Status == 0 && err != nil:
 			// Encoding error. We didn't even attempt to send the event
 			logger.Error("Drop docs: failed to add docs to the bulk request buffer.",
 				zap.NamedError("reason", err))
 
+		case settings.shouldDeadLetter(index, resp.Status):
+			logger.Warn("Routing rejected doc to the dead letter index",
+				zap.String("name", index),
+				zap.String("dead_letter_index", settings.deadLetterIndex),
+				zap.Int("status", resp.Status),
+				zap.String("error.type", resp.Error.Type),
+				zap.String("error.reason", resp.Error.Reason))
+
+			deadLetter, derr := newDeadLetterDocument(index, document, resp)
+			if derr == nil && !h.later(h.newItem(settings.deadLetterIndex, deadLetter), 0) {
+				derr = errors.New("exporter stopped")
+			}
+			if derr != nil {
+				logger.Error("Drop docs: failed to route doc to the dead letter index",
+					zap.String("name", index),
+					zap.String("dead_letter_index", settings.deadLetterIndex),
+					zap.NamedError("reason", derr))
+			}
+
 		case err != nil:
 			logger.Error("Drop docs: failed to index",
 				zap.String("name", index),
@@ -229,6 +451,5 @@ func pushDocuments(ctx context.Context, logger *zap.Logger, index string, docume
 				zap.Int("status", resp.Status))
 		}
 	}
-
-	return bulkIndexer.Add(ctx, item)
+	return item
 }
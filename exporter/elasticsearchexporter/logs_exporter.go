@@ -30,11 +30,11 @@ import (
 type elasticsearchLogsExporter struct {
 	logger *zap.Logger
 
-	index       string
-	maxAttempts int
+	index string
 
 	client      *esClientCurrent
 	bulkIndexer esBulkIndexerCurrent
+	items       *bulkItemHandler
 	model       mappingModel
 }
 
@@ -57,11 +57,6 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 		return nil, err
 	}
 
-	maxAttempts := 1
-	if cfg.Retry.Enabled {
-		maxAttempts = cfg.Retry.MaxRequests
-	}
-
 	// TODO: Apply encoding and field mapping settings.
 	model := &encodeModel{dedup: true, dedot: false}
 
@@ -74,13 +69,14 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 		client:      client,
 		bulkIndexer: bulkIndexer,
 		index:       indexStr,
-		maxAttempts: maxAttempts,
+		items:       newBulkItemHandler(logger, bulkIndexer, newBulkItemSettings(cfg)),
 		model:       model,
 	}
 	return esLogsExp, nil
 }
 
 func (e *elasticsearchLogsExporter) Shutdown(ctx context.Context) error {
+	e.items.stop()
 	return e.bulkIndexer.Close(ctx)
 }
 
@@ -114,5 +110,5 @@ func (e *elasticsearchLogsExporter) pushLogRecord(ctx context.Context, resource
 	if err != nil {
 		return fmt.Errorf("Failed to encode log event: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, e.index, document, e.bulkIndexer, e.maxAttempts)
+	return e.items.pushDocument(ctx, e.index, document)
 }
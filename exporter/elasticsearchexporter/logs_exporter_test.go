@@ -19,8 +19,10 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -173,6 +175,29 @@ func TestExporter_PushEvent(t *testing.T) {
 		rec.WaitItems(1)
 	})
 
+	t.Run("retry throttled http request after the retry-after delay", func(t *testing.T) {
+		var throttledAt time.Time
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			if throttledAt.IsZero() {
+				throttledAt = time.Now()
+				return nil, &httpTestError{status: http.StatusTooManyRequests, message: "slow down", retryAfter: "1"}
+			}
+
+			assert.GreaterOrEqual(t, time.Since(throttledAt), time.Second)
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.Retry.InitialInterval = time.Millisecond
+			cfg.Retry.MaxInterval = 10 * time.Millisecond
+		})
+		mustSend(t, exporter, `{"message": "test1"}`)
+
+		rec.WaitItems(1)
+	})
+
 	t.Run("no retry", func(t *testing.T) {
 		configurations := map[string]func(string) *Config{
 			"max_requests limited": withTestExporterConfig(func(cfg *Config) {
@@ -314,6 +339,119 @@ func TestExporter_PushEvent(t *testing.T) {
 
 		assert.Equal(t, [3]int{1, 2, 1}, attempts)
 	})
+
+	t.Run("retry several items rejected in one response", func(t *testing.T) {
+		var attempts int
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			attempts++
+			if attempts == 1 {
+				return itemsReportStatus(docs, http.StatusTooManyRequests)
+			}
+
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.Flush.Interval = 50 * time.Millisecond
+			cfg.Retry.InitialInterval = 200 * time.Millisecond
+		})
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			mustSend(t, exporter, fmt.Sprintf(`{"message": "test%d"}`, i))
+		}
+
+		rec.WaitItems(5)
+		// The items are backed off together, not one after the other.
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("shutdown drops pending retries", func(t *testing.T) {
+		attempts := atomic.NewInt64(0)
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			attempts.Add(int64(len(docs)))
+			return itemsReportStatus(docs, http.StatusTooManyRequests)
+		})
+
+		exporter, err := newLogsExporter(zaptest.NewLogger(t), withTestExporterConfig(func(cfg *Config) {
+			cfg.Retry.InitialInterval = time.Hour
+		})(server.URL))
+		require.NoError(t, err)
+		mustSend(t, exporter, `{"message": "test1"}`)
+		mustSend(t, exporter, `{"message": "test2"}`)
+
+		require.Eventually(t, func() bool { return attempts.Load() == 2 }, time.Second, 10*time.Millisecond)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, exporter.Shutdown(ctx))
+		assert.Equal(t, int64(2), attempts.Load())
+	})
+
+	t.Run("route rejected item to dead letter index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			resp := make([]itemResponse, len(docs))
+			for i, doc := range docs {
+				resp[i].Status = http.StatusOK
+				if strings.Contains(string(doc.Action), `"logs-generic-default"`) {
+					resp[i].Status = http.StatusBadRequest
+					resp[i].ErrorType = "mapper_parsing_exception"
+					resp[i].ErrorReason = "failed to parse field [message]"
+				}
+			}
+			return resp, nil
+		})
+
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.DeadLetterIndex = "logs-dead-letter"
+		})
+		mustSend(t, exporter, `{"message": "test1"}`)
+
+		rec.WaitItems(2)
+
+		items := rec.Items()
+		assert.Contains(t, string(items[1].Action), `"logs-dead-letter"`)
+
+		var deadLetter struct {
+			Index  string
+			Status int
+			Error  struct {
+				Type   string
+				Reason string
+			}
+			Document string
+		}
+		require.NoError(t, json.Unmarshal(items[1].Document, &deadLetter))
+		assert.Equal(t, "logs-generic-default", deadLetter.Index)
+		assert.Equal(t, http.StatusBadRequest, deadLetter.Status)
+		assert.Equal(t, "mapper_parsing_exception", deadLetter.Error.Type)
+		assert.Equal(t, "failed to parse field [message]", deadLetter.Error.Reason)
+		assert.JSONEq(t, `{"message": "test1"}`, deadLetter.Document)
+	})
+
+	t.Run("do not route retryable item to dead letter index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsReportStatus(docs, http.StatusTooManyRequests)
+		})
+
+		exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+			cfg.DeadLetterIndex = "logs-dead-letter"
+			cfg.Retry.MaxRequests = 2
+			cfg.Retry.InitialInterval = 1 * time.Millisecond
+		})
+		mustSend(t, exporter, `{"message": "test1"}`)
+
+		rec.WaitItems(2)
+		time.Sleep(200 * time.Millisecond)
+		for _, item := range rec.Items() {
+			assert.NotContains(t, string(item.Action), `"logs-dead-letter"`)
+		}
+		assert.Equal(t, 2, rec.NumItems())
+	})
 }
 
 func newTestExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchLogsExporter {
@@ -338,6 +476,6 @@ func withTestExporterConfig(fns ...func(*Config)) func(string) *Config {
 }
 
 func mustSend(t *testing.T, exporter *elasticsearchLogsExporter, contents string) {
-	err := pushDocuments(context.TODO(), zap.L(), exporter.index, []byte(contents), exporter.bulkIndexer, exporter.maxAttempts)
+	err := exporter.items.pushDocument(context.TODO(), exporter.index, []byte(contents))
 	require.NoError(t, err)
 }
@@ -30,11 +30,11 @@ import (
 type elasticsearchTracesExporter struct {
 	logger *zap.Logger
 
-	index       string
-	maxAttempts int
+	index string
 
 	client      *esClientCurrent
 	bulkIndexer esBulkIndexerCurrent
+	items       *bulkItemHandler
 	model       mappingModel
 }
 
@@ -53,11 +53,6 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchTracesExp
 		return nil, err
 	}
 
-	maxAttempts := 1
-	if cfg.Retry.Enabled {
-		maxAttempts = cfg.Retry.MaxRequests
-	}
-
 	// TODO: Apply encoding and field mapping settings.
 	model := &encodeModel{dedup: true, dedot: false}
 
@@ -66,13 +61,14 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchTracesExp
 		client:      client,
 		bulkIndexer: bulkIndexer,
 
-		index:       cfg.TracesIndex,
-		maxAttempts: maxAttempts,
-		model:       model,
+		index: cfg.TracesIndex,
+		items: newBulkItemHandler(logger, bulkIndexer, newBulkItemSettings(cfg)),
+		model: model,
 	}, nil
 }
 
 func (e *elasticsearchTracesExporter) Shutdown(ctx context.Context) error {
+	e.items.stop()
 	return e.bulkIndexer.Close(ctx)
 }
 
@@ -107,5 +103,5 @@ func (e *elasticsearchTracesExporter) pushTraceRecord(ctx context.Context, resou
 	if err != nil {
 		return fmt.Errorf("Failed to encode trace record: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, e.index, document, e.bulkIndexer, e.maxAttempts)
+	return e.items.pushDocument(ctx, e.index, document)
 }
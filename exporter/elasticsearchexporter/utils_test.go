@@ -34,6 +34,10 @@ type itemRequest struct {
 
 type itemResponse struct {
 	Status int `json:"status"`
+
+	// ErrorType and ErrorReason are reported for rejected items, when set.
+	ErrorType   string
+	ErrorReason string
 }
 
 type bulkResult struct {
@@ -45,9 +49,10 @@ type bulkResult struct {
 type bulkHandler func([]itemRequest) ([]itemResponse, error)
 
 type httpTestError struct {
-	status  int
-	message string
-	cause   error
+	status     int
+	message    string
+	cause      error
+	retryAfter string
 }
 
 const currentESVersion = "7.14.0"
@@ -187,6 +192,9 @@ func handleErr(fn func(http.ResponseWriter, *http.Request) error) http.HandlerFu
 		if err != nil {
 			httpError := &httpTestError{}
 			if errors.As(err, &httpError) {
+				if httpError.retryAfter != "" {
+					w.Header().Set("Retry-After", httpError.retryAfter)
+				}
 				http.Error(w, httpError.Message(), httpError.Status())
 			} else {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -197,7 +205,12 @@ func handleErr(fn func(http.ResponseWriter, *http.Request) error) http.HandlerFu
 
 func (item *itemResponse) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
-	fmt.Fprintf(&buf, `{"create": {"status": %v}}`, item.Status)
+	if item.ErrorType == "" {
+		fmt.Fprintf(&buf, `{"create": {"status": %v}}`, item.Status)
+		return buf.Bytes(), nil
+	}
+	fmt.Fprintf(&buf, `{"create": {"status": %v, "error": {"type": %q, "reason": %q}}}`,
+		item.Status, item.ErrorType, item.ErrorReason)
 	return buf.Bytes(), nil
 }
 
@@ -15,7 +15,9 @@
 package googlemanagedprometheusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/googlemanagedprometheusexporter"
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/collector"
 	"github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/collector/googlemanagedprometheus"
@@ -39,6 +41,23 @@ type GMPConfig struct {
 	ProjectID    string                 `mapstructure:"project"`
 	UserAgent    string                 `mapstructure:"user_agent"`
 	ClientConfig collector.ClientConfig `mapstructure:",squash"`
+	MetricConfig MetricConfig           `mapstructure:"metric"`
+}
+
+// MetricConfig configures how metrics are written to GMP.
+type MetricConfig struct {
+	// MaxTimeSeriesPerRequest is the maximum number of time series written in
+	// one CreateTimeSeries request. It can't exceed the API limit of 200.
+	MaxTimeSeriesPerRequest int `mapstructure:"max_time_series_per_request"`
+
+	// MaxRequestsPerSecond limits the rate of CreateTimeSeries requests, to
+	// stay within the project's quota. Zero means no limit.
+	MaxRequestsPerSecond float64 `mapstructure:"max_requests_per_second"`
+
+	// UntypedMetrics are regular expressions matching the names of metrics
+	// scraped from Prometheus untyped metrics. They are written as both the
+	// "unknown" gauge and the "unknown:counter" counter of GMP.
+	UntypedMetrics []string `mapstructure:"untyped_metrics"`
 }
 
 func (c *GMPConfig) toCollectorConfig() collector.Config {
@@ -50,7 +69,7 @@ func (c *GMPConfig) toCollectorConfig() collector.Config {
 	cfg.MetricConfig.InstrumentationLibraryLabels = false
 	cfg.MetricConfig.ServiceResourceLabels = false
 	// Update metric naming to match GMP conventions
-	cfg.MetricConfig.GetMetricName = googlemanagedprometheus.GetMetricName
+	cfg.MetricConfig.GetMetricName = newUntypedMatcher(c.MetricConfig.UntypedMetrics).getMetricName
 	// Map to the prometheus_target monitored resource
 	cfg.MetricConfig.MapMonitoredResource = googlemanagedprometheus.MapToPrometheusTarget
 	cfg.MetricConfig.EnableSumOfSquaredDeviation = true
@@ -68,5 +87,23 @@ func (cfg *Config) Validate() error {
 	if err := collector.ValidateConfig(cfg.toCollectorConfig()); err != nil {
 		return fmt.Errorf("exporter settings are invalid :%w", err)
 	}
+	if err := cfg.MetricConfig.Validate(); err != nil {
+		return fmt.Errorf("exporter settings are invalid :%w", err)
+	}
+	return nil
+}
+
+func (c *MetricConfig) Validate() error {
+	if c.MaxTimeSeriesPerRequest < 1 || c.MaxTimeSeriesPerRequest > maxTimeSeriesPerRequest {
+		return fmt.Errorf("metric.max_time_series_per_request must be between 1 and %d", maxTimeSeriesPerRequest)
+	}
+	if c.MaxRequestsPerSecond < 0 {
+		return errors.New("metric.max_requests_per_second must not be negative")
+	}
+	for _, pattern := range c.UntypedMetrics {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("metric.untyped_metrics: %w", err)
+		}
+	}
 	return nil
 }
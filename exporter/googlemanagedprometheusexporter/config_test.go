@@ -53,6 +53,11 @@ func TestLoadConfig(t *testing.T) {
 			GMPConfig: GMPConfig{
 				ProjectID: "my-project",
 				UserAgent: "opentelemetry-collector-contrib {{version}}",
+				MetricConfig: MetricConfig{
+					MaxTimeSeriesPerRequest: 100,
+					MaxRequestsPerSecond:    5,
+					UntypedMetrics:          []string{"legacy_.*"},
+				},
 			},
 			RetrySettings: exporterhelper.RetrySettings{
 				Enabled:         true,
@@ -67,3 +72,41 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestValidateMetricConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  MetricConfig
+		err  string
+	}{
+		{
+			name: "default",
+			cfg:  createDefaultConfig().(*Config).MetricConfig,
+		},
+		{
+			name: "too many time series per request",
+			cfg:  MetricConfig{MaxTimeSeriesPerRequest: 201},
+			err:  "metric.max_time_series_per_request must be between 1 and 200",
+		},
+		{
+			name: "negative requests per second",
+			cfg:  MetricConfig{MaxTimeSeriesPerRequest: 200, MaxRequestsPerSecond: -1},
+			err:  "metric.max_requests_per_second must not be negative",
+		},
+		{
+			name: "invalid untyped metrics pattern",
+			cfg:  MetricConfig{MaxTimeSeriesPerRequest: 200, UntypedMetrics: []string{"("}},
+			err:  "metric.untyped_metrics: error parsing regexp: missing closing ): `(`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -46,8 +46,13 @@ func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
 		TimeoutSettings:  exporterhelper.TimeoutSettings{Timeout: defaultTimeout},
-		RetrySettings:    exporterhelper.NewDefaultRetrySettings(),
-		QueueSettings:    exporterhelper.NewDefaultQueueSettings(),
+		GMPConfig: GMPConfig{
+			MetricConfig: MetricConfig{
+				MaxTimeSeriesPerRequest: maxTimeSeriesPerRequest,
+			},
+		},
+		RetrySettings: exporterhelper.NewDefaultRetrySettings(),
+		QueueSettings: exporterhelper.NewDefaultQueueSettings(),
 	}
 }
 
@@ -65,7 +70,7 @@ func createMetricsExporter(
 		ctx,
 		params,
 		cfg,
-		mExp.PushMetrics,
+		newMetricsExporter(eCfg.MetricConfig, mExp.PushMetrics).pushMetrics,
 		exporterhelper.WithShutdown(mExp.Shutdown),
 		// Disable exporterhelper Timeout, since we are using a custom mechanism
 		// within exporter itself
@@ -0,0 +1,333 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlemanagedprometheusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/googlemanagedprometheusexporter"
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/collector/googlemanagedprometheus"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+// maxTimeSeriesPerRequest is the hard limit of time series in one
+// CreateTimeSeries request of the GCM API.
+const maxTimeSeriesPerRequest = 200
+
+// untypedMatcher recognizes the metrics scraped from Prometheus untyped metrics.
+type untypedMatcher []*regexp.Regexp
+
+// newUntypedMatcher compiles the patterns, skipping the invalid ones which are
+// reported by the config validation.
+func newUntypedMatcher(patterns []string) untypedMatcher {
+	var m untypedMatcher
+	for _, pattern := range patterns {
+		if re, err := regexp.Compile("^(?:" + pattern + ")$"); err == nil {
+			m = append(m, re)
+		}
+	}
+	return m
+}
+
+func (m untypedMatcher) matches(name string) bool {
+	for _, re := range m {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// getMetricName names untyped metrics according to GMP conventions: the gauge
+// is written as "unknown" and its counter copy as "unknown:counter".
+func (m untypedMatcher) getMetricName(baseName string, metric pmetric.Metric) (string, error) {
+	if m.matches(metric.Name()) {
+		switch metric.Type() {
+		case pmetric.MetricTypeGauge:
+			return baseName + "/unknown", nil
+		case pmetric.MetricTypeSum:
+			return baseName + "/unknown:counter", nil
+		}
+	}
+	return googlemanagedprometheus.GetMetricName(baseName, metric)
+}
+
+// metricsExporter splits metrics in batches that fit in a single
+// CreateTimeSeries request per project, and limits the rate of requests.
+type metricsExporter struct {
+	push      func(context.Context, pmetric.Metrics) error
+	batchSize int
+	untyped   untypedMatcher
+	limiter   *requestLimiter
+}
+
+func newMetricsExporter(cfg MetricConfig, push func(context.Context, pmetric.Metrics) error) *metricsExporter {
+	e := &metricsExporter{
+		push:      push,
+		batchSize: cfg.MaxTimeSeriesPerRequest,
+		untyped:   newUntypedMatcher(cfg.UntypedMetrics),
+	}
+	if cfg.MaxRequestsPerSecond > 0 {
+		e.limiter = &requestLimiter{interval: time.Duration(float64(time.Second) / cfg.MaxRequestsPerSecond)}
+	}
+	return e
+}
+
+// pushMetrics sends the batches, returning the metrics of the batches which
+// failed, to be retried, along with the errors.
+func (e *metricsExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var errs error
+	failed := pmetric.NewMetrics()
+	batches := e.batches(md)
+	for i, batch := range batches {
+		if e.limiter != nil {
+			if err := e.limiter.wait(ctx); err != nil {
+				// the remaining batches are not sent
+				for _, remaining := range batches[i:] {
+					remaining.ResourceMetrics().MoveAndAppendTo(failed.ResourceMetrics())
+				}
+				errs = multierr.Append(errs, err)
+				break
+			}
+		}
+		if err := e.push(ctx, e.withUntypedCounters(batch)); err != nil {
+			batch.ResourceMetrics().MoveAndAppendTo(failed.ResourceMetrics())
+			errs = multierr.Append(errs, err)
+		}
+	}
+	if errs != nil {
+		return consumererror.NewMetrics(errs, failed)
+	}
+	return nil
+}
+
+// batches splits the metrics in batches of at most batchSize time series.
+// The data points of untyped gauges count twice, for their counter copy added
+// to the batch when it's sent.
+func (e *metricsExporter) batches(md pmetric.Metrics) []pmetric.Metrics {
+	b := &batcher{size: e.batchSize}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		b.resource = rm
+		b.hasResource = false
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			b.scope = sm
+			b.hasScope = false
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				metric := ms.At(k)
+				b.addMetric(metric, e.isUntyped(metric))
+			}
+		}
+	}
+	return b.done()
+}
+
+func (e *metricsExporter) isUntyped(metric pmetric.Metric) bool {
+	return metric.Type() == pmetric.MetricTypeGauge && e.untyped.matches(metric.Name())
+}
+
+// withUntypedCounters returns a copy of the batch where the untyped gauges are
+// followed by their counter copy, the batch being left unchanged to be
+// returned as is when it fails.
+func (e *metricsExporter) withUntypedCounters(batch pmetric.Metrics) pmetric.Metrics {
+	if len(e.untyped) == 0 {
+		return batch
+	}
+	req := pmetric.NewMetrics()
+	batch.CopyTo(req)
+	rms := req.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			withCounters := pmetric.NewMetricSlice()
+			for k := 0; k < ms.Len(); k++ {
+				metric := ms.At(k)
+				metric.CopyTo(withCounters.AppendEmpty())
+				if !e.isUntyped(metric) {
+					continue
+				}
+				counter := withCounters.AppendEmpty()
+				counter.SetName(metric.Name())
+				counter.SetDescription(metric.Description())
+				counter.SetUnit(metric.Unit())
+				counter.SetEmptySum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+				counter.Sum().SetIsMonotonic(true)
+				metric.Gauge().DataPoints().CopyTo(counter.Sum().DataPoints())
+			}
+			withCounters.CopyTo(ms)
+		}
+	}
+	return req
+}
+
+// batcher copies data points to batches, creating the resource, scope and
+// metric of the data points in each batch they are copied to.
+type batcher struct {
+	size    int
+	batches []pmetric.Metrics
+	current pmetric.Metrics
+	count   int
+
+	resource    pmetric.ResourceMetrics
+	scope       pmetric.ScopeMetrics
+	hasResource bool
+	hasScope    bool
+	hasMetric   bool
+	dest        pmetric.Metric
+	destRM      pmetric.ResourceMetrics
+	destSM      pmetric.ScopeMetrics
+}
+
+// addMetric copies the data points of the metric, the data points of untyped
+// gauges counting as two time series.
+func (b *batcher) addMetric(metric pmetric.Metric, untyped bool) {
+	b.hasMetric = false
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		weight := 1
+		if untyped {
+			weight = 2
+		}
+		dps := metric.Gauge().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dps.At(l).CopyTo(b.reserve(metric, weight).Gauge().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dps.At(l).CopyTo(b.reserve(metric, 1).Sum().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dps.At(l).CopyTo(b.reserve(metric, 1).Histogram().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			dps.At(l).CopyTo(b.reserve(metric, 1).ExponentialHistogram().DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for l := 0; l < dps.Len(); l++ {
+			// summaries are written as a sum, a count and a series per quantile
+			weight := 2 + dps.At(l).QuantileValues().Len()
+			dps.At(l).CopyTo(b.reserve(metric, weight).Summary().DataPoints().AppendEmpty())
+		}
+	}
+}
+
+// reserve returns the metric of the current batch a data point of the given
+// number of time series is copied to, starting a new batch if it doesn't fit.
+func (b *batcher) reserve(metric pmetric.Metric, weight int) pmetric.Metric {
+	if b.count > 0 && b.count+weight > b.size {
+		b.flush()
+	}
+	if b.count == 0 {
+		b.current = pmetric.NewMetrics()
+	}
+	b.count += weight
+
+	if !b.hasResource {
+		b.destRM = b.current.ResourceMetrics().AppendEmpty()
+		b.destRM.SetSchemaUrl(b.resource.SchemaUrl())
+		b.resource.Resource().CopyTo(b.destRM.Resource())
+		b.hasResource = true
+	}
+	if !b.hasScope {
+		b.destSM = b.destRM.ScopeMetrics().AppendEmpty()
+		b.destSM.SetSchemaUrl(b.scope.SchemaUrl())
+		b.scope.Scope().CopyTo(b.destSM.Scope())
+		b.hasScope = true
+	}
+	if !b.hasMetric {
+		b.dest = b.destSM.Metrics().AppendEmpty()
+		b.dest.SetName(metric.Name())
+		b.dest.SetDescription(metric.Description())
+		b.dest.SetUnit(metric.Unit())
+		switch metric.Type() {
+		case pmetric.MetricTypeGauge:
+			b.dest.SetEmptyGauge()
+		case pmetric.MetricTypeSum:
+			b.dest.SetEmptySum().SetAggregationTemporality(metric.Sum().AggregationTemporality())
+			b.dest.Sum().SetIsMonotonic(metric.Sum().IsMonotonic())
+		case pmetric.MetricTypeHistogram:
+			b.dest.SetEmptyHistogram().SetAggregationTemporality(metric.Histogram().AggregationTemporality())
+		case pmetric.MetricTypeExponentialHistogram:
+			b.dest.SetEmptyExponentialHistogram().SetAggregationTemporality(metric.ExponentialHistogram().AggregationTemporality())
+		case pmetric.MetricTypeSummary:
+			b.dest.SetEmptySummary()
+		}
+		b.hasMetric = true
+	}
+	return b.dest
+}
+
+func (b *batcher) flush() {
+	b.batches = append(b.batches, b.current)
+	b.count = 0
+	b.hasResource = false
+	b.hasScope = false
+	b.hasMetric = false
+}
+
+func (b *batcher) done() []pmetric.Metrics {
+	if b.count > 0 {
+		b.flush()
+	}
+	return b.batches
+}
+
+// requestLimiter spaces requests by a fixed interval.
+type requestLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait blocks until the next request can be sent, or the context is done.
+func (l *requestLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := at.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package googlemanagedprometheusexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func testMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "test")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetEmptyGauge()
+	for i := 0; i < 3; i++ {
+		gauge.Gauge().DataPoints().AppendEmpty().SetDoubleValue(float64(i))
+	}
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("summary")
+	dp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	dp.QuantileValues().AppendEmpty().SetQuantile(0.5)
+	dp.QuantileValues().AppendEmpty().SetQuantile(0.99)
+	return md
+}
+
+// timeSeriesCount counts the time series the batch is written as.
+func timeSeriesCount(md pmetric.Metrics) int {
+	count := 0
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				switch m.Type() {
+				case pmetric.MetricTypeSummary:
+					for l := 0; l < m.Summary().DataPoints().Len(); l++ {
+						count += 2 + m.Summary().DataPoints().At(l).QuantileValues().Len()
+					}
+				case pmetric.MetricTypeGauge:
+					count += m.Gauge().DataPoints().Len()
+				case pmetric.MetricTypeSum:
+					count += m.Sum().DataPoints().Len()
+				}
+			}
+		}
+	}
+	return count
+}
+
+func TestBatches(t *testing.T) {
+	e := newMetricsExporter(MetricConfig{MaxTimeSeriesPerRequest: 2}, nil)
+	batches := e.batches(testMetrics())
+
+	// the summary with two quantiles is four time series on its own
+	require.Len(t, batches, 3)
+	assert.Equal(t, 2, timeSeriesCount(batches[0]))
+	assert.Equal(t, 1, timeSeriesCount(batches[1]))
+	assert.Equal(t, 4, timeSeriesCount(batches[2]))
+
+	for _, batch := range batches {
+		rm := batch.ResourceMetrics().At(0)
+		name, _ := rm.Resource().Attributes().Get("service.name")
+		assert.Equal(t, "test", name.Str())
+		assert.Equal(t, "scope", rm.ScopeMetrics().At(0).Scope().Name())
+	}
+	assert.Equal(t, "gauge", batches[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	assert.Equal(t, 2.0, batches[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).DoubleValue())
+}
+
+func TestBatchesUntypedMetrics(t *testing.T) {
+	e := newMetricsExporter(MetricConfig{MaxTimeSeriesPerRequest: maxTimeSeriesPerRequest, UntypedMetrics: []string{"gau.*"}}, nil)
+	batches := e.batches(testMetrics())
+
+	require.Len(t, batches, 1)
+	require.Equal(t, 2, batches[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len())
+	ms := e.withUntypedCounters(batches[0]).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, ms.Len())
+	assert.Equal(t, pmetric.MetricTypeGauge, ms.At(0).Type())
+	assert.Equal(t, "gauge", ms.At(1).Name())
+	assert.Equal(t, pmetric.MetricTypeSum, ms.At(1).Type())
+	assert.True(t, ms.At(1).Sum().IsMonotonic())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, ms.At(1).Sum().AggregationTemporality())
+	assert.Equal(t, 3, ms.At(1).Sum().DataPoints().Len())
+	assert.Equal(t, pmetric.MetricTypeSummary, ms.At(2).Type())
+
+	name, err := e.untyped.getMetricName("gauge", ms.At(0))
+	require.NoError(t, err)
+	assert.Equal(t, "gauge/unknown", name)
+	name, err = e.untyped.getMetricName("gauge", ms.At(1))
+	require.NoError(t, err)
+	assert.Equal(t, "gauge/unknown:counter", name)
+	name, err = e.untyped.getMetricName("summary", ms.At(2))
+	require.NoError(t, err)
+	assert.Equal(t, "summary/summary", name)
+}
+
+func TestPushMetrics(t *testing.T) {
+	var pushed []pmetric.Metrics
+	e := newMetricsExporter(MetricConfig{MaxTimeSeriesPerRequest: 2, MaxRequestsPerSecond: 20}, func(_ context.Context, md pmetric.Metrics) error {
+		pushed = append(pushed, md)
+		if len(pushed) == 2 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	start := time.Now()
+	err := e.pushMetrics(context.Background(), testMetrics())
+	assert.EqualError(t, err, "failed")
+	assert.Len(t, pushed, 3)
+	// the third request waits for two intervals of 50ms
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	// only the metrics of the failed batch are retried
+	var metricsErr consumererror.Metrics
+	require.ErrorAs(t, err, &metricsErr)
+	assert.Equal(t, e.batches(testMetrics())[1], metricsErr.GetMetrics())
+}
+
+func TestPushMetricsUntypedFailed(t *testing.T) {
+	e := newMetricsExporter(MetricConfig{MaxTimeSeriesPerRequest: maxTimeSeriesPerRequest, UntypedMetrics: []string{"gau.*"}}, func(_ context.Context, md pmetric.Metrics) error {
+		return errors.New("failed")
+	})
+
+	err := e.pushMetrics(context.Background(), testMetrics())
+	var metricsErr consumererror.Metrics
+	require.ErrorAs(t, err, &metricsErr)
+	// the counter copies of the untyped gauges are not retried along with the gauges
+	assert.Equal(t, testMetrics(), metricsErr.GetMetrics())
+}
+
+func TestRequestLimiterCanceled(t *testing.T) {
+	l := &requestLimiter{interval: time.Hour}
+	require.NoError(t, l.wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, l.wait(ctx), context.Canceled)
+}
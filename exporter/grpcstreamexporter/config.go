@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcstreamexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/grpcstreamexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	encodingProto = "proto"
+	encodingJSON  = "json"
+)
+
+// Config defines configuration for the gRPC stream exporter.
+type Config struct {
+	config.ExporterSettings       `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// The number of workers that send the gRPC requests, each on its own stream.
+	NumWorkers int `mapstructure:"num_workers"`
+
+	// DescriptorSet is the path of the compiled descriptor set defining the method, as produced
+	// by `protoc --include_imports --descriptor_set_out`.
+	DescriptorSet string `mapstructure:"descriptor_set"`
+
+	// Method is the full name of the client streaming method the telemetry is sent to,
+	// such as "acme.ingest.v1.Ingest/Push".
+	Method string `mapstructure:"method"`
+
+	// PayloadField is the name of the field of the input message of the method the OTLP data is
+	// set to. The OTLP data is serialized with Encoding when the field is a bytes or a string field,
+	// or decoded from its protobuf encoding when the field is a message, which must then be wire
+	// compatible with the OTLP export request.
+	PayloadField string `mapstructure:"payload_field"`
+
+	// Encoding is the encoding of the OTLP data set to a bytes or string field, either "proto" or "json".
+	// Default: "proto" for bytes fields, "json" for string fields.
+	Encoding string `mapstructure:"encoding"`
+
+	// Fields are the values of the scalar fields of the message set in every message, by name.
+	Fields map[string]string `mapstructure:"fields"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	if cfg.NumWorkers <= 0 {
+		return errors.New("num_workers must be positive")
+	}
+	if cfg.DescriptorSet == "" {
+		return errors.New("descriptor_set must be set")
+	}
+	if _, _, err := splitMethod(cfg.Method); err != nil {
+		return err
+	}
+	if cfg.PayloadField == "" {
+		return errors.New("payload_field must be set")
+	}
+	switch cfg.Encoding {
+	case "", encodingProto, encodingJSON:
+	default:
+		return fmt.Errorf("encoding must be either %q or %q: %q", encodingProto, encodingJSON, cfg.Encoding)
+	}
+	return nil
+}
+
+// splitMethod splits the full name of a method into the full name of its service and its name.
+func splitMethod(method string) (string, string, error) {
+	service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !ok || service == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("method must be the full name of a method, such as \"package.Service/Method\": %q", method)
+	}
+	return service, name, nil
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcstreamexporter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	defaultRetrySettings := exporterhelper.NewDefaultRetrySettings()
+	defaultQueueSettings := exporterhelper.NewDefaultQueueSettings()
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Exporter
+		expectedErr string
+	}{
+		{
+			id: config.NewComponentID(typeStr),
+			expected: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+				GRPCClientSettings: configgrpc.GRPCClientSettings{
+					Endpoint:        "ingest.example.com:4317",
+					Headers:         map[string]string{},
+					WriteBufferSize: 512 * 1024,
+				},
+				RetrySettings: defaultRetrySettings,
+				QueueSettings: defaultQueueSettings,
+				NumWorkers:    2,
+				DescriptorSet: "testdata/ingest.protoset",
+				Method:        "acme.ingest.v1.Ingest/Push",
+				PayloadField:  "payload",
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "allsettings"),
+			expected: &Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+				GRPCClientSettings: configgrpc.GRPCClientSettings{
+					Endpoint:    "ingest.example.com:4317",
+					Compression: "gzip",
+					TLSSetting: configtls.TLSClientSetting{
+						Insecure: true,
+					},
+					Headers:         map[string]string{"x-tenant": "team-a"},
+					WriteBufferSize: 512 * 1024,
+				},
+				RetrySettings: exporterhelper.RetrySettings{
+					Enabled:         false,
+					InitialInterval: defaultRetrySettings.InitialInterval,
+					MaxInterval:     defaultRetrySettings.MaxInterval,
+					MaxElapsedTime:  defaultRetrySettings.MaxElapsedTime,
+				},
+				QueueSettings: exporterhelper.QueueSettings{
+					Enabled:      false,
+					NumConsumers: defaultQueueSettings.NumConsumers,
+					QueueSize:    defaultQueueSettings.QueueSize,
+				},
+				NumWorkers:    4,
+				DescriptorSet: "testdata/ingest.protoset",
+				Method:        "acme.ingest.v1.Ingest/Exchange",
+				PayloadField:  "json_payload",
+				Encoding:      "json",
+				Fields: map[string]string{
+					"tenant":   "team-a",
+					"priority": "PRIORITY_HIGH",
+				},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "noendpoint"),
+			expectedErr: "endpoint must be set",
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "nodescriptorset"),
+			expectedErr: "descriptor_set must be set",
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidmethod"),
+			expectedErr: `method must be the full name of a method, such as "package.Service/Method": "Push"`,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "nopayloadfield"),
+			expectedErr: "payload_field must be set",
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidencoding"),
+			expectedErr: `encoding must be either "proto" or "json": "avro"`,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "noworkers"),
+			expectedErr: "num_workers must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExporter(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
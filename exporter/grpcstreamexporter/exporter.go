@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcstreamexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/grpcstreamexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// See https://godoc.org/google.golang.org/grpc#ClientConn.NewStream
+// why we need to keep the cancel func to cancel the stream
+type streamWithCancel struct {
+	cancel context.CancelFunc
+	stream grpc.ClientStream
+}
+
+type streamExporter struct {
+	cfg     *Config
+	builder *messageBuilder
+	// In the channel we keep always NumWorkers object (sometimes nil),
+	// to make sure we don't open more than NumWorkers streams at any moment.
+	streams        chan *streamWithCancel
+	grpcClientConn *grpc.ClientConn
+
+	tracesMarshaler  ptrace.Marshaler
+	metricsMarshaler pmetric.Marshaler
+	logsMarshaler    plog.Marshaler
+
+	settings component.TelemetrySettings
+}
+
+func newStreamExporter(cfg *Config, settings component.TelemetrySettings) *streamExporter {
+	return &streamExporter{
+		cfg:      cfg,
+		streams:  make(chan *streamWithCancel, cfg.NumWorkers),
+		settings: settings,
+	}
+}
+
+// start loads the descriptor set, and creates the gRPC client connection.
+func (e *streamExporter) start(ctx context.Context, host component.Host) error {
+	builder, err := newMessageBuilder(e.cfg)
+	if err != nil {
+		return err
+	}
+	e.builder = builder
+	if builder.encoding == encodingJSON {
+		e.tracesMarshaler = ptrace.NewJSONMarshaler()
+		e.metricsMarshaler = pmetric.NewJSONMarshaler()
+		e.logsMarshaler = plog.NewJSONMarshaler()
+	} else {
+		e.tracesMarshaler = ptrace.NewProtoMarshaler()
+		e.metricsMarshaler = pmetric.NewProtoMarshaler()
+		e.logsMarshaler = plog.NewProtoMarshaler()
+	}
+
+	dialOpts, err := e.cfg.GRPCClientSettings.ToDialOptions(host, e.settings)
+	if err != nil {
+		return err
+	}
+	if e.grpcClientConn, err = grpc.DialContext(ctx, e.cfg.GRPCClientSettings.Endpoint, dialOpts...); err != nil {
+		return err
+	}
+
+	for i := 0; i < e.cfg.NumWorkers; i++ {
+		// Populate the channel with NumWorkers nil streams to keep the number of workers
+		// constant in the channel.
+		e.streams <- nil
+	}
+	return nil
+}
+
+// shutdown half-closes the streams, waiting for the server to acknowledge them, and closes the connection.
+func (e *streamExporter) shutdown(ctx context.Context) error {
+	if e.grpcClientConn == nil {
+		return nil
+	}
+	var errs error
+	// First remove all the streams from the channel.
+	for i := 0; i < e.cfg.NumWorkers; i++ {
+		if s := <-e.streams; s != nil {
+			errs = multierr.Append(errs, e.closeStream(ctx, s))
+		}
+	}
+	// Now close the channel
+	close(e.streams)
+	return multierr.Append(errs, e.grpcClientConn.Close())
+}
+
+func (e *streamExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
+	payload, err := e.tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.send(payload)
+}
+
+func (e *streamExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
+	payload, err := e.metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.send(payload)
+}
+
+func (e *streamExporter) pushLogs(_ context.Context, ld plog.Logs) error {
+	payload, err := e.logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.send(payload)
+}
+
+func (e *streamExporter) send(payload []byte) error {
+	msg, err := e.builder.build(payload)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	// Get first available stream.
+	s, ok := <-e.streams
+	if !ok {
+		return errors.New("failed to push data, gRPC stream exporter was already stopped")
+	}
+
+	// Here check if the stream is nil and create a new one if that is the case. A nil
+	// object means that an error happened: could not connect, service went down, etc.
+	if s == nil {
+		s, err = e.createStream()
+		if err != nil {
+			// Cannot create a stream, put back nil to keep the number of workers constant.
+			e.streams <- nil
+			return err
+		}
+	}
+
+	if err = s.stream.SendMsg(msg); err != nil {
+		// The stream was closed by the server, the status of the stream is returned by RecvMsg.
+		if errors.Is(err, io.EOF) && !e.builder.streamDesc.ServerStreams {
+			if err = s.stream.RecvMsg(nil); err == nil {
+				err = errors.New("stream closed by the server")
+			}
+		}
+		// Error received, cancel the context used to create the stream to free all resources,
+		// put back nil to keep the number of workers constant.
+		s.cancel()
+		e.streams <- nil
+		return err
+	}
+	e.streams <- s
+	return nil
+}
+
+func (e *streamExporter) createStream() (*streamWithCancel, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if len(e.cfg.Headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(e.cfg.Headers))
+	}
+	// Cannot use grpc.WaitForReady(cfg.WaitForReady) because will block forever.
+	stream, err := e.grpcClientConn.NewStream(ctx, &e.builder.streamDesc, e.builder.fullMethod, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to open a stream to %q: %w", e.builder.fullMethod, err)
+	}
+	if e.builder.streamDesc.ServerStreams {
+		// The responses of bidirectional streams are discarded, so that the server isn't blocked.
+		go func() {
+			for {
+				if err := stream.RecvMsg(nil); err != nil {
+					return
+				}
+			}
+		}()
+	}
+	return &streamWithCancel{cancel: cancel, stream: stream}, nil
+}
+
+func (e *streamExporter) closeStream(ctx context.Context, s *streamWithCancel) error {
+	defer s.cancel()
+	if err := s.stream.CloseSend(); err != nil {
+		return err
+	}
+	if e.builder.streamDesc.ServerStreams {
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.stream.RecvMsg(nil)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcstreamexporter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// bytesCodec receives the messages as raw bytes.
+type bytesCodec struct{}
+
+func (bytesCodec) Marshal(v interface{}) ([]byte, error) {
+	return v.([]byte), nil
+}
+
+func (bytesCodec) Unmarshal(data []byte, v interface{}) error {
+	*(v.(*[]byte)) = append([]byte(nil), data...)
+	return nil
+}
+
+func (bytesCodec) Name() string {
+	return "proto"
+}
+
+// ingestServer records the messages received on the streams of any method.
+type ingestServer struct {
+	mu       sync.Mutex
+	methods  []string
+	headers  []metadata.MD
+	messages [][]byte
+	streams  int
+	// failFirstStream closes the first stream with an error after receiving its first message.
+	failFirstStream bool
+}
+
+func startIngestServer(t *testing.T, srv *ingestServer) string {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.ForceServerCodec(bytesCodec{}), grpc.UnknownServiceHandler(srv.handle))
+	go func() {
+		_ = server.Serve(ln)
+	}()
+	t.Cleanup(server.Stop)
+	return ln.Addr().String()
+}
+
+func (s *ingestServer) handle(_ interface{}, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.mu.Lock()
+	s.streams++
+	fail := s.failFirstStream && s.streams == 1
+	s.methods = append(s.methods, method)
+	s.headers = append(s.headers, md)
+	s.mu.Unlock()
+
+	for {
+		var buf []byte
+		err := stream.RecvMsg(&buf)
+		if errors.Is(err, io.EOF) {
+			// the acknowledgement is an empty Ack message
+			return stream.SendMsg([]byte{})
+		}
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.messages = append(s.messages, buf)
+		s.mu.Unlock()
+
+		if fail {
+			return status.Error(codes.Unavailable, "ingestion paused")
+		}
+	}
+}
+
+func (s *ingestServer) received() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.messages)
+}
+
+func newTestExporter(t *testing.T, cfg *Config) *streamExporter {
+	exp := newStreamExporter(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func TestPushOnClientStream(t *testing.T) {
+	srv := &ingestServer{}
+	cfg := newTestConfig("acme.ingest.v1.Ingest/Push", "payload")
+	cfg.Endpoint = startIngestServer(t, srv)
+	cfg.TLSSetting.Insecure = true
+	cfg.Headers = map[string]string{"x-tenant": "team-a"}
+	cfg.NumWorkers = 1
+	cfg.Fields = map[string]string{"tenant": "team-a"}
+	exp := newTestExporter(t, cfg)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("checkout")
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("started")
+
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+	require.NoError(t, exp.pushMetrics(context.Background(), md))
+	require.NoError(t, exp.pushLogs(context.Background(), ld))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	// all the messages are sent on the same stream, which is acknowledged on shutdown
+	assert.Equal(t, 1, srv.streams)
+	assert.Equal(t, []string{"/acme.ingest.v1.Ingest/Push"}, srv.methods)
+	assert.Equal(t, []string{"team-a"}, srv.headers[0].Get("x-tenant"))
+	require.Len(t, srv.messages, 3)
+
+	records := make([]*dynamicpb.Message, len(srv.messages))
+	for i, buf := range srv.messages {
+		records[i] = dynamicpb.NewMessage(exp.builder.message)
+		require.NoError(t, proto.Unmarshal(buf, records[i]))
+		assert.Equal(t, "team-a", records[i].Get(exp.builder.message.Fields().ByName("tenant")).String())
+	}
+	payload := exp.builder.payload
+
+	traces, err := ptrace.NewProtoUnmarshaler().UnmarshalTraces(records[0].Get(payload).Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, td, traces)
+	metrics, err := pmetric.NewProtoUnmarshaler().UnmarshalMetrics(records[1].Get(payload).Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, md, metrics)
+	logs, err := plog.NewProtoUnmarshaler().UnmarshalLogs(records[2].Get(payload).Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, ld, logs)
+}
+
+func TestPushOnBidirectionalStream(t *testing.T) {
+	srv := &ingestServer{}
+	cfg := newTestConfig("acme.ingest.v1.Ingest/Exchange", "json_payload")
+	cfg.Endpoint = startIngestServer(t, srv)
+	cfg.TLSSetting.Insecure = true
+	exp := newTestExporter(t, cfg)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("started")
+	require.NoError(t, exp.pushLogs(context.Background(), ld))
+	require.Eventually(t, func() bool { return srv.received() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	record := dynamicpb.NewMessage(exp.builder.message)
+	require.NoError(t, proto.Unmarshal(srv.messages[0], record))
+	logs, err := plog.NewJSONUnmarshaler().UnmarshalLogs([]byte(record.Get(exp.builder.payload).String()))
+	require.NoError(t, err)
+	assert.Equal(t, ld, logs)
+}
+
+func TestPushReopensFailedStream(t *testing.T) {
+	srv := &ingestServer{failFirstStream: true}
+	cfg := newTestConfig("acme.ingest.v1.Ingest/Push", "payload")
+	cfg.Endpoint = startIngestServer(t, srv)
+	cfg.TLSSetting.Insecure = true
+	cfg.NumWorkers = 1
+	exp := newTestExporter(t, cfg)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+	require.Eventually(t, func() bool { return srv.received() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// the stream is closed by the server, and its status is returned
+	var err error
+	require.Eventually(t, func() bool {
+		err = exp.pushTraces(context.Background(), td)
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// a new stream is opened for the next data
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, 2, srv.streams)
+}
+
+func TestPushAfterShutdown(t *testing.T) {
+	cfg := newTestConfig("acme.ingest.v1.Ingest/Push", "payload")
+	cfg.Endpoint = startIngestServer(t, &ingestServer{})
+	cfg.TLSSetting.Insecure = true
+	exp := newTestExporter(t, cfg)
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	assert.EqualError(t, exp.pushTraces(context.Background(), ptrace.NewTraces()), "failed to push data, gRPC stream exporter was already stopped")
+}
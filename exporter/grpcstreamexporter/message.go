@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcstreamexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/grpcstreamexporter"
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// messageBuilder wraps the OTLP data in the input messages of the configured method.
+type messageBuilder struct {
+	fullMethod string
+	streamDesc grpc.StreamDesc
+	message    protoreflect.MessageDescriptor
+	payload    protoreflect.FieldDescriptor
+	encoding   string
+	fields     map[protoreflect.FieldDescriptor]protoreflect.Value
+}
+
+func newMessageBuilder(cfg *Config) (*messageBuilder, error) {
+	buf, err := os.ReadFile(cfg.DescriptorSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the descriptor set: %w", err)
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(buf, set); err != nil {
+		return nil, fmt.Errorf("failed to decode the descriptor set %q: %w", cfg.DescriptorSet, err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set %q: %w", cfg.DescriptorSet, err)
+	}
+
+	serviceName, methodName, err := splitMethod(cfg.Method)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, fmt.Errorf("service %q not found in the descriptor set: %w", serviceName, err)
+	}
+	service, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a service", serviceName)
+	}
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, fmt.Errorf("method %q not found in service %q", methodName, serviceName)
+	}
+	if !method.IsStreamingClient() {
+		return nil, fmt.Errorf("method %q is not a client streaming method", cfg.Method)
+	}
+
+	b := &messageBuilder{
+		fullMethod: "/" + serviceName + "/" + methodName,
+		streamDesc: grpc.StreamDesc{
+			StreamName:    methodName,
+			ClientStreams: true,
+			ServerStreams: method.IsStreamingServer(),
+		},
+		message:  method.Input(),
+		encoding: cfg.Encoding,
+		fields:   make(map[protoreflect.FieldDescriptor]protoreflect.Value, len(cfg.Fields)),
+	}
+
+	b.payload = b.message.Fields().ByName(protoreflect.Name(cfg.PayloadField))
+	if b.payload == nil {
+		return nil, fmt.Errorf("payload field %q not found in message %q", cfg.PayloadField, b.message.FullName())
+	}
+	if b.payload.IsList() || b.payload.IsMap() {
+		return nil, fmt.Errorf("payload field %q must not be repeated", cfg.PayloadField)
+	}
+	switch b.payload.Kind() {
+	case protoreflect.BytesKind:
+		if b.encoding == "" {
+			b.encoding = encodingProto
+		}
+	case protoreflect.StringKind:
+		if b.encoding == "" {
+			b.encoding = encodingJSON
+		}
+		if b.encoding != encodingJSON {
+			return nil, fmt.Errorf("payload field %q is a string field, which requires the %q encoding", cfg.PayloadField, encodingJSON)
+		}
+	case protoreflect.MessageKind:
+		if b.encoding != "" && b.encoding != encodingProto {
+			return nil, fmt.Errorf("payload field %q is a message field, which requires the %q encoding", cfg.PayloadField, encodingProto)
+		}
+		b.encoding = encodingProto
+	default:
+		return nil, fmt.Errorf("payload field %q must be a bytes, string or message field", cfg.PayloadField)
+	}
+
+	for name, value := range cfg.Fields {
+		field := b.message.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil, fmt.Errorf("field %q not found in message %q", name, b.message.FullName())
+		}
+		if field == b.payload {
+			return nil, fmt.Errorf("field %q is the payload field", name)
+		}
+		v, err := parseScalar(field, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of field %q: %w", name, err)
+		}
+		b.fields[field] = v
+	}
+	return b, nil
+}
+
+// build returns the encoded message holding the OTLP data, already serialized with the encoding of the builder.
+func (b *messageBuilder) build(payload []byte) ([]byte, error) {
+	msg := dynamicpb.NewMessage(b.message)
+	for field, value := range b.fields {
+		msg.Set(field, value)
+	}
+
+	switch b.payload.Kind() {
+	case protoreflect.MessageKind:
+		if err := proto.Unmarshal(payload, msg.Mutable(b.payload).Message().Interface()); err != nil {
+			return nil, fmt.Errorf("failed to decode the OTLP data as %q: %w", b.payload.Message().FullName(), err)
+		}
+	case protoreflect.StringKind:
+		msg.Set(b.payload, protoreflect.ValueOfString(string(payload)))
+	default:
+		msg.Set(b.payload, protoreflect.ValueOfBytes(payload))
+	}
+	return proto.Marshal(msg)
+}
+
+// parseScalar parses the value of a scalar field.
+func parseScalar(field protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	if field.IsList() || field.IsMap() {
+		return protoreflect.Value{}, fmt.Errorf("not a scalar field")
+	}
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(value)), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(value)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(value, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(value, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(value, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(value, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(value, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(value)); enumValue != nil {
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown value of enum %q: %q", field.Enum().FullName(), value)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("not a scalar field")
+	}
+}
+
+// rawCodec sends the messages already encoded by the message builder, and ignores the responses.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	buf, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return buf, nil
+}
+
+func (rawCodec) Unmarshal([]byte, interface{}) error {
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcstreamexporter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func newTestConfig(method string, payloadField string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DescriptorSet = filepath.Join("testdata", "ingest.protoset")
+	cfg.Method = method
+	cfg.PayloadField = payloadField
+	return cfg
+}
+
+// decodeRecord decodes a message built by the builder.
+func decodeRecord(t *testing.T, b *messageBuilder, buf []byte) protoreflect.Message {
+	msg := dynamicpb.NewMessage(b.message)
+	require.NoError(t, proto.Unmarshal(buf, msg))
+	return msg
+}
+
+func TestNewMessageBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:        "missing descriptor set",
+			modify:      func(cfg *Config) { cfg.DescriptorSet = filepath.Join("testdata", "missing.protoset") },
+			expectedErr: "failed to read the descriptor set",
+		},
+		{
+			name:        "invalid descriptor set",
+			modify:      func(cfg *Config) { cfg.DescriptorSet = filepath.Join("testdata", "ingest.proto") },
+			expectedErr: "failed to decode the descriptor set",
+		},
+		{
+			name:        "unknown service",
+			modify:      func(cfg *Config) { cfg.Method = "acme.ingest.v1.Unknown/Push" },
+			expectedErr: `service "acme.ingest.v1.Unknown" not found in the descriptor set`,
+		},
+		{
+			name:        "not a service",
+			modify:      func(cfg *Config) { cfg.Method = "acme.ingest.v1.Record/Push" },
+			expectedErr: `"acme.ingest.v1.Record" is not a service`,
+		},
+		{
+			name:        "unknown method",
+			modify:      func(cfg *Config) { cfg.Method = "acme.ingest.v1.Ingest/Pull" },
+			expectedErr: `method "Pull" not found in service "acme.ingest.v1.Ingest"`,
+		},
+		{
+			name:        "unary method",
+			modify:      func(cfg *Config) { cfg.Method = "acme.ingest.v1.Ingest/Send" },
+			expectedErr: `method "acme.ingest.v1.Ingest/Send" is not a client streaming method`,
+		},
+		{
+			name:        "unknown payload field",
+			modify:      func(cfg *Config) { cfg.PayloadField = "data" },
+			expectedErr: `payload field "data" not found in message "acme.ingest.v1.Record"`,
+		},
+		{
+			name:        "repeated payload field",
+			modify:      func(cfg *Config) { cfg.PayloadField = "tags" },
+			expectedErr: `payload field "tags" must not be repeated`,
+		},
+		{
+			name:        "scalar payload field",
+			modify:      func(cfg *Config) { cfg.PayloadField = "shard" },
+			expectedErr: `payload field "shard" must be a bytes, string or message field`,
+		},
+		{
+			name: "proto encoding of string payload field",
+			modify: func(cfg *Config) {
+				cfg.PayloadField = "json_payload"
+				cfg.Encoding = encodingProto
+			},
+			expectedErr: `payload field "json_payload" is a string field, which requires the "json" encoding`,
+		},
+		{
+			name: "json encoding of message payload field",
+			modify: func(cfg *Config) {
+				cfg.PayloadField = "request"
+				cfg.Encoding = encodingJSON
+			},
+			expectedErr: `payload field "request" is a message field, which requires the "proto" encoding`,
+		},
+		{
+			name:        "unknown field",
+			modify:      func(cfg *Config) { cfg.Fields = map[string]string{"region": "eu"} },
+			expectedErr: `field "region" not found in message "acme.ingest.v1.Record"`,
+		},
+		{
+			name:        "payload field set",
+			modify:      func(cfg *Config) { cfg.Fields = map[string]string{"payload": "eu"} },
+			expectedErr: `field "payload" is the payload field`,
+		},
+		{
+			name:        "invalid integer field",
+			modify:      func(cfg *Config) { cfg.Fields = map[string]string{"shard": "first"} },
+			expectedErr: `invalid value of field "shard"`,
+		},
+		{
+			name:        "invalid enum field",
+			modify:      func(cfg *Config) { cfg.Fields = map[string]string{"priority": "PRIORITY_LOW"} },
+			expectedErr: `invalid value of field "priority": unknown value of enum "acme.ingest.v1.Record.Priority": "PRIORITY_LOW"`,
+		},
+		{
+			name:        "repeated field",
+			modify:      func(cfg *Config) { cfg.Fields = map[string]string{"tags": "a"} },
+			expectedErr: `invalid value of field "tags": not a scalar field`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig("acme.ingest.v1.Ingest/Push", "payload")
+			tt.modify(cfg)
+			_, err := newMessageBuilder(cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestMessageBuilder(t *testing.T) {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+	protoPayload, err := ptrace.NewProtoMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+
+	t.Run("bytes payload field", func(t *testing.T) {
+		cfg := newTestConfig("/acme.ingest.v1.Ingest/Push", "payload")
+		cfg.Fields = map[string]string{
+			"tenant":   "team-a",
+			"priority": "PRIORITY_HIGH",
+			"shard":    "-3",
+		}
+		b, err := newMessageBuilder(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, "/acme.ingest.v1.Ingest/Push", b.fullMethod)
+		assert.False(t, b.streamDesc.ServerStreams)
+		assert.Equal(t, encodingProto, b.encoding)
+
+		buf, err := b.build(protoPayload)
+		require.NoError(t, err)
+		msg := decodeRecord(t, b, buf)
+		fields := b.message.Fields()
+		assert.Equal(t, "team-a", msg.Get(fields.ByName("tenant")).String())
+		assert.Equal(t, protoreflect.EnumNumber(1), msg.Get(fields.ByName("priority")).Enum())
+		assert.Equal(t, int64(-3), msg.Get(fields.ByName("shard")).Int())
+		assert.Equal(t, protoPayload, msg.Get(fields.ByName("payload")).Bytes())
+	})
+
+	t.Run("string payload field", func(t *testing.T) {
+		b, err := newMessageBuilder(newTestConfig("acme.ingest.v1.Ingest/Exchange", "json_payload"))
+		require.NoError(t, err)
+		assert.True(t, b.streamDesc.ServerStreams)
+		assert.Equal(t, encodingJSON, b.encoding)
+
+		buf, err := b.build([]byte(`{"resourceSpans":[]}`))
+		require.NoError(t, err)
+		msg := decodeRecord(t, b, buf)
+		assert.Equal(t, `{"resourceSpans":[]}`, msg.Get(b.message.Fields().ByName("json_payload")).String())
+	})
+
+	t.Run("message payload field", func(t *testing.T) {
+		b, err := newMessageBuilder(newTestConfig("acme.ingest.v1.Ingest/Push", "request"))
+		require.NoError(t, err)
+
+		buf, err := b.build(protoPayload)
+		require.NoError(t, err)
+		msg := decodeRecord(t, b, buf)
+		request := msg.Get(b.message.Fields().ByName("request")).Message()
+		resourceData := request.Get(request.Descriptor().Fields().ByName("resource_data")).List()
+		require.Equal(t, 1, resourceData.Len())
+
+		// the message is wire compatible with the OTLP export request
+		requestBuf, err := proto.Marshal(request.Interface())
+		require.NoError(t, err)
+		decoded, err := ptrace.NewProtoUnmarshaler().UnmarshalTraces(requestBuf)
+		require.NoError(t, err)
+		assert.Equal(t, td, decoded)
+
+		_, err = b.build([]byte{0xff})
+		assert.Error(t, err)
+	})
+}
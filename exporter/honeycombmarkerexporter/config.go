@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombmarkerexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/honeycombmarkerexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for the Honeycomb marker exporter.
+type Config struct {
+	config.ExporterSettings       `mapstructure:",squash"`
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// APIKey is the Honeycomb API key, which must have the permission to manage markers.
+	APIKey string `mapstructure:"api_key"`
+
+	// Markers defines the log records to convert to markers.
+	Markers []MarkerConfig `mapstructure:"markers"`
+}
+
+// MarkerConfig defines a kind of marker, and the log records it's created from.
+type MarkerConfig struct {
+	// Type is the type of the marker, such as "deploy".
+	Type string `mapstructure:"type"`
+
+	// Attributes are the attributes a log record, or its resource, must have to be converted to
+	// the marker, each with the given value. An empty value matches any value of the attribute.
+	Attributes map[string]string `mapstructure:"attributes"`
+
+	// MessageKey is the attribute holding the message of the marker.
+	// Default: the body of the log record.
+	MessageKey string `mapstructure:"message_key"`
+
+	// URLKey is the attribute holding the URL of the marker, such as the URL of the build.
+	// Default: no URL.
+	URLKey string `mapstructure:"url_key"`
+
+	// DatasetSlug is the dataset to add the marker to.
+	// Default: "__all__", the marker is added to all the datasets of the environment.
+	DatasetSlug string `mapstructure:"dataset_slug"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.APIKey == "" {
+		return errors.New("api_key must be set")
+	}
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must be set")
+	}
+	if len(cfg.Markers) == 0 {
+		return errors.New("at least one marker must be configured")
+	}
+	for i, marker := range cfg.Markers {
+		if marker.Type == "" {
+			return fmt.Errorf("markers[%d]: type must be set", i)
+		}
+		if len(marker.Attributes) == 0 {
+			return fmt.Errorf("markers[%d]: attributes must be set", i)
+		}
+	}
+	return nil
+}
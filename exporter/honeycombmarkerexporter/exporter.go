@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombmarkerexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/honeycombmarkerexporter"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// marker is the body of a request creating a marker, as documented at
+// https://docs.honeycomb.io/api/markers/
+type marker struct {
+	StartTime int64  `json:"start_time"`
+	Message   string `json:"message,omitempty"`
+	Type      string `json:"type"`
+	URL       string `json:"url,omitempty"`
+}
+
+type markerExporter struct {
+	config   *Config
+	settings component.TelemetrySettings
+	client   *http.Client
+}
+
+func newMarkerExporter(config *Config, settings component.TelemetrySettings) *markerExporter {
+	return &markerExporter{
+		config:   config,
+		settings: settings,
+	}
+}
+
+func (e *markerExporter) start(_ context.Context, host component.Host) (err error) {
+	e.client, err = e.config.HTTPClientSettings.ToClient(host, e.settings)
+	return err
+}
+
+// exportMarkers creates a marker for each log record matching a marker configuration. The log records
+// for which a marker couldn't be created because of a transient error are returned once, with their
+// resource and scope, to be retried. All the markers of a retried log record are created again.
+func (e *markerExporter) exportMarkers(ctx context.Context, ld plog.Logs) error {
+	var errs, permanentErrs error
+	failed := plog.NewLogs()
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		failedRl := plog.NewResourceLogs()
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			failedSl := plog.NewScopeLogs()
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				err, permanentErr := e.exportRecordMarkers(ctx, rl.Resource(), lr)
+				permanentErrs = multierr.Append(permanentErrs, permanentErr)
+				if err != nil {
+					errs = multierr.Append(errs, err)
+					lr.CopyTo(failedSl.LogRecords().AppendEmpty())
+				}
+			}
+			if failedSl.LogRecords().Len() > 0 {
+				sl.Scope().CopyTo(failedSl.Scope())
+				failedSl.SetSchemaUrl(sl.SchemaUrl())
+				failedSl.MoveTo(failedRl.ScopeLogs().AppendEmpty())
+			}
+		}
+		if failedRl.ScopeLogs().Len() > 0 {
+			rl.Resource().CopyTo(failedRl.Resource())
+			failedRl.SetSchemaUrl(rl.SchemaUrl())
+			failedRl.MoveTo(failed.ResourceLogs().AppendEmpty())
+		}
+	}
+
+	if errs != nil {
+		return consumererror.NewLogs(multierr.Append(errs, permanentErrs), failed)
+	}
+	if permanentErrs != nil {
+		return consumererror.NewPermanent(permanentErrs)
+	}
+	return nil
+}
+
+// exportRecordMarkers creates the markers of the log record, returning separately the transient
+// errors, for which the log record should be retried, and the permanent ones.
+func (e *markerExporter) exportRecordMarkers(ctx context.Context, resource pcommon.Resource, lr plog.LogRecord) (errs, permanentErrs error) {
+	for _, markerCfg := range e.config.Markers {
+		if !matches(markerCfg, resource, lr) {
+			continue
+		}
+		err := e.sendMarker(ctx, markerCfg, newMarker(markerCfg, resource, lr))
+		if consumererror.IsPermanent(err) {
+			permanentErrs = multierr.Append(permanentErrs, err)
+		} else {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	return errs, permanentErrs
+}
+
+func (e *markerExporter) sendMarker(ctx context.Context, markerCfg MarkerConfig, m marker) error {
+	body, err := json.Marshal(m)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	datasetSlug := markerCfg.DatasetSlug
+	if datasetSlug == "" {
+		datasetSlug = defaultDatasetSlug
+	}
+	endpoint := strings.TrimSuffix(e.config.Endpoint, "/") + "/1/markers/" + url.PathEscape(datasetSlug)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Honeycomb-Team", e.config.APIKey)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to create the %q marker: %w", m.Type, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		e.settings.Logger.Debug("failed to create the marker", zap.String("response", string(respBody)))
+		err = fmt.Errorf("failed to create the %q marker: got http status %s", m.Type, resp.Status)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return consumererror.NewPermanent(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// matches tells whether the log record, or its resource, has the attributes of the marker configuration.
+func matches(markerCfg MarkerConfig, resource pcommon.Resource, lr plog.LogRecord) bool {
+	for key, expected := range markerCfg.Attributes {
+		value, ok := lookup(key, resource, lr)
+		if !ok || (expected != "" && value != expected) {
+			return false
+		}
+	}
+	return true
+}
+
+func newMarker(markerCfg MarkerConfig, resource pcommon.Resource, lr plog.LogRecord) marker {
+	timestamp := lr.Timestamp()
+	if timestamp == 0 {
+		timestamp = lr.ObservedTimestamp()
+	}
+	startTime := timestamp.AsTime().Unix()
+	if timestamp == 0 {
+		startTime = time.Now().Unix()
+	}
+
+	m := marker{
+		StartTime: startTime,
+		Message:   lr.Body().AsString(),
+		Type:      markerCfg.Type,
+	}
+	if markerCfg.MessageKey != "" {
+		m.Message, _ = lookup(markerCfg.MessageKey, resource, lr)
+	}
+	if markerCfg.URLKey != "" {
+		m.URL, _ = lookup(markerCfg.URLKey, resource, lr)
+	}
+	return m
+}
+
+// lookup returns the value of the attribute of the log record, or of its resource.
+func lookup(key string, resource pcommon.Resource, lr plog.LogRecord) (string, bool) {
+	if value, ok := lr.Attributes().Get(key); ok {
+		return value.AsString(), true
+	}
+	if value, ok := resource.Attributes().Get(key); ok {
+		return value.AsString(), true
+	}
+	return "", false
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package honeycombmarkerexporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type receivedMarker struct {
+	path   string
+	apiKey string
+	marker marker
+}
+
+func newTestServer(t *testing.T, status int, received *[]receivedMarker) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m marker
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&m))
+		*received = append(*received, receivedMarker{
+			path:   r.URL.Path,
+			apiKey: r.Header.Get("X-Honeycomb-Team"),
+			marker: m,
+		})
+		w.WriteHeader(status)
+	}))
+}
+
+func newTestExporter(t *testing.T, endpoint string, markers ...MarkerConfig) *markerExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.APIKey = "test-apikey"
+	cfg.Markers = markers
+
+	exp := newMarkerExporter(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func testLogs() plog.Logs {
+	timestamp := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("deployer")
+	records := sl.LogRecords()
+
+	deploy := records.AppendEmpty()
+	deploy.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	deploy.Body().SetStr("deployed checkout")
+	deploy.Attributes().PutStr("event.name", "deploy")
+	deploy.Attributes().PutStr("deploy.version", "v1.2.3")
+	deploy.Attributes().PutStr("deploy.url", "https://ci.example.com/builds/42")
+
+	other := records.AppendEmpty()
+	other.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	other.Body().SetStr("request served")
+	return logs
+}
+
+func TestExportMarkers(t *testing.T) {
+	var received []receivedMarker
+	server := newTestServer(t, http.StatusCreated, &received)
+	defer server.Close()
+
+	exp := newTestExporter(t, server.URL,
+		MarkerConfig{
+			Type:       "deploy",
+			Attributes: map[string]string{"event.name": "deploy", "service.name": ""},
+			MessageKey: "deploy.version",
+			URLKey:     "deploy.url",
+		},
+		MarkerConfig{
+			Type:        "service-deploy",
+			Attributes:  map[string]string{"event.name": "deploy", "service.name": "checkout"},
+			DatasetSlug: "checkout",
+		},
+		MarkerConfig{
+			Type:       "build",
+			Attributes: map[string]string{"event.name": "build"},
+		},
+	)
+
+	require.NoError(t, exp.exportMarkers(context.Background(), testLogs()))
+
+	require.Len(t, received, 2)
+	assert.Equal(t, receivedMarker{
+		path:   "/1/markers/__all__",
+		apiKey: "test-apikey",
+		marker: marker{
+			StartTime: 1664625600,
+			Message:   "v1.2.3",
+			Type:      "deploy",
+			URL:       "https://ci.example.com/builds/42",
+		},
+	}, received[0])
+	assert.Equal(t, receivedMarker{
+		path:   "/1/markers/checkout",
+		apiKey: "test-apikey",
+		marker: marker{
+			StartTime: 1664625600,
+			Message:   "deployed checkout",
+			Type:      "service-deploy",
+		},
+	}, received[1])
+}
+
+func TestExportMarkersErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantPermanent bool
+	}{
+		{
+			name:          "bad request",
+			status:        http.StatusBadRequest,
+			wantPermanent: true,
+		},
+		{
+			name:   "too many requests",
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name:   "server error",
+			status: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []receivedMarker
+			server := newTestServer(t, tt.status, &received)
+			defer server.Close()
+
+			exp := newTestExporter(t, server.URL,
+				MarkerConfig{
+					Type:       "deploy",
+					Attributes: map[string]string{"event.name": "deploy"},
+				},
+				MarkerConfig{
+					Type:       "service-deploy",
+					Attributes: map[string]string{"service.name": "checkout"},
+				},
+			)
+
+			err := exp.exportMarkers(context.Background(), testLogs())
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, consumererror.IsPermanent(err))
+			if tt.wantPermanent {
+				return
+			}
+
+			var logsErr consumererror.Logs
+			require.ErrorAs(t, err, &logsErr)
+			failed := logsErr.GetLogs()
+			require.Equal(t, 2, failed.LogRecordCount())
+			require.Equal(t, 1, failed.ResourceLogs().Len())
+			require.Equal(t, 1, failed.ResourceLogs().At(0).ScopeLogs().Len())
+			failedSl := failed.ResourceLogs().At(0).ScopeLogs().At(0)
+			assert.Equal(t, "deployer", failedSl.Scope().Name())
+			assert.Equal(t, "deployed checkout", failedSl.LogRecords().At(0).Body().Str())
+			assert.Equal(t, "request served", failedSl.LogRecords().At(1).Body().Str())
+		})
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/tracebatch"
 )
 
 // Config defines configuration for Jaeger gRPC exporter.
@@ -28,11 +30,15 @@ type Config struct {
 	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
 
 	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Batching groups the spans sent by process, and holds the spans of each trace
+	// to send the spans of the traces together.
+	Batching tracebatch.Config `mapstructure:"trace_batching"`
 }
 
 var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
-	return nil
+	return cfg.Batching.Validate()
 }
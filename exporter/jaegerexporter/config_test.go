@@ -27,6 +27,8 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/service/servicetest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/tracebatch"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -66,10 +68,22 @@ func TestLoadConfig(t *testing.T) {
 				WriteBufferSize: 512 * 1024,
 				BalancerName:    "round_robin",
 			},
+			Batching: tracebatch.NewDefaultConfig(),
 		})
 
 	set := componenttest.NewNopExporterCreateSettings()
 	te, err := factory.CreateTracesExporter(context.Background(), set, e1)
 	require.NoError(t, err)
 	require.NotNil(t, te)
+
+	e2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "batching")]
+	assert.Equal(t, tracebatch.Config{
+		GroupByService: true,
+		TraceWait:      5 * time.Second,
+		MaxTraceWait:   30 * time.Second,
+		MaxTraces:      1000,
+	}, e2.(*Config).Batching)
+	te, err = factory.CreateTracesExporter(context.Background(), set, e2)
+	require.NoError(t, err)
+	require.NotNil(t, te)
 }
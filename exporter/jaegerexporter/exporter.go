@@ -20,6 +20,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jaegertracing/jaeger/model"
 	jaegerproto "github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -34,6 +35,7 @@ import (
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/tracebatch"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/jaeger"
 )
 
@@ -42,7 +44,7 @@ import (
 // The collectorEndpoint should be of the form "hostname:14250" (a gRPC target).
 func newTracesExporter(cfg *Config, set component.ExporterCreateSettings) (component.TracesExporter, error) {
 	s := newProtoGRPCSender(cfg, set.TelemetrySettings)
-	return exporterhelper.NewTracesExporter(
+	exp, err := exporterhelper.NewTracesExporter(
 		context.TODO(), set, cfg, s.pushTraces,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 		exporterhelper.WithStart(s.start),
@@ -51,16 +53,21 @@ func newTracesExporter(cfg *Config, set component.ExporterCreateSettings) (compo
 		exporterhelper.WithRetry(cfg.RetrySettings),
 		exporterhelper.WithQueue(cfg.QueueSettings),
 	)
+	if err != nil {
+		return nil, err
+	}
+	return tracebatch.NewTracesExporter(cfg.Batching, exp, set.Logger), nil
 }
 
 // protoGRPCSender forwards spans encoded in the jaeger proto
 // format, to a grpc server.
 type protoGRPCSender struct {
-	name         string
-	settings     component.TelemetrySettings
-	client       jaegerproto.CollectorServiceClient
-	metadata     metadata.MD
-	waitForReady bool
+	name           string
+	settings       component.TelemetrySettings
+	client         jaegerproto.CollectorServiceClient
+	metadata       metadata.MD
+	waitForReady   bool
+	groupByService bool
 
 	conn                      stateReporter
 	connStateReporterInterval time.Duration
@@ -78,6 +85,7 @@ func newProtoGRPCSender(cfg *Config, settings component.TelemetrySettings) *prot
 		settings:                  settings,
 		metadata:                  metadata.New(cfg.GRPCClientSettings.Headers),
 		waitForReady:              cfg.WaitForReady,
+		groupByService:            cfg.Batching.GroupByService,
 		connStateReporterInterval: time.Second,
 		stopCh:                    make(chan struct{}),
 		clientSettings:            &cfg.GRPCClientSettings,
@@ -100,6 +108,10 @@ func (s *protoGRPCSender) pushTraces(
 		return consumererror.NewPermanent(fmt.Errorf("failed to push trace data via Jaeger exporter: %w", err))
 	}
 
+	if s.groupByService {
+		batches = groupByProcess(batches)
+	}
+
 	if s.metadata.Len() > 0 {
 		ctx = metadata.NewOutgoingContext(ctx, s.metadata)
 	}
@@ -118,6 +130,24 @@ func (s *protoGRPCSender) pushTraces(
 	return nil
 }
 
+// groupByProcess merges the batches of the same process, keeping the order in
+// which the processes are first found.
+func groupByProcess(batches []*model.Batch) []*model.Batch {
+	var groups []*model.Batch
+	index := map[string]*model.Batch{}
+	for _, batch := range batches {
+		key := batch.Process.String()
+		group, ok := index[key]
+		if !ok {
+			group = &model.Batch{Process: batch.Process}
+			index[key] = group
+			groups = append(groups, group)
+		}
+		group.Spans = append(group.Spans, batch.Spans...)
+	}
+	return groups
+}
+
 func (s *protoGRPCSender) shutdown(context.Context) error {
 	s.stopLock.Lock()
 	s.stopped = true
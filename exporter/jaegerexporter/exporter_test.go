@@ -382,3 +382,23 @@ func (h *mockSpanHandler) PostSpans(_ context.Context, r *api_v2.PostSpansReques
 	h.requests = append(h.requests, r)
 	return &api_v2.PostSpansResponse{}, nil
 }
+
+func TestGroupByProcess(t *testing.T) {
+	frontend := &model.Process{ServiceName: "frontend", Tags: []model.KeyValue{model.String("host.name", "a")}}
+	backend := &model.Process{ServiceName: "backend"}
+	batches := []*model.Batch{
+		{Process: frontend, Spans: []*model.Span{{OperationName: "a"}}},
+		{Process: backend, Spans: []*model.Span{{OperationName: "b"}}},
+		{Process: &model.Process{ServiceName: "frontend", Tags: []model.KeyValue{model.String("host.name", "a")}}, Spans: []*model.Span{{OperationName: "c"}}},
+		{Process: &model.Process{ServiceName: "frontend", Tags: []model.KeyValue{model.String("host.name", "b")}}, Spans: []*model.Span{{OperationName: "d"}}},
+	}
+
+	groups := groupByProcess(batches)
+	require.Len(t, groups, 3)
+	assert.Equal(t, frontend, groups[0].Process)
+	assert.Equal(t, []*model.Span{{OperationName: "a"}, {OperationName: "c"}}, groups[0].Spans)
+	assert.Equal(t, backend, groups[1].Process)
+	assert.Equal(t, []*model.Span{{OperationName: "b"}}, groups[1].Spans)
+	assert.Equal(t, "b", groups[2].Process.Tags[0].VStr)
+	assert.Equal(t, []*model.Span{{OperationName: "d"}}, groups[2].Spans)
+}
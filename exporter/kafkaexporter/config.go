@@ -87,6 +87,19 @@ type Producer struct {
 	// broker request. Defaults to 0 for unlimited. Similar to
 	// `queue.buffering.max.messages` in the JVM producer.
 	FlushMaxMessages int `mapstructure:"flush_max_messages"`
+
+	// Idempotent enables the idempotent producer, similar to `enable.idempotence`
+	// in the JVM producer. Brokers then discard the duplicates written when the
+	// producer retries after a broker failover.
+	// Requires required_acks set to -1 and protocol_version 0.11.0 or later.
+	Idempotent bool `mapstructure:"idempotent"`
+
+	// TransactionalID enables transactional writes when set: all the messages
+	// of an export are committed in a single transaction, so consumers reading
+	// with the read_committed isolation level see either all or none of them.
+	// The signal type is appended to the ID, as every pipeline owns a producer.
+	// Requires idempotent.
+	TransactionalID string `mapstructure:"transactional_id"`
 }
 
 // MetadataRetry defines retry configuration for Metadata.
@@ -112,6 +125,24 @@ func (cfg *Config) Validate() error {
 		return err
 	}
 
+	if cfg.Producer.TransactionalID != "" && !cfg.Producer.Idempotent {
+		return fmt.Errorf("producer.idempotent has to be enabled when producer.transactional_id is set")
+	}
+	if cfg.Producer.Idempotent {
+		if cfg.Producer.RequiredAcks != sarama.WaitForAll {
+			return fmt.Errorf("producer.required_acks has to be -1 when producer.idempotent is enabled. configured value %v", cfg.Producer.RequiredAcks)
+		}
+		if cfg.ProtocolVersion != "" {
+			version, err := sarama.ParseKafkaVersion(cfg.ProtocolVersion)
+			if err != nil {
+				return err
+			}
+			if !version.IsAtLeast(sarama.V0_11_0_0) {
+				return fmt.Errorf("protocol_version has to be at least 0.11.0 when producer.idempotent is enabled. configured value %v", cfg.ProtocolVersion)
+			}
+		}
+	}
+
 	return nil
 }
 
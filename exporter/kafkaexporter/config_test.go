@@ -138,3 +138,49 @@ func Test_saramaProducerCompressionCodec(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate_idempotent(t *testing.T) {
+	tests := []struct {
+		name     string
+		producer Producer
+		version  string
+		err      string
+	}{
+		{
+			name:     "idempotent",
+			producer: Producer{Compression: "none", RequiredAcks: sarama.WaitForAll, Idempotent: true},
+			version:  "2.0.0",
+		},
+		{
+			name:     "transactional",
+			producer: Producer{Compression: "none", RequiredAcks: sarama.WaitForAll, Idempotent: true, TransactionalID: "otelcol"},
+		},
+		{
+			name:     "transactional without idempotent",
+			producer: Producer{Compression: "none", RequiredAcks: sarama.WaitForAll, TransactionalID: "otelcol"},
+			err:      "producer.idempotent has to be enabled when producer.transactional_id is set",
+		},
+		{
+			name:     "idempotent without acks from all replicas",
+			producer: Producer{Compression: "none", RequiredAcks: sarama.WaitForLocal, Idempotent: true},
+			err:      "producer.required_acks has to be -1 when producer.idempotent is enabled. configured value 1",
+		},
+		{
+			name:     "idempotent with old protocol version",
+			producer: Producer{Compression: "none", RequiredAcks: sarama.WaitForAll, Idempotent: true},
+			version:  "0.10.2.0",
+			err:      "protocol_version has to be at least 0.11.0 when producer.idempotent is enabled. configured value 0.10.2.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ProtocolVersion: tt.version, Producer: tt.producer}
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
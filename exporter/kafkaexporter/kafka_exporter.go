@@ -36,6 +36,7 @@ type kafkaTracesProducer struct {
 	topic     string
 	marshaler TracesMarshaler
 	logger    *zap.Logger
+	name      string
 }
 
 type kafkaErrors struct {
@@ -52,17 +53,7 @@ func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td ptrace.Traces)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	err = e.producer.SendMessages(messages)
-	if err != nil {
-		var prodErr sarama.ProducerErrors
-		if errors.As(err, &prodErr) {
-			if len(prodErr) > 0 {
-				return kafkaErrors{len(prodErr), prodErr[0].Err.Error()}
-			}
-		}
-		return err
-	}
-	return nil
+	return sendMessages(e.name, e.producer, messages)
 }
 
 func (e *kafkaTracesProducer) Close(context.Context) error {
@@ -75,6 +66,7 @@ type kafkaMetricsProducer struct {
 	topic     string
 	marshaler MetricsMarshaler
 	logger    *zap.Logger
+	name      string
 }
 
 func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pmetric.Metrics) error {
@@ -82,17 +74,7 @@ func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pmetric.M
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	err = e.producer.SendMessages(messages)
-	if err != nil {
-		var prodErr sarama.ProducerErrors
-		if errors.As(err, &prodErr) {
-			if len(prodErr) > 0 {
-				return kafkaErrors{len(prodErr), prodErr[0].Err.Error()}
-			}
-		}
-		return err
-	}
-	return nil
+	return sendMessages(e.name, e.producer, messages)
 }
 
 func (e *kafkaMetricsProducer) Close(context.Context) error {
@@ -105,6 +87,7 @@ type kafkaLogsProducer struct {
 	topic     string
 	marshaler LogsMarshaler
 	logger    *zap.Logger
+	name      string
 }
 
 func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) error {
@@ -112,24 +95,35 @@ func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) erro
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
-	err = e.producer.SendMessages(messages)
-	if err != nil {
-		var prodErr sarama.ProducerErrors
-		if errors.As(err, &prodErr) {
-			if len(prodErr) > 0 {
-				return kafkaErrors{len(prodErr), prodErr[0].Err.Error()}
-			}
-		}
-		return err
-	}
-	return nil
+	return sendMessages(e.name, e.producer, messages)
 }
 
 func (e *kafkaLogsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
-func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
+// sendMessages sends the messages of an export and records its delivery report.
+func sendMessages(name string, producer sarama.SyncProducer, messages []*sarama.ProducerMessage) error {
+	err := producer.SendMessages(messages)
+	if err == nil {
+		recordDeliveryReport(name, len(messages), 0)
+		return nil
+	}
+	var prodErr sarama.ProducerErrors
+	if errors.As(err, &prodErr) && len(prodErr) > 0 {
+		failed := len(prodErr)
+		if producer.IsTransactional() {
+			// The transaction was aborted, none of the messages are visible to consumers.
+			failed = len(messages)
+		}
+		recordDeliveryReport(name, len(messages)-failed, failed)
+		return kafkaErrors{len(prodErr), prodErr[0].Err.Error()}
+	}
+	recordDeliveryReport(name, 0, len(messages))
+	return err
+}
+
+func newSaramaProducer(config Config, signal string, logger *zap.Logger) (sarama.SyncProducer, error) {
 	c := sarama.NewConfig()
 	// These setting are required by the sarama.SyncProducer implementation.
 	c.Producer.Return.Successes = true
@@ -142,6 +136,14 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
 	c.Producer.MaxMessageBytes = config.Producer.MaxMessageBytes
 	c.Producer.Flush.MaxMessages = config.Producer.FlushMaxMessages
+	if config.Producer.Idempotent {
+		c.Producer.Idempotent = true
+		// Required by sarama to guarantee the ordering of idempotent writes.
+		c.Net.MaxOpenRequests = 1
+	}
+	if config.Producer.TransactionalID != "" {
+		c.Producer.Transaction.ID = config.Producer.TransactionalID + "-" + signal
+	}
 
 	if config.ProtocolVersion != "" {
 		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
@@ -165,6 +167,9 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	if err != nil {
 		return nil, err
 	}
+	if producer.IsTransactional() {
+		return newTransactionalProducer(producer, config.ID().String(), logger), nil
+	}
 	return producer, nil
 }
 
@@ -173,7 +178,7 @@ func newMetricsExporter(config Config, set component.ExporterCreateSettings, mar
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
-	producer, err := newSaramaProducer(config)
+	producer, err := newSaramaProducer(config, "metrics", set.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -183,6 +188,7 @@ func newMetricsExporter(config Config, set component.ExporterCreateSettings, mar
 		topic:     config.Topic,
 		marshaler: marshaler,
 		logger:    set.Logger,
+		name:      config.ID().String(),
 	}, nil
 
 }
@@ -193,7 +199,7 @@ func newTracesExporter(config Config, set component.ExporterCreateSettings, mars
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
-	producer, err := newSaramaProducer(config)
+	producer, err := newSaramaProducer(config, "traces", set.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +208,7 @@ func newTracesExporter(config Config, set component.ExporterCreateSettings, mars
 		topic:     config.Topic,
 		marshaler: marshaler,
 		logger:    set.Logger,
+		name:      config.ID().String(),
 	}, nil
 }
 
@@ -210,7 +217,7 @@ func newLogsExporter(config Config, set component.ExporterCreateSettings, marsha
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
-	producer, err := newSaramaProducer(config)
+	producer, err := newSaramaProducer(config, "logs", set.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -220,6 +227,7 @@ func newLogsExporter(config Config, set component.ExporterCreateSettings, marsha
 		topic:     config.Topic,
 		marshaler: marshaler,
 		logger:    set.Logger,
+		name:      config.ID().String(),
 	}, nil
 
 }
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagInstanceName, _ = tag.NewKey("name")
+	tagOutcome, _      = tag.NewKey("outcome")
+
+	statMessagesDelivered = stats.Int64("kafka_exporter_messages_delivered", "Number of messages acknowledged by the brokers", stats.UnitDimensionless)
+	statMessagesFailed    = stats.Int64("kafka_exporter_messages_failed", "Number of messages that failed to be delivered", stats.UnitDimensionless)
+	statTransactions      = stats.Int64("kafka_exporter_transactions", "Number of transactions, by outcome", stats.UnitDimensionless)
+)
+
+const (
+	transactionCommitted = "committed"
+	transactionAborted   = "aborted"
+)
+
+// MetricViews return metric views for Kafka exporter.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagInstanceName}
+
+	countMessagesDelivered := &view.View{
+		Name:        statMessagesDelivered.Name(),
+		Measure:     statMessagesDelivered,
+		Description: statMessagesDelivered.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countMessagesFailed := &view.View{
+		Name:        statMessagesFailed.Name(),
+		Measure:     statMessagesFailed,
+		Description: statMessagesFailed.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countTransactions := &view.View{
+		Name:        statTransactions.Name(),
+		Measure:     statTransactions,
+		Description: statTransactions.Description(),
+		TagKeys:     []tag.Key{tagInstanceName, tagOutcome},
+		Aggregation: view.Sum(),
+	}
+
+	return []*view.View{
+		countMessagesDelivered,
+		countMessagesFailed,
+		countTransactions,
+	}
+}
+
+// recordDeliveryReport records the number of messages of an export that were
+// delivered and that failed.
+func recordDeliveryReport(name string, delivered, failed int) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, name)},
+		statMessagesDelivered.M(int64(delivered)),
+		statMessagesFailed.M(int64(failed)))
+}
+
+func recordTransaction(name string, outcome string) {
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, name), tag.Upsert(tagOutcome, outcome)},
+		statTransactions.M(1))
+}
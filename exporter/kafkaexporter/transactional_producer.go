@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// transactionalProducer writes the messages of every SendMessages call in a
+// single transaction. Calls are serialized, as a producer can only have one
+// transaction in flight.
+type transactionalProducer struct {
+	sarama.SyncProducer
+	name   string
+	logger *zap.Logger
+	mu     sync.Mutex
+}
+
+var _ sarama.SyncProducer = (*transactionalProducer)(nil)
+
+func newTransactionalProducer(producer sarama.SyncProducer, name string, logger *zap.Logger) *transactionalProducer {
+	return &transactionalProducer{
+		SyncProducer: producer,
+		name:         name,
+		logger:       logger,
+	}
+}
+
+func (p *transactionalProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	var partition int32
+	var offset int64
+	err := p.inTransaction(func() error {
+		var err error
+		partition, offset, err = p.SyncProducer.SendMessage(msg)
+		return err
+	})
+	return partition, offset, err
+}
+
+func (p *transactionalProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	return p.inTransaction(func() error {
+		return p.SyncProducer.SendMessages(msgs)
+	})
+}
+
+func (p *transactionalProducer) inTransaction(send func() error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.SyncProducer.BeginTxn(); err != nil {
+		return err
+	}
+	err := send()
+	if err == nil {
+		err = p.SyncProducer.CommitTxn()
+	}
+	if err != nil {
+		if abortErr := p.SyncProducer.AbortTxn(); abortErr != nil {
+			p.logger.Warn("failed to abort transaction", zap.Error(abortErr))
+		}
+		recordTransaction(p.name, transactionAborted)
+		return err
+	}
+	recordTransaction(p.name, transactionCommitted)
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+)
+
+func newTransactionalMockProducer(t *testing.T) *mocks.SyncProducer {
+	c := sarama.NewConfig()
+	c.Producer.Idempotent = true
+	c.Producer.RequiredAcks = sarama.WaitForAll
+	c.Net.MaxOpenRequests = 1
+	c.Producer.Transaction.ID = "otelcol-traces"
+	return mocks.NewSyncProducer(t, c)
+}
+
+func TestTransactionalProducer(t *testing.T) {
+	views := MetricViews()
+	view.Unregister(views...)
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	mock := newTransactionalMockProducer(t)
+	mock.ExpectSendMessageAndSucceed()
+	mock.ExpectSendMessageAndSucceed()
+	producer := newTransactionalProducer(mock, "kafka", zap.NewNop())
+	t.Cleanup(func() {
+		require.NoError(t, producer.Close())
+	})
+	require.True(t, producer.IsTransactional())
+
+	err := sendMessages("kafka", producer, []*sarama.ProducerMessage{
+		{Topic: "otlp_spans", Value: sarama.StringEncoder("foo")},
+		{Topic: "otlp_spans", Value: sarama.StringEncoder("bar")},
+	})
+	require.NoError(t, err)
+
+	rows, err := view.RetrieveData(statMessagesDelivered.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, float64(2), rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(statTransactions.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, transactionCommitted, rows[0].Tags[1].Value)
+}
+
+func TestTransactionalProducer_abort(t *testing.T) {
+	views := MetricViews()
+	view.Unregister(views...)
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	mock := newTransactionalMockProducer(t)
+	expErr := fmt.Errorf("failed to send")
+	mock.ExpectSendMessageAndFail(expErr)
+	producer := newTransactionalProducer(mock, "kafka", zap.NewNop())
+	t.Cleanup(func() {
+		require.NoError(t, producer.Close())
+	})
+
+	err := sendMessages("kafka", producer, []*sarama.ProducerMessage{
+		{Topic: "otlp_spans", Value: sarama.StringEncoder("foo")},
+	})
+	assert.Error(t, err)
+
+	rows, err := view.RetrieveData(statMessagesFailed.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, float64(1), rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(statTransactions.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, transactionAborted, rows[0].Tags[1].Value)
+}
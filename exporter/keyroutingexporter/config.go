@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyroutingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/keyroutingexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+)
+
+// Config defines configuration for the key routing exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"`
+
+	// OTLP holds the settings of the OTLP/HTTP exporters the data is sent
+	// with. Its endpoints are used for the data not matching any route.
+	OTLP otlphttpexporter.Config `mapstructure:"otlp"`
+
+	// APIKeyHeader is the name of the header the API key is sent in.
+	APIKeyHeader string `mapstructure:"api_key_header"`
+
+	// DefaultAPIKey is the API key sent with the data not matching any route.
+	// No API key is sent when it is empty.
+	DefaultAPIKey string `mapstructure:"default_api_key"`
+
+	// FromAttribute is the resource attribute whose value selects the route.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// Routes are the API keys and endpoints of the values of the attribute.
+	Routes []RouteConfig `mapstructure:"routes"`
+}
+
+// RouteConfig defines the API key and endpoints the data is sent with when
+// its resource attribute has the value.
+type RouteConfig struct {
+	// Value is the value of the attribute.
+	Value string `mapstructure:"value"`
+
+	// APIKey is the API key sent with the data.
+	APIKey string `mapstructure:"api_key"`
+
+	// Endpoint replaces the endpoint of the OTLP settings, and the endpoints
+	// of its signals, when set.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// TracesEndpoint, MetricsEndpoint and LogsEndpoint replace the endpoint
+	// of their signal when set.
+	TracesEndpoint  string `mapstructure:"traces_endpoint"`
+	MetricsEndpoint string `mapstructure:"metrics_endpoint"`
+	LogsEndpoint    string `mapstructure:"logs_endpoint"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if err := cfg.OTLP.Validate(); err != nil {
+		return fmt.Errorf("otlp: %w", err)
+	}
+	if cfg.APIKeyHeader == "" {
+		return errors.New("api_key_header must not be empty")
+	}
+	if len(cfg.Routes) > 0 && cfg.FromAttribute == "" {
+		return errors.New("from_attribute is required when routes are defined")
+	}
+
+	values := map[string]bool{}
+	for _, route := range cfg.Routes {
+		if route.Value == "" {
+			return errors.New("the value of a route must not be empty")
+		}
+		if values[route.Value] {
+			return fmt.Errorf("duplicate route for value %q", route.Value)
+		}
+		values[route.Value] = true
+		if route.APIKey == "" {
+			return fmt.Errorf("the route for value %q has no api_key", route.Value)
+		}
+	}
+	return nil
+}
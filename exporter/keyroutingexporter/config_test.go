@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyroutingexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	defaultCfg := createDefaultConfig().(*Config)
+	defaultCfg.OTLP.Endpoint = "https://otlp.example.com"
+
+	routesCfg := createDefaultConfig().(*Config)
+	routesCfg.OTLP.Endpoint = "https://otlp.example.com"
+	routesCfg.OTLP.Headers = map[string]string{"x-source": "collector"}
+	routesCfg.OTLP.Timeout = 10 * time.Second
+	routesCfg.APIKeyHeader = "x-api-key"
+	routesCfg.DefaultAPIKey = "shared-key"
+	routesCfg.FromAttribute = "team"
+	routesCfg.Routes = []RouteConfig{
+		{Value: "payments", APIKey: "payments-key"},
+		{Value: "search", APIKey: "search-key", Endpoint: "https://eu.otlp.example.com"},
+	}
+
+	tests := []struct {
+		id       config.ComponentID
+		expected config.Exporter
+	}{
+		{
+			id:       config.NewComponentID(typeStr),
+			expected: defaultCfg,
+		},
+		{
+			id:       config.NewComponentIDWithName(typeStr, "routes"),
+			expected: routesCfg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := createDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExporter(sub, cfg))
+
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		error  string
+	}{
+		{
+			name:   "no endpoint",
+			modify: func(cfg *Config) { cfg.OTLP.Endpoint = "" },
+			error:  "otlp: at least one endpoint must be specified",
+		},
+		{
+			name:   "no api key header",
+			modify: func(cfg *Config) { cfg.APIKeyHeader = "" },
+			error:  "api_key_header must not be empty",
+		},
+		{
+			name:   "routes without attribute",
+			modify: func(cfg *Config) { cfg.FromAttribute = "" },
+			error:  "from_attribute is required when routes are defined",
+		},
+		{
+			name:   "route without value",
+			modify: func(cfg *Config) { cfg.Routes[0].Value = "" },
+			error:  "the value of a route must not be empty",
+		},
+		{
+			name:   "duplicate route",
+			modify: func(cfg *Config) { cfg.Routes = append(cfg.Routes, cfg.Routes[0]) },
+			error:  `duplicate route for value "payments"`,
+		},
+		{
+			name:   "route without api key",
+			modify: func(cfg *Config) { cfg.Routes[0].APIKey = "" },
+			error:  `the route for value "payments" has no api_key`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.OTLP.Endpoint = "https://otlp.example.com"
+			cfg.FromAttribute = "team"
+			cfg.Routes = []RouteConfig{{Value: "payments", APIKey: "payments-key"}}
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.error)
+		})
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyroutingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/keyroutingexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+)
+
+// defaultRoute is the key of the exporter of the data not matching any route.
+// Route values are never empty.
+const defaultRoute = ""
+
+// defaultRouteName names the exporter of the data not matching any route.
+const defaultRouteName = "default"
+
+var (
+	_ component.TracesExporter  = (*tracesExporter)(nil)
+	_ component.MetricsExporter = (*metricsExporter)(nil)
+	_ component.LogsExporter    = (*logsExporter)(nil)
+)
+
+// router holds an OTLP/HTTP exporter for each route, and selects the route of
+// resources.
+type router struct {
+	attribute string
+	exporters map[string]component.Exporter
+}
+
+func newRouter(cfg *Config, create func(oCfg *otlphttpexporter.Config) (component.Exporter, error)) (*router, error) {
+	r := &router{
+		attribute: cfg.FromAttribute,
+		exporters: make(map[string]component.Exporter, len(cfg.Routes)+1),
+	}
+
+	oCfg := buildExporterConfig(cfg, nil)
+	exp, err := create(&oCfg)
+	if err != nil {
+		return nil, err
+	}
+	r.exporters[defaultRoute] = exp
+
+	for i := range cfg.Routes {
+		route := &cfg.Routes[i]
+		oCfg := buildExporterConfig(cfg, route)
+		exp, err := create(&oCfg)
+		if err != nil {
+			return nil, err
+		}
+		r.exporters[route.Value] = exp
+	}
+	return r, nil
+}
+
+// buildExporterConfig returns the settings of the OTLP/HTTP exporter of the
+// route, or of the data not matching any route when route is nil.
+func buildExporterConfig(cfg *Config, route *RouteConfig) otlphttpexporter.Config {
+	oCfg := cfg.OTLP
+	// the ID of the exporter names its route, telling apart the telemetry of
+	// the exporters of the routes
+	routeName := defaultRouteName
+	if route != nil {
+		routeName = route.Value
+	}
+	oCfg.ExporterSettings = config.NewExporterSettings(config.NewComponentIDWithName("otlphttp", cfg.ID().String()+"/"+routeName))
+
+	apiKey := cfg.DefaultAPIKey
+	if route != nil {
+		apiKey = route.APIKey
+		if route.Endpoint != "" {
+			oCfg.Endpoint = route.Endpoint
+			oCfg.TracesEndpoint = ""
+			oCfg.MetricsEndpoint = ""
+			oCfg.LogsEndpoint = ""
+		}
+		if route.TracesEndpoint != "" {
+			oCfg.TracesEndpoint = route.TracesEndpoint
+		}
+		if route.MetricsEndpoint != "" {
+			oCfg.MetricsEndpoint = route.MetricsEndpoint
+		}
+		if route.LogsEndpoint != "" {
+			oCfg.LogsEndpoint = route.LogsEndpoint
+		}
+	}
+
+	// the headers are copied so that the exporters do not share them
+	oCfg.Headers = make(map[string]string, len(cfg.OTLP.Headers)+1)
+	for k, v := range cfg.OTLP.Headers {
+		oCfg.Headers[k] = v
+	}
+	if apiKey != "" {
+		oCfg.Headers[cfg.APIKeyHeader] = apiKey
+	}
+	return oCfg
+}
+
+// route returns the route of the resource.
+func (r *router) route(resource pcommon.Resource) string {
+	if r.attribute == "" {
+		return defaultRoute
+	}
+	v, ok := resource.Attributes().Get(r.attribute)
+	if !ok {
+		return defaultRoute
+	}
+	if _, ok := r.exporters[v.AsString()]; !ok {
+		return defaultRoute
+	}
+	return v.AsString()
+}
+
+// routes returns the route of each resource, and whether they all have the
+// same route.
+func (r *router) routes(n int, resource func(i int) pcommon.Resource) ([]string, bool) {
+	routes := make([]string, n)
+	same := true
+	for i := 0; i < n; i++ {
+		routes[i] = r.route(resource(i))
+		same = same && routes[i] == routes[0]
+	}
+	return routes, same
+}
+
+func (r *router) Start(ctx context.Context, host component.Host) error {
+	for _, exp := range r.exporters {
+		if err := exp.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *router) Shutdown(ctx context.Context) error {
+	var errs error
+	for _, exp := range r.exporters {
+		errs = multierr.Append(errs, exp.Shutdown(ctx))
+	}
+	return errs
+}
+
+func (r *router) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+type tracesExporter struct {
+	*router
+}
+
+func (e *tracesExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	rss := td.ResourceSpans()
+	routes, same := e.routes(rss.Len(), func(i int) pcommon.Resource { return rss.At(i).Resource() })
+	if same {
+		if len(routes) == 0 {
+			return nil
+		}
+		return e.exporters[routes[0]].(component.TracesExporter).ConsumeTraces(ctx, td)
+	}
+
+	batches := map[string]ptrace.Traces{}
+	for i, route := range routes {
+		batch, ok := batches[route]
+		if !ok {
+			batch = ptrace.NewTraces()
+			batches[route] = batch
+		}
+		rss.At(i).CopyTo(batch.ResourceSpans().AppendEmpty())
+	}
+
+	var errs error
+	for route, batch := range batches {
+		errs = multierr.Append(errs, e.exporters[route].(component.TracesExporter).ConsumeTraces(ctx, batch))
+	}
+	return errs
+}
+
+type metricsExporter struct {
+	*router
+}
+
+func (e *metricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	rms := md.ResourceMetrics()
+	routes, same := e.routes(rms.Len(), func(i int) pcommon.Resource { return rms.At(i).Resource() })
+	if same {
+		if len(routes) == 0 {
+			return nil
+		}
+		return e.exporters[routes[0]].(component.MetricsExporter).ConsumeMetrics(ctx, md)
+	}
+
+	batches := map[string]pmetric.Metrics{}
+	for i, route := range routes {
+		batch, ok := batches[route]
+		if !ok {
+			batch = pmetric.NewMetrics()
+			batches[route] = batch
+		}
+		rms.At(i).CopyTo(batch.ResourceMetrics().AppendEmpty())
+	}
+
+	var errs error
+	for route, batch := range batches {
+		errs = multierr.Append(errs, e.exporters[route].(component.MetricsExporter).ConsumeMetrics(ctx, batch))
+	}
+	return errs
+}
+
+type logsExporter struct {
+	*router
+}
+
+func (e *logsExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	rls := ld.ResourceLogs()
+	routes, same := e.routes(rls.Len(), func(i int) pcommon.Resource { return rls.At(i).Resource() })
+	if same {
+		if len(routes) == 0 {
+			return nil
+		}
+		return e.exporters[routes[0]].(component.LogsExporter).ConsumeLogs(ctx, ld)
+	}
+
+	batches := map[string]plog.Logs{}
+	for i, route := range routes {
+		batch, ok := batches[route]
+		if !ok {
+			batch = plog.NewLogs()
+			batches[route] = batch
+		}
+		rls.At(i).CopyTo(batch.ResourceLogs().AppendEmpty())
+	}
+
+	var errs error
+	for route, batch := range batches {
+		errs = multierr.Append(errs, e.exporters[route].(component.LogsExporter).ConsumeLogs(ctx, batch))
+	}
+	return errs
+}
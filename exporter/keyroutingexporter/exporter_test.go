@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyroutingexporter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// request is a request received by the test backend.
+type request struct {
+	host   string
+	path   string
+	apiKey string
+}
+
+type backend struct {
+	mu       sync.Mutex
+	requests []request
+	server   *httptest.Server
+}
+
+func newBackend(t *testing.T) *backend {
+	b := &backend{}
+	b.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.requests = append(b.requests, request{host: r.Host, path: r.URL.Path, apiKey: r.Header.Get("x-api-key")})
+	}))
+	t.Cleanup(b.server.Close)
+	return b
+}
+
+func (b *backend) received() []request {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sort.Slice(b.requests, func(i, j int) bool { return b.requests[i].apiKey < b.requests[j].apiKey })
+	return b.requests
+}
+
+func newTestConfig(endpoint string, routeEndpoint string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.OTLP.Endpoint = endpoint
+	cfg.OTLP.QueueSettings.Enabled = false
+	cfg.OTLP.RetrySettings.Enabled = false
+	cfg.APIKeyHeader = "x-api-key"
+	cfg.DefaultAPIKey = "shared-key"
+	cfg.FromAttribute = "team"
+	cfg.Routes = []RouteConfig{
+		{Value: "payments", APIKey: "payments-key"},
+		{Value: "search", APIKey: "search-key", LogsEndpoint: routeEndpoint + "/custom/logs"},
+	}
+	return cfg
+}
+
+func TestConsumeTraces(t *testing.T) {
+	b := newBackend(t)
+	cfg := newTestConfig(b.server.URL, b.server.URL)
+	exp, err := NewFactory().CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+
+	td := ptrace.NewTraces()
+	for _, team := range []string{"payments", "unknown", "payments", ""} {
+		rs := td.ResourceSpans().AppendEmpty()
+		if team != "" {
+			rs.Resource().Attributes().PutStr("team", team)
+		}
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	}
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+
+	assert.Equal(t, []request{
+		{host: b.server.Listener.Addr().String(), path: "/v1/traces", apiKey: "payments-key"},
+		{host: b.server.Listener.Addr().String(), path: "/v1/traces", apiKey: "shared-key"},
+	}, b.received())
+}
+
+func TestConsumeMetricsSingleRoute(t *testing.T) {
+	b := newBackend(t)
+	cfg := newTestConfig(b.server.URL, b.server.URL)
+	exp, err := NewFactory().CreateMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+
+	md := pmetric.NewMetrics()
+	for i := 0; i < 2; i++ {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("team", "search")
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	}
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+
+	assert.Equal(t, []request{
+		{host: b.server.Listener.Addr().String(), path: "/v1/metrics", apiKey: "search-key"},
+	}, b.received())
+}
+
+func TestConsumeLogsRouteEndpoint(t *testing.T) {
+	b := newBackend(t)
+	cfg := newTestConfig(b.server.URL, b.server.URL)
+	exp, err := NewFactory().CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+
+	ld := plog.NewLogs()
+	for _, team := range []string{"search", "payments"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("team", team)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log")
+	}
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+
+	assert.Equal(t, []request{
+		{host: b.server.Listener.Addr().String(), path: "/v1/logs", apiKey: "payments-key"},
+		{host: b.server.Listener.Addr().String(), path: "/custom/logs", apiKey: "search-key"},
+	}, b.received())
+}
+
+func TestBuildExporterConfig(t *testing.T) {
+	cfg := newTestConfig("https://otlp.example.com", "https://eu.otlp.example.com")
+	cfg.OTLP.Headers = map[string]string{"x-source": "collector"}
+	cfg.OTLP.TracesEndpoint = "https://otlp.example.com/traces"
+
+	oCfg := buildExporterConfig(cfg, nil)
+	assert.Equal(t, config.NewComponentIDWithName("otlphttp", "keyrouting/default"), oCfg.ID())
+	assert.Equal(t, "https://otlp.example.com", oCfg.Endpoint)
+	assert.Equal(t, "https://otlp.example.com/traces", oCfg.TracesEndpoint)
+	assert.Equal(t, map[string]string{"x-source": "collector", "x-api-key": "shared-key"}, oCfg.Headers)
+
+	oCfg = buildExporterConfig(cfg, &cfg.Routes[1])
+	assert.Equal(t, config.NewComponentIDWithName("otlphttp", "keyrouting/search"), oCfg.ID())
+	assert.Equal(t, "https://otlp.example.com", oCfg.Endpoint)
+	assert.Equal(t, "https://otlp.example.com/traces", oCfg.TracesEndpoint)
+	assert.Equal(t, "https://eu.otlp.example.com/custom/logs", oCfg.LogsEndpoint)
+	assert.Equal(t, map[string]string{"x-source": "collector", "x-api-key": "search-key"}, oCfg.Headers)
+
+	// the endpoint of a route replaces the endpoints of the signals
+	cfg.Routes[1].Endpoint = "https://eu.otlp.example.com"
+	oCfg = buildExporterConfig(cfg, &cfg.Routes[1])
+	assert.Equal(t, "https://eu.otlp.example.com", oCfg.Endpoint)
+	assert.Equal(t, "", oCfg.TracesEndpoint)
+	assert.Equal(t, "https://eu.otlp.example.com/custom/logs", oCfg.LogsEndpoint)
+
+	// the headers of the settings are not modified
+	assert.Equal(t, map[string]string{"x-source": "collector"}, cfg.OTLP.Headers)
+
+	cfg.DefaultAPIKey = ""
+	oCfg = buildExporterConfig(cfg, nil)
+	assert.Equal(t, map[string]string{"x-source": "collector"}, oCfg.Headers)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyroutingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/keyroutingexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "keyrouting"
+	// The stability level of the exporter.
+	stability = component.StabilityLevelInDevelopment
+
+	defaultAPIKeyHeader = "api-key"
+)
+
+// NewFactory creates a factory for the exporter.
+func NewFactory() component.ExporterFactory {
+	return component.NewExporterFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesExporter(createTracesExporter, stability),
+		component.WithMetricsExporter(createMetricsExporter, stability),
+		component.WithLogsExporter(createLogsExporter, stability),
+	)
+}
+
+func createDefaultConfig() config.Exporter {
+	otlpFactory := otlphttpexporter.NewFactory()
+	otlpDefaultCfg := otlpFactory.CreateDefaultConfig().(*otlphttpexporter.Config)
+
+	return &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		OTLP:             *otlpDefaultCfg,
+		APIKeyHeader:     defaultAPIKeyHeader,
+	}
+}
+
+func createTracesExporter(ctx context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.TracesExporter, error) {
+	otlpFactory := otlphttpexporter.NewFactory()
+	r, err := newRouter(cfg.(*Config), func(oCfg *otlphttpexporter.Config) (component.Exporter, error) {
+		return otlpFactory.CreateTracesExporter(ctx, params, oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tracesExporter{router: r}, nil
+}
+
+func createMetricsExporter(ctx context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+	otlpFactory := otlphttpexporter.NewFactory()
+	r, err := newRouter(cfg.(*Config), func(oCfg *otlphttpexporter.Config) (component.Exporter, error) {
+		return otlpFactory.CreateMetricsExporter(ctx, params, oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &metricsExporter{router: r}, nil
+}
+
+func createLogsExporter(ctx context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.LogsExporter, error) {
+	otlpFactory := otlphttpexporter.NewFactory()
+	r, err := newRouter(cfg.(*Config), func(oCfg *otlphttpexporter.Config) (component.Exporter, error) {
+		return otlpFactory.CreateLogsExporter(ctx, params, oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &logsExporter{router: r}, nil
+}
@@ -45,6 +45,7 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	Local  *LocalResolver  `mapstructure:"local"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -59,3 +60,9 @@ type DNSResolver struct {
 	Interval time.Duration `mapstructure:"interval"`
 	Timeout  time.Duration `mapstructure:"timeout"`
 }
+
+// LocalResolver defines the configuration for the resolver providing loopback receivers of the same collector as backends,
+// avoiding the serialization and the network hop of an OTLP backend
+type LocalResolver struct {
+	Receivers []config.ComponentID `mapstructure:"receivers"`
+}
@@ -54,6 +54,8 @@ type loadBalancerImp struct {
 
 	componentFactory componentFactory
 	exporters        map[string]component.Exporter
+	// local is true when the backends are loopback receivers of this collector, identified by their ID
+	local bool
 
 	stopped    bool
 	updateLock sync.RWMutex
@@ -63,7 +65,13 @@ type loadBalancerImp struct {
 func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporter, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+	numResolvers := 0
+	for _, set := range []bool{oCfg.Resolver.Static != nil, oCfg.Resolver.DNS != nil, oCfg.Resolver.Local != nil} {
+		if set {
+			numResolvers++
+		}
+	}
+	if numResolvers > 1 {
 		return nil, errMultipleResolversProvided
 	}
 
@@ -85,6 +93,19 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 		}
 	}
 
+	if oCfg.Resolver.Local != nil {
+		ids := make([]string, len(oCfg.Resolver.Local.Receivers))
+		for i, id := range oCfg.Resolver.Local.Receivers {
+			ids[i] = id.String()
+		}
+
+		var err error
+		res, err = newStaticResolver(ids)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
@@ -94,6 +115,7 @@ func newLoadBalancer(params component.ExporterCreateSettings, cfg config.Exporte
 		res:              res,
 		componentFactory: factory,
 		exporters:        map[string]component.Exporter{},
+		local:            oCfg.Resolver.Local != nil,
 	}, nil
 }
 
@@ -123,10 +145,10 @@ func (lb *loadBalancerImp) onBackendChanges(resolved []string) {
 
 func (lb *loadBalancerImp) addMissingExporters(ctx context.Context, endpoints []string) {
 	for _, endpoint := range endpoints {
-		endpoint = endpointWithPort(endpoint)
+		endpoint = lb.normalizeEndpoint(endpoint)
 
 		if _, exists := lb.exporters[endpoint]; !exists {
-			exp, err := lb.componentFactory(ctx, endpoint)
+			exp, err := lb.newExporter(ctx, endpoint)
 			if err != nil {
 				lb.logger.Error("failed to create new exporter for endpoint", zap.String("endpoint", endpoint), zap.Error(err))
 				continue
@@ -141,6 +163,21 @@ func (lb *loadBalancerImp) addMissingExporters(ctx context.Context, endpoints []
 	}
 }
 
+func (lb *loadBalancerImp) newExporter(ctx context.Context, endpoint string) (component.Exporter, error) {
+	if lb.local {
+		return newLocalExporter(endpoint)
+	}
+	return lb.componentFactory(ctx, endpoint)
+}
+
+// normalizeEndpoint adds the default port to network endpoints. Local endpoints are receiver IDs, kept as is.
+func (lb *loadBalancerImp) normalizeEndpoint(endpoint string) string {
+	if lb.local {
+		return endpoint
+	}
+	return endpointWithPort(endpoint)
+}
+
 func endpointWithPort(endpoint string) string {
 	if !strings.Contains(endpoint, ":") {
 		endpoint = fmt.Sprintf("%s:%s", endpoint, defaultPort)
@@ -151,7 +188,7 @@ func endpointWithPort(endpoint string) string {
 func (lb *loadBalancerImp) removeExtraExporters(ctx context.Context, endpoints []string) {
 	endpointsWithPort := make([]string, len(endpoints))
 	for i, e := range endpoints {
-		endpointsWithPort[i] = endpointWithPort(e)
+		endpointsWithPort[i] = lb.normalizeEndpoint(e)
 	}
 	for existing := range lb.exporters {
 		if !endpointFound(existing, endpointsWithPort) {
@@ -188,7 +225,7 @@ func (lb *loadBalancerImp) Exporter(endpoint string) (component.Exporter, error)
 	// data loss because the latest batches sent to outdated backend will never find their way out.
 	// for details: https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/1690
 	lb.updateLock.RLock()
-	exp, found := lb.exporters[endpointWithPort(endpoint)]
+	exp, found := lb.exporters[lb.normalizeEndpoint(endpoint)]
 	lb.updateLock.RUnlock()
 	if !found {
 		// something is really wrong... how come we couldn't find the exporter??
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/loopback"
+)
+
+var _ component.TracesExporter = (*localExporter)(nil)
+var _ component.LogsExporter = (*localExporter)(nil)
+
+// localExporter hands the data over to a loopback receiver of the same collector, without serializing it.
+// The data then goes through the processors and exporters of the pipelines the receiver belongs to.
+// The receiver is looked up on every call, as receivers are started after the exporters.
+type localExporter struct {
+	id config.ComponentID
+}
+
+func newLocalExporter(endpoint string) (*localExporter, error) {
+	id, err := config.NewComponentIDFromString(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &localExporter{id: id}, nil
+}
+
+func (e *localExporter) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *localExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *localExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *localExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	next := loopback.Traces(e.id)
+	if next == nil {
+		return fmt.Errorf("loopback receiver %q is not running in a traces pipeline", e.id)
+	}
+	return next.ConsumeTraces(ctx, td)
+}
+
+func (e *localExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	next := loopback.Logs(e.id)
+	if next == nil {
+		return fmt.Errorf("loopback receiver %q is not running in a logs pipeline", e.id)
+	}
+	return next.ConsumeLogs(ctx, ld)
+}
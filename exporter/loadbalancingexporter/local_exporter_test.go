@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/loopback"
+)
+
+func TestLocalExporter(t *testing.T) {
+	// prepare
+	id := config.NewComponentIDWithName("loopback", "shard-1")
+	exp, err := newLocalExporter("loopback/shard-1")
+	require.NoError(t, err)
+
+	// test and verify: the receiver isn't running yet
+	assert.Error(t, exp.ConsumeTraces(context.Background(), simpleTraces()))
+	assert.Error(t, exp.ConsumeLogs(context.Background(), simpleLogs()))
+
+	tracesSink := new(consumertest.TracesSink)
+	unregisterTraces, err := loopback.RegisterTraces(id, tracesSink)
+	require.NoError(t, err)
+	defer unregisterTraces()
+	logsSink := new(consumertest.LogsSink)
+	unregisterLogs, err := loopback.RegisterLogs(id, logsSink)
+	require.NoError(t, err)
+	defer unregisterLogs()
+
+	// test and verify: the receiver is running
+	assert.NoError(t, exp.ConsumeTraces(context.Background(), simpleTraces()))
+	assert.NoError(t, exp.ConsumeLogs(context.Background(), simpleLogs()))
+	assert.Len(t, tracesSink.AllTraces(), 1)
+	assert.Len(t, logsSink.AllLogs(), 1)
+}
+
+func TestNewLocalExporterInvalidID(t *testing.T) {
+	// test
+	exp, err := newLocalExporter("/shard-1")
+
+	// verify
+	assert.Nil(t, exp)
+	assert.Error(t, err)
+}
+
+func TestLoadBalancerWithLocalResolver(t *testing.T) {
+	// prepare
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Local: &LocalResolver{
+				Receivers: []config.ComponentID{
+					config.NewComponentIDWithName("loopback", "shard-1"),
+					config.NewComponentIDWithName("loopback", "shard-2"),
+				},
+			},
+		},
+	}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return nil, errors.New("no exporter should be created for local backends")
+	}
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Len(t, p.exporters, 2)
+	assert.Contains(t, p.exporters, "loopback/shard-1")
+	assert.Contains(t, p.exporters, "loopback/shard-2")
+
+	exp, err := p.Exporter(p.Endpoint([]byte{128, 128, 0, 0}))
+	require.NoError(t, err)
+	assert.IsType(t, &localExporter{}, exp)
+}
+
+func TestMultipleResolversWithLocal(t *testing.T) {
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{
+				Hostnames: []string{"endpoint-1", "endpoint-2"},
+			},
+			Local: &LocalResolver{
+				Receivers: []config.ComponentID{config.NewComponentIDWithName("loopback", "shard-1")},
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
+}
@@ -30,6 +30,10 @@ type Config struct {
 	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
 	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
 
+	// CardinalityLimits bounds the label cardinality of each push request, labels
+	// exceeding the limits being kept as attributes of the log entries instead.
+	CardinalityLimits CardinalityLimits `mapstructure:"cardinality_limits"`
+
 	// TenantID defines the tenant ID to associate log streams with.
 	// Deprecated: [v0.57.0] use the attribute processor to add a `loki.tenant` hint.
 	// See this component's documentation for more information on how to specify the hint.
@@ -52,11 +56,29 @@ type Config struct {
 	Tenant *Tenant `mapstructure:"tenant"`
 }
 
+// CardinalityLimits defines the limits of the label cardinality of a push request.
+// A zero value means no limit.
+type CardinalityLimits struct {
+	// MaxStreams is the maximum number of streams in a push request.
+	MaxStreams int `mapstructure:"max_streams"`
+
+	// MaxLabelCardinality is the maximum number of distinct values of a label in a push request.
+	MaxLabelCardinality int `mapstructure:"max_label_cardinality"`
+}
+
 func (c *Config) Validate() error {
 	if _, err := url.Parse(c.Endpoint); c.Endpoint == "" || err != nil {
 		return fmt.Errorf("\"endpoint\" must be a valid URL")
 	}
 
+	if c.CardinalityLimits.MaxStreams < 0 {
+		return fmt.Errorf("\"cardinality_limits.max_streams\" must not be negative")
+	}
+
+	if c.CardinalityLimits.MaxLabelCardinality < 0 {
+		return fmt.Errorf("\"cardinality_limits.max_label_cardinality\" must not be negative")
+	}
+
 	// further validation is needed only if we are in legacy mode
 	if !c.isLegacy() {
 		return nil
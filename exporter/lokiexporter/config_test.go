@@ -73,6 +73,10 @@ func TestLoadConfigNewExporter(t *testing.T) {
 			NumConsumers: 2,
 			QueueSize:    10,
 		},
+		CardinalityLimits: CardinalityLimits{
+			MaxStreams:          100,
+			MaxLabelCardinality: 50,
+		},
 	}
 	require.Equal(t, &expectedCfg, actualCfg)
 }
@@ -149,6 +153,45 @@ func TestIsLegacy(t *testing.T) {
 	}
 }
 
+func TestValidateCardinalityLimits(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		limits CardinalityLimits
+		err    string
+	}{
+		{
+			desc:   "no limits",
+			limits: CardinalityLimits{},
+		},
+		{
+			desc:   "negative max streams",
+			limits: CardinalityLimits{MaxStreams: -1},
+			err:    `"cardinality_limits.max_streams" must not be negative`,
+		},
+		{
+			desc:   "negative max label cardinality",
+			limits: CardinalityLimits{MaxLabelCardinality: -1},
+			err:    `"cardinality_limits.max_label_cardinality" must not be negative`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://loki.example.com",
+				},
+				CardinalityLimits: tC.limits,
+			}
+			err := cfg.Validate()
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
 func stringp(str string) *string {
 	return &str
 }
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
@@ -138,6 +139,7 @@ func TestExporter_pushLogData(t *testing.T) {
 		name             string
 		reqTestFunc      func(t *testing.T, r *http.Request)
 		httpResponseCode int
+		retryAfter       int
 		testServer       bool
 		config           *Config
 		genLogsFunc      func() plog.Logs
@@ -236,6 +238,22 @@ func TestExporter_pushLogData(t *testing.T) {
 				var e consumererror.Logs
 				require.True(t, errors.As(err, &e))
 				assert.Equal(t, 10, e.GetLogs().LogRecordCount())
+				assert.Contains(t, err.Error(), "Throttle (0s)")
+			},
+		},
+		{
+			name:             "too many requests with retry after",
+			reqTestFunc:      genericReqTestFunc,
+			config:           genericConfig,
+			httpResponseCode: http.StatusTooManyRequests,
+			retryAfter:       30,
+			testServer:       true,
+			genLogsFunc:      genericGenLogsFunc,
+			errFunc: func(err error) {
+				var e consumererror.Logs
+				require.True(t, errors.As(err, &e))
+				assert.Equal(t, 10, e.GetLogs().LogRecordCount())
+				assert.Contains(t, err.Error(), "Throttle (30s)")
 			},
 		},
 	}
@@ -246,6 +264,9 @@ func TestExporter_pushLogData(t *testing.T) {
 					if tt.reqTestFunc != nil {
 						tt.reqTestFunc(t, r)
 					}
+					if tt.retryAfter != 0 {
+						w.Header().Set("Retry-After", strconv.Itoa(tt.retryAfter))
+					}
 					w.WriteHeader(tt.httpResponseCode)
 				}))
 				defer server.Close()
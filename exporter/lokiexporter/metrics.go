@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	mDemotedLabels = stats.Int64("loki_demoted_labels", "Number of times a label was kept as an attribute to stay within the cardinality limits", stats.UnitDimensionless)
+
+	labelTagKey = tag.MustNewKey("label")
+)
+
+// MetricViews returns the metrics views of the Loki exporter.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mDemotedLabels.Name(),
+			Measure:     mDemotedLabels,
+			Description: mDemotedLabels.Description(),
+			Aggregation: view.Count(),
+			TagKeys: []tag.Key{
+				labelTagKey,
+			},
+		},
+	}
+}
@@ -23,11 +23,15 @@ import (
 	"net/http"
 	"sync"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/throttle"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
 )
 
@@ -48,7 +52,19 @@ func newNextExporter(config *Config, settings component.TelemetrySettings) *next
 }
 
 func (l *nextLokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
-	pushReq, report := loki.LogsToLoki(ld)
+	pushReq, report := loki.LogsToLokiWithLimits(ld, loki.Limits{
+		MaxStreams:          l.config.CardinalityLimits.MaxStreams,
+		MaxLabelCardinality: l.config.CardinalityLimits.MaxLabelCardinality,
+	})
+	if len(report.DemotedLabels) > 0 {
+		l.settings.Logger.Warn(
+			"some labels were kept as attributes to stay within the cardinality limits",
+			zap.Strings("labels", report.DemotedLabels),
+		)
+		for _, label := range report.DemotedLabels {
+			_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(labelTagKey, label)}, mDemotedLabels.M(1))
+		}
+	}
 	if len(pushReq.Streams) == 0 {
 		return consumererror.NewPermanent(fmt.Errorf("failed to transform logs into Loki log streams"))
 	}
@@ -92,6 +108,12 @@ func (l *nextLokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error
 			line = scanner.Text()
 		}
 		err = fmt.Errorf("HTTP %d %q: %s", resp.StatusCode, http.StatusText(resp.StatusCode), line)
+
+		// Loki rejects the requests going over the ingestion rate limits with a 429,
+		// so pause for the delay it asked for, if any, before retrying
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			err = exporterhelper.NewThrottleRetry(err, throttle.RetryAfter(resp.Header))
+		}
 		return consumererror.NewLogs(err, ld)
 	}
 
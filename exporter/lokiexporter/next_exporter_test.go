@@ -30,6 +30,9 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestPushLogData(t *testing.T) {
@@ -133,3 +136,57 @@ func TestPushLogData(t *testing.T) {
 		})
 	}
 }
+
+func TestPushLogDataCardinalityLimits(t *testing.T) {
+	actualPushRequest := &logproto.PushRequest{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encPayload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		decPayload, err := snappy.Decode(nil, encPayload)
+		require.NoError(t, err)
+
+		err = proto.Unmarshal(decPayload, actualPushRequest)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ts.URL,
+		},
+		CardinalityLimits: CardinalityLimits{
+			MaxStreams: 1,
+		},
+	}
+
+	core, obs := observer.New(zapcore.WarnLevel)
+	set := componenttest.NewNopExporterCreateSettings()
+	set.Logger = zap.New(core)
+
+	exp, err := NewFactory().CreateLogsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 3; i++ {
+		log := logs.AppendEmpty()
+		log.Attributes().PutStr("host.name", "guarana")
+		log.Attributes().PutStr("request.id", fmt.Sprintf("id-%d", i))
+		log.Attributes().PutStr("loki.attribute.labels", "host.name,request.id")
+	}
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, actualPushRequest.Streams, 1)
+	assert.Equal(t, `{exporter="OTLP", host.name="guarana"}`, actualPushRequest.Streams[0].Labels)
+	require.Len(t, actualPushRequest.Streams[0].Entries, 3)
+	assert.Equal(t, `{"attributes":{"request.id":"id-0"}}`, actualPushRequest.Streams[0].Entries[0].Line)
+
+	warnings := obs.FilterMessage("some labels were kept as attributes to stay within the cardinality limits").All()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, []interface{}{"request.id"}, warnings[0].ContextMap()["labels"])
+
+	assert.NoError(t, exp.Shutdown(context.Background()))
+}
@@ -40,18 +40,20 @@ type collector struct {
 	accumulator accumulator
 	logger      *zap.Logger
 
-	sendTimestamps bool
-	namespace      string
-	constLabels    prometheus.Labels
+	sendTimestamps      bool
+	namespace           string
+	translationStrategy prometheustranslator.TranslationStrategy
+	constLabels         prometheus.Labels
 }
 
 func newCollector(config *Config, logger *zap.Logger) *collector {
 	return &collector{
-		accumulator:    newAccumulator(logger, config.MetricExpiration),
-		logger:         logger,
-		namespace:      prometheustranslator.CleanUpString(config.Namespace),
-		sendTimestamps: config.SendTimestamps,
-		constLabels:    config.ConstLabels,
+		accumulator:         newAccumulator(logger, config.MetricExpiration),
+		logger:              logger,
+		namespace:           prometheustranslator.CleanUpString(config.Namespace),
+		translationStrategy: config.TranslationStrategy,
+		sendTimestamps:      config.SendTimestamps,
+		constLabels:         config.ConstLabels,
 	}
 }
 
@@ -109,7 +111,7 @@ func (c *collector) getMetricMetadata(metric pmetric.Metric, attributes pcommon.
 	}
 
 	return prometheus.NewDesc(
-		prometheustranslator.BuildPromCompliantName(metric, c.namespace),
+		prometheustranslator.BuildMetricName(metric, c.namespace, c.translationStrategy),
 		metric.Description(),
 		keys,
 		c.constLabels,
@@ -27,6 +27,8 @@ import (
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 )
 
 type mockAccumulator struct {
@@ -250,6 +252,36 @@ func TestCollectMetricsLabelSanitize(t *testing.T) {
 	require.Empty(t, loggerCore.errorMessages, "labels were not sanitized properly")
 }
 
+func TestCollectMetricsTranslationStrategy(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test.metric")
+	metric.SetUnit("By")
+	metric.SetDescription("test description")
+	dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetIntValue(42)
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+
+	c := collector{
+		namespace:           "test_space",
+		translationStrategy: prometheustranslator.TranslationStrategyUnderscoreEscapingWithSuffixes,
+		accumulator: &mockAccumulator{
+			[]pmetric.Metric{metric},
+			pcommon.NewMap(),
+		},
+		logger: zap.NewNop(),
+	}
+
+	ch := make(chan prometheus.Metric, 1)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+
+	for m := range ch {
+		require.Contains(t, m.Desc().String(), "fqName: \"test_space_test_metric_bytes\"")
+	}
+}
+
 func TestCollectMetrics(t *testing.T) {
 	tests := []struct {
 		name       string
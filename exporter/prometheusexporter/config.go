@@ -15,6 +15,7 @@
 package prometheusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -22,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/resourcetotelemetry"
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 )
 
 // Config defines configuration for Prometheus exporter.
@@ -32,6 +34,10 @@ type Config struct {
 	// Namespace if set, exports metrics under the provided value.
 	Namespace string `mapstructure:"namespace"`
 
+	// TranslationStrategy is the strategy to translate the OpenTelemetry metric names to Prometheus metric names.
+	// The NormalizeName feature gate is followed if empty.
+	TranslationStrategy prometheustranslator.TranslationStrategy `mapstructure:"translation_strategy"`
+
 	// ConstLabels are values that are applied for every exported metric.
 	ConstLabels prometheus.Labels `mapstructure:"const_labels"`
 
@@ -52,5 +58,8 @@ var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	if !cfg.TranslationStrategy.EscapesNames() {
+		return fmt.Errorf("translation strategy %q is not supported by the exposition formats of the exporter", cfg.TranslationStrategy)
+	}
 	return nil
 }
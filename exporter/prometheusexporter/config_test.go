@@ -26,6 +26,8 @@ import (
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/service/servicetest"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -57,7 +59,8 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 			},
-			Namespace: "test-space",
+			Namespace:           "test-space",
+			TranslationStrategy: prometheustranslator.TranslationStrategyUnderscoreEscapingWithoutSuffixes,
 			ConstLabels: map[string]string{
 				"label1":        "value1",
 				"another label": "spaced value",
@@ -67,3 +70,12 @@ func TestLoadConfig(t *testing.T) {
 		})
 
 }
+
+func TestValidateTranslationStrategy(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TranslationStrategy = prometheustranslator.TranslationStrategyUnderscoreEscapingWithSuffixes
+	assert.NoError(t, cfg.Validate())
+
+	cfg.TranslationStrategy = prometheustranslator.TranslationStrategyNoUTF8EscapingWithSuffixes
+	assert.EqualError(t, cfg.Validate(), "translation strategy \"NoUTF8EscapingWithSuffixes\" is not supported by the exposition formats of the exporter")
+}
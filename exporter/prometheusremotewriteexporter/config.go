@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/resourcetotelemetry"
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 )
 
 // Config defines configuration for Remote Write exporter.
@@ -34,6 +35,10 @@ type Config struct {
 	// See: https://prometheus.io/docs/practices/naming/#metric-names
 	Namespace string `mapstructure:"namespace"`
 
+	// TranslationStrategy is the strategy to translate the OpenTelemetry metric names to Prometheus metric names.
+	// The NormalizeName feature gate is followed if empty.
+	TranslationStrategy prometheustranslator.TranslationStrategy `mapstructure:"translation_strategy"`
+
 	// QueueConfig allows users to fine tune the queues
 	// that handle outgoing requests.
 	RemoteWriteQueue RemoteWriteQueue `mapstructure:"remote_write_queue"`
@@ -51,6 +56,23 @@ type Config struct {
 
 	// TargetInfo allows customizing the target_info metric
 	TargetInfo *TargetInfo `mapstructure:"target_info,omitempty"`
+
+	// Tenant allows routing metrics to the tenant found in a resource attribute.
+	Tenant TenantConfig `mapstructure:"tenant"`
+}
+
+// TenantConfig allows to send the metrics of each tenant with its own tenant header.
+type TenantConfig struct {
+	// ResourceAttribute is the resource attribute holding the tenant of the metrics.
+	// Per-tenant routing is disabled if empty.
+	ResourceAttribute string `mapstructure:"resource_attribute"`
+
+	// Header is the HTTP header set to the tenant. Default is X-Scope-OrgID.
+	Header string `mapstructure:"header"`
+
+	// Default is the tenant of the metrics without the resource attribute.
+	// These metrics are sent without the tenant header if empty.
+	Default string `mapstructure:"default"`
 }
 
 type TargetInfo struct {
@@ -71,6 +93,12 @@ type RemoteWriteQueue struct {
 	// NumWorkers configures the number of workers used by
 	// the collector to fan out remote write requests.
 	NumConsumers int `mapstructure:"num_consumers"`
+
+	// NumShards configures the number of parallel queues the time series
+	// are sent through, like the Prometheus remote write shards. Each time
+	// series is assigned to a shard by the hash of its labels, so that its
+	// samples are always sent in order. Sharding is disabled if lower than 2.
+	NumShards int `mapstructure:"num_shards"`
 }
 
 // TODO(jbd): Add capacity, max_samples_per_send to QueueConfig.
@@ -91,6 +119,18 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("remote write consumer number can't be negative")
 	}
 
+	if cfg.RemoteWriteQueue.NumShards < 0 {
+		return fmt.Errorf("remote write shard number can't be negative")
+	}
+
+	if cfg.WAL != nil && cfg.RemoteWriteQueue.NumShards > 1 {
+		return fmt.Errorf("remote write shards can't be used with the WAL")
+	}
+
+	if cfg.WAL != nil && cfg.Tenant.ResourceAttribute != "" {
+		return fmt.Errorf("tenant routing can't be used with the WAL")
+	}
+
 	if cfg.TargetInfo == nil {
 		cfg.TargetInfo = &TargetInfo{
 			Enabled: true,
@@ -29,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/service/servicetest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/resourcetotelemetry"
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 )
 
 // From the default configurations -- checks if a correct exporter is instantiated
@@ -77,8 +78,9 @@ func Test_loadConfigDefaultAutomaticRename(t *testing.T) {
 				QueueSize:    2000,
 				NumConsumers: 10,
 			},
-			Namespace:      "test-space",
-			ExternalLabels: map[string]string{"key1": "value1", "key2": "value2"},
+			Namespace:           "test-space",
+			TranslationStrategy: prometheustranslator.TranslationStrategyNoUTF8EscapingWithSuffixes,
+			ExternalLabels:      map[string]string{"key1": "value1", "key2": "value2"},
 			HTTPClientSettings: confighttp.HTTPClientSettings{
 				Endpoint: "localhost:8888",
 				TLSSetting: configtls.TLSClientSetting{
@@ -142,3 +144,26 @@ func TestDisabledTargetInfo(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, cfg.Exporters[config.NewComponentID(typeStr)].(*Config).TargetInfo.Enabled)
 }
+
+func TestNegativeNumShards(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	_, err = servicetest.LoadConfigAndValidate(filepath.Join("testdata", "negative_num_shards.yaml"), factories)
+	assert.Error(t, err)
+}
+
+func TestWALIncompatibleSettings(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.WAL = &WALConfig{Directory: t.TempDir()}
+	require.NoError(t, cfg.Validate())
+
+	cfg.RemoteWriteQueue.NumShards = 4
+	assert.EqualError(t, cfg.Validate(), "remote write shards can't be used with the WAL")
+
+	cfg.RemoteWriteQueue.NumShards = 0
+	cfg.Tenant.ResourceAttribute = "tenant"
+	assert.EqualError(t, cfg.Validate(), "tenant routing can't be used with the WAL")
+}
@@ -25,6 +25,7 @@ type ctxKey int
 
 const (
 	loggerCtxKey ctxKey = iota
+	tenantCtxKey
 )
 
 func contextWithLogger(ctx context.Context, log *zap.Logger) context.Context {
@@ -44,3 +45,13 @@ func loggerFromContext(ctx context.Context) (*zap.Logger, error) {
 
 	return l, nil
 }
+
+func contextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantCtxKey, tenant)
+}
+
+// tenantFromContext returns the tenant of the write requests, or an empty string if none.
+func tenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantCtxKey).(string)
+	return tenant
+}
@@ -39,23 +39,30 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
 )
 
-const maxBatchByteSize = 3000000
+const (
+	maxBatchByteSize = 3000000
+
+	defaultTenantHeader = "X-Scope-OrgID"
+)
 
 // prwExporter converts OTLP metrics to Prometheus remote write TimeSeries and sends them to a remote endpoint.
 type prwExporter struct {
-	namespace         string
-	externalLabels    map[string]string
-	endpointURL       *url.URL
-	client            *http.Client
-	wg                *sync.WaitGroup
-	closeChan         chan struct{}
-	concurrency       int
-	userAgentHeader   string
-	clientSettings    *confighttp.HTTPClientSettings
-	settings          component.TelemetrySettings
-	disableTargetInfo bool
-
-	wal *prweWAL
+	namespace           string
+	translationStrategy prometheustranslator.TranslationStrategy
+	externalLabels      map[string]string
+	endpointURL         *url.URL
+	client              *http.Client
+	wg                  *sync.WaitGroup
+	closeChan           chan struct{}
+	concurrency         int
+	userAgentHeader     string
+	clientSettings      *confighttp.HTTPClientSettings
+	settings            component.TelemetrySettings
+	disableTargetInfo   bool
+	tenant              TenantConfig
+
+	wal    *prweWAL
+	shards *shardedSender
 }
 
 // newPRWExporter initializes a new prwExporter instance and sets fields accordingly.
@@ -73,16 +80,24 @@ func newPRWExporter(cfg *Config, set component.ExporterCreateSettings) (*prwExpo
 	userAgentHeader := fmt.Sprintf("%s/%s", strings.ReplaceAll(strings.ToLower(set.BuildInfo.Description), " ", "-"), set.BuildInfo.Version)
 
 	prwe := &prwExporter{
-		namespace:         cfg.Namespace,
-		externalLabels:    sanitizedLabels,
-		endpointURL:       endpointURL,
-		wg:                new(sync.WaitGroup),
-		closeChan:         make(chan struct{}),
-		userAgentHeader:   userAgentHeader,
-		concurrency:       cfg.RemoteWriteQueue.NumConsumers,
-		clientSettings:    &cfg.HTTPClientSettings,
-		settings:          set.TelemetrySettings,
-		disableTargetInfo: !cfg.TargetInfo.Enabled,
+		namespace:           cfg.Namespace,
+		translationStrategy: cfg.TranslationStrategy,
+		externalLabels:      sanitizedLabels,
+		endpointURL:         endpointURL,
+		wg:                  new(sync.WaitGroup),
+		closeChan:           make(chan struct{}),
+		userAgentHeader:     userAgentHeader,
+		concurrency:         cfg.RemoteWriteQueue.NumConsumers,
+		clientSettings:      &cfg.HTTPClientSettings,
+		settings:            set.TelemetrySettings,
+		disableTargetInfo:   !cfg.TargetInfo.Enabled,
+		tenant:              cfg.Tenant,
+	}
+	if prwe.tenant.Header == "" {
+		prwe.tenant.Header = defaultTenantHeader
+	}
+	if cfg.RemoteWriteQueue.NumShards > 1 {
+		prwe.shards = newShardedSender(cfg.RemoteWriteQueue.NumShards, cfg.RetrySettings, set.Logger, prwe.execute)
 	}
 	if cfg.WAL == nil {
 		return prwe, nil
@@ -101,6 +116,9 @@ func (prwe *prwExporter) Start(ctx context.Context, host component.Host) (err er
 	if err != nil {
 		return err
 	}
+	if prwe.shards != nil {
+		prwe.shards.start()
+	}
 	return prwe.turnOnWALIfEnabled(contextWithLogger(ctx, prwe.settings.Logger.Named("prw.wal")))
 }
 
@@ -113,7 +131,7 @@ func (prwe *prwExporter) shutdownWALIfEnabled() error {
 
 // Shutdown stops the exporter from accepting incoming calls(and return error), and wait for current export operations
 // to finish before returning
-func (prwe *prwExporter) Shutdown(context.Context) error {
+func (prwe *prwExporter) Shutdown(ctx context.Context) error {
 	select {
 	case <-prwe.closeChan:
 	default:
@@ -121,6 +139,9 @@ func (prwe *prwExporter) Shutdown(context.Context) error {
 	}
 	err := prwe.shutdownWALIfEnabled()
 	prwe.wg.Wait()
+	if prwe.shards != nil {
+		prwe.shards.shutdown(ctx)
+	}
 	return err
 }
 
@@ -135,15 +156,50 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
-		tsMap, err := prometheusremotewrite.FromMetrics(md, prometheusremotewrite.Settings{Namespace: prwe.namespace, ExternalLabels: prwe.externalLabels, DisableTargetInfo: prwe.disableTargetInfo})
-		if err != nil {
-			err = consumererror.NewPermanent(err)
+		var errs error
+		for tenant, tenantMetrics := range prwe.splitByTenant(md) {
+			tsMap, err := prometheusremotewrite.FromMetrics(tenantMetrics, prometheusremotewrite.Settings{
+				Namespace:           prwe.namespace,
+				ExternalLabels:      prwe.externalLabels,
+				DisableTargetInfo:   prwe.disableTargetInfo,
+				TranslationStrategy: prwe.translationStrategy,
+			})
+			if err != nil {
+				err = consumererror.NewPermanent(err)
+			}
+			// Call export even if a conversion error, since there may be points that were successfully converted.
+			errs = multierr.Combine(errs, err, prwe.handleExport(contextWithTenant(ctx, tenant), tsMap))
 		}
-		// Call export even if a conversion error, since there may be points that were successfully converted.
-		return multierr.Combine(err, prwe.handleExport(ctx, tsMap))
+		return errs
 	}
 }
 
+// splitByTenant groups the resource metrics by the tenant found in the configured
+// resource attribute. All the metrics belong to the default tenant if per-tenant
+// routing is disabled.
+func (prwe *prwExporter) splitByTenant(md pmetric.Metrics) map[string]pmetric.Metrics {
+	if prwe.tenant.ResourceAttribute == "" {
+		return map[string]pmetric.Metrics{prwe.tenant.Default: md}
+	}
+
+	tenants := make(map[string]pmetric.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		tenant := prwe.tenant.Default
+		if v, ok := rm.Resource().Attributes().Get(prwe.tenant.ResourceAttribute); ok && v.AsString() != "" {
+			tenant = v.AsString()
+		}
+		tenantMetrics, ok := tenants[tenant]
+		if !ok {
+			tenantMetrics = pmetric.NewMetrics()
+			tenants[tenant] = tenantMetrics
+		}
+		rm.CopyTo(tenantMetrics.ResourceMetrics().AppendEmpty())
+	}
+	return tenants
+}
+
 func validateAndSanitizeExternalLabels(cfg *Config) (map[string]string, error) {
 	sanitizedLabels := make(map[string]string)
 	for key, value := range cfg.ExternalLabels {
@@ -162,6 +218,11 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 		return nil
 	}
 
+	if prwe.shards != nil {
+		// The time series are sent in parallel by the shards.
+		return prwe.shards.enqueue(ctx, tsMap)
+	}
+
 	// Calls the helper function to convert and batch the TsMap to the desired format
 	requests, err := batchTimeSeries(tsMap, maxBatchByteSize)
 	if err != nil {
@@ -244,6 +305,9 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	req.Header.Set("Content-Type", "application/x-protobuf")
 	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
 	req.Header.Set("User-Agent", prwe.userAgentHeader)
+	if tenant := tenantFromContext(ctx); tenant != "" {
+		req.Header.Set(prwe.tenant.Header, tenant)
+	}
 
 	resp, err := prwe.client.Do(req)
 	if err != nil {
@@ -16,13 +16,16 @@ package prometheusremotewriteexporter
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
@@ -35,7 +38,9 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
@@ -950,3 +955,136 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 	assert.Equal(t, want, gotFromUpload)
 	assert.Equal(t, gotFromWAL, gotFromUpload)
 }
+
+func decodeWriteRequest(t *testing.T, r *http.Request) *prompb.WriteRequest {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	dest, err := snappy.Decode(nil, body)
+	require.NoError(t, err)
+	writeReq := &prompb.WriteRequest{}
+	require.NoError(t, proto.Unmarshal(dest, writeReq))
+	return writeReq
+}
+
+func newTestExporter(t *testing.T, endpoint string, configure func(cfg *Config)) *prwExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = endpoint
+	configure(cfg)
+	require.NoError(t, cfg.Validate())
+
+	prwe, err := newPRWExporter(cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	return prwe
+}
+
+func TestPushMetricsPerTenant(t *testing.T) {
+	var mu sync.Mutex
+	seriesByTenant := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReq := decodeWriteRequest(t, r)
+		mu.Lock()
+		seriesByTenant[r.Header.Get("X-Tenant")] += len(writeReq.Timeseries)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	prwe := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.Tenant = TenantConfig{
+			ResourceAttribute: "tenant",
+			Header:            "X-Tenant",
+			Default:           "anonymous",
+		}
+		cfg.TargetInfo.Enabled = false
+	})
+
+	md := pmetric.NewMetrics()
+	for i, tenant := range []string{"team-a", "team-b", "team-a", ""} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if tenant != "" {
+			rm.Resource().Attributes().PutStr("tenant", tenant)
+		}
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName(fmt.Sprintf("metric_%d", i))
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(int64(i))
+	}
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+	require.NoError(t, prwe.Shutdown(context.Background()))
+
+	assert.Equal(t, map[string]int{"team-a": 2, "team-b": 1, "anonymous": 1}, seriesByTenant)
+}
+
+func TestPushMetricsSharded(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReq := decodeWriteRequest(t, r)
+		mu.Lock()
+		for _, ts := range writeReq.Timeseries {
+			for _, l := range ts.Labels {
+				if l.Name == "__name__" {
+					received[l.Value] = true
+				}
+			}
+		}
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	prwe := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.RemoteWriteQueue.NumShards = 4
+		cfg.TargetInfo.Enabled = false
+	})
+	require.NotNil(t, prwe.shards)
+
+	const numMetrics = 50
+	var metrics []pmetric.Metric
+	for i := 0; i < numMetrics; i++ {
+		metrics = append(metrics, getIntGaugeMetric(fmt.Sprintf("metric_%d", i), pcommon.NewMap(), int64(i), uint64(time.Now().UnixNano())))
+	}
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), getMetricsFromMetricList(metrics...)))
+	require.NoError(t, prwe.Shutdown(context.Background()))
+
+	assert.Len(t, received, numMetrics)
+}
+
+func TestPushMetricsShardedError(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	prwe := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.RemoteWriteQueue.NumShards = 4
+		cfg.RetrySettings.Enabled = false
+		cfg.TargetInfo.Enabled = false
+	})
+
+	var metrics []pmetric.Metric
+	for i := 0; i < 10; i++ {
+		metrics = append(metrics, getIntGaugeMetric(fmt.Sprintf("metric_%d", i), pcommon.NewMap(), int64(i), uint64(time.Now().UnixNano())))
+	}
+
+	// The send errors are reported to the caller instead of being dropped,
+	// as permanent errors since the shards already retried the requests.
+	err := prwe.PushMetrics(context.Background(), getMetricsFromMetricList(metrics...))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Greater(t, atomic.LoadInt32(&requests), int32(0))
+	require.NoError(t, prwe.Shutdown(context.Background()))
+}
+
+func TestShardIndex(t *testing.T) {
+	for _, signature := range []string{"", "a", "metric_1-label-value"} {
+		i := shardIndex(signature, 4)
+		assert.GreaterOrEqual(t, i, 0)
+		assert.Less(t, i, 4)
+		assert.Equal(t, i, shardIndex(signature, 4))
+	}
+}
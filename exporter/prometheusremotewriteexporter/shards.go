@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// shardQueueCapacity is the number of write requests each shard can hold
+// before enqueueing blocks.
+const shardQueueCapacity = 5
+
+// shardRequest is a write request queued on a shard, along with its tenant
+// and the channel its send result is reported to.
+type shardRequest struct {
+	tenant  string
+	request *prompb.WriteRequest
+	result  chan<- error
+}
+
+// shardedSender sends the time series through several parallel queues, like
+// the Prometheus remote write shards. Each time series is assigned to a shard
+// by the hash of its labels, so that its samples are always sent in order.
+type shardedSender struct {
+	queues []chan shardRequest
+	send   func(context.Context, *prompb.WriteRequest) error
+	retry  exporterhelper.RetrySettings
+	logger *zap.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newShardedSender(numShards int, retry exporterhelper.RetrySettings, logger *zap.Logger, send func(context.Context, *prompb.WriteRequest) error) *shardedSender {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &shardedSender{
+		queues: make([]chan shardRequest, numShards),
+		send:   send,
+		retry:  retry,
+		logger: logger,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for i := range s.queues {
+		s.queues[i] = make(chan shardRequest, shardQueueCapacity)
+	}
+	return s
+}
+
+// start starts a sender per shard.
+func (s *shardedSender) start() {
+	s.wg.Add(len(s.queues))
+	for _, queue := range s.queues {
+		go s.run(queue)
+	}
+}
+
+// shutdown stops the shards once their queued requests have been sent, or
+// aborts them when the context is done.
+func (s *shardedSender) shutdown(ctx context.Context) {
+	for _, queue := range s.queues {
+		close(queue)
+	}
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.cancel()
+		<-done
+	}
+	s.cancel()
+}
+
+// enqueue assigns the time series to the shards and blocks until all the
+// resulting write requests are sent. The tenant of the requests is taken from
+// the context. The requests that could not be sent, after being retried by
+// their shard, are reported as permanent errors so that they are not retried
+// again.
+func (s *shardedSender) enqueue(ctx context.Context, tsMap map[string]*prompb.TimeSeries) error {
+	shardMaps := make([]map[string]*prompb.TimeSeries, len(s.queues))
+	for key, ts := range tsMap {
+		i := shardIndex(key, len(s.queues))
+		if shardMaps[i] == nil {
+			shardMaps[i] = make(map[string]*prompb.TimeSeries)
+		}
+		shardMaps[i][key] = ts
+	}
+
+	tenant := tenantFromContext(ctx)
+	requests := make([][]*prompb.WriteRequest, len(shardMaps))
+	numRequests := 0
+	for i, shardMap := range shardMaps {
+		if len(shardMap) == 0 {
+			continue
+		}
+		var err error
+		if requests[i], err = batchTimeSeries(shardMap, maxBatchByteSize); err != nil {
+			return err
+		}
+		numRequests += len(requests[i])
+	}
+
+	// The channel is large enough for the shards to never block on it, even
+	// when the results are no longer waited for.
+	results := make(chan error, numRequests)
+	queued := 0
+	var errs error
+queueing:
+	for i, shardRequests := range requests {
+		for _, request := range shardRequests {
+			select {
+			case s.queues[i] <- shardRequest{tenant: tenant, request: request, result: results}:
+				queued++
+			case <-ctx.Done():
+				// The requests already queued are still waited for.
+				errs = ctx.Err()
+				break queueing
+			}
+		}
+	}
+
+	for ; queued > 0; queued-- {
+		if err := <-results; err != nil {
+			errs = multierr.Append(errs, consumererror.NewPermanent(err))
+		}
+	}
+	return errs
+}
+
+// shardIndex returns the shard of the time series with the given label signature.
+func shardIndex(signature string, numShards int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(signature))
+	return int(h.Sum32() % uint32(numShards))
+}
+
+func (s *shardedSender) run(queue chan shardRequest) {
+	defer s.wg.Done()
+	for req := range queue {
+		err := s.sendWithRetry(contextWithTenant(s.ctx, req.tenant), req.request)
+		if err != nil {
+			s.logger.Debug("Failed to send time series",
+				zap.String("tenant", req.tenant),
+				zap.Int("series", len(req.request.Timeseries)),
+				zap.Error(err))
+		}
+		req.result <- err
+	}
+}
+
+// sendWithRetry sends the write request, retrying on recoverable errors.
+func (s *shardedSender) sendWithRetry(ctx context.Context, request *prompb.WriteRequest) error {
+	if !s.retry.Enabled {
+		return s.send(ctx, request)
+	}
+
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = s.retry.InitialInterval
+	expBackoff.MaxInterval = s.retry.MaxInterval
+	expBackoff.MaxElapsedTime = s.retry.MaxElapsedTime
+	return backoff.Retry(func() error {
+		err := s.send(ctx, request)
+		if consumererror.IsPermanent(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, backoff.WithContext(expBackoff, ctx))
+}
@@ -14,7 +14,12 @@
 
 package sentryexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sentryexporter"
 
-import "go.opentelemetry.io/collector/config"
+import (
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config"
+)
 
 // Config defines the configuration for the Sentry Exporter.
 type Config struct {
@@ -23,4 +28,31 @@ type Config struct {
 	DSN string `mapstructure:"dsn"`
 	// InsecureSkipVerify controls whether the client verifies the Sentry server certificate chain
 	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+	// Tunnel is the URL of an endpoint forwarding the envelopes to Sentry. If set, the events are sent
+	// to the tunnel as envelopes holding the DSN, instead of being sent to the host of the DSN.
+	Tunnel string `mapstructure:"tunnel"`
+	// AttributeMapping defines the resource attributes used as the release, environment and dist of the events.
+	AttributeMapping AttributeMapping `mapstructure:"attribute_mapping"`
+}
+
+// AttributeMapping defines the resource attributes used as the release, environment and dist of the events.
+// An empty attribute name disables the mapping.
+type AttributeMapping struct {
+	// Release is the resource attribute used as the release of the events.
+	Release string `mapstructure:"release"`
+	// Environment is the resource attribute used as the environment of the events.
+	Environment string `mapstructure:"environment"`
+	// Dist is the resource attribute used as the distribution of the release of the events.
+	Dist string `mapstructure:"dist"`
+}
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Tunnel != "" {
+		u, err := url.Parse(cfg.Tunnel)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid tunnel URL %q", cfg.Tunnel)
+		}
+	}
+	return nil
 }
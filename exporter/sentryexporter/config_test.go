@@ -43,5 +43,31 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, e1, &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
 		DSN:              "https://key@host/path/42",
+		AttributeMapping: AttributeMapping{
+			Release:     "service.version",
+			Environment: "deployment.environment",
+		},
 	})
+
+	e2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "3")]
+	assert.Equal(t, e2, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "3")),
+		DSN:              "https://key@host/path/42",
+		Tunnel:           "https://tunnel.example.com/sentry",
+		AttributeMapping: AttributeMapping{
+			Release: "app.release",
+			Dist:    "app.dist",
+		},
+	})
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Tunnel = "/sentry"
+	assert.EqualError(t, cfg.Validate(), `invalid tunnel URL "/sentry"`)
+
+	cfg.Tunnel = "https://tunnel.example.com/sentry"
+	assert.NoError(t, cfg.Validate())
 }
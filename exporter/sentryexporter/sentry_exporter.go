@@ -79,7 +79,8 @@ var canonicalCodesGrpcMap = map[string]sentry.SpanStatus{
 
 // SentryExporter defines the Sentry Exporter.
 type SentryExporter struct {
-	transport transport
+	transport        transport
+	attributeMapping AttributeMapping
 }
 
 // pushTraceData takes an incoming OpenTelemetry trace, converts them into Sentry spans and transactions
@@ -145,6 +146,10 @@ func (s *SentryExporter) pushTraceData(_ context.Context, td ptrace.Traces) erro
 
 	transactions = append(transactions, exceptionEvents...)
 
+	for _, event := range transactions {
+		s.attributeMapping.apply(event)
+	}
+
 	s.transport.SendEvents(transactions)
 
 	return nil
@@ -458,6 +463,20 @@ func transactionFromSpan(span *sentry.Span) *sentry.Event {
 	return transaction
 }
 
+// apply sets the release, environment and dist of an event from its tags, which hold the
+// resource attributes of the span the event was created from.
+func (m AttributeMapping) apply(event *sentry.Event) {
+	if release, ok := event.Tags[m.Release]; ok && m.Release != "" {
+		event.Release = release
+	}
+	if environment, ok := event.Tags[m.Environment]; ok && m.Environment != "" {
+		event.Environment = environment
+	}
+	if dist, ok := event.Tags[m.Dist]; ok && m.Dist != "" {
+		event.Dist = dist
+	}
+}
+
 func uuid() string {
 	id := make([]byte, 16)
 	// Prefer rand.Read over rand.Reader, see https://go-review.googlesource.com/c/go/+/272326/.
@@ -485,10 +504,19 @@ func CreateSentryExporter(config *Config, set component.ExporterCreateSettings)
 		clientOptions.HTTPTransport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
 	}
 
+	if config.Tunnel != "" {
+		tunnel, err := newTunnelRoundTripper(config.Tunnel, config.DSN, clientOptions.HTTPTransport)
+		if err != nil {
+			return nil, err
+		}
+		clientOptions.HTTPTransport = tunnel
+	}
+
 	transport.Configure(clientOptions)
 
 	s := &SentryExporter{
-		transport: transport,
+		transport:        transport,
+		attributeMapping: config.AttributeMapping,
 	}
 
 	return exporterhelper.NewTracesExporter(
@@ -25,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
@@ -736,6 +737,38 @@ func TestPushTraceData(t *testing.T) {
 	}
 }
 
+func TestPushTraceDataAttributeMapping(t *testing.T) {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(conventions.AttributeServiceVersion, "1.2.3")
+	rs.Resource().Attributes().PutStr(conventions.AttributeDeploymentEnvironment, "production")
+	rs.Resource().Attributes().PutStr("app.dist", "42")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	exception := span.Events().AppendEmpty()
+	exception.SetName("exception")
+	exception.Attributes().PutStr(conventions.AttributeExceptionMessage, "error message")
+
+	transport := &mockTransport{}
+	s := &SentryExporter{
+		transport: transport,
+		attributeMapping: AttributeMapping{
+			Release:     conventions.AttributeServiceVersion,
+			Environment: conventions.AttributeDeploymentEnvironment,
+			Dist:        "app.dist",
+		},
+	}
+
+	require.NoError(t, s.pushTraceData(context.Background(), traces))
+	require.Len(t, transport.transactions, 2)
+	for _, event := range transport.transactions {
+		assert.Equal(t, "1.2.3", event.Release)
+		assert.Equal(t, "production", event.Environment)
+		assert.Equal(t, "42", event.Dist)
+	}
+}
+
 type TransactionFromSpanMarshalEventTestCase struct {
 	testName string
 	// input
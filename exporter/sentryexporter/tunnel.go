@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentryexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sentryexporter"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// tunnelRoundTripper sends the requests of the Sentry transport to a tunnel. A tunnel only
+// accepts envelopes, and finds out where to forward them from the DSN in their header, so the
+// DSN is added to the header of the transaction envelopes, and the error events, which are
+// sent to the store endpoint, are wrapped into envelopes.
+//
+// See https://docs.sentry.io/platforms/javascript/troubleshooting/#using-the-tunnel-option
+type tunnelRoundTripper struct {
+	tunnel *url.URL
+	dsn    string
+	next   http.RoundTripper
+}
+
+func newTunnelRoundTripper(tunnel string, dsn string, next http.RoundTripper) (*tunnelRoundTripper, error) {
+	u, err := url.Parse(tunnel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tunnel URL %q: %w", tunnel, err)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &tunnelRoundTripper{
+		tunnel: u,
+		dsn:    dsn,
+		next:   next,
+	}, nil
+}
+
+func (t *tunnelRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope []byte
+	if strings.HasSuffix(req.URL.Path, "/envelope/") {
+		envelope, err = envelopeWithDSN(body, t.dsn)
+	} else {
+		envelope, err = eventEnvelope(body, t.dsn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tunnelReq := req.Clone(req.Context())
+	tunnelReq.URL = t.tunnel
+	tunnelReq.Host = t.tunnel.Host
+	tunnelReq.Body = io.NopCloser(bytes.NewReader(envelope))
+	tunnelReq.ContentLength = int64(len(envelope))
+	tunnelReq.Header.Set("Content-Type", "application/x-sentry-envelope")
+	return t.next.RoundTrip(tunnelReq)
+}
+
+// envelopeWithDSN adds the DSN to the header of an envelope, which is its first line.
+func envelopeWithDSN(envelope []byte, dsn string) ([]byte, error) {
+	headerEnd := bytes.IndexByte(envelope, '\n')
+	if headerEnd < 0 {
+		headerEnd = len(envelope)
+	}
+
+	header := map[string]interface{}{}
+	if err := json.Unmarshal(envelope[:headerEnd], &header); err != nil {
+		return nil, fmt.Errorf("failed to parse envelope header: %w", err)
+	}
+	header["dsn"] = dsn
+
+	b, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, envelope[headerEnd:]...), nil
+}
+
+// eventEnvelope wraps an event into an envelope holding the DSN.
+func eventEnvelope(event []byte, dsn string) ([]byte, error) {
+	var e struct {
+		EventID string `json:"event_id"`
+	}
+	if err := json.Unmarshal(event, &e); err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	if err := enc.Encode(map[string]string{"event_id": e.EventID, "dsn": dsn}); err != nil {
+		return nil, err
+	}
+	if err := enc.Encode(map[string]interface{}{"type": "event", "length": len(event)}); err != nil {
+		return nil, err
+	}
+	b.Write(event)
+	b.WriteByte('\n')
+	return b.Bytes(), nil
+}
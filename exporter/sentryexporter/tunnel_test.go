@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentryexporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelopeWithDSN(t *testing.T) {
+	envelope := []byte("{\"event_id\":\"abc\"}\n{\"type\":\"transaction\",\"length\":2}\n{}\n")
+
+	got, err := envelopeWithDSN(envelope, "https://key@host/42")
+	require.NoError(t, err)
+	assert.Equal(t, "{\"dsn\":\"https://key@host/42\",\"event_id\":\"abc\"}\n{\"type\":\"transaction\",\"length\":2}\n{}\n", string(got))
+
+	_, err = envelopeWithDSN([]byte("not json\n"), "https://key@host/42")
+	assert.Error(t, err)
+}
+
+func TestEventEnvelope(t *testing.T) {
+	event := []byte(`{"event_id":"abc","message":"error"}`)
+
+	got, err := eventEnvelope(event, "https://key@host/42")
+	require.NoError(t, err)
+	assert.Equal(t, "{\"dsn\":\"https://key@host/42\",\"event_id\":\"abc\"}\n{\"length\":36,\"type\":\"event\"}\n"+string(event)+"\n", string(got))
+
+	_, err = eventEnvelope([]byte("not json"), "https://key@host/42")
+	assert.Error(t, err)
+}
+
+func TestTunnel(t *testing.T) {
+	var mu sync.Mutex
+	var envelopes [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tunnel", r.URL.Path)
+		assert.Equal(t, "application/x-sentry-envelope", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		envelopes = append(envelopes, body)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	dsn := "https://key@sentry.invalid/42"
+	tunnel, err := newTunnelRoundTripper(server.URL+"/tunnel", dsn, nil)
+	require.NoError(t, err)
+
+	transport := newSentryTransport()
+	transport.Configure(sentry.ClientOptions{Dsn: dsn, HTTPTransport: tunnel})
+
+	transaction := transactionFromSpan(&sentry.Span{
+		TraceID: TraceIDFromHex("1915f8aa35ff8fbebbfeedb9d7e07216"),
+		SpanID:  SpanIDFromHex("ea4864700408805c"),
+	})
+	errorEvent, err := sentryEventFromError("error message", "error type", &sentry.Span{
+		TraceID: TraceIDFromHex("1915f8aa35ff8fbebbfeedb9d7e07216"),
+		SpanID:  SpanIDFromHex("ea4864700408805c"),
+	})
+	require.NoError(t, err)
+
+	transport.SendEvents([]*sentry.Event{transaction, errorEvent})
+	require.True(t, transport.httpTransport.Flush(5*time.Second))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, envelopes, 2)
+
+	itemTypes := make([]string, 0, len(envelopes))
+	for _, envelope := range envelopes {
+		lines := bytes.Split(envelope, []byte("\n"))
+		require.GreaterOrEqual(t, len(lines), 3)
+
+		var header map[string]interface{}
+		require.NoError(t, json.Unmarshal(lines[0], &header))
+		assert.Equal(t, dsn, header["dsn"])
+
+		var itemHeader map[string]interface{}
+		require.NoError(t, json.Unmarshal(lines[1], &itemHeader))
+		itemTypes = append(itemTypes, itemHeader["type"].(string))
+	}
+	assert.ElementsMatch(t, []string{"transaction", "event"}, itemTypes)
+}
@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/correlation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/dimensions"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
@@ -91,6 +92,11 @@ type Config struct {
 	//            And keep `override=true` in resourcedetection config.
 	SyncHostMetadata bool `mapstructure:"sync_host_metadata"`
 
+	// DimensionSync defines resource attributes of metrics that are synced as
+	// properties of the dimension identified by another resource attribute,
+	// e.g. the service and environment of a pod on the "k8s.pod.uid" dimension.
+	DimensionSync []dimensions.ResourceSyncRule `mapstructure:"dimension_sync"`
+
 	// ExcludeMetrics defines dpfilter.MetricFilters that will determine metrics to be
 	// excluded from sending to SignalFx backend. If translations enabled with
 	// TranslationRules options, the exclusion will be applie on translated metrics.
@@ -165,6 +171,15 @@ func (cfg *Config) validateConfig() error {
 		return errors.New(`cannot have a negative "max_connections"`)
 	}
 
+	for i, rule := range cfg.DimensionSync {
+		if rule.Dimension == "" {
+			return fmt.Errorf(`"dimension_sync[%d]" requires a non-empty "dimension"`, i)
+		}
+		if len(rule.Properties) == 0 {
+			return fmt.Errorf(`"dimension_sync[%d]" requires at least one entry in "properties"`, i)
+		}
+	}
+
 	return nil
 }
 
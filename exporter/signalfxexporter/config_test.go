@@ -31,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/service/servicetest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/correlation"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/dimensions"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/translation/dpfilters"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
@@ -117,6 +118,15 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		DimensionSync: []dimensions.ResourceSyncRule{
+			{
+				Dimension: "k8s.pod.uid",
+				Properties: map[string]string{
+					"service.name":           "service",
+					"deployment.environment": "environment",
+				},
+			},
+		},
 		ExcludeMetrics: []dpfilters.MetricFilter{
 			{
 				MetricName: "metric1",
@@ -201,6 +211,7 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		Headers          map[string]string
 		TranslationRules []translation.Rule
 		SyncHostMetadata bool
+		DimensionSync    []dimensions.ResourceSyncRule
 	}
 	tests := []struct {
 		name    string
@@ -287,6 +298,30 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Test dimension sync without dimension",
+			fields: fields{
+				Realm:       "us0",
+				AccessToken: "access_token",
+				DimensionSync: []dimensions.ResourceSyncRule{
+					{Properties: map[string]string{"service.name": "service"}},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Test dimension sync without properties",
+			fields: fields{
+				Realm:       "us0",
+				AccessToken: "access_token",
+				DimensionSync: []dimensions.ResourceSyncRule{
+					{Dimension: "k8s.pod.uid"},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "Test empty config",
 			want:    nil,
@@ -321,6 +356,7 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 				Headers:             tt.fields.Headers,
 				TranslationRules:    tt.fields.TranslationRules,
 				SyncHostMetadata:    tt.fields.SyncHostMetadata,
+				DimensionSync:       tt.fields.DimensionSync,
 				DeltaTranslationTTL: 3600,
 			}
 
@@ -62,6 +62,7 @@ type signalfxExporter struct {
 	pushMetadata       func(metadata []*metadata.MetadataUpdate) error
 	pushLogsData       func(ctx context.Context, ld plog.Logs) (droppedLogRecords int, err error)
 	hostMetadataSyncer *hostmetadata.Syncer
+	resourceSyncer     *dimensions.ResourceSyncer
 }
 
 type exporterOptions struct {
@@ -147,10 +148,16 @@ func newSignalFxExporter(
 		hms = hostmetadata.NewSyncer(logger, dimClient)
 	}
 
+	var rs *dimensions.ResourceSyncer
+	if len(config.DimensionSync) > 0 {
+		rs = dimensions.NewResourceSyncer(logger, dimClient, config.DimensionSync)
+	}
+
 	return &signalfxExporter{
 		pushMetricsData:    dpClient.pushMetricsData,
 		pushMetadata:       dimClient.PushMetadata,
 		hostMetadataSyncer: hms,
+		resourceSyncer:     rs,
 	}, nil
 }
 
@@ -202,6 +209,9 @@ func (se *signalfxExporter) pushMetrics(ctx context.Context, md pmetric.Metrics)
 	if err == nil && se.hostMetadataSyncer != nil {
 		se.hostMetadataSyncer.Sync(md)
 	}
+	if err == nil && se.resourceSyncer != nil {
+		se.resourceSyncer.Sync(md)
+	}
 	return err
 }
 
@@ -32,7 +32,8 @@ import (
 
 // Tracker correlation
 type Tracker struct {
-	once         sync.Once
+	mu           sync.Mutex
+	warnedHostID bool
 	log          *zap.Logger
 	cfg          *Config
 	params       component.ExporterCreateSettings
@@ -96,41 +97,56 @@ func (cor *Tracker) AddSpans(ctx context.Context, traces ptrace.Traces) error {
 		return nil
 	}
 
-	cor.once.Do(func() {
-		res := traces.ResourceSpans().At(0).Resource()
-		hostID, ok := splunk.ResourceToHostID(res)
+	cor.mu.Lock()
+	if cor.traceTracker == nil {
+		cor.startTraceTracker(traces.ResourceSpans())
+	}
+	traceTracker := cor.traceTracker
+	cor.mu.Unlock()
 
-		if ok {
-			cor.log.Info("Detected host resource ID for correlation", zap.Any("hostID", hostID))
-		} else {
-			cor.log.Warn("Unable to determine host resource ID for correlation syncing")
-			return
-		}
+	if traceTracker != nil {
+		traceTracker.AddSpansGeneric(ctx, spanListWrap{traces.ResourceSpans()})
+	}
 
-		hostDimension := string(hostID.Key)
+	return nil
+}
 
-		cor.traceTracker = tracetracker.New(
-			newZapShim(cor.params.Logger),
-			cor.cfg.StaleServiceTimeout,
-			cor.correlation,
-			map[string]string{
-				hostDimension: hostID.ID,
-			},
-			false,
-			nil,
-			cor.cfg.SyncAttributes)
+// startTraceTracker starts tracking the services and environments of spans
+// once the host of the collector can be determined from the resource of a
+// batch. Batches without host attributes are skipped until one is received,
+// so correlation is not disabled forever by a single early batch.
+func (cor *Tracker) startTraceTracker(rss ptrace.ResourceSpansSlice) {
+	var hostID splunk.HostID
+	found := false
+	for i := 0; i < rss.Len() && !found; i++ {
+		hostID, found = splunk.ResourceToHostID(rss.At(i).Resource())
+	}
+	if !found {
+		if !cor.warnedHostID {
+			cor.log.Warn("Unable to determine host resource ID for correlation syncing, waiting for spans with host attributes")
+			cor.warnedHostID = true
+		}
+		return
+	}
+	cor.log.Info("Detected host resource ID for correlation", zap.Any("hostID", hostID))
 
-		cor.pTicker = &timeutils.PolicyTicker{OnTickFunc: cor.traceTracker.Purge}
-		cor.pTicker.Start(cor.cfg.StaleServiceTimeout)
+	hostDimension := string(hostID.Key)
 
-		cor.correlation.Start()
-	})
+	cor.traceTracker = tracetracker.New(
+		newZapShim(cor.params.Logger),
+		cor.cfg.StaleServiceTimeout,
+		cor.correlation,
+		map[string]string{
+			hostDimension: hostID.ID,
+		},
+		false,
+		nil,
+		cor.cfg.SyncAttributes)
 
-	if cor.traceTracker != nil {
-		cor.traceTracker.AddSpansGeneric(ctx, spanListWrap{traces.ResourceSpans()})
-	}
+	cor.pTicker = &timeutils.PolicyTicker{OnTickFunc: cor.traceTracker.Purge}
+	cor.pTicker.Start(cor.cfg.StaleServiceTimeout)
 
-	return nil
+	cor.correlation.Start()
 }
 
 // Start correlation tracking.
@@ -150,6 +166,8 @@ func (cor *Tracker) Shutdown(_ context.Context) error {
 			cor.correlation.cancel()
 		}
 
+		cor.mu.Lock()
+		defer cor.mu.Unlock()
 		if cor.pTicker != nil {
 			cor.pTicker.Stop()
 		}
@@ -53,6 +53,31 @@ func TestTrackerAddSpans(t *testing.T) {
 	assert.NoError(t, tracker.Shutdown(context.Background()))
 }
 
+func TestTrackerAddSpansWithoutHostID(t *testing.T) {
+	tracker := NewTracker(
+		DefaultConfig(),
+		"abcd",
+		componenttest.NewNopExporterCreateSettings(),
+	)
+
+	err := tracker.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	// Spans without host attributes do not disable correlation.
+	noHost := ptrace.NewTraces()
+	noHost.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+	assert.NoError(t, tracker.AddSpans(context.Background(), noHost))
+	assert.Nil(t, tracker.traceTracker)
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+	traces.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("host.name", "localhost")
+	assert.NoError(t, tracker.AddSpans(context.Background(), traces))
+	assert.NotNil(t, tracker.traceTracker, "trace tracker should be set")
+
+	assert.NoError(t, tracker.Shutdown(context.Background()))
+}
+
 func TestTrackerStart(t *testing.T) {
 
 	tests := []struct {
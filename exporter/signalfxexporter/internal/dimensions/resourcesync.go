@@ -0,0 +1,166 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dimensions // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter/internal/dimensions"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+)
+
+// maxSyncedDimensions bounds the number of dimensions whose last synced
+// properties are remembered. The cache is reset when it is exceeded, which
+// only results in the properties being sent again.
+const maxSyncedDimensions = 10000
+
+// ResourceSyncRule defines resource attributes that are synced as properties
+// of the dimension identified by another resource attribute.
+type ResourceSyncRule struct {
+	// Dimension is the resource attribute holding the value of the dimension
+	// to update, e.g. "k8s.pod.uid".
+	Dimension string `mapstructure:"dimension"`
+
+	// Properties maps the resource attributes to sync to the name of the
+	// dimension property they are synced to. The attribute key is used as
+	// property name when no name is given.
+	Properties map[string]string `mapstructure:"properties"`
+}
+
+// ResourceSyncer pushes property updates for the dimensions described by the
+// resource attributes of metrics. An update is only sent when the synced
+// properties of a dimension change.
+type ResourceSyncer struct {
+	logger    *zap.Logger
+	dimClient MetadataUpdateClient
+	rules     []ResourceSyncRule
+
+	mu     sync.Mutex
+	synced map[DimensionKey]map[string]string
+}
+
+// NewResourceSyncer creates a ResourceSyncer for the given rules.
+func NewResourceSyncer(logger *zap.Logger, dimClient MetadataUpdateClient, rules []ResourceSyncRule) *ResourceSyncer {
+	return &ResourceSyncer{
+		logger:    logger,
+		dimClient: dimClient,
+		rules:     rules,
+		synced:    map[DimensionKey]map[string]string{},
+	}
+}
+
+// Sync sends the properties of every dimension found in the resources of md
+// that changed since they were last synced. The properties of all the rules
+// for the same dimension are merged, and only the first resource describing a
+// dimension is considered.
+func (s *ResourceSyncer) Sync(md pmetric.Metrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var keys []DimensionKey
+	props := map[DimensionKey]map[string]string{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		attrs := rms.At(i).Resource().Attributes()
+		found := map[DimensionKey]bool{}
+		for _, rule := range s.rules {
+			dimValue, ok := attrs.Get(rule.Dimension)
+			if !ok || dimValue.AsString() == "" {
+				continue
+			}
+			key := DimensionKey{Name: rule.Dimension, Value: dimValue.AsString()}
+			if _, ok := props[key]; ok && !found[key] {
+				// already described by a previous resource
+				continue
+			}
+			if !found[key] {
+				found[key] = true
+				keys = append(keys, key)
+				props[key] = map[string]string{}
+			}
+			for prop, value := range resourceProperties(attrs, rule.Properties) {
+				props[key][prop] = value
+			}
+		}
+	}
+
+	var updates []*metadata.MetadataUpdate
+	var changedKeys []DimensionKey
+	for _, key := range keys {
+		delta, changed := propertiesDelta(s.synced[key], props[key])
+		if !changed {
+			continue
+		}
+		updates = append(updates, &metadata.MetadataUpdate{
+			ResourceIDKey: key.Name,
+			ResourceID:    metadata.ResourceID(key.Value),
+			MetadataDelta: metadata.MetadataDelta{
+				MetadataToUpdate: delta,
+			},
+		})
+		changedKeys = append(changedKeys, key)
+	}
+	if len(updates) == 0 {
+		return
+	}
+
+	if err := s.dimClient.PushMetadata(updates); err != nil {
+		s.logger.Warn("Failed to push dimension properties from resource attributes", zap.Error(err))
+		return
+	}
+
+	if len(s.synced)+len(changedKeys) > maxSyncedDimensions {
+		s.synced = map[DimensionKey]map[string]string{}
+	}
+	for _, key := range changedKeys {
+		s.synced[key] = props[key]
+	}
+}
+
+func resourceProperties(attrs pcommon.Map, properties map[string]string) map[string]string {
+	out := make(map[string]string, len(properties))
+	for attr, prop := range properties {
+		v, ok := attrs.Get(attr)
+		if !ok || v.AsString() == "" {
+			continue
+		}
+		if prop == "" {
+			prop = attr
+		}
+		out[prop] = v.AsString()
+	}
+	return out
+}
+
+// propertiesDelta returns the property updates turning previous into current.
+// Properties that are no longer set are updated with an empty value, which
+// removes them from the dimension.
+func propertiesDelta(previous, current map[string]string) (map[string]string, bool) {
+	delta := map[string]string{}
+	for k, v := range current {
+		if prev, ok := previous[k]; !ok || prev != v {
+			delta[k] = v
+		}
+	}
+	for k := range previous {
+		if _, ok := current[k]; !ok {
+			delta[k] = ""
+		}
+	}
+	return delta, len(delta) > 0
+}
@@ -0,0 +1,147 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dimensions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	metadata "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+)
+
+type fakeMetadataClient struct {
+	fail    bool
+	updates [][]*metadata.MetadataUpdate
+}
+
+func (c *fakeMetadataClient) PushMetadata(updates []*metadata.MetadataUpdate) error {
+	if c.fail {
+		return errors.New("failed")
+	}
+	c.updates = append(c.updates, updates)
+	return nil
+}
+
+func metricsWithResources(resources ...map[string]string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, res := range resources {
+		attrs := md.ResourceMetrics().AppendEmpty().Resource().Attributes()
+		for k, v := range res {
+			attrs.PutStr(k, v)
+		}
+	}
+	return md
+}
+
+func TestResourceSyncer(t *testing.T) {
+	client := &fakeMetadataClient{}
+	syncer := NewResourceSyncer(zap.NewNop(), client, []ResourceSyncRule{
+		{
+			Dimension: "k8s.pod.uid",
+			Properties: map[string]string{
+				"service.name":           "service",
+				"deployment.environment": "environment",
+				"k8s.pod.name":           "",
+			},
+		},
+	})
+
+	pod := map[string]string{
+		"k8s.pod.uid":            "pod-1",
+		"k8s.pod.name":           "frontend-0",
+		"service.name":           "frontend",
+		"deployment.environment": "prod",
+	}
+	syncer.Sync(metricsWithResources(pod, pod, map[string]string{"service.name": "no-pod"}))
+	require.Len(t, client.updates, 1)
+	assert.Equal(t, []*metadata.MetadataUpdate{
+		{
+			ResourceIDKey: "k8s.pod.uid",
+			ResourceID:    "pod-1",
+			MetadataDelta: metadata.MetadataDelta{
+				MetadataToUpdate: map[string]string{
+					"service":      "frontend",
+					"environment":  "prod",
+					"k8s.pod.name": "frontend-0",
+				},
+			},
+		},
+	}, client.updates[0])
+
+	// Unchanged properties are not sent again.
+	syncer.Sync(metricsWithResources(pod))
+	require.Len(t, client.updates, 1)
+
+	// Only changed and removed properties are sent.
+	syncer.Sync(metricsWithResources(map[string]string{
+		"k8s.pod.uid":  "pod-1",
+		"k8s.pod.name": "frontend-0",
+		"service.name": "frontend-v2",
+	}))
+	require.Len(t, client.updates, 2)
+	assert.Equal(t, map[string]string{
+		"service":     "frontend-v2",
+		"environment": "",
+	}, client.updates[1][0].MetadataToUpdate)
+}
+
+func TestResourceSyncerMergesRules(t *testing.T) {
+	client := &fakeMetadataClient{}
+	syncer := NewResourceSyncer(zap.NewNop(), client, []ResourceSyncRule{
+		{Dimension: "host.name", Properties: map[string]string{"deployment.environment": "environment"}},
+		{Dimension: "k8s.pod.uid", Properties: map[string]string{"k8s.pod.name": ""}},
+		{Dimension: "host.name", Properties: map[string]string{"cloud.region": "region"}},
+	})
+
+	syncer.Sync(metricsWithResources(map[string]string{
+		"host.name":              "node-1",
+		"deployment.environment": "prod",
+		"cloud.region":           "us-east-1",
+	}))
+	require.Len(t, client.updates, 1)
+	require.Len(t, client.updates[0], 1)
+	assert.Equal(t, map[string]string{
+		"environment": "prod",
+		"region":      "us-east-1",
+	}, client.updates[0][0].MetadataToUpdate)
+
+	// The properties of the second rule are not removed by the first one.
+	syncer.Sync(metricsWithResources(map[string]string{
+		"host.name":              "node-1",
+		"deployment.environment": "prod",
+		"cloud.region":           "us-east-1",
+	}))
+	assert.Len(t, client.updates, 1)
+}
+
+func TestResourceSyncerRetriesFailedPush(t *testing.T) {
+	client := &fakeMetadataClient{fail: true}
+	syncer := NewResourceSyncer(zap.NewNop(), client, []ResourceSyncRule{
+		{Dimension: "host.name", Properties: map[string]string{"deployment.environment": "environment"}},
+	})
+
+	md := metricsWithResources(map[string]string{"host.name": "node-1", "deployment.environment": "prod"})
+	syncer.Sync(md)
+	assert.Empty(t, client.updates)
+
+	client.fail = false
+	syncer.Sync(md)
+	assert.Len(t, client.updates, 1)
+}
@@ -27,6 +27,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -40,6 +41,7 @@ import (
 type client struct {
 	config  *Config
 	url     *url.URL
+	rawURL  *url.URL
 	client  *http.Client
 	logger  *zap.Logger
 	zippers sync.Pool
@@ -182,6 +184,10 @@ func (c *client) pushLogData(ctx context.Context, ld plog.Logs) error {
 
 	// Callback when each batch is to be sent.
 	send := func(ctx context.Context, buf *bytes.Buffer, headers map[string]string) (err error) {
+		target := c.url
+		if c.sendsRaw(headers) {
+			target = c.rawURL
+		}
 		localHeaders := headers
 		if ld.ResourceLogs().Len() != 0 {
 			accessToken, found := ld.ResourceLogs().At(0).Resource().Attributes().Get(splunk.HecTokenLabel)
@@ -208,10 +214,10 @@ func (c *client) pushLogData(ctx context.Context, ld plog.Logs) error {
 				return fmt.Errorf("failed flushing compressed data to gzip writer: %w", err)
 			}
 
-			return c.postEvents(ctx, gzipBuffer, localHeaders, shouldCompress)
+			return c.post(ctx, target, gzipBuffer, localHeaders, shouldCompress)
 		}
 
-		return c.postEvents(ctx, buf, localHeaders, shouldCompress)
+		return c.post(ctx, target, buf, localHeaders, shouldCompress)
 	}
 
 	return c.pushLogDataInBatches(ctx, ld, send)
@@ -227,10 +233,29 @@ var profilingHeaders = map[string]string{
 	libraryHeaderName: profilingLibraryName,
 }
 
+const rawChannelHeaderName = "X-Splunk-Request-Channel"
+
 func isProfilingData(sl plog.ScopeLogs) bool {
 	return sl.Scope().Name() == profilingLibraryName
 }
 
+// rawHeaders returns the headers of the requests sent to the HEC raw endpoint.
+func (c *client) rawHeaders() map[string]string {
+	headers := map[string]string{
+		"Content-Type": "text/plain",
+	}
+	if c.config.RawChannel != "" {
+		headers[rawChannelHeaderName] = c.config.RawChannel
+	}
+	return headers
+}
+
+// sendsRaw returns whether the log records sent with the given headers go to the
+// HEC raw endpoint. Profiling data is always sent to the event endpoint.
+func (c *client) sendsRaw(headers map[string]string) bool {
+	return c.config.UseRawEndpoint && headers[libraryHeaderName] != profilingLibraryName
+}
+
 func makeBlankBufferState(bufCap uint) bufferState {
 	// Buffer of JSON encoded Splunk events, last record is expected to overflow bufCap, hence the padding
 	var buf = bytes.NewBuffer(make([]byte, 0, bufCap+bufCapPadding))
@@ -258,6 +283,12 @@ func (c *client) pushLogDataInBatches(ctx context.Context, ld plog.Logs, send fu
 	var profilingBufState = makeBlankBufferState(c.config.MaxContentLengthLogs)
 	var permanentErrors []error
 
+	// Log records sent to the raw endpoint are batched as unstructured text.
+	var logsHeaders map[string]string
+	if c.config.UseRawEndpoint {
+		logsHeaders = c.rawHeaders()
+	}
+
 	var rls = ld.ResourceLogs()
 	var droppedProfilingDataRecords, droppedLogRecords int
 	for i := 0; i < rls.Len(); i++ {
@@ -279,7 +310,7 @@ func (c *client) pushLogDataInBatches(ctx context.Context, ld plog.Logs, send fu
 					continue
 				}
 				bufState.resource, bufState.library = i, j
-				newPermanentErrors, err = c.pushLogRecords(ctx, rls, &bufState, nil, send)
+				newPermanentErrors, err = c.pushLogRecords(ctx, rls, &bufState, logsHeaders, send)
 			}
 
 			if err != nil {
@@ -299,7 +330,7 @@ func (c *client) pushLogDataInBatches(ctx context.Context, ld plog.Logs, send fu
 
 	// There's some leftover unsent non-profiling data
 	if bufState.buf.Len() > 0 {
-		if err := send(ctx, bufState.buf, nil); err != nil {
+		if err := send(ctx, bufState.buf, logsHeaders); err != nil {
 			return consumererror.NewLogs(err, c.subLogs(ld, bufState.bufFront, profilingBufState.bufFront))
 		}
 	}
@@ -325,12 +356,9 @@ func (c *client) pushLogRecords(ctx context.Context, lds plog.ResourceLogsSlice,
 			state.bufFront = &index{resource: state.resource, library: state.library, record: k}
 		}
 
-		// Parsing log record to Splunk event.
-		event := mapLogRecordToSplunkEvent(res.Resource(), logs.At(k), c.config, c.logger)
-		// JSON encoding event and writing to buffer.
-		b, err := jsoniter.Marshal(event)
+		b, err := c.encodeLogRecord(res.Resource(), logs.At(k), headers)
 		if err != nil {
-			permanentErrors = append(permanentErrors, consumererror.NewPermanent(fmt.Errorf("dropped log event: %v, error: %w", event, err)))
+			permanentErrors = append(permanentErrors, consumererror.NewPermanent(err))
 			continue
 		}
 		state.buf.Write(b)
@@ -383,6 +411,26 @@ func (c *client) pushLogRecords(ctx context.Context, lds plog.ResourceLogsSlice,
 	return permanentErrors, nil
 }
 
+// encodeLogRecord returns the bytes of the log record written to the batch sent with the given headers.
+func (c *client) encodeLogRecord(res pcommon.Resource, lr plog.LogRecord, headers map[string]string) ([]byte, error) {
+	if c.sendsRaw(headers) {
+		b, err := mapLogRecordToRawEvent(lr, c.logger)
+		if err != nil {
+			return nil, fmt.Errorf("dropped raw log event, error: %w", err)
+		}
+		return b, nil
+	}
+
+	// Parsing log record to Splunk event.
+	event := mapLogRecordToSplunkEvent(res, lr, c.config, c.logger)
+	// JSON encoding event and writing to buffer.
+	b, err := jsoniter.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("dropped log event: %v, error: %w", event, err)
+	}
+	return b, nil
+}
+
 func (c *client) pushMetricsRecords(ctx context.Context, mds pmetric.ResourceMetricsSlice, state *bufferState, send func(context.Context, *bytes.Buffer) error) (permanentErrors []error, sendingError error) {
 	res := mds.At(state.resource)
 	metrics := res.ScopeMetrics().At(state.library).Metrics()
@@ -592,7 +640,11 @@ func (c *client) pushTracesDataInBatches(ctx context.Context, td ptrace.Traces,
 }
 
 func (c *client) postEvents(ctx context.Context, events io.Reader, headers map[string]string, compressed bool) error {
-	req, err := http.NewRequestWithContext(ctx, "POST", c.url.String(), events)
+	return c.post(ctx, c.url, events, headers, compressed)
+}
+
+func (c *client) post(ctx context.Context, target *url.URL, events io.Reader, headers map[string]string, compressed bool) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", target.String(), events)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -172,6 +172,7 @@ func createLogDataWithCustomLibraries(numResources int, libraries []string, numR
 type receivedRequest struct {
 	body    []byte
 	headers http.Header
+	url     *url.URL
 }
 
 type CapturingData struct {
@@ -194,7 +195,7 @@ func (c *CapturingData) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		panic(err)
 	}
 	go func() {
-		c.receivedRequest <- receivedRequest{body, r.Header}
+		c.receivedRequest <- receivedRequest{body, r.Header, r.URL}
 	}()
 	w.WriteHeader(c.statusCode)
 }
@@ -589,6 +590,40 @@ func TestReceiveLogs(t *testing.T) {
 	}
 }
 
+func TestReceiveRawLogs(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.DisableCompression = true
+	cfg.UseRawEndpoint = true
+	cfg.RawChannel = "11111111-1111-1111-1111-111111111111"
+	cfg.SourceType = "access_combined"
+
+	logs := createLogDataWithCustomLibraries(1, []string{"otel", "otel.profiling"}, []int{2, 1})
+	logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Body().SetStr("second line")
+
+	got, err := runLogExport(cfg, logs, t)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	var raw, profiling receivedRequest
+	for _, r := range got {
+		if r.url.Path == "/services/collector/raw" {
+			raw = r
+		} else {
+			profiling = r
+		}
+	}
+	require.NotNil(t, raw.url)
+	assert.Equal(t, "mylog\nsecond line\n", string(raw.body))
+	assert.Equal(t, "access_combined", raw.url.Query().Get("sourcetype"))
+	assert.Equal(t, "text/plain", raw.headers.Get("Content-Type"))
+	assert.Equal(t, cfg.RawChannel, raw.headers.Get(rawChannelHeaderName))
+
+	require.NotNil(t, profiling.url)
+	assert.Equal(t, "/services/collector", profiling.url.Path)
+	assert.Equal(t, profilingLibraryName, profiling.headers.Get(libraryHeaderName))
+	assert.Contains(t, string(profiling.body), `"otel.log.name":"0_1_0"`)
+}
+
 func TestReceiveMetrics(t *testing.T) {
 	md := createMetricsData(3)
 	cfg := NewFactory().CreateDefaultConfig().(*Config)
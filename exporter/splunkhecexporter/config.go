@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -29,7 +30,10 @@ import (
 
 const (
 	// hecPath is the default HEC path on the Splunk instance.
-	hecPath                          = "services/collector"
+	hecPath = "services/collector"
+	// hecRawPath is the path of the HEC raw endpoint, relative to hecPath.
+	hecRawPath = "raw"
+
 	defaultContentLengthLogsLimit    = 2 * 1024 * 1024
 	defaultContentLengthMetricsLimit = 2 * 1024 * 1024
 	defaultContentLengthTracesLimit  = 2 * 1024 * 1024
@@ -107,6 +111,16 @@ type Config struct {
 	HecToOtelAttrs splunk.HecToOtelAttrs `mapstructure:"hec_metadata_to_otel_attrs"`
 	// HecFields creates a mapping from attributes to HEC fields.
 	HecFields OtelToHecFields `mapstructure:"otel_to_hec_fields"`
+	// MappingProfile controls which log attributes become HEC fields, and the templates of the HEC metadata.
+	MappingProfile MappingProfile `mapstructure:"mapping_profile"`
+
+	// UseRawEndpoint sends the log records, other than profiling data, to the HEC raw endpoint
+	// as unstructured payloads. Only the log record bodies are sent, with the source, sourcetype
+	// and index of the configuration.
+	UseRawEndpoint bool `mapstructure:"use_raw_endpoint"`
+	// RawChannel is the HEC channel identifier (a GUID) sent with the requests to the raw endpoint.
+	// It is required by Splunk if indexer acknowledgement is enabled on the token.
+	RawChannel string `mapstructure:"raw_channel"`
 }
 
 func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
@@ -120,8 +134,9 @@ func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
 	}
 
 	return &exporterOptions{
-		url:   url,
-		token: cfg.Token,
+		url:    url,
+		rawURL: getRawURL(url, cfg),
+		token:  cfg.Token,
 	}, nil
 }
 
@@ -162,6 +177,26 @@ func (cfg *Config) getURL() (out *url.URL, err error) {
 	return
 }
 
+// getRawURL returns the URL of the HEC raw endpoint next to the event endpoint. The static
+// metadata of the events is set in the query parameters.
+func getRawURL(eventURL *url.URL, cfg *Config) *url.URL {
+	out := *eventURL
+	p := strings.TrimSuffix(out.Path, "/")
+	if !strings.HasSuffix(p, "/"+hecRawPath) {
+		p = path.Join(strings.TrimSuffix(p, "/event"), hecRawPath)
+	}
+	out.Path = p
+
+	query := out.Query()
+	for k, v := range map[string]string{"source": cfg.Source, "sourcetype": cfg.SourceType, "index": cfg.Index} {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	out.RawQuery = query.Encode()
+	return &out
+}
+
 // Validate checks if the exporter configuration is valid.
 func (cfg *Config) Validate() error {
 	if err := cfg.QueueSettings.Validate(); err != nil {
@@ -170,5 +205,8 @@ func (cfg *Config) Validate() error {
 	if !cfg.LogDataEnabled && !cfg.ProfilingDataEnabled {
 		return errors.New(`either "log_data_enabled" or "profiling_data_enabled" has to be true`)
 	}
+	if err := cfg.MappingProfile.Validate(); err != nil {
+		return fmt.Errorf("mapping_profile settings has invalid configuration: %w", err)
+	}
 	return nil
 }
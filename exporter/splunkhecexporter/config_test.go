@@ -100,6 +100,16 @@ func TestLoadConfig(t *testing.T) {
 			SeverityNumber: "myseveritynumfield",
 			Name:           "mynamefield",
 		},
+		MappingProfile: MappingProfile{
+			Fields:        []string{"k8s.*", "service.name"},
+			ExcludeFields: []string{"k8s.pod.uid"},
+			Source:        "otel:{{service.name}}",
+			SourceType:    "kube:{{k8s.container.name}}",
+			Host:          "{{k8s.node.name}}",
+			Index:         "{{k8s.namespace.name}}-logs",
+		},
+		UseRawEndpoint: true,
+		RawChannel:     "11111111-1111-1111-1111-111111111111",
 	}
 	assert.Equal(t, &expectedCfg, e1)
 
@@ -155,6 +165,11 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 					Host:   "example.com:8000",
 					Path:   "services/collector",
 				},
+				rawURL: &url.URL{
+					Scheme: "https",
+					Host:   "example.com:8000",
+					Path:   "services/collector/raw",
+				},
 			},
 			wantErr: false,
 		},
@@ -215,3 +230,50 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_getRawURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		cfg      *Config
+		want     string
+	}{
+		{
+			name:     "default path",
+			endpoint: "https://example.com:8088/services/collector",
+			cfg:      &Config{},
+			want:     "https://example.com:8088/services/collector/raw",
+		},
+		{
+			name:     "event path",
+			endpoint: "https://example.com:8088/services/collector/event/",
+			cfg:      &Config{Source: "otel", SourceType: "syslog", Index: "main"},
+			want:     "https://example.com:8088/services/collector/raw?index=main&source=otel&sourcetype=syslog",
+		},
+		{
+			name:     "raw path",
+			endpoint: "https://example.com:8088/services/collector/raw",
+			cfg:      &Config{Index: "main"},
+			want:     "https://example.com:8088/services/collector/raw?index=main",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventURL, err := url.Parse(tt.endpoint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, getRawURL(eventURL, tt.cfg).String())
+		})
+	}
+}
+
+func TestConfig_ValidateMappingProfile(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MappingProfile.Source = "otel:{{service.name}}"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MappingProfile.Index = "{{k8s.namespace.name"
+	assert.EqualError(t, cfg.Validate(), `mapping_profile settings has invalid configuration: invalid "index" template: unclosed attribute reference`)
+
+	cfg.MappingProfile.Index = "logs-{{ }}"
+	assert.EqualError(t, cfg.Validate(), `mapping_profile settings has invalid configuration: invalid "index" template: empty attribute reference`)
+}
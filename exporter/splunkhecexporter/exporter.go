@@ -51,8 +51,9 @@ type splunkExporter struct {
 }
 
 type exporterOptions struct {
-	url   *url.URL
-	token string
+	url    *url.URL
+	rawURL *url.URL
+	token  string
 }
 
 // createExporter returns a new Splunk exporter.
@@ -103,7 +104,8 @@ func buildClient(options *exporterOptions, config *Config, logger *zap.Logger) (
 		return nil, fmt.Errorf("could not retrieve TLS config for Splunk HEC Exporter: %w", err)
 	}
 	return &client{
-		url: options.url,
+		url:    options.url,
+		rawURL: options.rawURL,
 		client: &http.Client{
 			Timeout: config.Timeout,
 			Transport: &http.Transport{
@@ -17,6 +17,7 @@ package splunkhecexporter // import "github.com/open-telemetry/opentelemetry-col
 import (
 	"time"
 
+	jsoniter "github.com/json-iterator/go"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
@@ -44,6 +45,7 @@ func mapLogRecordToSplunkEvent(res pcommon.Resource, lr plog.LogRecord, config *
 	hostKey := config.HecToOtelAttrs.Host
 	severityTextKey := config.HecFields.SeverityText
 	severityNumberKey := config.HecFields.SeverityNumber
+	profile := &config.MappingProfile
 	if spanID := lr.SpanID().HexString(); spanID != "" {
 		fields[spanIDFieldKey] = spanID
 	}
@@ -70,7 +72,9 @@ func mapLogRecordToSplunkEvent(res pcommon.Resource, lr plog.LogRecord, config *
 		case splunk.HecTokenLabel:
 			// ignore
 		default:
-			fields[k] = convertAttributeValue(v, logger)
+			if profile.includeField(k) {
+				fields[k] = convertAttributeValue(v, logger)
+			}
 		}
 		return true
 	})
@@ -87,11 +91,33 @@ func mapLogRecordToSplunkEvent(res pcommon.Resource, lr plog.LogRecord, config *
 		case splunk.HecTokenLabel:
 			// ignore
 		default:
-			fields[k] = convertAttributeValue(v, logger)
+			if profile.includeField(k) {
+				fields[k] = convertAttributeValue(v, logger)
+			}
 		}
 		return true
 	})
 
+	// The templates of the mapping profile take precedence over the attribute mapping.
+	lookup := func(key string) (pcommon.Value, bool) {
+		if v, ok := lr.Attributes().Get(key); ok {
+			return v, true
+		}
+		return res.Attributes().Get(key)
+	}
+	if v, ok := renderTemplate(profile.Host, lookup); ok {
+		host = v
+	}
+	if v, ok := renderTemplate(profile.Source, lookup); ok {
+		source = v
+	}
+	if v, ok := renderTemplate(profile.SourceType, lookup); ok {
+		sourcetype = v
+	}
+	if v, ok := renderTemplate(profile.Index, lookup); ok {
+		index = v
+	}
+
 	eventValue := convertAttributeValue(lr.Body(), logger)
 	return &splunk.Event{
 		Time:       nanoTimestampToEpochMilliseconds(lr.Timestamp()),
@@ -104,6 +130,20 @@ func mapLogRecordToSplunkEvent(res pcommon.Resource, lr plog.LogRecord, config *
 	}
 }
 
+// mapLogRecordToRawEvent returns the log record body as sent to the HEC raw endpoint,
+// terminated by a line break. String bodies are sent as is, other bodies are JSON encoded.
+func mapLogRecordToRawEvent(lr plog.LogRecord, logger *zap.Logger) ([]byte, error) {
+	body := lr.Body()
+	if body.Type() == pcommon.ValueTypeStr {
+		return append([]byte(body.Str()), '\n'), nil
+	}
+	b, err := jsoniter.Marshal(convertAttributeValue(body, logger))
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
 func convertAttributeValue(value pcommon.Value, logger *zap.Logger) interface{} {
 	switch value.Type() {
 	case pcommon.ValueTypeInt:
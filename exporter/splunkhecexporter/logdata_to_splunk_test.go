@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
@@ -430,6 +431,49 @@ func Test_mapLogRecordToSplunkEvent(t *testing.T) {
 					"myhost", "myapp", "myapp-type"),
 			},
 		},
+		{
+			name: "with mapping profile",
+			logRecordFn: func() plog.LogRecord {
+				logRecord := plog.NewLogRecord()
+				logRecord.Body().SetStr("mylog")
+				logRecord.Attributes().PutStr("log.file.name", "app.log")
+				logRecord.Attributes().PutStr("k8s.container.name", "app")
+				logRecord.Attributes().PutStr("custom", "custom")
+				logRecord.SetTimestamp(ts)
+				return logRecord
+			},
+			logResourceFn: func() pcommon.Resource {
+				res := pcommon.NewResource()
+				res.Attributes().PutStr("k8s.namespace.name", "payments")
+				res.Attributes().PutStr("k8s.pod.name", "app-0")
+				res.Attributes().PutStr(conventions.AttributeHostName, "myhost")
+				return res
+			},
+			configDataFn: func() *Config {
+				config := createDefaultConfig().(*Config)
+				config.Source = "source"
+				config.SourceType = "sourcetype"
+				config.Index = "main"
+				config.MappingProfile = MappingProfile{
+					Fields:        []string{"k8s.*", "custom"},
+					ExcludeFields: []string{"k8s.pod.name"},
+					Source:        "file:{{log.file.name}}",
+					SourceType:    "kube:{{k8s.container.name}}",
+					Index:         "{{k8s.namespace.name}}-logs",
+					Host:          "{{missing.attribute}}",
+				}
+				return config
+			},
+			wantSplunkEvents: func() []*splunk.Event {
+				event := commonLogSplunkEvent("mylog", ts, map[string]interface{}{
+					"custom":             "custom",
+					"k8s.container.name": "app",
+					"k8s.namespace.name": "payments",
+				}, "myhost", "file:app.log", "kube:app")
+				event.Index = "payments-logs"
+				return []*splunk.Event{event}
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -479,3 +523,16 @@ func Test_nanoTimestampToEpochMilliseconds(t *testing.T) {
 	splunkTs = nanoTimestampToEpochMilliseconds(0)
 	assert.True(t, nil == splunkTs)
 }
+
+func Test_mapLogRecordToRawEvent(t *testing.T) {
+	lr := plog.NewLogRecord()
+	lr.Body().SetStr("127.0.0.1 - GET /index.html 200")
+	b, err := mapLogRecordToRawEvent(lr, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1 - GET /index.html 200\n", string(b))
+
+	lr.Body().SetEmptyMap().PutStr("message", "hello")
+	b, err = mapLogRecordToRawEvent(lr, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, "{\"message\":\"hello\"}\n", string(b))
+}
@@ -0,0 +1,135 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	templateStart = "{{"
+	templateEnd   = "}}"
+)
+
+// MappingProfile controls how the attributes of the log records and their resource
+// are mapped to the HEC event metadata and fields.
+type MappingProfile struct {
+	// Fields is the list of attributes copied to the HEC fields. A pattern ending
+	// with "*" matches all the attributes starting with the pattern prefix.
+	// All the attributes are copied if empty.
+	Fields []string `mapstructure:"fields"`
+	// ExcludeFields is the list of attributes never copied to the HEC fields,
+	// with the same pattern syntax as Fields.
+	ExcludeFields []string `mapstructure:"exclude_fields"`
+	// Source is the template of the HEC source, e.g. "otel:{{service.name}}".
+	Source string `mapstructure:"source"`
+	// SourceType is the template of the HEC sourcetype.
+	SourceType string `mapstructure:"sourcetype"`
+	// Host is the template of the HEC host.
+	Host string `mapstructure:"host"`
+	// Index is the template of the HEC index.
+	Index string `mapstructure:"index"`
+}
+
+// Validate checks the templates of the mapping profile are valid.
+func (mp *MappingProfile) Validate() error {
+	templates := []struct {
+		name string
+		tmpl string
+	}{
+		{"source", mp.Source},
+		{"sourcetype", mp.SourceType},
+		{"host", mp.Host},
+		{"index", mp.Index},
+	}
+	for _, t := range templates {
+		if err := validateTemplate(t.tmpl); err != nil {
+			return fmt.Errorf("invalid %q template: %w", t.name, err)
+		}
+	}
+	return nil
+}
+
+// includeField returns whether the attribute is copied to the HEC fields.
+func (mp *MappingProfile) includeField(key string) bool {
+	if matchesAnyPattern(key, mp.ExcludeFields) {
+		return false
+	}
+	return len(mp.Fields) == 0 || matchesAnyPattern(key, mp.Fields)
+}
+
+func matchesAnyPattern(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func validateTemplate(tmpl string) error {
+	for {
+		start := strings.Index(tmpl, templateStart)
+		if start < 0 {
+			return nil
+		}
+		tmpl = tmpl[start+len(templateStart):]
+		end := strings.Index(tmpl, templateEnd)
+		if end < 0 {
+			return errors.New("unclosed attribute reference")
+		}
+		if strings.TrimSpace(tmpl[:end]) == "" {
+			return errors.New("empty attribute reference")
+		}
+		tmpl = tmpl[end+len(templateEnd):]
+	}
+}
+
+// renderTemplate replaces the "{{attribute}}" references of the template with the
+// attribute values. It returns false if the template is empty or an attribute is
+// missing, in which case the default value must be used.
+func renderTemplate(tmpl string, lookup func(key string) (pcommon.Value, bool)) (string, bool) {
+	if tmpl == "" {
+		return "", false
+	}
+	var sb strings.Builder
+	for {
+		start := strings.Index(tmpl, templateStart)
+		if start < 0 {
+			sb.WriteString(tmpl)
+			return sb.String(), true
+		}
+		sb.WriteString(tmpl[:start])
+		tmpl = tmpl[start+len(templateStart):]
+		end := strings.Index(tmpl, templateEnd)
+		if end < 0 {
+			return "", false
+		}
+		v, ok := lookup(strings.TrimSpace(tmpl[:end]))
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(v.AsString())
+		tmpl = tmpl[end+len(templateEnd):]
+	}
+}
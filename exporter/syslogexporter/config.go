@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	networkTCP = "tcp"
+	networkUDP = "udp"
+
+	protocolRFC5424 = "rfc5424"
+	protocolRFC3164 = "rfc3164"
+
+	framingOctetCounting  = "octet_counting"
+	framingNonTransparent = "non_transparent"
+
+	// maxFacility is the highest facility code, local7.
+	maxFacility = 23
+)
+
+// Config defines configuration for the syslog exporter.
+type Config struct {
+	config.ExporterSettings        `mapstructure:",squash"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// Endpoint is the address of the syslog server, in the host:port form.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Network is the transport used to reach the server, either "tcp" (default) or "udp".
+	Network string `mapstructure:"network"`
+
+	// Protocol is the format of the messages, either "rfc5424" (default) or "rfc3164".
+	Protocol string `mapstructure:"protocol"`
+
+	// Framing is how messages are delimited over TCP, as defined by RFC6587:
+	// either "octet_counting" (default) or "non_transparent", terminating every message with a new line.
+	// Messages sent over UDP are not framed, every message being sent in its own datagram.
+	Framing string `mapstructure:"framing"`
+
+	// Facility is the facility code of the messages, from 0 (kern) to 23 (local7).
+	// Default: 1 (user).
+	Facility int `mapstructure:"facility"`
+
+	// StructuredDataID is the SD-ID of the RFC5424 structured data element holding the
+	// attributes of the log records.
+	StructuredDataID string `mapstructure:"structured_data_id"`
+
+	// TLSSetting configures TLS over TCP. TLS is disabled unless `insecure` is set to false.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	if _, _, err := net.SplitHostPort(cfg.Endpoint); err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+	}
+
+	switch cfg.Network {
+	case networkTCP:
+	case networkUDP:
+		if !cfg.TLSSetting.Insecure {
+			return errors.New("tls is not supported over udp")
+		}
+	default:
+		return fmt.Errorf("invalid network %q, must be one of %q or %q", cfg.Network, networkTCP, networkUDP)
+	}
+
+	switch cfg.Protocol {
+	case protocolRFC5424, protocolRFC3164:
+	default:
+		return fmt.Errorf("invalid protocol %q, must be one of %q or %q", cfg.Protocol, protocolRFC5424, protocolRFC3164)
+	}
+
+	switch cfg.Framing {
+	case framingOctetCounting, framingNonTransparent:
+	default:
+		return fmt.Errorf("invalid framing %q, must be one of %q or %q", cfg.Framing, framingOctetCounting, framingNonTransparent)
+	}
+
+	if cfg.Facility < 0 || cfg.Facility > maxFacility {
+		return fmt.Errorf("invalid facility %d, must be between 0 and %d", cfg.Facility, maxFacility)
+	}
+
+	if cfg.StructuredDataID == "" || sdName(cfg.StructuredDataID) != cfg.StructuredDataID {
+		return fmt.Errorf("invalid structured_data_id %q", cfg.StructuredDataID)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       config.ComponentID
+		expected func(cfg *Config)
+	}{
+		{
+			id: config.NewComponentID(typeStr),
+			expected: func(cfg *Config) {
+				cfg.Endpoint = "syslog.example.com:6514"
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "rfc3164"),
+			expected: func(cfg *Config) {
+				cfg.Endpoint = "syslog.example.com:514"
+				cfg.Network = networkUDP
+				cfg.Protocol = protocolRFC3164
+				cfg.Facility = 16
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "tls"),
+			expected: func(cfg *Config) {
+				cfg.Endpoint = "syslog.example.com:6514"
+				cfg.Framing = framingNonTransparent
+				cfg.StructuredDataID = "logs@32473"
+				cfg.TLSSetting = configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile: "/var/lib/ca.pem",
+					},
+				}
+				cfg.RetrySettings.Enabled = false
+				cfg.QueueSettings.Enabled = false
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExporter(sub, cfg))
+
+			expected := factory.CreateDefaultConfig().(*Config)
+			expected.SetIDName(tt.id.Name())
+			tt.expected(expected)
+
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		error  string
+	}{
+		{
+			name:   "no endpoint",
+			modify: func(cfg *Config) { cfg.Endpoint = "" },
+			error:  "endpoint is required",
+		},
+		{
+			name:   "endpoint without port",
+			modify: func(cfg *Config) { cfg.Endpoint = "localhost" },
+			error:  `invalid endpoint "localhost": address localhost: missing port in address`,
+		},
+		{
+			name:   "invalid network",
+			modify: func(cfg *Config) { cfg.Network = "unix" },
+			error:  `invalid network "unix", must be one of "tcp" or "udp"`,
+		},
+		{
+			name: "tls over udp",
+			modify: func(cfg *Config) {
+				cfg.Network = networkUDP
+				cfg.TLSSetting.Insecure = false
+			},
+			error: "tls is not supported over udp",
+		},
+		{
+			name:   "invalid protocol",
+			modify: func(cfg *Config) { cfg.Protocol = "rfc5425" },
+			error:  `invalid protocol "rfc5425", must be one of "rfc5424" or "rfc3164"`,
+		},
+		{
+			name:   "invalid framing",
+			modify: func(cfg *Config) { cfg.Framing = "none" },
+			error:  `invalid framing "none", must be one of "octet_counting" or "non_transparent"`,
+		},
+		{
+			name:   "invalid facility",
+			modify: func(cfg *Config) { cfg.Facility = 24 },
+			error:  "invalid facility 24, must be between 0 and 23",
+		},
+		{
+			name:   "invalid structured data ID",
+			modify: func(cfg *Config) { cfg.StructuredDataID = "otel attributes" },
+			error:  `invalid structured_data_id "otel attributes"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:514"
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.error)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+type syslogExporter struct {
+	config   *Config
+	logger   *zap.Logger
+	sender   *sender
+	hostname string
+}
+
+func newExporter(cfg *Config, logger *zap.Logger) (*syslogExporter, error) {
+	tlsConfig, err := cfg.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		logger.Warn("Failed to get the hostname, only the host.name resource attribute will be used", zap.Error(err))
+	}
+
+	return &syslogExporter{
+		config:   cfg,
+		logger:   logger,
+		sender:   newSender(cfg.Network, cfg.Endpoint, cfg.Framing, tlsConfig),
+		hostname: hostname,
+	}, nil
+}
+
+func (e *syslogExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	messages := make([]string, 0, ld.LogRecordCount())
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				m := newMessage(e.config.Facility, e.hostname, rl.Resource(), lrs.At(k))
+				if e.config.Protocol == protocolRFC3164 {
+					messages = append(messages, m.rfc3164())
+				} else {
+					messages = append(messages, m.rfc5424(e.config.StructuredDataID))
+				}
+			}
+		}
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+	return e.sender.send(ctx, messages)
+}
+
+func (e *syslogExporter) shutdown(context.Context) error {
+	return e.sender.close()
+}
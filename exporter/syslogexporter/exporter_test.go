@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+func testLogs(bodies ...string) plog.Logs {
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range bodies {
+		lr := lrs.AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+		lr.Body().SetStr(body)
+	}
+	return ld
+}
+
+func testExporter(t *testing.T, network, endpoint string, modify func(cfg *Config)) *syslogExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Network = network
+	cfg.Endpoint = endpoint
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+
+	exp, err := newExporter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	exp.hostname = "host"
+	t.Cleanup(func() {
+		assert.NoError(t, exp.shutdown(context.Background()))
+	})
+	return exp
+}
+
+func TestPushLogsTCPOctetCounting(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	exp := testExporter(t, networkTCP, ln.Addr().String(), nil)
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("first", "second")))
+	require.NoError(t, exp.shutdown(context.Background()))
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	data, err := io.ReadAll(conn)
+	require.NoError(t, err)
+
+	first := "<14>1 2022-10-04T08:30:05.123456Z host - - - - first"
+	second := "<14>1 2022-10-04T08:30:05.123456Z host - - - - second"
+	assert.Equal(t, "52 "+first+"53 "+second, string(data))
+}
+
+func TestPushLogsTCPNonTransparent(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	exp := testExporter(t, networkTCP, ln.Addr().String(), func(cfg *Config) {
+		cfg.Protocol = protocolRFC3164
+		cfg.Framing = framingNonTransparent
+	})
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("first", "second")))
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	reader := bufio.NewReader(conn)
+
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "<14>Oct  4 08:30:05 host first\n", line)
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "<14>Oct  4 08:30:05 host second\n", line)
+}
+
+func TestPushLogsUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "localhost:0")
+	require.NoError(t, err)
+	defer pc.Close()
+
+	exp := testExporter(t, networkUDP, pc.LocalAddr().String(), nil)
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("first", "second")))
+
+	require.NoError(t, pc.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 1024)
+	for _, body := range []string{"first", "second"} {
+		n, _, err := pc.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, "<14>1 2022-10-04T08:30:05.123456Z host - - - - "+body, string(buf[:n]))
+	}
+}
+
+func TestPushLogsReconnects(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	exp := testExporter(t, networkTCP, endpoint, func(cfg *Config) {
+		cfg.Framing = framingNonTransparent
+	})
+	assert.Error(t, exp.pushLogs(context.Background(), testLogs("lost")))
+
+	ln, err = net.Listen("tcp", endpoint)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs("delivered")))
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(line, " delivered\n"))
+}
+
+func TestPushLogsEmpty(t *testing.T) {
+	// nothing listens on the endpoint, no connection must be attempted
+	exp := testExporter(t, networkTCP, "localhost:1", nil)
+	assert.NoError(t, exp.pushLogs(context.Background(), plog.NewLogs()))
+}
+
+func TestFrame(t *testing.T) {
+	assert.Equal(t, "5 hello", frame(framingOctetCounting, "hello"))
+	assert.Equal(t, "hello\n", frame(framingNonTransparent, "hello"))
+}
@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	nilValue = "-"
+
+	// rfc5424TimeFormat is the RFC3339 format limited to microseconds, as required by RFC5424.
+	rfc5424TimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+	maxHostnameLen = 255
+	maxAppNameLen  = 48
+	maxProcIDLen   = 128
+	maxSDNameLen   = 32
+	maxTagLen      = 32
+)
+
+// Syslog severities, see RFC5424 section 6.2.1.
+const (
+	severityCritical      = 2
+	severityError         = 3
+	severityWarning       = 4
+	severityNotice        = 5
+	severityInformational = 6
+	severityDebug         = 7
+)
+
+// message holds the fields of a syslog message, extracted from a log record and its resource.
+type message struct {
+	priority   int
+	timestamp  time.Time
+	hostname   string
+	appName    string
+	procID     string
+	attributes pcommon.Map
+	msg        string
+}
+
+func newMessage(facility int, defaultHostname string, res pcommon.Resource, lr plog.LogRecord) message {
+	m := message{
+		priority:   facility*8 + severity(lr.SeverityNumber()),
+		timestamp:  lr.Timestamp().AsTime(),
+		hostname:   defaultHostname,
+		attributes: lr.Attributes(),
+		msg:        lr.Body().AsString(),
+	}
+	if lr.Timestamp() == 0 {
+		m.timestamp = lr.ObservedTimestamp().AsTime()
+		if lr.ObservedTimestamp() == 0 {
+			m.timestamp = time.Now()
+		}
+	}
+
+	attrs := res.Attributes()
+	if v, ok := attrs.Get(conventions.AttributeHostName); ok {
+		m.hostname = v.AsString()
+	}
+	if v, ok := attrs.Get(conventions.AttributeServiceName); ok {
+		m.appName = v.AsString()
+	}
+	if v, ok := attrs.Get(conventions.AttributeProcessPID); ok {
+		m.procID = v.AsString()
+	}
+	return m
+}
+
+// rfc5424 formats the message as defined by RFC5424, the attributes being
+// added as parameters of the sdID structured data element.
+func (m message) rfc5424(sdID string) string {
+	return fmt.Sprintf("<%d>1 %s %s %s %s %s %s %s",
+		m.priority,
+		m.timestamp.UTC().Format(rfc5424TimeFormat),
+		headerField(m.hostname, maxHostnameLen),
+		headerField(m.appName, maxAppNameLen),
+		headerField(m.procID, maxProcIDLen),
+		nilValue,
+		m.structuredData(sdID),
+		m.msg)
+}
+
+func (m message) structuredData(sdID string) string {
+	if m.attributes.Len() == 0 {
+		return nilValue
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(sdID)
+	m.attributes.Range(func(k string, v pcommon.Value) bool {
+		sb.WriteString(" ")
+		sb.WriteString(sdName(k))
+		sb.WriteString(`="`)
+		sb.WriteString(sdParamValueEscaper.Replace(v.AsString()))
+		sb.WriteString(`"`)
+		return true
+	})
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// rfc3164 formats the message as defined by RFC3164. The timestamp is written in UTC,
+// as the format doesn't carry any time zone, and the attributes are dropped.
+func (m message) rfc3164() string {
+	hostname := headerField(m.hostname, maxHostnameLen)
+	if hostname == nilValue {
+		hostname = "localhost"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<%d>%s %s ", m.priority, m.timestamp.UTC().Format(time.Stamp), hostname)
+	if tag := tag(m.appName); tag != "" {
+		sb.WriteString(tag)
+		if m.procID != "" {
+			fmt.Fprintf(&sb, "[%s]", headerField(m.procID, maxProcIDLen))
+		}
+		sb.WriteString(": ")
+	}
+	sb.WriteString(m.msg)
+	return sb.String()
+}
+
+// severity maps the severity number of a log record to a syslog severity.
+func severity(n plog.SeverityNumber) int {
+	switch {
+	case n >= plog.SeverityNumberFatal:
+		return severityCritical
+	case n >= plog.SeverityNumberError:
+		return severityError
+	case n >= plog.SeverityNumberWarn:
+		return severityWarning
+	case n >= plog.SeverityNumberInfo2:
+		return severityNotice
+	case n >= plog.SeverityNumberInfo:
+		return severityInformational
+	case n >= plog.SeverityNumberTrace:
+		return severityDebug
+	default:
+		return severityInformational
+	}
+}
+
+var sdParamValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// headerField returns the value restricted to printable US-ASCII characters and to
+// the given length, or the nil value if empty.
+func headerField(s string, maxLen int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(s) > maxLen {
+		s = s[:maxLen]
+	}
+	if s == "" {
+		return nilValue
+	}
+	return s
+}
+
+// sdName returns a valid SD-NAME, replacing the characters not allowed in it.
+func sdName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(s) > maxSDNameLen {
+		s = s[:maxSDNameLen]
+	}
+	return s
+}
+
+// tag returns the RFC3164 TAG for the application name. The TAG ends at the first
+// character that is not printable, or that would be mistaken for its end.
+func tag(appName string) string {
+	end := 0
+	for end < len(appName) && end < maxTagLen && isTagChar(appName[end]) {
+		end++
+	}
+	return appName[:end]
+}
+
+func isTagChar(c byte) bool {
+	return c >= 33 && c <= 126 && c != ':' && c != '[' && c != ']'
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+var testTimestamp = time.Date(2022, 10, 4, 8, 30, 5, 123456789, time.UTC)
+
+func testRecord() (pcommon.Resource, plog.LogRecord) {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr(conventions.AttributeHostName, "web-1")
+	res.Attributes().PutStr(conventions.AttributeServiceName, "checkout")
+	res.Attributes().PutInt(conventions.AttributeProcessPID, 4242)
+
+	lr := plog.NewLogRecord()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.Body().SetStr("payment failed")
+	lr.Attributes().PutStr("order.id", `42"]`)
+	lr.Attributes().PutInt("retry count", 3)
+	return res, lr
+}
+
+func TestRFC5424(t *testing.T) {
+	res, lr := testRecord()
+
+	m := newMessage(1, "fallback", res, lr)
+
+	assert.Equal(t,
+		`<11>1 2022-10-04T08:30:05.123456Z web-1 checkout 4242 - [otel@32473 order.id="42\"\]" retry_count="3"] payment failed`,
+		m.rfc5424(defaultStructuredDataID))
+}
+
+func TestRFC5424NilValues(t *testing.T) {
+	lr := plog.NewLogRecord()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+	lr.Body().SetStr("hello")
+
+	m := newMessage(16, "", pcommon.NewResource(), lr)
+
+	assert.Equal(t, "<134>1 2022-10-04T08:30:05.123456Z - - - - - hello", m.rfc5424(defaultStructuredDataID))
+}
+
+func TestRFC3164(t *testing.T) {
+	res, lr := testRecord()
+
+	m := newMessage(1, "fallback", res, lr)
+
+	assert.Equal(t, "<11>Oct  4 08:30:05 web-1 checkout[4242]: payment failed", m.rfc3164())
+}
+
+func TestRFC3164DefaultHostname(t *testing.T) {
+	lr := plog.NewLogRecord()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+	lr.Body().SetStr("hello")
+
+	m := newMessage(1, "fallback", pcommon.NewResource(), lr)
+
+	assert.Equal(t, "<14>Oct  4 08:30:05 fallback hello", m.rfc3164())
+}
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		severityNumber plog.SeverityNumber
+		expected       int
+	}{
+		{plog.SeverityNumberUndefined, severityInformational},
+		{plog.SeverityNumberTrace, severityDebug},
+		{plog.SeverityNumberDebug4, severityDebug},
+		{plog.SeverityNumberInfo, severityInformational},
+		{plog.SeverityNumberInfo2, severityNotice},
+		{plog.SeverityNumberWarn, severityWarning},
+		{plog.SeverityNumberError3, severityError},
+		{plog.SeverityNumberFatal, severityCritical},
+		{plog.SeverityNumberFatal4, severityCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.severityNumber.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, severity(tt.severityNumber))
+		})
+	}
+}
+
+func TestHeaderField(t *testing.T) {
+	assert.Equal(t, "-", headerField("", maxAppNameLen))
+	assert.Equal(t, "my_app", headerField("my app", maxAppNameLen))
+	assert.Equal(t, "abc", headerField("abcdef", 3))
+}
+
+func TestTag(t *testing.T) {
+	assert.Equal(t, "checkout-api", tag("checkout-api"))
+	assert.Equal(t, "checkout", tag("checkout:api"))
+	assert.Equal(t, "", tag(""))
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// sender writes syslog messages to a server, reusing the same connection
+// until a write fails.
+type sender struct {
+	network   string
+	endpoint  string
+	framing   string
+	tlsConfig *tls.Config
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newSender(network, endpoint, framing string, tlsConfig *tls.Config) *sender {
+	return &sender{
+		network:   network,
+		endpoint:  endpoint,
+		framing:   framing,
+		tlsConfig: tlsConfig,
+	}
+}
+
+// send writes the messages, in order. Over UDP, every message is sent in its own
+// datagram. Over TCP, the messages are framed and written at once.
+func (s *sender) send(ctx context.Context, messages []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := s.conn.SetWriteDeadline(deadline); err != nil {
+			return s.reset(err)
+		}
+	}
+
+	if s.network == networkUDP {
+		for _, m := range messages {
+			if _, err := s.conn.Write([]byte(m)); err != nil {
+				return s.reset(err)
+			}
+		}
+		return nil
+	}
+
+	var sb strings.Builder
+	for _, m := range messages {
+		sb.WriteString(frame(s.framing, m))
+	}
+	if _, err := s.conn.Write([]byte(sb.String())); err != nil {
+		return s.reset(err)
+	}
+	return nil
+}
+
+func (s *sender) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{}
+	if s.tlsConfig != nil {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+		return tlsDialer.DialContext(ctx, networkTCP, s.endpoint)
+	}
+	return dialer.DialContext(ctx, s.network, s.endpoint)
+}
+
+// reset closes the connection after a failed write, so that the next send reconnects.
+func (s *sender) reset(err error) error {
+	_ = s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *sender) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// frame delimits the message as defined by RFC6587.
+func frame(framing string, msg string) string {
+	if framing == framingNonTransparent {
+		return msg + "\n"
+	}
+	return strconv.Itoa(len(msg)) + " " + msg
+}
@@ -18,6 +18,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/tracebatch"
 )
 
 // Config defines configuration settings for the Zipkin exporter.
@@ -33,11 +35,15 @@ type Config struct {
 	Format string `mapstructure:"format"`
 
 	DefaultServiceName string `mapstructure:"default_service_name"`
+
+	// Batching groups the spans sent by local endpoint, and holds the spans of each trace
+	// to send the spans of the traces together.
+	Batching tracebatch.Config `mapstructure:"trace_batching"`
 }
 
 var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
-	return nil
+	return cfg.Batching.Validate()
 }
@@ -27,6 +27,8 @@ import (
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/service/servicetest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/tracebatch"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -69,8 +71,19 @@ func TestLoadConfig(t *testing.T) {
 		},
 		Format:             "proto",
 		DefaultServiceName: "test_name",
+		Batching:           tracebatch.NewDefaultConfig(),
 	}, e1)
 	set := componenttest.NewNopExporterCreateSettings()
 	_, err = factory.CreateTracesExporter(context.Background(), set, e1)
 	require.NoError(t, err)
+
+	e2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "batching")]
+	assert.Equal(t, tracebatch.Config{
+		GroupByService: true,
+		TraceWait:      5 * time.Second,
+		MaxTraceWait:   30 * time.Second,
+		MaxTraces:      1000,
+	}, e2.(*Config).Batching)
+	_, err = factory.CreateTracesExporter(context.Background(), set, e2)
+	require.NoError(t, err)
 }
@@ -23,6 +23,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/tracebatch"
 )
 
 const (
@@ -58,6 +60,7 @@ func createDefaultConfig() config.Exporter {
 		},
 		Format:             defaultFormat,
 		DefaultServiceName: defaultServiceName,
+		Batching:           tracebatch.NewDefaultConfig(),
 	}
 }
 
@@ -77,7 +80,7 @@ func createTracesExporter(
 	if err != nil {
 		return nil, err
 	}
-	return exporterhelper.NewTracesExporter(
+	exp, err := exporterhelper.NewTracesExporter(
 		ctx,
 		set,
 		cfg,
@@ -87,4 +90,8 @@ func createTracesExporter(
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithQueue(zc.QueueSettings),
 		exporterhelper.WithRetry(zc.RetrySettings))
+	if err != nil {
+		return nil, err
+	}
+	return tracebatch.NewTracesExporter(zc.Batching, exp, set.Logger), nil
 }
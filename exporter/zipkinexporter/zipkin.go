@@ -20,12 +20,14 @@ import (
 	"fmt"
 	"net/http"
 
+	zipkinmodel "github.com/openzipkin/zipkin-go/model"
 	"github.com/openzipkin/zipkin-go/proto/zipkin_proto3"
 	zipkinreporter "github.com/openzipkin/zipkin-go/reporter"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/zipkin/zipkinv2"
 )
@@ -39,6 +41,7 @@ var translator zipkinv2.FromTranslator
 // OpenCensus spandata.
 type zipkinExporter struct {
 	defaultServiceName string
+	groupByService     bool
 
 	url            string
 	client         *http.Client
@@ -50,6 +53,7 @@ type zipkinExporter struct {
 func createZipkinExporter(cfg *Config, settings component.TelemetrySettings) (*zipkinExporter, error) {
 	ze := &zipkinExporter{
 		defaultServiceName: cfg.DefaultServiceName,
+		groupByService:     cfg.Batching.GroupByService,
 		url:                cfg.Endpoint,
 		clientSettings:     &cfg.HTTPClientSettings,
 		client:             nil,
@@ -80,6 +84,18 @@ func (ze *zipkinExporter) pushTraces(ctx context.Context, td ptrace.Traces) erro
 		return consumererror.NewPermanent(fmt.Errorf("failed to push trace data via Zipkin exporter: %w", err))
 	}
 
+	if !ze.groupByService {
+		return ze.send(ctx, spans)
+	}
+	var errs error
+	for _, group := range groupByLocalEndpoint(spans) {
+		errs = multierr.Append(errs, ze.send(ctx, group))
+	}
+	return errs
+}
+
+// send sends the spans in a single request.
+func (ze *zipkinExporter) send(ctx context.Context, spans []*zipkinmodel.SpanModel) error {
 	body, err := ze.serializer.Serialize(spans)
 	if err != nil {
 		return consumererror.NewPermanent(fmt.Errorf("failed to push trace data via Zipkin exporter: %w", err))
@@ -101,3 +117,28 @@ func (ze *zipkinExporter) pushTraces(ctx context.Context, td ptrace.Traces) erro
 	}
 	return nil
 }
+
+// groupByLocalEndpoint groups the spans by local endpoint, keeping the order in
+// which the endpoints are first found.
+func groupByLocalEndpoint(spans []*zipkinmodel.SpanModel) [][]*zipkinmodel.SpanModel {
+	var groups [][]*zipkinmodel.SpanModel
+	index := map[string]int{}
+	for _, span := range spans {
+		key := localEndpointKey(span.LocalEndpoint)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], span)
+	}
+	return groups
+}
+
+func localEndpointKey(endpoint *zipkinmodel.Endpoint) string {
+	if endpoint == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s|%s|%s|%d", endpoint.ServiceName, endpoint.IPv4, endpoint.IPv6, endpoint.Port)
+}
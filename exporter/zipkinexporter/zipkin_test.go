@@ -17,6 +17,7 @@ package zipkinexporter
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -32,8 +33,12 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/tracebatch"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zipkinreceiver"
 )
 
@@ -353,3 +358,44 @@ func TestZipkinExporter_roundtripProto(t *testing.T) {
 	_, err = zipkin_proto3.ParseSpans(gotBytes, false)
 	require.NoError(t, err)
 }
+
+func TestZipkinExporter_groupByService(t *testing.T) {
+	var requests [][]zipkinmodel.SpanModel
+	cst := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var spans []zipkinmodel.SpanModel
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&spans))
+		requests = append(requests, spans)
+		r.Body.Close()
+	}))
+	defer cst.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: cst.URL,
+		},
+		Format:   "json",
+		Batching: tracebatch.Config{GroupByService: true},
+	}
+	zexp, err := NewFactory().CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, zexp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, zexp.Shutdown(context.Background())) })
+
+	td := ptrace.NewTraces()
+	for i, service := range []string{"frontend", "backend", "frontend"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr(conventions.AttributeServiceName, service)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID(pcommon.TraceID([16]byte{1}))
+		span.SetSpanID(pcommon.SpanID([8]byte{byte(i + 1)}))
+		span.SetName(service)
+	}
+	require.NoError(t, zexp.ConsumeTraces(context.Background(), td))
+
+	require.Len(t, requests, 2)
+	require.Len(t, requests[0], 2)
+	assert.Equal(t, "frontend", requests[0][0].LocalEndpoint.ServiceName)
+	assert.Equal(t, "frontend", requests[0][1].LocalEndpoint.ServiceName)
+	require.Len(t, requests[1], 1)
+	assert.Equal(t, "backend", requests[1][0].LocalEndpoint.ServiceName)
+}
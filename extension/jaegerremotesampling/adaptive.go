@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremotesampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTargetSamplesPerSecond     = 1.0
+	defaultCalculationInterval        = time.Minute
+	defaultInitialSamplingProbability = 0.001
+	defaultMinSamplingProbability     = 1e-5
+	defaultMinSamplesPerSecond        = 1.0 / 60
+
+	// the probability of an operation is left unchanged while its throughput is within this ratio of the target
+	deltaTolerance = 0.3
+	// the highest increase of the probability of an operation at each calculation, as Jaeger does
+	percentIncreaseCap = 0.5
+
+	// the metric, and its attributes, reporting the number of spans of each operation in the spanmetrics processor
+	callsMetricName = "calls_total"
+	serviceNameKey  = conventions.AttributeServiceName
+	operationKey    = "operation"
+)
+
+var _ strategystore.StrategyStore = (*adaptiveStore)(nil)
+
+type serviceOperation struct {
+	service   string
+	operation string
+}
+
+// adaptiveStore computes the sampling probability of each operation for its sampled spans to approach
+// the target throughput, approximating the adaptive sampling of the Jaeger Collector.
+type adaptiveStore struct {
+	settings AdaptiveSettings
+	logger   *zap.Logger
+
+	mu sync.Mutex
+	// counts holds the number of spans of each operation since the last calculation
+	counts map[serviceOperation]int64
+	// cumulative holds the last value of each cumulative series, to compute the number of new spans
+	cumulative      map[string]int64
+	probabilities   map[string]map[string]float64
+	lastCalculation time.Time
+
+	stop       chan struct{}
+	shutdownWG sync.WaitGroup
+}
+
+func newAdaptiveStore(settings AdaptiveSettings, logger *zap.Logger) *adaptiveStore {
+	if settings.TargetSamplesPerSecond == 0 {
+		settings.TargetSamplesPerSecond = defaultTargetSamplesPerSecond
+	}
+	if settings.CalculationInterval == 0 {
+		settings.CalculationInterval = defaultCalculationInterval
+	}
+	if settings.InitialSamplingProbability == 0 {
+		settings.InitialSamplingProbability = defaultInitialSamplingProbability
+	}
+	if settings.MinSamplingProbability == 0 {
+		settings.MinSamplingProbability = defaultMinSamplingProbability
+	}
+	if settings.MinSamplesPerSecond == 0 {
+		settings.MinSamplesPerSecond = defaultMinSamplesPerSecond
+	}
+
+	return &adaptiveStore{
+		settings:        settings,
+		logger:          logger,
+		counts:          map[serviceOperation]int64{},
+		cumulative:      map[string]int64{},
+		probabilities:   map[string]map[string]float64{},
+		lastCalculation: time.Now(),
+		stop:            make(chan struct{}),
+	}
+}
+
+func (s *adaptiveStore) start() {
+	s.shutdownWG.Add(1)
+	go func() {
+		defer s.shutdownWG.Done()
+
+		ticker := time.NewTicker(s.settings.CalculationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				s.calculate(now)
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (s *adaptiveStore) shutdown() {
+	close(s.stop)
+	s.shutdownWG.Wait()
+}
+
+// GetSamplingStrategy returns the probabilities computed for the operations of the service, the operations
+// not yet observed being sampled with the initial probability.
+func (s *adaptiveStore) GetSamplingStrategy(_ context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	s.mu.Lock()
+	operations := s.probabilities[serviceName]
+	strategies := make([]*sampling.OperationSamplingStrategy, 0, len(operations))
+	for operation, probability := range operations {
+		strategies = append(strategies, &sampling.OperationSamplingStrategy{
+			Operation:             operation,
+			ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: probability},
+		})
+	}
+	s.mu.Unlock()
+
+	sort.Slice(strategies, func(i, j int) bool {
+		return strategies[i].Operation < strategies[j].Operation
+	})
+
+	return &sampling.SamplingStrategyResponse{
+		StrategyType: sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{
+			SamplingRate: s.settings.InitialSamplingProbability,
+		},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       s.settings.InitialSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: s.settings.MinSamplesPerSecond,
+			PerOperationStrategies:           strategies,
+		},
+	}, nil
+}
+
+// recordMetrics adds the spans counted in the calls metric of the spanmetrics processor to the throughput
+// of their operation.
+func (s *adaptiveStore) recordMetrics(md pmetric.Metrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if metric.Name() != callsMetricName || metric.Type() != pmetric.MetricTypeSum {
+					continue
+				}
+				s.recordSum(metric.Sum())
+			}
+		}
+	}
+}
+
+func (s *adaptiveStore) recordSum(sum pmetric.Sum) {
+	cumulative := sum.AggregationTemporality() == pmetric.MetricAggregationTemporalityCumulative
+	dps := sum.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		service, ok := dp.Attributes().Get(serviceNameKey)
+		if !ok {
+			continue
+		}
+		operation, ok := dp.Attributes().Get(operationKey)
+		if !ok {
+			continue
+		}
+
+		value := dp.IntValue()
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeDouble {
+			value = int64(dp.DoubleValue())
+		}
+
+		count := value
+		if cumulative {
+			key := seriesKey(dp.Attributes())
+			// a lower value means the series was reset, the value being the number of spans since then
+			if previous, ok := s.cumulative[key]; ok && value >= previous {
+				count = value - previous
+			}
+			s.cumulative[key] = value
+		}
+
+		s.counts[serviceOperation{service: service.Str(), operation: operation.Str()}] += count
+	}
+}
+
+// calculate updates the probability of the operations from their throughput since the last calculation.
+func (s *adaptiveStore) calculate(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := now.Sub(s.lastCalculation).Seconds()
+	s.lastCalculation = now
+	if elapsed <= 0 {
+		return
+	}
+
+	for key, count := range s.counts {
+		operations, ok := s.probabilities[key.service]
+		if !ok {
+			operations = map[string]float64{}
+			s.probabilities[key.service] = operations
+		}
+
+		probability, ok := operations[key.operation]
+		if !ok {
+			probability = s.settings.InitialSamplingProbability
+		}
+		operations[key.operation] = s.calculateProbability(probability, float64(count)/elapsed)
+	}
+	s.counts = map[serviceOperation]int64{}
+
+	s.logger.Debug("Computed the adaptive sampling probabilities", zap.Any("probabilities", s.probabilities))
+}
+
+func (s *adaptiveStore) calculateProbability(probability float64, throughput float64) float64 {
+	target := s.settings.TargetSamplesPerSecond
+	if throughput == 0 || math.Abs(throughput-target)/target < deltaTolerance {
+		return probability
+	}
+
+	newProbability := probability * target / throughput
+	if newProbability > probability*(1+percentIncreaseCap) {
+		newProbability = probability * (1 + percentIncreaseCap)
+	}
+	return math.Min(1, math.Max(s.settings.MinSamplingProbability, newProbability))
+}
+
+// seriesKey identifies a data point series from its attributes.
+func seriesKey(attrs pcommon.Map) string {
+	raw := attrs.AsRaw()
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%v;", k, raw[k])
+	}
+	return b.String()
+}
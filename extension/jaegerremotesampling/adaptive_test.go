@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremotesampling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// callsMetrics builds the calls metric of the spanmetrics processor, with one data point per operation.
+func callsMetrics(temporality pmetric.MetricAggregationTemporality, calls map[string]int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName(callsMetricName)
+	metric.SetEmptySum().SetAggregationTemporality(temporality)
+	for operation, count := range calls {
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr(serviceNameKey, "foo")
+		dp.Attributes().PutStr(operationKey, operation)
+		dp.Attributes().PutStr("span.kind", "SPAN_KIND_SERVER")
+		dp.SetIntValue(count)
+	}
+	return md
+}
+
+func probabilities(t *testing.T, store *adaptiveStore, service string) map[string]float64 {
+	resp, err := store.GetSamplingStrategy(context.Background(), service)
+	require.NoError(t, err)
+	probabilities := map[string]float64{}
+	for _, strategy := range resp.OperationSampling.PerOperationStrategies {
+		probabilities[strategy.Operation] = strategy.ProbabilisticSampling.SamplingRate
+	}
+	return probabilities
+}
+
+func TestAdaptiveStoreDefaults(t *testing.T) {
+	store := newAdaptiveStore(AdaptiveSettings{}, zap.NewNop())
+
+	resp, err := store.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, &sampling.SamplingStrategyResponse{
+		StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: defaultInitialSamplingProbability},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       defaultInitialSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: defaultMinSamplesPerSecond,
+			PerOperationStrategies:           []*sampling.OperationSamplingStrategy{},
+		},
+	}, resp)
+}
+
+func TestAdaptiveStoreCalculate(t *testing.T) {
+	store := newAdaptiveStore(AdaptiveSettings{
+		TargetSamplesPerSecond:     1,
+		InitialSamplingProbability: 0.1,
+		MinSamplingProbability:     0.001,
+	}, zap.NewNop())
+	start := store.lastCalculation
+
+	store.recordMetrics(callsMetrics(pmetric.MetricAggregationTemporalityDelta, map[string]int64{
+		"/busy":   400,
+		"/target": 10,
+		"/quiet":  2,
+		"/flood":  1000000,
+	}))
+	store.calculate(start.Add(10 * time.Second))
+
+	assert.InDeltaMapValues(t, map[string]float64{
+		// 40 spans per second are sampled, 40 times the target
+		"/busy": 0.0025,
+		// the throughput is already the target
+		"/target": 0.1,
+		// the increase is capped
+		"/quiet": 0.15,
+		// the probability is not lower than the minimum
+		"/flood": 0.001,
+	}, probabilities(t, store, "foo"), 1e-9)
+	assert.Empty(t, probabilities(t, store, "bar"))
+
+	// the operations without spans keep their probability
+	store.recordMetrics(callsMetrics(pmetric.MetricAggregationTemporalityDelta, map[string]int64{"/busy": 5}))
+	store.calculate(start.Add(20 * time.Second))
+	assert.InDeltaMapValues(t, map[string]float64{
+		"/busy":   0.00375,
+		"/target": 0.1,
+		"/quiet":  0.15,
+		"/flood":  0.001,
+	}, probabilities(t, store, "foo"), 1e-9)
+}
+
+func TestAdaptiveStoreCumulative(t *testing.T) {
+	store := newAdaptiveStore(AdaptiveSettings{
+		TargetSamplesPerSecond:     1,
+		InitialSamplingProbability: 0.1,
+	}, zap.NewNop())
+	start := store.lastCalculation
+
+	store.recordMetrics(callsMetrics(pmetric.MetricAggregationTemporalityCumulative, map[string]int64{"/busy": 100}))
+	store.recordMetrics(callsMetrics(pmetric.MetricAggregationTemporalityCumulative, map[string]int64{"/busy": 300}))
+	store.calculate(start.Add(10 * time.Second))
+	assert.InDeltaMapValues(t, map[string]float64{"/busy": 1.0 / 300}, probabilities(t, store, "foo"), 1e-9)
+
+	// only the spans counted since the previous value are recorded, the lower values being reset series
+	store.recordMetrics(callsMetrics(pmetric.MetricAggregationTemporalityCumulative, map[string]int64{"/busy": 500}))
+	store.recordMetrics(callsMetrics(pmetric.MetricAggregationTemporalityCumulative, map[string]int64{"/busy": 200}))
+	store.calculate(start.Add(20 * time.Second))
+	assert.InDeltaMapValues(t, map[string]float64{"/busy": 1.0 / 300 / 40}, probabilities(t, store, "foo"), 1e-9)
+}
+
+func TestAdaptiveStoreIgnoredMetrics(t *testing.T) {
+	store := newAdaptiveStore(AdaptiveSettings{}, zap.NewNop())
+
+	md := callsMetrics(pmetric.MetricAggregationTemporalityDelta, map[string]int64{"/busy": 100})
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("latency")
+	noOperation := callsMetrics(pmetric.MetricAggregationTemporalityDelta, map[string]int64{"/busy": 100})
+	noOperation.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes().Remove(operationKey)
+
+	store.recordMetrics(md)
+	store.recordMetrics(noOperation)
+	assert.Empty(t, store.counts)
+}
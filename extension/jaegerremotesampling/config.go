@@ -16,6 +16,7 @@ package jaegerremotesampling // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -24,8 +25,8 @@ import (
 )
 
 var (
-	errTooManySources     = errors.New("too many sources specified, has to be either 'file' or 'remote'")
-	errNoSources          = errors.New("no sources specified, has to be either 'file' or 'remote'")
+	errTooManySources     = errors.New("too many sources specified, has to be one of 'file', 'remote' or 'adaptive'")
+	errNoSources          = errors.New("no sources specified, has to be one of 'file', 'remote' or 'adaptive'")
 	errAtLeastOneProtocol = errors.New("no protocols selected to serve the strategies, use 'grpc', 'http', or both")
 )
 
@@ -49,6 +50,29 @@ type Source struct {
 
 	// ReloadInterval determines the periodicity to refresh the strategies
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// Adaptive computes the strategies from the throughput of the operations, as reported by the spanmetrics processor
+	Adaptive *AdaptiveSettings `mapstructure:"adaptive"`
+}
+
+// AdaptiveSettings configures the computation of the per-operation sampling probabilities.
+// The zero values are replaced by the defaults.
+type AdaptiveSettings struct {
+	// TargetSamplesPerSecond is the number of spans per second each operation should be sampled at
+	TargetSamplesPerSecond float64 `mapstructure:"target_samples_per_second"`
+
+	// CalculationInterval determines the periodicity to compute the sampling probabilities
+	CalculationInterval time.Duration `mapstructure:"calculation_interval"`
+
+	// InitialSamplingProbability is the sampling probability of the operations not yet observed
+	InitialSamplingProbability float64 `mapstructure:"initial_sampling_probability"`
+
+	// MinSamplingProbability is the lowest sampling probability an operation can be given
+	MinSamplingProbability float64 `mapstructure:"min_sampling_probability"`
+
+	// MinSamplesPerSecond is the lower bound rate of traces the clients sample for each operation,
+	// whatever its sampling probability
+	MinSamplesPerSecond float64 `mapstructure:"min_samples_per_second"`
 }
 
 var _ config.Extension = (*Config)(nil)
@@ -59,13 +83,52 @@ func (cfg *Config) Validate() error {
 		return errAtLeastOneProtocol
 	}
 
-	if cfg.Source.File != "" && cfg.Source.Remote != nil {
+	sources := 0
+	if cfg.Source.File != "" {
+		sources++
+	}
+	if cfg.Source.Remote != nil {
+		sources++
+	}
+	if cfg.Source.Adaptive != nil {
+		sources++
+	}
+
+	if sources > 1 {
 		return errTooManySources
 	}
 
-	if cfg.Source.File == "" && cfg.Source.Remote == nil {
+	if sources == 0 {
 		return errNoSources
 	}
 
+	if cfg.Source.Adaptive != nil {
+		return cfg.Source.Adaptive.Validate()
+	}
+
+	return nil
+}
+
+// Validate checks if the adaptive sampling settings are valid
+func (cfg *AdaptiveSettings) Validate() error {
+	if cfg.TargetSamplesPerSecond < 0 {
+		return errors.New("'target_samples_per_second' must not be negative")
+	}
+	if cfg.CalculationInterval < 0 {
+		return errors.New("'calculation_interval' must not be negative")
+	}
+	if cfg.MinSamplesPerSecond < 0 {
+		return errors.New("'min_samples_per_second' must not be negative")
+	}
+	if err := validateProbability("initial_sampling_probability", cfg.InitialSamplingProbability); err != nil {
+		return err
+	}
+	return validateProbability("min_sampling_probability", cfg.MinSamplingProbability)
+}
+
+func validateProbability(name string, probability float64) error {
+	if probability < 0 || probability > 1 {
+		return fmt.Errorf("'%s' must be between 0 and 1", name)
+	}
 	return nil
 }
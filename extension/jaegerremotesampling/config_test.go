@@ -15,6 +15,7 @@
 package jaegerremotesampling
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 	"time"
@@ -66,6 +67,24 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "2"),
+			expected: &Config{
+				ExtensionSettings:  config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				HTTPServerSettings: &confighttp.HTTPServerSettings{Endpoint: ":5778"},
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{NetAddr: confignet.NetAddr{
+					Endpoint:  ":14250",
+					Transport: "tcp",
+				}},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{
+						TargetSamplesPerSecond:     2,
+						CalculationInterval:        30 * time.Second,
+						InitialSamplingProbability: 0.01,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -112,6 +131,46 @@ func TestValidate(t *testing.T) {
 			},
 			expected: errTooManySources,
 		},
+		{
+			desc: "adaptive and file sources",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					File:     "/tmp/some-file",
+					Adaptive: &AdaptiveSettings{},
+				},
+			},
+			expected: errTooManySources,
+		},
+		{
+			desc: "adaptive source",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{},
+				},
+			},
+		},
+		{
+			desc: "invalid adaptive probability",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{MinSamplingProbability: 2},
+				},
+			},
+			expected: errors.New("'min_sampling_probability' must be between 0 and 1"),
+		},
+		{
+			desc: "negative adaptive target",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{TargetSamplesPerSecond: -1},
+				},
+			},
+			expected: errors.New("'target_samples_per_second' must not be negative"),
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
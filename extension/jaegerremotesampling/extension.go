@@ -16,19 +16,27 @@ package jaegerremotesampling // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	grpcStore "github.com/jaegertracing/jaeger/cmd/agent/app/configmanager/grpc"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
 	"github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
 )
 
-var _ component.Extension = (*jrsExtension)(nil)
+var (
+	_ component.Extension = (*jrsExtension)(nil)
+	_ consumer.Metrics    = (*jrsExtension)(nil)
+)
+
+var errAdaptiveNotEnabled = errors.New("the 'adaptive' source is not configured, the metrics can't be consumed")
 
 type jrsExtension struct {
 	cfg       *Config
@@ -37,6 +45,7 @@ type jrsExtension struct {
 	httpServer    component.Component
 	grpcServer    component.Component
 	samplingStore strategystore.StrategyStore
+	adaptiveStore *adaptiveStore
 
 	closers []func() error
 }
@@ -54,6 +63,7 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	// source of the sampling config:
 	// - remote (gRPC)
 	// - local file
+	// - adaptive
 	// we can then use a simplified logic here to assign the appropriate store
 	if jrse.cfg.Source.File != "" {
 		opts := static.Options{
@@ -86,6 +96,16 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 		})
 	}
 
+	if jrse.cfg.Source.Adaptive != nil {
+		jrse.adaptiveStore = newAdaptiveStore(*jrse.cfg.Source.Adaptive, jrse.telemetry.Logger)
+		jrse.adaptiveStore.start()
+		jrse.samplingStore = jrse.adaptiveStore
+		jrse.closers = append(jrse.closers, func() error {
+			jrse.adaptiveStore.shutdown()
+			return nil
+		})
+	}
+
 	if jrse.cfg.HTTPServerSettings != nil {
 		httpServer, err := internal.NewHTTP(jrse.telemetry, *jrse.cfg.HTTPServerSettings, jrse.samplingStore)
 		if err != nil {
@@ -135,3 +155,17 @@ func (jrse *jrsExtension) Shutdown(ctx context.Context) error {
 
 	return nil
 }
+
+// ConsumeMetrics records the throughput of the operations, as reported by the spanmetrics processor
+// having this extension as its metrics exporter.
+func (jrse *jrsExtension) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	if jrse.adaptiveStore == nil {
+		return errAdaptiveNotEnabled
+	}
+	jrse.adaptiveStore.recordMetrics(md)
+	return nil
+}
+
+func (jrse *jrsExtension) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
@@ -20,12 +20,14 @@ import (
 	"net"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"google.golang.org/grpc"
 )
 
@@ -93,3 +95,34 @@ func (s samplingServer) GetSamplingStrategy(ctx context.Context, param *api_v2.S
 		StrategyType: api_v2.SamplingStrategyType_PROBABILISTIC,
 	}, nil
 }
+
+func TestStartAndShutdownAdaptive(t *testing.T) {
+	// prepare
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPServerSettings.Endpoint = "localhost:0"
+	cfg.GRPCServerSettings.NetAddr.Endpoint = "localhost:0"
+	cfg.Source.Adaptive = &AdaptiveSettings{InitialSamplingProbability: 0.1}
+
+	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, e)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+
+	// test
+	assert.NoError(t, e.ConsumeMetrics(context.Background(), callsMetrics(pmetric.MetricAggregationTemporalityDelta, map[string]int64{"/busy": 100})))
+	e.adaptiveStore.calculate(time.Now())
+	resp, err := e.samplingStore.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+
+	// verify
+	require.Len(t, resp.OperationSampling.PerOperationStrategies, 1)
+	assert.Equal(t, "/busy", resp.OperationSampling.PerOperationStrategies[0].Operation)
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestConsumeMetricsWithoutAdaptive(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Source.File = filepath.Join("testdata", "strategy.json")
+	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+
+	assert.ErrorIs(t, e.ConsumeMetrics(context.Background(), pmetric.NewMetrics()), errAdaptiveNotEnabled)
+}
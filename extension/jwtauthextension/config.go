@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jwtauthextension"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/multierr"
+	"gopkg.in/square/go-jose.v2"
+)
+
+var (
+	errNoKeyProvided        = errors.New("no private_key or jwks_file provided")
+	errBothKeysProvided     = errors.New("only one of private_key and jwks_file can be provided")
+	errNoKeyIDProvided      = errors.New("no key_id provided, which is required with private_key")
+	errNoTTLProvided        = errors.New("no valid ttl was provided")
+	errNoRefreshInterval    = errors.New("no valid jwks_refresh_interval was provided")
+	errNoHeaderProvided     = errors.New("no header provided")
+	errReservedClaim        = errors.New("claims can't set a registered claim")
+	errUnsupportedSignature = errors.New("unsupported signing_method")
+)
+
+// registeredClaims are the claims set from the settings of the extension.
+var registeredClaims = map[string]bool{
+	"iss": true,
+	"sub": true,
+	"aud": true,
+	"exp": true,
+	"nbf": true,
+	"iat": true,
+	"jti": true,
+}
+
+// supportedSigningMethods are the asymmetric signing methods of RFC 7518.
+var supportedSigningMethods = map[jose.SignatureAlgorithm]bool{
+	jose.RS256: true,
+	jose.RS384: true,
+	jose.RS512: true,
+	jose.PS256: true,
+	jose.PS384: true,
+	jose.PS512: true,
+	jose.ES256: true,
+	jose.ES384: true,
+	jose.ES512: true,
+	jose.EdDSA: true,
+}
+
+// Config specifies how the JWTs sent with each request are minted.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// PrivateKey is the PEM encoded private key signing the tokens.
+	PrivateKey string `mapstructure:"private_key"`
+
+	// KeyID is the `kid` header of the tokens signed with the private key. With a JWKS file,
+	// it selects the key signing the tokens, the first key of the set being used otherwise.
+	KeyID string `mapstructure:"key_id"`
+
+	// JWKSFile is the path to a JSON Web Key Set holding the private keys signing the tokens,
+	// reloaded every JWKSRefreshInterval so that the keys can be rotated.
+	JWKSFile string `mapstructure:"jwks_file"`
+
+	// JWKSRefreshInterval is the interval at which the JWKS file is reloaded.
+	JWKSRefreshInterval time.Duration `mapstructure:"jwks_refresh_interval"`
+
+	// SigningMethod is the `alg` of the tokens, unless set by the key of the JWKS file.
+	SigningMethod string `mapstructure:"signing_method"`
+
+	// Issuer is the `iss` claim of the tokens.
+	Issuer string `mapstructure:"issuer"`
+
+	// Subject is the `sub` claim of the tokens.
+	Subject string `mapstructure:"subject"`
+
+	// Audience is the `aud` claim of the tokens.
+	Audience []string `mapstructure:"audience"`
+
+	// TTL is the time until expiry of each token. The tokens are reused until half of their TTL.
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// Claims are the custom claims added to the tokens.
+	Claims map[string]interface{} `mapstructure:"claims"`
+
+	// Header is the header of the requests carrying the tokens.
+	Header string `mapstructure:"header"`
+
+	// Scheme prefixes the tokens in the header, the token being sent alone when empty.
+	Scheme string `mapstructure:"scheme"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	var errs error
+	switch {
+	case cfg.PrivateKey == "" && cfg.JWKSFile == "":
+		errs = multierr.Append(errs, errNoKeyProvided)
+	case cfg.PrivateKey != "" && cfg.JWKSFile != "":
+		errs = multierr.Append(errs, errBothKeysProvided)
+	case cfg.PrivateKey != "" && cfg.KeyID == "":
+		errs = multierr.Append(errs, errNoKeyIDProvided)
+	case cfg.JWKSFile != "" && cfg.JWKSRefreshInterval <= 0:
+		errs = multierr.Append(errs, errNoRefreshInterval)
+	}
+	if !supportedSigningMethods[jose.SignatureAlgorithm(cfg.SigningMethod)] {
+		errs = multierr.Append(errs, fmt.Errorf("%w %q", errUnsupportedSignature, cfg.SigningMethod))
+	}
+	if cfg.TTL <= 0 {
+		errs = multierr.Append(errs, errNoTTLProvided)
+	}
+	if cfg.Header == "" {
+		errs = multierr.Append(errs, errNoHeaderProvided)
+	}
+	for claim := range cfg.Claims {
+		if registeredClaims[claim] {
+			errs = multierr.Append(errs, fmt.Errorf("%w %q", errReservedClaim, claim))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauthextension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := os.ReadFile(filepath.Join("testdata", "private_key.pem"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Extension
+		expectedErr error
+	}{
+		{
+			id: config.NewComponentID(typeStr),
+			expected: &Config{
+				ExtensionSettings:   config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				PrivateKey:          string(privateKey),
+				KeyID:               "test-key",
+				JWKSRefreshInterval: defaultJWKSRefreshInterval,
+				SigningMethod:       "ES256",
+				Issuer:              "collector",
+				Subject:             "otel",
+				Audience:            []string{"ingest"},
+				TTL:                 10 * time.Minute,
+				Claims:              map[string]interface{}{"tenant": "acme"},
+				Header:              "X-Auth-Token",
+				Scheme:              "",
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "jwks"),
+			expected: &Config{
+				ExtensionSettings:   config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				JWKSFile:            "testdata/jwks.json",
+				JWKSRefreshInterval: 30 * time.Second,
+				SigningMethod:       "RS256",
+				Issuer:              "collector",
+				TTL:                 defaultTTL,
+				Header:              defaultHeader,
+				Scheme:              defaultScheme,
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "missingkey"),
+			expectedErr: errNoKeyProvided,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "bothkeys"),
+			expectedErr: errBothKeysProvided,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "missingkeyid"),
+			expectedErr: errNoKeyIDProvided,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "reservedclaim"),
+			expectedErr: errReservedClaim,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "unsupportedsigningmethod"),
+			expectedErr: errUnsupportedSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExtension(sub, cfg))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
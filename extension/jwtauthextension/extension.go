@@ -0,0 +1,310 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jwtauthextension"
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// signingKey signs the tokens with the key of the `kid`.
+type signingKey struct {
+	id     string
+	signer jose.Signer
+}
+
+// jwtClientAuthenticator mints the JWTs sent with each request, reusing each
+// token until half of its TTL.
+type jwtClientAuthenticator struct {
+	cfg    *Config
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu        sync.Mutex
+	key       *signingKey
+	token     string
+	refreshAt time.Time
+
+	shutdownCh chan struct{}
+	doneCh     chan struct{}
+}
+
+var _ configauth.ClientAuthenticator = (*jwtClientAuthenticator)(nil)
+
+func newJWTClientAuthenticator(cfg *Config, logger *zap.Logger) (*jwtClientAuthenticator, error) {
+	a := &jwtClientAuthenticator{
+		cfg:    cfg,
+		logger: logger,
+		now:    time.Now,
+	}
+	if cfg.PrivateKey == "" {
+		return a, nil
+	}
+	pk, err := parsePrivateKey([]byte(cfg.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	key, err := newSigningKey(cfg.KeyID, jose.SignatureAlgorithm(cfg.SigningMethod), pk)
+	if err != nil {
+		return nil, err
+	}
+	a.key = key
+	return a, nil
+}
+
+// Start loads the JWKS file, and starts reloading it periodically.
+func (a *jwtClientAuthenticator) Start(_ context.Context, _ component.Host) error {
+	if a.cfg.JWKSFile == "" {
+		return nil
+	}
+	if err := a.loadJWKS(); err != nil {
+		return err
+	}
+
+	a.shutdownCh = make(chan struct{})
+	a.doneCh = make(chan struct{})
+	go a.refreshJWKS()
+	return nil
+}
+
+// Shutdown stops reloading the JWKS file.
+func (a *jwtClientAuthenticator) Shutdown(_ context.Context) error {
+	if a.shutdownCh == nil {
+		return nil
+	}
+	close(a.shutdownCh)
+	<-a.doneCh
+	a.shutdownCh = nil
+	return nil
+}
+
+func (a *jwtClientAuthenticator) refreshJWKS() {
+	defer close(a.doneCh)
+
+	ticker := time.NewTicker(a.cfg.JWKSRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.loadJWKS(); err != nil {
+				a.logger.Error("Failed to reload the JWKS file, the current key is kept", zap.Error(err))
+			}
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+// loadJWKS selects the signing key in the JWKS file, a new token being minted
+// when the key changes.
+func (a *jwtClientAuthenticator) loadJWKS() error {
+	data, err := os.ReadFile(a.cfg.JWKSFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the JWKS file: %w", err)
+	}
+	var jwks jose.JSONWebKeySet
+	if err = json.Unmarshal(data, &jwks); err != nil {
+		return fmt.Errorf("failed to parse the JWKS file: %w", err)
+	}
+
+	jwk, err := selectKey(jwks, a.cfg.KeyID)
+	if err != nil {
+		return err
+	}
+	alg := jose.SignatureAlgorithm(jwk.Algorithm)
+	if alg == "" {
+		alg = jose.SignatureAlgorithm(a.cfg.SigningMethod)
+	}
+	key, err := newSigningKey(jwk.KeyID, alg, jwk.Key)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.key == nil || a.key.id != key.id {
+		a.logger.Info("Signing the tokens with a new key", zap.String("kid", key.id))
+		a.token = ""
+	}
+	a.key = key
+	return nil
+}
+
+// selectKey returns the private key of the key ID, or the first private key
+// when no key ID is set.
+func selectKey(jwks jose.JSONWebKeySet, keyID string) (jose.JSONWebKey, error) {
+	for _, jwk := range jwks.Keys {
+		if jwk.IsPublic() || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		if keyID == "" || jwk.KeyID == keyID {
+			return jwk, nil
+		}
+	}
+	if keyID != "" {
+		return jose.JSONWebKey{}, fmt.Errorf("no private signing key with the kid %q in the JWKS file", keyID)
+	}
+	return jose.JSONWebKey{}, errors.New("no private signing key in the JWKS file")
+}
+
+func newSigningKey(id string, alg jose.SignatureAlgorithm, key interface{}) (*signingKey, error) {
+	opts := (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", id)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the signer of the key %q: %w", id, err)
+	}
+	return &signingKey{id: id, signer: signer}, nil
+}
+
+// parsePrivateKey parses a PEM encoded PKCS #8, PKCS #1 or EC private key.
+func parsePrivateKey(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode the PEM private key")
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("failed to parse the private key, which must be a PKCS #8, PKCS #1 or EC private key")
+}
+
+// headerValue returns the value of the header carrying the current token.
+func (a *jwtClientAuthenticator) headerValue() (string, error) {
+	token, err := a.currentToken()
+	if err != nil {
+		return "", err
+	}
+	if a.cfg.Scheme == "" {
+		return token, nil
+	}
+	return a.cfg.Scheme + " " + token, nil
+}
+
+// currentToken returns the current token, minting a new one after half of its TTL.
+func (a *jwtClientAuthenticator) currentToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if a.token != "" && now.Before(a.refreshAt) {
+		return a.token, nil
+	}
+	if a.key == nil {
+		return "", errors.New("no signing key loaded")
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	claims := jwt.Claims{
+		Issuer:    a.cfg.Issuer,
+		Subject:   a.cfg.Subject,
+		Audience:  jwt.Audience(a.cfg.Audience),
+		Expiry:    jwt.NewNumericDate(now.Add(a.cfg.TTL)),
+		NotBefore: jwt.NewNumericDate(now),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ID:        hex.EncodeToString(jti),
+	}
+	builder := jwt.Signed(a.key.signer).Claims(claims)
+	if len(a.cfg.Claims) > 0 {
+		builder = builder.Claims(a.cfg.Claims)
+	}
+	token, err := builder.CompactSerialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the token: %w", err)
+	}
+
+	a.token = token
+	a.refreshAt = now.Add(a.cfg.TTL / 2)
+	return token, nil
+}
+
+// RoundTripper returns a RoundTripper adding the token to the requests.
+func (a *jwtClientAuthenticator) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &roundTripper{
+		base:          base,
+		authenticator: a,
+	}, nil
+}
+
+// PerRPCCredentials returns a credentials.PerRPCCredentials adding the token to the RPCs.
+func (a *jwtClientAuthenticator) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return &perRPCAuth{authenticator: a}, nil
+}
+
+// roundTripper adds the token to each http request.
+type roundTripper struct {
+	base          http.RoundTripper
+	authenticator *jwtClientAuthenticator
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	value, err := rt.authenticator.headerValue()
+	if err != nil {
+		return nil, err
+	}
+	req2 := req.Clone(req.Context())
+	if req2.Header == nil {
+		req2.Header = make(http.Header)
+	}
+	req2.Header.Set(rt.authenticator.cfg.Header, value)
+	return rt.base.RoundTrip(req2)
+}
+
+var _ credentials.PerRPCCredentials = (*perRPCAuth)(nil)
+
+// perRPCAuth adds the token to the metadata of each RPC.
+type perRPCAuth struct {
+	authenticator *jwtClientAuthenticator
+}
+
+// GetRequestMetadata returns the request metadata to be used with the RPC.
+func (c *perRPCAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	value, err := c.authenticator.headerValue()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{strings.ToLower(c.authenticator.cfg.Header): value}, nil
+}
+
+// RequireTransportSecurity always returns true for this implementation.
+func (*perRPCAuth) RequireTransportSecurity() bool {
+	return true
+}
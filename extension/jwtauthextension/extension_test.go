@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauthextension
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+type mockRoundTripper struct {
+	header http.Header
+}
+
+func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	m.header = req.Header
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func loadJWKS(t *testing.T, path string) jose.JSONWebKeySet {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var jwks jose.JSONWebKeySet
+	require.NoError(t, json.Unmarshal(data, &jwks))
+	return jwks
+}
+
+func writeJWKS(t *testing.T, path string, keys ...jose.JSONWebKey) {
+	data, err := json.Marshal(jose.JSONWebKeySet{Keys: keys})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+// parseToken checks the signature of the token, and returns its kid and claims.
+func parseToken(t *testing.T, raw string, jwks jose.JSONWebKeySet) (string, map[string]interface{}) {
+	token, err := jwt.ParseSigned(raw)
+	require.NoError(t, err)
+	require.Len(t, token.Headers, 1)
+	kid := token.Headers[0].KeyID
+	keys := jwks.Key(kid)
+	require.Len(t, keys, 1)
+
+	claims := map[string]interface{}{}
+	require.NoError(t, token.Claims(keys[0].Public().Key, &claims))
+	return kid, claims
+}
+
+func TestMintToken(t *testing.T) {
+	privateKey, err := os.ReadFile(filepath.Join("testdata", "private_key.pem"))
+	require.NoError(t, err)
+	pk, err := parsePrivateKey(privateKey)
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.PrivateKey = string(privateKey)
+	cfg.KeyID = "test-key"
+	cfg.SigningMethod = "ES256"
+	cfg.Issuer = "collector"
+	cfg.Subject = "otel"
+	cfg.Audience = []string{"ingest"}
+	cfg.TTL = 10 * time.Minute
+	cfg.Claims = map[string]interface{}{"tenant": "acme"}
+	a, err := newJWTClientAuthenticator(cfg, zap.NewNop())
+	require.NoError(t, err)
+	now := time.Unix(1665000000, 0)
+	a.now = func() time.Time { return now }
+
+	value, err := a.headerValue()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(value, "Bearer "))
+
+	jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: pk.(*ecdsa.PrivateKey).Public(), KeyID: "test-key"}}}
+	kid, claims := parseToken(t, strings.TrimPrefix(value, "Bearer "), jwks)
+	assert.Equal(t, "test-key", kid)
+	assert.Equal(t, "collector", claims["iss"])
+	assert.Equal(t, "otel", claims["sub"])
+	assert.Equal(t, []interface{}{"ingest"}, claims["aud"])
+	assert.Equal(t, "acme", claims["tenant"])
+	assert.EqualValues(t, 1665000000, claims["iat"])
+	assert.EqualValues(t, 1665000600, claims["exp"])
+	assert.NotEmpty(t, claims["jti"])
+
+	// the token is reused until half of its TTL
+	now = now.Add(4 * time.Minute)
+	reused, err := a.headerValue()
+	require.NoError(t, err)
+	assert.Equal(t, value, reused)
+
+	now = now.Add(time.Minute)
+	renewed, err := a.headerValue()
+	require.NoError(t, err)
+	assert.NotEqual(t, value, renewed)
+}
+
+func TestJWKSRotation(t *testing.T) {
+	jwks := loadJWKS(t, filepath.Join("testdata", "jwks.json"))
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, jwks.Keys[0], jwks.Keys[1])
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.JWKSFile = path
+	cfg.JWKSRefreshInterval = 10 * time.Millisecond
+	a, err := newJWTClientAuthenticator(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, a.Shutdown(context.Background())) }()
+
+	token, err := a.currentToken()
+	require.NoError(t, err)
+	kid, _ := parseToken(t, token, jwks)
+	assert.Equal(t, "key-1", kid)
+
+	// the new key is published first
+	writeJWKS(t, path, jwks.Keys[1], jwks.Keys[0])
+	require.Eventually(t, func() bool {
+		token, err = a.currentToken()
+		require.NoError(t, err)
+		kid, _ = parseToken(t, token, jwks)
+		return kid == "key-2"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// an invalid file keeps the current key
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0600))
+	time.Sleep(50 * time.Millisecond)
+	token, err = a.currentToken()
+	require.NoError(t, err)
+	kid, _ = parseToken(t, token, jwks)
+	assert.Equal(t, "key-2", kid)
+}
+
+func TestJWKSKeyID(t *testing.T) {
+	jwks := loadJWKS(t, filepath.Join("testdata", "jwks.json"))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.JWKSFile = filepath.Join("testdata", "jwks.json")
+	cfg.KeyID = "key-2"
+	a, err := newJWTClientAuthenticator(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, a.Shutdown(context.Background())) }()
+
+	token, err := a.currentToken()
+	require.NoError(t, err)
+	kid, _ := parseToken(t, token, jwks)
+	assert.Equal(t, "key-2", kid)
+
+	cfg.KeyID = "unknown"
+	a, err = newJWTClientAuthenticator(cfg, zap.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, a.Start(context.Background(), componenttest.NewNopHost()), `no private signing key with the kid "unknown" in the JWKS file`)
+}
+
+func TestJWKSPublicKeysOnly(t *testing.T) {
+	jwks := loadJWKS(t, filepath.Join("testdata", "jwks.json"))
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, jwks.Keys[0].Public())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.JWKSFile = path
+	a, err := newJWTClientAuthenticator(cfg, zap.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, a.Start(context.Background(), componenttest.NewNopHost()), "no private signing key in the JWKS file")
+}
+
+func TestRoundTripper(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.JWKSFile = filepath.Join("testdata", "jwks.json")
+	cfg.Header = "X-Auth-Token"
+	cfg.Scheme = ""
+	a, err := newJWTClientAuthenticator(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, a.Shutdown(context.Background())) }()
+
+	base := &mockRoundTripper{}
+	rt, err := a.RoundTripper(base)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.NoError(t, err)
+
+	token := base.header.Get("X-Auth-Token")
+	kid, _ := parseToken(t, token, loadJWKS(t, cfg.JWKSFile))
+	assert.Equal(t, "key-1", kid)
+	assert.Empty(t, req.Header.Get("X-Auth-Token"))
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.JWKSFile = filepath.Join("testdata", "jwks.json")
+	a, err := newJWTClientAuthenticator(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	creds, err := a.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+
+	// no key is loaded before the start
+	_, err = creds.GetRequestMetadata(context.Background())
+	assert.EqualError(t, err, "no signing key loaded")
+
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, a.Shutdown(context.Background())) }()
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, md, "authorization")
+	assert.True(t, strings.HasPrefix(md["authorization"], "Bearer "))
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jwtauthextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"gopkg.in/square/go-jose.v2"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "jwtauth"
+
+	defaultTTL                 = 5 * time.Minute
+	defaultJWKSRefreshInterval = time.Minute
+	defaultHeader              = "Authorization"
+	defaultScheme              = "Bearer"
+)
+
+// NewFactory creates a factory for the JWT minting client Authenticator extension.
+func NewFactory() component.ExtensionFactory {
+	return component.NewExtensionFactory(
+		typeStr,
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelAlpha,
+	)
+}
+
+func createDefaultConfig() config.Extension {
+	return &Config{
+		ExtensionSettings:   config.NewExtensionSettings(config.NewComponentID(typeStr)),
+		JWKSRefreshInterval: defaultJWKSRefreshInterval,
+		SigningMethod:       string(jose.RS256),
+		TTL:                 defaultTTL,
+		Header:              defaultHeader,
+		Scheme:              defaultScheme,
+	}
+}
+
+func createExtension(_ context.Context, set component.ExtensionCreateSettings, cfg config.Extension) (component.Extension, error) {
+	return newJWTClientAuthenticator(cfg.(*Config), set.Logger)
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtauthextension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	expected := &Config{
+		ExtensionSettings:   config.NewExtensionSettings(config.NewComponentID(typeStr)),
+		JWKSRefreshInterval: defaultJWKSRefreshInterval,
+		SigningMethod:       "RS256",
+		TTL:                 defaultTTL,
+		Header:              defaultHeader,
+		Scheme:              defaultScheme,
+	}
+
+	cfg := createDefaultConfig()
+
+	assert.Equal(t, expected, cfg)
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+}
+
+func TestCreateExtension(t *testing.T) {
+	privateKey, err := os.ReadFile(filepath.Join("testdata", "private_key.pem"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		privateKey  string
+		method      string
+		shouldError bool
+	}{
+		{
+			name:       "valid_settings",
+			privateKey: string(privateKey),
+			method:     "ES256",
+		},
+		{
+			name:        "invalid_private_key",
+			privateKey:  "invalidpk",
+			method:      "ES256",
+			shouldError: true,
+		},
+		{
+			name:        "mismatched_signing_method",
+			privateKey:  string(privateKey),
+			method:      "RS256",
+			shouldError: true,
+		},
+	}
+	for _, testcase := range tests {
+		t.Run(testcase.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.PrivateKey = testcase.privateKey
+			cfg.KeyID = "test-key"
+			cfg.SigningMethod = testcase.method
+
+			ext, err := createExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+			if testcase.shouldError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, ext)
+			}
+		})
+	}
+}
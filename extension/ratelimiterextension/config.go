@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiterextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/ratelimiterextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+// Config configures the request rate limits enforced when the receivers authenticate their requests.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Auth configures the server authenticator extension authenticating the requests before their
+	// rate is limited, the requests being only rate limited when not set.
+	Auth *configauth.Authentication `mapstructure:"auth,omitempty"`
+
+	// Global limits the rate of all the requests of the receivers using the extension.
+	Global *LimitSettings `mapstructure:"global,omitempty"`
+
+	// PerIdentity limits the rate of the requests of each identity.
+	PerIdentity *IdentityLimitSettings `mapstructure:"per_identity,omitempty"`
+}
+
+// LimitSettings defines a token bucket limiting the rate of the requests.
+type LimitSettings struct {
+	// RequestsPerSecond is the rate at which the requests are allowed, the number of requests not being
+	// limited when not set.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second,omitempty"`
+
+	// Burst is the number of requests allowed at once, defaulting to the requests per second, and at least 1.
+	Burst int `mapstructure:"burst,omitempty"`
+
+	// ItemsPerSecond is the rate at which the items of the requests, such as spans, data points or log
+	// records, are allowed, the number of items not being limited when not set. Only the receivers using
+	// the extension as their rate limiter count the items of the requests.
+	ItemsPerSecond float64 `mapstructure:"items_per_second,omitempty"`
+
+	// ItemsBurst is the number of items allowed at once, defaulting to the items per second, and at least 1.
+	ItemsBurst int `mapstructure:"items_burst,omitempty"`
+}
+
+// IdentityLimitSettings defines how the identity of the requests is found, and the limit of each identity.
+type IdentityLimitSettings struct {
+	LimitSettings `mapstructure:",squash"`
+
+	// Header is the request header, or gRPC metadata key, holding the identity of the request.
+	Header string `mapstructure:"header,omitempty"`
+
+	// AuthAttribute is the attribute of the authentication data set by the authenticator holding
+	// the identity of the request, such as the username or the subject of a token.
+	AuthAttribute string `mapstructure:"auth_attribute,omitempty"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+var (
+	errNoLimit           = errors.New("at least one of 'global' or 'per_identity' must be specified")
+	errNoIdentity        = errors.New("one of 'header' or 'auth_attribute' must be specified in 'per_identity'")
+	errTooManyIdentities = errors.New("only one of 'header' or 'auth_attribute' can be specified in 'per_identity'")
+	errNoAuthenticator   = errors.New("'auth_attribute' requires 'auth' to be specified")
+)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Global == nil && cfg.PerIdentity == nil {
+		return errNoLimit
+	}
+	if cfg.Global != nil {
+		if err := cfg.Global.validate(); err != nil {
+			return fmt.Errorf("'global': %w", err)
+		}
+	}
+	if cfg.PerIdentity != nil {
+		if err := cfg.PerIdentity.validate(); err != nil {
+			return fmt.Errorf("'per_identity': %w", err)
+		}
+		switch {
+		case cfg.PerIdentity.Header == "" && cfg.PerIdentity.AuthAttribute == "":
+			return errNoIdentity
+		case cfg.PerIdentity.Header != "" && cfg.PerIdentity.AuthAttribute != "":
+			return errTooManyIdentities
+		case cfg.PerIdentity.AuthAttribute != "" && cfg.Auth == nil:
+			return errNoAuthenticator
+		}
+	}
+	return nil
+}
+
+func (s *LimitSettings) validate() error {
+	if s.RequestsPerSecond < 0 {
+		return errors.New("'requests_per_second' must not be negative")
+	}
+	if s.ItemsPerSecond < 0 {
+		return errors.New("'items_per_second' must not be negative")
+	}
+	if s.RequestsPerSecond == 0 && s.ItemsPerSecond == 0 {
+		return errors.New("at least one of 'requests_per_second' or 'items_per_second' must be specified")
+	}
+	if s.Burst < 0 {
+		return errors.New("'burst' must not be negative")
+	}
+	if s.ItemsBurst < 0 {
+		return errors.New("'items_burst' must not be negative")
+	}
+	return nil
+}
+
+func (s *LimitSettings) burst() int {
+	return burst(s.Burst, s.RequestsPerSecond)
+}
+
+func (s *LimitSettings) itemsBurst() int {
+	return burst(s.ItemsBurst, s.ItemsPerSecond)
+}
+
+func burst(burst int, perSecond float64) int {
+	if burst > 0 {
+		return burst
+	}
+	if perSecond < 1 {
+		return 1
+	}
+	return int(perSecond)
+}
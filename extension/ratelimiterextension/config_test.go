@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiterextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Extension
+		expectedErr string
+	}{
+		{
+			id:          config.NewComponentID(typeStr),
+			expectedErr: errNoLimit.Error(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "global"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				Global:            &LimitSettings{RequestsPerSecond: 100},
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "tenants"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				Auth:              &configauth.Authentication{AuthenticatorID: config.NewComponentID("basicauth")},
+				Global:            &LimitSettings{RequestsPerSecond: 1000, Burst: 2000},
+				PerIdentity: &IdentityLimitSettings{
+					LimitSettings: LimitSettings{RequestsPerSecond: 10, Burst: 20},
+					AuthAttribute: "username",
+				},
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "header"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				PerIdentity: &IdentityLimitSettings{
+					LimitSettings: LimitSettings{RequestsPerSecond: 0.5},
+					Header:        "X-Scope-OrgID",
+				},
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "items"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+				Global:            &LimitSettings{ItemsPerSecond: 10000},
+				PerIdentity: &IdentityLimitSettings{
+					LimitSettings: LimitSettings{ItemsPerSecond: 1000, ItemsBurst: 5000},
+					Header:        "X-Scope-OrgID",
+				},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "no_identity"),
+			expectedErr: errNoIdentity.Error(),
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "both_identities"),
+			expectedErr: errTooManyIdentities.Error(),
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "auth_attribute_without_auth"),
+			expectedErr: errNoAuthenticator.Error(),
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalid_rate"),
+			expectedErr: "'global': at least one of 'requests_per_second' or 'items_per_second' must be specified",
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalid_burst"),
+			expectedErr: "'per_identity': 'burst' must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalExtension(sub, cfg))
+			if tt.expectedErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestBurst(t *testing.T) {
+	assert.Equal(t, 5, (&LimitSettings{RequestsPerSecond: 10, Burst: 5}).burst())
+	assert.Equal(t, 10, (&LimitSettings{RequestsPerSecond: 10.5}).burst())
+	assert.Equal(t, 1, (&LimitSettings{RequestsPerSecond: 0.5}).burst())
+	assert.Equal(t, 100, (&LimitSettings{ItemsPerSecond: 100}).itemsBurst())
+	assert.Equal(t, 50, (&LimitSettings{ItemsPerSecond: 100, ItemsBurst: 50}).itemsBurst())
+}
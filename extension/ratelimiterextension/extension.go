@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiterextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/ratelimiterextension"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// sweepInterval is how often the limiters of the identities without recent requests are removed.
+const sweepInterval = time.Minute
+
+// Limiter is implemented by the extension, for the receivers to limit the rate of the requests they
+// accept and of their items, such as spans, data points or log records.
+type Limiter interface {
+	component.Extension
+
+	// Limit returns an error when the request holding the given number of items exceeds the global
+	// limit or the limit of its identity, the identity being read from the headers or from the
+	// authentication data of ctx. The error is a RESOURCE_EXHAUSTED gRPC status holding a RetryInfo,
+	// telling when the request can be retried, the HTTP receivers replying with WriteHTTPRejection.
+	Limit(ctx context.Context, headers map[string][]string, items int) error
+}
+
+// GetLimiter returns the rate limiter extension with the given ID.
+func GetLimiter(extensions map[config.ComponentID]component.Extension, id config.ComponentID) (Limiter, error) {
+	ext, ok := extensions[id]
+	if !ok {
+		return nil, fmt.Errorf("rate limiter %q not found", id)
+	}
+	limiter, ok := ext.(Limiter)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a rate limiter", id)
+	}
+	return limiter, nil
+}
+
+// RetryAfter returns the delay after which the request rejected by a Limiter with err can be
+// retried, and false when err isn't such a rejection.
+func RetryAfter(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return 0, false
+	}
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			return retryInfo.RetryDelay.AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// WriteHTTPRejection replies to the HTTP request rejected by a Limiter with err with a 429 Too Many
+// Requests status, and a Retry-After header holding the delay after which it can be retried.
+func WriteHTTPRejection(w http.ResponseWriter, err error) {
+	SetRetryAfter(w.Header(), err)
+	msg := "rate limit exceeded"
+	if st, ok := status.FromError(err); ok {
+		msg = st.Message()
+	}
+	http.Error(w, msg, http.StatusTooManyRequests)
+}
+
+// SetRetryAfter sets the Retry-After header of the response to the HTTP request rejected by a Limiter
+// with err, for the receivers replying with their own response body.
+func SetRetryAfter(header http.Header, err error) {
+	if delay, ok := RetryAfter(err); ok {
+		// the delay is rounded up for the request not to be retried too early
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+	}
+}
+
+// limiters limit the rate of the requests and of their items, each of them being nil when not limited.
+type limiters struct {
+	requests *rate.Limiter
+	items    *rate.Limiter
+}
+
+func newLimiters(s *LimitSettings) limiters {
+	var l limiters
+	if s.RequestsPerSecond > 0 {
+		l.requests = rate.NewLimiter(rate.Limit(s.RequestsPerSecond), s.burst())
+	}
+	if s.ItemsPerSecond > 0 {
+		l.items = rate.NewLimiter(rate.Limit(s.ItemsPerSecond), s.itemsBurst())
+	}
+	return l
+}
+
+// identityLimiter limits the rate of the requests of an identity.
+type identityLimiter struct {
+	limiters
+	lastSeen time.Time
+}
+
+type rateLimiter struct {
+	configauth.ServerAuthenticator
+
+	cfg    *Config
+	logger *zap.Logger
+
+	authenticator configauth.ServerAuthenticator
+	global        limiters
+
+	mu         sync.Mutex
+	identities map[string]*identityLimiter
+	lastSweep  time.Time
+	// idleTimeout is the time after which the buckets of an identity without requests are full again,
+	// its limiters being then equivalent to new ones.
+	idleTimeout time.Duration
+}
+
+var _ Limiter = (*rateLimiter)(nil)
+
+func newRateLimiter(cfg *Config, logger *zap.Logger) *rateLimiter {
+	rl := &rateLimiter{
+		cfg:        cfg,
+		logger:     logger,
+		identities: map[string]*identityLimiter{},
+		lastSweep:  time.Now(),
+	}
+	if cfg.Global != nil {
+		rl.global = newLimiters(cfg.Global)
+	}
+	if cfg.PerIdentity != nil {
+		if cfg.PerIdentity.RequestsPerSecond > 0 {
+			rl.idleTimeout = refillTime(cfg.PerIdentity.burst(), cfg.PerIdentity.RequestsPerSecond)
+		}
+		if cfg.PerIdentity.ItemsPerSecond > 0 {
+			if d := refillTime(cfg.PerIdentity.itemsBurst(), cfg.PerIdentity.ItemsPerSecond); d > rl.idleTimeout {
+				rl.idleTimeout = d
+			}
+		}
+	}
+
+	rl.ServerAuthenticator = configauth.NewServerAuthenticator(
+		configauth.WithStart(rl.start),
+		configauth.WithAuthenticate(rl.authenticate),
+	)
+	return rl
+}
+
+// refillTime returns the time it takes for an empty bucket to be full again.
+func refillTime(burst int, perSecond float64) time.Duration {
+	return time.Duration(float64(burst) / perSecond * float64(time.Second))
+}
+
+func (rl *rateLimiter) start(_ context.Context, host component.Host) error {
+	if rl.cfg.Auth == nil {
+		return nil
+	}
+	authenticator, err := rl.cfg.Auth.GetServerAuthenticator(host.GetExtensions())
+	if err != nil {
+		return err
+	}
+	rl.authenticator = authenticator
+	return nil
+}
+
+// authenticate authenticates the request with the configured authenticator, and rejects it when it
+// exceeds the global limit or the limit of its identity. The items of the request being unknown
+// when it's authenticated, only its number of requests is limited.
+func (rl *rateLimiter) authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	if rl.authenticator != nil {
+		var err error
+		if ctx, err = rl.authenticator.Authenticate(ctx, headers); err != nil {
+			return ctx, err
+		}
+	}
+	return ctx, rl.Limit(ctx, headers, 0)
+}
+
+func (rl *rateLimiter) Limit(ctx context.Context, headers map[string][]string, items int) error {
+	now := time.Now()
+	var rs reservations
+	if delay := rs.reserve(rl.global, now, items); delay > 0 {
+		rs.cancel(now)
+		rl.logger.Debug("Request rejected by the global rate limit", zap.Duration("retry_after", delay))
+		return rejection(delay)
+	}
+
+	if rl.cfg.PerIdentity != nil {
+		identity := rl.identity(ctx, headers)
+		if identity == "" {
+			return nil
+		}
+		if delay := rs.reserve(rl.identityLimiters(identity, now), now, items); delay > 0 {
+			// the request is rejected, so it must not count against the global limit either
+			rs.cancel(now)
+			rl.logger.Debug("Request rejected by the rate limit of its identity", zap.String("identity", identity), zap.Duration("retry_after", delay))
+			return rejection(delay)
+		}
+	}
+	return nil
+}
+
+// identity returns the identity of the request, empty when the request doesn't have any.
+func (rl *rateLimiter) identity(ctx context.Context, headers map[string][]string) string {
+	if rl.cfg.PerIdentity.Header != "" {
+		for k, v := range headers {
+			if strings.EqualFold(k, rl.cfg.PerIdentity.Header) && len(v) > 0 {
+				return v[0]
+			}
+		}
+		return ""
+	}
+
+	authData := client.FromContext(ctx).Auth
+	if authData == nil {
+		return ""
+	}
+	switch value := authData.GetAttribute(rl.cfg.PerIdentity.AuthAttribute).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// identityLimiters returns the limiters of the identity, removing the ones of the identities without
+// recent requests.
+func (rl *rateLimiter) identityLimiters(identity string, now time.Time) limiters {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if now.Sub(rl.lastSweep) >= sweepInterval {
+		for id, il := range rl.identities {
+			if now.Sub(il.lastSeen) >= rl.idleTimeout {
+				delete(rl.identities, id)
+			}
+		}
+		rl.lastSweep = now
+	}
+
+	il, ok := rl.identities[identity]
+	if !ok {
+		il = &identityLimiter{limiters: newLimiters(&rl.cfg.PerIdentity.LimitSettings)}
+		rl.identities[identity] = il
+	}
+	il.lastSeen = now
+	return il.limiters
+}
+
+// reservations are the tokens taken for a request, given back when the request is rejected.
+type reservations []*rate.Reservation
+
+// reserve takes a request and its items from the limiters, returning how long to wait for the
+// request to be allowed when one of their buckets is empty. A request holding more items than the
+// burst takes all the items of the bucket, and is only allowed when the bucket is full.
+func (rs *reservations) reserve(l limiters, now time.Time, items int) time.Duration {
+	var delay time.Duration
+	if l.requests != nil {
+		delay = rs.reserveN(l.requests, now, 1)
+	}
+	if l.items != nil && items > 0 {
+		if items > l.items.Burst() {
+			items = l.items.Burst()
+		}
+		if d := rs.reserveN(l.items, now, items); d > delay {
+			delay = d
+		}
+	}
+	return delay
+}
+
+func (rs *reservations) reserveN(l *rate.Limiter, now time.Time, n int) time.Duration {
+	r := l.ReserveN(now, n)
+	*rs = append(*rs, r)
+	return r.DelayFrom(now)
+}
+
+// cancel gives back the tokens taken by the reservations.
+func (rs reservations) cancel(now time.Time) {
+	for _, r := range rs {
+		r.CancelAt(now)
+	}
+}
+
+// rejection returns the error rejecting a request exceeding the limit, with the delay after which
+// it can be retried. The gRPC clients receive it as a RESOURCE_EXHAUSTED status with a RetryInfo.
+func rejection(delay time.Duration) error {
+	// the delay is rounded up for the request not to be retried too early
+	st := status.Newf(codes.ResourceExhausted, "rate limit exceeded, retry after %v", (delay + time.Millisecond - 1).Truncate(time.Millisecond))
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimiterextension
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// hourly is a rate low enough for the buckets not to be refilled during the tests.
+const hourly = 1.0 / 3600
+
+type authData struct {
+	username string
+}
+
+func (a authData) GetAttribute(name string) interface{} {
+	if name == "username" {
+		return a.username
+	}
+	return nil
+}
+
+func (a authData) GetAttributeNames() []string {
+	return []string{"username"}
+}
+
+type mockHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *mockHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+// usernameAuthenticator authenticates the requests whose username header is set.
+var usernameAuthenticator = configauth.NewServerAuthenticator(
+	configauth.WithAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+		if len(headers["username"]) == 0 {
+			return ctx, errors.New("no username")
+		}
+		cl := client.FromContext(ctx)
+		cl.Auth = authData{username: headers["username"][0]}
+		return client.NewContext(ctx, cl), nil
+	}),
+)
+
+func startRateLimiter(t *testing.T, cfg *Config) *rateLimiter {
+	rl := newRateLimiter(cfg, zap.NewNop())
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			config.NewComponentID("usernameauth"): usernameAuthenticator,
+		},
+	}
+	require.NoError(t, rl.Start(context.Background(), host))
+	t.Cleanup(func() { assert.NoError(t, rl.Shutdown(context.Background())) })
+	return rl
+}
+
+func assertRejected(t *testing.T, err error) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Greater(t, retryInfo.RetryDelay.AsDuration(), time.Duration(0))
+}
+
+func TestGlobalLimit(t *testing.T) {
+	rl := startRateLimiter(t, &Config{
+		Global: &LimitSettings{RequestsPerSecond: hourly, Burst: 2},
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := rl.Authenticate(context.Background(), nil)
+		require.NoError(t, err)
+	}
+	_, err := rl.Authenticate(context.Background(), nil)
+	assertRejected(t, err)
+}
+
+func TestPerIdentityHeaderLimit(t *testing.T) {
+	rl := startRateLimiter(t, &Config{
+		Global: &LimitSettings{RequestsPerSecond: hourly, Burst: 3},
+		PerIdentity: &IdentityLimitSettings{
+			LimitSettings: LimitSettings{RequestsPerSecond: hourly, Burst: 1},
+			Header:        "X-Scope-OrgID",
+		},
+	})
+
+	// the gRPC metadata keys are lower case
+	_, err := rl.Authenticate(context.Background(), map[string][]string{"x-scope-orgid": {"foo"}})
+	require.NoError(t, err)
+	_, err = rl.Authenticate(context.Background(), map[string][]string{"X-Scope-Orgid": {"foo"}})
+	assertRejected(t, err)
+
+	// the rejected request didn't count against the global limit
+	_, err = rl.Authenticate(context.Background(), map[string][]string{"X-Scope-Orgid": {"bar"}})
+	require.NoError(t, err)
+	// the requests without identity are only limited globally
+	_, err = rl.Authenticate(context.Background(), nil)
+	require.NoError(t, err)
+	_, err = rl.Authenticate(context.Background(), nil)
+	assertRejected(t, err)
+}
+
+func TestPerIdentityAuthAttributeLimit(t *testing.T) {
+	rl := startRateLimiter(t, &Config{
+		Auth: &configauth.Authentication{AuthenticatorID: config.NewComponentID("usernameauth")},
+		PerIdentity: &IdentityLimitSettings{
+			LimitSettings: LimitSettings{RequestsPerSecond: hourly, Burst: 1},
+			AuthAttribute: "username",
+		},
+	})
+
+	_, err := rl.Authenticate(context.Background(), nil)
+	assert.EqualError(t, err, "no username")
+
+	ctx, err := rl.Authenticate(context.Background(), map[string][]string{"username": {"foo"}})
+	require.NoError(t, err)
+	assert.Equal(t, authData{username: "foo"}, client.FromContext(ctx).Auth)
+
+	_, err = rl.Authenticate(context.Background(), map[string][]string{"username": {"foo"}})
+	assertRejected(t, err)
+	_, err = rl.Authenticate(context.Background(), map[string][]string{"username": {"bar"}})
+	require.NoError(t, err)
+}
+
+func TestUnknownAuthenticator(t *testing.T) {
+	rl := newRateLimiter(&Config{
+		Auth:   &configauth.Authentication{AuthenticatorID: config.NewComponentID("unknown")},
+		Global: &LimitSettings{RequestsPerSecond: 1},
+	}, zap.NewNop())
+	assert.Error(t, rl.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestSweepIdleIdentities(t *testing.T) {
+	rl := &rateLimiter{
+		cfg: &Config{PerIdentity: &IdentityLimitSettings{
+			LimitSettings: LimitSettings{RequestsPerSecond: 1, Burst: 10},
+			Header:        "X-Scope-OrgID",
+		}},
+		logger:      zap.NewNop(),
+		identities:  map[string]*identityLimiter{},
+		idleTimeout: 10 * time.Second,
+	}
+	now := time.Now()
+	rl.lastSweep = now
+
+	rl.identityLimiters("foo", now)
+	rl.identityLimiters("bar", now.Add(55*time.Second))
+	assert.Len(t, rl.identities, 2)
+
+	// foo is removed at the next sweep, its bucket being full again
+	rl.identityLimiters("bar", now.Add(time.Minute))
+	assert.Len(t, rl.identities, 1)
+	assert.Contains(t, rl.identities, "bar")
+}
+
+func TestItemsLimit(t *testing.T) {
+	rl := startRateLimiter(t, &Config{
+		Global: &LimitSettings{RequestsPerSecond: hourly, Burst: 10, ItemsPerSecond: hourly, ItemsBurst: 100},
+		PerIdentity: &IdentityLimitSettings{
+			LimitSettings: LimitSettings{ItemsPerSecond: hourly, ItemsBurst: 50},
+			Header:        "X-Scope-OrgID",
+		},
+	})
+	foo := map[string][]string{"X-Scope-OrgID": {"foo"}}
+
+	require.NoError(t, rl.Limit(context.Background(), foo, 30))
+	assertRejected(t, rl.Limit(context.Background(), foo, 30))
+	require.NoError(t, rl.Limit(context.Background(), foo, 20))
+	// the authenticated requests only count against the requests limit
+	_, err := rl.Authenticate(context.Background(), foo)
+	require.NoError(t, err)
+
+	// the rejected requests didn't count against the global limit, which has 50 items left
+	bar := map[string][]string{"X-Scope-OrgID": {"bar"}}
+	assertRejected(t, rl.Limit(context.Background(), bar, 60))
+	require.NoError(t, rl.Limit(context.Background(), bar, 50))
+}
+
+func TestItemsLimitAboveBurst(t *testing.T) {
+	rl := startRateLimiter(t, &Config{
+		Global: &LimitSettings{ItemsPerSecond: hourly, ItemsBurst: 10},
+	})
+
+	// a request holding more items than the burst is allowed when the bucket is full
+	require.NoError(t, rl.Limit(context.Background(), nil, 20))
+	assertRejected(t, rl.Limit(context.Background(), nil, 1))
+}
+
+func TestGetLimiter(t *testing.T) {
+	rl := newRateLimiter(&Config{Global: &LimitSettings{RequestsPerSecond: 1}}, zap.NewNop())
+	extensions := map[config.ComponentID]component.Extension{
+		config.NewComponentID("ratelimiter"):  rl,
+		config.NewComponentID("usernameauth"): usernameAuthenticator,
+	}
+
+	limiter, err := GetLimiter(extensions, config.NewComponentID("ratelimiter"))
+	require.NoError(t, err)
+	assert.Equal(t, rl, limiter)
+
+	_, err = GetLimiter(extensions, config.NewComponentID("usernameauth"))
+	assert.EqualError(t, err, `extension "usernameauth" is not a rate limiter`)
+	_, err = GetLimiter(extensions, config.NewComponentID("unknown"))
+	assert.EqualError(t, err, `rate limiter "unknown" not found`)
+}
+
+func TestWriteHTTPRejection(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteHTTPRejection(w, rejection(1500*time.Millisecond))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Equal(t, "rate limit exceeded, retry after 1.5s\n", w.Body.String())
+
+	delay, ok := RetryAfter(rejection(time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, delay)
+	_, ok = RetryAfter(errors.New("err"))
+	assert.False(t, ok)
+}
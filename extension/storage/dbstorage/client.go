@@ -19,7 +19,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	// MySQL driver
+	_ "github.com/go-sql-driver/mysql"
 	// Postgres driver
 	_ "github.com/jackc/pgx/v4/stdlib"
 	// SQLite driver
@@ -27,57 +30,125 @@ import (
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 )
 
-const (
-	createTable     = "create table if not exists %s (key text primary key, value blob)"
-	getQueryText    = "select value from %s where key=?"
-	setQueryText    = "insert into %s(key, value) values(?,?) on conflict(key) do update set value=?"
-	deleteQueryText = "delete from %s where key=?"
-)
-
 type dbStorageClient struct {
-	db          *sql.DB
-	getQuery    *sql.Stmt
-	setQuery    *sql.Stmt
-	deleteQuery *sql.Stmt
+	db                *sql.DB
+	getQuery          *sql.Stmt
+	getForUpdateQuery *sql.Stmt
+	setQuery          *sql.Stmt
+	deleteQuery       *sql.Stmt
 }
 
-func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageClient, error) {
-	var err error
-	_, err = db.ExecContext(ctx, fmt.Sprintf(createTable, tableName))
+func newClient(ctx context.Context, db *sql.DB, d dialect, tableName string, lockTimeout time.Duration) (*dbStorageClient, error) {
+	if err := migrate(ctx, db, d, tableName, lockTimeout); err != nil {
+		return nil, fmt.Errorf("failed to migrate table %s: %w", tableName, err)
+	}
+
+	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(d.getQuery, tableName))
 	if err != nil {
 		return nil, err
 	}
-
-	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(getQueryText, tableName))
+	getForUpdateQuery, err := db.PrepareContext(ctx, fmt.Sprintf(d.getForUpdateQuery, tableName))
 	if err != nil {
 		return nil, err
 	}
-	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(setQueryText, tableName))
+	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(d.setQuery, tableName))
 	if err != nil {
 		return nil, err
 	}
-	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(deleteQueryText, tableName))
+	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(d.deleteQuery, tableName))
 	if err != nil {
 		return nil, err
 	}
-	return &dbStorageClient{db, selectQuery, setQuery, deleteQuery}, nil
+	return &dbStorageClient{db, selectQuery, getForUpdateQuery, setQuery, deleteQuery}, nil
 }
 
-// Get will retrieve data from storage that corresponds to the specified key
-func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
-	rows, err := c.getQuery.QueryContext(ctx, key)
+// migrate brings the schema of the table to the latest version. The migration
+// runs under a lock shared by all the collectors connected to the database, so
+// that collectors starting at the same time don't migrate the table twice.
+func migrate(ctx context.Context, db *sql.DB, d dialect, tableName string, lockTimeout time.Duration) (err error) {
+	if lockTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, lockTimeout)
+		defer cancel()
+	}
+
+	// Locks are bound to a connection, all statements must use the same one.
+	conn, err := db.Conn(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if !rows.Next() {
-		return nil, nil
+	defer conn.Close()
+
+	if err = d.lock(ctx, conn, tableName); err != nil {
+		return err
 	}
-	var result []byte
-	err = rows.Scan(&result)
+	defer func() {
+		if uerr := d.unlock(context.Background(), conn, tableName); uerr != nil && err == nil {
+			err = uerr
+		}
+	}()
+
+	if _, err = conn.ExecContext(ctx, d.createVersionsTable); err != nil {
+		return err
+	}
+
+	var version int
+	err = conn.QueryRowContext(ctx, d.getVersionQuery, tableName).Scan(&version)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	for ; version < len(d.migrations); version++ {
+		if err = applyMigration(ctx, conn, d, tableName, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyMigration applies the migration at the given index and records the new
+// schema version in a single transaction.
+func applyMigration(ctx context.Context, conn *sql.Conn, d dialect, tableName string, index int) error {
+	tx, err := conn.BeginTx(ctx, nil)
 	if err != nil {
-		return result, err
+		return err
+	}
+	defer func() {
+		// No-op once committed.
+		_ = tx.Rollback()
+	}()
+
+	m := d.migrations[index]
+	apply := true
+	if m.column != "" {
+		var count int
+		if err = tx.QueryRowContext(ctx, d.columnExistsQuery, tableName, m.column).Scan(&count); err != nil {
+			return err
+		}
+		apply = count == 0
+	}
+	if apply {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(m.statement, tableName)); err != nil {
+			return err
+		}
+	}
+	if _, err = tx.ExecContext(ctx, d.setVersionQuery, tableName, index+1, index+1); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	return get(ctx, c.getQuery, key)
+}
+
+func get(ctx context.Context, stmt *sql.Stmt, key string) ([]byte, error) {
+	var result []byte
+	err := stmt.QueryRowContext(ctx, key).Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
 	}
-	err = rows.Close()
 	return result, err
 }
 
@@ -93,26 +164,37 @@ func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
 	return err
 }
 
-// Batch executes the specified operations in order. Get operation results are updated in place
+// Batch executes the specified operations in order, in a single transaction.
+// Get operation results are updated in place. The rows read are locked
+// until the transaction ends, so that collectors sharing the database don't
+// overwrite each other's updates.
 func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
-	var err error
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	getQuery := tx.StmtContext(ctx, c.getForUpdateQuery)
+	setQuery := tx.StmtContext(ctx, c.setQuery)
+	deleteQuery := tx.StmtContext(ctx, c.deleteQuery)
+
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
-			op.Value, err = c.Get(ctx, op.Key)
+			op.Value, err = get(ctx, getQuery, op.Key)
 		case storage.Set:
-			err = c.Set(ctx, op.Key, op.Value)
+			_, err = setQuery.ExecContext(ctx, op.Key, op.Value, op.Value)
 		case storage.Delete:
-			err = c.Delete(ctx, op.Key)
+			_, err = deleteQuery.ExecContext(ctx, op.Key)
 		default:
-			return errors.New("wrong operation type")
+			err = errors.New("wrong operation type")
 		}
 
 		if err != nil {
+			_ = tx.Rollback()
 			return err
 		}
 	}
-	return err
+	return tx.Commit()
 }
 
 // Close will close the database
@@ -123,6 +205,9 @@ func (c *dbStorageClient) Close(_ context.Context) error {
 	if err := c.deleteQuery.Close(); err != nil {
 		return err
 	}
+	if err := c.getForUpdateQuery.Close(); err != nil {
+		return err
+	}
 	if err := c.getQuery.Close(); err != nil {
 		return err
 	}
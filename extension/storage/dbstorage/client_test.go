@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open(driverSQLite, fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir()))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, db.Close()) })
+	return db
+}
+
+func TestClientMigratesExistingTable(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// Table created before schema versions were recorded.
+	_, err := db.Exec("create table receiver_nop_ (key text primary key, value blob)")
+	require.NoError(t, err)
+	_, err = db.Exec("insert into receiver_nop_(key, value) values('foo', 'bar')")
+	require.NoError(t, err)
+
+	client, err := newClient(ctx, db, sqliteDialect, "receiver_nop_", time.Second)
+	require.NoError(t, err)
+	defer client.Close(ctx)
+
+	value, err := client.Get(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bar"), value)
+
+	require.NoError(t, client.Set(ctx, "foo", []byte("baz")))
+	var updatedAt sql.NullTime
+	require.NoError(t, db.QueryRow("select updated_at from receiver_nop_ where key='foo'").Scan(&updatedAt))
+	assert.True(t, updatedAt.Valid)
+
+	var version int
+	require.NoError(t, db.QueryRow("select version from "+schemaVersionsTable+" where table_name='receiver_nop_'").Scan(&version))
+	assert.Equal(t, len(sqliteDialect.migrations), version)
+
+	// Migrations are not applied twice.
+	other, err := newClient(ctx, db, sqliteDialect, "receiver_nop_", time.Second)
+	require.NoError(t, err)
+	require.NoError(t, other.Close(ctx))
+}
+
+func TestClientMigrationSkipsExistingColumn(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// Table already migrated, but whose schema version was not recorded.
+	_, err := db.Exec("create table receiver_nop_ (key text primary key, value blob, updated_at timestamp)")
+	require.NoError(t, err)
+
+	client, err := newClient(ctx, db, sqliteDialect, "receiver_nop_", 0)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "foo", []byte("bar")))
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientBatch(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx, newTestDB(t), sqliteDialect, "receiver_nop_", time.Second)
+	require.NoError(t, err)
+	defer client.Close(ctx)
+
+	require.NoError(t, client.Set(ctx, "foo", []byte("bar")))
+
+	get := storage.GetOperation("foo")
+	missing := storage.GetOperation("missing")
+	err = client.Batch(ctx,
+		get,
+		missing,
+		storage.SetOperation("baz", []byte("qux")),
+		storage.DeleteOperation("foo"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bar"), get.Value)
+	assert.Nil(t, missing.Value)
+
+	value, err := client.Get(ctx, "baz")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("qux"), value)
+	value, err = client.Get(ctx, "foo")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestClientBatchRollback(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(ctx, newTestDB(t), sqliteDialect, "receiver_nop_", time.Second)
+	require.NoError(t, err)
+	defer client.Close(ctx)
+
+	invalid := storage.GetOperation("foo")
+	invalid.Type = storage.Delete + 1
+	err = client.Batch(ctx, storage.SetOperation("foo", []byte("bar")), invalid)
+	require.Error(t, err)
+
+	value, err := client.Get(ctx, "foo")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestDialectFor(t *testing.T) {
+	assert.Equal(t, postgresDialect.getForUpdateQuery, dialectFor(driverPostgres).getForUpdateQuery)
+	assert.Equal(t, mysqlDialect.getForUpdateQuery, dialectFor(driverMySQL).getForUpdateQuery)
+	assert.Equal(t, sqliteDialect.getForUpdateQuery, dialectFor(driverSQLite).getForUpdateQuery)
+	assert.Equal(t, sqliteDialect.getForUpdateQuery, dialectFor("custom").getForUpdateQuery)
+
+	for _, d := range []dialect{sqliteDialect, postgresDialect, mysqlDialect} {
+		assert.Len(t, d.migrations, len(sqliteDialect.migrations))
+	}
+}
+
+func TestMySQLLockName(t *testing.T) {
+	name := mysqlLockName("receiver_filelog_a_very_long_name_that_would_not_fit_in_a_mysql_lock_name")
+	assert.LessOrEqual(t, len(name), 64)
+	assert.Equal(t, name, mysqlLockName("receiver_filelog_a_very_long_name_that_would_not_fit_in_a_mysql_lock_name"))
+	assert.NotEqual(t, name, mysqlLockName("receiver_filelog_"))
+}
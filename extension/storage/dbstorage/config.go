@@ -15,7 +15,9 @@
 package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 )
@@ -25,6 +27,18 @@ type Config struct {
 	config.ExtensionSettings `mapstructure:",squash"`
 	DriverName               string `mapstructure:"driver,omitempty"`
 	DataSource               string `mapstructure:"datasource,omitempty"`
+
+	// MaxOpenConns is the maximum number of open connections to the database.
+	// The number of connections is unlimited when 0.
+	MaxOpenConns int `mapstructure:"max_open_conns,omitempty"`
+	// MaxIdleConns is the maximum number of idle connections kept in the pool.
+	MaxIdleConns int `mapstructure:"max_idle_conns,omitempty"`
+	// ConnMaxLifetime is the maximum time a connection may be reused.
+	// Connections are reused forever when 0.
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime,omitempty"`
+	// LockTimeout is the maximum time to wait for the lock taken while the
+	// schema of a table is created or migrated. There is no limit when 0.
+	LockTimeout time.Duration `mapstructure:"lock_timeout,omitempty"`
 }
 
 func (cfg *Config) Validate() error {
@@ -34,6 +48,18 @@ func (cfg *Config) Validate() error {
 	if cfg.DriverName == "" {
 		return fmt.Errorf(fmt.Sprintf("missing driver name for %s", cfg.ID()))
 	}
+	if cfg.MaxOpenConns < 0 {
+		return errors.New("max_open_conns must not be negative")
+	}
+	if cfg.MaxIdleConns < 0 {
+		return errors.New("max_idle_conns must not be negative")
+	}
+	if cfg.ConnMaxLifetime < 0 {
+		return errors.New("conn_max_lifetime must not be negative")
+	}
+	if cfg.LockTimeout < 0 {
+		return errors.New("lock_timeout must not be negative")
+	}
 
 	return nil
 }
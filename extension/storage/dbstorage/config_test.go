@@ -20,6 +20,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -45,6 +46,26 @@ func TestConfig_Validate(t *testing.T) {
 			Config{DriverName: "foo", DataSource: "bar"},
 			nil,
 		},
+		{
+			"Negative max open connections",
+			Config{DriverName: "foo", DataSource: "bar", MaxOpenConns: -1},
+			errors.New("max_open_conns must not be negative"),
+		},
+		{
+			"Negative max idle connections",
+			Config{DriverName: "foo", DataSource: "bar", MaxIdleConns: -1},
+			errors.New("max_idle_conns must not be negative"),
+		},
+		{
+			"Negative connection max lifetime",
+			Config{DriverName: "foo", DataSource: "bar", ConnMaxLifetime: -time.Second},
+			errors.New("conn_max_lifetime must not be negative"),
+		},
+		{
+			"Negative lock timeout",
+			Config{DriverName: "foo", DataSource: "bar", LockTimeout: -time.Second},
+			errors.New("lock_timeout must not be negative"),
+		},
 	}
 
 	for _, test := range tests {
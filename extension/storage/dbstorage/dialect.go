@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+)
+
+const (
+	driverSQLite   = "sqlite3"
+	driverPostgres = "pgx"
+	driverMySQL    = "mysql"
+
+	// schemaVersionsTable records the schema version of every client table.
+	schemaVersionsTable = "dbstorage_schema_versions"
+)
+
+// migration changes the schema of a client table.
+type migration struct {
+	// statement is formatted with the client table name.
+	statement string
+	// column is the column added by the statement, if any. The migration is
+	// skipped when the table already has it.
+	column string
+}
+
+// dialect holds the SQL statements and locking primitives of a database.
+// Statements containing %s are formatted with the client table name.
+type dialect struct {
+	createVersionsTable string
+	getVersionQuery     string
+	setVersionQuery     string
+
+	// columnExistsQuery counts the columns of the given table with the given
+	// name.
+	columnExistsQuery string
+
+	// migrations are applied in order to every client table. The index of a
+	// migration plus one is the schema version it brings the table to. New
+	// migrations must only be appended.
+	migrations []migration
+
+	getQuery          string
+	getForUpdateQuery string
+	// setQuery takes the key and the value twice, once for the insert and
+	// once for the update of an existing row.
+	setQuery    string
+	deleteQuery string
+
+	// lock and unlock acquire and release a lock shared by all the collectors
+	// connected to the database, on the given connection. They are no-ops for
+	// embedded databases.
+	lock   func(ctx context.Context, conn *sql.Conn, name string) error
+	unlock func(ctx context.Context, conn *sql.Conn, name string) error
+}
+
+var sqliteDialect = dialect{
+	createVersionsTable: "create table if not exists " + schemaVersionsTable + " (table_name text primary key, version integer not null)",
+	getVersionQuery:     "select version from " + schemaVersionsTable + " where table_name=?",
+	setVersionQuery:     "insert into " + schemaVersionsTable + "(table_name, version) values(?,?) on conflict(table_name) do update set version=?",
+	columnExistsQuery:   "select count(*) from pragma_table_info(?) where name=?",
+	migrations: []migration{
+		{statement: "create table if not exists %s (key text primary key, value blob)"},
+		{statement: "alter table %s add column updated_at timestamp", column: "updated_at"},
+	},
+	getQuery: "select value from %s where key=?",
+	// SQLite locks the whole database for writing, rows don't need to be locked.
+	getForUpdateQuery: "select value from %s where key=?",
+	setQuery:          "insert into %s(key, value, updated_at) values(?,?,current_timestamp) on conflict(key) do update set value=?, updated_at=current_timestamp",
+	deleteQuery:       "delete from %s where key=?",
+	lock:              noopLock,
+	unlock:            noopLock,
+}
+
+var postgresDialect = dialect{
+	createVersionsTable: "create table if not exists " + schemaVersionsTable + " (table_name text primary key, version integer not null)",
+	getVersionQuery:     "select version from " + schemaVersionsTable + " where table_name=$1",
+	setVersionQuery:     "insert into " + schemaVersionsTable + "(table_name, version) values($1,$2) on conflict(table_name) do update set version=$3",
+	columnExistsQuery:   "select count(*) from information_schema.columns where table_schema=current_schema() and table_name=$1 and column_name=$2",
+	migrations: []migration{
+		{statement: "create table if not exists %s (key text primary key, value bytea)"},
+		{statement: "alter table %s add column updated_at timestamp", column: "updated_at"},
+	},
+	getQuery:          "select value from %s where key=$1",
+	getForUpdateQuery: "select value from %s where key=$1 for update",
+	setQuery:          "insert into %s(key, value, updated_at) values($1,$2,current_timestamp) on conflict(key) do update set value=$3, updated_at=current_timestamp",
+	deleteQuery:       "delete from %s where key=$1",
+	lock:              lockPostgres,
+	unlock:            unlockPostgres,
+}
+
+var mysqlDialect = dialect{
+	createVersionsTable: "create table if not exists " + schemaVersionsTable + " (table_name varchar(255) primary key, version integer not null)",
+	getVersionQuery:     "select version from " + schemaVersionsTable + " where table_name=?",
+	setVersionQuery:     "insert into " + schemaVersionsTable + "(table_name, version) values(?,?) on duplicate key update version=?",
+	columnExistsQuery:   "select count(*) from information_schema.columns where table_schema=database() and table_name=? and column_name=?",
+	migrations: []migration{
+		{statement: "create table if not exists %s (`key` varchar(255) primary key, value longblob)"},
+		{statement: "alter table %s add column updated_at timestamp null", column: "updated_at"},
+	},
+	getQuery:          "select value from %s where `key`=?",
+	getForUpdateQuery: "select value from %s where `key`=? for update",
+	setQuery:          "insert into %s(`key`, value, updated_at) values(?,?,current_timestamp) on duplicate key update value=?, updated_at=current_timestamp",
+	deleteQuery:       "delete from %s where `key`=?",
+	lock:              lockMySQL,
+	unlock:            unlockMySQL,
+}
+
+// dialectFor returns the dialect of the given driver. Drivers added by
+// implementors are assumed to accept the SQLite dialect.
+func dialectFor(driverName string) dialect {
+	switch driverName {
+	case driverPostgres:
+		return postgresDialect
+	case driverMySQL:
+		return mysqlDialect
+	default:
+		return sqliteDialect
+	}
+}
+
+func noopLock(context.Context, *sql.Conn, string) error {
+	return nil
+}
+
+// lockID derives the numeric identifier of an advisory lock from its name.
+func lockID(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+func lockPostgres(ctx context.Context, conn *sql.Conn, name string) error {
+	_, err := conn.ExecContext(ctx, "select pg_advisory_lock($1)", lockID(name))
+	return err
+}
+
+func unlockPostgres(ctx context.Context, conn *sql.Conn, name string) error {
+	_, err := conn.ExecContext(ctx, "select pg_advisory_unlock($1)", lockID(name))
+	return err
+}
+
+// mysqlLockName returns a lock name within the 64 characters accepted by MySQL.
+func mysqlLockName(name string) string {
+	return fmt.Sprintf("dbstorage_%016x", uint64(lockID(name)))
+}
+
+func lockMySQL(ctx context.Context, conn *sql.Conn, name string) error {
+	// A negative timeout waits forever, bound the wait by the context deadline.
+	timeout := -1
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = int(math.Ceil(time.Until(deadline).Seconds()))
+	}
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "select get_lock(?, ?)", mysqlLockName(name), timeout).Scan(&acquired); err != nil {
+		return err
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return fmt.Errorf("failed to acquire lock for %s", name)
+	}
+	return nil
+}
+
+func unlockMySQL(ctx context.Context, conn *sql.Conn, name string) error {
+	_, err := conn.ExecContext(ctx, "select release_lock(?)", mysqlLockName(name))
+	return err
+}
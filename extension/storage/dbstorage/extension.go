@@ -29,6 +29,8 @@ import (
 type databaseStorage struct {
 	driverName     string
 	datasourceName string
+	config         *Config
+	dialect        dialect
 	logger         *zap.Logger
 	db             *sql.DB
 }
@@ -40,6 +42,8 @@ func newDBStorage(logger *zap.Logger, config *Config) (component.Extension, erro
 	return &databaseStorage{
 		driverName:     config.DriverName,
 		datasourceName: config.DataSource,
+		config:         config,
+		dialect:        dialectFor(config.DriverName),
 		logger:         logger,
 	}, nil
 }
@@ -51,6 +55,10 @@ func (ds *databaseStorage) Start(context.Context, component.Host) error {
 		return err
 	}
 
+	db.SetMaxOpenConns(ds.config.MaxOpenConns)
+	db.SetMaxIdleConns(ds.config.MaxIdleConns)
+	db.SetConnMaxLifetime(ds.config.ConnMaxLifetime)
+
 	if err := db.Ping(); err != nil {
 		return err
 	}
@@ -72,7 +80,7 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.db, fullName)
+	return newClient(ctx, ds.db, ds.dialect, fullName, ds.config.LockTimeout)
 }
 
 func kindString(k component.Kind) string {
@@ -16,13 +16,19 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 )
 
 // The value of extension "type" in configuration.
-const typeStr config.Type = "db_storage"
+const (
+	typeStr config.Type = "db_storage"
+
+	defaultMaxIdleConns = 2
+	defaultLockTimeout  = 30 * time.Second
+)
 
 // NewFactory creates a factory for DBStorage extension.
 func NewFactory() component.ExtensionFactory {
@@ -37,6 +43,8 @@ func NewFactory() component.ExtensionFactory {
 func createDefaultConfig() config.Extension {
 	return &Config{
 		ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+		MaxIdleConns:      defaultMaxIdleConns,
+		LockTimeout:       defaultLockTimeout,
 	}
 }
 
@@ -46,6 +46,12 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+
+	// writeTimes holds the time each key was last set, reported by the diagnostics as the age of the
+	// queued items. It is nil unless the diagnostics are enabled.
+	writeTimes    map[string]time.Time
+	writeTimesMux sync.Mutex
+	openedAt      time.Time
 }
 
 func bboltOptions(timeout time.Duration) *bbolt.Options {
@@ -73,7 +79,7 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout, openedAt: time.Now()}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -141,7 +147,40 @@ func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation)
 
 	c.compactionMutex.RLock()
 	defer c.compactionMutex.RUnlock()
-	return c.db.Update(batch)
+	if err := c.db.Update(batch); err != nil {
+		return err
+	}
+
+	if c.writeTimes != nil {
+		c.recordWriteTimes(ops)
+	}
+	return nil
+}
+
+// recordWriteTimes keeps the time the keys of the set operations were written at
+func (c *fileStorageClient) recordWriteTimes(ops []storage.Operation) {
+	now := time.Now()
+	c.writeTimesMux.Lock()
+	defer c.writeTimesMux.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Set:
+			c.writeTimes[op.Key] = now
+		case storage.Delete:
+			delete(c.writeTimes, op.Key)
+		}
+	}
+}
+
+// writeTime returns the time the key was last set at. The keys set before the client
+// was opened are reported as written when it was opened.
+func (c *fileStorageClient) writeTime(key string) time.Time {
+	c.writeTimesMux.Lock()
+	defer c.writeTimesMux.Unlock()
+	if t, ok := c.writeTimes[key]; ok {
+		return t
+	}
+	return c.openedAt
 }
 
 // Close will close the database
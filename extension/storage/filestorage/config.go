@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 )
 
 // Config defines configuration for file storage extension.
@@ -32,6 +33,16 @@ type Config struct {
 	Timeout   time.Duration `mapstructure:"timeout,omitempty"`
 
 	Compaction *CompactionConfig `mapstructure:"compaction,omitempty"`
+
+	Diagnostics *DiagnosticsConfig `mapstructure:"diagnostics,omitempty"`
+}
+
+// DiagnosticsConfig defines configuration for the server reporting the state of the persistent queues
+// stored with the extension.
+type DiagnosticsConfig struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+	// TenantAttribute is the resource attribute by which the queued spans, data points and log records are counted
+	TenantAttribute string `mapstructure:"tenant_attribute,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -91,5 +102,9 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.Diagnostics != nil && cfg.Diagnostics.Endpoint == "" {
+		return errors.New("diagnostics endpoint must be set")
+	}
+
 	return nil
 }
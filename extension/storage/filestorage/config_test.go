@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 )
 
@@ -59,6 +60,18 @@ func TestLoadConfig(t *testing.T) {
 				Timeout: 2 * time.Second,
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "diagnostics"),
+			expected: func() config.Extension {
+				ret := NewFactory().CreateDefaultConfig()
+				ret.(*Config).Directory = "."
+				ret.(*Config).Diagnostics = &DiagnosticsConfig{
+					HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: "localhost:55680"},
+					TenantAttribute:    "tenant.id",
+				}
+				return ret
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -103,3 +116,12 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestHandleMissingDiagnosticsEndpointWithAnError(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Diagnostics = &DiagnosticsConfig{}
+
+	require.EqualError(t, cfg.Validate(), "diagnostics endpoint must be set")
+}
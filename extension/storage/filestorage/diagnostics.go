@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const queuezPath = "/debug/queuez"
+
+// The layout of the persistent queue of the exporterhelper, whose keys aren't exported by the collector.
+// The queue is made of the items from the read index, included, to the write index, excluded, and of the
+// items being dispatched, whose indexes are stored preceded by their number. The items are stored under
+// their index in decimal. TestQueuezExporterhelperLayout fails if the exporterhelper changes this layout.
+const (
+	queueReadIndexKey       = "ri"
+	queueWriteIndexKey      = "wi"
+	queueDispatchedItemsKey = "di"
+)
+
+// maxSampledItems is the maximum number of queued items copied by the diagnostics, from the front of the queue,
+// to be decoded once the database is released. Only the size of the other items is read.
+const maxSampledItems = 100
+
+var (
+	tracesUnmarshaler  = ptrace.NewProtoUnmarshaler()
+	metricsUnmarshaler = pmetric.NewProtoUnmarshaler()
+	logsUnmarshaler    = plog.NewProtoUnmarshaler()
+)
+
+// queueDiagnostics is the state of a persistent queue
+type queueDiagnostics struct {
+	Kind      string `json:"kind"`
+	Component string `json:"component"`
+	Signal    string `json:"signal"`
+	// Depth is the number of queued requests, including the ones being dispatched
+	Depth      uint64 `json:"depth"`
+	Dispatched int    `json:"dispatched"`
+	// SampledItems is the number of requests read from the front of the queue, at most maxSampledItems
+	SampledItems int `json:"sampled_items"`
+	// Bytes is the size of the queued requests
+	Bytes int `json:"bytes"`
+	// OldestItemAgeSeconds is the time since the oldest queued request was written
+	OldestItemAgeSeconds float64 `json:"oldest_item_age_seconds"`
+	// SampledTenants are the numbers of spans, data points or log records of the sampled requests by value
+	// of the tenant attribute
+	SampledTenants map[string]int `json:"sampled_tenants,omitempty"`
+}
+
+// queueSample is what is read from a persistent queue: its indexes, the size of its items and a copy of
+// the items at its front
+type queueSample struct {
+	depth      int
+	dispatched int
+	bytes      int
+	keys       []string
+	items      [][]byte
+}
+
+type queuezResponse struct {
+	Queues []*queueDiagnostics `json:"queues"`
+}
+
+// handleQueuez reports the state of the persistent queues stored by the clients of the extension
+func (lfs *localFileStorage) handleQueuez(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	lfs.clientsMux.Lock()
+	clients := make([]*diagnosedClient, 0, len(lfs.clients))
+	for _, c := range lfs.clients {
+		clients = append(clients, c)
+	}
+	lfs.clientsMux.Unlock()
+
+	now := time.Now()
+	resp := queuezResponse{Queues: make([]*queueDiagnostics, 0, len(clients))}
+	for _, c := range clients {
+		diag, err := c.client.queueDiagnostics(now, c.name, lfs.cfg.Diagnostics.TenantAttribute)
+		if err != nil {
+			lfs.logger.Error("failed to read persistent queue", zap.String("component", c.id.String()), zap.Error(err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if diag == nil {
+			continue
+		}
+		diag.Kind = kindString(c.kind)
+		diag.Component = c.id.String()
+		resp.Queues = append(resp.Queues, diag)
+	}
+	sort.Slice(resp.Queues, func(i, j int) bool {
+		a, b := resp.Queues[i], resp.Queues[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		return a.Signal < b.Signal
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		lfs.logger.Error("failed to write queuez response", zap.Error(err))
+	}
+}
+
+// queueDiagnostics reports the state of the persistent queue stored by the client,
+// or nil if the client doesn't store a persistent queue or is closed
+func (c *fileStorageClient) queueDiagnostics(now time.Time, signal string, tenantAttribute string) (*queueDiagnostics, error) {
+	sample, err := c.sampleQueue()
+	if sample == nil || err != nil {
+		return nil, err
+	}
+
+	diag := &queueDiagnostics{
+		Signal:       signal,
+		Depth:        uint64(sample.depth),
+		Dispatched:   sample.dispatched,
+		SampledItems: len(sample.items),
+		Bytes:        sample.bytes,
+	}
+	if tenantAttribute != "" {
+		diag.SampledTenants = make(map[string]int)
+	}
+
+	// the requests are decoded out of the transaction, which would otherwise hold the database meanwhile
+	oldest := now
+	for i, item := range sample.items {
+		if t := c.writeTime(sample.keys[i]); t.Before(oldest) {
+			oldest = t
+		}
+		if diag.SampledTenants != nil {
+			countTenants(diag.SampledTenants, signal, item, tenantAttribute)
+		}
+	}
+	diag.OldestItemAgeSeconds = now.Sub(oldest).Seconds()
+	return diag, nil
+}
+
+// sampleQueue reads the indexes of the persistent queue stored by the client and the size of its items,
+// and copies at most maxSampledItems items from its front, the dispatched ones first, or returns nil if
+// the client doesn't store a persistent queue or is closed
+func (c *fileStorageClient) sampleQueue() (*queueSample, error) {
+	c.compactionMutex.RLock()
+	defer c.compactionMutex.RUnlock()
+	if c.closed {
+		return nil, nil
+	}
+
+	var sample *queueSample
+	err := c.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		readIndex, readOK := bytesToItemIndex(bucket.Get([]byte(queueReadIndexKey)))
+		writeIndex, writeOK := bytesToItemIndex(bucket.Get([]byte(queueWriteIndexKey)))
+		if !readOK || !writeOK {
+			return nil
+		}
+		dispatched := bytesToItemIndexes(bucket.Get([]byte(queueDispatchedItemsKey)))
+
+		sample = &queueSample{dispatched: len(dispatched)}
+		if writeIndex > readIndex {
+			sample.depth = len(dispatched) + int(writeIndex-readIndex)
+		} else {
+			sample.depth = len(dispatched)
+		}
+
+		read := func(index uint64) {
+			key := strconv.FormatUint(index, 10)
+			value := bucket.Get([]byte(key))
+			if value == nil {
+				return
+			}
+			sample.bytes += len(value)
+			if len(sample.items) < maxSampledItems {
+				// the value is only valid during the transaction
+				sample.keys = append(sample.keys, key)
+				sample.items = append(sample.items, append([]byte(nil), value...))
+			}
+		}
+		for _, index := range dispatched {
+			read(index)
+		}
+		for index := readIndex; index < writeIndex; index++ {
+			read(index)
+		}
+		return nil
+	})
+	return sample, err
+}
+
+// bytesToItemIndex decodes an index of the persistent queue
+func bytesToItemIndex(b []byte) (uint64, bool) {
+	if len(b) != 8 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(b), true
+}
+
+// bytesToItemIndexes decodes the indexes of the dispatched items of the persistent queue,
+// which are preceded by their number
+func bytesToItemIndexes(b []byte) []uint64 {
+	if len(b) < 4 {
+		return nil
+	}
+	size := int(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	indexes := make([]uint64, 0, size)
+	for i := 0; i < size && len(b) >= 8; i++ {
+		indexes = append(indexes, binary.LittleEndian.Uint64(b))
+		b = b[8:]
+	}
+	return indexes
+}
+
+// countTenants adds the spans, data points or log records of a queued request to the counts
+// of the values of the tenant attribute of their resource. The request is skipped if it can't
+// be decoded.
+func countTenants(tenants map[string]int, signal string, value []byte, tenantAttribute string) {
+	switch config.DataType(signal) {
+	case config.TracesDataType:
+		td, err := tracesUnmarshaler.UnmarshalTraces(value)
+		if err != nil {
+			return
+		}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			rs := td.ResourceSpans().At(i)
+			tenant := tenantOf(rs.Resource(), tenantAttribute)
+			for j := 0; j < rs.ScopeSpans().Len(); j++ {
+				tenants[tenant] += rs.ScopeSpans().At(j).Spans().Len()
+			}
+		}
+	case config.MetricsDataType:
+		md, err := metricsUnmarshaler.UnmarshalMetrics(value)
+		if err != nil {
+			return
+		}
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			rm := md.ResourceMetrics().At(i)
+			tenant := tenantOf(rm.Resource(), tenantAttribute)
+			for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+				metrics := rm.ScopeMetrics().At(j).Metrics()
+				for k := 0; k < metrics.Len(); k++ {
+					tenants[tenant] += dataPointCount(metrics.At(k))
+				}
+			}
+		}
+	case config.LogsDataType:
+		ld, err := logsUnmarshaler.UnmarshalLogs(value)
+		if err != nil {
+			return
+		}
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			rl := ld.ResourceLogs().At(i)
+			tenant := tenantOf(rl.Resource(), tenantAttribute)
+			for j := 0; j < rl.ScopeLogs().Len(); j++ {
+				tenants[tenant] += rl.ScopeLogs().At(j).LogRecords().Len()
+			}
+		}
+	}
+}
+
+// tenantOf returns the value of the tenant attribute of the resource, or an empty string if it isn't set
+func tenantOf(resource pcommon.Resource, tenantAttribute string) string {
+	if v, ok := resource.Attributes().Get(tenantAttribute); ok {
+		return v.AsString()
+	}
+	return ""
+}
+
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestDiagnosticsExtension(t *testing.T, tenantAttribute string) *localFileStorage {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Diagnostics = &DiagnosticsConfig{
+		HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: "localhost:0"},
+		TenantAttribute:    tenantAttribute,
+	}
+
+	extension, err := f.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), cfg)
+	require.NoError(t, err)
+	return extension.(*localFileStorage)
+}
+
+func itemIndexBytes(index uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, index)
+	return b
+}
+
+func itemIndexesBytes(indexes ...uint64) []byte {
+	b := make([]byte, 4, 4+8*len(indexes))
+	binary.LittleEndian.PutUint32(b, uint32(len(indexes)))
+	for _, index := range indexes {
+		b = append(b, itemIndexBytes(index)...)
+	}
+	return b
+}
+
+func tracesBytes(t *testing.T, tenant string, spans int) []byte {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	if tenant != "" {
+		rs.Resource().Attributes().PutStr("tenant", tenant)
+	}
+	ss := rs.ScopeSpans().AppendEmpty()
+	for i := 0; i < spans; i++ {
+		ss.Spans().AppendEmpty()
+	}
+	b, err := ptrace.NewProtoMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+	return b
+}
+
+func getQueuez(t *testing.T, lfs *localFileStorage) queuezResponse {
+	rec := httptest.NewRecorder()
+	lfs.handleQueuez(rec, httptest.NewRequest(http.MethodGet, queuezPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var resp queuezResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestQueuez(t *testing.T) {
+	ctx := context.Background()
+	lfs := newTestDiagnosticsExtension(t, "tenant")
+
+	queueClient, err := lfs.GetClient(ctx, component.KindExporter, newTestEntity("queue"), "traces")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, queueClient.Close(ctx)) })
+
+	otherClient, err := lfs.GetClient(ctx, component.KindReceiver, newTestEntity("other"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, otherClient.Close(ctx)) })
+	require.NoError(t, otherClient.Set(ctx, "key", []byte("value")))
+
+	// The item 0 is being dispatched, and the items 1 and 2 are queued
+	item0 := tracesBytes(t, "a", 1)
+	item1 := tracesBytes(t, "b", 2)
+	item2 := tracesBytes(t, "", 3)
+	require.NoError(t, queueClient.Set(ctx, "0", item0))
+	require.NoError(t, queueClient.Set(ctx, "1", item1))
+	require.NoError(t, queueClient.Set(ctx, "2", item2))
+	require.NoError(t, queueClient.Set(ctx, queueReadIndexKey, itemIndexBytes(1)))
+	require.NoError(t, queueClient.Set(ctx, queueWriteIndexKey, itemIndexBytes(3)))
+	require.NoError(t, queueClient.Set(ctx, queueDispatchedItemsKey, itemIndexesBytes(0)))
+
+	resp := getQueuez(t, lfs)
+	require.Len(t, resp.Queues, 1)
+	queue := resp.Queues[0]
+	assert.Equal(t, "exporter", queue.Kind)
+	assert.Equal(t, "nop/queue", queue.Component)
+	assert.Equal(t, "traces", queue.Signal)
+	assert.Equal(t, uint64(3), queue.Depth)
+	assert.Equal(t, 1, queue.Dispatched)
+	assert.Equal(t, 3, queue.SampledItems)
+	assert.Equal(t, len(item0)+len(item1)+len(item2), queue.Bytes)
+	assert.Greater(t, queue.OldestItemAgeSeconds, 0.0)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "": 3}, queue.SampledTenants)
+
+	// The queue is drained
+	require.NoError(t, queueClient.Batch(ctx,
+		storage.DeleteOperation("0"), storage.DeleteOperation("1"), storage.DeleteOperation("2"),
+	))
+	require.NoError(t, queueClient.Set(ctx, queueReadIndexKey, itemIndexBytes(3)))
+	require.NoError(t, queueClient.Set(ctx, queueDispatchedItemsKey, itemIndexesBytes()))
+
+	resp = getQueuez(t, lfs)
+	require.Len(t, resp.Queues, 1)
+	queue = resp.Queues[0]
+	assert.Equal(t, uint64(0), queue.Depth)
+	assert.Equal(t, 0, queue.Dispatched)
+	assert.Equal(t, 0, queue.Bytes)
+	assert.Equal(t, 0.0, queue.OldestItemAgeSeconds)
+	assert.Empty(t, queue.SampledTenants)
+}
+
+func TestQueuezSampledItems(t *testing.T) {
+	ctx := context.Background()
+	lfs := newTestDiagnosticsExtension(t, "tenant")
+
+	client, err := lfs.GetClient(ctx, component.KindExporter, newTestEntity("queue"), "traces")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close(ctx)) })
+
+	// Only the items at the front of the queue are read
+	const depth = maxSampledItems + 50
+	ops := make([]storage.Operation, 0, depth+2)
+	for i := 0; i < depth; i++ {
+		ops = append(ops, storage.SetOperation(strconv.Itoa(i), tracesBytes(t, "a", 1)))
+	}
+	ops = append(ops,
+		storage.SetOperation(queueReadIndexKey, itemIndexBytes(0)),
+		storage.SetOperation(queueWriteIndexKey, itemIndexBytes(depth)),
+	)
+	require.NoError(t, client.Batch(ctx, ops...))
+
+	resp := getQueuez(t, lfs)
+	require.Len(t, resp.Queues, 1)
+	queue := resp.Queues[0]
+	assert.Equal(t, uint64(depth), queue.Depth)
+	assert.Equal(t, maxSampledItems, queue.SampledItems)
+	assert.Equal(t, depth*len(tracesBytes(t, "a", 1)), queue.Bytes)
+	assert.Equal(t, map[string]int{"a": maxSampledItems}, queue.SampledTenants)
+}
+
+// storageHost is a host with the file storage extension
+type storageHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *storageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+// TestQueuezExporterhelperLayout checks that the keys read by the diagnostics are the ones of the persistent
+// queue of the exporterhelper, which doesn't export them.
+func TestQueuezExporterhelperLayout(t *testing.T) {
+	ctx := context.Background()
+	lfs := newTestDiagnosticsExtension(t, "tenant")
+	storageID := config.NewComponentID(typeStr)
+	host := &storageHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{storageID: lfs},
+	}
+
+	// The first request is being dispatched until the end of the test, and the other ones stay queued
+	dispatching := make(chan struct{}, 1)
+	release := make(chan struct{})
+	queueSettings := exporterhelper.NewDefaultQueueSettings()
+	queueSettings.NumConsumers = 1
+	queueSettings.StorageID = &storageID
+	exporter, err := exporterhelper.NewTracesExporter(
+		ctx,
+		componenttest.NewNopExporterCreateSettings(),
+		&exporterSettings{ExporterSettings: config.NewExporterSettings(newTestEntity("queue"))},
+		func(context.Context, ptrace.Traces) error {
+			dispatching <- struct{}{}
+			<-release
+			return nil
+		},
+		exporterhelper.WithQueue(queueSettings),
+		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(ctx, host))
+	t.Cleanup(func() {
+		close(release)
+		require.NoError(t, exporter.Shutdown(ctx))
+	})
+
+	for _, tenant := range []string{"a", "b", "b"} {
+		td, err := ptrace.NewProtoUnmarshaler().UnmarshalTraces(tracesBytes(t, tenant, 1))
+		require.NoError(t, err)
+		require.NoError(t, exporter.ConsumeTraces(ctx, td))
+	}
+	<-dispatching
+
+	require.Eventually(t, func() bool {
+		resp := getQueuez(t, lfs)
+		return len(resp.Queues) == 1 && resp.Queues[0].Depth == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	queue := getQueuez(t, lfs).Queues[0]
+	assert.Equal(t, "exporter", queue.Kind)
+	assert.Equal(t, "nop/queue", queue.Component)
+	assert.Equal(t, "traces", queue.Signal)
+	// the queue may already have taken the next request out to dispatch it
+	assert.GreaterOrEqual(t, queue.Dispatched, 1)
+	assert.Equal(t, 3, queue.SampledItems)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, queue.SampledTenants)
+}
+
+// exporterSettings is the configuration of the exporter of TestQueuezExporterhelperLayout
+type exporterSettings struct {
+	config.ExporterSettings `mapstructure:",squash"`
+}
+
+func TestQueuezClosedClient(t *testing.T) {
+	ctx := context.Background()
+	lfs := newTestDiagnosticsExtension(t, "")
+
+	client, err := lfs.GetClient(ctx, component.KindExporter, newTestEntity("queue"), "logs")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, queueReadIndexKey, itemIndexBytes(0)))
+	require.NoError(t, client.Set(ctx, queueWriteIndexKey, itemIndexBytes(0)))
+	require.Len(t, getQueuez(t, lfs).Queues, 1)
+
+	require.NoError(t, client.Close(ctx))
+	require.Empty(t, getQueuez(t, lfs).Queues)
+}
+
+func TestQueuezMethodNotAllowed(t *testing.T) {
+	lfs := newTestDiagnosticsExtension(t, "")
+
+	rec := httptest.NewRecorder()
+	lfs.handleQueuez(rec, httptest.NewRequest(http.MethodPost, queuezPath, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestDiagnosticsServer(t *testing.T) {
+	lfs := newTestDiagnosticsExtension(t, "")
+
+	require.NoError(t, lfs.Start(context.Background(), componenttest.NewNopHost()))
+	require.NotNil(t, lfs.diagnosticsServer)
+	require.NoError(t, lfs.Shutdown(context.Background()))
+}
@@ -16,8 +16,13 @@ package filestorage // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -26,29 +31,78 @@ import (
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg      *Config
+	logger   *zap.Logger
+	settings component.TelemetrySettings
+
+	// clients are the clients reported by the diagnostics, by file name
+	clients    map[string]*diagnosedClient
+	clientsMux sync.Mutex
+
+	diagnosticsServer   *http.Server
+	diagnosticsServerWG sync.WaitGroup
+}
+
+// diagnosedClient is a client along with the component it was created for
+type diagnosedClient struct {
+	kind   component.Kind
+	id     config.ComponentID
+	name   string
+	client *fileStorageClient
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
-func newLocalFileStorage(logger *zap.Logger, config *Config) (component.Extension, error) {
+func newLocalFileStorage(settings component.TelemetrySettings, config *Config) (component.Extension, error) {
 	return &localFileStorage{
-		cfg:    config,
-		logger: logger,
+		cfg:      config,
+		logger:   settings.Logger,
+		settings: settings,
+		clients:  make(map[string]*diagnosedClient),
 	}, nil
 }
 
-// Start does nothing
-func (lfs *localFileStorage) Start(context.Context, component.Host) error {
+// Start starts the diagnostics server, if configured
+func (lfs *localFileStorage) Start(_ context.Context, host component.Host) error {
+	if lfs.cfg.Diagnostics == nil {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(queuezPath, lfs.handleQueuez)
+
+	var err error
+	lfs.diagnosticsServer, err = lfs.cfg.Diagnostics.ToServer(host, lfs.settings, mux)
+	if err != nil {
+		return err
+	}
+
+	var listener net.Listener
+	listener, err = lfs.cfg.Diagnostics.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", lfs.cfg.Diagnostics.Endpoint, err)
+	}
+	lfs.diagnosticsServerWG.Add(1)
+	go func() {
+		defer lfs.diagnosticsServerWG.Done()
+
+		if errHTTP := lfs.diagnosticsServer.Serve(listener); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
 	return nil
 }
 
-// Shutdown will close any open databases
+// Shutdown will stop the diagnostics server
 func (lfs *localFileStorage) Shutdown(context.Context) error {
 	// TODO clean up data files that did not have a client
 	// and are older than a threshold (possibly configurable)
+	if lfs.diagnosticsServer != nil {
+		err := lfs.diagnosticsServer.Close()
+		lfs.diagnosticsServerWG.Wait()
+		return err
+	}
 	return nil
 }
 
@@ -68,6 +122,13 @@ func (lfs *localFileStorage) GetClient(ctx context.Context, kind component.Kind,
 		return nil, err
 	}
 
+	if lfs.cfg.Diagnostics != nil {
+		client.writeTimes = make(map[string]time.Time)
+		lfs.clientsMux.Lock()
+		lfs.clients[rawName] = &diagnosedClient{kind: kind, id: ent, name: name, client: client}
+		lfs.clientsMux.Unlock()
+	}
+
 	// return if compaction is not required
 	if lfs.cfg.Compaction.OnStart {
 		compactionErr := client.Compact(lfs.cfg.Compaction.Directory, lfs.cfg.Timeout, lfs.cfg.Compaction.MaxTransactionSize)
@@ -16,17 +16,19 @@ package ecsutil // import "github.com/open-telemetry/opentelemetry-collector-con
 
 // TaskMetadata defines task metadata for a task
 type TaskMetadata struct {
-	AvailabilityZone string              `json:"AvailabilityZone,omitempty"`
-	Cluster          string              `json:"Cluster,omitempty"`
-	Containers       []ContainerMetadata `json:"Containers,omitempty"`
-	Family           string              `json:"Family,omitempty"`
-	KnownStatus      string              `json:"KnownStatus,omitempty"`
-	LaunchType       string              `json:"LaunchType,omitempty"`
-	Limits           Limits              `json:"Limits,omitempty"`
-	PullStartedAt    string              `json:"PullStartedAt,omitempty"`
-	PullStoppedAt    string              `json:"PullStoppedAt,omitempty"`
-	Revision         string              `json:"Revision,omitempty"`
-	TaskARN          string              `json:"TaskARN,omitempty"`
+	AvailabilityZone        string                   `json:"AvailabilityZone,omitempty"`
+	Cluster                 string                   `json:"Cluster,omitempty"`
+	Containers              []ContainerMetadata      `json:"Containers,omitempty"`
+	EBSVolumeStats          []EBSVolumeStats         `json:"EBSVolumeStats,omitempty"`
+	EphemeralStorageMetrics *EphemeralStorageMetrics `json:"EphemeralStorageMetrics,omitempty"`
+	Family                  string                   `json:"Family,omitempty"`
+	KnownStatus             string                   `json:"KnownStatus,omitempty"`
+	LaunchType              string                   `json:"LaunchType,omitempty"`
+	Limits                  Limits                   `json:"Limits,omitempty"`
+	PullStartedAt           string                   `json:"PullStartedAt,omitempty"`
+	PullStoppedAt           string                   `json:"PullStoppedAt,omitempty"`
+	Revision                string                   `json:"Revision,omitempty"`
+	TaskARN                 string                   `json:"TaskARN,omitempty"`
 }
 
 // ContainerMetadata defines container metadata for a container
@@ -56,6 +58,20 @@ type Limits struct {
 	Memory *uint64  `json:"Memory,omitempty"`
 }
 
+// EphemeralStorageMetrics defines the ephemeral storage usage of a Fargate task, in MiB
+type EphemeralStorageMetrics struct {
+	Utilized *uint64 `json:"Utilized,omitempty"`
+	Reserved *uint64 `json:"Reserved,omitempty"`
+}
+
+// EBSVolumeStats defines the filesystem usage of an EBS volume attached to a task, in bytes
+type EBSVolumeStats struct {
+	VolumeID   string  `json:"VolumeId,omitempty"`
+	VolumeName string  `json:"VolumeName,omitempty"`
+	Utilized   *uint64 `json:"Utilized,omitempty"`
+	Capacity   *uint64 `json:"Capacity,omitempty"`
+}
+
 // LogOptions defines the CloudWatch configuration
 type LogOptions struct {
 	LogGroup string `json:"awslogs-group,omitempty"`
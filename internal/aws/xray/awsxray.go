@@ -41,6 +41,9 @@ const (
 	// AWSXRayTracedAttribute is the `traced` field in an X-Ray subsegment
 	AWSXRayTracedAttribute = "aws.xray.traced"
 
+	// AWSXRayPrecursorAttribute is set on the span links translated from the `precursor_ids` of an X-Ray subsegment
+	AWSXRayPrecursorAttribute = "aws.xray.precursor"
+
 	// AWSXraySegmentMetadataAttributePrefix is the prefix of the attribute that
 	// will be treated by the X-Ray exporter as metadata. The key of a metadata
 	// will be AWSXraySegmentMetadataAttributePrefix + <metadata_key>.
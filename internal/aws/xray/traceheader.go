@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsxray // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TraceHeader is the trace context propagated by X-Ray, such as in the X-Amzn-Trace-Id
+// HTTP header, the AWSTraceHeader attribute of SQS messages, or the trace header of
+// Step Functions executions, for example
+// "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1".
+type TraceHeader struct {
+	// TraceID is the ID of the trace, from the Root field.
+	TraceID string
+	// ParentID is the ID of the parent segment, from the Parent field, if any.
+	ParentID string
+	// Sampled is the sampling decision, from the Sampled field, if any.
+	Sampled *bool
+}
+
+// IsTraceHeader returns whether the value is a trace header, rather than a trace ID.
+func IsTraceHeader(v string) bool {
+	return strings.Contains(v, "=")
+}
+
+// ParseTraceHeader parses a trace header. The fields other than Root, Parent and Sampled,
+// such as the Lineage field set by Lambda or the Self field set by load balancers, are ignored.
+func ParseTraceHeader(header string) (TraceHeader, error) {
+	var th TraceHeader
+	for _, field := range strings.Split(header, ";") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return TraceHeader{}, fmt.Errorf("invalid trace header field %q", field)
+		}
+		switch strings.TrimSpace(key) {
+		case "Root":
+			th.TraceID = strings.TrimSpace(value)
+		case "Parent":
+			th.ParentID = strings.TrimSpace(value)
+		case "Sampled":
+			switch strings.TrimSpace(value) {
+			case "1":
+				sampled := true
+				th.Sampled = &sampled
+			case "0":
+				sampled := false
+				th.Sampled = &sampled
+			}
+		}
+	}
+	if th.TraceID == "" {
+		return TraceHeader{}, errors.New("trace header has no Root field")
+	}
+	return th, nil
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsxray
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTraceHeader(t *testing.T) {
+	sampled, notSampled := true, false
+	tests := []struct {
+		name    string
+		header  string
+		want    TraceHeader
+		wantErr string
+	}{
+		{
+			name:   "sqs",
+			header: "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
+			want:   TraceHeader{TraceID: "1-5759e988-bd862e3fe1be46a994272793", ParentID: "53995c3f42cd8ad8", Sampled: &sampled},
+		},
+		{
+			name:   "step functions with lineage",
+			header: "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=0;Lineage=a87bd80c:1",
+			want:   TraceHeader{TraceID: "1-5759e988-bd862e3fe1be46a994272793", ParentID: "53995c3f42cd8ad8", Sampled: &notSampled},
+		},
+		{
+			name:   "root only with spaces and deferred sampling",
+			header: " Root=1-5759e988-bd862e3fe1be46a994272793; Sampled=?;",
+			want:   TraceHeader{TraceID: "1-5759e988-bd862e3fe1be46a994272793"},
+		},
+		{
+			name:    "missing root",
+			header:  "Parent=53995c3f42cd8ad8;Sampled=1",
+			wantErr: "trace header has no Root field",
+		},
+		{
+			name:    "invalid field",
+			header:  "Root=1-5759e988-bd862e3fe1be46a994272793;Parent",
+			wantErr: `invalid trace header field "Parent"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTraceHeader(tt.header)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsTraceHeader(t *testing.T) {
+	assert.True(t, IsTraceHeader("Root=1-5759e988-bd862e3fe1be46a994272793"))
+	assert.False(t, IsTraceHeader("1-5759e988-bd862e3fe1be46a994272793"))
+}
@@ -63,6 +63,9 @@ type Segment struct {
 	Annotations map[string]interface{}            `json:"annotations,omitempty"`
 	Metadata    map[string]map[string]interface{} `json:"metadata,omitempty"`
 	Subsegments []Segment                         `json:"subsegments,omitempty"`
+	// Links are the spans related to the segment, such as the producers of the SQS
+	// messages processed by the segment.
+	Links []SpanLinkData `json:"links,omitempty"`
 
 	// (for both embedded and independent) subsegment-only (optional) fields.
 	// Please refer to https://docs.aws.amazon.com/xray/latest/devguide/xray-api-segmentdocuments.html#api-segmentdocuments-subsegments
@@ -280,3 +283,13 @@ type ServiceData struct {
 	CompilerVersion *string `json:"compiler_version,omitempty"`
 	Compiler        *string `json:"compiler,omitempty"`
 }
+
+// SpanLinkData provides the shape for unmarshalling the links of a segment.
+type SpanLinkData struct {
+	// TraceID is either the trace ID of the linked span, or the trace header
+	// propagated to the segment, such as the AWSTraceHeader attribute of an
+	// SQS message, which also holds the ID of the linked span.
+	TraceID    *string                `json:"trace_id"`
+	SpanID     *string                `json:"id,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package throttle extracts the throttling hints sent by HTTP backends, so that
+// the exporters can turn them into throttle errors delaying the next retry.
+package throttle // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/throttle"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderRetryAfter     = "Retry-After"
+	HeaderRateLimitReset = "RateLimit-Reset"
+)
+
+// now is overridden in tests.
+var now = time.Now
+
+// RetryAfter returns how long the server asked to wait before retrying. The
+// delay is read from the Retry-After header, holding either a number of seconds
+// or an HTTP date, falling back to the RateLimit-Reset header, holding a number
+// of seconds, sent by the rate limiting proxies. It returns 0 when the response
+// has no valid hint, in which case the default backoff policy applies.
+func RetryAfter(header http.Header) time.Duration {
+	if delay, ok := parseRetryAfter(header.Get(HeaderRetryAfter)); ok {
+		return delay
+	}
+	if delay, ok := parseSeconds(header.Get(HeaderRateLimitReset)); ok {
+		return delay
+	}
+	return 0
+}
+
+func parseRetryAfter(val string) (time.Duration, bool) {
+	if delay, ok := parseSeconds(val); ok {
+		return delay, true
+	}
+	date, err := http.ParseTime(val)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now()); delay > 0 {
+		return delay.Round(time.Second), true
+	}
+	return 0, true
+}
+
+func parseSeconds(val string) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
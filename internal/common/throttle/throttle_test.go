@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package throttle
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter(t *testing.T) {
+	date := time.Date(2022, 10, 5, 10, 0, 0, 0, time.UTC)
+	now = func() time.Time { return date }
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   time.Duration
+	}{
+		{
+			name:   "no_header",
+			header: map[string]string{},
+			want:   0,
+		},
+		{
+			name:   "retry_after_seconds",
+			header: map[string]string{HeaderRetryAfter: "120"},
+			want:   2 * time.Minute,
+		},
+		{
+			name:   "retry_after_date",
+			header: map[string]string{HeaderRetryAfter: date.Add(30 * time.Second).Format(http.TimeFormat)},
+			want:   30 * time.Second,
+		},
+		{
+			name:   "retry_after_past_date",
+			header: map[string]string{HeaderRetryAfter: date.Add(-time.Minute).Format(http.TimeFormat)},
+			want:   0,
+		},
+		{
+			name:   "retry_after_invalid",
+			header: map[string]string{HeaderRetryAfter: "soon"},
+			want:   0,
+		},
+		{
+			name:   "retry_after_negative",
+			header: map[string]string{HeaderRetryAfter: "-1", HeaderRateLimitReset: "5"},
+			want:   5 * time.Second,
+		},
+		{
+			name:   "rate_limit_reset",
+			header: map[string]string{HeaderRateLimitReset: "10"},
+			want:   10 * time.Second,
+		},
+		{
+			name:   "retry_after_preferred",
+			header: map[string]string{HeaderRetryAfter: "3", HeaderRateLimitReset: "10"},
+			want:   3 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.header {
+				header.Set(k, v)
+			}
+			assert.Equal(t, tt.want, RetryAfter(header))
+		})
+	}
+}
@@ -40,10 +40,12 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jwtauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ratelimiterextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
@@ -78,6 +80,14 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "ratelimiter",
+			getConfigFn: func() config.Extension {
+				cfg := extFactories["ratelimiter"].CreateDefaultConfig().(*ratelimiterextension.Config)
+				cfg.Global = &ratelimiterextension.LimitSettings{RequestsPerSecond: 100}
+				return cfg
+			},
+		},
 		{
 			extension: "sigv4auth",
 			getConfigFn: func() config.Extension {
@@ -170,6 +180,14 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "jwtauth",
+			getConfigFn: func() config.Extension {
+				cfg := extFactories["jwtauth"].CreateDefaultConfig().(*jwtauthextension.Config)
+				cfg.JWKSFile = filepath.Join("..", "..", "extension", "jwtauthextension", "testdata", "jwks.json")
+				return cfg
+			},
+		},
 		{
 			extension: "oauth2client",
 			getConfigFn: func() config.Extension {
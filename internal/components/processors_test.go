@@ -65,6 +65,10 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "deltatorate",
 		},
+		{
+			processor:     "diskbuffer",
+			skipLifecycle: true, // Requires a storage extension to be configured
+		},
 		{
 			processor: "filter",
 		},
@@ -78,6 +82,9 @@ func TestDefaultProcessors(t *testing.T) {
 			processor:     "k8sattributes",
 			skipLifecycle: true, // Requires a k8s API to communicate with
 		},
+		{
+			processor: "logdedup",
+		},
 		{
 			processor: "memory_limiter",
 			getConfigFn: func() config.Processor {
@@ -131,6 +138,10 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "tail_sampling",
 		},
+		{
+			processor:     "traceduration",
+			skipLifecycle: true, // Requires a running exporter to send metrics to
+		},
 		{
 			processor: "transform",
 		},
@@ -111,6 +111,9 @@ func TestDefaultReceivers(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			receiver: "ciwebhook",
+		},
 		{
 			receiver: "collectd",
 		},
@@ -177,6 +180,14 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver:     "k8s_events",
 			skipLifecyle: true, // need a valid Kubernetes host and port
 		},
+		{
+			receiver:     "k8s_log",
+			skipLifecyle: true, // need a valid Kubernetes host and port
+		},
+		{
+			receiver:     "k8sobjects",
+			skipLifecyle: true, // need a valid Kubernetes host and port
+		},
 		{
 			receiver:     "kafka",
 			skipLifecyle: true, // TODO: It needs access to internals to successful start.
@@ -192,6 +203,9 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver:     "kubeletstats",
 			skipLifecyle: true, // Requires access to certificates to auth against kubelet
 		},
+		{
+			receiver: "loopback",
+		},
 		{
 			receiver: "memcached",
 		},
@@ -217,6 +231,10 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "nsxt",
 		},
+		{
+			receiver:     "objectstore",
+			skipLifecyle: true, // Requires an SQS queue or a storage queue
+		},
 		{
 			receiver:     "opencensus",
 			skipLifecyle: true, // TODO: Usage of CMux doesn't allow proper shutdown.
@@ -292,6 +310,10 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "snmp",
 		},
+		{
+			receiver:     "snowflake",
+			skipLifecyle: true, // need a valid Snowflake account
+		},
 		{
 			receiver: "splunk_hec",
 		},
@@ -309,6 +331,9 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver:     "wavefront",
 			skipLifecyle: true, // Depends on carbon receiver to be running correctly
 		},
+		{
+			receiver: "webhookevent",
+		},
 		{
 			receiver:     "windowseventlog",
 			skipLifecyle: true, // Requires a running windows process
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// combinedRegex parses the lines in the common or combined log formats, optionally followed by the duration of the request,
+// such as the $request_time of nginx, or the %D of Apache HTTP Server.
+var combinedRegex = regexp.MustCompile(`^\S+ \S+ \S+ \[[^\]]+\] "(?P<method>[A-Z]+) (?P<path>\S+)[^"]*" (?P<status>\d{3}) \S+(?: "[^"]*" "[^"]*")?(?: (?P<duration>\d+(?:\.\d+)?))?\s*$`)
+
+// durationBounds are the bounds of the buckets of the duration histograms, in seconds.
+var durationBounds = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type requestKey struct {
+	route       string
+	method      string
+	statusClass string
+}
+
+type durationKey struct {
+	route  string
+	method string
+}
+
+type histogram struct {
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+}
+
+// RequestsRecorder records the number of requests with the given route, method and status class.
+type RequestsRecorder func(route, method, statusClass string, count int64)
+
+// DurationRecorder records the histogram of the duration, in seconds, of the requests with the given route and method.
+type DurationRecorder func(route, method string, count uint64, sum float64, bounds []float64, bucketCounts []uint64)
+
+// Deriver derives the request rate, duration and status metrics from an access log. The metrics are
+// cumulative since the start of the deriver, which only reads the lines appended to the access log.
+// They are recorded by the receivers with their metrics builder, generated from their metadata.yaml.
+type Deriver struct {
+	parser       *regexp.Regexp
+	durationUnit time.Duration
+	routes       *routeNormalizer
+	tailer       *tailer
+	logger       *zap.Logger
+
+	requests     map[requestKey]int64
+	durations    map[durationKey]*histogram
+	invalidLines int64
+}
+
+// NewDeriver creates a deriver of the metrics of the configured access log.
+func NewDeriver(cfg *Config, logger *zap.Logger) (*Deriver, error) {
+	parser := combinedRegex
+	if cfg.Format == FormatRegex {
+		var err error
+		if parser, err = compileRegex(cfg.Regex); err != nil {
+			return nil, err
+		}
+	}
+	routes, err := newRouteNormalizer(cfg.Routes, cfg.MaxRoutes)
+	if err != nil {
+		return nil, err
+	}
+	durationUnit := time.Second
+	if unit, ok := durationUnits[cfg.DurationUnit]; ok {
+		durationUnit = unit
+	}
+
+	return &Deriver{
+		parser:       parser,
+		durationUnit: durationUnit,
+		routes:       routes,
+		tailer:       &tailer{path: cfg.Path},
+		logger:       logger,
+		requests:     make(map[requestKey]int64),
+		durations:    make(map[durationKey]*histogram),
+	}, nil
+}
+
+// Start starts tailing the access log from its end.
+func (d *Deriver) Start() error {
+	return d.tailer.start()
+}
+
+// Shutdown stops tailing the access log.
+func (d *Deriver) Shutdown() error {
+	return d.tailer.stop()
+}
+
+// Record reads the lines appended to the access log, and records the derived metrics, ordered by
+// route, method and status class.
+func (d *Deriver) Record(recordRequests RequestsRecorder, recordDuration DurationRecorder) error {
+	invalidLines := d.invalidLines
+	err := d.tailer.readLines(d.processLine)
+	if d.invalidLines > invalidLines {
+		d.logger.Debug("Ignored the access log lines which could not be parsed", zap.Int64("lines", d.invalidLines-invalidLines))
+	}
+	d.recordRequests(recordRequests)
+	d.recordDurations(recordDuration)
+	return err
+}
+
+func (d *Deriver) processLine(line string) {
+	match := d.parser.FindStringSubmatch(line)
+	if match == nil {
+		d.invalidLines++
+		return
+	}
+	status := match[d.parser.SubexpIndex("status")]
+	if len(status) != 3 {
+		d.invalidLines++
+		return
+	}
+	method := match[d.parser.SubexpIndex("method")]
+	route := d.routes.normalize(match[d.parser.SubexpIndex("path")])
+	d.requests[requestKey{route: route, method: method, statusClass: status[:1] + "xx"}]++
+
+	i := d.parser.SubexpIndex("duration")
+	if i < 0 || match[i] == "" {
+		return
+	}
+	value, err := strconv.ParseFloat(match[i], 64)
+	if err != nil {
+		return
+	}
+	seconds := value * d.durationUnit.Seconds()
+
+	key := durationKey{route: route, method: method}
+	h, ok := d.durations[key]
+	if !ok {
+		h = &histogram{bucketCounts: make([]uint64, len(durationBounds)+1)}
+		d.durations[key] = h
+	}
+	h.count++
+	h.sum += seconds
+	h.bucketCounts[sort.SearchFloat64s(durationBounds, seconds)]++
+}
+
+func (d *Deriver) recordRequests(record RequestsRecorder) {
+	keys := make([]requestKey, 0, len(d.requests))
+	for key := range d.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].statusClass < keys[j].statusClass
+	})
+
+	for _, key := range keys {
+		record(key.route, key.method, key.statusClass, d.requests[key])
+	}
+}
+
+func (d *Deriver) recordDurations(record DurationRecorder) {
+	keys := make([]durationKey, 0, len(d.durations))
+	for key := range d.durations {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	for _, key := range keys {
+		h := d.durations[key]
+		record(key.route, key.method, h.count, h.sum, durationBounds, h.bucketCounts)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recordedRequests struct {
+	route       string
+	method      string
+	statusClass string
+	count       int64
+}
+
+type recordedDuration struct {
+	route        string
+	method       string
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+}
+
+type recorder struct {
+	requests  []recordedRequests
+	durations []recordedDuration
+}
+
+func record(t *testing.T, d *Deriver) *recorder {
+	r := &recorder{}
+	require.NoError(t, d.Record(func(route, method, statusClass string, count int64) {
+		r.requests = append(r.requests, recordedRequests{route, method, statusClass, count})
+	}, func(route, method string, count uint64, sum float64, bounds []float64, bucketCounts []uint64) {
+		assert.Equal(t, durationBounds, bounds)
+		r.durations = append(r.durations, recordedDuration{route, method, count, sum, append([]uint64(nil), bucketCounts...)})
+	}))
+	return r
+}
+
+func TestDeriverCombined(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	require.NoError(t, os.WriteFile(path, []byte(`127.0.0.1 - - [10/Oct/2022:13:55:36 +0000] "GET /before HTTP/1.1" 200 2326`+"\n"), 0600))
+
+	d, err := NewDeriver(&Config{Path: path}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, d.Start())
+	defer func() { assert.NoError(t, d.Shutdown()) }()
+
+	assert.Equal(t, &recorder{}, record(t, d))
+
+	appendFile(t, path, `127.0.0.1 - - [10/Oct/2022:13:55:37 +0000] "GET /orders/12 HTTP/1.1" 200 2326 "-" "curl/7.79.1" 0.004
+127.0.0.1 - - [10/Oct/2022:13:55:37 +0000] "GET /orders/34?expand=items HTTP/1.1" 200 2326 "-" "curl/7.79.1" 0.030
+127.0.0.1 - - [10/Oct/2022:13:55:38 +0000] "POST /orders HTTP/1.1" 503 0 "-" "curl/7.79.1" 2.000
+127.0.0.1 - - [10/Oct/2022:13:55:39 +0000] "GET /health HTTP/1.0" 204 0
+not an access log line
+`)
+
+	r := record(t, d)
+	assert.Equal(t, []recordedRequests{
+		{"/health", "GET", "2xx", 1},
+		{"/orders", "POST", "5xx", 1},
+		{"/orders/{id}", "GET", "2xx", 2},
+	}, r.requests)
+	require.Len(t, r.durations, 2)
+	assert.Equal(t, recordedDuration{"/orders", "POST", 1, 2, []uint64{0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0}}, r.durations[0])
+	assert.Equal(t, "/orders/{id}", r.durations[1].route)
+	assert.Equal(t, "GET", r.durations[1].method)
+	assert.Equal(t, uint64(2), r.durations[1].count)
+	assert.InDelta(t, 0.034, r.durations[1].sum, 1e-9)
+	assert.Equal(t, []uint64{1, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0}, r.durations[1].bucketCounts)
+
+	// the metrics are cumulative
+	appendFile(t, path, `127.0.0.1 - - [10/Oct/2022:13:55:40 +0000] "GET /health HTTP/1.0" 204 0`+"\n")
+	assert.Equal(t, recordedRequests{"/health", "GET", "2xx", 2}, record(t, d).requests[0])
+}
+
+func TestDeriverRegex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	cfg := &Config{
+		Path:         path,
+		Format:       FormatRegex,
+		Regex:        `^(?P<status>\d{3}) (?P<method>\S+) (?P<path>\S+) (?P<duration>\d+)$`,
+		DurationUnit: "us",
+	}
+	d, err := NewDeriver(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, d.Start())
+	defer func() { assert.NoError(t, d.Shutdown()) }()
+
+	appendFile(t, path, "404 GET /missing 1500\n")
+
+	r := record(t, d)
+	assert.Equal(t, []recordedRequests{{"/missing", "GET", "4xx", 1}}, r.requests)
+	require.Len(t, r.durations, 1)
+	assert.InDelta(t, 0.0015, r.durations[0].sum, 1e-9)
+}
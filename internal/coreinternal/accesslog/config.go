@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	// FormatCombined parses the lines in the common or combined log formats,
+	// optionally followed by the duration of the request.
+	FormatCombined = "combined"
+	// FormatRegex parses the lines with the regular expression of the configuration.
+	FormatRegex = "regex"
+
+	defaultMaxRoutes = 100
+)
+
+var durationUnits = map[string]time.Duration{
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+}
+
+// Config defines how the metrics are derived from an access log.
+type Config struct {
+	// Path is the path of the access log, which is tailed from its end.
+	Path string `mapstructure:"path"`
+
+	// Format is the format of the lines of the access log, either "combined" or "regex".
+	// Default: "combined".
+	Format string `mapstructure:"format"`
+
+	// Regex parses the lines of the access log when the format is "regex". Its named groups
+	// "method", "path", "status" and, optionally, "duration" hold the fields of the requests.
+	Regex string `mapstructure:"regex"`
+
+	// DurationUnit is the unit of the durations of the requests, either "s", "ms" or "us".
+	// Default: "s".
+	DurationUnit string `mapstructure:"duration_unit"`
+
+	// Routes normalize the paths of the requests into routes. The first route matching the
+	// path is used, otherwise the numeric and the identifier segments of the path are replaced by "{id}".
+	Routes []RouteConfig `mapstructure:"routes"`
+
+	// MaxRoutes is the maximum number of routes, limiting the cardinality of the metrics.
+	// The requests of the following routes are reported with the "other" route.
+	// Default: 100.
+	MaxRoutes int `mapstructure:"max_routes"`
+}
+
+// RouteConfig defines a route.
+type RouteConfig struct {
+	// Match is the regular expression matching the paths of the route.
+	Match string `mapstructure:"match"`
+	// Route is the name of the route, such as "/users/{id}".
+	Route string `mapstructure:"route"`
+}
+
+// Validate checks if the configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("access_log: path must be set")
+	}
+	switch cfg.Format {
+	case "", FormatCombined:
+		if cfg.Regex != "" {
+			return fmt.Errorf("access_log: regex requires the %q format", FormatRegex)
+		}
+	case FormatRegex:
+		if _, err := compileRegex(cfg.Regex); err != nil {
+			return fmt.Errorf("access_log: %w", err)
+		}
+	default:
+		return fmt.Errorf("access_log: format must be either %q or %q: %q", FormatCombined, FormatRegex, cfg.Format)
+	}
+	if _, ok := durationUnits[cfg.DurationUnit]; cfg.DurationUnit != "" && !ok {
+		return fmt.Errorf("access_log: duration_unit must be either \"s\", \"ms\" or \"us\": %q", cfg.DurationUnit)
+	}
+	for i, route := range cfg.Routes {
+		if route.Route == "" {
+			return fmt.Errorf("access_log: routes[%d]: route must be set", i)
+		}
+		if _, err := regexp.Compile(route.Match); err != nil {
+			return fmt.Errorf("access_log: routes[%d]: invalid match: %w", i, err)
+		}
+	}
+	if cfg.MaxRoutes < 0 {
+		return errors.New("access_log: max_routes must not be negative")
+	}
+	return nil
+}
+
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, errors.New("regex must be set")
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %w", err)
+	}
+	for _, group := range []string{"method", "path", "status"} {
+		if re.SubexpIndex(group) < 0 {
+			return nil, fmt.Errorf("regex must have a %q named group", group)
+		}
+	}
+	return re, nil
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr string
+	}{
+		{
+			name: "default",
+			cfg:  Config{Path: "/var/log/nginx/access.log"},
+		},
+		{
+			name: "regex",
+			cfg: Config{
+				Path:         "/var/log/nginx/access.log",
+				Format:       FormatRegex,
+				Regex:        `^(?P<method>\S+) (?P<path>\S+) (?P<status>\d+) (?P<duration>\S+)$`,
+				DurationUnit: "ms",
+				Routes:       []RouteConfig{{Match: `^/users/`, Route: "/users/{name}"}},
+				MaxRoutes:    10,
+			},
+		},
+		{
+			name:        "missing path",
+			cfg:         Config{},
+			expectedErr: "access_log: path must be set",
+		},
+		{
+			name:        "invalid format",
+			cfg:         Config{Path: "access.log", Format: "json"},
+			expectedErr: `access_log: format must be either "combined" or "regex": "json"`,
+		},
+		{
+			name:        "regex without format",
+			cfg:         Config{Path: "access.log", Regex: `^(?P<method>\S+)$`},
+			expectedErr: `access_log: regex requires the "regex" format`,
+		},
+		{
+			name:        "missing regex",
+			cfg:         Config{Path: "access.log", Format: FormatRegex},
+			expectedErr: "access_log: regex must be set",
+		},
+		{
+			name:        "missing group",
+			cfg:         Config{Path: "access.log", Format: FormatRegex, Regex: `^(?P<method>\S+) (?P<path>\S+)$`},
+			expectedErr: `access_log: regex must have a "status" named group`,
+		},
+		{
+			name:        "invalid duration unit",
+			cfg:         Config{Path: "access.log", DurationUnit: "ns"},
+			expectedErr: `access_log: duration_unit must be either "s", "ms" or "us": "ns"`,
+		},
+		{
+			name:        "missing route",
+			cfg:         Config{Path: "access.log", Routes: []RouteConfig{{Match: `^/`}}},
+			expectedErr: "access_log: routes[0]: route must be set",
+		},
+		{
+			name:        "invalid match",
+			cfg:         Config{Path: "access.log", Routes: []RouteConfig{{Match: `(`, Route: "/"}}},
+			expectedErr: "access_log: routes[0]: invalid match: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:        "negative max routes",
+			cfg:         Config{Path: "access.log", MaxRoutes: -1},
+			expectedErr: "access_log: max_routes must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
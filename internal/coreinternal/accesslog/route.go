@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	idSegment   = "{id}"
+	otherRoute  = "other"
+	unknownPath = "unknown"
+)
+
+var (
+	numericSegment = regexp.MustCompile(`^[0-9]+$`)
+	uuidSegment    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hexSegment     = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
+)
+
+type route struct {
+	match *regexp.Regexp
+	name  string
+}
+
+// routeNormalizer normalizes the paths of the requests into a bounded set of routes.
+type routeNormalizer struct {
+	routes    []route
+	maxRoutes int
+	seen      map[string]struct{}
+}
+
+func newRouteNormalizer(cfgs []RouteConfig, maxRoutes int) (*routeNormalizer, error) {
+	if maxRoutes == 0 {
+		maxRoutes = defaultMaxRoutes
+	}
+	n := &routeNormalizer{
+		maxRoutes: maxRoutes,
+		seen:      make(map[string]struct{}),
+	}
+	for _, cfg := range cfgs {
+		re, err := regexp.Compile(cfg.Match)
+		if err != nil {
+			return nil, err
+		}
+		n.routes = append(n.routes, route{match: re, name: cfg.Route})
+	}
+	return n, nil
+}
+
+// normalize returns the route of the path of a request.
+func (n *routeNormalizer) normalize(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	if path == "" {
+		return unknownPath
+	}
+
+	name := ""
+	for _, r := range n.routes {
+		if r.match.MatchString(path) {
+			name = r.name
+			break
+		}
+	}
+	if name == "" {
+		segments := strings.Split(path, "/")
+		for i, segment := range segments {
+			if numericSegment.MatchString(segment) || uuidSegment.MatchString(segment) || hexSegment.MatchString(segment) {
+				segments[i] = idSegment
+			}
+		}
+		name = strings.Join(segments, "/")
+	}
+
+	if _, ok := n.seen[name]; !ok {
+		if len(n.seen) >= n.maxRoutes {
+			return otherRoute
+		}
+		n.seen[name] = struct{}{}
+	}
+	return name
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	n, err := newRouteNormalizer([]RouteConfig{
+		{Match: `^/users/[^/]+/avatar$`, Route: "/users/{name}/avatar"},
+	}, 0)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/", "/"},
+		{"", "unknown"},
+		{"?page=2", "unknown"},
+		{"/index.html?page=2#top", "/index.html"},
+		{"/orders/12345", "/orders/{id}"},
+		{"/orders/12345/items/6", "/orders/{id}/items/{id}"},
+		{"/sessions/0f8fad5b-d9cb-469f-a165-70867728950e", "/sessions/{id}"},
+		{"/blobs/9b2a8c04e1f3d5a6b7c8", "/blobs/{id}"},
+		{"/blobs/cafe", "/blobs/cafe"},
+		{"/users/alice/avatar", "/users/{name}/avatar"},
+		{"/users/42", "/users/{id}"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, n.normalize(tt.path), tt.path)
+	}
+}
+
+func TestNormalizeMaxRoutes(t *testing.T) {
+	n, err := newRouteNormalizer(nil, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/a", n.normalize("/a"))
+	assert.Equal(t, "/b/{id}", n.normalize("/b/1"))
+	assert.Equal(t, "other", n.normalize("/c"))
+	assert.Equal(t, "/a", n.normalize("/a?x=y"))
+	assert.Equal(t, "/b/{id}", n.normalize("/b/2"))
+}
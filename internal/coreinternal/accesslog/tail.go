@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxLineSize is the maximum size of a line, the longer lines are discarded.
+const maxLineSize = 1024 * 1024
+
+// tailer reads the lines appended to a file, following its rotations and truncations.
+type tailer struct {
+	path    string
+	file    *os.File
+	offset  int64
+	partial []byte
+	skip    bool
+}
+
+// start opens the file, positioned at its end. A file created later is read from its start.
+func (t *tailer) start() error {
+	f, err := os.Open(t.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	end, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	t.file, t.offset = f, end
+	return nil
+}
+
+func (t *tailer) stop() error {
+	if t.file == nil {
+		return nil
+	}
+	err := t.file.Close()
+	t.file = nil
+	return err
+}
+
+// readLines calls fn with the complete lines appended to the file since the last call.
+func (t *tailer) readLines(fn func(line string)) error {
+	if t.file != nil {
+		if err := t.readFile(fn); err != nil {
+			return err
+		}
+	}
+
+	info, err := os.Stat(t.path)
+	if os.IsNotExist(err) {
+		// the file was rotated, but not created again yet
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if t.file != nil {
+		current, err := t.file.Stat()
+		if err == nil && os.SameFile(info, current) {
+			if info.Size() >= t.offset {
+				return nil
+			}
+			// the file was truncated, it's read again from its start
+			if _, err = t.file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			t.offset, t.partial, t.skip = 0, t.partial[:0], false
+			return t.readFile(fn)
+		}
+		// the file was rotated, the new file is read from its start
+		t.file.Close()
+		t.file = nil
+	}
+
+	f, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	t.file, t.offset, t.partial, t.skip = f, 0, t.partial[:0], false
+	return t.readFile(fn)
+}
+
+func (t *tailer) readFile(fn func(line string)) error {
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := t.file.Read(buf)
+		if n > 0 {
+			t.offset += int64(n)
+			t.splitLines(buf[:n], fn)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (t *tailer) splitLines(data []byte, fn func(line string)) {
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			if !t.skip && len(t.partial)+len(data) > maxLineSize {
+				t.partial, t.skip = t.partial[:0], true
+			}
+			if !t.skip {
+				t.partial = append(t.partial, data...)
+			}
+			return
+		}
+		if !t.skip && len(t.partial)+i <= maxLineSize {
+			line := data[:i]
+			if len(t.partial) > 0 {
+				line = append(t.partial, line...)
+			}
+			fn(strings.TrimSuffix(string(line), "\r"))
+		}
+		t.partial, t.skip = t.partial[:0], false
+		data = data[i+1:]
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendFile(t *testing.T, path string, data string) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func readLines(t *testing.T, tl *tailer) []string {
+	var lines []string
+	require.NoError(t, tl.readLines(func(line string) {
+		lines = append(lines, line)
+	}))
+	return lines
+}
+
+func TestTailer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	appendFile(t, path, "old\n")
+
+	tl := &tailer{path: path}
+	require.NoError(t, tl.start())
+	defer func() { assert.NoError(t, tl.stop()) }()
+	assert.Empty(t, readLines(t, tl))
+
+	appendFile(t, path, "first\nsecond\r\nthi")
+	assert.Equal(t, []string{"first", "second"}, readLines(t, tl))
+
+	appendFile(t, path, "rd\n")
+	assert.Equal(t, []string{"third"}, readLines(t, tl))
+}
+
+func TestTailerMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+
+	tl := &tailer{path: path}
+	require.NoError(t, tl.start())
+	defer func() { assert.NoError(t, tl.stop()) }()
+	assert.Empty(t, readLines(t, tl))
+
+	appendFile(t, path, "first\n")
+	assert.Equal(t, []string{"first"}, readLines(t, tl))
+}
+
+func TestTailerRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	appendFile(t, path, "")
+
+	tl := &tailer{path: path}
+	require.NoError(t, tl.start())
+	defer func() { assert.NoError(t, tl.stop()) }()
+
+	appendFile(t, path, "first\n")
+	require.NoError(t, os.Rename(path, path+".1"))
+	assert.Equal(t, []string{"first"}, readLines(t, tl))
+
+	appendFile(t, path+".1", "second\n")
+	appendFile(t, path, "third\n")
+	assert.Equal(t, []string{"second", "third"}, readLines(t, tl))
+}
+
+func TestTailerTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	appendFile(t, path, "first\n")
+
+	tl := &tailer{path: path}
+	require.NoError(t, tl.start())
+	defer func() { assert.NoError(t, tl.stop()) }()
+
+	require.NoError(t, os.Truncate(path, 0))
+	appendFile(t, path, "2nd\n")
+	assert.Equal(t, []string{"2nd"}, readLines(t, tl))
+}
+
+func TestTailerLongLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	appendFile(t, path, "")
+
+	tl := &tailer{path: path}
+	require.NoError(t, tl.start())
+	defer func() { assert.NoError(t, tl.stop()) }()
+
+	appendFile(t, path, strings.Repeat("x", maxLineSize+1)+"\nshort\n")
+	assert.Equal(t, []string{"short"}, readLines(t, tl))
+}
@@ -15,8 +15,10 @@
 package attraction // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 
@@ -30,7 +32,7 @@ import (
 // Settings specifies the processor settings.
 type Settings struct {
 	// Actions specifies the list of attributes to act on.
-	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT, CONVERT}.
+	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, MASK, EXTRACT, CONVERT}.
 	// This is a required field.
 	Actions []ActionKeyValue `mapstructure:"actions"`
 }
@@ -70,8 +72,25 @@ type ActionKeyValue struct {
 	// If the value cannot be converted, the original value will be left as-is
 	ConvertedType string `mapstructure:"converted_type"`
 
+	// HashAlgorithm specifies the algorithm used by the HASH action, one of
+	// sha1, sha256 or hmac-sha256. Defaults to sha1.
+	HashAlgorithm string `mapstructure:"hash_algorithm"`
+
+	// SaltFromEnv specifies the environment variable holding the salt of the
+	// HASH action. The salt is prepended to the value for sha256, and is the
+	// key for hmac-sha256, which requires a salt. It isn't supported by sha1.
+	SaltFromEnv string `mapstructure:"salt_from_env"`
+
+	// SaltFile specifies the file holding the salt of the HASH action, as an
+	// alternative to SaltFromEnv. A trailing newline is ignored.
+	SaltFile string `mapstructure:"salt_file"`
+
+	// KeepLast specifies the number of trailing characters left unmasked by
+	// the MASK action. Values which aren't longer are masked entirely.
+	KeepLast int `mapstructure:"keep_last"`
+
 	// Action specifies the type of action to perform.
-	// The set of values are {INSERT, UPDATE, UPSERT, DELETE, HASH}.
+	// The set of values are {INSERT, UPDATE, UPSERT, DELETE, HASH, MASK}.
 	// Both lower case and upper case are supported.
 	// INSERT -  Inserts the key/value to attributes when the key does not exist.
 	//           No action is applied to attributes where the key already exists.
@@ -86,8 +105,10 @@ type ActionKeyValue struct {
 	//           Either Value, FromAttribute or FromContext must be set.
 	// DELETE  - Deletes the attribute. If the key doesn't exist,
 	//           no action is performed.
-	// HASH    - Calculates the SHA-1 hash of an existing value and overwrites the
-	//           value with it's SHA-1 hash result.
+	// HASH    - Calculates the hash of an existing value with HashAlgorithm and
+	//           overwrites the value with it's hash result.
+	// MASK    - Replaces the characters of an existing string or int value
+	//           with `*`, except for the last KeepLast ones.
 	// EXTRACT - Extracts values using a regular expression rule from the input
 	//           'key' to target keys specified in the 'rule'. If a target key
 	//           already exists, it will be overridden.
@@ -134,11 +155,16 @@ const (
 	// Supports pattern which is matched against attribute key.
 	DELETE Action = "delete"
 
-	// HASH calculates the SHA-1 hash of an existing value and overwrites the
-	// value with it's SHA-1 hash result.
+	// HASH calculates the hash of an existing value and overwrites the
+	// value with it's hash result. The hash algorithm defaults to SHA-1.
 	// Supports pattern which is matched against attribute key.
 	HASH Action = "hash"
 
+	// MASK replaces the characters of an existing string or int value with `*`,
+	// except for the last KeepLast ones, and overwrites the value with the result.
+	// Supports pattern which is matched against attribute key.
+	MASK Action = "mask"
+
 	// EXTRACT extracts values using a regular expression rule from the input
 	// 'key' to target keys specified in the 'rule'. If a target key already
 	// exists, it will be overridden.
@@ -153,6 +179,9 @@ type attributeAction struct {
 	FromAttribute string
 	FromContext   string
 	ConvertedType string
+	HashAlgorithm string
+	HashSalt      []byte
+	KeepLast      int
 	// Compiled regex if provided
 	Regex *regexp.Regexp
 	// Attribute names extracted from the regexp's subexpressions.
@@ -181,8 +210,10 @@ func NewAttrProc(settings *Settings) (*AttrProc, error) {
 		// Convert `action` to lowercase for comparison.
 		a.Action = Action(strings.ToLower(string(a.Action)))
 
+		a.HashAlgorithm = strings.ToLower(a.HashAlgorithm)
+
 		switch a.Action {
-		case DELETE, HASH:
+		case DELETE, HASH, MASK:
 			// requires `key` and/or `pattern`
 			if a.Key == "" && a.RegexPattern == "" {
 				return nil, fmt.Errorf("error creating AttrProc due to missing required field (at least one of \"key\" and \"pattern\" have to be used) at the %d-th actions", i)
@@ -194,6 +225,13 @@ func NewAttrProc(settings *Settings) (*AttrProc, error) {
 			}
 		}
 
+		if a.Action != HASH && (a.HashAlgorithm != "" || a.SaltFromEnv != "" || a.SaltFile != "") {
+			return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use the \"hash_algorithm\", \"salt_from_env\" or \"salt_file\" fields. These must not be specified for %d-th action", a.Action, i)
+		}
+		if a.Action != MASK && a.KeepLast != 0 {
+			return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use the \"keep_last\" field. This must not be specified for %d-th action", a.Action, i)
+		}
+
 		action := attributeAction{
 			Key:    a.Key,
 			Action: a.Action,
@@ -227,7 +265,7 @@ func NewAttrProc(settings *Settings) (*AttrProc, error) {
 				action.FromAttribute = a.FromAttribute
 				action.FromContext = a.FromContext
 			}
-		case HASH, DELETE:
+		case HASH, DELETE, MASK:
 			if a.Value != nil || a.FromAttribute != "" {
 				return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use \"value\" or \"from_attribute\" field. These must not be specified for %d-th action", a.Action, i)
 			}
@@ -242,6 +280,18 @@ func NewAttrProc(settings *Settings) (*AttrProc, error) {
 			if a.ConvertedType != "" {
 				return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use the \"converted_type\" field. This must not be specified for %d-th action", a.Action, i)
 			}
+			if a.Action == HASH {
+				salt, err := newHashSalt(a, i)
+				if err != nil {
+					return nil, err
+				}
+				action.HashAlgorithm = a.HashAlgorithm
+				action.HashSalt = salt
+			}
+			if a.KeepLast < 0 {
+				return nil, fmt.Errorf("error creating AttrProc due to negative value %d in field \"keep_last\" at the %d-th action", a.KeepLast, i)
+			}
+			action.KeepLast = a.KeepLast
 		case EXTRACT:
 			if valueSourceCount > 0 {
 				return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use a value source field. These must not be specified for %d-th action", a.Action, i)
@@ -336,10 +386,16 @@ func (ap *AttrProc) Process(ctx context.Context, logger *zap.Logger, attrs pcomm
 				av.CopyTo(attrs.PutEmpty(action.Key))
 			}
 		case HASH:
-			hashAttribute(action.Key, attrs)
+			hashAttribute(action, action.Key, attrs)
+
+			for _, k := range getMatchingKeys(action.Regex, attrs) {
+				hashAttribute(action, k, attrs)
+			}
+		case MASK:
+			maskAttribute(action, action.Key, attrs)
 
 			for _, k := range getMatchingKeys(action.Regex, attrs) {
-				hashAttribute(k, attrs)
+				maskAttribute(action, k, attrs)
 			}
 		case EXTRACT:
 			extractAttributes(action, attrs)
@@ -404,9 +460,57 @@ func getSourceAttributeValue(ctx context.Context, action attributeAction, attrs
 	return attrs.Get(action.FromAttribute)
 }
 
-func hashAttribute(key string, attrs pcommon.Map) {
+// newHashSalt validates the hash algorithm of the HASH action and reads its salt
+// from the environment variable or the file, if any.
+func newHashSalt(a ActionKeyValue, i int) ([]byte, error) {
+	if a.SaltFromEnv != "" && a.SaltFile != "" {
+		return nil, fmt.Errorf("error creating AttrProc due to both \"salt_from_env\" and \"salt_file\" being set at the %d-th action", i)
+	}
+
+	var salt []byte
+	switch {
+	case a.SaltFromEnv != "":
+		val, ok := os.LookupEnv(a.SaltFromEnv)
+		if !ok || val == "" {
+			return nil, fmt.Errorf("error creating AttrProc due to missing or empty environment variable %q in field \"salt_from_env\" at the %d-th action", a.SaltFromEnv, i)
+		}
+		salt = []byte(val)
+	case a.SaltFile != "":
+		content, err := os.ReadFile(a.SaltFile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating AttrProc. Field \"salt_file\" could not be read at the %d-th action: %w", i, err)
+		}
+		salt = bytes.TrimRight(content, "\r\n")
+		if len(salt) == 0 {
+			return nil, fmt.Errorf("error creating AttrProc due to empty file %q in field \"salt_file\" at the %d-th action", a.SaltFile, i)
+		}
+	}
+
+	switch a.HashAlgorithm {
+	case "", sha1Algorithm:
+		if salt != nil {
+			return nil, fmt.Errorf("error creating AttrProc. Hash algorithm \"%s\" does not support a salt at the %d-th action", sha1Algorithm, i)
+		}
+	case sha256Algorithm:
+	case hmacSHA256Algorithm:
+		if salt == nil {
+			return nil, fmt.Errorf("error creating AttrProc due to missing salt for hash algorithm \"%s\" at the %d-th action", a.HashAlgorithm, i)
+		}
+	default:
+		return nil, fmt.Errorf("error creating AttrProc due to invalid value \"%s\" in field \"hash_algorithm\" at the %d-th action", a.HashAlgorithm, i)
+	}
+	return salt, nil
+}
+
+func hashAttribute(action attributeAction, key string, attrs pcommon.Map) {
+	if value, exists := attrs.Get(key); exists {
+		hashValue(value, newHash(action.HashAlgorithm, action.HashSalt))
+	}
+}
+
+func maskAttribute(action attributeAction, key string, attrs pcommon.Map) {
 	if value, exists := attrs.Get(key); exists {
-		sha1Hasher(value)
+		maskValue(value, action.KeepLast)
 	}
 }
 
@@ -16,11 +16,15 @@ package attraction
 
 import (
 	"context"
+	"crypto/hmac"
 	"crypto/sha1" // #nosec
+	"crypto/sha256"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -702,6 +706,140 @@ func TestAttributes_HashValue(t *testing.T) {
 	}
 }
 
+func TestAttributes_HashValueAlgorithms(t *testing.T) {
+	t.Setenv("ATTRACTION_TEST_SALT", "salt")
+	saltFile := filepath.Join(t.TempDir(), "salt")
+	require.NoError(t, os.WriteFile(saltFile, []byte("salt\n"), 0600))
+
+	mac := hmac.New(sha256.New, []byte("salt"))
+	mac.Write([]byte("foo"))
+
+	testCases := []struct {
+		name     string
+		action   ActionKeyValue
+		expected string
+	}{
+		{
+			name:     "SHA256",
+			action:   ActionKeyValue{Key: "updateme", Action: HASH, HashAlgorithm: "sha256"},
+			expected: fmt.Sprintf("%x", sha256.Sum256([]byte("foo"))),
+		},
+		{
+			name:     "SHA256SaltFromEnv",
+			action:   ActionKeyValue{Key: "updateme", Action: HASH, HashAlgorithm: "SHA256", SaltFromEnv: "ATTRACTION_TEST_SALT"},
+			expected: fmt.Sprintf("%x", sha256.Sum256([]byte("saltfoo"))),
+		},
+		{
+			name:     "HMACSHA256SaltFile",
+			action:   ActionKeyValue{Key: "updateme", Action: HASH, HashAlgorithm: "hmac-sha256", SaltFile: saltFile},
+			expected: fmt.Sprintf("%x", mac.Sum(nil)),
+		},
+	}
+
+	for _, tc := range testCases {
+		ap, err := NewAttrProc(&Settings{Actions: []ActionKeyValue{tc.action}})
+		require.Nil(t, err)
+		require.NotNil(t, ap)
+
+		runIndividualTestCase(t, testCase{
+			name: tc.name,
+			inputAttributes: map[string]interface{}{
+				"updateme": "foo",
+			},
+			expectedAttributes: map[string]interface{}{
+				"updateme": tc.expected,
+			},
+		}, ap)
+	}
+}
+
+func TestAttributes_MaskValue(t *testing.T) {
+	testCases := []testCase{
+		// Ensure no changes to the span as the key does not exist.
+		{
+			name: "MaskKeyNoExist",
+			inputAttributes: map[string]interface{}{
+				"boo": "foo",
+			},
+			expectedAttributes: map[string]interface{}{
+				"boo": "foo",
+			},
+		},
+		{
+			name: "MaskString",
+			inputAttributes: map[string]interface{}{
+				"account": "FR7630006000011234567890189",
+			},
+			expectedAttributes: map[string]interface{}{
+				"account": "***********************0189",
+			},
+		},
+		{
+			name: "MaskInt",
+			inputAttributes: map[string]interface{}{
+				"account": int64(1234567890),
+			},
+			expectedAttributes: map[string]interface{}{
+				"account": "******7890",
+			},
+		},
+		// Ensure short values are masked entirely
+		{
+			name: "MaskShortString",
+			inputAttributes: map[string]interface{}{
+				"account": "1234",
+			},
+			expectedAttributes: map[string]interface{}{
+				"account": "****",
+			},
+		},
+		{
+			name: "MaskMultiByteString",
+			inputAttributes: map[string]interface{}{
+				"account": "çàé12345",
+			},
+			expectedAttributes: map[string]interface{}{
+				"account": "****2345",
+			},
+		},
+		{
+			name: "MaskBoolUnchanged",
+			inputAttributes: map[string]interface{}{
+				"account": true,
+			},
+			expectedAttributes: map[string]interface{}{
+				"account": true,
+			},
+		},
+		// Ensure regex pattern is being used
+		{
+			name: "MaskRegex",
+			inputAttributes: map[string]interface{}{
+				"card.number":  "4111111111111111",
+				"card.country": "FR",
+			},
+			expectedAttributes: map[string]interface{}{
+				"card.number":  "************1111",
+				"card.country": "FR",
+			},
+		},
+	}
+
+	cfg := &Settings{
+		Actions: []ActionKeyValue{
+			{Key: "account", RegexPattern: "^card\\.number$", Action: MASK, KeepLast: 4},
+		},
+	}
+
+	ap, err := NewAttrProc(cfg)
+	require.Nil(t, err)
+	require.NotNil(t, ap)
+
+	for _, tt := range testCases {
+		runIndividualTestCase(t, tt, ap)
+	}
+}
+
 func TestAttributes_FromAttributeNoChange(t *testing.T) {
 	tc := testCase{
 		name: "FromAttributeNoChange",
@@ -901,6 +1039,62 @@ func TestInvalidConfig(t *testing.T) {
 			},
 			errorString: "error creating AttrProc. Field \"pattern\" contains at least one unnamed matcher group at the 0-th actions",
 		},
+		{
+			name: "hash algorithm for delete",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", HashAlgorithm: "sha256", Action: DELETE},
+			},
+			errorString: "error creating AttrProc. Action \"delete\" does not use the \"hash_algorithm\", \"salt_from_env\" or \"salt_file\" fields. These must not be specified for 0-th action",
+		},
+		{
+			name: "invalid hash algorithm",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", HashAlgorithm: "fnv", Action: HASH},
+			},
+			errorString: "error creating AttrProc due to invalid value \"fnv\" in field \"hash_algorithm\" at the 0-th action",
+		},
+		{
+			name: "salt for sha1",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", SaltFromEnv: "PATH", Action: HASH},
+			},
+			errorString: "error creating AttrProc. Hash algorithm \"sha1\" does not support a salt at the 0-th action",
+		},
+		{
+			name: "missing salt for hmac",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", HashAlgorithm: "hmac-sha256", Action: HASH},
+			},
+			errorString: "error creating AttrProc due to missing salt for hash algorithm \"hmac-sha256\" at the 0-th action",
+		},
+		{
+			name: "both salt sources",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", HashAlgorithm: "sha256", SaltFromEnv: "PATH", SaltFile: "salt", Action: HASH},
+			},
+			errorString: "error creating AttrProc due to both \"salt_from_env\" and \"salt_file\" being set at the 0-th action",
+		},
+		{
+			name: "missing salt environment variable",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", HashAlgorithm: "sha256", SaltFromEnv: "ATTRACTION_TEST_MISSING_SALT", Action: HASH},
+			},
+			errorString: "error creating AttrProc due to missing or empty environment variable \"ATTRACTION_TEST_MISSING_SALT\" in field \"salt_from_env\" at the 0-th action",
+		},
+		{
+			name: "keep last for hash",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", KeepLast: 4, Action: HASH},
+			},
+			errorString: "error creating AttrProc. Action \"hash\" does not use the \"keep_last\" field. This must not be specified for 0-th action",
+		},
+		{
+			name: "negative keep last",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", KeepLast: -1, Action: MASK},
+			},
+			errorString: "error creating AttrProc due to negative value -1 in field \"keep_last\" at the 0-th action",
+		},
 	}
 
 	for _, tc := range testcase {
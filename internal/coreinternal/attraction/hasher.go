@@ -15,10 +15,13 @@
 package attraction // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
 
 import (
+	"crypto/hmac"
 	// #nosec
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
+	"hash"
 	"math"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -27,6 +30,10 @@ import (
 const (
 	int64ByteSize   = 8
 	float64ByteSize = 8
+
+	sha1Algorithm       = "sha1"
+	sha256Algorithm     = "sha256"
+	hmacSHA256Algorithm = "hmac-sha256"
 )
 
 var (
@@ -34,11 +41,27 @@ var (
 	byteFalse = [1]byte{0}
 )
 
-// sha1Hasher hashes an AttributeValue using SHA1 and returns a
+// newHash returns the hash of the given algorithm. The salt is prepended to
+// the hashed values for SHA256, and is the key of HMAC-SHA256.
+func newHash(algorithm string, salt []byte) hash.Hash {
+	switch algorithm {
+	case sha256Algorithm:
+		h := sha256.New()
+		_, _ = h.Write(salt)
+		return h
+	case hmacSHA256Algorithm:
+		return hmac.New(sha256.New, salt)
+	default:
+		// #nosec
+		return sha1.New()
+	}
+}
+
+// hashValue hashes an AttributeValue using the given hash and returns a
 // hashed version of the attribute. In practice, this would mostly be used
 // for string attributes but we support all types for completeness/correctness
 // and eliminate any surprises.
-func sha1Hasher(attr pcommon.Value) {
+func hashValue(attr pcommon.Value, h hash.Hash) {
 	var val []byte
 	switch attr.Type() {
 	case pcommon.ValueTypeStr:
@@ -59,8 +82,6 @@ func sha1Hasher(attr pcommon.Value) {
 
 	var hashed string
 	if len(val) > 0 {
-		// #nosec
-		h := sha1.New()
 		_, _ = h.Write(val)
 		val = h.Sum(nil)
 		hashedBytes := make([]byte, hex.EncodedLen(len(val)))
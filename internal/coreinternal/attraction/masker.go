@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attraction // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const maskChar = "*"
+
+// maskValue replaces the characters of a string or int AttributeValue with
+// `*`, except for the last keepLast ones, so that values such as account
+// numbers can still be told apart. The values which aren't longer than
+// keepLast are masked entirely, and int values are turned into strings.
+// Other types are left unchanged.
+func maskValue(attr pcommon.Value, keepLast int) {
+	var val string
+	switch attr.Type() {
+	case pcommon.ValueTypeStr:
+		val = attr.Str()
+	case pcommon.ValueTypeInt:
+		val = strconv.FormatInt(attr.Int(), 10)
+	default:
+		return
+	}
+
+	runes := []rune(val)
+	kept := 0
+	if len(runes) > keepLast {
+		kept = keepLast
+	}
+	attr.SetStr(strings.Repeat(maskChar, len(runes)-kept) + string(runes[len(runes)-kept:]))
+}
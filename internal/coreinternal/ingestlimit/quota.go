@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestlimit // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/ingestlimit"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// unknownIdentity is used for clients that have neither the configured auth
+// attribute nor a known network address.
+const unknownIdentity = "unknown"
+
+// idleTimeout is the time after which the buckets of an identity that has not
+// sent anything are dropped.
+const idleTimeout = 5 * time.Minute
+
+// QuotaSettings defines the ingestion quota applied to every client identity.
+type QuotaSettings struct {
+	// IdentityAttribute is the attribute of the authentication data, as set by
+	// the configured authenticator, identifying the tenant. Clients without
+	// this attribute are identified by their network address.
+	IdentityAttribute string `mapstructure:"identity_attribute"`
+
+	// RequestsPerSecond is the number of requests accepted per second for a
+	// single identity. Zero means no limit.
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+
+	// SpansPerSecond is the number of spans accepted per second for a single
+	// identity. Zero means no limit.
+	SpansPerSecond float64 `mapstructure:"spans_per_second"`
+}
+
+// Validate checks the quota settings are valid.
+func (s *QuotaSettings) Validate() error {
+	if s.RequestsPerSecond < 0 {
+		return errors.New("requests_per_second must not be negative")
+	}
+	if s.SpansPerSecond < 0 {
+		return errors.New("spans_per_second must not be negative")
+	}
+	if s.RequestsPerSecond == 0 && s.SpansPerSecond == 0 {
+		return errors.New("at least one of requests_per_second or spans_per_second must be set")
+	}
+	return nil
+}
+
+// Quota tracks the requests and spans received from every client identity
+// using token buckets holding one second worth of traffic. A nil *Quota
+// accepts everything.
+type Quota struct {
+	settings QuotaSettings
+	now      func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*identityBuckets
+	lastPrune time.Time
+}
+
+type identityBuckets struct {
+	requests bucket
+	spans    bucket
+	lastSeen time.Time
+}
+
+// NewQuota creates a Quota enforcing the given settings.
+func NewQuota(settings QuotaSettings) *Quota {
+	return &Quota{
+		settings:  settings,
+		now:       time.Now,
+		buckets:   map[string]*identityBuckets{},
+		lastPrune: time.Now(),
+	}
+}
+
+// AllowRequest reports whether a new request from the client in ctx is
+// within the quota of its identity.
+func (q *Quota) AllowRequest(ctx context.Context) bool {
+	if q == nil || q.settings.RequestsPerSecond == 0 {
+		return true
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	b, now := q.identityBuckets(ctx)
+	return b.requests.take(now, q.settings.RequestsPerSecond, 1)
+}
+
+// AllowSpans reports whether n more spans from the client in ctx are within
+// the quota of its identity. A batch is accepted as long as the identity has
+// spans left, even if it holds more spans than remaining; the excess is
+// deducted from the following seconds.
+func (q *Quota) AllowSpans(ctx context.Context, n int) bool {
+	if q == nil || q.settings.SpansPerSecond == 0 {
+		return true
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	b, now := q.identityBuckets(ctx)
+	return b.spans.take(now, q.settings.SpansPerSecond, float64(n))
+}
+
+func (q *Quota) identityBuckets(ctx context.Context) (*identityBuckets, time.Time) {
+	now := q.now()
+	if now.Sub(q.lastPrune) > idleTimeout {
+		for id, b := range q.buckets {
+			if now.Sub(b.lastSeen) > idleTimeout {
+				delete(q.buckets, id)
+			}
+		}
+		q.lastPrune = now
+	}
+
+	id := Identity(ctx, q.settings.IdentityAttribute)
+	b, ok := q.buckets[id]
+	if !ok {
+		b = &identityBuckets{
+			requests: newBucket(now, q.settings.RequestsPerSecond),
+			spans:    newBucket(now, q.settings.SpansPerSecond),
+		}
+		q.buckets[id] = b
+	}
+	b.lastSeen = now
+	return b, now
+}
+
+// Identity returns the identity of the client in ctx: the value of the given
+// auth attribute when present, the client address otherwise.
+func Identity(ctx context.Context, attribute string) string {
+	info := client.FromContext(ctx)
+	if attribute != "" && info.Auth != nil {
+		if v := info.Auth.GetAttribute(attribute); v != nil {
+			return fmt.Sprint(v)
+		}
+	}
+	if info.Addr != nil {
+		addr := info.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			return host
+		}
+		return addr
+	}
+	return unknownIdentity
+}
+
+// bucket is a token bucket refilled at a fixed rate up to one second worth
+// of tokens, or a single token for rates lower than one per second.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(now time.Time, rate float64) bucket {
+	return bucket{tokens: bucketCapacity(rate), last: now}
+}
+
+func bucketCapacity(rate float64) float64 {
+	return math.Max(rate, 1)
+}
+
+// take removes n tokens from the bucket. At least one token must be
+// available; the bucket goes into debt for the remainder if needed.
+func (b *bucket) take(now time.Time, rate float64, n float64) bool {
+	b.tokens = math.Min(b.tokens+now.Sub(b.last).Seconds()*rate, bucketCapacity(rate))
+	b.last = now
+	if b.tokens < math.Min(n, 1) {
+		return false
+	}
+	b.tokens -= n
+	return true
+}
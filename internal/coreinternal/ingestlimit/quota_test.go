@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestlimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/client"
+)
+
+type authData map[string]interface{}
+
+func (a authData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	var names []string
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func contextWithTenant(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4567},
+		Auth: authData{"tenant": tenant},
+	})
+}
+
+func TestQuotaSettingsValidate(t *testing.T) {
+	assert.NoError(t, (&QuotaSettings{RequestsPerSecond: 10}).Validate())
+	assert.NoError(t, (&QuotaSettings{SpansPerSecond: 10}).Validate())
+	assert.EqualError(t, (&QuotaSettings{}).Validate(), "at least one of requests_per_second or spans_per_second must be set")
+	assert.EqualError(t, (&QuotaSettings{RequestsPerSecond: -1}).Validate(), "requests_per_second must not be negative")
+	assert.EqualError(t, (&QuotaSettings{SpansPerSecond: -1}).Validate(), "spans_per_second must not be negative")
+}
+
+func TestIdentity(t *testing.T) {
+	assert.Equal(t, "acme", Identity(contextWithTenant("acme"), "tenant"))
+	assert.Equal(t, "10.0.0.1", Identity(contextWithTenant("acme"), "other"))
+	assert.Equal(t, "10.0.0.1", Identity(contextWithTenant("acme"), ""))
+	assert.Equal(t, unknownIdentity, Identity(context.Background(), "tenant"))
+}
+
+func TestQuotaRequests(t *testing.T) {
+	now := time.Unix(1000, 0)
+	q := NewQuota(QuotaSettings{IdentityAttribute: "tenant", RequestsPerSecond: 2})
+	q.now = func() time.Time { return now }
+
+	acme := contextWithTenant("acme")
+	assert.True(t, q.AllowRequest(acme))
+	assert.True(t, q.AllowRequest(acme))
+	assert.False(t, q.AllowRequest(acme))
+
+	// Other tenants have their own quota.
+	assert.True(t, q.AllowRequest(contextWithTenant("other")))
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, q.AllowRequest(acme))
+	assert.False(t, q.AllowRequest(acme))
+
+	// No span quota configured.
+	assert.True(t, q.AllowSpans(acme, 1_000_000))
+}
+
+func TestQuotaSpans(t *testing.T) {
+	now := time.Unix(1000, 0)
+	q := NewQuota(QuotaSettings{IdentityAttribute: "tenant", SpansPerSecond: 100})
+	q.now = func() time.Time { return now }
+
+	acme := contextWithTenant("acme")
+	assert.True(t, q.AllowSpans(acme, 60))
+	// Batches larger than the remaining quota are accepted once and paid
+	// back over the following seconds.
+	assert.True(t, q.AllowSpans(acme, 150))
+	assert.False(t, q.AllowSpans(acme, 1))
+
+	now = now.Add(time.Second)
+	assert.False(t, q.AllowSpans(acme, 1))
+	now = now.Add(200 * time.Millisecond)
+	assert.True(t, q.AllowSpans(acme, 1))
+
+	// No request quota configured.
+	assert.True(t, q.AllowRequest(acme))
+}
+
+func TestQuotaPrunesIdleIdentities(t *testing.T) {
+	now := time.Unix(1000, 0)
+	q := NewQuota(QuotaSettings{RequestsPerSecond: 1})
+	q.now = func() time.Time { return now }
+	q.lastPrune = now
+
+	assert.True(t, q.AllowRequest(contextWithTenant("acme")))
+	assert.Len(t, q.buckets, 1)
+
+	now = now.Add(2 * idleTimeout)
+	assert.True(t, q.AllowRequest(context.Background()))
+	assert.Len(t, q.buckets, 1)
+	assert.Contains(t, q.buckets, unknownIdentity)
+}
+
+func TestNilQuota(t *testing.T) {
+	var q *Quota
+	assert.True(t, q.AllowRequest(context.Background()))
+	assert.True(t, q.AllowSpans(context.Background(), 10))
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestlimit // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/ingestlimit"
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrBodyTooLarge is returned by a LimitedReadCloser once more than the
+// allowed number of bytes has been read.
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// LimitedReadCloser fails with ErrBodyTooLarge instead of returning a
+// truncated body when more than the allowed number of bytes is read.
+type LimitedReadCloser struct {
+	rc        io.ReadCloser
+	limit     int64
+	remaining int64
+}
+
+// NewLimitedReadCloser wraps rc so that reading more than limit bytes fails
+// with ErrBodyTooLarge. It is meant to wrap a request body after
+// decompression, so that small compressed payloads can not expand into
+// arbitrarily large ones. A limit lower or equal to zero disables the check.
+func NewLimitedReadCloser(rc io.ReadCloser, limit int64) *LimitedReadCloser {
+	return &LimitedReadCloser{rc: rc, limit: limit, remaining: limit}
+}
+
+func (l *LimitedReadCloser) Read(p []byte) (int, error) {
+	if l.limit <= 0 {
+		return l.rc.Read(p)
+	}
+	if l.remaining < 0 {
+		return 0, ErrBodyTooLarge
+	}
+	// Read one byte more than allowed to detect bodies that exceed the limit.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.rc.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), ErrBodyTooLarge
+	}
+	return n, err
+}
+
+// Exceeded reports whether the limit was exceeded. It is useful when the
+// reader is consumed by a decoder that does not preserve the read errors.
+func (l *LimitedReadCloser) Exceeded() bool {
+	return l.limit > 0 && l.remaining < 0
+}
+
+func (l *LimitedReadCloser) Close() error {
+	return l.rc.Close()
+}
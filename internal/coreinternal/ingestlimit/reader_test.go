@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestlimit
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitedReadCloser(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		limit   int64
+		wantErr error
+	}{
+		{
+			name:  "under limit",
+			body:  "hello",
+			limit: 10,
+		},
+		{
+			name:  "at limit",
+			body:  "hello",
+			limit: 5,
+		},
+		{
+			name:    "over limit",
+			body:    "hello world",
+			limit:   5,
+			wantErr: ErrBodyTooLarge,
+		},
+		{
+			name:  "disabled",
+			body:  "hello world",
+			limit: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := NewLimitedReadCloser(io.NopCloser(strings.NewReader(tt.body)), tt.limit)
+			got, err := io.ReadAll(rc)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Len(t, got, int(tt.limit))
+				assert.True(t, rc.Exceeded())
+				return
+			}
+			require.NoError(t, err)
+			assert.False(t, rc.Exceeded())
+			assert.Equal(t, tt.body, string(got))
+			assert.NoError(t, rc.Close())
+		})
+	}
+}
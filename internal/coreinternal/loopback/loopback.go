@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loopback connects the exporters and the receivers of the same
+// collector, so that data exported by a pipeline is received by another one
+// without being serialized or sent over the network.
+package loopback // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/loopback"
+
+import (
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+var (
+	mu      sync.RWMutex
+	traces  = map[config.ComponentID]consumer.Traces{}
+	logs    = map[config.ComponentID]consumer.Logs{}
+	metrics = map[config.ComponentID]consumer.Metrics{}
+)
+
+// RegisterTraces makes the given consumer available to the exporters as the
+// traces loopback receiver with the given ID. The returned function removes
+// it.
+func RegisterTraces(id config.ComponentID, next consumer.Traces) (func(), error) {
+	return register(traces, id, next)
+}
+
+// RegisterLogs makes the given consumer available to the exporters as the
+// logs loopback receiver with the given ID. The returned function removes it.
+func RegisterLogs(id config.ComponentID, next consumer.Logs) (func(), error) {
+	return register(logs, id, next)
+}
+
+// RegisterMetrics makes the given consumer available to the exporters as the
+// metrics loopback receiver with the given ID. The returned function removes
+// it.
+func RegisterMetrics(id config.ComponentID, next consumer.Metrics) (func(), error) {
+	return register(metrics, id, next)
+}
+
+// Traces returns the consumer of the traces loopback receiver with the given
+// ID, or nil if there is none.
+func Traces(id config.ComponentID) consumer.Traces {
+	return lookup(traces, id)
+}
+
+// Logs returns the consumer of the logs loopback receiver with the given ID,
+// or nil if there is none.
+func Logs(id config.ComponentID) consumer.Logs {
+	return lookup(logs, id)
+}
+
+// Metrics returns the consumer of the metrics loopback receiver with the given
+// ID, or nil if there is none.
+func Metrics(id config.ComponentID) consumer.Metrics {
+	return lookup(metrics, id)
+}
+
+func register[T any](consumers map[config.ComponentID]T, id config.ComponentID, next T) (func(), error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := consumers[id]; ok {
+		return nil, fmt.Errorf("loopback receiver %q is already registered", id)
+	}
+	consumers[id] = next
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			mu.Lock()
+			defer mu.Unlock()
+			delete(consumers, id)
+		})
+	}, nil
+}
+
+func lookup[T any](consumers map[config.ComponentID]T, id config.ComponentID) T {
+	mu.RLock()
+	defer mu.RUnlock()
+	return consumers[id]
+}
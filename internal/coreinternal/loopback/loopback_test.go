@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loopback
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestRegisterTraces(t *testing.T) {
+	id := config.NewComponentIDWithName("loopback", "traces")
+	assert.Nil(t, Traces(id))
+
+	sink := new(consumertest.TracesSink)
+	unregister, err := RegisterTraces(id, sink)
+	require.NoError(t, err)
+	assert.Equal(t, sink, Traces(id))
+	// Signals are registered independently.
+	assert.Nil(t, Logs(id))
+	assert.Nil(t, Metrics(id))
+
+	_, err = RegisterTraces(id, sink)
+	assert.EqualError(t, err, `loopback receiver "loopback/traces" is already registered`)
+
+	unregister()
+	assert.Nil(t, Traces(id))
+	// Unregistering twice is a no-op.
+	unregister()
+}
+
+func TestRegisterLogsAndMetrics(t *testing.T) {
+	id := config.NewComponentIDWithName("loopback", "logs")
+
+	logsSink := new(consumertest.LogsSink)
+	unregisterLogs, err := RegisterLogs(id, logsSink)
+	require.NoError(t, err)
+	defer unregisterLogs()
+
+	metricsSink := new(consumertest.MetricsSink)
+	unregisterMetrics, err := RegisterMetrics(id, metricsSink)
+	require.NoError(t, err)
+	defer unregisterMetrics()
+
+	assert.Equal(t, logsSink, Logs(id))
+	assert.Equal(t, metricsSink, Metrics(id))
+}
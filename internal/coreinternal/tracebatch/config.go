@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracebatch // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/tracebatch"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultMaxTraceWait = 10 * time.Second
+	defaultMaxTraces    = 10000
+
+	// minTraceWait bounds the rate at which the traces ready to be sent are checked.
+	minTraceWait = 10 * time.Millisecond
+)
+
+// Config defines how the spans sent by a trace exporter are grouped.
+type Config struct {
+	// GroupByService sends the spans of each local service in requests of their own.
+	GroupByService bool `mapstructure:"group_by_service"`
+
+	// TraceWait holds the spans of each trace until no span of the trace was received
+	// for this duration, to send the spans of the traces together. Disabled when 0.
+	TraceWait time.Duration `mapstructure:"trace_wait"`
+
+	// MaxTraceWait bounds the time the spans of a trace are held, from the reception
+	// of its first span.
+	MaxTraceWait time.Duration `mapstructure:"max_trace_wait"`
+
+	// MaxTraces bounds the number of traces held, the oldest traces being sent
+	// early when exceeded.
+	MaxTraces int `mapstructure:"max_traces"`
+}
+
+// NewDefaultConfig returns the default settings, which don't hold the traces.
+func NewDefaultConfig() Config {
+	return Config{
+		MaxTraceWait: defaultMaxTraceWait,
+		MaxTraces:    defaultMaxTraces,
+	}
+}
+
+// Validate checks if the settings are valid.
+func (cfg *Config) Validate() error {
+	if cfg.TraceWait < 0 {
+		return errors.New("trace_wait must not be negative")
+	}
+	if cfg.TraceWait == 0 {
+		return nil
+	}
+	if cfg.TraceWait < minTraceWait {
+		return fmt.Errorf("trace_wait must be at least %v", minTraceWait)
+	}
+	if cfg.MaxTraceWait < cfg.TraceWait {
+		return errors.New("max_trace_wait must not be lower than trace_wait")
+	}
+	if cfg.MaxTraces <= 0 {
+		return errors.New("max_traces must be positive")
+	}
+	return nil
+}
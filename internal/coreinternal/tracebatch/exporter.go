@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracebatch // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/tracebatch"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// pendingTrace holds the spans of a trace until they're sent.
+type pendingTrace struct {
+	traces ptrace.Traces
+	first  time.Time
+	last   time.Time
+	sent   bool
+}
+
+// tracesExporter holds the spans of each trace before passing them to the
+// wrapped exporter, to send the spans of the traces together.
+type tracesExporter struct {
+	component.TracesExporter
+	cfg    Config
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	pending map[pcommon.TraceID]*pendingTrace
+	// order lists the pending traces by reception of their first span.
+	order []*pendingTrace
+
+	started bool
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+}
+
+// NewTracesExporter wraps the exporter to hold the spans of each trace as set in
+// the configuration, the exporter being returned as is when the traces aren't held.
+func NewTracesExporter(cfg Config, exporter component.TracesExporter, logger *zap.Logger) component.TracesExporter {
+	if cfg.TraceWait <= 0 {
+		return exporter
+	}
+	return &tracesExporter{
+		TracesExporter: exporter,
+		cfg:            cfg,
+		logger:         logger,
+		pending:        map[pcommon.TraceID]*pendingTrace{},
+		stopCh:         make(chan struct{}),
+		doneCh:         make(chan struct{}),
+	}
+}
+
+func (e *tracesExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *tracesExporter) Start(ctx context.Context, host component.Host) error {
+	if err := e.TracesExporter.Start(ctx, host); err != nil {
+		return err
+	}
+	e.started = true
+	go e.flushLoop()
+	return nil
+}
+
+// Shutdown sends the pending traces before shutting down the wrapped exporter.
+func (e *tracesExporter) Shutdown(ctx context.Context) error {
+	// the flush loop only runs once started
+	if e.started {
+		select {
+		case <-e.stopCh:
+		default:
+			close(e.stopCh)
+			<-e.doneCh
+		}
+	}
+
+	e.mu.Lock()
+	ready := e.order
+	e.order = nil
+	e.pending = map[pcommon.TraceID]*pendingTrace{}
+	e.mu.Unlock()
+	e.send(ctx, ready)
+
+	return e.TracesExporter.Shutdown(ctx)
+}
+
+// ConsumeTraces holds the spans until their trace is sent, sending the oldest
+// traces when too many traces are held.
+func (e *tracesExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	now := time.Now()
+
+	e.mu.Lock()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			// the spans of each trace in this scope, appended to the same scope copy
+			scopes := map[pcommon.TraceID]ptrace.ScopeSpans{}
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				dest, ok := scopes[span.TraceID()]
+				if !ok {
+					trace := e.pendingTrace(span.TraceID(), now)
+					destRS := trace.traces.ResourceSpans().AppendEmpty()
+					destRS.SetSchemaUrl(rs.SchemaUrl())
+					rs.Resource().CopyTo(destRS.Resource())
+					dest = destRS.ScopeSpans().AppendEmpty()
+					dest.SetSchemaUrl(ss.SchemaUrl())
+					ss.Scope().CopyTo(dest.Scope())
+					scopes[span.TraceID()] = dest
+				}
+				span.CopyTo(dest.Spans().AppendEmpty())
+			}
+		}
+	}
+
+	var evicted []*pendingTrace
+	for len(e.pending) > e.cfg.MaxTraces {
+		evicted = append(evicted, e.popOldest())
+	}
+	e.mu.Unlock()
+
+	return e.send(ctx, evicted)
+}
+
+// pendingTrace returns the pending trace of the ID, created if not pending yet.
+func (e *tracesExporter) pendingTrace(id pcommon.TraceID, now time.Time) *pendingTrace {
+	trace, ok := e.pending[id]
+	if !ok {
+		trace = &pendingTrace{traces: ptrace.NewTraces(), first: now}
+		e.pending[id] = trace
+		e.order = append(e.order, trace)
+	}
+	trace.last = now
+	return trace
+}
+
+// popOldest removes the oldest pending trace.
+func (e *tracesExporter) popOldest() *pendingTrace {
+	for len(e.order) > 0 {
+		trace := e.order[0]
+		e.order = e.order[1:]
+		if !trace.sent {
+			e.remove(trace)
+			return trace
+		}
+	}
+	return nil
+}
+
+func (e *tracesExporter) remove(trace *pendingTrace) {
+	trace.sent = true
+	for id, t := range e.pending {
+		if t == trace {
+			delete(e.pending, id)
+			return
+		}
+	}
+}
+
+func (e *tracesExporter) flushLoop() {
+	defer close(e.doneCh)
+
+	// the traces are checked twice per trace wait, which isn't validated when
+	// the exporter is used directly
+	interval := e.cfg.TraceWait / 2
+	if interval < minTraceWait/2 {
+		interval = minTraceWait / 2
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = e.send(context.Background(), e.ready(time.Now()))
+		case <-e.stopCh:
+			return
+		}
+	}
+}
+
+// ready removes the traces which didn't get new spans for the trace wait, or
+// which were held for the max trace wait.
+func (e *tracesExporter) ready(now time.Time) []*pendingTrace {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var ready []*pendingTrace
+	for id, trace := range e.pending {
+		if now.Sub(trace.last) >= e.cfg.TraceWait || now.Sub(trace.first) >= e.cfg.MaxTraceWait {
+			trace.sent = true
+			delete(e.pending, id)
+			ready = append(ready, trace)
+		}
+	}
+	if len(ready) > 0 {
+		order := e.order[:0]
+		for _, trace := range e.order {
+			if !trace.sent {
+				order = append(order, trace)
+			}
+		}
+		e.order = order
+	}
+	return ready
+}
+
+// send passes the spans of the traces to the wrapped exporter, at once.
+func (e *tracesExporter) send(ctx context.Context, traces []*pendingTrace) error {
+	if len(traces) == 0 {
+		return nil
+	}
+	td := ptrace.NewTraces()
+	for _, trace := range traces {
+		trace.traces.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+	err := e.TracesExporter.ConsumeTraces(ctx, td)
+	if err != nil {
+		e.logger.Error("Failed to send the held traces", zap.Int("traces", len(traces)), zap.Error(err))
+	}
+	return err
+}
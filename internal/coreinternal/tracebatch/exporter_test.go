@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracebatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+type sinkExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumertest.TracesSink
+}
+
+func newTraces(ids ...byte) ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, id := range ids {
+		spans.AppendEmpty().SetTraceID(pcommon.TraceID([16]byte{id}))
+	}
+	return td
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    Config
+		errMsg string
+	}{
+		{name: "default", cfg: NewDefaultConfig()},
+		{name: "trace wait", cfg: Config{TraceWait: time.Second, MaxTraceWait: time.Second, MaxTraces: 1}},
+		{name: "negative trace wait", cfg: Config{TraceWait: -time.Second}, errMsg: "trace_wait must not be negative"},
+		{name: "short trace wait", cfg: Config{TraceWait: time.Nanosecond, MaxTraceWait: time.Second, MaxTraces: 1}, errMsg: "trace_wait must be at least 10ms"},
+		{name: "max trace wait", cfg: Config{TraceWait: time.Second, MaxTraces: 1}, errMsg: "max_trace_wait must not be lower than trace_wait"},
+		{name: "max traces", cfg: Config{TraceWait: time.Second, MaxTraceWait: time.Second}, errMsg: "max_traces must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestNewTracesExporterDisabled(t *testing.T) {
+	sink := &sinkExporter{}
+	assert.Same(t, sink, NewTracesExporter(NewDefaultConfig(), sink, zap.NewNop()))
+}
+
+func TestTracesExporterHoldsTraces(t *testing.T) {
+	sink := &sinkExporter{}
+	cfg := Config{TraceWait: 50 * time.Millisecond, MaxTraceWait: time.Minute, MaxTraces: 10}
+	exp := NewTracesExporter(cfg, sink, zap.NewNop())
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), newTraces(1, 2)))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), newTraces(1)))
+	assert.Equal(t, 0, sink.SpanCount())
+
+	require.Eventually(t, func() bool { return sink.SpanCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+	// the spans of the traces are sent at once
+	require.Len(t, sink.AllTraces(), 1)
+	rss := sink.AllTraces()[0].ResourceSpans()
+	require.Equal(t, 3, rss.Len())
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestTracesExporterMaxTraces(t *testing.T) {
+	sink := &sinkExporter{}
+	cfg := Config{TraceWait: time.Minute, MaxTraceWait: time.Minute, MaxTraces: 2}
+	exp := NewTracesExporter(cfg, sink, zap.NewNop())
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), newTraces(1, 2)))
+	assert.Equal(t, 0, sink.SpanCount())
+	require.NoError(t, exp.ConsumeTraces(context.Background(), newTraces(3)))
+	require.Equal(t, 1, sink.SpanCount())
+	assert.Equal(t, pcommon.TraceID([16]byte{1}),
+		sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+
+	// the pending traces are sent on shutdown
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.Equal(t, 3, sink.SpanCount())
+}
+
+func TestTracesExporterShutdownNotStarted(t *testing.T) {
+	sink := &sinkExporter{}
+	cfg := Config{TraceWait: time.Minute, MaxTraceWait: time.Minute, MaxTraces: 2}
+	exp := NewTracesExporter(cfg, sink, zap.NewNop())
+	require.NoError(t, exp.ConsumeTraces(context.Background(), newTraces(1)))
+
+	// the pending traces are sent without waiting for the flush loop
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestTracesExporterShortTraceWait(t *testing.T) {
+	sink := &sinkExporter{}
+	cfg := Config{TraceWait: time.Nanosecond, MaxTraceWait: time.Minute, MaxTraces: 10}
+	exp := NewTracesExporter(cfg, sink, zap.NewNop())
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), newTraces(1)))
+	require.Eventually(t, func() bool { return sink.SpanCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package unitconv converts the values of metrics between units of time, data
+// or ratio, updating the unit of the metrics.
+//
+// The units are the case-sensitive UCUM units used by the semantic conventions,
+// such as "ms", "s", "By" or "MiBy", optionally divided by another unit, such
+// as "By/s".
+package unitconv // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/unitconv"
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unitconv // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/unitconv"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type dimension string
+
+const (
+	dimensionTime  dimension = "time"
+	dimensionData  dimension = "data"
+	dimensionRatio dimension = "ratio"
+)
+
+// unit is worth num/den of the base unit of its dimension. The ratio is kept
+// as two integers for the factors between units to be exact whenever possible.
+type unit struct {
+	dimension dimension
+	num       float64
+	den       float64
+}
+
+var units = map[string]unit{
+	"ns":  {dimensionTime, 1, 1e9},
+	"us":  {dimensionTime, 1, 1e6},
+	"ms":  {dimensionTime, 1, 1e3},
+	"s":   {dimensionTime, 1, 1},
+	"min": {dimensionTime, 60, 1},
+	"h":   {dimensionTime, 3600, 1},
+	"d":   {dimensionTime, 86400, 1},
+
+	"bit":  {dimensionData, 1, 8},
+	"kbit": {dimensionData, 1e3, 8},
+	"Mbit": {dimensionData, 1e6, 8},
+	"Gbit": {dimensionData, 1e9, 8},
+	"By":   {dimensionData, 1, 1},
+	"kBy":  {dimensionData, 1e3, 1},
+	"MBy":  {dimensionData, 1e6, 1},
+	"GBy":  {dimensionData, 1e9, 1},
+	"TBy":  {dimensionData, 1e12, 1},
+	"KiBy": {dimensionData, 1 << 10, 1},
+	"MiBy": {dimensionData, 1 << 20, 1},
+	"GiBy": {dimensionData, 1 << 30, 1},
+	"TiBy": {dimensionData, 1 << 40, 1},
+
+	"1": {dimensionRatio, 1, 1},
+	"%": {dimensionRatio, 1, 100},
+}
+
+// Factor returns the factor the values expressed in the from unit are
+// multiplied by to be expressed in the to unit.
+func Factor(from, to string) (float64, error) {
+	fromNum, fromDen, err := parse(from)
+	if err != nil {
+		return 0, err
+	}
+	toNum, toDen, err := parse(to)
+	if err != nil {
+		return 0, err
+	}
+	if fromNum.dimension != toNum.dimension || fromDen.dimension != toDen.dimension {
+		return 0, fmt.Errorf("unit %q can't be converted to %q", from, to)
+	}
+
+	// from/to = (fromNum/fromDen) / (toNum/toDen)
+	num := fromNum.num * toNum.den * fromDen.den * toDen.num
+	den := fromNum.den * toNum.num * fromDen.num * toDen.den
+	return num / den, nil
+}
+
+// parse returns the unit, and the unit it is divided by, which is "1" when none.
+func parse(s string) (unit, unit, error) {
+	numName, denName, divided := strings.Cut(s, "/")
+	if !divided {
+		denName = "1"
+	}
+	num, ok := units[numName]
+	if !ok {
+		return unit{}, unit{}, fmt.Errorf("unsupported unit %q", s)
+	}
+	den, ok := units[denName]
+	if !ok {
+		return unit{}, unit{}, fmt.Errorf("unsupported unit %q", s)
+	}
+	return num, den, nil
+}
+
+// ConvertMetric converts the values of all the data points of the metric from
+// its unit to the given unit, and sets its unit. The int values are converted
+// to double values.
+func ConvertMetric(metric pmetric.Metric, to string) error {
+	if metric.Unit() == to {
+		return nil
+	}
+	factor, err := Factor(metric.Unit(), to)
+	if err != nil {
+		return err
+	}
+	if err = ScaleMetric(metric, factor); err != nil {
+		return err
+	}
+	metric.SetUnit(to)
+	return nil
+}
+
+// ScaleMetric multiplies the values of all the data points of the metric by
+// the factor. The exponential histograms can't be scaled by an arbitrary factor.
+func ScaleMetric(metric pmetric.Metric, factor float64) error {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		scaleNumberDataPoints(metric.Gauge().DataPoints(), factor)
+	case pmetric.MetricTypeSum:
+		scaleNumberDataPoints(metric.Sum().DataPoints(), factor)
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			ScaleHistogramDataPoint(dps.At(i), factor)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			ScaleSummaryDataPoint(dps.At(i), factor)
+		}
+	default:
+		return fmt.Errorf("the values of %s metrics can't be scaled", metric.Type())
+	}
+	return nil
+}
+
+func scaleNumberDataPoints(dps pmetric.NumberDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		ScaleNumberDataPoint(dps.At(i), factor)
+	}
+}
+
+// ScaleNumberDataPoint multiplies the value of the data point, and of its
+// exemplars, by the factor.
+func ScaleNumberDataPoint(dp pmetric.NumberDataPoint, factor float64) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		dp.SetDoubleValue(float64(dp.IntValue()) * factor)
+	case pmetric.NumberDataPointValueTypeDouble:
+		dp.SetDoubleValue(dp.DoubleValue() * factor)
+	}
+	scaleExemplars(dp.Exemplars(), factor)
+}
+
+// ScaleHistogramDataPoint multiplies the sum, min, max and bucket bounds of
+// the data point, and the values of its exemplars, by the factor.
+func ScaleHistogramDataPoint(dp pmetric.HistogramDataPoint, factor float64) {
+	if dp.HasSum() {
+		dp.SetSum(dp.Sum() * factor)
+	}
+	if dp.HasMin() {
+		dp.SetMin(dp.Min() * factor)
+	}
+	if dp.HasMax() {
+		dp.SetMax(dp.Max() * factor)
+	}
+	bounds := dp.ExplicitBounds()
+	for i := 0; i < bounds.Len(); i++ {
+		bounds.SetAt(i, bounds.At(i)*factor)
+	}
+	scaleExemplars(dp.Exemplars(), factor)
+}
+
+// ScaleSummaryDataPoint multiplies the sum and the quantile values of the data
+// point by the factor.
+func ScaleSummaryDataPoint(dp pmetric.SummaryDataPoint, factor float64) {
+	dp.SetSum(dp.Sum() * factor)
+	quantiles := dp.QuantileValues()
+	for i := 0; i < quantiles.Len(); i++ {
+		quantiles.At(i).SetValue(quantiles.At(i).Value() * factor)
+	}
+}
+
+func scaleExemplars(exemplars pmetric.ExemplarSlice, factor float64) {
+	for i := 0; i < exemplars.Len(); i++ {
+		e := exemplars.At(i)
+		switch e.ValueType() {
+		case pmetric.ExemplarValueTypeInt:
+			e.SetDoubleValue(float64(e.IntValue()) * factor)
+		case pmetric.ExemplarValueTypeDouble:
+			e.SetDoubleValue(e.DoubleValue() * factor)
+		}
+	}
+}
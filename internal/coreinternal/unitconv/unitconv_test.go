@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unitconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestFactor(t *testing.T) {
+	tests := []struct {
+		from   string
+		to     string
+		factor float64
+		err    string
+	}{
+		{from: "s", to: "ms", factor: 1000},
+		{from: "ms", to: "s", factor: 0.001},
+		{from: "ns", to: "s", factor: 1e-9},
+		{from: "h", to: "min", factor: 60},
+		{from: "By", to: "MiBy", factor: 1.0 / (1 << 20)},
+		{from: "GiBy", to: "MiBy", factor: 1024},
+		{from: "By", to: "bit", factor: 8},
+		{from: "kBy", to: "By", factor: 1000},
+		{from: "By/s", to: "Mbit/s", factor: 8e-6},
+		{from: "By/ms", to: "By/s", factor: 1000},
+		{from: "1", to: "%", factor: 100},
+		{from: "s", to: "s", factor: 1},
+		{from: "s", to: "By", err: `unit "s" can't be converted to "By"`},
+		{from: "By/s", to: "By", err: `unit "By/s" can't be converted to "By"`},
+		{from: "{requests}", to: "1", err: `unsupported unit "{requests}"`},
+		{from: "s", to: "", err: `unsupported unit ""`},
+		{from: "By/week", to: "By/s", err: `unsupported unit "By/week"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			factor, err := Factor(tt.from, tt.to)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.factor, factor, tt.factor*1e-12)
+		})
+	}
+}
+
+func TestConvertMetric(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetUnit("s")
+	dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetIntValue(2)
+	dp.Exemplars().AppendEmpty().SetDoubleValue(1.5)
+
+	require.NoError(t, ConvertMetric(metric, "ms"))
+	assert.Equal(t, "ms", metric.Unit())
+	assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+	assert.Equal(t, 2000.0, dp.DoubleValue())
+	assert.Equal(t, 1500.0, dp.Exemplars().At(0).DoubleValue())
+
+	// converting to the same unit leaves the values unchanged
+	require.NoError(t, ConvertMetric(metric, "ms"))
+	assert.Equal(t, 2000.0, dp.DoubleValue())
+
+	assert.EqualError(t, ConvertMetric(metric, "By"), `unit "ms" can't be converted to "By"`)
+	assert.Equal(t, "ms", metric.Unit())
+}
+
+func TestScaleMetric(t *testing.T) {
+	histogram := pmetric.NewMetric()
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetSum(10)
+	hdp.SetMin(1)
+	hdp.SetMax(5)
+	hdp.ExplicitBounds().FromRaw([]float64{1, 2})
+	hdp.Exemplars().AppendEmpty().SetIntValue(3)
+	require.NoError(t, ScaleMetric(histogram, 2))
+	assert.Equal(t, 20.0, hdp.Sum())
+	assert.Equal(t, 2.0, hdp.Min())
+	assert.Equal(t, 10.0, hdp.Max())
+	assert.Equal(t, []float64{2, 4}, hdp.ExplicitBounds().AsRaw())
+	assert.Equal(t, 6.0, hdp.Exemplars().At(0).DoubleValue())
+
+	summary := pmetric.NewMetric()
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetSum(10)
+	sdp.QuantileValues().AppendEmpty().SetValue(4)
+	require.NoError(t, ScaleMetric(summary, 0.5))
+	assert.Equal(t, 5.0, sdp.Sum())
+	assert.Equal(t, 2.0, sdp.QuantileValues().At(0).Value())
+
+	sum := pmetric.NewMetric()
+	sum.SetEmptySum().DataPoints().AppendEmpty().SetDoubleValue(3)
+	require.NoError(t, ScaleMetric(sum, 2))
+	assert.Equal(t, 6.0, sum.Sum().DataPoints().At(0).DoubleValue())
+
+	exponential := pmetric.NewMetric()
+	exponential.SetEmptyExponentialHistogram()
+	assert.EqualError(t, ScaleMetric(exponential, 2), "the values of ExponentialHistogram metrics can't be scaled")
+}
@@ -30,7 +30,7 @@ type Config struct {
 	// A list of filters whose matching images are to be excluded. Supports literals, globs, and regex.
 	ExcludedImages []string `mapstructure:"excluded_images"`
 
-	// Docker client API version.
+	// Docker client API version. The API version is negotiated with the daemon when 0.
 	DockerAPIVersion float64 `mapstructure:"api_version"`
 }
 
@@ -66,7 +66,7 @@ func (config Config) validate() error {
 	if config.Endpoint == "" {
 		return errors.New("config.Endpoint must be specified")
 	}
-	if config.DockerAPIVersion < minimalRequiredDockerAPIVersion {
+	if config.DockerAPIVersion != 0 && config.DockerAPIVersion < minimalRequiredDockerAPIVersion {
 		return fmt.Errorf("Docker API version must be at least %v", minimalRequiredDockerAPIVersion)
 	}
 	return nil
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -27,6 +28,7 @@ import (
 	dtypes "github.com/docker/docker/api/types"
 	devents "github.com/docker/docker/api/types/events"
 	dfilters "github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/versions"
 	docker "github.com/docker/docker/client"
 	"go.uber.org/zap"
 )
@@ -57,13 +59,17 @@ type Client struct {
 }
 
 func NewDockerClient(config *Config, logger *zap.Logger, opts ...docker.Opt) (*Client, error) {
-	client, err := docker.NewClientWithOpts(
-		append([]docker.Opt{
-			docker.WithHost(config.Endpoint),
-			docker.WithVersion(fmt.Sprintf("v%v", config.DockerAPIVersion)),
-			docker.WithHTTPHeaders(map[string]string{"User-Agent": userAgent}),
-		}, opts...)...,
-	)
+	clientOpts := []docker.Opt{
+		docker.WithHost(hostFromEndpoint(config.Endpoint)),
+		docker.WithHTTPHeaders(map[string]string{"User-Agent": userAgent}),
+	}
+	if config.DockerAPIVersion == 0 {
+		// Use the highest API version supported by both the client and the daemon.
+		clientOpts = append(clientOpts, docker.WithAPIVersionNegotiation())
+	} else {
+		clientOpts = append(clientOpts, docker.WithVersion(apiVersionString(config.DockerAPIVersion)))
+	}
+	client, err := docker.NewClientWithOpts(append(clientOpts, opts...)...)
 	if err != nil {
 		return nil, fmt.Errorf("could not create docker client: %w", err)
 	}
@@ -85,6 +91,39 @@ func NewDockerClient(config *Config, logger *zap.Logger, opts ...docker.Opt) (*C
 	return dc, nil
 }
 
+// apiVersionString formats an API version with two decimals, as 1.40 would
+// otherwise be formatted as "1.4".
+func apiVersionString(version float64) string {
+	return strconv.FormatFloat(version, 'f', 2, 64)
+}
+
+// hostFromEndpoint returns the docker host of the endpoint. Endpoints that are a plain socket
+// path, e.g. "/run/podman/podman.sock", are treated as unix sockets.
+func hostFromEndpoint(endpoint string) string {
+	if strings.HasPrefix(endpoint, "/") {
+		return "unix://" + endpoint
+	}
+	return endpoint
+}
+
+// NegotiateAPIVersion negotiates the API version with the daemon, unless an API version
+// is configured, and verifies that the API version in use is supported.
+// It works with any daemon exposing the Docker Engine API, including Podman.
+func (dc *Client) NegotiateAPIVersion(ctx context.Context) error {
+	if dc.config.DockerAPIVersion == 0 {
+		pingCtx, cancel := context.WithTimeout(ctx, dc.config.Timeout)
+		defer cancel()
+		dc.client.NegotiateAPIVersion(pingCtx)
+	}
+
+	version := dc.client.ClientVersion()
+	if versions.LessThan(version, apiVersionString(minimalRequiredDockerAPIVersion)) {
+		return fmt.Errorf("Docker API version %s is not supported, must be at least %s", version, apiVersionString(minimalRequiredDockerAPIVersion))
+	}
+	dc.logger.Debug("Using Docker API version", zap.String("version", version))
+	return nil
+}
+
 // Containers provides a slice of Container to use for individual FetchContainerStats calls.
 func (dc *Client) Containers() []Container {
 	dc.containersLock.Lock()
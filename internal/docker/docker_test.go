@@ -264,3 +264,66 @@ func TestEventLoopHandlesError(t *testing.T) {
 		return
 	}
 }
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		apiVersion      float64
+		serverVersion   string
+		expectedVersion string
+		expectedErr     string
+	}{
+		{
+			name:            "negotiated",
+			serverVersion:   "1.40",
+			expectedVersion: "1.40",
+		},
+		{
+			name:          "negotiated version not supported",
+			serverVersion: "1.21",
+			expectedErr:   "Docker API version 1.21 is not supported, must be at least 1.22",
+		},
+		{
+			name:            "configured",
+			apiVersion:      1.24,
+			serverVersion:   "1.41",
+			expectedVersion: "1.24",
+		},
+		{
+			name:            "configured with trailing zero",
+			apiVersion:      1.40,
+			serverVersion:   "1.41",
+			expectedVersion: "1.40",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Api-Version", tt.serverVersion)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			cli, err := NewDockerClient(&Config{
+				Endpoint:         srv.URL,
+				Timeout:          time.Second,
+				DockerAPIVersion: tt.apiVersion,
+			}, zap.NewNop())
+			require.NoError(t, err)
+
+			err = cli.NegotiateAPIVersion(context.Background())
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, cli.client.ClientVersion())
+		})
+	}
+}
+
+func TestHostFromEndpoint(t *testing.T) {
+	assert.Equal(t, "unix:///run/podman/podman.sock", hostFromEndpoint("/run/podman/podman.sock"))
+	assert.Equal(t, "unix:///var/run/docker.sock", hostFromEndpoint("unix:///var/run/docker.sock"))
+	assert.Equal(t, "tcp://localhost:2375", hostFromEndpoint("tcp://localhost:2375"))
+}
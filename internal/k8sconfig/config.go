@@ -22,6 +22,7 @@ import (
 
 	quotaclientset "github.com/openshift/client-go/quota/clientset/versioned"
 	k8sruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
 	k8s "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -63,6 +64,10 @@ type APIConfig struct {
 	// token provided to the agent pod), or `kubeConfig` to use credentials
 	// from `~/.kube/config`.
 	AuthType AuthType `mapstructure:"auth_type"`
+
+	// Context is the name of the kubeconfig context to use when AuthType is
+	// `kubeConfig`. The current context of the kubeconfig file is used if empty.
+	Context string `mapstructure:"context"`
 }
 
 // Validate validates the K8s API config
@@ -70,6 +75,9 @@ func (c APIConfig) Validate() error {
 	if !authTypes[c.AuthType] {
 		return fmt.Errorf("invalid authType for kubernetes: %v", c.AuthType)
 	}
+	if c.Context != "" && c.AuthType != AuthTypeKubeConfig {
+		return fmt.Errorf("context can only be used with authType %s", AuthTypeKubeConfig)
+	}
 
 	return nil
 }
@@ -93,7 +101,7 @@ func createRestConfig(apiConf APIConfig) (*rest.Config, error) {
 	switch authType {
 	case AuthTypeKubeConfig:
 		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-		configOverrides := &clientcmd.ConfigOverrides{}
+		configOverrides := &clientcmd.ConfigOverrides{CurrentContext: apiConf.Context}
 		authConf, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 			loadingRules, configOverrides).ClientConfig()
 
@@ -144,6 +152,26 @@ func MakeClient(apiConf APIConfig) (k8s.Interface, error) {
 	return client, nil
 }
 
+// MakeDynamicClient can take configuration if needed for other types of auth
+// and return a client for arbitrary, possibly custom, K8s resources
+func MakeDynamicClient(apiConf APIConfig) (dynamic.Interface, error) {
+	if err := apiConf.Validate(); err != nil {
+		return nil, err
+	}
+
+	authConf, err := createRestConfig(apiConf)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := dynamic.NewForConfig(authConf)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
 // MakeOpenShiftQuotaClient can take configuration if needed for other types of auth
 // and return an OpenShift quota API client
 func MakeOpenShiftQuotaClient(apiConf APIConfig) (quotaclientset.Interface, error) {
@@ -3,6 +3,7 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk
 go 1.18
 
 require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.61.0
 	github.com/stretchr/testify v1.8.0
 	go.opentelemetry.io/collector v0.61.1-0.20221004012633-7cb544d3be36
 	go.opentelemetry.io/collector/pdata v0.61.1-0.20221004012633-7cb544d3be36
@@ -41,3 +42,5 @@ require (
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/common => ../common
@@ -15,18 +15,34 @@
 package splunk // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httputil"
-	"strconv"
-	"time"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/throttle"
+)
+
+const (
+	HeaderRetryAfter = throttle.HeaderRetryAfter
+
+	// hecServerBusyCode is the code of the HEC responses sent when the server is busy.
+	hecServerBusyCode = 9
+	// maxBodySize bounds the part of the response body read to find the HEC response.
+	maxBodySize = 4096
 )
 
-const HeaderRetryAfter = "Retry-After"
+// hecResponse is the body of the responses of the Splunk HTTP Event Collector.
+type hecResponse struct {
+	Text string `json:"text"`
+	Code int    `json:"code"`
+}
 
 // HandleHTTPCode handles an http response and returns the right type of error in case of a failure.
 func HandleHTTPCode(resp *http.Response) error {
@@ -40,21 +56,22 @@ func HandleHTTPCode(resp *http.Response) error {
 		resp.StatusCode,
 		http.StatusText(resp.StatusCode))
 
-	switch resp.StatusCode {
+	// The HEC response tells whether the server is busy, even when a proxy in front of it changed the status.
+	hecResp, isHEC := readHECResponse(resp)
+	if isHEC && hecResp.Text != "" {
+		err = fmt.Errorf("%w: %s", err, hecResp.Text)
+	}
+
+	switch {
 	// Check for responses that may include "Retry-After" header.
-	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
-		// Fallback to 0 if the Retry-After header is not present. This will trigger the
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable ||
+		(isHEC && hecResp.Code == hecServerBusyCode):
+		// Fallback to 0 if the response has no throttling hint. This will trigger the
 		// default backoff policy by our caller (retry handler).
-		retryAfter := 0
-		if val := resp.Header.Get(HeaderRetryAfter); val != "" {
-			if seconds, err2 := strconv.Atoi(val); err2 == nil {
-				retryAfter = seconds
-			}
-		}
-		// Indicate to our caller to pause for the specified number of seconds.
-		err = exporterhelper.NewThrottleRetry(err, time.Duration(retryAfter)*time.Second)
+		// Indicate to our caller to pause for the specified delay.
+		err = exporterhelper.NewThrottleRetry(err, throttle.RetryAfter(resp.Header))
 	// Check for permanent errors.
-	case http.StatusBadRequest, http.StatusUnauthorized:
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized:
 		dump, err2 := httputil.DumpResponse(resp, true)
 		if err2 == nil {
 			err = consumererror.NewPermanent(fmt.Errorf("%w", fmt.Errorf("%q", dump)))
@@ -65,3 +82,27 @@ func HandleHTTPCode(resp *http.Response) error {
 
 	return err
 }
+
+// readHECResponse decodes the HEC response from the beginning of the body, which
+// is then restored for the later readers. It returns false if the body isn't a
+// HEC response, such as for the responses of the SignalFx ingest API.
+func readHECResponse(resp *http.Response) (hecResponse, bool) {
+	var hecResp hecResponse
+	if resp.Body == nil {
+		return hecResp, false
+	}
+
+	head, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	if err != nil {
+		return hecResp, false
+	}
+
+	if err = json.Unmarshal(head, &hecResp); err != nil || (hecResp.Text == "" && hecResp.Code == 0) {
+		return hecResp, false
+	}
+	return hecResp, true
+}
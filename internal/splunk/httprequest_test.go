@@ -16,12 +16,15 @@ package splunk
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -95,3 +98,66 @@ func TestConsumeMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleHTTPCodeHECResponse(t *testing.T) {
+	tests := []struct {
+		name             string
+		httpResponseCode int
+		body             string
+		wantErr          string
+		wantPermanentErr bool
+		wantThrottleErr  bool
+	}{
+		{
+			name:             "server_busy",
+			httpResponseCode: http.StatusServiceUnavailable,
+			body:             `{"text":"Server is busy","code":9}`,
+			wantErr:          `HTTP 503 "Service Unavailable": Server is busy`,
+			wantThrottleErr:  true,
+		},
+		{
+			name:             "server_busy_behind_proxy",
+			httpResponseCode: http.StatusBadGateway,
+			body:             `{"text":"Server is busy","code":9}`,
+			wantErr:          `HTTP 502 "Bad Gateway": Server is busy`,
+			wantThrottleErr:  true,
+		},
+		{
+			name:             "internal_error",
+			httpResponseCode: http.StatusInternalServerError,
+			body:             `{"text":"Internal server error","code":8}`,
+			wantErr:          `HTTP 500 "Internal Server Error": Internal server error`,
+		},
+		{
+			name:             "not_hec_response",
+			httpResponseCode: http.StatusInternalServerError,
+			body:             `upstream connect error`,
+			wantErr:          `HTTP 500 "Internal Server Error"`,
+		},
+		{
+			name:             "invalid_data",
+			httpResponseCode: http.StatusBadRequest,
+			body:             `{"text":"Invalid data format","code":6}`,
+			wantErr:          `"HTTP/0.0 400 Bad Request\r\n\r\n{\"text\":\"Invalid data format\",\"code\":6}"`,
+			wantPermanentErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.httpResponseCode,
+				Status:     fmt.Sprintf("%d %s", tt.httpResponseCode, http.StatusText(tt.httpResponseCode)),
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+
+			err := HandleHTTPCode(resp)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantPermanentErr, consumererror.IsPermanent(err))
+			if tt.wantThrottleErr {
+				assert.IsType(t, exporterhelper.NewThrottleRetry(nil, 0), err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlquery // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+)
+
+// Query is a SQL statement, and the definitions of the metrics of its rows.
+type Query struct {
+	SQL     string      `mapstructure:"sql"`
+	Metrics []MetricCfg `mapstructure:"metrics"`
+}
+
+func (q Query) Validate() error {
+	var errs error
+	if q.SQL == "" {
+		errs = multierr.Append(errs, errors.New("'query.sql' cannot be empty"))
+	}
+	if len(q.Metrics) == 0 {
+		errs = multierr.Append(errs, errors.New("'query.metrics' cannot be empty"))
+	}
+	for _, metric := range q.Metrics {
+		if err := metric.Validate(); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// MetricCfg defines a metric of the rows of a query: the column of its value,
+// the columns of its attributes, and its type.
+type MetricCfg struct {
+	MetricName       string            `mapstructure:"metric_name"`
+	ValueColumn      string            `mapstructure:"value_column"`
+	AttributeColumns []string          `mapstructure:"attribute_columns"`
+	Monotonic        bool              `mapstructure:"monotonic"`
+	ValueType        MetricValueType   `mapstructure:"value_type"`
+	DataType         MetricType        `mapstructure:"data_type"`
+	Aggregation      MetricAggregation `mapstructure:"aggregation"`
+	Unit             string            `mapstructure:"unit"`
+	Description      string            `mapstructure:"description"`
+	StaticAttributes map[string]string `mapstructure:"static_attributes"`
+}
+
+func (c MetricCfg) Validate() error {
+	var errs error
+	if c.MetricName == "" {
+		errs = multierr.Append(errs, errors.New("'metric_name' cannot be empty"))
+	}
+	if c.ValueColumn == "" {
+		errs = multierr.Append(errs, errors.New("'value_column' cannot be empty"))
+	}
+	if err := c.ValueType.Validate(); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	if err := c.DataType.Validate(); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	if err := c.Aggregation.Validate(); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	if c.DataType == MetricTypeGauge && c.Aggregation != "" {
+		errs = multierr.Append(errs, fmt.Errorf("aggregation=%s but data_type=%s does not support aggregation", c.Aggregation, c.DataType))
+	}
+	if errs != nil && c.MetricName != "" {
+		errs = multierr.Append(fmt.Errorf("invalid metric config with metric_name '%s'", c.MetricName), errs)
+	}
+	return errs
+}
+
+type MetricType string
+
+const (
+	MetricTypeUnspecified MetricType = ""
+	MetricTypeGauge       MetricType = "gauge"
+	MetricTypeSum         MetricType = "sum"
+)
+
+func (t MetricType) Validate() error {
+	switch t {
+	case MetricTypeUnspecified, MetricTypeGauge, MetricTypeSum:
+		return nil
+	}
+	return fmt.Errorf("metric config has unsupported data_type: '%s'", t)
+}
+
+type MetricValueType string
+
+const (
+	MetricValueTypeUnspecified MetricValueType = ""
+	MetricValueTypeInt         MetricValueType = "int"
+	MetricValueTypeDouble      MetricValueType = "double"
+)
+
+func (t MetricValueType) Validate() error {
+	switch t {
+	case MetricValueTypeUnspecified, MetricValueTypeInt, MetricValueTypeDouble:
+		return nil
+	}
+	return fmt.Errorf("metric config has unsupported value_type: '%s'", t)
+}
+
+type MetricAggregation string
+
+const (
+	MetricAggregationUnspecified MetricAggregation = ""
+	MetricAggregationCumulative  MetricAggregation = "cumulative"
+	MetricAggregationDelta       MetricAggregation = "delta"
+)
+
+func (a MetricAggregation) Validate() error {
+	switch a {
+	case MetricAggregationUnspecified, MetricAggregationCumulative, MetricAggregationDelta:
+		return nil
+	}
+	return fmt.Errorf("metric config has unsupported aggregation: '%s'", a)
+}
+
+// ValidateQueries validates a list of queries, returning the errors of all of them.
+func ValidateQueries(queries []Query) error {
+	var errs error
+	for _, query := range queries {
+		errs = multierr.Append(errs, query.Validate())
+	}
+	return errs
+}
+
+// LoadQueries reads and validates the queries defined in YAML under the
+// `queries` key, as a receiver configuration would, e.g. the built-in queries
+// of a receiver.
+func LoadQueries(data []byte) ([]Query, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse the queries: %w", err)
+	}
+	var defs struct {
+		Queries []Query `mapstructure:"queries"`
+	}
+	if err := confmap.NewFromStringMap(raw).UnmarshalExact(&defs); err != nil {
+		return nil, fmt.Errorf("failed to decode the queries: %w", err)
+	}
+	if len(defs.Queries) == 0 {
+		return nil, errors.New("'queries' cannot be empty")
+	}
+	if err := ValidateQueries(defs.Queries); err != nil {
+		return nil, err
+	}
+	return defs.Queries, nil
+}
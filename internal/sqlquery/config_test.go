@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadQueries(t *testing.T) {
+	queries, err := LoadQueries([]byte(`
+queries:
+  - sql: "select count(*) as count, type from mytable group by type"
+    metrics:
+      - metric_name: val.count
+        value_column: count
+        attribute_columns: [type]
+        data_type: sum
+        monotonic: true
+        unit: "{rows}"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Query{{
+		SQL: "select count(*) as count, type from mytable group by type",
+		Metrics: []MetricCfg{{
+			MetricName:       "val.count",
+			ValueColumn:      "count",
+			AttributeColumns: []string{"type"},
+			DataType:         MetricTypeSum,
+			Monotonic:        true,
+			Unit:             "{rows}",
+		}},
+	}}, queries)
+}
+
+func TestLoadQueriesErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{
+			name:        "invalid yaml",
+			data:        "queries: [",
+			expectedErr: "failed to parse the queries",
+		},
+		{
+			name:        "unknown field",
+			data:        "queries:\n  - sql: select 1\n    metric: []",
+			expectedErr: "failed to decode the queries",
+		},
+		{
+			name:        "no queries",
+			data:        "queries: []",
+			expectedErr: "'queries' cannot be empty",
+		},
+		{
+			name:        "invalid metric",
+			data:        "queries:\n  - sql: select 1 as one\n    metrics:\n      - metric_name: one\n        value_column: one\n        data_type: histogram",
+			expectedErr: "metric config has unsupported data_type: 'histogram'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadQueries([]byte(tt.data))
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+package sqlquery // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 
 import (
 	"context"
@@ -20,27 +20,28 @@ import (
 	"fmt"
 	"reflect"
 
-	// register db drivers
-	_ "github.com/SAP/go-hdb/driver"
-	_ "github.com/denisenkom/go-mssqldb"
-	_ "github.com/go-sql-driver/mysql"
-	_ "github.com/lib/pq"
-	_ "github.com/sijms/go-ora/v2"
-	_ "github.com/snowflakedb/gosnowflake"
 	"go.uber.org/zap"
 )
 
-type dbClient interface {
-	metricRows(ctx context.Context) ([]metricRow, error)
+// DbClient runs a query, returning its rows.
+type DbClient interface {
+	MetricRows(ctx context.Context) ([]MetricRow, error)
 }
 
+// DbProviderFunc opens the database the queries are run on.
+type DbProviderFunc func() (*sql.DB, error)
+
+// ClientProviderFunc returns the client running a query on a database.
+type ClientProviderFunc func(*sql.DB, string, *zap.Logger) DbClient
+
 type dbSQLClient struct {
 	db     *sql.DB
 	logger *zap.Logger
 	sql    string
 }
 
-func newDbClient(db *sql.DB, sql string, logger *zap.Logger) dbClient {
+// NewDbClient returns the client running the sql query on db.
+func NewDbClient(db *sql.DB, sql string, logger *zap.Logger) DbClient {
 	return dbSQLClient{
 		db:     db,
 		sql:    sql,
@@ -48,14 +49,15 @@ func newDbClient(db *sql.DB, sql string, logger *zap.Logger) dbClient {
 	}
 }
 
-type metricRow map[string]string
+// MetricRow is a row of a query, the values of its columns by column name.
+type MetricRow map[string]string
 
-func (cl dbSQLClient) metricRows(ctx context.Context) ([]metricRow, error) {
+func (cl dbSQLClient) MetricRows(ctx context.Context) ([]MetricRow, error) {
 	sqlRows, err := cl.db.QueryContext(ctx, cl.sql)
 	if err != nil {
 		return nil, err
 	}
-	var out []metricRow
+	var out []MetricRow
 	row := reusableRow{
 		attrs: map[string]func() string{},
 	}
@@ -93,8 +95,8 @@ type reusableRow struct {
 	scanDest []interface{}
 }
 
-func (row reusableRow) toMetricRow() metricRow {
-	out := metricRow{}
+func (row reusableRow) toMetricRow() MetricRow {
+	out := MetricRow{}
 	for k, f := range row.attrs {
 		out[k] = f()
 	}
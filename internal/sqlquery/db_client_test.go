@@ -12,17 +12,17 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package sqlqueryreceiver
+package sqlquery
 
 import "context"
 
 type fakeDBClient struct {
 	requestCounter int
-	responses      [][]metricRow
+	responses      [][]MetricRow
 	err            error
 }
 
-func (c *fakeDBClient) metricRows(context.Context) ([]metricRow, error) {
+func (c *fakeDBClient) MetricRows(context.Context) ([]MetricRow, error) {
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -12,24 +12,40 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+package sqlquery // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
-	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
 )
 
-func rowToMetric(row metricRow, cfg MetricCfg, dest pmetric.Metric, startTime pcommon.Timestamp, ts pcommon.Timestamp, scrapeCfg scraperhelper.ScraperControllerSettings) error {
+// RowsToMetrics appends to dest a metric per row and metric definition of the
+// query. The start time of the cumulative sums is startTime, the one of the
+// delta sums being ts minus the collection interval.
+func RowsToMetrics(rows []MetricRow, query Query, dest pmetric.MetricSlice, startTime pcommon.Timestamp, ts pcommon.Timestamp, collectionInterval time.Duration) error {
+	var errs error
+	for _, metricCfg := range query.Metrics {
+		for i, row := range rows {
+			if err := rowToMetric(row, metricCfg, dest.AppendEmpty(), startTime, ts, collectionInterval); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("row %d: %w", i, err))
+			}
+		}
+	}
+	return errs
+}
+
+func rowToMetric(row MetricRow, cfg MetricCfg, dest pmetric.Metric, startTime pcommon.Timestamp, ts pcommon.Timestamp, collectionInterval time.Duration) error {
 	dest.SetName(cfg.MetricName)
 	dest.SetDescription(cfg.Description)
 	dest.SetUnit(cfg.Unit)
 	dataPointSlice := setMetricFields(cfg, dest)
 	dataPoint := dataPointSlice.AppendEmpty()
-	setTimestamp(cfg, dataPoint, startTime, ts, scrapeCfg)
+	setTimestamp(cfg, dataPoint, startTime, ts, collectionInterval)
 	value, found := row[cfg.ValueColumn]
 	if !found {
 		return fmt.Errorf("rowToMetric: value_column '%s' not found in result set", cfg.ValueColumn)
@@ -52,7 +68,7 @@ func rowToMetric(row metricRow, cfg MetricCfg, dest pmetric.Metric, startTime pc
 	return nil
 }
 
-func setTimestamp(cfg MetricCfg, dp pmetric.NumberDataPoint, startTime pcommon.Timestamp, ts pcommon.Timestamp, scrapeCfg scraperhelper.ScraperControllerSettings) {
+func setTimestamp(cfg MetricCfg, dp pmetric.NumberDataPoint, startTime pcommon.Timestamp, ts pcommon.Timestamp, collectionInterval time.Duration) {
 	dp.SetTimestamp(ts)
 
 	// Cumulative sum should have a start time set to the beginning of the data points cumulation
@@ -62,7 +78,7 @@ func setTimestamp(cfg MetricCfg, dp pmetric.NumberDataPoint, startTime pcommon.T
 
 	// Non-cumulative sum should have a start time set to the previous endpoint
 	if cfg.Aggregation == MetricAggregationDelta && cfg.DataType != MetricTypeGauge {
-		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(ts.AsTime().Add(-scrapeCfg.CollectionInterval)))
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(ts.AsTime().Add(-collectionInterval)))
 	}
 }
 
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+package sqlquery // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 
 import (
 	"context"
@@ -25,29 +25,41 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
-	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
-type scraper struct {
+// Scraper runs a query, and converts its rows to the metrics of its definitions.
+type Scraper struct {
 	id                 config.ComponentID
 	query              Query
 	scrapeCfg          scraperhelper.ScraperControllerSettings
 	startTime          pcommon.Timestamp
-	clientProviderFunc clientProviderFunc
-	dbProviderFunc     dbProviderFunc
+	clientProviderFunc ClientProviderFunc
+	dbProviderFunc     DbProviderFunc
 	logger             *zap.Logger
-	client             dbClient
+	client             DbClient
 	db                 *sql.DB
 }
 
-var _ scraperhelper.Scraper = (*scraper)(nil)
+var _ scraperhelper.Scraper = (*Scraper)(nil)
 
-func (s scraper) ID() config.ComponentID {
+// NewScraper returns the scraper of a query, run on the database of dbProviderFunc.
+func NewScraper(id config.ComponentID, query Query, scrapeCfg scraperhelper.ScraperControllerSettings, logger *zap.Logger, dbProviderFunc DbProviderFunc, clientProviderFunc ClientProviderFunc) *Scraper {
+	return &Scraper{
+		id:                 id,
+		query:              query,
+		scrapeCfg:          scrapeCfg,
+		logger:             logger,
+		dbProviderFunc:     dbProviderFunc,
+		clientProviderFunc: clientProviderFunc,
+	}
+}
+
+func (s Scraper) ID() config.ComponentID {
 	return s.id
 }
 
-func (s *scraper) Start(context.Context, component.Host) error {
+func (s *Scraper) Start(context.Context, component.Host) error {
 	var err error
 	s.db, err = s.dbProviderFunc()
 	if err != nil {
@@ -59,9 +71,9 @@ func (s *scraper) Start(context.Context, component.Host) error {
 	return nil
 }
 
-func (s scraper) Scrape(ctx context.Context) (pmetric.Metrics, error) {
+func (s Scraper) Scrape(ctx context.Context) (pmetric.Metrics, error) {
 	out := pmetric.NewMetrics()
-	rows, err := s.client.metricRows(ctx)
+	rows, err := s.client.MetricRows(ctx)
 	ts := pcommon.NewTimestampFromTime(time.Now())
 	if err != nil {
 		return out, fmt.Errorf("scraper: %w", err)
@@ -71,21 +83,13 @@ func (s scraper) Scrape(ctx context.Context) (pmetric.Metrics, error) {
 	sms := rm.ScopeMetrics()
 	sm := sms.AppendEmpty()
 	ms := sm.Metrics()
-	var errs error
-	for _, metricCfg := range s.query.Metrics {
-		for i, row := range rows {
-			if err = rowToMetric(row, metricCfg, ms.AppendEmpty(), s.startTime, ts, s.scrapeCfg); err != nil {
-				err = fmt.Errorf("row %d: %w", i, err)
-				errs = multierr.Append(errs, err)
-			}
-		}
-	}
+	errs := RowsToMetrics(rows, s.query, ms, s.startTime, ts, s.scrapeCfg.CollectionInterval)
 	if errs != nil {
 		errs = fmt.Errorf("scraper.Scrape row conversion errors: %w", errs)
 	}
 	return out, errs
 }
 
-func (s scraper) Shutdown(ctx context.Context) error {
+func (s Scraper) Shutdown(ctx context.Context) error {
 	return s.db.Close()
 }
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package sqlqueryreceiver
+package sqlquery
 
 import (
 	"context"
@@ -27,7 +27,7 @@ import (
 )
 
 func TestScraper_ErrorOnStart(t *testing.T) {
-	scrpr := scraper{
+	scrpr := Scraper{
 		dbProviderFunc: func() (*sql.DB, error) {
 			return nil, errors.New("oops")
 		},
@@ -40,7 +40,7 @@ func TestScraper_ClientErrorOnScrape(t *testing.T) {
 	client := &fakeDBClient{
 		err: errors.New("oops"),
 	}
-	scrpr := scraper{
+	scrpr := Scraper{
 		client: client,
 	}
 	_, err := scrpr.Scrape(context.Background())
@@ -49,11 +49,11 @@ func TestScraper_ClientErrorOnScrape(t *testing.T) {
 
 func TestScraper_RowToMetricErrorOnScrape_Float(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]MetricRow{
 			{{"myfloat": "blah"}},
 		},
 	}
-	scrpr := scraper{
+	scrpr := Scraper{
 		client: client,
 		query: Query{
 			Metrics: []MetricCfg{{
@@ -74,11 +74,11 @@ func TestScraper_RowToMetricErrorOnScrape_Float(t *testing.T) {
 
 func TestScraper_RowToMetricErrorOnScrape_Int(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]MetricRow{
 			{{"myint": "blah"}},
 		},
 	}
-	scrpr := scraper{
+	scrpr := Scraper{
 		client: client,
 		query: Query{
 			Metrics: []MetricCfg{{
@@ -99,12 +99,12 @@ func TestScraper_RowToMetricErrorOnScrape_Int(t *testing.T) {
 
 func TestScraper_RowToMetricMultiErrorsOnScrape(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{{
+		responses: [][]MetricRow{{
 			{"myint": "foo"},
 			{"myint": "bar"},
 		}},
 	}
-	scrpr := scraper{
+	scrpr := Scraper{
 		client: client,
 		query: Query{
 			Metrics: []MetricCfg{{
@@ -124,9 +124,9 @@ func TestScraper_RowToMetricMultiErrorsOnScrape(t *testing.T) {
 }
 
 func TestScraper_SingleRow_MultiMetrics(t *testing.T) {
-	scrpr := scraper{
+	scrpr := Scraper{
 		client: &fakeDBClient{
-			responses: [][]metricRow{{{
+			responses: [][]MetricRow{{{
 				"count":    "42",
 				"foo_name": "baz",
 				"bar_name": "quux",
@@ -196,7 +196,7 @@ func TestScraper_SingleRow_MultiMetrics(t *testing.T) {
 
 func TestScraper_MultiRow(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{{
+		responses: [][]MetricRow{{
 			{
 				"count": "42",
 				"genre": "action",
@@ -207,7 +207,7 @@ func TestScraper_MultiRow(t *testing.T) {
 			},
 		}},
 	}
-	scrpr := scraper{
+	scrpr := Scraper{
 		client: client,
 		query: Query{
 			Metrics: []MetricCfg{
@@ -242,12 +242,12 @@ func TestScraper_MultiRow(t *testing.T) {
 
 func TestScraper_MultiResults_CumulativeSum(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]MetricRow{
 			{{"count": "42"}},
 			{{"count": "43"}},
 		},
 	}
-	scrpr := scraper{
+	scrpr := Scraper{
 		client: client,
 		query: Query{
 			Metrics: []MetricCfg{{
@@ -265,12 +265,12 @@ func TestScraper_MultiResults_CumulativeSum(t *testing.T) {
 
 func TestScraper_MultiResults_DeltaSum(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]MetricRow{
 			{{"count": "42"}},
 			{{"count": "43"}},
 		},
 	}
-	scrpr := scraper{
+	scrpr := Scraper{
 		client: client,
 		query: Query{
 			Metrics: []MetricCfg{{
@@ -286,7 +286,7 @@ func TestScraper_MultiResults_DeltaSum(t *testing.T) {
 	assertTransactionCount(t, scrpr, 43, pmetric.MetricAggregationTemporalityDelta)
 }
 
-func assertTransactionCount(t *testing.T, scrpr scraper, expected int, agg pmetric.MetricAggregationTemporality) {
+func assertTransactionCount(t *testing.T, scrpr Scraper, expected int, agg pmetric.MetricAggregationTemporality) {
 	metrics, err := scrpr.Scrape(context.Background())
 	require.NoError(t, err)
 	metric := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
@@ -302,11 +302,11 @@ func assertTransactionCount(t *testing.T, scrpr scraper, expected int, agg pmetr
 
 func TestScraper_Float(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]MetricRow{
 			{{"myfloat": "123.4"}},
 		},
 	}
-	scrpr := scraper{
+	scrpr := Scraper{
 		client: client,
 		query: Query{
 			Metrics: []MetricCfg{{
@@ -326,11 +326,11 @@ func TestScraper_Float(t *testing.T) {
 
 func TestScraper_DescriptionAndUnit(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]MetricRow{
 			{{"mycol": "123"}},
 		},
 	}
-	scrpr := scraper{
+	scrpr := Scraper{
 		client: client,
 		query: Query{
 			Metrics: []MetricCfg{{
@@ -112,6 +112,19 @@ func Test_newPathGetSetter(t *testing.T) {
 				log.Body().SetStr("head")
 			},
 		},
+		{
+			name: "body map",
+			path: []ottl.Field{
+				{
+					Name: "body",
+				},
+			},
+			orig:   "body",
+			newVal: newAttrs,
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				newAttrs.CopyTo(log.Body().SetEmptyMap())
+			},
+		},
 		{
 			name: "flags",
 			path: []ottl.Field{
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Flatten[K any](target ottl.GetSetter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) interface{} {
+		val := target.Get(ctx)
+		if val == nil {
+			return nil
+		}
+
+		if attrs, ok := val.(pcommon.Map); ok {
+			flattened := pcommon.NewMap()
+			flattenMap(flattened, "", attrs)
+			flattened.CopyTo(attrs)
+		}
+		return nil
+	}, nil
+}
+
+func flattenMap(dest pcommon.Map, prefix string, src pcommon.Map) {
+	src.Range(func(key string, value pcommon.Value) bool {
+		flattenValue(dest, prefix+key, value)
+		return true
+	})
+}
+
+func flattenValue(dest pcommon.Map, key string, value pcommon.Value) {
+	switch {
+	case value.Type() == pcommon.ValueTypeMap && value.Map().Len() > 0:
+		flattenMap(dest, key+".", value.Map())
+	case value.Type() == pcommon.ValueTypeSlice && value.Slice().Len() > 0:
+		for i := 0; i < value.Slice().Len(); i++ {
+			flattenValue(dest, key+"."+strconv.Itoa(i), value.Slice().At(i))
+		}
+	default:
+		value.CopyTo(dest.PutEmpty(key))
+	}
+}
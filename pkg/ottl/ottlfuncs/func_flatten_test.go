@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_flatten(t *testing.T) {
+	target := &ottl.StandardGetSetter[pcommon.Map]{
+		Getter: func(ctx pcommon.Map) interface{} {
+			return ctx
+		},
+		Setter: func(ctx pcommon.Map, val interface{}) {
+			val.(pcommon.Map).CopyTo(ctx)
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "flat map",
+			input: map[string]interface{}{
+				"level":   "error",
+				"retries": int64(3),
+			},
+			expected: map[string]interface{}{
+				"level":   "error",
+				"retries": int64(3),
+			},
+		},
+		{
+			name: "nested maps",
+			input: map[string]interface{}{
+				"level": "error",
+				"http": map[string]interface{}{
+					"method": "GET",
+					"response": map[string]interface{}{
+						"status_code": int64(500),
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"level":                     "error",
+				"http.method":               "GET",
+				"http.response.status_code": int64(500),
+			},
+		},
+		{
+			name: "slices",
+			input: map[string]interface{}{
+				"tags": []interface{}{"billing", map[string]interface{}{"team": "payments"}},
+			},
+			expected: map[string]interface{}{
+				"tags.0":      "billing",
+				"tags.1.team": "payments",
+			},
+		},
+		{
+			name: "empty nested values",
+			input: map[string]interface{}{
+				"map":   map[string]interface{}{},
+				"slice": []interface{}{},
+			},
+			expected: map[string]interface{}{
+				"map":   map[string]interface{}{},
+				"slice": []interface{}{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMap := pcommon.NewMap()
+			scenarioMap.FromRaw(tt.input)
+
+			exprFunc, err := Flatten[pcommon.Map](target)
+			require.NoError(t, err)
+			exprFunc(scenarioMap)
+
+			assert.Equal(t, tt.expected, scenarioMap.AsRaw())
+		})
+	}
+}
+
+func Test_flatten_bad_input(t *testing.T) {
+	input := pcommon.NewValueStr("not a map")
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) interface{} {
+			return ctx
+		},
+		Setter: func(ctx interface{}, val interface{}) {
+			t.Errorf("nothing should be set in this scenario")
+		},
+	}
+
+	exprFunc, err := Flatten[interface{}](target)
+	require.NoError(t, err)
+	exprFunc(input)
+
+	assert.Equal(t, pcommon.NewValueStr("not a map"), input)
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	mergeInsert = "insert"
+	mergeUpdate = "update"
+	mergeUpsert = "upsert"
+)
+
+func MergeMaps[K any](target ottl.GetSetter[K], source ottl.Getter[K], strategy string) (ottl.ExprFunc[K], error) {
+	if strategy != mergeInsert && strategy != mergeUpdate && strategy != mergeUpsert {
+		return nil, fmt.Errorf("invalid value for strategy, %v, must be 'insert', 'update' or 'upsert'", strategy)
+	}
+
+	return func(ctx K) interface{} {
+		targetMap, ok := target.Get(ctx).(pcommon.Map)
+		if !ok {
+			return nil
+		}
+		sourceMap, ok := source.Get(ctx).(pcommon.Map)
+		if !ok {
+			return nil
+		}
+
+		sourceMap.Range(func(key string, value pcommon.Value) bool {
+			_, exists := targetMap.Get(key)
+			if (strategy == mergeInsert && exists) || (strategy == mergeUpdate && !exists) {
+				return true
+			}
+			value.CopyTo(targetMap.PutEmpty(key))
+			return true
+		})
+		return nil
+	}, nil
+}
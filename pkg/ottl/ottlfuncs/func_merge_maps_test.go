@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_mergeMaps(t *testing.T) {
+	input := pcommon.NewMap()
+	input.PutStr("attr1", "value1")
+	input.PutStr("attr2", "value2")
+
+	source := pcommon.NewMap()
+	source.PutStr("attr2", "value3")
+	nested := source.PutEmptyMap("attr3")
+	nested.PutInt("nested", 1)
+
+	target := &ottl.StandardGetSetter[pcommon.Map]{
+		Getter: func(ctx pcommon.Map) interface{} {
+			return ctx
+		},
+		Setter: func(ctx pcommon.Map, val interface{}) {
+			val.(pcommon.Map).CopyTo(ctx)
+		},
+	}
+
+	tests := []struct {
+		name     string
+		source   ottl.Getter[pcommon.Map]
+		strategy string
+		want     func(pcommon.Map)
+	}{
+		{
+			name: "upsert",
+			source: &ottl.StandardGetSetter[pcommon.Map]{
+				Getter: func(ctx pcommon.Map) interface{} {
+					return source
+				},
+			},
+			strategy: "upsert",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("attr1", "value1")
+				expectedMap.PutStr("attr2", "value3")
+				expectedMap.PutEmptyMap("attr3").PutInt("nested", 1)
+			},
+		},
+		{
+			name: "insert",
+			source: &ottl.StandardGetSetter[pcommon.Map]{
+				Getter: func(ctx pcommon.Map) interface{} {
+					return source
+				},
+			},
+			strategy: "insert",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("attr1", "value1")
+				expectedMap.PutStr("attr2", "value2")
+				expectedMap.PutEmptyMap("attr3").PutInt("nested", 1)
+			},
+		},
+		{
+			name: "update",
+			source: &ottl.StandardGetSetter[pcommon.Map]{
+				Getter: func(ctx pcommon.Map) interface{} {
+					return source
+				},
+			},
+			strategy: "update",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("attr1", "value1")
+				expectedMap.PutStr("attr2", "value3")
+			},
+		},
+		{
+			name: "source is not a pcommon.Map",
+			source: &ottl.StandardGetSetter[pcommon.Map]{
+				Getter: func(ctx pcommon.Map) interface{} {
+					return "not a map"
+				},
+			},
+			strategy: "upsert",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("attr1", "value1")
+				expectedMap.PutStr("attr2", "value2")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMap := pcommon.NewMap()
+			input.CopyTo(scenarioMap)
+
+			exprFunc, err := MergeMaps[pcommon.Map](target, tt.source, tt.strategy)
+			require.NoError(t, err)
+			exprFunc(scenarioMap)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+
+			assert.Equal(t, expected, scenarioMap)
+		})
+	}
+}
+
+func Test_mergeMaps_bad_input(t *testing.T) {
+	input := pcommon.NewValueStr("not a map")
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) interface{} {
+			return ctx
+		},
+		Setter: func(ctx interface{}, val interface{}) {
+			t.Errorf("nothing should be set in this scenario")
+		},
+	}
+	source := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) interface{} {
+			return pcommon.NewMap()
+		},
+	}
+
+	exprFunc, err := MergeMaps[interface{}](target, source, "upsert")
+	require.NoError(t, err)
+	exprFunc(input)
+
+	assert.Equal(t, pcommon.NewValueStr("not a map"), input)
+}
+
+func Test_mergeMaps_bad_strategy(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{}
+
+	_, err := MergeMaps[interface{}](target, target, "replace")
+	assert.Error(t, err)
+}
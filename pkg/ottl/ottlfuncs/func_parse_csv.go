@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func ParseCSV[K any](target ottl.Getter[K], header string, delimiter string) (ottl.ExprFunc[K], error) {
+	if utf8.RuneCountInString(delimiter) != 1 {
+		return nil, fmt.Errorf("invalid delimiter for ParseCSV function, %q must be a single character", delimiter)
+	}
+	comma, _ := utf8.DecodeRuneInString(delimiter)
+
+	fields, err := readCSVRecord(header, comma, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid header for ParseCSV function: %w", err)
+	}
+
+	return func(ctx K) interface{} {
+		if val := target.Get(ctx); val != nil {
+			if valStr, ok := val.(string); ok {
+				values, err := readCSVRecord(valStr, comma, len(fields))
+				if err != nil {
+					return nil
+				}
+				result := pcommon.NewMap()
+				result.EnsureCapacity(len(fields))
+				for i, field := range fields {
+					result.PutStr(field, values[i])
+				}
+				return result
+			}
+		}
+		return nil
+	}, nil
+}
+
+// readCSVRecord reads the single record of s, which must have fieldCount fields
+// unless fieldCount is 0.
+func readCSVRecord(s string, comma rune, fieldCount int) ([]string, error) {
+	reader := csv.NewReader(strings.NewReader(s))
+	reader.Comma = comma
+	reader.FieldsPerRecord = fieldCount
+	return reader.Read()
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		header    string
+		delimiter string
+		expected  map[string]interface{}
+	}{
+		{
+			name:      "comma delimited",
+			value:     "2022-10-04,ERROR,payment declined",
+			header:    "date,level,message",
+			delimiter: ",",
+			expected: map[string]interface{}{
+				"date":    "2022-10-04",
+				"level":   "ERROR",
+				"message": "payment declined",
+			},
+		},
+		{
+			name:      "pipe delimited with quotes",
+			value:     `2022-10-04|ERROR|"payment | declined"`,
+			header:    "date|level|message",
+			delimiter: "|",
+			expected: map[string]interface{}{
+				"date":    "2022-10-04",
+				"level":   "ERROR",
+				"message": "payment | declined",
+			},
+		},
+		{
+			name:      "tab delimited",
+			value:     "2022-10-04\tERROR\t",
+			header:    "date\tlevel\tmessage",
+			delimiter: "\t",
+			expected: map[string]interface{}{
+				"date":    "2022-10-04",
+				"level":   "ERROR",
+				"message": "",
+			},
+		},
+		{
+			name:      "too few fields",
+			value:     "2022-10-04,ERROR",
+			header:    "date,level,message",
+			delimiter: ",",
+			expected:  nil,
+		},
+		{
+			name:      "too many fields",
+			value:     "2022-10-04,ERROR,payment declined,billing",
+			header:    "date,level,message",
+			delimiter: ",",
+			expected:  nil,
+		},
+		{
+			name:      "invalid quotes",
+			value:     `2022-10-04,ERROR,"payment declined`,
+			header:    "date,level,message",
+			delimiter: ",",
+			expected:  nil,
+		},
+		{
+			name:      "non-string",
+			value:     123,
+			header:    "date,level,message",
+			delimiter: ",",
+			expected:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) interface{} {
+					return tt.value
+				},
+			}
+			exprFunc, err := ParseCSV[interface{}](target, tt.header, tt.delimiter)
+			require.NoError(t, err)
+			result := exprFunc(nil)
+			if tt.expected == nil {
+				assert.Nil(t, result)
+				return
+			}
+			require.IsType(t, pcommon.Map{}, result)
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_parseCSV_validation(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{}
+
+	_, err := ParseCSV[interface{}](target, "date,level", "")
+	assert.Error(t, err)
+
+	_, err = ParseCSV[interface{}](target, "date,level", ",;")
+	assert.Error(t, err)
+
+	_, err = ParseCSV[interface{}](target, `"date,level`, ",")
+	assert.Error(t, err)
+}
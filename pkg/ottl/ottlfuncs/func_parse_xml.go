@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func ParseXML[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) interface{} {
+		if val := target.Get(ctx); val != nil {
+			if valStr, ok := val.(string); ok {
+				root, err := parseXMLElement(valStr)
+				if err != nil {
+					return nil
+				}
+				result := pcommon.NewMap()
+				root.copyTo(result)
+				return result
+			}
+		}
+		return nil
+	}, nil
+}
+
+// xmlElement is an element of a parsed XML document, kept until the whole
+// document is known to be valid before being converted to a pcommon.Map.
+type xmlElement struct {
+	tag        string
+	attributes []xml.Attr
+	content    strings.Builder
+	children   []*xmlElement
+}
+
+func parseXMLElement(s string) (*xmlElement, error) {
+	decoder := xml.NewDecoder(strings.NewReader(s))
+	var root *xmlElement
+	var stack []*xmlElement
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{tag: t.Name.Local, attributes: t.Attr}
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, errors.New("xml document has more than one root element")
+				}
+				root = element
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, element)
+			}
+			stack = append(stack, element)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].content.Write(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("xml document has no root element")
+	}
+	return root, nil
+}
+
+func (e *xmlElement) copyTo(dest pcommon.Map) {
+	dest.PutStr("tag", e.tag)
+	if len(e.attributes) > 0 {
+		attributes := dest.PutEmptyMap("attributes")
+		for _, attr := range e.attributes {
+			attributes.PutStr(attr.Name.Local, attr.Value)
+		}
+	}
+	if content := strings.TrimSpace(e.content.String()); content != "" {
+		dest.PutStr("content", content)
+	}
+	if len(e.children) > 0 {
+		children := dest.PutEmptySlice("children")
+		for _, child := range e.children {
+			child.copyTo(children.AppendEmpty().SetEmptyMap())
+		}
+	}
+}
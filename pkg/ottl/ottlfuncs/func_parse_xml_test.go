@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   ottl.Getter[interface{}]
+		expected map[string]interface{}
+	}{
+		{
+			name: "single element",
+			target: &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) interface{} {
+					return `<log>request failed</log>`
+				},
+			},
+			expected: map[string]interface{}{
+				"tag":     "log",
+				"content": "request failed",
+			},
+		},
+		{
+			name: "nested elements",
+			target: &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) interface{} {
+					return `<?xml version="1.0"?>
+<event id="42" level="error">
+  <source>billing</source>
+  <message lang="en">payment declined</message>
+  <empty/>
+</event>`
+				},
+			},
+			expected: map[string]interface{}{
+				"tag": "event",
+				"attributes": map[string]interface{}{
+					"id":    "42",
+					"level": "error",
+				},
+				"children": []interface{}{
+					map[string]interface{}{
+						"tag":     "source",
+						"content": "billing",
+					},
+					map[string]interface{}{
+						"tag": "message",
+						"attributes": map[string]interface{}{
+							"lang": "en",
+						},
+						"content": "payment declined",
+					},
+					map[string]interface{}{
+						"tag": "empty",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid xml",
+			target: &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) interface{} {
+					return `<log>request failed</event>`
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "multiple root elements",
+			target: &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) interface{} {
+					return `<log>one</log><log>two</log>`
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "no root element",
+			target: &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) interface{} {
+					return "request failed"
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "non-string",
+			target: &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) interface{} {
+					return 123
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "nil",
+			target: &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) interface{} {
+					return nil
+				},
+			},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseXML(tt.target)
+			require.NoError(t, err)
+			result := exprFunc(nil)
+			if tt.expected == nil {
+				assert.Nil(t, result)
+				return
+			}
+			require.IsType(t, pcommon.Map{}, result)
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// noCompression is the compression setting of the files read as is
+	noCompression = ""
+	// autoCompression is the compression setting of the files decompressed when compressed with gzip or zstd
+	autoCompression = "auto"
+)
+
+// compression is the compression format of a file
+type compression int
+
+const (
+	uncompressed compression = iota
+	gzipCompressed
+	zstdCompressed
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// detectCompression detects the compression format of a file from its magic bytes, or from its
+// extension when it is too short to hold them yet.
+func detectCompression(file *os.File) compression {
+	magic := make([]byte, len(zstdMagic))
+	n, _ := file.ReadAt(magic, 0)
+	switch {
+	case bytes.HasPrefix(magic[:n], gzipMagic):
+		return gzipCompressed
+	case bytes.HasPrefix(magic[:n], zstdMagic):
+		return zstdCompressed
+	case n == len(magic):
+		return uncompressed
+	}
+
+	switch filepath.Ext(file.Name()) {
+	case ".gz":
+		return gzipCompressed
+	case ".zst":
+		return zstdCompressed
+	}
+	return uncompressed
+}
+
+// decompress returns a reader of the decompressed content of the file, from its beginning. The file
+// may be read concurrently, and a file truncated in the middle of a compressed block, such as a file
+// still being compressed, ends there.
+func (c compression) decompress(file *os.File) (io.ReadCloser, error) {
+	src := io.NewSectionReader(file, 0, math.MaxInt64)
+	switch c {
+	case gzipCompressed:
+		r, err := gzip.NewReader(src)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		return truncatedReader{ReadCloser: r}, nil
+	case zstdCompressed:
+		r, err := zstd.NewReader(src, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		return truncatedReader{ReadCloser: r.IOReadCloser()}, nil
+	}
+	return io.NopCloser(src), nil
+}
+
+// truncatedReader ends the content of a truncated compressed file without error
+type truncatedReader struct {
+	io.ReadCloser
+}
+
+func (r truncatedReader) Read(dst []byte) (int, error) {
+	n, err := r.ReadCloser.Read(dst)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
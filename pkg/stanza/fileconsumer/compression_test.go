@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func gzipCompress(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdCompress(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestDetectCompression(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cases := []struct {
+		name     string
+		content  []byte
+		expected compression
+	}{
+		{"plain.log", []byte("testlog\n"), uncompressed},
+		{"plain.gz", []byte("testlog\n"), uncompressed},
+		{"gzip.log", gzipCompress(t, "testlog\n"), gzipCompressed},
+		{"zstd.log", zstdCompress(t, "testlog\n"), zstdCompressed},
+		{"empty.gz", nil, gzipCompressed},
+		{"short.zst", []byte{0x28, 0xb5}, zstdCompressed},
+		{"short.log", []byte{0x28, 0xb5}, uncompressed},
+	}
+	for _, tc := range cases {
+		path := filepath.Join(tempDir, tc.name)
+		require.NoError(t, os.WriteFile(path, tc.content, 0600))
+		require.Equal(t, tc.expected, detectCompression(openFile(t, path)), tc.name)
+	}
+}
+
+func TestReadCompressedFiles(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		fileName string
+		compress func(*testing.T, string) []byte
+	}{
+		{"gzip", "test.log.gz", gzipCompress},
+		{"zstd", "test.log.zst", zstdCompress},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = "auto"
+			operator, emitCalls := buildTestManager(t, cfg)
+			operator.persister = testutil.NewMockPersister("test")
+
+			path := filepath.Join(tempDir, tc.fileName)
+			require.NoError(t, os.WriteFile(path, tc.compress(t, "testlog1\ntestlog2\n"), 0600))
+
+			operator.poll(context.Background())
+			waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+
+			// the decompressed content is read from the offset, when the file is written again
+			require.NoError(t, os.WriteFile(path, tc.compress(t, "testlog1\ntestlog2\ntestlog3\n"), 0600))
+			operator.poll(context.Background())
+			waitForToken(t, emitCalls, []byte("testlog3"))
+			expectNoTokens(t, emitCalls)
+		})
+	}
+}
+
+// UnchangedCompressedFile tests that a compressed file is not decompressed again until it changes
+func TestUnchangedCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	f, emitChan := testReaderFactory(t)
+	f.readerConfig.decompress = true
+	core, logs := observer.New(zapcore.ErrorLevel)
+	f.SugaredLogger = zap.New(core).Sugar()
+
+	path := filepath.Join(t.TempDir(), "test.log.gz")
+	content := gzipCompress(t, "testlog1\n")
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	file := openFile(t, path)
+
+	r, err := f.newReader(file, nil, nil)
+	require.NoError(t, err)
+	r.ReadToEnd(context.Background())
+	require.Equal(t, []byte("testlog1"), readToken(t, emitChan))
+
+	// the content after the magic bytes is replaced by garbage which can't be decompressed,
+	// keeping the size and modification time
+	info, err := file.Stat()
+	require.NoError(t, err)
+	_, err = file.WriteAt(bytes.Repeat([]byte{0}, len(content)-len(gzipMagic)), int64(len(gzipMagic)))
+	require.NoError(t, err)
+	require.NoError(t, os.Chtimes(path, info.ModTime(), info.ModTime()))
+
+	r, err = f.copy(r, file, r.fileAttributes)
+	require.NoError(t, err)
+	r.ReadToEnd(context.Background())
+	require.Zero(t, logs.Len())
+
+	// once its modification time changes, the file is decompressed again
+	modTime := info.ModTime().Add(time.Second)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	r.ReadToEnd(context.Background())
+	require.Equal(t, 1, logs.FilterMessage("Failed to decompress").Len())
+}
+
+func TestReadCompressedFilesDisabled(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	content := gzipCompress(t, "testlog1\n")
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "test.log.gz"), content, 0600))
+
+	operator.poll(context.Background())
+	// the compressed content is read as is
+	emitCall := waitForEmit(t, emitCalls)
+	require.NotEqual(t, []byte("testlog1"), emitCall.token)
+}
+
+// CompressedRotation tests that a file rotated and compressed, as by logrotate, keeps its offset
+func TestCompressedRotation(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "auto"
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	path := filepath.Join(tempDir, "test.log")
+	temp := openFile(t, path)
+	writeString(t, temp, "testlog1\ntestlog2\n")
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+
+	// a line is written just before the file is rotated and compressed
+	writeString(t, temp, "testlog3\n")
+	require.NoError(t, temp.Close())
+	require.NoError(t, os.WriteFile(path+".1.gz", gzipCompress(t, "testlog1\ntestlog2\ntestlog3\n"), 0600))
+	require.NoError(t, os.Remove(path))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog3"))
+	expectNoTokens(t, emitCalls)
+}
+
+func TestTruncatedCompressedFile(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = "auto"
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	// a file still being compressed is read up to its last complete block
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte("testlog1\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "test.log.gz"), buf.Bytes(), 0600))
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog1"))
+}
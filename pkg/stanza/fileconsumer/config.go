@@ -57,7 +57,16 @@ type Config struct {
 	FingerprintSize         helper.ByteSize       `mapstructure:"fingerprint_size,omitempty"`
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"`
+	SplitterOverrides       []SplitterOverride    `mapstructure:"splitter_overrides,omitempty"`
+}
+
+// SplitterOverride replaces the multiline configuration of the splitter for the files
+// matching one of its include patterns. The first matching override is used.
+type SplitterOverride struct {
+	Include   []string               `mapstructure:"include,omitempty"`
+	Multiline helper.MultilineConfig `mapstructure:"multiline,omitempty"`
 }
 
 // Build will build a file input operator from the supplied configuration
@@ -106,6 +115,34 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, err
 	}
 
+	overrides := make([]splitterOverride, 0, len(c.SplitterOverrides))
+	for i, override := range c.SplitterOverrides {
+		if len(override.Include) == 0 {
+			return nil, fmt.Errorf("splitter override %d: required argument `include` is empty", i)
+		}
+		for _, include := range override.Include {
+			if _, err = doublestar.PathMatch(include, "matchstring"); err != nil {
+				return nil, fmt.Errorf("splitter override %d: parse include glob: %w", i, err)
+			}
+		}
+
+		splitter := c.Splitter
+		splitter.Multiline = override.Multiline
+		if _, err = splitter.Build(false, int(c.MaxLogSize)); err != nil {
+			return nil, fmt.Errorf("splitter override %d: %w", i, err)
+		}
+		overrides = append(overrides, splitterOverride{
+			include:        override.Include,
+			splitterConfig: splitter,
+		})
+	}
+
+	switch c.Compression {
+	case noCompression, autoCompression:
+	default:
+		return nil, fmt.Errorf("invalid compression '%s'", c.Compression)
+	}
+
 	var startAtBeginning bool
 	switch c.StartAt {
 	case "beginning":
@@ -125,10 +162,12 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
 				emit:            emit,
+				decompress:      c.Compression == autoCompression,
 			},
-			fromBeginning:  startAtBeginning,
-			splitterConfig: c.Splitter,
-			encodingConfig: c.Splitter.EncodingConfig,
+			fromBeginning:     startAtBeginning,
+			splitterConfig:    c.Splitter,
+			splitterOverrides: overrides,
+			encodingConfig:    c.Splitter.EncodingConfig,
 		},
 		finder:        c.Finder,
 		roller:        newRoller(),
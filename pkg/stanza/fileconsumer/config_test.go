@@ -288,6 +288,30 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "compression_auto",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Compression = "auto"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "splitter_overrides",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.SplitterOverrides = []SplitterOverride{
+						{
+							Include:   []string{"/var/log/app*.log"},
+							Multiline: helper.MultilineConfig{LineStartPattern: "Start"},
+						},
+						{
+							Include: []string{"/var/log/access.log"},
+						},
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "start_at_string",
 				Expect: func() *mockOperatorConfig {
@@ -432,6 +456,80 @@ func TestBuild(t *testing.T) {
 			require.NoError,
 			func(t *testing.T, f *Manager) {},
 		},
+		{
+			"AutoCompression",
+			func(f *Config) {
+				f.Compression = "auto"
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.True(t, f.readerFactory.readerConfig.decompress)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "gzip"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"SplitterOverride",
+			func(f *Config) {
+				f.SplitterOverrides = []SplitterOverride{
+					{
+						Include:   []string{"/var/log/app*.log"},
+						Multiline: helper.MultilineConfig{LineStartPattern: `^\d{4}-\d{2}-\d{2}`},
+					},
+				}
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Len(t, f.readerFactory.splitterOverrides, 1)
+				override := f.readerFactory.splitterOverrides[0]
+				require.Equal(t, []string{"/var/log/app*.log"}, override.include)
+				require.Equal(t, `^\d{4}-\d{2}-\d{2}`, override.splitterConfig.Multiline.LineStartPattern)
+				require.Equal(t, f.readerFactory.splitterConfig.EncodingConfig, override.splitterConfig.EncodingConfig)
+				require.Equal(t, f.readerFactory.splitterConfig.Flusher, override.splitterConfig.Flusher)
+			},
+		},
+		{
+			"SplitterOverrideNoInclude",
+			func(f *Config) {
+				f.SplitterOverrides = []SplitterOverride{
+					{Multiline: helper.MultilineConfig{LineStartPattern: "START.*"}},
+				}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"SplitterOverrideBadIncludeGlob",
+			func(f *Config) {
+				f.SplitterOverrides = []SplitterOverride{
+					{Include: []string{"["}},
+				}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"SplitterOverrideStartAndEndPatterns",
+			func(f *Config) {
+				f.SplitterOverrides = []SplitterOverride{
+					{
+						Include: []string{"/var/log/app*.log"},
+						Multiline: helper.MultilineConfig{
+							LineEndPattern:   "Exists",
+							LineStartPattern: "Exists",
+						},
+					},
+				}
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidEncoding",
 			func(f *Config) {
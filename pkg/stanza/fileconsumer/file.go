@@ -153,6 +153,8 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 func (m *Manager) makeReaders(filesPaths []string) []*Reader {
 	// Open the files first to minimize the time between listing and opening
 	files := make([]*os.File, 0, len(filesPaths))
+	attrs := make([]*FileAttributes, 0, len(filesPaths))
+	resolvedPaths := make(map[string]struct{}, len(filesPaths))
 	for _, path := range filesPaths {
 		if _, ok := m.seenPaths[path]; !ok {
 			if m.readerFactory.fromBeginning {
@@ -162,20 +164,40 @@ func (m *Manager) makeReaders(filesPaths []string) []*Reader {
 			}
 			m.seenPaths[path] = struct{}{}
 		}
-		file, err := os.Open(path) // #nosec - operator must read in files defined by user
+		// Resolve the symlinks before opening the file, so that its attributes describe the opened file even
+		// when a symlink is changed in between, as the kubelet does when a container restarts
+		fileAttrs, err := resolveFileAttributes(path)
+		if err != nil {
+			m.Debugw("Failed to resolve file", "path", path, zap.Error(err))
+			continue
+		}
+		// A file matched through several symlinks, or through a loop of symlinked directories, is read once
+		if _, ok := resolvedPaths[fileAttrs.PathResolved]; ok {
+			continue
+		}
+		resolvedPaths[fileAttrs.PathResolved] = struct{}{}
+
+		file, err := os.Open(fileAttrs.PathResolved) // #nosec - operator must read in files defined by user
 		if err != nil {
 			m.Debugf("Failed to open file", zap.Error(err))
 			continue
 		}
 		files = append(files, file)
+		attrs = append(attrs, fileAttrs)
 	}
 
 	// Get fingerprints for each file
 	fps := make([]*Fingerprint, 0, len(files))
-	for _, file := range files {
-		fp, err := m.readerFactory.newFingerprint(file)
+	for i := 0; i < len(files); i++ {
+		fp, err := m.readerFactory.newFingerprint(files[i])
 		if err != nil {
 			m.Errorw("Failed creating fingerprint", zap.Error(err))
+			if err := files[i].Close(); err != nil {
+				m.Errorf("problem closing file", "file", files[i].Name())
+			}
+			files = append(files[:i], files[i+1:]...)
+			attrs = append(attrs[:i], attrs[i+1:]...)
+			i--
 			continue
 		}
 		fps = append(fps, fp)
@@ -192,6 +214,7 @@ OUTER:
 			// Empty file, don't read it until we can compare its fingerprint
 			fps = append(fps[:i], fps[i+1:]...)
 			files = append(files[:i], files[i+1:]...)
+			attrs = append(attrs[:i], attrs[i+1:]...)
 			i--
 			continue
 		}
@@ -204,6 +227,7 @@ OUTER:
 				}
 				fps = append(fps[:i], fps[i+1:]...)
 				files = append(files[:i], files[i+1:]...)
+				attrs = append(attrs[:i], attrs[i+1:]...)
 				i--
 				continue OUTER
 			}
@@ -212,7 +236,7 @@ OUTER:
 
 	readers := make([]*Reader, 0, len(fps))
 	for i := 0; i < len(fps); i++ {
-		reader, err := m.newReader(files[i], fps[i])
+		reader, err := m.newReader(files[i], fps[i], attrs[i])
 		if err != nil {
 			m.Errorw("Failed to create reader", zap.Error(err))
 			continue
@@ -242,14 +266,14 @@ func (m *Manager) saveCurrent(readers []*Reader) {
 	}
 }
 
-func (m *Manager) newReader(file *os.File, fp *Fingerprint) (*Reader, error) {
+func (m *Manager) newReader(file *os.File, fp *Fingerprint, attrs *FileAttributes) (*Reader, error) {
 	// Check if the new path has the same fingerprint as an old path
 	if oldReader, ok := m.findFingerprintMatch(fp); ok {
-		return m.readerFactory.copy(oldReader, file)
+		return m.readerFactory.copy(oldReader, file, attrs)
 	}
 
 	// If we don't match any previously known files, create a new reader from scratch
-	return m.readerFactory.newReader(file, fp)
+	return m.readerFactory.newReader(file, fp, attrs)
 }
 
 func (m *Manager) findFingerprintMatch(fp *Fingerprint) (*Reader, bool) {
@@ -209,6 +209,63 @@ func TestAddFileResolvedFieldsWithChangeOfSymlinkTarget(t *testing.T) {
 	require.Equal(t, resolved2, emitCall.attrs.PathResolved)
 }
 
+// ReadSymlinkTarget tests that the target of a symlink is opened, for the
+// resolved attributes to describe the opened file even if the symlink changes
+func TestReadSymlinkTarget(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	operator, _ := buildTestManager(t, cfg)
+
+	dir := t.TempDir()
+	file := openTemp(t, dir)
+	writeString(t, file, "testlog\n")
+	resolved, err := filepath.EvalSymlinks(file.Name())
+	require.NoError(t, err)
+
+	symLinkPath := filepath.Join(tempDir, "symlink")
+	require.NoError(t, os.Symlink(file.Name(), symLinkPath))
+
+	readers := operator.makeReaders([]string{symLinkPath})
+	require.Len(t, readers, 1)
+	defer readers[0].Close()
+	require.Equal(t, resolved, readers[0].file.Name())
+	require.Equal(t, symLinkPath, readers[0].fileAttributes.Path)
+	require.Equal(t, resolved, readers[0].fileAttributes.PathResolved)
+
+	// the dangling symlinks are skipped
+	require.NoError(t, os.Remove(file.Name()))
+	require.Empty(t, operator.makeReaders([]string{symLinkPath}))
+}
+
+// ReadSymlinkedDirectoryLoop tests that a file matched many times
+// through a loop of symlinked directories is read once
+func TestReadSymlinkedDirectoryLoop(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig()
+	cfg.Include = []string{filepath.Join(tempDir, "**", "*.log")}
+	cfg.StartAt = "beginning"
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	logsDir := filepath.Join(tempDir, "logs")
+	require.NoError(t, os.Mkdir(logsDir, 0700))
+	require.NoError(t, os.Symlink(tempDir, filepath.Join(logsDir, "loop")))
+	file := openFile(t, filepath.Join(logsDir, "a.log"))
+	writeString(t, file, "testlog\n")
+	require.Greater(t, len(operator.finder.FindFiles()), 1)
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("testlog"))
+	expectNoTokens(t, emitCalls)
+}
+
 // ReadExistingLogs tests that, when starting from beginning, we
 // read all the lines that are already there
 func TestReadExistingLogs(t *testing.T) {
@@ -671,6 +728,39 @@ func TestMultiFileSimple(t *testing.T) {
 	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
 }
 
+func TestSplitterOverrides(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.SplitterOverrides = []SplitterOverride{
+		{
+			Include:   []string{filepath.Join(tempDir, "app*.log")},
+			Multiline: helper.MultilineConfig{LineStartPattern: "START"},
+		},
+	}
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	appLog := openFile(t, filepath.Join(tempDir, "app.log"))
+	accessLog := openFile(t, filepath.Join(tempDir, "access.log"))
+
+	writeString(t, appLog, "START 1\n\tat frame1\n\tat frame2\nSTART 2\n")
+	writeString(t, accessLog, "access1\naccess2\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForTokens(t, emitCalls, [][]byte{
+		[]byte("START 1\n\tat frame1\n\tat frame2"),
+		[]byte("START 2"), // flushed after the force flush period
+		[]byte("access1"),
+		[]byte("access2"),
+	})
+}
+
 func TestMultiFileParallel_PreloadedFiles(t *testing.T) {
 	t.Parallel()
 
@@ -944,7 +1034,7 @@ func TestFileReader_FingerprintUpdated(t *testing.T) {
 	fp, err := operator.readerFactory.newFingerprint(temp)
 	require.NoError(t, err)
 
-	reader, err := operator.readerFactory.newReader(tempCopy, fp)
+	reader, err := operator.readerFactory.newReader(tempCopy, fp, nil)
 	require.NoError(t, err)
 	defer reader.Close()
 
@@ -987,7 +1077,7 @@ func TestFingerprintGrowsAndStops(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, []byte(""), fp.FirstBytes)
 
-			reader, err := operator.readerFactory.newReader(tempCopy, fp)
+			reader, err := operator.readerFactory.newReader(tempCopy, fp, nil)
 			require.NoError(t, err)
 			defer reader.Close()
 
@@ -1050,7 +1140,7 @@ func TestFingerprintChangeSize(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, []byte(""), fp.FirstBytes)
 
-			reader, err := operator.readerFactory.newReader(tempCopy, fp)
+			reader, err := operator.readerFactory.newReader(tempCopy, fp, nil)
 			require.NoError(t, err)
 			defer reader.Close()
 
@@ -47,6 +47,26 @@ func NewFingerprint(file *os.File, size int) (*Fingerprint, error) {
 	return fp, nil
 }
 
+// newCompressedFingerprint creates a new fingerprint from the decompressed content of an open file,
+// so that the fingerprint of a file is kept once it is compressed, such as when it is rotated
+func newCompressedFingerprint(file *os.File, c compression, size int) (*Fingerprint, error) {
+	content, err := c.decompress(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+	defer content.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(content, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+
+	return &Fingerprint{
+		FirstBytes: buf[:n],
+	}, nil
+}
+
 // Copy creates a new copy of the fingerprint
 func (f Fingerprint) Copy() *Fingerprint {
 	buf := make([]byte, len(f.FirstBytes), cap(f.FirstBytes))
@@ -17,7 +17,9 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -29,6 +31,8 @@ type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
 	emit            EmitFunc
+	// decompress enables the decompression of the files compressed with gzip or zstd
+	decompress bool
 }
 
 // Reader manages a single file
@@ -43,6 +47,14 @@ type Reader struct {
 	generation     int
 	file           *os.File
 	fileAttributes *FileAttributes
+	// compression is the compression format of the file. The offset and the fingerprint
+	// of a compressed file are the ones of its decompressed content.
+	compression compression
+	// src is the content of the file read by the scanner
+	src io.Reader
+	// compressedInfo is the file info of a compressed file when it was last read to its end, so
+	// that the file is not decompressed again as long as its size and modification time are unchanged
+	compressedInfo os.FileInfo
 }
 
 // offsetToEnd sets the starting offset
@@ -51,15 +63,57 @@ func (r *Reader) offsetToEnd() error {
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
 	}
+
+	if r.compression != uncompressed {
+		content, err := r.compression.decompress(r.file)
+		if err != nil {
+			return err
+		}
+		defer content.Close()
+		size, err := io.Copy(io.Discard, content)
+		if err != nil {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		r.Offset = size
+		r.compressedInfo = info
+		return nil
+	}
+
 	r.Offset = info.Size()
 	return nil
 }
 
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
-		r.Errorw("Failed to seek", zap.Error(err))
-		return
+	var info os.FileInfo
+	if r.compression != uncompressed {
+		var err error
+		if info, err = r.file.Stat(); err != nil {
+			r.Errorw("Failed to stat", zap.Error(err))
+			return
+		}
+		if r.compressedUnchanged(info) {
+			return
+		}
+
+		// a compressed file can't be seeked, so its decompressed content is skipped up to the offset
+		content, err := r.compression.decompress(r.file)
+		if err != nil {
+			r.Errorw("Failed to decompress", zap.Error(err))
+			return
+		}
+		defer content.Close()
+		if _, err = io.CopyN(io.Discard, content, r.Offset); err != nil && !errors.Is(err, io.EOF) {
+			r.Errorw("Failed to skip to offset", zap.Error(err))
+			return
+		}
+		r.src = content
+	} else {
+		if _, err := r.file.Seek(r.Offset, 0); err != nil {
+			r.Errorw("Failed to seek", zap.Error(err))
+			return
+		}
+		r.src = r.file
 	}
 
 	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitFunc)
@@ -76,6 +130,7 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		if !ok {
 			if err := scanner.getError(); err != nil {
 				r.Errorw("Failed during scan", zap.Error(err))
+				return
 			}
 			break
 		}
@@ -89,6 +144,18 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 
 		r.Offset = scanner.Pos()
 	}
+
+	if r.compression != uncompressed {
+		r.compressedInfo = info
+	}
+}
+
+// compressedUnchanged returns whether the compressed file was already read to its end with the same
+// size and modification time
+func (r *Reader) compressedUnchanged(info os.FileInfo) bool {
+	return r.compressedInfo != nil &&
+		r.compressedInfo.Size() == info.Size() &&
+		r.compressedInfo.ModTime().Equal(info.ModTime())
 }
 
 // Close will close the file
@@ -105,9 +172,9 @@ func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.src.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.src.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
@@ -18,6 +18,7 @@ import (
 	"bufio"
 	"os"
 
+	"github.com/bmatcuk/doublestar/v3"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
@@ -25,26 +26,50 @@ import (
 
 type readerFactory struct {
 	*zap.SugaredLogger
-	readerConfig   *readerConfig
-	fromBeginning  bool
+	readerConfig      *readerConfig
+	fromBeginning     bool
+	splitterConfig    helper.SplitterConfig
+	splitterOverrides []splitterOverride
+	encodingConfig    helper.EncodingConfig
+}
+
+// splitterOverride is the splitter config used for the files matching one of the include patterns
+type splitterOverride struct {
+	include        []string
 	splitterConfig helper.SplitterConfig
-	encodingConfig helper.EncodingConfig
 }
 
-func (f *readerFactory) newReader(file *os.File, fp *Fingerprint) (*Reader, error) {
+// splitterConfigFor returns the splitter config of the first override matching the path,
+// or the default splitter config if none does
+func (f *readerFactory) splitterConfigFor(path string) helper.SplitterConfig {
+	for _, override := range f.splitterOverrides {
+		for _, include := range override.include {
+			if matches, _ := doublestar.PathMatch(include, path); matches { // compile error checked in build
+				return override.splitterConfig
+			}
+		}
+	}
+	return f.splitterConfig
+}
+
+// newReader creates a Reader of the file, whose attributes are resolved from its name when not provided
+func (f *readerFactory) newReader(file *os.File, fp *Fingerprint, attrs *FileAttributes) (*Reader, error) {
 	return f.newReaderBuilder().
 		withFile(file).
+		withFileAttributes(attrs).
 		withFingerprint(fp).
 		build()
 }
 
 // copy creates a deep copy of a Reader
-func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
+func (f *readerFactory) copy(old *Reader, newFile *os.File, attrs *FileAttributes) (*Reader, error) {
 	return f.newReaderBuilder().
 		withFile(newFile).
+		withFileAttributes(attrs).
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
 		withSplitterFunc(old.splitFunc).
+		withCompressedInfo(old.compressedInfo).
 		build()
 }
 
@@ -53,15 +78,23 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 }
 
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
+	if f.readerConfig.decompress {
+		if c := detectCompression(file); c != uncompressed {
+			return newCompressedFingerprint(file, c, f.readerConfig.fingerprintSize)
+		}
+	}
 	return NewFingerprint(file, f.readerConfig.fingerprintSize)
 }
 
 type readerBuilder struct {
 	*readerFactory
 	file      *os.File
+	fileAttrs *FileAttributes
 	fp        *Fingerprint
 	offset    int64
 	splitFunc bufio.SplitFunc
+	// compressedInfo is the file info of the compressed file when it was last read to its end
+	compressedInfo os.FileInfo
 }
 
 func (f *readerFactory) newReaderBuilder() *readerBuilder {
@@ -78,6 +111,11 @@ func (b *readerBuilder) withFile(f *os.File) *readerBuilder {
 	return b
 }
 
+func (b *readerBuilder) withFileAttributes(attrs *FileAttributes) *readerBuilder {
+	b.fileAttrs = attrs
+	return b
+}
+
 func (b *readerBuilder) withFingerprint(fp *Fingerprint) *readerBuilder {
 	b.fp = fp
 	return b
@@ -88,17 +126,27 @@ func (b *readerBuilder) withOffset(offset int64) *readerBuilder {
 	return b
 }
 
+func (b *readerBuilder) withCompressedInfo(info os.FileInfo) *readerBuilder {
+	b.compressedInfo = info
+	return b
+}
+
 func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
-		readerConfig: b.readerConfig,
-		Offset:       b.offset,
+		readerConfig:   b.readerConfig,
+		Offset:         b.offset,
+		compressedInfo: b.compressedInfo,
 	}
 
 	var splitter *helper.Splitter
 	if b.splitFunc != nil {
 		r.splitFunc = b.splitFunc
 	} else {
-		splitter, err = b.splitterConfig.Build(false, b.readerConfig.maxLogSize)
+		splitterConfig := b.splitterConfig
+		if b.file != nil {
+			splitterConfig = b.splitterConfigFor(b.file.Name())
+		}
+		splitter, err = splitterConfig.Build(false, b.readerConfig.maxLogSize)
 		r.splitFunc = splitter.SplitFunc
 		if err != nil {
 			return
@@ -113,10 +161,17 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 
 	if b.file != nil {
 		r.file = b.file
+		if b.readerConfig.decompress {
+			r.compression = detectCompression(b.file)
+		}
 		r.SugaredLogger = b.SugaredLogger.With("path", b.file.Name())
-		r.fileAttributes, err = resolveFileAttributes(b.file.Name())
-		if err != nil {
-			b.Errorf("resolve attributes: %w", err)
+		if b.fileAttrs != nil {
+			r.fileAttributes = b.fileAttrs
+		} else {
+			r.fileAttributes, err = resolveFileAttributes(b.file.Name())
+			if err != nil {
+				b.Errorf("resolve attributes: %w", err)
+			}
 		}
 
 		// unsafeReader has the file set to nil, so don't try emending its offset.
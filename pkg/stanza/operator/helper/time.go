@@ -22,6 +22,7 @@ import (
 
 	strptime "github.com/observiq/ctimefmt"
 	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
@@ -36,6 +37,9 @@ const GotimeKey = "gotime"
 // EpochKey is literally "epoch" and can parse seconds and/or subseconds
 const EpochKey = "epoch"
 
+// EpochAutoLayout is the epoch layout detecting the unit of the timestamps by their magnitude
+const EpochAutoLayout = "auto"
+
 // NativeKey is literally "native" and refers to Golang's native time.Time
 const NativeKey = "native" // provided for operator development
 
@@ -48,10 +52,11 @@ func NewTimeParser() TimeParser {
 
 // TimeParser is a helper that parses time onto an entry.
 type TimeParser struct {
-	ParseFrom  *entry.Field `mapstructure:"parse_from"`
-	Layout     string       `mapstructure:"layout"`
-	LayoutType string       `mapstructure:"layout_type"`
-	Location   string       `mapstructure:"location"`
+	ParseFrom       *entry.Field `mapstructure:"parse_from"`
+	Layout          string       `mapstructure:"layout"`
+	FallbackLayouts []string     `mapstructure:"fallback_layouts"`
+	LayoutType      string       `mapstructure:"layout_type"`
+	Location        string       `mapstructure:"location"`
 
 	location *time.Location
 }
@@ -86,6 +91,13 @@ func (t *TimeParser) Validate() error {
 		t.LayoutType = StrptimeKey
 	}
 
+	if len(t.FallbackLayouts) > 0 && t.LayoutType != StrptimeKey && t.LayoutType != GotimeKey {
+		return errors.NewError(
+			fmt.Sprintf("`fallback_layouts` is not supported for layout_type %s", t.LayoutType),
+			"use `fallback_layouts` with the 'strptime' or 'gotime' layout types",
+		)
+	}
+
 	switch t.LayoutType {
 	case NativeKey, GotimeKey: // ok
 	case StrptimeKey:
@@ -94,14 +106,19 @@ func (t *TimeParser) Validate() error {
 		if err != nil {
 			return errors.Wrap(err, "parse strptime layout")
 		}
+		for i, layout := range t.FallbackLayouts {
+			if t.FallbackLayouts[i], err = strptime.ToNative(layout); err != nil {
+				return errors.Wrap(err, "parse strptime fallback layout")
+			}
+		}
 		t.LayoutType = GotimeKey
 	case EpochKey:
 		switch t.Layout {
-		case "s", "ms", "us", "ns", "s.ms", "s.us", "s.ns": // ok
+		case "s", "ms", "us", "ns", "s.ms", "s.us", "s.ns", EpochAutoLayout: // ok
 		default:
 			return errors.NewError(
 				"invalid `layout` for `epoch` type",
-				"specify 's', 'ms', 'us', 'ns', 's.ms', 's.us', or 's.ns'",
+				"specify 's', 'ms', 'us', 'ns', 's.ms', 's.us', 's.ns', or 'auto'",
 			)
 		}
 	default:
@@ -141,6 +158,15 @@ func (t *TimeParser) setLocation() error {
 	return nil
 }
 
+// locationFor returns the location to parse the timestamps of a layout with.
+// A fallback layout ending with 'Z' is interpretted as Zulu (UTC) time, like the main layout.
+func (t *TimeParser) locationFor(layout string) *time.Location {
+	if t.Location == "" && strings.HasSuffix(layout, "Z") {
+		return time.UTC
+	}
+	return t.location
+}
+
 // Parse will parse time from a field and attach it to the entry
 func (t *TimeParser) Parse(entry *entry.Entry) error {
 	value, ok := entry.Get(t.ParseFrom)
@@ -189,7 +215,26 @@ func (t *TimeParser) parseGotime(value interface{}) (time.Time, error) {
 		return time.Time{}, fmt.Errorf("type %T cannot be parsed as a time", value)
 	}
 
-	result, err := time.ParseInLocation(t.Layout, str, t.location)
+	result, err := t.parseGotimeLayout(t.Layout, str)
+	if err == nil || len(t.FallbackLayouts) == 0 {
+		return result, err
+	}
+
+	// Try the fallback layouts in order, for the sources mixing several formats
+	errs := err
+	for _, layout := range t.FallbackLayouts {
+		result, err = t.parseGotimeLayout(layout, str)
+		if err == nil {
+			return result, nil
+		}
+		errs = multierr.Append(errs, err)
+	}
+	return time.Time{}, fmt.Errorf("value '%s' does not match any layout: %w", str, errs)
+}
+
+func (t *TimeParser) parseGotimeLayout(layout string, str string) (time.Time, error) {
+	location := t.locationFor(layout)
+	result, err := time.ParseInLocation(layout, str, location)
 
 	// Depending on the timezone database, we may get a pseudo-matching timezone
 	// This is apparent when the zone is not "UTC", but the offset is still 0
@@ -206,7 +251,7 @@ func (t *TimeParser) parseGotime(value interface{}) (time.Time, error) {
 	}
 
 	// Reparse the timestamp, with the location
-	resultLoc, locErr := time.ParseInLocation(t.Layout, str, loc)
+	resultLoc, locErr := time.ParseInLocation(layout, str, loc)
 	if locErr != nil {
 		// can't correct offset, just return original result
 		return result, err
@@ -216,6 +261,10 @@ func (t *TimeParser) parseGotime(value interface{}) (time.Time, error) {
 }
 
 func (t *TimeParser) parseEpochTime(value interface{}) (time.Time, error) {
+	if t.Layout == EpochAutoLayout {
+		return parseEpochAuto(value)
+	}
+
 	stamp, err := getEpochStamp(t.Layout, value)
 	if err != nil {
 		return time.Time{}, err
@@ -277,6 +326,59 @@ func getEpochStamp(layout string, value interface{}) (string, error) {
 	}
 }
 
+// parseEpochAuto parses an epoch timestamp, with an optional fractional part, detecting
+// its unit by the magnitude of its integer part: seconds below 1e11, which is the year 5138,
+// then milliseconds below 1e14, microseconds below 1e17 and nanoseconds above. This assumes
+// that the timestamps in milliseconds, microseconds or nanoseconds are after March 1973.
+func parseEpochAuto(value interface{}) (time.Time, error) {
+	var integer, fraction string
+	switch v := value.(type) {
+	case string:
+		integer, fraction, _ = strings.Cut(v, ".")
+	case []byte:
+		integer, fraction, _ = strings.Cut(string(v), ".")
+	case int, int32, int64, uint32, uint64:
+		integer = fmt.Sprintf("%d", v)
+	case float64:
+		integer, fraction, _ = strings.Cut(strconv.FormatFloat(v, 'f', -1, 64), ".")
+	default:
+		return time.Time{}, fmt.Errorf("type %T cannot be parsed as a time", v)
+	}
+
+	i, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid value '%v' for layout '%s'", value, EpochAutoLayout)
+	}
+
+	// Nanoseconds of the fraction of the unit, as if it was a fraction of a second
+	var frac int64
+	if fraction != "" {
+		if len(fraction) > 9 {
+			fraction = fraction[:9]
+		}
+		frac, err = strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+		if err != nil || frac < 0 {
+			return time.Time{}, fmt.Errorf("invalid value '%v' for layout '%s'", value, EpochAutoLayout)
+		}
+	}
+
+	magnitude := i
+	if magnitude < 0 {
+		magnitude = -magnitude
+		frac = -frac
+	}
+	switch {
+	case magnitude < 1e11:
+		return time.Unix(i, frac), nil
+	case magnitude < 1e14:
+		return time.Unix(i/1e3, (i%1e3)*1e6+frac/1e3), nil
+	case magnitude < 1e17:
+		return time.Unix(i/1e6, (i%1e6)*1e3+frac/1e6), nil
+	default:
+		return time.Unix(i/1e9, i%1e9+frac/1e9), nil
+	}
+}
+
 type toTimeFunc = func(int64) time.Time
 
 var toTime = map[string]toTimeFunc{
@@ -561,6 +561,20 @@ func TestTimeErrors(t *testing.T) {
 			sample:     "not-a-number",
 			parseErr:   true,
 		},
+		{
+			name:       "bad-epoch-auto-value",
+			layoutType: "epoch",
+			layout:     "auto",
+			sample:     "1136214245.not-a-number",
+			parseErr:   true,
+		},
+		{
+			name:       "bad-epoch-auto-type",
+			layoutType: "epoch",
+			layout:     "auto",
+			sample:     true,
+			parseErr:   true,
+		},
 	}
 
 	rootField := entry.NewBodyField()
@@ -577,6 +591,158 @@ func TestTimeErrors(t *testing.T) {
 	}
 }
 
+func TestTimeEpochAuto(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sample   interface{}
+		expected time.Time
+	}{
+		{
+			name:     "s-string",
+			sample:   "1136214245",
+			expected: time.Unix(1136214245, 0),
+		},
+		{
+			name:     "s-int",
+			sample:   1136214245,
+			expected: time.Unix(1136214245, 0),
+		},
+		{
+			name:     "s.ms-string",
+			sample:   "1136214245.123",
+			expected: time.Unix(1136214245, 123000000),
+		},
+		{
+			name:     "s.ns-string",
+			sample:   "1136214245.123456789",
+			expected: time.Unix(1136214245, 123456789),
+		},
+		{
+			name:     "s.ms-float",
+			sample:   1136214245.5,
+			expected: time.Unix(1136214245, 500000000),
+		},
+		{
+			name:     "ms-string",
+			sample:   "1136214245123",
+			expected: time.Unix(1136214245, 123000000),
+		},
+		{
+			name:     "ms-bytes",
+			sample:   []byte("1136214245123"),
+			expected: time.Unix(1136214245, 123000000),
+		},
+		{
+			name:     "ms-int",
+			sample:   1136214245123,
+			expected: time.Unix(1136214245, 123000000),
+		},
+		{
+			name:     "ms.us-string",
+			sample:   "1136214245123.456",
+			expected: time.Unix(1136214245, 123456000),
+		},
+		{
+			name:     "us-string",
+			sample:   "1136214245123456",
+			expected: time.Unix(1136214245, 123456000),
+		},
+		{
+			name:     "us-float",
+			sample:   1136214245123456.0,
+			expected: time.Unix(1136214245, 123456000),
+		},
+		{
+			name:     "ns-string",
+			sample:   "1136214245123456789",
+			expected: time.Unix(1136214245, 123456789),
+		},
+		{
+			name:     "ns-int",
+			sample:   1136214245123456789,
+			expected: time.Unix(1136214245, 123456789),
+		},
+		{
+			name:     "before-epoch",
+			sample:   "-86400.5",
+			expected: time.Unix(-86400, -500000000),
+		},
+	}
+
+	rootField := entry.NewBodyField()
+	for _, tc := range testCases {
+		cfg := parseTimeTestConfig(EpochKey, EpochAutoLayout, "", rootField)
+		t.Run(tc.name, runTimeParseTest(cfg, makeTestEntry(rootField, tc.sample), false, false, tc.expected))
+	}
+}
+
+func TestTimeFallbackLayouts(t *testing.T) {
+	testCases := []struct {
+		name       string
+		layoutType string
+		layout     string
+		fallbacks  []string
+		sample     string
+		expected   time.Time
+		buildErr   bool
+		parseErr   bool
+	}{
+		{
+			name:       "strptime-main",
+			layoutType: StrptimeKey,
+			layout:     "%Y-%m-%dT%H:%M:%SZ",
+			fallbacks:  []string{"%d/%m/%Y %H:%M:%S %z"},
+			sample:     "2006-01-02T15:04:05Z",
+			expected:   time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:       "strptime-fallback",
+			layoutType: StrptimeKey,
+			layout:     "%Y-%m-%dT%H:%M:%SZ",
+			fallbacks:  []string{"%Y-%m-%d", "%d/%m/%Y %H:%M:%S %z"},
+			sample:     "02/01/2006 15:04:05 -0700",
+			expected:   time.Date(2006, time.January, 2, 22, 4, 5, 0, time.UTC),
+		},
+		{
+			name:       "gotime-fallback-utc",
+			layoutType: GotimeKey,
+			layout:     time.RFC1123Z,
+			fallbacks:  []string{"2006-01-02T15:04:05Z"},
+			sample:     "2006-01-02T15:04:05Z",
+			expected:   time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:       "no-match",
+			layoutType: GotimeKey,
+			layout:     time.RFC1123Z,
+			fallbacks:  []string{time.RFC3339},
+			sample:     "not a time",
+			parseErr:   true,
+		},
+		{
+			name:       "bad-strptime-fallback",
+			layoutType: StrptimeKey,
+			layout:     "%Y-%m-%d",
+			fallbacks:  []string{"%1"},
+			buildErr:   true,
+		},
+		{
+			name:       "epoch",
+			layoutType: EpochKey,
+			layout:     "s",
+			fallbacks:  []string{"ms"},
+			buildErr:   true,
+		},
+	}
+
+	rootField := entry.NewBodyField()
+	for _, tc := range testCases {
+		cfg := parseTimeTestConfig(tc.layoutType, tc.layout, "", rootField)
+		cfg.FallbackLayouts = tc.fallbacks
+		t.Run(tc.name, runTimeParseTest(cfg, makeTestEntry(rootField, tc.sample), tc.buildErr, tc.parseErr, tc.expected))
+	}
+}
+
 func runTimeParseTest(timeParser *TimeParser, ent *entry.Entry, buildErr bool, parseErr bool, expected time.Time) func(*testing.T) {
 	return runLossyTimeParseTest(timeParser, ent, buildErr, parseErr, expected, time.Duration(0))
 }
@@ -656,6 +822,16 @@ func TestUnmarshalTimeConfig(t *testing.T) {
 					return c
 				}(),
 			},
+			{
+				Name: "fallback_layouts",
+				Expect: func() *helpersConfig {
+					c := newHelpersConfig()
+					c.Time = NewTimeParser()
+					c.Time.Layout = "%Y-%m-%dT%H:%M:%S"
+					c.Time.FallbackLayouts = []string{"%d/%m/%Y %H:%M:%S", "%b %d %H:%M:%S"}
+					return c
+				}(),
+			},
 			{
 				Name: "layout_type",
 				Expect: func() *helpersConfig {
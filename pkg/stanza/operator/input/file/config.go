@@ -15,6 +15,8 @@
 package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
 
 import (
+	"fmt"
+
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
@@ -45,6 +47,7 @@ func NewConfigWithID(operatorID string) *Config {
 type Config struct {
 	helper.InputConfig  `mapstructure:",squash"`
 	fileconsumer.Config `mapstructure:",squash"`
+	Preset              string `mapstructure:"preset,omitempty"`
 }
 
 // Build will build a file input operator from the supplied configuration
@@ -68,6 +71,17 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		preEmitOptions = append(preEmitOptions, setFilePathResolved)
 	}
 
+	switch c.Preset {
+	case "":
+	case presetKubernetes:
+		if len(c.Include) == 0 {
+			c.Include = []string{kubernetesInclude}
+		}
+		preEmitOptions = append(preEmitOptions, setKubernetesAttributes)
+	default:
+		return nil, fmt.Errorf("invalid preset '%s'", c.Preset)
+	}
+
 	var toBody toBodyFunc = func(token []byte) interface{} {
 		return string(token)
 	}
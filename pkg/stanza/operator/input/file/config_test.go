@@ -366,6 +366,15 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "preset_kubernetes",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Preset = "kubernetes"
+					return cfg
+				}(),
+			},
 			{
 				Name:      "start_at_string",
 				ExpectErr: false,
@@ -587,6 +596,34 @@ func TestBuild(t *testing.T) {
 			require.NoError,
 			func(t *testing.T, f *Input) {},
 		},
+		{
+			"KubernetesPreset",
+			func(f *Config) {
+				f.Preset = "kubernetes"
+			},
+			require.NoError,
+			func(t *testing.T, f *Input) {
+				expectOptions := []preEmitOption{setFileName, setKubernetesAttributes}
+				requireSamePreEmitOptions(t, expectOptions, f.preEmitOptions)
+			},
+		},
+		{
+			"KubernetesPresetDefaultInclude",
+			func(f *Config) {
+				f.Preset = "kubernetes"
+				f.Include = nil
+			},
+			require.NoError,
+			func(t *testing.T, f *Input) {},
+		},
+		{
+			"InvalidPreset",
+			func(f *Config) {
+				f.Preset = "docker"
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidEncoding",
 			func(f *Config) {
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/file"
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+)
+
+const (
+	presetKubernetes = "kubernetes"
+
+	// kubernetesInclude matches the log files written by the kubelet, at
+	// /var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log
+	kubernetesInclude = "/var/log/pods/*/*/*.log"
+)
+
+// kubernetesAttributes extracts the pod and the container of a log file from its path, either
+// written by the kubelet in /var/log/pods, or linked to from /var/log/containers as
+// <pod_name>_<namespace>_<container_name>-<container_id>.log
+func kubernetesAttributes(attrs *fileconsumer.FileAttributes) map[string]string {
+	resource := map[string]string{}
+	for _, path := range []string{attrs.Path, attrs.PathResolved} {
+		if path == "" {
+			continue
+		}
+		parsePodsPath(filepath.ToSlash(path), resource)
+		parseContainersPath(filepath.Base(path), resource)
+	}
+	return resource
+}
+
+// parsePodsPath parses /var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log,
+// or one of its rotated files, as <restart_count>.log.<timestamp>
+func parsePodsPath(path string, resource map[string]string) {
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return
+	}
+	pod := strings.Split(parts[len(parts)-3], "_")
+	container := parts[len(parts)-2]
+	restartCount, _, _ := strings.Cut(parts[len(parts)-1], ".")
+	if len(pod) != 3 || pod[0] == "" || pod[1] == "" || pod[2] == "" || container == "" || !isDigits(restartCount) {
+		return
+	}
+
+	resource["k8s.namespace.name"] = pod[0]
+	resource["k8s.pod.name"] = pod[1]
+	resource["k8s.pod.uid"] = pod[2]
+	resource["k8s.container.name"] = container
+	resource["k8s.container.restart_count"] = restartCount
+}
+
+// parseContainersPath parses the name of a /var/log/containers/<pod_name>_<namespace>_<container_name>-<container_id>.log file
+func parseContainersPath(name string, resource map[string]string) {
+	if !strings.HasSuffix(name, ".log") {
+		return
+	}
+	parts := strings.Split(strings.TrimSuffix(name, ".log"), "_")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return
+	}
+	sep := strings.LastIndex(parts[2], "-")
+	if sep <= 0 || sep == len(parts[2])-1 {
+		return
+	}
+
+	resource["k8s.pod.name"] = parts[0]
+	resource["k8s.namespace.name"] = parts[1]
+	resource["k8s.container.name"] = parts[2][:sep]
+	resource["container.id"] = parts[2][sep+1:]
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func setKubernetesAttributes(attrs *fileconsumer.FileAttributes, ent *entry.Entry) error {
+	for k, v := range kubernetesAttributes(attrs) {
+		if err := ent.Set(entry.NewResourceField(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const testContainerID = "5c7c1c8d4f19e1b2a1bb6e4b0c0e9f0d7d2a4c7b4e6f0e0c2d3b1a9f8e7d6c5b"
+
+func TestKubernetesAttributes(t *testing.T) {
+	cases := []struct {
+		name     string
+		attrs    fileconsumer.FileAttributes
+		expected map[string]string
+	}{
+		{
+			name: "pods",
+			attrs: fileconsumer.FileAttributes{
+				Path:         "/var/log/pods/kube-system_coredns-565d847f94-tqd8m_b2b6b5a3-3c7e-4b0c-9d1f-4d6b5e0f3f2a/coredns/2.log",
+				PathResolved: "/var/log/pods/kube-system_coredns-565d847f94-tqd8m_b2b6b5a3-3c7e-4b0c-9d1f-4d6b5e0f3f2a/coredns/2.log",
+			},
+			expected: map[string]string{
+				"k8s.namespace.name":          "kube-system",
+				"k8s.pod.name":                "coredns-565d847f94-tqd8m",
+				"k8s.pod.uid":                 "b2b6b5a3-3c7e-4b0c-9d1f-4d6b5e0f3f2a",
+				"k8s.container.name":          "coredns",
+				"k8s.container.restart_count": "2",
+			},
+		},
+		{
+			name: "rotated pods",
+			attrs: fileconsumer.FileAttributes{
+				Path: "/var/log/pods/default_web-0_0c2e7a0e-5b0a-4c5b-8d0e-6a9d7e4c2b1f/nginx/0.log.20221016-104517",
+			},
+			expected: map[string]string{
+				"k8s.namespace.name":          "default",
+				"k8s.pod.name":                "web-0",
+				"k8s.pod.uid":                 "0c2e7a0e-5b0a-4c5b-8d0e-6a9d7e4c2b1f",
+				"k8s.container.name":          "nginx",
+				"k8s.container.restart_count": "0",
+			},
+		},
+		{
+			name: "containers symlink",
+			attrs: fileconsumer.FileAttributes{
+				Path:         "/var/log/containers/web-0_default_nginx-" + testContainerID + ".log",
+				PathResolved: "/var/log/pods/default_web-0_0c2e7a0e-5b0a-4c5b-8d0e-6a9d7e4c2b1f/nginx/1.log",
+			},
+			expected: map[string]string{
+				"k8s.namespace.name":          "default",
+				"k8s.pod.name":                "web-0",
+				"k8s.pod.uid":                 "0c2e7a0e-5b0a-4c5b-8d0e-6a9d7e4c2b1f",
+				"k8s.container.name":          "nginx",
+				"k8s.container.restart_count": "1",
+				"container.id":                testContainerID,
+			},
+		},
+		{
+			name: "containers symlink to docker",
+			attrs: fileconsumer.FileAttributes{
+				Path:         "/var/log/containers/web-0_default_nginx-sidecar-" + testContainerID + ".log",
+				PathResolved: "/var/lib/docker/containers/" + testContainerID + "/" + testContainerID + "-json.log",
+			},
+			expected: map[string]string{
+				"k8s.namespace.name": "default",
+				"k8s.pod.name":       "web-0",
+				"k8s.container.name": "nginx-sidecar",
+				"container.id":       testContainerID,
+			},
+		},
+		{
+			name: "not kubernetes",
+			attrs: fileconsumer.FileAttributes{
+				Path:         "/var/log/syslog",
+				PathResolved: "/var/log/syslog",
+			},
+			expected: map[string]string{},
+		},
+		{
+			name: "not a restart count",
+			attrs: fileconsumer.FileAttributes{
+				Path: "/var/log/pods/default_web-0_0c2e7a0e/nginx/access.log",
+			},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, kubernetesAttributes(&tc.attrs))
+		})
+	}
+}
+
+func TestKubernetesPreset(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	podsDir := filepath.Join(root, "pods", "default_web-0_0c2e7a0e-5b0a-4c5b-8d0e-6a9d7e4c2b1f", "nginx")
+	containersDir := filepath.Join(root, "containers")
+	require.NoError(t, os.MkdirAll(podsDir, 0700))
+	require.NoError(t, os.MkdirAll(containersDir, 0700))
+
+	operator, logReceived, _ := newTestFileOperator(t, func(cfg *Config) {
+		cfg.Include = []string{filepath.Join(containersDir, "*.log")}
+		cfg.Preset = "kubernetes"
+	}, nil)
+
+	temp := openFile(t, filepath.Join(podsDir, "0.log"))
+	writeString(t, temp, "testlog\n")
+	symLinkPath := filepath.Join(containersDir, fmt.Sprintf("web-0_default_nginx-%s.log", testContainerID))
+	require.NoError(t, os.Symlink(temp.Name(), symLinkPath))
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	e := waitForOne(t, logReceived)
+	require.Equal(t, map[string]interface{}{
+		"k8s.namespace.name":          "default",
+		"k8s.pod.name":                "web-0",
+		"k8s.pod.uid":                 "0c2e7a0e-5b0a-4c5b-8d0e-6a9d7e4c2b1f",
+		"k8s.container.name":          "nginx",
+		"k8s.container.restart_count": "0",
+		"container.id":                testContainerID,
+	}, e.Resource)
+}
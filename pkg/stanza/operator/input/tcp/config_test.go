@@ -17,6 +17,7 @@ package tcp
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"go.opentelemetry.io/collector/config/configtls"
 
@@ -54,6 +55,13 @@ func TestUnmarshal(t *testing.T) {
 						},
 						ClientCAFile: "foo4",
 					}
+					cfg.TLSReload = true
+					cfg.ClientCertRevocation = &RevocationConfig{
+						CRLFiles:     []string{"foo5"},
+						OCSP:         true,
+						OCSPTimeout:  2 * time.Second,
+						OCSPFailOpen: true,
+					}
 					return cfg
 				}(),
 			},
@@ -79,6 +79,12 @@ type BaseConfig struct {
 	AddAttributes bool                        `mapstructure:"add_attributes,omitempty"`
 	Encoding      helper.EncodingConfig       `mapstructure:",squash,omitempty"`
 	Multiline     helper.MultilineConfig      `mapstructure:"multiline,omitempty"`
+
+	// TLSReload reloads the TLS configuration when one of its files changes on disk.
+	TLSReload bool `mapstructure:"tls_reload,omitempty"`
+
+	// ClientCertRevocation checks client certificates against CRLs and OCSP responders.
+	ClientCertRevocation *RevocationConfig `mapstructure:"client_cert_revocation,omitempty"`
 }
 
 // Build will build a tcp input operator.
@@ -135,11 +141,27 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		resolver: resolver,
 	}
 
-	if c.TLS != nil {
-		tcpInput.tls, err = c.TLS.LoadTLSConfig()
-		if err != nil {
-			return nil, err
+	if c.TLS == nil {
+		if c.TLSReload {
+			return nil, fmt.Errorf("'tls_reload' requires 'tls'")
+		}
+		if c.ClientCertRevocation != nil {
+			return nil, fmt.Errorf("'client_cert_revocation' requires 'tls'")
 		}
+		return tcpInput, nil
+	}
+
+	if c.ClientCertRevocation != nil && c.TLS.ClientCAFile == "" {
+		return nil, fmt.Errorf("'client_cert_revocation' requires 'tls.client_ca_file'")
+	}
+
+	tcpInput.tls, err = c.loadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.TLSReload {
+		tcpInput.tlsReloader = newTLSReloader(logger, tcpInput.tls, c.loadTLSConfig, c.tlsFiles())
 	}
 
 	return tcpInput, nil
@@ -158,6 +180,8 @@ type Input struct {
 	tls      *tls.Config
 	backoff  backoff.Backoff
 
+	tlsReloader *tlsReloader
+
 	encoding  helper.Encoding
 	splitFunc bufio.SplitFunc
 	resolver  *helper.IPResolver
@@ -185,10 +209,14 @@ func (t *Input) configureListener() error {
 		return nil
 	}
 
-	t.tls.Time = time.Now
-	t.tls.Rand = rand.Reader
+	tlsConfig := t.tls
+	if t.tlsReloader != nil {
+		tlsConfig = &tls.Config{GetConfigForClient: t.tlsReloader.getConfigForClient}
+	}
+	tlsConfig.Time = time.Now
+	tlsConfig.Rand = rand.Reader
 
-	listener, err := tls.Listen("tcp", t.address, t.tls)
+	listener, err := tls.Listen("tcp", t.address, tlsConfig)
 	if err != nil {
 		return fmt.Errorf("failed to configure tls listener: %w", err)
 	}
@@ -0,0 +1,331 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcp // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/tcp"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	// tlsReloadCheckInterval is the minimal duration between two checks of
+	// the TLS files for changes
+	tlsReloadCheckInterval = time.Second
+
+	// defaultOCSPTimeout is the timeout of OCSP requests if OCSPTimeout is not set
+	defaultOCSPTimeout = 5 * time.Second
+)
+
+// RevocationConfig is the configuration of the revocation checks of client certificates.
+type RevocationConfig struct {
+	// CRLFiles are PEM or DER encoded certificate revocation lists.
+	CRLFiles []string `mapstructure:"crl_files,omitempty"`
+
+	// OCSP enables checking client certificates against the OCSP responder they list.
+	OCSP bool `mapstructure:"ocsp,omitempty"`
+
+	// OCSPTimeout is the timeout of a request to an OCSP responder.
+	OCSPTimeout time.Duration `mapstructure:"ocsp_timeout,omitempty"`
+
+	// OCSPFailOpen accepts client certificates whose OCSP status can't be determined.
+	OCSPFailOpen bool `mapstructure:"ocsp_fail_open,omitempty"`
+}
+
+// loadTLSConfig loads the TLS configuration of the listener, including the
+// revocation checks of client certificates.
+func (c BaseConfig) loadTLSConfig() (*tls.Config, error) {
+	tlsConfig, err := c.TLS.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if c.ClientCertRevocation == nil {
+		return tlsConfig, nil
+	}
+
+	checker, err := newRevocationChecker(*c.ClientCertRevocation)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.VerifyPeerCertificate = checker.verifyPeerCertificate
+	return tlsConfig, nil
+}
+
+// tlsFiles returns the files the TLS configuration is loaded from.
+func (c BaseConfig) tlsFiles() []string {
+	var files []string
+	for _, file := range []string{c.TLS.CertFile, c.TLS.KeyFile, c.TLS.CAFile, c.TLS.ClientCAFile} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	if c.ClientCertRevocation != nil {
+		files = append(files, c.ClientCertRevocation.CRLFiles...)
+	}
+	return files
+}
+
+// tlsReloader provides the TLS configuration of new connections, loading it
+// again when one of the files it is built from changed on disk.
+type tlsReloader struct {
+	logger *zap.SugaredLogger
+	load   func() (*tls.Config, error)
+	files  []string
+
+	mu        sync.Mutex
+	config    *tls.Config
+	modTimes  map[string]time.Time
+	nextCheck time.Time
+}
+
+func newTLSReloader(logger *zap.SugaredLogger, config *tls.Config, load func() (*tls.Config, error), files []string) *tlsReloader {
+	r := &tlsReloader{
+		logger: logger,
+		load:   load,
+		files:  files,
+		config: config,
+	}
+	r.modTimes, _ = r.readModTimes()
+	return r
+}
+
+func (r *tlsReloader) readModTimes() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, len(r.files))
+	for _, file := range r.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (r *tlsReloader) changed(modTimes map[string]time.Time) bool {
+	for file, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+// getConfigForClient returns the current TLS configuration. Files that can't be
+// read or loaded keep the previous configuration in use, they are tried again
+// on the next check.
+func (r *tlsReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(r.nextCheck) {
+		return r.config, nil
+	}
+	r.nextCheck = now.Add(tlsReloadCheckInterval)
+
+	modTimes, err := r.readModTimes()
+	if err != nil {
+		r.logger.Warnw("Failed to check TLS files for changes", zap.Error(err))
+		return r.config, nil
+	}
+	if !r.changed(modTimes) {
+		return r.config, nil
+	}
+
+	config, err := r.load()
+	if err != nil {
+		r.logger.Warnw("Failed to reload TLS configuration, keeping the previous one", zap.Error(err))
+		return r.config, nil
+	}
+	r.logger.Info("Reloaded TLS configuration")
+	r.config = config
+	r.modTimes = modTimes
+	return r.config, nil
+}
+
+// revocationChecker rejects client certificates that have been revoked.
+type revocationChecker struct {
+	crls         []*pkix.CertificateList
+	ocsp         bool
+	ocspFailOpen bool
+	client       *http.Client
+
+	mu        sync.Mutex
+	ocspCache map[string]*ocsp.Response
+}
+
+func newRevocationChecker(cfg RevocationConfig) (*revocationChecker, error) {
+	c := &revocationChecker{
+		ocsp:         cfg.OCSP,
+		ocspFailOpen: cfg.OCSPFailOpen,
+		ocspCache:    make(map[string]*ocsp.Response),
+	}
+
+	for _, file := range cfg.CRLFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CRL file %s: %w", file, err)
+		}
+		// x509.ParseRevocationList requires go 1.19.
+		crl, err := x509.ParseCRL(data) //nolint:staticcheck
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CRL file %s: %w", file, err)
+		}
+		c.crls = append(c.crls, crl)
+	}
+
+	timeout := cfg.OCSPTimeout
+	if timeout == 0 {
+		timeout = defaultOCSPTimeout
+	}
+	c.client = &http.Client{Timeout: timeout}
+	return c, nil
+}
+
+// verifyPeerCertificate accepts the client certificate if one of its verified
+// chains has no revoked certificate.
+func (c *revocationChecker) verifyPeerCertificate(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+	var err error
+	for _, chain := range verifiedChains {
+		if err = c.verifyChain(chain); err == nil {
+			return nil
+		}
+	}
+	if err == nil {
+		return errors.New("no verified client certificate chain")
+	}
+	return err
+}
+
+func (c *revocationChecker) verifyChain(chain []*x509.Certificate) error {
+	for i := 0; i < len(chain)-1; i++ {
+		cert, issuer := chain[i], chain[i+1]
+		if c.revokedByCRL(cert, issuer) {
+			return fmt.Errorf("certificate %q has been revoked", cert.Subject)
+		}
+	}
+	if c.ocsp && len(chain) > 1 {
+		return c.verifyOCSP(chain[0], chain[1])
+	}
+	return nil
+}
+
+func (c *revocationChecker) revokedByCRL(cert, issuer *x509.Certificate) bool {
+	for _, crl := range c.crls {
+		// CRLs that are not signed by the issuer don't apply to the certificate.
+		if err := issuer.CheckCRLSignature(crl); err != nil { //nolint:staticcheck
+			continue
+		}
+		for _, revoked := range crl.TBSCertList.RevokedCertificates {
+			if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verifyOCSP asks the OCSP responders listed in the certificate for its status.
+// Responses are cached until their next update.
+func (c *revocationChecker) verifyOCSP(cert, issuer *x509.Certificate) error {
+	if len(cert.OCSPServer) == 0 {
+		return nil
+	}
+
+	key := string(issuer.RawSubjectPublicKeyInfo) + cert.SerialNumber.String()
+	c.mu.Lock()
+	resp, ok := c.ocspCache[key]
+	c.mu.Unlock()
+	if !ok || time.Now().After(resp.NextUpdate) {
+		var err error
+		resp, err = c.requestOCSP(cert, issuer)
+		if err != nil {
+			if c.ocspFailOpen {
+				return nil
+			}
+			return fmt.Errorf("failed to check OCSP status of certificate %q: %w", cert.Subject, err)
+		}
+		if !resp.NextUpdate.IsZero() {
+			c.mu.Lock()
+			c.ocspCache[key] = resp
+			c.mu.Unlock()
+		}
+	}
+
+	switch resp.Status {
+	case ocsp.Good:
+		return nil
+	case ocsp.Revoked:
+		return fmt.Errorf("certificate %q has been revoked", cert.Subject)
+	default:
+		if c.ocspFailOpen {
+			return nil
+		}
+		return fmt.Errorf("unknown OCSP status of certificate %q", cert.Subject)
+	}
+}
+
+func (c *revocationChecker) requestOCSP(cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs error
+	for _, server := range cert.OCSPServer {
+		resp, err := c.postOCSP(server, req, cert, issuer)
+		if err == nil {
+			return resp, nil
+		}
+		errs = multierr.Append(errs, err)
+	}
+	return nil, errs
+}
+
+func (c *revocationChecker) postOCSP(server string, req []byte, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	httpReq, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server, bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCSP responder %s returned %s", server, httpResp.Status)
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return ocsp.ParseResponseForCert(body, cert, issuer)
+}
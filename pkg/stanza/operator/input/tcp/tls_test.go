@@ -0,0 +1,321 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, serial int64, issuer *testCert, ocspServer string) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "test"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if ocspServer != "" {
+		template.OCSPServer = []string{ocspServer}
+	}
+
+	parent, parentKey := template, key
+	if issuer == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		parent, parentKey = issuer.cert, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) writeFiles(t *testing.T, dir, name string) (string, string) {
+	certFile := filepath.Join(dir, name+".crt")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600))
+
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key}
+}
+
+func startTLSInput(t *testing.T, cfg *Config) *Input {
+	cfg.ListenAddress = "127.0.0.1:0"
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	tcpInput := op.(*Input)
+	require.NoError(t, tcpInput.Start(testutil.NewMockPersister("test")))
+	t.Cleanup(func() {
+		require.NoError(t, tcpInput.Stop())
+	})
+	return tcpInput
+}
+
+// handshake connects to the input and returns the certificate presented by the
+// server, or the error of the handshake.
+func handshake(t *testing.T, tcpInput *Input, ca *testCert, client *testCert) (*x509.Certificate, error) {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	config := &tls.Config{RootCAs: roots, ServerName: "localhost"}
+	if client != nil {
+		config.Certificates = []tls.Certificate{client.tlsCertificate()}
+	}
+
+	conn, err := tls.Dial("tcp", tcpInput.listener.Addr().String(), config)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Client certificates are verified by the server after the client finished
+	// its side of the handshake, so read to get the server's verdict.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	_, err = conn.Read(make([]byte, 1))
+	if netErr, ok := err.(interface{ Timeout() bool }); ok && netErr.Timeout() {
+		err = nil
+	}
+	return conn.ConnectionState().PeerCertificates[0], err
+}
+
+func TestBuildTLSOptions(t *testing.T) {
+	cases := []struct {
+		name      string
+		tls       *configtls.TLSServerSetting
+		reload    bool
+		crlFiles  []string
+		expectErr string
+	}{
+		{
+			name:      "reload-without-tls",
+			reload:    true,
+			expectErr: "'tls_reload' requires 'tls'",
+		},
+		{
+			name:      "revocation-without-tls",
+			crlFiles:  []string{},
+			expectErr: "'client_cert_revocation' requires 'tls'",
+		},
+		{
+			name:      "revocation-without-client-ca",
+			tls:       &configtls.TLSServerSetting{},
+			crlFiles:  []string{},
+			expectErr: "'client_cert_revocation' requires 'tls.client_ca_file'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			cfg.ListenAddress = "127.0.0.1:0"
+			cfg.TLS = tc.tls
+			cfg.TLSReload = tc.reload
+			if tc.crlFiles != nil {
+				cfg.ClientCertRevocation = &RevocationConfig{CRLFiles: tc.crlFiles}
+			}
+			_, err := cfg.Build(testutil.Logger(t))
+			require.EqualError(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestTLSReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, 1, nil, "")
+	first := newTestCert(t, 2, ca, "")
+	certFile, keyFile := first.writeFiles(t, dir, "server")
+
+	cfg := NewConfigWithID("test_id")
+	cfg.TLS = &configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		},
+	}
+	cfg.TLSReload = true
+	tcpInput := startTLSInput(t, cfg)
+
+	cert, err := handshake(t, tcpInput, ca, nil)
+	require.NoError(t, err)
+	require.Equal(t, first.cert.SerialNumber, cert.SerialNumber)
+
+	second := newTestCert(t, 3, ca, "")
+	second.writeFiles(t, dir, "server")
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+
+	tcpInput.tlsReloader.mu.Lock()
+	tcpInput.tlsReloader.nextCheck = time.Time{}
+	tcpInput.tlsReloader.mu.Unlock()
+
+	cert, err = handshake(t, tcpInput, ca, nil)
+	require.NoError(t, err)
+	require.Equal(t, second.cert.SerialNumber, cert.SerialNumber)
+
+	// A broken file keeps the previous configuration in use.
+	require.NoError(t, os.WriteFile(certFile, []byte("invalid"), 0600))
+	tcpInput.tlsReloader.mu.Lock()
+	tcpInput.tlsReloader.nextCheck = time.Time{}
+	tcpInput.tlsReloader.mu.Unlock()
+
+	cert, err = handshake(t, tcpInput, ca, nil)
+	require.NoError(t, err)
+	require.Equal(t, second.cert.SerialNumber, cert.SerialNumber)
+}
+
+func TestClientCertRevocationCRL(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, 1, nil, "")
+	server := newTestCert(t, 2, ca, "")
+	valid := newTestCert(t, 3, ca, "")
+	revoked := newTestCert(t, 4, ca, "")
+
+	certFile, keyFile := server.writeFiles(t, dir, "server")
+	caFile, _ := ca.writeFiles(t, dir, "ca")
+
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:              big.NewInt(1),
+		ThisUpdate:          time.Now(),
+		NextUpdate:          time.Now().Add(time.Hour),
+		RevokedCertificates: []pkix.RevokedCertificate{{SerialNumber: revoked.cert.SerialNumber, RevocationTime: time.Now()}},
+	}, ca.cert, ca.key)
+	require.NoError(t, err)
+	crlFile := filepath.Join(dir, "ca.crl")
+	require.NoError(t, os.WriteFile(crlFile, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}), 0600))
+
+	cfg := NewConfigWithID("test_id")
+	cfg.TLS = &configtls.TLSServerSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		},
+		ClientCAFile: caFile,
+	}
+	cfg.ClientCertRevocation = &RevocationConfig{CRLFiles: []string{crlFile}}
+	tcpInput := startTLSInput(t, cfg)
+
+	_, err = handshake(t, tcpInput, ca, valid)
+	require.NoError(t, err)
+
+	_, err = handshake(t, tcpInput, ca, revoked)
+	require.Error(t, err)
+}
+
+func TestClientCertRevocationOCSP(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, 1, nil, "")
+
+	revokedSerial := big.NewInt(4)
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+
+		status := ocsp.Good
+		if req.SerialNumber.Cmp(revokedSerial) == 0 {
+			status = ocsp.Revoked
+		}
+		resp, err := ocsp.CreateResponse(ca.cert, ca.cert, ocsp.Response{
+			Status:       status,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now(),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    time.Now(),
+		}, ca.key)
+		require.NoError(t, err)
+		_, _ = w.Write(resp)
+	}))
+	defer responder.Close()
+
+	server := newTestCert(t, 2, ca, "")
+	valid := newTestCert(t, 3, ca, responder.URL)
+	revoked := newTestCert(t, revokedSerial.Int64(), ca, responder.URL)
+	unreachable := newTestCert(t, 5, ca, "http://127.0.0.1:1")
+
+	certFile, keyFile := server.writeFiles(t, dir, "server")
+	caFile, _ := ca.writeFiles(t, dir, "ca")
+
+	for _, tc := range []struct {
+		name     string
+		failOpen bool
+		client   *testCert
+		wantErr  bool
+	}{
+		{name: "good", client: valid},
+		{name: "revoked", client: revoked, wantErr: true},
+		{name: "unreachable", client: unreachable, wantErr: true},
+		{name: "unreachable fail open", failOpen: true, client: unreachable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			cfg.TLS = &configtls.TLSServerSetting{
+				TLSSetting: configtls.TLSSetting{
+					CertFile: certFile,
+					KeyFile:  keyFile,
+				},
+				ClientCAFile: caFile,
+			}
+			cfg.ClientCertRevocation = &RevocationConfig{OCSP: true, OCSPFailOpen: tc.failOpen, OCSPTimeout: time.Second}
+			tcpInput := startTLSInput(t, cfg)
+
+			_, err := handshake(t, tcpInput, ca, tc.client)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
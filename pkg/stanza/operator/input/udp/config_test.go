@@ -46,6 +46,20 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "async",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ListenAddress = "10.0.0.1:9000"
+					cfg.AsyncConfig = &AsyncConfig{
+						Readers:        2,
+						Processors:     4,
+						MaxQueueLength: 1000,
+					}
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package udp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropReporting(t *testing.T) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadBuffer(4096))
+	require.NoError(t, enableDropReporting(conn))
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Overflow the receive buffer, then drain it.
+	for i := 0; i < 100; i++ {
+		_, err = client.Write(make([]byte, 1000))
+		require.NoError(t, err)
+	}
+	buf := make([]byte, MaxUDPSize)
+	oob := make([]byte, oobSize)
+	for {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+		if _, _, _, _, err = conn.ReadMsgUDP(buf, oob); err != nil {
+			break
+		}
+	}
+
+	// The next message reports the count of dropped packets.
+	_, err = client.Write([]byte("message"))
+	require.NoError(t, err)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	_, oobn, _, _, err := conn.ReadMsgUDP(buf, oob)
+	require.NoError(t, err)
+	count, ok := parseDropCount(oob[:oobn])
+	require.True(t, ok)
+	require.Greater(t, count, uint32(0))
+}
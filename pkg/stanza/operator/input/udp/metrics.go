@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package udp // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/udp"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagListenAddress, _ = tag.NewKey("listen_address")
+
+	statDroppedPackets = stats.Int64("udp_input_dropped_packets", "Number of packets dropped by the kernel because the socket receive buffer was full", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metric views of the udp input operator.
+func MetricViews() []*view.View {
+	countDroppedPackets := &view.View{
+		Name:        statDroppedPackets.Name(),
+		Measure:     statDroppedPackets,
+		Description: statDroppedPackets.Description(),
+		TagKeys:     []tag.Key{tagListenAddress},
+		Aggregation: view.Sum(),
+	}
+
+	return []*view.View{countDroppedPackets}
+}
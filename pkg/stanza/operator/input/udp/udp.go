@@ -18,11 +18,15 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
 	"sync"
+	"sync/atomic"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -34,6 +38,10 @@ const (
 
 	// Maximum UDP packet size
 	MaxUDPSize = 64 * 1024
+
+	defaultReaders        = 1
+	defaultProcessors     = 1
+	defaultMaxQueueLength = 100
 )
 
 func init() {
@@ -71,6 +79,20 @@ type BaseConfig struct {
 	AddAttributes bool                   `mapstructure:"add_attributes,omitempty"`
 	Encoding      helper.EncodingConfig  `mapstructure:",squash,omitempty"`
 	Multiline     helper.MultilineConfig `mapstructure:"multiline,omitempty"`
+	AsyncConfig   *AsyncConfig           `mapstructure:"async,omitempty"`
+}
+
+// AsyncConfig is the configuration of the asynchronous reading of the messages,
+// where the messages read from the socket are queued to be processed separately,
+// so that bursts of messages are read before the socket receive buffer is full.
+type AsyncConfig struct {
+	// Readers is the number of goroutines reading the messages from the socket.
+	Readers int `mapstructure:"readers,omitempty"`
+	// Processors is the number of goroutines turning the messages into entries.
+	Processors int `mapstructure:"processors,omitempty"`
+	// MaxQueueLength is the number of messages waiting to be processed beyond
+	// which the readers wait for the processors.
+	MaxQueueLength int `mapstructure:"max_queue_length,omitempty"`
 }
 
 // Build will build a udp input operator.
@@ -100,6 +122,18 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	var async *AsyncConfig
+	if c.AsyncConfig != nil {
+		async = &AsyncConfig{
+			Readers:        defaultReaders,
+			Processors:     defaultProcessors,
+			MaxQueueLength: defaultMaxQueueLength,
+		}
+		if err = c.AsyncConfig.build(async); err != nil {
+			return nil, err
+		}
+	}
+
 	var resolver *helper.IPResolver
 	if c.AddAttributes {
 		resolver = helper.NewIPResolver()
@@ -108,31 +142,72 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 	udpInput := &Input{
 		InputOperator: inputOperator,
 		address:       address,
-		buffer:        make([]byte, MaxUDPSize),
 		addAttributes: c.AddAttributes,
+		async:         async,
 		encoding:      encoding,
 		splitFunc:     splitFunc,
 		resolver:      resolver,
 	}
+
+	// The read buffers are all allocated upfront, one per reader and processor,
+	// and one per message in the queue.
+	bufferCount := 1
+	if async != nil {
+		bufferCount = async.Readers + async.Processors + async.MaxQueueLength
+		udpInput.messageQueue = make(chan message, async.MaxQueueLength)
+	}
+	udpInput.buffers = make(chan []byte, bufferCount)
+	for i := 0; i < bufferCount; i++ {
+		udpInput.buffers <- make([]byte, MaxUDPSize)
+	}
 	return udpInput, nil
 }
 
+// build fills the non-zero settings of c in async, and validates them.
+func (c AsyncConfig) build(async *AsyncConfig) error {
+	if c.Readers < 0 || c.Processors < 0 || c.MaxQueueLength < 0 {
+		return fmt.Errorf("async readers, processors and max_queue_length can't be negative")
+	}
+	if c.Readers > 0 {
+		async.Readers = c.Readers
+	}
+	if c.Processors > 0 {
+		async.Processors = c.Processors
+	}
+	if c.MaxQueueLength > 0 {
+		async.MaxQueueLength = c.MaxQueueLength
+	}
+	return nil
+}
+
 // Input is an operator that listens to a socket for log entries.
 type Input struct {
-	buffer []byte
 	helper.InputOperator
 	address       *net.UDPAddr
 	addAttributes bool
+	async         *AsyncConfig // async is nil when the messages are read and processed by a single goroutine
 
-	connection net.PacketConn
+	connection *net.UDPConn
 	cancel     context.CancelFunc
 	wg         sync.WaitGroup
 
+	buffers      chan []byte  // buffers holds the read buffers which are not in use
+	messageQueue chan message // messageQueue holds the messages waiting to be processed in async mode
+	drops        uint32       // drops is the last count of packets dropped by the kernel, as reported with SO_RXQ_OVFL
+
 	encoding  helper.Encoding
 	splitFunc bufio.SplitFunc
 	resolver  *helper.IPResolver
 }
 
+// message is a message read from the socket, whose buffer must be released
+// once it has been processed.
+type message struct {
+	buffer     []byte
+	data       []byte
+	remoteAddr net.Addr
+}
+
 // Start will start listening for messages on a socket.
 func (u *Input) Start(persister operator.Persister) error {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -144,7 +219,20 @@ func (u *Input) Start(persister operator.Persister) error {
 	}
 	u.connection = conn
 
-	u.goHandleMessages(ctx)
+	if err = enableDropReporting(conn); err != nil {
+		u.Debugw("Failed to enable the reporting of dropped packets", zap.Error(err))
+	}
+
+	if u.async == nil {
+		u.goHandleMessages(ctx)
+		return nil
+	}
+	for i := 0; i < u.async.Readers; i++ {
+		u.goReadMessages(ctx)
+	}
+	for i := 0; i < u.async.Processors; i++ {
+		u.goProcessMessages(ctx)
+	}
 	return nil
 }
 
@@ -156,74 +244,166 @@ func (u *Input) goHandleMessages(ctx context.Context) {
 		defer u.wg.Done()
 
 		buf := make([]byte, 0, MaxUDPSize)
+		oob := make([]byte, oobSize)
 		for {
-			message, remoteAddr, err := u.readMessage()
+			msg, err := u.readMessage(oob)
 			if err != nil {
-				select {
-				case <-ctx.Done():
+				if u.readFailed(ctx, err) {
 					return
-				default:
-					u.Errorw("Failed reading messages", zap.Error(err))
 				}
-				break
+				continue
 			}
 
-			scanner := bufio.NewScanner(bytes.NewReader(message))
-			scanner.Buffer(buf, MaxUDPSize)
-
-			scanner.Split(u.splitFunc)
+			u.processMessage(ctx, msg, buf)
+		}
+	}()
+}
 
-			for scanner.Scan() {
-				decoded, err := u.encoding.Decode(scanner.Bytes())
-				if err != nil {
-					u.Errorw("Failed to decode data", zap.Error(err))
-					continue
-				}
+// goReadMessages will read messages from a udp connection and queue them
+// to be processed by goProcessMessages.
+func (u *Input) goReadMessages(ctx context.Context) {
+	u.wg.Add(1)
 
-				entry, err := u.NewEntry(string(decoded))
-				if err != nil {
-					u.Errorw("Failed to create entry", zap.Error(err))
-					continue
-				}
+	go func() {
+		defer u.wg.Done()
 
-				if u.addAttributes {
-					entry.AddAttribute("net.transport", "IP.UDP")
-					if addr, ok := u.connection.LocalAddr().(*net.UDPAddr); ok {
-						ip := addr.IP.String()
-						entry.AddAttribute("net.host.ip", addr.IP.String())
-						entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
-						entry.AddAttribute("net.host.name", u.resolver.GetHostFromIP(ip))
-					}
-
-					if addr, ok := remoteAddr.(*net.UDPAddr); ok {
-						ip := addr.IP.String()
-						entry.AddAttribute("net.peer.ip", ip)
-						entry.AddAttribute("net.peer.port", strconv.FormatInt(int64(addr.Port), 10))
-						entry.AddAttribute("net.peer.name", u.resolver.GetHostFromIP(ip))
-					}
+		oob := make([]byte, oobSize)
+		for {
+			msg, err := u.readMessage(oob)
+			if err != nil {
+				if u.readFailed(ctx, err) {
+					return
 				}
+				continue
+			}
 
-				u.Write(ctx, entry)
+			select {
+			case u.messageQueue <- msg:
+			case <-ctx.Done():
+				u.buffers <- msg.buffer
+				return
 			}
-			if err := scanner.Err(); err != nil {
-				u.Errorw("Scanner error", zap.Error(err))
+		}
+	}()
+}
+
+// goProcessMessages will process the messages queued by goReadMessages.
+func (u *Input) goProcessMessages(ctx context.Context) {
+	u.wg.Add(1)
+
+	go func() {
+		defer u.wg.Done()
+
+		buf := make([]byte, 0, MaxUDPSize)
+		for {
+			select {
+			case msg := <-u.messageQueue:
+				u.processMessage(ctx, msg, buf)
+			case <-ctx.Done():
+				return
 			}
 		}
 	}()
 }
 
-// readMessage will read log messages from the connection.
-func (u *Input) readMessage() ([]byte, net.Addr, error) {
-	n, addr, err := u.connection.ReadFrom(u.buffer)
+// processMessage writes the entries of a message, then releases its buffer.
+func (u *Input) processMessage(ctx context.Context, msg message, buf []byte) {
+	defer func() { u.buffers <- msg.buffer }()
+
+	scanner := bufio.NewScanner(bytes.NewReader(msg.data))
+	scanner.Buffer(buf, MaxUDPSize)
+
+	scanner.Split(u.splitFunc)
+
+	for scanner.Scan() {
+		decoded, err := u.encoding.Decode(scanner.Bytes())
+		if err != nil {
+			u.Errorw("Failed to decode data", zap.Error(err))
+			continue
+		}
+
+		entry, err := u.NewEntry(string(decoded))
+		if err != nil {
+			u.Errorw("Failed to create entry", zap.Error(err))
+			continue
+		}
+
+		if u.addAttributes {
+			entry.AddAttribute("net.transport", "IP.UDP")
+			if addr, ok := u.connection.LocalAddr().(*net.UDPAddr); ok {
+				ip := addr.IP.String()
+				entry.AddAttribute("net.host.ip", addr.IP.String())
+				entry.AddAttribute("net.host.port", strconv.FormatInt(int64(addr.Port), 10))
+				entry.AddAttribute("net.host.name", u.resolver.GetHostFromIP(ip))
+			}
+
+			if addr, ok := msg.remoteAddr.(*net.UDPAddr); ok {
+				ip := addr.IP.String()
+				entry.AddAttribute("net.peer.ip", ip)
+				entry.AddAttribute("net.peer.port", strconv.FormatInt(int64(addr.Port), 10))
+				entry.AddAttribute("net.peer.name", u.resolver.GetHostFromIP(ip))
+			}
+		}
+
+		u.Write(ctx, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		u.Errorw("Scanner error", zap.Error(err))
+	}
+}
+
+// readFailed logs a read error and reports whether the reader must stop, which it
+// must once the input is stopping, or when the error is not temporary.
+func (u *Input) readFailed(ctx context.Context, err error) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+	}
+	u.Errorw("Failed reading messages", zap.Error(err))
+	var netErr net.Error
+	return !errors.As(err, &netErr) || !netErr.Temporary() //nolint:staticcheck
+}
+
+// readMessage will read log messages from the connection into a free buffer.
+func (u *Input) readMessage(oob []byte) (message, error) {
+	buffer := <-u.buffers
+	n, oobn, _, addr, err := u.connection.ReadMsgUDP(buffer, oob)
 	if err != nil {
-		return nil, nil, err
+		u.buffers <- buffer
+		return message{}, err
+	}
+
+	if count, ok := parseDropCount(oob[:oobn]); ok {
+		u.recordDrops(count)
 	}
 
 	// Remove trailing characters and NULs
-	for ; (n > 0) && (u.buffer[n-1] < 32); n-- {
+	for ; (n > 0) && (buffer[n-1] < 32); n-- {
 	}
 
-	return u.buffer[:n], addr, nil
+	return message{buffer: buffer, data: buffer[:n], remoteAddr: addr}, nil
+}
+
+// recordDrops records the packets dropped since the last count reported by the kernel.
+// The count is shared by the readers, which may see the counts out of order.
+func (u *Input) recordDrops(count uint32) {
+	for {
+		last := atomic.LoadUint32(&u.drops)
+		dropped := count - last
+		if int32(dropped) <= 0 {
+			return
+		}
+		if atomic.CompareAndSwapUint32(&u.drops, last, count) {
+			u.Debugw("Packets dropped by the kernel", zap.Uint32("dropped", dropped))
+			_ = stats.RecordWithTags(
+				context.Background(),
+				[]tag.Mutator{tag.Upsert(tagListenAddress, u.address.String())},
+				statDroppedPackets.M(int64(dropped)),
+			)
+			return
+		}
+	}
 }
 
 // Stop will stop listening for udp messages.
@@ -235,6 +415,10 @@ func (u *Input) Stop() error {
 		}
 	}
 	u.wg.Wait()
+	// Release the buffers of the messages which were not processed.
+	for len(u.messageQueue) > 0 {
+		u.buffers <- (<-u.messageQueue).buffer
+	}
 	if u.resolver != nil {
 		u.resolver.Stop()
 	}
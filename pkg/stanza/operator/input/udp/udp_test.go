@@ -15,6 +15,8 @@
 package udp
 
 import (
+	"context"
+	"math"
 	"math/rand"
 	"net"
 	"strconv"
@@ -30,10 +32,13 @@ import (
 )
 
 func udpInputTest(input []byte, expected []string) func(t *testing.T) {
-	return func(t *testing.T) {
-		cfg := NewConfigWithID("test_input")
-		cfg.ListenAddress = ":0"
+	cfg := NewConfigWithID("test_input")
+	cfg.ListenAddress = ":0"
+	return udpInputConfigTest(cfg, input, expected)
+}
 
+func udpInputConfigTest(cfg *Config, input []byte, expected []string) func(t *testing.T) {
+	return func(t *testing.T) {
 		op, err := cfg.Build(testutil.Logger(t))
 		require.NoError(t, err)
 
@@ -162,6 +167,89 @@ func TestInputAttributes(t *testing.T) {
 	t.Run("NewlineInMessage", udpInputAttributesTest([]byte("message1\nmessage2\n"), []string{"message1\nmessage2"}))
 }
 
+func TestInputAsync(t *testing.T) {
+	cfg := NewConfigWithID("test_input")
+	cfg.ListenAddress = ":0"
+	cfg.AsyncConfig = &AsyncConfig{
+		Readers:    2,
+		Processors: 2,
+	}
+
+	t.Run("Simple", udpInputConfigTest(cfg, []byte("message1"), []string{"message1"}))
+	t.Run("TrailingNewlines", udpInputConfigTest(cfg, []byte("message1\n"), []string{"message1"}))
+	t.Run("NewlineInMessage", udpInputConfigTest(cfg, []byte("message1\nmessage2\n"), []string{"message1\nmessage2"}))
+}
+
+func TestBuildAsync(t *testing.T) {
+	cfg := NewConfigWithID("test_input")
+	cfg.ListenAddress = ":0"
+	cfg.AsyncConfig = &AsyncConfig{Processors: 4}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	udpInput := op.(*Input)
+	require.Equal(t, &AsyncConfig{Readers: 1, Processors: 4, MaxQueueLength: 100}, udpInput.async)
+	require.Equal(t, 1+4+100, len(udpInput.buffers))
+	require.Equal(t, 100, cap(udpInput.messageQueue))
+
+	cfg.AsyncConfig = &AsyncConfig{Readers: -1}
+	_, err = cfg.Build(testutil.Logger(t))
+	require.Error(t, err)
+}
+
+func TestStopAsyncReleasesBuffers(t *testing.T) {
+	cfg := NewConfigWithID("test_input")
+	cfg.ListenAddress = ":0"
+	cfg.AsyncConfig = &AsyncConfig{Readers: 1, Processors: 1, MaxQueueLength: 1}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	udpInput := op.(*Input)
+
+	// the output blocks until the input stops, so that the reader ends up holding
+	// a buffer while it waits for the full queue
+	mockOutput := testutil.Operator{}
+	mockOutput.On("Process", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(nil)
+	udpInput.InputOperator.OutputOperators = []operator.Operator{&mockOutput}
+
+	require.NoError(t, udpInput.Start(testutil.NewMockPersister("test")))
+
+	conn, err := net.Dial("udp", udpInput.connection.LocalAddr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	for i := 0; i < 5; i++ {
+		_, err = conn.Write([]byte("message"))
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool { return len(udpInput.buffers) == 0 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, udpInput.Stop())
+	require.Equal(t, cap(udpInput.buffers), len(udpInput.buffers))
+}
+
+func TestRecordDrops(t *testing.T) {
+	cfg := NewConfigWithID("test_input")
+	cfg.ListenAddress = ":0"
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	udpInput := op.(*Input)
+
+	udpInput.recordDrops(3)
+	require.Equal(t, uint32(3), udpInput.drops)
+	// A count older than the last one, as seen by a slower reader, is ignored.
+	udpInput.recordDrops(2)
+	require.Equal(t, uint32(3), udpInput.drops)
+	udpInput.recordDrops(10)
+	require.Equal(t, uint32(10), udpInput.drops)
+	// The count wraps around.
+	udpInput.drops = math.MaxUint32
+	udpInput.recordDrops(1)
+	require.Equal(t, uint32(1), udpInput.drops)
+}
+
 func TestFailToBind(t *testing.T) {
 	ip := "localhost"
 	port := 0
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracecontext // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/tracecontext"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	formatW3C  = "w3c"
+	formatXRay = "xray"
+	formatB3   = "b3"
+)
+
+var defaultFormats = []string{formatW3C, formatXRay, formatB3}
+
+var (
+	// https://www.w3.org/TR/trace-context/#traceparent-header-field-values
+	w3cPattern = regexp.MustCompile(`(?i)\b([0-9a-f]{2})-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})\b`)
+
+	// https://docs.aws.amazon.com/xray/latest/devguide/xray-concepts.html#xray-concepts-tracingheader
+	xrayPattern        = regexp.MustCompile(`Root=1-([0-9a-fA-F]{8})-([0-9a-fA-F]{24})[^\s,"']*`)
+	xrayParentPattern  = regexp.MustCompile(`;\s*Parent=([0-9a-fA-F]{16})`)
+	xraySampledPattern = regexp.MustCompile(`;\s*Sampled=([01])`)
+
+	// https://github.com/openzipkin/b3-propagation#single-header
+	b3Pattern = regexp.MustCompile(`(?i)\b([0-9a-f]{32}|[0-9a-f]{16})-([0-9a-f]{16})(?:-([01d])(?:-[0-9a-f]{16})?)?\b`)
+)
+
+// traceContext holds the decoded trace context of an entry.
+type traceContext struct {
+	traceID    []byte
+	spanID     []byte
+	traceFlags []byte
+}
+
+// format finds trace context in a log line or in a map of headers, keyed by
+// lower case header names.
+type format struct {
+	fromText    func(s string) (traceContext, bool)
+	fromHeaders func(headers map[string]string) (traceContext, bool)
+}
+
+var formatsByName = map[string]format{
+	formatW3C: {
+		fromText: parseW3C,
+		fromHeaders: func(headers map[string]string) (traceContext, bool) {
+			return parseW3C(headers["traceparent"])
+		},
+	},
+	formatXRay: {
+		fromText: parseXRay,
+		fromHeaders: func(headers map[string]string) (traceContext, bool) {
+			return parseXRay(headers["x-amzn-trace-id"])
+		},
+	},
+	formatB3: {
+		fromText: parseB3,
+		fromHeaders: func(headers map[string]string) (traceContext, bool) {
+			if tc, ok := parseB3(headers["b3"]); ok {
+				return tc, true
+			}
+			return parseB3Multi(headers)
+		},
+	},
+}
+
+// normalizeHeaders returns the string values of a map keyed by lower case names.
+func normalizeHeaders(m map[string]interface{}) map[string]string {
+	headers := make(map[string]string, len(m))
+	for k, v := range m {
+		switch s := v.(type) {
+		case string:
+			headers[strings.ToLower(k)] = s
+		case []interface{}:
+			// Headers with multiple values, only the first one is used.
+			if len(s) > 0 {
+				headers[strings.ToLower(k)] = fmt.Sprintf("%v", s[0])
+			}
+		}
+	}
+	return headers
+}
+
+// parseW3C finds a traceparent value, e.g. 00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01.
+func parseW3C(s string) (traceContext, bool) {
+	for _, m := range w3cPattern.FindAllStringSubmatch(s, -1) {
+		if strings.EqualFold(m[1], "ff") {
+			continue
+		}
+		traceID, spanID, flags := decodeHex(m[2]), decodeHex(m[3]), decodeHex(m[4])
+		if isZero(traceID) || isZero(spanID) {
+			continue
+		}
+		return traceContext{traceID: traceID, spanID: spanID, traceFlags: flags}, true
+	}
+	return traceContext{}, false
+}
+
+// parseXRay finds an X-Ray trace header, e.g. Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1.
+func parseXRay(s string) (traceContext, bool) {
+	loc := xrayPattern.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return traceContext{}, false
+	}
+	header := s[loc[0]:loc[1]]
+	tc := traceContext{
+		traceID: decodeHex(s[loc[2]:loc[3]] + s[loc[4]:loc[5]]),
+	}
+	if m := xrayParentPattern.FindStringSubmatch(header); m != nil {
+		tc.spanID = decodeHex(m[1])
+	}
+	if m := xraySampledPattern.FindStringSubmatch(header); m != nil {
+		tc.traceFlags = sampledFlags(m[1] == "1")
+	}
+	return tc, true
+}
+
+// parseB3 finds a B3 single header value, e.g. 80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1.
+func parseB3(s string) (traceContext, bool) {
+	for _, loc := range b3Pattern.FindAllStringSubmatchIndex(s, -1) {
+		// Skip the trace and span IDs of a traceparent value.
+		if loc[0] > 0 && s[loc[0]-1] == '-' {
+			continue
+		}
+		traceID := decodeTraceID(s[loc[2]:loc[3]])
+		spanID := decodeHex(s[loc[4]:loc[5]])
+		if isZero(traceID) || isZero(spanID) {
+			continue
+		}
+		tc := traceContext{traceID: traceID, spanID: spanID}
+		if loc[6] >= 0 {
+			tc.traceFlags = sampledFlags(s[loc[6]:loc[7]] != "0")
+		}
+		return tc, true
+	}
+	return traceContext{}, false
+}
+
+// parseB3Multi reads the X-B3-TraceId, X-B3-SpanId and X-B3-Sampled headers.
+func parseB3Multi(headers map[string]string) (traceContext, bool) {
+	traceIDHex, spanIDHex := headers["x-b3-traceid"], headers["x-b3-spanid"]
+	if (len(traceIDHex) != 16 && len(traceIDHex) != 32) || len(spanIDHex) != 16 {
+		return traceContext{}, false
+	}
+	traceID, spanID := decodeTraceID(traceIDHex), decodeHex(spanIDHex)
+	if traceID == nil || spanID == nil || isZero(traceID) || isZero(spanID) {
+		return traceContext{}, false
+	}
+	tc := traceContext{traceID: traceID, spanID: spanID}
+	switch headers["x-b3-sampled"] {
+	case "1", "true":
+		tc.traceFlags = sampledFlags(true)
+	case "0", "false":
+		tc.traceFlags = sampledFlags(false)
+	}
+	if headers["x-b3-flags"] == "1" {
+		tc.traceFlags = sampledFlags(true)
+	}
+	return tc, true
+}
+
+// decodeTraceID decodes a 64 or 128 bit trace ID into 16 bytes.
+func decodeTraceID(s string) []byte {
+	if len(s) == 16 {
+		s = strings.Repeat("0", 16) + s
+	}
+	return decodeHex(s)
+}
+
+// decodeHex decodes a hex string, returning nil if it is invalid.
+func decodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return len(b) > 0
+}
+
+func sampledFlags(sampled bool) []byte {
+	if sampled {
+		return []byte{1}
+	}
+	return []byte{0}
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracecontext // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/tracecontext"
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "trace_context_parser"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new trace context parser config with default values.
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new trace context parser config with default values.
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, operatorType),
+		ParseFrom:         entry.NewBodyField(),
+	}
+}
+
+// Config is the configuration of a trace context parser operator.
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+
+	// ParseFrom is the field searched for trace context, either a string or a
+	// map of headers.
+	ParseFrom entry.Field `mapstructure:"parse_from"`
+
+	// Formats are the trace context formats recognized, in order of preference.
+	// Defaults to w3c, xray and b3.
+	Formats []string `mapstructure:"formats"`
+}
+
+// Build will build a trace context parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	names := c.Formats
+	if len(names) == 0 {
+		names = defaultFormats
+	}
+	formats := make([]format, 0, len(names))
+	for _, name := range names {
+		f, ok := formatsByName[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported format '%s', must be one of '%s', '%s' or '%s'", name, formatW3C, formatXRay, formatB3)
+		}
+		formats = append(formats, f)
+	}
+
+	return &Parser{
+		TransformerOperator: transformerOperator,
+		parseFrom:           c.ParseFrom,
+		formats:             formats,
+	}, nil
+}
+
+// Parser is an operator that sets the trace context of an entry from trace
+// context tokens found in a field.
+type Parser struct {
+	helper.TransformerOperator
+	parseFrom entry.Field
+	formats   []format
+}
+
+// Process will parse the trace context of an entry.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse sets the trace context of the entry from the first format found in
+// the parse_from field. Entries without trace context are left unchanged.
+func (p *Parser) parse(ent *entry.Entry) error {
+	value, ok := ent.Get(p.parseFrom)
+	if !ok {
+		return nil
+	}
+
+	var find func(f format) (traceContext, bool)
+	switch v := value.(type) {
+	case string:
+		find = func(f format) (traceContext, bool) { return f.fromText(v) }
+	case []byte:
+		find = func(f format) (traceContext, bool) { return f.fromText(string(v)) }
+	case map[string]interface{}:
+		headers := normalizeHeaders(v)
+		find = func(f format) (traceContext, bool) { return f.fromHeaders(headers) }
+	default:
+		return fmt.Errorf("type '%T' cannot be parsed as trace context", value)
+	}
+
+	for _, f := range p.formats {
+		if tc, found := find(f); found {
+			ent.TraceID = tc.traceID
+			ent.SpanID = tc.spanID
+			ent.TraceFlags = tc.traceFlags
+			return nil
+		}
+	}
+	return nil
+}
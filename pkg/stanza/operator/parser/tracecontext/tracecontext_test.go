@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracecontext
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("trace_context_parser")
+	require.True(t, ok, "expected trace_context_parser to be registered")
+	require.Equal(t, "trace_context_parser", builder().Type())
+}
+
+func TestBuildInvalidFormat(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Formats = []string{"w3c", "jaeger"}
+	_, err := cfg.Build(testutil.Logger(t))
+	require.EqualError(t, err, "unsupported format 'jaeger', must be one of 'w3c', 'xray' or 'b3'")
+}
+
+func mustDecode(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestParser(t *testing.T) {
+	const (
+		w3cTraceID  = "0af7651916cd43dd8448eb211c80319c"
+		w3cSpanID   = "b7ad6b7169203331"
+		xrayTraceID = "5759e988bd862e3fe1be46a994272793"
+		xraySpanID  = "53995c3f42cd8ad8"
+		b3TraceID   = "80f198ee56343ba864fe8b2a57d3eff7"
+		b3SpanID    = "e457b5a2e4d86bd1"
+	)
+
+	testCases := []struct {
+		name       string
+		formats    []string
+		parseFrom  entry.Field
+		body       interface{}
+		attributes map[string]interface{}
+		expectErr  bool
+		traceID    string
+		spanID     string
+		traceFlags []byte
+	}{
+		{
+			name:       "w3c",
+			body:       "GET /api 200 traceparent=00-" + w3cTraceID + "-" + w3cSpanID + "-01",
+			traceID:    w3cTraceID,
+			spanID:     w3cSpanID,
+			traceFlags: []byte{1},
+		},
+		{
+			name: "w3c_invalid_version",
+			body: "traceparent=ff-" + w3cTraceID + "-" + w3cSpanID + "-01",
+		},
+		{
+			name: "w3c_zero_trace_id",
+			body: "traceparent=00-00000000000000000000000000000000-" + w3cSpanID + "-01",
+		},
+		{
+			name:       "xray",
+			body:       "request failed X-Amzn-Trace-Id: Root=1-5759e988-bd862e3fe1be46a994272793;Parent=" + xraySpanID + ";Sampled=1 retrying",
+			traceID:    xrayTraceID,
+			spanID:     xraySpanID,
+			traceFlags: []byte{1},
+		},
+		{
+			name:    "xray_root_only",
+			body:    "Root=1-5759e988-bd862e3fe1be46a994272793",
+			traceID: xrayTraceID,
+		},
+		{
+			name:       "b3_single",
+			body:       "b3=" + b3TraceID + "-" + b3SpanID + "-0-05e3ac9a4f6e3b90",
+			traceID:    b3TraceID,
+			spanID:     b3SpanID,
+			traceFlags: []byte{0},
+		},
+		{
+			name:    "b3_single_64_bit",
+			body:    "b3: 64fe8b2a57d3eff7-" + b3SpanID,
+			traceID: "000000000000000064fe8b2a57d3eff7",
+			spanID:  b3SpanID,
+		},
+		{
+			name:    "b3_does_not_match_w3c",
+			formats: []string{"b3"},
+			body:    "traceparent=00-" + w3cTraceID + "-" + w3cSpanID + "-01",
+		},
+		{
+			name:       "preference",
+			formats:    []string{"xray", "w3c"},
+			body:       "00-" + w3cTraceID + "-" + w3cSpanID + "-01 Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=0",
+			traceID:    xrayTraceID,
+			traceFlags: []byte{0},
+		},
+		{
+			name: "no_trace_context",
+			body: "GET /api 200",
+		},
+		{
+			name:      "headers",
+			parseFrom: entry.NewAttributeField("headers"),
+			attributes: map[string]interface{}{
+				"headers": map[string]interface{}{
+					"Content-Type": "application/json",
+					"Traceparent":  []interface{}{"00-" + w3cTraceID + "-" + w3cSpanID + "-00"},
+				},
+			},
+			traceID:    w3cTraceID,
+			spanID:     w3cSpanID,
+			traceFlags: []byte{0},
+		},
+		{
+			name:      "headers_b3_multi",
+			parseFrom: entry.NewAttributeField("headers"),
+			attributes: map[string]interface{}{
+				"headers": map[string]interface{}{
+					"X-B3-TraceId": b3TraceID,
+					"X-B3-SpanId":  b3SpanID,
+					"X-B3-Sampled": "1",
+				},
+			},
+			traceID:    b3TraceID,
+			spanID:     b3SpanID,
+			traceFlags: []byte{1},
+		},
+		{
+			name:      "missing_field",
+			parseFrom: entry.NewAttributeField("headers"),
+			body:      "00-" + w3cTraceID + "-" + w3cSpanID + "-01",
+		},
+		{
+			name:      "invalid_type",
+			body:      42,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Formats = tc.formats
+			if tc.parseFrom.FieldInterface != nil {
+				cfg.ParseFrom = tc.parseFrom
+			}
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			e := entry.New()
+			e.Body = tc.body
+			e.Attributes = tc.attributes
+
+			err = op.Process(context.Background(), e)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.traceID == "" {
+				require.Nil(t, e.TraceID)
+			} else {
+				require.Equal(t, mustDecode(t, tc.traceID), e.TraceID)
+			}
+			if tc.spanID == "" {
+				require.Nil(t, e.SpanID)
+			} else {
+				require.Equal(t, mustDecode(t, tc.spanID), e.SpanID)
+			}
+			require.Equal(t, tc.traceFlags, e.TraceFlags)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// DefaultBatchSize is the default maximum number of entries processed by a worker at once.
+const DefaultBatchSize = 100
+
+var _ Pipeline = (*ParallelPipeline)(nil)
+
+// ParallelConfig is the configuration of a pipeline whose operators are run
+// by several workers concurrently.
+type ParallelConfig struct {
+	Config
+	// WorkerCount is the number of workers, each running its own copy of the operators.
+	WorkerCount int
+	// BatchSize is the maximum number of consecutive entries processed by a worker at once.
+	BatchSize int
+}
+
+// Build will build a parallel pipeline from the config.
+func (c ParallelConfig) Build(logger *zap.SugaredLogger) (*ParallelPipeline, error) {
+	if logger == nil {
+		return nil, errors.NewError("logger must be provided", "")
+	}
+	if c.DefaultOutput == nil {
+		return nil, errors.NewError("default output must be provided", "")
+	}
+	if c.WorkerCount <= 0 {
+		return nil, errors.NewError("worker count must be positive", "")
+	}
+
+	batchSize := c.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	workers := make([]*parallelWorker, 0, c.WorkerCount)
+	for i := 0; i < c.WorkerCount; i++ {
+		outputOperator, err := helper.NewOutputConfig(c.DefaultOutput.ID(), "parallel_output").Build(logger)
+		if err != nil {
+			return nil, err
+		}
+		col := &collector{
+			OutputOperator: outputOperator,
+			output:         c.DefaultOutput,
+		}
+
+		pipe, err := Config{
+			Operators:     c.Operators,
+			DefaultOutput: col,
+		}.Build(logger)
+		if err != nil {
+			return nil, err
+		}
+
+		workers = append(workers, &parallelWorker{
+			pipe:      pipe,
+			first:     pipe.Operators()[0],
+			collector: col,
+		})
+	}
+
+	return &ParallelPipeline{
+		output:    c.DefaultOutput,
+		workers:   workers,
+		batchSize: batchSize,
+	}, nil
+}
+
+// ParallelPipeline is a pipeline that processes batches of entries concurrently,
+// with one copy of the operators per worker, and writes the entries produced by
+// the operators to the default output in the order of the processed entries.
+//
+// Since each worker has its own operators, the state of stateful operators,
+// such as recombine, is not shared between the workers.
+type ParallelPipeline struct {
+	output    operator.Operator
+	workers   []*parallelWorker
+	batchSize int
+
+	// processMux serializes the calls to Process, so that the workers only
+	// process the batches of a single call at a time.
+	processMux sync.Mutex
+	startOnce  sync.Once
+	stopOnce   sync.Once
+}
+
+type parallelWorker struct {
+	pipe      *DirectedPipeline
+	first     operator.Operator
+	collector *collector
+}
+
+// Start will start the default output and the operators of every worker
+func (p *ParallelPipeline) Start(persister operator.Persister) error {
+	var err error = alreadyStarted
+	p.startOnce.Do(func() {
+		err = p.start(persister)
+	})
+	return err
+}
+
+func (p *ParallelPipeline) start(persister operator.Persister) error {
+	if err := p.output.Start(operator.NewScopedPersister(p.output.ID(), persister)); err != nil {
+		return err
+	}
+	for _, w := range p.workers {
+		if err := w.pipe.Start(persister); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop will stop the operators of every worker, then the default output
+func (p *ParallelPipeline) Stop() error {
+	var err error = alreadyStopped
+	p.stopOnce.Do(func() {
+		err = nil
+		for _, w := range p.workers {
+			err = multierr.Append(err, w.pipe.Stop())
+		}
+		err = multierr.Append(err, p.output.Stop())
+	})
+	return err
+}
+
+// Operators returns the operators of the first worker
+func (p *ParallelPipeline) Operators() []operator.Operator {
+	return p.workers[0].pipe.Operators()
+}
+
+// Render will render the pipeline of the first worker as a dot graph
+func (p *ParallelPipeline) Render() ([]byte, error) {
+	return p.workers[0].pipe.Render()
+}
+
+// parallelBatch is a slice of consecutive entries processed by a worker, along
+// with the entries written to the default output while processing them.
+type parallelBatch struct {
+	entries []*entry.Entry
+	output  []*entry.Entry
+	err     error
+}
+
+// Process splits the entries into batches that are processed concurrently by
+// the workers, then writes the output of the batches to the default output in
+// order. As when the entries are processed sequentially, the processing stops
+// at the first entry that fails, and its error is returned.
+func (p *ParallelPipeline) Process(ctx context.Context, entries []*entry.Entry) error {
+	p.processMux.Lock()
+	defer p.processMux.Unlock()
+
+	batches := make([]*parallelBatch, 0, (len(entries)+p.batchSize-1)/p.batchSize)
+	for start := 0; start < len(entries); start += p.batchSize {
+		end := start + p.batchSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		batches = append(batches, &parallelBatch{entries: entries[start:end]})
+	}
+
+	batchChan := make(chan *parallelBatch, len(batches))
+	for _, b := range batches {
+		batchChan <- b
+	}
+	close(batchChan)
+
+	var wg sync.WaitGroup
+	for i := 0; i < len(p.workers) && i < len(batches); i++ {
+		wg.Add(1)
+		go func(w *parallelWorker) {
+			defer wg.Done()
+			for b := range batchChan {
+				w.process(ctx, b)
+			}
+		}(p.workers[i])
+	}
+	wg.Wait()
+
+	for _, b := range batches {
+		for _, e := range b.output {
+			if err := p.output.Process(ctx, e); err != nil {
+				return err
+			}
+		}
+		if b.err != nil {
+			return b.err
+		}
+	}
+	return nil
+}
+
+// process runs the entries of the batch through the operators of the worker
+func (w *parallelWorker) process(ctx context.Context, b *parallelBatch) {
+	w.collector.collect()
+	for _, e := range b.entries {
+		if err := w.first.Process(ctx, e); err != nil {
+			b.err = err
+			break
+		}
+	}
+	b.output = w.collector.take()
+}
+
+// collector is the default output of the operators of a worker. It keeps the
+// entries written while the worker processes a batch, so that they can be
+// written to the actual default output in order, and forwards the entries
+// written at any other time, such as the entries flushed on a timer.
+type collector struct {
+	helper.OutputOperator
+	output operator.Operator
+
+	mux        sync.Mutex
+	collecting bool
+	entries    []*entry.Entry
+}
+
+// Process will keep the entry if a batch is being processed, or write it to the default output.
+func (c *collector) Process(ctx context.Context, e *entry.Entry) error {
+	c.mux.Lock()
+	if c.collecting {
+		c.entries = append(c.entries, e)
+		c.mux.Unlock()
+		return nil
+	}
+	c.mux.Unlock()
+	return c.output.Process(ctx, e)
+}
+
+func (c *collector) collect() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.collecting = true
+	c.entries = nil
+}
+
+func (c *collector) take() []*entry.Entry {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.collecting = false
+	entries := c.entries
+	c.entries = nil
+	return entries
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newParallelTestConfig(output operator.Operator, workerCount, batchSize int) ParallelConfig {
+	regexCfg := regex.NewConfig()
+	regexCfg.Regex = `^entry (?P<n>\d+)$`
+	return ParallelConfig{
+		Config: Config{
+			Operators:     []operator.Config{{Builder: regexCfg}},
+			DefaultOutput: output,
+		},
+		WorkerCount: workerCount,
+		BatchSize:   batchSize,
+	}
+}
+
+func newParallelTestEntries(bodies ...string) []*entry.Entry {
+	entries := make([]*entry.Entry, 0, len(bodies))
+	for _, body := range bodies {
+		e := entry.New()
+		e.Body = body
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func TestBuildParallelPipeline(t *testing.T) {
+	output := testutil.NewFakeOutput(t)
+
+	pipe, err := newParallelTestConfig(output, 4, 0).Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.Len(t, pipe.workers, 4)
+	require.Equal(t, DefaultBatchSize, pipe.batchSize)
+	require.Len(t, pipe.Operators(), 2)
+	require.Equal(t, "regex_parser", pipe.Operators()[0].ID())
+	require.Equal(t, "fake", pipe.Operators()[1].ID())
+}
+
+func TestBuildParallelPipelineErrors(t *testing.T) {
+	output := testutil.NewFakeOutput(t)
+
+	_, err := newParallelTestConfig(output, 1, 1).Build(nil)
+	require.EqualError(t, err, "logger must be provided")
+
+	_, err = newParallelTestConfig(nil, 1, 1).Build(testutil.Logger(t))
+	require.EqualError(t, err, "default output must be provided")
+
+	_, err = newParallelTestConfig(output, 0, 1).Build(testutil.Logger(t))
+	require.EqualError(t, err, "worker count must be positive")
+}
+
+func TestParallelPipelinePreservesOrder(t *testing.T) {
+	output := testutil.NewFakeOutput(t)
+	pipe, err := newParallelTestConfig(output, 4, 3).Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.NoError(t, pipe.Start(testutil.NewMockPersister("test")))
+	defer func() { require.NoError(t, pipe.Stop()) }()
+
+	bodies := make([]string, 0, 50)
+	for i := 0; i < 50; i++ {
+		bodies = append(bodies, fmt.Sprintf("entry %d", i))
+	}
+	require.NoError(t, pipe.Process(context.Background(), newParallelTestEntries(bodies...)))
+
+	require.Len(t, output.Received, 50)
+	for i := 0; i < 50; i++ {
+		e := <-output.Received
+		require.Equal(t, fmt.Sprintf("entry %d", i), e.Body)
+		require.Equal(t, map[string]interface{}{"n": fmt.Sprint(i)}, e.Attributes)
+	}
+}
+
+func TestParallelPipelineStopsAtFirstError(t *testing.T) {
+	output := testutil.NewFakeOutput(t)
+	pipe, err := newParallelTestConfig(output, 2, 2).Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.NoError(t, pipe.Start(testutil.NewMockPersister("test")))
+	defer func() { require.NoError(t, pipe.Stop()) }()
+
+	entries := newParallelTestEntries("entry 0", "entry 1", "entry 2", "invalid", "entry 4", "entry 5")
+	require.Error(t, pipe.Process(context.Background(), entries))
+
+	// The invalid entry is still sent, as the parser is configured to do on error
+	require.Len(t, output.Received, 4)
+	for _, body := range []string{"entry 0", "entry 1", "entry 2", "invalid"} {
+		require.Equal(t, body, (<-output.Received).Body)
+	}
+}
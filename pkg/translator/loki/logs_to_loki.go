@@ -16,8 +16,10 @@ package loki // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/grafana/loki/pkg/logproto"
+	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
@@ -27,6 +29,19 @@ type PushReport struct {
 	Errors       []error
 	NumSubmitted int
 	NumDropped   int
+	// DemotedLabels are the names of the labels, sorted, that were kept as
+	// attributes of the log entries to stay within the Limits.
+	DemotedLabels []string
+}
+
+// Limits bounds the label cardinality of the push requests created by
+// LogsToLokiWithLimits. A zero value means no limit.
+type Limits struct {
+	// MaxStreams is the maximum number of streams in a push request.
+	MaxStreams int
+	// MaxLabelCardinality is the maximum number of distinct values of a label
+	// in a push request.
+	MaxLabelCardinality int
 }
 
 // LogsToLoki converts a Logs pipeline data into a Loki PushRequest.
@@ -44,9 +59,19 @@ type PushReport struct {
 // to make this decision, as it includes all of the errors that were encountered,
 // as well as the number of items dropped and submitted.
 func LogsToLoki(ld plog.Logs) (*logproto.PushRequest, *PushReport) {
+	return LogsToLokiWithLimits(ld, Limits{})
+}
+
+// LogsToLokiWithLimits converts a Logs pipeline data into a Loki PushRequest,
+// like LogsToLoki, demoting labels exceeding the limits. The label with more
+// distinct values than the MaxLabelCardinality are demoted, then, as long as
+// the request has more than MaxStreams streams, the label with the most
+// distinct values is demoted. The attributes of demoted labels are kept in
+// the log entries, and the demoted labels are listed in the PushReport.
+func LogsToLokiWithLimits(ld plog.Logs, limits Limits) (*logproto.PushRequest, *PushReport) {
 	report := &PushReport{}
 
-	streams := make(map[string]*logproto.Stream)
+	var records []lokiRecord
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
 		ills := rls.At(i).ScopeLogs()
@@ -63,37 +88,52 @@ func LogsToLoki(ld plog.Logs) (*logproto.PushRequest, *PushReport) {
 				resource := pcommon.NewResource()
 				rls.At(i).Resource().CopyTo(resource)
 
-				mergedLabels := convertAttributesAndMerge(log.Attributes(), resource.Attributes())
-				// remove the attributes that were promoted to labels
-				removeAttributes(log.Attributes(), mergedLabels)
-				removeAttributes(resource.Attributes(), mergedLabels)
-
-				// create the stream name based on the labels
-				labels := mergedLabels.String()
-
-				entry, err := convertLogToJSONEntry(log, resource)
-				if err != nil {
-					// Couldn't convert so dropping log.
-					report.Errors = append(report.Errors, fmt.Errorf("failed to convert, dropping log: %w", err))
-					report.NumDropped++
-					continue
-				}
-
-				report.NumSubmitted++
-
-				if stream, ok := streams[labels]; ok {
-					stream.Entries = append(stream.Entries, *entry)
-					continue
-				}
-
-				streams[labels] = &logproto.Stream{
-					Labels:  labels,
-					Entries: []logproto.Entry{*entry},
-				}
+				records = append(records, lokiRecord{
+					log:      log,
+					resource: resource,
+					labels:   convertAttributesAndMerge(log.Attributes(), resource.Attributes()),
+				})
 			}
 		}
 	}
 
+	demoted := demoteLabels(records, limits)
+	for name := range demoted {
+		report.DemotedLabels = append(report.DemotedLabels, string(name))
+	}
+	sort.Strings(report.DemotedLabels)
+
+	streams := make(map[string]*logproto.Stream)
+	for _, record := range records {
+		mergedLabels := withoutLabels(record.labels, demoted)
+		// remove the attributes that were promoted to labels
+		removeAttributes(record.log.Attributes(), mergedLabels)
+		removeAttributes(record.resource.Attributes(), mergedLabels)
+
+		// create the stream name based on the labels
+		labels := mergedLabels.String()
+
+		entry, err := convertLogToJSONEntry(record.log, record.resource)
+		if err != nil {
+			// Couldn't convert so dropping log.
+			report.Errors = append(report.Errors, fmt.Errorf("failed to convert, dropping log: %w", err))
+			report.NumDropped++
+			continue
+		}
+
+		report.NumSubmitted++
+
+		if stream, ok := streams[labels]; ok {
+			stream.Entries = append(stream.Entries, *entry)
+			continue
+		}
+
+		streams[labels] = &logproto.Stream{
+			Labels:  labels,
+			Entries: []logproto.Entry{*entry},
+		}
+	}
+
 	pr := &logproto.PushRequest{
 		Streams: make([]logproto.Stream, len(streams)),
 	}
@@ -106,3 +146,94 @@ func LogsToLoki(ld plog.Logs) (*logproto.PushRequest, *PushReport) {
 
 	return pr, report
 }
+
+// lokiRecord is a copy of a log record and its resource, along with the labels
+// inferred from their hints.
+type lokiRecord struct {
+	log      plog.LogRecord
+	resource pcommon.Resource
+	labels   model.LabelSet
+}
+
+// demoteLabels returns the names of the labels to keep as attributes for the
+// records to stay within the limits.
+func demoteLabels(records []lokiRecord, limits Limits) map[model.LabelName]struct{} {
+	demoted := map[model.LabelName]struct{}{}
+
+	if limits.MaxLabelCardinality > 0 {
+		for name, cardinality := range labelCardinalities(records, demoted) {
+			if cardinality > limits.MaxLabelCardinality {
+				demoted[name] = struct{}{}
+			}
+		}
+	}
+
+	if limits.MaxStreams > 0 {
+		for countStreams(records, demoted) > limits.MaxStreams {
+			name, ok := mostDistinctLabel(labelCardinalities(records, demoted))
+			if !ok {
+				break
+			}
+			demoted[name] = struct{}{}
+		}
+	}
+
+	return demoted
+}
+
+// labelCardinalities returns the number of distinct values of each label that is not demoted.
+func labelCardinalities(records []lokiRecord, demoted map[model.LabelName]struct{}) map[model.LabelName]int {
+	values := map[model.LabelName]map[model.LabelValue]struct{}{}
+	for _, record := range records {
+		for name, value := range record.labels {
+			if _, ok := demoted[name]; ok {
+				continue
+			}
+			if values[name] == nil {
+				values[name] = map[model.LabelValue]struct{}{}
+			}
+			values[name][value] = struct{}{}
+		}
+	}
+
+	cardinalities := make(map[model.LabelName]int, len(values))
+	for name, vs := range values {
+		cardinalities[name] = len(vs)
+	}
+	return cardinalities
+}
+
+// mostDistinctLabel returns the label with the most distinct values, the first
+// in alphabetical order on ties, unless no label has more than one value.
+func mostDistinctLabel(cardinalities map[model.LabelName]int) (model.LabelName, bool) {
+	var most model.LabelName
+	mostCardinality := 1
+	for name, cardinality := range cardinalities {
+		if cardinality > mostCardinality || (cardinality == mostCardinality && cardinality > 1 && name < most) {
+			most = name
+			mostCardinality = cardinality
+		}
+	}
+	return most, most != ""
+}
+
+func countStreams(records []lokiRecord, demoted map[model.LabelName]struct{}) int {
+	streams := map[string]struct{}{}
+	for _, record := range records {
+		streams[withoutLabels(record.labels, demoted).String()] = struct{}{}
+	}
+	return len(streams)
+}
+
+func withoutLabels(labels model.LabelSet, demoted map[model.LabelName]struct{}) model.LabelSet {
+	if len(demoted) == 0 {
+		return labels
+	}
+	out := make(model.LabelSet, len(labels))
+	for name, value := range labels {
+		if _, ok := demoted[name]; !ok {
+			out[name] = value
+		}
+	}
+	return out
+}
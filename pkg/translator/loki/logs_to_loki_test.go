@@ -116,3 +116,69 @@ func TestLogsToLoki(t *testing.T) {
 		})
 	}
 }
+
+func TestLogsToLokiWithLimits(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		limits          Limits
+		expectedStreams int
+		expectedDemoted []string
+	}{
+		{
+			desc:            "no limits",
+			expectedStreams: 4,
+		},
+		{
+			desc:            "max label cardinality",
+			limits:          Limits{MaxLabelCardinality: 2},
+			expectedStreams: 2,
+			expectedDemoted: []string{"request.id"},
+		},
+		{
+			desc:            "max streams",
+			limits:          Limits{MaxStreams: 2},
+			expectedStreams: 2,
+			expectedDemoted: []string{"request.id"},
+		},
+		{
+			desc:            "max streams demoting all varying labels",
+			limits:          Limits{MaxStreams: 1},
+			expectedStreams: 1,
+			expectedDemoted: []string{"level", "request.id"},
+		},
+		{
+			desc:            "limits not exceeded",
+			limits:          Limits{MaxStreams: 4, MaxLabelCardinality: 4},
+			expectedStreams: 4,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ld := plog.NewLogs()
+			logs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+			for i, level := range []string{"info", "info", "error", "error"} {
+				log := logs.AppendEmpty()
+				log.Body().SetStr(fmt.Sprintf("request %d", i))
+				log.Attributes().PutStr("level", level)
+				log.Attributes().PutStr("request.id", fmt.Sprintf("id-%d", i))
+				log.Attributes().PutStr(hintAttributes, "level,request.id")
+			}
+
+			pushRequest, report := LogsToLokiWithLimits(ld, tC.limits)
+			assert.Empty(t, report.Errors)
+			assert.Equal(t, 4, report.NumSubmitted)
+			assert.Equal(t, tC.expectedDemoted, report.DemotedLabels)
+			assert.Len(t, pushRequest.Streams, tC.expectedStreams)
+
+			for _, stream := range pushRequest.Streams {
+				for _, demoted := range tC.expectedDemoted {
+					// demoted labels are kept as attributes of the entries
+					assert.NotContains(t, stream.Labels, demoted)
+					for _, entry := range stream.Entries {
+						assert.Contains(t, entry.Line, demoted)
+					}
+				}
+			}
+		})
+	}
+}
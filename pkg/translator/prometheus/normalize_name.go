@@ -102,15 +102,18 @@ func init() {
 // See rules at https://prometheus.io/docs/concepts/data_model/#metric-names-and-labels
 // and https://prometheus.io/docs/practices/naming/#metric-and-label-naming
 func BuildPromCompliantName(metric pmetric.Metric, namespace string) string {
-	var metricName string
-
 	// Full normalization following standard Prometheus naming conventions
 	if featuregate.GetRegistry().IsEnabled(normalizeNameGate.ID) {
 		return normalizeName(metric, namespace)
 	}
 
+	return sanitizeName(metric, namespace)
+}
+
+// Build a sanitized name for the specified metric
+func sanitizeName(metric pmetric.Metric, namespace string) string {
 	// Simple case (no full normalization, no units, etc.), we simply trim out forbidden chars
-	metricName = RemovePromForbiddenRunes(metric.Name())
+	metricName := RemovePromForbiddenRunes(metric.Name())
 
 	// Namespace?
 	if namespace != "" {
@@ -134,45 +137,16 @@ func normalizeName(metric pmetric.Metric, namespace string) string {
 		func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) },
 	)
 
-	// Split unit at the '/' if any
-	unitTokens := strings.SplitN(metric.Unit(), "/", 2)
-
-	// Main unit
-	// Append if not blank, doesn't contain '{}', and is not present in metric name already
-	if len(unitTokens) > 0 {
-		mainUnitOtel := strings.TrimSpace(unitTokens[0])
-		if mainUnitOtel != "" && !strings.ContainsAny(mainUnitOtel, "{}") {
-			mainUnitProm := CleanUpString(unitMapGetOrDefault(mainUnitOtel))
-			if mainUnitProm != "" && !contains(nameTokens, mainUnitProm) {
-				nameTokens = append(nameTokens, mainUnitProm)
-			}
-		}
-
-		// Per unit
-		// Append if not blank, doesn't contain '{}', and is not present in metric name already
-		if len(unitTokens) > 1 && unitTokens[1] != "" {
-			perUnitOtel := strings.TrimSpace(unitTokens[1])
-			if perUnitOtel != "" && !strings.ContainsAny(perUnitOtel, "{}") {
-				perUnitProm := CleanUpString(perUnitMapGetOrDefault(perUnitOtel))
-				if perUnitProm != "" && !contains(nameTokens, perUnitProm) {
-					nameTokens = append(append(nameTokens, "per"), perUnitProm)
-				}
-			}
-		}
-
-	}
+	// Append the unit tokens
+	nameTokens = append(nameTokens, unitTokens(metric.Unit(), nameTokens)...)
 
 	// Append _total for Counters
-	if metric.Type() == pmetric.MetricTypeSum && metric.Sum().IsMonotonic() {
+	if isCounter(metric) {
 		nameTokens = append(removeItem(nameTokens, "total"), "total")
 	}
 
 	// Append _ratio for metrics with unit "1"
-	// Some Otel receivers improperly use unit "1" for counters of objects
-	// See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues?q=is%3Aissue+some+metric+units+don%27t+follow+otel+semantic+conventions
-	// Until these issues have been fixed, we're appending `_ratio` for gauges ONLY
-	// Theoretically, counters could be ratios as well, but it's absurd (for mathematical reasons)
-	if metric.Unit() == "1" && metric.Type() == pmetric.MetricTypeGauge {
+	if isRatio(metric) {
 		nameTokens = append(removeItem(nameTokens, "ratio"), "ratio")
 	}
 
@@ -192,6 +166,53 @@ func normalizeName(metric pmetric.Metric, namespace string) string {
 	return normalizedName
 }
 
+// Build the tokens of the specified unit, to append to the specified name tokens
+func unitTokens(unit string, nameTokens []string) []string {
+	var tokens []string
+
+	// Split unit at the '/' if any
+	unitTokens := strings.SplitN(unit, "/", 2)
+
+	// Main unit
+	// Append if not blank, doesn't contain '{}', and is not present in metric name already
+	mainUnitOtel := strings.TrimSpace(unitTokens[0])
+	if mainUnitOtel != "" && !strings.ContainsAny(mainUnitOtel, "{}") {
+		mainUnitProm := CleanUpString(unitMapGetOrDefault(mainUnitOtel))
+		if mainUnitProm != "" && !contains(nameTokens, mainUnitProm) {
+			tokens = append(tokens, mainUnitProm)
+		}
+	}
+
+	// Per unit
+	// Append if not blank, doesn't contain '{}', and is not present in metric name already
+	if len(unitTokens) > 1 && unitTokens[1] != "" {
+		perUnitOtel := strings.TrimSpace(unitTokens[1])
+		if perUnitOtel != "" && !strings.ContainsAny(perUnitOtel, "{}") {
+			perUnitProm := CleanUpString(perUnitMapGetOrDefault(perUnitOtel))
+			if perUnitProm != "" && !contains(nameTokens, perUnitProm) {
+				tokens = append(tokens, "per", perUnitProm)
+			}
+		}
+	}
+
+	return tokens
+}
+
+// Returns whether the specified metric is a counter, which gets the _total suffix
+func isCounter(metric pmetric.Metric) bool {
+	return metric.Type() == pmetric.MetricTypeSum && metric.Sum().IsMonotonic()
+}
+
+// Returns whether the specified metric is a ratio, which gets the _ratio suffix
+//
+// Some Otel receivers improperly use unit "1" for counters of objects
+// See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues?q=is%3Aissue+some+metric+units+don%27t+follow+otel+semantic+conventions
+// Until these issues have been fixed, we're appending `_ratio` for gauges ONLY
+// Theoretically, counters could be ratios as well, but it's absurd (for mathematical reasons)
+func isRatio(metric pmetric.Metric) bool {
+	return metric.Unit() == "1" && metric.Type() == pmetric.MetricTypeGauge
+}
+
 // Clean up specified string so it's Prometheus compliant
 func CleanUpString(s string) string {
 	return strings.Join(strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }), "_")
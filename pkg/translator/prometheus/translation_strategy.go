@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+
+import (
+	"encoding"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// TranslationStrategy is the strategy to translate the OpenTelemetry metric names to Prometheus metric names,
+// following the translation strategies of the OTLP receiver of Prometheus.
+type TranslationStrategy string
+
+const (
+	// TranslationStrategyDefault builds the names with BuildPromCompliantName, depending on the
+	// pkg.translator.prometheus.NormalizeName feature gate.
+	TranslationStrategyDefault TranslationStrategy = ""
+
+	// TranslationStrategyUnderscoreEscapingWithSuffixes fully normalizes the names: the unsupported
+	// characters are replaced with underscores, and the unit, _total and _ratio suffixes are appended.
+	TranslationStrategyUnderscoreEscapingWithSuffixes TranslationStrategy = "UnderscoreEscapingWithSuffixes"
+
+	// TranslationStrategyUnderscoreEscapingWithoutSuffixes replaces the unsupported characters
+	// with underscores, without appending any suffix.
+	TranslationStrategyUnderscoreEscapingWithoutSuffixes TranslationStrategy = "UnderscoreEscapingWithoutSuffixes"
+
+	// TranslationStrategyNoUTF8EscapingWithSuffixes keeps the UTF-8 characters of the names, as supported
+	// by Prometheus 3.0, and appends the unit, _total and _ratio suffixes.
+	TranslationStrategyNoUTF8EscapingWithSuffixes TranslationStrategy = "NoUTF8EscapingWithSuffixes"
+
+	// TranslationStrategyNoTranslation keeps the names as they are.
+	TranslationStrategyNoTranslation TranslationStrategy = "NoTranslation"
+)
+
+var _ encoding.TextUnmarshaler = (*TranslationStrategy)(nil)
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (ts *TranslationStrategy) UnmarshalText(in []byte) error {
+	switch strategy := TranslationStrategy(in); strategy {
+	case TranslationStrategyDefault,
+		TranslationStrategyUnderscoreEscapingWithSuffixes,
+		TranslationStrategyUnderscoreEscapingWithoutSuffixes,
+		TranslationStrategyNoUTF8EscapingWithSuffixes,
+		TranslationStrategyNoTranslation:
+		*ts = strategy
+		return nil
+	default:
+		return fmt.Errorf("invalid translation strategy %q", strategy)
+	}
+}
+
+// EscapesNames returns whether the names built with the strategy only contain the characters
+// of the legacy Prometheus metric names.
+func (ts TranslationStrategy) EscapesNames() bool {
+	return ts != TranslationStrategyNoUTF8EscapingWithSuffixes && ts != TranslationStrategyNoTranslation
+}
+
+// BuildMetricName builds the Prometheus metric name of the specified metric with the specified strategy
+//
+// Metric name is prefixed with specified namespace and underscore (if any).
+// Namespace is not cleaned up. Make sure specified namespace follows Prometheus
+// naming convention.
+func BuildMetricName(metric pmetric.Metric, namespace string, strategy TranslationStrategy) string {
+	switch strategy {
+	case TranslationStrategyUnderscoreEscapingWithSuffixes:
+		return normalizeName(metric, namespace)
+	case TranslationStrategyUnderscoreEscapingWithoutSuffixes:
+		return sanitizeName(metric, namespace)
+	case TranslationStrategyNoUTF8EscapingWithSuffixes:
+		return prefixName(utf8Name(metric), namespace)
+	case TranslationStrategyNoTranslation:
+		return prefixName(metric.Name(), namespace)
+	default:
+		return BuildPromCompliantName(metric, namespace)
+	}
+}
+
+// Build a name keeping the UTF-8 characters of the specified metric, with the suffixes
+// of the full normalization
+func utf8Name(metric pmetric.Metric) string {
+	name := metric.Name()
+
+	// Tokens of the name, to not append the units already present in the name
+	nameTokens := strings.FieldsFunc(
+		name,
+		func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) },
+	)
+	for _, token := range unitTokens(metric.Unit(), nameTokens) {
+		name += "_" + token
+	}
+
+	if isCounter(metric) && !strings.HasSuffix(name, "_total") {
+		name += "_total"
+	}
+	if isRatio(metric) && !strings.HasSuffix(name, "_ratio") {
+		name += "_ratio"
+	}
+	return name
+}
+
+// Prefix the specified name with the specified namespace and an underscore (if any)
+func prefixName(name string, namespace string) string {
+	if namespace != "" {
+		return namespace + "_" + name
+	}
+	return name
+}
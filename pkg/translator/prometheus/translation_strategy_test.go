@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func TestBuildMetricName(t *testing.T) {
+
+	defer testutil.SetFeatureGateForTest(t, normalizeNameGate.ID, false)()
+
+	tests := []struct {
+		strategy TranslationStrategy
+		counter  string
+		gauge    string
+		ratio    string
+		utf8     string
+	}{
+		{
+			strategy: TranslationStrategyDefault,
+			counter:  "system_io",
+			gauge:    "system_network_I_O",
+			ratio:    "system_memory_utilization",
+			utf8:     "http_server_durée",
+		},
+		{
+			strategy: TranslationStrategyUnderscoreEscapingWithSuffixes,
+			counter:  "system_io_bytes_total",
+			gauge:    "system_network_I_O_bytes",
+			ratio:    "system_memory_utilization_ratio",
+			utf8:     "http_server_durée_seconds",
+		},
+		{
+			strategy: TranslationStrategyUnderscoreEscapingWithoutSuffixes,
+			counter:  "system_io",
+			gauge:    "system_network_I_O",
+			ratio:    "system_memory_utilization",
+			utf8:     "http_server_durée",
+		},
+		{
+			strategy: TranslationStrategyNoUTF8EscapingWithSuffixes,
+			counter:  "system.io_bytes_total",
+			gauge:    "system_network (I/O)_bytes",
+			ratio:    "system.memory.utilization_ratio",
+			utf8:     "http.server.durée_seconds",
+		},
+		{
+			strategy: TranslationStrategyNoTranslation,
+			counter:  "system.io",
+			gauge:    "system_network (I/O)",
+			ratio:    "system.memory.utilization",
+			utf8:     "http.server.durée",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			require.Equal(t, tt.counter, BuildMetricName(createCounter("system.io", "By"), "", tt.strategy))
+			require.Equal(t, tt.gauge, BuildMetricName(createGauge("network (I/O)", "By"), "system", tt.strategy))
+			require.Equal(t, tt.ratio, BuildMetricName(createGauge("system.memory.utilization", "1"), "", tt.strategy))
+			require.Equal(t, tt.utf8, BuildMetricName(createGauge("http.server.durée", "s"), "", tt.strategy))
+		})
+	}
+
+}
+
+func TestBuildMetricNameSuffixesAlreadyPresent(t *testing.T) {
+
+	require.Equal(t, "http.requests_total", BuildMetricName(createCounter("http.requests_total", ""), "", TranslationStrategyNoUTF8EscapingWithSuffixes))
+	require.Equal(t, "disk.io.bytes_total", BuildMetricName(createCounter("disk.io.bytes", "By"), "", TranslationStrategyNoUTF8EscapingWithSuffixes))
+	require.Equal(t, "cpu.utilization_ratio", BuildMetricName(createGauge("cpu.utilization_ratio", "1"), "", TranslationStrategyNoUTF8EscapingWithSuffixes))
+
+}
+
+func TestUnmarshalTranslationStrategy(t *testing.T) {
+
+	var strategy TranslationStrategy
+	require.NoError(t, strategy.UnmarshalText([]byte("NoUTF8EscapingWithSuffixes")))
+	require.Equal(t, TranslationStrategyNoUTF8EscapingWithSuffixes, strategy)
+	require.False(t, strategy.EscapesNames())
+
+	require.NoError(t, strategy.UnmarshalText([]byte("UnderscoreEscapingWithoutSuffixes")))
+	require.Equal(t, TranslationStrategyUnderscoreEscapingWithoutSuffixes, strategy)
+	require.True(t, strategy.EscapesNames())
+
+	require.EqualError(t, strategy.UnmarshalText([]byte("utf8")), `invalid translation strategy "utf8"`)
+
+}
@@ -27,6 +27,7 @@ import (
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 )
 
 // Test_validateMetrics checks validateMetrics return true if a type and temporality combination is valid, false
@@ -611,3 +612,38 @@ func TestMostRecentTimestampInMetric(t *testing.T) {
 		})
 	}
 }
+
+func TestAddSingleNumberDataPointTranslationStrategy(t *testing.T) {
+	for _, tc := range []struct {
+		strategy prometheustranslator.TranslationStrategy
+		expected string
+	}{
+		{
+			strategy: prometheustranslator.TranslationStrategyUnderscoreEscapingWithSuffixes,
+			expected: "http_server_active_requests_total",
+		},
+		{
+			strategy: prometheustranslator.TranslationStrategyUnderscoreEscapingWithoutSuffixes,
+			expected: "http_server_active_requests",
+		},
+		{
+			strategy: prometheustranslator.TranslationStrategyNoUTF8EscapingWithSuffixes,
+			expected: "http.server.active_requests_total",
+		},
+		{
+			strategy: prometheustranslator.TranslationStrategyNoTranslation,
+			expected: "http.server.active_requests",
+		},
+	} {
+		t.Run(string(tc.strategy), func(t *testing.T) {
+			metric := getIntSumMetric("http.server.active_requests", pcommon.NewMap(), 1, 0)
+			metric.Sum().SetIsMonotonic(true)
+			tsMap := map[string]*prompb.TimeSeries{}
+			addSingleNumberDataPoint(metric.Sum().DataPoints().At(0), pcommon.NewResource(), metric, Settings{TranslationStrategy: tc.strategy}, tsMap)
+			assert.Len(t, tsMap, 1)
+			for _, ts := range tsMap {
+				assert.Equal(t, []prompb.Label{getLabel(nameStr, tc.expected)}, ts.Labels)
+			}
+		})
+	}
+}
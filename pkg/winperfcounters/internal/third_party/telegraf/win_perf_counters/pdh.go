@@ -181,6 +181,7 @@ var (
 	pdh_ValidatePathW             *syscall.Proc
 	pdh_ExpandWildCardPathW       *syscall.Proc
 	pdh_GetCounterInfoW           *syscall.Proc
+	pdh_LookupPerfNameByIndexW    *syscall.Proc
 )
 
 func init() {
@@ -199,6 +200,7 @@ func init() {
 	pdh_ValidatePathW = libpdhDll.MustFindProc("PdhValidatePathW")
 	pdh_ExpandWildCardPathW = libpdhDll.MustFindProc("PdhExpandWildCardPathW")
 	pdh_GetCounterInfoW = libpdhDll.MustFindProc("PdhGetCounterInfoW")
+	pdh_LookupPerfNameByIndexW = libpdhDll.MustFindProc("PdhLookupPerfNameByIndexW")
 }
 
 // PdhAddCounter adds the specified counter to the query. This is the internationalized version. Preferably, use the
@@ -499,3 +501,24 @@ func PdhGetCounterInfo(hCounter PDH_HCOUNTER, bRetrieveExplainText int, pdwBuffe
 
 	return uint32(ret)
 }
+
+// PdhLookupPerfNameByIndex returns the performance object name or counter name corresponding to the specified index.
+// The name is returned in the language of the system.
+//
+// dwNameIndex [in]
+// Index of the performance object or counter, as listed in the Counter value of the Perflib registry key.
+//
+// szNameBuffer [out]
+// Caller-allocated buffer that receives the null-terminated name.
+//
+// pcchNameBufferSize [in, out]
+// Size of the szNameBuffer buffer, in characters. On output, the size of the returned name, in characters.
+func PdhLookupPerfNameByIndex(dwNameIndex uint32, szNameBuffer *uint16, pcchNameBufferSize *uint32) uint32 {
+	ret, _, _ := pdh_LookupPerfNameByIndexW.Call(
+		uintptr(unsafe.Pointer(nil)), // look up the name on the local computer
+		uintptr(dwNameIndex),
+		uintptr(unsafe.Pointer(szNameBuffer)),
+		uintptr(unsafe.Pointer(pcchNameBufferSize)))
+
+	return uint32(ret)
+}
@@ -209,6 +209,17 @@ func (m *PerformanceQueryImpl) IsVistaOrNewer() bool {
 	return PdhAddEnglishCounterSupported()
 }
 
+// LookupPerfNameByIndex returns the name, in the language of the system, of the performance object or counter with the given index
+func LookupPerfNameByIndex(index uint32) (string, error) {
+	// PDH_MAX_COUNTER_NAME
+	buff := make([]uint16, 1024)
+	bufSize := uint32(len(buff))
+	if ret := PdhLookupPerfNameByIndex(index, &buff[0], &bufSize); ret != ERROR_SUCCESS {
+		return "", NewPdhError(ret)
+	}
+	return UTF16PtrToString(&buff[0]), nil
+}
+
 // UTF16PtrToString converts Windows API LPTSTR (pointer to string) to go string
 func UTF16PtrToString(s *uint16) string {
 	if s == nil {
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+// +build windows
+
+package winperfcounters // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/winperfcounters"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/winperfcounters/internal/third_party/telegraf/win_perf_counters"
+)
+
+// englishNamesKey holds the English names of the performance objects and
+// counters, which are installed along with those of the system language.
+const englishNamesKey = `SOFTWARE\Microsoft\Windows NT\CurrentVersion\Perflib\009`
+
+var (
+	englishNameIndexesOnce sync.Once
+	englishNameIndexes     map[string]uint32
+	englishNameIndexesErr  error
+)
+
+// loadEnglishNameIndexes reads the index of each English object and counter name.
+func loadEnglishNameIndexes() (map[string]uint32, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, englishNamesKey, registry.QUERY_VALUE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the registry key of English counter names: %w", err)
+	}
+	defer key.Close()
+
+	values, _, err := key.GetStringsValue("Counter")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read English counter names: %w", err)
+	}
+	return parseNameIndexes(values), nil
+}
+
+// parseNameIndexes parses the alternating indexes and names of the Counter
+// registry value. Names are matched case-insensitively, and the first index of
+// a name listed more than once is kept.
+func parseNameIndexes(values []string) map[string]uint32 {
+	indexes := make(map[string]uint32, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		index, err := strconv.ParseUint(values[i], 10, 32)
+		if err != nil {
+			continue
+		}
+		name := strings.ToLower(values[i+1])
+		if _, ok := indexes[name]; !ok {
+			indexes[name] = uint32(index)
+		}
+	}
+	return indexes
+}
+
+// localizeName returns the name, in the language of the system, of the object
+// or counter with the given English name.
+func localizeName(name string) (string, error) {
+	englishNameIndexesOnce.Do(func() {
+		englishNameIndexes, englishNameIndexesErr = loadEnglishNameIndexes()
+	})
+	if englishNameIndexesErr != nil {
+		return "", englishNameIndexesErr
+	}
+
+	index, ok := englishNameIndexes[strings.ToLower(name)]
+	if !ok {
+		return "", fmt.Errorf("no index found for the English name %q", name)
+	}
+	localized, err := win_perf_counters.LookupPerfNameByIndex(index)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the name of index %d: %w", index, err)
+	}
+	return localized, nil
+}
+
+// localizedCounterPath returns the counter path with the object and counter
+// names in the language of the system. Instance names are not localized.
+func localizedCounterPath(object, instance, counterName string) (string, error) {
+	localizedObject, err := localizeName(object)
+	if err != nil {
+		return "", err
+	}
+	localizedCounter, err := localizeName(counterName)
+	if err != nil {
+		return "", err
+	}
+	return counterPath(localizedObject, instance, localizedCounter), nil
+}
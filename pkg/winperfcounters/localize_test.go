@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+// +build windows
+
+package winperfcounters // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/winperfcounters"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNameIndexes(t *testing.T) {
+	indexes := parseNameIndexes([]string{
+		"1", "1847",
+		"2", "System",
+		"4", "Memory",
+		"invalid", "Ignored",
+		"1406", "memory",
+		"26", "Committed Bytes",
+		"28",
+	})
+
+	assert.Equal(t, map[string]uint32{
+		"1847":            1,
+		"system":          2,
+		"memory":          4,
+		"committed bytes": 26,
+	}, indexes)
+}
+
+func TestLocalizedCounterPath(t *testing.T) {
+	path, err := localizedCounterPath("Memory", "", "Committed Bytes")
+	require.NoError(t, err)
+
+	pc, err := newLocalizedPerfCounter(`\Memory\Committed Bytes`, path, false)
+	require.NoError(t, err)
+	assert.Equal(t, `\Memory\Committed Bytes`, pc.Path())
+	assert.NoError(t, pc.Close())
+}
+
+func TestLocalizedCounterPath_UnknownName(t *testing.T) {
+	_, err := localizedCounterPath("Memory", "", "Unknown Counter")
+	assert.EqualError(t, err, `no index found for the English name "Unknown Counter"`)
+}
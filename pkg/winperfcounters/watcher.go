@@ -46,12 +46,24 @@ type perfCounter struct {
 }
 
 // NewWatcher creates new PerfCounterWatcher by provided parts of its path.
+// The object and counter names are English names. When the system does not
+// resolve them, e.g. for some counters on non-English installs, they are
+// replaced with the localized names of their index.
 func NewWatcher(object, instance, counterName string) (PerfCounterWatcher, error) {
 	path := counterPath(object, instance, counterName)
 	counter, err := newPerfCounter(path, true)
-	if err != nil {
+	if err == nil {
+		return counter, nil
+	}
+
+	localizedPath, localizeErr := localizedCounterPath(object, instance, counterName)
+	if localizeErr != nil || localizedPath == path {
 		return nil, fmt.Errorf("failed to create perf counter with path %v: %w", path, err)
 	}
+	counter, err = newLocalizedPerfCounter(path, localizedPath, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create perf counter with path %v (localized as %v): %w", path, localizedPath, err)
+	}
 	return counter, nil
 }
 
@@ -65,6 +77,20 @@ func counterPath(object, instance, counterName string) string {
 
 // newPerfCounter returns a new performance counter for the specified descriptor.
 func newPerfCounter(counterPath string, collectOnStartup bool) (*perfCounter, error) {
+	return openPerfCounter(counterPath, collectOnStartup, func(query win_perf_counters.PerformanceQuery) (win_perf_counters.PDH_HCOUNTER, error) {
+		return query.AddEnglishCounterToQuery(counterPath)
+	})
+}
+
+// newLocalizedPerfCounter returns a new performance counter for the specified
+// descriptor, added to the query with its path in the language of the system.
+func newLocalizedPerfCounter(counterPath, localizedPath string, collectOnStartup bool) (*perfCounter, error) {
+	return openPerfCounter(counterPath, collectOnStartup, func(query win_perf_counters.PerformanceQuery) (win_perf_counters.PDH_HCOUNTER, error) {
+		return query.AddCounterToQuery(localizedPath)
+	})
+}
+
+func openPerfCounter(counterPath string, collectOnStartup bool, addCounter func(win_perf_counters.PerformanceQuery) (win_perf_counters.PDH_HCOUNTER, error)) (*perfCounter, error) {
 	query := &win_perf_counters.PerformanceQueryImpl{}
 	err := query.Open()
 	if err != nil {
@@ -72,8 +98,9 @@ func newPerfCounter(counterPath string, collectOnStartup bool) (*perfCounter, er
 	}
 
 	var handle win_perf_counters.PDH_HCOUNTER
-	handle, err = query.AddEnglishCounterToQuery(counterPath)
+	handle, err = addCounter(query)
 	if err != nil {
+		_ = query.Close()
 		return nil, err
 	}
 
@@ -82,6 +109,7 @@ func newPerfCounter(counterPath string, collectOnStartup bool) (*perfCounter, er
 	if collectOnStartup {
 		err = query.CollectData()
 		if err != nil {
+			_ = query.Close()
 			return nil, err
 		}
 	}
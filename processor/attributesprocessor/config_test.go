@@ -94,6 +94,28 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "hash_hmac"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Settings: attraction.Settings{
+					Actions: []attraction.ActionKeyValue{
+						{Key: "user.id", Action: attraction.HASH, HashAlgorithm: "hmac-sha256", SaltFromEnv: "USER_ID_HASH_KEY"},
+					},
+				},
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "mask"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Settings: attraction.Settings{
+					Actions: []attraction.ActionKeyValue{
+						{Key: "account_number", Action: attraction.MASK, KeepLast: 4},
+					},
+				},
+			},
+		},
 		{
 			id: config.NewComponentIDWithName(typeStr, "excludemulti"),
 			expected: &Config{
@@ -36,6 +36,10 @@ type Config struct {
 	// Cannot be used with deprecated Metrics config option.
 	Include MatchMetrics `mapstructure:"include"`
 	Exclude MatchMetrics `mapstructure:"exclude"`
+
+	// StorageID is the ID of the storage extension the states are saved in when
+	// the processor shuts down, and restored from when it starts.
+	StorageID *config.ComponentID `mapstructure:"storage"`
 }
 
 type MatchMetrics struct {
@@ -30,6 +30,7 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := config.NewComponentID("file_storage")
 	tests := []struct {
 		id           config.ComponentID
 		expected     config.Processor
@@ -91,6 +92,14 @@ func TestLoadConfig(t *testing.T) {
 				MaxStaleness: 10 * time.Second,
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "storage"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				MaxStaleness:      time.Hour,
+				StorageID:         &storageID,
+			},
+		},
 		{
 			id:           config.NewComponentIDWithName(typeStr, "missing_match_type"),
 			errorMessage: "match_type must be set if metrics are supplied",
@@ -204,3 +204,32 @@ func (t *MetricTracker) sweeper(ctx context.Context, remove func(pcommon.Timesta
 		}
 	}
 }
+
+// Snapshot returns the last point of every tracked series, by series identity.
+func (t *MetricTracker) Snapshot() map[string]ValuePoint {
+	points := make(map[string]ValuePoint)
+	t.states.Range(func(key, value interface{}) bool {
+		s := value.(*State)
+		s.Lock()
+		points[key.(string)] = s.PrevPoint
+		s.Unlock()
+		return true
+	})
+	return points
+}
+
+// Restore tracks the points of a previous Snapshot, for the next points of
+// their series to be converted from them. The points that would already be
+// stale are ignored, as are the series tracked since.
+func (t *MetricTracker) Restore(points map[string]ValuePoint) {
+	var staleBefore pcommon.Timestamp
+	if t.maxStaleness > 0 {
+		staleBefore = pcommon.NewTimestampFromTime(time.Now().Add(-t.maxStaleness))
+	}
+	for key, point := range points {
+		if point.ObservedTimestamp < staleBefore {
+			continue
+		}
+		t.states.LoadOrStore(key, &State{PrevPoint: point})
+	}
+}
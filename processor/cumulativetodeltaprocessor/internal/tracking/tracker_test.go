@@ -213,6 +213,37 @@ func Test_metricTracker_removeStale(t *testing.T) {
 	}
 }
 
+func TestMetricTracker_SnapshotRestore(t *testing.T) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+	stale := pcommon.NewTimestampFromTime(time.Now().Add(-time.Hour))
+	freshPoint := ValuePoint{ObservedTimestamp: now, IntValue: 100}
+	histogramPoint := ValuePoint{
+		ObservedTimestamp: now,
+		HistogramValue:    &HistogramPoint{Count: 3, Sum: 6, Buckets: []uint64{1, 2}},
+	}
+
+	tr := NewMetricTracker(context.Background(), zap.NewNop(), time.Minute)
+	tr.Restore(map[string]ValuePoint{
+		"fresh":     freshPoint,
+		"histogram": histogramPoint,
+		"stale":     {ObservedTimestamp: stale, IntValue: 10},
+	})
+
+	want := map[string]ValuePoint{
+		"fresh":     freshPoint,
+		"histogram": histogramPoint,
+	}
+	if got := tr.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("MetricTracker.Snapshot() = %v, want %v", got, want)
+	}
+
+	// the series tracked since are kept
+	tr.Restore(map[string]ValuePoint{"fresh": {ObservedTimestamp: now, IntValue: 50}})
+	if got := tr.Snapshot()["fresh"]; !reflect.DeepEqual(got, freshPoint) {
+		t.Errorf("MetricTracker.Restore() replaced %v by %v", freshPoint, got)
+	}
+}
+
 func Test_metricTracker_sweeper(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	sweepEvent := make(chan pcommon.Timestamp)
@@ -16,10 +16,15 @@ package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentele
 
 import (
 	"context"
+	"fmt"
 	"math"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
@@ -45,6 +50,9 @@ type cumulativeToDeltaProcessor struct {
 	deltaCalculator         *tracking.MetricTracker
 	cancelFunc              context.CancelFunc
 	histogramSupportEnabled bool
+	id                      config.ComponentID
+	storageID               *config.ComponentID
+	storageClient           storage.Client
 }
 
 func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulativeToDeltaProcessor {
@@ -54,6 +62,8 @@ func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulati
 		deltaCalculator:         tracking.NewMetricTracker(ctx, logger, config.MaxStaleness),
 		cancelFunc:              cancel,
 		histogramSupportEnabled: featuregate.GetRegistry().IsEnabled(enableHistogramSupportGateID),
+		id:                      config.ID(),
+		storageID:               config.StorageID,
 	}
 	if len(config.Include.Metrics) > 0 {
 		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
@@ -134,11 +144,40 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 	return md, nil
 }
 
-func (ctdp *cumulativeToDeltaProcessor) shutdown(context.Context) error {
-	ctdp.cancelFunc()
+// start restores the states saved in the storage extension, if any.
+func (ctdp *cumulativeToDeltaProcessor) start(ctx context.Context, host component.Host) error {
+	if ctdp.storageID == nil {
+		return nil
+	}
+	client, err := getStorageClient(ctx, host, *ctdp.storageID, ctdp.id)
+	if err != nil {
+		return err
+	}
+	ctdp.storageClient = client
+
+	points, err := loadStates(ctx, client)
+	if err != nil {
+		// The deltas are computed again from the next points, as after a first start.
+		ctdp.logger.Warn("Failed to restore the states, ignoring them", zap.Error(err))
+		return nil
+	}
+	ctdp.deltaCalculator.Restore(points)
+	ctdp.logger.Debug("Restored the states", zap.Int("series", len(points)))
 	return nil
 }
 
+func (ctdp *cumulativeToDeltaProcessor) shutdown(ctx context.Context) error {
+	ctdp.cancelFunc()
+	if ctdp.storageClient == nil {
+		return nil
+	}
+	var errs error
+	if err := saveStates(ctx, ctdp.storageClient, ctdp.deltaCalculator.Snapshot()); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to save the states: %w", err))
+	}
+	return multierr.Append(errs, ctdp.storageClient.Close(ctx))
+}
+
 func (ctdp *cumulativeToDeltaProcessor) shouldConvertMetric(metricName string) bool {
 	return (ctdp.includeFS == nil || ctdp.includeFS.Matches(metricName)) &&
 		(ctdp.excludeFS == nil || !ctdp.excludeFS.Matches(metricName))
@@ -31,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
 )
 
@@ -371,6 +372,46 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 	}
 }
 
+func TestCumulativeToDeltaProcessorStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("test")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	// consume runs a processor from its start to its shutdown, returning the
+	// value of the converted point.
+	consume := func(value float64) float64 {
+		next := new(consumertest.MetricsSink)
+		mgp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, next)
+		require.NoError(t, err)
+		require.NoError(t, mgp.Start(context.Background(), host))
+		require.NoError(t, mgp.ConsumeMetrics(context.Background(), generateTestSumMetrics(testSumMetric{
+			metricNames:  []string{"metric_1"},
+			metricValues: [][]float64{{value}},
+			isCumulative: []bool{true},
+		})))
+		require.NoError(t, mgp.Shutdown(context.Background()))
+
+		require.Len(t, next.AllMetrics(), 1)
+		return next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).DoubleValue()
+	}
+
+	assert.Equal(t, 100.0, consume(100))
+	// the delta is computed from the value seen before the restart
+	assert.Equal(t, 50.0, consume(150))
+	assert.Equal(t, 25.0, consume(175))
+}
+
+func TestCumulativeToDeltaProcessorMissingStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	mgp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, mgp.Start(context.Background(), storagetest.NewStorageHost()), "storage extension 'test_storage/missing' not found")
+}
+
 func generateTestSumMetrics(tm testSumMetric) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor"
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+)
+
+// statesKey is the storage key of the last points of the tracked series.
+const statesKey = "states"
+
+// loadStates returns the last points of the series saved by saveStates, or nil
+// when none were saved.
+func loadStates(ctx context.Context, client storage.Client) (map[string]tracking.ValuePoint, error) {
+	data, err := client.Get(ctx, statesKey)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var points map[string]tracking.ValuePoint
+	// gob rather than JSON, the histogram sums being possibly NaN.
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&points); err != nil {
+		return nil, fmt.Errorf("invalid states: %w", err)
+	}
+	return points, nil
+}
+
+func saveStates(ctx context.Context, client storage.Client, points map[string]tracking.ValuePoint) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(points); err != nil {
+		return err
+	}
+	return client.Set(ctx, statesKey, buf.Bytes())
+}
+
+// getStorageClient returns a client of the configured storage extension.
+func getStorageClient(ctx context.Context, host component.Host, storageID config.ComponentID, componentID config.ComponentID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbufferprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/diskbufferprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+const (
+	metadataKey = "metadata"
+
+	// metadataSize is the size of the encoded head, tail and size of the buffer.
+	metadataSize = 3 * 8
+	// timestampSize is the size of the timestamp prefixing every item.
+	timestampSize = 8
+)
+
+var errInvalidMetadata = errors.New("invalid buffer metadata")
+
+// item is a batch of serialized data, along with the time it was spilled.
+type item struct {
+	timestamp time.Time
+	data      []byte
+	// size is the number of bytes taken by the item in the storage.
+	size int64
+}
+
+// spillBuffer is a FIFO queue of serialized batches, persisted in a storage client.
+// It is not safe for concurrent use.
+type spillBuffer struct {
+	client storage.Client
+
+	// head is the index of the oldest item, tail the index of the next item to be pushed.
+	head uint64
+	tail uint64
+	// size is the number of bytes taken by all the items.
+	size int64
+}
+
+// newSpillBuffer returns a buffer backed by the given client, restoring the items
+// left by a previous run.
+func newSpillBuffer(ctx context.Context, client storage.Client) (*spillBuffer, error) {
+	b := &spillBuffer{client: client}
+
+	data, err := client.Get(ctx, metadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return b, nil
+	}
+	if len(data) != metadataSize {
+		return nil, errInvalidMetadata
+	}
+	b.head = binary.BigEndian.Uint64(data[0:])
+	b.tail = binary.BigEndian.Uint64(data[8:])
+	b.size = int64(binary.BigEndian.Uint64(data[16:]))
+	if b.head > b.tail {
+		return nil, errInvalidMetadata
+	}
+	return b, nil
+}
+
+// len returns the number of items in the buffer.
+func (b *spillBuffer) len() uint64 {
+	return b.tail - b.head
+}
+
+// bytes returns the number of bytes taken by the items in the buffer.
+func (b *spillBuffer) bytes() int64 {
+	return b.size
+}
+
+// push appends the data at the end of the buffer.
+func (b *spillBuffer) push(ctx context.Context, timestamp time.Time, data []byte) error {
+	value := make([]byte, timestampSize+len(data))
+	binary.BigEndian.PutUint64(value, uint64(timestamp.UnixNano()))
+	copy(value[timestampSize:], data)
+
+	tail, size := b.tail+1, b.size+int64(len(value))
+	if err := b.client.Batch(ctx,
+		storage.SetOperation(itemKey(b.tail), value),
+		storage.SetOperation(metadataKey, encodeMetadata(b.head, tail, size)),
+	); err != nil {
+		return err
+	}
+	b.tail, b.size = tail, size
+	return nil
+}
+
+// peek returns the oldest item of the buffer, or false if the buffer is empty.
+func (b *spillBuffer) peek(ctx context.Context) (item, bool, error) {
+	if b.len() == 0 {
+		return item{}, false, nil
+	}
+	value, err := b.client.Get(ctx, itemKey(b.head))
+	if err != nil {
+		return item{}, false, err
+	}
+	if len(value) < timestampSize {
+		return item{size: int64(len(value))}, true, fmt.Errorf("invalid item %d in buffer", b.head)
+	}
+	return item{
+		timestamp: time.Unix(0, int64(binary.BigEndian.Uint64(value))),
+		data:      value[timestampSize:],
+		size:      int64(len(value)),
+	}, true, nil
+}
+
+// pop removes the oldest item, as returned by peek, from the buffer.
+func (b *spillBuffer) pop(ctx context.Context, it item) error {
+	if b.len() == 0 {
+		return nil
+	}
+	head, size := b.head+1, b.size-it.size
+	if head == b.tail || size < 0 {
+		// the size can't be trusted anymore if an item was lost
+		size = 0
+	}
+	if err := b.client.Batch(ctx,
+		storage.DeleteOperation(itemKey(b.head)),
+		storage.SetOperation(metadataKey, encodeMetadata(head, b.tail, size)),
+	); err != nil {
+		return err
+	}
+	b.head, b.size = head, size
+	return nil
+}
+
+func itemKey(index uint64) string {
+	return strconv.FormatUint(index, 10)
+}
+
+func encodeMetadata(head, tail uint64, size int64) []byte {
+	data := make([]byte, metadataSize)
+	binary.BigEndian.PutUint64(data[0:], head)
+	binary.BigEndian.PutUint64(data[8:], tail)
+	binary.BigEndian.PutUint64(data[16:], uint64(size))
+	return data
+}
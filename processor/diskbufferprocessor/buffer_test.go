@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbufferprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestSpillBufferFIFO(t *testing.T) {
+	ctx := context.Background()
+	b, err := newSpillBuffer(ctx, newMapClient())
+	require.NoError(t, err)
+
+	_, ok, err := b.peek(ctx)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	now := time.Now()
+	require.NoError(t, b.push(ctx, now, []byte("first")))
+	require.NoError(t, b.push(ctx, now.Add(time.Second), []byte("second")))
+	assert.EqualValues(t, 2, b.len())
+	assert.EqualValues(t, 2*timestampSize+len("first")+len("second"), b.bytes())
+
+	it, ok, err := b.peek(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "first", string(it.data))
+	assert.Equal(t, now.UnixNano(), it.timestamp.UnixNano())
+	require.NoError(t, b.pop(ctx, it))
+
+	it, ok, err = b.peek(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "second", string(it.data))
+	require.NoError(t, b.pop(ctx, it))
+
+	assert.EqualValues(t, 0, b.len())
+	assert.EqualValues(t, 0, b.bytes())
+}
+
+func TestSpillBufferRestore(t *testing.T) {
+	ctx := context.Background()
+	client := newMapClient()
+	b, err := newSpillBuffer(ctx, client)
+	require.NoError(t, err)
+	require.NoError(t, b.push(ctx, time.Now(), []byte("first")))
+	require.NoError(t, b.push(ctx, time.Now(), []byte("second")))
+	it, _, err := b.peek(ctx)
+	require.NoError(t, err)
+	require.NoError(t, b.pop(ctx, it))
+
+	// test
+	restored, err := newSpillBuffer(ctx, client)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, b.len(), restored.len())
+	assert.Equal(t, b.bytes(), restored.bytes())
+	it, ok, err := restored.peek(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "second", string(it.data))
+}
+
+func TestSpillBufferInvalidMetadata(t *testing.T) {
+	ctx := context.Background()
+	client := newMapClient()
+	require.NoError(t, client.Set(ctx, metadataKey, []byte("invalid")))
+
+	_, err := newSpillBuffer(ctx, client)
+	assert.ErrorIs(t, err, errInvalidMetadata)
+}
+
+func TestSpillBufferMissingItem(t *testing.T) {
+	ctx := context.Background()
+	client := newMapClient()
+	b, err := newSpillBuffer(ctx, client)
+	require.NoError(t, err)
+	require.NoError(t, b.push(ctx, time.Now(), []byte("first")))
+	require.NoError(t, client.Delete(ctx, itemKey(0)))
+
+	it, ok, err := b.peek(ctx)
+	assert.True(t, ok)
+	assert.Error(t, err)
+
+	require.NoError(t, b.pop(ctx, it))
+	assert.EqualValues(t, 0, b.len())
+	assert.EqualValues(t, 0, b.bytes())
+}
+
+// mapClient is an in-memory storage.Client.
+type mapClient struct {
+	mu     sync.Mutex
+	data   map[string][]byte
+	closed bool
+}
+
+func newMapClient() *mapClient {
+	return &mapClient{data: map[string][]byte{}}
+}
+
+func (c *mapClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *mapClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *mapClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *mapClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.data[op.Key]
+		case storage.Set:
+			c.data[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.data, op.Key)
+		}
+	}
+	return nil
+}
+
+func (c *mapClient) Close(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbufferprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/diskbufferprocessor"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config is the configuration for the processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// StorageID is the ID of the storage extension holding the spilled batches.
+	// Required.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+
+	// MaxSizeMiB is the maximum size of the spilled batches, in MiB.
+	// Default: 1024.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib"`
+
+	// HighWatermarkPercentage is the percentage of MaxSizeMiB above which incoming data
+	// is refused, until the replay brings the buffer back under LowWatermarkPercentage.
+	// Default: 90.
+	HighWatermarkPercentage int64 `mapstructure:"high_watermark_percentage"`
+
+	// LowWatermarkPercentage is the percentage of MaxSizeMiB under which incoming data
+	// is accepted again, after the HighWatermarkPercentage has been reached.
+	// Default: 70.
+	LowWatermarkPercentage int64 `mapstructure:"low_watermark_percentage"`
+
+	// MaxAge is the maximum time a batch is kept in the buffer. Older batches are
+	// evicted instead of being replayed. Zero means no eviction.
+	// Default: 1h.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// ReplayInterval is the time to wait between attempts to replay the spilled batches.
+	// Default: 1s.
+	ReplayInterval time.Duration `mapstructure:"replay_interval"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.StorageID == nil {
+		return errors.New("storage is required")
+	}
+	if cfg.MaxSizeMiB <= 0 {
+		return errors.New("max_size_mib must be greater than 0")
+	}
+	if cfg.LowWatermarkPercentage <= 0 || cfg.HighWatermarkPercentage > 100 ||
+		cfg.LowWatermarkPercentage >= cfg.HighWatermarkPercentage {
+		return errors.New("watermarks must satisfy 0 < low_watermark_percentage < high_watermark_percentage <= 100")
+	}
+	if cfg.MaxAge < 0 {
+		return errors.New("max_age must not be negative")
+	}
+	if cfg.ReplayInterval <= 0 {
+		return errors.New("replay_interval must be greater than 0")
+	}
+	return nil
+}
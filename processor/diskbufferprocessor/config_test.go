@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbufferprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	storageID := config.NewComponentID("file_storage")
+	customStorageID := config.NewComponentIDWithName("file_storage", "diskbuffer")
+
+	tests := []struct {
+		id       config.ComponentID
+		expected config.Processor
+	}{
+		{
+			id: config.NewComponentID(typeStr),
+			expected: &Config{
+				ProcessorSettings:       config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				StorageID:               &storageID,
+				MaxSizeMiB:              defaultMaxSizeMiB,
+				HighWatermarkPercentage: defaultHighWatermarkPercentage,
+				LowWatermarkPercentage:  defaultLowWatermarkPercentage,
+				MaxAge:                  defaultMaxAge,
+				ReplayInterval:          defaultReplayInterval,
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "custom"),
+			expected: &Config{
+				ProcessorSettings:       config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				StorageID:               &customStorageID,
+				MaxSizeMiB:              512,
+				HighWatermarkPercentage: 80,
+				LowWatermarkPercentage:  50,
+				MaxAge:                  30 * time.Minute,
+				ReplayInterval:          5 * time.Second,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	storageID := config.NewComponentID("file_storage")
+
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		error  string
+	}{
+		{
+			name:   "no storage",
+			modify: func(cfg *Config) { cfg.StorageID = nil },
+			error:  "storage is required",
+		},
+		{
+			name:   "no max size",
+			modify: func(cfg *Config) { cfg.MaxSizeMiB = 0 },
+			error:  "max_size_mib must be greater than 0",
+		},
+		{
+			name:   "low watermark above high watermark",
+			modify: func(cfg *Config) { cfg.LowWatermarkPercentage = 95 },
+			error:  "watermarks must satisfy 0 < low_watermark_percentage < high_watermark_percentage <= 100",
+		},
+		{
+			name:   "high watermark above 100",
+			modify: func(cfg *Config) { cfg.HighWatermarkPercentage = 110 },
+			error:  "watermarks must satisfy 0 < low_watermark_percentage < high_watermark_percentage <= 100",
+		},
+		{
+			name:   "negative max age",
+			modify: func(cfg *Config) { cfg.MaxAge = -time.Second },
+			error:  "max_age must not be negative",
+		},
+		{
+			name:   "no replay interval",
+			modify: func(cfg *Config) { cfg.ReplayInterval = 0 },
+			error:  "replay_interval must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.StorageID = &storageID
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.error)
+		})
+	}
+}
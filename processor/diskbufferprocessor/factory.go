@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbufferprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/diskbufferprocessor"
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// typeStr is the value of "type" for this processor in the configuration.
+	typeStr config.Type = "diskbuffer"
+	// The stability level of the processor.
+	stability = component.StabilityLevelInDevelopment
+
+	defaultMaxSizeMiB              = 1024
+	defaultHighWatermarkPercentage = 90
+	defaultLowWatermarkPercentage  = 70
+	defaultMaxAge                  = time.Hour
+	defaultReplayInterval          = time.Second
+)
+
+// NewFactory returns a new factory for the disk buffer processor.
+func NewFactory() component.ProcessorFactory {
+	// TODO: find a more appropriate way to get this done, as we are swallowing the error here
+	_ = view.Register(MetricViews()...)
+
+	return component.NewProcessorFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesProcessor(createTracesProcessor, stability),
+		component.WithMetricsProcessor(createMetricsProcessor, stability),
+		component.WithLogsProcessor(createLogsProcessor, stability))
+}
+
+// createDefaultConfig creates the default configuration for the processor.
+func createDefaultConfig() config.Processor {
+	return &Config{
+		ProcessorSettings:       config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		MaxSizeMiB:              defaultMaxSizeMiB,
+		HighWatermarkPercentage: defaultHighWatermarkPercentage,
+		LowWatermarkPercentage:  defaultLowWatermarkPercentage,
+		MaxAge:                  defaultMaxAge,
+		ReplayInterval:          defaultReplayInterval,
+	}
+}
+
+func createTracesProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Traces) (component.TracesProcessor, error) {
+	return newTracesProcessor(params.Logger, cfg.(*Config), nextConsumer), nil
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Metrics) (component.MetricsProcessor, error) {
+	return newMetricsProcessor(params.Logger, cfg.(*Config), nextConsumer), nil
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Logs) (component.LogsProcessor, error) {
+	return newLogsProcessor(params.Logger, cfg.(*Config), nextConsumer), nil
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbufferprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestDefaultConfiguration(t *testing.T) {
+	// test
+	c := createDefaultConfig().(*Config)
+
+	// verify
+	assert.Nil(t, c.StorageID)
+	assert.EqualValues(t, defaultMaxSizeMiB, c.MaxSizeMiB)
+	assert.EqualValues(t, defaultHighWatermarkPercentage, c.HighWatermarkPercentage)
+	assert.EqualValues(t, defaultLowWatermarkPercentage, c.LowWatermarkPercentage)
+	assert.Equal(t, defaultMaxAge, c.MaxAge)
+	assert.Equal(t, defaultReplayInterval, c.ReplayInterval)
+}
+
+func TestCreateProcessors(t *testing.T) {
+	f := NewFactory()
+	c := f.CreateDefaultConfig()
+	set := componenttest.NewNopProcessorCreateSettings()
+
+	tp, err := f.CreateTracesProcessor(context.Background(), set, c, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, tp)
+
+	mp, err := f.CreateMetricsProcessor(context.Background(), set, c, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, mp)
+
+	lp, err := f.CreateLogsProcessor(context.Background(), set, c, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, lp)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbufferprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/diskbufferprocessor"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	tagProcessorKey, _ = tag.NewKey("processor")
+	tagSignalKey, _    = tag.NewKey("signal")
+
+	mBatchesSpilled  = stats.Int64("batches_spilled", "Batches refused by the next consumer and spilled to disk", stats.UnitDimensionless)
+	mBatchesReplayed = stats.Int64("batches_replayed", "Spilled batches successfully replayed to the next consumer", stats.UnitDimensionless)
+	mBatchesEvicted  = stats.Int64("batches_evicted", "Spilled batches evicted because they reached the maximum age", stats.UnitDimensionless)
+	mBatchesDropped  = stats.Int64("batches_dropped", "Spilled batches dropped because they could not be replayed", stats.UnitDimensionless)
+	mBatchesRefused  = stats.Int64("batches_refused", "Batches refused because the buffer reached its watermark", stats.UnitDimensionless)
+	mBufferBatches   = stats.Int64("buffer_batches", "Number of batches currently spilled to disk", stats.UnitDimensionless)
+	mBufferSize      = stats.Int64("buffer_size", "Size of the batches currently spilled to disk", stats.UnitBytes)
+)
+
+// MetricViews return the metrics views according to given telemetry level.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagProcessorKey, tagSignalKey}
+
+	sumViews := []*stats.Int64Measure{mBatchesSpilled, mBatchesReplayed, mBatchesEvicted, mBatchesDropped, mBatchesRefused}
+	lastValueViews := []*stats.Int64Measure{mBufferBatches, mBufferSize}
+
+	var views []*view.View
+	for _, m := range sumViews {
+		views = append(views, &view.View{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), m.Name()),
+			Measure:     m,
+			Description: m.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		})
+	}
+	for _, m := range lastValueViews {
+		views = append(views, &view.View{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), m.Name()),
+			Measure:     m,
+			Description: m.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.LastValue(),
+		})
+	}
+	return views
+}
@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbufferprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/diskbufferprocessor"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+var errBufferFull = errors.New("disk buffer is full")
+
+// diskBufferProcessor forwards the data to the next consumer, spilling it to disk when
+// the next consumer refuses it. The spilled batches are replayed in order once the next
+// consumer accepts data again. While the buffer is not empty, incoming data is spilled
+// as well, so that the order is preserved.
+type diskBufferProcessor struct {
+	logger   *zap.Logger
+	config   *Config
+	signal   config.DataType
+	statsCtx context.Context
+
+	// replayFn forwards a spilled batch to the next consumer.
+	replayFn func(ctx context.Context, data []byte) error
+
+	maxSize       int64
+	highWatermark int64
+	lowWatermark  int64
+
+	mu     sync.Mutex
+	client storage.Client
+	buffer *spillBuffer
+	// refusing is set when the buffer reaches the high watermark, and cleared
+	// once the replay brings it back under the low watermark.
+	refusing bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newDiskBufferProcessor(logger *zap.Logger, cfg *Config, signal config.DataType, replayFn func(context.Context, []byte) error) *diskBufferProcessor {
+	statsCtx, _ := tag.New(context.Background(),
+		tag.Upsert(tagProcessorKey, cfg.ID().String()),
+		tag.Upsert(tagSignalKey, string(signal)))
+
+	maxSize := cfg.MaxSizeMiB * 1024 * 1024
+	return &diskBufferProcessor{
+		logger:        logger,
+		config:        cfg,
+		signal:        signal,
+		statsCtx:      statsCtx,
+		replayFn:      replayFn,
+		maxSize:       maxSize,
+		highWatermark: maxSize * cfg.HighWatermarkPercentage / 100,
+		lowWatermark:  maxSize * cfg.LowWatermarkPercentage / 100,
+	}
+}
+
+func (p *diskBufferProcessor) Start(ctx context.Context, host component.Host) error {
+	client, err := getStorageClient(ctx, host, *p.config.StorageID, p.config.ID(), p.signal)
+	if err != nil {
+		return err
+	}
+	buffer, err := newSpillBuffer(ctx, client)
+	if err != nil {
+		_ = client.Close(ctx)
+		return err
+	}
+
+	p.mu.Lock()
+	p.client, p.buffer = client, buffer
+	p.refusing = buffer.bytes() >= p.highWatermark
+	p.recordBufferStats()
+	p.mu.Unlock()
+
+	if buffer.len() > 0 {
+		p.logger.Info("Replaying batches spilled by a previous run", zap.Uint64("batches", buffer.len()))
+	}
+
+	replayCtx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.wg.Add(1)
+	go p.replayLoop(replayCtx)
+	return nil
+}
+
+// Shutdown stops the replay. The batches still in the buffer are kept in the storage,
+// to be replayed by the next run.
+func (p *diskBufferProcessor) Shutdown(ctx context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+	if p.client != nil {
+		return p.client.Close(ctx)
+	}
+	return nil
+}
+
+func (p *diskBufferProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// consume forwards the data to the next consumer, or spills it when the next consumer refuses it.
+func (p *diskBufferProcessor) consume(ctx context.Context, forward func(context.Context) error, marshal func() ([]byte, error)) error {
+	if !p.spilling() {
+		err := forward(ctx)
+		if err == nil || consumererror.IsPermanent(err) {
+			return err
+		}
+		p.logger.Debug("Next consumer refused the data, spilling it to disk", zap.Error(err))
+	}
+
+	data, err := marshal()
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return p.spill(ctx, data)
+}
+
+func (p *diskBufferProcessor) spilling() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.buffer.len() > 0
+}
+
+func (p *diskBufferProcessor) spill(ctx context.Context, data []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.refusing || p.buffer.bytes()+timestampSize+int64(len(data)) > p.maxSize {
+		stats.Record(p.statsCtx, mBatchesRefused.M(1))
+		return errBufferFull
+	}
+	if err := p.buffer.push(ctx, time.Now(), data); err != nil {
+		return err
+	}
+	if p.buffer.bytes() >= p.highWatermark {
+		p.refusing = true
+		p.logger.Warn("Disk buffer reached its high watermark, refusing incoming data", zap.Int64("size", p.buffer.bytes()))
+	}
+
+	stats.Record(p.statsCtx, mBatchesSpilled.M(1))
+	p.recordBufferStats()
+	return nil
+}
+
+func (p *diskBufferProcessor) replayLoop(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.config.ReplayInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.replay(ctx)
+		}
+	}
+}
+
+// replay forwards the spilled batches in order, until the buffer is empty or
+// the next consumer refuses one of them.
+func (p *diskBufferProcessor) replay(ctx context.Context) {
+	for ctx.Err() == nil {
+		p.mu.Lock()
+		it, ok, err := p.buffer.peek(ctx)
+		p.mu.Unlock()
+		if !ok {
+			if err != nil {
+				p.logger.Warn("Failed to read the disk buffer", zap.Error(err))
+			}
+			return
+		}
+
+		m := mBatchesReplayed
+		switch {
+		case err != nil:
+			p.logger.Error("Dropping unreadable spilled batch", zap.Error(err))
+			m = mBatchesDropped
+		case p.config.MaxAge > 0 && time.Since(it.timestamp) > p.config.MaxAge:
+			m = mBatchesEvicted
+		default:
+			if err = p.replayFn(ctx, it.data); err != nil {
+				if !consumererror.IsPermanent(err) {
+					p.logger.Debug("Next consumer still refuses data, will retry later", zap.Error(err))
+					return
+				}
+				p.logger.Error("Dropping spilled batch refused by the next consumer", zap.Error(err))
+				m = mBatchesDropped
+			}
+		}
+
+		if err = p.remove(ctx, it, m); err != nil {
+			p.logger.Error("Failed to remove batch from the disk buffer", zap.Error(err))
+			return
+		}
+	}
+}
+
+func (p *diskBufferProcessor) remove(ctx context.Context, it item, m *stats.Int64Measure) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.buffer.pop(ctx, it); err != nil {
+		return err
+	}
+	if p.refusing && p.buffer.bytes() <= p.lowWatermark {
+		p.refusing = false
+		p.logger.Info("Disk buffer went under its low watermark, accepting incoming data", zap.Int64("size", p.buffer.bytes()))
+	}
+
+	stats.Record(p.statsCtx, m.M(1))
+	p.recordBufferStats()
+	return nil
+}
+
+// recordBufferStats records the current state of the buffer. Must be called with the lock held.
+func (p *diskBufferProcessor) recordBufferStats() {
+	stats.Record(p.statsCtx,
+		mBufferBatches.M(int64(p.buffer.len())),
+		mBufferSize.M(p.buffer.bytes()))
+}
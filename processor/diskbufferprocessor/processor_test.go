@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbufferprocessor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+var errUnavailable = errors.New("backend unavailable")
+
+func TestForwardWhenNextAccepts(t *testing.T) {
+	// prepare
+	next := &tracesSink{}
+	p, client := startTracesProcessor(t, testConfig(), next)
+
+	// test
+	require.NoError(t, p.ConsumeTraces(context.Background(), tracesWithName("a")))
+
+	// verify
+	assert.Equal(t, []string{"a"}, next.names())
+	assert.EqualValues(t, 0, p.buffer.len())
+	assert.Empty(t, client.data)
+}
+
+func TestSpillAndReplayInOrder(t *testing.T) {
+	// prepare
+	next := &tracesSink{err: errUnavailable}
+	p, _ := startTracesProcessor(t, testConfig(), next)
+	ctx := context.Background()
+
+	// test
+	require.NoError(t, p.ConsumeTraces(ctx, tracesWithName("a")))
+	next.setErr(nil)
+	// the buffer isn't empty, so new data has to wait for the spilled data
+	require.NoError(t, p.ConsumeTraces(ctx, tracesWithName("b")))
+
+	// verify
+	assert.Empty(t, next.names())
+	assert.EqualValues(t, 2, p.buffer.len())
+
+	// test
+	p.replay(ctx)
+
+	// verify
+	assert.Equal(t, []string{"a", "b"}, next.names())
+	assert.EqualValues(t, 0, p.buffer.len())
+
+	// test
+	require.NoError(t, p.ConsumeTraces(ctx, tracesWithName("c")))
+
+	// verify
+	assert.Equal(t, []string{"a", "b", "c"}, next.names())
+}
+
+func TestReplayStopsWhileNextRefuses(t *testing.T) {
+	// prepare
+	next := &tracesSink{err: errUnavailable}
+	p, _ := startTracesProcessor(t, testConfig(), next)
+	ctx := context.Background()
+	require.NoError(t, p.ConsumeTraces(ctx, tracesWithName("a")))
+
+	// test
+	p.replay(ctx)
+
+	// verify
+	assert.EqualValues(t, 1, p.buffer.len())
+	assert.Empty(t, next.names())
+}
+
+func TestPermanentErrorIsNotSpilled(t *testing.T) {
+	// prepare
+	next := &tracesSink{err: consumererror.NewPermanent(errors.New("invalid data"))}
+	p, _ := startTracesProcessor(t, testConfig(), next)
+
+	// test
+	err := p.ConsumeTraces(context.Background(), tracesWithName("a"))
+
+	// verify
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.EqualValues(t, 0, p.buffer.len())
+}
+
+func TestRefuseAboveHighWatermark(t *testing.T) {
+	// prepare
+	next := &tracesSink{err: errUnavailable}
+	p, _ := startTracesProcessor(t, testConfig(), next)
+	ctx := context.Background()
+
+	td := tracesWithName("a")
+	data, err := tracesMarshaler.MarshalTraces(td)
+	require.NoError(t, err)
+	itemSize := int64(timestampSize + len(data))
+	p.maxSize = 10 * itemSize
+	p.highWatermark = 2 * itemSize
+	p.lowWatermark = itemSize
+
+	// test
+	require.NoError(t, p.ConsumeTraces(ctx, td))
+	require.NoError(t, p.ConsumeTraces(ctx, td))
+	err = p.ConsumeTraces(ctx, td)
+
+	// verify
+	assert.ErrorIs(t, err, errBufferFull)
+	assert.EqualValues(t, 2, p.buffer.len())
+
+	// test: replaying a single batch brings the buffer under the low watermark
+	next.setErr(nil)
+	it, _, err := p.buffer.peek(ctx)
+	require.NoError(t, err)
+	require.NoError(t, p.remove(ctx, it, mBatchesReplayed))
+
+	// verify
+	assert.NoError(t, p.ConsumeTraces(ctx, td))
+}
+
+func TestEvictOldBatches(t *testing.T) {
+	// prepare
+	next := &tracesSink{}
+	cfg := testConfig()
+	cfg.MaxAge = time.Minute
+	p, _ := startTracesProcessor(t, cfg, next)
+	ctx := context.Background()
+
+	data, err := tracesMarshaler.MarshalTraces(tracesWithName("old"))
+	require.NoError(t, err)
+	require.NoError(t, p.buffer.push(ctx, time.Now().Add(-time.Hour), data))
+	data, err = tracesMarshaler.MarshalTraces(tracesWithName("recent"))
+	require.NoError(t, err)
+	require.NoError(t, p.buffer.push(ctx, time.Now(), data))
+
+	// test
+	p.replay(ctx)
+
+	// verify
+	assert.Equal(t, []string{"recent"}, next.names())
+	assert.EqualValues(t, 0, p.buffer.len())
+}
+
+func TestReplaySpilledByPreviousRun(t *testing.T) {
+	// prepare
+	cfg := testConfig()
+	next := &tracesSink{err: errUnavailable}
+	client := newMapClient()
+	host := newStorageHost(*cfg.StorageID, client)
+	p := newTracesProcessor(zap.NewNop(), cfg, next)
+	require.NoError(t, p.Start(context.Background(), host))
+	require.NoError(t, p.ConsumeTraces(context.Background(), tracesWithName("a")))
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.True(t, client.closed)
+
+	// test
+	next.setErr(nil)
+	restarted := newTracesProcessor(zap.NewNop(), cfg, next)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		assert.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return len(next.names()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"a"}, next.names())
+}
+
+func TestStartWithoutStorageExtension(t *testing.T) {
+	p := newTracesProcessor(zap.NewNop(), testConfig(), &tracesSink{})
+	assert.Error(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func testConfig() *Config {
+	storageID := config.NewComponentID("file_storage")
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+	cfg.ReplayInterval = 10 * time.Millisecond
+	return cfg
+}
+
+// startTracesProcessor starts a processor whose replay loop never runs on its own,
+// so that the tests can trigger it explicitly.
+func startTracesProcessor(t *testing.T, cfg *Config, next consumer.Traces) (*tracesProcessor, *mapClient) {
+	cfg.ReplayInterval = time.Hour
+	client := newMapClient()
+	p := newTracesProcessor(zap.NewNop(), cfg, next)
+	require.NoError(t, p.Start(context.Background(), newStorageHost(*cfg.StorageID, client)))
+	t.Cleanup(func() {
+		assert.NoError(t, p.Shutdown(context.Background()))
+	})
+	return p, client
+}
+
+func tracesWithName(name string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	return td
+}
+
+// tracesSink records the name of the first span of the traces it accepts,
+// and refuses all of them while err is set.
+type tracesSink struct {
+	mu       sync.Mutex
+	err      error
+	received []string
+}
+
+func (s *tracesSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (s *tracesSink) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.received = append(s.received, td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	return nil
+}
+
+func (s *tracesSink) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *tracesSink) names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.received...)
+}
+
+type storageHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func newStorageHost(id config.ComponentID, client storage.Client) *storageHost {
+	return &storageHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			id: &storageExtension{client: client},
+		},
+	}
+}
+
+func (h *storageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+type storageExtension struct {
+	client storage.Client
+}
+
+func (e *storageExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *storageExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *storageExtension) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return e.client, nil
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbufferprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/diskbufferprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+var (
+	tracesMarshaler    = ptrace.NewProtoMarshaler()
+	tracesUnmarshaler  = ptrace.NewProtoUnmarshaler()
+	metricsMarshaler   = pmetric.NewProtoMarshaler()
+	metricsUnmarshaler = pmetric.NewProtoUnmarshaler()
+	logsMarshaler      = plog.NewProtoMarshaler()
+	logsUnmarshaler    = plog.NewProtoUnmarshaler()
+)
+
+type tracesProcessor struct {
+	*diskBufferProcessor
+	next consumer.Traces
+}
+
+func newTracesProcessor(logger *zap.Logger, cfg *Config, next consumer.Traces) *tracesProcessor {
+	p := &tracesProcessor{next: next}
+	p.diskBufferProcessor = newDiskBufferProcessor(logger, cfg, config.TracesDataType, p.replayBatch)
+	return p
+}
+
+func (p *tracesProcessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return p.consume(ctx,
+		func(ctx context.Context) error { return p.next.ConsumeTraces(ctx, td) },
+		func() ([]byte, error) { return tracesMarshaler.MarshalTraces(td) })
+}
+
+func (p *tracesProcessor) replayBatch(ctx context.Context, data []byte) error {
+	td, err := tracesUnmarshaler.UnmarshalTraces(data)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return p.next.ConsumeTraces(ctx, td)
+}
+
+type metricsProcessor struct {
+	*diskBufferProcessor
+	next consumer.Metrics
+}
+
+func newMetricsProcessor(logger *zap.Logger, cfg *Config, next consumer.Metrics) *metricsProcessor {
+	p := &metricsProcessor{next: next}
+	p.diskBufferProcessor = newDiskBufferProcessor(logger, cfg, config.MetricsDataType, p.replayBatch)
+	return p
+}
+
+func (p *metricsProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return p.consume(ctx,
+		func(ctx context.Context) error { return p.next.ConsumeMetrics(ctx, md) },
+		func() ([]byte, error) { return metricsMarshaler.MarshalMetrics(md) })
+}
+
+func (p *metricsProcessor) replayBatch(ctx context.Context, data []byte) error {
+	md, err := metricsUnmarshaler.UnmarshalMetrics(data)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return p.next.ConsumeMetrics(ctx, md)
+}
+
+type logsProcessor struct {
+	*diskBufferProcessor
+	next consumer.Logs
+}
+
+func newLogsProcessor(logger *zap.Logger, cfg *Config, next consumer.Logs) *logsProcessor {
+	p := &logsProcessor{next: next}
+	p.diskBufferProcessor = newDiskBufferProcessor(logger, cfg, config.LogsDataType, p.replayBatch)
+	return p
+}
+
+func (p *logsProcessor) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return p.consume(ctx,
+		func(ctx context.Context) error { return p.next.ConsumeLogs(ctx, ld) },
+		func() ([]byte, error) { return logsMarshaler.MarshalLogs(ld) })
+}
+
+func (p *logsProcessor) replayBatch(ctx context.Context, data []byte) error {
+	ld, err := logsUnmarshaler.UnmarshalLogs(data)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return p.next.ConsumeLogs(ctx, ld)
+}
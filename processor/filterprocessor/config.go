@@ -79,6 +79,9 @@ type LogFilters struct {
 	// all other logs should be included.
 	// If both Include and Exclude are specified, Include filtering occurs first.
 	Exclude *LogMatchProperties `mapstructure:"exclude"`
+	// RateLimit keeps all logs at or above a severity and rate limits the logs
+	// with a lower severity. It is applied after Include and Exclude.
+	RateLimit *LogRateLimit `mapstructure:"rate_limit"`
 }
 
 // LogMatchType specifies the strategy for matching against `plog.Log`s.
@@ -249,6 +252,78 @@ func (lmp LogSeverityNumberMatchProperties) validate() error {
 	return lmp.Min.validate()
 }
 
+// LogRateLimit rate limits the log records below a severity, per value of a
+// resource attribute.
+type LogRateLimit struct {
+	// MinSeverity is the lowest severity of the log records that are never rate limited.
+	MinSeverity logSeverity `mapstructure:"min_severity"`
+
+	// GroupBy is the resource attribute whose values get their own rate limits.
+	// Defaults to "service.name".
+	GroupBy string `mapstructure:"group_by"`
+
+	// DroppedCountAttribute is the log record attribute holding the number of
+	// records dropped since the previous record kept within the same limit.
+	// Defaults to "filter.rate_limit.dropped_count".
+	DroppedCountAttribute string `mapstructure:"dropped_count_attribute"`
+
+	// Limits are the rate limits of the severities below MinSeverity. A limit
+	// applies to the log records from its severity up to the severity of the
+	// next limit. Records below all limits are governed by the lowest one.
+	Limits []LogSeverityRateLimit `mapstructure:"limits"`
+}
+
+// LogSeverityRateLimit is the token bucket limiting the rate of log records from a severity.
+type LogSeverityRateLimit struct {
+	// Severity is the lowest severity the limit applies to.
+	Severity logSeverity `mapstructure:"severity"`
+
+	// Rate is the number of log records per second kept.
+	Rate float64 `mapstructure:"rate"`
+
+	// Burst is the number of log records that may be kept at once.
+	// Defaults to Rate, and at least 1.
+	Burst int `mapstructure:"burst"`
+}
+
+// validate checks that the LogRateLimit is valid
+func (lrl LogRateLimit) validate() error {
+	if lrl.MinSeverity == "" {
+		return errors.New("rate_limit: min_severity must be set")
+	}
+	if err := lrl.MinSeverity.validate(); err != nil {
+		return fmt.Errorf("rate_limit: %w", err)
+	}
+	if len(lrl.Limits) == 0 {
+		return errors.New("rate_limit: at least one limit must be set")
+	}
+
+	seen := make(map[plog.SeverityNumber]bool, len(lrl.Limits))
+	for _, limit := range lrl.Limits {
+		if limit.Severity == "" {
+			return errors.New("rate_limit: limit severity must be set")
+		}
+		if err := limit.Severity.validate(); err != nil {
+			return fmt.Errorf("rate_limit: %w", err)
+		}
+		sev := limit.Severity.severityNumber()
+		if sev >= lrl.MinSeverity.severityNumber() {
+			return fmt.Errorf("rate_limit: limit severity '%s' must be lower than min_severity '%s'", limit.Severity, lrl.MinSeverity)
+		}
+		if seen[sev] {
+			return fmt.Errorf("rate_limit: duplicate limit for severity '%s'", limit.Severity)
+		}
+		seen[sev] = true
+		if limit.Rate <= 0 {
+			return fmt.Errorf("rate_limit: rate of severity '%s' must be greater than 0", limit.Severity)
+		}
+		if limit.Burst < 0 {
+			return fmt.Errorf("rate_limit: burst of severity '%s' must not be negative", limit.Severity)
+		}
+	}
+	return nil
+}
+
 var _ config.Processor = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
@@ -263,5 +338,9 @@ func (cfg *Config) Validate() error {
 		err = multierr.Append(err, cfg.Logs.Exclude.validate())
 	}
 
+	if cfg.Logs.RateLimit != nil {
+		err = multierr.Append(err, cfg.Logs.RateLimit.validate())
+	}
+
 	return err
 }
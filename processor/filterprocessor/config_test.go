@@ -504,6 +504,76 @@ func TestLoadingConfigMinSeverityNumberLogs(t *testing.T) {
 	}
 }
 
+// TestLoadingConfigRateLimitLogs tests loading testdata/config_logs_rate_limit.yaml
+func TestLoadingConfigRateLimitLogs(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_logs_rate_limit.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    *Config
+		expectedErr string
+	}{
+		{
+			id: config.NewComponentIDWithName("filter", "rate_limit"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Logs: LogFilters{
+					RateLimit: &LogRateLimit{
+						MinSeverity: logSeverity("WARN"),
+						Limits: []LogSeverityRateLimit{
+							{Severity: logSeverity("DEBUG"), Rate: 10},
+							{Severity: logSeverity("INFO"), Rate: 100, Burst: 500},
+						},
+					},
+				},
+			},
+		}, {
+			id: config.NewComponentIDWithName("filter", "rate_limit_custom"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Logs: LogFilters{
+					RateLimit: &LogRateLimit{
+						MinSeverity:           logSeverity("INFO"),
+						GroupBy:               "k8s.namespace.name",
+						DroppedCountAttribute: "dropped",
+						Limits: []LogSeverityRateLimit{
+							{Severity: logSeverity("TRACE"), Rate: 0.5},
+						},
+					},
+				},
+			},
+		}, {
+			id:          config.NewComponentIDWithName("filter", "rate_limit_above_min"),
+			expectedErr: "rate_limit: limit severity 'WARN' must be lower than min_severity 'INFO'",
+		}, {
+			id:          config.NewComponentIDWithName("filter", "rate_limit_no_limits"),
+			expectedErr: "rate_limit: at least one limit must be set",
+		}, {
+			id:          config.NewComponentIDWithName("filter", "rate_limit_invalid_rate"),
+			expectedErr: "rate_limit: rate of severity 'DEBUG' must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
 // TestLoadingConfigRegexp tests loading testdata/config_regexp.yaml
 func TestLoadingConfigRegexp(t *testing.T) {
 	// list of filters used repeatedly on testdata/config.yaml
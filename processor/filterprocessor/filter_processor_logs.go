@@ -29,6 +29,7 @@ type filterLogProcessor struct {
 	cfg            *Config
 	excludeMatcher filterlog.Matcher
 	includeMatcher filterlog.Matcher
+	rateLimiter    *logRateLimiter
 	logger         *zap.Logger
 }
 
@@ -52,10 +53,16 @@ func newFilterLogsProcessor(logger *zap.Logger, cfg *Config) (*filterLogProcesso
 		}
 	}
 
+	var rateLimiter *logRateLimiter
+	if cfg.Logs.RateLimit != nil {
+		rateLimiter = newLogRateLimiter(cfg.Logs.RateLimit)
+	}
+
 	return &filterLogProcessor{
 		cfg:            cfg,
 		excludeMatcher: excludeMatcher,
 		includeMatcher: includeMatcher,
+		rateLimiter:    rateLimiter,
 		logger:         logger,
 	}, nil
 }
@@ -97,6 +104,11 @@ func (flp *filterLogProcessor) filterLogRecords(rLogs plog.ResourceLogsSlice) {
 					return flp.excludeMatcher.MatchLogRecord(lr, resource, instrumentationScope)
 				})
 			}
+
+			if flp.rateLimiter != nil {
+				// Remove the records exceeding the rate limit of their severity.
+				flp.rateLimiter.limit(resource, lrs)
+			}
 		}
 
 		scopes.RemoveIf(func(sl plog.ScopeLogs) bool {
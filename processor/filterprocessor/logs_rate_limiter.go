@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	defaultRateLimitGroupBy      = "service.name"
+	defaultDroppedCountAttribute = "filter.rate_limit.dropped_count"
+
+	// maxRateLimitBuckets bounds the number of token buckets kept in memory.
+	maxRateLimitBuckets = 10000
+)
+
+type severityRateLimit struct {
+	severity plog.SeverityNumber
+	rate     float64
+	burst    float64
+}
+
+type rateLimitKey struct {
+	group string
+	limit int
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	// dropped is the number of records dropped since the last record kept.
+	dropped int64
+}
+
+// logRateLimiter drops the log records below a severity exceeding the rate
+// limit of their severity, with a token bucket per group and limit.
+type logRateLimiter struct {
+	minSeverity           plog.SeverityNumber
+	limits                []severityRateLimit // sorted by ascending severity
+	groupBy               string
+	droppedCountAttribute string
+	now                   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[rateLimitKey]*tokenBucket
+}
+
+func newLogRateLimiter(cfg *LogRateLimit) *logRateLimiter {
+	limits := make([]severityRateLimit, 0, len(cfg.Limits))
+	for _, limit := range cfg.Limits {
+		burst := float64(limit.Burst)
+		if burst == 0 {
+			burst = math.Max(1, math.Ceil(limit.Rate))
+		}
+		limits = append(limits, severityRateLimit{
+			severity: limit.Severity.severityNumber(),
+			rate:     limit.Rate,
+			burst:    burst,
+		})
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		return limits[i].severity < limits[j].severity
+	})
+
+	rl := &logRateLimiter{
+		minSeverity:           cfg.MinSeverity.severityNumber(),
+		limits:                limits,
+		groupBy:               cfg.GroupBy,
+		droppedCountAttribute: cfg.DroppedCountAttribute,
+		now:                   time.Now,
+		buckets:               map[rateLimitKey]*tokenBucket{},
+	}
+	if rl.groupBy == "" {
+		rl.groupBy = defaultRateLimitGroupBy
+	}
+	if rl.droppedCountAttribute == "" {
+		rl.droppedCountAttribute = defaultDroppedCountAttribute
+	}
+	return rl
+}
+
+// limit removes the records of the resource exceeding their rate limit.
+func (rl *logRateLimiter) limit(resource pcommon.Resource, lrs plog.LogRecordSlice) {
+	var group string
+	if v, ok := resource.Attributes().Get(rl.groupBy); ok {
+		group = v.AsString()
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	lrs.RemoveIf(func(lr plog.LogRecord) bool {
+		return !rl.allow(group, lr, now)
+	})
+}
+
+func (rl *logRateLimiter) allow(group string, lr plog.LogRecord, now time.Time) bool {
+	idx := rl.limitIndex(lr.SeverityNumber())
+	if idx < 0 {
+		return true
+	}
+	limit := rl.limits[idx]
+
+	key := rateLimitKey{group: group, limit: idx}
+	b, ok := rl.buckets[key]
+	if !ok {
+		if len(rl.buckets) >= maxRateLimitBuckets {
+			rl.evict(now)
+		}
+		b = &tokenBucket{tokens: limit.burst, last: now}
+		rl.buckets[key] = b
+	} else {
+		b.tokens = math.Min(limit.burst, b.tokens+now.Sub(b.last).Seconds()*limit.rate)
+		b.last = now
+	}
+
+	if b.tokens < 1 {
+		b.dropped++
+		return false
+	}
+	b.tokens--
+	if b.dropped > 0 {
+		lr.Attributes().PutInt(rl.droppedCountAttribute, b.dropped)
+		b.dropped = 0
+	}
+	return true
+}
+
+// limitIndex returns the index of the limit governing the severity, or -1 if
+// records with this severity are not rate limited.
+func (rl *logRateLimiter) limitIndex(sev plog.SeverityNumber) int {
+	if sev == plog.SeverityNumberUndefined || sev >= rl.minSeverity {
+		return -1
+	}
+	idx := 0
+	for i, limit := range rl.limits {
+		if limit.severity <= sev {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// evict removes the buckets that are full again and have no pending dropped
+// count, as they behave like new ones. All buckets are reset if none is idle.
+func (rl *logRateLimiter) evict(now time.Time) {
+	for key, b := range rl.buckets {
+		limit := rl.limits[key.limit]
+		if b.dropped == 0 && b.tokens+now.Sub(b.last).Seconds()*limit.rate >= limit.burst {
+			delete(rl.buckets, key)
+		}
+	}
+	if len(rl.buckets) >= maxRateLimitBuckets {
+		rl.buckets = map[rateLimitKey]*tokenBucket{}
+	}
+}
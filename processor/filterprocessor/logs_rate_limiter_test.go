@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func newRateLimitedLogs(service string, severities ...plog.SeverityNumber) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	if service != "" {
+		rl.Resource().Attributes().PutStr("service.name", service)
+	}
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, sev := range severities {
+		lrs.AppendEmpty().SetSeverityNumber(sev)
+	}
+	return ld
+}
+
+func limitLogs(rl *logRateLimiter, ld plog.Logs) plog.LogRecordSlice {
+	r := ld.ResourceLogs().At(0)
+	lrs := r.ScopeLogs().At(0).LogRecords()
+	rl.limit(r.Resource(), lrs)
+	return lrs
+}
+
+func severities(lrs plog.LogRecordSlice) []plog.SeverityNumber {
+	var sevs []plog.SeverityNumber
+	for i := 0; i < lrs.Len(); i++ {
+		sevs = append(sevs, lrs.At(i).SeverityNumber())
+	}
+	return sevs
+}
+
+func TestLogRateLimiter(t *testing.T) {
+	rl := newLogRateLimiter(&LogRateLimit{
+		MinSeverity: logSeverity("WARN"),
+		Limits: []LogSeverityRateLimit{
+			{Severity: logSeverity("INFO"), Rate: 2},
+			{Severity: logSeverity("DEBUG"), Rate: 1},
+		},
+	})
+	now := time.Unix(1000, 0)
+	rl.now = func() time.Time { return now }
+
+	debug, info, warn := plog.SeverityNumberDebug, plog.SeverityNumberInfo2, plog.SeverityNumberWarn
+	trace, undefined := plog.SeverityNumberTrace, plog.SeverityNumberUndefined
+
+	// TRACE records share the DEBUG limit, WARN and undefined records are never limited.
+	got := limitLogs(rl, newRateLimitedLogs("svc", debug, trace, info, info, info, warn, warn, undefined))
+	assert.Equal(t, []plog.SeverityNumber{debug, info, info, warn, warn, undefined}, severities(got))
+
+	// Other services have their own limits.
+	got = limitLogs(rl, newRateLimitedLogs("other", debug, debug))
+	assert.Equal(t, []plog.SeverityNumber{debug}, severities(got))
+
+	// After one second, the buckets are refilled and the first record kept
+	// carries the number of records dropped before it.
+	now = now.Add(time.Second)
+	got = limitLogs(rl, newRateLimitedLogs("svc", debug, info, info))
+	require.Equal(t, []plog.SeverityNumber{debug, info, info}, severities(got))
+
+	dropped, ok := got.At(0).Attributes().Get(defaultDroppedCountAttribute)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), dropped.Int())
+	dropped, ok = got.At(1).Attributes().Get(defaultDroppedCountAttribute)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), dropped.Int())
+	_, ok = got.At(2).Attributes().Get(defaultDroppedCountAttribute)
+	assert.False(t, ok)
+}
+
+func TestLogRateLimiterGroupBy(t *testing.T) {
+	rl := newLogRateLimiter(&LogRateLimit{
+		MinSeverity:           logSeverity("INFO"),
+		GroupBy:               "k8s.namespace.name",
+		DroppedCountAttribute: "dropped",
+		Limits: []LogSeverityRateLimit{
+			{Severity: logSeverity("DEBUG"), Rate: 0.5, Burst: 2},
+		},
+	})
+	now := time.Unix(1000, 0)
+	rl.now = func() time.Time { return now }
+
+	debug := plog.SeverityNumberDebug
+
+	// Records without the group attribute share a single limit.
+	got := limitLogs(rl, newRateLimitedLogs("a", debug, debug, debug))
+	assert.Equal(t, 2, got.Len())
+	got = limitLogs(rl, newRateLimitedLogs("b", debug))
+	assert.Equal(t, 0, got.Len())
+
+	ld := newRateLimitedLogs("", debug, debug, debug)
+	ld.ResourceLogs().At(0).Resource().Attributes().PutStr("k8s.namespace.name", "default")
+	got = limitLogs(rl, ld)
+	assert.Equal(t, 2, got.Len())
+
+	// Half a token per second: a single record is kept after two seconds.
+	now = now.Add(2 * time.Second)
+	got = limitLogs(rl, newRateLimitedLogs("c", debug, debug))
+	require.Equal(t, 1, got.Len())
+	dropped, ok := got.At(0).Attributes().Get("dropped")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), dropped.Int())
+}
+
+func TestLogRateLimiterEvict(t *testing.T) {
+	rl := newLogRateLimiter(&LogRateLimit{
+		MinSeverity: logSeverity("INFO"),
+		Limits: []LogSeverityRateLimit{
+			{Severity: logSeverity("DEBUG"), Rate: 1},
+		},
+	})
+	now := time.Unix(1000, 0)
+	rl.now = func() time.Time { return now }
+
+	for i := 0; i < maxRateLimitBuckets; i++ {
+		rl.buckets[rateLimitKey{group: strconv.Itoa(i)}] = &tokenBucket{last: now}
+	}
+	// A bucket with dropped records is kept while idle buckets are evicted.
+	rl.buckets[rateLimitKey{group: "0"}].dropped = 1
+	now = now.Add(time.Second)
+
+	got := limitLogs(rl, newRateLimitedLogs("svc", plog.SeverityNumberDebug))
+	assert.Equal(t, 1, got.Len())
+	assert.Len(t, rl.buckets, 2)
+}
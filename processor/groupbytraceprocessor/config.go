@@ -15,6 +15,7 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -47,4 +48,40 @@ type Config struct {
 	// Default: false.
 	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// StorageID is the ID of the storage extension to spill the traces to once the number of spans held
+	// in memory goes over MaxSpansInMemory.
+	// Default: nil, the traces are kept in memory only.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+
+	// MaxSpansInMemory is the max number of spans to keep in memory before spilling the traces
+	// receiving new spans to the storage extension. Required when a storage is configured.
+	MaxSpansInMemory int `mapstructure:"max_spans_in_memory"`
+
+	// RoutingHint configures the resource attribute describing where the trace was grouped.
+	RoutingHint RoutingHintConfig `mapstructure:"routing_hint"`
+}
+
+// RoutingHintConfig defines the resource attribute added to the released traces, so that the shard affinity
+// of the traces routed by a loadbalancing exporter in front of this processor can be verified.
+type RoutingHintConfig struct {
+	// Attribute is the name of the resource attribute holding the routing hint, in the form
+	// "<instance>/<position>", where position is the position of the trace ID in the ring of the
+	// loadbalancing exporter.
+	// Default: "", the routing hint isn't added.
+	Attribute string `mapstructure:"attribute"`
+
+	// Instance is the name of this instance in the routing hint.
+	// Default: the hostname.
+	Instance string `mapstructure:"instance"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.StorageID != nil && cfg.MaxSpansInMemory <= 0 {
+		return errors.New("max_spans_in_memory must be positive when a storage is configured")
+	}
+	return nil
 }
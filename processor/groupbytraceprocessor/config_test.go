@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	storageID := config.NewComponentIDWithName("file_storage", "groupbytrace")
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Processor
+		expectedErr string
+	}{
+		{
+			id: config.NewComponentIDWithName(typeStr, "custom"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				NumTraces:         1000,
+				NumWorkers:        defaultNumWorkers,
+				WaitDuration:      10 * time.Second,
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "spill"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				NumTraces:         defaultNumTraces,
+				NumWorkers:        defaultNumWorkers,
+				WaitDuration:      30 * time.Second,
+				StorageID:         &storageID,
+				MaxSpansInMemory:  100_000,
+				RoutingHint: RoutingHintConfig{
+					Attribute: "groupbytrace.instance",
+					Instance:  "collector-1",
+				},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "missing_max_spans"),
+			expectedErr: "max_spans_in_memory must be positive when a storage is configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
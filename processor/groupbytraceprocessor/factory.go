@@ -17,6 +17,7 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"go.opencensus.io/stats/view"
@@ -85,8 +86,17 @@ func createTracesProcessor(
 		return nil, errDiscardOrphansNotSupported
 	}
 
-	// the only supported storage for now
+	// the traces are spilled to the storage extension, if any, when the processor starts
 	st = newMemoryStorage()
 
-	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, *oCfg), nil
+	pCfg := *oCfg
+	if pCfg.RoutingHint.Attribute != "" && pCfg.RoutingHint.Instance == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't determine the instance of the routing hint: %w", err)
+		}
+		pCfg.RoutingHint.Instance = hostname
+	}
+
+	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, pCfg), nil
 }
@@ -30,6 +30,7 @@ var (
 	mReleasedTraces     = stats.Int64("processor_groupbytrace_traces_released", "Traces released to the next consumer", stats.UnitDimensionless)
 	mIncompleteReleases = stats.Int64("processor_groupbytrace_incomplete_releases", "Releases that are suspected to have been incomplete", stats.UnitDimensionless)
 	mEventLatency       = stats.Int64("processor_groupbytrace_event_latency", "How long the queue events are taking to be processed", stats.UnitMilliseconds)
+	mTracesSpilled      = stats.Int64("processor_groupbytrace_traces_spilled", "Traces spilled to the storage extension", stats.UnitDimensionless)
 )
 
 // MetricViews return the metrics views according to given telemetry level.
@@ -87,5 +88,11 @@ func MetricViews() []*view.View {
 			},
 			Aggregation: view.Distribution(0, 5, 10, 20, 50, 100, 200, 500, 1000),
 		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mTracesSpilled.Name()),
+			Measure:     mTracesSpilled,
+			Description: mTracesSpilled.Description(),
+			Aggregation: view.Sum(),
+		},
 	}
 }
@@ -17,6 +17,7 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 import (
 	"context"
 	"fmt"
+	"hash/crc32"
 	"time"
 
 	"go.opencensus.io/stats"
@@ -58,6 +59,9 @@ var _ component.TracesProcessor = (*groupByTraceProcessor)(nil)
 
 const bufferSize = 10_000
 
+// maxPositions is the number of positions in the ring of the loadbalancing exporter
+const maxPositions uint32 = 36000
+
 // newGroupByTraceProcessor returns a new processor.
 func newGroupByTraceProcessor(logger *zap.Logger, st storage, nextConsumer consumer.Traces, config Config) *groupByTraceProcessor {
 	// the event machine will buffer up to N concurrent events before blocking
@@ -93,7 +97,15 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
+	if sp.config.StorageID != nil {
+		client, err := getStorageClient(ctx, host, *sp.config.StorageID, sp.config.ID())
+		if err != nil {
+			return err
+		}
+		sp.st = newSpillStorage(sp.st, client, sp.config.MaxSpansInMemory)
+	}
+
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
@@ -217,6 +229,9 @@ func (sp *groupByTraceProcessor) onTraceReleased(rss []ptrace.ResourceSpans) err
 		trs := trace.ResourceSpans().AppendEmpty()
 		rs.CopyTo(trs)
 	}
+	if sp.config.RoutingHint.Attribute != "" {
+		sp.addRoutingHint(trace)
+	}
 	stats.Record(context.Background(),
 		mReleasedSpans.M(int64(trace.SpanCount())),
 		mReleasedTraces.M(1),
@@ -248,3 +263,17 @@ func (sp *groupByTraceProcessor) addSpans(traceID pcommon.TraceID, trace ptrace.
 	sp.logger.Debug("creating trace at the storage", zap.String("traceID", traceID.HexString()))
 	return sp.st.createOrAppend(traceID, trace)
 }
+
+// addRoutingHint adds the instance grouping the trace and the position of the trace in the ring of
+// the loadbalancing exporter to the resources of the trace.
+func (sp *groupByTraceProcessor) addRoutingHint(trace ptrace.Traces) {
+	rss := trace.ResourceSpans()
+	if rss.Len() == 0 || rss.At(0).ScopeSpans().Len() == 0 || rss.At(0).ScopeSpans().At(0).Spans().Len() == 0 {
+		return
+	}
+	traceID := rss.At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
+	hint := fmt.Sprintf("%s/%d", sp.config.RoutingHint.Instance, crc32.ChecksumIEEE(traceID[:])%maxPositions)
+	for i := 0; i < rss.Len(); i++ {
+		rss.At(i).Resource().Attributes().PutStr(sp.config.RoutingHint.Attribute, hint)
+	}
+}
@@ -17,6 +17,8 @@ package groupbytraceprocessor
 import (
 	"context"
 	"errors"
+	"fmt"
+	"hash/crc32"
 	"sync"
 	"testing"
 	"time"
@@ -29,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 )
 
@@ -555,6 +558,102 @@ func TestAsyncOnRelease(t *testing.T) {
 	close(blockCh)
 }
 
+func TestRoutingHint(t *testing.T) {
+	// prepare
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	config := Config{
+		WaitDuration: time.Nanosecond,
+		NumTraces:    10,
+		NumWorkers:   1,
+		RoutingHint: RoutingHintConfig{
+			Attribute: "groupbytrace.instance",
+			Instance:  "collector-1",
+		},
+	}
+
+	wg := &sync.WaitGroup{}
+	var received ptrace.Traces
+	mockProcessor := &mockProcessor{
+		onTraces: func(ctx context.Context, td ptrace.Traces) error {
+			received = td
+			wg.Done()
+			return nil
+		},
+	}
+
+	p := newGroupByTraceProcessor(zap.NewNop(), newMemoryStorage(), mockProcessor, config)
+	ctx := context.Background()
+	assert.NoError(t, p.Start(ctx, nil))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// test
+	wg.Add(1)
+	assert.NoError(t, p.ConsumeTraces(ctx, simpleTracesWithID(traceID)))
+	wg.Wait()
+
+	// verify
+	hint, ok := received.ResourceSpans().At(0).Resource().Attributes().Get("groupbytrace.instance")
+	require.True(t, ok)
+	assert.Equal(t, fmt.Sprintf("collector-1/%d", crc32.ChecksumIEEE(traceID[:])%maxPositions), hint.Str())
+}
+
+func TestTraceIsSpilledToStorage(t *testing.T) {
+	// prepare
+	storageID := storagetest.NewStorageID("test")
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("test")
+	config := Config{
+		WaitDuration:     50 * time.Millisecond,
+		NumTraces:        10,
+		NumWorkers:       1,
+		StorageID:        &storageID,
+		MaxSpansInMemory: 1,
+	}
+
+	wg := &sync.WaitGroup{}
+	var received ptrace.Traces
+	mockProcessor := &mockProcessor{
+		onTraces: func(ctx context.Context, td ptrace.Traces) error {
+			received = td
+			wg.Done()
+			return nil
+		},
+	}
+
+	p := newGroupByTraceProcessor(zap.NewNop(), newMemoryStorage(), mockProcessor, config)
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx, host))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// test
+	wg.Add(1)
+	assert.NoError(t, p.ConsumeTraces(ctx, simpleTraces()))
+	assert.NoError(t, p.ConsumeTraces(ctx, simpleTraces()))
+	wg.Wait()
+
+	// verify
+	assert.Equal(t, 2, received.SpanCount())
+}
+
+func TestMissingStorage(t *testing.T) {
+	// prepare
+	storageID := storagetest.NewStorageID("missing")
+	config := Config{
+		StorageID:        &storageID,
+		MaxSpansInMemory: 1,
+	}
+	p := newGroupByTraceProcessor(zap.NewNop(), newMemoryStorage(), &mockProcessor{}, config)
+
+	// test
+	err := p.Start(context.Background(), storagetest.NewStorageHost())
+
+	// verify
+	assert.EqualError(t, err, "storage extension 'test_storage/missing' not found")
+}
+
 func BenchmarkConsumeTracesCompleteOnFirstBatch(b *testing.B) {
 	// prepare
 	config := Config{
@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	storageextension "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+)
+
+// spillStorage keeps the traces in the wrapped storage until the number of spans held there goes over
+// the max, at which point the traces receiving new spans are moved to the storage extension. Traces
+// that were spilled stay in the storage extension until they are deleted.
+type spillStorage struct {
+	sync.Mutex
+	memory   storage
+	client   storageextension.Client
+	maxSpans int
+
+	// the number of spans held in memory, per trace and in total
+	spans      map[pcommon.TraceID]int
+	totalSpans int
+
+	// the traces held by the storage extension
+	spilled map[pcommon.TraceID]struct{}
+
+	marshaler   ptrace.Marshaler
+	unmarshaler ptrace.Unmarshaler
+}
+
+var _ storage = (*spillStorage)(nil)
+
+func newSpillStorage(memory storage, client storageextension.Client, maxSpans int) *spillStorage {
+	return &spillStorage{
+		memory:      memory,
+		client:      client,
+		maxSpans:    maxSpans,
+		spans:       make(map[pcommon.TraceID]int),
+		spilled:     make(map[pcommon.TraceID]struct{}),
+		marshaler:   ptrace.NewProtoMarshaler(),
+		unmarshaler: ptrace.NewProtoUnmarshaler(),
+	}
+}
+
+func (st *spillStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	st.Lock()
+	defer st.Unlock()
+
+	if _, ok := st.spilled[traceID]; ok {
+		return st.appendToStorage(traceID, td)
+	}
+
+	count := td.SpanCount()
+	if st.totalSpans+count <= st.maxSpans {
+		if err := st.memory.createOrAppend(traceID, td); err != nil {
+			return err
+		}
+		st.spans[traceID] += count
+		st.totalSpans += count
+		return nil
+	}
+
+	// memory is under pressure: move the trace, including the spans received so far, to the storage
+	rss, err := st.memory.delete(traceID)
+	if err != nil {
+		return err
+	}
+	st.totalSpans -= st.spans[traceID]
+	delete(st.spans, traceID)
+
+	trace := ptrace.NewTraces()
+	for _, rs := range rss {
+		rs.CopyTo(trace.ResourceSpans().AppendEmpty())
+	}
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		td.ResourceSpans().At(i).CopyTo(trace.ResourceSpans().AppendEmpty())
+	}
+	if err = st.write(traceID, trace); err != nil {
+		return err
+	}
+	st.spilled[traceID] = struct{}{}
+	stats.Record(context.Background(), mTracesSpilled.M(1))
+	return nil
+}
+
+func (st *spillStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	_, ok := st.spilled[traceID]
+	st.Unlock()
+	if !ok {
+		return st.memory.get(traceID)
+	}
+
+	trace, err := st.read(traceID)
+	if err != nil || trace == nil {
+		return nil, err
+	}
+	return resourceSpans(trace), nil
+}
+
+func (st *spillStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	if _, ok := st.spilled[traceID]; !ok {
+		st.totalSpans -= st.spans[traceID]
+		delete(st.spans, traceID)
+		return st.memory.delete(traceID)
+	}
+
+	trace, err := st.read(traceID)
+	if err != nil {
+		return nil, err
+	}
+	if err = st.client.Delete(context.Background(), storageKey(traceID)); err != nil {
+		return nil, fmt.Errorf("couldn't delete the spilled trace: %w", err)
+	}
+	delete(st.spilled, traceID)
+	if trace == nil {
+		return nil, nil
+	}
+	return resourceSpans(trace), nil
+}
+
+func (st *spillStorage) start() error {
+	return st.memory.start()
+}
+
+func (st *spillStorage) shutdown() error {
+	return multierr.Append(st.memory.shutdown(), st.client.Close(context.Background()))
+}
+
+// appendToStorage adds the given spans to the trace held by the storage extension, and must be called
+// with the lock held.
+func (st *spillStorage) appendToStorage(traceID pcommon.TraceID, td ptrace.Traces) error {
+	stored, err := st.read(traceID)
+	if err != nil {
+		return err
+	}
+	trace := ptrace.NewTraces()
+	if stored != nil {
+		trace = *stored
+	}
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		td.ResourceSpans().At(i).CopyTo(trace.ResourceSpans().AppendEmpty())
+	}
+	return st.write(traceID, trace)
+}
+
+func (st *spillStorage) read(traceID pcommon.TraceID) (*ptrace.Traces, error) {
+	buf, err := st.client.Get(context.Background(), storageKey(traceID))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the spilled trace: %w", err)
+	}
+	if buf == nil {
+		return nil, nil
+	}
+	trace, err := st.unmarshaler.UnmarshalTraces(buf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal the spilled trace: %w", err)
+	}
+	return &trace, nil
+}
+
+func (st *spillStorage) write(traceID pcommon.TraceID, trace ptrace.Traces) error {
+	buf, err := st.marshaler.MarshalTraces(trace)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal the trace to spill: %w", err)
+	}
+	if err = st.client.Set(context.Background(), storageKey(traceID), buf); err != nil {
+		return fmt.Errorf("couldn't spill the trace: %w", err)
+	}
+	return nil
+}
+
+func storageKey(traceID pcommon.TraceID) string {
+	return traceID.HexString()
+}
+
+func resourceSpans(trace *ptrace.Traces) []ptrace.ResourceSpans {
+	result := make([]ptrace.ResourceSpans, 0, trace.ResourceSpans().Len())
+	for i := 0; i < trace.ResourceSpans().Len(); i++ {
+		result = append(result, trace.ResourceSpans().At(i))
+	}
+	return result
+}
+
+// getStorageClient returns a client of the configured storage extension.
+func getStorageClient(ctx context.Context, host component.Host, storageID config.ComponentID, componentID config.ComponentID) (storageextension.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storageextension.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
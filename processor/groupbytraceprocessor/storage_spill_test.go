@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestSpillStorage(t *testing.T) {
+	// prepare
+	client := storagetest.NewInMemoryClient(component.KindProcessor, storagetest.NewStorageID("test"), "")
+	st := newSpillStorage(newMemoryStorage(), client, 2)
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	otherTraceID := pcommon.TraceID([16]byte{2, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.createOrAppend(otherTraceID, simpleTracesWithID(otherTraceID)))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// verify
+	assert.Contains(t, st.spilled, traceID)
+	assert.NotContains(t, st.spilled, otherTraceID)
+	assert.Equal(t, 1, st.totalSpans)
+
+	rss, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Len(t, rss, 3)
+
+	rss, err = st.delete(traceID)
+	require.NoError(t, err)
+	assert.Len(t, rss, 3)
+	assert.NotContains(t, st.spilled, traceID)
+
+	buf, err := client.Get(context.Background(), storageKey(traceID))
+	require.NoError(t, err)
+	assert.Nil(t, buf)
+
+	rss, err = st.delete(otherTraceID)
+	require.NoError(t, err)
+	assert.Len(t, rss, 1)
+	assert.Equal(t, 0, st.totalSpans)
+
+	require.NoError(t, st.shutdown())
+}
+
+func TestSpillStorageGetMissingTrace(t *testing.T) {
+	// prepare
+	client := storagetest.NewInMemoryClient(component.KindProcessor, storagetest.NewStorageID("test"), "")
+	st := newSpillStorage(newMemoryStorage(), client, 1)
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	// test
+	rss, err := st.get(traceID)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Nil(t, rss)
+}
@@ -15,6 +15,7 @@
 package k8sattributesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor"
 
 import (
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/collector/config"
@@ -50,6 +51,12 @@ type Config struct {
 	// Exclude section allows to define names of pod that should be
 	// ignored while tagging.
 	Exclude ExcludeConfig `mapstructure:"exclude"`
+
+	// Clusters section allows to enrich telemetry from several clusters,
+	// each one reached through a context of the kubeconfig file. Telemetry is
+	// matched to a cluster by its k8s.cluster.name resource attribute.
+	// It requires auth_type to be kubeConfig.
+	Clusters []ClusterConfig `mapstructure:"clusters"`
 }
 
 func (cfg *Config) Validate() error {
@@ -61,6 +68,34 @@ func (cfg *Config) Validate() error {
 		if len(assoc.Sources) > kube.PodIdentifierMaxLength {
 			return fmt.Errorf("too many association sources. limit is %v", kube.PodIdentifierMaxLength)
 		}
+		if assoc.Expression != "" {
+			if assoc.From != "" || len(assoc.Sources) > 0 {
+				return errors.New("pod association expression cannot be used together with sources")
+			}
+			if _, err := parsePodAssociationExpression(assoc.Expression); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(cfg.Clusters) > 0 {
+		if cfg.AuthType != k8sconfig.AuthTypeKubeConfig {
+			return fmt.Errorf("clusters can only be used with auth_type %s", k8sconfig.AuthTypeKubeConfig)
+		}
+		if cfg.Passthrough {
+			return errors.New("clusters cannot be used in passthrough mode")
+		}
+		names := map[string]bool{}
+		for _, cluster := range cfg.Clusters {
+			if cluster.Context == "" {
+				return errors.New("cluster context must be specified")
+			}
+			name := cluster.name()
+			if names[name] {
+				return fmt.Errorf("duplicate cluster name %q", name)
+			}
+			names[name] = true
+		}
 	}
 
 	return nil
@@ -250,6 +285,14 @@ type PodAssociationConfig struct {
 	// List of pod association sources which should be taken
 	// to identify pod
 	Sources []PodAssociationSourceConfig `mapstructure:"sources"`
+
+	// Expression identifies the pod with resource attributes and the connection
+	// IP, combined with "and", with fallbacks separated by "or", e.g.
+	//
+	//   resource.attributes["k8s.pod.name"] and resource.attributes["k8s.namespace.name"] or connection
+	//
+	// "and" has precedence over "or". It cannot be used together with Sources.
+	Expression string `mapstructure:"expression"`
 }
 
 // ExcludeConfig represent a list of Pods to exclude
@@ -271,3 +314,20 @@ type PodAssociationSourceConfig struct {
 	// e.g. ip, pod_uid, k8s.pod.ip
 	Name string `mapstructure:"name"`
 }
+
+// ClusterConfig represents a Kubernetes cluster reached through a kubeconfig context.
+type ClusterConfig struct {
+	// Name is the value of the k8s.cluster.name resource attribute of the
+	// telemetry coming from the cluster. The context name is used if empty.
+	Name string `mapstructure:"name"`
+
+	// Context is the name of the kubeconfig context used to connect to the cluster.
+	Context string `mapstructure:"context"`
+}
+
+func (c ClusterConfig) name() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.Context
+}
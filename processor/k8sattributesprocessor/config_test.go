@@ -141,6 +141,28 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "multi_cluster"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				APIConfig:         k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+				Association: []PodAssociationConfig{
+					{
+						Expression: `resource.attributes["k8s.pod.name"] and resource.attributes["k8s.namespace.name"] or connection`,
+					},
+				},
+				Exclude: ExcludeConfig{
+					Pods: []ExcludePodConfig{
+						{Name: "jaeger-agent"},
+						{Name: "jaeger-collector"},
+					},
+				},
+				Clusters: []ClusterConfig{
+					{Name: "prod-us", Context: "arn:aws:eks:us-east-1:123456789012:cluster/prod-us"},
+					{Context: "prod-eu"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -160,3 +182,65 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		errMsg string
+	}{
+		{
+			name: "expression with sources",
+			cfg: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Association: []PodAssociationConfig{
+					{
+						Expression: "connection",
+						Sources:    []PodAssociationSourceConfig{{From: "connection"}},
+					},
+				},
+			},
+			errMsg: "pod association expression cannot be used together with sources",
+		},
+		{
+			name: "invalid expression",
+			cfg: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Association: []PodAssociationConfig{
+					{Expression: `resource.attributes["k8s.pod.name"] and attributes["foo"]`},
+				},
+			},
+			errMsg: `invalid pod association expression "resource.attributes[\"k8s.pod.name\"] and attributes[\"foo\"]": ` +
+				`unexpected "attributes[\"foo\"]", must be connection or resource.attributes["<name>"]`,
+		},
+		{
+			name: "clusters without kubeconfig",
+			cfg: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Clusters:  []ClusterConfig{{Context: "a"}},
+			},
+			errMsg: "clusters can only be used with auth_type kubeConfig",
+		},
+		{
+			name: "cluster without context",
+			cfg: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+				Clusters:  []ClusterConfig{{Name: "a"}},
+			},
+			errMsg: "cluster context must be specified",
+		},
+		{
+			name: "duplicate cluster name",
+			cfg: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+				Clusters:  []ClusterConfig{{Context: "a"}, {Name: "a", Context: "b"}},
+			},
+			errMsg: `duplicate cluster name "a"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.errMsg)
+		})
+	}
+}
@@ -43,6 +43,14 @@
 //   - from: resource_attribute
 //     name: k8s.namespace.name
 //
+// An association can also be written as an expression over resource attributes and the connection IP.
+// Sources are combined with "and", and fallbacks are separated by "or", which has a lower precedence.
+// The following association is equivalent to the pair `k8s.pod.name` and `k8s.namespace.name` with a fallback
+// on the connection IP:
+//
+//	pod_association:
+//	  - expression: resource.attributes["k8s.pod.name"] and resource.attributes["k8s.namespace.name"] or connection
+//
 // If Pod association rules are not configured resources are associated with metadata only by connection's IP Address.
 //
 // Which metadata to collect is determined by `metadata` configuration that defines list of resource attributes
@@ -222,6 +230,19 @@
 // No special configuration changes are needed to be made on the collector. It'll automatically detect
 // the IP address of spans, logs and metrics sent by the agents as well as directly by other services/pods.
 //
+// # Multiple clusters
+//
+// A central gateway can enrich the telemetry of several clusters by connecting to each of them
+// through a context of the kubeconfig file. Telemetry is matched to a cluster by its `k8s.cluster.name`
+// resource attribute, which defaults to the context name. Telemetry without a known `k8s.cluster.name` is not enriched.
+//
+//	k8sattributes:
+//	  auth_type: kubeConfig
+//	  clusters:
+//	    - context: arn:aws:eks:us-east-1:123456789012:cluster/prod-us
+//	      name: prod-us
+//	    - context: prod-eu
+//
 // # Caveats
 //
 // There are some edge-cases and scenarios where k8sattributes will not work properly.
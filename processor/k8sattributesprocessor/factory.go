@@ -179,7 +179,7 @@ func createKubernetesProcessor(
 	}
 
 	// This might have been set by an option already
-	if kp.kc == nil {
+	if kp.kc == nil && kp.clusterClients == nil {
 		err := kp.initKubeClient(kp.logger, kubeClientProvider)
 		if err != nil {
 			return nil, err
@@ -212,6 +212,8 @@ func createProcessorOpts(cfg config.Processor) []option {
 
 	opts = append(opts, withExcludes(oCfg.Exclude))
 
+	opts = append(opts, withClusters(oCfg.Clusters...))
+
 	return opts
 }
 
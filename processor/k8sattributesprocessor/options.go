@@ -304,6 +304,14 @@ func withExtractPodAssociations(podAssociations ...PodAssociationConfig) option
 		associations := make([]kube.Association, 0, len(podAssociations))
 		var assoc kube.Association
 		for _, association := range podAssociations {
+			if association.Expression != "" {
+				exprAssociations, err := parsePodAssociationExpression(association.Expression)
+				if err != nil {
+					return err
+				}
+				associations = append(associations, exprAssociations...)
+				continue
+			}
 			assoc = kube.Association{
 				Sources: []kube.AssociationSource{},
 			}
@@ -340,6 +348,14 @@ func withExtractPodAssociations(podAssociations ...PodAssociationConfig) option
 	}
 }
 
+// withClusters allows specifying the clusters to enrich telemetry from
+func withClusters(clusters ...ClusterConfig) option {
+	return func(p *kubernetesprocessor) error {
+		p.clusters = clusters
+		return nil
+	}
+}
+
 // withExcludes allows specifying pods to exclude
 func withExcludes(podExclude ExcludeConfig) option {
 	return func(p *kubernetesprocessor) error {
@@ -792,6 +792,51 @@ func TestWithExtractPodAssociation(t *testing.T) {
 				},
 			},
 		},
+		{
+			"expression",
+			[]PodAssociationConfig{
+				{
+					Expression: `resource.attributes["k8s.pod.name"] and resource.attributes["k8s.namespace.name"] or connection`,
+				},
+				{
+					Sources: []PodAssociationSourceConfig{
+						{
+							From: "resource_attribute",
+							Name: "k8s.pod.uid",
+						},
+					},
+				},
+			},
+			[]kube.Association{
+				{
+					Sources: []kube.AssociationSource{
+						{
+							From: "resource_attribute",
+							Name: "k8s.pod.name",
+						},
+						{
+							From: "resource_attribute",
+							Name: "k8s.namespace.name",
+						},
+					},
+				},
+				{
+					Sources: []kube.AssociationSource{
+						{
+							From: "connection",
+						},
+					},
+				},
+				{
+					Sources: []kube.AssociationSource{
+						{
+							From: "resource_attribute",
+							Name: "k8s.pod.uid",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
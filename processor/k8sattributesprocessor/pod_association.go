@@ -16,7 +16,9 @@ package k8sattributesprocessor // import "github.com/open-telemetry/opentelemetr
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"regexp"
 	"strings"
 
 	"go.opentelemetry.io/collector/client"
@@ -26,6 +28,39 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
 )
 
+var (
+	podAssociationOrRegexp            = regexp.MustCompile(`\s+or\s+`)
+	podAssociationAndRegexp           = regexp.MustCompile(`\s+and\s+`)
+	podAssociationResourceAttrsRegexp = regexp.MustCompile(`^resource\.attributes\["([^"]+)"\]$`)
+)
+
+// parsePodAssociationExpression parses a pod association expression into a
+// list of associations, one per fallback, in order of precedence.
+func parsePodAssociationExpression(expression string) ([]kube.Association, error) {
+	var associations []kube.Association
+	for _, alternative := range podAssociationOrRegexp.Split(strings.TrimSpace(expression), -1) {
+		terms := podAssociationAndRegexp.Split(alternative, -1)
+		if len(terms) > kube.PodIdentifierMaxLength {
+			return nil, fmt.Errorf("too many association sources in expression %q. limit is %v", alternative, kube.PodIdentifierMaxLength)
+		}
+		assoc := kube.Association{Sources: make([]kube.AssociationSource, 0, len(terms))}
+		for _, term := range terms {
+			if term == kube.ConnectionSource {
+				assoc.Sources = append(assoc.Sources, kube.AssociationSource{From: kube.ConnectionSource})
+				continue
+			}
+			match := podAssociationResourceAttrsRegexp.FindStringSubmatch(term)
+			if match == nil {
+				return nil, fmt.Errorf("invalid pod association expression %q: unexpected %q, must be "+
+					"connection or resource.attributes[\"<name>\"]", expression, term)
+			}
+			assoc.Sources = append(assoc.Sources, kube.AssociationSource{From: kube.ResourceSource, Name: match[1]})
+		}
+		associations = append(associations, assoc)
+	}
+	return associations, nil
+}
+
 // extractPodIds returns pod identifier for first association matching all sources
 func extractPodID(ctx context.Context, attrs pcommon.Map, associations []kube.Association) kube.PodIdentifier {
 	// If pod association is not set
@@ -44,36 +44,80 @@ type kubernetesprocessor struct {
 	filters         kube.Filters
 	podAssociations []kube.Association
 	podIgnore       kube.Excludes
+	clusters        []ClusterConfig
+	// clusterClients holds a client per cluster name when several clusters are configured.
+	clusterClients map[string]kube.Client
 }
 
 func (kp *kubernetesprocessor) initKubeClient(logger *zap.Logger, kubeClient kube.ClientProvider) error {
 	if kubeClient == nil {
 		kubeClient = kube.New
 	}
-	if !kp.passthroughMode {
+	if kp.passthroughMode {
+		return nil
+	}
+	if len(kp.clusters) == 0 {
 		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, nil, nil)
 		if err != nil {
 			return err
 		}
 		kp.kc = kc
+		return nil
+	}
+
+	kp.clusterClients = make(map[string]kube.Client, len(kp.clusters))
+	for _, cluster := range kp.clusters {
+		apiConfig := kp.apiConfig
+		apiConfig.Context = cluster.Context
+		kc, err := kubeClient(logger, apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create client for cluster %q: %w", cluster.name(), err)
+		}
+		kp.clusterClients[cluster.name()] = kc
 	}
 	return nil
 }
 
 func (kp *kubernetesprocessor) Start(_ context.Context, _ component.Host) error {
-	if !kp.passthroughMode {
-		go kp.kc.Start()
+	for _, kc := range kp.kubeClients() {
+		go kc.Start()
 	}
 	return nil
 }
 
 func (kp *kubernetesprocessor) Shutdown(context.Context) error {
-	if !kp.passthroughMode {
-		kp.kc.Stop()
+	for _, kc := range kp.kubeClients() {
+		kc.Stop()
 	}
 	return nil
 }
 
+// kubeClients returns all the clients of the processor.
+func (kp *kubernetesprocessor) kubeClients() []kube.Client {
+	if kp.passthroughMode {
+		return nil
+	}
+	if len(kp.clusterClients) == 0 {
+		return []kube.Client{kp.kc}
+	}
+	clients := make([]kube.Client, 0, len(kp.clusterClients))
+	for _, kc := range kp.clusterClients {
+		clients = append(clients, kc)
+	}
+	return clients
+}
+
+// kubeClient returns the client of the cluster the resource comes from. When
+// several clusters are configured, the cluster is identified by the
+// k8s.cluster.name resource attribute.
+func (kp *kubernetesprocessor) kubeClient(attrs pcommon.Map) (kube.Client, bool) {
+	if len(kp.clusterClients) == 0 {
+		return kp.kc, true
+	}
+	kc, ok := kp.clusterClients[stringAttributeFromMap(attrs, conventions.AttributeK8SClusterName)]
+	return kc, ok
+}
+
 // processTraces process traces and add k8s metadata using resource IP or incoming IP as pod origin.
 func (kp *kubernetesprocessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
 	rss := td.ResourceSpans()
@@ -121,8 +165,14 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 		return
 	}
 
+	kc, ok := kp.kubeClient(resource.Attributes())
+	if !ok {
+		kp.logger.Debug("no cluster found for resource", zap.String("cluster", stringAttributeFromMap(resource.Attributes(), conventions.AttributeK8SClusterName)))
+		return
+	}
+
 	if podIdentifierValue.IsNotEmpty() {
-		if pod, ok := kp.kc.GetPod(podIdentifierValue); ok {
+		if pod, ok := kc.GetPod(podIdentifierValue); ok {
 			kp.logger.Debug("getting the pod", zap.Any("pod", pod))
 
 			for key, val := range pod.Attributes {
@@ -136,7 +186,7 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pco
 
 	namespace := stringAttributeFromMap(resource.Attributes(), conventions.AttributeK8SNamespaceName)
 	if namespace != "" {
-		attrsToAdd := kp.getAttributesForPodsNamespace(namespace)
+		attrsToAdd := getAttributesForPodsNamespace(kc, namespace)
 		for key, val := range attrsToAdd {
 			if _, found := resource.Attributes().Get(key); !found {
 				resource.Attributes().PutStr(key, val)
@@ -182,8 +232,8 @@ func (kp *kubernetesprocessor) addContainerAttributes(attrs pcommon.Map, pod *ku
 	}
 }
 
-func (kp *kubernetesprocessor) getAttributesForPodsNamespace(namespace string) map[string]string {
-	ns, ok := kp.kc.GetNamespace(namespace)
+func getAttributesForPodsNamespace(kc kube.Client, namespace string) map[string]string {
+	ns, ok := kc.GetNamespace(namespace)
 	if !ok {
 		return nil
 	}
@@ -301,6 +301,12 @@ func withPodUID(uid string) generateResourceFunc {
 	}
 }
 
+func withClusterName(clusterName string) generateResourceFunc {
+	return func(res pcommon.Resource) {
+		res.Attributes().PutStr(conventions.AttributeK8SClusterName, clusterName)
+	}
+}
+
 func withContainerName(containerName string) generateResourceFunc {
 	return func(res pcommon.Resource) {
 		res.Attributes().PutStr(conventions.AttributeK8SContainerName, containerName)
@@ -674,6 +680,53 @@ func TestPodUID(t *testing.T) {
 	})
 }
 
+func TestProcessorMultiCluster(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.AuthType = k8sconfig.AuthTypeKubeConfig
+	cfg.Clusters = []ClusterConfig{
+		{Name: "cluster-a", Context: "context-a"},
+		{Context: "context-b"},
+	}
+	m := newMultiTest(t, cfg, nil)
+
+	podUID := "ef10d10b-2da5-4030-812e-5f45c1531227"
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		require.Nil(t, kp.kc)
+		require.Len(t, kp.clusterClients, 2)
+		kp.podAssociations = []kube.Association{
+			{
+				Sources: []kube.AssociationSource{
+					{
+						From: "resource_attribute",
+						Name: "k8s.pod.uid",
+					},
+				},
+			},
+		}
+		for name, kubeContext := range map[string]string{"cluster-a": "context-a", "context-b": "context-b"} {
+			fc := kp.clusterClients[name].(*fakeClient)
+			assert.Equal(t, kubeContext, fc.APIConfig.Context)
+			fc.Pods[newPodIdentifier("resource_attribute", "k8s.pod.uid", podUID)] = &kube.Pod{
+				Name:       "PodA",
+				Attributes: map[string]string{"cluster": name},
+			}
+		}
+	})
+
+	m.testConsume(context.Background(),
+		generateTraces(withPodUID(podUID), withClusterName("context-b")),
+		generateMetrics(withPodUID(podUID), withClusterName("cluster-a")),
+		generateLogs(withPodUID(podUID), withClusterName("unknown")),
+		nil)
+
+	m.assertBatchesLen(1)
+	m.assertResourceObjectLen(0)
+	assertResourceHasStringAttribute(t, m.nextTrace.AllTraces()[0].ResourceSpans().At(0).Resource(), "cluster", "context-b")
+	assertResourceHasStringAttribute(t, m.nextMetrics.AllMetrics()[0].ResourceMetrics().At(0).Resource(), "cluster", "cluster-a")
+	_, found := m.nextLogs.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("cluster")
+	assert.False(t, found)
+}
+
 func TestProcessorAddLabels(t *testing.T) {
 	m := newMultiTest(
 		t,
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration for the log deduplication processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Interval is the time window in which duplicate logs are aggregated
+	// before a single record is emitted for them.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// LogCountAttribute is the name of the attribute holding the number of
+	// logs aggregated into an emitted record.
+	LogCountAttribute string `mapstructure:"log_count_attribute"`
+
+	// MaxFingerprints is the maximum number of distinct logs aggregated at
+	// once. When it is reached, the aggregated logs are emitted early.
+	MaxFingerprints int `mapstructure:"max_fingerprints"`
+
+	// Fingerprint configures the fields identifying duplicate logs.
+	Fingerprint FingerprintConfig `mapstructure:"fingerprint"`
+}
+
+// FingerprintConfig configures the fields identifying duplicate logs. Logs
+// are always compared on their resource, scope, severity and body.
+type FingerprintConfig struct {
+	// Attributes are the keys of the log attributes compared in addition to
+	// the body.
+	Attributes []string `mapstructure:"attributes"`
+
+	// BodyMasks are regular expressions whose matches in the body are
+	// replaced before comparison, so that logs built from the same template
+	// with different values, such as IDs, are duplicates.
+	BodyMasks []string `mapstructure:"body_masks"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be greater than 0")
+	}
+	if cfg.LogCountAttribute == "" {
+		return errors.New("log_count_attribute must not be empty")
+	}
+	if cfg.MaxFingerprints <= 0 {
+		return errors.New("max_fingerprints must be greater than 0")
+	}
+	for _, mask := range cfg.Fingerprint.BodyMasks {
+		if _, err := regexp.Compile(mask); err != nil {
+			return fmt.Errorf("invalid body mask %q: %w", mask, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id       config.ComponentID
+		expected config.Processor
+	}{
+		{
+			id: config.NewComponentID(typeStr),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Interval:          defaultInterval,
+				LogCountAttribute: defaultLogCountAttribute,
+				MaxFingerprints:   defaultMaxFingerprints,
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "custom"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Interval:          time.Minute,
+				LogCountAttribute: "duplicates",
+				MaxFingerprints:   500,
+				Fingerprint: FingerprintConfig{
+					Attributes: []string{"error.code", "http.route"},
+					BodyMasks: []string{
+						`\d+`,
+						`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		error  string
+	}{
+		{
+			name:   "no interval",
+			modify: func(cfg *Config) { cfg.Interval = 0 },
+			error:  "interval must be greater than 0",
+		},
+		{
+			name:   "no log count attribute",
+			modify: func(cfg *Config) { cfg.LogCountAttribute = "" },
+			error:  "log_count_attribute must not be empty",
+		},
+		{
+			name:   "no max fingerprints",
+			modify: func(cfg *Config) { cfg.MaxFingerprints = 0 },
+			error:  "max_fingerprints must be greater than 0",
+		},
+		{
+			name:   "invalid body mask",
+			modify: func(cfg *Config) { cfg.Fingerprint.BodyMasks = []string{"("} },
+			error:  "invalid body mask \"(\": error parsing regexp: missing closing ): `(`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.error)
+		})
+	}
+}
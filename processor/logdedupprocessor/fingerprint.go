@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"regexp"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// bodyMaskReplacement replaces the matches of the body masks.
+const bodyMaskReplacement = "*"
+
+// fingerprinter computes the keys identifying duplicate logs.
+type fingerprinter struct {
+	attributes []string
+	bodyMasks  []*regexp.Regexp
+}
+
+func newFingerprinter(cfg FingerprintConfig) (*fingerprinter, error) {
+	f := &fingerprinter{attributes: cfg.Attributes}
+	for _, mask := range cfg.BodyMasks {
+		re, err := regexp.Compile(mask)
+		if err != nil {
+			return nil, err
+		}
+		f.bodyMasks = append(f.bodyMasks, re)
+	}
+	return f, nil
+}
+
+// resourceKey identifies a resource by its attributes.
+func (f *fingerprinter) resourceKey(resource pcommon.Resource) uint64 {
+	h := fnv.New64a()
+	writeMap(h, resource.Attributes())
+	return h.Sum64()
+}
+
+// scopeKey identifies a scope within the resource of the given key.
+func (f *fingerprinter) scopeKey(resourceKey uint64, scope pcommon.InstrumentationScope) uint64 {
+	h := fnv.New64a()
+	writeUint64(h, resourceKey)
+	writeString(h, scope.Name())
+	writeString(h, scope.Version())
+	writeMap(h, scope.Attributes())
+	return h.Sum64()
+}
+
+// logKey identifies the duplicates of a log within the scope of the given key.
+func (f *fingerprinter) logKey(scopeKey uint64, lr plog.LogRecord) uint64 {
+	h := fnv.New64a()
+	writeUint64(h, scopeKey)
+	writeUint64(h, uint64(lr.SeverityNumber()))
+	writeString(h, lr.SeverityText())
+	writeString(h, f.bodyTemplate(lr.Body()))
+	for _, key := range f.attributes {
+		writeString(h, key)
+		if v, ok := lr.Attributes().Get(key); ok {
+			writeValue(h, v)
+		} else {
+			writeUint64(h, uint64(pcommon.ValueTypeEmpty))
+		}
+	}
+	return h.Sum64()
+}
+
+// bodyTemplate returns the body with the matches of the body masks replaced.
+func (f *fingerprinter) bodyTemplate(body pcommon.Value) string {
+	template := body.AsString()
+	for _, re := range f.bodyMasks {
+		template = re.ReplaceAllLiteralString(template, bodyMaskReplacement)
+	}
+	return template
+}
+
+func writeMap(h hash.Hash64, m pcommon.Map) {
+	keys := make([]string, 0, m.Len())
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	writeUint64(h, uint64(len(keys)))
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		writeString(h, k)
+		writeValue(h, v)
+	}
+}
+
+func writeValue(h hash.Hash64, v pcommon.Value) {
+	writeUint64(h, uint64(v.Type()))
+	writeString(h, v.AsString())
+}
+
+func writeString(h hash.Hash64, s string) {
+	writeUint64(h, uint64(len(s)))
+	_, _ = h.Write([]byte(s))
+}
+
+func writeUint64(h hash.Hash64, v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	_, _ = h.Write(b[:])
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestResourceKeyIgnoresAttributeOrder(t *testing.T) {
+	f, err := newFingerprinter(FingerprintConfig{})
+	require.NoError(t, err)
+
+	r1 := pcommon.NewResource()
+	r1.Attributes().PutStr("service.name", "checkout")
+	r1.Attributes().PutStr("host.name", "host-1")
+	r2 := pcommon.NewResource()
+	r2.Attributes().PutStr("host.name", "host-1")
+	r2.Attributes().PutStr("service.name", "checkout")
+	r3 := pcommon.NewResource()
+	r3.Attributes().PutStr("host.name", "host-2")
+	r3.Attributes().PutStr("service.name", "checkout")
+
+	assert.Equal(t, f.resourceKey(r1), f.resourceKey(r2))
+	assert.NotEqual(t, f.resourceKey(r1), f.resourceKey(r3))
+}
+
+func TestLogKey(t *testing.T) {
+	f, err := newFingerprinter(FingerprintConfig{
+		Attributes: []string{"error.code"},
+		BodyMasks:  []string{`\d+`},
+	})
+	require.NoError(t, err)
+
+	newLog := func(body string, severity plog.SeverityNumber, attributes map[string]interface{}) plog.LogRecord {
+		lr := plog.NewLogRecord()
+		lr.Body().SetStr(body)
+		lr.SetSeverityNumber(severity)
+		lr.Attributes().FromRaw(attributes)
+		return lr
+	}
+	base := newLog("user 42 not found", plog.SeverityNumberError, map[string]interface{}{"error.code": "404", "request.id": "a"})
+
+	testCases := []struct {
+		name      string
+		lr        plog.LogRecord
+		duplicate bool
+	}{
+		{
+			name:      "masked body and other attributes",
+			lr:        newLog("user 7 not found", plog.SeverityNumberError, map[string]interface{}{"error.code": "404", "request.id": "b"}),
+			duplicate: true,
+		},
+		{
+			name: "different body",
+			lr:   newLog("user 42 deleted", plog.SeverityNumberError, map[string]interface{}{"error.code": "404"}),
+		},
+		{
+			name: "different severity",
+			lr:   newLog("user 42 not found", plog.SeverityNumberWarn, map[string]interface{}{"error.code": "404"}),
+		},
+		{
+			name: "different fingerprint attribute",
+			lr:   newLog("user 42 not found", plog.SeverityNumberError, map[string]interface{}{"error.code": "500"}),
+		},
+		{
+			name: "missing fingerprint attribute",
+			lr:   newLog("user 42 not found", plog.SeverityNumberError, map[string]interface{}{}),
+		},
+		{
+			name: "fingerprint attribute of another type",
+			lr:   newLog("user 42 not found", plog.SeverityNumberError, map[string]interface{}{"error.code": int64(404)}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.duplicate {
+				assert.Equal(t, f.logKey(1, base), f.logKey(1, tc.lr))
+			} else {
+				assert.NotEqual(t, f.logKey(1, base), f.logKey(1, tc.lr))
+			}
+		})
+	}
+
+	assert.NotEqual(t, f.logKey(1, base), f.logKey(2, base), "logs of different scopes")
+}
+
+func TestBodyTemplate(t *testing.T) {
+	f, err := newFingerprinter(FingerprintConfig{BodyMasks: []string{`\d+`, `id=\S+`}})
+	require.NoError(t, err)
+	assert.Equal(t, "retry * of * failed for *", f.bodyTemplate(pcommon.NewValueStr("retry 3 of 5 failed for id=abc")))
+}
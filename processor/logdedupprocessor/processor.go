@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	firstObservedAttribute = "first_observed_timestamp"
+	lastObservedAttribute  = "last_observed_timestamp"
+)
+
+var _ component.LogsProcessor = (*processor)(nil)
+
+// aggregatedLog is a log and the number of its duplicates in a window.
+type aggregatedLog struct {
+	resourceKey   uint64
+	scopeKey      uint64
+	record        plog.LogRecord
+	count         int64
+	firstObserved pcommon.Timestamp
+	lastObserved  pcommon.Timestamp
+}
+
+// window holds the logs aggregated since the last emission.
+type window struct {
+	resources map[uint64]pcommon.Resource
+	scopes    map[uint64]pcommon.InstrumentationScope
+	logs      map[uint64]*aggregatedLog
+	// order holds the keys of the logs in the order they were first seen.
+	order []uint64
+}
+
+func newWindow() *window {
+	return &window{
+		resources: map[uint64]pcommon.Resource{},
+		scopes:    map[uint64]pcommon.InstrumentationScope{},
+		logs:      map[uint64]*aggregatedLog{},
+	}
+}
+
+type processor struct {
+	config        *Config
+	logger        *zap.Logger
+	nextConsumer  consumer.Logs
+	fingerprinter *fingerprinter
+	now           func() time.Time
+
+	mu     sync.Mutex
+	window *window
+
+	shutdownCh chan struct{}
+	loopDone   sync.WaitGroup
+}
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Logs) (*processor, error) {
+	f, err := newFingerprinter(cfg.Fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	return &processor{
+		config:        cfg,
+		logger:        logger,
+		nextConsumer:  nextConsumer,
+		fingerprinter: f,
+		now:           time.Now,
+		window:        newWindow(),
+		shutdownCh:    make(chan struct{}),
+	}, nil
+}
+
+func (p *processor) Start(context.Context, component.Host) error {
+	p.loopDone.Add(1)
+	go p.emitLoop()
+	return nil
+}
+
+func (p *processor) Shutdown(ctx context.Context) error {
+	close(p.shutdownCh)
+	p.loopDone.Wait()
+	return p.emit(ctx, p.takeWindow())
+}
+
+func (p *processor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeLogs aggregates the logs into the current window. The logs are
+// emitted when the window ends, or when the window holds the maximum number of
+// distinct logs.
+func (p *processor) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	var full []*window
+
+	p.mu.Lock()
+	now := pcommon.NewTimestampFromTime(p.now())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceKey := p.fingerprinter.resourceKey(rl.Resource())
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			scopeKey := p.fingerprinter.scopeKey(resourceKey, sl.Scope())
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				if w := p.aggregate(rl.Resource(), resourceKey, sl.Scope(), scopeKey, lrs.At(k), now); w != nil {
+					full = append(full, w)
+				}
+			}
+		}
+	}
+	p.mu.Unlock()
+
+	var errs error
+	for _, w := range full {
+		errs = multierr.Append(errs, p.emit(ctx, w))
+	}
+	return errs
+}
+
+// aggregate adds the log to the current window. If the log is distinct and the
+// window is full, a new window is started and the full one is returned. It must
+// be called with the lock held.
+func (p *processor) aggregate(resource pcommon.Resource, resourceKey uint64, scope pcommon.InstrumentationScope, scopeKey uint64, lr plog.LogRecord, now pcommon.Timestamp) *window {
+	observed := lr.Timestamp()
+	if observed == 0 {
+		observed = lr.ObservedTimestamp()
+	}
+	if observed == 0 {
+		observed = now
+	}
+
+	key := p.fingerprinter.logKey(scopeKey, lr)
+	if agg, ok := p.window.logs[key]; ok {
+		agg.count++
+		if observed < agg.firstObserved {
+			agg.firstObserved = observed
+		}
+		if observed > agg.lastObserved {
+			agg.lastObserved = observed
+		}
+		return nil
+	}
+
+	var full *window
+	if len(p.window.logs) >= p.config.MaxFingerprints {
+		full = p.window
+		p.window = newWindow()
+	}
+
+	if _, ok := p.window.resources[resourceKey]; !ok {
+		r := pcommon.NewResource()
+		resource.CopyTo(r)
+		p.window.resources[resourceKey] = r
+	}
+	if _, ok := p.window.scopes[scopeKey]; !ok {
+		s := pcommon.NewInstrumentationScope()
+		scope.CopyTo(s)
+		p.window.scopes[scopeKey] = s
+	}
+
+	record := plog.NewLogRecord()
+	lr.CopyTo(record)
+	p.window.logs[key] = &aggregatedLog{
+		resourceKey:   resourceKey,
+		scopeKey:      scopeKey,
+		record:        record,
+		count:         1,
+		firstObserved: observed,
+		lastObserved:  observed,
+	}
+	p.window.order = append(p.window.order, key)
+	return full
+}
+
+func (p *processor) takeWindow() *window {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w := p.window
+	p.window = newWindow()
+	return w
+}
+
+func (p *processor) emitLoop() {
+	defer p.loopDone.Done()
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.emit(context.Background(), p.takeWindow()); err != nil {
+				p.logger.Error("failed to emit deduplicated logs", zap.Error(err))
+			}
+		case <-p.shutdownCh:
+			return
+		}
+	}
+}
+
+// emit sends a record for each distinct log of the window, with the number of
+// its duplicates and the time range in which they were observed.
+func (p *processor) emit(ctx context.Context, w *window) error {
+	if len(w.order) == 0 {
+		return nil
+	}
+
+	ld := plog.NewLogs()
+	resourceLogs := map[uint64]plog.ResourceLogs{}
+	scopeLogs := map[uint64]plog.ScopeLogs{}
+	for _, key := range w.order {
+		agg := w.logs[key]
+
+		sl, ok := scopeLogs[agg.scopeKey]
+		if !ok {
+			rl, ok := resourceLogs[agg.resourceKey]
+			if !ok {
+				rl = ld.ResourceLogs().AppendEmpty()
+				w.resources[agg.resourceKey].CopyTo(rl.Resource())
+				resourceLogs[agg.resourceKey] = rl
+			}
+			sl = rl.ScopeLogs().AppendEmpty()
+			w.scopes[agg.scopeKey].CopyTo(sl.Scope())
+			scopeLogs[agg.scopeKey] = sl
+		}
+
+		lr := sl.LogRecords().AppendEmpty()
+		agg.record.MoveTo(lr)
+		lr.Attributes().PutInt(p.config.LogCountAttribute, agg.count)
+		lr.Attributes().PutStr(firstObservedAttribute, agg.firstObserved.AsTime().Format(time.RFC3339Nano))
+		lr.Attributes().PutStr(lastObservedAttribute, agg.lastObserved.AsTime().Format(time.RFC3339Nano))
+	}
+
+	return p.nextConsumer.ConsumeLogs(ctx, ld)
+}
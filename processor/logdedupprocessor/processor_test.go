@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logdedupprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+func newTestProcessor(t *testing.T, cfg *Config, sink *consumertest.LogsSink) *processor {
+	p, err := newProcessor(zap.NewNop(), cfg, sink)
+	require.NoError(t, err)
+	return p
+}
+
+// appendLog appends a log with the given body and timestamp to the scope of
+// the service.
+func appendLog(ld plog.Logs, service string, body string, ts time.Time) plog.LogRecord {
+	var rl plog.ResourceLogs
+	found := false
+	for i := 0; i < ld.ResourceLogs().Len() && !found; i++ {
+		rl = ld.ResourceLogs().At(i)
+		v, _ := rl.Resource().Attributes().Get("service.name")
+		found = v.Str() == service
+	}
+	if !found {
+		rl = ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().Scope().SetName("scope")
+	}
+
+	lr := rl.ScopeLogs().At(0).LogRecords().AppendEmpty()
+	lr.Body().SetStr(body)
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	return lr
+}
+
+func TestConsumeLogsAggregatesDuplicates(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Fingerprint.BodyMasks = []string{`\d+`}
+	sink := new(consumertest.LogsSink)
+	p := newTestProcessor(t, cfg, sink)
+
+	start := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	ld := plog.NewLogs()
+	appendLog(ld, "checkout", "connection 1 refused", start.Add(time.Second)).Attributes().PutStr("attempt", "first")
+	appendLog(ld, "checkout", "connection 2 refused", start)
+	appendLog(ld, "checkout", "timeout", start)
+	appendLog(ld, "payment", "connection 3 refused", start)
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	ld = plog.NewLogs()
+	appendLog(ld, "checkout", "connection 4 refused", start.Add(2*time.Second))
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	// logs are only emitted at the end of the window
+	assert.Equal(t, 0, sink.LogRecordCount())
+	require.NoError(t, p.emit(context.Background(), p.takeWindow()))
+
+	require.Len(t, sink.AllLogs(), 1)
+	out := sink.AllLogs()[0]
+	require.Equal(t, 2, out.ResourceLogs().Len())
+	assert.Equal(t, 3, out.LogRecordCount())
+
+	checkout := out.ResourceLogs().At(0)
+	service, _ := checkout.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", service.Str())
+	require.Equal(t, 1, checkout.ScopeLogs().Len())
+	assert.Equal(t, "scope", checkout.ScopeLogs().At(0).Scope().Name())
+	records := checkout.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+
+	refused := records.At(0)
+	assert.Equal(t, "connection 1 refused", refused.Body().Str())
+	assert.Equal(t, map[string]interface{}{
+		"attempt":                "first",
+		defaultLogCountAttribute: int64(3),
+		firstObservedAttribute:   "2022-10-01T12:00:00Z",
+		lastObservedAttribute:    "2022-10-01T12:00:02Z",
+	}, refused.Attributes().AsRaw())
+
+	count, _ := records.At(1).Attributes().Get(defaultLogCountAttribute)
+	assert.Equal(t, int64(1), count.Int())
+
+	payment := out.ResourceLogs().At(1)
+	service, _ = payment.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "payment", service.Str())
+	assert.Equal(t, 1, payment.ScopeLogs().At(0).LogRecords().Len())
+
+	// the window is empty once emitted
+	require.NoError(t, p.emit(context.Background(), p.takeWindow()))
+	assert.Len(t, sink.AllLogs(), 1)
+}
+
+func TestConsumeLogsMaxFingerprints(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxFingerprints = 2
+	sink := new(consumertest.LogsSink)
+	p := newTestProcessor(t, cfg, sink)
+
+	now := time.Now()
+	ld := plog.NewLogs()
+	appendLog(ld, "checkout", "a", now)
+	appendLog(ld, "checkout", "b", now)
+	appendLog(ld, "checkout", "a", now)
+	appendLog(ld, "checkout", "c", now)
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, 2, sink.AllLogs()[0].LogRecordCount())
+	assert.Len(t, p.window.logs, 1)
+}
+
+func TestConsumeLogsUsesObservedTime(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	sink := new(consumertest.LogsSink)
+	p := newTestProcessor(t, cfg, sink)
+	now := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	ld := plog.NewLogs()
+	appendLog(ld, "checkout", "a", time.Time{}).SetTimestamp(0)
+	appendLog(ld, "checkout", "a", time.Time{}).SetTimestamp(0)
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).SetObservedTimestamp(pcommon.NewTimestampFromTime(now.Add(-time.Minute)))
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, p.emit(context.Background(), p.takeWindow()))
+
+	attrs := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+	first, _ := attrs.Get(firstObservedAttribute)
+	last, _ := attrs.Get(lastObservedAttribute)
+	assert.Equal(t, "2022-10-01T11:59:00Z", first.Str())
+	assert.Equal(t, "2022-10-01T12:00:00Z", last.Str())
+}
+
+func TestShutdownEmitsAggregatedLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = time.Hour
+	sink := new(consumertest.LogsSink)
+	p := newTestProcessor(t, cfg, sink)
+	require.NoError(t, p.Start(context.Background(), nil))
+
+	ld := plog.NewLogs()
+	appendLog(ld, "checkout", "a", time.Now())
+	appendLog(ld, "checkout", "a", time.Now())
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestEmitLoop(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 10 * time.Millisecond
+	sink := new(consumertest.LogsSink)
+	p := newTestProcessor(t, cfg, sink)
+	require.NoError(t, p.Start(context.Background(), nil))
+	defer func() { require.NoError(t, p.Shutdown(context.Background())) }()
+
+	ld := plog.NewLogs()
+	appendLog(ld, "checkout", "a", time.Now())
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	assert.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, time.Second, 5*time.Millisecond)
+}
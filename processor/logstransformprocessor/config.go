@@ -26,6 +26,20 @@ import (
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	adapter.BaseConfig       `mapstructure:",squash"`
+	Concurrency              ConcurrencyConfig `mapstructure:"concurrency"`
+}
+
+// ConcurrencyConfig controls how many entries are processed by the operators concurrently.
+type ConcurrencyConfig struct {
+	// WorkerCount defines how many worker goroutines run the operators, each with
+	// its own copy of the operators. The state of stateful operators, such as
+	// recombine, is not shared between the workers, so they should only be used
+	// with a single worker.
+	// By default: a single worker runs the operators.
+	WorkerCount int `mapstructure:"worker_count"`
+	// BatchSize defines the maximum number of consecutive entries processed by a
+	// worker at once.
+	BatchSize int `mapstructure:"batch_size"`
 }
 
 var _ config.Processor = (*Config)(nil)
@@ -35,5 +49,11 @@ func (cfg *Config) Validate() error {
 	if len(cfg.BaseConfig.Operators) == 0 {
 		return errors.New("no operators were configured for this logs transform processor")
 	}
+	if cfg.Concurrency.WorkerCount <= 0 {
+		return errors.New("concurrency worker_count must be positive")
+	}
+	if cfg.Concurrency.BatchSize <= 0 {
+		return errors.New("concurrency batch_size must be positive")
+	}
 	return nil
 }
@@ -64,5 +64,22 @@ func TestLoadConfig(t *testing.T) {
 				FlushInterval: 100 * time.Millisecond,
 			},
 		},
+		Concurrency: ConcurrencyConfig{
+			WorkerCount: 1,
+			BatchSize:   100,
+		},
 	}, cfg)
 }
+
+func TestValidateConcurrency(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Operators = []operator.Config{{Builder: regex.NewConfig()}}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Concurrency.WorkerCount = 0
+	assert.EqualError(t, cfg.Validate(), "concurrency worker_count must be positive")
+
+	cfg.Concurrency.WorkerCount = 4
+	cfg.Concurrency.BatchSize = -1
+	assert.EqualError(t, cfg.Validate(), "concurrency batch_size must be positive")
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
 )
 
 const (
@@ -56,6 +57,10 @@ func createDefaultConfig() config.Processor {
 				FlushInterval: 100 * time.Millisecond,
 			},
 		},
+		Concurrency: ConcurrencyConfig{
+			WorkerCount: 1,
+			BatchSize:   pipeline.DefaultBatchSize,
+		},
 	}
 }
 
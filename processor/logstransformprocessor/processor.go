@@ -29,7 +29,6 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
 )
 
@@ -43,8 +42,7 @@ type logsTransformProcessor struct {
 	config *Config
 	id     config.ComponentID
 
-	pipe          *pipeline.DirectedPipeline
-	firstOperator operator.Operator
+	pipe          *pipeline.ParallelPipeline
 	emitter       *adapter.LogEmitter
 	converter     *adapter.Converter
 	fromConverter *adapter.FromPdataConverter
@@ -75,9 +73,13 @@ func (ltp *logsTransformProcessor) Start(ctx context.Context, host component.Hos
 		emitterOpts = append(emitterOpts, adapter.LogEmitterWithFlushInterval(baseCfg.Converter.FlushInterval))
 	}
 	ltp.emitter = adapter.NewLogEmitter(emitterOpts...)
-	pipe, err := pipeline.Config{
-		Operators:     baseCfg.Operators,
-		DefaultOutput: ltp.emitter,
+	pipe, err := pipeline.ParallelConfig{
+		Config: pipeline.Config{
+			Operators:     baseCfg.Operators,
+			DefaultOutput: ltp.emitter,
+		},
+		WorkerCount: int(math.Max(1, float64(ltp.config.Concurrency.WorkerCount))),
+		BatchSize:   ltp.config.Concurrency.BatchSize,
 	}.Build(ltp.logger.Sugar())
 	if err != nil {
 		return err
@@ -90,11 +92,6 @@ func (ltp *logsTransformProcessor) Start(ctx context.Context, host component.Hos
 	}
 
 	ltp.pipe = pipe
-	pipelineOperators := pipe.Operators()
-	if len(pipelineOperators) == 0 {
-		return errors.New("processor requires at least one operator to be configured")
-	}
-	ltp.firstOperator = pipelineOperators[0]
 
 	wkrCount := int(math.Max(1, float64(runtime.NumCPU())))
 	if baseCfg.Converter.WorkerCount > 0 {
@@ -114,7 +111,7 @@ func (ltp *logsTransformProcessor) Start(ctx context.Context, host component.Hos
 
 	// Below we're starting 3 loops:
 	// * first which reads all the logs translated by the fromConverter and then forwards
-	//   them to pipeline
+	//   them to pipeline, which runs the operators on concurrent workers
 	// ...
 	ltp.wg.Add(1)
 	go ltp.converterLoop(ctx)
@@ -177,12 +174,9 @@ func (ltp *logsTransformProcessor) converterLoop(ctx context.Context) {
 				return
 			}
 
-			for _, e := range entries {
-				// Add item to the first operator of the pipeline manually
-				if err := ltp.firstOperator.Process(ctx, e); err != nil {
-					ltp.outputChannel <- outputType{err: fmt.Errorf("processor encountered an issue with the pipeline: %w", err)}
-					break
-				}
+			// Process the items in the pipeline manually, preserving their order
+			if err := ltp.pipe.Process(ctx, entries); err != nil {
+				ltp.outputChannel <- outputType{err: fmt.Errorf("processor encountered an issue with the pipeline: %w", err)}
 			}
 		}
 	}
@@ -16,6 +16,7 @@ package logstransformprocessor
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -180,6 +181,56 @@ func TestLogsTransformProcessor(t *testing.T) {
 	}
 }
 
+func TestLogsTransformProcessorConcurrency(t *testing.T) {
+	concurrencyCfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		BaseConfig: adapter.BaseConfig{
+			Operators: []operator.Config{
+				{
+					Builder: func() *regex.Config {
+						cfg := regex.NewConfig()
+						cfg.Regex = "^message (?P<n>\\d+)$"
+						return cfg
+					}(),
+				},
+			},
+			Converter: adapter.ConverterConfig{
+				MaxFlushCount: 10,
+				FlushInterval: time.Minute,
+			},
+		},
+		Concurrency: ConcurrencyConfig{
+			WorkerCount: 4,
+			BatchSize:   2,
+		},
+	}
+
+	tln := new(consumertest.LogsSink)
+	factory := NewFactory()
+	ltp, err := factory.CreateLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), concurrencyCfg, tln)
+	require.NoError(t, err)
+
+	require.NoError(t, ltp.Start(context.Background(), nil))
+	defer func() { require.NoError(t, ltp.Shutdown(context.Background())) }()
+
+	sourceMessages := make([]testLogMessage, 0, 10)
+	for i := 0; i < 10; i++ {
+		sourceMessages = append(sourceMessages, testLogMessage{body: pcommon.NewValueStr(fmt.Sprintf("message %d", i))})
+	}
+	require.NoError(t, ltp.ConsumeLogs(context.Background(), generateLogData(sourceMessages)))
+
+	logs := tln.AllLogs()
+	require.Len(t, logs, 1)
+	records := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 10, records.Len())
+	for i := 0; i < records.Len(); i++ {
+		assert.Equal(t, fmt.Sprintf("message %d", i), records.At(i).Body().Str())
+		n, ok := records.At(i).Attributes().Get("n")
+		require.True(t, ok)
+		assert.Equal(t, fmt.Sprint(i), n.Str())
+	}
+}
+
 func generateLogData(messages []testLogMessage) plog.Logs {
 	ld := testdata.GenerateLogsOneEmptyResourceLogs()
 	scope := ld.ResourceLogs().At(0).ScopeLogs().AppendEmpty()
@@ -49,6 +49,9 @@ const (
 	// ScaleFieldName is the mapstructure field name for Scale field
 	ScaleFieldName = "experimental_scale"
 
+	// UnitFieldName is the mapstructure field name for Unit field
+	UnitFieldName = "unit"
+
 	// SubmatchCaseFieldName is the mapstructure field name for SubmatchCase field
 	SubmatchCaseFieldName = "submatch_case"
 )
@@ -142,6 +145,9 @@ type Operation struct {
 	// Scale is a scalar to multiply the values with.
 	Scale float64 `mapstructure:"experimental_scale"`
 
+	// Unit is the unit to convert the values to, from the unit of the metric.
+	Unit string `mapstructure:"unit"`
+
 	// LabelValue identifies the exact label value to operate on
 	LabelValue string `mapstructure:"label_value"`
 }
@@ -207,6 +213,11 @@ const (
 	// ScaleValue multiplies the value by a constant scalar
 	ScaleValue OperationAction = "experimental_scale_value"
 
+	// ConvertUnit converts the values from the unit of the metric to Operation.Unit, and sets the unit of the metric.
+	// Metric has to match the FilterConfig with all its data points if used with Update ConfigAction,
+	// otherwise the operation will be ignored.
+	ConvertUnit OperationAction = "convert_unit"
+
 	// AggregateLabels aggregates away all labels other than the ones in Operation.LabelSet
 	// by the method indicated by Operation.AggregationType.
 	// Metric has to match the FilterConfig with all its data points if used with Update ConfigAction,
@@ -220,7 +231,7 @@ const (
 	AggregateLabelValues OperationAction = "aggregate_label_values"
 )
 
-var operationActions = []OperationAction{AddLabel, UpdateLabel, DeleteLabelValue, ToggleScalarDataType, ScaleValue, AggregateLabels, AggregateLabelValues}
+var operationActions = []OperationAction{AddLabel, UpdateLabel, DeleteLabelValue, ToggleScalarDataType, ScaleValue, ConvertUnit, AggregateLabels, AggregateLabelValues}
 
 func (oa OperationAction) isValid() bool {
 	for _, operationAction := range operationActions {
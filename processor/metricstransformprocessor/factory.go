@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/unitconv"
 )
 
 const (
@@ -131,6 +133,14 @@ func validateConfiguration(config *Config) error {
 			if op.Action == ScaleValue && op.Scale == 0 {
 				return fmt.Errorf("operation %v: missing required field %q while %q is %v", i+1, ScaleFieldName, ActionFieldName, ScaleValue)
 			}
+			if op.Action == ConvertUnit && op.Unit == "" {
+				return fmt.Errorf("operation %v: missing required field %q while %q is %v", i+1, UnitFieldName, ActionFieldName, ConvertUnit)
+			}
+			if op.Action == ConvertUnit && op.Unit != "" {
+				if _, err := unitconv.Factor(op.Unit, op.Unit); err != nil {
+					return fmt.Errorf("operation %v: %w", i+1, err)
+				}
+			}
 
 			if op.AggregationType != "" && !op.AggregationType.isValid() {
 				return fmt.Errorf("operation %v: %q must be in %q", i+1, AggregationTypeFieldName, aggregationTypes)
@@ -92,6 +92,16 @@ func TestCreateProcessors(t *testing.T) {
 			succeed:      false,
 			errorMessage: fmt.Sprintf("operation %v: missing required field %q while %q is %v", 1, ScaleFieldName, ActionFieldName, ScaleValue),
 		},
+		{
+			configName:   "config_invalid_unit.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("operation %v: missing required field %q while %q is %v", 1, UnitFieldName, ActionFieldName, ConvertUnit),
+		},
+		{
+			configName:   "config_invalid_unsupported_unit.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("operation %v: unsupported unit %q", 1, "{requests}"),
+		},
 		{
 			configName:   "config_invalid_regexp.yaml",
 			succeed:      false,
@@ -557,6 +557,10 @@ func transformMetric(metric pmetric.Metric, transform internalTransform) bool {
 			toggleScalarDataTypeOp(metric, transform.MetricIncludeFilter)
 		case ScaleValue:
 			scaleValueOp(metric, op, transform.MetricIncludeFilter)
+		case ConvertUnit:
+			if canChangeMetric {
+				convertUnitOp(metric, op)
+			}
 		case AddLabel:
 			if canChangeMetric {
 				addLabelOp(metric, op)
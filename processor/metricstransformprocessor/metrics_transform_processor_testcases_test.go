@@ -1456,6 +1456,68 @@ var (
 					addIntDatapoint(1, 1, 3, "value2").build(),
 			},
 		},
+		// Convert Unit
+		{
+			name: "metric_convert_unit",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterRegexp{include: regexp.MustCompile("^metric")},
+					Action:              Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action: ConvertUnit,
+								Unit:   "s",
+							},
+						},
+					},
+				},
+			},
+			in: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeGauge, "metric1").setUnit("ms").addIntDatapoint(1, 1, 1500).build(),
+				metricBuilder(pmetric.MetricTypeHistogram, "metric2").setUnit("ms").
+					addHistogramDatapoint(1, 1, 3, 6000, []float64{1000, 2000}, []uint64{1, 1, 1}).build(),
+				metricBuilder(pmetric.MetricTypeGauge, "metric3").setUnit("s").addDoubleDatapoint(1, 1, 2).build(),
+				// the metrics whose unit can't be converted are left unchanged
+				metricBuilder(pmetric.MetricTypeGauge, "metric4").setUnit("By").addIntDatapoint(1, 1, 3).build(),
+			},
+			out: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeGauge, "metric1").setUnit("s").addDoubleDatapoint(1, 1, 1.5).build(),
+				metricBuilder(pmetric.MetricTypeHistogram, "metric2").setUnit("s").
+					addHistogramDatapoint(1, 1, 3, 6, []float64{1, 2}, []uint64{1, 1, 1}).build(),
+				metricBuilder(pmetric.MetricTypeGauge, "metric3").setUnit("s").addDoubleDatapoint(1, 1, 2).build(),
+				metricBuilder(pmetric.MetricTypeGauge, "metric4").setUnit("By").addIntDatapoint(1, 1, 3).build(),
+			},
+		},
+		{
+			name: "metric_convert_unit_with_attr_filtering",
+			transforms: []internalTransform{
+				{
+					MetricIncludeFilter: internalFilterStrict{include: "metric1",
+						attrMatchers: map[string]StringMatcher{"label1": strictMatcher("value1")}},
+					Action: Update,
+					Operations: []internalOperation{
+						{
+							configOperation: Operation{
+								Action: ConvertUnit,
+								Unit:   "MiBy",
+							},
+						},
+					},
+				},
+			},
+			in: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeSum, "metric1", "label1").setUnit("By").
+					addIntDatapoint(1, 1, 1048576, "value1").
+					addIntDatapoint(1, 1, 2097152, "value2").build(),
+			},
+			// the unit is shared by all the data points, which must all match to be converted
+			out: []pmetric.Metric{
+				metricBuilder(pmetric.MetricTypeSum, "metric1", "label1").setUnit("By").
+					addIntDatapoint(1, 1, 1048576, "value1").
+					addIntDatapoint(1, 1, 2097152, "value2").build(),
+			},
+		},
 		// Add Label to a metric
 		{
 			name: "update_existing_metric_by_adding_a_new_label_when_there_are_no_labels",
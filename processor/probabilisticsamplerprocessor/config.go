@@ -15,9 +15,19 @@
 package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	// hashSeedMode samples the traces by hashing their trace ID with the hash seed.
+	hashSeedMode = "hash_seed"
+	// proportionalMode samples the traces by comparing the randomness of their trace ID to the sampling
+	// threshold, consistently with the other samplers propagating their threshold in the tracestate.
+	proportionalMode = "proportional"
+)
+
 // Config has the configuration guiding the trace sampler processor.
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -30,11 +40,24 @@ type Config struct {
 	// have different sampling rates: if they use the same seed all passing one layer may pass the other even if they have
 	// different sampling rates, configuring different seeds avoids that.
 	HashSeed uint32 `mapstructure:"hash_seed"`
+
+	// SamplerMode is how the traces are sampled: either "hash_seed", hashing the trace ID with the hash seed, or
+	// "proportional", comparing the randomness of the trace ID to the sampling threshold. Defaults to "hash_seed".
+	SamplerMode string `mapstructure:"sampler_mode"`
+
+	// ParallelHashing computes the decision of the other sampler mode too, only counting the spans on which the
+	// decisions of both modes differ, to assess a migration from one mode to the other before switching.
+	ParallelHashing bool `mapstructure:"parallel_hashing"`
 }
 
 var _ config.Processor = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
-	return nil
+	switch cfg.SamplerMode {
+	case hashSeedMode, proportionalMode:
+		return nil
+	default:
+		return fmt.Errorf("invalid sampler_mode %q, must be %q or %q", cfg.SamplerMode, hashSeedMode, proportionalMode)
+	}
 }
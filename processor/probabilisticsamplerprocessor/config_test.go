@@ -37,6 +37,16 @@ func TestLoadConfig(t *testing.T) {
 				ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
 				SamplingPercentage: 15.3,
 				HashSeed:           22,
+				SamplerMode:        hashSeedMode,
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "proportional"),
+			expected: &Config{
+				ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				SamplingPercentage: 25,
+				SamplerMode:        proportionalMode,
+				ParallelHashing:    true,
 			},
 		},
 		{
@@ -62,3 +72,9 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SamplerMode = "random"
+	assert.EqualError(t, cfg.Validate(), `invalid sampler_mode "random", must be "hash_seed" or "proportional"`)
+}
@@ -27,7 +27,8 @@ var (
 	tagPolicyKey, _  = tag.NewKey("policy")
 	tagSampledKey, _ = tag.NewKey("sampled")
 
-	statCountTracesSampled = stats.Int64("count_traces_sampled", "Count of traces that were sampled or not", stats.UnitDimensionless)
+	statCountTracesSampled      = stats.Int64("count_traces_sampled", "Count of traces that were sampled or not", stats.UnitDimensionless)
+	statCountSamplingMismatches = stats.Int64("count_sampling_mismatches", "Count of spans the sampler modes decided differently on", stats.UnitDimensionless)
 )
 
 // SamplingProcessorMetricViews return the metrics views according to given telemetry level.
@@ -45,7 +46,16 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	countSamplingMismatchesView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statCountSamplingMismatches.Name()),
+		Measure:     statCountSamplingMismatches,
+		Description: statCountSamplingMismatches.Description(),
+		TagKeys:     sampledTagKeys,
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		countTracesSampledView,
+		countSamplingMismatchesView,
 	}
 }
@@ -55,7 +55,11 @@ const (
 type tracesamplerprocessor struct {
 	scaledSamplingRate uint32
 	hashSeed           uint32
-	logger             *zap.Logger
+	samplerMode        string
+	parallelHashing    bool
+	// threshold is the sampling threshold of the proportional mode.
+	threshold uint64
+	logger    *zap.Logger
 }
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform head sampling according to the given
@@ -65,6 +69,9 @@ func newTracesProcessor(ctx context.Context, set component.ProcessorCreateSettin
 		// Adjust sampling percentage on private so recalculations are avoided.
 		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
 		hashSeed:           cfg.HashSeed,
+		samplerMode:        cfg.SamplerMode,
+		parallelHashing:    cfg.ParallelHashing,
+		threshold:          thresholdOf(float64(cfg.SamplingPercentage) / 100),
 		logger:             set.Logger,
 	}
 
@@ -100,26 +107,54 @@ func (tsp *tracesamplerprocessor) processTraces(ctx context.Context, td ptrace.T
 					statCountTracesSampled.M(int64(1)),
 				)
 
-				// If one assumes random trace ids hashing may seems avoidable, however, traces can be coming from sources
-				// with various different criteria to generate trace id and perhaps were already sampled without hashing.
-				// Hashing here prevents bias due to such systems.
-				tidBytes := s.TraceID()
-				sampled := sp == mustSampleSpan ||
-					hash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < tsp.scaledSamplingRate
-
-				if sampled {
+				policy := "trace_id_hash"
+				if tsp.samplerMode == proportionalMode {
+					policy = "trace_id_threshold"
+				}
+				if sp == mustSampleSpan {
+					// The span isn't sampled with a probability, its tracestate is left as is.
 					_ = stats.RecordWithTags(
 						ctx,
-						[]tag.Mutator{tag.Upsert(tagPolicyKey, "trace_id_hash"), tag.Upsert(tagSampledKey, "true")},
+						[]tag.Mutator{tag.Upsert(tagPolicyKey, policy), tag.Upsert(tagSampledKey, "true")},
 						statCountTracesSampled.M(int64(1)),
 					)
+					return false
+				}
+
+				var sampled, otherSampled bool
+				var threshold uint64
+				if tsp.samplerMode == proportionalMode {
+					sampled, threshold = tsp.proportionalDecision(s)
+					otherSampled = sampled
+					if tsp.parallelHashing {
+						otherSampled = tsp.hashSeedDecision(s)
+					}
 				} else {
+					sampled = tsp.hashSeedDecision(s)
+					otherSampled = sampled
+					if tsp.parallelHashing {
+						otherSampled, _ = tsp.proportionalDecision(s)
+					}
+				}
+
+				_ = stats.RecordWithTags(
+					ctx,
+					[]tag.Mutator{tag.Upsert(tagPolicyKey, policy), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
+					statCountTracesSampled.M(int64(1)),
+				)
+				if otherSampled != sampled {
 					_ = stats.RecordWithTags(
 						ctx,
-						[]tag.Mutator{tag.Upsert(tagPolicyKey, "trace_id_hash"), tag.Upsert(tagSampledKey, "false")},
-						statCountTracesSampled.M(int64(1)),
+						[]tag.Mutator{tag.Upsert(tagPolicyKey, policy), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
+						statCountSamplingMismatches.M(int64(1)),
 					)
 				}
+
+				// Only the decisions taken on the randomness of the trace are consistent with the
+				// threshold, the hash of the trace ID being unrelated to its randomness.
+				if sampled && tsp.samplerMode == proportionalMode {
+					setThreshold(s.TraceState(), threshold)
+				}
 				return !sampled
 			})
 			// Filter out empty ScopeMetrics
@@ -134,6 +169,25 @@ func (tsp *tracesamplerprocessor) processTraces(ctx context.Context, td ptrace.T
 	return td, nil
 }
 
+// hashSeedDecision samples the span by hashing its trace ID with the hash seed.
+func (tsp *tracesamplerprocessor) hashSeedDecision(s ptrace.Span) bool {
+	// If one assumes random trace ids hashing may seems avoidable, however, traces can be coming from sources
+	// with various different criteria to generate trace id and perhaps were already sampled without hashing.
+	// Hashing here prevents bias due to such systems.
+	tidBytes := s.TraceID()
+	return hash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < tsp.scaledSamplingRate
+}
+
+// proportionalDecision samples the span by comparing the randomness of its trace to the threshold, or to the
+// threshold it was sampled with upstream when higher, returning the threshold it was compared to.
+func (tsp *tracesamplerprocessor) proportionalDecision(s ptrace.Span) (bool, uint64) {
+	threshold := tsp.threshold
+	if incoming, ok := incomingThreshold(s.TraceState()); ok && incoming > threshold {
+		threshold = incoming
+	}
+	return threshold < maxThreshold && randomness(s.TraceState(), s.TraceID()) >= threshold, threshold
+}
+
 // parseSpanSamplingPriority checks if the span has the "sampling.priority" tag to
 // decide if the span should be sampled or not. The usage of the tag follows the
 // OpenTracing semantic tags:
@@ -139,6 +139,40 @@ func Test_tracesamplerprocessor_SamplingPercentageRange(t *testing.T) {
 			numTracesPerBatch: 1,
 			acceptableDelta:   0.0,
 		},
+		{
+			name: "proportional_sampling_small",
+			cfg: &Config{
+				ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				SamplingPercentage: 5,
+				SamplerMode:        proportionalMode,
+			},
+			numBatches:        1e5,
+			numTracesPerBatch: 2,
+			acceptableDelta:   0.2,
+		},
+		{
+			name: "proportional_sampling_medium",
+			cfg: &Config{
+				ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				SamplingPercentage: 50.0,
+				SamplerMode:        proportionalMode,
+				ParallelHashing:    true,
+			},
+			numBatches:        1e5,
+			numTracesPerBatch: 4,
+			acceptableDelta:   0.5,
+		},
+		{
+			name: "proportional_sampling_all",
+			cfg: &Config{
+				ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				SamplingPercentage: 100.0,
+				SamplerMode:        proportionalMode,
+			},
+			numBatches:        1e5,
+			numTracesPerBatch: 1,
+			acceptableDelta:   0.0,
+		},
 	}
 	const testSvcName = "test-svc"
 	for _, tt := range tests {
@@ -340,6 +374,104 @@ func Test_tracesamplerprocessor_SpanSamplingPriority(t *testing.T) {
 	}
 }
 
+// Test_tracesamplerprocessor_Threshold checks the thresholds written in the tracestate of the sampled spans.
+func Test_tracesamplerprocessor_Threshold(t *testing.T) {
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 0xf0, 0, 0, 0, 0, 0, 0})
+	tests := []struct {
+		name       string
+		cfg        *Config
+		traceState string
+		priority   bool
+		// the tracestate of the sampled span, empty if dropped
+		want string
+	}{
+		{
+			name: "hash_seed",
+			cfg: &Config{
+				SamplingPercentage: 100,
+			},
+			traceState: "vendor=value",
+			want:       "vendor=value",
+		},
+		{
+			name: "hash_seed_incoming_threshold",
+			cfg: &Config{
+				SamplingPercentage: 100,
+			},
+			traceState: "vendor=value,ot=rv:12345678901234;th:8",
+			want:       "vendor=value,ot=rv:12345678901234;th:8",
+		},
+		{
+			name: "proportional_trace_id_randomness",
+			cfg: &Config{
+				SamplingPercentage: 10,
+				SamplerMode:        proportionalMode,
+			},
+			want: "ot=th:e6666666666666",
+		},
+		{
+			name: "proportional_explicit_randomness",
+			cfg: &Config{
+				SamplingPercentage: 50,
+				SamplerMode:        proportionalMode,
+			},
+			traceState: "ot=rv:40000000000000",
+		},
+		{
+			name: "proportional_incoming_threshold",
+			cfg: &Config{
+				SamplingPercentage: 50,
+				SamplerMode:        proportionalMode,
+				ParallelHashing:    true,
+			},
+			traceState: "ot=th:c",
+			want:       "ot=th:c",
+		},
+		{
+			name: "proportional_higher_incoming_threshold",
+			cfg: &Config{
+				SamplingPercentage: 50,
+				SamplerMode:        proportionalMode,
+			},
+			traceState: "ot=th:fc;rv:f0000000000000",
+		},
+		{
+			name: "sampling_priority",
+			cfg: &Config{
+				SamplingPercentage: 0,
+				SamplerMode:        proportionalMode,
+			},
+			traceState: "ot=th:fc",
+			priority:   true,
+			want:       "ot=th:fc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			tsp, err := newTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), tt.cfg, sink)
+			require.NoError(t, err)
+
+			td := ptrace.NewTraces()
+			span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID(traceID)
+			span.TraceState().FromRaw(tt.traceState)
+			if tt.priority {
+				span.Attributes().PutInt("sampling.priority", 1)
+			}
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+
+			if tt.want == "" {
+				assert.Equal(t, 0, sink.SpanCount())
+				return
+			}
+			require.Equal(t, 1, sink.SpanCount())
+			sampled := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.want, sampled.TraceState().AsRaw())
+		})
+	}
+}
+
 // Test_parseSpanSamplingPriority ensures that the function parsing the attributes is taking "sampling.priority"
 // attribute correctly.
 func Test_parseSpanSamplingPriority(t *testing.T) {
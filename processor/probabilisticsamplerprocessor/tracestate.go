@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	// otTraceStateKey is the tracestate entry of OpenTelemetry, whose "th" and "rv" sub-keys hold the sampling
+	// threshold and the randomness of the trace.
+	otTraceStateKey = "ot"
+
+	thresholdKey  = "th"
+	randomnessKey = "rv"
+
+	// The threshold and the randomness are 56 bits values, encoded as up to 14 hexadecimal digits.
+	thresholdDigits = 14
+	maxThreshold    = uint64(1) << 56
+)
+
+// thresholdOf returns the threshold which the randomness of the sampled traces is greater than or equal to,
+// for the given sampling probability.
+func thresholdOf(probability float64) uint64 {
+	switch {
+	case probability >= 1:
+		return 0
+	case probability <= 0:
+		return maxThreshold
+	}
+	// the rejected part is computed from the sampled one, whose low bits fit in a float64
+	return maxThreshold - uint64(math.Round(probability*float64(maxThreshold)))
+}
+
+// encodeThreshold encodes the threshold as the "th" sub-key value, without its trailing zeros.
+func encodeThreshold(threshold uint64) string {
+	encoded := strconv.FormatUint(threshold, 16)
+	encoded = strings.Repeat("0", thresholdDigits-len(encoded)) + encoded
+	encoded = strings.TrimRight(encoded, "0")
+	if encoded == "" {
+		return "0"
+	}
+	return encoded
+}
+
+// decodeThreshold decodes a "th" sub-key value, the missing trailing digits being zeros.
+func decodeThreshold(value string) (uint64, bool) {
+	if value == "" || len(value) > thresholdDigits {
+		return 0, false
+	}
+	threshold, err := strconv.ParseUint(value+strings.Repeat("0", thresholdDigits-len(value)), 16, 64)
+	return threshold, err == nil
+}
+
+// otSubKeys returns the sub-keys of the "ot" entry of a tracestate, as "key:value" strings.
+func otSubKeys(traceState string) []string {
+	for _, entry := range strings.Split(traceState, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if found && key == otTraceStateKey {
+			return strings.Split(value, ";")
+		}
+	}
+	return nil
+}
+
+// otSubKey returns the value of a sub-key of the "ot" entry of a tracestate.
+func otSubKey(traceState string, key string) (string, bool) {
+	for _, subKey := range otSubKeys(traceState) {
+		k, v, found := strings.Cut(subKey, ":")
+		if found && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// incomingThreshold returns the threshold the span was sampled with upstream, if any.
+func incomingThreshold(traceState pcommon.TraceState) (uint64, bool) {
+	value, ok := otSubKey(traceState.AsRaw(), thresholdKey)
+	if !ok {
+		return 0, false
+	}
+	return decodeThreshold(value)
+}
+
+// randomness returns the randomness of the trace, either explicit in the "rv" sub-key of the tracestate, or the
+// 56 least significant bits of the trace ID.
+func randomness(traceState pcommon.TraceState, traceID pcommon.TraceID) uint64 {
+	if value, ok := otSubKey(traceState.AsRaw(), randomnessKey); ok && len(value) == thresholdDigits {
+		if rv, err := strconv.ParseUint(value, 16, 64); err == nil {
+			return rv
+		}
+	}
+	return binary.BigEndian.Uint64(traceID[8:]) & (maxThreshold - 1)
+}
+
+// setThreshold writes the threshold in the "ot" entry of the tracestate, which is moved first as modified
+// entries must be, keeping its other sub-keys and the other entries.
+func setThreshold(traceState pcommon.TraceState, threshold uint64) {
+	subKeys := []string{thresholdKey + ":" + encodeThreshold(threshold)}
+	for _, subKey := range otSubKeys(traceState.AsRaw()) {
+		if subKey != "" && !strings.HasPrefix(subKey, thresholdKey+":") {
+			subKeys = append(subKeys, subKey)
+		}
+	}
+
+	entries := []string{otTraceStateKey + "=" + strings.Join(subKeys, ";")}
+	if raw := traceState.AsRaw(); raw != "" {
+		for _, entry := range strings.Split(raw, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry != "" && !strings.HasPrefix(entry, otTraceStateKey+"=") {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	traceState.FromRaw(strings.Join(entries, ","))
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestThresholdEncoding(t *testing.T) {
+	tests := []struct {
+		probability float64
+		threshold   uint64
+		encoded     string
+	}{
+		{probability: 1, threshold: 0, encoded: "0"},
+		{probability: 0.5, threshold: 0x80000000000000, encoded: "8"},
+		{probability: 0.25, threshold: 0xc0000000000000, encoded: "c"},
+		{probability: 1.0 / 256, threshold: 0xff000000000000, encoded: "ff"},
+		{probability: 0.1, threshold: 0xe6666666666666, encoded: "e6666666666666"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoded, func(t *testing.T) {
+			assert.Equal(t, tt.threshold, thresholdOf(tt.probability))
+			assert.Equal(t, tt.encoded, encodeThreshold(tt.threshold))
+			decoded, ok := decodeThreshold(tt.encoded)
+			assert.True(t, ok)
+			assert.Equal(t, tt.threshold, decoded)
+		})
+	}
+
+	assert.Equal(t, maxThreshold, thresholdOf(0))
+	for _, invalid := range []string{"", "xyz", "123456789012345"} {
+		_, ok := decodeThreshold(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestTraceStateThreshold(t *testing.T) {
+	ts := pcommon.NewTraceState()
+	_, ok := incomingThreshold(ts)
+	assert.False(t, ok)
+
+	setThreshold(ts, 0xc0000000000000)
+	assert.Equal(t, "ot=th:c", ts.AsRaw())
+
+	ts.FromRaw("a=b, ot=rv:0123456789abcd;th:8;x:y ,c=d")
+	threshold, ok := incomingThreshold(ts)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0x80000000000000), threshold)
+	assert.Equal(t, uint64(0x0123456789abcd), randomness(ts, pcommon.NewTraceIDEmpty()))
+
+	setThreshold(ts, 0xe0000000000000)
+	assert.Equal(t, "ot=th:e;rv:0123456789abcd;x:y,a=b,c=d", ts.AsRaw())
+}
+
+func TestTraceIDRandomness(t *testing.T) {
+	traceID := pcommon.TraceID([16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 1, 2, 3, 4, 5, 6, 7})
+	assert.Equal(t, uint64(0x01020304050607), randomness(pcommon.NewTraceState(), traceID))
+}
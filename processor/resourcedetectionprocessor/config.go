@@ -15,6 +15,9 @@
 package resourcedetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
 
 import (
+	"fmt"
+	"time"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 
@@ -42,6 +45,15 @@ type Config struct {
 	// Attributes is an allowlist of attributes to add.
 	// If a supplied attribute is not a valid atrtibute of a supplied detector it will be ignored.
 	Attributes []string `mapstructure:"attributes"`
+	// DetectorTimeouts are the timeouts of the individual detectors, which run in parallel.
+	// The timeout of a detector defaults to the timeout of the HTTP client.
+	DetectorTimeouts map[string]time.Duration `mapstructure:"detector_timeouts"`
+	// CacheFile is the file caching the detected resource across restarts, so that the
+	// processor starts without waiting for the detectors. Disabled when empty.
+	CacheFile string `mapstructure:"cache_file"`
+	// RefreshInterval is the interval at which the detectors are run again, to pick up
+	// the changes of the resource. The detectors are only run at startup when zero.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
 }
 
 // DetectorConfig contains user-specified configurations unique to all individual detectors
@@ -71,5 +83,25 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 
 // Validate config
 func (cfg *Config) Validate() error {
+	for detector, timeout := range cfg.DetectorTimeouts {
+		if !cfg.hasDetector(detector) {
+			return fmt.Errorf("detector_timeouts: detector %q is not configured", detector)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("detector_timeouts: the timeout of detector %q must be positive", detector)
+		}
+	}
+	if cfg.RefreshInterval < 0 {
+		return fmt.Errorf("refresh_interval must not be negative")
+	}
 	return cfg.DetectorConfig.SystemConfig.Validate()
 }
+
+func (cfg *Config) hasDetector(detector string) bool {
+	for _, configured := range cfg.Detectors {
+		if configured == detector {
+			return true
+		}
+	}
+	return false
+}
@@ -79,10 +79,26 @@ func TestLoadConfig(t *testing.T) {
 				Attributes:         []string{"a", "b"},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "refresh"),
+			expected: &Config{
+				ProcessorSettings:  config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Detectors:          []string{"env", "ec2", "system"},
+				HTTPClientSettings: cfg,
+				Override:           true,
+				DetectorTimeouts:   map[string]time.Duration{"ec2": 500 * time.Millisecond},
+				CacheFile:          "/var/lib/otelcol/resource.json",
+				RefreshInterval:    5 * time.Minute,
+			},
+		},
 		{
 			id:           config.NewComponentIDWithName(typeStr, "invalid"),
 			errorMessage: "hostname_sources contains invalid value: \"invalid_source\"",
 		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "invalid_detector_timeout"),
+			errorMessage: "detector_timeouts: detector \"ec2\" is not configured",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
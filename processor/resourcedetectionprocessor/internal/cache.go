@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+
+import (
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// readCache reads the resource cached in the cache file. The resource is stored as
+// the resource of OTLP JSON traces, which keeps the types of the attributes and the schema URL.
+func (p *ResourceProvider) readCache() (*resourceResult, bool) {
+	if p.cacheFile == "" {
+		return nil, false
+	}
+
+	buf, err := os.ReadFile(p.cacheFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			p.logger.Warn("failed to read the cached resource information", zap.Error(err))
+		}
+		return nil, false
+	}
+
+	td, err := ptrace.NewJSONUnmarshaler().UnmarshalTraces(buf)
+	if err != nil || td.ResourceSpans().Len() != 1 {
+		p.logger.Warn("ignoring invalid cached resource information", zap.String("file", p.cacheFile))
+		return nil, false
+	}
+
+	rs := td.ResourceSpans().At(0)
+	return &resourceResult{
+		resource:  rs.Resource(),
+		schemaURL: rs.SchemaUrl(),
+	}, true
+}
+
+// writeCache writes the detected resource to the cache file, replacing it atomically.
+func (p *ResourceProvider) writeCache() {
+	if p.cacheFile == "" {
+		return
+	}
+
+	resource, schemaURL, _ := p.Current()
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	resource.CopyTo(rs.Resource())
+	rs.SetSchemaUrl(schemaURL)
+
+	buf, err := ptrace.NewJSONMarshaler().MarshalTraces(td)
+	if err == nil {
+		err = writeFileAtomically(p.cacheFile, buf)
+	}
+	if err != nil {
+		p.logger.Warn("failed to cache the resource information", zap.Error(err))
+	}
+}
+
+func writeFileAtomically(name string, buf []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(buf); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
@@ -25,6 +25,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
@@ -42,6 +43,21 @@ type ResourceDetectorConfig interface {
 
 type DetectorFactory func(component.ProcessorCreateSettings, DetectorConfig) (Detector, error)
 
+// ProviderSettings configures how a resource provider runs its detectors.
+type ProviderSettings struct {
+	// DetectorTimeouts are the timeouts of the individual detectors, defaulting to
+	// the timeout of the HTTP client.
+	DetectorTimeouts map[DetectorType]time.Duration
+
+	// CacheFile is the file caching the detected resource across restarts. When the file
+	// exists, the cached resource is used right away while the detection runs in the background.
+	CacheFile string
+
+	// RefreshInterval is the interval at which the detection is run again, to pick up
+	// the changes of the resource. The detection is only run once when zero.
+	RefreshInterval time.Duration
+}
+
 type ResourceProviderFactory struct {
 	// detectors holds all possible detector types.
 	detectors map[DetectorType]DetectorFactory
@@ -56,6 +72,7 @@ func (f *ResourceProviderFactory) CreateResourceProvider(
 	timeout time.Duration,
 	attributes []string,
 	detectorConfigs ResourceDetectorConfig,
+	settings ProviderSettings,
 	detectorTypes ...DetectorType) (*ResourceProvider, error) {
 	detectors, err := f.getDetectors(params, detectorConfigs, detectorTypes)
 	if err != nil {
@@ -70,6 +87,12 @@ func (f *ResourceProviderFactory) CreateResourceProvider(
 	}
 
 	provider := NewResourceProvider(params.Logger, timeout, attributesToKeep, detectors...)
+	provider.detectorTimeouts = make([]time.Duration, len(detectorTypes))
+	for i, detectorType := range detectorTypes {
+		provider.detectorTimeouts[i] = settings.DetectorTimeouts[detectorType]
+	}
+	provider.cacheFile = settings.CacheFile
+	provider.refreshInterval = settings.RefreshInterval
 	return provider, nil
 }
 
@@ -96,9 +119,23 @@ type ResourceProvider struct {
 	logger           *zap.Logger
 	timeout          time.Duration
 	detectors        []Detector
-	detectedResource *resourceResult
+	detectorTimeouts []time.Duration
+	cacheFile        string
+	refreshInterval  time.Duration
 	once             sync.Once
 	attributesToKeep map[string]struct{}
+
+	// the detected resource is replaced when the detection runs again
+	lock             sync.RWMutex
+	detectedResource *resourceResult
+
+	// the resource is refreshed in the background while the provider is used by started processors,
+	// the provider being shared by the processors of the different pipelines
+	runLock        sync.Mutex
+	users          int
+	stopCh         chan struct{}
+	stopped        sync.WaitGroup
+	refreshPending bool
 }
 
 type resourceResult struct {
@@ -116,32 +153,175 @@ func NewResourceProvider(logger *zap.Logger, timeout time.Duration, attributesTo
 	}
 }
 
+// Get returns the detected resource, running the detection on the first call. When the
+// resource is cached, the cached resource is returned, and the detection runs in the background.
+// Every call starts the background refresh of the resource until the matching call to Stop.
 func (p *ResourceProvider) Get(ctx context.Context, client *http.Client) (resource pcommon.Resource, schemaURL string, err error) {
 	p.once.Do(func() {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, client.Timeout)
-		defer cancel()
-		p.detectResource(ctx)
+		if cached, ok := p.readCache(); ok {
+			p.logger.Info("using the cached resource information", zap.String("file", p.cacheFile))
+			p.setResult(cached)
+			p.refreshPending = true
+			return
+		}
+
+		result, err := p.detectResource(ctx, client)
+		p.setResult(result)
+		if err == nil {
+			p.writeCache()
+		}
 	})
 
+	p.start(client)
+	return p.Current()
+}
+
+// Current returns the latest detected resource.
+func (p *ResourceProvider) Current() (resource pcommon.Resource, schemaURL string, err error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.detectedResource == nil {
+		return pcommon.NewResource(), "", nil
+	}
 	return p.detectedResource.resource, p.detectedResource.schemaURL, p.detectedResource.err
 }
 
-func (p *ResourceProvider) detectResource(ctx context.Context) {
-	p.detectedResource = &resourceResult{}
+// start starts the background refresh of the resource when the provider isn't already in use.
+func (p *ResourceProvider) start(client *http.Client) {
+	p.runLock.Lock()
+	defer p.runLock.Unlock()
+
+	p.users++
+	if p.stopCh != nil || (!p.refreshPending && p.refreshInterval <= 0) {
+		return
+	}
+	stopCh := make(chan struct{})
+	p.stopCh = stopCh
+	p.stopped.Add(1)
+	go func() {
+		defer p.stopped.Done()
+		p.refreshPeriodically(client, stopCh)
+	}()
+}
+
+// Stop stops the background refresh of the resource once it isn't used by any started processor.
+func (p *ResourceProvider) Stop() {
+	p.runLock.Lock()
+	if p.users > 0 {
+		p.users--
+	}
+	stopCh := p.stopCh
+	if p.users > 0 || stopCh == nil {
+		p.runLock.Unlock()
+		return
+	}
+	p.stopCh = nil
+	p.runLock.Unlock()
+
+	close(stopCh)
+	p.stopped.Wait()
+}
+
+func (p *ResourceProvider) setResult(result *resourceResult) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.detectedResource = result
+}
+
+// refresh runs the detection again, keeping the previous resource when one of the detectors fails.
+func (p *ResourceProvider) refresh(client *http.Client, stopCh chan struct{}) {
+	ctx, cancel := context.WithCancel(ContextWithClient(context.Background(), client))
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	result, err := p.detectResource(ctx, client)
+	select {
+	case <-stopCh:
+		return
+	default:
+	}
+	if err != nil {
+		p.logger.Warn("keeping the previous resource information", zap.Error(err))
+		return
+	}
+	p.setResult(result)
+	p.writeCache()
+
+	p.runLock.Lock()
+	p.refreshPending = false
+	p.runLock.Unlock()
+}
+
+func (p *ResourceProvider) refreshPeriodically(client *http.Client, stopCh chan struct{}) {
+	p.runLock.Lock()
+	pending := p.refreshPending
+	p.runLock.Unlock()
+	if pending {
+		p.refresh(client, stopCh)
+	}
+	if p.refreshInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(p.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.refresh(client, stopCh)
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// detectResource runs the detectors in parallel, each with its own timeout, and merges the
+// detected resources in the order of the detectors. The errors of the detectors failing are
+// returned along with the resource detected by the others.
+func (p *ResourceProvider) detectResource(ctx context.Context, client *http.Client) (*resourceResult, error) {
+	detectedResource := &resourceResult{}
 
 	res := pcommon.NewResource()
 	mergedSchemaURL := ""
 
 	p.logger.Info("began detecting resource information")
 
-	for _, detector := range p.detectors {
-		r, schemaURL, err := detector.Detect(ctx)
-		if err != nil {
-			p.logger.Warn("failed to detect resource", zap.Error(err))
+	results := make([]resourceResult, len(p.detectors))
+	var wg sync.WaitGroup
+	for i, detector := range p.detectors {
+		timeout := client.Timeout
+		if i < len(p.detectorTimeouts) && p.detectorTimeouts[i] > 0 {
+			timeout = p.detectorTimeouts[i]
+		}
+
+		wg.Add(1)
+		go func(i int, detector Detector, timeout time.Duration) {
+			defer wg.Done()
+			detectorCtx := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				detectorCtx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			results[i].resource, results[i].schemaURL, results[i].err = detector.Detect(detectorCtx)
+		}(i, detector, timeout)
+	}
+	wg.Wait()
+
+	var errs error
+	for _, result := range results {
+		if result.err != nil {
+			p.logger.Warn("failed to detect resource", zap.Error(result.err))
+			errs = multierr.Append(errs, result.err)
 		} else {
-			mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, schemaURL)
-			MergeResource(res, r, false)
+			mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, result.schemaURL)
+			MergeResource(res, result.resource, false)
 		}
 	}
 
@@ -152,8 +332,9 @@ func (p *ResourceProvider) detectResource(ctx context.Context) {
 		p.logger.Info("dropped resource information", zap.Strings("resource keys", droppedAttributes))
 	}
 
-	p.detectedResource.resource = res
-	p.detectedResource.schemaURL = mergedSchemaURL
+	detectedResource.resource = res
+	detectedResource.schemaURL = mergedSchemaURL
+	return detectedResource, errs
 }
 
 func AttributesToMap(am pcommon.Map) map[string]interface{} {
@@ -19,7 +19,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -110,7 +113,7 @@ func TestDetect(t *testing.T) {
 			}
 
 			f := NewProviderFactory(mockDetectors)
-			p, err := f.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, tt.attributes, &mockDetectorConfig{}, mockDetectorTypes...)
+			p, err := f.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, tt.attributes, &mockDetectorConfig{}, ProviderSettings{}, mockDetectorTypes...)
 			require.NoError(t, err)
 
 			got, _, err := p.Get(context.Background(), http.DefaultClient)
@@ -126,7 +129,7 @@ func TestDetect(t *testing.T) {
 func TestDetectResource_InvalidDetectorType(t *testing.T) {
 	mockDetectorKey := DetectorType("mock")
 	p := NewProviderFactory(map[DetectorType]DetectorFactory{})
-	_, err := p.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, nil, &mockDetectorConfig{}, mockDetectorKey)
+	_, err := p.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, nil, &mockDetectorConfig{}, ProviderSettings{}, mockDetectorKey)
 	require.EqualError(t, err, fmt.Sprintf("invalid detector key: %v", mockDetectorKey))
 }
 
@@ -137,7 +140,7 @@ func TestDetectResource_DetectoryFactoryError(t *testing.T) {
 			return nil, errors.New("creation failed")
 		},
 	})
-	_, err := p.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, nil, &mockDetectorConfig{}, mockDetectorKey)
+	_, err := p.CreateResourceProvider(componenttest.NewNopProcessorCreateSettings(), time.Second, nil, &mockDetectorConfig{}, ProviderSettings{}, mockDetectorKey)
 	require.EqualError(t, err, fmt.Sprintf("failed creating detector type %q: %v", mockDetectorKey, "creation failed"))
 }
 
@@ -249,6 +252,166 @@ func TestDetectResource_Parallel(t *testing.T) {
 	md3.AssertNumberOfCalls(t, "Detect", 1)
 }
 
+type slowDetector struct {
+	resource pcommon.Resource
+	delay    time.Duration
+}
+
+func (d *slowDetector) Detect(ctx context.Context) (pcommon.Resource, string, error) {
+	select {
+	case <-time.After(d.delay):
+		return d.resource, "", nil
+	case <-ctx.Done():
+		return pcommon.NewResource(), "", ctx.Err()
+	}
+}
+
+func TestDetectResource_DetectorTimeouts(t *testing.T) {
+	md1 := &slowDetector{resource: NewResource(map[string]interface{}{"a": "1"}), delay: time.Hour}
+	md2 := &slowDetector{resource: NewResource(map[string]interface{}{"a": "11", "b": "2"})}
+	md3 := &slowDetector{resource: NewResource(map[string]interface{}{"b": "22", "c": "3"})}
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2, md3)
+	p.detectorTimeouts = []time.Duration{10 * time.Millisecond, 0, 0}
+
+	detected, _, err := p.Get(context.Background(), &http.Client{Timeout: time.Minute})
+	require.NoError(t, err)
+	detected.Attributes().Sort()
+
+	expected := NewResource(map[string]interface{}{"a": "11", "b": "2", "c": "3"})
+	expected.Attributes().Sort()
+	assert.Equal(t, expected, detected)
+}
+
+func TestDetectResource_Cache(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "resource.json")
+	expected := NewResource(map[string]interface{}{"a": "1", "b": int64(2)})
+
+	md1 := &MockDetector{}
+	md1.On("Detect").Return(expected, nil)
+	p1 := NewResourceProvider(zap.NewNop(), time.Second, nil, md1)
+	p1.cacheFile = cacheFile
+	_, _, err := p1.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	require.FileExists(t, cacheFile)
+
+	// the cached resource is returned without waiting for the detector
+	md2 := NewMockParallelDetector()
+	md2.On("Detect").Return(NewResource(map[string]interface{}{"a": "11"}), nil)
+	p2 := NewResourceProvider(zap.NewNop(), time.Second, nil, md2)
+	p2.cacheFile = cacheFile
+	defer p2.Stop()
+
+	detected, _, err := p2.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, expected, detected)
+
+	// the resource is replaced once the detection running in the background completes
+	md2.ch <- struct{}{}
+	assert.Eventually(t, func() bool {
+		detected, _, _ = p2.Current()
+		value, ok := detected.Attributes().Get("a")
+		return ok && value.Str() == "11"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestDetectResource_InvalidCache(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "resource.json")
+	require.NoError(t, os.WriteFile(cacheFile, []byte("invalid"), 0600))
+
+	expected := NewResource(map[string]interface{}{"a": "1"})
+	md := &MockDetector{}
+	md.On("Detect").Return(expected, nil)
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	p.cacheFile = cacheFile
+
+	detected, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, expected, detected)
+	md.AssertNumberOfCalls(t, "Detect", 1)
+}
+
+func TestDetectResource_RefreshInterval(t *testing.T) {
+	md := &MockDetector{}
+	md.On("Detect").Return(NewResource(map[string]interface{}{"a": "1"}), nil).Once()
+	md.On("Detect").Return(NewResource(map[string]interface{}{"a": "2"}), nil)
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	p.refreshInterval = 10 * time.Millisecond
+
+	detected, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, NewResource(map[string]interface{}{"a": "1"}), detected)
+
+	assert.Eventually(t, func() bool {
+		detected, _, _ = p.Current()
+		value, ok := detected.Attributes().Get("a")
+		return ok && value.Str() == "2"
+	}, time.Second, 5*time.Millisecond)
+	p.Stop()
+}
+
+func TestDetectResource_RefreshError(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "resource.json")
+	var failures int32
+	md := &MockDetector{}
+	md.On("Detect").Return(NewResource(map[string]interface{}{"a": "1"}), nil).Once()
+	md.On("Detect").Return(pcommon.NewResource(), errors.New("err1")).Run(func(mock.Arguments) {
+		atomic.AddInt32(&failures, 1)
+	})
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	p.cacheFile = cacheFile
+	p.refreshInterval = 10 * time.Millisecond
+	defer p.Stop()
+
+	_, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	cached, err := os.ReadFile(cacheFile)
+	require.NoError(t, err)
+
+	// the resource and its cache are kept when the detection fails again
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&failures) >= 2
+	}, time.Second, 5*time.Millisecond)
+	detected, _, _ := p.Current()
+	assert.Equal(t, NewResource(map[string]interface{}{"a": "1"}), detected)
+	current, err := os.ReadFile(cacheFile)
+	require.NoError(t, err)
+	assert.Equal(t, cached, current)
+}
+
+func TestDetectResource_RestartRefresh(t *testing.T) {
+	var detections int32
+	md := &MockDetector{}
+	md.On("Detect").Return(NewResource(map[string]interface{}{"a": "1"}), nil).Run(func(mock.Arguments) {
+		atomic.AddInt32(&detections, 1)
+	})
+
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md)
+	p.refreshInterval = 10 * time.Millisecond
+
+	// the provider is shared by the processors of the different pipelines
+	for i := 0; i < 2; i++ {
+		_, _, err := p.Get(context.Background(), http.DefaultClient)
+		require.NoError(t, err)
+	}
+	p.Stop()
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&detections) >= 3
+	}, time.Second, 5*time.Millisecond, "the refresh stopped while the provider is still used")
+	p.Stop()
+
+	// the refresh runs again once the processors are restarted
+	count := atomic.LoadInt32(&detections)
+	_, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&detections) >= count+2
+	}, time.Second, 5*time.Millisecond)
+	p.Stop()
+}
+
 func TestFilterAttributes_Match(t *testing.T) {
 	m := map[string]struct{}{
 		"host.name": {},
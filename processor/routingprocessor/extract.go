@@ -18,6 +18,7 @@ import (
 	"context"
 	"strings"
 
+	"go.opentelemetry.io/collector/client"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
 )
@@ -40,18 +41,23 @@ func newExtractor(fromAttr string, logger *zap.Logger) extractor {
 }
 
 func (e extractor) extractFromContext(ctx context.Context) string {
-	// right now, we only support looking up attributes from requests that have
-	// gone through the gRPC server in that case, it will add the HTTP headers
-	// as context metadata
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return ""
-	}
+	// the client metadata holds the gRPC metadata or the HTTP headers of the
+	// request when the receiver has include_metadata enabled, and is kept in
+	// the context passed down the pipeline
+	values := client.FromContext(ctx).Metadata.Get(e.fromAttr)
+	if len(values) == 0 {
+		// otherwise, fall back to the metadata the gRPC server adds to the
+		// context of the requests
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return ""
+		}
 
-	// we have gRPC metadata in the context but does it have our key?
-	values, ok := md[strings.ToLower(e.fromAttr)]
-	if !ok {
-		return ""
+		// we have gRPC metadata in the context but does it have our key?
+		values, ok = md[strings.ToLower(e.fromAttr)]
+		if !ok {
+			return ""
+		}
 	}
 
 	if len(values) > 1 {
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/client"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
 )
@@ -66,6 +67,42 @@ func TestExtractorForTraces_FromContext(t *testing.T) {
 			fromAttr:      "X-Tenant",
 			expectedValue: "globex",
 		},
+		{
+			name: "value from client metadata",
+			ctxFunc: func() context.Context {
+				return client.NewContext(context.Background(), client.Info{
+					Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"acme"}}),
+				})
+			},
+			fromAttr:      "X-Tenant",
+			expectedValue: "acme",
+		},
+		{
+			name: "client metadata takes precedence over GRPC attribute",
+			ctxFunc: func() context.Context {
+				ctx := metadata.NewIncomingContext(context.Background(),
+					metadata.Pairs("X-Tenant", "globex"),
+				)
+				return client.NewContext(ctx, client.Info{
+					Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {"acme"}}),
+				})
+			},
+			fromAttr:      "X-Tenant",
+			expectedValue: "acme",
+		},
+		{
+			name: "value from GRPC attribute when missing from client metadata",
+			ctxFunc: func() context.Context {
+				ctx := metadata.NewIncomingContext(context.Background(),
+					metadata.Pairs("X-Tenant", "globex"),
+				)
+				return client.NewContext(ctx, client.Info{
+					Metadata: client.NewMetadata(map[string][]string{"X-Other": {"acme"}}),
+				})
+			},
+			fromAttr:      "X-Tenant",
+			expectedValue: "globex",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -18,6 +18,12 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	delta      = "AGGREGATION_TEMPORALITY_DELTA"
+	cumulative = "AGGREGATION_TEMPORALITY_CUMULATIVE"
 )
 
 // Config defines the configuration options for servicegraphprocessor.
@@ -42,6 +48,14 @@ type Config struct {
 
 	// Store contains the config for the in-memory store used to find requests between services by pairing spans.
 	Store StoreConfig `mapstructure:"store"`
+
+	// AggregationTemporality is the aggregation temporality of the metrics, either
+	// "AGGREGATION_TEMPORALITY_CUMULATIVE" or "AGGREGATION_TEMPORALITY_DELTA".
+	AggregationTemporality string `mapstructure:"aggregation_temporality"`
+
+	// GroupByResource emits the metrics under the resources of the client spans of the requests, or of the
+	// server spans when the client isn't traced, instead of under a single resource without attributes.
+	GroupByResource bool `mapstructure:"group_by_resource"`
 }
 
 type StoreConfig struct {
@@ -50,3 +64,12 @@ type StoreConfig struct {
 	// TTL is the time to live for items in the store.
 	TTL time.Duration `mapstructure:"ttl"`
 }
+
+// GetAggregationTemporality converts the string value given in the config into a MetricAggregationTemporality.
+// Returns cumulative, unless delta is correctly specified.
+func (c Config) GetAggregationTemporality() pmetric.MetricAggregationTemporality {
+	if c.AggregationTemporality == delta {
+		return pmetric.MetricAggregationTemporalityDelta
+	}
+	return pmetric.MetricAggregationTemporalityCumulative
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/service/servicetest"
 )
 
@@ -49,7 +50,20 @@ func TestLoadConfig(t *testing.T) {
 				TTL:      time.Second,
 				MaxItems: 10,
 			},
+			AggregationTemporality: delta,
+			GroupByResource:        true,
 		},
 		cfg.Processors[config.NewComponentID(typeStr)],
 	)
 }
+
+func TestGetAggregationTemporality(t *testing.T) {
+	cfg := &Config{AggregationTemporality: delta}
+	assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, cfg.GetAggregationTemporality())
+
+	cfg = &Config{AggregationTemporality: cumulative}
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, cfg.GetAggregationTemporality())
+
+	cfg = &Config{}
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, cfg.GetAggregationTemporality())
+}
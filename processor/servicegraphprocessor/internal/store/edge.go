@@ -44,15 +44,21 @@ type Edge struct {
 	// Additional dimension to add to the metrics
 	Dimensions map[string]string
 
+	// The attributes of the resources of the client and the server spans,
+	// only set when the metrics are grouped by resource.
+	ClientResource, ServerResource pcommon.Map
+
 	// expiration is the time at which the Edge expires, expressed as Unix time
 	expiration time.Time
 }
 
 func newEdge(key string, ttl time.Duration) *Edge {
 	return &Edge{
-		key:        key,
-		Dimensions: make(map[string]string),
-		expiration: time.Now().Add(ttl),
+		key:            key,
+		Dimensions:     make(map[string]string),
+		ClientResource: pcommon.NewMap(),
+		ServerResource: pcommon.NewMap(),
+		expiration:     time.Now().Add(ttl),
 	}
 }
 
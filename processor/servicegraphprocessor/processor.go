@@ -48,6 +48,7 @@ var (
 
 type metricSeries struct {
 	dimensions  pcommon.Map
+	resourceKey string
 	lastUpdated int64 // Used to remove stale series
 }
 
@@ -73,6 +74,9 @@ type processor struct {
 
 	keyToMetric map[string]metricSeries
 
+	// The attributes of the resources of the series, when the metrics are grouped by resource.
+	resources map[string]pcommon.Map
+
 	shutdownCh chan interface{}
 }
 
@@ -96,6 +100,7 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		reqDurationBounds:              bounds,
 		reqDurationSecondsBucketCounts: make(map[string][]uint64),
 		keyToMetric:                    make(map[string]metricSeries),
+		resources:                      make(map[string]pcommon.Map),
 		shutdownCh:                     make(chan interface{}),
 	}
 
@@ -205,6 +210,9 @@ func (p *processor) aggregateMetrics(ctx context.Context, td ptrace.Traces) (err
 						e.ClientLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
 						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
 						p.upsertDimensions(e.Dimensions, rAttributes, span.Attributes())
+						if p.config.GroupByResource {
+							rAttributes.CopyTo(e.ClientResource)
+						}
 
 						// A database request will only have one span, we don't wait for the server
 						// span but just copy details from the client span
@@ -228,6 +236,9 @@ func (p *processor) aggregateMetrics(ctx context.Context, td ptrace.Traces) (err
 						e.ServerLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
 						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
 						p.upsertDimensions(e.Dimensions, rAttributes, span.Attributes())
+						if p.config.GroupByResource {
+							rAttributes.CopyTo(e.ServerResource)
+						}
 					})
 				default:
 					// this span is not part of an edge
@@ -288,12 +299,29 @@ func (p *processor) aggregateMetricsForEdge(e *store.Edge) {
 	metricKey := p.buildMetricKey(e.ClientService, e.ServerService, string(e.ConnectionType), e.Dimensions)
 	dimensions := buildDimensions(e)
 
+	// The metrics are grouped by the resource of the client, or of the server when the client isn't traced,
+	// such as a database or a messaging system.
+	resourceKey := ""
+	resource := e.ClientResource
+	if resource.Len() == 0 {
+		resource = e.ServerResource
+	}
+	if p.config.GroupByResource {
+		resourceKey = buildResourceKey(resource)
+		if resourceKey != "" {
+			metricKey = resourceKey + metricKeySeparator + metricKey
+		}
+	}
+
 	// TODO: Consider configuring server or client latency
 	duration := e.ServerLatencySec
 
 	p.seriesMutex.Lock()
 	defer p.seriesMutex.Unlock()
-	p.updateSeries(metricKey, dimensions)
+	if _, ok := p.resources[resourceKey]; resourceKey != "" && !ok {
+		p.resources[resourceKey] = resource
+	}
+	p.updateSeries(metricKey, resourceKey, dimensions)
 	p.updateCountMetrics(metricKey)
 	if e.Failed {
 		p.updateErrorMetrics(metricKey)
@@ -301,10 +329,11 @@ func (p *processor) aggregateMetricsForEdge(e *store.Edge) {
 	p.updateDurationMetrics(metricKey, duration)
 }
 
-func (p *processor) updateSeries(key string, dimensions pcommon.Map) {
+func (p *processor) updateSeries(key string, resourceKey string, dimensions pcommon.Map) {
 	// Overwrite the series if it already exists
 	p.keyToMetric[key] = metricSeries{
 		dimensions:  dimensions,
+		resourceKey: resourceKey,
 		lastUpdated: time.Now().UnixMilli(),
 	}
 }
@@ -345,31 +374,63 @@ func buildDimensions(e *store.Edge) pcommon.Map {
 
 func (p *processor) buildMetrics() (pmetric.Metrics, error) {
 	m := pmetric.NewMetrics()
-	ilm := m.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
-	ilm.Scope().SetName("traces_service_graph_servicegraphprocessor")
+	scopes := make(map[string]pmetric.ScopeMetrics)
+	scopeMetrics := func(key string) pmetric.ScopeMetrics {
+		resourceKey := p.keyToMetric[key].resourceKey
+		ilm, ok := scopes[resourceKey]
+		if !ok {
+			rm := m.ResourceMetrics().AppendEmpty()
+			if attrs, ok := p.resources[resourceKey]; ok {
+				attrs.CopyTo(rm.Resource().Attributes())
+			}
+			ilm = rm.ScopeMetrics().AppendEmpty()
+			ilm.Scope().SetName("traces_service_graph_servicegraphprocessor")
+			scopes[resourceKey] = ilm
+		}
+		return ilm
+	}
 
 	// Obtain write lock to reset data
 	p.seriesMutex.Lock()
 	defer p.seriesMutex.Unlock()
 
-	if err := p.collectCountMetrics(ilm); err != nil {
+	if !p.config.GroupByResource {
+		// All the metrics are emitted under a single resource, even when there are none.
+		scopeMetrics("")
+	}
+
+	if err := p.collectCountMetrics(scopeMetrics); err != nil {
 		return m, err
 	}
 
-	if err := p.collectLatencyMetrics(ilm); err != nil {
+	if err := p.collectLatencyMetrics(scopeMetrics); err != nil {
 		return m, err
 	}
 
+	// If delta metrics, reset accumulated data, the following data points start from now
+	if p.config.GetAggregationTemporality() == pmetric.MetricAggregationTemporalityDelta {
+		p.resetAccumulatedMetrics()
+	}
+
 	return m, nil
 }
 
-func (p *processor) collectCountMetrics(ilm pmetric.ScopeMetrics) error {
+// resetAccumulatedMetrics resets the accumulated metrics, which start again from now.
+func (p *processor) resetAccumulatedMetrics() {
+	p.startTime = time.Now()
+	p.reqTotal = make(map[string]int64)
+	p.reqFailedTotal = make(map[string]int64)
+	p.reqDurationSecondsSum = make(map[string]float64)
+	p.reqDurationSecondsCount = make(map[string]uint64)
+	p.reqDurationSecondsBucketCounts = make(map[string][]uint64)
+}
+
+func (p *processor) collectCountMetrics(scopeMetrics func(key string) pmetric.ScopeMetrics) error {
 	for key, c := range p.reqTotal {
-		mCount := ilm.Metrics().AppendEmpty()
+		mCount := scopeMetrics(key).Metrics().AppendEmpty()
 		mCount.SetName("traces_service_graph_request_total")
 		mCount.SetEmptySum().SetIsMonotonic(true)
-		// TODO: Support other aggregation temporalities
-		mCount.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		mCount.Sum().SetAggregationTemporality(p.config.GetAggregationTemporality())
 
 		dpCalls := mCount.Sum().DataPoints().AppendEmpty()
 		dpCalls.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
@@ -385,11 +446,10 @@ func (p *processor) collectCountMetrics(ilm pmetric.ScopeMetrics) error {
 	}
 
 	for key, c := range p.reqFailedTotal {
-		mCount := ilm.Metrics().AppendEmpty()
+		mCount := scopeMetrics(key).Metrics().AppendEmpty()
 		mCount.SetName("traces_service_graph_request_failed_total")
 		mCount.SetEmptySum().SetIsMonotonic(true)
-		// TODO: Support other aggregation temporalities
-		mCount.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		mCount.Sum().SetAggregationTemporality(p.config.GetAggregationTemporality())
 
 		dpCalls := mCount.Sum().DataPoints().AppendEmpty()
 		dpCalls.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
@@ -407,12 +467,11 @@ func (p *processor) collectCountMetrics(ilm pmetric.ScopeMetrics) error {
 	return nil
 }
 
-func (p *processor) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
+func (p *processor) collectLatencyMetrics(scopeMetrics func(key string) pmetric.ScopeMetrics) error {
 	for key := range p.reqDurationSecondsCount {
-		mDuration := ilm.Metrics().AppendEmpty()
+		mDuration := scopeMetrics(key).Metrics().AppendEmpty()
 		mDuration.SetName("traces_service_graph_request_duration_seconds")
-		// TODO: Support other aggregation temporalities
-		mDuration.SetEmptyHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+		mDuration.SetEmptyHistogram().SetAggregationTemporality(p.config.GetAggregationTemporality())
 
 		timestamp := pcommon.NewTimestampFromTime(time.Now())
 
@@ -478,8 +537,11 @@ func (p *processor) cacheLoop(d time.Duration) {
 
 }
 
-// cleanCache removes series that have not been updated in 15 minutes
+// cleanCache removes series that have not been updated in 15 minutes, and the resources of no series
 func (p *processor) cleanCache() {
+	p.seriesMutex.Lock()
+	defer p.seriesMutex.Unlock()
+
 	var staleSeries []string
 	for key, series := range p.keyToMetric {
 		if series.lastUpdated+15*time.Minute.Milliseconds() < time.Now().UnixMilli() {
@@ -490,6 +552,19 @@ func (p *processor) cleanCache() {
 	for _, key := range staleSeries {
 		delete(p.keyToMetric, key)
 	}
+
+	if len(p.resources) == 0 {
+		return
+	}
+	usedResources := make(map[string]struct{}, len(p.resources))
+	for _, series := range p.keyToMetric {
+		usedResources[series.resourceKey] = struct{}{}
+	}
+	for key := range p.resources {
+		if _, ok := usedResources[key]; !ok {
+			delete(p.resources, key)
+		}
+	}
 }
 
 func buildEdgeKey(k1, k2 string) string {
@@ -133,6 +133,68 @@ func TestProcessorConsume(t *testing.T) {
 	assert.NoError(t, processor.Shutdown(context.Background()))
 }
 
+func TestProcessorConsumeDeltaGroupByResource(t *testing.T) {
+	// Prepare
+	cfg := &Config{
+		MetricsExporter:        "mock",
+		Dimensions:             []string{"some-attribute"},
+		AggregationTemporality: delta,
+		GroupByResource:        true,
+		Store:                  StoreConfig{MaxItems: 10, TTL: time.Second},
+	}
+
+	var consumed []pmetric.Metrics
+	mockMetricsExporter := newMockMetricsExporter(func(md pmetric.Metrics) error {
+		consumed = append(consumed, md)
+		return nil
+	})
+
+	processor := newProcessor(zaptest.NewLogger(t), cfg, consumertest.NewNop())
+
+	mHost := &mockHost{
+		GetExportersFunc: func() map[config.DataType]map[config.ComponentID]component.Exporter {
+			return map[config.DataType]map[config.ComponentID]component.Exporter{
+				config.MetricsDataType: {
+					config.NewComponentID("mock"): mockMetricsExporter,
+				},
+			}
+		},
+	}
+
+	assert.NoError(t, processor.Start(context.Background(), mHost))
+
+	// Test
+	assert.NoError(t, processor.ConsumeTraces(context.Background(), sampleTraces()))
+	assert.NoError(t, processor.ConsumeTraces(context.Background(), sampleTraces()))
+
+	// Verify: each request is only reported once, under the resource of the spans
+	require.Len(t, consumed, 2)
+	for _, md := range consumed {
+		assert.Equal(t, 2, md.MetricCount())
+
+		rms := md.ResourceMetrics()
+		require.Equal(t, 1, rms.Len())
+		verifyAttr(t, rms.At(0).Resource().Attributes(), semconv.AttributeServiceName, "some-service")
+		verifyAttr(t, rms.At(0).Resource().Attributes(), "deployment.environment", "test")
+
+		ms := rms.At(0).ScopeMetrics().At(0).Metrics()
+		assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, ms.At(0).Sum().AggregationTemporality())
+		assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, ms.At(1).Histogram().AggregationTemporality())
+		assert.Equal(t, "traces_service_graph_request_total", ms.At(0).Name())
+		assert.Equal(t, int64(1), ms.At(0).Sum().DataPoints().At(0).IntValue())
+		verifyAttr(t, ms.At(0).Sum().DataPoints().At(0).Attributes(), "some-attribute", "val")
+		assert.Equal(t, "traces_service_graph_request_duration_seconds", ms.At(1).Name())
+		assert.Equal(t, uint64(1), ms.At(1).Histogram().DataPoints().At(0).Count())
+	}
+	assert.LessOrEqual(t,
+		consumed[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Timestamp(),
+		consumed[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).StartTimestamp(),
+	)
+
+	// Shutdown the processor
+	assert.NoError(t, processor.Shutdown(context.Background()))
+}
+
 func verifyMetrics(t *testing.T, md pmetric.Metrics) error {
 	assert.Equal(t, 2, md.MetricCount())
 
@@ -207,6 +269,7 @@ func sampleTraces() ptrace.Traces {
 
 	resourceSpans := traces.ResourceSpans().AppendEmpty()
 	resourceSpans.Resource().Attributes().PutStr(semconv.AttributeServiceName, "some-service")
+	resourceSpans.Resource().Attributes().PutStr("deployment.environment", "test")
 
 	scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
 
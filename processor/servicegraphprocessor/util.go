@@ -15,6 +15,9 @@
 package servicegraphprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor"
 
 import (
+	"sort"
+	"strings"
+
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	semconv "go.opentelemetry.io/collector/semconv/v1.9.0"
 )
@@ -31,3 +34,23 @@ func findAttributeValue(key string, attributes ...pcommon.Map) (string, bool) {
 func findServiceName(attributes pcommon.Map) (string, bool) {
 	return findAttributeValue(semconv.AttributeServiceName, attributes)
 }
+
+// buildResourceKey builds a key identifying a resource from its attributes, sorted by name.
+func buildResourceKey(attributes pcommon.Map) string {
+	names := make([]string, 0, attributes.Len())
+	attributes.Range(func(k string, _ pcommon.Value) bool {
+		names = append(names, k)
+		return true
+	})
+	sort.Strings(names)
+
+	var b strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(metricKeySeparator)
+		}
+		v, _ := attributes.Get(name)
+		b.WriteString(name + metricKeySeparator + v.AsString())
+	}
+	return b.String()
+}
@@ -60,6 +60,10 @@ type Config struct {
 
 	AggregationTemporality string `mapstructure:"aggregation_temporality"`
 
+	// GroupByResource emits the metrics under the resources of the spans they're generated from,
+	// instead of under a single resource without attributes.
+	GroupByResource bool `mapstructure:"group_by_resource"`
+
 	// skipSanitizeLabel if enabled, labels that start with _ are not sanitized
 	skipSanitizeLabel bool
 }
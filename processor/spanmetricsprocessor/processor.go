@@ -87,6 +87,10 @@ type processorImp struct {
 	// An LRU cache of dimension key-value maps keyed by a unique identifier formed by a concatenation of its values:
 	// e.g. { "foo/barOK": { "serviceName": "foo", "operation": "/bar", "status_code": "OK" }}
 	metricKeyToDimensions *cache.Cache
+
+	// The resources of the metrics, and their attributes, when the metrics are grouped by resource.
+	metricKeyToResource map[metricKey]string
+	resourceAttributes  map[string]pcommon.Map
 }
 
 func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer consumer.Traces) (*processorImp, error) {
@@ -126,6 +130,8 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		nextConsumer:          nextConsumer,
 		dimensions:            pConfig.Dimensions,
 		metricKeyToDimensions: metricKeyToDimensionsCache,
+		metricKeyToResource:   make(map[metricKey]string),
+		resourceAttributes:    make(map[string]pcommon.Map),
 	}, nil
 }
 
@@ -198,6 +204,17 @@ func (p *processorImp) Start(ctx context.Context, host component.Host) error {
 			break
 		}
 	}
+	if p.metricsExporter == nil {
+		// An extension consuming metrics, such as the jaegerremotesampling extension computing the
+		// sampling probabilities from the throughput of the operations, can be used as well.
+		for k, ext := range host.GetExtensions() {
+			if metricsExt, ok := ext.(component.MetricsExporter); ok && k.String() == p.config.MetricsExporter {
+				p.metricsExporter = metricsExt
+				p.logger.Info("Found extension", zap.String("spanmetrics-exporter", p.config.MetricsExporter))
+				break
+			}
+		}
+	}
 	if p.metricsExporter == nil {
 		return fmt.Errorf("failed to find metrics exporter: '%s'; please configure metrics_exporter from one of: %+v",
 			p.config.MetricsExporter, availableMetricsExporters)
@@ -253,14 +270,31 @@ func (p *processorImp) tracesToMetrics(ctx context.Context, traces ptrace.Traces
 // writes the raw metrics data into the metrics object.
 func (p *processorImp) buildMetrics() (pmetric.Metrics, error) {
 	m := pmetric.NewMetrics()
-	ilm := m.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
-	ilm.Scope().SetName("spanmetricsprocessor")
+	scopes := make(map[string]pmetric.ScopeMetrics)
+	scopeMetrics := func(key metricKey) pmetric.ScopeMetrics {
+		resourceKey := p.metricKeyToResource[key]
+		ilm, ok := scopes[resourceKey]
+		if !ok {
+			rm := m.ResourceMetrics().AppendEmpty()
+			if attrs, ok := p.resourceAttributes[resourceKey]; ok {
+				attrs.CopyTo(rm.Resource().Attributes())
+			}
+			ilm = rm.ScopeMetrics().AppendEmpty()
+			ilm.Scope().SetName("spanmetricsprocessor")
+			scopes[resourceKey] = ilm
+		}
+		return ilm
+	}
+	if !p.config.GroupByResource {
+		// All the metrics are emitted under a single resource, even when there are none.
+		scopeMetrics("")
+	}
 
-	if err := p.collectCallMetrics(ilm); err != nil {
+	if err := p.collectCallMetrics(scopeMetrics); err != nil {
 		return pmetric.Metrics{}, err
 	}
 
-	if err := p.collectLatencyMetrics(ilm); err != nil {
+	if err := p.collectLatencyMetrics(scopeMetrics); err != nil {
 		return pmetric.Metrics{}, err
 	}
 
@@ -276,10 +310,10 @@ func (p *processorImp) buildMetrics() (pmetric.Metrics, error) {
 }
 
 // collectLatencyMetrics collects the raw latency metrics, writing the data
-// into the given instrumentation library metrics.
-func (p *processorImp) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
+// into the instrumentation library metrics of their resource.
+func (p *processorImp) collectLatencyMetrics(scopeMetrics func(metricKey) pmetric.ScopeMetrics) error {
 	for key := range p.latencyCount {
-		mLatency := ilm.Metrics().AppendEmpty()
+		mLatency := scopeMetrics(key).Metrics().AppendEmpty()
 		mLatency.SetName("latency")
 		mLatency.SetUnit("ms")
 		mLatency.SetEmptyHistogram().SetAggregationTemporality(p.config.GetAggregationTemporality())
@@ -308,10 +342,10 @@ func (p *processorImp) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
 }
 
 // collectCallMetrics collects the raw call count metrics, writing the data
-// into the given instrumentation library metrics.
-func (p *processorImp) collectCallMetrics(ilm pmetric.ScopeMetrics) error {
+// into the instrumentation library metrics of their resource.
+func (p *processorImp) collectCallMetrics(scopeMetrics func(metricKey) pmetric.ScopeMetrics) error {
 	for key := range p.callSum {
-		mCalls := ilm.Metrics().AppendEmpty()
+		mCalls := scopeMetrics(key).Metrics().AppendEmpty()
 		mCalls.SetName("calls_total")
 		mCalls.SetEmptySum().SetIsMonotonic(true)
 		mCalls.Sum().SetAggregationTemporality(p.config.GetAggregationTemporality())
@@ -362,18 +396,28 @@ func (p *processorImp) aggregateMetrics(traces ptrace.Traces) {
 }
 
 func (p *processorImp) aggregateMetricsForServiceSpans(rspans ptrace.ResourceSpans, serviceName string) {
+	resourceKey := ""
+	if p.config.GroupByResource {
+		resourceKey = buildResourceKey(rspans.Resource().Attributes())
+		if _, ok := p.resourceAttributes[resourceKey]; !ok {
+			attrs := pcommon.NewMap()
+			rspans.Resource().Attributes().CopyTo(attrs)
+			p.resourceAttributes[resourceKey] = attrs
+		}
+	}
+
 	ilsSlice := rspans.ScopeSpans()
 	for j := 0; j < ilsSlice.Len(); j++ {
 		ils := ilsSlice.At(j)
 		spans := ils.Spans()
 		for k := 0; k < spans.Len(); k++ {
 			span := spans.At(k)
-			p.aggregateMetricsForSpan(serviceName, span, rspans.Resource().Attributes())
+			p.aggregateMetricsForSpan(serviceName, resourceKey, span, rspans.Resource().Attributes())
 		}
 	}
 }
 
-func (p *processorImp) aggregateMetricsForSpan(serviceName string, span ptrace.Span, resourceAttr pcommon.Map) {
+func (p *processorImp) aggregateMetricsForSpan(serviceName string, resourceKey string, span ptrace.Span, resourceAttr pcommon.Map) {
 	// Protect against end timestamps before start timestamps. Assume 0 duration.
 	latencyInMilliseconds := float64(0)
 	startTime := span.StartTimestamp()
@@ -386,6 +430,10 @@ func (p *processorImp) aggregateMetricsForSpan(serviceName string, span ptrace.S
 	index := sort.SearchFloat64s(p.latencyBounds, latencyInMilliseconds)
 
 	key := buildKey(serviceName, span, p.dimensions, resourceAttr)
+	if resourceKey != "" {
+		key = metricKey(resourceKey + metricKeySeparator + string(key))
+		p.metricKeyToResource[key] = resourceKey
+	}
 
 	p.cache(serviceName, span, key, resourceAttr)
 	p.updateCallMetrics(key)
@@ -406,6 +454,8 @@ func (p *processorImp) resetAccumulatedMetrics() {
 	p.latencySum = make(map[metricKey]float64)
 	p.latencyBucketCounts = make(map[metricKey][]uint64)
 	p.metricKeyToDimensions.Purge()
+	p.metricKeyToResource = make(map[metricKey]string)
+	p.resourceAttributes = make(map[string]pcommon.Map)
 }
 
 // updateLatencyExemplars sets the histogram exemplars for the given metric key and append the exemplar data.
@@ -483,6 +533,24 @@ func buildKey(serviceName string, span ptrace.Span, optionalDims []Dimension, re
 	return k
 }
 
+// buildResourceKey builds a key identifying a resource from its attributes, sorted by name.
+func buildResourceKey(attrs pcommon.Map) string {
+	names := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		names = append(names, k)
+		return true
+	})
+	sort.Strings(names)
+
+	var resourceKeyBuilder strings.Builder
+	for i, name := range names {
+		v, _ := attrs.Get(name)
+		concatDimensionValue(&resourceKeyBuilder, name, i > 0)
+		concatDimensionValue(&resourceKeyBuilder, v.AsString(), true)
+	}
+	return resourceKeyBuilder.String()
+}
+
 // getDimensionValue gets the dimension value for the given configured dimension.
 // It searches through the span's attributes first, being the more specific;
 // falling back to searching in resource attributes if it can't be found in the span.
@@ -105,6 +105,7 @@ func TestProcessorStart(t *testing.T) {
 			}
 			mhost := &mocks.Host{}
 			mhost.On("GetExporters").Return(exporters)
+			mhost.On("GetExtensions").Return(map[config.ComponentID]component.Extension{})
 
 			// Create spanmetrics processor
 			factory := NewFactory()
@@ -129,6 +130,29 @@ func TestProcessorStart(t *testing.T) {
 	}
 }
 
+func TestProcessorStartWithExtension(t *testing.T) {
+	// Prepare
+	extensionID := config.NewComponentID("jaegerremotesampling")
+	mext := &mocks.MetricsExporter{}
+	mhost := &mocks.Host{}
+	mhost.On("GetExporters").Return(map[config.DataType]map[config.ComponentID]component.Exporter{})
+	mhost.On("GetExtensions").Return(map[config.ComponentID]component.Extension{extensionID: mext})
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.MetricsExporter = extensionID.String()
+
+	traceProcessor, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	// Test
+	smp := traceProcessor.(*processorImp)
+	require.NoError(t, smp.Start(context.Background(), mhost))
+
+	// Verify
+	assert.Equal(t, mext, smp.metricsExporter)
+}
+
 func TestProcessorShutdown(t *testing.T) {
 	// Prepare
 	factory := NewFactory()
@@ -311,6 +335,77 @@ func TestProcessorConsumeTraces(t *testing.T) {
 	}
 }
 
+func TestProcessorGroupByResource(t *testing.T) {
+	for _, temporality := range []string{cumulative, delta} {
+		t.Run(temporality, func(t *testing.T) {
+			p := newProcessorImp(&mocks.MetricsExporter{}, &mocks.TracesConsumer{}, nil, temporality, zaptest.NewLogger(t))
+			p.config.GroupByResource = true
+
+			for i := 0; i < 2; i++ {
+				p.aggregateMetrics(buildSampleTrace())
+				m, err := p.buildMetrics()
+				require.NoError(t, err)
+
+				rms := m.ResourceMetrics()
+				require.Equal(t, 2, rms.Len())
+				metricCounts := make(map[string]int)
+				for j := 0; j < rms.Len(); j++ {
+					rm := rms.At(j)
+					serviceName, ok := rm.Resource().Attributes().Get(conventions.AttributeServiceName)
+					require.True(t, ok)
+					region, ok := rm.Resource().Attributes().Get(regionResourceAttrName)
+					require.True(t, ok)
+					assert.Equal(t, sampleRegion, region.Str())
+
+					require.Equal(t, 1, rm.ScopeMetrics().Len())
+					metrics := rm.ScopeMetrics().At(0).Metrics()
+					metricCounts[serviceName.Str()] = metrics.Len()
+					for k := 0; k < metrics.Len(); k++ {
+						metric := metrics.At(k)
+						if metric.Name() != "calls_total" {
+							continue
+						}
+						dp := metric.Sum().DataPoints().At(0)
+						service, _ := dp.Attributes().Get(serviceNameKey)
+						assert.Equal(t, serviceName.Str(), service.Str())
+						expected := int64(i + 1)
+						if temporality == delta {
+							expected = 1
+						}
+						assert.Equal(t, expected, dp.IntValue())
+					}
+				}
+				assert.Equal(t, map[string]int{"service-a": 4, "service-b": 2}, metricCounts)
+			}
+		})
+	}
+}
+
+func TestProcessorGroupByResourceWithoutSpans(t *testing.T) {
+	p := newProcessorImp(&mocks.MetricsExporter{}, &mocks.TracesConsumer{}, nil, cumulative, zaptest.NewLogger(t))
+	p.config.GroupByResource = true
+
+	m, err := p.buildMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, 0, m.ResourceMetrics().Len())
+}
+
+func TestBuildResourceKey(t *testing.T) {
+	attrs0 := pcommon.NewMap()
+	attrs0.PutStr("a", "b")
+	attrs0.PutInt("c", 1)
+	attrs1 := pcommon.NewMap()
+	attrs1.PutInt("c", 1)
+	attrs1.PutStr("a", "b")
+	attrs2 := pcommon.NewMap()
+	attrs2.PutStr("a", "b\x00c")
+	attrs2.PutStr("1", "")
+
+	assert.Equal(t, buildResourceKey(attrs0), buildResourceKey(attrs1))
+	assert.NotEqual(t, buildResourceKey(attrs0), buildResourceKey(attrs2))
+	assert.Equal(t, "", buildResourceKey(pcommon.NewMap()))
+}
+
 func TestMetricKeyCache(t *testing.T) {
 	mexp := &mocks.MetricsExporter{}
 	tcon := &mocks.TracesConsumer{}
@@ -403,6 +498,8 @@ func newProcessorImp(mexp *mocks.MetricsExporter, tcon *mocks.TracesConsumer, de
 			{regionResourceAttrName, nil},
 		},
 		metricKeyToDimensions: metricKeyToDimensions,
+		metricKeyToResource:   make(map[metricKey]string),
+		resourceAttributes:    make(map[string]pcommon.Map),
 	}
 }
 
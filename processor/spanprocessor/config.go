@@ -59,6 +59,15 @@ type Name struct {
 	// values. Used with FromAttributes only.
 	Separator string `mapstructure:"separator"`
 
+	// FromTemplates is a list of templates used to generate the new span name,
+	// e.g. "{http.method} {http.route}". Each placeholder lists one or more attribute
+	// keys separated by "|", e.g. "{http.route|http.target}", and is replaced by the
+	// value of the first of these attributes found in the span. The templates are
+	// tried in order and the first one whose placeholders can all be resolved is used.
+	// If none can be resolved, no re-name will occur. Literal braces are written "{{" and "}}".
+	// Cannot be used together with FromAttributes.
+	FromTemplates []string `mapstructure:"from_templates"`
+
 	// ToAttributes specifies a configuration to extract attributes from span name.
 	ToAttributes *ToAttributes `mapstructure:"to_attributes"`
 }
@@ -81,6 +90,12 @@ type ToAttributes struct {
 	// match. If it is false rule processing will continue to be performed over the
 	// modified span name.
 	BreakAfterMatch bool `mapstructure:"break_after_match"`
+
+	// KeepOriginalName specifies if the span name should be left unchanged. When true,
+	// all the rules are matched against the original span name and their named
+	// subexpressions are extracted as attributes in a single pass, so that several
+	// attributes can be extracted without chaining rules over a rewritten span name.
+	KeepOriginalName bool `mapstructure:"keep_original_name"`
 }
 
 type Status struct {
@@ -65,6 +65,27 @@ func TestLoadingConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName("span", "from_templates"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID("span")),
+				Rename: Name{
+					FromTemplates: []string{"{http.method} {http.route|http.target}", "{rpc.service}/{rpc.method}"},
+				},
+			},
+		},
+		{
+			id: config.NewComponentIDWithName("span", "keep_original_name"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID("span")),
+				Rename: Name{
+					ToAttributes: &ToAttributes{
+						Rules:            []string{`^\/api\/(?P<version>v\d+)\/document\/(?P<documentId>.*)$`},
+						KeepOriginalName: true,
+					},
+				},
+			},
+		},
 		{
 			id: config.NewComponentIDWithName("span", "includeexclude"),
 			expected: &Config{
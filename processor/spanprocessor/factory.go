@@ -46,9 +46,10 @@ var processorCapabilities = consumer.Capabilities{MutatesData: true}
 //
 //	Move this to the error package that allows for span name and field to be specified.
 var (
-	errMissingRequiredField       = errors.New("error creating \"span\" processor: either \"from_attributes\" or \"to_attributes\" must be specified in \"name:\" or \"setStatus\" must be specified")
+	errMissingRequiredField       = errors.New("error creating \"span\" processor: either \"from_attributes\", \"from_templates\" or \"to_attributes\" must be specified in \"name:\" or \"setStatus\" must be specified")
 	errIncorrectStatusCode        = errors.New("error creating \"span\" processor: \"status\" must have specified \"code\" as \"Ok\" or \"Error\" or \"Unset\"")
 	errIncorrectStatusDescription = errors.New("error creating \"span\" processor: \"description\" can be specified only for \"code\" \"Error\"")
+	errFromAttributesAndTemplates = errors.New("error creating \"span\" processor: \"from_attributes\" and \"from_templates\" cannot be specified together in \"name:\"")
 )
 
 // NewFactory returns a new factory for the Span processor.
@@ -72,15 +73,20 @@ func createTracesProcessor(
 	nextConsumer consumer.Traces,
 ) (component.TracesProcessor, error) {
 
-	// 'from_attributes' or 'to_attributes' under 'name' has to be set for the span
+	// 'from_attributes', 'from_templates' or 'to_attributes' under 'name' has to be set for the span
 	// processor to be valid. If not set and not enforced, the processor would do no work.
 	oCfg := cfg.(*Config)
 	if len(oCfg.Rename.FromAttributes) == 0 &&
+		len(oCfg.Rename.FromTemplates) == 0 &&
 		(oCfg.Rename.ToAttributes == nil || len(oCfg.Rename.ToAttributes.Rules) == 0) &&
 		oCfg.SetStatus == nil {
 		return nil, errMissingRequiredField
 	}
 
+	if len(oCfg.Rename.FromAttributes) > 0 && len(oCfg.Rename.FromTemplates) > 0 {
+		return nil, errFromAttributesAndTemplates
+	}
+
 	if oCfg.SetStatus != nil {
 		if oCfg.SetStatus.Code != statusCodeUnset && oCfg.SetStatus.Code != statusCodeError && oCfg.SetStatus.Code != statusCodeOk {
 			return nil, errIncorrectStatusCode
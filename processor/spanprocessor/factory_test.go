@@ -80,6 +80,21 @@ func TestFactory_CreateTracesProcessor_InvalidConfig(t *testing.T) {
 			},
 			err: fmt.Errorf("invalid regexp pattern \\"),
 		},
+		{
+			name: "from_attributes_and_templates",
+			cfg: Name{
+				FromAttributes: []string{"http.method"},
+				FromTemplates:  []string{"{http.method}"},
+			},
+			err: errFromAttributesAndTemplates,
+		},
+		{
+			name: "invalid_template",
+			cfg: Name{
+				FromTemplates: []string{"{http.method"},
+			},
+			err: fmt.Errorf("invalid template %q: missing closing brace", "{http.method"),
+		},
 	}
 
 	for _, test := range testcases {
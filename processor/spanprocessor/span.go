@@ -30,6 +30,7 @@ import (
 type spanProcessor struct {
 	config           Config
 	toAttributeRules []toAttributeRule
+	nameTemplates    []nameTemplate
 	include          filterspan.Matcher
 	exclude          filterspan.Matcher
 }
@@ -60,6 +61,14 @@ func newSpanProcessor(config Config) (*spanProcessor, error) {
 		exclude: exclude,
 	}
 
+	for _, template := range config.Rename.FromTemplates {
+		t, err := parseNameTemplate(template)
+		if err != nil {
+			return nil, err
+		}
+		sp.nameTemplates = append(sp.nameTemplates, t)
+	}
+
 	// Compile ToAttributes regexp and extract attributes names.
 	if config.Rename.ToAttributes != nil {
 		for _, pattern := range config.Rename.ToAttributes.Rules {
@@ -97,6 +106,7 @@ func (sp *spanProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptr
 					continue
 				}
 				sp.processFromAttributes(s)
+				sp.processFromTemplates(s)
 				sp.processToAttributes(s)
 				sp.processUpdateStatus(s)
 			}
@@ -142,22 +152,40 @@ func (sp *spanProcessor) processFromAttributes(span ptrace.Span) {
 			sb.WriteString(sp.config.Rename.Separator)
 		}
 
-		switch attr.Type() {
-		case pcommon.ValueTypeStr:
-			sb.WriteString(attr.Str())
-		case pcommon.ValueTypeBool:
-			sb.WriteString(strconv.FormatBool(attr.Bool()))
-		case pcommon.ValueTypeDouble:
-			sb.WriteString(strconv.FormatFloat(attr.Double(), 'f', -1, 64))
-		case pcommon.ValueTypeInt:
-			sb.WriteString(strconv.FormatInt(attr.Int(), 10))
-		default:
-			sb.WriteString("<unknown-attribute-type>")
-		}
+		sb.WriteString(attributeValueString(attr))
 	}
 	span.SetName(sb.String())
 }
 
+// processFromTemplates renames the span with the first template whose
+// placeholders can all be resolved from the span attributes.
+func (sp *spanProcessor) processFromTemplates(span ptrace.Span) {
+	attrs := span.Attributes()
+	for _, template := range sp.nameTemplates {
+		if name, ok := template.render(attrs); ok {
+			span.SetName(name)
+			return
+		}
+	}
+}
+
+// attributeValueString returns the string representation of an attribute
+// value used in span names.
+func attributeValueString(attr pcommon.Value) string {
+	switch attr.Type() {
+	case pcommon.ValueTypeStr:
+		return attr.Str()
+	case pcommon.ValueTypeBool:
+		return strconv.FormatBool(attr.Bool())
+	case pcommon.ValueTypeDouble:
+		return strconv.FormatFloat(attr.Double(), 'f', -1, 64)
+	case pcommon.ValueTypeInt:
+		return strconv.FormatInt(attr.Int(), 10)
+	default:
+		return "<unknown-attribute-type>"
+	}
+}
+
 func (sp *spanProcessor) processToAttributes(span ptrace.Span) {
 	if span.Name() == "" {
 		// There is no span name to work on.
@@ -169,6 +197,11 @@ func (sp *spanProcessor) processToAttributes(span ptrace.Span) {
 		return
 	}
 
+	if sp.config.Rename.ToAttributes.KeepOriginalName {
+		sp.extractAttributes(span)
+		return
+	}
+
 	// Process rules one by one. Store results of processing in the span
 	// so that each subsequent rule works on the span name that is the output
 	// after processing the previous rule.
@@ -223,6 +256,32 @@ func (sp *spanProcessor) processToAttributes(span ptrace.Span) {
 	}
 }
 
+// extractAttributes extracts the named subexpressions of all the matching rules
+// from the span name, in a single pass, without modifying the span name.
+func (sp *spanProcessor) extractAttributes(span ptrace.Span) {
+	name := span.Name()
+	attrs := span.Attributes()
+	for _, rule := range sp.toAttributeRules {
+		submatchIdxPairs := rule.re.FindStringSubmatchIndex(name)
+		if submatchIdxPairs == nil {
+			continue
+		}
+		for i := 1; i < len(rule.attrNames); i++ {
+			start, end := submatchIdxPairs[i*2], submatchIdxPairs[i*2+1]
+			// Skip unnamed subexpressions and the ones that did not participate in the match.
+			if rule.attrNames[i] == "" || start < 0 {
+				continue
+			}
+			attrs.PutStr(rule.attrNames[i], name[start:end])
+		}
+
+		if sp.config.Rename.ToAttributes.BreakAfterMatch {
+			// Stop processing, break after first match is requested.
+			break
+		}
+	}
+}
+
 func (sp *spanProcessor) processUpdateStatus(span ptrace.Span) {
 	cfg := sp.config.SetStatus
 	if cfg != nil {
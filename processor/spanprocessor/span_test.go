@@ -526,6 +526,141 @@ func TestSpanProcessor_ToAttributes(t *testing.T) {
 	}
 }
 
+// TestSpanProcessor_FromTemplates tests naming a span from templates with fallbacks.
+func TestSpanProcessor_FromTemplates(t *testing.T) {
+	testCases := []testCase{
+		{
+			inputName: "all keys present",
+			inputAttributes: map[string]interface{}{
+				"http.method": "GET",
+				"http.route":  "/users/{id}",
+				"http.target": "/users/42",
+			},
+			outputName: "GET /users/{id}",
+			outputAttributes: map[string]interface{}{
+				"http.method": "GET",
+				"http.route":  "/users/{id}",
+				"http.target": "/users/42",
+			},
+		},
+		{
+			inputName: "placeholder fallback",
+			inputAttributes: map[string]interface{}{
+				"http.method": "POST",
+				"http.target": "/users",
+			},
+			outputName: "POST /users",
+			outputAttributes: map[string]interface{}{
+				"http.method": "POST",
+				"http.target": "/users",
+			},
+		},
+		{
+			inputName: "template fallback",
+			inputAttributes: map[string]interface{}{
+				"rpc.service": "UserService",
+				"rpc.method":  "Get",
+				"retries":     2,
+			},
+			outputName: "UserService/Get {2}",
+			outputAttributes: map[string]interface{}{
+				"rpc.service": "UserService",
+				"rpc.method":  "Get",
+				"retries":     2,
+			},
+		},
+		{
+			inputName: "no template resolved",
+			inputAttributes: map[string]interface{}{
+				"http.method": "GET",
+			},
+			outputName: "no template resolved",
+			outputAttributes: map[string]interface{}{
+				"http.method": "GET",
+			},
+		},
+	}
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Rename.FromTemplates = []string{
+		"{http.method} {http.route|http.target}",
+		"{rpc.service}/{rpc.method} {{{retries}}}",
+	}
+
+	tp, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), oCfg, consumertest.NewNop())
+	require.Nil(t, err)
+	require.NotNil(t, tp)
+
+	for _, tc := range testCases {
+		runIndividualTestCase(t, tc, tp)
+	}
+}
+
+// TestSpanProcessor_ToAttributesKeepOriginalName tests extracting attributes without renaming the span.
+func TestSpanProcessor_ToAttributesKeepOriginalName(t *testing.T) {
+	testCases := []struct {
+		rules           []string
+		breakAfterMatch bool
+		testCase
+	}{
+		{
+			rules: []string{
+				`^\/api\/(?P<version>[^/]+)\/`,
+				`\/document\/(?P<documentId>[^/]+)\/(?P<operation>[^/]+)$`,
+			},
+			testCase: testCase{
+				inputName:  "/api/v1/document/321083210/update",
+				outputName: "/api/v1/document/321083210/update",
+				outputAttributes: map[string]interface{}{
+					"documentId": "321083210",
+					"operation":  "update",
+					"version":    "v1",
+				},
+			},
+		},
+		{
+			rules: []string{
+				`^\/api\/(?P<version>[^/]+)\/`,
+				`\/document\/(?P<documentId>[^/]+)\/`,
+			},
+			breakAfterMatch: true,
+			testCase: testCase{
+				inputName:  "/api/v2/document/321083210/delete",
+				outputName: "/api/v2/document/321083210/delete",
+				outputAttributes: map[string]interface{}{
+					"version": "v2",
+				},
+			},
+		},
+		{
+			// unnamed and non participating subexpressions are not extracted
+			rules: []string{`^\/(api|web)\/(?P<version>v\d+)?\/?items$`},
+			testCase: testCase{
+				inputName:        "/web/items",
+				outputName:       "/web/items",
+				outputAttributes: map[string]interface{}{},
+			},
+		},
+	}
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Rename.ToAttributes = &ToAttributes{KeepOriginalName: true}
+
+	for _, tc := range testCases {
+		oCfg.Rename.ToAttributes.Rules = tc.rules
+		oCfg.Rename.ToAttributes.BreakAfterMatch = tc.breakAfterMatch
+		tp, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), oCfg, consumertest.NewNop())
+		require.Nil(t, err)
+		require.NotNil(t, tp)
+
+		runIndividualTestCase(t, tc.testCase, tp)
+	}
+}
+
 func TestSpanProcessor_skipSpan(t *testing.T) {
 	testCases := []testCase{
 		{
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanprocessor"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// nameTemplate is the compiled equivalent of an entry of config.FromTemplates.
+// A template is made of literal text and placeholders, e.g. "{http.method} {http.route}".
+// A placeholder lists one or more attribute keys separated by "|", e.g. "{http.route|http.target}":
+// the value of the first attribute found in the span is used.
+// Literal braces are written "{{" and "}}".
+type nameTemplate struct {
+	segments []templateSegment
+}
+
+// templateSegment is either a literal text or a placeholder.
+type templateSegment struct {
+	literal string
+	// keys of the placeholder, in order of preference. Empty for a literal.
+	keys []string
+}
+
+// parseNameTemplate compiles a span name template.
+func parseNameTemplate(template string) (nameTemplate, error) {
+	var t nameTemplate
+	var literal strings.Builder
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			t.segments = append(t.segments, templateSegment{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(template); i++ {
+		switch c := template[i]; {
+		case c == '{' && i+1 < len(template) && template[i+1] == '{':
+			literal.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(template) && template[i+1] == '}':
+			literal.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return nameTemplate{}, fmt.Errorf("invalid template %q: missing closing brace", template)
+			}
+			var keys []string
+			for _, key := range strings.Split(template[i+1:i+end], "|") {
+				if key = strings.TrimSpace(key); key == "" {
+					return nameTemplate{}, fmt.Errorf("invalid template %q: empty attribute key", template)
+				}
+				keys = append(keys, key)
+			}
+			flushLiteral()
+			t.segments = append(t.segments, templateSegment{keys: keys})
+			i += end
+		case c == '}':
+			return nameTemplate{}, fmt.Errorf("invalid template %q: unexpected closing brace", template)
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flushLiteral()
+	return t, nil
+}
+
+// render builds the span name from the attributes. It returns false if none of
+// the keys of a placeholder is found in the attributes.
+func (t nameTemplate) render(attrs pcommon.Map) (string, bool) {
+	var sb strings.Builder
+	for _, segment := range t.segments {
+		if segment.keys == nil {
+			sb.WriteString(segment.literal)
+			continue
+		}
+		found := false
+		for _, key := range segment.keys {
+			if attr, ok := attrs.Get(key); ok {
+				sb.WriteString(attributeValueString(attr))
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
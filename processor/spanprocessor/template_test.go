@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestParseNameTemplate(t *testing.T) {
+	testCases := []struct {
+		template string
+		expected nameTemplate
+		err      string
+	}{
+		{
+			template: "{http.method} {http.route|http.target}",
+			expected: nameTemplate{segments: []templateSegment{
+				{keys: []string{"http.method"}},
+				{literal: " "},
+				{keys: []string{"http.route", "http.target"}},
+			}},
+		},
+		{
+			template: "static",
+			expected: nameTemplate{segments: []templateSegment{{literal: "static"}}},
+		},
+		{
+			template: "{{{ key | other }}}",
+			expected: nameTemplate{segments: []templateSegment{
+				{literal: "{"},
+				{keys: []string{"key", "other"}},
+				{literal: "}"},
+			}},
+		},
+		{
+			template: "{http.method",
+			err:      `invalid template "{http.method": missing closing brace`,
+		},
+		{
+			template: "http.method}",
+			err:      `invalid template "http.method}": unexpected closing brace`,
+		},
+		{
+			template: "{http.route|}",
+			err:      `invalid template "{http.route|}": empty attribute key`,
+		},
+		{
+			template: "{}",
+			err:      `invalid template "{}": empty attribute key`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.template, func(t *testing.T) {
+			tmpl, err := parseNameTemplate(tc.template)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, tmpl)
+		})
+	}
+}
+
+func TestNameTemplateRender(t *testing.T) {
+	tmpl, err := parseNameTemplate("{http.method} {http.route|http.target} ({http.status_code})")
+	require.NoError(t, err)
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr("http.method", "GET")
+	attrs.PutStr("http.target", "/users/42")
+	_, ok := tmpl.render(attrs)
+	assert.False(t, ok)
+
+	attrs.PutInt("http.status_code", 200)
+	name, ok := tmpl.render(attrs)
+	assert.True(t, ok)
+	assert.Equal(t, "GET /users/42 (200)", name)
+
+	attrs.PutStr("http.route", "/users/{id}")
+	name, ok = tmpl.render(attrs)
+	assert.True(t, ok)
+	assert.Equal(t, "GET /users/{id} (200)", name)
+}
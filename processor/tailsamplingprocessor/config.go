@@ -209,4 +209,7 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// ServiceMetrics enables the metrics broken down by the service.name of the
+	// spans. It is disabled by default as the number of services can be large.
+	ServiceMetrics bool `mapstructure:"service_metrics"`
 }
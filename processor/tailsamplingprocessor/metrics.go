@@ -27,6 +27,8 @@ var (
 	tagPolicyKey, _    = tag.NewKey("policy")
 	tagSampledKey, _   = tag.NewKey("sampled")
 	tagSourceFormat, _ = tag.NewKey("source_format")
+	tagDecisionKey, _  = tag.NewKey("decision")
+	tagServiceKey, _   = tag.NewKey("service")
 
 	statDecisionLatencyMicroSec  = stats.Int64("sampling_decision_latency", "Latency (in microseconds) of a given sampling policy", "µs")
 	statOverallDecisionLatencyUs = stats.Int64("sampling_decision_timer_latency", "Latency (in microseconds) of each run of the sampling decision timer", "µs")
@@ -37,6 +39,11 @@ var (
 	statPolicyEvaluationErrorCount = stats.Int64("sampling_policy_evaluation_error", "Count of sampling policy evaluation errors", stats.UnitDimensionless)
 
 	statCountTracesSampled = stats.Int64("count_traces_sampled", "Count of traces that were sampled or not", stats.UnitDimensionless)
+	statPolicyDecision     = stats.Int64("sampling_policy_decision", "Count of traces evaluated by a sampling policy, per decision of the policy", stats.UnitDimensionless)
+	statTraceDecisionAge   = stats.Int64("sampling_trace_decision_age", "Time (in seconds) from arrival of a new trace until its sampling decision", "s")
+
+	statCountTracesSampledByService = stats.Int64("count_traces_sampled_by_service", "Count of traces that were sampled or not, per service taking part in the trace", stats.UnitDimensionless)
+	statSpansOnMemoryByService      = stats.Int64("sampling_spans_on_memory_by_service", "Tracks the number of spans waiting for a sampling decision on memory, per service", stats.UnitDimensionless)
 
 	statDroppedTooEarlyCount    = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped the configured wait time", stats.UnitDimensionless)
 	statNewTraceIDReceivedCount = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
@@ -97,6 +104,36 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	policyDecisionTagKeys := []tag.Key{tagPolicyKey, tagDecisionKey}
+	countPolicyDecisionView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statPolicyDecision.Name()),
+		Measure:     statPolicyDecision,
+		Description: statPolicyDecision.Description(),
+		TagKeys:     policyDecisionTagKeys,
+		Aggregation: view.Sum(),
+	}
+	traceDecisionAgeView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statTraceDecisionAge.Name()),
+		Measure:     statTraceDecisionAge,
+		Description: statTraceDecisionAge.Description(),
+		Aggregation: ageDistributionAggregation,
+	}
+
+	countTracesSampledByServiceView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statCountTracesSampledByService.Name()),
+		Measure:     statCountTracesSampledByService,
+		Description: statCountTracesSampledByService.Description(),
+		TagKeys:     []tag.Key{tagServiceKey, tagSampledKey},
+		Aggregation: view.Sum(),
+	}
+	trackSpansOnMemoryByServiceView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statSpansOnMemoryByService.Name()),
+		Measure:     statSpansOnMemoryByService,
+		Description: statSpansOnMemoryByService.Description(),
+		TagKeys:     []tag.Key{tagServiceKey},
+		Aggregation: view.LastValue(),
+	}
+
 	countTraceDroppedTooEarlyView := &view.View{
 		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDroppedTooEarlyCount.Name()),
 		Measure:     statDroppedTooEarlyCount,
@@ -126,6 +163,11 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		countPolicyEvaluationErrorView,
 
 		countTracesSampledView,
+		countPolicyDecisionView,
+		traceDecisionAgeView,
+
+		countTracesSampledByServiceView,
+		trackSpansOnMemoryByServiceView,
 
 		countTraceDroppedTooEarlyView,
 		countTraceIDArrivalView,
@@ -60,6 +60,8 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+	// serviceSpans is nil unless service metrics are enabled.
+	serviceSpans *serviceSpanCounter
 }
 
 const (
@@ -110,6 +112,10 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		numTracesOnMap:  atomic.NewUint64(0),
 	}
 
+	if cfg.ServiceMetrics {
+		tsp.serviceSpans = newServiceSpanCounter()
+	}
+
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
 	tsp.deleteChan = make(chan pcommon.TraceID, cfg.NumTraces)
 
@@ -188,6 +194,11 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.ReceivedBatches.MoveTo(allSpans)
 		trace.Unlock()
 
+		stats.Record(tsp.ctx, statTraceDecisionAge.M(int64(trace.DecisionTime.Sub(trace.ArrivalTime)/time.Second)))
+		if tsp.serviceSpans != nil {
+			tsp.recordServiceDecision(allSpans, decision)
+		}
+
 		if decision == sampling.Sampled {
 			_ = tsp.nextConsumer.ConsumeTraces(policy.ctx, allSpans)
 		}
@@ -198,6 +209,9 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		statDroppedTooEarlyCount.M(metrics.idNotFoundOnMapCount),
 		statPolicyEvaluationErrorCount.M(metrics.evaluateErrorCount),
 		statTracesOnMemoryGauge.M(int64(tsp.numTracesOnMap.Load())))
+	if tsp.serviceSpans != nil {
+		tsp.serviceSpans.record(tsp.ctx)
+	}
 
 	tsp.logger.Debug("Sampling policy evaluation completed",
 		zap.Int("batch.len", batchLen),
@@ -226,6 +240,11 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		stats.Record(
 			p.ctx,
 			statDecisionLatencyMicroSec.M(int64(time.Since(policyEvaluateStartTime)/time.Microsecond)))
+		_ = stats.RecordWithTags(
+			p.ctx,
+			[]tag.Mutator{tag.Upsert(tagDecisionKey, decisionTagValue(decision, err))},
+			statPolicyDecision.M(int64(1)),
+		)
 
 		if err != nil {
 			samplingDecision[sampling.Error] = true
@@ -366,6 +385,9 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 				// be duplicated in the final trace.
 				appendToTraces(actualData.ReceivedBatches, resourceSpans, spans)
 				actualData.Unlock()
+				if tsp.serviceSpans != nil {
+					tsp.serviceSpans.add(serviceName(resourceSpans.Resource()), lenSpans)
+				}
 				break
 			}
 			actualData.Unlock()
@@ -430,6 +452,15 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 		return
 	}
 
+	if tsp.serviceSpans != nil {
+		// Spans of traces dropped before their decision are released from memory now.
+		trace.Lock()
+		for svc, count := range spanCountByService(trace.ReceivedBatches) {
+			tsp.serviceSpans.add(svc, -count)
+		}
+		trace.Unlock()
+	}
+
 	stats.Record(tsp.ctx, statTraceRemovalAgeSec.M(int64(deletionTime.Sub(trace.ArrivalTime)/time.Second)))
 }
 
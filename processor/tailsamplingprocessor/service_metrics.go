@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const unknownService = "unknown_service"
+
+// serviceSpanCounter tracks the number of spans waiting for a sampling
+// decision, per service.
+type serviceSpanCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func newServiceSpanCounter() *serviceSpanCounter {
+	return &serviceSpanCounter{counts: map[string]int64{}}
+}
+
+func (c *serviceSpanCounter) add(service string, count int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[service] += count
+}
+
+// record reports the number of spans on memory of every service. Services
+// without spans on memory are reported one last time, then forgotten.
+func (c *serviceSpanCounter) record(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for service, count := range c.counts {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(tagServiceKey, service)},
+			statSpansOnMemoryByService.M(count),
+		)
+		if count <= 0 {
+			delete(c.counts, service)
+		}
+	}
+}
+
+// recordServiceDecision releases the spans of a decided trace from the per
+// service counters and records the decision for every service of the trace.
+func (tsp *tailSamplingSpanProcessor) recordServiceDecision(td ptrace.Traces, decision sampling.Decision) {
+	sampled := "false"
+	if decision == sampling.Sampled {
+		sampled = "true"
+	}
+	for service, count := range spanCountByService(td) {
+		tsp.serviceSpans.add(service, -count)
+		_ = stats.RecordWithTags(
+			tsp.ctx,
+			[]tag.Mutator{tag.Upsert(tagServiceKey, service), tag.Upsert(tagSampledKey, sampled)},
+			statCountTracesSampledByService.M(int64(1)),
+		)
+	}
+}
+
+func spanCountByService(td ptrace.Traces) map[string]int64 {
+	counts := map[string]int64{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		service := serviceName(rs.Resource())
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			counts[service] += int64(ilss.At(j).Spans().Len())
+		}
+	}
+	return counts
+}
+
+func serviceName(resource pcommon.Resource) string {
+	if v, ok := resource.Attributes().Get(conventions.AttributeServiceName); ok && v.Str() != "" {
+		return v.Str()
+	}
+	return unknownService
+}
+
+// decisionTagValue returns the value of the decision tag recorded for the
+// evaluation of a policy.
+func decisionTagValue(decision sampling.Decision, err error) string {
+	if err != nil {
+		return "error"
+	}
+	switch decision {
+	case sampling.Sampled:
+		return "sampled"
+	case sampling.NotSampled:
+		return "not_sampled"
+	case sampling.InvertSampled:
+		return "inverted_sampled"
+	case sampling.InvertNotSampled:
+		return "inverted_not_sampled"
+	default:
+		return "unknown"
+	}
+}
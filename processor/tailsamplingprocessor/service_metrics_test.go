@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func TestSpanCountByService(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "frontend")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "backend")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	rs = td.ResourceSpans().AppendEmpty()
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+
+	assert.Equal(t, map[string]int64{
+		"frontend":     2,
+		"backend":      1,
+		unknownService: 1,
+	}, spanCountByService(td))
+}
+
+func TestDecisionTagValue(t *testing.T) {
+	assert.Equal(t, "sampled", decisionTagValue(sampling.Sampled, nil))
+	assert.Equal(t, "not_sampled", decisionTagValue(sampling.NotSampled, nil))
+	assert.Equal(t, "inverted_sampled", decisionTagValue(sampling.InvertSampled, nil))
+	assert.Equal(t, "inverted_not_sampled", decisionTagValue(sampling.InvertNotSampled, nil))
+	assert.Equal(t, "error", decisionTagValue(sampling.NotSampled, errors.New("error")))
+}
+
+func TestServiceSpansReleasedOnDecision(t *testing.T) {
+	const maxSize = 100
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.NotSampled}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:             context.Background(),
+		nextConsumer:    msp,
+		maxNumTraces:    maxSize,
+		logger:          zap.NewNop(),
+		decisionBatcher: newSyncIDBatcher(1),
+		policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:      make(chan pcommon.TraceID, maxSize),
+		policyTicker:    &manualTTicker{},
+		tickerFrequency: 100 * time.Millisecond,
+		numTracesOnMap:  atomic.NewUint64(0),
+		serviceSpans:    newServiceSpanCounter(),
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	_, batches := generateIdsAndBatches(3)
+	for _, batch := range batches {
+		batch.ResourceSpans().At(0).Resource().Attributes().PutStr("service.name", "frontend")
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+	assert.Equal(t, map[string]int64{"frontend": 6}, tsp.serviceSpans.counts)
+
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+
+	assert.Empty(t, tsp.serviceSpans.counts)
+	assert.Empty(t, msp.AllTraces())
+}
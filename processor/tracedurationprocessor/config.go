@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracedurationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tracedurationprocessor"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines the configuration for the trace duration processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MetricsExporter is the name of the metrics exporter to use to ship metrics.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// DecisionWait is the time to wait after the first span of a trace is
+	// received before the trace is considered complete and its durations are
+	// computed. Spans arriving later are not taken into account.
+	DecisionWait time.Duration `mapstructure:"decision_wait"`
+
+	// NumTraces is the maximum number of traces buffered at once. When it is
+	// reached, the oldest trace is considered complete early.
+	NumTraces int `mapstructure:"num_traces"`
+
+	// LatencyHistogramBuckets is the list of durations representing latency histogram buckets.
+	// See defaultLatencyHistogramBucketsMs in processor.go for the default value.
+	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MetricsExporter == "" {
+		return errors.New("metrics_exporter is required")
+	}
+	if cfg.DecisionWait <= 0 {
+		return errors.New("decision_wait must be greater than 0")
+	}
+	if cfg.NumTraces <= 0 {
+		return errors.New("num_traces must be greater than 0")
+	}
+	for i := 1; i < len(cfg.LatencyHistogramBuckets); i++ {
+		if cfg.LatencyHistogramBuckets[i] <= cfg.LatencyHistogramBuckets[i-1] {
+			return errors.New("latency_histogram_buckets must be sorted in increasing order")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracedurationprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		id       config.ComponentID
+		expected config.Processor
+	}{
+		{
+			id: config.NewComponentID(typeStr),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				MetricsExporter:   "prometheus",
+				DecisionWait:      defaultDecisionWait,
+				NumTraces:         defaultNumTraces,
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "custom"),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "custom")),
+				MetricsExporter:   "prometheus",
+				DecisionWait:      10 * time.Second,
+				NumTraces:         1000,
+				LatencyHistogramBuckets: []time.Duration{
+					10 * time.Millisecond,
+					100 * time.Millisecond,
+					time.Second,
+					10 * time.Second,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalProcessor(sub, cfg))
+
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		error  string
+	}{
+		{
+			name:   "no metrics exporter",
+			modify: func(cfg *Config) { cfg.MetricsExporter = "" },
+			error:  "metrics_exporter is required",
+		},
+		{
+			name:   "no decision wait",
+			modify: func(cfg *Config) { cfg.DecisionWait = 0 },
+			error:  "decision_wait must be greater than 0",
+		},
+		{
+			name:   "no num traces",
+			modify: func(cfg *Config) { cfg.NumTraces = 0 },
+			error:  "num_traces must be greater than 0",
+		},
+		{
+			name: "unsorted buckets",
+			modify: func(cfg *Config) {
+				cfg.LatencyHistogramBuckets = []time.Duration{time.Second, time.Millisecond}
+			},
+			error: "latency_histogram_buckets must be sorted in increasing order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.MetricsExporter = "prometheus"
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.error)
+		})
+	}
+}
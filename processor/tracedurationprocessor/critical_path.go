@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracedurationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tracedurationprocessor"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// spanData holds the parts of a span needed to compute the trace durations.
+type spanData struct {
+	spanID       pcommon.SpanID
+	parentSpanID pcommon.SpanID
+	service      string
+	name         string
+	start        pcommon.Timestamp
+	end          pcommon.Timestamp
+}
+
+// traceSummary is the result of the analysis of a complete trace.
+type traceSummary struct {
+	rootService   string
+	rootOperation string
+	// hasRoot is false when the span without parent was not received, in which
+	// case the earliest span whose parent is missing is used as root.
+	hasRoot bool
+	// duration is the time between the start of the earliest span and the end
+	// of the latest one, in nanoseconds.
+	duration uint64
+	// criticalPath is the time each service spent on the critical path of the
+	// root span, in nanoseconds. The values add up to the root span duration.
+	criticalPath map[string]uint64
+}
+
+type spanNode struct {
+	*spanData
+	children []*spanNode
+}
+
+// analyzeTrace computes the duration and the critical path of a trace.
+func analyzeTrace(spans []spanData) traceSummary {
+	nodes := make(map[pcommon.SpanID]*spanNode, len(spans))
+	for i := range spans {
+		nodes[spans[i].spanID] = &spanNode{spanData: &spans[i]}
+	}
+
+	var (
+		root       *spanNode
+		start, end pcommon.Timestamp
+	)
+	for i := range spans {
+		s := &spans[i]
+		if i == 0 || s.start < start {
+			start = s.start
+		}
+		if s.end > end {
+			end = s.end
+		}
+
+		node := nodes[s.spanID]
+		if node.spanData != s {
+			// Duplicated span ID, only the last span is kept.
+			continue
+		}
+		if parent, ok := nodes[s.parentSpanID]; ok && !s.parentSpanID.IsEmpty() && parent != node {
+			parent.children = append(parent.children, node)
+			continue
+		}
+		if root == nil || isBetterRoot(node, root) {
+			root = node
+		}
+	}
+
+	summary := traceSummary{
+		criticalPath: map[string]uint64{},
+	}
+	if end > start {
+		summary.duration = uint64(end - start)
+	}
+	if root == nil {
+		return summary
+	}
+	summary.rootService = root.service
+	summary.rootOperation = root.name
+	summary.hasRoot = root.parentSpanID.IsEmpty()
+
+	for _, node := range nodes {
+		// Last finishing children first.
+		sort.Slice(node.children, func(i, j int) bool {
+			return node.children[i].end > node.children[j].end
+		})
+	}
+	walkCriticalPath(root, root.start, root.end, summary.criticalPath, map[*spanNode]bool{})
+	return summary
+}
+
+// isBetterRoot prefers actual root spans to spans whose parent is missing, then
+// the earliest span.
+func isBetterRoot(candidate, current *spanNode) bool {
+	if candidate.parentSpanID.IsEmpty() != current.parentSpanID.IsEmpty() {
+		return candidate.parentSpanID.IsEmpty()
+	}
+	return candidate.start < current.start
+}
+
+// walkCriticalPath attributes the time of the node, clipped to [lo, hi], to the
+// services on its critical path. Going backwards from the end of the node, the
+// time is attributed to the last finishing child for as long as it runs, then
+// to the node itself until the next child finishes.
+func walkCriticalPath(node *spanNode, lo, hi pcommon.Timestamp, criticalPath map[string]uint64, visited map[*spanNode]bool) {
+	if visited[node] {
+		return
+	}
+	visited[node] = true
+
+	start, end := maxTimestamp(node.start, lo), minTimestamp(node.end, hi)
+	if end <= start {
+		return
+	}
+
+	cursor := end
+	for _, child := range node.children {
+		if child.start >= cursor {
+			// Runs entirely after a child already on the critical path.
+			continue
+		}
+		childEnd := minTimestamp(child.end, cursor)
+		if childEnd <= start {
+			break
+		}
+		criticalPath[node.service] += uint64(cursor - childEnd)
+		walkCriticalPath(child, start, childEnd, criticalPath, visited)
+		cursor = maxTimestamp(child.start, start)
+	}
+	criticalPath[node.service] += uint64(cursor - start)
+}
+
+func minTimestamp(a, b pcommon.Timestamp) pcommon.Timestamp {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxTimestamp(a, b pcommon.Timestamp) pcommon.Timestamp {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracedurationprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func ms(v int) pcommon.Timestamp {
+	return pcommon.Timestamp(time.Duration(v) * time.Millisecond)
+}
+
+func spanID(b byte) pcommon.SpanID {
+	return pcommon.SpanID([8]byte{b})
+}
+
+func newSpan(id, parent byte, service string, start, end int) spanData {
+	s := spanData{
+		spanID:  spanID(id),
+		service: service,
+		name:    service + "-op",
+		start:   ms(start),
+		end:     ms(end),
+	}
+	if parent != 0 {
+		s.parentSpanID = spanID(parent)
+	}
+	return s
+}
+
+func TestAnalyzeTrace(t *testing.T) {
+	tests := []struct {
+		name             string
+		spans            []spanData
+		wantRoot         string
+		wantHasRoot      bool
+		wantDuration     int
+		wantCriticalPath map[string]int
+	}{
+		{
+			name:             "single span",
+			spans:            []spanData{newSpan(1, 0, "a", 0, 100)},
+			wantRoot:         "a",
+			wantHasRoot:      true,
+			wantDuration:     100,
+			wantCriticalPath: map[string]int{"a": 100},
+		},
+		{
+			name: "sequential children",
+			spans: []spanData{
+				newSpan(2, 1, "b", 10, 40),
+				newSpan(3, 1, "c", 50, 90),
+				newSpan(1, 0, "a", 0, 100),
+			},
+			wantRoot:         "a",
+			wantHasRoot:      true,
+			wantDuration:     100,
+			wantCriticalPath: map[string]int{"a": 30, "b": 30, "c": 40},
+		},
+		{
+			name: "parallel children",
+			spans: []spanData{
+				newSpan(1, 0, "a", 0, 100),
+				newSpan(2, 1, "b", 10, 80),
+				newSpan(3, 1, "c", 20, 60),
+			},
+			wantRoot:         "a",
+			wantHasRoot:      true,
+			wantDuration:     100,
+			wantCriticalPath: map[string]int{"a": 30, "b": 70},
+		},
+		{
+			name: "nested calls back to the root service",
+			spans: []spanData{
+				newSpan(1, 0, "a", 0, 100),
+				newSpan(2, 1, "b", 10, 90),
+				newSpan(3, 2, "a", 20, 80),
+			},
+			wantRoot:         "a",
+			wantHasRoot:      true,
+			wantDuration:     100,
+			wantCriticalPath: map[string]int{"a": 80, "b": 20},
+		},
+		{
+			name: "child outliving its parent",
+			spans: []spanData{
+				newSpan(1, 0, "a", 0, 50),
+				newSpan(2, 1, "b", 40, 90),
+			},
+			wantRoot:         "a",
+			wantHasRoot:      true,
+			wantDuration:     90,
+			wantCriticalPath: map[string]int{"a": 40, "b": 10},
+		},
+		{
+			name: "missing root span",
+			spans: []spanData{
+				newSpan(3, 2, "c", 20, 30),
+				newSpan(2, 1, "b", 10, 40),
+			},
+			wantRoot:         "b",
+			wantDuration:     30,
+			wantCriticalPath: map[string]int{"b": 20, "c": 10},
+		},
+		{
+			name: "span parent of itself",
+			spans: []spanData{
+				newSpan(1, 0, "a", 0, 100),
+				newSpan(2, 2, "b", 10, 20),
+			},
+			wantRoot:         "a",
+			wantHasRoot:      true,
+			wantDuration:     100,
+			wantCriticalPath: map[string]int{"a": 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := analyzeTrace(tt.spans)
+			assert.Equal(t, tt.wantRoot, summary.rootService)
+			assert.Equal(t, tt.wantRoot+"-op", summary.rootOperation)
+			assert.Equal(t, tt.wantHasRoot, summary.hasRoot)
+			assert.Equal(t, uint64(ms(tt.wantDuration)), summary.duration)
+
+			criticalPath := map[string]int{}
+			for service, d := range summary.criticalPath {
+				if d > 0 {
+					criticalPath[service] = int(time.Duration(d) / time.Millisecond)
+				}
+			}
+			assert.Equal(t, tt.wantCriticalPath, criticalPath)
+		})
+	}
+}
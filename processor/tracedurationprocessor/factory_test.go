@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracedurationprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestCreateTracesProcessor(t *testing.T) {
+	for _, tc := range []struct {
+		name                    string
+		latencyHistogramBuckets []time.Duration
+		expectedBounds          []float64
+	}{
+		{
+			name:           "default buckets",
+			expectedBounds: defaultLatencyHistogramBucketsMs,
+		},
+		{
+			name:                    "custom buckets",
+			latencyHistogramBuckets: []time.Duration{500 * time.Microsecond, time.Second},
+			expectedBounds:          []float64{0.5, 1000},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.LatencyHistogramBuckets = tc.latencyHistogramBuckets
+
+			tp, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+			require.NoError(t, err)
+
+			p := tp.(*processor)
+			assert.Equal(t, tc.expectedBounds, p.bounds)
+			assert.False(t, p.Capabilities().MutatesData)
+		})
+	}
+}
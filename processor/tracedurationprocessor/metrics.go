@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracedurationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tracedurationprocessor"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+var (
+	statTracesEvicted = stats.Int64("traces_evicted", "Number of traces computed before decision_wait elapsed because num_traces was reached", stats.UnitDimensionless)
+	statTracesOrphan  = stats.Int64("traces_without_root", "Number of traces computed without their root span", stats.UnitDimensionless)
+)
+
+func traceDurationProcessorViews() []*view.View {
+	tracesEvictedView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statTracesEvicted.Name()),
+		Description: statTracesEvicted.Description(),
+		Measure:     statTracesEvicted,
+		Aggregation: view.Sum(),
+	}
+	tracesOrphanView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statTracesOrphan.Name()),
+		Description: statTracesOrphan.Description(),
+		Measure:     statTracesOrphan,
+		Aggregation: view.Sum(),
+	}
+
+	return []*view.View{
+		tracesEvictedView,
+		tracesOrphanView,
+	}
+}
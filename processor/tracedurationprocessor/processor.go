@@ -0,0 +1,384 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracedurationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tracedurationprocessor"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+)
+
+const (
+	metricKeySeparator = string(byte(0))
+
+	traceDurationMetricName = "traces_duration_milliseconds"
+	criticalPathMetricName  = "traces_critical_path_duration_milliseconds"
+
+	rootServiceKey   = "root.service.name"
+	rootOperationKey = "root.operation"
+	unknownService   = "unknown"
+
+	// tickInterval is how often buffered traces are checked for completion.
+	tickInterval = time.Second
+)
+
+var (
+	defaultLatencyHistogramBucketsMs = []float64{
+		2, 4, 6, 8, 10, 50, 100, 200, 400, 800, 1000, 1400, 2000, 5000, 10_000, 15_000,
+	}
+)
+
+var _ component.TracesProcessor = (*processor)(nil)
+
+// traceData is a trace being buffered until it is considered complete.
+type traceData struct {
+	firstSeen time.Time
+	spans     []spanData
+}
+
+// histogramSeries is a cumulative latency histogram for a set of attributes.
+type histogramSeries struct {
+	attributes   map[string]string
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+}
+
+type processor struct {
+	config          *Config
+	logger          *zap.Logger
+	nextConsumer    consumer.Traces
+	metricsExporter consumer.Metrics
+
+	bounds       []float64
+	startTime    time.Time
+	now          func() time.Time
+	tickInterval time.Duration
+
+	tracesMutex sync.Mutex
+	traces      map[pcommon.TraceID]*traceData
+	// arrivals holds the IDs of the buffered traces in the order they were
+	// first seen, which is also the order in which they complete.
+	arrivals []pcommon.TraceID
+
+	seriesMutex    sync.Mutex
+	traceDurations map[string]*histogramSeries
+	criticalPaths  map[string]*histogramSeries
+
+	shutdownCh chan struct{}
+	loopDone   sync.WaitGroup
+}
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.Traces) *processor {
+	bounds := defaultLatencyHistogramBucketsMs
+	if cfg.LatencyHistogramBuckets != nil {
+		bounds = mapDurationsToMillis(cfg.LatencyHistogramBuckets)
+	}
+
+	return &processor{
+		config:         cfg,
+		logger:         logger,
+		nextConsumer:   nextConsumer,
+		bounds:         bounds,
+		startTime:      time.Now(),
+		now:            time.Now,
+		tickInterval:   tickInterval,
+		traces:         map[pcommon.TraceID]*traceData{},
+		traceDurations: map[string]*histogramSeries{},
+		criticalPaths:  map[string]*histogramSeries{},
+		shutdownCh:     make(chan struct{}),
+	}
+}
+
+func (p *processor) Start(_ context.Context, host component.Host) error {
+	exporters := host.GetExporters()
+
+	// The available list of exporters come from any configured metrics pipelines' exporters.
+	for k, exp := range exporters[config.MetricsDataType] {
+		metricsExp, ok := exp.(component.MetricsExporter)
+		if k.String() == p.config.MetricsExporter && ok {
+			p.metricsExporter = metricsExp
+			break
+		}
+	}
+
+	if p.metricsExporter == nil {
+		return fmt.Errorf("failed to find metrics exporter: %s",
+			p.config.MetricsExporter)
+	}
+
+	p.loopDone.Add(1)
+	go p.completionLoop(p.tickInterval)
+
+	p.logger.Info("Started tracedurationprocessor")
+	return nil
+}
+
+func (p *processor) Shutdown(ctx context.Context) error {
+	p.logger.Info("Shutting down tracedurationprocessor")
+	close(p.shutdownCh)
+	p.loopDone.Wait()
+
+	if p.metricsExporter == nil {
+		return nil
+	}
+	// The exporters are shut down after the processors, flush the traces
+	// still buffered so that their durations are not lost.
+	p.tracesMutex.Lock()
+	completed := make([]*traceData, 0, len(p.traces))
+	for _, id := range p.arrivals {
+		completed = append(completed, p.traces[id])
+	}
+	p.traces = map[pcommon.TraceID]*traceData{}
+	p.arrivals = nil
+	p.tracesMutex.Unlock()
+
+	return p.record(ctx, completed)
+}
+
+func (p *processor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (p *processor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	evicted := p.bufferSpans(td)
+	if len(evicted) > 0 {
+		stats.Record(ctx, statTracesEvicted.M(int64(len(evicted))))
+		if err := p.record(ctx, evicted); err != nil {
+			p.logger.Warn("failed to export trace duration metrics", zap.Error(err))
+		}
+	}
+
+	return p.nextConsumer.ConsumeTraces(ctx, td)
+}
+
+// bufferSpans adds the spans to the buffered traces and returns the traces
+// evicted to stay within num_traces.
+func (p *processor) bufferSpans(td ptrace.Traces) []*traceData {
+	p.tracesMutex.Lock()
+	defer p.tracesMutex.Unlock()
+
+	now := p.now()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		service := unknownService
+		if v, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName); ok {
+			service = v.AsString()
+		}
+
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				trace, ok := p.traces[span.TraceID()]
+				if !ok {
+					trace = &traceData{firstSeen: now}
+					p.traces[span.TraceID()] = trace
+					p.arrivals = append(p.arrivals, span.TraceID())
+				}
+				trace.spans = append(trace.spans, spanData{
+					spanID:       span.SpanID(),
+					parentSpanID: span.ParentSpanID(),
+					service:      service,
+					name:         span.Name(),
+					start:        span.StartTimestamp(),
+					end:          span.EndTimestamp(),
+				})
+			}
+		}
+	}
+
+	var evicted []*traceData
+	for len(p.traces) > p.config.NumTraces {
+		evicted = append(evicted, p.popOldest())
+	}
+	return evicted
+}
+
+// popOldest removes the trace first seen the longest time ago from the
+// buffer. Must be called with tracesMutex held.
+func (p *processor) popOldest() *traceData {
+	id := p.arrivals[0]
+	p.arrivals = p.arrivals[1:]
+	trace := p.traces[id]
+	delete(p.traces, id)
+	return trace
+}
+
+// completeExpired records the traces buffered for longer than decision_wait.
+func (p *processor) completeExpired(ctx context.Context) error {
+	p.tracesMutex.Lock()
+	var completed []*traceData
+	deadline := p.now().Add(-p.config.DecisionWait)
+	for len(p.arrivals) > 0 && !p.traces[p.arrivals[0]].firstSeen.After(deadline) {
+		completed = append(completed, p.popOldest())
+	}
+	p.tracesMutex.Unlock()
+
+	return p.record(ctx, completed)
+}
+
+// record updates the histograms with the given complete traces, and exports
+// them.
+func (p *processor) record(ctx context.Context, traces []*traceData) error {
+	if len(traces) == 0 {
+		return nil
+	}
+
+	p.seriesMutex.Lock()
+	orphans := 0
+	for _, trace := range traces {
+		summary := analyzeTrace(trace.spans)
+		if !summary.hasRoot {
+			orphans++
+		}
+		p.updateSeries(p.traceDurations, summary.duration, map[string]string{
+			conventions.AttributeServiceName: summary.rootService,
+			rootOperationKey:                 summary.rootOperation,
+		})
+		for service, d := range summary.criticalPath {
+			p.updateSeries(p.criticalPaths, d, map[string]string{
+				conventions.AttributeServiceName: service,
+				rootServiceKey:                   summary.rootService,
+				rootOperationKey:                 summary.rootOperation,
+			})
+		}
+	}
+	md := p.buildMetrics()
+	p.seriesMutex.Unlock()
+
+	if orphans > 0 {
+		stats.Record(ctx, statTracesOrphan.M(int64(orphans)))
+	}
+	return p.metricsExporter.ConsumeMetrics(ctx, md)
+}
+
+// updateSeries records a duration, in nanoseconds, in the histogram identified
+// by the attributes. Must be called with seriesMutex held.
+func (p *processor) updateSeries(series map[string]*histogramSeries, nanos uint64, attributes map[string]string) {
+	key := buildMetricKey(attributes)
+	s, ok := series[key]
+	if !ok {
+		s = &histogramSeries{
+			attributes:   attributes,
+			bucketCounts: make([]uint64, len(p.bounds)+1),
+		}
+		series[key] = s
+	}
+
+	latency := float64(nanos) / float64(time.Millisecond.Nanoseconds())
+	s.count++
+	s.sum += latency
+	s.bucketCounts[sort.SearchFloat64s(p.bounds, latency)]++
+}
+
+// buildMetrics builds the cumulative histograms. Must be called with
+// seriesMutex held.
+func (p *processor) buildMetrics() pmetric.Metrics {
+	m := pmetric.NewMetrics()
+	ilm := m.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	ilm.Scope().SetName("tracedurationprocessor")
+
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	p.collectHistogram(ilm, traceDurationMetricName, p.traceDurations, timestamp)
+	p.collectHistogram(ilm, criticalPathMetricName, p.criticalPaths, timestamp)
+	return m
+}
+
+func (p *processor) collectHistogram(ilm pmetric.ScopeMetrics, name string, series map[string]*histogramSeries, timestamp pcommon.Timestamp) {
+	if len(series) == 0 {
+		return
+	}
+
+	m := ilm.Metrics().AppendEmpty()
+	m.SetName(name)
+	m.SetUnit("ms")
+	m.SetEmptyHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+
+	dps := m.Histogram().DataPoints()
+	dps.EnsureCapacity(len(series))
+	for _, s := range series {
+		dp := dps.AppendEmpty()
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dp.SetTimestamp(timestamp)
+		dp.ExplicitBounds().FromRaw(p.bounds)
+		dp.BucketCounts().FromRaw(s.bucketCounts)
+		dp.SetCount(s.count)
+		dp.SetSum(s.sum)
+		for k, v := range s.attributes {
+			dp.Attributes().PutStr(k, v)
+		}
+	}
+}
+
+// completionLoop periodically records the traces that are complete.
+func (p *processor) completionLoop(d time.Duration) {
+	defer p.loopDone.Done()
+
+	t := time.NewTicker(d)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := p.completeExpired(context.Background()); err != nil {
+				p.logger.Warn("failed to export trace duration metrics", zap.Error(err))
+			}
+		case <-p.shutdownCh:
+			return
+		}
+	}
+}
+
+func buildMetricKey(attributes map[string]string) string {
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var key string
+	for _, k := range keys {
+		key += k + metricKeySeparator + attributes[k] + metricKeySeparator
+	}
+	return key
+}
+
+// durationToMillis converts the given duration to the number of milliseconds it represents.
+// Note that this can return sub-millisecond (i.e. < 1ms) values as well.
+func durationToMillis(d time.Duration) float64 {
+	return float64(d.Nanoseconds()) / float64(time.Millisecond.Nanoseconds())
+}
+
+func mapDurationsToMillis(vs []time.Duration) []float64 {
+	vsm := make([]float64, len(vs))
+	for i, v := range vs {
+		vsm[i] = durationToMillis(v)
+	}
+	return vsm
+}
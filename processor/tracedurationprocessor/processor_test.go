@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracedurationprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap/zaptest"
+)
+
+type mockHost struct {
+	component.Host
+	exporters map[config.DataType]map[config.ComponentID]component.Exporter
+}
+
+func (m *mockHost) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
+	return m.exporters
+}
+
+type mockMetricsExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	*consumertest.MetricsSink
+}
+
+func newTestProcessor(t *testing.T, cfg *Config) (*processor, *consumertest.TracesSink, *consumertest.MetricsSink) {
+	cfg.MetricsExporter = "mock"
+	next := new(consumertest.TracesSink)
+	metrics := new(consumertest.MetricsSink)
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[config.DataType]map[config.ComponentID]component.Exporter{
+			config.MetricsDataType: {
+				config.NewComponentID("mock"): &mockMetricsExporter{MetricsSink: metrics},
+			},
+		},
+	}
+
+	p := newProcessor(zaptest.NewLogger(t), cfg, next)
+	// Traces are completed explicitly by the tests.
+	p.tickInterval = time.Hour
+	require.NoError(t, p.Start(context.Background(), host))
+	t.Cleanup(func() {
+		select {
+		case <-p.shutdownCh:
+		default:
+			assert.NoError(t, p.Shutdown(context.Background()))
+		}
+	})
+	return p, next, metrics
+}
+
+// sampleTrace builds a trace where service "frontend" calls "backend" for
+// 60ms out of 100ms.
+func sampleTrace(traceID byte) ptrace.Traces {
+	td := ptrace.NewTraces()
+	start := time.Unix(1000, 0)
+	id := pcommon.TraceID([16]byte{traceID})
+
+	frontend := td.ResourceSpans().AppendEmpty()
+	frontend.Resource().Attributes().PutStr(conventions.AttributeServiceName, "frontend")
+	root := frontend.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	root.SetTraceID(id)
+	root.SetSpanID(spanID(1))
+	root.SetName("GET /checkout")
+	root.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	root.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(100 * time.Millisecond)))
+
+	backend := td.ResourceSpans().AppendEmpty()
+	backend.Resource().Attributes().PutStr(conventions.AttributeServiceName, "backend")
+	child := backend.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	child.SetTraceID(id)
+	child.SetSpanID(spanID(2))
+	child.SetParentSpanID(spanID(1))
+	child.SetName("charge")
+	child.SetStartTimestamp(pcommon.NewTimestampFromTime(start.Add(20 * time.Millisecond)))
+	child.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(80 * time.Millisecond)))
+
+	return td
+}
+
+// histogramPoints returns the data points of the named histogram, keyed by the
+// service.name attribute.
+func histogramPoints(t *testing.T, md pmetric.Metrics, name string) map[string]pmetric.HistogramDataPoint {
+	points := map[string]pmetric.HistogramDataPoint{}
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() != name {
+			continue
+		}
+		assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, m.Histogram().AggregationTemporality())
+		dps := m.Histogram().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			service, ok := dps.At(j).Attributes().Get(conventions.AttributeServiceName)
+			require.True(t, ok)
+			points[service.Str()] = dps.At(j)
+		}
+	}
+	return points
+}
+
+func TestProcessorStart(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	p := newProcessor(zaptest.NewLogger(t), cfg, consumertest.NewNop())
+
+	err := p.Start(context.Background(), &mockHost{Host: componenttest.NewNopHost()})
+	assert.EqualError(t, err, "failed to find metrics exporter: prometheus")
+}
+
+func TestProcessorRecordsCompleteTraces(t *testing.T) {
+	p, next, metrics := newTestProcessor(t, createDefaultConfig().(*Config))
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), sampleTrace(1)))
+	assert.Equal(t, 2, next.SpanCount())
+
+	// The trace is not complete until decision_wait has elapsed.
+	require.NoError(t, p.completeExpired(context.Background()))
+	assert.Empty(t, metrics.AllMetrics())
+
+	now = now.Add(p.config.DecisionWait)
+	require.NoError(t, p.completeExpired(context.Background()))
+	require.Len(t, metrics.AllMetrics(), 1)
+	md := metrics.AllMetrics()[0]
+
+	durations := histogramPoints(t, md, traceDurationMetricName)
+	require.Len(t, durations, 1)
+	dp := durations["frontend"]
+	assert.EqualValues(t, 1, dp.Count())
+	assert.Equal(t, 100.0, dp.Sum())
+	op, _ := dp.Attributes().Get(rootOperationKey)
+	assert.Equal(t, "GET /checkout", op.Str())
+
+	criticalPath := histogramPoints(t, md, criticalPathMetricName)
+	require.Len(t, criticalPath, 2)
+	assert.Equal(t, 40.0, criticalPath["frontend"].Sum())
+	assert.Equal(t, 60.0, criticalPath["backend"].Sum())
+	rootService, _ := criticalPath["backend"].Attributes().Get(rootServiceKey)
+	assert.Equal(t, "frontend", rootService.Str())
+
+	// Histograms are cumulative.
+	require.NoError(t, p.ConsumeTraces(context.Background(), sampleTrace(2)))
+	now = now.Add(p.config.DecisionWait)
+	require.NoError(t, p.completeExpired(context.Background()))
+	require.Len(t, metrics.AllMetrics(), 2)
+	durations = histogramPoints(t, metrics.AllMetrics()[1], traceDurationMetricName)
+	assert.EqualValues(t, 2, durations["frontend"].Count())
+	assert.Equal(t, 200.0, durations["frontend"].Sum())
+}
+
+func TestProcessorEvictsOldestTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NumTraces = 1
+	p, _, metrics := newTestProcessor(t, cfg)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), sampleTrace(1)))
+	assert.Empty(t, metrics.AllMetrics())
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), sampleTrace(2)))
+	require.Len(t, metrics.AllMetrics(), 1)
+	durations := histogramPoints(t, metrics.AllMetrics()[0], traceDurationMetricName)
+	assert.EqualValues(t, 1, durations["frontend"].Count())
+	assert.Len(t, p.traces, 1)
+}
+
+func TestProcessorShutdownFlushesBufferedTraces(t *testing.T) {
+	p, _, metrics := newTestProcessor(t, createDefaultConfig().(*Config))
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), sampleTrace(1)))
+	require.NoError(t, p.ConsumeTraces(context.Background(), sampleTrace(2)))
+	assert.Empty(t, metrics.AllMetrics())
+
+	require.NoError(t, p.Shutdown(context.Background()))
+	require.Len(t, metrics.AllMetrics(), 1)
+	durations := histogramPoints(t, metrics.AllMetrics()[0], traceDurationMetricName)
+	assert.EqualValues(t, 2, durations["frontend"].Count())
+}
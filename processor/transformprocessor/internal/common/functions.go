@@ -26,6 +26,8 @@ func Functions[K any]() map[string]interface{} {
 		"Concat":               ottlfuncs.Concat[K],
 		"Split":                ottlfuncs.Split[K],
 		"Int":                  ottlfuncs.Int[K],
+		"ParseCSV":             ottlfuncs.ParseCSV[K],
+		"ParseXML":             ottlfuncs.ParseXML[K],
 		"keep_keys":            ottlfuncs.KeepKeys[K],
 		"set":                  ottlfuncs.Set[K],
 		"truncate_all":         ottlfuncs.TruncateAll[K],
@@ -36,5 +38,7 @@ func Functions[K any]() map[string]interface{} {
 		"replace_all_patterns": ottlfuncs.ReplaceAllPatterns[K],
 		"delete_key":           ottlfuncs.DeleteKey[K],
 		"delete_matching_keys": ottlfuncs.DeleteMatchingKeys[K],
+		"merge_maps":           ottlfuncs.MergeMaps[K],
+		"flatten":              ottlfuncs.Flatten[K],
 	}
 }
@@ -193,6 +193,21 @@ func TestProcess(t *testing.T) {
 			statement: `set(attributes["test"], Split(attributes["not_exist"], "|"))`,
 			want:      func(td plog.Logs) {},
 		},
+		{
+			statement: `set(body, ParseCSV(attributes["flags"], "first|second|third", "|")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				body := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().SetEmptyMap()
+				body.PutStr("first", "A")
+				body.PutStr("second", "B")
+				body.PutStr("third", "C")
+			},
+		},
+		{
+			statement: `merge_maps(attributes, ParseCSV(attributes["flags"], "http.method|first", "|"), "insert")`,
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("first", "D")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/unitconv"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+)
+
+func convertUnit(unit string) (ottl.ExprFunc[ottldatapoints.TransformContext], error) {
+	if _, err := unitconv.Factor(unit, unit); err != nil {
+		return nil, err
+	}
+
+	return func(ctx ottldatapoints.TransformContext) interface{} {
+		// All the data points of the metric are converted along with the unit, the first time the condition
+		// holds, the function being a noop for the next data points. The metrics whose unit can't be
+		// converted are left unchanged.
+		_ = unitconv.ConvertMetric(ctx.GetMetric(), unit)
+		return nil
+	}, nil
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func Test_convertUnit(t *testing.T) {
+	tests := []struct {
+		name  string
+		input pmetric.Metric
+		unit  string
+		want  func(pmetric.MetricSlice)
+	}{
+		{
+			name:  "histogram",
+			input: getTestHistogramMetric(),
+			unit:  "s",
+			want: func(metrics pmetric.MetricSlice) {
+				histogramMetric := getTestHistogramMetric()
+				histogramMetric.SetUnit("s")
+				dp := histogramMetric.Histogram().DataPoints().At(0)
+				dp.SetSum(12.34 / 1000)
+				dp.ExplicitBounds().FromRaw([]float64{1.0 / 1000, 10.0 / 1000})
+				histogramMetric.CopyTo(metrics.AppendEmpty())
+			},
+		},
+		{
+			name: "gauge with several data points",
+			input: func() pmetric.Metric {
+				gaugeMetric := getTestGaugeMetric()
+				gaugeMetric.SetUnit("By")
+				gaugeMetric.Gauge().DataPoints().AppendEmpty().SetIntValue(2 << 20)
+				return gaugeMetric
+			}(),
+			unit: "MiBy",
+			want: func(metrics pmetric.MetricSlice) {
+				gaugeMetric := getTestGaugeMetric()
+				gaugeMetric.SetUnit("MiBy")
+				gaugeMetric.Gauge().DataPoints().At(0).SetDoubleValue(12.0 / (1 << 20))
+				gaugeMetric.Gauge().DataPoints().AppendEmpty().SetDoubleValue(2)
+				gaugeMetric.CopyTo(metrics.AppendEmpty())
+			},
+		},
+		{
+			name: "incompatible unit",
+			input: func() pmetric.Metric {
+				gaugeMetric := getTestGaugeMetric()
+				gaugeMetric.SetUnit("By")
+				return gaugeMetric
+			}(),
+			unit: "s",
+			want: func(metrics pmetric.MetricSlice) {
+				gaugeMetric := getTestGaugeMetric()
+				gaugeMetric.SetUnit("By")
+				gaugeMetric.CopyTo(metrics.AppendEmpty())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualMetrics := pmetric.NewMetricSlice()
+			tt.input.CopyTo(actualMetrics.AppendEmpty())
+
+			evaluate, err := convertUnit(tt.unit)
+			assert.NoError(t, err)
+
+			evaluateOnEachDataPoint(t, evaluate, actualMetrics.At(0), actualMetrics)
+
+			expected := pmetric.NewMetricSlice()
+			tt.want(expected)
+			assert.Equal(t, expected, actualMetrics)
+		})
+	}
+}
+
+func Test_convertUnit_validation(t *testing.T) {
+	_, err := convertUnit("{requests}")
+	assert.EqualError(t, err, `unsupported unit "{requests}"`)
+}
+
+func Test_convertUnit_whereClause(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      []float64
+	}{
+		{
+			name:      "condition on the metric",
+			statement: `convert_unit("s") where metric.name == "a"`,
+			want:      []float64{0.001, 0.002, 3, 4},
+		},
+		{
+			name:      "condition on the unit",
+			statement: `convert_unit("s") where metric.unit == "ms"`,
+			want:      []float64{0.001, 0.002, 0.003, 0.004},
+		},
+		{
+			name:      "condition on one of the data points",
+			statement: `convert_unit("s") where attributes["attr"] == "second"`,
+			want:      []float64{0.001, 0.002, 0.003, 0.004},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := pmetric.NewMetrics()
+			metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+			for i, name := range []string{"a", "b"} {
+				m := metrics.AppendEmpty()
+				m.SetName(name)
+				m.SetUnit("ms")
+				dps := m.SetEmptyGauge().DataPoints()
+				for j, attr := range []string{"first", "second"} {
+					dp := dps.AppendEmpty()
+					dp.SetDoubleValue(float64(2*i + j + 1))
+					dp.Attributes().PutStr("attr", attr)
+				}
+			}
+
+			processor, err := NewProcessor([]string{tt.statement}, Functions(), componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+			_, err = processor.ProcessMetrics(context.Background(), md)
+			assert.NoError(t, err)
+
+			var got []float64
+			for i := 0; i < metrics.Len(); i++ {
+				dps := metrics.At(i).Gauge().DataPoints()
+				for j := 0; j < dps.Len(); j++ {
+					got = append(got, dps.At(j).DoubleValue())
+				}
+			}
+			assert.InDeltaSlice(t, tt.want, got, 1e-9)
+		})
+	}
+}
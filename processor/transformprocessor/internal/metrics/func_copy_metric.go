@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+)
+
+func copyMetric(name string) (ottl.ExprFunc[ottldatapoints.TransformContext], error) {
+	return func(ctx ottldatapoints.TransformContext) interface{} {
+		metric := ctx.GetMetric()
+		if metric.Name() == name {
+			return nil
+		}
+
+		dest := getOrAppendCopy(ctx.GetMetrics(), metric, name)
+		switch dp := ctx.GetDataPoint().(type) {
+		case pmetric.NumberDataPoint:
+			if dest.Type() == pmetric.MetricTypeSum {
+				dp.CopyTo(dest.Sum().DataPoints().AppendEmpty())
+			} else {
+				dp.CopyTo(dest.Gauge().DataPoints().AppendEmpty())
+			}
+		case pmetric.HistogramDataPoint:
+			dp.CopyTo(dest.Histogram().DataPoints().AppendEmpty())
+		case pmetric.ExponentialHistogramDataPoint:
+			dp.CopyTo(dest.ExponentialHistogram().DataPoints().AppendEmpty())
+		case pmetric.SummaryDataPoint:
+			dp.CopyTo(dest.Summary().DataPoints().AppendEmpty())
+		}
+		return nil
+	}, nil
+}
+
+// getOrAppendCopy returns the metric with the given name and the type of the
+// source metric, appending a copy of the source metric without data points when
+// the slice has none.
+func getOrAppendCopy(metrics pmetric.MetricSlice, source pmetric.Metric, name string) pmetric.Metric {
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() == name && m.Type() == source.Type() {
+			return m
+		}
+	}
+	dest := metrics.AppendEmpty()
+	dest.SetDescription(source.Description())
+	dest.SetName(name)
+	dest.SetUnit(source.Unit())
+	switch source.Type() {
+	case pmetric.MetricTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		dest.SetEmptySum().SetAggregationTemporality(source.Sum().AggregationTemporality())
+		dest.Sum().SetIsMonotonic(source.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(source.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(source.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		dest.SetEmptySummary()
+	}
+	return dest
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func Test_copyMetric(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   pmetric.Metric
+		newName string
+		want    func(pmetric.MetricSlice)
+	}{
+		{
+			name:    "histogram",
+			input:   getTestHistogramMetric(),
+			newName: "histogram_copy",
+			want: func(metrics pmetric.MetricSlice) {
+				getTestHistogramMetric().CopyTo(metrics.AppendEmpty())
+				copied := metrics.AppendEmpty()
+				getTestHistogramMetric().CopyTo(copied)
+				copied.SetName("histogram_copy")
+			},
+		},
+		{
+			name:    "gauge",
+			input:   getTestGaugeMetric(),
+			newName: "gauge_copy",
+			want: func(metrics pmetric.MetricSlice) {
+				getTestGaugeMetric().CopyTo(metrics.AppendEmpty())
+				copied := metrics.AppendEmpty()
+				getTestGaugeMetric().CopyTo(copied)
+				copied.SetName("gauge_copy")
+			},
+		},
+		{
+			name:    "same name",
+			input:   getTestGaugeMetric(),
+			newName: "gauge_metric",
+			want: func(metrics pmetric.MetricSlice) {
+				getTestGaugeMetric().CopyTo(metrics.AppendEmpty())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualMetrics := pmetric.NewMetricSlice()
+			tt.input.CopyTo(actualMetrics.AppendEmpty())
+
+			evaluate, err := copyMetric(tt.newName)
+			assert.NoError(t, err)
+
+			evaluateOnEachDataPoint(t, evaluate, actualMetrics.At(0), actualMetrics)
+
+			expected := pmetric.NewMetricSlice()
+			tt.want(expected)
+			assert.Equal(t, expected, actualMetrics)
+		})
+	}
+}
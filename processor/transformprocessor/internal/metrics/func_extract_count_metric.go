@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+)
+
+func extractCountMetric(monotonic bool) (ottl.ExprFunc[ottldatapoints.TransformContext], error) {
+	return func(ctx ottldatapoints.TransformContext) interface{} {
+		metric := ctx.GetMetric()
+		aggTemp, ok := distributionAggregationTemporality(metric)
+		if !ok {
+			return nil
+		}
+
+		var count uint64
+		switch dp := ctx.GetDataPoint().(type) {
+		case pmetric.HistogramDataPoint:
+			count = dp.Count()
+		case pmetric.ExponentialHistogramDataPoint:
+			count = dp.Count()
+		case pmetric.SummaryDataPoint:
+			count = dp.Count()
+		default:
+			return nil
+		}
+
+		countMetric := getOrAppendSum(ctx.GetMetrics(), metric, metric.Name()+"_count", aggTemp, monotonic)
+		countDp := countMetric.Sum().DataPoints().AppendEmpty()
+		copyDataPointFields(ctx.GetDataPoint(), countDp)
+		countDp.SetIntValue(int64(count))
+		return nil
+	}, nil
+}
+
+// distributionAggregationTemporality returns the aggregation temporality of the
+// histogram, exponential histogram or summary metric. Summaries are always
+// cumulative.
+func distributionAggregationTemporality(metric pmetric.Metric) (pmetric.MetricAggregationTemporality, bool) {
+	switch metric.Type() {
+	case pmetric.MetricTypeHistogram:
+		return metric.Histogram().AggregationTemporality(), true
+	case pmetric.MetricTypeExponentialHistogram:
+		return metric.ExponentialHistogram().AggregationTemporality(), true
+	case pmetric.MetricTypeSummary:
+		return pmetric.MetricAggregationTemporalityCumulative, true
+	default:
+		return pmetric.MetricAggregationTemporalityUnspecified, false
+	}
+}
+
+// getOrAppendSum returns the Sum metric with the given name, appending it with
+// the description and unit of the source metric when the slice has none, so
+// that the data points of all the source data points end up in a single metric.
+func getOrAppendSum(metrics pmetric.MetricSlice, source pmetric.Metric, name string, aggTemp pmetric.MetricAggregationTemporality, monotonic bool) pmetric.Metric {
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() == name && m.Type() == pmetric.MetricTypeSum {
+			return m
+		}
+	}
+	sumMetric := metrics.AppendEmpty()
+	sumMetric.SetDescription(source.Description())
+	sumMetric.SetName(name)
+	sumMetric.SetUnit(source.Unit())
+	sumMetric.SetEmptySum().SetAggregationTemporality(aggTemp)
+	sumMetric.Sum().SetIsMonotonic(monotonic)
+	return sumMetric
+}
+
+// copyDataPointFields copies the attributes and timestamps of a histogram,
+// exponential histogram or summary data point to a number data point.
+func copyDataPointFields(source interface{}, dest pmetric.NumberDataPoint) {
+	switch dp := source.(type) {
+	case pmetric.HistogramDataPoint:
+		dp.Attributes().CopyTo(dest.Attributes())
+		dest.SetStartTimestamp(dp.StartTimestamp())
+		dest.SetTimestamp(dp.Timestamp())
+	case pmetric.ExponentialHistogramDataPoint:
+		dp.Attributes().CopyTo(dest.Attributes())
+		dest.SetStartTimestamp(dp.StartTimestamp())
+		dest.SetTimestamp(dp.Timestamp())
+	case pmetric.SummaryDataPoint:
+		dp.Attributes().CopyTo(dest.Attributes())
+		dest.SetStartTimestamp(dp.StartTimestamp())
+		dest.SetTimestamp(dp.Timestamp())
+	}
+}
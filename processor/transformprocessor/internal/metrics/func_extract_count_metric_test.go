@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+)
+
+func getTestHistogramMetric() pmetric.Metric {
+	metricInput := pmetric.NewMetric()
+	metricInput.SetEmptyHistogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	metricInput.SetName("histogram_metric")
+	metricInput.SetUnit("ms")
+	input := metricInput.Histogram().DataPoints().AppendEmpty()
+	input.SetCount(5)
+	input.SetSum(12.34)
+	input.ExplicitBounds().FromRaw([]float64{1, 10})
+	input.BucketCounts().FromRaw([]uint64{1, 3, 1})
+
+	attrs := getTestAttributes()
+	attrs.CopyTo(input.Attributes())
+	return metricInput
+}
+
+func Test_extractCountMetric(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     pmetric.Metric
+		monotonic bool
+		want      func(pmetric.MetricSlice)
+	}{
+		{
+			name:      "histogram",
+			input:     getTestHistogramMetric(),
+			monotonic: true,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestHistogramMetric().CopyTo(metrics.AppendEmpty())
+				countMetric := metrics.AppendEmpty()
+				countMetric.SetName("histogram_metric_count")
+				countMetric.SetUnit("ms")
+				countMetric.SetEmptySum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+				countMetric.Sum().SetIsMonotonic(true)
+
+				dp := countMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetIntValue(5)
+				getTestAttributes().CopyTo(dp.Attributes())
+			},
+		},
+		{
+			name:      "summary",
+			input:     getTestSummaryMetric(),
+			monotonic: false,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestSummaryMetric().CopyTo(metrics.AppendEmpty())
+				countMetric := metrics.AppendEmpty()
+				countMetric.SetName("summary_metric_count")
+				countMetric.SetEmptySum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+				countMetric.Sum().SetIsMonotonic(false)
+
+				dp := countMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetIntValue(100)
+				getTestAttributes().CopyTo(dp.Attributes())
+			},
+		},
+		{
+			name:      "no op",
+			input:     getTestGaugeMetric(),
+			monotonic: false,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestGaugeMetric().CopyTo(metrics.AppendEmpty())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualMetrics := pmetric.NewMetricSlice()
+			tt.input.CopyTo(actualMetrics.AppendEmpty())
+
+			evaluate, err := extractCountMetric(tt.monotonic)
+			assert.NoError(t, err)
+
+			evaluateOnEachDataPoint(t, evaluate, actualMetrics.At(0), actualMetrics)
+
+			expected := pmetric.NewMetricSlice()
+			tt.want(expected)
+			assert.Equal(t, expected, actualMetrics)
+		})
+	}
+}
+
+func Test_extractCountMetric_singleMetric(t *testing.T) {
+	actualMetrics := pmetric.NewMetricSlice()
+	histogram := getTestHistogramMetric()
+	histogram.Histogram().DataPoints().At(0).CopyTo(histogram.Histogram().DataPoints().AppendEmpty())
+	histogram.CopyTo(actualMetrics.AppendEmpty())
+
+	evaluate, err := extractCountMetric(true)
+	assert.NoError(t, err)
+
+	evaluateOnEachDataPoint(t, evaluate, actualMetrics.At(0), actualMetrics)
+
+	assert.Equal(t, 2, actualMetrics.Len())
+	assert.Equal(t, 2, actualMetrics.At(1).Sum().DataPoints().Len())
+}
+
+// evaluateOnEachDataPoint calls evaluate for each data point of the metric, the
+// way the processor does.
+func evaluateOnEachDataPoint(t *testing.T, evaluate func(ottldatapoints.TransformContext) interface{}, metric pmetric.Metric, metrics pmetric.MetricSlice) {
+	var dps []interface{}
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Gauge().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Sum().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Histogram().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			dps = append(dps, metric.ExponentialHistogram().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			dps = append(dps, metric.Summary().DataPoints().At(i))
+		}
+	default:
+		t.Fatalf("unexpected metric type %v", metric.Type())
+	}
+	for _, dp := range dps {
+		evaluate(ottldatapoints.NewTransformContext(dp, metric, metrics, pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+)
+
+func extractSumMetric(monotonic bool) (ottl.ExprFunc[ottldatapoints.TransformContext], error) {
+	return func(ctx ottldatapoints.TransformContext) interface{} {
+		metric := ctx.GetMetric()
+		aggTemp, ok := distributionAggregationTemporality(metric)
+		if !ok {
+			return nil
+		}
+
+		var sum float64
+		switch dp := ctx.GetDataPoint().(type) {
+		case pmetric.HistogramDataPoint:
+			if !dp.HasSum() {
+				return nil
+			}
+			sum = dp.Sum()
+		case pmetric.ExponentialHistogramDataPoint:
+			if !dp.HasSum() {
+				return nil
+			}
+			sum = dp.Sum()
+		case pmetric.SummaryDataPoint:
+			sum = dp.Sum()
+		default:
+			return nil
+		}
+
+		sumMetric := getOrAppendSum(ctx.GetMetrics(), metric, metric.Name()+"_sum", aggTemp, monotonic)
+		sumDp := sumMetric.Sum().DataPoints().AppendEmpty()
+		copyDataPointFields(ctx.GetDataPoint(), sumDp)
+		sumDp.SetDoubleValue(sum)
+		return nil
+	}, nil
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func Test_extractSumMetric(t *testing.T) {
+	histogramWithoutSum := pmetric.NewMetric()
+	histogramWithoutSum.SetEmptyHistogram().DataPoints().AppendEmpty().SetCount(5)
+	histogramWithoutSum.SetName("histogram_metric")
+
+	tests := []struct {
+		name      string
+		input     pmetric.Metric
+		monotonic bool
+		want      func(pmetric.MetricSlice)
+	}{
+		{
+			name:      "histogram",
+			input:     getTestHistogramMetric(),
+			monotonic: true,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestHistogramMetric().CopyTo(metrics.AppendEmpty())
+				sumMetric := metrics.AppendEmpty()
+				sumMetric.SetName("histogram_metric_sum")
+				sumMetric.SetUnit("ms")
+				sumMetric.SetEmptySum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+				sumMetric.Sum().SetIsMonotonic(true)
+
+				dp := sumMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetDoubleValue(12.34)
+				getTestAttributes().CopyTo(dp.Attributes())
+			},
+		},
+		{
+			name:      "histogram without sum",
+			input:     histogramWithoutSum,
+			monotonic: true,
+			want: func(metrics pmetric.MetricSlice) {
+				histogramWithoutSum.CopyTo(metrics.AppendEmpty())
+			},
+		},
+		{
+			name:      "summary",
+			input:     getTestSummaryMetric(),
+			monotonic: false,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestSummaryMetric().CopyTo(metrics.AppendEmpty())
+				sumMetric := metrics.AppendEmpty()
+				sumMetric.SetName("summary_metric_sum")
+				sumMetric.SetEmptySum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+				sumMetric.Sum().SetIsMonotonic(false)
+
+				dp := sumMetric.Sum().DataPoints().AppendEmpty()
+				dp.SetDoubleValue(12.34)
+				getTestAttributes().CopyTo(dp.Attributes())
+			},
+		},
+		{
+			name:      "no op",
+			input:     getTestGaugeMetric(),
+			monotonic: false,
+			want: func(metrics pmetric.MetricSlice) {
+				getTestGaugeMetric().CopyTo(metrics.AppendEmpty())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualMetrics := pmetric.NewMetricSlice()
+			tt.input.CopyTo(actualMetrics.AppendEmpty())
+
+			evaluate, err := extractSumMetric(tt.monotonic)
+			assert.NoError(t, err)
+
+			evaluateOnEachDataPoint(t, evaluate, actualMetrics.At(0), actualMetrics)
+
+			expected := pmetric.NewMetricSlice()
+			tt.want(expected)
+			assert.Equal(t, expected, actualMetrics)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/unitconv"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoints"
+)
+
+func scaleMetric(factor float64, unit string) (ottl.ExprFunc[ottldatapoints.TransformContext], error) {
+	return func(ctx ottldatapoints.TransformContext) interface{} {
+		switch dp := ctx.GetDataPoint().(type) {
+		case pmetric.NumberDataPoint:
+			unitconv.ScaleNumberDataPoint(dp, factor)
+		case pmetric.HistogramDataPoint:
+			unitconv.ScaleHistogramDataPoint(dp, factor)
+		case pmetric.SummaryDataPoint:
+			unitconv.ScaleSummaryDataPoint(dp, factor)
+		default:
+			// The buckets of exponential histograms can't be scaled by an arbitrary factor.
+			return nil
+		}
+
+		if unit != "" {
+			ctx.GetMetric().SetUnit(unit)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func Test_scaleMetric(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  pmetric.Metric
+		factor float64
+		unit   string
+		want   func(pmetric.MetricSlice)
+	}{
+		{
+			name:   "int gauge",
+			input:  getTestGaugeMetric(),
+			factor: 0.5,
+			unit:   "s",
+			want: func(metrics pmetric.MetricSlice) {
+				gaugeMetric := getTestGaugeMetric()
+				gaugeMetric.SetUnit("s")
+				gaugeMetric.Gauge().DataPoints().At(0).SetDoubleValue(6)
+				gaugeMetric.CopyTo(metrics.AppendEmpty())
+			},
+		},
+		{
+			name:   "histogram",
+			input:  getTestHistogramMetric(),
+			factor: 0.001,
+			unit:   "s",
+			want: func(metrics pmetric.MetricSlice) {
+				histogramMetric := getTestHistogramMetric()
+				histogramMetric.SetUnit("s")
+				dp := histogramMetric.Histogram().DataPoints().At(0)
+				dp.SetSum(12.34 * 0.001)
+				dp.ExplicitBounds().FromRaw([]float64{1 * 0.001, 10 * 0.001})
+				histogramMetric.CopyTo(metrics.AppendEmpty())
+			},
+		},
+		{
+			name:   "summary without unit",
+			input:  getTestSummaryMetric(),
+			factor: 2,
+			want: func(metrics pmetric.MetricSlice) {
+				summaryMetric := getTestSummaryMetric()
+				dp := summaryMetric.Summary().DataPoints().At(0)
+				dp.SetSum(12.34 * 2)
+				for i := 0; i < dp.QuantileValues().Len(); i++ {
+					dp.QuantileValues().At(i).SetValue(float64(i+1) * 2)
+				}
+				summaryMetric.CopyTo(metrics.AppendEmpty())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualMetrics := pmetric.NewMetricSlice()
+			tt.input.CopyTo(actualMetrics.AppendEmpty())
+
+			evaluate, err := scaleMetric(tt.factor, tt.unit)
+			assert.NoError(t, err)
+
+			evaluateOnEachDataPoint(t, evaluate, actualMetrics.At(0), actualMetrics)
+
+			expected := pmetric.NewMetricSlice()
+			tt.want(expected)
+			assert.Equal(t, expected, actualMetrics)
+		})
+	}
+}
@@ -25,6 +25,11 @@ var registry = map[string]interface{}{
 	"convert_gauge_to_sum":             convertGaugeToSum,
 	"convert_summary_sum_val_to_sum":   convertSummarySumValToSum,
 	"convert_summary_count_val_to_sum": convertSummaryCountValToSum,
+	"extract_count_metric":             extractCountMetric,
+	"extract_sum_metric":               extractSumMetric,
+	"scale_metric":                     scaleMetric,
+	"convert_unit":                     convertUnit,
+	"copy_metric":                      copyMetric,
 }
 
 func init() {
@@ -30,6 +30,11 @@ func Test_DefaultFunctions(t *testing.T) {
 	expected["convert_gauge_to_sum"] = convertGaugeToSum
 	expected["convert_summary_sum_val_to_sum"] = convertSummarySumValToSum
 	expected["convert_summary_count_val_to_sum"] = convertSummaryCountValToSum
+	expected["extract_count_metric"] = extractCountMetric
+	expected["extract_sum_metric"] = extractSumMetric
+	expected["scale_metric"] = scaleMetric
+	expected["convert_unit"] = convertUnit
+	expected["copy_metric"] = copyMetric
 
 	actual := Functions()
 
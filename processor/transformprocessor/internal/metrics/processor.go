@@ -46,7 +46,8 @@ func (p *Processor) ProcessMetrics(_ context.Context, td pmetric.Metrics) (pmetr
 		for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
 			smetrics := rmetrics.ScopeMetrics().At(j)
 			metrics := smetrics.Metrics()
-			for k := 0; k < metrics.Len(); k++ {
+			// The metrics appended by the statements, such as copy_metric, aren't processed.
+			for k, n := 0, metrics.Len(); k < n; k++ {
 				metric := metrics.At(k)
 				switch metric.Type() {
 				case pmetric.MetricTypeSum:
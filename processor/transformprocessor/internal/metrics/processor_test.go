@@ -277,7 +277,8 @@ func TestProcess(t *testing.T) {
 				sumMetric.SetName(summaryMetric.Name() + "_sum")
 				sumMetric.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
 				sumMetric.Sum().SetIsMonotonic(true)
-				sumMetric.SetUnit("new unit")
+				// the metrics appended by the statements aren't processed
+				sumMetric.SetUnit(summaryMetric.Unit())
 
 				summaryDp.Attributes().CopyTo(sumDp.Attributes())
 				sumDp.SetDoubleValue(summaryDp.Sum())
@@ -290,6 +291,38 @@ func TestProcess(t *testing.T) {
 				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(3).SetUnit("new unit")
 			},
 		},
+		{
+			statements: []string{`extract_count_metric(true) where metric.name == "operationB"`},
+			want: func(td pmetric.Metrics) {
+				histogramMetric := pmetric.NewMetric()
+				fillMetricTwo(histogramMetric)
+
+				countMetric := td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty()
+				countMetric.SetDescription(histogramMetric.Description())
+				countMetric.SetName(histogramMetric.Name() + "_count")
+				countMetric.SetUnit(histogramMetric.Unit())
+				countMetric.SetEmptySum().SetIsMonotonic(true)
+
+				for i := 0; i < histogramMetric.Histogram().DataPoints().Len(); i++ {
+					histogramDp := histogramMetric.Histogram().DataPoints().At(i)
+					countDp := countMetric.Sum().DataPoints().AppendEmpty()
+					histogramDp.Attributes().CopyTo(countDp.Attributes())
+					countDp.SetIntValue(int64(histogramDp.Count()))
+					countDp.SetStartTimestamp(StartTimestamp)
+				}
+			},
+		},
+		{
+			statements: []string{
+				`copy_metric("operationE") where metric.name == "operationA"`,
+				`copy_metric("operationF") where metric.name == "operationE"`,
+			},
+			want: func(td pmetric.Metrics) {
+				copied := td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty()
+				fillMetricOne(copied)
+				copied.SetName("operationE")
+			},
+		},
 		{
 			statements: []string{`set(attributes["test"], "pass") where IsMatch(metric.name, "operation[AC]") == true`},
 			want: func(td pmetric.Metrics) {
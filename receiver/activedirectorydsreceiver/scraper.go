@@ -45,7 +45,7 @@ func newActiveDirectoryDSScraper(ms metadata.MetricsSettings, params component.R
 func (a *activeDirectoryDSScraper) start(ctx context.Context, host component.Host) error {
 	watchers, err := getWatchers(defaultWatcherCreater{})
 	if err != nil {
-		return fmt.Errorf("failed to create performance counter watchers: %w", err)
+		return fmt.Errorf("failed to create performance counter watchers of object %q: %w", object, err)
 	}
 
 	a.w = watchers
@@ -255,7 +255,9 @@ func (a *activeDirectoryDSScraper) scrape(ctx context.Context) (pmetric.Metrics,
 	}
 
 	if multiErr != nil {
-		return pmetric.Metrics(a.mb.Emit()), scrapererror.NewPartialScrapeError(multiErr, len(multierr.Errors(multiErr)))
+		failed := len(multierr.Errors(multiErr))
+		return pmetric.Metrics(a.mb.Emit()), scrapererror.NewPartialScrapeError(
+			fmt.Errorf("failed to scrape %d performance counters of object %q: %w", failed, object, multiErr), failed)
 	}
 
 	return pmetric.Metrics(a.mb.Emit()), nil
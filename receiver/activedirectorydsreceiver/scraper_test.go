@@ -87,6 +87,11 @@ func TestScrape(t *testing.T) {
 		require.True(t, scrapererror.IsPartialScrapeError(err))
 		require.Contains(t, err.Error(), fullSyncObjectsRemainingErr.Error())
 		require.Contains(t, err.Error(), draInboundValuesDNErr.Error())
+		require.Contains(t, err.Error(), `failed to scrape 2 performance counters of object "DirectoryServices"`)
+
+		var partialErr scrapererror.PartialScrapeError
+		require.ErrorAs(t, err, &partialErr)
+		require.Equal(t, 2, partialErr.Failed)
 
 		expectedMetrics, err := golden.ReadMetrics(partialScrapePath)
 		require.NoError(t, err)
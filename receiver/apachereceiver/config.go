@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/apachereceiver/internal/metadata"
 )
 
@@ -29,6 +30,9 @@ type Config struct {
 	confighttp.HTTPClientSettings           `mapstructure:",squash"`
 	serverName                              string
 	Metrics                                 metadata.MetricsSettings `mapstructure:"metrics"`
+
+	// AccessLog, when set, derives the request rate, duration and status metrics from the access log of Apache HTTP Server.
+	AccessLog *accesslog.Config `mapstructure:"access_log"`
 }
 
 var (
@@ -54,6 +58,12 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("query must be 'auto': '%s'", cfg.Endpoint)
 	}
 
+	if cfg.AccessLog != nil {
+		if err = cfg.AccessLog.Validate(); err != nil {
+			return err
+		}
+	}
+
 	cfg.serverName = u.Hostname()
 	return nil
 }
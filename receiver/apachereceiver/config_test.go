@@ -22,12 +22,15 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
 )
 
 func TestValidate(t *testing.T) {
 	testCases := []struct {
 		desc        string
 		endpoint    string
+		accessLog   *accesslog.Config
 		errExpected bool
 		errText     string
 	}{
@@ -75,11 +78,25 @@ func TestValidate(t *testing.T) {
 			errExpected: true,
 			errText:     "query must be 'auto': 'http://localhost:8080/server-status?nonsense'",
 		},
+		{
+			desc:        "access_log",
+			endpoint:    "http://localhost:8080/server-status?auto",
+			accessLog:   &accesslog.Config{Path: "/var/log/apache2/access.log", DurationUnit: "us"},
+			errExpected: false,
+		},
+		{
+			desc:        "invalid_access_log",
+			endpoint:    "http://localhost:8080/server-status?auto",
+			accessLog:   &accesslog.Config{Path: "/var/log/apache2/access.log", DurationUnit: "ns"},
+			errExpected: true,
+			errText:     `access_log: duration_unit must be either "s", "ms" or "us": "ns"`,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
 			cfg := NewFactory().CreateDefaultConfig().(*Config)
 			cfg.Endpoint = tc.endpoint
+			cfg.AccessLog = tc.accessLog
 			err := cfg.Validate()
 			if tc.errExpected {
 				require.EqualError(t, err, tc.errText)
@@ -63,7 +63,7 @@ func createMetricsReceiver(
 	cfg := rConf.(*Config)
 
 	ns := newApacheScraper(params, cfg)
-	scraper, err := scraperhelper.NewScraper(typeStr, ns.scrape, scraperhelper.WithStart(ns.start))
+	scraper, err := scraperhelper.NewScraper(typeStr, ns.scrape, scraperhelper.WithStart(ns.start), scraperhelper.WithShutdown(ns.shutdown))
 	if err != nil {
 		return nil, err
 	}
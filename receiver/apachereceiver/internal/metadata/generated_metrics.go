@@ -19,6 +19,8 @@ type MetricSettings struct {
 
 // MetricsSettings provides settings for apachereceiver metrics.
 type MetricsSettings struct {
+	ApacheAccessDuration     MetricSettings `mapstructure:"apache.access.duration"`
+	ApacheAccessRequests     MetricSettings `mapstructure:"apache.access.requests"`
 	ApacheCPULoad            MetricSettings `mapstructure:"apache.cpu.load"`
 	ApacheCPUTime            MetricSettings `mapstructure:"apache.cpu.time"`
 	ApacheCurrentConnections MetricSettings `mapstructure:"apache.current_connections"`
@@ -35,6 +37,12 @@ type MetricsSettings struct {
 
 func DefaultMetricsSettings() MetricsSettings {
 	return MetricsSettings{
+		ApacheAccessDuration: MetricSettings{
+			Enabled: true,
+		},
+		ApacheAccessRequests: MetricSettings{
+			Enabled: true,
+		},
 		ApacheCPULoad: MetricSettings{
 			Enabled: true,
 		},
@@ -218,6 +226,119 @@ var MapAttributeWorkersState = map[string]AttributeWorkersState{
 	"idle": AttributeWorkersStateIdle,
 }
 
+type metricApacheAccessDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills apache.access.duration metric with initial data.
+func (m *metricApacheAccessDuration) init() {
+	m.data.SetName("apache.access.duration")
+	m.data.SetDescription("The duration of the requests, derived from the access log. Only recorded when access_log is set and the access log has the duration of the requests.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyHistogram()
+	m.data.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Histogram().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricApacheAccessDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, count uint64, sum float64, bounds []float64, bucketCounts []uint64, serverNameAttributeValue string, routeAttributeValue string, methodAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Histogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(bucketCounts)
+	dp.Attributes().PutStr("server_name", serverNameAttributeValue)
+	dp.Attributes().PutStr("http.route", routeAttributeValue)
+	dp.Attributes().PutStr("http.method", methodAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricApacheAccessDuration) updateCapacity() {
+	if m.data.Histogram().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Histogram().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricApacheAccessDuration) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Histogram().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricApacheAccessDuration(settings MetricSettings) metricApacheAccessDuration {
+	m := metricApacheAccessDuration{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricApacheAccessRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills apache.access.requests metric with initial data.
+func (m *metricApacheAccessRequests) init() {
+	m.data.SetName("apache.access.requests")
+	m.data.SetDescription("The number of requests, derived from the access log. Only recorded when access_log is set.")
+	m.data.SetUnit("{requests}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricApacheAccessRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, serverNameAttributeValue string, routeAttributeValue string, methodAttributeValue string, statusClassAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("server_name", serverNameAttributeValue)
+	dp.Attributes().PutStr("http.route", routeAttributeValue)
+	dp.Attributes().PutStr("http.method", methodAttributeValue)
+	dp.Attributes().PutStr("http.status_class", statusClassAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricApacheAccessRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricApacheAccessRequests) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricApacheAccessRequests(settings MetricSettings) metricApacheAccessRequests {
+	m := metricApacheAccessRequests{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricApacheCPULoad struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	settings MetricSettings // metric settings provided by user.
@@ -858,6 +979,8 @@ type MetricsBuilder struct {
 	resourceCapacity               int                 // maximum observed number of resource attributes.
 	metricsBuffer                  pmetric.Metrics     // accumulates metrics data before emitting.
 	buildInfo                      component.BuildInfo // contains version information
+	metricApacheAccessDuration     metricApacheAccessDuration
+	metricApacheAccessRequests     metricApacheAccessRequests
 	metricApacheCPULoad            metricApacheCPULoad
 	metricApacheCPUTime            metricApacheCPUTime
 	metricApacheCurrentConnections metricApacheCurrentConnections
@@ -887,6 +1010,8 @@ func NewMetricsBuilder(settings MetricsSettings, buildInfo component.BuildInfo,
 		startTime:                      pcommon.NewTimestampFromTime(time.Now()),
 		metricsBuffer:                  pmetric.NewMetrics(),
 		buildInfo:                      buildInfo,
+		metricApacheAccessDuration:     newMetricApacheAccessDuration(settings.ApacheAccessDuration),
+		metricApacheAccessRequests:     newMetricApacheAccessRequests(settings.ApacheAccessRequests),
 		metricApacheCPULoad:            newMetricApacheCPULoad(settings.ApacheCPULoad),
 		metricApacheCPUTime:            newMetricApacheCPUTime(settings.ApacheCPUTime),
 		metricApacheCurrentConnections: newMetricApacheCurrentConnections(settings.ApacheCurrentConnections),
@@ -931,6 +1056,12 @@ func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
 				dps = metrics.At(i).Gauge().DataPoints()
 			case pmetric.MetricTypeSum:
 				dps = metrics.At(i).Sum().DataPoints()
+			case pmetric.MetricTypeHistogram:
+				hdps := metrics.At(i).Histogram().DataPoints()
+				for j := 0; j < hdps.Len(); j++ {
+					hdps.At(j).SetStartTimestamp(start)
+				}
+				continue
 			}
 			for j := 0; j < dps.Len(); j++ {
 				dps.At(j).SetStartTimestamp(start)
@@ -951,6 +1082,8 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	ils.Scope().SetName("otelcol/apachereceiver")
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricApacheAccessDuration.emit(ils.Metrics())
+	mb.metricApacheAccessRequests.emit(ils.Metrics())
 	mb.metricApacheCPULoad.emit(ils.Metrics())
 	mb.metricApacheCPUTime.emit(ils.Metrics())
 	mb.metricApacheCurrentConnections.emit(ils.Metrics())
@@ -982,6 +1115,16 @@ func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
 	return metrics
 }
 
+// RecordApacheAccessDurationDataPoint adds a data point to apache.access.duration metric.
+func (mb *MetricsBuilder) RecordApacheAccessDurationDataPoint(ts pcommon.Timestamp, count uint64, sum float64, bounds []float64, bucketCounts []uint64, serverNameAttributeValue string, routeAttributeValue string, methodAttributeValue string) {
+	mb.metricApacheAccessDuration.recordDataPoint(mb.startTime, ts, count, sum, bounds, bucketCounts, serverNameAttributeValue, routeAttributeValue, methodAttributeValue)
+}
+
+// RecordApacheAccessRequestsDataPoint adds a data point to apache.access.requests metric.
+func (mb *MetricsBuilder) RecordApacheAccessRequestsDataPoint(ts pcommon.Timestamp, val int64, serverNameAttributeValue string, routeAttributeValue string, methodAttributeValue string, statusClassAttributeValue string) {
+	mb.metricApacheAccessRequests.recordDataPoint(mb.startTime, ts, val, serverNameAttributeValue, routeAttributeValue, methodAttributeValue, statusClassAttributeValue)
+}
+
 // RecordApacheCPULoadDataPoint adds a data point to apache.cpu.load metric.
 func (mb *MetricsBuilder) RecordApacheCPULoadDataPoint(ts pcommon.Timestamp, inputVal string, serverNameAttributeValue string) error {
 	val, err := strconv.ParseFloat(inputVal, 64)
@@ -29,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/apachereceiver/internal/metadata"
 )
 
@@ -37,6 +38,7 @@ type apacheScraper struct {
 	cfg        *Config
 	httpClient *http.Client
 	mb         *metadata.MetricsBuilder
+	accessLog  *accesslog.Deriver
 }
 
 func newApacheScraper(
@@ -56,22 +58,51 @@ func (r *apacheScraper) start(_ context.Context, host component.Host) error {
 		return err
 	}
 	r.httpClient = httpClient
+
+	if r.cfg.AccessLog != nil {
+		r.accessLog, err = accesslog.NewDeriver(r.cfg.AccessLog, r.settings.Logger)
+		if err != nil {
+			return err
+		}
+		return r.accessLog.Start()
+	}
+	return nil
+}
+
+func (r *apacheScraper) shutdown(context.Context) error {
+	if r.accessLog != nil {
+		return r.accessLog.Shutdown()
+	}
 	return nil
 }
 
+// statusMetricsCount is the number of metrics recorded from the server status.
+const statusMetricsCount = 12
+
 func (r *apacheScraper) scrape(context.Context) (pmetric.Metrics, error) {
-	if r.httpClient == nil {
-		return pmetric.Metrics{}, errors.New("failed to connect to Apache HTTPd")
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	// The access log metrics are recorded even when the server status can't be fetched.
+	if r.accessLog != nil {
+		err := r.accessLog.Record(func(route, method, statusClass string, count int64) {
+			r.mb.RecordApacheAccessRequestsDataPoint(now, count, r.cfg.serverName, route, method, statusClass)
+		}, func(route, method string, count uint64, sum float64, bounds []float64, bucketCounts []uint64) {
+			r.mb.RecordApacheAccessDurationDataPoint(now, count, sum, bounds, bucketCounts, r.cfg.serverName, route, method)
+		})
+		if err != nil {
+			r.settings.Logger.Error("failed to read the Apache Httpd access log", zap.Error(err))
+		}
 	}
 
-	stats, err := r.GetStats()
+	stats, err := r.getStats()
 	if err != nil {
-		r.settings.Logger.Error("failed to fetch Apache Httpd stats", zap.Error(err))
-		return pmetric.Metrics{}, err
+		if r.accessLog == nil {
+			return pmetric.Metrics{}, err
+		}
+		return r.mb.Emit(), scrapererror.NewPartialScrapeError(err, statusMetricsCount)
 	}
 
 	errs := &scrapererror.ScrapeErrors{}
-	now := pcommon.NewTimestampFromTime(time.Now())
 	for metricKey, metricValue := range parseStats(stats) {
 		switch metricKey {
 		case "ServerUptimeSeconds":
@@ -134,6 +165,19 @@ func (r *apacheScraper) scrape(context.Context) (pmetric.Metrics, error) {
 	return r.mb.Emit(), errs.Combine()
 }
 
+func (r *apacheScraper) getStats() (string, error) {
+	if r.httpClient == nil {
+		return "", errors.New("failed to connect to Apache HTTPd")
+	}
+
+	stats, err := r.GetStats()
+	if err != nil {
+		r.settings.Logger.Error("failed to fetch Apache Httpd stats", zap.Error(err))
+		return "", err
+	}
+	return stats, nil
+}
+
 func addPartialIfError(errs *scrapererror.ScrapeErrors, err error) {
 	if err != nil {
 		errs.AddPartial(1, err)
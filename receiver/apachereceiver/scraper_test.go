@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -27,7 +28,10 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest/golden"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/apachereceiver/internal/metadata"
@@ -54,6 +58,69 @@ func TestScraper(t *testing.T) {
 	require.NoError(t, scrapertest.CompareMetrics(expectedMetrics, actualMetrics))
 }
 
+func TestScraperAccessLog(t *testing.T) {
+	apacheMock := newMockServer(t)
+	path := filepath.Join(t.TempDir(), "access.log")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = fmt.Sprintf("%s%s", apacheMock.URL, "/server-status?auto")
+	cfg.AccessLog = &accesslog.Config{Path: path, DurationUnit: "us"}
+	require.NoError(t, cfg.Validate())
+
+	scraper := newApacheScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`127.0.0.1 - - [10/Oct/2022:13:55:36 +0000] "POST /cart HTTP/1.1" 500 0 "-" "curl/7.79.1" 250000` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	metrics := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	names := map[string]pmetric.Metric{}
+	for i := 0; i < metrics.Len(); i++ {
+		names[metrics.At(i).Name()] = metrics.At(i)
+	}
+	require.Contains(t, names, "apache.requests")
+	require.Contains(t, names, "apache.access.requests")
+	require.Contains(t, names, "apache.access.duration")
+
+	require.Equal(t, "5xx", names["apache.access.requests"].Sum().DataPoints().At(0).Attributes().AsRaw()["http.status_class"])
+	require.InDelta(t, 0.25, names["apache.access.duration"].Histogram().DataPoints().At(0).Sum(), 1e-9)
+}
+
+func TestScraperAccessLogStatusError(t *testing.T) {
+	apacheMock := newMockServer(t)
+	apacheMock.Close()
+	path := filepath.Join(t.TempDir(), "access.log")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = fmt.Sprintf("%s%s", apacheMock.URL, "/server-status?auto")
+	cfg.AccessLog = &accesslog.Config{Path: path}
+	require.NoError(t, cfg.Validate())
+
+	scraper := newApacheScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`127.0.0.1 - - [10/Oct/2022:13:55:36 +0000] "POST /cart HTTP/1.1" 500 0` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.Equal(t, 1, actualMetrics.MetricCount())
+	require.Equal(t, "apache.access.requests", actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
 func TestScraperFailedStart(t *testing.T) {
 	sc := newApacheScraper(componenttest.NewNopReceiverCreateSettings(), &Config{
 		HTTPClientSettings: confighttp.HTTPClientSettings{
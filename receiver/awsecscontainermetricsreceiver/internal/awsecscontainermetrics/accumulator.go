@@ -26,7 +26,8 @@ import (
 
 // metricDataAccumulator defines the accumulator
 type metricDataAccumulator struct {
-	mds []pmetric.Metrics
+	mds            []pmetric.Metrics
+	rateCalculator *NetworkRateCalculator
 }
 
 // getMetricsData generates OT Metrics data from task metadata and docker stats
@@ -49,6 +50,9 @@ func (acc *metricDataAccumulator) getMetricsData(containerStatsMap map[string]*C
 		if ok && !isEmptyStats(stats) {
 
 			containerMetrics := convertContainerMetrics(stats, logger, containerMetadata)
+			if acc.rateCalculator != nil {
+				acc.rateCalculator.update(stats, &containerMetrics)
+			}
 			acc.accumulate(convertToOTLPMetrics(containerPrefix, containerMetrics, containerResource, timestamp))
 			aggregateTaskMetrics(&taskMetrics, containerMetrics)
 
@@ -64,8 +68,14 @@ func (acc *metricDataAccumulator) getMetricsData(containerStatsMap map[string]*C
 
 		}
 	}
+	if acc.rateCalculator != nil {
+		acc.rateCalculator.prune(containerStatsMap)
+	}
 	overrideWithTaskLevelLimit(&taskMetrics, metadata)
 	acc.accumulate(convertToOTLPMetrics(taskPrefix, taskMetrics, taskResource, timestamp))
+	if hasTaskStorageMetrics(metadata) {
+		acc.accumulate(convertTaskStorageToOTLPMetrics(taskPrefix, metadata, taskResource, timestamp))
+	}
 }
 
 func (acc *metricDataAccumulator) accumulate(md pmetric.Metrics) {
@@ -35,6 +35,9 @@ const (
 	cpusInVCpu = 1024
 	bytesInMiB = 1024 * 1024
 
+	// Windows reports CPU usage in 100ns intervals.
+	windowsCPUUsageUnit = 100
+
 	taskPrefix      = "ecs.task."
 	containerPrefix = "container."
 
@@ -69,6 +72,14 @@ const (
 	attributeStorageRead  = "storage.read_bytes"
 	attributeStorageWrite = "storage.write_bytes"
 
+	attributeEphemeralStorageUtilized = "ephemeral_storage.utilized"
+	attributeEphemeralStorageReserved = "ephemeral_storage.reserved"
+	attributeEBSFilesystemUtilized    = "ebs.filesystem.utilized"
+	attributeEBSFilesystemSize        = "ebs.filesystem.size"
+
+	attributeECSVolumeID   = "aws.ecs.volume.id"
+	attributeECSVolumeName = "aws.ecs.volume.name"
+
 	attributeDuration = "duration"
 
 	unitBytes       = "Bytes"
@@ -31,6 +31,16 @@ type ContainerStats struct {
 	NetworkRate *NetworkRateStats       `json:"network_rate_stats,omitempty"`
 	CPU         *CPUStats               `json:"cpu_stats,omitempty"`
 	PreviousCPU *CPUStats               `json:"precpu_stats,omitempty"`
+
+	// Windows only
+	NumProcs uint32        `json:"num_procs,omitempty"`
+	Storage  *StorageStats `json:"storage_stats,omitempty"`
+}
+
+// isWindows returns true when the stats were reported by a Windows container,
+// which uses a different schema for memory, CPU and disk stats.
+func (s *ContainerStats) isWindows() bool {
+	return s.NumProcs > 0 || s.Storage != nil
 }
 
 // MemoryStats defines the memory stats
@@ -41,6 +51,11 @@ type MemoryStats struct {
 	MemoryUtilized *uint64
 	MemoryReserved *uint64
 	Stats          map[string]uint64 `json:"stats,omitempty"`
+
+	// Windows only
+	Commit            *uint64 `json:"commitbytes,omitempty"`
+	CommitPeak        *uint64 `json:"commitpeakbytes,omitempty"`
+	PrivateWorkingSet *uint64 `json:"privateworkingset,omitempty"`
 }
 
 // DiskStats defines the storage stats
@@ -56,6 +71,14 @@ type IoServiceBytesRecursive struct {
 	Value *uint64 `json:"value,omitempty"`
 }
 
+// StorageStats defines the storage stats of Windows containers
+type StorageStats struct {
+	ReadCountNormalized  *uint64 `json:"read_count_normalized,omitempty"`
+	ReadSizeBytes        *uint64 `json:"read_size_bytes,omitempty"`
+	WriteCountNormalized *uint64 `json:"write_count_normalized,omitempty"`
+	WriteSizeBytes       *uint64 `json:"write_size_bytes,omitempty"`
+}
+
 // NetworkStats defines the network stats
 type NetworkStats struct {
 	RxBytes   *uint64 `json:"rx_bytes,omitempty"`
@@ -21,9 +21,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/ecsutil"
 )
 
-// MetricsData generates OTLP metrics from endpoint raw data
-func MetricsData(containerStatsMap map[string]*ContainerStats, metadata ecsutil.TaskMetadata, logger *zap.Logger) []pmetric.Metrics {
-	acc := &metricDataAccumulator{}
+// MetricsData generates OTLP metrics from endpoint raw data. The rate calculator
+// keeps track of the network counters across calls, it can be nil.
+func MetricsData(containerStatsMap map[string]*ContainerStats, metadata ecsutil.TaskMetadata, rateCalculator *NetworkRateCalculator, logger *zap.Logger) []pmetric.Metrics {
+	acc := &metricDataAccumulator{rateCalculator: rateCalculator}
 	acc.getMetricsData(containerStatsMap, metadata, logger)
 
 	return acc.mds
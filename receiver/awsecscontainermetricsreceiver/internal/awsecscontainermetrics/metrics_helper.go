@@ -22,35 +22,59 @@ import (
 func getContainerMetrics(stats *ContainerStats, logger *zap.Logger) ECSMetrics {
 	m := ECSMetrics{}
 
-	if stats.Memory != nil {
-		m.MemoryUsage = *stats.Memory.Usage
-		m.MemoryMaxUsage = *stats.Memory.MaxUsage
-		m.MemoryLimit = *stats.Memory.Limit
+	switch {
+	case stats.Memory == nil:
+		logger.Debug("Nil memory stats found for docker container:" + stats.Name)
+	case stats.isWindows():
+		// Windows containers report the private working set instead of the usage,
+		// and have no memory limit.
+		m.MemoryUsage = valueOf(stats.Memory.PrivateWorkingSet)
+		m.MemoryMaxUsage = valueOf(stats.Memory.CommitPeak)
+		m.MemoryUtilized = m.MemoryUsage / bytesInMiB
+	default:
+		m.MemoryUsage = valueOf(stats.Memory.Usage)
+		m.MemoryMaxUsage = valueOf(stats.Memory.MaxUsage)
+		m.MemoryLimit = valueOf(stats.Memory.Limit)
 
 		if stats.Memory.Stats != nil {
-			m.MemoryUtilized = (*stats.Memory.Usage - stats.Memory.Stats["cache"]) / bytesInMiB
+			m.MemoryUtilized = (m.MemoryUsage - stats.Memory.Stats["cache"]) / bytesInMiB
 		}
-	} else {
-		logger.Debug("Nil memory stats found for docker container:" + stats.Name)
 	}
 
 	if stats.CPU != nil && stats.CPU.CPUUsage != nil {
 		numOfCores := (uint64)(len(stats.CPU.CPUUsage.PerCPUUsage))
+		onlineCpus := valueOf(stats.CPU.OnlineCpus)
+		// Windows containers report the CPU usage in 100ns intervals and the
+		// number of processors separately.
+		cpuUsageUnit := uint64(1)
+		if stats.isWindows() {
+			cpuUsageUnit = windowsCPUUsageUnit
+			if numOfCores == 0 {
+				numOfCores = uint64(stats.NumProcs)
+			}
+			if onlineCpus == 0 {
+				onlineCpus = uint64(stats.NumProcs)
+			}
+		}
+		totalUsage := valueOf(stats.CPU.CPUUsage.TotalUsage) * cpuUsageUnit
 		timeDiffSinceLastRead := (float64)(stats.Read.Sub(stats.PreviousRead).Nanoseconds())
 
 		cpuUsageInVCpu := float64(0)
-		if timeDiffSinceLastRead > 0 {
-			cpuDelta := (float64)(*stats.CPU.CPUUsage.TotalUsage - *stats.PreviousCPU.CPUUsage.TotalUsage)
-			cpuUsageInVCpu = cpuDelta / timeDiffSinceLastRead
+		if timeDiffSinceLastRead > 0 && stats.PreviousCPU != nil && stats.PreviousCPU.CPUUsage != nil {
+			previousUsage := valueOf(stats.PreviousCPU.CPUUsage.TotalUsage) * cpuUsageUnit
+			if totalUsage >= previousUsage {
+				cpuDelta := (float64)(totalUsage - previousUsage)
+				cpuUsageInVCpu = cpuDelta / timeDiffSinceLastRead
+			}
 		}
 		cpuUtilized := cpuUsageInVCpu * 100
 
-		m.CPUTotalUsage = *stats.CPU.CPUUsage.TotalUsage
-		m.CPUUsageInKernelmode = *stats.CPU.CPUUsage.UsageInKernelmode
-		m.CPUUsageInUserMode = *stats.CPU.CPUUsage.UsageInUserMode
+		m.CPUTotalUsage = totalUsage
+		m.CPUUsageInKernelmode = valueOf(stats.CPU.CPUUsage.UsageInKernelmode) * cpuUsageUnit
+		m.CPUUsageInUserMode = valueOf(stats.CPU.CPUUsage.UsageInUserMode) * cpuUsageUnit
 		m.NumOfCPUCores = numOfCores
-		m.CPUOnlineCpus = *stats.CPU.OnlineCpus
-		m.SystemCPUUsage = *stats.CPU.SystemCPUUsage
+		m.CPUOnlineCpus = onlineCpus
+		m.SystemCPUUsage = valueOf(stats.CPU.SystemCPUUsage)
 		m.CPUUsageInVCPU = cpuUsageInVCpu
 		m.CPUUtilized = cpuUtilized
 	} else {
@@ -58,8 +82,8 @@ func getContainerMetrics(stats *ContainerStats, logger *zap.Logger) ECSMetrics {
 	}
 
 	if stats.NetworkRate != nil {
-		m.NetworkRateRxBytesPerSecond = *stats.NetworkRate.RxBytesPerSecond
-		m.NetworkRateTxBytesPerSecond = *stats.NetworkRate.TxBytesPerSecond
+		m.NetworkRateRxBytesPerSecond = valueOfFloat(stats.NetworkRate.RxBytesPerSecond)
+		m.NetworkRateTxBytesPerSecond = valueOfFloat(stats.NetworkRate.TxBytesPerSecond)
 	} else {
 		logger.Debug("Nil NetworkRate stats found for docker container:" + stats.Name)
 	}
@@ -80,30 +104,49 @@ func getContainerMetrics(stats *ContainerStats, logger *zap.Logger) ECSMetrics {
 		logger.Debug("Nil Network stats found for docker container:" + stats.Name)
 	}
 
-	if stats.Disk != nil {
+	switch {
+	case stats.Disk != nil:
 		storageReadBytes, storageWriteBytes := extractStorageUsage(stats.Disk)
 
 		m.StorageReadBytes = storageReadBytes
 		m.StorageWriteBytes = storageWriteBytes
+	case stats.Storage != nil:
+		m.StorageReadBytes = valueOf(stats.Storage.ReadSizeBytes)
+		m.StorageWriteBytes = valueOf(stats.Storage.WriteSizeBytes)
 	}
 
 	return m
 }
 
+// valueOf returns the value of an optional stat, 0 if it was not reported.
+func valueOf(v *uint64) uint64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func valueOfFloat(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
 // Followed ECS Agent calculations
 // https://github.com/aws/amazon-ecs-agent/blob/1ebf0604c13013596cfd4eb239574a85890b13e8/agent/stats/utils.go#L30
 func getNetworkStats(stats map[string]NetworkStats) [8]uint64 {
 	var netStatArray [8]uint64
 	for _, netStat := range stats {
-		netStatArray[0] += *netStat.RxBytes
-		netStatArray[1] += *netStat.RxPackets
-		netStatArray[2] += *netStat.RxErrors
-		netStatArray[3] += *netStat.RxDropped
-
-		netStatArray[4] += *netStat.TxBytes
-		netStatArray[5] += *netStat.TxPackets
-		netStatArray[6] += *netStat.TxErrors
-		netStatArray[7] += *netStat.TxDropped
+		netStatArray[0] += valueOf(netStat.RxBytes)
+		netStatArray[1] += valueOf(netStat.RxPackets)
+		netStatArray[2] += valueOf(netStat.RxErrors)
+		netStatArray[3] += valueOf(netStat.RxDropped)
+
+		netStatArray[4] += valueOf(netStat.TxBytes)
+		netStatArray[5] += valueOf(netStat.TxPackets)
+		netStatArray[6] += valueOf(netStat.TxErrors)
+		netStatArray[7] += valueOf(netStat.TxDropped)
 	}
 	return netStatArray
 }
@@ -119,9 +162,9 @@ func extractStorageUsage(stats *DiskStats) (uint64, uint64) {
 	for _, blockStat := range stats.IoServiceBytesRecursives {
 		switch op := blockStat.Op; op {
 		case "Read":
-			readBytes = *blockStat.Value
+			readBytes = valueOf(blockStat.Value)
 		case "Write":
-			writeBytes = *blockStat.Value
+			writeBytes = valueOf(blockStat.Value)
 		default:
 			// ignoring "Async", "Total", "Sum", etc
 			continue
@@ -310,3 +310,64 @@ func TestGetNetworkStats(t *testing.T) {
 	}
 	require.EqualValues(t, 800, sum)
 }
+
+func TestGetContainerMetricsWindows(t *testing.T) {
+	privateWorkingSet := uint64(200 * bytesInMiB)
+	commitPeak := uint64(300 * bytesInMiB)
+	totalUsage := uint64(2000)
+	previousUsage := uint64(1000)
+	readBytes := uint64(10)
+	writeBytes := uint64(20)
+	now := time.Now()
+
+	// Windows stats have no memory limit, per CPU usage, online CPUs nor blkio stats.
+	winStats := ContainerStats{
+		Name:         "test",
+		ID:           "001",
+		Read:         now,
+		PreviousRead: now.Add(-time.Second),
+		NumProcs:     2,
+		Memory: &MemoryStats{
+			PrivateWorkingSet: &privateWorkingSet,
+			CommitPeak:        &commitPeak,
+		},
+		CPU: &CPUStats{
+			CPUUsage: &CPUUsage{TotalUsage: &totalUsage},
+		},
+		PreviousCPU: &CPUStats{
+			CPUUsage: &CPUUsage{TotalUsage: &previousUsage},
+		},
+		Network: net,
+		Storage: &StorageStats{ReadSizeBytes: &readBytes, WriteSizeBytes: &writeBytes},
+	}
+
+	containerMetrics := getContainerMetrics(&winStats, logger)
+
+	require.EqualValues(t, privateWorkingSet, containerMetrics.MemoryUsage)
+	require.EqualValues(t, commitPeak, containerMetrics.MemoryMaxUsage)
+	require.EqualValues(t, 0, containerMetrics.MemoryLimit)
+	require.EqualValues(t, 200, containerMetrics.MemoryUtilized)
+
+	require.EqualValues(t, totalUsage*windowsCPUUsageUnit, containerMetrics.CPUTotalUsage)
+	require.EqualValues(t, 2, containerMetrics.NumOfCPUCores)
+	require.EqualValues(t, 2, containerMetrics.CPUOnlineCpus)
+	require.InDelta(t, 0.0001, containerMetrics.CPUUsageInVCPU, 1e-9)
+
+	require.EqualValues(t, v, containerMetrics.NetworkRxBytes)
+	require.EqualValues(t, readBytes, containerMetrics.StorageReadBytes)
+	require.EqualValues(t, writeBytes, containerMetrics.StorageWriteBytes)
+}
+
+func TestGetContainerMetricsMissingPreviousCPU(t *testing.T) {
+	containerStats = ContainerStats{
+		Name:         "test",
+		ID:           "001",
+		Read:         time.Now(),
+		PreviousRead: time.Now().Add(-10 * time.Second),
+		CPU:          &CPUStats{CPUUsage: &CPUUsage{TotalUsage: &v}},
+	}
+
+	containerMetrics := getContainerMetrics(&containerStats, logger)
+	require.EqualValues(t, v, containerMetrics.CPUTotalUsage)
+	require.EqualValues(t, floatZero, containerMetrics.CPUUsageInVCPU)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsecscontainermetrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsecscontainermetricsreceiver/internal/awsecscontainermetrics"
+
+import "time"
+
+// NetworkRateCalculator calculates the network rates of containers for which
+// the ECS agent does not report them (e.g. Windows and some Fargate tasks),
+// from the network counters of two consecutive scrapes.
+type NetworkRateCalculator struct {
+	previous map[string]networkSample
+}
+
+type networkSample struct {
+	read    time.Time
+	rxBytes uint64
+	txBytes uint64
+}
+
+// NewNetworkRateCalculator returns a new NetworkRateCalculator
+func NewNetworkRateCalculator() *NetworkRateCalculator {
+	return &NetworkRateCalculator{previous: map[string]networkSample{}}
+}
+
+// update records the network counters of the container and sets its network
+// rates, unless they were already reported by the ECS agent.
+func (c *NetworkRateCalculator) update(stats *ContainerStats, m *ECSMetrics) {
+	current := networkSample{read: stats.Read, rxBytes: m.NetworkRxBytes, txBytes: m.NetworkTxBytes}
+	previous, ok := c.previous[stats.ID]
+	c.previous[stats.ID] = current
+
+	if stats.NetworkRate != nil || stats.Network == nil || !ok {
+		return
+	}
+
+	elapsed := current.read.Sub(previous.read).Seconds()
+	if elapsed <= 0 || current.rxBytes < previous.rxBytes || current.txBytes < previous.txBytes {
+		// Counters were reset, e.g. the container restarted.
+		return
+	}
+	m.NetworkRateRxBytesPerSecond = float64(current.rxBytes-previous.rxBytes) / elapsed
+	m.NetworkRateTxBytesPerSecond = float64(current.txBytes-previous.txBytes) / elapsed
+}
+
+// prune forgets the containers that are not part of the task anymore.
+func (c *NetworkRateCalculator) prune(containerStatsMap map[string]*ContainerStats) {
+	for id := range c.previous {
+		if stats, ok := containerStatsMap[id]; !ok || isEmptyStats(stats) {
+			delete(c.previous, id)
+		}
+	}
+}
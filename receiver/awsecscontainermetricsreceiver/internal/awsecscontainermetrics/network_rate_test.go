@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsecscontainermetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkRateCalculator(t *testing.T) {
+	c := NewNetworkRateCalculator()
+	now := time.Now()
+
+	rx, tx := uint64(1000), uint64(500)
+	stats := &ContainerStats{
+		ID:      "001",
+		Read:    now,
+		Network: map[string]NetworkStats{"eth0": {RxBytes: &rx, TxBytes: &tx}},
+	}
+
+	// No rate on the first collection.
+	m := ECSMetrics{NetworkRxBytes: rx, NetworkTxBytes: tx}
+	c.update(stats, &m)
+	assert.Zero(t, m.NetworkRateRxBytesPerSecond)
+	assert.Zero(t, m.NetworkRateTxBytesPerSecond)
+
+	stats.Read = now.Add(10 * time.Second)
+	m = ECSMetrics{NetworkRxBytes: 3000, NetworkTxBytes: 1500}
+	c.update(stats, &m)
+	assert.Equal(t, 200.0, m.NetworkRateRxBytesPerSecond)
+	assert.Equal(t, 100.0, m.NetworkRateTxBytesPerSecond)
+
+	// Counters reset when the container restarts.
+	stats.Read = now.Add(20 * time.Second)
+	m = ECSMetrics{NetworkRxBytes: 10, NetworkTxBytes: 10}
+	c.update(stats, &m)
+	assert.Zero(t, m.NetworkRateRxBytesPerSecond)
+	assert.Zero(t, m.NetworkRateTxBytesPerSecond)
+}
+
+func TestNetworkRateCalculatorKeepsAgentRates(t *testing.T) {
+	c := NewNetworkRateCalculator()
+	now := time.Now()
+	rate := 42.0
+	stats := &ContainerStats{
+		ID:          "001",
+		Read:        now,
+		Network:     net,
+		NetworkRate: &NetworkRateStats{RxBytesPerSecond: &rate, TxBytesPerSecond: &rate},
+	}
+
+	m := ECSMetrics{NetworkRxBytes: 0, NetworkRateRxBytesPerSecond: rate, NetworkRateTxBytesPerSecond: rate}
+	c.update(stats, &m)
+	stats.Read = now.Add(time.Second)
+	m = ECSMetrics{NetworkRxBytes: 1000, NetworkRateRxBytesPerSecond: rate, NetworkRateTxBytesPerSecond: rate}
+	c.update(stats, &m)
+	assert.Equal(t, rate, m.NetworkRateRxBytesPerSecond)
+	assert.Equal(t, rate, m.NetworkRateTxBytesPerSecond)
+}
+
+func TestNetworkRateCalculatorPrune(t *testing.T) {
+	c := NewNetworkRateCalculator()
+	c.update(&ContainerStats{ID: "001", Network: net}, &ECSMetrics{})
+	c.update(&ContainerStats{ID: "002", Network: net}, &ECSMetrics{})
+
+	c.prune(map[string]*ContainerStats{"001": {ID: "001"}})
+	assert.Contains(t, c.previous, "001")
+	assert.NotContains(t, c.previous, "002")
+}
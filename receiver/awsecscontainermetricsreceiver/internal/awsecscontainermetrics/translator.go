@@ -18,6 +18,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/ecsutil"
 )
 
 func convertToOTLPMetrics(prefix string, m ECSMetrics, r pcommon.Resource, timestamp pcommon.Timestamp) pmetric.Metrics {
@@ -62,6 +64,44 @@ func convertToOTLPMetrics(prefix string, m ECSMetrics, r pcommon.Resource, times
 	return md
 }
 
+func hasTaskStorageMetrics(metadata ecsutil.TaskMetadata) bool {
+	return metadata.EphemeralStorageMetrics != nil || len(metadata.EBSVolumeStats) > 0
+}
+
+func convertTaskStorageToOTLPMetrics(prefix string, metadata ecsutil.TaskMetadata, r pcommon.Resource, timestamp pcommon.Timestamp) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl(conventions.SchemaURL)
+	r.CopyTo(rm.Resource())
+
+	ilms := rm.ScopeMetrics()
+
+	if es := metadata.EphemeralStorageMetrics; es != nil {
+		appendIntGauge(prefix+attributeEphemeralStorageUtilized, unitMegaBytes, int64(valueOf(es.Utilized)), timestamp, ilms.AppendEmpty())
+		appendIntGauge(prefix+attributeEphemeralStorageReserved, unitMegaBytes, int64(valueOf(es.Reserved)), timestamp, ilms.AppendEmpty())
+	}
+
+	if len(metadata.EBSVolumeStats) > 0 {
+		ilm := ilms.AppendEmpty()
+		utilized := appendMetric(ilm, prefix+attributeEBSFilesystemUtilized, unitBytes).SetEmptyGauge()
+		size := appendMetric(ilm, prefix+attributeEBSFilesystemSize, unitBytes).SetEmptyGauge()
+		for _, volume := range metadata.EBSVolumeStats {
+			appendVolumeDataPoint(utilized.DataPoints(), volume, int64(valueOf(volume.Utilized)), timestamp)
+			appendVolumeDataPoint(size.DataPoints(), volume, int64(valueOf(volume.Capacity)), timestamp)
+		}
+	}
+
+	return md
+}
+
+func appendVolumeDataPoint(dataPoints pmetric.NumberDataPointSlice, volume ecsutil.EBSVolumeStats, value int64, ts pcommon.Timestamp) {
+	dataPoint := dataPoints.AppendEmpty()
+	dataPoint.SetIntValue(value)
+	dataPoint.SetTimestamp(ts)
+	dataPoint.Attributes().PutStr(attributeECSVolumeID, volume.VolumeID)
+	dataPoint.Attributes().PutStr(attributeECSVolumeName, volume.VolumeName)
+}
+
 func convertStoppedContainerDataToOTMetrics(prefix string, containerResource pcommon.Resource, timestamp pcommon.Timestamp, duration float64) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	rm := md.ResourceMetrics().AppendEmpty()
@@ -22,6 +22,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/ecsutil"
 )
 
 func TestConvertToOTMetrics(t *testing.T) {
@@ -74,3 +76,38 @@ func TestConvertStoppedContainerDataToOTMetrics(t *testing.T) {
 	md := convertStoppedContainerDataToOTMetrics("container.", resource, timestamp, duration)
 	require.EqualValues(t, 1, md.ResourceMetrics().At(0).ScopeMetrics().Len())
 }
+
+func TestConvertTaskStorageToOTLPMetrics(t *testing.T) {
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	resource := pcommon.NewResource()
+	utilized := uint64(100)
+	capacity := uint64(1000)
+	metadata := ecsutil.TaskMetadata{
+		EphemeralStorageMetrics: &ecsutil.EphemeralStorageMetrics{Utilized: &utilized, Reserved: &capacity},
+		EBSVolumeStats: []ecsutil.EBSVolumeStats{
+			{VolumeID: "vol-1", VolumeName: "data", Utilized: &utilized, Capacity: &capacity},
+			{VolumeID: "vol-2", VolumeName: "logs", Utilized: &utilized, Capacity: &capacity},
+		},
+	}
+	require.True(t, hasTaskStorageMetrics(metadata))
+	require.False(t, hasTaskStorageMetrics(ecsutil.TaskMetadata{}))
+
+	md := convertTaskStorageToOTLPMetrics("ecs.task.", metadata, resource, timestamp)
+	ilms := md.ResourceMetrics().At(0).ScopeMetrics()
+	require.EqualValues(t, 3, ilms.Len())
+	assert.Equal(t, "ecs.task.ephemeral_storage.utilized", ilms.At(0).Metrics().At(0).Name())
+	assert.EqualValues(t, 100, ilms.At(0).Metrics().At(0).Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, "ecs.task.ephemeral_storage.reserved", ilms.At(1).Metrics().At(0).Name())
+
+	ebs := ilms.At(2).Metrics()
+	require.EqualValues(t, 2, ebs.Len())
+	assert.Equal(t, "ecs.task.ebs.filesystem.utilized", ebs.At(0).Name())
+	assert.Equal(t, "ecs.task.ebs.filesystem.size", ebs.At(1).Name())
+	dps := ebs.At(1).Gauge().DataPoints()
+	require.EqualValues(t, 2, dps.Len())
+	assert.EqualValues(t, 1000, dps.At(1).IntValue())
+	assert.Equal(t, map[string]interface{}{
+		"aws.ecs.volume.id":   "vol-2",
+		"aws.ecs.volume.name": "logs",
+	}, dps.At(1).Attributes().AsRaw())
+}
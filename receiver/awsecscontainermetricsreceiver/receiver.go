@@ -36,6 +36,9 @@ type awsEcsContainerMetricsReceiver struct {
 	cancel       context.CancelFunc
 	restClient   ecsutil.RestClient
 	provider     *awsecscontainermetrics.StatsProvider
+	// rateCalculator keeps the network counters of the previous collection,
+	// for tasks whose network rates are not reported by the ECS agent.
+	rateCalculator *awsecscontainermetrics.NetworkRateCalculator
 }
 
 // New creates the aws ecs container metrics receiver with the given parameters.
@@ -49,10 +52,11 @@ func newAWSECSContainermetrics(
 	}
 
 	r := &awsEcsContainerMetricsReceiver{
-		logger:       logger,
-		nextConsumer: nextConsumer,
-		config:       config,
-		restClient:   rest,
+		logger:         logger,
+		nextConsumer:   nextConsumer,
+		config:         config,
+		restClient:     rest,
+		rateCalculator: awsecscontainermetrics.NewNetworkRateCalculator(),
 	}
 	return r, nil
 }
@@ -93,7 +97,7 @@ func (aecmr *awsEcsContainerMetricsReceiver) collectDataFromEndpoint(ctx context
 	}
 
 	// TODO: report self metrics using obsreport
-	mds := awsecscontainermetrics.MetricsData(stats, metadata, aecmr.logger)
+	mds := awsecscontainermetrics.MetricsData(stats, metadata, aecmr.rateCalculator, aecmr.logger)
 	for _, md := range mds {
 		err = aecmr.nextConsumer.ConsumeMetrics(ctx, md)
 		if err != nil {
@@ -19,6 +19,7 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/proxy"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/translator"
 )
 
 // Config defines the configurations for an AWS X-Ray receiver.
@@ -31,4 +32,8 @@ type Config struct {
 
 	// ProxyServer defines configurations related to the local TCP proxy server.
 	ProxyServer *proxy.Config `mapstructure:"proxy_server"`
+
+	// Translation defines how the annotations and metadata of segments are
+	// translated to span attributes.
+	Translation translator.Config `mapstructure:"translation"`
 }
@@ -27,6 +27,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/proxy"
 	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/translator"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -64,6 +65,7 @@ func TestLoadConfig(t *testing.T) {
 					RoleARN:     "",
 					AWSEndpoint: "",
 				},
+				Translation: translator.DefaultConfig(),
 			},
 		},
 		{
@@ -88,6 +90,23 @@ func TestLoadConfig(t *testing.T) {
 					AWSEndpoint: "https://another.aws.endpoint.com",
 					LocalMode:   true,
 				},
+				Translation: translator.DefaultConfig(),
+			}},
+		{
+			id: config.NewComponentIDWithName(awsxray.TypeStr, "translation"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(awsxray.TypeStr)),
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "0.0.0.0:2000",
+					Transport: "udp",
+				},
+				ProxyServer: proxy.DefaultConfig(),
+				Translation: translator.Config{
+					AnnotationPrefix:     "aws.xray.annotation.",
+					MetadataPrefix:       "xray.",
+					FlattenMetadata:      true,
+					PreserveNumericTypes: true,
+				},
 			}},
 	}
 
@@ -24,6 +24,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/proxy"
 	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/translator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/udppoller"
 )
 
@@ -48,6 +49,7 @@ func createDefaultConfig() config.Receiver {
 			Transport: udppoller.Transport,
 		},
 		ProxyServer: proxy.DefaultConfig(),
+		Translation: translator.DefaultConfig(),
 	}
 }
 
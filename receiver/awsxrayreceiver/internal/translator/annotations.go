@@ -14,25 +14,32 @@
 
 package translator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/translator"
 
-import "go.opentelemetry.io/collector/pdata/pcommon"
+import (
+	"encoding/json"
 
-func addAnnotations(annos map[string]interface{}, attrs pcommon.Map) {
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func addAnnotations(annos map[string]interface{}, attrs pcommon.Map, cfg Config) {
 	for k, v := range annos {
+		key := cfg.AnnotationPrefix + k
 		switch t := v.(type) {
 		case int:
-			attrs.PutInt(k, int64(t))
+			attrs.PutInt(key, int64(t))
 		case int32:
-			attrs.PutInt(k, int64(t))
+			attrs.PutInt(key, int64(t))
 		case int64:
-			attrs.PutInt(k, t)
+			attrs.PutInt(key, t)
 		case string:
-			attrs.PutStr(k, t)
+			attrs.PutStr(key, t)
 		case bool:
-			attrs.PutBool(k, t)
+			attrs.PutBool(key, t)
 		case float32:
-			attrs.PutDouble(k, float64(t))
+			attrs.PutDouble(key, float64(t))
 		case float64:
-			attrs.PutDouble(k, t)
+			attrs.PutDouble(key, t)
+		case json.Number:
+			fromJSON(t, attrs.PutEmpty(key), cfg)
 		default:
 		}
 	}
@@ -15,6 +15,7 @@
 package translator
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,7 +33,7 @@ func TestAddAnnotations(t *testing.T) {
 
 	attrMap := pcommon.NewMap()
 	attrMap.EnsureCapacity(initAttrCapacity)
-	addAnnotations(input, attrMap)
+	addAnnotations(input, attrMap, DefaultConfig())
 
 	expectedAttrMap := pcommon.NewMap()
 	expectedAttrMap.FromRaw(
@@ -47,3 +48,43 @@ func TestAddAnnotations(t *testing.T) {
 	)
 	assert.Equal(t, expectedAttrMap.Sort(), attrMap.Sort(), "attribute maps differ")
 }
+
+func TestAddAnnotationsWithConfig(t *testing.T) {
+	input := map[string]interface{}{
+		"int":    json.Number("1"),
+		"double": json.Number("1.5"),
+		"str":    "value",
+	}
+
+	testCases := []struct {
+		name     string
+		cfg      Config
+		expected map[string]interface{}
+	}{
+		{
+			name: "numbers as doubles",
+			cfg:  DefaultConfig(),
+			expected: map[string]interface{}{
+				"int":    1.0,
+				"double": 1.5,
+				"str":    "value",
+			},
+		},
+		{
+			name: "preserved numeric types with prefix",
+			cfg:  Config{AnnotationPrefix: "annotation.", PreserveNumericTypes: true},
+			expected: map[string]interface{}{
+				"annotation.int":    int64(1),
+				"annotation.double": 1.5,
+				"annotation.str":    "value",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attrMap := pcommon.NewMap()
+			addAnnotations(input, attrMap, tc.cfg)
+			assert.Equal(t, tc.expected, attrMap.AsRaw())
+		})
+	}
+}
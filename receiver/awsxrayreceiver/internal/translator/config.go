@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/translator"
+
+import (
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+// Config configures how the annotations and metadata of segments are
+// translated to span attributes.
+type Config struct {
+	// AnnotationPrefix is prepended to the keys of the annotations.
+	AnnotationPrefix string `mapstructure:"annotation_prefix"`
+
+	// MetadataPrefix is prepended to the keys of the metadata.
+	MetadataPrefix string `mapstructure:"metadata_prefix"`
+
+	// FlattenMetadata translates each value of the metadata to an attribute
+	// keyed by its path, rather than each namespace to an attribute holding
+	// its values as JSON.
+	FlattenMetadata bool `mapstructure:"flatten_metadata"`
+
+	// PreserveNumericTypes translates the integral numbers of the annotations
+	// and flattened metadata to int attributes rather than double attributes.
+	PreserveNumericTypes bool `mapstructure:"preserve_numeric_types"`
+}
+
+// DefaultConfig returns the translation expected by the X-Ray exporter to
+// convert spans back to segments.
+func DefaultConfig() Config {
+	return Config{
+		MetadataPrefix: awsxray.AWSXraySegmentMetadataAttributePrefix,
+	}
+}
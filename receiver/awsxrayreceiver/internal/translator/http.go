@@ -15,6 +15,8 @@
 package translator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/translator"
 
 import (
+	"encoding/json"
+
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 
@@ -60,6 +62,10 @@ func addHTTP(seg *awsxray.Segment, span ptrace.Span) {
 		case float64:
 			lengthPointer := int64(val)
 			addInt64(&lengthPointer, conventions.AttributeHTTPResponseContentLength, attrs)
+		case json.Number:
+			if length, err := val.Int64(); err == nil {
+				addInt64(&length, conventions.AttributeHTTPResponseContentLength, attrs)
+			}
 		}
 	}
 
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/translator"
+
+import (
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+// addLinks translates the links of a segment, such as the producers of the SQS messages
+// it processes, and its precursors, such as the preceding states of a Step Functions
+// execution, to span links. When the segment has no parent, its first link to a span of
+// the same trace, whose trace it continues, is returned as its parent instead. Malformed
+// links and precursors are logged and skipped rather than failing the whole segment.
+func addLinks(seg *awsxray.Segment, traceID [16]byte, findParent bool, span ptrace.Span, cfg Config, logger *zap.Logger) [8]byte {
+	var parentID [8]byte
+	for _, l := range seg.Links {
+		linkTraceID, linkSpanID, err := decodeLink(l)
+		if err != nil {
+			logger.Warn("Skipping malformed X-Ray segment link", zap.Error(err))
+			continue
+		}
+		if findParent && parentID == [8]byte{} && linkTraceID == traceID {
+			parentID = linkSpanID
+			continue
+		}
+
+		link := span.Links().AppendEmpty()
+		link.SetTraceID(linkTraceID)
+		link.SetSpanID(linkSpanID)
+		attrs := link.Attributes()
+		attrs.EnsureCapacity(len(l.Attributes))
+		for k, v := range l.Attributes {
+			fromJSON(v, attrs.PutEmpty(k), cfg)
+		}
+	}
+
+	for i := range seg.PrecursorIDs {
+		precursorID, err := decodeXRaySpanID(&seg.PrecursorIDs[i])
+		if err != nil {
+			logger.Warn("Skipping malformed X-Ray segment precursor", zap.String("precursor_id", seg.PrecursorIDs[i]), zap.Error(err))
+			continue
+		}
+		link := span.Links().AppendEmpty()
+		link.SetTraceID(traceID)
+		link.SetSpanID(precursorID)
+		link.Attributes().PutBool(awsxray.AWSXRayPrecursorAttribute, true)
+	}
+	return parentID
+}
+
+// decodeLink decodes the trace and span IDs of a link, whose trace ID may be a trace header
+// such as "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
+// holding the span ID in its Parent field.
+func decodeLink(l awsxray.SpanLinkData) ([16]byte, [8]byte, error) {
+	traceID, spanID := l.TraceID, l.SpanID
+	if traceID != nil && awsxray.IsTraceHeader(*traceID) {
+		th, err := awsxray.ParseTraceHeader(*traceID)
+		if err != nil {
+			return [16]byte{}, [8]byte{}, err
+		}
+		traceID = &th.TraceID
+		if spanID == nil && th.ParentID != "" {
+			spanID = &th.ParentID
+		}
+	}
+
+	linkTraceID, err := decodeXRayTraceID(traceID)
+	if err != nil {
+		return [16]byte{}, [8]byte{}, err
+	}
+	linkSpanID, err := decodeXRaySpanID(spanID)
+	if err != nil {
+		return [16]byte{}, [8]byte{}, err
+	}
+	return linkTraceID, linkSpanID, nil
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
+)
+
+func TestTranslateSQSConsumerLinks(t *testing.T) {
+	// the segment of a batch of SQS messages, continuing the trace of the first message,
+	// and linking to the trace of the second one by its AWSTraceHeader attribute
+	raw := []byte(`{
+		"name": "consumer",
+		"id": "6b8ef4b2a3c5d7e9",
+		"trace_id": "1-5759e988-bd862e3fe1be46a994272793",
+		"start_time": 1478293361.271,
+		"end_time": 1478293361.449,
+		"links": [
+			{"trace_id": "1-5759e988-bd862e3fe1be46a994272793", "id": "53995c3f42cd8ad8"},
+			{"trace_id": "Root=1-581cf771-a006649127e371903a2de979;Parent=defdfd9912dc5a56;Sampled=1", "attributes": {"messaging.system": "AmazonSQS", "retries": 2}}
+		]
+	}`)
+	traces, count, err := ToTraces(raw, Config{PreserveNumericTypes: true}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "53995c3f42cd8ad8", span.ParentSpanID().HexString())
+	assert.Equal(t, ptrace.SpanKindInternal, span.Kind())
+	require.Equal(t, 1, span.Links().Len())
+	link := span.Links().At(0)
+	assert.Equal(t, "581cf771a006649127e371903a2de979", link.TraceID().HexString())
+	assert.Equal(t, "defdfd9912dc5a56", link.SpanID().HexString())
+	assert.Equal(t, map[string]interface{}{"messaging.system": "AmazonSQS", "retries": int64(2)}, link.Attributes().AsRaw())
+}
+
+func TestTranslateStepFunctionsPrecursors(t *testing.T) {
+	raw := []byte(`{
+		"name": "state machine",
+		"id": "6b8ef4b2a3c5d7e9",
+		"trace_id": "1-5759e988-bd862e3fe1be46a994272793",
+		"start_time": 1478293361.271,
+		"end_time": 1478293361.449,
+		"origin": "AWS::StepFunctions::StateMachine",
+		"subsegments": [
+			{"name": "first", "id": "53995c3f42cd8ad8", "start_time": 1478293361.271, "end_time": 1478293361.3},
+			{"name": "second", "id": "defdfd9912dc5a56", "start_time": 1478293361.3, "end_time": 1478293361.449, "precursor_ids": ["53995c3f42cd8ad8"]}
+		]
+	}`)
+	traces, _, err := ToTraces(raw, DefaultConfig(), zap.NewNop())
+	require.NoError(t, err)
+
+	spans := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 3, spans.Len())
+	assert.Equal(t, ptrace.SpanKindServer, spans.At(0).Kind())
+	assert.Equal(t, 0, spans.At(1).Links().Len())
+	second := spans.At(2)
+	assert.Equal(t, "6b8ef4b2a3c5d7e9", second.ParentSpanID().HexString())
+	require.Equal(t, 1, second.Links().Len())
+	link := second.Links().At(0)
+	assert.Equal(t, second.TraceID(), link.TraceID())
+	assert.Equal(t, "53995c3f42cd8ad8", link.SpanID().HexString())
+	assert.Equal(t, map[string]interface{}{awsxray.AWSXRayPrecursorAttribute: true}, link.Attributes().AsRaw())
+}
+
+func TestTranslateInvalidLinks(t *testing.T) {
+	raw := []byte(`{
+		"name": "consumer",
+		"id": "6b8ef4b2a3c5d7e9",
+		"trace_id": "1-5759e988-bd862e3fe1be46a994272793",
+		"start_time": 1478293361.271,
+		"links": [
+			{"trace_id": "Parent=defdfd9912dc5a56;Sampled=1"},
+			{"trace_id": "1-581cf771-a006649127e371903a2de979", "id": "defdfd9912dc5a56"}
+		],
+		"precursor_ids": ["not-a-span-id"]
+	}`)
+	core, logs := observer.New(zap.WarnLevel)
+	traces, _, err := ToTraces(raw, DefaultConfig(), zap.New(core))
+	require.NoError(t, err)
+
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	require.Equal(t, 1, span.Links().Len())
+	assert.Equal(t, "581cf771a006649127e371903a2de979", span.Links().At(0).TraceID().HexString())
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, "trace header has no Root field", logs.All()[0].ContextMap()["error"])
+	assert.Equal(t, "not-a-span-id", logs.All()[1].ContextMap()["precursor_id"])
+}
@@ -18,18 +18,71 @@ import (
 	"encoding/json"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
-
-	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
 )
 
-func addMetadata(meta map[string]map[string]interface{}, attrs pcommon.Map) error {
+func addMetadata(meta map[string]map[string]interface{}, attrs pcommon.Map, cfg Config) error {
 	for k, v := range meta {
+		if cfg.FlattenMetadata {
+			for nk, nv := range v {
+				putFlattened(cfg.MetadataPrefix+k+"."+nk, nv, attrs, cfg)
+			}
+			continue
+		}
+
 		val, err := json.Marshal(v)
 		if err != nil {
 			return err
 		}
-		attrs.PutStr(
-			awsxray.AWSXraySegmentMetadataAttributePrefix+k, string(val))
+		attrs.PutStr(cfg.MetadataPrefix+k, string(val))
 	}
 	return nil
 }
+
+// putFlattened puts the value under the key, and the values of nested objects
+// under their path joined with dots.
+func putFlattened(key string, v interface{}, attrs pcommon.Map, cfg Config) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for nk, nv := range t {
+			putFlattened(key+"."+nk, nv, attrs, cfg)
+		}
+	case []interface{}:
+		s := attrs.PutEmptySlice(key)
+		s.EnsureCapacity(len(t))
+		for _, e := range t {
+			fromJSON(e, s.AppendEmpty(), cfg)
+		}
+	default:
+		fromJSON(t, attrs.PutEmpty(key), cfg)
+	}
+}
+
+// fromJSON sets the value decoded from the segment to the attribute value.
+func fromJSON(v interface{}, dest pcommon.Value, cfg Config) {
+	switch t := v.(type) {
+	case string:
+		dest.SetStr(t)
+	case bool:
+		dest.SetBool(t)
+	case float64:
+		dest.SetDouble(t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil && cfg.PreserveNumericTypes {
+			dest.SetInt(i)
+			return
+		}
+		f, _ := t.Float64()
+		dest.SetDouble(f)
+	case map[string]interface{}:
+		m := dest.SetEmptyMap()
+		for k, e := range t {
+			fromJSON(e, m.PutEmpty(k), cfg)
+		}
+	case []interface{}:
+		s := dest.SetEmptySlice()
+		s.EnsureCapacity(len(t))
+		for _, e := range t {
+			fromJSON(e, s.AppendEmpty(), cfg)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestAddMetadata(t *testing.T) {
+	input := map[string]map[string]interface{}{
+		"default": {
+			"count": json.Number("3"),
+			"ratio": json.Number("0.5"),
+			"request": map[string]interface{}{
+				"retried": true,
+				"tags":    []interface{}{"a", json.Number("1")},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		cfg      Config
+		expected map[string]interface{}
+	}{
+		{
+			name: "namespaces as json",
+			cfg:  DefaultConfig(),
+			expected: map[string]interface{}{
+				"aws.xray.metadata.default": `{"count":3,"ratio":0.5,"request":{"retried":true,"tags":["a",1]}}`,
+			},
+		},
+		{
+			name: "flattened",
+			cfg:  Config{MetadataPrefix: "metadata.", FlattenMetadata: true},
+			expected: map[string]interface{}{
+				"metadata.default.count":           3.0,
+				"metadata.default.ratio":           0.5,
+				"metadata.default.request.retried": true,
+				"metadata.default.request.tags":    []interface{}{"a", 1.0},
+			},
+		},
+		{
+			name: "flattened with preserved numeric types",
+			cfg:  Config{FlattenMetadata: true, PreserveNumericTypes: true},
+			expected: map[string]interface{}{
+				"default.count":           int64(3),
+				"default.ratio":           0.5,
+				"default.request.retried": true,
+				"default.request.tags":    []interface{}{"a", int64(1)},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attrMap := pcommon.NewMap()
+			require.NoError(t, addMetadata(input, attrMap, tc.cfg))
+			assert.Equal(t, tc.expected, attrMap.AsRaw())
+		})
+	}
+}
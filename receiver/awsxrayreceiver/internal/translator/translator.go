@@ -15,13 +15,16 @@
 package translator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsxrayreceiver/internal/translator"
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"io"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
 
 	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
 )
@@ -35,9 +38,8 @@ const (
 // `toPdata` in this receiver to a common package later
 
 // ToTraces converts X-Ray segment (and its subsegments) to an OT ResourceSpans.
-func ToTraces(rawSeg []byte) (ptrace.Traces, int, error) {
-	var seg awsxray.Segment
-	err := json.Unmarshal(rawSeg, &seg)
+func ToTraces(rawSeg []byte, cfg Config, logger *zap.Logger) (ptrace.Traces, int, error) {
+	seg, err := unmarshalSegment(rawSeg, cfg)
 	if err != nil {
 		// return 1 as total segment (&subsegments) count
 		// because we can't parse the body the UDP packet.
@@ -73,7 +75,7 @@ func ToTraces(rawSeg []byte) (ptrace.Traces, int, error) {
 	// TraceID of the root segment in because embedded subsegments
 	// do not have that information, but it's needed after we flatten
 	// the embedded subsegment to generate independent child spans.
-	_, err = segToSpans(seg, seg.TraceID, nil, spans)
+	_, err = segToSpans(seg, seg.TraceID, nil, spans, cfg, logger)
 	if err != nil {
 		return ptrace.Traces{}, count, err
 	}
@@ -81,11 +83,32 @@ func ToTraces(rawSeg []byte) (ptrace.Traces, int, error) {
 	return traceData, count, nil
 }
 
-func segToSpans(seg awsxray.Segment, traceID, parentID *string, spans ptrace.SpanSlice) (ptrace.Span, error) {
+// unmarshalSegment decodes the segment. The numbers of the annotations and
+// metadata are decoded as json.Number when their type is preserved, and as
+// float64 otherwise.
+func unmarshalSegment(rawSeg []byte, cfg Config) (awsxray.Segment, error) {
+	var seg awsxray.Segment
+	if !cfg.PreserveNumericTypes {
+		err := json.Unmarshal(rawSeg, &seg)
+		return seg, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(rawSeg))
+	dec.UseNumber()
+	if err := dec.Decode(&seg); err != nil {
+		return seg, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return seg, errors.New("invalid data after top-level segment")
+	}
+	return seg, nil
+}
+
+func segToSpans(seg awsxray.Segment, traceID, parentID *string, spans ptrace.SpanSlice, cfg Config, logger *zap.Logger) (ptrace.Span, error) {
 
 	span := spans.AppendEmpty()
 
-	err := populateSpan(&seg, traceID, parentID, span)
+	err := populateSpan(&seg, traceID, parentID, span, cfg, logger)
 	if err != nil {
 		return ptrace.Span{}, err
 	}
@@ -94,7 +117,7 @@ func segToSpans(seg awsxray.Segment, traceID, parentID *string, spans ptrace.Spa
 	for _, s := range seg.Subsegments {
 		populatedChildSpan, err = segToSpans(s,
 			traceID, seg.ID,
-			spans)
+			spans, cfg, logger)
 		if err != nil {
 			return ptrace.Span{}, err
 		}
@@ -117,7 +140,7 @@ func segToSpans(seg awsxray.Segment, traceID, parentID *string, spans ptrace.Spa
 	return span, nil
 }
 
-func populateSpan(seg *awsxray.Segment, traceID, parentID *string, span ptrace.Span) error {
+func populateSpan(seg *awsxray.Segment, traceID, parentID *string, span ptrace.Span, cfg Config, logger *zap.Logger) error {
 
 	attrs := span.Attributes()
 	attrs.Clear()
@@ -168,6 +191,11 @@ func populateSpan(seg *awsxray.Segment, traceID, parentID *string, span ptrace.S
 	span.SetTraceID(traceIDBytes)
 	span.SetSpanID(spanIDBytes)
 
+	linkedParentIDBytes := addLinks(seg, traceIDBytes, parentIDBytes == [8]byte{}, span, cfg, logger)
+	if parentIDBytes == [8]byte{} {
+		parentIDBytes = linkedParentIDBytes
+	}
+
 	if parentIDBytes != [8]byte{} {
 		span.SetParentSpanID(parentIDBytes)
 	} else {
@@ -189,8 +217,8 @@ func populateSpan(seg *awsxray.Segment, traceID, parentID *string, span ptrace.S
 
 	addBool(seg.Traced, awsxray.AWSXRayTracedAttribute, attrs)
 
-	addAnnotations(seg.Annotations, attrs)
-	return addMetadata(seg.Metadata, attrs)
+	addAnnotations(seg.Annotations, attrs, cfg)
+	return addMetadata(seg.Metadata, attrs, cfg)
 }
 
 func populateResource(seg *awsxray.Segment, rs pcommon.Resource) {
@@ -23,9 +23,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
 
 	awsxray "github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/xray"
 )
@@ -869,7 +871,7 @@ func TestTranslation(t *testing.T) {
 				)
 			}
 
-			traces, totalSpanCount, err := ToTraces(content)
+			traces, totalSpanCount, err := ToTraces(content, DefaultConfig(), zap.NewNop())
 			if err == nil || (!tc.expectedUnmarshallFailure && expectedRs.ScopeSpans().Len() > 0 && expectedRs.ScopeSpans().At(0).Spans().Len() > 0) {
 				assert.Equal(t, totalSpanCount,
 					expectedRs.ScopeSpans().At(0).Spans().Len(),
@@ -1092,3 +1094,35 @@ func TestDecodeXRaySpanID(t *testing.T) {
 	assert.Error(t, err)
 
 }
+
+func TestToTracesPreserveNumericTypes(t *testing.T) {
+	rawSeg := []byte(`{
+		"trace_id": "1-5f187253-6a106696d56b1f4ef9eba2ed",
+		"id": "5cc4a447f5d4d696",
+		"name": "service",
+		"start_time": 1595437651.680097,
+		"end_time": 1595437652.197392,
+		"annotations": {"retries": 2},
+		"metadata": {"default": {"attempts": {"count": 3}}},
+		"http": {"response": {"status": 200, "content_length": 42}}
+	}`)
+	cfg := Config{FlattenMetadata: true, PreserveNumericTypes: true}
+
+	traces, count, err := ToTraces(rawSeg, cfg, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	attrs := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	retries, ok := attrs.Get("retries")
+	require.True(t, ok)
+	assert.Equal(t, pcommon.NewValueInt(2), retries)
+	attempts, ok := attrs.Get("default.attempts.count")
+	require.True(t, ok)
+	assert.Equal(t, pcommon.NewValueInt(3), attempts)
+	length, ok := attrs.Get(conventions.AttributeHTTPResponseContentLength)
+	require.True(t, ok)
+	assert.Equal(t, pcommon.NewValueInt(42), length)
+
+	_, _, err = ToTraces(append(rawSeg, '}'), cfg, zap.NewNop())
+	assert.Error(t, err)
+}
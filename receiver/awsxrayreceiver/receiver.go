@@ -40,12 +40,13 @@ const (
 // xrayReceiver implements the component.TracesReceiver interface for converting
 // AWS X-Ray segment document into the OT internal trace format.
 type xrayReceiver struct {
-	instanceID config.ComponentID
-	poller     udppoller.Poller
-	server     proxy.Server
-	settings   component.ReceiverCreateSettings
-	consumer   consumer.Traces
-	obsrecv    *obsreport.Receiver
+	instanceID  config.ComponentID
+	poller      udppoller.Poller
+	server      proxy.Server
+	settings    component.ReceiverCreateSettings
+	consumer    consumer.Traces
+	obsrecv     *obsreport.Receiver
+	translation translator.Config
 }
 
 func newReceiver(config *Config,
@@ -87,6 +88,7 @@ func newReceiver(config *Config,
 			Transport:              udppoller.Transport,
 			ReceiverCreateSettings: set,
 		}),
+		translation: config.Translation,
 	}, nil
 }
 
@@ -117,7 +119,7 @@ func (x *xrayReceiver) start() {
 	incomingSegments := x.poller.SegmentsChan()
 	for seg := range incomingSegments {
 		ctx := x.obsrecv.StartTracesOp(seg.Ctx)
-		traces, totalSpanCount, err := translator.ToTraces(seg.Payload)
+		traces, totalSpanCount, err := translator.ToTraces(seg.Payload, x.translation, x.settings.Logger)
 		if err != nil {
 			x.settings.Logger.Warn("X-Ray segment to OT traces conversion failed", zap.Error(err))
 			x.obsrecv.EndTracesOp(ctx, awsxray.TypeStr, totalSpanCount, err)
@@ -88,6 +88,36 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "template"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "localhost:2003",
+					Transport: "tcp",
+				},
+				TCPIdleTimeout: 30 * time.Second,
+				Parser: &protocol.Config{
+					Type: "template",
+					Config: &protocol.TemplateParserConfig{
+						Rules: []*protocol.TemplateRule{
+							{
+								Template:   "servers.{host}.*.{metric}",
+								NamePrefix: "server",
+								Labels: map[string]string{
+									"key": "value",
+								},
+								MetricType: "cumulative",
+							},
+							{
+								Template: "{service}.{host}.{metric...}",
+							},
+						},
+						MetricNameSeparator: "_",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
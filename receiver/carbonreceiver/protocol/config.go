@@ -27,6 +27,7 @@ var (
 	parserMap = map[string]func() ParserConfig{
 		"plaintext": plaintextDefaultConfig,
 		"regex":     regexDefaultConfig,
+		"template":  templateDefaultConfig,
 	}
 
 	// validParsers keeps a list of all valid parsers to be used in error
@@ -64,6 +64,15 @@ func TestLoadParserConfig(t *testing.T) {
 				Config: &RegexParserConfig{},
 			},
 		},
+		{
+			name:   "default_template",
+			cfgMap: map[string]interface{}{"type": "template"},
+			cfg:    Config{Type: "template"},
+			want: Config{
+				Type:   "template",
+				Config: &TemplateParserConfig{MetricNameSeparator: "."},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
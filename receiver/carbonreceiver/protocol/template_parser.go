@@ -0,0 +1,315 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/protocol"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+)
+
+const (
+	// templateMetricNode is the name of the template nodes forming the metric name.
+	templateMetricNode = "metric"
+	// templateGreedySuffix marks the template node matching all the path nodes
+	// not matched by the other template nodes.
+	templateGreedySuffix = "..."
+	// templateSkipNode is a template node matching any path node, which is
+	// ignored.
+	templateSkipNode = "*"
+)
+
+// TemplateParserConfig has the configuration for a parser that can breakdown a
+// Carbon "metric path" and transform it in corresponding metric labels
+// according to a series of templates (see below for details).
+//
+// Templates are easier to write than regular expressions for the most common
+// naming hierarchies, where each node of the dotted path has a given meaning,
+// see https://graphite.readthedocs.io/en/latest/feeding-carbon.html#step-1-plan-a-naming-hierarchy
+//
+// Each node of a template is either:
+//   - "{key}", the path node being the value of the "key" label;
+//   - "{metric}", the path node being part of the metric name;
+//   - "*", the path node being ignored;
+//   - a literal, which the path node must be equal to.
+//
+// One of the "{key}" or "{metric}" nodes can end with "...", to match all the
+// path nodes not matched by the other template nodes.
+//
+// Examples:
+//
+// 1. Rule:
+//   - template: "{service}.{host}.{metric...}"
+//     Metric path: "checkout.host00.cpu.seconds"
+//     Resulting metric:
+//     name: cpu.seconds
+//     label keys: {"service", "host"}
+//     label values: {"checkout", "host00"}
+//
+// 2. Rule:
+//   - template: "servers.{host}.*.{metric}"
+//     name_prefix: "server"
+//     name_separator: "_"
+//     Metric path: "servers.host01.eth0.errors"
+//     Resulting metric:
+//     name: server_errors
+//     label keys: {"host"}
+//     label values: {"host01"}
+type TemplateParserConfig struct {
+	// Rules contains the templates to be used by the parser. The first rule
+	// whose template matches the metric path is applied. If no rules match
+	// the metric is then processed by the "plaintext" parser.
+	Rules []*TemplateRule `mapstructure:"rules"`
+
+	// MetricNameSeparator is used when joining the name prefix of each
+	// individual rule and the path nodes matched by the "{metric}" nodes.
+	// Defaults to ".".
+	MetricNameSeparator string `mapstructure:"name_separator"`
+}
+
+// TemplateRule describes how the nodes of the path of a metric are going to
+// be mapped to its name and labels. The rule is only applied if the path
+// matches the template.
+type TemplateRule struct {
+	// Template of the metric paths, see TemplateParserConfig.
+	Template string `mapstructure:"template"`
+
+	// NamePrefix is the prefix added to the metric name formed by the
+	// "{metric}" nodes.
+	NamePrefix string `mapstructure:"name_prefix"`
+
+	// Labels are key-value pairs added as labels to the metrics that match this
+	// rule.
+	Labels map[string]string `mapstructure:"labels"`
+
+	// MetricType selects the type of metric to be generated, supported values are
+	// "gauge" (the default) and "cumulative".
+	MetricType string `mapstructure:"type"`
+
+	// Fields cached after the compilation of the template.
+	nodes []templateNode
+	// greedy is the index of the greedy node, -1 if none.
+	greedy int
+}
+
+type templateNodeKind int
+
+const (
+	literalNode templateNodeKind = iota
+	labelNode
+	metricNode
+	skipNode
+)
+
+type templateNode struct {
+	kind templateNodeKind
+	// value is the label key of label nodes, or the literal of literal nodes.
+	value string
+}
+
+var _ (ParserConfig) = (*TemplateParserConfig)(nil)
+
+// BuildParser builds the respective parser of the configuration instance.
+func (tpc *TemplateParserConfig) BuildParser() (Parser, error) {
+	if tpc == nil {
+		return nil, errors.New("nil receiver on TemplateParserConfig.BuildParser")
+	}
+
+	if err := compileTemplateRules(tpc.Rules); err != nil {
+		return nil, err
+	}
+
+	tpp := &templatePathParser{
+		rules:               tpc.Rules,
+		metricNameSeparator: tpc.MetricNameSeparator,
+	}
+
+	return NewParser(tpp)
+}
+
+func compileTemplateRules(rules []*TemplateRule) error {
+	if len(rules) == 0 {
+		return errors.New(`no template rule was specified`)
+	}
+
+	for i, r := range rules {
+		switch TargetMetricType(r.MetricType) {
+		case DefaultMetricType, GaugeMetricType, CumulativeMetricType:
+		default:
+			return fmt.Errorf(
+				`error on %d-th rule: unknown metric type %q valid choices are: %q or %q`,
+				i,
+				r.MetricType,
+				GaugeMetricType,
+				CumulativeMetricType)
+		}
+
+		if r.Template == "" {
+			return fmt.Errorf("error on %d-th rule: empty template", i)
+		}
+
+		r.greedy = -1
+		r.nodes = nil
+		for j, part := range splitTemplate(r.Template) {
+			if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+				switch {
+				case part == templateSkipNode:
+					r.nodes = append(r.nodes, templateNode{kind: skipNode})
+				case part == "" || strings.ContainsAny(part, "{}"):
+					return fmt.Errorf("error on %d-th rule: invalid template node %q", i, part)
+				default:
+					r.nodes = append(r.nodes, templateNode{kind: literalNode, value: part})
+				}
+				continue
+			}
+
+			name := part[1 : len(part)-1]
+			if strings.HasSuffix(name, templateGreedySuffix) {
+				if r.greedy >= 0 {
+					return fmt.Errorf("error on %d-th rule: more than one template node ending with %q", i, templateGreedySuffix)
+				}
+				r.greedy = j
+				name = strings.TrimSuffix(name, templateGreedySuffix)
+			}
+			switch {
+			case name == "" || strings.ContainsAny(name, "{}"):
+				return fmt.Errorf("error on %d-th rule: invalid template node %q", i, part)
+			case name == templateMetricNode:
+				r.nodes = append(r.nodes, templateNode{kind: metricNode})
+			default:
+				r.nodes = append(r.nodes, templateNode{kind: labelNode, value: name})
+			}
+		}
+	}
+
+	return nil
+}
+
+// splitTemplate splits a template in its nodes, on the dots which aren't part
+// of the "..." suffix of a node.
+func splitTemplate(template string) []string {
+	var parts []string
+	start := 0
+	inNode := false
+	for i, c := range template {
+		switch {
+		case c == '{':
+			inNode = true
+		case c == '}':
+			inNode = false
+		case c == '.' && !inNode:
+			parts = append(parts, template[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, template[start:])
+}
+
+// match returns the path nodes matched by each template node, nil if the
+// path doesn't match the template.
+func (r *TemplateRule) match(pathNodes []string) [][]string {
+	if r.greedy < 0 && len(pathNodes) != len(r.nodes) ||
+		r.greedy >= 0 && len(pathNodes) < len(r.nodes) {
+		return nil
+	}
+
+	// The greedy node matches the path nodes between the ones matched by the
+	// nodes before and after it.
+	greedyLen := len(pathNodes) - len(r.nodes) + 1
+	matched := make([][]string, len(r.nodes))
+	p := 0
+	for i, node := range r.nodes {
+		n := 1
+		if i == r.greedy {
+			n = greedyLen
+		}
+		if node.kind == literalNode && pathNodes[p] != node.value {
+			return nil
+		}
+		matched[i] = pathNodes[p : p+n]
+		p += n
+	}
+	return matched
+}
+
+type templatePathParser struct {
+	rules []*TemplateRule
+
+	metricNameSeparator string
+
+	// plaintextParser is used if no rule matches a given metric.
+	plaintextPathParser PlaintextPathParser
+}
+
+// ParsePath converts the <metric_path> of a Carbon line (see PathParserHelper
+// a full description of the line format) according to the TemplateParserConfig
+// settings.
+func (tpp *templatePathParser) ParsePath(path string, parsedPath *ParsedPath) error {
+	pathNodes := strings.Split(path, ".")
+	for _, rule := range tpp.rules {
+		matched := rule.match(pathNodes)
+		if matched == nil {
+			continue
+		}
+
+		keys := make([]*metricspb.LabelKey, 0, len(matched)+len(rule.Labels))
+		values := make([]*metricspb.LabelValue, 0, len(matched)+len(rule.Labels))
+		var nameParts []string
+		if rule.NamePrefix != "" {
+			nameParts = append(nameParts, rule.NamePrefix)
+		}
+		for i, node := range rule.nodes {
+			switch node.kind {
+			case labelNode:
+				keys = append(keys, &metricspb.LabelKey{Key: node.value})
+				values = append(values, &metricspb.LabelValue{
+					Value:    strings.Join(matched[i], "."),
+					HasValue: true,
+				})
+			case metricNode:
+				nameParts = append(nameParts, matched[i]...)
+			}
+		}
+
+		for k, v := range rule.Labels {
+			keys = append(keys, &metricspb.LabelKey{Key: k})
+			values = append(values, &metricspb.LabelValue{
+				Value:    v,
+				HasValue: true,
+			})
+		}
+
+		actualMetricName := strings.Join(nameParts, tpp.metricNameSeparator)
+		if actualMetricName == "" {
+			actualMetricName = path
+		}
+
+		parsedPath.MetricName = actualMetricName
+		parsedPath.LabelKeys = keys
+		parsedPath.LabelValues = values
+		parsedPath.MetricType = TargetMetricType(rule.MetricType)
+		return nil
+	}
+
+	return tpp.plaintextPathParser.ParsePath(path, parsedPath)
+}
+
+func templateDefaultConfig() ParserConfig {
+	return &TemplateParserConfig{
+		MetricNameSeparator: ".",
+	}
+}
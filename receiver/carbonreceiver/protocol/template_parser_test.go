@@ -0,0 +1,215 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateParserConfigBuildParser(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ParserConfig
+		wantErr bool
+	}{
+		{
+			name:    "nil_method_receiver",
+			config:  (*TemplateParserConfig)(nil),
+			wantErr: true,
+		},
+		{
+			name:    "no_rules",
+			config:  &TemplateParserConfig{},
+			wantErr: true,
+		},
+		{
+			name: "empty_template",
+			config: &TemplateParserConfig{
+				Rules: []*TemplateRule{{}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty_node",
+			config: &TemplateParserConfig{
+				Rules: []*TemplateRule{{Template: "{service}..{metric}"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid_node",
+			config: &TemplateParserConfig{
+				Rules: []*TemplateRule{{Template: "{service}.{host.{metric}"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "two_greedy_nodes",
+			config: &TemplateParserConfig{
+				Rules: []*TemplateRule{{Template: "{service...}.{metric...}"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid_metric_type",
+			config: &TemplateParserConfig{
+				Rules: []*TemplateRule{
+					{
+						Template:   "{service}.{metric}",
+						MetricType: "unknown",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid_rules",
+			config: &TemplateParserConfig{
+				Rules: []*TemplateRule{
+					{Template: "servers.{host}.*.{metric}"},
+					{Template: "{service}.{host}.{metric...}"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.BuildParser()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			require.NotNil(t, got)
+		})
+	}
+}
+
+func Test_templateParser_parsePath(t *testing.T) {
+	config := TemplateParserConfig{
+		Rules: []*TemplateRule{
+			{
+				Template:   "servers.{host}.*.{metric}",
+				NamePrefix: "server",
+				Labels:     map[string]string{"k": "v"},
+				MetricType: string(CumulativeMetricType),
+			},
+			{
+				Template: "{service}.{host}.{metric...}.total",
+			},
+			{
+				Template: "{namespace...}.hosts.{host}",
+			},
+			{
+				Template:   "{service}.{host}.{metric...}",
+				MetricType: string(GaugeMetricType),
+			},
+		},
+		MetricNameSeparator: "_",
+	}
+
+	require.NoError(t, compileTemplateRules(config.Rules))
+	tp := &templatePathParser{
+		rules:               config.Rules,
+		metricNameSeparator: config.MetricNameSeparator,
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		wantName       string
+		wantKeys       []*metricspb.LabelKey
+		wantValues     []*metricspb.LabelValue
+		wantMetricType TargetMetricType
+	}{
+		{
+			name:     "no_rule_match",
+			path:     "single_node",
+			wantName: "single_node",
+		},
+		{
+			name:     "match_skip_node",
+			path:     "servers.host00.eth0.errors",
+			wantName: "server_errors",
+			wantKeys: []*metricspb.LabelKey{
+				{Key: "host"},
+				{Key: "k"},
+			},
+			wantValues: []*metricspb.LabelValue{
+				{Value: "host00", HasValue: true},
+				{Value: "v", HasValue: true},
+			},
+			wantMetricType: CumulativeMetricType,
+		},
+		{
+			name:     "match_greedy_metric_before_literal",
+			path:     "checkout.host01.http.requests.total",
+			wantName: "http_requests",
+			wantKeys: []*metricspb.LabelKey{
+				{Key: "service"},
+				{Key: "host"},
+			},
+			wantValues: []*metricspb.LabelValue{
+				{Value: "checkout", HasValue: true},
+				{Value: "host01", HasValue: true},
+			},
+		},
+		{
+			name:     "match_greedy_metric",
+			path:     "checkout.host02.cpu.seconds",
+			wantName: "cpu_seconds",
+			wantKeys: []*metricspb.LabelKey{
+				{Key: "service"},
+				{Key: "host"},
+			},
+			wantValues: []*metricspb.LabelValue{
+				{Value: "checkout", HasValue: true},
+				{Value: "host02", HasValue: true},
+			},
+			wantMetricType: GaugeMetricType,
+		},
+		{
+			name:     "match_greedy_label_without_metric",
+			path:     "eu.west.hosts.host03",
+			wantName: "eu.west.hosts.host03",
+			wantKeys: []*metricspb.LabelKey{
+				{Key: "namespace"},
+				{Key: "host"},
+			},
+			wantValues: []*metricspb.LabelValue{
+				{Value: "eu.west", HasValue: true},
+				{Value: "host03", HasValue: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParsedPath{}
+			require.NoError(t, tp.ParsePath(tt.path, &got))
+
+			assert.Equal(t, tt.wantName, got.MetricName)
+			assert.Equal(t, tt.wantKeys, got.LabelKeys)
+			assert.Equal(t, tt.wantValues, got.LabelValues)
+			assert.Equal(t, tt.wantMetricType, got.MetricType)
+		})
+	}
+}
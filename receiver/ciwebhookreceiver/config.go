@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/ciwebhookreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for the CI webhook receiver.
+type Config struct {
+	config.ReceiverSettings       `mapstructure:",squash"`
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// GitHub configures the path accepting the GitHub Actions events.
+	GitHub GitHubConfig `mapstructure:"github"`
+
+	// GitLab configures the path accepting the GitLab CI events.
+	GitLab GitLabConfig `mapstructure:"gitlab"`
+}
+
+// GitHubConfig defines how the "workflow_run" and "workflow_job" events of GitHub are received.
+type GitHubConfig struct {
+	// Path is the HTTP path of the webhook.
+	Path string `mapstructure:"path"`
+
+	// Secret is the secret of the webhook, the X-Hub-Signature-256 header of the events being
+	// verified with it. The events are not verified when not set.
+	Secret string `mapstructure:"secret"`
+}
+
+// GitLabConfig defines how the "Pipeline Hook" events of GitLab are received.
+type GitLabConfig struct {
+	// Path is the HTTP path of the webhook.
+	Path string `mapstructure:"path"`
+
+	// Token is the secret token of the webhook, the X-Gitlab-Token header of the events being
+	// compared to it. The events are not verified when not set.
+	Token string `mapstructure:"token"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	if cfg.MaxRequestBodySize <= 0 {
+		return errors.New("max_request_body_size must be greater than 0")
+	}
+	if !strings.HasPrefix(cfg.GitHub.Path, "/") {
+		return fmt.Errorf("invalid github path %q, must start with /", cfg.GitHub.Path)
+	}
+	if !strings.HasPrefix(cfg.GitLab.Path, "/") {
+		return fmt.Errorf("invalid gitlab path %q, must start with /", cfg.GitLab.Path)
+	}
+	if cfg.GitHub.Path == cfg.GitLab.Path {
+		return fmt.Errorf("github and gitlab paths must be different, both are %q", cfg.GitHub.Path)
+	}
+	return nil
+}
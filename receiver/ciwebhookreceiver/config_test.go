@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       config.ComponentID
+		expected config.Receiver
+	}{
+		{
+			id:       config.NewComponentID(typeStr),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "custom"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint:           "0.0.0.0:9443",
+					MaxRequestBodySize: 1048576,
+				},
+				GitHub: GitHubConfig{
+					Path:   "/events/github",
+					Secret: "github_secret",
+				},
+				GitLab: GitLabConfig{
+					Path:  "/events/gitlab",
+					Token: "gitlab_token",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:        "missing endpoint",
+			modify:      func(cfg *Config) { cfg.Endpoint = "" },
+			expectedErr: "endpoint is required",
+		},
+		{
+			name:        "invalid max request body size",
+			modify:      func(cfg *Config) { cfg.MaxRequestBodySize = 0 },
+			expectedErr: "max_request_body_size must be greater than 0",
+		},
+		{
+			name:        "relative github path",
+			modify:      func(cfg *Config) { cfg.GitHub.Path = "github" },
+			expectedErr: `invalid github path "github", must start with /`,
+		},
+		{
+			name:        "missing gitlab path",
+			modify:      func(cfg *Config) { cfg.GitLab.Path = "" },
+			expectedErr: `invalid gitlab path "", must start with /`,
+		},
+		{
+			name:        "same paths",
+			modify:      func(cfg *Config) { cfg.GitLab.Path = cfg.GitHub.Path },
+			expectedErr: `github and gitlab paths must be different, both are "/github"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
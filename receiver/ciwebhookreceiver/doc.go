@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ciwebhookreceiver implements a receiver accepting the webhook events
+// of GitHub Actions and GitLab CI, and converting the completed pipeline runs
+// and their jobs into traces and metrics.
+package ciwebhookreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/ciwebhookreceiver"
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/ciwebhookreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+)
+
+const (
+	// Value of "type" key in configuration.
+	typeStr = "ciwebhook"
+	// The stability level of the receiver.
+	stability = component.StabilityLevelInDevelopment
+
+	defaultEndpoint           = "0.0.0.0:8089"
+	defaultMaxRequestBodySize = 5 * 1024 * 1024
+	defaultGitHubPath         = "/github"
+	defaultGitLabPath         = "/gitlab"
+)
+
+// NewFactory creates a factory for the CI webhook receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesReceiver(createTracesReceiver, stability),
+		component.WithMetricsReceiver(createMetricsReceiver, stability))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint:           defaultEndpoint,
+			MaxRequestBodySize: defaultMaxRequestBodySize,
+		},
+		GitHub: GitHubConfig{Path: defaultGitHubPath},
+		GitLab: GitLabConfig{Path: defaultGitLabPath},
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newReceiver(cfg.(*Config), set)
+	})
+	if err := r.Unwrap().(*ciReceiver).registerTracesConsumer(nextConsumer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newReceiver(cfg.(*Config), set)
+	})
+	if err := r.Unwrap().(*ciReceiver).registerMetricsConsumer(nextConsumer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// receivers holds the receivers created for each configuration, the traces and the metrics
+// receivers of a configuration sharing the same HTTP server.
+var receivers = sharedcomponent.NewSharedComponents()
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+
+	rCfg := cfg.(*Config)
+	assert.Equal(t, defaultEndpoint, rCfg.Endpoint)
+	assert.Equal(t, int64(defaultMaxRequestBodySize), rCfg.MaxRequestBodySize)
+	assert.Equal(t, defaultGitHubPath, rCfg.GitHub.Path)
+	assert.Equal(t, defaultGitLabPath, rCfg.GitLab.Path)
+}
+
+func TestCreateReceivers(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := componenttest.NewNopReceiverCreateSettings()
+
+	traces := consumertest.NewNop()
+	tr, err := factory.CreateTracesReceiver(context.Background(), set, cfg, traces)
+	require.NoError(t, err)
+	metrics := consumertest.NewNop()
+	mr, err := factory.CreateMetricsReceiver(context.Background(), set, cfg, metrics)
+	require.NoError(t, err)
+
+	// the traces and the metrics receivers share the same HTTP server
+	r := tr.(*sharedcomponent.SharedComponent).Unwrap().(*ciReceiver)
+	assert.Same(t, r, mr.(*sharedcomponent.SharedComponent).Unwrap())
+	assert.Equal(t, traces, r.tracesConsumer)
+	assert.Equal(t, metrics, r.metricsConsumer)
+
+	_, err = factory.CreateTracesReceiver(context.Background(), set, factory.CreateDefaultConfig(), nil)
+	assert.Equal(t, errNilNextConsumer, err)
+}
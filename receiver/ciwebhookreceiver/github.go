@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/ciwebhookreceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	headerGitHubEvent = "X-GitHub-Event"
+
+	githubEventWorkflowRun = "workflow_run"
+	githubEventWorkflowJob = "workflow_job"
+	githubActionCompleted  = "completed"
+)
+
+type githubRepository struct {
+	FullName string `json:"full_name"`
+}
+
+type githubWorkflowRunEvent struct {
+	Action      string            `json:"action"`
+	WorkflowRun githubWorkflowRun `json:"workflow_run"`
+	Repository  githubRepository  `json:"repository"`
+}
+
+type githubWorkflowRun struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	RunAttempt   int64     `json:"run_attempt"`
+	Event        string    `json:"event"`
+	Conclusion   string    `json:"conclusion"`
+	HeadBranch   string    `json:"head_branch"`
+	HeadSHA      string    `json:"head_sha"`
+	HTMLURL      string    `json:"html_url"`
+	CreatedAt    timestamp `json:"created_at"`
+	RunStartedAt timestamp `json:"run_started_at"`
+	UpdatedAt    timestamp `json:"updated_at"`
+}
+
+type githubWorkflowJobEvent struct {
+	Action      string            `json:"action"`
+	WorkflowJob githubWorkflowJob `json:"workflow_job"`
+	Repository  githubRepository  `json:"repository"`
+}
+
+type githubWorkflowJob struct {
+	ID           int64        `json:"id"`
+	RunID        int64        `json:"run_id"`
+	RunAttempt   int64        `json:"run_attempt"`
+	WorkflowName string       `json:"workflow_name"`
+	HeadBranch   string       `json:"head_branch"`
+	HeadSHA      string       `json:"head_sha"`
+	Name         string       `json:"name"`
+	Conclusion   string       `json:"conclusion"`
+	RunnerName   string       `json:"runner_name"`
+	CreatedAt    timestamp    `json:"created_at"`
+	StartedAt    timestamp    `json:"started_at"`
+	CompletedAt  timestamp    `json:"completed_at"`
+	Steps        []githubStep `json:"steps"`
+}
+
+type githubStep struct {
+	Number      int64     `json:"number"`
+	Name        string    `json:"name"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   timestamp `json:"started_at"`
+	CompletedAt timestamp `json:"completed_at"`
+}
+
+// parseGitHubEvent converts a "workflow_run" or a "workflow_job" completed event. It returns nil
+// for the other events and actions, which are acknowledged and ignored.
+func parseGitHubEvent(event string, body []byte) (*pipelineRun, error) {
+	switch event {
+	case githubEventWorkflowRun:
+		var payload githubWorkflowRunEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("invalid %s event: %w", event, err)
+		}
+		if payload.Action != githubActionCompleted {
+			return nil, nil
+		}
+		return githubRunFromEvent(payload), nil
+	case githubEventWorkflowJob:
+		var payload githubWorkflowJobEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("invalid %s event: %w", event, err)
+		}
+		if payload.Action != githubActionCompleted {
+			return nil, nil
+		}
+		return githubJobFromEvent(payload), nil
+	default:
+		return nil, nil
+	}
+}
+
+func githubRunFromEvent(payload githubWorkflowRunEvent) *pipelineRun {
+	run := payload.WorkflowRun
+	start := run.RunStartedAt.Time
+	if start.IsZero() {
+		start = run.CreatedAt.Time
+	}
+	return &pipelineRun{
+		provider:   providerGitHub,
+		repository: payload.Repository.FullName,
+		id:         run.ID,
+		attempt:    run.RunAttempt,
+		name:       run.Name,
+		url:        run.HTMLURL,
+		ref:        run.HeadBranch,
+		revision:   run.HeadSHA,
+		trigger:    run.Event,
+		completed:  true,
+		conclusion: run.Conclusion,
+		status:     githubStatus(run.Conclusion),
+		start:      start,
+		end:        run.UpdatedAt.Time,
+	}
+}
+
+// githubJobFromEvent returns the run of the job, known from the job only, so that the job is
+// added to the trace of its run.
+func githubJobFromEvent(payload githubWorkflowJobEvent) *pipelineRun {
+	j := payload.WorkflowJob
+	created := j.CreatedAt.Time
+	if created.IsZero() {
+		created = j.StartedAt.Time
+	}
+
+	steps := make([]step, 0, len(j.Steps))
+	for _, s := range j.Steps {
+		// the skipped steps have no times
+		if s.StartedAt.IsZero() || s.CompletedAt.IsZero() {
+			continue
+		}
+		steps = append(steps, step{
+			number:     s.Number,
+			name:       s.Name,
+			conclusion: s.Conclusion,
+			status:     githubStatus(s.Conclusion),
+			start:      s.StartedAt.Time,
+			end:        s.CompletedAt.Time,
+		})
+	}
+
+	return &pipelineRun{
+		provider:   providerGitHub,
+		repository: payload.Repository.FullName,
+		id:         j.RunID,
+		attempt:    j.RunAttempt,
+		name:       j.WorkflowName,
+		ref:        j.HeadBranch,
+		revision:   j.HeadSHA,
+		jobs: []job{{
+			id:         j.ID,
+			name:       j.Name,
+			runner:     j.RunnerName,
+			conclusion: j.Conclusion,
+			status:     githubStatus(j.Conclusion),
+			created:    created,
+			start:      j.StartedAt.Time,
+			end:        j.CompletedAt.Time,
+			steps:      steps,
+		}},
+	}
+}
+
+func githubStatus(conclusion string) ptrace.StatusCode {
+	switch conclusion {
+	case "success":
+		return ptrace.StatusCodeOk
+	case "failure", "timed_out", "startup_failure":
+		return ptrace.StatusCodeError
+	default:
+		return ptrace.StatusCodeUnset
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
+
+func at(minute, second int) time.Time {
+	return time.Date(2022, 10, 4, 10, minute, second, 0, time.UTC)
+}
+
+func TestParseGitHubWorkflowRun(t *testing.T) {
+	run, err := parseGitHubEvent(githubEventWorkflowRun, readTestdata(t, "github_workflow_run.json"))
+	require.NoError(t, err)
+	assert.Equal(t, &pipelineRun{
+		provider:   providerGitHub,
+		repository: "octo/hello",
+		id:         3216549870,
+		attempt:    2,
+		name:       "CI",
+		url:        "https://github.com/octo/hello/actions/runs/3216549870",
+		ref:        "main",
+		revision:   "c0ffee1234567890c0ffee1234567890c0ffee12",
+		trigger:    "push",
+		completed:  true,
+		conclusion: "failure",
+		status:     ptrace.StatusCodeError,
+		start:      at(20, 5),
+		end:        at(25, 0),
+	}, run)
+}
+
+func TestParseGitHubWorkflowJob(t *testing.T) {
+	run, err := parseGitHubEvent(githubEventWorkflowJob, readTestdata(t, "github_workflow_job.json"))
+	require.NoError(t, err)
+	assert.Equal(t, &pipelineRun{
+		provider:   providerGitHub,
+		repository: "octo/hello",
+		id:         3216549870,
+		attempt:    2,
+		name:       "CI",
+		ref:        "main",
+		revision:   "c0ffee1234567890c0ffee1234567890c0ffee12",
+		jobs: []job{{
+			id:         8765432101,
+			name:       "test",
+			runner:     "GitHub Actions 2",
+			conclusion: "failure",
+			status:     ptrace.StatusCodeError,
+			created:    at(20, 5),
+			start:      at(20, 35),
+			end:        at(24, 35),
+			steps: []step{
+				{
+					number:     1,
+					name:       "Set up job",
+					conclusion: "success",
+					status:     ptrace.StatusCodeOk,
+					start:      at(20, 35),
+					end:        at(20, 40),
+				},
+				{
+					number:     2,
+					name:       "Run tests",
+					conclusion: "failure",
+					status:     ptrace.StatusCodeError,
+					start:      at(20, 40),
+					end:        at(24, 30),
+				},
+			},
+		}},
+	}, run)
+}
+
+func TestParseGitHubIgnoredEvents(t *testing.T) {
+	run, err := parseGitHubEvent("ping", []byte(`{"zen": "Keep it logically awesome."}`))
+	assert.NoError(t, err)
+	assert.Nil(t, run)
+
+	run, err = parseGitHubEvent(githubEventWorkflowJob, []byte(`{"action": "in_progress", "workflow_job": {"id": 1}}`))
+	assert.NoError(t, err)
+	assert.Nil(t, run)
+
+	_, err = parseGitHubEvent(githubEventWorkflowRun, []byte(`{"action": "completed", "workflow_run": {"updated_at": "yesterday"}}`))
+	assert.EqualError(t, err, `invalid workflow_run event: invalid time "yesterday"`)
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/ciwebhookreceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	headerGitLabEvent = "X-Gitlab-Event"
+	headerGitLabToken = "X-Gitlab-Token"
+
+	gitlabEventPipeline = "Pipeline Hook"
+)
+
+type gitlabPipelineEvent struct {
+	ObjectAttributes gitlabPipeline `json:"object_attributes"`
+	Project          gitlabProject  `json:"project"`
+	Builds           []gitlabBuild  `json:"builds"`
+}
+
+type gitlabPipeline struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Ref        string    `json:"ref"`
+	SHA        string    `json:"sha"`
+	Source     string    `json:"source"`
+	Status     string    `json:"status"`
+	URL        string    `json:"url"`
+	CreatedAt  timestamp `json:"created_at"`
+	FinishedAt timestamp `json:"finished_at"`
+}
+
+type gitlabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+type gitlabBuild struct {
+	ID         int64         `json:"id"`
+	Stage      string        `json:"stage"`
+	Name       string        `json:"name"`
+	Status     string        `json:"status"`
+	CreatedAt  timestamp     `json:"created_at"`
+	StartedAt  timestamp     `json:"started_at"`
+	FinishedAt timestamp     `json:"finished_at"`
+	Runner     *gitlabRunner `json:"runner"`
+}
+
+type gitlabRunner struct {
+	Description string `json:"description"`
+}
+
+// parseGitLabEvent converts a "Pipeline Hook" event of a finished pipeline, with all its jobs. It
+// returns nil for the other events and for the running pipelines, which are acknowledged and
+// ignored.
+func parseGitLabEvent(event string, body []byte) (*pipelineRun, error) {
+	if event != gitlabEventPipeline {
+		return nil, nil
+	}
+
+	var payload gitlabPipelineEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid %s event: %w", event, err)
+	}
+	pipeline := payload.ObjectAttributes
+	if !gitlabFinished(pipeline.Status) || pipeline.FinishedAt.IsZero() {
+		return nil, nil
+	}
+
+	name := pipeline.Name
+	if name == "" {
+		name = payload.Project.PathWithNamespace
+	}
+
+	jobs := make([]job, 0, len(payload.Builds))
+	for _, b := range payload.Builds {
+		// the jobs which were skipped, or never started, have no times
+		if b.StartedAt.IsZero() || b.FinishedAt.IsZero() {
+			continue
+		}
+		j := job{
+			id:         b.ID,
+			name:       b.Name,
+			stage:      b.Stage,
+			conclusion: b.Status,
+			status:     gitlabStatus(b.Status),
+			created:    b.CreatedAt.Time,
+			start:      b.StartedAt.Time,
+			end:        b.FinishedAt.Time,
+		}
+		if b.Runner != nil {
+			j.runner = b.Runner.Description
+		}
+		jobs = append(jobs, j)
+	}
+
+	return &pipelineRun{
+		provider:   providerGitLab,
+		repository: payload.Project.PathWithNamespace,
+		id:         pipeline.ID,
+		name:       name,
+		url:        pipeline.URL,
+		ref:        pipeline.Ref,
+		revision:   pipeline.SHA,
+		trigger:    pipeline.Source,
+		completed:  true,
+		conclusion: pipeline.Status,
+		status:     gitlabStatus(pipeline.Status),
+		start:      pipeline.CreatedAt.Time,
+		end:        pipeline.FinishedAt.Time,
+		jobs:       jobs,
+	}, nil
+}
+
+func gitlabFinished(status string) bool {
+	switch status {
+	case "success", "failed", "canceled", "skipped":
+		return true
+	default:
+		return false
+	}
+}
+
+func gitlabStatus(status string) ptrace.StatusCode {
+	switch status {
+	case "success":
+		return ptrace.StatusCodeOk
+	case "failed":
+		return ptrace.StatusCodeError
+	default:
+		return ptrace.StatusCodeUnset
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestParseGitLabPipeline(t *testing.T) {
+	run, err := parseGitLabEvent(gitlabEventPipeline, readTestdata(t, "gitlab_pipeline.json"))
+	require.NoError(t, err)
+	assert.Equal(t, &pipelineRun{
+		provider:   providerGitLab,
+		repository: "group/project",
+		id:         31,
+		name:       "Nightly",
+		url:        "https://gitlab.example.com/group/project/-/pipelines/31",
+		ref:        "master",
+		revision:   "bcbb5ec396a2c0f828686f14fac9b80b780504f2",
+		trigger:    "schedule",
+		completed:  true,
+		conclusion: "success",
+		status:     ptrace.StatusCodeOk,
+		start:      at(20, 0),
+		end:        at(23, 30),
+		// the skipped job is left out
+		jobs: []job{{
+			id:         377,
+			name:       "unit",
+			stage:      "test",
+			runner:     "shared-runners-manager-6.gitlab.com",
+			conclusion: "success",
+			status:     ptrace.StatusCodeOk,
+			created:    at(20, 0),
+			start:      at(20, 30),
+			end:        at(22, 0),
+		}},
+	}, run)
+}
+
+func TestParseGitLabIgnoredEvents(t *testing.T) {
+	run, err := parseGitLabEvent("Job Hook", []byte(`{"object_kind": "build"}`))
+	assert.NoError(t, err)
+	assert.Nil(t, run)
+
+	run, err = parseGitLabEvent(gitlabEventPipeline, []byte(`{"object_attributes": {"id": 31, "status": "running"}}`))
+	assert.NoError(t, err)
+	assert.Nil(t, run)
+
+	_, err = parseGitLabEvent(gitlabEventPipeline, []byte(`{"object_attributes": []}`))
+	assert.Error(t, err)
+}
+
+func TestTimestamp(t *testing.T) {
+	for _, value := range []string{
+		`"2022-10-04T10:20:30Z"`,
+		`"2022-10-04T12:20:30+02:00"`,
+		`"2022-10-04 10:20:30 UTC"`,
+		`"2022-10-04 12:20:30 +0200"`,
+	} {
+		var ts timestamp
+		require.NoError(t, ts.UnmarshalJSON([]byte(value)), value)
+		assert.True(t, at(20, 30).Equal(ts.Time), value)
+	}
+
+	var ts timestamp
+	require.NoError(t, ts.UnmarshalJSON([]byte(`null`)))
+	assert.True(t, ts.IsZero())
+	assert.EqualError(t, ts.UnmarshalJSON([]byte(`"04/10/2022"`)), `invalid time "04/10/2022"`)
+}
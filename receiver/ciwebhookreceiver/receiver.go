@@ -0,0 +1,268 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/ciwebhookreceiver"
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	headerGitHubSignature = "X-Hub-Signature-256"
+	githubSignaturePrefix = "sha256="
+
+	obsreportTransportHTTP = "http"
+)
+
+var (
+	errMissingHost     = errors.New("nil host")
+	errNilNextConsumer = errors.New("nil next consumer")
+	errBodyTooLarge    = errors.New("request body too large")
+
+	errMissingSignature = errors.New("missing signature")
+	errInvalidSignature = errors.New("invalid signature")
+	errInvalidToken     = errors.New("invalid token")
+)
+
+// ciReceiver receives the webhook events of GitHub and GitLab, the traces and the metrics
+// receivers of a configuration sharing it.
+type ciReceiver struct {
+	config   *Config
+	settings component.ReceiverCreateSettings
+
+	tracesConsumer  consumer.Traces
+	metricsConsumer consumer.Metrics
+	obsrecv         *obsreport.Receiver
+
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+func newReceiver(cfg *Config, set component.ReceiverCreateSettings) *ciReceiver {
+	return &ciReceiver{
+		config:   cfg,
+		settings: set,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              obsreportTransportHTTP,
+			ReceiverCreateSettings: set,
+		}),
+	}
+}
+
+func (r *ciReceiver) registerTracesConsumer(next consumer.Traces) error {
+	if next == nil {
+		return errNilNextConsumer
+	}
+	r.tracesConsumer = next
+	return nil
+}
+
+func (r *ciReceiver) registerMetricsConsumer(next consumer.Metrics) error {
+	if next == nil {
+		return errNilNextConsumer
+	}
+	r.metricsConsumer = next
+	return nil
+}
+
+// Start starts the HTTP server receiving the webhook events.
+func (r *ciReceiver) Start(_ context.Context, host component.Host) error {
+	if host == nil {
+		return errMissingHost
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(r.config.GitHub.Path, r.handleGitHub)
+	mux.HandleFunc(r.config.GitLab.Path, r.handleGitLab)
+
+	var err error
+	r.server, err = r.config.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	var listener net.Listener
+	listener, err = r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+
+		if errHTTP := r.server.Serve(listener); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *ciReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Close()
+	r.shutdownWG.Wait()
+	return err
+}
+
+func (r *ciReceiver) handleGitHub(w http.ResponseWriter, req *http.Request) {
+	body, ok := r.readEvent(w, req)
+	if !ok {
+		return
+	}
+	if r.config.GitHub.Secret != "" {
+		if err := verifyGitHubSignature(req.Header, body, r.config.GitHub.Secret); err != nil {
+			r.settings.Logger.Debug("Refusing GitHub event", zap.Error(err))
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	run, err := parseGitHubEvent(req.Header.Get(headerGitHubEvent), body)
+	r.handleRun(w, req, providerGitHub, run, err)
+}
+
+func (r *ciReceiver) handleGitLab(w http.ResponseWriter, req *http.Request) {
+	body, ok := r.readEvent(w, req)
+	if !ok {
+		return
+	}
+	if r.config.GitLab.Token != "" {
+		if subtle.ConstantTimeCompare([]byte(req.Header.Get(headerGitLabToken)), []byte(r.config.GitLab.Token)) != 1 {
+			r.settings.Logger.Debug("Refusing GitLab event", zap.Error(errInvalidToken))
+			http.Error(w, errInvalidToken.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	run, err := parseGitLabEvent(req.Header.Get(headerGitLabEvent), body)
+	r.handleRun(w, req, providerGitLab, run, err)
+}
+
+// readEvent reads the body of a webhook event, replying with an error and returning false when
+// it can't be read.
+func (r *ciReceiver) readEvent(w http.ResponseWriter, req *http.Request) ([]byte, bool) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+
+	body, err := readBody(req.Body, r.config.MaxRequestBodySize)
+	_ = req.Body.Close()
+	switch {
+	case errors.Is(err, errBodyTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return nil, false
+	case err != nil:
+		http.Error(w, "failed to read the request body", http.StatusBadRequest)
+		return nil, false
+	}
+	return body, true
+}
+
+func (r *ciReceiver) handleRun(w http.ResponseWriter, req *http.Request, provider string, run *pipelineRun, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// the events which are not converted are acknowledged, not to be retried
+	if run == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err = r.consume(req.Context(), provider, run); err != nil {
+		r.settings.Logger.Error("Failed to consume CI event", zap.String("provider", provider), zap.Error(err))
+		status := http.StatusServiceUnavailable
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, "failed to process the event", status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *ciReceiver) consume(ctx context.Context, provider string, run *pipelineRun) error {
+	var errs error
+	if r.tracesConsumer != nil {
+		td := toTraces(run)
+		obsCtx := r.obsrecv.StartTracesOp(ctx)
+		err := r.tracesConsumer.ConsumeTraces(obsCtx, td)
+		r.obsrecv.EndTracesOp(obsCtx, provider, td.SpanCount(), err)
+		errs = multierr.Append(errs, err)
+	}
+	if r.metricsConsumer != nil {
+		md := toMetrics(run)
+		obsCtx := r.obsrecv.StartMetricsOp(ctx)
+		err := r.metricsConsumer.ConsumeMetrics(obsCtx, md)
+		r.obsrecv.EndMetricsOp(obsCtx, provider, md.DataPointCount(), err)
+		errs = multierr.Append(errs, err)
+	}
+	return errs
+}
+
+// verifyGitHubSignature checks the HMAC-SHA256 of the body in the X-Hub-Signature-256 header.
+func verifyGitHubSignature(header http.Header, body []byte, secret string) error {
+	value := header.Get(headerGitHubSignature)
+	if value == "" {
+		return errMissingSignature
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(value, githubSignaturePrefix))
+	if err != nil {
+		return errInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// readBody reads the whole body, failing with errBodyTooLarge when it exceeds
+// the given size.
+func readBody(body io.Reader, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, errBodyTooLarge
+	}
+	return data, nil
+}
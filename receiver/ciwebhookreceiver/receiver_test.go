@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+type testReceiver struct {
+	*ciReceiver
+	traces  *consumertest.TracesSink
+	metrics *consumertest.MetricsSink
+}
+
+func newTestReceiver(t *testing.T, modify func(cfg *Config)) *testReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	modify(cfg)
+
+	r := &testReceiver{
+		ciReceiver: newReceiver(cfg, componenttest.NewNopReceiverCreateSettings()),
+		traces:     new(consumertest.TracesSink),
+		metrics:    new(consumertest.MetricsSink),
+	}
+	require.NoError(t, r.registerTracesConsumer(r.traces))
+	require.NoError(t, r.registerMetricsConsumer(r.metrics))
+	return r
+}
+
+func githubSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return githubSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func postGitHub(r *testReceiver, event string, body []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, r.config.GitHub.Path, strings.NewReader(string(body)))
+	req.Header.Set(headerGitHubEvent, event)
+	if signature != "" {
+		req.Header.Set(headerGitHubSignature, signature)
+	}
+	got := httptest.NewRecorder()
+	r.handleGitHub(got, req)
+	return got
+}
+
+func postGitLab(r *testReceiver, event string, body []byte, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, r.config.GitLab.Path, strings.NewReader(string(body)))
+	req.Header.Set(headerGitLabEvent, event)
+	if token != "" {
+		req.Header.Set(headerGitLabToken, token)
+	}
+	got := httptest.NewRecorder()
+	r.handleGitLab(got, req)
+	return got
+}
+
+func TestStart(t *testing.T) {
+	r := newTestReceiver(t, func(*Config) {})
+	assert.Equal(t, errMissingHost, r.Start(context.Background(), nil))
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	t.Run("WithPortTaken", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "localhost:")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, listener.Close())
+		})
+
+		r := newTestReceiver(t, func(cfg *Config) { cfg.Endpoint = listener.Addr().String() })
+		require.Error(t, r.Start(context.Background(), componenttest.NewNopHost()))
+		require.NoError(t, r.Shutdown(context.Background()))
+	})
+}
+
+func TestHandleGitHub(t *testing.T) {
+	r := newTestReceiver(t, func(cfg *Config) { cfg.GitHub.Secret = "secret" })
+	runEvent := readTestdata(t, "github_workflow_run.json")
+	jobEvent := readTestdata(t, "github_workflow_job.json")
+
+	got := postGitHub(r, githubEventWorkflowRun, runEvent, githubSignature("secret", runEvent))
+	require.Equal(t, http.StatusOK, got.Code)
+	got = postGitHub(r, githubEventWorkflowJob, jobEvent, githubSignature("secret", jobEvent))
+	require.Equal(t, http.StatusOK, got.Code)
+
+	require.Len(t, r.traces.AllTraces(), 2)
+	assert.Equal(t, 4, r.traces.SpanCount())
+	require.Len(t, r.metrics.AllMetrics(), 2)
+	assert.Equal(t, 5, r.metrics.DataPointCount())
+
+	tests := []struct {
+		name      string
+		signature string
+		expected  string
+	}{
+		{name: "missing signature", expected: errMissingSignature.Error()},
+		{name: "invalid encoding", signature: "sha256=xyz", expected: errInvalidSignature.Error()},
+		{name: "wrong secret", signature: githubSignature("other", runEvent), expected: errInvalidSignature.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := postGitHub(r, githubEventWorkflowRun, runEvent, tt.signature)
+			assert.Equal(t, http.StatusUnauthorized, got.Code)
+			assert.Equal(t, tt.expected, strings.TrimSpace(got.Body.String()))
+		})
+	}
+	assert.Len(t, r.traces.AllTraces(), 2)
+}
+
+func TestHandleGitLab(t *testing.T) {
+	r := newTestReceiver(t, func(cfg *Config) { cfg.GitLab.Token = "token" })
+	event := readTestdata(t, "gitlab_pipeline.json")
+
+	got := postGitLab(r, gitlabEventPipeline, event, "token")
+	require.Equal(t, http.StatusOK, got.Code)
+	assert.Equal(t, 2, r.traces.SpanCount())
+	assert.Equal(t, 5, r.metrics.DataPointCount())
+
+	got = postGitLab(r, gitlabEventPipeline, event, "other")
+	assert.Equal(t, http.StatusUnauthorized, got.Code)
+	got = postGitLab(r, gitlabEventPipeline, event, "")
+	assert.Equal(t, http.StatusUnauthorized, got.Code)
+	assert.Equal(t, 2, r.traces.SpanCount())
+}
+
+func TestHandleIgnoredEvents(t *testing.T) {
+	r := newTestReceiver(t, func(*Config) {})
+
+	got := postGitHub(r, "push", []byte(`{"ref": "refs/heads/main"}`), "")
+	assert.Equal(t, http.StatusOK, got.Code)
+	got = postGitHub(r, githubEventWorkflowJob, []byte(`{"action": "queued"}`), "")
+	assert.Equal(t, http.StatusOK, got.Code)
+	got = postGitLab(r, "Push Hook", []byte(`{"object_kind": "push"}`), "")
+	assert.Equal(t, http.StatusOK, got.Code)
+
+	assert.Empty(t, r.traces.AllTraces())
+	assert.Empty(t, r.metrics.AllMetrics())
+}
+
+func TestHandleInvalidRequests(t *testing.T) {
+	r := newTestReceiver(t, func(cfg *Config) { cfg.MaxRequestBodySize = 16 })
+
+	req := httptest.NewRequest(http.MethodGet, defaultGitHubPath, nil)
+	got := httptest.NewRecorder()
+	r.handleGitHub(got, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, got.Code)
+	assert.Equal(t, http.MethodPost, got.Header().Get("Allow"))
+
+	got = postGitHub(r, githubEventWorkflowRun, []byte(`{"action": "completed", "workflow_run": {}}`), "")
+	assert.Equal(t, http.StatusRequestEntityTooLarge, got.Code)
+
+	got = postGitLab(r, gitlabEventPipeline, []byte(`{"builds": {`), "")
+	assert.Equal(t, http.StatusBadRequest, got.Code)
+}
+
+func TestHandleConsumerErrors(t *testing.T) {
+	event := readTestdata(t, "gitlab_pipeline.json")
+
+	r := newTestReceiver(t, func(*Config) {})
+	r.metricsConsumer = consumertest.NewErr(errors.New("unavailable"))
+	got := postGitLab(r, gitlabEventPipeline, event, "")
+	assert.Equal(t, http.StatusServiceUnavailable, got.Code)
+	// the traces are still consumed
+	assert.Equal(t, 2, r.traces.SpanCount())
+
+	r.metricsConsumer = consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid")))
+	got = postGitLab(r, gitlabEventPipeline, event, "")
+	assert.Equal(t, http.StatusBadRequest, got.Code)
+}
+
+func TestRegisterNilConsumer(t *testing.T) {
+	r := newReceiver(createDefaultConfig().(*Config), componenttest.NewNopReceiverCreateSettings())
+	assert.Equal(t, errNilNextConsumer, r.registerTracesConsumer(nil))
+	assert.Equal(t, errNilNextConsumer, r.registerMetricsConsumer(nil))
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/ciwebhookreceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	providerGitHub = "github"
+	providerGitLab = "gitlab"
+)
+
+// pipelineRun is a run of a GitHub Actions workflow or of a GitLab CI pipeline, with the jobs
+// reported by an event.
+type pipelineRun struct {
+	provider   string
+	repository string
+	id         int64
+	// attempt is the number of the attempt of the run, 0 when the provider doesn't retry runs
+	attempt  int64
+	name     string
+	url      string
+	ref      string
+	revision string
+	trigger  string
+
+	// completed is set when the event reports the end of the run, and not only the end of some
+	// of its jobs
+	completed  bool
+	conclusion string
+	status     ptrace.StatusCode
+	start      time.Time
+	end        time.Time
+
+	jobs []job
+}
+
+// job is a job of a pipeline run, its queue duration being the time between its creation and
+// its start.
+type job struct {
+	id         int64
+	name       string
+	stage      string
+	runner     string
+	conclusion string
+	status     ptrace.StatusCode
+	created    time.Time
+	start      time.Time
+	end        time.Time
+
+	steps []step
+}
+
+type step struct {
+	number     int64
+	name       string
+	conclusion string
+	status     ptrace.StatusCode
+	start      time.Time
+	end        time.Time
+}
+
+// timestamp decodes the times of the events, either as RFC3339 strings as sent by GitHub and
+// the recent GitLab versions, or as "2006-01-02 15:04:05 UTC" strings as sent by GitLab.
+type timestamp struct {
+	time.Time
+}
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+}
+
+func (t *timestamp) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == nil || *s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, *s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid time %q", *s)
+}
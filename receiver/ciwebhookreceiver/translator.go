@@ -0,0 +1,320 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/ciwebhookreceiver"
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	scopeName = "otelcol/ciwebhookreceiver"
+
+	attributeProvider           = "ci.provider"
+	attributePipelineName       = "ci.pipeline.name"
+	attributePipelineID         = "ci.pipeline.id"
+	attributePipelineAttempt    = "ci.pipeline.attempt"
+	attributePipelineURL        = "ci.pipeline.url"
+	attributePipelineConclusion = "ci.pipeline.conclusion"
+	attributeRef                = "ci.ref"
+	attributeRevision           = "ci.revision"
+	attributeTrigger            = "ci.trigger"
+	attributeJobName            = "ci.job.name"
+	attributeJobID              = "ci.job.id"
+	attributeJobStage           = "ci.job.stage"
+	attributeJobRunner          = "ci.job.runner"
+	attributeJobConclusion      = "ci.job.conclusion"
+	attributeJobQueueDuration   = "ci.job.queue_duration"
+	attributeJobRunDuration     = "ci.job.run_duration"
+	attributeStepName           = "ci.step.name"
+	attributeStepNumber         = "ci.step.number"
+	attributeStepConclusion     = "ci.step.conclusion"
+
+	metricPipelineRuns     = "ci.pipeline.runs"
+	metricPipelineDuration = "ci.pipeline.duration"
+	metricJobRuns          = "ci.job.runs"
+	metricJobQueueDuration = "ci.job.queue.duration"
+	metricJobRunDuration   = "ci.job.run.duration"
+
+	eventJobStarted = "job started"
+)
+
+// traceKey identifies the trace of a run, the IDs of the trace and of its spans being derived
+// from it so that the jobs reported by separate events are added to the trace of their run.
+func (r *pipelineRun) traceKey() string {
+	return fmt.Sprintf("%s/%s/%d/%d", r.provider, r.repository, r.id, r.attempt)
+}
+
+func (r *pipelineRun) traceID() pcommon.TraceID {
+	var id pcommon.TraceID
+	sum := sha256.Sum256([]byte(r.traceKey()))
+	copy(id[:], sum[:])
+	return id
+}
+
+func (r *pipelineRun) spanID() pcommon.SpanID {
+	return spanID(r.traceKey() + "/run")
+}
+
+func (r *pipelineRun) jobSpanID(j *job) pcommon.SpanID {
+	return spanID(fmt.Sprintf("%s/job/%d", r.traceKey(), j.id))
+}
+
+func (r *pipelineRun) stepSpanID(j *job, s *step) pcommon.SpanID {
+	return spanID(fmt.Sprintf("%s/job/%d/step/%d", r.traceKey(), j.id, s.number))
+}
+
+func spanID(key string) pcommon.SpanID {
+	var id pcommon.SpanID
+	sum := sha256.Sum256([]byte(key))
+	copy(id[:], sum[:])
+	return id
+}
+
+func fillResource(resource pcommon.Resource, run *pipelineRun) {
+	attrs := resource.Attributes()
+	attrs.PutStr(conventions.AttributeServiceName, run.repository)
+	attrs.PutStr(attributeProvider, run.provider)
+}
+
+// toTraces converts the run into a span for the run itself, when completed, and spans for its
+// jobs and their steps.
+func toTraces(run *pipelineRun) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	fillResource(rs.Resource(), run)
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(scopeName)
+	spans := ss.Spans()
+
+	traceID := run.traceID()
+	if run.completed && !run.end.IsZero() {
+		span := spans.AppendEmpty()
+		span.SetTraceID(traceID)
+		span.SetSpanID(run.spanID())
+		span.SetName(run.name)
+		span.SetKind(ptrace.SpanKindServer)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(run.start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(run.end))
+		span.Status().SetCode(run.status)
+		attrs := span.Attributes()
+		putRunAttributes(attrs, run)
+		putNotEmpty(attrs, attributePipelineURL, run.url)
+		putNotEmpty(attrs, attributePipelineConclusion, run.conclusion)
+		putNotEmpty(attrs, attributeTrigger, run.trigger)
+	}
+
+	for _, j := range run.endedJobs() {
+		span := spans.AppendEmpty()
+		span.SetTraceID(traceID)
+		span.SetSpanID(run.jobSpanID(j))
+		span.SetParentSpanID(run.spanID())
+		span.SetName(j.name)
+		span.SetKind(ptrace.SpanKindInternal)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(j.queued()))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(j.end))
+		span.Status().SetCode(j.status)
+		attrs := span.Attributes()
+		putRunAttributes(attrs, run)
+		putJobAttributes(attrs, j)
+		attrs.PutDouble(attributeJobQueueDuration, j.queueDuration().Seconds())
+		attrs.PutDouble(attributeJobRunDuration, j.runDuration().Seconds())
+		if !j.start.IsZero() {
+			event := span.Events().AppendEmpty()
+			event.SetName(eventJobStarted)
+			event.SetTimestamp(pcommon.NewTimestampFromTime(j.start))
+		}
+
+		for k := range j.steps {
+			s := &j.steps[k]
+			stepSpan := spans.AppendEmpty()
+			stepSpan.SetTraceID(traceID)
+			stepSpan.SetSpanID(run.stepSpanID(j, s))
+			stepSpan.SetParentSpanID(span.SpanID())
+			stepSpan.SetName(s.name)
+			stepSpan.SetKind(ptrace.SpanKindInternal)
+			stepSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(s.start))
+			stepSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(s.end))
+			stepSpan.Status().SetCode(s.status)
+			stepAttrs := stepSpan.Attributes()
+			stepAttrs.PutStr(attributeStepName, s.name)
+			stepAttrs.PutInt(attributeStepNumber, s.number)
+			putNotEmpty(stepAttrs, attributeStepConclusion, s.conclusion)
+		}
+	}
+	return td
+}
+
+// toMetrics converts the run into a data point of each metric of the run, when completed, and of
+// its jobs.
+func toMetrics(run *pipelineRun) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	fillResource(rm.Resource(), run)
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+	metrics := sm.Metrics()
+
+	if run.completed && !run.end.IsZero() {
+		runs := newSum(metrics, metricPipelineRuns, "The number of completed pipeline runs.", "{runs}")
+		dp := runs.Sum().DataPoints().AppendEmpty()
+		setTimestamps(dp, run.start, run.end)
+		dp.SetIntValue(1)
+		putRunMetricAttributes(dp.Attributes(), run)
+		putNotEmpty(dp.Attributes(), attributePipelineConclusion, run.conclusion)
+
+		duration := newGauge(metrics, metricPipelineDuration, "The duration of the completed pipeline runs.", "s")
+		dp = duration.Gauge().DataPoints().AppendEmpty()
+		setTimestamps(dp, run.start, run.end)
+		dp.SetDoubleValue(run.end.Sub(run.start).Seconds())
+		putRunMetricAttributes(dp.Attributes(), run)
+		putNotEmpty(dp.Attributes(), attributePipelineConclusion, run.conclusion)
+	}
+
+	jobs := run.endedJobs()
+	if len(jobs) == 0 {
+		return md
+	}
+	jobRuns := newSum(metrics, metricJobRuns, "The number of completed jobs.", "{runs}")
+	queueDuration := newGauge(metrics, metricJobQueueDuration, "The time the completed jobs waited for a runner.", "s")
+	runDuration := newGauge(metrics, metricJobRunDuration, "The duration of the completed jobs, once started.", "s")
+	for _, j := range jobs {
+		dp := jobRuns.Sum().DataPoints().AppendEmpty()
+		setTimestamps(dp, j.queued(), j.end)
+		dp.SetIntValue(1)
+		putJobMetricAttributes(dp.Attributes(), run, j)
+
+		dp = queueDuration.Gauge().DataPoints().AppendEmpty()
+		setTimestamps(dp, j.queued(), j.end)
+		dp.SetDoubleValue(j.queueDuration().Seconds())
+		putJobMetricAttributes(dp.Attributes(), run, j)
+
+		dp = runDuration.Gauge().DataPoints().AppendEmpty()
+		setTimestamps(dp, j.queued(), j.end)
+		dp.SetDoubleValue(j.runDuration().Seconds())
+		putJobMetricAttributes(dp.Attributes(), run, j)
+	}
+	return md
+}
+
+// endedJobs returns the jobs whose end is known.
+func (r *pipelineRun) endedJobs() []*job {
+	jobs := make([]*job, 0, len(r.jobs))
+	for i := range r.jobs {
+		if !r.jobs[i].end.IsZero() {
+			jobs = append(jobs, &r.jobs[i])
+		}
+	}
+	return jobs
+}
+
+// queued returns the time the job was created, or started when its creation time is not known.
+func (j *job) queued() time.Time {
+	if j.created.IsZero() {
+		return j.started()
+	}
+	return j.created
+}
+
+// started returns the time the job started, or ended when it was cancelled before starting.
+func (j *job) started() time.Time {
+	if j.start.IsZero() {
+		return j.end
+	}
+	return j.start
+}
+
+func (j *job) queueDuration() time.Duration {
+	if d := j.started().Sub(j.queued()); d > 0 {
+		return d
+	}
+	return 0
+}
+
+func (j *job) runDuration() time.Duration {
+	if d := j.end.Sub(j.started()); d > 0 {
+		return d
+	}
+	return 0
+}
+
+func newSum(metrics pmetric.MetricSlice, name, description, unit string) pmetric.Metric {
+	metric := metrics.AppendEmpty()
+	metric.SetName(name)
+	metric.SetDescription(description)
+	metric.SetUnit(unit)
+	sum := metric.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	sum.SetIsMonotonic(true)
+	return metric
+}
+
+func newGauge(metrics pmetric.MetricSlice, name, description, unit string) pmetric.Metric {
+	metric := metrics.AppendEmpty()
+	metric.SetName(name)
+	metric.SetDescription(description)
+	metric.SetUnit(unit)
+	metric.SetEmptyGauge()
+	return metric
+}
+
+func setTimestamps(dp pmetric.NumberDataPoint, start, end time.Time) {
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(end))
+}
+
+func putRunAttributes(attrs pcommon.Map, run *pipelineRun) {
+	attrs.PutStr(attributePipelineName, run.name)
+	attrs.PutInt(attributePipelineID, run.id)
+	if run.attempt > 0 {
+		attrs.PutInt(attributePipelineAttempt, run.attempt)
+	}
+	putNotEmpty(attrs, attributeRef, run.ref)
+	putNotEmpty(attrs, attributeRevision, run.revision)
+}
+
+func putJobAttributes(attrs pcommon.Map, j *job) {
+	attrs.PutStr(attributeJobName, j.name)
+	attrs.PutInt(attributeJobID, j.id)
+	putNotEmpty(attrs, attributeJobStage, j.stage)
+	putNotEmpty(attrs, attributeJobRunner, j.runner)
+	putNotEmpty(attrs, attributeJobConclusion, j.conclusion)
+}
+
+// putRunMetricAttributes sets the attributes of the data points of a run, leaving out the
+// attributes specific to each run, such as its ID, not to create a series for each run.
+func putRunMetricAttributes(attrs pcommon.Map, run *pipelineRun) {
+	attrs.PutStr(attributePipelineName, run.name)
+	putNotEmpty(attrs, attributeRef, run.ref)
+}
+
+func putJobMetricAttributes(attrs pcommon.Map, run *pipelineRun, j *job) {
+	putRunMetricAttributes(attrs, run)
+	attrs.PutStr(attributeJobName, j.name)
+	putNotEmpty(attrs, attributeJobStage, j.stage)
+	putNotEmpty(attrs, attributeJobConclusion, j.conclusion)
+}
+
+func putNotEmpty(attrs pcommon.Map, key, value string) {
+	if value != "" {
+		attrs.PutStr(key, value)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ciwebhookreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func parseTestdata(t *testing.T, provider, event, name string) *pipelineRun {
+	parse := parseGitHubEvent
+	if provider == providerGitLab {
+		parse = parseGitLabEvent
+	}
+	run, err := parse(event, readTestdata(t, name))
+	require.NoError(t, err)
+	require.NotNil(t, run)
+	return run
+}
+
+func TestToTracesGitHub(t *testing.T) {
+	run := parseTestdata(t, providerGitHub, githubEventWorkflowRun, "github_workflow_run.json")
+	runTraces := toTraces(run)
+	require.Equal(t, 1, runTraces.SpanCount())
+	assert.Equal(t, map[string]interface{}{
+		"service.name": "octo/hello",
+		"ci.provider":  "github",
+	}, runTraces.ResourceSpans().At(0).Resource().Attributes().AsRaw())
+
+	runSpan := runTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "CI", runSpan.Name())
+	assert.Equal(t, ptrace.SpanKindServer, runSpan.Kind())
+	assert.True(t, runSpan.ParentSpanID().IsEmpty())
+	assert.Equal(t, at(20, 5), runSpan.StartTimestamp().AsTime())
+	assert.Equal(t, at(25, 0), runSpan.EndTimestamp().AsTime())
+	assert.Equal(t, ptrace.StatusCodeError, runSpan.Status().Code())
+	assert.Equal(t, map[string]interface{}{
+		"ci.pipeline.name":       "CI",
+		"ci.pipeline.id":         int64(3216549870),
+		"ci.pipeline.attempt":    int64(2),
+		"ci.pipeline.url":        "https://github.com/octo/hello/actions/runs/3216549870",
+		"ci.pipeline.conclusion": "failure",
+		"ci.ref":                 "main",
+		"ci.revision":            "c0ffee1234567890c0ffee1234567890c0ffee12",
+		"ci.trigger":             "push",
+	}, runSpan.Attributes().AsRaw())
+
+	// the job, received in a separate event, is added to the trace of its run
+	jobTraces := toTraces(parseTestdata(t, providerGitHub, githubEventWorkflowJob, "github_workflow_job.json"))
+	require.Equal(t, 3, jobTraces.SpanCount())
+	spans := jobTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+
+	jobSpan := spans.At(0)
+	assert.Equal(t, runSpan.TraceID(), jobSpan.TraceID())
+	assert.Equal(t, runSpan.SpanID(), jobSpan.ParentSpanID())
+	assert.Equal(t, "test", jobSpan.Name())
+	assert.Equal(t, at(20, 5), jobSpan.StartTimestamp().AsTime())
+	assert.Equal(t, at(24, 35), jobSpan.EndTimestamp().AsTime())
+	assert.Equal(t, ptrace.StatusCodeError, jobSpan.Status().Code())
+	assert.Equal(t, map[string]interface{}{
+		"ci.pipeline.name":      "CI",
+		"ci.pipeline.id":        int64(3216549870),
+		"ci.pipeline.attempt":   int64(2),
+		"ci.ref":                "main",
+		"ci.revision":           "c0ffee1234567890c0ffee1234567890c0ffee12",
+		"ci.job.name":           "test",
+		"ci.job.id":             int64(8765432101),
+		"ci.job.runner":         "GitHub Actions 2",
+		"ci.job.conclusion":     "failure",
+		"ci.job.queue_duration": 30.0,
+		"ci.job.run_duration":   240.0,
+	}, jobSpan.Attributes().AsRaw())
+	require.Equal(t, 1, jobSpan.Events().Len())
+	assert.Equal(t, eventJobStarted, jobSpan.Events().At(0).Name())
+	assert.Equal(t, at(20, 35), jobSpan.Events().At(0).Timestamp().AsTime())
+
+	stepSpan := spans.At(2)
+	assert.Equal(t, runSpan.TraceID(), stepSpan.TraceID())
+	assert.Equal(t, jobSpan.SpanID(), stepSpan.ParentSpanID())
+	assert.NotEqual(t, spans.At(1).SpanID(), stepSpan.SpanID())
+	assert.Equal(t, "Run tests", stepSpan.Name())
+	assert.Equal(t, at(20, 40), stepSpan.StartTimestamp().AsTime())
+	assert.Equal(t, at(24, 30), stepSpan.EndTimestamp().AsTime())
+	assert.Equal(t, ptrace.StatusCodeError, stepSpan.Status().Code())
+	assert.Equal(t, map[string]interface{}{
+		"ci.step.name":       "Run tests",
+		"ci.step.number":     int64(2),
+		"ci.step.conclusion": "failure",
+	}, stepSpan.Attributes().AsRaw())
+}
+
+func TestToTracesAttempts(t *testing.T) {
+	run := parseTestdata(t, providerGitHub, githubEventWorkflowRun, "github_workflow_run.json")
+	retried := *run
+	retried.attempt++
+	assert.NotEqual(t, run.traceID(), retried.traceID())
+
+	other := *run
+	other.repository = "octo/other"
+	assert.NotEqual(t, run.traceID(), other.traceID())
+}
+
+func TestToTracesGitLab(t *testing.T) {
+	td := toTraces(parseTestdata(t, providerGitLab, gitlabEventPipeline, "gitlab_pipeline.json"))
+	require.Equal(t, 2, td.SpanCount())
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+
+	pipelineSpan := spans.At(0)
+	assert.Equal(t, "Nightly", pipelineSpan.Name())
+	assert.Equal(t, at(20, 0), pipelineSpan.StartTimestamp().AsTime())
+	assert.Equal(t, at(23, 30), pipelineSpan.EndTimestamp().AsTime())
+	assert.Equal(t, ptrace.StatusCodeOk, pipelineSpan.Status().Code())
+	_, ok := pipelineSpan.Attributes().Get(attributePipelineAttempt)
+	assert.False(t, ok)
+
+	jobSpan := spans.At(1)
+	assert.Equal(t, pipelineSpan.TraceID(), jobSpan.TraceID())
+	assert.Equal(t, pipelineSpan.SpanID(), jobSpan.ParentSpanID())
+	assert.Equal(t, "unit", jobSpan.Name())
+	stage, _ := jobSpan.Attributes().Get(attributeJobStage)
+	assert.Equal(t, "test", stage.Str())
+	queue, _ := jobSpan.Attributes().Get(attributeJobQueueDuration)
+	assert.Equal(t, 30.0, queue.Double())
+	duration, _ := jobSpan.Attributes().Get(attributeJobRunDuration)
+	assert.Equal(t, 90.0, duration.Double())
+}
+
+func TestToTracesJobNotStarted(t *testing.T) {
+	run := &pipelineRun{
+		provider: providerGitHub,
+		id:       1,
+		jobs: []job{
+			{id: 1, name: "cancelled", created: at(20, 0), end: at(21, 0)},
+			{id: 2, name: "unknown end", created: at(20, 0), start: at(20, 10)},
+		},
+	}
+	td := toTraces(run)
+	require.Equal(t, 1, td.SpanCount())
+	span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "cancelled", span.Name())
+	assert.Equal(t, 0, span.Events().Len())
+	queue, _ := span.Attributes().Get(attributeJobQueueDuration)
+	assert.Equal(t, 60.0, queue.Double())
+	duration, _ := span.Attributes().Get(attributeJobRunDuration)
+	assert.Equal(t, 0.0, duration.Double())
+}
+
+func TestToMetrics(t *testing.T) {
+	md := toMetrics(parseTestdata(t, providerGitLab, gitlabEventPipeline, "gitlab_pipeline.json"))
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+
+	type point struct {
+		value      float64
+		start, end time.Time
+		attributes map[string]interface{}
+	}
+	runAttributes := map[string]interface{}{
+		"ci.pipeline.name":       "Nightly",
+		"ci.ref":                 "master",
+		"ci.pipeline.conclusion": "success",
+	}
+	jobAttributes := map[string]interface{}{
+		"ci.pipeline.name":  "Nightly",
+		"ci.ref":            "master",
+		"ci.job.name":       "unit",
+		"ci.job.stage":      "test",
+		"ci.job.conclusion": "success",
+	}
+	expected := map[string]point{
+		metricPipelineRuns:     {value: 1, start: at(20, 0), end: at(23, 30), attributes: runAttributes},
+		metricPipelineDuration: {value: 210, start: at(20, 0), end: at(23, 30), attributes: runAttributes},
+		metricJobRuns:          {value: 1, start: at(20, 0), end: at(22, 0), attributes: jobAttributes},
+		metricJobQueueDuration: {value: 30, start: at(20, 0), end: at(22, 0), attributes: jobAttributes},
+		metricJobRunDuration:   {value: 90, start: at(20, 0), end: at(22, 0), attributes: jobAttributes},
+	}
+
+	require.Equal(t, len(expected), metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		want, ok := expected[metric.Name()]
+		require.True(t, ok, metric.Name())
+
+		var dps pmetric.NumberDataPointSlice
+		if metric.Type() == pmetric.MetricTypeSum {
+			assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, metric.Sum().AggregationTemporality())
+			assert.True(t, metric.Sum().IsMonotonic())
+			dps = metric.Sum().DataPoints()
+		} else {
+			dps = metric.Gauge().DataPoints()
+		}
+		require.Equal(t, 1, dps.Len(), metric.Name())
+		dp := dps.At(0)
+
+		value := dp.DoubleValue()
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			value = float64(dp.IntValue())
+		}
+		assert.Equal(t, want, point{
+			value:      value,
+			start:      dp.StartTimestamp().AsTime(),
+			end:        dp.Timestamp().AsTime(),
+			attributes: dp.Attributes().AsRaw(),
+		}, metric.Name())
+	}
+}
+
+func TestToMetricsJobsOnly(t *testing.T) {
+	md := toMetrics(parseTestdata(t, providerGitHub, githubEventWorkflowJob, "github_workflow_job.json"))
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	names := make([]string, 0, metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	assert.Equal(t, []string{metricJobRuns, metricJobQueueDuration, metricJobRunDuration}, names)
+
+	md = toMetrics(&pipelineRun{provider: providerGitHub, jobs: []job{{id: 1, start: at(20, 0)}}})
+	assert.Equal(t, 0, md.DataPointCount())
+}
+
+func TestSpanIDs(t *testing.T) {
+	run := &pipelineRun{provider: providerGitHub, repository: "octo/hello", id: 1, attempt: 1}
+	j := &job{id: 1}
+	ids := map[pcommon.SpanID]bool{
+		run.spanID():                        true,
+		run.jobSpanID(j):                    true,
+		run.stepSpanID(j, &step{number: 1}): true,
+		run.stepSpanID(j, &step{number: 2}): true,
+		run.jobSpanID(&job{id: 2}):          true,
+	}
+	assert.Len(t, ids, 5)
+	assert.Equal(t, run.traceID(), (&pipelineRun{provider: providerGitHub, repository: "octo/hello", id: 1, attempt: 1}).traceID())
+}
@@ -47,6 +47,9 @@ type collectDRecord struct {
 	Message        *string                `json:"message"`
 	Meta           map[string]interface{} `json:"meta"`
 	Severity       *string                `json:"severity"`
+
+	// unit of the values, from the types.db
+	unit string
 }
 
 func (r *collectDRecord) isEvent() bool {
@@ -115,6 +118,7 @@ func (r *collectDRecord) newMetric(name string, dsType *string, val *json.Number
 	metricType := r.metricType(dsType, isDouble)
 	metric.MetricDescriptor = &metricspb.MetricDescriptor{
 		Name:      name,
+		Unit:      r.unit,
 		Type:      metricType,
 		LabelKeys: lKeys,
 	}
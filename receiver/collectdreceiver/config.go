@@ -29,4 +29,11 @@ type Config struct {
 	Timeout          time.Duration `mapstructure:"timeout"`
 	AttributesPrefix string        `mapstructure:"attributes_prefix"`
 	Encoding         string        `mapstructure:"encoding"`
+
+	// TypesDB are the types.db files defining the names and the types of the values of the collectd types,
+	// for the records which don't have them. The metrics of the records get the units of their types too.
+	TypesDB []string `mapstructure:"types_db"`
+	// Units are the units of the collectd types, overriding the built-in units of the standard types, used
+	// with TypesDB.
+	Units map[string]string `mapstructure:"units"`
 }
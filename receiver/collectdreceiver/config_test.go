@@ -49,6 +49,19 @@ func TestLoadConfig(t *testing.T) {
 				Encoding:         "command",
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "typesdb"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				TCPAddr: confignet.TCPAddr{
+					Endpoint: defaultBindEndpoint,
+				},
+				Timeout:  defaultTimeout,
+				Encoding: defaultEncodingFormat,
+				TypesDB:  []string{"/usr/share/collectd/types.db"},
+				Units:    map[string]string{"load": "{processes}"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -70,5 +70,12 @@ func createMetricsReceiver(
 			c.Encoding,
 		)
 	}
-	return newCollectdReceiver(params.Logger, c.Endpoint, c.Timeout, c.AttributesPrefix, nextConsumer)
+	var db *typesDB
+	if len(c.TypesDB) > 0 {
+		var err error
+		if db, err = loadTypesDB(c.TypesDB, c.Units); err != nil {
+			return nil, err
+		}
+	}
+	return newCollectdReceiver(params.Logger, c.Endpoint, c.Timeout, c.AttributesPrefix, db, nextConsumer)
 }
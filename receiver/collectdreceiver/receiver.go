@@ -41,6 +41,7 @@ type collectdReceiver struct {
 	server             *http.Server
 	defaultAttrsPrefix string
 	nextConsumer       consumer.Metrics
+	typesDB            *typesDB
 }
 
 // newCollectdReceiver creates the CollectD receiver with the given parameters.
@@ -49,6 +50,7 @@ func newCollectdReceiver(
 	addr string,
 	timeout time.Duration,
 	defaultAttrsPrefix string,
+	typesDB *typesDB,
 	nextConsumer consumer.Metrics) (component.MetricsReceiver, error) {
 	if nextConsumer == nil {
 		return nil, component.ErrNilNextConsumer
@@ -59,6 +61,7 @@ func newCollectdReceiver(
 		addr:               addr,
 		nextConsumer:       nextConsumer,
 		defaultAttrsPrefix: defaultAttrsPrefix,
+		typesDB:            typesDB,
 	}
 	r.server = &http.Server{
 		Addr:         addr,
@@ -112,6 +115,9 @@ func (cdr *collectdReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var metrics []*metricspb.Metric
 	ctx := context.Background()
 	for _, record := range records {
+		if cdr.typesDB != nil {
+			cdr.typesDB.complete(&record)
+		}
 		metrics, err = record.appendToMetrics(metrics, defaultAttrs)
 		if err != nil {
 			cdr.handleHTTPErr(w, err, "unable to process metrics")
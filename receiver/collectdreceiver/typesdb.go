@@ -0,0 +1,159 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/collectdreceiver"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// defaultUnits are the units of the values of the types of the types.db file distributed with collectd.
+var defaultUnits = map[string]string{
+	"bytes":          "By",
+	"cache_size":     "By",
+	"current":        "A",
+	"delay":          "s",
+	"df_complex":     "By",
+	"disk_io_time":   "ms",
+	"disk_octets":    "By",
+	"disk_time":      "ms",
+	"duration":       "s",
+	"file_size":      "By",
+	"frequency":      "Hz",
+	"if_octets":      "By",
+	"io_octets":      "By",
+	"latency":        "s",
+	"memory":         "By",
+	"percent":        "%",
+	"percent_bytes":  "%",
+	"ping":           "ms",
+	"ping_stddev":    "ms",
+	"power":          "W",
+	"ps_disk_octets": "By",
+	"ps_rss":         "By",
+	"ps_vm":          "By",
+	"response_time":  "s",
+	"swap":           "By",
+	"temperature":    "Cel",
+	"total_bytes":    "By",
+	"uptime":         "s",
+	"voltage":        "V",
+}
+
+// dataSource is a value of a collectd type, as defined in a types.db file.
+type dataSource struct {
+	name   string
+	dsType string
+}
+
+// typesDB holds the values and the units of the collectd types.
+type typesDB struct {
+	types map[string][]dataSource
+	units map[string]string
+}
+
+// loadTypesDB loads the types.db files, the units of the types being the default ones, overridden by the
+// given units.
+func loadTypesDB(paths []string, units map[string]string) (*typesDB, error) {
+	db := &typesDB{
+		types: map[string][]dataSource{},
+		units: make(map[string]string, len(defaultUnits)+len(units)),
+	}
+	for k, v := range defaultUnits {
+		db.units[k] = v
+	}
+	for k, v := range units {
+		db.units[k] = v
+	}
+
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load types.db: %w", err)
+		}
+		err = parseTypesDB(f, db.types)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load types.db %s: %w", path, err)
+		}
+	}
+	return db, nil
+}
+
+// parseTypesDB parses the lines of a types.db file, such as:
+//
+//	if_octets  rx:DERIVE:0:U, tx:DERIVE:0:U
+//
+// See https://collectd.org/documentation/manpages/types.db.5.shtml.
+func parseTypesDB(r io.Reader, types map[string][]dataSource) error {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) < 2 {
+			return fmt.Errorf("line %d: missing data sources of type %q", line, fields[0])
+		}
+
+		var dataSources []dataSource
+		for _, spec := range strings.Split(strings.Join(fields[1:], " "), ",") {
+			parts := strings.Split(strings.TrimSpace(spec), ":")
+			if len(parts) != 4 || parts[0] == "" {
+				return fmt.Errorf("line %d: invalid data source %q", line, strings.TrimSpace(spec))
+			}
+			dsType := strings.ToLower(parts[1])
+			switch dsType {
+			case collectDMetricGauge, collectDMetricDerive, collectDMetricCounter, collectDMetricAbsolute:
+			default:
+				return fmt.Errorf("line %d: invalid data source type %q", line, parts[1])
+			}
+			dataSources = append(dataSources, dataSource{name: parts[0], dsType: dsType})
+		}
+		types[fields[0]] = dataSources
+	}
+	return scanner.Err()
+}
+
+// complete sets the names and the types of the values of the record from the types.db, when the record
+// doesn't have them, and the unit of its type.
+func (db *typesDB) complete(r *collectDRecord) {
+	if isNilOrEmpty(r.TypeS) {
+		return
+	}
+	r.unit = db.units[*r.TypeS]
+
+	dataSources, ok := db.types[*r.TypeS]
+	if !ok || len(dataSources) != len(r.Values) {
+		return
+	}
+	if len(r.Dsnames) == 0 {
+		r.Dsnames = make([]*string, len(dataSources))
+		for i := range dataSources {
+			r.Dsnames[i] = &dataSources[i].name
+		}
+	}
+	if len(r.Dstypes) == 0 {
+		r.Dstypes = make([]*string, len(dataSources))
+		for i := range dataSources {
+			r.Dstypes[i] = &dataSources[i].dsType
+		}
+	}
+}
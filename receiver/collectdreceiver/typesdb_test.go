@@ -0,0 +1,102 @@
+// Copyright 2019, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collectdreceiver
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTypesDB(t *testing.T) {
+	db, err := loadTypesDB([]string{filepath.Join("testdata", "types.db")}, map[string]string{"load": "{processes}", "memory": "KiBy"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []dataSource{
+		{name: "rx", dsType: "derive"},
+		{name: "tx", dsType: "derive"},
+	}, db.types["if_octets"])
+	assert.Equal(t, []dataSource{{name: "value", dsType: "absolute"}}, db.types["absolute"])
+	assert.Len(t, db.types, 5)
+
+	assert.Equal(t, "By", db.units["if_octets"])
+	assert.Equal(t, "{processes}", db.units["load"])
+	assert.Equal(t, "KiBy", db.units["memory"])
+
+	_, err = loadTypesDB([]string{filepath.Join("testdata", "invalid_types.db")}, nil)
+	assert.EqualError(t, err, `failed to load types.db testdata/invalid_types.db: line 1: invalid data source type "RATE"`)
+
+	_, err = loadTypesDB([]string{filepath.Join("testdata", "missing_types.db")}, nil)
+	assert.ErrorContains(t, err, "failed to load types.db: open testdata/missing_types.db")
+}
+
+func TestTypesDBComplete(t *testing.T) {
+	db, err := loadTypesDB([]string{filepath.Join("testdata", "types.db")}, nil)
+	require.NoError(t, err)
+
+	var records []collectDRecord
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{
+			"host": "i-b13d1e5f",
+			"interval": 10.0,
+			"plugin": "interface",
+			"plugin_instance": "eth0",
+			"time": 1415062577.4949999,
+			"type": "if_octets",
+			"values": [1024, 2048]
+		},
+		{
+			"dsnames": ["used"],
+			"dstypes": ["gauge"],
+			"host": "i-b13d1e5f",
+			"interval": 10.0,
+			"plugin": "memory",
+			"plugin_instance": "",
+			"time": 1415062577.4949999,
+			"type": "memory",
+			"values": [1.5]
+		},
+		{
+			"host": "i-b13d1e5f",
+			"interval": 10.0,
+			"plugin": "load",
+			"plugin_instance": "",
+			"time": 1415062577.4949999,
+			"type": "load",
+			"values": [0.5]
+		}
+	]`), &records))
+
+	var metrics []*metricspb.Metric
+	for _, record := range records {
+		db.complete(&record)
+		metrics, err = record.appendToMetrics(metrics, map[string]string{})
+		require.NoError(t, err)
+	}
+
+	// the values of the records without names are named after the types.db, unless their number doesn't match
+	require.Len(t, metrics, 3)
+	assert.Equal(t, "if_octets.rx", metrics[0].MetricDescriptor.Name)
+	assert.Equal(t, "By", metrics[0].MetricDescriptor.Unit)
+	assert.Equal(t, metricspb.MetricDescriptor_CUMULATIVE_INT64, metrics[0].MetricDescriptor.Type)
+	assert.Equal(t, "if_octets.tx", metrics[1].MetricDescriptor.Name)
+	assert.Equal(t, "memory", metrics[2].MetricDescriptor.Name)
+	assert.Equal(t, "By", metrics[2].MetricDescriptor.Unit)
+	assert.Equal(t, metricspb.MetricDescriptor_GAUGE_DOUBLE, metrics[2].MetricDescriptor.Type)
+}
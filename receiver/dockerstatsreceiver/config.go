@@ -56,7 +56,7 @@ type Config struct {
 	// Whether to report all CPU metrics.  Default is false
 	ProvidePerCoreCPUMetrics bool `mapstructure:"provide_per_core_cpu_metrics"`
 
-	// Docker client API version. Default is 1.22
+	// Docker client API version. By default, the API version is negotiated with the daemon.
 	DockerAPIVersion float64 `mapstructure:"api_version"`
 
 	// Metrics config. Enable or disable stats by name.
@@ -70,7 +70,7 @@ func (config Config) Validate() error {
 	if config.CollectionInterval == 0 {
 		return errors.New("collection_interval must be a positive duration")
 	}
-	if config.DockerAPIVersion < minimalRequiredDockerAPIVersion {
+	if config.DockerAPIVersion != 0 && config.DockerAPIVersion < minimalRequiredDockerAPIVersion {
 		return fmt.Errorf("api_version must be at least %v", minimalRequiredDockerAPIVersion)
 	}
 	return nil
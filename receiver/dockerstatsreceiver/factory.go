@@ -55,7 +55,6 @@ func createDefaultConfig() config.Receiver {
 		ScraperControllerSettings: scs,
 		Endpoint:                  "unix:///var/run/docker.sock",
 		Timeout:                   5 * time.Second,
-		DockerAPIVersion:          defaultDockerAPIVersion,
 		MetricsConfig:             metadata.DefaultMetricsSettings(),
 	}
 }
@@ -30,7 +30,6 @@ import (
 )
 
 const (
-	defaultDockerAPIVersion         = 1.22
 	minimalRequiredDockerAPIVersion = 1.22
 )
 
@@ -63,6 +62,10 @@ func (r *receiver) start(ctx context.Context, _ component.Host) error {
 		return err
 	}
 
+	if err = r.client.NegotiateAPIVersion(ctx); err != nil {
+		return err
+	}
+
 	if err = r.client.LoadContainerList(ctx); err != nil {
 		return err
 	}
@@ -114,8 +114,7 @@ func TestNewReceiver(t *testing.T) {
 		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
 			CollectionInterval: 1 * time.Second,
 		},
-		Endpoint:         "unix:///run/some.sock",
-		DockerAPIVersion: defaultDockerAPIVersion,
+		Endpoint: "unix:///run/some.sock",
 	}
 	mr := newReceiver(componenttest.NewNopReceiverCreateSettings(), cfg)
 	assert.NotNil(t, mr)
@@ -127,8 +126,7 @@ func TestErrorsInStart(t *testing.T) {
 		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
 			CollectionInterval: 1 * time.Second,
 		},
-		Endpoint:         unreachable,
-		DockerAPIVersion: defaultDockerAPIVersion,
+		Endpoint: unreachable,
 	}
 	recv := newReceiver(componenttest.NewNopReceiverCreateSettings(), cfg)
 	assert.NotNil(t, recv)
@@ -144,6 +142,20 @@ func TestErrorsInStart(t *testing.T) {
 	assert.Contains(t, err.Error(), "context deadline exceeded")
 }
 
+func TestStartWithUnsupportedAPIVersion(t *testing.T) {
+	engine := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Api-Version", "1.21")
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer engine.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = engine.URL
+	recv := newReceiver(componenttest.NewNopReceiverCreateSettings(), cfg)
+	err := recv.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "Docker API version 1.21 is not supported, must be at least 1.22")
+}
+
 func TestScrapeV2(t *testing.T) {
 	containerIDs := []string{
 		"10b703fb312b25e8368ab5a3bce3a1610d1cee5d71a94920f1a7adbc5b0cb326",
@@ -226,6 +238,11 @@ func dockerMockServer(urlToFile *map[string]string) (*httptest.Server, error) {
 	}
 
 	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/_ping" {
+			rw.Header().Set("Api-Version", "1.22")
+			rw.WriteHeader(http.StatusOK)
+			return
+		}
 		data, ok := urlToFileContents[req.URL.Path]
 		if !ok {
 			rw.WriteHeader(http.StatusNotFound)
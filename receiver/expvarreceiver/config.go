@@ -15,8 +15,10 @@
 package expvarreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/expvarreceiver"
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -25,10 +27,43 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/expvarreceiver/internal/metadata"
 )
 
+const (
+	customMetricTypeGauge = "gauge"
+	customMetricTypeSum   = "sum"
+
+	customMetricValueTypeInt    = "int"
+	customMetricValueTypeDouble = "double"
+)
+
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	confighttp.HTTPClientSettings           `mapstructure:",squash"`
 	MetricsConfig                           metadata.MetricsSettings `mapstructure:"metrics"`
+	// CustomMetrics maps values of the expvar JSON document, other than memstats, to metrics.
+	CustomMetrics []CustomMetricConfig `mapstructure:"custom_metrics"`
+}
+
+// CustomMetricConfig maps a value of the expvar JSON document to a metric.
+type CustomMetricConfig struct {
+	// Name of the metric.
+	Name string `mapstructure:"name"`
+	// Path of the value in the JSON document, as dot separated keys, e.g. "myapp.requests".
+	// If the value is a JSON object, e.g. an expvar.Map, a data point is recorded for each
+	// of its numeric entries, with the entry key in the attribute set by Attribute.
+	Path string `mapstructure:"path"`
+	// Type of the metric, either "gauge" or "sum".
+	Type string `mapstructure:"type"`
+	// Monotonic sets whether a sum metric is monotonic.
+	Monotonic bool `mapstructure:"monotonic"`
+	// ValueType of the data points, either "int" or "double". Default is "double".
+	ValueType string `mapstructure:"value_type"`
+	// Unit of the metric.
+	Unit string `mapstructure:"unit"`
+	// Description of the metric.
+	Description string `mapstructure:"description"`
+	// Attribute is the data point attribute set to the entry key when the value is
+	// a JSON object. Default is "key".
+	Attribute string `mapstructure:"attribute"`
 }
 
 var _ config.Receiver = (*Config)(nil)
@@ -44,5 +79,43 @@ func (c *Config) Validate() error {
 	if u.Host == "" {
 		return fmt.Errorf("host not found in HTTP endpoint")
 	}
+	names := make(map[string]bool, len(c.CustomMetrics))
+	for i, cm := range c.CustomMetrics {
+		if err := cm.validate(); err != nil {
+			return fmt.Errorf("custom_metrics[%d]: %w", i, err)
+		}
+		if names[cm.Name] {
+			return fmt.Errorf("custom_metrics[%d]: duplicate metric name '%s'", i, cm.Name)
+		}
+		names[cm.Name] = true
+	}
+	return nil
+}
+
+func (cm *CustomMetricConfig) validate() error {
+	if cm.Name == "" {
+		return errors.New("name must be specified")
+	}
+	if cm.Path == "" {
+		return errors.New("path must be specified")
+	}
+	for _, key := range strings.Split(cm.Path, ".") {
+		if key == "" {
+			return fmt.Errorf("path '%s' has an empty key", cm.Path)
+		}
+	}
+	switch cm.Type {
+	case customMetricTypeGauge, customMetricTypeSum:
+	default:
+		return fmt.Errorf("type must be '%s' or '%s', but was '%s'", customMetricTypeGauge, customMetricTypeSum, cm.Type)
+	}
+	switch cm.ValueType {
+	case "", customMetricValueTypeInt, customMetricValueTypeDouble:
+	default:
+		return fmt.Errorf("value_type must be '%s' or '%s', but was '%s'", customMetricValueTypeInt, customMetricValueTypeDouble, cm.ValueType)
+	}
+	if cm.Monotonic && cm.Type != customMetricTypeSum {
+		return errors.New("monotonic can only be set on sum metrics")
+	}
 	return nil
 }
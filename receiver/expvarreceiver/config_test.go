@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -60,6 +61,50 @@ func TestLoadConfig(t *testing.T) {
 				MetricsConfig: metricCfg,
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "custom_metrics"),
+			expected: func() config.Receiver {
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.CustomMetrics = []CustomMetricConfig{
+					{
+						Name:        "myapp.requests",
+						Path:        "myapp.requests",
+						Type:        customMetricTypeSum,
+						Monotonic:   true,
+						ValueType:   customMetricValueTypeInt,
+						Unit:        "{requests}",
+						Description: "Number of requests by method.",
+						Attribute:   "method",
+					},
+					{
+						Name: "myapp.goroutines",
+						Path: "goroutines",
+						Type: customMetricTypeGauge,
+					},
+				}
+				return cfg
+			}(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "auth"),
+			expected: func() config.Receiver {
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Auth = &configauth.Authentication{AuthenticatorID: config.NewComponentID("oauth2client")}
+				return cfg
+			}(),
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "bad_custom_metric_type"),
+			errorMessage: "custom_metrics[0]: type must be 'gauge' or 'sum', but was 'histogram'",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "bad_custom_metric_path"),
+			errorMessage: "custom_metrics[0]: path 'myapp..requests' has an empty key",
+		},
+		{
+			id:           config.NewComponentIDWithName(typeStr, "bad_custom_metric_duplicate"),
+			errorMessage: "custom_metrics[1]: duplicate metric name 'myapp.requests'",
+		},
 		{
 			id:           config.NewComponentIDWithName(typeStr, "bad_schemeless_endpoint"),
 			errorMessage: "scheme must be 'http' or 'https', but was 'localhost'",
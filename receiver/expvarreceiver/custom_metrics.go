@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvarreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/expvarreceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+const defaultCustomMetricAttribute = "key"
+
+// decodeDocument decodes the expvar JSON document, keeping numbers as json.Number
+// to avoid losing the precision of large integers.
+func decodeDocument(body io.Reader) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// lookupPath returns the value found at the dot separated path of the document.
+func lookupPath(doc map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = doc
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// recordCustomMetrics appends the configured custom metrics to the metrics slice. The
+// returned error lists the custom metrics that could not be recorded.
+func recordCustomMetrics(metrics pmetric.MetricSlice, customMetrics []CustomMetricConfig, doc map[string]interface{}, start, now pcommon.Timestamp) error {
+	var errs error
+	for _, cm := range customMetrics {
+		value, ok := lookupPath(doc, cm.Path)
+		if !ok {
+			errs = multierr.Append(errs, fmt.Errorf("custom metric '%s': path '%s' not found", cm.Name, cm.Path))
+			continue
+		}
+
+		m := pmetric.NewMetric()
+		m.SetName(cm.Name)
+		m.SetUnit(cm.Unit)
+		m.SetDescription(cm.Description)
+		var dps pmetric.NumberDataPointSlice
+		if cm.Type == customMetricTypeSum {
+			sum := m.SetEmptySum()
+			sum.SetIsMonotonic(cm.Monotonic)
+			sum.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+			dps = sum.DataPoints()
+		} else {
+			dps = m.SetEmptyGauge().DataPoints()
+		}
+
+		if object, isObject := value.(map[string]interface{}); isObject {
+			attribute := cm.Attribute
+			if attribute == "" {
+				attribute = defaultCustomMetricAttribute
+			}
+			keys := make([]string, 0, len(object))
+			for key := range object {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				// Entries that aren't numbers, e.g. nested objects, are skipped.
+				if dp, recorded := recordCustomDataPoint(dps, cm.ValueType, object[key], start, now); recorded {
+					dp.Attributes().PutStr(attribute, key)
+				}
+			}
+		} else if _, recorded := recordCustomDataPoint(dps, cm.ValueType, value, start, now); !recorded {
+			errs = multierr.Append(errs, fmt.Errorf("custom metric '%s': value at path '%s' is not a number", cm.Name, cm.Path))
+			continue
+		}
+
+		if dps.Len() > 0 {
+			m.MoveTo(metrics.AppendEmpty())
+		}
+	}
+	return errs
+}
+
+// recordCustomDataPoint appends a data point set to the value, if it is a number or a boolean.
+func recordCustomDataPoint(dps pmetric.NumberDataPointSlice, valueType string, value interface{}, start, now pcommon.Timestamp) (pmetric.NumberDataPoint, bool) {
+	var f float64
+	var i int64
+	switch v := value.(type) {
+	case json.Number:
+		var err error
+		if f, err = v.Float64(); err != nil {
+			return pmetric.NumberDataPoint{}, false
+		}
+		if i, err = v.Int64(); err != nil {
+			i = int64(f)
+		}
+	case bool:
+		if v {
+			f, i = 1, 1
+		}
+	default:
+		return pmetric.NumberDataPoint{}, false
+	}
+
+	dp := dps.AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(now)
+	if valueType == customMetricValueTypeInt {
+		dp.SetIntValue(i)
+	} else {
+		dp.SetDoubleValue(f)
+	}
+	return dp, true
+}
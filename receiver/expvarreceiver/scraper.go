@@ -15,6 +15,7 @@
 package expvarreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/expvarreceiver"
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -26,6 +27,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/expvarreceiver/internal/metadata"
 )
@@ -36,10 +39,11 @@ type expVar struct {
 }
 
 type expVarScraper struct {
-	cfg    *Config
-	set    *component.ReceiverCreateSettings
-	client *http.Client
-	mb     *metadata.MetricsBuilder
+	cfg       *Config
+	set       *component.ReceiverCreateSettings
+	client    *http.Client
+	mb        *metadata.MetricsBuilder
+	startTime pcommon.Timestamp
 }
 
 func newExpVarScraper(cfg *Config, set component.ReceiverCreateSettings) *expVarScraper {
@@ -56,6 +60,7 @@ func (e *expVarScraper) start(_ context.Context, host component.Host) error {
 		return err
 	}
 	e.client = client
+	e.startTime = pcommon.NewTimestampFromTime(time.Now())
 	return nil
 }
 
@@ -74,7 +79,11 @@ func (e *expVarScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 		return emptyMetrics, fmt.Errorf("expected 200 but received %d status code", resp.StatusCode)
 	}
 
-	result, err := decodeResponseBody(resp.Body)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return emptyMetrics, err
+	}
+	result, err := decodeResponseBody(bytes.NewReader(body))
 	if err != nil {
 		return emptyMetrics, fmt.Errorf("could not decode response body to JSON: %w", err)
 	}
@@ -114,10 +123,34 @@ func (e *expVarScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 	// The most recent pause is at PauseNs[(NumGC+255)%256].
 	e.mb.RecordProcessRuntimeMemstatsLastPauseDataPoint(now, int64(memStats.PauseNs[(memStats.NumGC+255)%256]))
 
-	return e.mb.Emit(), nil
+	md := e.mb.Emit()
+	if len(e.cfg.CustomMetrics) == 0 {
+		return md, nil
+	}
+
+	doc, err := decodeDocument(bytes.NewReader(body))
+	if err != nil {
+		return md, fmt.Errorf("could not decode response body to JSON: %w", err)
+	}
+	if err = recordCustomMetrics(e.scopeMetrics(md).Metrics(), e.cfg.CustomMetrics, doc, e.startTime, now); err != nil {
+		return md, scrapererror.NewPartialScrapeError(err, len(multierr.Errors(err)))
+	}
+	return md, nil
+}
+
+// scopeMetrics returns the scope metrics emitted by the metrics builder, creating
+// them if no memstats metric is enabled.
+func (e *expVarScraper) scopeMetrics(md pmetric.Metrics) pmetric.ScopeMetrics {
+	if md.ResourceMetrics().Len() > 0 {
+		return md.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	}
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("otelcol/expvarreceiver")
+	sm.Scope().SetVersion(e.set.BuildInfo.Version)
+	return sm
 }
 
-func decodeResponseBody(body io.ReadCloser) (*expVar, error) {
+func decodeResponseBody(body io.Reader) (*expVar, error) {
 	var result expVar
 	if err := json.NewDecoder(body).Decode(&result); err != nil {
 		return nil, err
@@ -22,9 +22,14 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest/golden"
@@ -193,3 +198,123 @@ func TestEmptyResponseBodyError(t *testing.T) {
 	require.EqualError(t, err, "could not decode response body to JSON: EOF")
 	require.NoError(t, scrapertest.CompareMetrics(expectedMetrics, actualMetrics))
 }
+
+func TestCustomMetrics(t *testing.T) {
+	ms := newMockServer(t, filepath.Join("testdata", "response", "expvar_custom_response.json"))
+	defer ms.Close()
+	cfg := newDefaultConfig().(*Config)
+	cfg.Endpoint = ms.URL + defaultPath
+	cfg.MetricsConfig = allMetricsDisabled
+	cfg.CustomMetrics = []CustomMetricConfig{
+		{
+			Name:      "myapp.requests",
+			Path:      "myapp.requests",
+			Type:      customMetricTypeSum,
+			Monotonic: true,
+			ValueType: customMetricValueTypeInt,
+			Unit:      "{requests}",
+			Attribute: "method",
+		},
+		{
+			Name: "myapp.queue_length",
+			Path: "myapp.queue_length",
+			Type: customMetricTypeGauge,
+		},
+		{
+			Name:      "myapp.ready",
+			Path:      "myapp.ready",
+			Type:      customMetricTypeGauge,
+			ValueType: customMetricValueTypeInt,
+		},
+		{
+			Name: "myapp.version",
+			Path: "myapp.version",
+			Type: customMetricTypeGauge,
+		},
+		{
+			Name: "myapp.missing",
+			Path: "myapp.missing",
+			Type: customMetricTypeGauge,
+		},
+	}
+	scraper := newExpVarScraper(cfg, componenttest.NewNopReceiverCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.Contains(t, err.Error(), "custom metric 'myapp.version': value at path 'myapp.version' is not a number")
+	assert.Contains(t, err.Error(), "custom metric 'myapp.missing': path 'myapp.missing' not found")
+
+	require.Equal(t, 1, actualMetrics.ResourceMetrics().Len())
+	metrics := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+
+	requests := metrics.At(0)
+	assert.Equal(t, "myapp.requests", requests.Name())
+	assert.Equal(t, "{requests}", requests.Unit())
+	assert.True(t, requests.Sum().IsMonotonic())
+	dps := requests.Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	method, ok := dps.At(0).Attributes().Get("method")
+	require.True(t, ok)
+	assert.Equal(t, "GET", method.Str())
+	assert.Equal(t, int64(42), dps.At(0).IntValue())
+	method, ok = dps.At(1).Attributes().Get("method")
+	require.True(t, ok)
+	assert.Equal(t, "POST", method.Str())
+	assert.Equal(t, int64(7), dps.At(1).IntValue())
+
+	queueLength := metrics.At(1)
+	assert.Equal(t, "myapp.queue_length", queueLength.Name())
+	assert.Equal(t, 3.5, queueLength.Gauge().DataPoints().At(0).DoubleValue())
+
+	ready := metrics.At(2)
+	assert.Equal(t, "myapp.ready", ready.Name())
+	assert.Equal(t, int64(1), ready.Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestClientAuthenticator(t *testing.T) {
+	ms := newMockServer(t, filepath.Join("testdata", "response", "expvar_response.json"))
+	defer ms.Close()
+
+	var authenticated bool
+	authID := config.NewComponentID("mockauth")
+	host := &authHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{
+			authID: configauth.NewClientAuthenticator(configauth.WithClientRoundTripper(func(base http.RoundTripper) (http.RoundTripper, error) {
+				return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					authenticated = true
+					req.Header.Set("Authorization", "Bearer token")
+					return base.RoundTrip(req)
+				}), nil
+			})),
+		},
+	}
+
+	cfg := newDefaultConfig().(*Config)
+	cfg.Endpoint = ms.URL + defaultPath
+	cfg.Auth = &configauth.Authentication{AuthenticatorID: authID}
+	scraper := newExpVarScraper(cfg, componenttest.NewNopReceiverCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), host))
+
+	_, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	assert.True(t, authenticated)
+}
+
+type authHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *authHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
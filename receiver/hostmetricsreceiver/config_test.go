@@ -37,6 +37,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemscraper"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -94,6 +95,12 @@ func TestLoadConfig(t *testing.T) {
 				}
 				return cfg
 			})(),
+			systemscraper.TypeStr: (func() internal.Config {
+				cfg := (&systemscraper.Factory{}).CreateDefaultConfig()
+				storageID := config.NewComponentID("file_storage")
+				cfg.(*systemscraper.Config).Storage = &storageID
+				return cfg
+			})(),
 		},
 	}
 
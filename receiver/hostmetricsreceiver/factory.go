@@ -16,6 +16,7 @@ package hostmetricsreceiver // import "github.com/open-telemetry/opentelemetry-c
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
@@ -35,6 +36,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemscraper"
 )
 
 // This file implements Factory for HostMetrics receiver.
@@ -44,6 +46,8 @@ const (
 	typeStr = "hostmetrics"
 	// The stability level of the host metrics receiver.
 	stability = component.StabilityLevelBeta
+	// The stability level of the reboot events of the logs receiver.
+	logsStability = component.StabilityLevelAlpha
 )
 
 var (
@@ -57,6 +61,7 @@ var (
 		pagingscraper.TypeStr:     &pagingscraper.Factory{},
 		processesscraper.TypeStr:  &processesscraper.Factory{},
 		processscraper.TypeStr:    &processscraper.Factory{},
+		systemscraper.TypeStr:     &systemscraper.Factory{},
 	}
 )
 
@@ -65,7 +70,8 @@ func NewFactory() component.ReceiverFactory {
 	return component.NewReceiverFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithMetricsReceiver(createMetricsReceiver, stability))
+		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, logsStability))
 }
 
 func getScraperFactory(key string) (internal.ScraperFactory, bool) {
@@ -103,6 +109,28 @@ func createMetricsReceiver(
 	)
 }
 
+// createLogsReceiver creates a logs receiver emitting the reboots of the host detected by the system scraper.
+func createLogsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	oCfg := cfg.(*Config)
+
+	systemCfg, ok := oCfg.Scrapers[systemscraper.TypeStr]
+	if !ok {
+		return nil, errors.New("the system scraper must be configured to use the hostmetrics receiver in a logs pipeline")
+	}
+	sCfg := systemCfg.(*systemscraper.Config)
+	// without storage the first boot time seen after each start of the collector would be taken as
+	// the last one, and no reboot would ever be reported
+	if sCfg.Storage == nil {
+		return nil, errors.New("the storage of the system scraper must be set to use the hostmetrics receiver in a logs pipeline")
+	}
+	return systemscraper.NewRebootReceiver(set, oCfg.ID(), sCfg, oCfg.CollectionInterval, consumer), nil
+}
+
 func logDeprecatedFeatureGateForDirection(log *zap.Logger, gateID string) {
 	log.Warn("WARNING: The " + gateID + " feature gate is deprecated and will be removed in the next release. The change to remove " +
 		"the direction attribute has been reverted in the specification. See https://github.com/open-telemetry/opentelemetry-specification/issues/2726 " +
@@ -22,10 +22,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemscraper"
 )
 
 var creationSet = componenttest.NewNopReceiverCreateSettings()
@@ -50,8 +52,20 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NotNil(t, mReceiver)
 
 	tLogs, err := factory.CreateLogsReceiver(context.Background(), creationSet, cfg, consumertest.NewNop())
-	assert.Equal(t, err, component.ErrDataTypeIsNotSupported)
+	assert.EqualError(t, err, "the system scraper must be configured to use the hostmetrics receiver in a logs pipeline")
+	assert.Nil(t, tLogs)
+
+	systemCfg := (&systemscraper.Factory{}).CreateDefaultConfig().(*systemscraper.Config)
+	cfg.(*Config).Scrapers = map[string]internal.Config{systemscraper.TypeStr: systemCfg}
+	tLogs, err = factory.CreateLogsReceiver(context.Background(), creationSet, cfg, consumertest.NewNop())
+	assert.EqualError(t, err, "the storage of the system scraper must be set to use the hostmetrics receiver in a logs pipeline")
 	assert.Nil(t, tLogs)
+
+	storageID := config.NewComponentID("file_storage")
+	systemCfg.Storage = &storageID
+	tLogs, err = factory.CreateLogsReceiver(context.Background(), creationSet, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, tLogs)
 }
 
 func TestCreateReceiver_ScraperKeyConfigError(t *testing.T) {
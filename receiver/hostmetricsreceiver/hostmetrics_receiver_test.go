@@ -43,6 +43,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemscraper"
 )
 
 var standardMetrics = []string{
@@ -64,6 +65,8 @@ var standardMetrics = []string{
 	"system.network.packets",
 	"system.paging.operations",
 	"system.paging.usage",
+	"system.boot_time",
+	"system.info",
 }
 
 var resourceMetrics = []string{
@@ -91,6 +94,7 @@ var factories = map[string]internal.ScraperFactory{
 	pagingscraper.TypeStr:     &pagingscraper.Factory{},
 	processesscraper.TypeStr:  &processesscraper.Factory{},
 	processscraper.TypeStr:    &processscraper.Factory{},
+	systemscraper.TypeStr:     &systemscraper.Factory{},
 }
 
 func TestGatherMetrics_EndToEnd(t *testing.T) {
@@ -112,6 +116,7 @@ func TestGatherMetrics_EndToEnd(t *testing.T) {
 			networkscraper.TypeStr:    scraperFactories[networkscraper.TypeStr].CreateDefaultConfig(),
 			pagingscraper.TypeStr:     scraperFactories[pagingscraper.TypeStr].CreateDefaultConfig(),
 			processesscraper.TypeStr:  scraperFactories[processesscraper.TypeStr].CreateDefaultConfig(),
+			systemscraper.TypeStr:     scraperFactories[systemscraper.TypeStr].CreateDefaultConfig(),
 		},
 	}
 
@@ -21,4 +21,9 @@ import (
 // Config relating to CPU Metric Scraper.
 type Config struct {
 	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
+
+	// IncludeTopology adds the socket, core, thread and NUMA node of every CPU
+	// as attributes of the cpu metrics. Only supported on Linux. The topology is
+	// read when the scraper starts.
+	IncludeTopology bool `mapstructure:"include_topology"`
 }
@@ -37,16 +37,18 @@ type scraper struct {
 	config   *Config
 	mb       *metadata.MetricsBuilder
 	ucal     *ucal.CPUUtilizationCalculator
+	topology map[string]cpuTopology
 
 	// for mocking
-	bootTime func() (uint64, error)
-	times    func(bool) ([]cpu.TimesStat, error)
-	now      func() time.Time
+	bootTime    func() (uint64, error)
+	times       func(bool) ([]cpu.TimesStat, error)
+	now         func() time.Time
+	cpuTopology func() (map[string]cpuTopology, error)
 }
 
 // newCPUScraper creates a set of CPU related metrics
 func newCPUScraper(_ context.Context, settings component.ReceiverCreateSettings, cfg *Config) *scraper {
-	return &scraper{settings: settings, config: cfg, bootTime: host.BootTime, times: cpu.Times, ucal: &ucal.CPUUtilizationCalculator{}, now: time.Now}
+	return &scraper{settings: settings, config: cfg, bootTime: host.BootTime, times: cpu.Times, ucal: &ucal.CPUUtilizationCalculator{}, now: time.Now, cpuTopology: getCPUTopology}
 }
 
 func (s *scraper) start(context.Context, component.Host) error {
@@ -54,6 +56,13 @@ func (s *scraper) start(context.Context, component.Host) error {
 	if err != nil {
 		return err
 	}
+	// The topology is only read once: CPUs brought online later have no
+	// topology attributes until the collector is restarted.
+	if s.config.IncludeTopology {
+		if s.topology, err = s.cpuTopology(); err != nil {
+			return err
+		}
+	}
 	s.mb = metadata.NewMetricsBuilder(s.config.Metrics, s.settings.BuildInfo, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
 	return nil
 }
@@ -74,5 +83,9 @@ func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
 		return pmetric.NewMetrics(), scrapererror.NewPartialScrapeError(err, metricsLen)
 	}
 
-	return s.mb.Emit(), nil
+	md := s.mb.Emit()
+	if !s.config.IncludeTopology {
+		removeTopologyAttributes(md)
+	}
+	return md, nil
 }
@@ -100,7 +100,7 @@ func (m *metricSystemCPUTime) init() {
 	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricSystemCPUTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, cpuAttributeValue string, stateAttributeValue string) {
+func (m *metricSystemCPUTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, cpuAttributeValue string, stateAttributeValue string, socketAttributeValue string, coreAttributeValue string, threadAttributeValue string, numaNodeAttributeValue string) {
 	if !m.settings.Enabled {
 		return
 	}
@@ -110,6 +110,10 @@ func (m *metricSystemCPUTime) recordDataPoint(start pcommon.Timestamp, ts pcommo
 	dp.SetDoubleValue(val)
 	dp.Attributes().PutStr("cpu", cpuAttributeValue)
 	dp.Attributes().PutStr("state", stateAttributeValue)
+	dp.Attributes().PutStr("socket", socketAttributeValue)
+	dp.Attributes().PutStr("core", coreAttributeValue)
+	dp.Attributes().PutStr("thread", threadAttributeValue)
+	dp.Attributes().PutStr("numa_node", numaNodeAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
@@ -152,7 +156,7 @@ func (m *metricSystemCPUUtilization) init() {
 	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricSystemCPUUtilization) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, cpuAttributeValue string, stateAttributeValue string) {
+func (m *metricSystemCPUUtilization) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, cpuAttributeValue string, stateAttributeValue string, socketAttributeValue string, coreAttributeValue string, threadAttributeValue string, numaNodeAttributeValue string) {
 	if !m.settings.Enabled {
 		return
 	}
@@ -162,6 +166,10 @@ func (m *metricSystemCPUUtilization) recordDataPoint(start pcommon.Timestamp, ts
 	dp.SetDoubleValue(val)
 	dp.Attributes().PutStr("cpu", cpuAttributeValue)
 	dp.Attributes().PutStr("state", stateAttributeValue)
+	dp.Attributes().PutStr("socket", socketAttributeValue)
+	dp.Attributes().PutStr("core", coreAttributeValue)
+	dp.Attributes().PutStr("thread", threadAttributeValue)
+	dp.Attributes().PutStr("numa_node", numaNodeAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
@@ -293,13 +301,13 @@ func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
 }
 
 // RecordSystemCPUTimeDataPoint adds a data point to system.cpu.time metric.
-func (mb *MetricsBuilder) RecordSystemCPUTimeDataPoint(ts pcommon.Timestamp, val float64, cpuAttributeValue string, stateAttributeValue AttributeState) {
-	mb.metricSystemCPUTime.recordDataPoint(mb.startTime, ts, val, cpuAttributeValue, stateAttributeValue.String())
+func (mb *MetricsBuilder) RecordSystemCPUTimeDataPoint(ts pcommon.Timestamp, val float64, cpuAttributeValue string, stateAttributeValue AttributeState, socketAttributeValue string, coreAttributeValue string, threadAttributeValue string, numaNodeAttributeValue string) {
+	mb.metricSystemCPUTime.recordDataPoint(mb.startTime, ts, val, cpuAttributeValue, stateAttributeValue.String(), socketAttributeValue, coreAttributeValue, threadAttributeValue, numaNodeAttributeValue)
 }
 
 // RecordSystemCPUUtilizationDataPoint adds a data point to system.cpu.utilization metric.
-func (mb *MetricsBuilder) RecordSystemCPUUtilizationDataPoint(ts pcommon.Timestamp, val float64, cpuAttributeValue string, stateAttributeValue AttributeState) {
-	mb.metricSystemCPUUtilization.recordDataPoint(mb.startTime, ts, val, cpuAttributeValue, stateAttributeValue.String())
+func (mb *MetricsBuilder) RecordSystemCPUUtilizationDataPoint(ts pcommon.Timestamp, val float64, cpuAttributeValue string, stateAttributeValue AttributeState, socketAttributeValue string, coreAttributeValue string, threadAttributeValue string, numaNodeAttributeValue string) {
+	mb.metricSystemCPUUtilization.recordDataPoint(mb.startTime, ts, val, cpuAttributeValue, stateAttributeValue.String(), socketAttributeValue, coreAttributeValue, threadAttributeValue, numaNodeAttributeValue)
 }
 
 // Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpuscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// cpuTopology is the location of a logical CPU, recorded as attributes of the
+// data points of the CPU.
+type cpuTopology struct {
+	socket   string
+	core     string
+	thread   string
+	numaNode string
+}
+
+// topologyAttributes are the attributes of the data points set from cpuTopology.
+var topologyAttributes = map[string]bool{"socket": true, "core": true, "thread": true, "numa_node": true}
+
+// removeTopologyAttributes removes the topology attributes from all the data
+// points of md, so the series of the cpu metrics are not changed when the
+// topology is not included.
+func removeTopologyAttributes(md pmetric.Metrics) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				var dps pmetric.NumberDataPointSlice
+				switch m := ms.At(k); m.Type() {
+				case pmetric.MetricTypeSum:
+					dps = m.Sum().DataPoints()
+				case pmetric.MetricTypeGauge:
+					dps = m.Gauge().DataPoints()
+				default:
+					continue
+				}
+				for l := 0; l < dps.Len(); l++ {
+					dps.At(l).Attributes().RemoveIf(func(k string, _ pcommon.Value) bool {
+						return topologyAttributes[k]
+					})
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package cpuscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func getCPUTopology() (map[string]cpuTopology, error) {
+	sysPath := os.Getenv("HOST_SYS")
+	if sysPath == "" {
+		sysPath = "/sys"
+	}
+	return readCPUTopology(filepath.Join(sysPath, "devices", "system", "cpu"))
+}
+
+// readCPUTopology reads the topology of every online CPU found in dir, keyed
+// by CPU name as reported by the cpu attribute, e.g. "cpu0".
+func readCPUTopology(dir string) (map[string]cpuTopology, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "cpu[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	topology := make(map[string]cpuTopology, len(paths))
+	for _, path := range paths {
+		name := filepath.Base(path)
+		var num int
+		if num, err = strconv.Atoi(strings.TrimPrefix(name, "cpu")); err != nil {
+			continue
+		}
+		topologyDir := filepath.Join(path, "topology")
+		if _, err = os.Stat(topologyDir); err != nil {
+			// Offline CPUs have no topology.
+			continue
+		}
+
+		var t cpuTopology
+		if t.socket, err = readSysValue(filepath.Join(topologyDir, "physical_package_id")); err != nil {
+			return nil, err
+		}
+		if t.core, err = readSysValue(filepath.Join(topologyDir, "core_id")); err != nil {
+			return nil, err
+		}
+		var siblings string
+		if siblings, err = readSysValue(filepath.Join(topologyDir, "thread_siblings_list")); err != nil {
+			return nil, err
+		}
+		var thread int
+		if thread, err = siblingIndex(siblings, num); err != nil {
+			return nil, fmt.Errorf("invalid thread siblings of %s: %w", name, err)
+		}
+		t.thread = strconv.Itoa(thread)
+
+		// The NUMA node of a CPU is exposed as a nodeN link.
+		nodes, _ := filepath.Glob(filepath.Join(path, "node[0-9]*"))
+		if len(nodes) > 0 {
+			t.numaNode = strings.TrimPrefix(filepath.Base(nodes[0]), "node")
+		}
+
+		topology[name] = t
+	}
+	return topology, nil
+}
+
+func readSysValue(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// siblingIndex returns the position of cpu in a CPU list, e.g. "0,4" or "0-1".
+func siblingIndex(list string, cpu int) (int, error) {
+	idx := 0
+	for _, r := range strings.Split(list, ",") {
+		lo, hi, isRange := strings.Cut(r, "-")
+		start, err := strconv.Atoi(lo)
+		if err != nil {
+			return 0, err
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(hi); err != nil {
+				return 0, err
+			}
+		}
+		if cpu >= start && cpu <= end {
+			return idx + cpu - start, nil
+		}
+		idx += end - start + 1
+	}
+	return 0, fmt.Errorf("cpu%d not found in %q", cpu, list)
+}
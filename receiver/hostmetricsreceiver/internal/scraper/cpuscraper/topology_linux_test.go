@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package cpuscraper
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCPUTopology(t *testing.T) {
+	topology, err := readCPUTopology(filepath.Join("testdata", "cpu"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cpuTopology{
+		"cpu0": {socket: "0", core: "0", thread: "0", numaNode: "0"},
+		"cpu1": {socket: "0", core: "1", thread: "0", numaNode: "0"},
+		"cpu2": {socket: "0", core: "0", thread: "1", numaNode: "0"},
+		"cpu3": {socket: "0", core: "1", thread: "1", numaNode: "0"},
+	}, topology)
+}
+
+func TestSiblingIndex(t *testing.T) {
+	tests := []struct {
+		list     string
+		cpu      int
+		expected int
+	}{
+		{list: "0", cpu: 0, expected: 0},
+		{list: "0,4", cpu: 4, expected: 1},
+		{list: "2-3", cpu: 3, expected: 1},
+		{list: "0-1,8-9", cpu: 8, expected: 2},
+	}
+	for _, tt := range tests {
+		idx, err := siblingIndex(tt.list, tt.cpu)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, idx)
+	}
+
+	_, err := siblingIndex("0,4", 2)
+	assert.EqualError(t, err, `cpu2 not found in "0,4"`)
+}
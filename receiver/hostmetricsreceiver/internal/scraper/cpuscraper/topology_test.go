@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpuscraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cpuscraper/internal/metadata"
+)
+
+func TestScrape_Topology(t *testing.T) {
+	cfg := &Config{Metrics: metadata.DefaultMetricsSettings(), IncludeTopology: true}
+	scraper := newCPUScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg)
+	scraper.times = func(bool) ([]cpu.TimesStat, error) {
+		return []cpu.TimesStat{{CPU: "cpu0", User: 1}, {CPU: "cpu1", User: 2}}, nil
+	}
+	scraper.cpuTopology = func() (map[string]cpuTopology, error) {
+		return map[string]cpuTopology{
+			"cpu0": {socket: "1", core: "3", thread: "0", numaNode: "1"},
+		}, nil
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Greater(t, dps.Len(), 0)
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes().AsRaw()
+		if attrs["cpu"] != "cpu0" {
+			// CPUs without known topology have empty topology attributes.
+			assert.Equal(t, map[string]interface{}{
+				"cpu":       attrs["cpu"],
+				"state":     attrs["state"],
+				"socket":    "",
+				"core":      "",
+				"thread":    "",
+				"numa_node": "",
+			}, attrs)
+			continue
+		}
+		assert.Equal(t, map[string]interface{}{
+			"cpu":       "cpu0",
+			"state":     attrs["state"],
+			"socket":    "1",
+			"core":      "3",
+			"thread":    "0",
+			"numa_node": "1",
+		}, attrs)
+	}
+}
+
+func TestScrape_NoTopology(t *testing.T) {
+	cfg := &Config{Metrics: metadata.DefaultMetricsSettings()}
+	scraper := newCPUScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg)
+	scraper.times = func(bool) ([]cpu.TimesStat, error) {
+		return []cpu.TimesStat{{CPU: "cpu0", User: 1}}, nil
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Greater(t, dps.Len(), 0)
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes().AsRaw()
+		assert.Equal(t, map[string]interface{}{"cpu": "cpu0", "state": attrs["state"]}, attrs)
+	}
+}
+
+func TestScrape_TopologyError(t *testing.T) {
+	cfg := &Config{Metrics: metadata.DefaultMetricsSettings(), IncludeTopology: true}
+	scraper := newCPUScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg)
+	scraper.cpuTopology = func() (map[string]cpuTopology, error) {
+		return nil, errors.New("err1")
+	}
+	assert.EqualError(t, scraper.start(context.Background(), componenttest.NewNopHost()), "err1")
+}
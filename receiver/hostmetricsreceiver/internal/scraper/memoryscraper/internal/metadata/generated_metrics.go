@@ -18,12 +18,16 @@ type MetricSettings struct {
 
 // MetricsSettings provides settings for hostmetricsreceiver/memory metrics.
 type MetricsSettings struct {
+	SystemMemoryNumaUsage   MetricSettings `mapstructure:"system.memory.numa.usage"`
 	SystemMemoryUsage       MetricSettings `mapstructure:"system.memory.usage"`
 	SystemMemoryUtilization MetricSettings `mapstructure:"system.memory.utilization"`
 }
 
 func DefaultMetricsSettings() MetricsSettings {
 	return MetricsSettings{
+		SystemMemoryNumaUsage: MetricSettings{
+			Enabled: false,
+		},
 		SystemMemoryUsage: MetricSettings{
 			Enabled: true,
 		},
@@ -79,6 +83,60 @@ var MapAttributeState = map[string]AttributeState{
 	"used":               AttributeStateUsed,
 }
 
+type metricSystemMemoryNumaUsage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.memory.numa.usage metric with initial data.
+func (m *metricSystemMemoryNumaUsage) init() {
+	m.data.SetName("system.memory.numa.usage")
+	m.data.SetDescription("Bytes of memory in use per NUMA node. Only available on Linux.")
+	m.data.SetUnit("By")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemMemoryNumaUsage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, numaNodeAttributeValue string, stateAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("numa_node", numaNodeAttributeValue)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemMemoryNumaUsage) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemMemoryNumaUsage) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemMemoryNumaUsage(settings MetricSettings) metricSystemMemoryNumaUsage {
+	m := metricSystemMemoryNumaUsage{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricSystemMemoryUsage struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	settings MetricSettings // metric settings provided by user.
@@ -191,6 +249,7 @@ type MetricsBuilder struct {
 	resourceCapacity              int                 // maximum observed number of resource attributes.
 	metricsBuffer                 pmetric.Metrics     // accumulates metrics data before emitting.
 	buildInfo                     component.BuildInfo // contains version information
+	metricSystemMemoryNumaUsage   metricSystemMemoryNumaUsage
 	metricSystemMemoryUsage       metricSystemMemoryUsage
 	metricSystemMemoryUtilization metricSystemMemoryUtilization
 }
@@ -210,6 +269,7 @@ func NewMetricsBuilder(settings MetricsSettings, buildInfo component.BuildInfo,
 		startTime:                     pcommon.NewTimestampFromTime(time.Now()),
 		metricsBuffer:                 pmetric.NewMetrics(),
 		buildInfo:                     buildInfo,
+		metricSystemMemoryNumaUsage:   newMetricSystemMemoryNumaUsage(settings.SystemMemoryNumaUsage),
 		metricSystemMemoryUsage:       newMetricSystemMemoryUsage(settings.SystemMemoryUsage),
 		metricSystemMemoryUtilization: newMetricSystemMemoryUtilization(settings.SystemMemoryUtilization),
 	}
@@ -265,6 +325,7 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	ils.Scope().SetName("otelcol/hostmetricsreceiver/memory")
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricSystemMemoryNumaUsage.emit(ils.Metrics())
 	mb.metricSystemMemoryUsage.emit(ils.Metrics())
 	mb.metricSystemMemoryUtilization.emit(ils.Metrics())
 	for _, op := range rmo {
@@ -286,6 +347,11 @@ func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
 	return metrics
 }
 
+// RecordSystemMemoryNumaUsageDataPoint adds a data point to system.memory.numa.usage metric.
+func (mb *MetricsBuilder) RecordSystemMemoryNumaUsageDataPoint(ts pcommon.Timestamp, val int64, numaNodeAttributeValue string, stateAttributeValue AttributeState) {
+	mb.metricSystemMemoryNumaUsage.recordDataPoint(mb.startTime, ts, val, numaNodeAttributeValue, stateAttributeValue.String())
+}
+
 // RecordSystemMemoryUsageDataPoint adds a data point to system.memory.usage metric.
 func (mb *MetricsBuilder) RecordSystemMemoryUsageDataPoint(ts pcommon.Timestamp, val int64, stateAttributeValue AttributeState) {
 	mb.metricSystemMemoryUsage.recordDataPoint(mb.startTime, ts, val, stateAttributeValue.String())
@@ -43,11 +43,22 @@ type scraper struct {
 	// for mocking gopsutil mem.VirtualMemory
 	bootTime      func() (uint64, error)
 	virtualMemory func() (*mem.VirtualMemoryStat, error)
+	numaMemory    func() ([]numaNodeMemory, error)
+}
+
+// numaNodeMemory holds the memory statistics of a NUMA node, in bytes.
+type numaNodeMemory struct {
+	node              string
+	total             uint64
+	free              uint64
+	filePages         uint64
+	slabReclaimable   uint64
+	slabUnreclaimable uint64
 }
 
 // newMemoryScraper creates a Memory Scraper
 func newMemoryScraper(_ context.Context, settings component.ReceiverCreateSettings, cfg *Config) *scraper {
-	return &scraper{settings: settings, config: cfg, bootTime: host.BootTime, virtualMemory: mem.VirtualMemory}
+	return &scraper{settings: settings, config: cfg, bootTime: host.BootTime, virtualMemory: mem.VirtualMemory, numaMemory: getNUMAMemory}
 }
 
 func (s *scraper) start(context.Context, component.Host) error {
@@ -76,5 +87,29 @@ func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
 		s.recordMemoryUtilizationMetric(now, memInfo)
 	}
 
+	if s.config.Metrics.SystemMemoryNumaUsage.Enabled {
+		var nodes []numaNodeMemory
+		nodes, err = s.numaMemory()
+		if err != nil {
+			return s.mb.Emit(), scrapererror.NewPartialScrapeError(err, 1)
+		}
+		s.recordNUMAMemoryUsageMetric(now, nodes)
+	}
+
 	return s.mb.Emit(), nil
 }
+
+func (s *scraper) recordNUMAMemoryUsageMetric(now pcommon.Timestamp, nodes []numaNodeMemory) {
+	for _, n := range nodes {
+		slab := n.slabReclaimable + n.slabUnreclaimable
+		var used uint64
+		if n.total > n.free+n.filePages+slab {
+			used = n.total - n.free - n.filePages - slab
+		}
+		s.mb.RecordSystemMemoryNumaUsageDataPoint(now, int64(used), n.node, metadata.AttributeStateUsed)
+		s.mb.RecordSystemMemoryNumaUsageDataPoint(now, int64(n.free), n.node, metadata.AttributeStateFree)
+		s.mb.RecordSystemMemoryNumaUsageDataPoint(now, int64(n.filePages), n.node, metadata.AttributeStateCached)
+		s.mb.RecordSystemMemoryNumaUsageDataPoint(now, int64(n.slabReclaimable), n.node, metadata.AttributeStateSlabReclaimable)
+		s.mb.RecordSystemMemoryNumaUsageDataPoint(now, int64(n.slabUnreclaimable), n.node, metadata.AttributeStateSlabUnreclaimable)
+	}
+}
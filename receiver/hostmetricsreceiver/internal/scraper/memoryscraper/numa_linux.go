@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package memoryscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/memoryscraper"
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func getNUMAMemory() ([]numaNodeMemory, error) {
+	sysPath := os.Getenv("HOST_SYS")
+	if sysPath == "" {
+		sysPath = "/sys"
+	}
+	return readNUMAMemory(filepath.Join(sysPath, "devices", "system", "node"))
+}
+
+// readNUMAMemory reads the meminfo file of every NUMA node found in dir.
+func readNUMAMemory(dir string) ([]numaNodeMemory, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "node[0-9]*", "meminfo"))
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]numaNodeMemory, 0, len(paths))
+	for _, path := range paths {
+		node := strings.TrimPrefix(filepath.Base(filepath.Dir(path)), "node")
+		if _, err = strconv.Atoi(node); err != nil {
+			continue
+		}
+		var n numaNodeMemory
+		if n, err = readNodeMemInfo(path); err != nil {
+			return nil, err
+		}
+		n.node = node
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// readNodeMemInfo parses a node meminfo file, whose lines look like
+// "Node 0 MemFree:        1234 kB".
+func readNodeMemInfo(path string) (numaNodeMemory, error) {
+	var n numaNodeMemory
+
+	f, err := os.Open(path)
+	if err != nil {
+		return n, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		var value uint64
+		if value, err = strconv.ParseUint(fields[3], 10, 64); err != nil {
+			return n, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if len(fields) > 4 && fields[4] == "kB" {
+			value *= 1024
+		}
+		switch strings.TrimSuffix(fields[2], ":") {
+		case "MemTotal":
+			n.total = value
+		case "MemFree":
+			n.free = value
+		case "FilePages":
+			n.filePages = value
+		case "SReclaimable":
+			n.slabReclaimable = value
+		case "SUnreclaim":
+			n.slabUnreclaimable = value
+		}
+	}
+	return n, scanner.Err()
+}
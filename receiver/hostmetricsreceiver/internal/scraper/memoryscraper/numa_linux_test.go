@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package memoryscraper
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/memoryscraper/internal/metadata"
+)
+
+func TestReadNUMAMemory(t *testing.T) {
+	nodes, err := readNUMAMemory(filepath.Join("testdata", "node"))
+	require.NoError(t, err)
+	assert.Equal(t, []numaNodeMemory{
+		{
+			node:              "0",
+			total:             16384000 * 1024,
+			free:              4096000 * 1024,
+			filePages:         5000000 * 1024,
+			slabReclaimable:   400000 * 1024,
+			slabUnreclaimable: 200000 * 1024,
+		},
+		{
+			node:              "1",
+			total:             8192000 * 1024,
+			free:              1024000 * 1024,
+			filePages:         2000000 * 1024,
+			slabReclaimable:   100000 * 1024,
+			slabUnreclaimable: 50000 * 1024,
+		},
+	}, nodes)
+}
+
+func TestScrape_NUMAMemoryUsage(t *testing.T) {
+	config := &Config{
+		Metrics: metadata.MetricsSettings{
+			SystemMemoryNumaUsage: metadata.MetricSettings{
+				Enabled: true,
+			},
+		},
+	}
+	scraper := newMemoryScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), config)
+	scraper.numaMemory = func() ([]numaNodeMemory, error) {
+		return readNUMAMemory(filepath.Join("testdata", "node"))
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, md.MetricCount())
+
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "system.memory.numa.usage", metric.Name())
+	dps := metric.Sum().DataPoints()
+	require.Equal(t, 10, dps.Len())
+
+	used := dps.At(0)
+	node, ok := used.Attributes().Get("numa_node")
+	require.True(t, ok)
+	assert.Equal(t, "0", node.Str())
+	state, ok := used.Attributes().Get("state")
+	require.True(t, ok)
+	assert.Equal(t, pcommon.NewValueStr(metadata.AttributeStateUsed.String()), state)
+	assert.Equal(t, int64((16384000-4096000-5000000-400000-200000)*1024), used.IntValue())
+}
@@ -18,21 +18,22 @@ type MetricSettings struct {
 
 // MetricsSettings provides settings for hostmetricsreceiver/network metrics.
 type MetricsSettings struct {
-	SystemNetworkConnections     MetricSettings `mapstructure:"system.network.connections"`
-	SystemNetworkConntrackCount  MetricSettings `mapstructure:"system.network.conntrack.count"`
-	SystemNetworkConntrackMax    MetricSettings `mapstructure:"system.network.conntrack.max"`
-	SystemNetworkDropped         MetricSettings `mapstructure:"system.network.dropped"`
-	SystemNetworkDroppedReceive  MetricSettings `mapstructure:"system.network.dropped.receive"`
-	SystemNetworkDroppedTransmit MetricSettings `mapstructure:"system.network.dropped.transmit"`
-	SystemNetworkErrors          MetricSettings `mapstructure:"system.network.errors"`
-	SystemNetworkErrorsReceive   MetricSettings `mapstructure:"system.network.errors.receive"`
-	SystemNetworkErrorsTransmit  MetricSettings `mapstructure:"system.network.errors.transmit"`
-	SystemNetworkIo              MetricSettings `mapstructure:"system.network.io"`
-	SystemNetworkIoReceive       MetricSettings `mapstructure:"system.network.io.receive"`
-	SystemNetworkIoTransmit      MetricSettings `mapstructure:"system.network.io.transmit"`
-	SystemNetworkPackets         MetricSettings `mapstructure:"system.network.packets"`
-	SystemNetworkPacketsReceive  MetricSettings `mapstructure:"system.network.packets.receive"`
-	SystemNetworkPacketsTransmit MetricSettings `mapstructure:"system.network.packets.transmit"`
+	SystemNetworkConnections        MetricSettings `mapstructure:"system.network.connections"`
+	SystemNetworkConntrackCount     MetricSettings `mapstructure:"system.network.conntrack.count"`
+	SystemNetworkConntrackMax       MetricSettings `mapstructure:"system.network.conntrack.max"`
+	SystemNetworkDropped            MetricSettings `mapstructure:"system.network.dropped"`
+	SystemNetworkDroppedReceive     MetricSettings `mapstructure:"system.network.dropped.receive"`
+	SystemNetworkDroppedTransmit    MetricSettings `mapstructure:"system.network.dropped.transmit"`
+	SystemNetworkErrors             MetricSettings `mapstructure:"system.network.errors"`
+	SystemNetworkErrorsReceive      MetricSettings `mapstructure:"system.network.errors.receive"`
+	SystemNetworkErrorsTransmit     MetricSettings `mapstructure:"system.network.errors.transmit"`
+	SystemNetworkIo                 MetricSettings `mapstructure:"system.network.io"`
+	SystemNetworkIoReceive          MetricSettings `mapstructure:"system.network.io.receive"`
+	SystemNetworkIoTransmit         MetricSettings `mapstructure:"system.network.io.transmit"`
+	SystemNetworkPackets            MetricSettings `mapstructure:"system.network.packets"`
+	SystemNetworkPacketsReceive     MetricSettings `mapstructure:"system.network.packets.receive"`
+	SystemNetworkPacketsTransmit    MetricSettings `mapstructure:"system.network.packets.transmit"`
+	SystemNetworkProcessConnections MetricSettings `mapstructure:"system.network.process.connections"`
 }
 
 func DefaultMetricsSettings() MetricsSettings {
@@ -82,6 +83,9 @@ func DefaultMetricsSettings() MetricsSettings {
 		SystemNetworkPacketsTransmit: MetricSettings{
 			Enabled: true,
 		},
+		SystemNetworkProcessConnections: MetricSettings{
+			Enabled: false,
+		},
 	}
 }
 
@@ -117,6 +121,7 @@ type AttributeProtocol int
 const (
 	_ AttributeProtocol = iota
 	AttributeProtocolTcp
+	AttributeProtocolUdp
 )
 
 // String returns the string representation of the AttributeProtocol.
@@ -124,6 +129,8 @@ func (av AttributeProtocol) String() string {
 	switch av {
 	case AttributeProtocolTcp:
 		return "tcp"
+	case AttributeProtocolUdp:
+		return "udp"
 	}
 	return ""
 }
@@ -131,6 +138,7 @@ func (av AttributeProtocol) String() string {
 // MapAttributeProtocol is a helper map of string to AttributeProtocol attribute value.
 var MapAttributeProtocol = map[string]AttributeProtocol{
 	"tcp": AttributeProtocolTcp,
+	"udp": AttributeProtocolUdp,
 }
 
 type metricSystemNetworkConnections struct {
@@ -929,29 +937,86 @@ func newMetricSystemNetworkPacketsTransmit(settings MetricSettings) metricSystem
 	return m
 }
 
+type metricSystemNetworkProcessConnections struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.network.process.connections metric with initial data.
+func (m *metricSystemNetworkProcessConnections) init() {
+	m.data.SetName("system.network.process.connections")
+	m.data.SetDescription("The number of sockets of each process, by protocol and state. Finding the process owning each socket requires reading the file descriptors of all the processes, which is expensive on hosts with many processes.")
+	m.data.SetUnit("{connections}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemNetworkProcessConnections) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, protocolAttributeValue string, stateAttributeValue string, processPidAttributeValue int64, processExecutableNameAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("protocol", protocolAttributeValue)
+	dp.Attributes().PutStr("state", stateAttributeValue)
+	dp.Attributes().PutInt("process.pid", processPidAttributeValue)
+	dp.Attributes().PutStr("process.executable.name", processExecutableNameAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemNetworkProcessConnections) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemNetworkProcessConnections) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemNetworkProcessConnections(settings MetricSettings) metricSystemNetworkProcessConnections {
+	m := metricSystemNetworkProcessConnections{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 // MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
 // required to produce metric representation defined in metadata and user settings.
 type MetricsBuilder struct {
-	startTime                          pcommon.Timestamp   // start time that will be applied to all recorded data points.
-	metricsCapacity                    int                 // maximum observed number of metrics per resource.
-	resourceCapacity                   int                 // maximum observed number of resource attributes.
-	metricsBuffer                      pmetric.Metrics     // accumulates metrics data before emitting.
-	buildInfo                          component.BuildInfo // contains version information
-	metricSystemNetworkConnections     metricSystemNetworkConnections
-	metricSystemNetworkConntrackCount  metricSystemNetworkConntrackCount
-	metricSystemNetworkConntrackMax    metricSystemNetworkConntrackMax
-	metricSystemNetworkDropped         metricSystemNetworkDropped
-	metricSystemNetworkDroppedReceive  metricSystemNetworkDroppedReceive
-	metricSystemNetworkDroppedTransmit metricSystemNetworkDroppedTransmit
-	metricSystemNetworkErrors          metricSystemNetworkErrors
-	metricSystemNetworkErrorsReceive   metricSystemNetworkErrorsReceive
-	metricSystemNetworkErrorsTransmit  metricSystemNetworkErrorsTransmit
-	metricSystemNetworkIo              metricSystemNetworkIo
-	metricSystemNetworkIoReceive       metricSystemNetworkIoReceive
-	metricSystemNetworkIoTransmit      metricSystemNetworkIoTransmit
-	metricSystemNetworkPackets         metricSystemNetworkPackets
-	metricSystemNetworkPacketsReceive  metricSystemNetworkPacketsReceive
-	metricSystemNetworkPacketsTransmit metricSystemNetworkPacketsTransmit
+	startTime                             pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity                       int                 // maximum observed number of metrics per resource.
+	resourceCapacity                      int                 // maximum observed number of resource attributes.
+	metricsBuffer                         pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                             component.BuildInfo // contains version information
+	metricSystemNetworkConnections        metricSystemNetworkConnections
+	metricSystemNetworkConntrackCount     metricSystemNetworkConntrackCount
+	metricSystemNetworkConntrackMax       metricSystemNetworkConntrackMax
+	metricSystemNetworkDropped            metricSystemNetworkDropped
+	metricSystemNetworkDroppedReceive     metricSystemNetworkDroppedReceive
+	metricSystemNetworkDroppedTransmit    metricSystemNetworkDroppedTransmit
+	metricSystemNetworkErrors             metricSystemNetworkErrors
+	metricSystemNetworkErrorsReceive      metricSystemNetworkErrorsReceive
+	metricSystemNetworkErrorsTransmit     metricSystemNetworkErrorsTransmit
+	metricSystemNetworkIo                 metricSystemNetworkIo
+	metricSystemNetworkIoReceive          metricSystemNetworkIoReceive
+	metricSystemNetworkIoTransmit         metricSystemNetworkIoTransmit
+	metricSystemNetworkPackets            metricSystemNetworkPackets
+	metricSystemNetworkPacketsReceive     metricSystemNetworkPacketsReceive
+	metricSystemNetworkPacketsTransmit    metricSystemNetworkPacketsTransmit
+	metricSystemNetworkProcessConnections metricSystemNetworkProcessConnections
 }
 
 // metricBuilderOption applies changes to default metrics builder.
@@ -966,24 +1031,25 @@ func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
 
 func NewMetricsBuilder(settings MetricsSettings, buildInfo component.BuildInfo, options ...metricBuilderOption) *MetricsBuilder {
 	mb := &MetricsBuilder{
-		startTime:                          pcommon.NewTimestampFromTime(time.Now()),
-		metricsBuffer:                      pmetric.NewMetrics(),
-		buildInfo:                          buildInfo,
-		metricSystemNetworkConnections:     newMetricSystemNetworkConnections(settings.SystemNetworkConnections),
-		metricSystemNetworkConntrackCount:  newMetricSystemNetworkConntrackCount(settings.SystemNetworkConntrackCount),
-		metricSystemNetworkConntrackMax:    newMetricSystemNetworkConntrackMax(settings.SystemNetworkConntrackMax),
-		metricSystemNetworkDropped:         newMetricSystemNetworkDropped(settings.SystemNetworkDropped),
-		metricSystemNetworkDroppedReceive:  newMetricSystemNetworkDroppedReceive(settings.SystemNetworkDroppedReceive),
-		metricSystemNetworkDroppedTransmit: newMetricSystemNetworkDroppedTransmit(settings.SystemNetworkDroppedTransmit),
-		metricSystemNetworkErrors:          newMetricSystemNetworkErrors(settings.SystemNetworkErrors),
-		metricSystemNetworkErrorsReceive:   newMetricSystemNetworkErrorsReceive(settings.SystemNetworkErrorsReceive),
-		metricSystemNetworkErrorsTransmit:  newMetricSystemNetworkErrorsTransmit(settings.SystemNetworkErrorsTransmit),
-		metricSystemNetworkIo:              newMetricSystemNetworkIo(settings.SystemNetworkIo),
-		metricSystemNetworkIoReceive:       newMetricSystemNetworkIoReceive(settings.SystemNetworkIoReceive),
-		metricSystemNetworkIoTransmit:      newMetricSystemNetworkIoTransmit(settings.SystemNetworkIoTransmit),
-		metricSystemNetworkPackets:         newMetricSystemNetworkPackets(settings.SystemNetworkPackets),
-		metricSystemNetworkPacketsReceive:  newMetricSystemNetworkPacketsReceive(settings.SystemNetworkPacketsReceive),
-		metricSystemNetworkPacketsTransmit: newMetricSystemNetworkPacketsTransmit(settings.SystemNetworkPacketsTransmit),
+		startTime:                             pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                         pmetric.NewMetrics(),
+		buildInfo:                             buildInfo,
+		metricSystemNetworkConnections:        newMetricSystemNetworkConnections(settings.SystemNetworkConnections),
+		metricSystemNetworkConntrackCount:     newMetricSystemNetworkConntrackCount(settings.SystemNetworkConntrackCount),
+		metricSystemNetworkConntrackMax:       newMetricSystemNetworkConntrackMax(settings.SystemNetworkConntrackMax),
+		metricSystemNetworkDropped:            newMetricSystemNetworkDropped(settings.SystemNetworkDropped),
+		metricSystemNetworkDroppedReceive:     newMetricSystemNetworkDroppedReceive(settings.SystemNetworkDroppedReceive),
+		metricSystemNetworkDroppedTransmit:    newMetricSystemNetworkDroppedTransmit(settings.SystemNetworkDroppedTransmit),
+		metricSystemNetworkErrors:             newMetricSystemNetworkErrors(settings.SystemNetworkErrors),
+		metricSystemNetworkErrorsReceive:      newMetricSystemNetworkErrorsReceive(settings.SystemNetworkErrorsReceive),
+		metricSystemNetworkErrorsTransmit:     newMetricSystemNetworkErrorsTransmit(settings.SystemNetworkErrorsTransmit),
+		metricSystemNetworkIo:                 newMetricSystemNetworkIo(settings.SystemNetworkIo),
+		metricSystemNetworkIoReceive:          newMetricSystemNetworkIoReceive(settings.SystemNetworkIoReceive),
+		metricSystemNetworkIoTransmit:         newMetricSystemNetworkIoTransmit(settings.SystemNetworkIoTransmit),
+		metricSystemNetworkPackets:            newMetricSystemNetworkPackets(settings.SystemNetworkPackets),
+		metricSystemNetworkPacketsReceive:     newMetricSystemNetworkPacketsReceive(settings.SystemNetworkPacketsReceive),
+		metricSystemNetworkPacketsTransmit:    newMetricSystemNetworkPacketsTransmit(settings.SystemNetworkPacketsTransmit),
+		metricSystemNetworkProcessConnections: newMetricSystemNetworkProcessConnections(settings.SystemNetworkProcessConnections),
 	}
 	for _, op := range options {
 		op(mb)
@@ -1052,6 +1118,7 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	mb.metricSystemNetworkPackets.emit(ils.Metrics())
 	mb.metricSystemNetworkPacketsReceive.emit(ils.Metrics())
 	mb.metricSystemNetworkPacketsTransmit.emit(ils.Metrics())
+	mb.metricSystemNetworkProcessConnections.emit(ils.Metrics())
 	for _, op := range rmo {
 		op(rm)
 	}
@@ -1146,6 +1213,11 @@ func (mb *MetricsBuilder) RecordSystemNetworkPacketsTransmitDataPoint(ts pcommon
 	mb.metricSystemNetworkPacketsTransmit.recordDataPoint(mb.startTime, ts, val, deviceAttributeValue)
 }
 
+// RecordSystemNetworkProcessConnectionsDataPoint adds a data point to system.network.process.connections metric.
+func (mb *MetricsBuilder) RecordSystemNetworkProcessConnectionsDataPoint(ts pcommon.Timestamp, val int64, protocolAttributeValue AttributeProtocol, stateAttributeValue string, processPidAttributeValue int64, processExecutableNameAttributeValue string) {
+	mb.metricSystemNetworkProcessConnections.recordDataPoint(mb.startTime, ts, val, protocolAttributeValue.String(), stateAttributeValue, processPidAttributeValue, processExecutableNameAttributeValue)
+}
+
 // Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
 // and metrics builder should update its startTime and reset it's internal state accordingly.
 func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/shirou/gopsutil/v3/host"
 	"github.com/shirou/gopsutil/v3/net"
+	"github.com/shirou/gopsutil/v3/process"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -51,6 +52,7 @@ type scraper struct {
 	ioCounters                           func(bool) ([]net.IOCountersStat, error)
 	connections                          func(string) ([]net.ConnectionStat, error)
 	conntrack                            func() ([]net.FilterStat, error)
+	processName                          func(int32) (string, error)
 	emitMetricsWithDirectionAttribute    bool
 	emitMetricsWithoutDirectionAttribute bool
 }
@@ -64,6 +66,7 @@ func newNetworkScraper(_ context.Context, settings component.ReceiverCreateSetti
 		ioCounters:                           net.IOCounters,
 		connections:                          net.Connections,
 		conntrack:                            net.FilterCounters,
+		processName:                          getProcessName,
 		emitMetricsWithDirectionAttribute:    featuregate.GetRegistry().IsEnabled(internal.EmitMetricsWithDirectionAttributeFeatureGateID),
 		emitMetricsWithoutDirectionAttribute: featuregate.GetRegistry().IsEnabled(internal.EmitMetricsWithoutDirectionAttributeFeatureGateID),
 	}
@@ -204,6 +207,19 @@ func (s *scraper) recordNetworkConnectionsMetrics() error {
 	tcpConnectionStatusCounts := getTCPConnectionStatusCounts(connections)
 
 	s.recordNetworkConnectionsMetric(now, tcpConnectionStatusCounts)
+
+	if !s.config.Metrics.SystemNetworkProcessConnections.Enabled {
+		return nil
+	}
+
+	udpConnections, err := s.connections("udp")
+	if err != nil {
+		return fmt.Errorf("failed to read UDP connections: %w", err)
+	}
+
+	processNames := map[int32]string{}
+	s.recordNetworkProcessConnectionsMetric(now, metadata.AttributeProtocolTcp, connections, processNames)
+	s.recordNetworkProcessConnectionsMetric(now, metadata.AttributeProtocolUdp, udpConnections, processNames)
 	return nil
 }
 
@@ -225,6 +241,42 @@ func (s *scraper) recordNetworkConnectionsMetric(now pcommon.Timestamp, connecti
 	}
 }
 
+// processConnectionsKey identifies the sockets of a process in a state.
+type processConnectionsKey struct {
+	pid   int32
+	state string
+}
+
+// recordNetworkProcessConnectionsMetric records the number of sockets of each process in each state. The sockets
+// whose process isn't known, such as the TCP connections in the TIME_WAIT state, are not attributed.
+func (s *scraper) recordNetworkProcessConnectionsMetric(now pcommon.Timestamp, protocol metadata.AttributeProtocol, connections []net.ConnectionStat, processNames map[int32]string) {
+	counts := map[processConnectionsKey]int64{}
+	for _, connection := range connections {
+		if connection.Pid == 0 {
+			continue
+		}
+		counts[processConnectionsKey{pid: connection.Pid, state: connection.Status}]++
+	}
+
+	for key, count := range counts {
+		name, ok := processNames[key.pid]
+		if !ok {
+			// the process may have exited since its sockets were listed
+			name, _ = s.processName(key.pid)
+			processNames[key.pid] = name
+		}
+		s.mb.RecordSystemNetworkProcessConnectionsDataPoint(now, count, protocol, key.state, int64(key.pid), name)
+	}
+}
+
+func getProcessName(pid int32) (string, error) {
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		return "", err
+	}
+	return proc.Name()
+}
+
 func (s *scraper) filterByInterface(ioCounters []net.IOCountersStat) []net.IOCountersStat {
 	if s.includeFS == nil && s.excludeFS == nil {
 		return ioCounters
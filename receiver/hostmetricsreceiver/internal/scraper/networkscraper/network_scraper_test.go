@@ -235,6 +235,79 @@ func TestScrape(t *testing.T) {
 	}
 }
 
+func TestScrapeProcessConnections(t *testing.T) {
+	config := &Config{Metrics: metadata.DefaultMetricsSettings()}
+	config.Metrics.SystemNetworkProcessConnections.Enabled = true
+	scraper, err := newNetworkScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), config)
+	require.NoError(t, err)
+	scraper.connections = func(kind string) ([]net.ConnectionStat, error) {
+		if kind == "udp" {
+			return []net.ConnectionStat{{Status: "NONE", Pid: 20}}, nil
+		}
+		return []net.ConnectionStat{
+			{Status: "ESTABLISHED", Pid: 10},
+			{Status: "ESTABLISHED", Pid: 10},
+			{Status: "LISTEN", Pid: 10},
+			{Status: "CLOSE_WAIT", Pid: 20},
+			{Status: "TIME_WAIT"},
+		}, nil
+	}
+	scraper.processName = func(pid int32) (string, error) {
+		if pid == 20 {
+			return "", errors.New("process exited")
+		}
+		return "nginx", nil
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	var metric pmetric.Metric
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == "system.network.process.connections" {
+			metric = metrics.At(i)
+		}
+	}
+	require.Equal(t, "system.network.process.connections", metric.Name())
+
+	counts := map[string]int64{}
+	dps := metric.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		protocol, _ := attrs.Get("protocol")
+		state, _ := attrs.Get("state")
+		pid, _ := attrs.Get("process.pid")
+		name, _ := attrs.Get("process.executable.name")
+		counts[protocol.Str()+"/"+state.Str()+"/"+pid.AsString()+"/"+name.Str()] = dps.At(i).IntValue()
+	}
+	assert.Equal(t, map[string]int64{
+		"tcp/ESTABLISHED/10/nginx": 2,
+		"tcp/LISTEN/10/nginx":      1,
+		"tcp/CLOSE_WAIT/20/":       1,
+		"udp/NONE/20/":             1,
+	}, counts)
+}
+
+func TestScrapeProcessConnectionsError(t *testing.T) {
+	config := &Config{Metrics: metadata.DefaultMetricsSettings()}
+	config.Metrics.SystemNetworkProcessConnections.Enabled = true
+	scraper, err := newNetworkScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), config)
+	require.NoError(t, err)
+	scraper.connections = func(kind string) ([]net.ConnectionStat, error) {
+		if kind == "udp" {
+			return nil, errors.New("err4")
+		}
+		return nil, nil
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	_, err = scraper.scrape(context.Background())
+	assert.EqualError(t, err, "failed to read UDP connections: err4")
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+}
+
 func assertNetworkIOMetricValid(t *testing.T, metric pmetric.Metric, expectedName string, startTime pcommon.Timestamp, expectDirectionRemoved bool) {
 	assert.Equal(t, expectedName, metric.Name())
 	if startTime != 0 {
@@ -91,6 +91,11 @@ type processHandle interface {
 	MemoryInfo() (*process.MemoryInfoStat, error)
 	IOCounters() (*process.IOCountersStat, error)
 	NumThreads() (int32, error)
+	NumFDs() (int32, error)
+	Rlimit() ([]process.RlimitStat, error)
+	RlimitUsage(bool) ([]process.RlimitStat, error)
+	NumCtxSwitches() (*process.NumCtxSwitchesStat, error)
+	PageFaults() (*process.PageFaultsStat, error)
 	CreateTime() (int64, error)
 	Parent() (*process.Process, error)
 }
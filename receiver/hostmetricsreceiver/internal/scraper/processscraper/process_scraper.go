@@ -18,8 +18,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
+	"github.com/shirou/gopsutil/v3/process"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -38,6 +40,12 @@ const (
 	threadMetricsLen = 1
 
 	metricsLen = cpuMetricsLen + memoryMetricsLen + diskMetricsLen + threadMetricsLen
+
+	fileDescriptorMetricsLen      = 1
+	fileDescriptorLimitMetricsLen = 1
+	contextSwitchMetricsLen       = 1
+	pagingMetricsLen              = 1
+	signalMetricsLen              = 1
 )
 
 // scraper for Process Metrics
@@ -123,6 +131,26 @@ func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
 			errs.AddPartial(threadMetricsLen, fmt.Errorf("error reading thread info for process %q (pid %v): %w", md.executable.name, md.pid, err))
 		}
 
+		if err = s.scrapeAndAppendOpenFileDescriptorsMetric(now, md.handle); err != nil {
+			errs.AddPartial(fileDescriptorMetricsLen, fmt.Errorf("error reading open file descriptors for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		}
+
+		if err = s.scrapeAndAppendOpenFileDescriptorsLimitMetric(now, md.handle); err != nil {
+			errs.AddPartial(fileDescriptorLimitMetricsLen, fmt.Errorf("error reading open file descriptors limit for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		}
+
+		if err = s.scrapeAndAppendContextSwitchMetrics(now, md.handle); err != nil {
+			errs.AddPartial(contextSwitchMetricsLen, fmt.Errorf("error reading context switch counts for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		}
+
+		if err = s.scrapeAndAppendPagingMetric(now, md.handle); err != nil {
+			errs.AddPartial(pagingMetricsLen, fmt.Errorf("error reading paging faults for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		}
+
+		if err = s.scrapeAndAppendSignalsPendingMetric(now, md.handle); err != nil {
+			errs.AddPartial(signalMetricsLen, fmt.Errorf("error reading pending signals for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		}
+
 		options := append(md.resourceOptions(), metadata.WithStartTimeOverride(pcommon.Timestamp(md.createTime*1e6)))
 		s.mb.EmitForResource(options...)
 	}
@@ -253,3 +281,79 @@ func (s *scraper) scrapeAndAppendThreadsMetrics(now pcommon.Timestamp, handle pr
 
 	return nil
 }
+
+func (s *scraper) scrapeAndAppendOpenFileDescriptorsMetric(now pcommon.Timestamp, handle processHandle) error {
+	if !s.config.Metrics.ProcessOpenFileDescriptors.Enabled {
+		return nil
+	}
+	fds, err := handle.NumFDs()
+	if err != nil {
+		return err
+	}
+	s.mb.RecordProcessOpenFileDescriptorsDataPoint(now, int64(fds))
+
+	return nil
+}
+
+func (s *scraper) scrapeAndAppendOpenFileDescriptorsLimitMetric(now pcommon.Timestamp, handle processHandle) error {
+	if !s.config.Metrics.ProcessOpenFileDescriptorsLimit.Enabled {
+		return nil
+	}
+	rlimits, err := handle.Rlimit()
+	if err != nil {
+		return err
+	}
+	for _, rlimit := range rlimits {
+		// an unlimited soft limit does not fit in an int64 and is not recorded
+		if rlimit.Resource == process.RLIMIT_NOFILE && rlimit.Soft <= math.MaxInt64 {
+			s.mb.RecordProcessOpenFileDescriptorsLimitDataPoint(now, int64(rlimit.Soft))
+		}
+	}
+
+	return nil
+}
+
+func (s *scraper) scrapeAndAppendContextSwitchMetrics(now pcommon.Timestamp, handle processHandle) error {
+	if !s.config.Metrics.ProcessContextSwitches.Enabled {
+		return nil
+	}
+	contextSwitches, err := handle.NumCtxSwitches()
+	if err != nil {
+		return err
+	}
+	s.mb.RecordProcessContextSwitchesDataPoint(now, contextSwitches.Involuntary, metadata.AttributeContextSwitchTypeInvoluntary)
+	s.mb.RecordProcessContextSwitchesDataPoint(now, contextSwitches.Voluntary, metadata.AttributeContextSwitchTypeVoluntary)
+
+	return nil
+}
+
+func (s *scraper) scrapeAndAppendPagingMetric(now pcommon.Timestamp, handle processHandle) error {
+	if !s.config.Metrics.ProcessPagingFaults.Enabled {
+		return nil
+	}
+	pageFaults, err := handle.PageFaults()
+	if err != nil {
+		return err
+	}
+	s.mb.RecordProcessPagingFaultsDataPoint(now, int64(pageFaults.MajorFaults), metadata.AttributePagingFaultTypeMajor)
+	s.mb.RecordProcessPagingFaultsDataPoint(now, int64(pageFaults.MinorFaults), metadata.AttributePagingFaultTypeMinor)
+
+	return nil
+}
+
+func (s *scraper) scrapeAndAppendSignalsPendingMetric(now pcommon.Timestamp, handle processHandle) error {
+	if !s.config.Metrics.ProcessSignalsPending.Enabled {
+		return nil
+	}
+	rlimits, err := handle.RlimitUsage(true)
+	if err != nil {
+		return err
+	}
+	for _, rlimit := range rlimits {
+		if rlimit.Resource == process.RLIMIT_SIGPENDING {
+			s.mb.RecordProcessSignalsPendingDataPoint(now, int64(rlimit.Used))
+		}
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"runtime"
 	"testing"
 	"time"
@@ -346,6 +347,31 @@ func (p *processHandleMock) NumThreads() (int32, error) {
 	return args.Get(0).(int32), args.Error(1)
 }
 
+func (p *processHandleMock) NumFDs() (int32, error) {
+	args := p.MethodCalled("NumFDs")
+	return args.Get(0).(int32), args.Error(1)
+}
+
+func (p *processHandleMock) Rlimit() ([]process.RlimitStat, error) {
+	args := p.MethodCalled("Rlimit")
+	return args.Get(0).([]process.RlimitStat), args.Error(1)
+}
+
+func (p *processHandleMock) RlimitUsage(gatherUsed bool) ([]process.RlimitStat, error) {
+	args := p.MethodCalled("RlimitUsage", gatherUsed)
+	return args.Get(0).([]process.RlimitStat), args.Error(1)
+}
+
+func (p *processHandleMock) NumCtxSwitches() (*process.NumCtxSwitchesStat, error) {
+	args := p.MethodCalled("NumCtxSwitches")
+	return args.Get(0).(*process.NumCtxSwitchesStat), args.Error(1)
+}
+
+func (p *processHandleMock) PageFaults() (*process.PageFaultsStat, error) {
+	args := p.MethodCalled("PageFaults")
+	return args.Get(0).(*process.PageFaultsStat), args.Error(1)
+}
+
 func (p *processHandleMock) CreateTime() (int64, error) {
 	args := p.MethodCalled("CreateTime")
 	return args.Get(0).(int64), args.Error(1)
@@ -729,3 +755,111 @@ func TestScrapeMetrics_MuteProcessNameError(t *testing.T) {
 		})
 	}
 }
+
+func TestScrapeMetrics_OptionalMetrics(t *testing.T) {
+	metricsSettings := metadata.DefaultMetricsSettings()
+	metricsSettings.ProcessOpenFileDescriptors.Enabled = true
+	metricsSettings.ProcessOpenFileDescriptorsLimit.Enabled = true
+	metricsSettings.ProcessContextSwitches.Enabled = true
+	metricsSettings.ProcessPagingFaults.Enabled = true
+	metricsSettings.ProcessSignalsPending.Enabled = true
+
+	scraper, err := newProcessScraper(componenttest.NewNopReceiverCreateSettings(), &Config{Metrics: metricsSettings})
+	require.NoError(t, err, "Failed to create process scraper: %v", err)
+	err = scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+	handleMock := newDefaultHandleMock()
+	handleMock.On("Name").Return("test", nil)
+	handleMock.On("Exe").Return("test", nil)
+	handleMock.On("CreateTime").Return(int64(0), nil)
+	handleMock.On("NumFDs").Return(int32(12), nil)
+	handleMock.On("Rlimit").Return([]process.RlimitStat{
+		{Resource: process.RLIMIT_CPU, Soft: math.MaxUint64, Hard: math.MaxUint64},
+		{Resource: process.RLIMIT_NOFILE, Soft: 1024, Hard: 4096},
+	}, nil)
+	handleMock.On("RlimitUsage", true).Return([]process.RlimitStat{
+		{Resource: process.RLIMIT_NOFILE, Soft: 1024, Hard: 4096, Used: 12},
+		{Resource: process.RLIMIT_SIGPENDING, Soft: 63432, Hard: 63432, Used: 2},
+	}, nil)
+	handleMock.On("NumCtxSwitches").Return(&process.NumCtxSwitchesStat{Voluntary: 30, Involuntary: 4}, nil)
+	handleMock.On("PageFaults").Return(&process.PageFaultsStat{MinorFaults: 500, MajorFaults: 7}, nil)
+
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	fds := getMetric(t, "process.open_file_descriptors", md.ResourceMetrics())
+	require.Equal(t, 1, fds.Sum().DataPoints().Len())
+	assert.Equal(t, int64(12), fds.Sum().DataPoints().At(0).IntValue())
+
+	fdsLimit := getMetric(t, "process.open_file_descriptors.limit", md.ResourceMetrics())
+	require.Equal(t, 1, fdsLimit.Sum().DataPoints().Len())
+	assert.Equal(t, int64(1024), fdsLimit.Sum().DataPoints().At(0).IntValue())
+
+	contextSwitches := getMetric(t, "process.context_switches", md.ResourceMetrics())
+	assert.True(t, contextSwitches.Sum().IsMonotonic())
+	require.Equal(t, 2, contextSwitches.Sum().DataPoints().Len())
+	assert.Equal(t, int64(4), contextSwitches.Sum().DataPoints().At(0).IntValue())
+	internal.AssertSumMetricHasAttributeValue(t, contextSwitches, 0, "context_switch_type",
+		pcommon.NewValueStr(metadata.AttributeContextSwitchTypeInvoluntary.String()))
+	assert.Equal(t, int64(30), contextSwitches.Sum().DataPoints().At(1).IntValue())
+	internal.AssertSumMetricHasAttributeValue(t, contextSwitches, 1, "context_switch_type",
+		pcommon.NewValueStr(metadata.AttributeContextSwitchTypeVoluntary.String()))
+
+	pagingFaults := getMetric(t, "process.paging.faults", md.ResourceMetrics())
+	assert.True(t, pagingFaults.Sum().IsMonotonic())
+	require.Equal(t, 2, pagingFaults.Sum().DataPoints().Len())
+	assert.Equal(t, int64(7), pagingFaults.Sum().DataPoints().At(0).IntValue())
+	internal.AssertSumMetricHasAttributeValue(t, pagingFaults, 0, "paging_fault_type",
+		pcommon.NewValueStr(metadata.AttributePagingFaultTypeMajor.String()))
+	assert.Equal(t, int64(500), pagingFaults.Sum().DataPoints().At(1).IntValue())
+	internal.AssertSumMetricHasAttributeValue(t, pagingFaults, 1, "paging_fault_type",
+		pcommon.NewValueStr(metadata.AttributePagingFaultTypeMinor.String()))
+
+	signalsPending := getMetric(t, "process.signals_pending", md.ResourceMetrics())
+	require.Equal(t, 1, signalsPending.Sum().DataPoints().Len())
+	assert.Equal(t, int64(2), signalsPending.Sum().DataPoints().At(0).IntValue())
+}
+
+func TestScrapeMetrics_OptionalMetricsErrors(t *testing.T) {
+	metricsSettings := metadata.DefaultMetricsSettings()
+	metricsSettings.ProcessOpenFileDescriptors.Enabled = true
+	metricsSettings.ProcessOpenFileDescriptorsLimit.Enabled = true
+	metricsSettings.ProcessContextSwitches.Enabled = true
+	metricsSettings.ProcessPagingFaults.Enabled = true
+	metricsSettings.ProcessSignalsPending.Enabled = true
+
+	scraper, err := newProcessScraper(componenttest.NewNopReceiverCreateSettings(), &Config{Metrics: metricsSettings})
+	require.NoError(t, err, "Failed to create process scraper: %v", err)
+	err = scraper.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+
+	handleMock := newDefaultHandleMock()
+	handleMock.On("Name").Return("test", nil)
+	handleMock.On("Exe").Return("test", nil)
+	handleMock.On("CreateTime").Return(int64(0), nil)
+	handleMock.On("NumFDs").Return(int32(0), errors.New("err1"))
+	handleMock.On("Rlimit").Return([]process.RlimitStat(nil), errors.New("err2"))
+	handleMock.On("NumCtxSwitches").Return((*process.NumCtxSwitchesStat)(nil), errors.New("err3"))
+	handleMock.On("PageFaults").Return((*process.PageFaultsStat)(nil), errors.New("err4"))
+	handleMock.On("RlimitUsage", true).Return([]process.RlimitStat(nil), errors.New("err5"))
+
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+
+	md, err := scraper.scrape(context.Background())
+	assert.Equal(t, cpuMetricsLen+memoryMetricsLen+diskMetricsLen, md.MetricCount())
+	assert.EqualError(t, err, `error reading open file descriptors for process "test" (pid 1): err1; `+
+		`error reading open file descriptors limit for process "test" (pid 1): err2; `+
+		`error reading context switch counts for process "test" (pid 1): err3; `+
+		`error reading paging faults for process "test" (pid 1): err4; `+
+		`error reading pending signals for process "test" (pid 1): err5`)
+	var scraperErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &scraperErr)
+	assert.Equal(t, fileDescriptorMetricsLen+fileDescriptorLimitMetricsLen+contextSwitchMetricsLen+pagingMetricsLen+signalMetricsLen, scraperErr.Failed)
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemscraper"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+const (
+	// lastBootTimeKey is the storage key of the last boot time seen by the receiver.
+	lastBootTimeKey = "last_boot_time"
+
+	// bootTimeTolerance is the maximum change of the boot time which isn't a reboot, since the
+	// boot time computed by the kernel from the uptime moves with the adjustments of the clock.
+	bootTimeTolerance = 10
+
+	rebootEventName = "system.reboot"
+)
+
+// rebootReceiver emits a log record when the boot time of the host changes, that is when the
+// host rebooted since the previous check.
+type rebootReceiver struct {
+	settings component.ReceiverCreateSettings
+	id       config.ComponentID
+	config   *Config
+	interval time.Duration
+	consumer consumer.Logs
+	client   storage.Client
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+
+	// for mocking
+	bootTime func() (uint64, error)
+	now      func() time.Time
+}
+
+// NewRebootReceiver creates a receiver checking the boot time of the host at each interval, and
+// emitting a log record when the host rebooted.
+func NewRebootReceiver(settings component.ReceiverCreateSettings, id config.ComponentID, cfg *Config, interval time.Duration, consumer consumer.Logs) component.LogsReceiver {
+	return &rebootReceiver{
+		settings: settings,
+		id:       id,
+		config:   cfg,
+		interval: interval,
+		consumer: consumer,
+		bootTime: host.BootTime,
+		now:      time.Now,
+	}
+}
+
+func (r *rebootReceiver) Start(ctx context.Context, host component.Host) error {
+	if r.config.Storage != nil {
+		extension, ok := host.GetExtensions()[*r.config.Storage]
+		if !ok {
+			return fmt.Errorf("storage extension '%s' not found", r.config.Storage)
+		}
+		storageExtension, ok := extension.(storage.Extension)
+		if !ok {
+			return fmt.Errorf("non-storage extension '%s' found", r.config.Storage)
+		}
+		client, err := storageExtension.GetClient(ctx, component.KindReceiver, r.id, TypeStr)
+		if err != nil {
+			return err
+		}
+		r.client = client
+	}
+
+	lastBootTime, err := r.loadLastBootTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	checkCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			lastBootTime = r.check(checkCtx, lastBootTime)
+			select {
+			case <-checkCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *rebootReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	if r.client != nil {
+		return r.client.Close(ctx)
+	}
+	return nil
+}
+
+// check emits a log record if the boot time changed since the last boot time, and returns the
+// new last boot time.
+func (r *rebootReceiver) check(ctx context.Context, lastBootTime uint64) uint64 {
+	bootTime, err := r.bootTime()
+	if err != nil {
+		r.settings.Logger.Error("Failed to get the boot time", zap.Error(err))
+		return lastBootTime
+	}
+	if lastBootTime != 0 && absDiff(bootTime, lastBootTime) <= bootTimeTolerance {
+		return lastBootTime
+	}
+
+	if lastBootTime != 0 {
+		if err = r.consumer.ConsumeLogs(ctx, r.rebootLogs(bootTime, lastBootTime)); err != nil {
+			r.settings.Logger.Error("Failed to emit the reboot event", zap.Error(err))
+			return lastBootTime
+		}
+	}
+	if r.client != nil {
+		if err = r.client.Set(ctx, lastBootTimeKey, []byte(strconv.FormatUint(bootTime, 10))); err != nil {
+			r.settings.Logger.Error("Failed to save the boot time", zap.Error(err))
+		}
+	}
+	return bootTime
+}
+
+// rebootLogs returns the log record of a reboot, with the boot time as timestamp.
+func (r *rebootReceiver) rebootLogs(bootTime, previousBootTime uint64) plog.Logs {
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.Timestamp(bootTime * 1e9))
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(r.now()))
+	lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	lr.SetSeverityText("INFO")
+	lr.Body().SetStr("Host rebooted")
+	lr.Attributes().PutStr("event.name", rebootEventName)
+	lr.Attributes().PutInt("system.boot_time", int64(bootTime))
+	lr.Attributes().PutInt("system.previous_boot_time", int64(previousBootTime))
+	return logs
+}
+
+// loadLastBootTime returns the boot time saved by the previous run of the collector, or 0.
+func (r *rebootReceiver) loadLastBootTime(ctx context.Context) (uint64, error) {
+	if r.client == nil {
+		return 0, nil
+	}
+	data, err := r.client.Get(ctx, lastBootTimeKey)
+	if err != nil || data == nil {
+		return 0, err
+	}
+	lastBootTime, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid last boot time: %w", err)
+	}
+	return lastBootTime, nil
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemscraper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var storageID = config.NewComponentIDWithName("file_storage", "hostmetrics")
+
+// memoryStorage is a storage extension keeping the data of its single client in memory.
+type memoryStorage struct {
+	data map[string][]byte
+}
+
+var _ storage.Extension = (*memoryStorage)(nil)
+var _ storage.Client = (*memoryStorage)(nil)
+
+func (s *memoryStorage) Start(context.Context, component.Host) error { return nil }
+func (s *memoryStorage) Shutdown(context.Context) error              { return nil }
+func (s *memoryStorage) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return s, nil
+}
+func (s *memoryStorage) Get(_ context.Context, key string) ([]byte, error) { return s.data[key], nil }
+func (s *memoryStorage) Set(_ context.Context, key string, value []byte) error {
+	s.data[key] = value
+	return nil
+}
+func (s *memoryStorage) Delete(_ context.Context, key string) error {
+	delete(s.data, key)
+	return nil
+}
+func (s *memoryStorage) Batch(context.Context, ...storage.Operation) error { return nil }
+func (s *memoryStorage) Close(context.Context) error                       { return nil }
+
+type storageHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *storageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func newTestRebootReceiver(cfg *Config, sink *consumertest.LogsSink, bootTime uint64) *rebootReceiver {
+	r := NewRebootReceiver(componenttest.NewNopReceiverCreateSettings(), config.NewComponentID("hostmetrics"), cfg, 10*time.Millisecond, sink).(*rebootReceiver)
+	r.bootTime = func() (uint64, error) { return bootTime, nil }
+	return r
+}
+
+func TestRebootReceiverDetectsReboot(t *testing.T) {
+	store := &memoryStorage{data: map[string][]byte{lastBootTimeKey: []byte("1664620000")}}
+	host := &storageHost{Host: componenttest.NewNopHost(), extensions: map[config.ComponentID]component.Extension{storageID: store}}
+	sink := new(consumertest.LogsSink)
+	r := newTestRebootReceiver(&Config{Storage: &storageID}, sink, bootTime)
+
+	require.NoError(t, r.Start(context.Background(), host))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() > 0 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// The reboot is reported once.
+	require.Equal(t, 1, sink.LogRecordCount())
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.Timestamp(bootTime*1e9), lr.Timestamp())
+	assert.Equal(t, plog.SeverityNumberInfo, lr.SeverityNumber())
+	assert.Equal(t, "Host rebooted", lr.Body().Str())
+	assert.Equal(t, map[string]interface{}{
+		"event.name":                rebootEventName,
+		"system.boot_time":          int64(bootTime),
+		"system.previous_boot_time": int64(1664620000),
+	}, lr.Attributes().AsRaw())
+	assert.Equal(t, []byte("1664625600"), store.data[lastBootTimeKey])
+}
+
+func TestRebootReceiverCheck(t *testing.T) {
+	tests := []struct {
+		name         string
+		lastBootTime uint64
+		wantReboot   bool
+		wantBootTime uint64
+	}{
+		{
+			name:         "first check",
+			wantBootTime: bootTime,
+		},
+		{
+			name:         "same boot time",
+			lastBootTime: bootTime,
+			wantBootTime: bootTime,
+		},
+		{
+			name:         "clock adjustment",
+			lastBootTime: bootTime - 1,
+			wantBootTime: bootTime - 1,
+		},
+		{
+			name:         "reboot",
+			lastBootTime: bootTime - 3600,
+			wantReboot:   true,
+			wantBootTime: bootTime,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			r := newTestRebootReceiver(&Config{}, sink, bootTime)
+
+			assert.Equal(t, tt.wantBootTime, r.check(context.Background(), tt.lastBootTime))
+			assert.Equal(t, tt.wantReboot, sink.LogRecordCount() == 1)
+		})
+	}
+}
+
+func TestRebootReceiverStorageNotFound(t *testing.T) {
+	r := newTestRebootReceiver(&Config{Storage: &storageID}, new(consumertest.LogsSink), bootTime)
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'file_storage/hostmetrics' not found")
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemscraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemscraper/internal/metadata"
+)
+
+const bootTime = 1664625600
+
+func TestScrape(t *testing.T) {
+	settings := componenttest.NewNopReceiverCreateSettings()
+	settings.BuildInfo.Version = "0.61.0"
+	scraper := newSystemScraper(context.Background(), settings, &Config{Metrics: metadata.DefaultMetricsSettings()})
+	scraper.bootTime = func() (uint64, error) { return bootTime, nil }
+	scraper.info = func() (*host.InfoStat, error) {
+		return &host.InfoStat{
+			BootTime:        bootTime,
+			OS:              "linux",
+			Platform:        "ubuntu",
+			PlatformVersion: "22.04",
+			KernelVersion:   "5.15.0-48-generic",
+		}, nil
+	}
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	bootTimeMetric := metrics.At(0)
+	assert.Equal(t, "system.boot_time", bootTimeMetric.Name())
+	assert.Equal(t, pmetric.MetricTypeGauge, bootTimeMetric.Type())
+	assert.Equal(t, int64(bootTime), bootTimeMetric.Gauge().DataPoints().At(0).IntValue())
+
+	infoMetric := metrics.At(1)
+	assert.Equal(t, "system.info", infoMetric.Name())
+	dp := infoMetric.Gauge().DataPoints().At(0)
+	assert.Equal(t, int64(1), dp.IntValue())
+	assert.Equal(t, map[string]interface{}{
+		"os.type":           "linux",
+		"os.name":           "ubuntu",
+		"os.version":        "22.04",
+		"kernel.version":    "5.15.0-48-generic",
+		"collector.version": "0.61.0",
+	}, dp.Attributes().AsRaw())
+}
+
+func TestScrapeError(t *testing.T) {
+	scraper := newSystemScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), &Config{Metrics: metadata.DefaultMetricsSettings()})
+	scraper.bootTime = func() (uint64, error) { return bootTime, nil }
+	scraper.info = func() (*host.InfoStat, error) { return nil, errors.New("err1") }
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	_, err := scraper.scrape(context.Background())
+	assert.EqualError(t, err, "err1")
+
+	isPartial := scrapererror.IsPartialScrapeError(err)
+	require.True(t, isPartial)
+	var scraperErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &scraperErr)
+	assert.Equal(t, metricsLen, scraperErr.Failed)
+}
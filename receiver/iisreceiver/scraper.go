@@ -19,6 +19,8 @@ package iisreceiver // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -48,6 +50,7 @@ type iisReceiver struct {
 
 // watcherRecorder is a struct containing perf counter watcher along with corresponding value recorder.
 type watcherRecorder struct {
+	object   string
 	watcher  winperfcounters.PerfCounterWatcher
 	recorder recordFunc
 }
@@ -87,10 +90,11 @@ func (rcvr *iisReceiver) scrape(ctx context.Context) (pmetric.Metrics, error) {
 }
 
 func (rcvr *iisReceiver) scrapeTotalMetrics(now pcommon.Timestamp) {
+	objectErrs := map[string][]error{}
 	for _, wr := range rcvr.totalWatcherRecorders {
 		counterValues, err := wr.watcher.ScrapeData()
 		if err != nil {
-			rcvr.params.Logger.Warn("some performance counters could not be scraped; ", zap.Error(err))
+			objectErrs[wr.object] = append(objectErrs[wr.object], err)
 			continue
 		}
 		value := 0.0
@@ -99,6 +103,7 @@ func (rcvr *iisReceiver) scrapeTotalMetrics(now pcommon.Timestamp) {
 		}
 		wr.recorder(rcvr.metricBuilder, now, value)
 	}
+	rcvr.logObjectErrors(objectErrs)
 
 	// resource for total metrics is empty
 	// this makes it so that the order that the scrape functions are called doesn't matter
@@ -115,11 +120,12 @@ func (rcvr *iisReceiver) scrapeInstanceMetrics(now pcommon.Timestamp, wrs []watc
 	// so that we can emit all metrics for a particular instance (site, app_pool) at once,
 	// keeping them in a single resource metric.
 	instanceToRecorders := map[string][]valRecorder{}
+	objectErrs := map[string][]error{}
 
 	for _, wr := range wrs {
 		counterValues, err := wr.watcher.ScrapeData()
 		if err != nil {
-			rcvr.params.Logger.Warn("some performance counters could not be scraped; ", zap.Error(err))
+			objectErrs[wr.object] = append(objectErrs[wr.object], err)
 			continue
 		}
 
@@ -139,6 +145,7 @@ func (rcvr *iisReceiver) scrapeInstanceMetrics(now pcommon.Timestamp, wrs []watc
 				})
 		}
 	}
+	rcvr.logObjectErrors(objectErrs)
 
 	// record all metrics for each instance, then emit them all as a single resource metric
 	for instanceName, recorders := range instanceToRecorders {
@@ -150,6 +157,24 @@ func (rcvr *iisReceiver) scrapeInstanceMetrics(now pcommon.Timestamp, wrs []watc
 	}
 }
 
+// logObjectErrors logs a warning for each performance object some counters of which could not be scraped.
+func (rcvr *iisReceiver) logObjectErrors(objectErrs map[string][]error) {
+	objects := make([]string, 0, len(objectErrs))
+	for object := range objectErrs {
+		objects = append(objects, object)
+	}
+	sort.Strings(objects)
+
+	for _, object := range objects {
+		errs := objectErrs[object]
+		rcvr.params.Logger.Warn("some performance counters could not be scraped; ",
+			zap.Error(multierr.Combine(errs...)),
+			zap.String("object", object),
+			zap.Int("failed_counters", len(errs)),
+		)
+	}
+}
+
 // shutdown closes the watchers
 func (rcvr iisReceiver) shutdown(ctx context.Context) error {
 	var errs error
@@ -163,13 +188,19 @@ func (rcvr *iisReceiver) buildWatcherRecorders(confs []perfCounterRecorderConf,
 	wrs := []watcherRecorder{}
 
 	for _, pcr := range confs {
+		var errs []error
 		for perfCounterName, recorder := range pcr.recorders {
 			w, err := rcvr.newWatcher(pcr.object, pcr.instance, perfCounterName)
 			if err != nil {
-				scrapeErrors.AddPartial(1, err)
+				errs = append(errs, err)
 				continue
 			}
-			wrs = append(wrs, watcherRecorder{w, recorder})
+			wrs = append(wrs, watcherRecorder{pcr.object, w, recorder})
+		}
+
+		if len(errs) > 0 {
+			scrapeErrors.AddPartial(len(errs), fmt.Errorf("failed to create %d performance counters of object %q: %w",
+				len(errs), pcr.object, multierr.Combine(errs...)))
 		}
 	}
 
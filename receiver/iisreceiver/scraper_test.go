@@ -19,6 +19,7 @@ package iisreceiver // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"testing"
@@ -96,6 +97,58 @@ func TestScrapeFailure(t *testing.T) {
 	require.EqualError(t, log.Context[0].Interface.(error), expectedError)
 }
 
+func TestScrapeFailureGroupedByObject(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	core, obs := observer.New(zapcore.WarnLevel)
+	rcvrSettings := componenttest.NewNopReceiverCreateSettings()
+	rcvrSettings.Logger = zap.New(core)
+
+	scraper := newIisReceiver(
+		rcvrSettings,
+		cfg,
+		consumertest.NewNop(),
+	)
+
+	failingWatcher, err := newMockWatcherFactory(errors.New("failure to collect metric"), 1)("", "", "")
+	require.NoError(t, err)
+	recorder := func(mb *metadata.MetricsBuilder, ts pcommon.Timestamp, val float64) {
+		mb.RecordIisConnectionActiveDataPoint(ts, int64(val))
+	}
+	scraper.siteWatcherRecorders = []watcherRecorder{
+		{object: "Web Service", watcher: failingWatcher, recorder: recorder},
+		{object: "Web Service", watcher: failingWatcher, recorder: recorder},
+	}
+
+	scraper.scrape(context.Background())
+
+	require.Equal(t, 1, obs.Len())
+	fields := obs.All()[0].ContextMap()
+	require.Equal(t, "Web Service", fields["object"])
+	require.Equal(t, int64(2), fields["failed_counters"])
+}
+
+func TestStartFailureGroupedByObject(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	scraper := newIisReceiver(
+		componenttest.NewNopReceiverCreateSettings(),
+		cfg,
+		consumertest.NewNop(),
+	)
+	scraper.newWatcher = func(object, instance, counterName string) (winperfcounters.PerfCounterWatcher, error) {
+		if object == "Process" {
+			return nil, fmt.Errorf("failed to create perf counter %s", counterName)
+		}
+		return newMockWatcherFactory(nil, 1)(object, instance, counterName)
+	}
+
+	err := scraper.start(context.Background(), componenttest.NewNopHost())
+	require.EqualError(t, err, `failed to create 1 performance counters of object "Process": failed to create perf counter Thread Count`)
+	require.Len(t, scraper.totalWatcherRecorders, 0)
+	require.NotEmpty(t, scraper.siteWatcherRecorders)
+}
+
 type mockPerfCounter struct {
 	watchErr error
 	value    float64
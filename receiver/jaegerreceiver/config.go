@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/ingestlimit"
 )
 
 const (
@@ -82,6 +84,12 @@ type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	Protocols               `mapstructure:"protocols"`
 	RemoteSampling          *RemoteSamplingConfig `mapstructure:"remote_sampling"`
+	// MaxDecompressedBodySize is the maximum size in bytes of a Thrift HTTP request
+	// body after decompression. Larger requests are rejected with 413. Disabled by default.
+	MaxDecompressedBodySize int64 `mapstructure:"max_decompressed_body_size"`
+	// Quota limits the requests and spans accepted from every client identity on
+	// the collector endpoints (gRPC and Thrift HTTP). Disabled by default.
+	Quota *ingestlimit.QuotaSettings `mapstructure:"quota"`
 }
 
 var _ config.Receiver = (*Config)(nil)
@@ -134,6 +142,16 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.MaxDecompressedBodySize < 0 {
+		return fmt.Errorf("max_decompressed_body_size must not be negative")
+	}
+
+	if cfg.Quota != nil {
+		if err := cfg.Quota.Validate(); err != nil {
+			return fmt.Errorf("invalid quota: %w", err)
+		}
+	}
+
 	return nil
 }
 
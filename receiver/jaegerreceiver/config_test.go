@@ -27,6 +27,8 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/ingestlimit"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -148,6 +150,22 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "limits"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				Protocols: Protocols{
+					ThriftHTTP: &confighttp.HTTPServerSettings{
+						Endpoint: defaultHTTPBindEndpoint,
+					},
+				},
+				MaxDecompressedBodySize: 20971520,
+				Quota: &ingestlimit.QuotaSettings{
+					IdentityAttribute: "tenant",
+					SpansPerSecond:    10000,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -288,6 +306,20 @@ func TestInvalidConfig(t *testing.T) {
 			},
 			err: "strategy file reload interval should be great zero",
 		},
+		{
+			desc: "negative-max-decompressed-body-size",
+			apply: func(cfg *Config) {
+				cfg.MaxDecompressedBodySize = -1
+			},
+			err: "max_decompressed_body_size must not be negative",
+		},
+		{
+			desc: "empty-quota",
+			apply: func(cfg *Config) {
+				cfg.Quota = &ingestlimit.QuotaSettings{}
+			},
+			err: "quota without any limit must fail",
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -110,6 +110,9 @@ func createTracesReceiver(
 		config.AgentCompactThrift = *rCfg.ThriftCompact
 	}
 
+	config.MaxDecompressedBodySize = rCfg.MaxDecompressedBodySize
+	config.Quota = rCfg.Quota
+
 	// Create the receiver.
 	return newJaegerReceiver(rCfg.ID(), &config, nextConsumer, set), nil
 }
@@ -19,7 +19,6 @@ import (
 	"errors"
 	"fmt"
 	"html"
-	"io"
 	"mime"
 	"net/http"
 	"sync"
@@ -48,7 +47,10 @@ import (
 	"go.opentelemetry.io/collector/obsreport"
 	"go.uber.org/multierr"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/ingestlimit"
 	jaegertranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/jaeger"
 )
 
@@ -61,6 +63,9 @@ type configuration struct {
 	AgentCompactThrift ProtocolUDP
 	AgentBinaryThrift  ProtocolUDP
 	AgentHTTPEndpoint  string
+
+	MaxDecompressedBodySize int64
+	Quota                   *ingestlimit.QuotaSettings
 }
 
 // Receiver type is used to receive spans that were originally intended to be sent to Jaeger.
@@ -83,6 +88,8 @@ type jReceiver struct {
 
 	grpcObsrecv *obsreport.Receiver
 	httpObsrecv *obsreport.Receiver
+
+	quota *ingestlimit.Quota
 }
 
 const (
@@ -110,6 +117,11 @@ func newJaegerReceiver(
 	nextConsumer consumer.Traces,
 	set component.ReceiverCreateSettings,
 ) *jReceiver {
+	var quota *ingestlimit.Quota
+	if config.Quota != nil {
+		quota = ingestlimit.NewQuota(*config.Quota)
+	}
+
 	return &jReceiver{
 		config:       config,
 		nextConsumer: nextConsumer,
@@ -125,6 +137,7 @@ func newJaegerReceiver(
 			Transport:              collectorHTTPTransport,
 			ReceiverCreateSettings: set,
 		}),
+		quota: quota,
 	}
 }
 
@@ -176,7 +189,10 @@ var _ agent.Agent = (*agentHandler)(nil)
 var _ api_v2.CollectorServiceServer = (*jReceiver)(nil)
 var _ configmanager.ClientConfigManager = (*notImplementedConfigManager)(nil)
 
-var errNotImplemented = fmt.Errorf("not implemented")
+var (
+	errNotImplemented = fmt.Errorf("not implemented")
+	errQuotaExceeded  = fmt.Errorf("quota exceeded")
+)
 
 type notImplementedConfigManager struct{}
 
@@ -208,9 +224,18 @@ func (h *agentHandler) EmitBatch(ctx context.Context, batch *jaeger.Batch) error
 }
 
 func (jr *jReceiver) PostSpans(ctx context.Context, r *api_v2.PostSpansRequest) (*api_v2.PostSpansResponse, error) {
+	if !jr.quota.AllowRequest(ctx) {
+		return nil, status.Error(codes.ResourceExhausted, errQuotaExceeded.Error())
+	}
+
 	ctx = jr.grpcObsrecv.StartTracesOp(ctx)
 
 	batch := r.GetBatch()
+	if !jr.quota.AllowSpans(ctx, len(batch.Spans)) {
+		jr.grpcObsrecv.EndTracesOp(ctx, protobufFormat, len(batch.Spans), errQuotaExceeded)
+		return nil, status.Error(codes.ResourceExhausted, errQuotaExceeded.Error())
+	}
+
 	td, err := jaegertranslator.ProtoToTraces([]*model.Batch{&batch})
 	if err != nil {
 		jr.grpcObsrecv.EndTracesOp(ctx, protobufFormat, len(batch.Spans), err)
@@ -309,14 +334,14 @@ func (jr *jReceiver) buildProcessor(address string, cfg ServerConfigUDP, factory
 }
 
 func (jr *jReceiver) decodeThriftHTTPBody(r *http.Request) (*jaeger.Batch, *httpError) {
-	bodyBytes, err := io.ReadAll(r.Body)
-	r.Body.Close()
-	if err != nil {
-		return nil, &httpError{
-			handler.UnableToReadBodyErrFormat,
-			http.StatusInternalServerError,
-		}
+	var maxBodySize int64
+	if jr.config != nil {
+		maxBodySize = jr.config.MaxDecompressedBodySize
 	}
+	// The body is already decompressed by the HTTP server, limiting it here
+	// protects against payloads expanding far beyond their compressed size.
+	body := ingestlimit.NewLimitedReadCloser(r.Body, maxBodySize)
+	defer body.Close()
 
 	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
 	if err != nil {
@@ -332,9 +357,16 @@ func (jr *jReceiver) decodeThriftHTTPBody(r *http.Request) (*jaeger.Batch, *http
 		}
 	}
 
-	tdes := apacheThrift.NewTDeserializer()
+	// Decode the batch while reading the body rather than buffering it first.
+	protocol := apacheThrift.NewTBinaryProtocolConf(apacheThrift.NewStreamTransportR(body), nil)
 	batch := &jaeger.Batch{}
-	if err = tdes.Read(r.Context(), batch, bodyBytes); err != nil {
+	if err = batch.Read(r.Context(), protocol); err != nil {
+		if body.Exceeded() {
+			return nil, &httpError{
+				fmt.Sprintf(handler.UnableToReadBodyErrFormat, ingestlimit.ErrBodyTooLarge),
+				http.StatusRequestEntityTooLarge,
+			}
+		}
 		return nil, &httpError{
 			fmt.Sprintf(handler.UnableToReadBodyErrFormat, err),
 			http.StatusBadRequest,
@@ -345,6 +377,11 @@ func (jr *jReceiver) decodeThriftHTTPBody(r *http.Request) (*jaeger.Batch, *http
 
 // HandleThriftHTTPBatch implements Jaeger HTTP Thrift handler.
 func (jr *jReceiver) HandleThriftHTTPBatch(w http.ResponseWriter, r *http.Request) {
+	if !jr.quota.AllowRequest(r.Context()) {
+		http.Error(w, errQuotaExceeded.Error(), http.StatusTooManyRequests)
+		return
+	}
+
 	ctx := jr.httpObsrecv.StartTracesOp(r.Context())
 
 	batch, hErr := jr.decodeThriftHTTPBody(r)
@@ -354,6 +391,12 @@ func (jr *jReceiver) HandleThriftHTTPBatch(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if !jr.quota.AllowSpans(ctx, len(batch.Spans)) {
+		http.Error(w, errQuotaExceeded.Error(), http.StatusTooManyRequests)
+		jr.httpObsrecv.EndTracesOp(ctx, thriftFormat, len(batch.Spans), errQuotaExceeded)
+		return
+	}
+
 	numSpans, err := consumeTraces(ctx, batch, jr.nextConsumer)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Cannot submit Jaeger batch: %v", err), http.StatusInternalServerError)
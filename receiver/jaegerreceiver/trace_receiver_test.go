@@ -44,10 +44,13 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/ingestlimit"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/jaeger"
 )
 
@@ -83,6 +86,73 @@ func TestThriftHTTPBodyDecode(t *testing.T) {
 	assert.Equal(t, batch, gotBatch)
 }
 
+func TestThriftHTTPBodyDecodeTooLarge(t *testing.T) {
+	batch := &jaegerthrift.Batch{
+		Process: jaegerthrift.NewProcess(),
+		Spans:   []*jaegerthrift.Span{jaegerthrift.NewSpan(), jaegerthrift.NewSpan()},
+	}
+	body, err := thrift.NewTSerializer().Write(context.Background(), batch)
+	require.NoError(t, err)
+
+	jr := jReceiver{config: &configuration{MaxDecompressedBodySize: int64(len(body))}}
+	r, err := jaegerBatchToHTTPBody(batch)
+	require.NoError(t, err)
+	gotBatch, hErr := jr.decodeThriftHTTPBody(r)
+	require.Nil(t, hErr)
+	assert.Equal(t, batch, gotBatch)
+
+	jr = jReceiver{config: &configuration{MaxDecompressedBodySize: int64(len(body)) - 1}}
+	r, err = jaegerBatchToHTTPBody(batch)
+	require.NoError(t, err)
+	_, hErr = jr.decodeThriftHTTPBody(r)
+	require.NotNil(t, hErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, hErr.statusCode)
+}
+
+func TestThriftHTTPQuota(t *testing.T) {
+	config := &configuration{
+		Quota: &ingestlimit.QuotaSettings{SpansPerSecond: 1},
+	}
+	sink := new(consumertest.TracesSink)
+
+	set := componenttest.NewNopReceiverCreateSettings()
+	jr := newJaegerReceiver(jaegerReceiver, config, sink, set)
+
+	batch := &jaegerthrift.Batch{
+		Process: jaegerthrift.NewProcess(),
+		Spans:   []*jaegerthrift.Span{jaegerthrift.NewSpan()},
+	}
+	var statusCodes []int
+	for i := 0; i < 2; i++ {
+		r, err := jaegerBatchToHTTPBody(batch)
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		jr.HandleThriftHTTPBatch(w, r)
+		statusCodes = append(statusCodes, w.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusAccepted, http.StatusTooManyRequests}, statusCodes)
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestGRPCQuota(t *testing.T) {
+	config := &configuration{
+		Quota: &ingestlimit.QuotaSettings{RequestsPerSecond: 1},
+	}
+	sink := new(consumertest.TracesSink)
+
+	set := componenttest.NewNopReceiverCreateSettings()
+	jr := newJaegerReceiver(jaegerReceiver, config, sink, set)
+
+	req := grpcFixture(time.Unix(1542158650, 536343000).UTC(), 10*time.Minute, 2*time.Second)
+	_, err := jr.PostSpans(context.Background(), req)
+	require.NoError(t, err)
+
+	_, err = jr.PostSpans(context.Background(), req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
 func TestReception(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	// 1. Create the Jaeger receiver aka "server"
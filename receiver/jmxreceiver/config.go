@@ -73,6 +73,40 @@ type Config struct {
 	// Log level used by the JMX metric gatherer. Should be one of:
 	// `"trace"`, `"debug"`, `"info"`, `"warn"`, `"error"`, `"off"`
 	LogLevel string `mapstructure:"log_level"`
+	// The JVMs scraped by a single JMX Metric Gatherer in place of the endpoint, reporting the jvm.* metrics
+	// of the semantic conventions with a resource for each target
+	Targets []TargetConfig `mapstructure:"targets"`
+	// The discovery of additional targets from observers
+	Discovery DiscoveryConfig `mapstructure:"discovery"`
+}
+
+// TargetConfig is a JVM scraped in target list mode.
+type TargetConfig struct {
+	// The Service URL or host:port of the JVM
+	Endpoint string `mapstructure:"endpoint"`
+	// The JMX username
+	Username string `mapstructure:"username"`
+	// The JMX password
+	Password string `mapstructure:"password"`
+	// The resource attributes of the metrics of the JVM
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+}
+
+// DiscoveryConfig defines how the JVMs scraped in target list mode are discovered from observers.
+type DiscoveryConfig struct {
+	// The observer extensions the JVMs are discovered from
+	WatchObservers []config.ComponentID `mapstructure:"watch_observers"`
+	// The JMX port of the discovered JVMs, the endpoints on other ports being ignored
+	Port uint16 `mapstructure:"port"`
+	// The JMX username of the discovered JVMs
+	Username string `mapstructure:"username"`
+	// The JMX password of the discovered JVMs
+	Password string `mapstructure:"password"`
+}
+
+// targetListMode returns whether the JVMs are listed in targets or discovered, rather than set as the endpoint.
+func (c *Config) targetListMode() bool {
+	return len(c.Targets) > 0 || len(c.Discovery.WatchObservers) > 0
 }
 
 // We don't embed the existing OTLP Exporter config as most fields are unsupported
@@ -232,10 +266,10 @@ func (c *Config) validate() error {
 	if c.JARPath == "" {
 		missingFields = append(missingFields, "`jar_path`")
 	}
-	if c.Endpoint == "" {
+	if c.Endpoint == "" && !c.targetListMode() {
 		missingFields = append(missingFields, "`endpoint`")
 	}
-	if c.TargetSystem == "" {
+	if c.TargetSystem == "" && !c.targetListMode() {
 		missingFields = append(missingFields, "`target_system`")
 	}
 	if c.JARPath == "" {
@@ -276,6 +310,10 @@ func (c *Config) validate() error {
 		}
 	}
 
+	if c.targetListMode() {
+		return c.validateTargetList()
+	}
+
 	for _, system := range strings.Split(c.TargetSystem, ",") {
 		if _, ok := validTargetSystems[strings.ToLower(system)]; !ok {
 			return fmt.Errorf("%v `target_system` list may only be a subset of %s", c.ID(), listKeys(validTargetSystems))
@@ -285,6 +323,24 @@ func (c *Config) validate() error {
 	return nil
 }
 
+func (c *Config) validateTargetList() error {
+	if c.Endpoint != "" {
+		return fmt.Errorf("%v `endpoint` can't be set with `targets` or `discovery`", c.ID())
+	}
+	if c.TargetSystem != "" && !strings.EqualFold(c.TargetSystem, "jvm") {
+		return fmt.Errorf("%v `target_system` must be 'jvm' with `targets` or `discovery`", c.ID())
+	}
+	for i, target := range c.Targets {
+		if _, err := serviceURL(target.Endpoint); err != nil {
+			return fmt.Errorf("%v `targets[%d]`: %w", c.ID(), i, err)
+		}
+	}
+	if len(c.Discovery.WatchObservers) > 0 && c.Discovery.Port == 0 {
+		return fmt.Errorf("%v missing required field: `discovery.port`", c.ID())
+	}
+	return nil
+}
+
 func listKeys(presenceMap map[string]struct{}) string {
 	list := make([]string, 0, len(presenceMap))
 	for k := range presenceMap {
@@ -214,6 +214,75 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "targets"),
+			expected: &Config{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				JARPath:            "testdata/fake_jmx.jar",
+				CollectionInterval: 10 * time.Second,
+				OTLPExporterConfig: otlpExporterConfig{
+					Endpoint: "0.0.0.0:0",
+					TimeoutSettings: exporterhelper.TimeoutSettings{
+						Timeout: 5 * time.Second,
+					},
+				},
+				Targets: []TargetConfig{
+					{
+						Endpoint: "myendpoint:12345",
+						ResourceAttributes: map[string]string{
+							"service.name": "checkout",
+						},
+					},
+					{
+						Endpoint: "service:jmx:rmi:///jndi/rmi://otherendpoint:23456/jmxrmi",
+						Username: "myusername",
+						Password: "mypassword",
+					},
+				},
+				Discovery: DiscoveryConfig{
+					WatchObservers: []config.ComponentID{config.NewComponentID("k8s_observer")},
+					Port:           9010,
+				},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalidtargetendpoint"),
+			expectedErr: "jmx `targets[0]`: failed to parse Endpoint \"myendpointwithoutport\": address myendpointwithoutport: missing port in address",
+			expected: &Config{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				JARPath:            "testdata/fake_jmx.jar",
+				CollectionInterval: 10 * time.Second,
+				OTLPExporterConfig: otlpExporterConfig{
+					Endpoint: "0.0.0.0:0",
+					TimeoutSettings: exporterhelper.TimeoutSettings{
+						Timeout: 5 * time.Second,
+					},
+				},
+				Targets: []TargetConfig{
+					{
+						Endpoint: "myendpointwithoutport",
+					},
+				},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "missingdiscoveryport"),
+			expectedErr: "jmx missing required field: `discovery.port`",
+			expected: &Config{
+				ReceiverSettings:   config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				JARPath:            "testdata/fake_jmx.jar",
+				CollectionInterval: 10 * time.Second,
+				OTLPExporterConfig: otlpExporterConfig{
+					Endpoint: "0.0.0.0:0",
+					TimeoutSettings: exporterhelper.TimeoutSettings{
+						Timeout: 5 * time.Second,
+					},
+				},
+				Discovery: DiscoveryConfig{
+					WatchObservers: []config.ComponentID{config.NewComponentID("k8s_observer")},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
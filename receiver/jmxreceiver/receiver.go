@@ -16,6 +16,7 @@ package jmxreceiver // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	_ "embed" // for the targets script
 	"fmt"
 	"net"
 	"net/url"
@@ -28,14 +29,25 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/jmxreceiver/internal/subprocess"
 )
 
 // jmxMainClass the class containing the main function for the JMX Metric Gatherer JAR
 const jmxMainClass = "io.opentelemetry.contrib.jmxmetrics.JmxMetrics"
 
+// placeholderServiceURL is the service URL given to the JMX Metric Gatherer in target-list mode without
+// configured targets, the gatherer requiring one while the targets script connects to the targets itself.
+const placeholderServiceURL = "service:jmx:rmi:///jndi/rmi://localhost:9999/jmxrmi"
+
+// targetsScript gathers the jvm.* metrics of the targets listed in the file of the otel.jmx.targets.file property.
+//
+//go:embed targets.groovy
+var targetsScript []byte
+
 var _ component.MetricsReceiver = (*jmxMetricReceiver)(nil)
 
 type jmxMetricReceiver struct {
@@ -46,6 +58,9 @@ type jmxMetricReceiver struct {
 	otlpReceiver component.MetricsReceiver
 	nextConsumer consumer.Metrics
 	configFile   string
+	scriptFile   string
+	targets      *targetList
+	observables  []observer.Observable
 }
 
 func newJMXMetricReceiver(
@@ -65,6 +80,12 @@ func (jmx *jmxMetricReceiver) Start(ctx context.Context, host component.Host) er
 	jmx.logger.Debug("starting JMX Receiver")
 
 	var err error
+	if jmx.config.targetListMode() {
+		if err = jmx.setupTargets(); err != nil {
+			return err
+		}
+	}
+
 	jmx.otlpReceiver, err = jmx.buildOTLPReceiver()
 	if err != nil {
 		return err
@@ -90,9 +111,13 @@ func (jmx *jmxMetricReceiver) Start(ctx context.Context, host component.Host) er
 	}
 
 	jmx.configFile = tmpFile.Name()
+	args := jmx.config.parseProperties(jmx.logger)
+	if jmx.targets != nil {
+		args = append(args, fmt.Sprintf("-Dotel.jmx.targets.file=%s", jmx.targets.path))
+	}
 	subprocessConfig := subprocess.Config{
 		ExecutablePath: "java",
-		Args:           append(jmx.config.parseProperties(jmx.logger), jmxMainClass, "-config", jmx.configFile),
+		Args:           append(args, jmxMainClass, "-config", jmx.configFile),
 		EnvironmentVariables: map[string]string{
 			"CLASSPATH": jmx.config.parseClasspath(),
 			// Overwrite these environment variables to reduce attack surface
@@ -107,6 +132,9 @@ func (jmx *jmxMetricReceiver) Start(ctx context.Context, host component.Host) er
 	if err != nil {
 		return err
 	}
+	if err = jmx.watchObservers(host); err != nil {
+		return err
+	}
 	go func() {
 		for range jmx.subprocess.Stdout {
 			// ensure stdout/stderr buffer is read from.
@@ -119,9 +147,16 @@ func (jmx *jmxMetricReceiver) Start(ctx context.Context, host component.Host) er
 
 func (jmx *jmxMetricReceiver) Shutdown(ctx context.Context) error {
 	jmx.logger.Debug("Shutting down JMX Receiver")
+	for _, observable := range jmx.observables {
+		observable.Unsubscribe(jmx.targets)
+	}
 	subprocessErr := jmx.subprocess.Shutdown(ctx)
 	otlpErr := jmx.otlpReceiver.Shutdown(ctx)
 	removeErr := os.Remove(jmx.configFile)
+	if jmx.targets != nil {
+		removeErr = multierr.Append(removeErr, os.Remove(jmx.scriptFile))
+		removeErr = multierr.Append(removeErr, os.Remove(jmx.targets.path))
+	}
 	if subprocessErr != nil {
 		return subprocessErr
 	}
@@ -131,6 +166,62 @@ func (jmx *jmxMetricReceiver) Shutdown(ctx context.Context) error {
 	return removeErr
 }
 
+// setupTargets writes the targets script and the initial list of targets it reads, in target-list mode.
+func (jmx *jmxMetricReceiver) setupTargets() error {
+	scriptFile, err := os.CreateTemp(os.TempDir(), "jmx-targets-*.groovy")
+	if err != nil {
+		return fmt.Errorf("failed to get tmp file for jmxreceiver targets script: %w", err)
+	}
+	jmx.scriptFile = scriptFile.Name()
+	if _, err = scriptFile.Write(targetsScript); err != nil {
+		scriptFile.Close()
+		return fmt.Errorf("failed to write jmxreceiver targets script: %w", err)
+	}
+	if err = scriptFile.Close(); err != nil {
+		return fmt.Errorf("failed to write jmxreceiver targets script: %w", err)
+	}
+
+	targetsFile, err := os.CreateTemp(os.TempDir(), "jmx-targets-*.tsv")
+	if err != nil {
+		return fmt.Errorf("failed to get tmp file for jmxreceiver targets: %w", err)
+	}
+	if err = targetsFile.Close(); err != nil {
+		return fmt.Errorf("failed to write jmxreceiver targets: %w", err)
+	}
+
+	jmx.targets, err = newTargetList(jmx.config, targetsFile.Name(), jmx.logger)
+	if err != nil {
+		return err
+	}
+	return jmx.targets.write()
+}
+
+// watchObservers subscribes the list of targets to the endpoints of the observers of `discovery.watch_observers`.
+func (jmx *jmxMetricReceiver) watchObservers(host component.Host) error {
+	for _, id := range jmx.config.Discovery.WatchObservers {
+		var observable observer.Observable
+		for cid, ext := range host.GetExtensions() {
+			if cid != id {
+				continue
+			}
+			obs, ok := ext.(observer.Observable)
+			if !ok {
+				return fmt.Errorf("extension %q in watch_observers is not an observer", id.String())
+			}
+			observable = obs
+		}
+		if observable == nil {
+			return fmt.Errorf("failed to find observer %q in the extensions list", id.String())
+		}
+		jmx.observables = append(jmx.observables, observable)
+	}
+
+	for _, observable := range jmx.observables {
+		observable.ListAndWatch(jmx.targets)
+	}
+	return nil
+}
+
 func (jmx *jmxMetricReceiver) buildOTLPReceiver() (component.MetricsReceiver, error) {
 	endpoint := jmx.config.OTLPExporterConfig.Endpoint
 	host, port, err := net.SplitHostPort(endpoint)
@@ -158,32 +249,35 @@ func (jmx *jmxMetricReceiver) buildOTLPReceiver() (component.MetricsReceiver, er
 	config.GRPC.NetAddr = confignet.NetAddr{Endpoint: endpoint, Transport: "tcp"}
 	config.HTTP = nil
 
-	return factory.CreateMetricsReceiver(context.Background(), jmx.params, config, jmx.nextConsumer)
+	nextConsumer := jmx.nextConsumer
+	if jmx.targets != nil {
+		nextConsumer = &targetsConsumer{next: nextConsumer, targets: jmx.targets}
+	}
+	return factory.CreateMetricsReceiver(context.Background(), jmx.params, config, nextConsumer)
 }
 
 func (jmx *jmxMetricReceiver) buildJMXMetricGathererConfig() (string, error) {
 	config := map[string]string{}
-	failedToParse := `failed to parse Endpoint "%s": %w`
-	parsed, err := url.Parse(jmx.config.Endpoint)
-	if err != nil {
-		return "", fmt.Errorf(failedToParse, jmx.config.Endpoint, err)
-	}
-
-	if !(parsed.Scheme == "service" && strings.HasPrefix(parsed.Opaque, "jmx:")) {
-		host, portStr, err := net.SplitHostPort(jmx.config.Endpoint)
-		if err != nil {
-			return "", fmt.Errorf(failedToParse, jmx.config.Endpoint, err)
+	if jmx.config.targetListMode() {
+		config["otel.jmx.service.url"] = placeholderServiceURL
+		if len(jmx.config.Targets) > 0 {
+			url, err := serviceURL(jmx.config.Targets[0].Endpoint)
+			if err != nil {
+				return "", err
+			}
+			config["otel.jmx.service.url"] = url
 		}
-		port, err := strconv.ParseInt(portStr, 10, 0)
+		config["otel.jmx.groovy.script"] = jmx.scriptFile
+	} else {
+		url, err := serviceURL(jmx.config.Endpoint)
 		if err != nil {
-			return "", fmt.Errorf(failedToParse, jmx.config.Endpoint, err)
+			return "", err
 		}
-		jmx.config.Endpoint = fmt.Sprintf("service:jmx:rmi:///jndi/rmi://%v:%d/jmxrmi", host, port)
+		jmx.config.Endpoint = url
+		config["otel.jmx.service.url"] = jmx.config.Endpoint
+		config["otel.jmx.target.system"] = jmx.config.TargetSystem
 	}
-
-	config["otel.jmx.service.url"] = jmx.config.Endpoint
 	config["otel.jmx.interval.milliseconds"] = strconv.FormatInt(jmx.config.CollectionInterval.Milliseconds(), 10)
-	config["otel.jmx.target.system"] = jmx.config.TargetSystem
 
 	endpoint := jmx.config.OTLPExporterConfig.Endpoint
 	if !strings.HasPrefix(endpoint, "http") {
@@ -266,3 +360,30 @@ func (jmx *jmxMetricReceiver) buildJMXMetricGathererConfig() (string, error) {
 
 	return strings.Join(content, "\n"), nil
 }
+
+// serviceURL returns the JMX service URL of an endpoint, given either as a service URL or as host:port.
+func serviceURL(endpoint string) (string, error) {
+	failedToParse := `failed to parse Endpoint "%s": %w`
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf(failedToParse, endpoint, err)
+	}
+	if parsed.Scheme == "service" && strings.HasPrefix(parsed.Opaque, "jmx:") {
+		return endpoint, nil
+	}
+
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", fmt.Errorf(failedToParse, endpoint, err)
+	}
+	port, err := strconv.ParseInt(portStr, 10, 0)
+	if err != nil {
+		return "", fmt.Errorf(failedToParse, endpoint, err)
+	}
+	return rmiServiceURL(host, strconv.FormatInt(port, 10)), nil
+}
+
+// rmiServiceURL returns the JMX service URL of the RMI connector listening on host:port.
+func rmiServiceURL(host string, port string) string {
+	return fmt.Sprintf("service:jmx:rmi:///jndi/rmi://%s/jmxrmi", net.JoinHostPort(host, port))
+}
@@ -170,6 +170,34 @@ otel.resource.attributes = abc=123,one=two`,
 	}
 }
 
+func TestBuildJMXMetricGathererConfigTargetList(t *testing.T) {
+	params := componenttest.NewNopReceiverCreateSettings()
+	receiver := newJMXMetricReceiver(params, &Config{
+		TargetSystem:       "jvm",
+		CollectionInterval: 10 * time.Second,
+		OTLPExporterConfig: otlpExporterConfig{
+			Endpoint: "myotlpendpoint",
+			TimeoutSettings: exporterhelper.TimeoutSettings{
+				Timeout: 5 * time.Second,
+			},
+		},
+		Targets: []TargetConfig{
+			{Endpoint: "myhost:12345"},
+			{Endpoint: "myotherhost:23456"},
+		},
+	}, consumertest.NewNop())
+	receiver.scriptFile = "/tmp/jmx-targets.groovy"
+
+	jmxConfig, err := receiver.buildJMXMetricGathererConfig()
+	require.NoError(t, err)
+	require.Equal(t, `otel.exporter.otlp.endpoint = http://myotlpendpoint
+otel.exporter.otlp.timeout = 5000
+otel.jmx.groovy.script = /tmp/jmx-targets.groovy
+otel.jmx.interval.milliseconds = 10000
+otel.jmx.service.url = service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi
+otel.metrics.exporter = otlp`, jmxConfig)
+}
+
 func TestBuildOTLPReceiverInvalidEndpoints(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,337 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jmxreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/jmxreceiver"
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// targetAttribute is the attribute of the data points recorded by the targets script holding the
+// ID of their target.
+const targetAttribute = "jmx.target"
+
+var _ observer.Notify = (*targetList)(nil)
+
+// target is a JVM scraped by the targets script, identified by its service URL.
+type target struct {
+	serviceURL string
+	username   string
+	password   string
+	resource   map[string]string
+}
+
+// targetList maintains the file listing the JVMs scraped by the targets script, from the configured
+// targets and the endpoints discovered by the observers.
+type targetList struct {
+	logger    *zap.Logger
+	path      string
+	discovery DiscoveryConfig
+
+	mu         sync.Mutex
+	static     []target
+	discovered map[observer.EndpointID]target
+}
+
+func newTargetList(cfg *Config, path string, logger *zap.Logger) (*targetList, error) {
+	static := make([]target, 0, len(cfg.Targets))
+	for _, t := range cfg.Targets {
+		url, err := serviceURL(t.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		static = append(static, target{
+			serviceURL: url,
+			username:   t.Username,
+			password:   t.Password,
+			resource:   targetResource(url, t.ResourceAttributes),
+		})
+	}
+
+	return &targetList{
+		logger:     logger,
+		path:       path,
+		discovery:  cfg.Discovery,
+		static:     static,
+		discovered: map[observer.EndpointID]target{},
+	}, nil
+}
+
+// targetResource returns the resource attributes of a target, identifying the JVM by its service URL
+// unless set otherwise.
+func targetResource(serviceURL string, attributes map[string]string) map[string]string {
+	resource := map[string]string{conventions.AttributeServiceInstanceID: serviceURL}
+	for k, v := range attributes {
+		resource[k] = v
+	}
+	return resource
+}
+
+// targets returns the targets by ID, the configured targets taking precedence over the discovered ones.
+func (l *targetList) targets() map[string]target {
+	targets := make(map[string]target, len(l.static)+len(l.discovered))
+	for _, t := range l.discovered {
+		targets[t.serviceURL] = t
+	}
+	for _, t := range l.static {
+		targets[t.serviceURL] = t
+	}
+	return targets
+}
+
+// write replaces the file read by the targets script, one target per line.
+func (l *targetList) write() error {
+	l.mu.Lock()
+	targets := l.targets()
+	l.mu.Unlock()
+
+	ids := make([]string, 0, len(targets))
+	for id := range targets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var b strings.Builder
+	for _, id := range ids {
+		t := targets[id]
+		fields := []string{id, t.serviceURL, t.username, t.password}
+		for i, f := range fields {
+			fields[i] = base64.StdEncoding.EncodeToString([]byte(f))
+		}
+		b.WriteString(strings.Join(fields, "\t"))
+		b.WriteString("\n")
+	}
+
+	// the file is replaced at once, not to be read by the script while partially written
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0600); err != nil {
+		return fmt.Errorf("failed to write the targets file: %w", err)
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return fmt.Errorf("failed to write the targets file: %w", err)
+	}
+	return nil
+}
+
+// resource returns the resource attributes of the target of the given ID.
+func (l *targetList) resource(id string) (map[string]string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t, ok := l.targets()[id]
+	return t.resource, ok
+}
+
+func (l *targetList) ID() observer.NotifyID {
+	return observer.NotifyID(fmt.Sprintf("jmxreceiver/%s", l.path))
+}
+
+func (l *targetList) OnAdd(added []observer.Endpoint) {
+	l.update(added, nil)
+}
+
+func (l *targetList) OnRemove(removed []observer.Endpoint) {
+	l.update(nil, removed)
+}
+
+func (l *targetList) OnChange(changed []observer.Endpoint) {
+	l.update(changed, nil)
+}
+
+func (l *targetList) update(added []observer.Endpoint, removed []observer.Endpoint) {
+	l.mu.Lock()
+	for _, e := range removed {
+		delete(l.discovered, e.ID)
+	}
+	for _, e := range added {
+		t, ok := l.endpointTarget(e)
+		if !ok {
+			delete(l.discovered, e.ID)
+			continue
+		}
+		l.discovered[e.ID] = t
+	}
+	l.mu.Unlock()
+
+	if err := l.write(); err != nil {
+		l.logger.Error("Failed to update the JMX targets", zap.Error(err))
+	}
+}
+
+// endpointTarget returns the target of a discovered endpoint, if listening on the JMX port.
+func (l *targetList) endpointTarget(e observer.Endpoint) (target, bool) {
+	attributes := map[string]string{}
+	switch details := e.Details.(type) {
+	case *observer.Port:
+		if details.Port != l.discovery.Port {
+			return target{}, false
+		}
+		attributes[conventions.AttributeK8SPodName] = details.Pod.Name
+		attributes[conventions.AttributeK8SPodUID] = details.Pod.UID
+		attributes[conventions.AttributeK8SNamespaceName] = details.Pod.Namespace
+	case *observer.HostPort:
+		if details.Port != l.discovery.Port {
+			return target{}, false
+		}
+		attributes[conventions.AttributeProcessExecutableName] = details.ProcessName
+	case *observer.Container:
+		if details.Port != l.discovery.Port && details.AlternatePort != l.discovery.Port {
+			return target{}, false
+		}
+		attributes[conventions.AttributeContainerName] = details.Name
+		attributes[conventions.AttributeContainerID] = details.ContainerID
+		attributes[conventions.AttributeContainerImageName] = details.Image
+	default:
+		return target{}, false
+	}
+
+	host, port, err := net.SplitHostPort(e.Target)
+	if err != nil {
+		l.logger.Debug("Ignoring the discovered JMX endpoint", zap.String("endpoint", e.Target), zap.Error(err))
+		return target{}, false
+	}
+	url := rmiServiceURL(host, port)
+	for k, v := range attributes {
+		if v == "" {
+			delete(attributes, k)
+		}
+	}
+	return target{
+		serviceURL: url,
+		username:   l.discovery.Username,
+		password:   l.discovery.Password,
+		resource:   targetResource(url, attributes),
+	}, true
+}
+
+// split moves the data points of each target to a resource of their own, with the resource attributes of
+// the target added to the resource of the metrics.
+func (l *targetList) split(md pmetric.Metrics) pmetric.Metrics {
+	out := pmetric.NewMetrics()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resources := map[string]pmetric.ResourceMetrics{}
+		resourceOf := func(id string) pmetric.ResourceMetrics {
+			if res, ok := resources[id]; ok {
+				return res
+			}
+			res := out.ResourceMetrics().AppendEmpty()
+			res.SetSchemaUrl(rm.SchemaUrl())
+			rm.Resource().CopyTo(res.Resource())
+			if attributes, ok := l.resource(id); ok {
+				for k, v := range attributes {
+					res.Resource().Attributes().PutStr(k, v)
+				}
+			}
+			resources[id] = res
+			return res
+		}
+
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			scopes := map[string]pmetric.ScopeMetrics{}
+			scopeOf := func(id string) pmetric.ScopeMetrics {
+				if scope, ok := scopes[id]; ok {
+					return scope
+				}
+				scope := resourceOf(id).ScopeMetrics().AppendEmpty()
+				scope.SetSchemaUrl(sm.SchemaUrl())
+				sm.Scope().CopyTo(scope.Scope())
+				scopes[id] = scope
+				return scope
+			}
+
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				splitMetric(metrics.At(k), scopeOf)
+			}
+		}
+	}
+	return out
+}
+
+// splitMetric copies the data points of the metric to the scope of their target, the metrics other than
+// gauges and sums being left in the scope without target.
+func splitMetric(metric pmetric.Metric, scopeOf func(id string) pmetric.ScopeMetrics) {
+	var dps pmetric.NumberDataPointSlice
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps = metric.Gauge().DataPoints()
+	case pmetric.MetricTypeSum:
+		dps = metric.Sum().DataPoints()
+	default:
+		metric.CopyTo(scopeOf("").Metrics().AppendEmpty())
+		return
+	}
+
+	copies := map[string]pmetric.NumberDataPointSlice{}
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		var id string
+		if v, ok := dp.Attributes().Get(targetAttribute); ok {
+			id = v.Str()
+		}
+
+		dest, ok := copies[id]
+		if !ok {
+			m := scopeOf(id).Metrics().AppendEmpty()
+			m.SetName(metric.Name())
+			m.SetDescription(metric.Description())
+			m.SetUnit(metric.Unit())
+			if metric.Type() == pmetric.MetricTypeSum {
+				sum := m.SetEmptySum()
+				sum.SetAggregationTemporality(metric.Sum().AggregationTemporality())
+				sum.SetIsMonotonic(metric.Sum().IsMonotonic())
+				dest = sum.DataPoints()
+			} else {
+				dest = m.SetEmptyGauge().DataPoints()
+			}
+			copies[id] = dest
+		}
+
+		copied := dest.AppendEmpty()
+		dp.CopyTo(copied)
+		copied.Attributes().Remove(targetAttribute)
+	}
+}
+
+// targetsConsumer moves the data points of each target to a resource of their own before passing them on.
+type targetsConsumer struct {
+	next    consumer.Metrics
+	targets *targetList
+}
+
+func (c *targetsConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *targetsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return c.next.ConsumeMetrics(ctx, c.targets.split(md))
+}
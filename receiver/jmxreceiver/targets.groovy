@@ -0,0 +1,193 @@
+/*
+ * Copyright The OpenTelemetry Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+// Collects the jvm.* metrics of the semantic conventions from each of the JVMs listed in the file of the
+// otel.jmx.targets.file system property, maintained by the JMX receiver in target list mode. Each line of
+// the file lists the base64 encoded ID, service URL, username and password of a target, separated by tabs.
+// The data points are recorded with the jmx.target attribute holding the ID of their target, which the
+// receiver replaces with the resource of the target.
+
+import io.opentelemetry.api.common.AttributeKey
+import io.opentelemetry.api.common.Attributes
+import java.util.logging.Logger
+import javax.management.ObjectName
+import javax.management.openmbean.CompositeData
+import javax.management.remote.JMXConnector
+import javax.management.remote.JMXConnectorFactory
+import javax.management.remote.JMXServiceURL
+
+def logger = Logger.getLogger("jmxreceiver.targets")
+
+def targetKey = AttributeKey.stringKey("jmx.target")
+def memoryTypeKey = AttributeKey.stringKey("jvm.memory.type")
+def memoryPoolKey = AttributeKey.stringKey("jvm.memory.pool.name")
+def daemonKey = AttributeKey.booleanKey("jvm.thread.daemon")
+
+def decode = { String field -> new String(Base64.decoder.decode(field), "UTF-8") }
+
+// the connections are kept between the runs of the script, in its binding
+if (!binding.hasVariable("jmxConnectors")) {
+    binding.setVariable("jmxConnectors", [:])
+}
+Map<String, JMXConnector> connectors = binding.getVariable("jmxConnectors")
+
+def targets = [:]
+def targetsFile = new File(System.getProperty("otel.jmx.targets.file"))
+if (targetsFile.exists()) {
+    targetsFile.eachLine { line ->
+        def fields = line.split("\t", -1)
+        if (fields.length == 4) {
+            targets[decode(fields[0])] = [
+                url: decode(fields[1]),
+                username: decode(fields[2]),
+                password: decode(fields[3]),
+            ]
+        }
+    }
+}
+
+// close the connections of the removed targets
+connectors.keySet().findAll { !targets.containsKey(it) }.each { id ->
+    try {
+        connectors.remove(id).close()
+    } catch (Exception ignored) {
+    }
+}
+
+def connect = { String id, Map target ->
+    def connector = connectors[id]
+    if (connector != null) {
+        try {
+            connector.getConnectionId()
+            return connector.getMBeanServerConnection()
+        } catch (Exception e) {
+            connectors.remove(id)
+            try {
+                connector.close()
+            } catch (Exception ignored) {
+            }
+        }
+    }
+    def env = [:]
+    if (target.username) {
+        env[JMXConnector.CREDENTIALS] = [target.username, target.password] as String[]
+    }
+    connector = JMXConnectorFactory.connect(new JMXServiceURL(target.url), env)
+    connectors[id] = connector
+    return connector.getMBeanServerConnection()
+}
+
+// the values of each target are read once per run, and recorded by the callbacks at export time
+def snapshots = []
+targets.each { id, target ->
+    try {
+        def connection = connect(id, target)
+        def snapshot = [id: id, pools: []]
+
+        connection.queryNames(new ObjectName("java.lang:type=MemoryPool,*"), null).each { name ->
+            def pool = [
+                name: connection.getAttribute(name, "Name"),
+                type: connection.getAttribute(name, "Type") == "HEAP" ? "heap" : "non_heap",
+                usage: connection.getAttribute(name, "Usage") as CompositeData,
+            ]
+            try {
+                pool.afterGC = connection.getAttribute(name, "CollectionUsage") as CompositeData
+            } catch (Exception ignored) {
+            }
+            snapshot.pools << pool
+        }
+
+        def threading = new ObjectName("java.lang:type=Threading")
+        snapshot.threads = connection.getAttribute(threading, "ThreadCount") as long
+        snapshot.daemonThreads = connection.getAttribute(threading, "DaemonThreadCount") as long
+
+        def classLoading = new ObjectName("java.lang:type=ClassLoading")
+        snapshot.classesLoaded = connection.getAttribute(classLoading, "TotalLoadedClassCount") as long
+        snapshot.classesUnloaded = connection.getAttribute(classLoading, "UnloadedClassCount") as long
+        snapshot.classes = connection.getAttribute(classLoading, "LoadedClassCount") as long
+
+        def os = new ObjectName("java.lang:type=OperatingSystem")
+        snapshot.cpus = connection.getAttribute(os, "AvailableProcessors") as long
+        try {
+            snapshot.cpuTime = (connection.getAttribute(os, "ProcessCpuTime") as long) / 1e9
+            snapshot.cpuUtilization = connection.getAttribute(os, "ProcessCpuLoad") as double
+        } catch (Exception ignored) {
+            // only available with the com.sun.management extension of the operating system MBean
+        }
+
+        snapshots << snapshot
+    } catch (Exception e) {
+        logger.warning("Failed to collect the metrics of ${target.url}: ${e}")
+    }
+}
+
+def recordPools = { measurement, String key ->
+    snapshots.each { snapshot ->
+        snapshot.pools.each { pool ->
+            def usage = key == "afterGC" ? pool.afterGC : pool.usage
+            def field = key == "afterGC" ? "used" : key
+            if (usage == null) {
+                return
+            }
+            def value = usage.get(field) as long
+            // the limit of the pools without a maximum size is undefined
+            if (value < 0) {
+                return
+            }
+            measurement.record(value, Attributes.of(
+                targetKey, snapshot.id, memoryTypeKey, pool.type, memoryPoolKey, pool.name))
+        }
+    }
+}
+
+otel.longUpDownCounterCallback("jvm.memory.used", "Measure of memory used.", "By") { recordPools(it, "used") }
+otel.longUpDownCounterCallback("jvm.memory.committed", "Measure of memory committed.", "By") { recordPools(it, "committed") }
+otel.longUpDownCounterCallback("jvm.memory.limit", "Measure of max obtainable memory.", "By") { recordPools(it, "max") }
+otel.longUpDownCounterCallback("jvm.memory.init", "Measure of initial memory requested.", "By") { recordPools(it, "init") }
+otel.longUpDownCounterCallback("jvm.memory.used_after_last_gc",
+    "Measure of memory used, as measured after the most recent garbage collection event on this pool.", "By") {
+    recordPools(it, "afterGC")
+}
+
+otel.longUpDownCounterCallback("jvm.thread.count", "Number of executing platform threads.", "{thread}") { measurement ->
+    snapshots.each { snapshot ->
+        measurement.record(snapshot.daemonThreads, Attributes.of(targetKey, snapshot.id, daemonKey, true))
+        measurement.record(snapshot.threads - snapshot.daemonThreads, Attributes.of(targetKey, snapshot.id, daemonKey, false))
+    }
+}
+
+otel.longCounterCallback("jvm.class.loaded", "Number of classes loaded since JVM start.", "{class}") { measurement ->
+    snapshots.each { measurement.record(it.classesLoaded, Attributes.of(targetKey, it.id)) }
+}
+otel.longCounterCallback("jvm.class.unloaded", "Number of classes unloaded since JVM start.", "{class}") { measurement ->
+    snapshots.each { measurement.record(it.classesUnloaded, Attributes.of(targetKey, it.id)) }
+}
+otel.longUpDownCounterCallback("jvm.class.count", "Number of classes currently loaded.", "{class}") { measurement ->
+    snapshots.each { measurement.record(it.classes, Attributes.of(targetKey, it.id)) }
+}
+
+otel.longUpDownCounterCallback("jvm.cpu.count", "Number of processors available to the Java virtual machine.", "{cpu}") { measurement ->
+    snapshots.each { measurement.record(it.cpus, Attributes.of(targetKey, it.id)) }
+}
+otel.doubleCounterCallback("jvm.cpu.time", "CPU time used by the process as reported by the JVM.", "s") { measurement ->
+    snapshots.findAll { it.cpuTime != null }.each { measurement.record(it.cpuTime, Attributes.of(targetKey, it.id)) }
+}
+otel.doubleValueCallback("jvm.cpu.recent_utilization", "Recent CPU utilization for the process as reported by the JVM.", "1") { measurement ->
+    snapshots.findAll { it.cpuUtilization != null && it.cpuUtilization >= 0 }.each {
+        measurement.record(it.cpuUtilization, Attributes.of(targetKey, it.id))
+    }
+}
@@ -0,0 +1,210 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jmxreceiver
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func newTestTargetList(t *testing.T) *targetList {
+	cfg := &Config{
+		Targets: []TargetConfig{
+			{
+				Endpoint: "myhost:12345",
+				Username: "myuser",
+				Password: "my\tpassword",
+				ResourceAttributes: map[string]string{
+					"service.name": "checkout",
+				},
+			},
+		},
+		Discovery: DiscoveryConfig{
+			Port:     9010,
+			Username: "discovereduser",
+		},
+	}
+	targets, err := newTargetList(cfg, filepath.Join(t.TempDir(), "targets.tsv"), zap.NewNop())
+	require.NoError(t, err)
+	return targets
+}
+
+func readTargetsFile(t *testing.T, path string) [][]string {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var lines [][]string
+	for _, line := range strings.Split(strings.TrimSuffix(string(content), "\n"), "\n") {
+		var fields []string
+		for _, f := range strings.Split(line, "\t") {
+			decoded, err := base64.StdEncoding.DecodeString(f)
+			require.NoError(t, err)
+			fields = append(fields, string(decoded))
+		}
+		lines = append(lines, fields)
+	}
+	return lines
+}
+
+func TestTargetListWrite(t *testing.T) {
+	targets := newTestTargetList(t)
+	require.NoError(t, targets.write())
+	assert.Equal(t, [][]string{
+		{"service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi", "service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi", "myuser", "my\tpassword"},
+	}, readTargetsFile(t, targets.path))
+}
+
+func TestTargetListDiscovery(t *testing.T) {
+	targets := newTestTargetList(t)
+	pod := observer.Endpoint{
+		ID:     "k8s_observer/pod-1/jmx",
+		Target: "10.0.0.1:9010",
+		Details: &observer.Port{
+			Name: "jmx",
+			Pod: observer.Pod{
+				Name:      "pod-1",
+				UID:       "uid-1",
+				Namespace: "default",
+			},
+			Port: 9010,
+		},
+	}
+	otherPort := observer.Endpoint{
+		ID:     "k8s_observer/pod-1/http",
+		Target: "10.0.0.1:8080",
+		Details: &observer.Port{
+			Name: "http",
+			Port: 8080,
+		},
+	}
+	container := observer.Endpoint{
+		ID:     "docker_observer/abc/9010",
+		Target: "172.17.0.2:9010",
+		Details: &observer.Container{
+			Name:        "kafka",
+			Image:       "bitnami/kafka",
+			ContainerID: "abc",
+			Port:        9010,
+		},
+	}
+
+	targets.OnAdd([]observer.Endpoint{pod, otherPort, container})
+	assert.Equal(t, [][]string{
+		{"service:jmx:rmi:///jndi/rmi://10.0.0.1:9010/jmxrmi", "service:jmx:rmi:///jndi/rmi://10.0.0.1:9010/jmxrmi", "discovereduser", ""},
+		{"service:jmx:rmi:///jndi/rmi://172.17.0.2:9010/jmxrmi", "service:jmx:rmi:///jndi/rmi://172.17.0.2:9010/jmxrmi", "discovereduser", ""},
+		{"service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi", "service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi", "myuser", "my\tpassword"},
+	}, readTargetsFile(t, targets.path))
+
+	resource, ok := targets.resource("service:jmx:rmi:///jndi/rmi://10.0.0.1:9010/jmxrmi")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"service.instance.id": "service:jmx:rmi:///jndi/rmi://10.0.0.1:9010/jmxrmi",
+		"k8s.pod.name":        "pod-1",
+		"k8s.pod.uid":         "uid-1",
+		"k8s.namespace.name":  "default",
+	}, resource)
+
+	resource, ok = targets.resource("service:jmx:rmi:///jndi/rmi://172.17.0.2:9010/jmxrmi")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"service.instance.id":  "service:jmx:rmi:///jndi/rmi://172.17.0.2:9010/jmxrmi",
+		"container.name":       "kafka",
+		"container.id":         "abc",
+		"container.image.name": "bitnami/kafka",
+	}, resource)
+
+	targets.OnRemove([]observer.Endpoint{pod, container})
+	assert.Equal(t, [][]string{
+		{"service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi", "service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi", "myuser", "my\tpassword"},
+	}, readTargetsFile(t, targets.path))
+}
+
+func TestTargetsConsumer(t *testing.T) {
+	targets := newTestTargetList(t)
+	sink := new(consumertest.MetricsSink)
+	c := &targetsConsumer{next: sink, targets: targets}
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", "collector")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("io.opentelemetry.contrib.jmxmetrics")
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("jvm.thread.count")
+	m.SetUnit("{threads}")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	for _, dp := range []struct {
+		target string
+		value  int64
+	}{
+		{"service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi", 10},
+		{"service:jmx:rmi:///jndi/rmi://unknown:12345/jmxrmi", 20},
+		{"service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi", 30},
+	} {
+		p := sum.DataPoints().AppendEmpty()
+		p.SetIntValue(dp.value)
+		p.Attributes().PutStr(targetAttribute, dp.target)
+		p.Attributes().PutBool("jvm.thread.daemon", true)
+	}
+	h := sm.Metrics().AppendEmpty()
+	h.SetName("histogram")
+	h.SetEmptyHistogram().DataPoints().AppendEmpty()
+
+	require.NoError(t, c.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+	out := sink.AllMetrics()[0]
+	require.Equal(t, 3, out.ResourceMetrics().Len())
+
+	known := out.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		"host.name":           "collector",
+		"service.name":        "checkout",
+		"service.instance.id": "service:jmx:rmi:///jndi/rmi://myhost:12345/jmxrmi",
+	}, known.Resource().Attributes().AsRaw())
+	assert.Equal(t, "io.opentelemetry.contrib.jmxmetrics", known.ScopeMetrics().At(0).Scope().Name())
+	metric := known.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "jvm.thread.count", metric.Name())
+	assert.Equal(t, "{threads}", metric.Unit())
+	assert.Equal(t, pmetric.MetricAggregationTemporalityCumulative, metric.Sum().AggregationTemporality())
+	require.Equal(t, 2, metric.Sum().DataPoints().Len())
+	assert.Equal(t, int64(10), metric.Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(30), metric.Sum().DataPoints().At(1).IntValue())
+	assert.Equal(t, map[string]interface{}{"jvm.thread.daemon": true}, metric.Sum().DataPoints().At(0).Attributes().AsRaw())
+
+	unknown := out.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]interface{}{"host.name": "collector"}, unknown.Resource().Attributes().AsRaw())
+	assert.Equal(t, int64(20), unknown.ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+
+	untargeted := out.ResourceMetrics().At(2)
+	assert.Equal(t, map[string]interface{}{"host.name": "collector"}, untargeted.Resource().Attributes().AsRaw())
+	assert.Equal(t, "histogram", untargeted.ScopeMetrics().At(0).Metrics().At(0).Name())
+
+	// the original data points are left untouched
+	_, ok := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes().Get(targetAttribute)
+	assert.True(t, ok)
+}
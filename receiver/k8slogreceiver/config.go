@@ -0,0 +1,125 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"k8s.io/apimachinery/pkg/labels"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const (
+	startAtBeginning = "beginning"
+	startAtEnd       = "end"
+)
+
+// Config defines configuration for the Kubernetes log receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+	k8sconfig.APIConfig     `mapstructure:",squash"`
+
+	// Namespaces to collect pod logs from. All namespaces are watched when empty.
+	Namespaces []string `mapstructure:"namespaces"`
+
+	// LabelSelector restricts log collection to pods matching the selector,
+	// e.g. "app=frontend,tier!=cache".
+	LabelSelector string `mapstructure:"label_selector"`
+
+	// NodeName restricts log collection to pods scheduled on the given node.
+	// Typically set to ${K8S_NODE_NAME} when the receiver runs as a DaemonSet.
+	NodeName string `mapstructure:"node_name"`
+
+	// Containers to collect logs from. All containers of a matching pod are
+	// collected when empty.
+	Containers []string `mapstructure:"containers"`
+
+	// StartAt defines where to start reading a container log stream that has
+	// no checkpoint yet. Either "beginning" or "end" (default).
+	StartAt string `mapstructure:"start_at"`
+
+	// StorageID is the ID of the storage extension used to persist the
+	// per-container read position, so that a restarted collector resumes
+	// where it stopped rather than re-reading or skipping logs.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+
+	// MaxBatchSize is the maximum number of log records sent downstream at once
+	// for a single container.
+	MaxBatchSize int `mapstructure:"max_batch_size"`
+
+	// FlushInterval is the maximum time a partial batch is held before being
+	// sent downstream.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// RetryInterval is the time to wait before reopening a container log
+	// stream that was closed by the API server, or before sending again log
+	// records refused by the next consumer.
+	RetryInterval time.Duration `mapstructure:"retry_interval"`
+
+	// PodLabels is the list of pod label keys copied to the resource as
+	// "k8s.pod.labels.<key>" attributes.
+	PodLabels []string `mapstructure:"pod_labels"`
+
+	// PodAnnotations is the list of pod annotation keys copied to the resource
+	// as "k8s.pod.annotations.<key>" attributes.
+	PodAnnotations []string `mapstructure:"pod_annotations"`
+
+	// For mocking
+	makeClient func(apiConf k8sconfig.APIConfig) (k8s.Interface, error)
+}
+
+func (cfg *Config) Validate() error {
+	if err := cfg.ReceiverSettings.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.APIConfig.Validate(); err != nil {
+		return err
+	}
+
+	switch cfg.StartAt {
+	case startAtBeginning, startAtEnd:
+	default:
+		return fmt.Errorf("invalid start_at %q, must be one of %q or %q", cfg.StartAt, startAtBeginning, startAtEnd)
+	}
+
+	if cfg.LabelSelector != "" {
+		if _, err := labels.Parse(cfg.LabelSelector); err != nil {
+			return fmt.Errorf("invalid label_selector: %w", err)
+		}
+	}
+
+	if cfg.MaxBatchSize <= 0 {
+		return errors.New("max_batch_size must be greater than 0")
+	}
+	if cfg.FlushInterval <= 0 {
+		return errors.New("flush_interval must be greater than 0")
+	}
+	if cfg.RetryInterval <= 0 {
+		return errors.New("retry_interval must be greater than 0")
+	}
+	return nil
+}
+
+func (cfg *Config) getK8sClient() (k8s.Interface, error) {
+	if cfg.makeClient == nil {
+		cfg.makeClient = k8sconfig.MakeClient
+	}
+	return cfg.makeClient(cfg.APIConfig)
+}
@@ -0,0 +1,107 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slogreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	storageID := config.NewComponentID("file_storage")
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Receiver
+		expectedErr string
+	}{
+		{
+			id:       config.NewComponentIDWithName(typeStr, ""),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "all_settings"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				APIConfig: k8sconfig.APIConfig{
+					AuthType: k8sconfig.AuthTypeKubeConfig,
+				},
+				Namespaces:     []string{"default", "my_namespace"},
+				LabelSelector:  "app=frontend",
+				NodeName:       "node-1",
+				Containers:     []string{"app"},
+				StartAt:        startAtBeginning,
+				StorageID:      &storageID,
+				MaxBatchSize:   500,
+				FlushInterval:  500 * time.Millisecond,
+				RetryInterval:  10 * time.Second,
+				PodLabels:      []string{"app"},
+				PodAnnotations: []string{"team"},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalid_start_at"),
+			expectedErr: `invalid start_at "middle"`,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalid_label_selector"),
+			expectedErr: "invalid label_selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateBatching(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxBatchSize = 0
+	assert.EqualError(t, cfg.Validate(), "max_batch_size must be greater than 0")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.FlushInterval = 0
+	assert.EqualError(t, cfg.Validate(), "flush_interval must be greater than 0")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.RetryInterval = 0
+	assert.EqualError(t, cfg.Validate(), "retry_interval must be greater than 0")
+}
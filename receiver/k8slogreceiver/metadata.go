@@ -0,0 +1,103 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.8.0"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// fillResource sets the resource attributes describing the container the
+// logs are read from.
+func fillResource(resource pcommon.Resource, pod *corev1.Pod, container string, cfg *Config) {
+	attrs := resource.Attributes()
+	attrs.PutStr(conventions.AttributeK8SNamespaceName, pod.Namespace)
+	attrs.PutStr(conventions.AttributeK8SPodName, pod.Name)
+	attrs.PutStr(conventions.AttributeK8SPodUID, string(pod.UID))
+	attrs.PutStr(conventions.AttributeK8SContainerName, container)
+	if pod.Spec.NodeName != "" {
+		attrs.PutStr(conventions.AttributeK8SNodeName, pod.Spec.NodeName)
+	}
+
+	for _, c := range pod.Spec.Containers {
+		if c.Name != container {
+			continue
+		}
+		name, tag := parseImage(c.Image)
+		attrs.PutStr(conventions.AttributeContainerImageName, name)
+		if tag != "" {
+			attrs.PutStr(conventions.AttributeContainerImageTag, tag)
+		}
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != container {
+			continue
+		}
+		attrs.PutInt(conventions.AttributeK8SContainerRestartCount, int64(status.RestartCount))
+		if id := containerID(status.ContainerID); id != "" {
+			attrs.PutStr(conventions.AttributeContainerID, id)
+		}
+	}
+
+	for _, owner := range pod.OwnerReferences {
+		switch owner.Kind {
+		case "ReplicaSet":
+			attrs.PutStr(conventions.AttributeK8SReplicaSetName, owner.Name)
+		case "DaemonSet":
+			attrs.PutStr(conventions.AttributeK8SDaemonSetName, owner.Name)
+		case "StatefulSet":
+			attrs.PutStr(conventions.AttributeK8SStatefulSetName, owner.Name)
+		case "Job":
+			attrs.PutStr(conventions.AttributeK8SJobName, owner.Name)
+		}
+	}
+
+	for _, key := range cfg.PodLabels {
+		if value, ok := pod.Labels[key]; ok {
+			attrs.PutStr("k8s.pod.labels."+key, value)
+		}
+	}
+	for _, key := range cfg.PodAnnotations {
+		if value, ok := pod.Annotations[key]; ok {
+			attrs.PutStr("k8s.pod.annotations."+key, value)
+		}
+	}
+}
+
+// parseImage splits a container image reference into its name and tag.
+// Digest references are returned without a tag.
+func parseImage(image string) (string, string) {
+	if idx := strings.IndexByte(image, '@'); idx >= 0 {
+		return image[:idx], ""
+	}
+	// A colon before the last slash belongs to the registry host and port.
+	if idx := strings.LastIndexByte(image, ':'); idx > strings.LastIndexByte(image, '/') {
+		return image[:idx], image[idx+1:]
+	}
+	return image, ""
+}
+
+// containerID strips the runtime prefix (e.g. "containerd://") from the
+// container ID reported in the pod status.
+func containerID(id string) string {
+	if idx := strings.Index(id, "://"); idx >= 0 {
+		return id[idx+3:]
+	}
+	return id
+}
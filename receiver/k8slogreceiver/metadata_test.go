@@ -0,0 +1,67 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slogreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestFillResource(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.PodLabels = []string{"app", "missing"}
+	cfg.PodAnnotations = []string{"team"}
+
+	resource := pcommon.NewResource()
+	fillResource(resource, getPod(), "app", cfg)
+
+	assert.Equal(t, map[string]interface{}{
+		"k8s.namespace.name":          "test",
+		"k8s.pod.name":                "test-34bcd-rn54",
+		"k8s.pod.uid":                 "059f3edc-b5a9",
+		"k8s.container.name":          "app",
+		"k8s.node.name":               "node-1",
+		"container.image.name":        "registry.local:5000/frontend",
+		"container.image.tag":         "1.2.3",
+		"k8s.container.restart_count": int64(2),
+		"container.id":                "abcdef",
+		"k8s.replicaset.name":         "test-34bcd",
+		"k8s.pod.labels.app":          "frontend",
+		"k8s.pod.annotations.team":    "web",
+	}, resource.Attributes().AsRaw())
+}
+
+func TestParseImage(t *testing.T) {
+	tests := []struct {
+		image string
+		name  string
+		tag   string
+	}{
+		{image: "nginx", name: "nginx"},
+		{image: "nginx:1.23", name: "nginx", tag: "1.23"},
+		{image: "registry.local:5000/nginx", name: "registry.local:5000/nginx"},
+		{image: "registry.local:5000/nginx:1.23", name: "registry.local:5000/nginx", tag: "1.23"},
+		{image: "nginx@sha256:abcdef", name: "nginx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			name, tag := parseImage(tt.image)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.tag, tag)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+type k8slogReceiver struct {
+	config          *Config
+	settings        component.ReceiverCreateSettings
+	client          k8s.Interface
+	logsConsumer    consumer.Logs
+	storageClient   storage.Client
+	stopperChanList []chan struct{}
+	startTime       time.Time
+	ctx             context.Context
+	cancel          context.CancelFunc
+	obsrecv         *obsreport.Receiver
+
+	mu      sync.Mutex
+	streams map[string]*containerStream
+	wg      sync.WaitGroup
+}
+
+// newReceiver creates the Kubernetes log receiver with the given configuration.
+func newReceiver(
+	set component.ReceiverCreateSettings,
+	config *Config,
+	consumer consumer.Logs,
+	client k8s.Interface,
+) (component.LogsReceiver, error) {
+	return &k8slogReceiver{
+		settings:      set,
+		config:        config,
+		client:        client,
+		logsConsumer:  consumer,
+		storageClient: storage.NewNopClient(),
+		startTime:     time.Now(),
+		streams:       map[string]*containerStream{},
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             config.ID(),
+			Transport:              "http",
+			ReceiverCreateSettings: set,
+		}),
+	}, nil
+}
+
+func (kr *k8slogReceiver) Start(ctx context.Context, host component.Host) error {
+	kr.ctx, kr.cancel = context.WithCancel(context.Background())
+
+	storageClient, err := getStorageClient(ctx, host, kr.config.StorageID, kr.config.ID())
+	if err != nil {
+		return fmt.Errorf("failed to set up storage: %w", err)
+	}
+	kr.storageClient = storageClient
+
+	kr.settings.Logger.Info("starting to watch pods for container logs.")
+	if len(kr.config.Namespaces) == 0 {
+		kr.startWatch(corev1.NamespaceAll)
+	} else {
+		for _, ns := range kr.config.Namespaces {
+			kr.startWatch(ns)
+		}
+	}
+
+	return nil
+}
+
+func (kr *k8slogReceiver) Shutdown(ctx context.Context) error {
+	// Stop watching all the namespaces by closing all the stopper channels.
+	for _, stopperChan := range kr.stopperChanList {
+		close(stopperChan)
+	}
+	if kr.cancel != nil {
+		kr.cancel()
+	}
+	kr.wg.Wait()
+	return kr.storageClient.Close(ctx)
+}
+
+func (kr *k8slogReceiver) startWatch(ns string) {
+	stopperChan := make(chan struct{})
+	kr.stopperChanList = append(kr.stopperChanList, stopperChan)
+
+	fs := fields.Everything()
+	if kr.config.NodeName != "" {
+		fs = fields.OneTermEqualSelector("spec.nodeName", kr.config.NodeName)
+	}
+
+	informer := cache.NewSharedInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				opts.LabelSelector = kr.config.LabelSelector
+				opts.FieldSelector = fs.String()
+				return kr.client.CoreV1().Pods(ns).List(kr.ctx, opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				opts.LabelSelector = kr.config.LabelSelector
+				opts.FieldSelector = fs.String()
+				return kr.client.CoreV1().Pods(ns).Watch(kr.ctx, opts)
+			},
+		},
+		&corev1.Pod{},
+		0,
+	)
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				kr.handlePod(pod)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				kr.handlePod(pod)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pod, ok := obj.(*corev1.Pod); ok {
+				kr.handlePodDelete(pod)
+			}
+		},
+	})
+	go informer.Run(stopperChan)
+}
+
+// handlePod starts a log stream for every running container of the pod that
+// is not streamed yet, and refreshes the pod metadata of existing streams.
+func (kr *k8slogReceiver) handlePod(pod *corev1.Pod) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if !kr.includeContainer(status.Name) {
+			continue
+		}
+		key := streamKey(pod, status.Name)
+		running := status.State.Running != nil
+		if stream, ok := kr.streams[key]; ok {
+			stream.update(pod, running)
+			continue
+		}
+		if !running {
+			continue
+		}
+		stream := newContainerStream(kr, key, pod, status.Name, status.State.Running.StartedAt.Time)
+		kr.streams[key] = stream
+		kr.wg.Add(1)
+		go func() {
+			defer kr.wg.Done()
+			stream.run(kr.ctx)
+			kr.removeStream(stream)
+		}()
+	}
+}
+
+// handlePodDelete stops all the log streams of a deleted pod.
+func (kr *k8slogReceiver) handlePodDelete(pod *corev1.Pod) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if stream, ok := kr.streams[streamKey(pod, status.Name)]; ok {
+			stream.stop()
+		}
+	}
+}
+
+func (kr *k8slogReceiver) removeStream(stream *containerStream) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	if kr.streams[stream.key] == stream {
+		delete(kr.streams, stream.key)
+	}
+}
+
+func (kr *k8slogReceiver) includeContainer(name string) bool {
+	if len(kr.config.Containers) == 0 {
+		return true
+	}
+	for _, c := range kr.config.Containers {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (kr *k8slogReceiver) logger() *zap.Logger {
+	return kr.settings.Logger
+}
+
+// streamKey uniquely identifies a container of a pod. It is also used as the
+// checkpoint key in the storage extension.
+func streamKey(pod *corev1.Pod, container string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", pod.Namespace, pod.Name, pod.UID, container)
+}
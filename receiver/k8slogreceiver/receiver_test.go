@@ -0,0 +1,182 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slogreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestNewReceiver(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	client := fake.NewSimpleClientset()
+	r, err := newReceiver(
+		componenttest.NewNopReceiverCreateSettings(),
+		rCfg,
+		consumertest.NewNop(),
+		client,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, r.Shutdown(context.Background()))
+
+	rCfg.Namespaces = []string{"test", "another_test"}
+	rCfg.NodeName = "node-1"
+	r1, err := newReceiver(
+		componenttest.NewNopReceiverCreateSettings(),
+		rCfg,
+		consumertest.NewNop(),
+		client,
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, r1)
+	require.NoError(t, r1.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, r1.Shutdown(context.Background()))
+}
+
+func TestStartWithMissingStorage(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	storageID := storagetest.NewStorageID("missing")
+	rCfg.StorageID = &storageID
+	r, err := newReceiver(
+		componenttest.NewNopReceiverCreateSettings(),
+		rCfg,
+		consumertest.NewNop(),
+		fake.NewSimpleClientset(),
+	)
+	require.NoError(t, err)
+	assert.ErrorContains(t, r.Start(context.Background(), componenttest.NewNopHost()), "failed to set up storage")
+}
+
+func TestReceiveContainerLogs(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.StartAt = startAtBeginning
+	rCfg.FlushInterval = 10 * time.Millisecond
+	rCfg.RetryInterval = time.Hour
+	rCfg.PodLabels = []string{"app"}
+	client := fake.NewSimpleClientset(getPod())
+	sink := new(consumertest.LogsSink)
+	r, err := newReceiver(
+		componenttest.NewNopReceiverCreateSettings(),
+		rCfg,
+		sink,
+		client,
+	)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	attrs := rl.Resource().Attributes().AsRaw()
+	assert.Equal(t, "test", attrs["k8s.namespace.name"])
+	assert.Equal(t, "test-34bcd-rn54", attrs["k8s.pod.name"])
+	assert.Equal(t, "059f3edc-b5a9", attrs["k8s.pod.uid"])
+	assert.Equal(t, "app", attrs["k8s.container.name"])
+	assert.Equal(t, "frontend", attrs["k8s.pod.labels.app"])
+	// The fake clientset always returns "fake logs" as container log.
+	assert.Equal(t, "fake logs", rl.ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestIgnoreContainers(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.Containers = []string{"sidecar"}
+	r, err := newReceiver(
+		componenttest.NewNopReceiverCreateSettings(),
+		rCfg,
+		consumertest.NewNop(),
+		fake.NewSimpleClientset(),
+	)
+	require.NoError(t, err)
+	recv := r.(*k8slogReceiver)
+	recv.ctx = context.Background()
+
+	recv.handlePod(getPod())
+	assert.Empty(t, recv.streams)
+}
+
+func TestHandlePodDelete(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.RetryInterval = time.Hour
+	r, err := newReceiver(
+		componenttest.NewNopReceiverCreateSettings(),
+		rCfg,
+		consumertest.NewNop(),
+		fake.NewSimpleClientset(),
+	)
+	require.NoError(t, err)
+	recv := r.(*k8slogReceiver)
+	recv.ctx = context.Background()
+
+	pod := getPod()
+	recv.handlePod(pod)
+	recv.handlePodDelete(pod)
+	recv.wg.Wait()
+
+	recv.mu.Lock()
+	defer recv.mu.Unlock()
+	assert.Empty(t, recv.streams)
+}
+
+func getPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "test-34bcd-rn54",
+			Namespace:   "test",
+			UID:         types.UID("059f3edc-b5a9"),
+			Labels:      map[string]string{"app": "frontend"},
+			Annotations: map[string]string{"team": "web"},
+			OwnerReferences: []v1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "test-34bcd"},
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+			Containers: []corev1.Container{
+				{Name: "app", Image: "registry.local:5000/frontend:1.2.3"},
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:         "app",
+					ContainerID:  "containerd://abcdef",
+					RestartCount: 2,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{StartedAt: v1.Now()},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8slogreceiver"
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// containerStream follows the log of a single container through the
+// Kubernetes API and forwards it downstream in batches.
+type containerStream struct {
+	receiver  *k8slogReceiver
+	key       string
+	container string
+	startedAt time.Time
+
+	mu      sync.Mutex
+	pod     *corev1.Pod
+	running bool
+	cancel  context.CancelFunc
+	stopped bool
+
+	// checkpoint is the position of the last record sent downstream.
+	checkpoint position
+}
+
+// position is the read position of a container log: the timestamp of the last
+// line sent downstream and the number of lines sent with that timestamp.
+type position struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+}
+
+// next returns the position after a line with the given timestamp.
+func (p position) next(ts time.Time) position {
+	if ts.Equal(p.Timestamp) {
+		return position{Timestamp: p.Timestamp, Count: p.Count + 1}
+	}
+	return position{Timestamp: ts, Count: 1}
+}
+
+// resumeFilter drops the lines of a (re)opened container log stream that were
+// already sent downstream. The API server filters lines at second resolution
+// only, and several lines may share the checkpoint timestamp: only as many of
+// them as recorded in the checkpoint are dropped.
+type resumeFilter struct {
+	from position
+	skip int
+}
+
+func newResumeFilter(from position) *resumeFilter {
+	return &resumeFilter{from: from, skip: from.Count}
+}
+
+// sent reports whether the line with the given timestamp was already sent.
+func (f *resumeFilter) sent(ts time.Time) bool {
+	switch {
+	case ts.IsZero():
+		return false
+	case ts.Before(f.from.Timestamp):
+		return true
+	case ts.Equal(f.from.Timestamp) && f.skip > 0:
+		f.skip--
+		return true
+	default:
+		return false
+	}
+}
+
+func newContainerStream(kr *k8slogReceiver, key string, pod *corev1.Pod, container string, startedAt time.Time) *containerStream {
+	return &containerStream{
+		receiver:  kr,
+		key:       key,
+		container: container,
+		startedAt: startedAt,
+		pod:       pod,
+		running:   true,
+	}
+}
+
+// update refreshes the pod used for metadata enrichment and records whether
+// the container is still running.
+func (s *containerStream) update(pod *corev1.Pod, running bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pod = pod
+	s.running = running
+}
+
+// stop terminates the stream, regardless of the state of the container.
+func (s *containerStream) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+func (s *containerStream) shouldReopen() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running && !s.stopped
+}
+
+func (s *containerStream) currentPod() *corev1.Pod {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pod
+}
+
+// run follows the container log until the container stops running, the pod
+// is deleted or the receiver shuts down.
+func (s *containerStream) run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.mu.Lock()
+	s.cancel = cancel
+	stopped := s.stopped
+	s.mu.Unlock()
+	if stopped {
+		return
+	}
+
+	s.checkpoint = s.loadCheckpoint(ctx)
+	for {
+		if err := s.follow(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			s.receiver.logger().Warn("container log stream interrupted", zap.String("container", s.key), zap.Error(err))
+		}
+		if !s.shouldReopen() {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.receiver.config.RetryInterval):
+		}
+	}
+}
+
+// follow opens the container log stream and reads it until it is closed.
+func (s *containerStream) follow(ctx context.Context) error {
+	pod := s.currentPod()
+	opts := &corev1.PodLogOptions{
+		Container:  s.container,
+		Follow:     true,
+		Timestamps: true,
+	}
+	if since := s.sinceTime(); !since.IsZero() {
+		opts.SinceTime = &metav1.Time{Time: since}
+	}
+
+	rc, err := s.receiver.client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		reader := bufio.NewReader(rc)
+		for {
+			line, err := reader.ReadString('\n')
+			if line = strings.TrimRight(line, "\r\n"); line != "" {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					readErr <- ctx.Err()
+					return
+				}
+			}
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					readErr <- err
+				}
+				return
+			}
+		}
+	}()
+
+	cfg := s.receiver.config
+	ticker := time.NewTicker(cfg.FlushInterval)
+	defer ticker.Stop()
+
+	filter := newResumeFilter(s.checkpoint)
+	read := s.checkpoint
+	b := s.newBatch()
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				if err = s.flush(ctx, b); err != nil {
+					return err
+				}
+				select {
+				case err = <-readErr:
+					return err
+				default:
+					return nil
+				}
+			}
+			ts, body := parseLine(line)
+			if filter.sent(ts) {
+				continue
+			}
+			if !ts.IsZero() {
+				read = read.next(ts)
+			}
+			b.add(ts, body, read)
+			if b.len() >= cfg.MaxBatchSize {
+				if err = s.flush(ctx, b); err != nil {
+					return err
+				}
+				b = s.newBatch()
+			}
+		case <-ticker.C:
+			if b.len() > 0 {
+				if err = s.flush(ctx, b); err != nil {
+					return err
+				}
+				b = s.newBatch()
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// sinceTime returns the time from which the container log must be requested.
+func (s *containerStream) sinceTime() time.Time {
+	if !s.checkpoint.Timestamp.IsZero() {
+		return s.checkpoint.Timestamp
+	}
+	// Containers that were already running when the receiver started are only
+	// read from the receiver start time on, unless configured otherwise.
+	if s.receiver.config.StartAt == startAtEnd && s.startedAt.Before(s.receiver.startTime) {
+		return s.receiver.startTime
+	}
+	return time.Time{}
+}
+
+// flush sends the batch downstream. Failed batches are retried until they are
+// accepted, or rejected permanently, so that the checkpoint never advances
+// past log records that were not sent. An error is only returned when the
+// context is done.
+func (s *containerStream) flush(ctx context.Context, b *batch) error {
+	if b.len() == 0 {
+		return nil
+	}
+	for {
+		obsCtx := s.receiver.obsrecv.StartLogsOp(ctx)
+		err := s.receiver.logsConsumer.ConsumeLogs(obsCtx, b.logs)
+		s.receiver.obsrecv.EndLogsOp(obsCtx, typeStr, b.len(), err)
+		if err == nil {
+			break
+		}
+		if consumererror.IsPermanent(err) {
+			s.receiver.logger().Error("dropping container logs rejected by the next consumer", zap.String("container", s.key), zap.Error(err))
+			break
+		}
+		s.receiver.logger().Warn("failed to consume container logs, retrying", zap.String("container", s.key), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.receiver.config.RetryInterval):
+		}
+	}
+	if !b.position.Timestamp.IsZero() && b.position != s.checkpoint {
+		s.checkpoint = b.position
+		s.saveCheckpoint(ctx)
+	}
+	return nil
+}
+
+func (s *containerStream) loadCheckpoint(ctx context.Context) position {
+	data, err := s.receiver.storageClient.Get(ctx, s.key)
+	if err != nil {
+		s.receiver.logger().Warn("failed to read checkpoint", zap.String("container", s.key), zap.Error(err))
+		return position{}
+	}
+	if data == nil {
+		return position{}
+	}
+	var p position
+	if err = json.Unmarshal(data, &p); err != nil {
+		s.receiver.logger().Warn("ignoring invalid checkpoint", zap.String("container", s.key), zap.Error(err))
+		return position{}
+	}
+	return p
+}
+
+func (s *containerStream) saveCheckpoint(ctx context.Context) {
+	data, err := json.Marshal(s.checkpoint)
+	if err != nil {
+		s.receiver.logger().Warn("failed to encode checkpoint", zap.String("container", s.key), zap.Error(err))
+		return
+	}
+	if err = s.receiver.storageClient.Set(ctx, s.key, data); err != nil {
+		s.receiver.logger().Warn("failed to write checkpoint", zap.String("container", s.key), zap.Error(err))
+	}
+}
+
+// parseLine splits a line returned by the Kubernetes API with timestamps
+// enabled into its timestamp and message. The zero time is returned when the
+// line does not start with a timestamp.
+func parseLine(line string) (time.Time, string) {
+	idx := strings.IndexByte(line, ' ')
+	if idx <= 0 {
+		return time.Time{}, line
+	}
+	ts, err := time.Parse(time.RFC3339Nano, line[:idx])
+	if err != nil {
+		return time.Time{}, line
+	}
+	return ts, line[idx+1:]
+}
+
+// batch accumulates the log records of a single container.
+type batch struct {
+	logs    plog.Logs
+	records plog.LogRecordSlice
+	// position is the read position after the last record of the batch.
+	position position
+}
+
+func (s *containerStream) newBatch() *batch {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	fillResource(rl.Resource(), s.currentPod(), s.container, s.receiver.config)
+	return &batch{
+		logs:    ld,
+		records: rl.ScopeLogs().AppendEmpty().LogRecords(),
+	}
+}
+
+func (b *batch) add(ts time.Time, body string, pos position) {
+	lr := b.records.AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	if !ts.IsZero() {
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	}
+	lr.Body().SetStr(body)
+	b.position = pos
+}
+
+func (b *batch) len() int {
+	return b.records.Len()
+}
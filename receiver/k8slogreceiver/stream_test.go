@@ -0,0 +1,172 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8slogreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestParseLine(t *testing.T) {
+	ts, body := parseLine("2022-10-10T12:00:00.123456789Z hello world")
+	assert.Equal(t, time.Date(2022, 10, 10, 12, 0, 0, 123456789, time.UTC), ts)
+	assert.Equal(t, "hello world", body)
+
+	ts, body = parseLine("hello world")
+	assert.True(t, ts.IsZero())
+	assert.Equal(t, "hello world", body)
+
+	ts, body = parseLine("no-timestamp-at-all")
+	assert.True(t, ts.IsZero())
+	assert.Equal(t, "no-timestamp-at-all", body)
+}
+
+func TestCheckpoint(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	recv := newTestReceiver(t, sink)
+	pod := getPod()
+	s := newContainerStream(recv, streamKey(pod, "app"), pod, "app", time.Now())
+
+	ctx := context.Background()
+	assert.Equal(t, position{}, s.loadCheckpoint(ctx))
+
+	ts := time.Date(2022, 10, 10, 12, 0, 0, 123456789, time.UTC)
+	b := s.newBatch()
+	read := position{}
+	for _, line := range []time.Time{ts.Add(-time.Second), ts, ts} {
+		read = read.next(line)
+		b.add(line, "line", read)
+	}
+	require.NoError(t, s.flush(ctx, b))
+
+	expected := position{Timestamp: ts, Count: 2}
+	assert.Equal(t, 3, sink.LogRecordCount())
+	assert.Equal(t, expected, s.checkpoint)
+	checkpoint := s.loadCheckpoint(ctx)
+	assert.True(t, ts.Equal(checkpoint.Timestamp))
+	assert.Equal(t, 2, checkpoint.Count)
+
+	// A restarted stream resumes from the checkpoint.
+	s2 := newContainerStream(recv, streamKey(pod, "app"), pod, "app", time.Now())
+	s2.checkpoint = s2.loadCheckpoint(ctx)
+	assert.True(t, ts.Equal(s2.sinceTime()))
+}
+
+func TestResumeFilter(t *testing.T) {
+	ts := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	filter := newResumeFilter(position{Timestamp: ts, Count: 2})
+
+	// Lines of the same second returned again by the API server.
+	assert.True(t, filter.sent(ts.Add(-time.Millisecond)))
+	// Only the lines with the checkpoint timestamp already sent are dropped.
+	assert.True(t, filter.sent(ts))
+	assert.True(t, filter.sent(ts))
+	assert.False(t, filter.sent(ts))
+	assert.False(t, filter.sent(ts.Add(time.Millisecond)))
+	// Lines without timestamp are never dropped.
+	assert.False(t, filter.sent(time.Time{}))
+
+	filter = newResumeFilter(position{})
+	assert.False(t, filter.sent(ts))
+}
+
+func TestFlushRetries(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	next := &failingConsumer{Logs: sink, failures: 2}
+	recv := newTestReceiver(t, next)
+	recv.config.RetryInterval = time.Millisecond
+	pod := getPod()
+	s := newContainerStream(recv, streamKey(pod, "app"), pod, "app", time.Now())
+
+	ts := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	b := s.newBatch()
+	b.add(ts, "line", position{Timestamp: ts, Count: 1})
+
+	ctx := context.Background()
+	require.NoError(t, s.flush(ctx, b))
+	assert.Equal(t, 1, sink.LogRecordCount())
+	assert.Equal(t, 3, next.calls)
+	assert.Equal(t, position{Timestamp: ts, Count: 1}, s.checkpoint)
+
+	// The checkpoint does not advance when the context is done before the
+	// batch is accepted.
+	next.failures = 1
+	b = s.newBatch()
+	b.add(ts.Add(time.Second), "line", position{Timestamp: ts.Add(time.Second), Count: 1})
+	recv.config.RetryInterval = time.Hour
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, s.flush(cancelled, b), context.Canceled)
+	assert.Equal(t, position{Timestamp: ts, Count: 1}, s.checkpoint)
+}
+
+// failingConsumer fails the given number of calls before forwarding logs.
+type failingConsumer struct {
+	consumer.Logs
+	failures int
+	calls    int
+}
+
+func (c *failingConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	c.calls++
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("downstream unavailable")
+	}
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+func TestSinceTime(t *testing.T) {
+	recv := newTestReceiver(t, consumertest.NewNop())
+	pod := getPod()
+
+	recv.config.StartAt = startAtEnd
+	s := newContainerStream(recv, streamKey(pod, "app"), pod, "app", recv.startTime.Add(-time.Hour))
+	assert.Equal(t, recv.startTime, s.sinceTime())
+
+	// Containers started after the receiver are read from the beginning.
+	s = newContainerStream(recv, streamKey(pod, "app"), pod, "app", recv.startTime.Add(time.Second))
+	assert.True(t, s.sinceTime().IsZero())
+
+	recv.config.StartAt = startAtBeginning
+	s = newContainerStream(recv, streamKey(pod, "app"), pod, "app", recv.startTime.Add(-time.Hour))
+	assert.True(t, s.sinceTime().IsZero())
+}
+
+func newTestReceiver(t *testing.T, sink consumer.Logs) *k8slogReceiver {
+	r, err := newReceiver(
+		componenttest.NewNopReceiverCreateSettings(),
+		createDefaultConfig().(*Config),
+		sink,
+		fake.NewSimpleClientset(),
+	)
+	require.NoError(t, err)
+	recv := r.(*k8slogReceiver)
+	recv.storageClient = storagetest.NewInMemoryClient(component.KindReceiver, recv.config.ID(), "")
+	return recv
+}
@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+type mode string
+
+const (
+	// PullMode lists the objects periodically.
+	PullMode mode = "pull"
+	// WatchMode watches the objects for additions, updates and deletions.
+	WatchMode mode = "watch"
+
+	defaultMode         mode = PullMode
+	defaultVersion           = "v1"
+	defaultPullInterval      = time.Hour
+)
+
+var modeMap = map[mode]bool{
+	PullMode:  true,
+	WatchMode: true,
+}
+
+var errNoObjects = errors.New("at least one object must be configured")
+
+// K8sObjectsConfig defines the Kubernetes objects to collect and how.
+type K8sObjectsConfig struct {
+	// Name of the resource, in its plural form, such as `pods`, `events`
+	// or the name of a custom resource.
+	Name string `mapstructure:"name"`
+	// Group of the resource, empty for the core resources.
+	Group string `mapstructure:"group"`
+	// Version of the resource, `v1` by default.
+	Version string `mapstructure:"version"`
+	// Namespaces to collect the objects from, all the namespaces if empty.
+	Namespaces []string `mapstructure:"namespaces"`
+	// Mode is either `pull`, to list the objects every Interval, or `watch`,
+	// to get notified of the additions, updates and deletions of the objects.
+	Mode mode `mapstructure:"mode"`
+	// Interval between the lists of the objects in pull mode.
+	Interval time.Duration `mapstructure:"interval"`
+	// LabelSelector filters the objects by their labels.
+	LabelSelector string `mapstructure:"label_selector"`
+	// FieldSelector filters the objects by their fields.
+	FieldSelector string `mapstructure:"field_selector"`
+}
+
+// gvr returns the group, version and resource of the objects.
+func (c *K8sObjectsConfig) gvr() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: c.Group, Version: c.Version, Resource: c.Name}
+}
+
+func (c *K8sObjectsConfig) validate() error {
+	if c.Name == "" {
+		return errors.New("name must be specified")
+	}
+	if !modeMap[c.Mode] {
+		return fmt.Errorf("invalid mode %q for %s", c.Mode, c.Name)
+	}
+	if c.Mode == PullMode && c.Interval <= 0 {
+		return fmt.Errorf("interval must be positive for %s", c.Name)
+	}
+	if _, err := labels.Parse(c.LabelSelector); err != nil {
+		return fmt.Errorf("invalid label_selector for %s: %w", c.Name, err)
+	}
+	if _, err := fields.ParseSelector(c.FieldSelector); err != nil {
+		return fmt.Errorf("invalid field_selector for %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// Config defines configuration for kubernetes objects receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+	k8sconfig.APIConfig     `mapstructure:",squash"`
+
+	// Objects to collect.
+	Objects []*K8sObjectsConfig `mapstructure:"objects"`
+
+	// StorageID is the ID of the storage extension where the resource
+	// versions of the watched objects are checkpointed, so that the watches
+	// resume where they left off after a restart.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+
+	// For mocking
+	makeDynamicClient func(apiConf k8sconfig.APIConfig) (dynamic.Interface, error)
+}
+
+func (cfg *Config) Validate() error {
+	if err := cfg.ReceiverSettings.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.APIConfig.Validate(); err != nil {
+		return err
+	}
+	if len(cfg.Objects) == 0 {
+		return errNoObjects
+	}
+
+	var errs error
+	for _, object := range cfg.Objects {
+		errs = multierr.Append(errs, object.validate())
+	}
+	return errs
+}
+
+// Unmarshal applies the defaults of the objects, which can't be set in the
+// default configuration since the objects are a list.
+func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
+	if componentParser == nil {
+		return nil
+	}
+	if err := componentParser.UnmarshalExact(cfg); err != nil {
+		return err
+	}
+	for _, object := range cfg.Objects {
+		if object.Version == "" {
+			object.Version = defaultVersion
+		}
+		if object.Mode == "" {
+			object.Mode = defaultMode
+		}
+		if object.Mode == PullMode && object.Interval == 0 {
+			object.Interval = defaultPullInterval
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) getDynamicClient() (dynamic.Interface, error) {
+	if cfg.makeDynamicClient == nil {
+		cfg.makeDynamicClient = k8sconfig.MakeDynamicClient
+	}
+	return cfg.makeDynamicClient(cfg.APIConfig)
+}
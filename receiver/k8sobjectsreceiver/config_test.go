@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	storageID := config.NewComponentID("file_storage")
+
+	tests := []struct {
+		id          config.ComponentID
+		expected    config.Receiver
+		expectedErr string
+	}{
+		{
+			id: config.NewComponentIDWithName(typeStr, ""),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				APIConfig: k8sconfig.APIConfig{
+					AuthType: k8sconfig.AuthTypeServiceAccount,
+				},
+				Objects: []*K8sObjectsConfig{
+					{
+						Name:     "pods",
+						Version:  "v1",
+						Mode:     PullMode,
+						Interval: time.Hour,
+					},
+				},
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "all_settings"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				APIConfig: k8sconfig.APIConfig{
+					AuthType: k8sconfig.AuthTypeKubeConfig,
+				},
+				StorageID: &storageID,
+				Objects: []*K8sObjectsConfig{
+					{
+						Name:          "pods",
+						Version:       "v1",
+						Mode:          PullMode,
+						Interval:      time.Minute,
+						LabelSelector: "environment in (production),tier in (frontend)",
+						FieldSelector: "status.phase=Running",
+					},
+					{
+						Name:       "events",
+						Group:      "events.k8s.io",
+						Version:    "v1",
+						Mode:       WatchMode,
+						Namespaces: []string{"default", "my_namespace"},
+					},
+					{
+						Name:    "widgets",
+						Group:   "example.com",
+						Version: "v1alpha1",
+						Mode:    WatchMode,
+					},
+				},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "no_objects"),
+			expectedErr: errNoObjects.Error(),
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalid_mode"),
+			expectedErr: `invalid mode "stream" for pods`,
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "invalid_selector"),
+			expectedErr: "invalid label_selector for pods",
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "no_name"),
+			expectedErr: "name must be specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, cfg.Validate(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
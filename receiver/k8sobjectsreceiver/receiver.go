@@ -0,0 +1,299 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// watchRetryInterval is the time to wait before listing or watching the
+// objects again after an error.
+var watchRetryInterval = 5 * time.Second
+
+var errResourceVersionExpired = errors.New("resource version expired")
+
+type k8sobjectsReceiver struct {
+	config        *Config
+	settings      component.ReceiverCreateSettings
+	client        dynamic.Interface
+	logsConsumer  consumer.Logs
+	storageClient storage.Client
+	ctx           context.Context
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+	obsrecv       *obsreport.Receiver
+}
+
+// newReceiver creates the Kubernetes objects receiver with the given configuration.
+func newReceiver(
+	set component.ReceiverCreateSettings,
+	config *Config,
+	consumer consumer.Logs,
+	client dynamic.Interface,
+) (component.LogsReceiver, error) {
+	transport := "http"
+
+	return &k8sobjectsReceiver{
+		settings:     set,
+		config:       config,
+		client:       client,
+		logsConsumer: consumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             config.ID(),
+			Transport:              transport,
+			ReceiverCreateSettings: set,
+		}),
+	}, nil
+}
+
+func (kr *k8sobjectsReceiver) Start(ctx context.Context, host component.Host) error {
+	storageClient, err := getStorageClient(ctx, host, kr.config.StorageID, kr.config.ID())
+	if err != nil {
+		return err
+	}
+	kr.storageClient = storageClient
+	kr.ctx, kr.cancel = context.WithCancel(context.Background())
+
+	for _, object := range kr.config.Objects {
+		namespaces := object.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{metav1.NamespaceAll}
+		}
+
+		kr.settings.Logger.Info("starting to collect objects",
+			zap.String("resource", object.gvr().String()),
+			zap.String("mode", string(object.Mode)))
+		for _, ns := range namespaces {
+			kr.wg.Add(1)
+			switch object.Mode {
+			case PullMode:
+				go kr.startPull(object, ns)
+			case WatchMode:
+				go kr.startWatch(object, ns)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (kr *k8sobjectsReceiver) Shutdown(ctx context.Context) error {
+	if kr.cancel == nil {
+		return nil
+	}
+	kr.cancel()
+	kr.wg.Wait()
+	return kr.storageClient.Close(ctx)
+}
+
+func (kr *k8sobjectsReceiver) resource(object *K8sObjectsConfig, ns string) dynamic.ResourceInterface {
+	resource := kr.client.Resource(object.gvr())
+	if ns == metav1.NamespaceAll {
+		return resource
+	}
+	return resource.Namespace(ns)
+}
+
+func listOptions(object *K8sObjectsConfig) metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: object.LabelSelector,
+		FieldSelector: object.FieldSelector,
+	}
+}
+
+// startPull lists the objects every interval and emits all of them.
+func (kr *k8sobjectsReceiver) startPull(object *K8sObjectsConfig, ns string) {
+	defer kr.wg.Done()
+
+	resource := kr.resource(object, ns)
+	ticker := time.NewTicker(object.Interval)
+	defer ticker.Stop()
+
+	for {
+		list, err := resource.List(kr.ctx, listOptions(object))
+		if err != nil {
+			kr.settings.Logger.Error("error listing objects",
+				zap.String("resource", object.Name), zap.String("namespace", ns), zap.Error(err))
+		} else if len(list.Items) > 0 {
+			kr.consumeLogs(unstructuredListToLogData(list, object.Name))
+		}
+
+		select {
+		case <-kr.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startWatch watches the objects and emits their additions, updates and
+// deletions. The watch resumes from the checkpointed resource version if any,
+// and from the current state of the objects otherwise, without emitting them.
+func (kr *k8sobjectsReceiver) startWatch(object *K8sObjectsConfig, ns string) {
+	defer kr.wg.Done()
+
+	resource := kr.resource(object, ns)
+	key := checkpointKey(object, ns)
+	resourceVersion, err := kr.loadResourceVersion(key)
+	if err != nil {
+		kr.settings.Logger.Warn("unable to load checkpointed resource version, watching from the current state",
+			zap.String("resource", object.Name), zap.String("namespace", ns), zap.Error(err))
+	}
+
+	listed := false
+	for kr.ctx.Err() == nil {
+		if resourceVersion == "" && !listed {
+			list, err := resource.List(kr.ctx, listOptions(object))
+			if err != nil {
+				kr.settings.Logger.Error("error listing objects",
+					zap.String("resource", object.Name), zap.String("namespace", ns), zap.Error(err))
+				kr.waitRetry()
+				continue
+			}
+			resourceVersion = list.GetResourceVersion()
+			listed = true
+		}
+
+		resourceVersion, err = kr.watch(object, resource, key, resourceVersion)
+		switch {
+		case errors.Is(err, errResourceVersionExpired):
+			kr.settings.Logger.Warn("resource version expired, changes may have been missed while watching from the current state",
+				zap.String("resource", object.Name), zap.String("namespace", ns))
+			resourceVersion, listed = "", false
+		case err != nil:
+			kr.settings.Logger.Error("error watching objects",
+				zap.String("resource", object.Name), zap.String("namespace", ns), zap.Error(err))
+			kr.waitRetry()
+		}
+	}
+}
+
+// watch consumes the events of a watch from resourceVersion until the watch
+// is closed, and returns the resource version to resume from.
+func (kr *k8sobjectsReceiver) watch(
+	object *K8sObjectsConfig,
+	resource dynamic.ResourceInterface,
+	key string,
+	resourceVersion string,
+) (string, error) {
+	opts := listOptions(object)
+	opts.ResourceVersion = resourceVersion
+	opts.AllowWatchBookmarks = true
+
+	watcher, err := resource.Watch(kr.ctx, opts)
+	if err != nil {
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+			return "", errResourceVersionExpired
+		}
+		return resourceVersion, err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-kr.ctx.Done():
+			return resourceVersion, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion, nil
+			}
+
+			if event.Type == watch.Error {
+				err = apierrors.FromObject(event.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					return "", errResourceVersionExpired
+				}
+				return resourceVersion, err
+			}
+
+			obj, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				kr.settings.Logger.Warn("unexpected object in watch event", zap.String("type", string(event.Type)))
+				continue
+			}
+			if event.Type != watch.Bookmark {
+				kr.consumeLogs(watchEventToLogData(&event, obj, object.Name))
+			}
+
+			resourceVersion = obj.GetResourceVersion()
+			if err = kr.storageClient.Set(kr.ctx, key, []byte(resourceVersion)); err != nil {
+				kr.settings.Logger.Warn("unable to checkpoint resource version", zap.String("key", key), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (kr *k8sobjectsReceiver) loadResourceVersion(key string) (string, error) {
+	value, err := kr.storageClient.Get(kr.ctx, key)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+func (kr *k8sobjectsReceiver) waitRetry() {
+	select {
+	case <-kr.ctx.Done():
+	case <-time.After(watchRetryInterval):
+	}
+}
+
+func (kr *k8sobjectsReceiver) consumeLogs(ld plog.Logs) {
+	ctx := kr.obsrecv.StartLogsOp(kr.ctx)
+	consumerErr := kr.logsConsumer.ConsumeLogs(ctx, ld)
+	kr.obsrecv.EndLogsOp(ctx, typeStr, ld.LogRecordCount(), consumerErr)
+}
+
+// checkpointKey is the storage key of the resource version of the watched
+// objects of a namespace.
+func checkpointKey(object *K8sObjectsConfig, ns string) string {
+	return "resource_version." + strings.Join([]string{object.Group, object.Version, object.Name, ns}, "/")
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *config.ComponentID, componentID config.ComponentID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, "")
+}
@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func TestPullObjects(t *testing.T) {
+	client := newFakeClient(
+		newPod("default", "pod1", "1"),
+		newPod("default", "pod2", "2"),
+		newPod("other", "pod3", "3"),
+	)
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.Objects = []*K8sObjectsConfig{
+		{Name: "pods", Version: "v1", Mode: PullMode, Interval: time.Hour},
+	}
+
+	sink := new(consumertest.LogsSink)
+	r, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), rCfg, sink, client)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, r.Shutdown(context.Background()))
+
+	logs := sink.AllLogs()[0]
+	assert.Equal(t, 2, logs.ResourceLogs().Len())
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	resource, ok := lr.Attributes().Get(attributeResourceName)
+	require.True(t, ok)
+	assert.Equal(t, "pods", resource.Str())
+	metadata, ok := lr.Body().Map().Get("metadata")
+	require.True(t, ok)
+	_, ok = metadata.Map().Get("resourceVersion")
+	assert.True(t, ok)
+}
+
+func TestWatchObjects(t *testing.T) {
+	client := newFakeClient()
+	watchers := make(chan *watch.FakeWatcher, 2)
+	client.PrependWatchReactor("pods", func(k8stesting.Action) (bool, watch.Interface, error) {
+		watcher := watch.NewFake()
+		watchers <- watcher
+		return true, watcher, nil
+	})
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.Objects = []*K8sObjectsConfig{
+		{Name: "pods", Version: "v1", Mode: WatchMode, Namespaces: []string{"default"}},
+	}
+
+	sink := new(consumertest.LogsSink)
+	r, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), rCfg, sink, client)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	watcher := <-watchers
+	watcher.Add(newPod("default", "pod1", "1"))
+	watcher.Modify(newPod("default", "pod1", "2"))
+	watcher.Delete(newPod("default", "pod1", "3"))
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for i, eventType := range []string{"ADDED", "MODIFIED", "DELETED"} {
+		rl := sink.AllLogs()[i].ResourceLogs().At(0)
+		namespace, ok := rl.Resource().Attributes().Get("k8s.namespace.name")
+		require.True(t, ok)
+		assert.Equal(t, "default", namespace.Str())
+
+		lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+		eventName, ok := lr.Attributes().Get(attributeEventName)
+		require.True(t, ok)
+		assert.Equal(t, eventType, eventName.Str())
+		bodyType, ok := lr.Body().Map().Get("type")
+		require.True(t, ok)
+		assert.Equal(t, eventType, bodyType.Str())
+	}
+
+	// The watch is started again after an expired resource version.
+	watcher.Error(&metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   410,
+		Reason: metav1.StatusReasonExpired,
+	})
+	watcher = <-watchers
+	watcher.Add(newPod("default", "pod2", "4"))
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestWatchObjectsCheckpoint(t *testing.T) {
+	client := newFakeClient()
+	resourceVersions := make(chan string, 1)
+	watchers := make(chan *watch.FakeWatcher, 1)
+	client.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		resourceVersions <- action.(k8stesting.WatchActionImpl).GetWatchRestrictions().ResourceVersion
+		watcher := watch.NewFake()
+		watchers <- watcher
+		return true, watcher, nil
+	})
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.StorageID = &storageID
+	rCfg.Objects = []*K8sObjectsConfig{
+		{Name: "pods", Version: "v1", Mode: WatchMode},
+	}
+
+	storageClient := newMockStorageClient()
+	key := checkpointKey(rCfg.Objects[0], metav1.NamespaceAll)
+	require.NoError(t, storageClient.Set(context.Background(), key, []byte("42")))
+	host := &storageHost{Host: componenttest.NewNopHost(), client: storageClient}
+
+	sink := new(consumertest.LogsSink)
+	r, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), rCfg, sink, client)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), host))
+
+	// The watch resumes from the checkpointed resource version.
+	assert.Equal(t, "42", <-resourceVersions)
+	watcher := <-watchers
+	watcher.Add(newPod("default", "pod1", "43"))
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, r.Shutdown(context.Background()))
+	value, err := storageClient.Get(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, "43", string(value))
+}
+
+func TestStartWithoutStorage(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.StorageID = &storageID
+	rCfg.Objects = []*K8sObjectsConfig{
+		{Name: "pods", Version: "v1", Mode: WatchMode},
+	}
+
+	r, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), rCfg, consumertest.NewNop(), newFakeClient())
+	require.NoError(t, err)
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'file_storage' not found")
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
+var storageID = config.NewComponentID("file_storage")
+
+func newFakeClient(objects ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{podsGVR: "PodList"},
+		objects...,
+	)
+}
+
+func newPod(namespace, name, resourceVersion string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"namespace":       namespace,
+				"name":            name,
+				"resourceVersion": resourceVersion,
+			},
+			"spec": map[string]interface{}{
+				"nodeName": "node1",
+			},
+		},
+	}
+}
+
+type storageHost struct {
+	component.Host
+	client storage.Client
+}
+
+func (h *storageHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return map[config.ComponentID]component.Extension{
+		storageID: &mockStorageExtension{client: h.client},
+	}
+}
+
+type mockStorageExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client storage.Client
+}
+
+func (e *mockStorageExtension) GetClient(context.Context, component.Kind, config.ComponentID, string) (storage.Client, error) {
+	return e.client, nil
+}
+
+type mockStorageClient struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newMockStorageClient() *mockStorageClient {
+	return &mockStorageClient{data: map[string][]byte{}}
+}
+
+func (c *mockStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data[key], nil
+}
+
+func (c *mockStorageClient) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func (c *mockStorageClient) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *mockStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storage.Get:
+			op.Value, err = c.Get(ctx, op.Key)
+		case storage.Set:
+			err = c.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			err = c.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *mockStorageClient) Close(context.Context) error {
+	return nil
+}
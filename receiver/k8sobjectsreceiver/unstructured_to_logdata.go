@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	semconv "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	attributeResourceName = "k8s.resource.name"
+	attributeEventName    = "event.name"
+	attributeEventDomain  = "event.domain"
+
+	eventDomain = "k8s"
+)
+
+// unstructuredListToLogData converts the objects of a list to log records,
+// one per object with the object as body, grouped by namespace.
+func unstructuredListToLogData(list *unstructured.UnstructuredList, resource string) plog.Logs {
+	ld := plog.NewLogs()
+	observedTime := pcommon.NewTimestampFromTime(time.Now())
+
+	recordsByNamespace := map[string]plog.LogRecordSlice{}
+	for i := range list.Items {
+		object := &list.Items[i]
+		records, ok := recordsByNamespace[object.GetNamespace()]
+		if !ok {
+			records = appendResourceLogs(ld, object.GetNamespace())
+			recordsByNamespace[object.GetNamespace()] = records
+		}
+
+		lr := records.AppendEmpty()
+		lr.SetObservedTimestamp(observedTime)
+		lr.Attributes().PutStr(attributeResourceName, resource)
+		lr.Body().SetEmptyMap().FromRaw(object.Object)
+	}
+
+	return ld
+}
+
+// watchEventToLogData converts a watch event to a log record, with the type of
+// the event and the object as body.
+func watchEventToLogData(event *watch.Event, object *unstructured.Unstructured, resource string) plog.Logs {
+	ld := plog.NewLogs()
+	lr := appendResourceLogs(ld, object.GetNamespace()).AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+
+	attrs := lr.Attributes()
+	attrs.PutStr(attributeResourceName, resource)
+	attrs.PutStr(attributeEventDomain, eventDomain)
+	attrs.PutStr(attributeEventName, string(event.Type))
+
+	lr.Body().SetEmptyMap().FromRaw(map[string]interface{}{
+		"type":   string(event.Type),
+		"object": object.Object,
+	})
+
+	return ld
+}
+
+func appendResourceLogs(ld plog.Logs, namespace string) plog.LogRecordSlice {
+	rl := ld.ResourceLogs().AppendEmpty()
+	if namespace != "" {
+		rl.Resource().Attributes().PutStr(semconv.AttributeK8SNamespaceName, namespace)
+	}
+	return rl.ScopeLogs().AppendEmpty().LogRecords()
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobjectsreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestUnstructuredListToLogData(t *testing.T) {
+	list := &unstructured.UnstructuredList{
+		Items: []unstructured.Unstructured{
+			*newPod("default", "pod1", "1"),
+			*newPod("other", "pod2", "2"),
+			*newPod("default", "pod3", "3"),
+		},
+	}
+
+	ld := unstructuredListToLogData(list, "pods")
+	assert.Equal(t, 3, ld.LogRecordCount())
+	require.Equal(t, 2, ld.ResourceLogs().Len())
+
+	rl := ld.ResourceLogs().At(0)
+	namespace, ok := rl.Resource().Attributes().Get("k8s.namespace.name")
+	require.True(t, ok)
+	assert.Equal(t, "default", namespace.Str())
+	records := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+	assert.Equal(t, list.Items[0].Object, records.At(0).Body().Map().AsRaw())
+	assert.Equal(t, list.Items[2].Object, records.At(1).Body().Map().AsRaw())
+}
+
+func TestWatchEventToLogData(t *testing.T) {
+	pod := newPod("", "pod1", "1")
+	event := &watch.Event{Type: watch.Modified, Object: pod}
+
+	ld := watchEventToLogData(event, pod, "pods")
+	require.Equal(t, 1, ld.LogRecordCount())
+
+	rl := ld.ResourceLogs().At(0)
+	assert.Equal(t, 0, rl.Resource().Attributes().Len())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.NotZero(t, lr.ObservedTimestamp())
+	assert.Equal(t, map[string]interface{}{
+		attributeResourceName: "pods",
+		attributeEventDomain:  "k8s",
+		attributeEventName:    "MODIFIED",
+	}, lr.Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{
+		"type":   "MODIFIED",
+		"object": pod.Object,
+	}, lr.Body().Map().AsRaw())
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+// partitionPauser is the subset of sarama.ConsumerGroup used to stop fetching
+// messages of a partition.
+type partitionPauser interface {
+	Pause(partitions map[string][]int32)
+	Resume(partitions map[string][]int32)
+}
+
+// backpressureHandler retries the messages refused by the next consumer
+// with the partition paused, instead of ending the consumer group session.
+type backpressureHandler struct {
+	id              config.ComponentID
+	pauser          partitionPauser
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	logger          *zap.Logger
+}
+
+func newBackpressureHandler(id config.ComponentID, cfg Backpressure, pauser partitionPauser, logger *zap.Logger) *backpressureHandler {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &backpressureHandler{
+		id:              id,
+		pauser:          pauser,
+		initialInterval: cfg.InitialInterval,
+		maxInterval:     cfg.MaxInterval,
+		logger:          logger,
+	}
+}
+
+// retry calls consume until it succeeds, returns a permanent error or the
+// session ends. err is the error returned by the first attempt.
+func (b *backpressureHandler) retry(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, err error, consume func() error) error {
+	if err == nil || consumererror.IsPermanent(err) {
+		return err
+	}
+
+	partitions := map[string][]int32{message.Topic: {message.Partition}}
+	b.pauser.Pause(partitions)
+	defer b.pauser.Resume(partitions)
+	_ = stats.RecordWithTags(
+		session.Context(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, b.id.String())},
+		statBackpressurePauses.M(1))
+	b.logger.Warn("Next consumer refused the message, pausing the partition",
+		zap.String("topic", message.Topic),
+		zap.Int32("partition", message.Partition),
+		zap.Error(err))
+
+	interval := b.initialInterval
+	for {
+		select {
+		case <-session.Context().Done():
+			return err
+		case <-time.After(interval):
+		}
+		if err = consume(); err == nil || consumererror.IsPermanent(err) {
+			return err
+		}
+		if interval *= 2; interval > b.maxInterval {
+			interval = b.maxInterval
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+)
+
+type testPauser struct {
+	paused  []map[string][]int32
+	resumed []map[string][]int32
+}
+
+func (p *testPauser) Pause(partitions map[string][]int32) {
+	p.paused = append(p.paused, partitions)
+}
+
+func (p *testPauser) Resume(partitions map[string][]int32) {
+	p.resumed = append(p.resumed, partitions)
+}
+
+func newTestBackpressureHandler(pauser partitionPauser) *backpressureHandler {
+	return newBackpressureHandler(config.NewComponentID(typeStr), Backpressure{
+		Enabled:         true,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+	}, pauser, zap.NewNop())
+}
+
+func TestNewBackpressureHandler_disabled(t *testing.T) {
+	assert.Nil(t, newBackpressureHandler(config.NewComponentID(typeStr), Backpressure{}, &testPauser{}, zap.NewNop()))
+}
+
+func TestBackpressureHandler_retry(t *testing.T) {
+	pauser := &testPauser{}
+	b := newTestBackpressureHandler(pauser)
+	message := &sarama.ConsumerMessage{Topic: testTopic, Partition: testPartition}
+
+	attempts := 0
+	err := b.retry(testConsumerGroupSession{}, message, errors.New("queue is full"), func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("queue is full")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	expected := []map[string][]int32{{testTopic: {testPartition}}}
+	assert.Equal(t, expected, pauser.paused)
+	assert.Equal(t, expected, pauser.resumed)
+}
+
+func TestBackpressureHandler_permanent(t *testing.T) {
+	pauser := &testPauser{}
+	b := newTestBackpressureHandler(pauser)
+	message := &sarama.ConsumerMessage{Topic: testTopic, Partition: testPartition}
+
+	permanent := consumererror.NewPermanent(errors.New("bad data"))
+	err := b.retry(testConsumerGroupSession{}, message, permanent, func() error {
+		t.Fatal("permanent errors must not be retried")
+		return nil
+	})
+	assert.Equal(t, permanent, err)
+	assert.Empty(t, pauser.paused)
+
+	attempts := 0
+	err = b.retry(testConsumerGroupSession{}, message, errors.New("queue is full"), func() error {
+		attempts++
+		return permanent
+	})
+	assert.Equal(t, permanent, err)
+	assert.Equal(t, 1, attempts)
+	assert.Len(t, pauser.resumed, 1)
+}
@@ -15,6 +15,9 @@
 package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -42,6 +45,27 @@ type MessageMarking struct {
 	OnError bool `mapstructure:"on_error"`
 }
 
+type HeaderExtraction struct {
+	// Whether or not to add the headers of the kafka messages to the resource
+	// attributes of the received data (default disabled).
+	ExtractHeaders bool `mapstructure:"extract_headers"`
+	// The keys of the headers to extract. Every header is added as a
+	// "kafka.header.<key>" attribute.
+	Headers []string `mapstructure:"headers"`
+}
+
+type Backpressure struct {
+	// If true, when the next consumer returns a non-permanent error the
+	// partition of the message is paused and the message is retried until it
+	// succeeds, instead of ending the consumer group session. This avoids a
+	// rebalance of the consumer group every time the pipeline is saturated.
+	Enabled bool `mapstructure:"enabled"`
+	// Time to wait after the first failure before retrying (default 1s).
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+	// Upper bound of the time to wait between retries (default 30s).
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+}
+
 // Config defines configuration for Kafka receiver.
 type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -51,6 +75,11 @@ type Config struct {
 	ProtocolVersion string `mapstructure:"protocol_version"`
 	// The name of the kafka topic to consume from (default "otlp_spans")
 	Topic string `mapstructure:"topic"`
+	// Regular expression of the kafka topics to consume from. When set, Topic
+	// is ignored and topics created afterwards are picked up automatically.
+	TopicRegex string `mapstructure:"topic_regex"`
+	// How frequently the topics matching TopicRegex are refreshed (default 1m).
+	TopicRefreshInterval time.Duration `mapstructure:"topic_refresh_interval"`
 	// Encoding of the messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
 	// The consumer group that receiver will be consuming messages from (default "otel-collector")
@@ -69,11 +98,30 @@ type Config struct {
 
 	// Controls the way the messages are marked as consumed
 	MessageMarking MessageMarking `mapstructure:"message_marking"`
+
+	// Controls the extraction of the kafka message headers
+	HeaderExtraction HeaderExtraction `mapstructure:"header_extraction"`
+
+	// Controls how the receiver behaves when the pipeline signals backpressure
+	Backpressure Backpressure `mapstructure:"backpressure"`
 }
 
 var _ config.Receiver = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.TopicRegex != "" {
+		if _, err := regexp.Compile(cfg.TopicRegex); err != nil {
+			return fmt.Errorf("topic_regex is not a valid regular expression: %w", err)
+		}
+		if cfg.TopicRefreshInterval <= 0 {
+			return errors.New("topic_refresh_interval has to be greater than 0")
+		}
+	}
+	if cfg.Backpressure.Enabled {
+		if cfg.Backpressure.InitialInterval <= 0 || cfg.Backpressure.MaxInterval < cfg.Backpressure.InitialInterval {
+			return errors.New("backpressure.initial_interval has to be greater than 0 and not greater than backpressure.max_interval")
+		}
+	}
 	return nil
 }
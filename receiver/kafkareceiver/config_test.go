@@ -118,3 +118,53 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "topic regex",
+			modify: func(cfg *Config) { cfg.TopicRegex = "^otlp_.*" },
+		},
+		{
+			name:   "invalid topic regex",
+			modify: func(cfg *Config) { cfg.TopicRegex = "(otlp" },
+			err:    "topic_regex is not a valid regular expression: error parsing regexp: missing closing ): `(otlp`",
+		},
+		{
+			name: "invalid topic refresh interval",
+			modify: func(cfg *Config) {
+				cfg.TopicRegex = "^otlp_.*"
+				cfg.TopicRefreshInterval = 0
+			},
+			err: "topic_refresh_interval has to be greater than 0",
+		},
+		{
+			name: "invalid backpressure intervals",
+			modify: func(cfg *Config) {
+				cfg.Backpressure.Enabled = true
+				cfg.Backpressure.MaxInterval = time.Millisecond
+			},
+			err: "backpressure.initial_interval has to be greater than 0 and not greater than backpressure.max_interval",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -47,6 +47,10 @@ const (
 	defaultAutoCommitEnable = true
 	// default from sarama.NewConfig()
 	defaultAutoCommitInterval = 1 * time.Second
+
+	defaultTopicRefreshInterval        = time.Minute
+	defaultBackpressureInitialInterval = time.Second
+	defaultBackpressureMaxInterval     = 30 * time.Second
 )
 
 // FactoryOption applies changes to kafkaExporterFactory.
@@ -102,12 +106,13 @@ func NewFactory(options ...FactoryOption) component.ReceiverFactory {
 
 func createDefaultConfig() config.Receiver {
 	return &Config{
-		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
-		Topic:            defaultTopic,
-		Encoding:         defaultEncoding,
-		Brokers:          []string{defaultBroker},
-		ClientID:         defaultClientID,
-		GroupID:          defaultGroupID,
+		ReceiverSettings:     config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		Topic:                defaultTopic,
+		TopicRefreshInterval: defaultTopicRefreshInterval,
+		Encoding:             defaultEncoding,
+		Brokers:              []string{defaultBroker},
+		ClientID:             defaultClientID,
+		GroupID:              defaultGroupID,
 		Metadata: kafkaexporter.Metadata{
 			Full: defaultMetadataFull,
 			Retry: kafkaexporter.MetadataRetry{
@@ -123,6 +128,11 @@ func createDefaultConfig() config.Receiver {
 			After:   false,
 			OnError: false,
 		},
+		Backpressure: Backpressure{
+			Enabled:         false,
+			InitialInterval: defaultBackpressureInitialInterval,
+			MaxInterval:     defaultBackpressureMaxInterval,
+		},
 	}
 }
 
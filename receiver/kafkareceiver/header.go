@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const headerAttributePrefix = "kafka.header."
+
+// headerExtractor adds the configured headers of a kafka message to the
+// resource attributes of the data it carries.
+type headerExtractor struct {
+	headers []string
+}
+
+func newHeaderExtractor(cfg HeaderExtraction) *headerExtractor {
+	if !cfg.ExtractHeaders || len(cfg.Headers) == 0 {
+		return nil
+	}
+	return &headerExtractor{headers: cfg.Headers}
+}
+
+func (he *headerExtractor) extractHeadersTraces(traces ptrace.Traces, message *sarama.ConsumerMessage) {
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		he.extractHeaders(rss.At(i).Resource(), message)
+	}
+}
+
+func (he *headerExtractor) extractHeadersMetrics(metrics pmetric.Metrics, message *sarama.ConsumerMessage) {
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		he.extractHeaders(rms.At(i).Resource(), message)
+	}
+}
+
+func (he *headerExtractor) extractHeadersLogs(logs plog.Logs, message *sarama.ConsumerMessage) {
+	rls := logs.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		he.extractHeaders(rls.At(i).Resource(), message)
+	}
+}
+
+func (he *headerExtractor) extractHeaders(resource pcommon.Resource, message *sarama.ConsumerMessage) {
+	for _, header := range he.headers {
+		if value, ok := getHeaderValue(message.Headers, header); ok {
+			resource.Attributes().PutStr(headerAttributePrefix+header, value)
+		}
+	}
+}
+
+func getHeaderValue(headers []*sarama.RecordHeader, key string) (string, bool) {
+	for _, header := range headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func testHeaderMessage() *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("tenant"), Value: []byte("acme")},
+			{Key: []byte("ignored"), Value: []byte("value")},
+		},
+	}
+}
+
+func TestNewHeaderExtractor(t *testing.T) {
+	assert.Nil(t, newHeaderExtractor(HeaderExtraction{Headers: []string{"tenant"}}))
+	assert.Nil(t, newHeaderExtractor(HeaderExtraction{ExtractHeaders: true}))
+	assert.NotNil(t, newHeaderExtractor(HeaderExtraction{ExtractHeaders: true, Headers: []string{"tenant"}}))
+}
+
+func TestHeaderExtractor(t *testing.T) {
+	he := newHeaderExtractor(HeaderExtraction{ExtractHeaders: true, Headers: []string{"tenant", "missing"}})
+	message := testHeaderMessage()
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty()
+	traces.ResourceSpans().AppendEmpty()
+	he.extractHeadersTraces(traces, message)
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		assert.Equal(t, map[string]interface{}{"kafka.header.tenant": "acme"}, traces.ResourceSpans().At(i).Resource().Attributes().AsRaw())
+	}
+
+	metrics := pmetric.NewMetrics()
+	metrics.ResourceMetrics().AppendEmpty()
+	he.extractHeadersMetrics(metrics, message)
+	assert.Equal(t, map[string]interface{}{"kafka.header.tenant": "acme"}, metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty()
+	he.extractHeadersLogs(logs, message)
+	assert.Equal(t, map[string]interface{}{"kafka.header.tenant": "acme"}, logs.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+}
+
+func TestGetHeaderValue(t *testing.T) {
+	value, ok := getHeaderValue(testHeaderMessage().Headers, "tenant")
+	require.True(t, ok)
+	assert.Equal(t, "acme", value)
+
+	_, ok = getHeaderValue(testHeaderMessage().Headers, "missing")
+	assert.False(t, ok)
+}
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
@@ -50,6 +51,9 @@ type kafkaTracesConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	headerExtractor   *headerExtractor
+	backpressure      Backpressure
+	topicWatcher      *topicWatcher
 }
 
 // kafkaMetricsConsumer uses sarama to consume and handle messages from kafka.
@@ -65,6 +69,9 @@ type kafkaMetricsConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	headerExtractor   *headerExtractor
+	backpressure      Backpressure
+	topicWatcher      *topicWatcher
 }
 
 // kafkaLogsConsumer uses sarama to consume and handle messages from kafka.
@@ -80,6 +87,9 @@ type kafkaLogsConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	headerExtractor   *headerExtractor
+	backpressure      Backpressure
+	topicWatcher      *topicWatcher
 }
 
 var _ component.Receiver = (*kafkaTracesConsumer)(nil)
@@ -111,6 +121,11 @@ func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unma
 	if err != nil {
 		return nil, err
 	}
+	watcher, err := newTopicWatcher(config, c, set.Logger)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 	return &kafkaTracesConsumer{
 		id:                config.ID(),
 		consumerGroup:     client,
@@ -120,6 +135,9 @@ func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unma
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		headerExtractor:   newHeaderExtractor(config.HeaderExtraction),
+		backpressure:      config.Backpressure,
+		topicWatcher:      watcher,
 	}, nil
 }
 
@@ -139,6 +157,8 @@ func (c *kafkaTracesConsumer) Start(context.Context, component.Host) error {
 		}),
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		headerExtractor:   c.headerExtractor,
+		backpressure:      newBackpressureHandler(c.id, c.backpressure, c.consumerGroup, c.settings.Logger),
 	}
 	go c.consumeLoop(ctx, consumerGroup) // nolint:errcheck
 	<-consumerGroup.ready
@@ -150,7 +170,7 @@ func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler sarama.Co
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := consume(ctx, c.consumerGroup, c.topics, c.topicWatcher, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
 		// check if context was cancelled, signaling that the consumer should stop
@@ -163,7 +183,7 @@ func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler sarama.Co
 
 func (c *kafkaTracesConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Append(c.consumerGroup.Close(), c.topicWatcher.close())
 }
 
 func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unmarshalers map[string]MetricsUnmarshaler, nextConsumer consumer.Metrics) (*kafkaMetricsConsumer, error) {
@@ -194,6 +214,11 @@ func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unm
 	if err != nil {
 		return nil, err
 	}
+	watcher, err := newTopicWatcher(config, c, set.Logger)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 	return &kafkaMetricsConsumer{
 		id:                config.ID(),
 		consumerGroup:     client,
@@ -203,6 +228,9 @@ func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unm
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		headerExtractor:   newHeaderExtractor(config.HeaderExtraction),
+		backpressure:      config.Backpressure,
+		topicWatcher:      watcher,
 	}, nil
 }
 
@@ -222,6 +250,8 @@ func (c *kafkaMetricsConsumer) Start(context.Context, component.Host) error {
 		}),
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		headerExtractor:   c.headerExtractor,
+		backpressure:      newBackpressureHandler(c.id, c.backpressure, c.consumerGroup, c.settings.Logger),
 	}
 	go c.consumeLoop(ctx, metricsConsumerGroup) // nolint:errcheck
 	<-metricsConsumerGroup.ready
@@ -233,7 +263,7 @@ func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler sarama.C
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := consume(ctx, c.consumerGroup, c.topics, c.topicWatcher, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
 		// check if context was cancelled, signaling that the consumer should stop
@@ -246,7 +276,7 @@ func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler sarama.C
 
 func (c *kafkaMetricsConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Append(c.consumerGroup.Close(), c.topicWatcher.close())
 }
 
 func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmarshalers map[string]LogsUnmarshaler, nextConsumer consumer.Logs) (*kafkaLogsConsumer, error) {
@@ -274,6 +304,11 @@ func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmars
 	if err != nil {
 		return nil, err
 	}
+	watcher, err := newTopicWatcher(config, c, set.Logger)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 	return &kafkaLogsConsumer{
 		id:                config.ID(),
 		consumerGroup:     client,
@@ -283,6 +318,9 @@ func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmars
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		headerExtractor:   newHeaderExtractor(config.HeaderExtraction),
+		backpressure:      config.Backpressure,
+		topicWatcher:      watcher,
 	}, nil
 }
 
@@ -302,6 +340,8 @@ func (c *kafkaLogsConsumer) Start(context.Context, component.Host) error {
 		}),
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		headerExtractor:   c.headerExtractor,
+		backpressure:      newBackpressureHandler(c.id, c.backpressure, c.consumerGroup, c.settings.Logger),
 	}
 	go c.consumeLoop(ctx, logsConsumerGroup) // nolint:errcheck
 	<-logsConsumerGroup.ready
@@ -313,7 +353,7 @@ func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler sarama.Cons
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := consume(ctx, c.consumerGroup, c.topics, c.topicWatcher, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
 		// check if context was cancelled, signaling that the consumer should stop
@@ -326,7 +366,7 @@ func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler sarama.Cons
 
 func (c *kafkaLogsConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Append(c.consumerGroup.Close(), c.topicWatcher.close())
 }
 
 type tracesConsumerGroupHandler struct {
@@ -342,6 +382,8 @@ type tracesConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	headerExtractor   *headerExtractor
+	backpressure      *backpressureHandler
 }
 
 type metricsConsumerGroupHandler struct {
@@ -357,6 +399,8 @@ type metricsConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	headerExtractor   *headerExtractor
+	backpressure      *backpressureHandler
 }
 
 type logsConsumerGroupHandler struct {
@@ -372,6 +416,8 @@ type logsConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	headerExtractor   *headerExtractor
+	backpressure      *backpressureHandler
 }
 
 var _ sarama.ConsumerGroupHandler = (*tracesConsumerGroupHandler)(nil)
@@ -422,9 +468,17 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 			}
 			return err
 		}
+		if c.headerExtractor != nil {
+			c.headerExtractor.extractHeadersTraces(traces, message)
+		}
 
 		spanCount := traces.SpanCount()
 		err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
+		if c.backpressure != nil {
+			err = c.backpressure.retry(session, message, err, func() error {
+				return c.nextConsumer.ConsumeTraces(session.Context(), traces)
+			})
+		}
 		c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), spanCount, err)
 		if err != nil {
 			if c.messageMarking.After && c.messageMarking.OnError {
@@ -487,9 +541,17 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 			}
 			return err
 		}
+		if c.headerExtractor != nil {
+			c.headerExtractor.extractHeadersMetrics(metrics, message)
+		}
 
 		dataPointCount := metrics.DataPointCount()
 		err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
+		if c.backpressure != nil {
+			err = c.backpressure.retry(session, message, err, func() error {
+				return c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
+			})
+		}
 		c.obsrecv.EndMetricsOp(ctx, c.unmarshaler.Encoding(), dataPointCount, err)
 		if err != nil {
 			if c.messageMarking.After && c.messageMarking.OnError {
@@ -556,8 +618,16 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 			}
 			return err
 		}
+		if c.headerExtractor != nil {
+			c.headerExtractor.extractHeadersLogs(logs, message)
+		}
 
 		err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
+		if c.backpressure != nil {
+			err = c.backpressure.retry(session, message, err, func() error {
+				return c.nextConsumer.ConsumeLogs(session.Context(), logs)
+			})
+		}
 		// TODO
 		c.obsrecv.EndLogsOp(ctx, c.unmarshaler.Encoding(), logs.LogRecordCount(), err)
 		if err != nil {
@@ -29,6 +29,8 @@ var (
 
 	statPartitionStart = stats.Int64("kafka_receiver_partition_start", "Number of started partitions", stats.UnitDimensionless)
 	statPartitionClose = stats.Int64("kafka_receiver_partition_close", "Number of finished partitions", stats.UnitDimensionless)
+
+	statBackpressurePauses = stats.Int64("kafka_receiver_backpressure_pauses", "Number of partitions paused because the pipeline refused a message", stats.UnitDimensionless)
 )
 
 // MetricViews return metric views for Kafka receiver.
@@ -75,11 +77,20 @@ func MetricViews() []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	countBackpressurePauses := &view.View{
+		Name:        statBackpressurePauses.Name(),
+		Measure:     statBackpressurePauses,
+		Description: statBackpressurePauses.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		countMessages,
 		lastValueOffset,
 		lastValueOffsetLag,
 		countPartitionStart,
 		countPartitionClose,
+		countBackpressurePauses,
 	}
 }
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+)
+
+// topicLister is the subset of sarama.Client used to discover topics.
+type topicLister interface {
+	RefreshMetadata(topics ...string) error
+	Topics() ([]string, error)
+	Close() error
+}
+
+// topicWatcher resolves the topics matching a regular expression, and ends
+// the consumer group session when the set of matching topics changes so that
+// the next session subscribes to the new set.
+type topicWatcher struct {
+	client   topicLister
+	regex    *regexp.Regexp
+	interval time.Duration
+	logger   *zap.Logger
+}
+
+func newTopicWatcher(config Config, c *sarama.Config, logger *zap.Logger) (*topicWatcher, error) {
+	if config.TopicRegex == "" {
+		return nil, nil
+	}
+	regex, err := regexp.Compile(config.TopicRegex)
+	if err != nil {
+		return nil, err
+	}
+	client, err := sarama.NewClient(config.Brokers, c)
+	if err != nil {
+		return nil, err
+	}
+	return &topicWatcher{
+		client:   client,
+		regex:    regex,
+		interval: config.TopicRefreshInterval,
+		logger:   logger,
+	}, nil
+}
+
+// matchingTopics returns the sorted list of the topics matching the regex.
+func (w *topicWatcher) matchingTopics() ([]string, error) {
+	if err := w.client.RefreshMetadata(); err != nil {
+		return nil, err
+	}
+	topics, err := w.client.Topics()
+	if err != nil {
+		return nil, err
+	}
+	var matching []string
+	for _, topic := range topics {
+		if w.regex.MatchString(topic) {
+			matching = append(matching, topic)
+		}
+	}
+	sort.Strings(matching)
+	return matching, nil
+}
+
+// watch calls cancel as soon as the topics matching the regex differ from
+// the given ones. It returns when ctx is done.
+func (w *topicWatcher) watch(ctx context.Context, topics []string, cancel context.CancelFunc) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			matching, err := w.matchingTopics()
+			if err != nil {
+				w.logger.Warn("Failed to refresh the topics matching topic_regex", zap.Error(err))
+				continue
+			}
+			if !equalTopics(topics, matching) {
+				w.logger.Info("Topics matching topic_regex changed", zap.Strings("topics", matching))
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+func (w *topicWatcher) close() error {
+	if w == nil {
+		return nil
+	}
+	return w.client.Close()
+}
+
+func equalTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var errNoMatchingTopic = errors.New("no topic matches topic_regex")
+
+// consume runs a single consumer group session. When a topic watcher is set,
+// the session subscribes to the topics matching its regex instead of topics,
+// and ends when they change.
+func consume(ctx context.Context, consumerGroup sarama.ConsumerGroup, topics []string, watcher *topicWatcher, handler sarama.ConsumerGroupHandler) error {
+	if watcher == nil {
+		return consumerGroup.Consume(ctx, topics, handler)
+	}
+
+	matching, err := watcher.matchingTopics()
+	if err == nil && len(matching) == 0 {
+		err = errNoMatchingTopic
+	}
+	if err != nil {
+		// Wait for topics to be created before trying again.
+		select {
+		case <-ctx.Done():
+		case <-time.After(watcher.interval):
+		}
+		return err
+	}
+
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go watcher.watch(sessionCtx, matching, cancel)
+	return consumerGroup.Consume(sessionCtx, matching, handler)
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testTopicLister struct {
+	mu     sync.Mutex
+	topics []string
+}
+
+func (l *testTopicLister) RefreshMetadata(...string) error {
+	return nil
+}
+
+func (l *testTopicLister) Topics() ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.topics...), nil
+}
+
+func (l *testTopicLister) Close() error {
+	return nil
+}
+
+func (l *testTopicLister) setTopics(topics ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.topics = topics
+}
+
+func newTestTopicWatcher(lister topicLister) *topicWatcher {
+	return &topicWatcher{
+		client:   lister,
+		regex:    regexp.MustCompile("^otlp_.*"),
+		interval: time.Millisecond,
+		logger:   zap.NewNop(),
+	}
+}
+
+func TestTopicWatcher_matchingTopics(t *testing.T) {
+	w := newTestTopicWatcher(&testTopicLister{topics: []string{"otlp_spans_b", "logs", "otlp_spans_a"}})
+	topics, err := w.matchingTopics()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"otlp_spans_a", "otlp_spans_b"}, topics)
+}
+
+// topicsConsumerGroup records the topics of every session and blocks until
+// the session ends.
+type topicsConsumerGroup struct {
+	testConsumerGroup
+	sessions chan []string
+}
+
+func (g *topicsConsumerGroup) Consume(ctx context.Context, topics []string, _ sarama.ConsumerGroupHandler) error {
+	g.sessions <- topics
+	<-ctx.Done()
+	return nil
+}
+
+func TestConsume_topicRegex(t *testing.T) {
+	lister := &testTopicLister{topics: []string{"otlp_spans"}}
+	w := newTestTopicWatcher(lister)
+	group := &topicsConsumerGroup{sessions: make(chan []string, 1)}
+
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, consume(context.Background(), group, nil, w, nil))
+		close(done)
+	}()
+	assert.Equal(t, []string{"otlp_spans"}, <-group.sessions)
+
+	// The session ends as soon as a new topic matches.
+	lister.setTopics("otlp_spans", "otlp_spans_eu")
+	<-done
+}
+
+func TestConsume_noMatchingTopic(t *testing.T) {
+	w := newTestTopicWatcher(&testTopicLister{topics: []string{"logs"}})
+	err := consume(context.Background(), &topicsConsumerGroup{}, nil, w, nil)
+	assert.ErrorIs(t, err, errNoMatchingTopic)
+}
@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loopbackreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/loopbackreceiver"
+
+import (
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the loopback receiver. The receiver is
+// referenced by the exporters through its ID, e.g. "loopback/shard-1".
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+}
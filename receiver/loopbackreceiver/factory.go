@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loopbackreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/loopbackreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/loopback"
+)
+
+const (
+	// Value of "type" key in configuration.
+	typeStr = "loopback"
+	// The stability level of the receiver.
+	stability = component.StabilityLevelInDevelopment
+)
+
+// NewFactory creates a factory for the loopback receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesReceiver(createTracesReceiver, stability),
+		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, stability))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	_ component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	id := cfg.ID()
+	return &loopbackReceiver{register: func() (func(), error) {
+		return loopback.RegisterTraces(id, nextConsumer)
+	}}, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	_ component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	id := cfg.ID()
+	return &loopbackReceiver{register: func() (func(), error) {
+		return loopback.RegisterMetrics(id, nextConsumer)
+	}}, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	_ component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	id := cfg.ID()
+	return &loopbackReceiver{register: func() (func(), error) {
+		return loopback.RegisterLogs(id, nextConsumer)
+	}}, nil
+}
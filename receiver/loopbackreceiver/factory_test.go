@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loopbackreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/loopback"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+	assert.Equal(t, config.NewComponentID(typeStr), cfg.ID())
+}
+
+func TestTracesReceiver(t *testing.T) {
+	cfg := createDefaultConfig()
+	cfg.SetIDName("traces")
+	sink := new(consumertest.TracesSink)
+
+	r, err := NewFactory().CreateTracesReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	assert.Nil(t, loopback.Traces(cfg.ID()))
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, sink, loopback.Traces(cfg.ID()))
+
+	// A second receiver with the same ID is refused.
+	other, err := NewFactory().CreateTracesReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, other.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Nil(t, loopback.Traces(cfg.ID()))
+}
+
+func TestMetricsReceiver(t *testing.T) {
+	cfg := createDefaultConfig()
+	cfg.SetIDName("metrics")
+	sink := new(consumertest.MetricsSink)
+
+	r, err := NewFactory().CreateMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, sink, loopback.Metrics(cfg.ID()))
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Nil(t, loopback.Metrics(cfg.ID()))
+}
+
+func TestLogsReceiver(t *testing.T) {
+	cfg := createDefaultConfig()
+	cfg.SetIDName("logs")
+	sink := new(consumertest.LogsSink)
+
+	r, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Equal(t, sink, loopback.Logs(cfg.ID()))
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Nil(t, loopback.Logs(cfg.ID()))
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loopbackreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/loopbackreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// loopbackReceiver makes its next consumer available to the exporters while
+// it is started.
+type loopbackReceiver struct {
+	register   func() (func(), error)
+	unregister func()
+}
+
+func (r *loopbackReceiver) Start(context.Context, component.Host) error {
+	unregister, err := r.register()
+	if err != nil {
+		return err
+	}
+	r.unregister = unregister
+	return nil
+}
+
+func (r *loopbackReceiver) Shutdown(context.Context) error {
+	if r.unregister != nil {
+		r.unregister()
+	}
+	return nil
+}
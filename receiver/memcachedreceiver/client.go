@@ -15,7 +15,15 @@
 package memcachedreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/memcachedreceiver"
 
 import (
+	"bufio"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/grobie/gomemcache/memcache"
@@ -25,14 +33,254 @@ type client interface {
 	Stats() (map[net.Addr]memcache.Stats, error)
 }
 
-type newMemcachedClientFunc func(endpoint string, timeout time.Duration) (client, error)
+type newMemcachedClientFunc func(cfg *Config, endpoint string) (client, error)
 
-func newMemcachedClient(endpoint string, timeout time.Duration) (client, error) {
-	newClient, err := memcache.New(endpoint)
+func newMemcachedClient(cfg *Config, endpoint string) (client, error) {
+	if cfg.TLS == nil && cfg.Auth == nil {
+		newClient, err := memcache.New(endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		newClient.Timeout = cfg.Timeout
+		return newClient, nil
+	}
+
+	c := &connClient{
+		endpoint: endpoint,
+		timeout:  cfg.Timeout,
+		auth:     cfg.Auth,
+	}
+	if cfg.TLS != nil {
+		tlsConfig, err := cfg.TLS.LoadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		c.tlsConfig = tlsConfig
+	}
+	return c, nil
+}
+
+const (
+	binaryRequestMagic  = 0x80
+	binaryResponseMagic = 0x81
+	binaryHeaderSize    = 24
+
+	opcodeStat     = 0x10
+	opcodeSASLAuth = 0x21
+
+	statusSuccess   = 0x00
+	statusAuthError = 0x20
+)
+
+var errAuthFailed = errors.New("memcached authentication failed")
+
+// statsGroups are the groups of stats requested from memcached, the general stats first,
+// as requested by the gomemcache client.
+var statsGroups = []string{"", "slabs", "items"}
+
+// connClient fetches the stats of a memcached instance over an encrypted or an authenticated connection,
+// which the gomemcache client doesn't support. The stats are requested with the text protocol, unless
+// the connection is authenticated with SASL, which requires the binary protocol.
+type connClient struct {
+	endpoint  string
+	timeout   time.Duration
+	tlsConfig *tls.Config
+	auth      *AuthConfig
+}
+
+var _ client = (*connClient)(nil)
+
+func (c *connClient) Stats() (map[net.Addr]memcache.Stats, error) {
+	network := "tcp"
+	if strings.Contains(c.endpoint, "/") {
+		network = "unix"
+	}
+	dialer := &net.Dialer{Timeout: c.timeout}
+
+	var conn net.Conn
+	var err error
+	if c.tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, network, c.endpoint, c.tlsConfig)
+	} else {
+		conn, err = dialer.Dial(network, c.endpoint)
+	}
 	if err != nil {
 		return nil, err
 	}
+	defer conn.Close()
+	if c.timeout > 0 {
+		if err = conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+			return nil, err
+		}
+	}
+
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	if c.auth != nil {
+		if err = authenticate(rw, c.auth); err != nil {
+			return nil, err
+		}
+	}
+
+	stats := memcache.Stats{
+		Stats: make(map[string]string),
+		Slabs: make(map[int]map[string]string),
+		Items: make(map[int]map[string]string),
+	}
+	for _, group := range statsGroups {
+		if c.auth != nil {
+			err = binaryStats(rw, group, &stats)
+		} else {
+			err = textStats(rw, group, &stats)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return map[net.Addr]memcache.Stats{conn.RemoteAddr(): stats}, nil
+}
+
+// addStat adds a stat to the general, the slab or the item stats, depending on its key:
+// "<name>", "<slab id>:<name>" or "items:<slab id>:<name>".
+func addStat(stats *memcache.Stats, key, value string) error {
+	fields := strings.Split(key, ":")
+	var group map[int]map[string]string
+	switch len(fields) {
+	case 1:
+		stats.Stats[key] = value
+		return nil
+	case 2:
+		group = stats.Slabs
+	case 3:
+		group = stats.Items
+		fields = fields[1:]
+	default:
+		return fmt.Errorf("unexpected stat: %q", key)
+	}
+
+	slabID, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return fmt.Errorf("unexpected stat: %q", key)
+	}
+	if group[slabID] == nil {
+		group[slabID] = make(map[string]string)
+	}
+	group[slabID][fields[1]] = value
+	return nil
+}
+
+// textStats requests a group of stats with the text protocol.
+func textStats(rw *bufio.ReadWriter, group string, stats *memcache.Stats) error {
+	cmd := "stats"
+	if group != "" {
+		cmd += " " + group
+	}
+	if _, err := rw.WriteString(cmd + "\r\n"); err != nil {
+		return err
+	}
+	if err := rw.Flush(); err != nil {
+		return err
+	}
+
+	for {
+		line, err := rw.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "END" {
+			return nil
+		}
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 || fields[0] != "STAT" {
+			return fmt.Errorf("unexpected stats response: %q", line)
+		}
+		if err = addStat(stats, fields[1], fields[2]); err != nil {
+			return err
+		}
+	}
+}
+
+// authenticate authenticates the connection with the SASL PLAIN mechanism of the binary protocol.
+func authenticate(rw *bufio.ReadWriter, auth *AuthConfig) error {
+	credentials := "\x00" + auth.Username + "\x00" + auth.Password
+	if err := writeBinaryRequest(rw, opcodeSASLAuth, "PLAIN", credentials); err != nil {
+		return err
+	}
+	status, _, value, err := readBinaryResponse(rw, opcodeSASLAuth)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case statusSuccess:
+		return nil
+	case statusAuthError:
+		return errAuthFailed
+	default:
+		return fmt.Errorf("memcached authentication failed with status 0x%02x: %s", status, value)
+	}
+}
+
+// binaryStats requests a group of stats with the binary protocol, which returns one response per stat
+// followed by an empty response.
+func binaryStats(rw *bufio.ReadWriter, group string, stats *memcache.Stats) error {
+	if err := writeBinaryRequest(rw, opcodeStat, group, ""); err != nil {
+		return err
+	}
+
+	for {
+		status, key, value, err := readBinaryResponse(rw, opcodeStat)
+		if err != nil {
+			return err
+		}
+		if status != statusSuccess {
+			return fmt.Errorf("memcached stats failed with status 0x%02x: %s", status, value)
+		}
+		if key == "" {
+			return nil
+		}
+		if err = addStat(stats, key, value); err != nil {
+			return err
+		}
+	}
+}
 
-	newClient.Timeout = timeout
-	return newClient, nil
+func writeBinaryRequest(rw *bufio.ReadWriter, opcode byte, key, value string) error {
+	header := make([]byte, binaryHeaderSize)
+	header[0] = binaryRequestMagic
+	header[1] = opcode
+	binary.BigEndian.PutUint16(header[2:4], uint16(len(key)))
+	binary.BigEndian.PutUint32(header[8:12], uint32(len(key)+len(value)))
+	if _, err := rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := rw.WriteString(key + value); err != nil {
+		return err
+	}
+	return rw.Flush()
+}
+
+func readBinaryResponse(rw *bufio.ReadWriter, opcode byte) (status uint16, key, value string, err error) {
+	header := make([]byte, binaryHeaderSize)
+	if _, err = io.ReadFull(rw, header); err != nil {
+		return 0, "", "", err
+	}
+	if header[0] != binaryResponseMagic || header[1] != opcode {
+		return 0, "", "", fmt.Errorf("unexpected memcached response: magic 0x%02x, opcode 0x%02x", header[0], header[1])
+	}
+	keyLength := int(binary.BigEndian.Uint16(header[2:4]))
+	extrasLength := int(header[4])
+	status = binary.BigEndian.Uint16(header[6:8])
+	bodyLength := int(binary.BigEndian.Uint32(header[8:12]))
+	if keyLength+extrasLength > bodyLength {
+		return 0, "", "", fmt.Errorf("invalid memcached response: body of %d bytes", bodyLength)
+	}
+
+	body := make([]byte, bodyLength)
+	if _, err = io.ReadFull(rw, body); err != nil {
+		return 0, "", "", err
+	}
+	key = string(body[extrasLength : extrasLength+keyLength])
+	value = string(body[extrasLength+keyLength:])
+	return status, key, value, nil
 }
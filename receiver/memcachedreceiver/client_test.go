@@ -0,0 +1,238 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memcachedreceiver
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grobie/gomemcache/memcache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+var fakeServerStats = map[string][][2]string{
+	"":      {{"curr_connections", "3"}, {"threads", "4"}},
+	"slabs": {{"1:chunk_size", "96"}, {"1:used_chunks", "10"}, {"active_slabs", "1"}},
+	"items": {{"items:1:evicted", "2"}, {"items:1:age", "60"}},
+}
+
+// newFakeServer starts a memcached server answering the stats commands of the text protocol, and
+// the SASL PLAIN authentication and the stat commands of the binary protocol.
+func newFakeServer(t *testing.T, listener net.Listener, credentials string) string {
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeConn(conn, credentials)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveFakeConn(conn net.Conn, credentials string) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	magic, err := r.Peek(1)
+	if err != nil {
+		return
+	}
+
+	if magic[0] != binaryRequestMagic {
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			group := strings.TrimPrefix(strings.TrimRight(line, "\r\n"), "stats")
+			stats, ok := fakeServerStats[strings.TrimSpace(group)]
+			if !ok {
+				return
+			}
+			for _, stat := range stats {
+				_, _ = io.WriteString(conn, "STAT "+stat[0]+" "+stat[1]+"\r\n")
+			}
+			_, _ = io.WriteString(conn, "END\r\n")
+		}
+	}
+
+	authenticated := false
+	for {
+		header := make([]byte, binaryHeaderSize)
+		if _, err = io.ReadFull(r, header); err != nil {
+			return
+		}
+		keyLength := binary.BigEndian.Uint16(header[2:4])
+		body := make([]byte, binary.BigEndian.Uint32(header[8:12]))
+		if _, err = io.ReadFull(r, body); err != nil {
+			return
+		}
+		switch header[1] {
+		case opcodeSASLAuth:
+			if string(body[:keyLength]) == "PLAIN" && string(body[keyLength:]) == credentials {
+				authenticated = true
+				writeFakeResponse(conn, opcodeSASLAuth, statusSuccess, "", "Authenticated")
+			} else {
+				writeFakeResponse(conn, opcodeSASLAuth, statusAuthError, "", "Auth failure")
+			}
+		case opcodeStat:
+			if !authenticated {
+				writeFakeResponse(conn, opcodeStat, statusAuthError, "", "Auth failure")
+				return
+			}
+			for _, stat := range fakeServerStats[string(body[:keyLength])] {
+				writeFakeResponse(conn, opcodeStat, statusSuccess, stat[0], stat[1])
+			}
+			writeFakeResponse(conn, opcodeStat, statusSuccess, "", "")
+		}
+	}
+}
+
+func writeFakeResponse(w io.Writer, opcode byte, status uint16, key, value string) {
+	header := make([]byte, binaryHeaderSize)
+	header[0] = binaryResponseMagic
+	header[1] = opcode
+	binary.BigEndian.PutUint16(header[2:4], uint16(len(key)))
+	binary.BigEndian.PutUint16(header[6:8], status)
+	binary.BigEndian.PutUint32(header[8:12], uint32(len(key)+len(value)))
+	_, _ = w.Write(append(header, key+value...))
+}
+
+func newTLSListener(t *testing.T) net.Listener {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	})
+	require.NoError(t, err)
+	return listener
+}
+
+func newTCPListener(t *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return listener
+}
+
+func TestConnClientStats(t *testing.T) {
+	expected := memcache.Stats{
+		Stats: map[string]string{"curr_connections": "3", "threads": "4", "active_slabs": "1"},
+		Slabs: map[int]map[string]string{1: {"chunk_size": "96", "used_chunks": "10"}},
+		Items: map[int]map[string]string{1: {"evicted": "2", "age": "60"}},
+	}
+	tlsSetting := &configtls.TLSClientSetting{InsecureSkipVerify: true}
+
+	tests := []struct {
+		name     string
+		listener func(t *testing.T) net.Listener
+		tls      *configtls.TLSClientSetting
+		auth     *AuthConfig
+	}{
+		{
+			name:     "tls",
+			listener: newTLSListener,
+			tls:      tlsSetting,
+		},
+		{
+			name:     "auth",
+			listener: newTCPListener,
+			auth:     &AuthConfig{Username: "otel", Password: "secret"},
+		},
+		{
+			name:     "tls and auth",
+			listener: newTLSListener,
+			tls:      tlsSetting,
+			auth:     &AuthConfig{Username: "otel", Password: "secret"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Endpoint = newFakeServer(t, tt.listener(t), "\x00otel\x00secret")
+			cfg.TLS = tt.tls
+			cfg.Auth = tt.auth
+			require.NoError(t, cfg.Validate())
+
+			c, err := newMemcachedClient(cfg, cfg.Endpoint)
+			require.NoError(t, err)
+			require.IsType(t, &connClient{}, c)
+
+			stats, err := c.Stats()
+			require.NoError(t, err)
+			require.Len(t, stats, 1)
+			for addr, s := range stats {
+				assert.Equal(t, cfg.Endpoint, addr.String())
+				assert.Equal(t, expected, s)
+			}
+		})
+	}
+}
+
+func TestConnClientAuthFailure(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = newFakeServer(t, newTCPListener(t), "\x00otel\x00secret")
+	cfg.Auth = &AuthConfig{Username: "otel", Password: "wrong"}
+
+	c, err := newMemcachedClient(cfg, cfg.Endpoint)
+	require.NoError(t, err)
+	_, err = c.Stats()
+	require.ErrorIs(t, err, errAuthFailed)
+}
+
+func TestConnClientUnexpectedResponse(t *testing.T) {
+	listener := newTCPListener(t)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = bufio.NewReader(conn).ReadString('\n')
+		_, _ = io.WriteString(conn, "ERROR\r\n")
+	}()
+
+	c := &connClient{endpoint: listener.Addr().String(), timeout: time.Second}
+	_, err := c.Stats()
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "unexpected stats response"), err.Error())
+}
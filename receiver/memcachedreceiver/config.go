@@ -15,9 +15,12 @@
 package memcachedreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/memcachedreceiver"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/memcachedreceiver/internal/metadata"
@@ -27,9 +30,59 @@ type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	confignet.NetAddr                       `mapstructure:",squash"`
 
+	// Endpoints are the endpoints of the memcached servers of a cluster, scraped instead of the endpoint.
+	// The metrics of each server are emitted under a resource with its memcached.node attribute.
+	Endpoints []string `mapstructure:"endpoints"`
+
 	// Timeout for the memcache stats request
 	Timeout time.Duration `mapstructure:"timeout"`
 
+	// TLS enables the encryption in transit of the connections to memcached.
+	TLS *configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// Auth authenticates the connections to memcached with SASL, which uses the binary protocol.
+	Auth *AuthConfig `mapstructure:"auth,omitempty"`
+
 	// Metrics allows customizing scraped metrics representation.
 	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
 }
+
+// AuthConfig defines the SASL PLAIN credentials of memcached.
+type AuthConfig struct {
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+var (
+	errEmptyEndpoint   = errors.New("endpoints: endpoint must not be empty")
+	errMissingUsername = errors.New("auth: username must be set")
+	errMissingPassword = errors.New("auth: password must be set")
+)
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	endpoints := make(map[string]struct{}, len(cfg.Endpoints))
+	for _, endpoint := range cfg.Endpoints {
+		if endpoint == "" {
+			return errEmptyEndpoint
+		}
+		if _, ok := endpoints[endpoint]; ok {
+			return fmt.Errorf("endpoints: duplicate endpoint %q", endpoint)
+		}
+		endpoints[endpoint] = struct{}{}
+	}
+	if cfg.Auth != nil {
+		if cfg.Auth.Username == "" {
+			return errMissingUsername
+		}
+		if cfg.Auth.Password == "" {
+			return errMissingPassword
+		}
+	}
+	if cfg.TLS != nil {
+		if _, err := cfg.TLS.LoadTLSConfig(); err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+	}
+	return nil
+}
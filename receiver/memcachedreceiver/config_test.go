@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 )
 
@@ -42,3 +43,49 @@ func TestLoadConfig(t *testing.T) {
 
 	require.Equal(t, factory.CreateDefaultConfig(), cfg)
 }
+
+func TestLoadAuthConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "auth").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+	require.NoError(t, cfg.Validate())
+
+	require.Equal(t, "my-cluster.abc123.cfg.use1.cache.amazonaws.com:11211", cfg.Endpoint)
+	require.Equal(t, &configtls.TLSClientSetting{
+		ServerName: "my-cluster.abc123.cfg.use1.cache.amazonaws.com",
+	}, cfg.TLS)
+	require.Equal(t, &AuthConfig{Username: "otel", Password: "${MEMCACHED_PASSWORD}"}, cfg.Auth)
+
+	cfg = factory.CreateDefaultConfig().(*Config)
+	sub, err = cm.Sub(config.NewComponentIDWithName(typeStr, "missing_password").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+	require.ErrorIs(t, cfg.Validate(), errMissingPassword)
+}
+
+func TestLoadClusterConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "cluster").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, []string{"memcached-0.memcached:11211", "memcached-1.memcached:11211"}, cfg.Endpoints)
+
+	cfg = factory.CreateDefaultConfig().(*Config)
+	sub, err = cm.Sub(config.NewComponentIDWithName(typeStr, "duplicate_endpoints").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+	require.EqualError(t, cfg.Validate(), `endpoints: duplicate endpoint "memcached-0.memcached:11211"`)
+
+	cfg.Endpoints = []string{""}
+	require.ErrorIs(t, cfg.Validate(), errEmptyEndpoint)
+}
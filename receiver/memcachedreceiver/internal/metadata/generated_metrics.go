@@ -29,6 +29,10 @@ type MetricsSettings struct {
 	MemcachedNetworkSent        MetricSettings `mapstructure:"memcached.network.sent"`
 	MemcachedOperationHitRatio  MetricSettings `mapstructure:"memcached.operation_hit_ratio"`
 	MemcachedOperations         MetricSettings `mapstructure:"memcached.operations"`
+	MemcachedSlabChunkSize      MetricSettings `mapstructure:"memcached.slab.chunk_size"`
+	MemcachedSlabChunksUsed     MetricSettings `mapstructure:"memcached.slab.chunks.used"`
+	MemcachedSlabEvictions      MetricSettings `mapstructure:"memcached.slab.evictions"`
+	MemcachedSlabItemsOldestAge MetricSettings `mapstructure:"memcached.slab.items.oldest_age"`
 	MemcachedThreads            MetricSettings `mapstructure:"memcached.threads"`
 }
 
@@ -70,6 +74,18 @@ func DefaultMetricsSettings() MetricsSettings {
 		MemcachedOperations: MetricSettings{
 			Enabled: true,
 		},
+		MemcachedSlabChunkSize: MetricSettings{
+			Enabled: false,
+		},
+		MemcachedSlabChunksUsed: MetricSettings{
+			Enabled: false,
+		},
+		MemcachedSlabEvictions: MetricSettings{
+			Enabled: false,
+		},
+		MemcachedSlabItemsOldestAge: MetricSettings{
+			Enabled: false,
+		},
 		MemcachedThreads: MetricSettings{
 			Enabled: true,
 		},
@@ -837,6 +853,214 @@ func newMetricMemcachedOperations(settings MetricSettings) metricMemcachedOperat
 	return m
 }
 
+type metricMemcachedSlabChunkSize struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills memcached.slab.chunk_size metric with initial data.
+func (m *metricMemcachedSlabChunkSize) init() {
+	m.data.SetName("memcached.slab.chunk_size")
+	m.data.SetDescription("The size of the chunks of the slab class.")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricMemcachedSlabChunkSize) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, slabIDAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("slab_id", slabIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricMemcachedSlabChunkSize) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricMemcachedSlabChunkSize) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricMemcachedSlabChunkSize(settings MetricSettings) metricMemcachedSlabChunkSize {
+	m := metricMemcachedSlabChunkSize{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricMemcachedSlabChunksUsed struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills memcached.slab.chunks.used metric with initial data.
+func (m *metricMemcachedSlabChunksUsed) init() {
+	m.data.SetName("memcached.slab.chunks.used")
+	m.data.SetDescription("The number of chunks of the slab class allocated to items.")
+	m.data.SetUnit("{chunks}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricMemcachedSlabChunksUsed) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, slabIDAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("slab_id", slabIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricMemcachedSlabChunksUsed) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricMemcachedSlabChunksUsed) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricMemcachedSlabChunksUsed(settings MetricSettings) metricMemcachedSlabChunksUsed {
+	m := metricMemcachedSlabChunksUsed{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricMemcachedSlabEvictions struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills memcached.slab.evictions metric with initial data.
+func (m *metricMemcachedSlabEvictions) init() {
+	m.data.SetName("memcached.slab.evictions")
+	m.data.SetDescription("Items evicted from the slab class to free memory.")
+	m.data.SetUnit("{evictions}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricMemcachedSlabEvictions) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, slabIDAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("slab_id", slabIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricMemcachedSlabEvictions) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricMemcachedSlabEvictions) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricMemcachedSlabEvictions(settings MetricSettings) metricMemcachedSlabEvictions {
+	m := metricMemcachedSlabEvictions{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricMemcachedSlabItemsOldestAge struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills memcached.slab.items.oldest_age metric with initial data.
+func (m *metricMemcachedSlabItemsOldestAge) init() {
+	m.data.SetName("memcached.slab.items.oldest_age")
+	m.data.SetDescription("The age of the oldest item of the slab class.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricMemcachedSlabItemsOldestAge) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, slabIDAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("slab_id", slabIDAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricMemcachedSlabItemsOldestAge) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricMemcachedSlabItemsOldestAge) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricMemcachedSlabItemsOldestAge(settings MetricSettings) metricMemcachedSlabItemsOldestAge {
+	m := metricMemcachedSlabItemsOldestAge{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricMemcachedThreads struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	settings MetricSettings // metric settings provided by user.
@@ -908,6 +1132,10 @@ type MetricsBuilder struct {
 	metricMemcachedNetworkSent        metricMemcachedNetworkSent
 	metricMemcachedOperationHitRatio  metricMemcachedOperationHitRatio
 	metricMemcachedOperations         metricMemcachedOperations
+	metricMemcachedSlabChunkSize      metricMemcachedSlabChunkSize
+	metricMemcachedSlabChunksUsed     metricMemcachedSlabChunksUsed
+	metricMemcachedSlabEvictions      metricMemcachedSlabEvictions
+	metricMemcachedSlabItemsOldestAge metricMemcachedSlabItemsOldestAge
 	metricMemcachedThreads            metricMemcachedThreads
 }
 
@@ -938,6 +1166,10 @@ func NewMetricsBuilder(settings MetricsSettings, buildInfo component.BuildInfo,
 		metricMemcachedNetworkSent:        newMetricMemcachedNetworkSent(settings.MemcachedNetworkSent),
 		metricMemcachedOperationHitRatio:  newMetricMemcachedOperationHitRatio(settings.MemcachedOperationHitRatio),
 		metricMemcachedOperations:         newMetricMemcachedOperations(settings.MemcachedOperations),
+		metricMemcachedSlabChunkSize:      newMetricMemcachedSlabChunkSize(settings.MemcachedSlabChunkSize),
+		metricMemcachedSlabChunksUsed:     newMetricMemcachedSlabChunksUsed(settings.MemcachedSlabChunksUsed),
+		metricMemcachedSlabEvictions:      newMetricMemcachedSlabEvictions(settings.MemcachedSlabEvictions),
+		metricMemcachedSlabItemsOldestAge: newMetricMemcachedSlabItemsOldestAge(settings.MemcachedSlabItemsOldestAge),
 		metricMemcachedThreads:            newMetricMemcachedThreads(settings.MemcachedThreads),
 	}
 	for _, op := range options {
@@ -959,6 +1191,13 @@ func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
 // ResourceMetricsOption applies changes to provided resource metrics.
 type ResourceMetricsOption func(pmetric.ResourceMetrics)
 
+// WithMemcachedNode sets provided value as "memcached.node" attribute for current resource.
+func WithMemcachedNode(val string) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		rm.Resource().Attributes().PutStr("memcached.node", val)
+	}
+}
+
 // WithStartTimeOverride overrides start time for all the resource metrics data points.
 // This option should be only used if different start time has to be set on metrics coming from different resources.
 func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
@@ -1003,6 +1242,10 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	mb.metricMemcachedNetworkSent.emit(ils.Metrics())
 	mb.metricMemcachedOperationHitRatio.emit(ils.Metrics())
 	mb.metricMemcachedOperations.emit(ils.Metrics())
+	mb.metricMemcachedSlabChunkSize.emit(ils.Metrics())
+	mb.metricMemcachedSlabChunksUsed.emit(ils.Metrics())
+	mb.metricMemcachedSlabEvictions.emit(ils.Metrics())
+	mb.metricMemcachedSlabItemsOldestAge.emit(ils.Metrics())
 	mb.metricMemcachedThreads.emit(ils.Metrics())
 	for _, op := range rmo {
 		op(rm)
@@ -1083,6 +1326,26 @@ func (mb *MetricsBuilder) RecordMemcachedOperationsDataPoint(ts pcommon.Timestam
 	mb.metricMemcachedOperations.recordDataPoint(mb.startTime, ts, val, typeAttributeValue.String(), operationAttributeValue.String())
 }
 
+// RecordMemcachedSlabChunkSizeDataPoint adds a data point to memcached.slab.chunk_size metric.
+func (mb *MetricsBuilder) RecordMemcachedSlabChunkSizeDataPoint(ts pcommon.Timestamp, val int64, slabIDAttributeValue string) {
+	mb.metricMemcachedSlabChunkSize.recordDataPoint(mb.startTime, ts, val, slabIDAttributeValue)
+}
+
+// RecordMemcachedSlabChunksUsedDataPoint adds a data point to memcached.slab.chunks.used metric.
+func (mb *MetricsBuilder) RecordMemcachedSlabChunksUsedDataPoint(ts pcommon.Timestamp, val int64, slabIDAttributeValue string) {
+	mb.metricMemcachedSlabChunksUsed.recordDataPoint(mb.startTime, ts, val, slabIDAttributeValue)
+}
+
+// RecordMemcachedSlabEvictionsDataPoint adds a data point to memcached.slab.evictions metric.
+func (mb *MetricsBuilder) RecordMemcachedSlabEvictionsDataPoint(ts pcommon.Timestamp, val int64, slabIDAttributeValue string) {
+	mb.metricMemcachedSlabEvictions.recordDataPoint(mb.startTime, ts, val, slabIDAttributeValue)
+}
+
+// RecordMemcachedSlabItemsOldestAgeDataPoint adds a data point to memcached.slab.items.oldest_age metric.
+func (mb *MetricsBuilder) RecordMemcachedSlabItemsOldestAgeDataPoint(ts pcommon.Timestamp, val int64, slabIDAttributeValue string) {
+	mb.metricMemcachedSlabItemsOldestAge.recordDataPoint(mb.startTime, ts, val, slabIDAttributeValue)
+}
+
 // RecordMemcachedThreadsDataPoint adds a data point to memcached.threads metric.
 func (mb *MetricsBuilder) RecordMemcachedThreadsDataPoint(ts pcommon.Timestamp, val int64) {
 	mb.metricMemcachedThreads.recordDataPoint(mb.startTime, ts, val)
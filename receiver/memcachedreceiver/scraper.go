@@ -16,6 +16,7 @@ package memcachedreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -23,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/memcachedreceiver/internal/metadata"
@@ -84,18 +87,45 @@ func newMemcachedScraper(
 }
 
 func (r *memcachedScraper) scrape(_ context.Context) (pmetric.Metrics, error) {
+	if len(r.config.Endpoints) == 0 {
+		if err := r.scrapeEndpoint(r.config.Endpoint); err != nil {
+			return pmetric.Metrics{}, err
+		}
+		return r.mb.Emit(), nil
+	}
+
+	// The metrics of each endpoint are emitted under their own resource.
+	var errs error
+	scrapeErrs := &scrapererror.ScrapeErrors{}
+	for _, endpoint := range r.config.Endpoints {
+		if err := r.scrapeEndpoint(endpoint); err != nil {
+			err = fmt.Errorf("failed to scrape %s: %w", endpoint, err)
+			errs = multierr.Append(errs, err)
+			scrapeErrs.AddPartial(1, err)
+			continue
+		}
+		r.mb.EmitForResource(metadata.WithMemcachedNode(endpoint))
+	}
+	if len(multierr.Errors(errs)) == len(r.config.Endpoints) {
+		return pmetric.Metrics{}, errs
+	}
+	return r.mb.Emit(), scrapeErrs.Combine()
+}
+
+// scrapeEndpoint records the metrics of the memcached servers of an endpoint.
+func (r *memcachedScraper) scrapeEndpoint(endpoint string) error {
 	// Init client in scrape method in case there are transient errors in the
 	// constructor.
-	statsClient, err := r.newClient(r.config.Endpoint, r.config.Timeout)
+	statsClient, err := r.newClient(r.config, endpoint)
 	if err != nil {
 		r.logger.Error("Failed to establish client", zap.Error(err))
-		return pmetric.Metrics{}, err
+		return err
 	}
 
 	allServerStats, err := statsClient.Stats()
 	if err != nil {
 		r.logger.Error("Failed to fetch memcached stats", zap.Error(err))
-		return pmetric.Metrics{}, err
+		return err
 	}
 
 	now := pcommon.NewTimestampFromTime(time.Now())
@@ -225,9 +255,42 @@ func (r *memcachedScraper) scrape(_ context.Context) (pmetric.Metrics, error) {
 		if okHit && okMiss {
 			r.mb.RecordMemcachedOperationHitRatioDataPoint(now, calculateHitRatio(parsedHit, parsedMiss), metadata.AttributeOperationGet)
 		}
+
+		// Slab Metrics
+		for slabID, slabStats := range stats.Slabs {
+			slabIDAttr := strconv.Itoa(slabID)
+			for k, v := range slabStats {
+				switch k {
+				case "chunk_size":
+					if parsedV, ok := r.parseInt(k, v); ok {
+						r.mb.RecordMemcachedSlabChunkSizeDataPoint(now, parsedV, slabIDAttr)
+					}
+				case "used_chunks":
+					if parsedV, ok := r.parseInt(k, v); ok {
+						r.mb.RecordMemcachedSlabChunksUsedDataPoint(now, parsedV, slabIDAttr)
+					}
+				}
+			}
+		}
+
+		for slabID, itemStats := range stats.Items {
+			slabIDAttr := strconv.Itoa(slabID)
+			for k, v := range itemStats {
+				switch k {
+				case "evicted":
+					if parsedV, ok := r.parseInt(k, v); ok {
+						r.mb.RecordMemcachedSlabEvictionsDataPoint(now, parsedV, slabIDAttr)
+					}
+				case "age":
+					if parsedV, ok := r.parseInt(k, v); ok {
+						r.mb.RecordMemcachedSlabItemsOldestAgeDataPoint(now, parsedV, slabIDAttr)
+					}
+				}
+			}
+		}
 	}
 
-	return r.mb.Emit(), nil
+	return nil
 }
 
 func calculateHitRatio(misses, hits int64) float64 {
@@ -16,12 +16,13 @@ package memcachedreceiver
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest/golden"
@@ -31,7 +32,7 @@ func TestScraper(t *testing.T) {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig().(*Config)
 	scraper := newMemcachedScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
-	scraper.newClient = func(endpoint string, timeout time.Duration) (client, error) {
+	scraper.newClient = func(cfg *Config, endpoint string) (client, error) {
 		return &fakeClient{}, nil
 	}
 
@@ -51,7 +52,7 @@ func TestScraperWithoutDirectionAttribute(t *testing.T) {
 	scraper := newMemcachedScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
 	scraper.emitMetricsWithDirectionAttribute = false
 	scraper.emitMetricsWithoutDirectionAttribute = true
-	scraper.newClient = func(endpoint string, timeout time.Duration) (client, error) {
+	scraper.newClient = func(cfg *Config, endpoint string) (client, error) {
 		return &fakeClient{}, nil
 	}
 
@@ -64,3 +65,68 @@ func TestScraperWithoutDirectionAttribute(t *testing.T) {
 
 	require.NoError(t, scrapertest.CompareMetrics(expectedMetrics, actualMetrics))
 }
+
+func TestScraperSlabs(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Metrics.MemcachedSlabChunkSize.Enabled = true
+	cfg.Metrics.MemcachedSlabChunksUsed.Enabled = true
+	cfg.Metrics.MemcachedSlabEvictions.Enabled = true
+	cfg.Metrics.MemcachedSlabItemsOldestAge.Enabled = true
+	scraper := newMemcachedScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	scraper.newClient = func(cfg *Config, endpoint string) (client, error) {
+		return &fakeClient{}, nil
+	}
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	expectedFile := filepath.Join("testdata", "expected_metrics", "test_scraper", "expected_slabs.json")
+	expectedMetrics, err := golden.ReadMetrics(expectedFile)
+	require.NoError(t, err)
+
+	require.NoError(t, scrapertest.CompareMetrics(expectedMetrics, actualMetrics))
+}
+
+func TestScraperMultipleEndpoints(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoints = []string{"memcached-0:11211", "memcached-1:11211", "memcached-2:11211"}
+	require.NoError(t, cfg.Validate())
+
+	scraper := newMemcachedScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	scraper.newClient = func(cfg *Config, endpoint string) (client, error) {
+		if endpoint == "memcached-2:11211" {
+			return nil, errors.New("connection refused")
+		}
+		return &fakeClient{}, nil
+	}
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.EqualError(t, err, "failed to scrape memcached-2:11211: connection refused")
+
+	rms := actualMetrics.ResourceMetrics()
+	require.Equal(t, 2, rms.Len())
+	for i, endpoint := range []string{"memcached-0:11211", "memcached-1:11211"} {
+		node, ok := rms.At(i).Resource().Attributes().Get("memcached.node")
+		require.True(t, ok)
+		require.Equal(t, endpoint, node.Str())
+		require.Equal(t, actualMetrics.MetricCount()/2, rms.At(i).ScopeMetrics().At(0).Metrics().Len())
+	}
+}
+
+func TestScraperMultipleEndpointsFailed(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoints = []string{"memcached-0:11211", "memcached-1:11211"}
+
+	scraper := newMemcachedScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	scraper.newClient = func(cfg *Config, endpoint string) (client, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	_, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	require.False(t, scrapererror.IsPartialScrapeError(err))
+}
@@ -42,6 +42,14 @@ type Config struct {
 	Logs                                    LogConfig                    `mapstructure:"logs"`
 	RetrySettings                           exporterhelper.RetrySettings `mapstructure:"retry_on_failure"`
 	StorageID                               *config.ComponentID          `mapstructure:"storage"`
+	Backfill                                BackfillConfig               `mapstructure:"backfill"`
+}
+
+// BackfillConfig configures the recovery of the measurements missed while the collector was down,
+// from the time of the last successful scrape, checkpointed with the storage extension.
+type BackfillConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	MaxWindow time.Duration `mapstructure:"max_window"`
 }
 
 type AlertConfig struct {
@@ -102,6 +110,10 @@ var (
 	// Logs Receiver Errors
 	errNoProjects    = errors.New("at least one 'project' must be specified")
 	errClusterConfig = errors.New("only one of 'include_clusters' or 'exclude_clusters' may be specified")
+
+	// Metrics Receiver Errors
+	errNoStorage     = errors.New("a 'storage' extension must be specified to backfill the measurements")
+	errInvalidWindow = errors.New("'max_window' must be positive")
 )
 
 func (c *Config) Validate() error {
@@ -110,6 +122,24 @@ func (c *Config) Validate() error {
 	errs = multierr.Append(errs, c.ScraperControllerSettings.Validate())
 	errs = multierr.Append(errs, c.Alerts.validate())
 	errs = multierr.Append(errs, c.Logs.validate())
+	errs = multierr.Append(errs, c.validateBackfill())
+
+	return errs
+}
+
+func (c *Config) validateBackfill() error {
+	if !c.Backfill.Enabled {
+		return nil
+	}
+
+	var errs error
+	if c.StorageID == nil {
+		errs = multierr.Append(errs, errNoStorage)
+	}
+
+	if c.Backfill.MaxWindow <= 0 {
+		errs = multierr.Append(errs, errInvalidWindow)
+	}
 
 	return errs
 }
@@ -18,10 +18,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
 func TestValidate(t *testing.T) {
+	storageID := config.NewComponentID("file_storage")
 	testCases := []struct {
 		name        string
 		input       Config
@@ -223,6 +225,36 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: errPageSizeIncorrect.Error(),
 		},
+		{
+			name: "Valid Backfill Config",
+			input: Config{
+				StorageID: &storageID,
+				Backfill: BackfillConfig{
+					Enabled:   true,
+					MaxWindow: defaultBackfillMaxWindow,
+				},
+			},
+		},
+		{
+			name: "Backfill missing storage",
+			input: Config{
+				Backfill: BackfillConfig{
+					Enabled:   true,
+					MaxWindow: defaultBackfillMaxWindow,
+				},
+			},
+			expectedErr: errNoStorage.Error(),
+		},
+		{
+			name: "Invalid Backfill Window",
+			input: Config{
+				StorageID: &storageID,
+				Backfill: BackfillConfig{
+					Enabled: true,
+				},
+			},
+			expectedErr: errInvalidWindow.Error(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -34,6 +35,8 @@ const (
 	defaultGranularity   = "PT1M" // 1-minute, as per https://docs.atlas.mongodb.com/reference/api/process-measurements/
 	defaultAlertsEnabled = false
 	defaultLogsEnabled   = false
+	// measurements with a 1-minute granularity are retained for 48 hours, as per https://www.mongodb.com/docs/atlas/review-available-metrics/
+	defaultBackfillMaxWindow = 24 * time.Hour
 )
 
 // NewFactory creates a factory for MongoDB Atlas receiver
@@ -108,5 +111,8 @@ func createDefaultConfig() config.Receiver {
 			Enabled:  defaultLogsEnabled,
 			Projects: []*ProjectConfig{},
 		},
+		Backfill: BackfillConfig{
+			MaxWindow: defaultBackfillMaxWindow,
+		},
 	}
 }
@@ -170,6 +170,12 @@ func checkMongoDBClientErr(err error, response *mongodbatlas.Response) error {
 	return nil
 }
 
+// A page without results stops the pagination, even if it links to a next page,
+// so that a misbehaving API can't keep the receiver paginating forever.
+func hasNextPage(results int, links []*mongodbatlas.Link) bool {
+	return results > 0 && hasNext(links)
+}
+
 func hasNext(links []*mongodbatlas.Link) bool {
 	for _, link := range links {
 		if link.Rel == "next" {
@@ -213,7 +219,7 @@ func (s *MongoDBAtlasClient) getOrganizationsPage(
 	if err != nil {
 		return nil, false, fmt.Errorf("error in retrieving organizations: %w", err)
 	}
-	return orgs.Results, hasNext(orgs.Links), nil
+	return orgs.Results, hasNextPage(len(orgs.Results), orgs.Links), nil
 }
 
 // GetOrganization retrieves a single organization specified by orgID
@@ -275,7 +281,7 @@ func (s *MongoDBAtlasClient) getProjectsPage(
 	if err != nil {
 		return nil, false, fmt.Errorf("error retrieving project page: %w", err)
 	}
-	return projects.Results, hasNext(projects.Links), nil
+	return projects.Results, hasNextPage(len(projects.Results), projects.Links), nil
 }
 
 // Processes returns the list of processes running for a given project.
@@ -283,27 +289,43 @@ func (s *MongoDBAtlasClient) Processes(
 	ctx context.Context,
 	projectID string,
 ) ([]*mongodbatlas.Process, error) {
-	// A paginated API, but the MongoDB client just returns the values from the first page
-
 	// Note: MongoDB Atlas also has the idea of a Cluster- we can retrieve a list of clusters from
 	// the Project, but a Cluster does not have a link to its Process list and a Process does not
 	// have a link to its Cluster (save through the hostname, which is not a documented relationship).
+	var allProcesses []*mongodbatlas.Process
+	pageNum := 1
+	for {
+		processes, hasMore, err := s.getProcessesPage(ctx, projectID, pageNum)
+		pageNum++
+		if err != nil {
+			return allProcesses, fmt.Errorf("error retrieving processes from MongoDB Atlas API: %w", err)
+		}
+		allProcesses = append(allProcesses, processes...)
+		if !hasMore {
+			break
+		}
+	}
+	return allProcesses, nil
+}
+
+func (s *MongoDBAtlasClient) getProcessesPage(
+	ctx context.Context,
+	projectID string,
+	pageNum int,
+) ([]*mongodbatlas.Process, bool, error) {
+	// The MongoDB client only returns the results of the page, its links are in the response
 	processes, response, err := s.client.Processes.List(
 		ctx,
 		projectID,
 		&mongodbatlas.ProcessesListOptions{
-			ListOptions: mongodbatlas.ListOptions{
-				PageNum:      0,
-				ItemsPerPage: 0,
-				IncludeCount: true,
-			},
+			ListOptions: mongodbatlas.ListOptions{PageNum: pageNum},
 		},
 	)
 	err = checkMongoDBClientErr(err, response)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving processes from MongoDB Atlas API: %w", err)
+		return nil, false, err
 	}
-	return processes, nil
+	return processes, hasNextPage(len(processes), response.Links), nil
 }
 
 func (s *MongoDBAtlasClient) getProcessDatabasesPage(
@@ -324,7 +346,7 @@ func (s *MongoDBAtlasClient) getProcessDatabasesPage(
 	if err != nil {
 		return nil, false, err
 	}
-	return databases.Results, hasNext(databases.Links), nil
+	return databases.Results, hasNextPage(len(databases.Results), databases.Links), nil
 }
 
 // ProcessDatabases lists databases that are running in a given MongoDB Atlas process
@@ -375,8 +397,8 @@ func (s *MongoDBAtlasClient) ProcessMetrics(
 			resolution,
 		)
 		if err != nil {
-			s.log.Debug("Error retrieving process metrics from MongoDB Atlas API", zap.Error(err))
-			break // Return partial results
+			// The partial results would leave a gap in the measurements, the window is retried instead
+			return fmt.Errorf("error retrieving process metrics from MongoDB Atlas API: %w", err)
 		}
 		pageNum++
 		allMeasurements = append(allMeasurements, measurements...)
@@ -413,7 +435,7 @@ func (s *MongoDBAtlasClient) getProcessMeasurementsPage(
 	if err != nil {
 		return nil, false, err
 	}
-	return measurements.Measurements, hasNext(measurements.Links), nil
+	return measurements.Measurements, hasNextPage(len(measurements.Measurements), measurements.Links), nil
 }
 
 // ProcessDatabaseMetrics returns metrics about a particular database running within a MongoDB Atlas process
@@ -482,7 +504,7 @@ func (s *MongoDBAtlasClient) getProcessDatabaseMeasurementsPage(
 	if err != nil {
 		return nil, false, err
 	}
-	return measurements.Measurements, hasNext(measurements.Links), nil
+	return measurements.Measurements, hasNextPage(len(measurements.Measurements), measurements.Links), nil
 }
 
 // ProcessDisks enumerates the disks accessible to a specified MongoDB Atlas process
@@ -491,14 +513,13 @@ func (s *MongoDBAtlasClient) ProcessDisks(
 	projectID string,
 	host string,
 	port int,
-) []*mongodbatlas.ProcessDisk {
+) ([]*mongodbatlas.ProcessDisk, error) {
 	var allDisks []*mongodbatlas.ProcessDisk
 	pageNum := 1
 	for {
 		disks, hasMore, err := s.getProcessDisksPage(ctx, projectID, host, port, pageNum)
 		if err != nil {
-			s.log.Debug("Error retrieving disk metrics from MongoDB Atlas API", zap.Error(err))
-			break // Return partial results
+			return allDisks, fmt.Errorf("error retrieving disks from MongoDB Atlas API: %w", err)
 		}
 		pageNum++
 		allDisks = append(allDisks, disks...)
@@ -506,7 +527,7 @@ func (s *MongoDBAtlasClient) ProcessDisks(
 			break
 		}
 	}
-	return allDisks
+	return allDisks, nil
 }
 
 func (s *MongoDBAtlasClient) getProcessDisksPage(
@@ -527,7 +548,7 @@ func (s *MongoDBAtlasClient) getProcessDisksPage(
 	if err != nil {
 		return nil, false, err
 	}
-	return disks.Results, hasNext(disks.Links), nil
+	return disks.Results, hasNextPage(len(disks.Results), disks.Links), nil
 }
 
 // ProcessDiskMetrics returns metrics supplied for a particular disk partition used by a MongoDB Atlas process
@@ -596,7 +617,7 @@ func (s *MongoDBAtlasClient) processDiskMeasurementsPage(
 	if err != nil {
 		return nil, false, err
 	}
-	return measurements.Measurements, hasNext(measurements.Links), nil
+	return measurements.Measurements, hasNextPage(len(measurements.Measurements), measurements.Links), nil
 }
 
 // GetLogs retrieves the logs from the mongo API using API call: https://www.mongodb.com/docs/atlas/reference/api/logs/#syntax
@@ -645,7 +666,7 @@ func (s *MongoDBAtlasClient) GetAlerts(ctx context.Context, groupID string, opts
 	if err != nil {
 		return nil, false, err
 	}
-	return alerts.Results, hasNext(response.Links), nil
+	return alerts.Results, hasNextPage(len(alerts.Results), response.Links), nil
 }
 
 func toUnixString(t time.Time) string {
@@ -16,27 +16,41 @@ package mongodbatlasreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
 
 	"go.mongodb.org/atlas/mongodbatlas"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mongodbatlasreceiver/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mongodbatlasreceiver/internal/metadata"
 )
 
+const lastRunCheckpointKey = "last_run"
+
 type receiver struct {
-	log         *zap.Logger
-	cfg         *Config
-	client      *internal.MongoDBAtlasClient
-	lastRun     time.Time
-	mb          *metadata.MetricsBuilder
-	stopperChan chan struct{}
+	log           *zap.Logger
+	cfg           *Config
+	client        *internal.MongoDBAtlasClient
+	lastRun       time.Time
+	mb            *metadata.MetricsBuilder
+	stopperChan   chan struct{}
+	storageClient storage.Client
+}
+
+// checkpoint is persisted with the storage extension, so that the measurements
+// missed while the collector was down are backfilled once it is restarted.
+type checkpoint struct {
+	LastRun time.Time `json:"last_run"`
 }
 
 type timeconstraints struct {
@@ -57,16 +71,44 @@ func newMongoDBAtlasReceiver(settings component.ReceiverCreateSettings, cfg *Con
 }
 
 func newMongoDBAtlasScraper(recv *receiver) (scraperhelper.Scraper, error) {
-	return scraperhelper.NewScraper(typeStr, recv.scrape, scraperhelper.WithShutdown(recv.shutdown))
+	return scraperhelper.NewScraper(
+		typeStr,
+		recv.scrape,
+		scraperhelper.WithStart(recv.start),
+		scraperhelper.WithShutdown(recv.shutdown),
+	)
+}
+
+func (s *receiver) start(ctx context.Context, host component.Host) error {
+	if !s.cfg.Backfill.Enabled {
+		return nil
+	}
+
+	storageClient, err := adapter.GetStorageClient(ctx, host, s.cfg.StorageID, s.cfg.ID())
+	if err != nil {
+		return fmt.Errorf("failed to set up storage: %w", err)
+	}
+	s.storageClient = storageClient
+	if err := s.syncCheckpoint(ctx); err != nil {
+		s.log.Error("there was an error syncing the receiver with checkpoint", zap.Error(err))
+	}
+	return nil
 }
 
 func (s *receiver) scrape(ctx context.Context) (pmetric.Metrics, error) {
 	now := time.Now()
-	if err := s.poll(ctx, s.timeConstraints(now)); err != nil {
+	err := s.poll(ctx, s.timeConstraints(now))
+	// The buffer of the builder is drained even on failure, so that the metrics
+	// recorded before the failure aren't emitted again by the next scrape.
+	metrics := s.mb.Emit()
+	if err != nil && !scrapererror.IsPartialScrapeError(err) {
 		return pmetric.Metrics{}, err
 	}
 	s.lastRun = now
-	return s.mb.Emit(), nil
+	if cpErr := s.writeCheckpoint(ctx); cpErr != nil {
+		s.log.Error("unable to write checkpoint", zap.Error(cpErr))
+	}
+	return metrics, err
 }
 
 func (s *receiver) timeConstraints(now time.Time) timeconstraints {
@@ -76,6 +118,14 @@ func (s *receiver) timeConstraints(now time.Time) timeconstraints {
 	} else {
 		start = s.lastRun
 	}
+	if s.cfg.Backfill.Enabled {
+		if oldest := now.Add(s.cfg.Backfill.MaxWindow * -1); start.Before(oldest) {
+			s.log.Warn("measurements older than the backfill window are skipped",
+				zap.Time("last_run", start),
+				zap.Duration("max_window", s.cfg.Backfill.MaxWindow))
+			start = oldest
+		}
+	}
 	return timeconstraints{
 		start.UTC().Format(time.RFC3339),
 		now.UTC().Format(time.RFC3339),
@@ -83,24 +133,62 @@ func (s *receiver) timeConstraints(now time.Time) timeconstraints {
 	}
 }
 
-func (s *receiver) shutdown(context.Context) error {
-	return s.client.Shutdown()
+func (s *receiver) shutdown(ctx context.Context) error {
+	var errs error
+	errs = multierr.Append(errs, s.client.Shutdown())
+	if s.storageClient != nil {
+		errs = multierr.Append(errs, s.storageClient.Close(ctx))
+	}
+	return errs
+}
+
+func (s *receiver) syncCheckpoint(ctx context.Context) error {
+	cBytes, err := s.storageClient.Get(ctx, lastRunCheckpointKey)
+	if err != nil {
+		return fmt.Errorf("unable to read checkpoint: %w", err)
+	}
+	if cBytes == nil {
+		return nil
+	}
+
+	var cp checkpoint
+	if err = json.Unmarshal(cBytes, &cp); err != nil {
+		return fmt.Errorf("unable to decode stored checkpoint: %w", err)
+	}
+	s.lastRun = cp.LastRun
+	return nil
+}
+
+func (s *receiver) writeCheckpoint(ctx context.Context) error {
+	if s.storageClient == nil {
+		return nil
+	}
+	marshalBytes, err := json.Marshal(&checkpoint{LastRun: s.lastRun})
+	if err != nil {
+		return fmt.Errorf("unable to write checkpoint: %w", err)
+	}
+	return s.storageClient.Set(ctx, lastRunCheckpointKey, marshalBytes)
 }
 
+// poll records the metrics of all the processes, the failures of some of them
+// being returned as a partial scrape error.
 func (s *receiver) poll(ctx context.Context, time timeconstraints) error {
 	orgs, err := s.client.Organizations(ctx)
 	if err != nil {
 		return fmt.Errorf("error retrieving organizations: %w", err)
 	}
+	var errs scrapererror.ScrapeErrors
 	for _, org := range orgs {
 		projects, err := s.client.Projects(ctx, org.ID)
 		if err != nil {
-			return fmt.Errorf("error retrieving projects: %w", err)
+			errs.AddPartial(1, fmt.Errorf("error retrieving projects: %w", err))
+			continue
 		}
 		for _, project := range projects {
 			processes, err := s.client.Processes(ctx, project.ID)
 			if err != nil {
-				return fmt.Errorf("error retrieving MongoDB Atlas processes: %w", err)
+				errs.AddPartial(1, fmt.Errorf("error retrieving MongoDB Atlas processes: %w", err))
+				continue
 			}
 			for _, process := range processes {
 				if err := s.extractProcessMetrics(
@@ -110,8 +198,10 @@ func (s *receiver) poll(ctx context.Context, time timeconstraints) error {
 					project,
 					process,
 				); err != nil {
-					return err
+					errs.AddPartial(1, err)
 				}
+				// Emitted even on failure, for the metrics recorded before it to
+				// be attributed to the process.
 				s.mb.EmitForResource(
 					metadata.WithMongodbAtlasOrgName(org.Name),
 					metadata.WithMongodbAtlasProjectName(project.Name),
@@ -124,7 +214,7 @@ func (s *receiver) poll(ctx context.Context, time timeconstraints) error {
 			}
 		}
 	}
-	return nil
+	return errs.Combine()
 }
 
 func (s *receiver) extractProcessMetrics(
@@ -134,6 +224,7 @@ func (s *receiver) extractProcessMetrics(
 	project *mongodbatlas.Project,
 	process *mongodbatlas.Process,
 ) error {
+	var errs error
 	// This receiver will support both logs and metrics- if one pipeline
 	//  or the other is not configured, it will be nil.
 	if err := s.client.ProcessMetrics(
@@ -146,17 +237,17 @@ func (s *receiver) extractProcessMetrics(
 		time.end,
 		time.resolution,
 	); err != nil {
-		return fmt.Errorf("error when polling process metrics from MongoDB Atlas: %w", err)
+		errs = multierr.Append(errs, fmt.Errorf("error when polling process metrics from MongoDB Atlas: %w", err))
 	}
 
 	if err := s.extractProcessDatabaseMetrics(ctx, time, orgName, project, process); err != nil {
-		return fmt.Errorf("error when polling process database metrics from MongoDB Atlas: %w", err)
+		errs = multierr.Append(errs, fmt.Errorf("error when polling process database metrics from MongoDB Atlas: %w", err))
 	}
 
 	if err := s.extractProcessDiskMetrics(ctx, time, orgName, project, process); err != nil {
-		return fmt.Errorf("error when polling process disk metrics from MongoDB Atlas: %w", err)
+		errs = multierr.Append(errs, fmt.Errorf("error when polling process disk metrics from MongoDB Atlas: %w", err))
 	}
-	return nil
+	return errs
 }
 
 func (s *receiver) extractProcessDatabaseMetrics(
@@ -176,6 +267,7 @@ func (s *receiver) extractProcessDatabaseMetrics(
 		return fmt.Errorf("error retrieving process databases: %w", err)
 	}
 
+	var errs error
 	for _, db := range processDatabases {
 		if err := s.client.ProcessDatabaseMetrics(
 			ctx,
@@ -188,7 +280,7 @@ func (s *receiver) extractProcessDatabaseMetrics(
 			time.end,
 			time.resolution,
 		); err != nil {
-			return fmt.Errorf("error when polling database metrics from MongoDB Atlas: %w", err)
+			errs = multierr.Append(errs, fmt.Errorf("error when polling database metrics from MongoDB Atlas: %w", err))
 		}
 		s.mb.EmitForResource(
 			metadata.WithMongodbAtlasOrgName(orgName),
@@ -201,7 +293,7 @@ func (s *receiver) extractProcessDatabaseMetrics(
 			metadata.WithMongodbAtlasDbName(db.DatabaseName),
 		)
 	}
-	return nil
+	return errs
 }
 
 func (s *receiver) extractProcessDiskMetrics(
@@ -211,7 +303,13 @@ func (s *receiver) extractProcessDiskMetrics(
 	project *mongodbatlas.Project,
 	process *mongodbatlas.Process,
 ) error {
-	for _, disk := range s.client.ProcessDisks(ctx, project.ID, process.Hostname, process.Port) {
+	disks, err := s.client.ProcessDisks(ctx, project.ID, process.Hostname, process.Port)
+	if err != nil {
+		return fmt.Errorf("error retrieving process disks: %w", err)
+	}
+
+	var errs error
+	for _, disk := range disks {
 		if err := s.client.ProcessDiskMetrics(
 			ctx,
 			s.mb,
@@ -223,7 +321,7 @@ func (s *receiver) extractProcessDiskMetrics(
 			time.end,
 			time.resolution,
 		); err != nil {
-			return fmt.Errorf("error when polling from MongoDB Atlas: %w", err)
+			errs = multierr.Append(errs, fmt.Errorf("error when polling disk metrics from MongoDB Atlas: %w", err))
 		}
 		s.mb.EmitForResource(
 			metadata.WithMongodbAtlasOrgName(orgName),
@@ -236,5 +334,5 @@ func (s *receiver) extractProcessDiskMetrics(
 			metadata.WithMongodbAtlasDiskPartition(disk.PartitionName),
 		)
 	}
-	return nil
+	return errs
 }
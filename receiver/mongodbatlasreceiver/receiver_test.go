@@ -21,7 +21,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -83,9 +86,109 @@ func TestTimeConstraints(t *testing.T) {
 				require.Equal(t, tc.start, recv.lastRun.UTC().Format(time.RFC3339))
 			},
 		},
+		{
+			name: "lookback after a downtime is limited to the backfill window",
+			run: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Backfill.Enabled = true
+				now := time.Now()
+				recv := receiver{
+					log: zap.NewNop(),
+					cfg: cfg,
+					// set last run to a downtime longer than the backfill window
+					lastRun: now.Add(cfg.Backfill.MaxWindow * -2),
+				}
+				tc := recv.timeConstraints(now)
+				require.NotNil(t, tc)
+				require.Equal(t, tc.start, now.Add(cfg.Backfill.MaxWindow*-1).UTC().Format(time.RFC3339))
+			},
+		},
+		{
+			name: "lookback after a downtime within the backfill window is lastRun",
+			run: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Backfill.Enabled = true
+				now := time.Now()
+				recv := receiver{
+					log:     zap.NewNop(),
+					cfg:     cfg,
+					lastRun: now.Add(-time.Hour),
+				}
+				tc := recv.timeConstraints(now)
+				require.NotNil(t, tc)
+				require.Equal(t, tc.start, recv.lastRun.UTC().Format(time.RFC3339))
+			},
+		},
 	}
 
 	for _, testCase := range tt {
 		t.Run(testCase.name, testCase.run)
 	}
 }
+
+func TestCheckpoint(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	ctx := context.Background()
+	storageClient := &mockStorageClient{cache: map[string][]byte{}}
+
+	lastRun := time.Date(2022, time.October, 1, 12, 0, 0, 0, time.UTC)
+	recv := &receiver{
+		log:           zap.NewNop(),
+		cfg:           cfg,
+		lastRun:       lastRun,
+		storageClient: storageClient,
+	}
+	require.NoError(t, recv.writeCheckpoint(ctx))
+
+	restarted := &receiver{
+		log:           zap.NewNop(),
+		cfg:           cfg,
+		storageClient: storageClient,
+	}
+	require.NoError(t, restarted.syncCheckpoint(ctx))
+	require.True(t, lastRun.Equal(restarted.lastRun))
+
+	storageClient.cache[lastRunCheckpointKey] = []byte("invalid")
+	require.Error(t, restarted.syncCheckpoint(ctx))
+}
+
+func TestStartBackfillMissingStorage(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	storageID := config.NewComponentID("file_storage")
+	cfg.StorageID = &storageID
+	cfg.Backfill.Enabled = true
+
+	recv := newMongoDBAtlasReceiver(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.Error(t, recv.start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, recv.shutdown(context.Background()))
+}
+
+type mockStorageClient struct {
+	cache map[string][]byte
+}
+
+func (m *mockStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	return m.cache[key], nil
+}
+
+func (m *mockStorageClient) Set(_ context.Context, key string, value []byte) error {
+	m.cache[key] = value
+	return nil
+}
+
+func (m *mockStorageClient) Delete(_ context.Context, key string) error {
+	delete(m.cache, key)
+	return nil
+}
+
+func (m *mockStorageClient) Batch(_ context.Context, _ ...storage.Operation) error {
+	return nil
+}
+
+func (m *mockStorageClient) Close(_ context.Context) error {
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	"go.mongodb.org/mongo-driver/bson"
@@ -37,6 +38,7 @@ type client interface {
 	DBStats(ctx context.Context, DBName string) (bson.M, error)
 	TopStats(ctx context.Context) (bson.M, error)
 	IndexStats(ctx context.Context, DBName, collectionName string) ([]bson.M, error)
+	ProfileEntries(ctx context.Context, DBName string, since, until time.Time, limit int64) ([]bson.M, error)
 }
 
 // mongodbClient is a mongodb metric scraper client
@@ -115,6 +117,25 @@ func (c *mongodbClient) IndexStats(ctx context.Context, database, collectionName
 	return indexStats, nil
 }
 
+// ProfileEntries returns the slowest operations recorded by the database profiler of a given database between since
+// (excluded) and until (included), slowest first.
+// more information can be found here: https://www.mongodb.com/docs/manual/reference/database-profiler/
+func (c *mongodbClient) ProfileEntries(ctx context.Context, database string, since, until time.Time, limit int64) ([]bson.M, error) {
+	filter := bson.M{"ts": bson.M{"$gt": since, "$lte": until}}
+	findOpts := options.Find().SetSort(bson.D{primitive.E{Key: "millis", Value: -1}}).SetLimit(limit)
+	cursor, err := c.Database(database).Collection("system.profile").Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []bson.M
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
 // GetVersion returns a result of the version of mongo the client is connected to so adjustments in collection protocol can
 // be determined
 func (c *mongodbClient) GetVersion(ctx context.Context) (*version.Version, error) {
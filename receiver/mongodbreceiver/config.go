@@ -39,6 +39,16 @@ type Config struct {
 	Password   string                   `mapstructure:"password"`
 	ReplicaSet string                   `mapstructure:"replica_set,omitempty"`
 	Timeout    time.Duration            `mapstructure:"timeout"`
+	// QuerySamples configures the query samples emitted as log records when
+	// the receiver is used in a logs pipeline.
+	QuerySamples QuerySamplesConfig `mapstructure:"query_samples"`
+}
+
+// QuerySamplesConfig configures the query samples emitted as log records.
+type QuerySamplesConfig struct {
+	// TopN is the number of slowest profiled operations emitted every
+	// collection interval.
+	TopN int `mapstructure:"top_n"`
 }
 
 func (c *Config) Validate() error {
@@ -59,6 +69,10 @@ func (c *Config) Validate() error {
 		err = multierr.Append(err, errors.New("password provided without user"))
 	}
 
+	if c.QuerySamples.TopN < 0 {
+		err = multierr.Append(err, errors.New("query_samples.top_n must not be negative"))
+	}
+
 	if _, tlsErr := c.LoadTLSConfig(); tlsErr != nil {
 		err = multierr.Append(err, fmt.Errorf("error loading tls configuration: %w", tlsErr))
 	}
@@ -33,6 +33,7 @@ func TestValidate(t *testing.T) {
 		desc      string
 		username  string
 		password  string
+		topN      int
 		expected  error
 	}{
 		{
@@ -74,6 +75,12 @@ func TestValidate(t *testing.T) {
 			endpoints: []string{"localhost"},
 			expected:  nil,
 		},
+		{
+			desc:      "negative top_n",
+			endpoints: []string{"localhost"},
+			topN:      -1,
+			expected:  errors.New("query_samples.top_n must not be negative"),
+		},
 		{
 			desc:      "empty host",
 			username:  "user",
@@ -96,6 +103,7 @@ func TestValidate(t *testing.T) {
 				Password: tc.password,
 				Hosts:    hosts,
 			}
+			cfg.QuerySamples.TopN = tc.topN
 			err := cfg.Validate()
 			if tc.expected == nil {
 				require.Nil(t, err)
@@ -220,6 +228,7 @@ func TestLoadConfig(t *testing.T) {
 	expected.Username = "otel"
 	expected.Password = "$MONGO_PASSWORD"
 	expected.CollectionInterval = time.Minute
+	expected.QuerySamples.TopN = 5
 
 	require.Equal(t, expected, cfg)
 }
@@ -29,8 +29,9 @@ import (
 )
 
 const (
-	typeStr   = "mongodb"
-	stability = component.StabilityLevelBeta
+	typeStr       = "mongodb"
+	stability     = component.StabilityLevelBeta
+	logsStability = component.StabilityLevelInDevelopment
 )
 
 // NewFactory creates a factory for mongodb receiver.
@@ -38,7 +39,8 @@ func NewFactory() component.ReceiverFactory {
 	return component.NewReceiverFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithMetricsReceiver(createMetricsReceiver, stability))
+		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, logsStability))
 }
 
 func createDefaultConfig() config.Receiver {
@@ -55,6 +57,9 @@ func createDefaultConfig() config.Receiver {
 		},
 		Metrics:          metadata.DefaultMetricsSettings(),
 		TLSClientSetting: configtls.TLSClientSetting{},
+		QuerySamples: QuerySamplesConfig{
+			TopN: 10,
+		},
 	}
 }
 
@@ -79,3 +84,13 @@ func createMetricsReceiver(
 		scraperhelper.AddScraper(scraper),
 	)
 }
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	rConf config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	cfg := rConf.(*Config)
+	return newQuerySamplesReceiver(params, cfg, consumer), nil
+}
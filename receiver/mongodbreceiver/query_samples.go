@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mongodbreceiver"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// querySamplesReceiver emits the slowest operations recorded by the database
+// profiler during every collection interval as query event log records.
+type querySamplesReceiver struct {
+	logger   *zap.Logger
+	config   *Config
+	consumer consumer.Logs
+
+	client client
+	// since is the end of the previous collection.
+	since time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newQuerySamplesReceiver(settings component.ReceiverCreateSettings, config *Config, consumer consumer.Logs) *querySamplesReceiver {
+	return &querySamplesReceiver{
+		logger:   settings.Logger,
+		config:   config,
+		consumer: consumer,
+	}
+}
+
+// Start connects to mongodb and starts collecting the query samples.
+func (r *querySamplesReceiver) Start(ctx context.Context, _ component.Host) error {
+	c, err := NewClient(ctx, r.config, r.logger)
+	if err != nil {
+		return fmt.Errorf("create mongo client: %w", err)
+	}
+	r.client = c
+	r.since = time.Now()
+
+	ctx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.config.CollectionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.collect(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the collection and disconnects from mongodb.
+func (r *querySamplesReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	if r.client == nil {
+		return nil
+	}
+	return r.client.Disconnect(ctx)
+}
+
+func (r *querySamplesReceiver) collect(ctx context.Context) {
+	logs, err := r.sample(ctx, time.Now())
+	if err != nil {
+		r.logger.Error("Failed to fetch profiled operations", zap.Error(err))
+	}
+	if logs.LogRecordCount() == 0 {
+		return
+	}
+	if err = r.consumer.ConsumeLogs(ctx, logs); err != nil {
+		r.logger.Error("Failed to consume query samples", zap.Error(err))
+	}
+}
+
+// sample returns the slowest operations profiled since the previous collection.
+func (r *querySamplesReceiver) sample(ctx context.Context, until time.Time) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	if r.config.QuerySamples.TopN == 0 {
+		return logs, nil
+	}
+	dbNames, err := r.client.ListDatabaseNames(ctx, bson.D{})
+	if err != nil {
+		return logs, fmt.Errorf("failed to fetch database names: %w", err)
+	}
+
+	limit := int64(r.config.QuerySamples.TopN)
+	var errs error
+	var entries []bson.M
+	for _, dbName := range dbNames {
+		dbEntries, err := r.client.ProfileEntries(ctx, dbName, r.since, until, limit)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to fetch profiled operations of %s: %w", dbName, err))
+			continue
+		}
+		entries = append(entries, dbEntries...)
+	}
+	r.since = until
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		mi, _ := collectMetric(entries[i], []string{"millis"})
+		mj, _ := collectMetric(entries[j], []string{"millis"})
+		return mi > mj
+	})
+	if len(entries) > r.config.QuerySamples.TopN {
+		entries = entries[:r.config.QuerySamples.TopN]
+	}
+	if len(entries) == 0 {
+		return logs, errs
+	}
+
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("db.system", "mongodb")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	now := pcommon.NewTimestampFromTime(until)
+	for _, entry := range entries {
+		lr := lrs.AppendEmpty()
+		lr.SetObservedTimestamp(now)
+		if ts, ok := entry["ts"].(primitive.DateTime); ok {
+			lr.SetTimestamp(pcommon.NewTimestampFromTime(ts.Time()))
+		}
+		if command, err := bson.MarshalExtJSON(entry["command"], false, false); err == nil {
+			lr.Body().SetStr(string(command))
+		}
+
+		attrs := lr.Attributes()
+		attrs.PutStr("event.name", "db.query.sample")
+		if ns, ok := entry["ns"].(string); ok {
+			dbName, collection, _ := strings.Cut(ns, ".")
+			attrs.PutStr("db.name", dbName)
+			attrs.PutStr("db.mongodb.collection", collection)
+		}
+		if op, ok := entry["op"].(string); ok {
+			attrs.PutStr("db.operation", op)
+		}
+		if queryHash, ok := entry["queryHash"].(string); ok {
+			attrs.PutStr("db.query.digest", queryHash)
+		}
+		attrs.PutInt("db.query.calls", 1)
+		if millis, err := collectMetric(entry, []string{"millis"}); err == nil {
+			attrs.PutDouble("db.query.duration", float64(millis)/1e3)
+		}
+		if rows, err := collectMetric(entry, []string{"nreturned"}); err == nil {
+			attrs.PutInt("db.query.rows", rows)
+		}
+		attrs.PutDouble("db.query.wait_time", float64(lockWaitMicros(entry))/1e6)
+	}
+	return logs, errs
+}
+
+// lockWaitMicros sums the time the profiled operation spent waiting to acquire
+// its locks, in microseconds.
+func lockWaitMicros(entry bson.M) int64 {
+	locks, ok := entry["locks"].(bson.M)
+	if !ok {
+		return 0
+	}
+	var total int64
+	for _, lock := range locks {
+		lockDoc, ok := lock.(bson.M)
+		if !ok {
+			continue
+		}
+		waits, ok := lockDoc["timeAcquiringMicros"].(bson.M)
+		if !ok {
+			continue
+		}
+		for _, wait := range waits {
+			if micros, err := parseInt(wait); err == nil {
+				total += micros
+			}
+		}
+	}
+	return total
+}
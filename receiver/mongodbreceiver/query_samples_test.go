@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestQuerySamples(t *testing.T) {
+	since := time.Now().Add(-time.Minute)
+	until := time.Now()
+	ts := primitive.NewDateTimeFromTime(until.Add(-time.Second))
+
+	fc := &fakeClient{}
+	fc.On("ListDatabaseNames", mock.Anything, mock.Anything, mock.Anything).Return([]string{"otel", "admin"}, nil)
+	fc.On("ProfileEntries", mock.Anything, "otel", since, until, int64(2)).Return([]bson.M{
+		{
+			"op": "query", "ns": "otel.spans", "ts": ts, "millis": int32(300), "nreturned": int32(12),
+			"queryHash": "A1B2C3D4", "command": bson.M{"find": "spans"},
+			"locks": bson.M{"Global": bson.M{"timeAcquiringMicros": bson.M{"r": int64(1500000)}}},
+		},
+		{"op": "update", "ns": "otel.logs", "ts": ts, "millis": int32(50), "command": bson.M{"update": "logs"}},
+	}, nil)
+	fc.On("ProfileEntries", mock.Anything, "admin", since, until, int64(2)).Return([]bson.M{
+		{"op": "command", "ns": "admin.$cmd", "ts": ts, "millis": int32(100), "command": bson.M{"ping": int32(1)}},
+	}, nil)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.QuerySamples.TopN = 2
+	r := newQuerySamplesReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	r.client = fc
+	r.since = since
+
+	logs, err := r.sample(context.Background(), until)
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.LogRecordCount())
+	assert.Equal(t, until, r.since)
+
+	rl := logs.ResourceLogs().At(0)
+	dbSystem, _ := rl.Resource().Attributes().Get("db.system")
+	assert.Equal(t, "mongodb", dbSystem.Str())
+
+	lrs := rl.ScopeLogs().At(0).LogRecords()
+	lr := lrs.At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(ts.Time()), lr.Timestamp())
+	assert.JSONEq(t, `{"find": "spans"}`, lr.Body().Str())
+	expected := map[string]interface{}{
+		"event.name":            "db.query.sample",
+		"db.name":               "otel",
+		"db.mongodb.collection": "spans",
+		"db.operation":          "query",
+		"db.query.digest":       "A1B2C3D4",
+		"db.query.calls":        int64(1),
+		"db.query.duration":     0.3,
+		"db.query.rows":         int64(12),
+		"db.query.wait_time":    1.5,
+	}
+	assert.Equal(t, expected, lr.Attributes().AsRaw())
+
+	db, _ := lrs.At(1).Attributes().Get("db.name")
+	assert.Equal(t, "admin", db.Str())
+	fc.AssertExpectations(t)
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlreceiver
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordProviderAWS(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "mydb.123456789012.eu-west-1.rds.amazonaws.com:3306"
+	cfg.Username = "otel"
+	cfg.IAMAuth = IAMAuthConfig{Provider: iamProviderAWS, Region: "eu-west-1"}
+
+	password, err := newPasswordProvider(cfg)
+	require.NoError(t, err)
+
+	token, err := password(context.Background())
+	require.NoError(t, err)
+
+	parts := strings.SplitN(token, "?", 2)
+	require.Len(t, parts, 2)
+	require.Equal(t, "mydb.123456789012.eu-west-1.rds.amazonaws.com:3306", parts[0])
+	query, err := url.ParseQuery(parts[1])
+	require.NoError(t, err)
+	require.Equal(t, "otel", query.Get("DBUser"))
+	require.Contains(t, query.Get("X-Amz-Credential"), "/eu-west-1/rds-db/")
+}
+
+func TestConnectorPasswordError(t *testing.T) {
+	c := &connector{
+		config: mysql.NewConfig(),
+		password: func(context.Context) (string, error) {
+			return "", errors.New("no token")
+		},
+	}
+
+	_, err := c.Connect(context.Background())
+	require.EqualError(t, err, "no token")
+}
+
+func TestConnectWithTLS(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Insecure = false
+	cfg.CAFile = "testdata/missing-ca.crt"
+
+	err := newMySQLClient(cfg).Connect()
+	require.ErrorContains(t, err, "unable to load TLS config")
+
+	cfg.CAFile = ""
+	client := newMySQLClient(cfg)
+	require.NoError(t, client.Connect())
+	require.NoError(t, client.Close())
+}
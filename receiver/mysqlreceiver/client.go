@@ -15,7 +15,9 @@
 package mysqlreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver"
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 
 	// registers the mysql driver
@@ -28,12 +30,13 @@ type client interface {
 	getInnodbStats() (map[string]string, error)
 	getTableIoWaitsStats() ([]TableIoWaitsStats, error)
 	getIndexIoWaitsStats() ([]IndexIoWaitsStats, error)
+	getQueryStats() ([]QueryStats, error)
 	Close() error
 }
 
 type mySQLClient struct {
-	connStr string
-	client  *sql.DB
+	config *Config
+	client *sql.DB
 }
 
 type IoWaitsStats struct {
@@ -58,33 +61,84 @@ type IndexIoWaitsStats struct {
 	index string
 }
 
+// QueryStats are the cumulative statistics of a statement digest.
+type QueryStats struct {
+	schema       string
+	digest       string
+	digestText   string
+	count        int64
+	sumTimerWait int64
+	sumLockTime  int64
+	sumRowsSent  int64
+}
+
 var _ client = (*mySQLClient)(nil)
 
 func newMySQLClient(conf *Config) client {
-	driverConf := mysql.Config{
-		User:                 conf.Username,
-		Passwd:               conf.Password,
-		Net:                  conf.Transport,
-		Addr:                 conf.Endpoint,
-		DBName:               conf.Database,
-		AllowNativePasswords: conf.AllowNativePasswords,
-	}
-	connStr := driverConf.FormatDSN()
-
 	return &mySQLClient{
-		connStr: connStr,
+		config: conf,
 	}
 }
 
 func (c *mySQLClient) Connect() error {
-	clientDB, err := sql.Open("mysql", c.connStr)
+	driverConf := mysql.NewConfig()
+	driverConf.User = c.config.Username
+	driverConf.Net = c.config.Transport
+	driverConf.Addr = c.config.Endpoint
+	driverConf.DBName = c.config.Database
+	driverConf.AllowNativePasswords = c.config.AllowNativePasswords
+	// the IAM authentication tokens are sent with the cleartext authentication plugin
+	driverConf.AllowCleartextPasswords = c.config.IAMAuth.Provider != ""
+
+	tlsConfig, err := c.config.TLSClientSetting.LoadTLSConfig()
+	if err != nil {
+		return fmt.Errorf("unable to load TLS config: %w", err)
+	}
+	if tlsConfig != nil {
+		// the driver only accepts the TLS configurations registered under a name
+		driverConf.TLSConfig = "otelcol-" + c.config.ID().String()
+		if err = mysql.RegisterTLSConfig(driverConf.TLSConfig, tlsConfig); err != nil {
+			return fmt.Errorf("unable to register TLS config: %w", err)
+		}
+	}
+
+	password, err := newPasswordProvider(c.config)
 	if err != nil {
 		return fmt.Errorf("unable to connect to database: %w", err)
 	}
-	c.client = clientDB
+
+	c.client = sql.OpenDB(&connector{config: driverConf, password: password})
 	return nil
 }
 
+// connector opens the connections to the database with the password of the password
+// provider, so that each connection gets a fresh IAM authentication token.
+type connector struct {
+	config   *mysql.Config
+	password passwordProvider
+}
+
+var _ driver.Connector = (*connector)(nil)
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	password, err := c.password(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config := c.config.Clone()
+	config.Passwd = password
+	mysqlConnector, err := mysql.NewConnector(config)
+	if err != nil {
+		return nil, err
+	}
+	return mysqlConnector.Connect(ctx)
+}
+
+func (c *connector) Driver() driver.Driver {
+	return mysql.MySQLDriver{}
+}
+
 // getGlobalStats queries the db for global status metrics.
 func (c *mySQLClient) getGlobalStats() (map[string]string, error) {
 	query := "SHOW GLOBAL STATUS;"
@@ -152,6 +206,32 @@ func (c *mySQLClient) getIndexIoWaitsStats() ([]IndexIoWaitsStats, error) {
 	return stats, nil
 }
 
+// getQueryStats queries the db for the statistics of the statement digests.
+func (c *mySQLClient) getQueryStats() ([]QueryStats, error) {
+	query := "SELECT ifnull(SCHEMA_NAME, 'NONE') as SCHEMA_NAME, DIGEST, DIGEST_TEXT, " +
+		"COUNT_STAR, SUM_TIMER_WAIT, SUM_LOCK_TIME, SUM_ROWS_SENT " +
+		"FROM performance_schema.events_statements_summary_by_digest " +
+		"WHERE DIGEST IS NOT NULL;"
+
+	rows, err := c.client.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var stats []QueryStats
+	for rows.Next() {
+		var s QueryStats
+		err := rows.Scan(&s.schema, &s.digest, &s.digestText,
+			&s.count, &s.sumTimerWait, &s.sumLockTime, &s.sumRowsSent)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, nil
+}
+
 func Query(c mySQLClient, query string) (map[string]string, error) {
 	rows, err := c.client.Query(query)
 	if err != nil {
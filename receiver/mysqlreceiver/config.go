@@ -15,7 +15,10 @@
 package mysqlreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver"
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver/internal/metadata"
@@ -28,5 +31,58 @@ type Config struct {
 	Database                                string `mapstructure:"database,omitempty"`
 	AllowNativePasswords                    bool   `mapstructure:"allow_native_passwords,omitempty"`
 	confignet.NetAddr                       `mapstructure:",squash"`
+	configtls.TLSClientSetting              `mapstructure:"tls,omitempty"`
 	Metrics                                 metadata.MetricsSettings `mapstructure:"metrics"`
+	QuerySamples                            QuerySamplesConfig       `mapstructure:"query_samples"`
+	IAMAuth                                 IAMAuthConfig            `mapstructure:"iam_auth"`
+}
+
+// The providers of IAM database authentication tokens.
+const (
+	iamProviderAWS = "aws"
+	iamProviderGCP = "gcp"
+)
+
+// IAMAuthConfig configures the authentication with IAM database authentication
+// tokens instead of a password, for the managed databases prohibiting passwords.
+type IAMAuthConfig struct {
+	// Provider is the cloud provider issuing the tokens: "aws" for Amazon RDS, or
+	// "gcp" for Cloud SQL. The password is used when empty.
+	Provider string `mapstructure:"provider"`
+
+	// Region is the AWS region of the RDS instance, defaulting to the region of the
+	// AWS SDK configuration. Only used with the "aws" provider.
+	Region string `mapstructure:"region"`
+}
+
+// QuerySamplesConfig configures the query samples emitted as log records when
+// the receiver is used in a logs pipeline.
+type QuerySamplesConfig struct {
+	// TopN is the number of statement digests with the longest execution time
+	// emitted every collection interval.
+	TopN int `mapstructure:"top_n"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.QuerySamples.TopN < 0 {
+		return errors.New("query_samples.top_n must not be negative")
+	}
+
+	switch cfg.IAMAuth.Provider {
+	case "":
+	case iamProviderAWS, iamProviderGCP:
+		if cfg.Password != "" {
+			return errors.New("password must not be set with iam_auth")
+		}
+		// the tokens are sent in cleartext, they must be protected by TLS
+		if cfg.Insecure {
+			return errors.New("iam_auth requires tls to be enabled")
+		}
+		if cfg.IAMAuth.Provider == iamProviderAWS && cfg.Transport != "tcp" {
+			return errors.New("iam_auth with the aws provider requires the tcp transport")
+		}
+	default:
+		return errors.New("iam_auth.provider must be aws or gcp")
+	}
+	return nil
 }
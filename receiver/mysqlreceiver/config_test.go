@@ -41,6 +41,72 @@ func TestLoadConfig(t *testing.T) {
 	expected.Password = "$MYSQL_PASSWORD"
 	expected.Database = "otel"
 	expected.CollectionInterval = 10 * time.Second
+	expected.QuerySamples.TopN = 5
 
 	require.Equal(t, expected, cfg)
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Validate())
+
+	cfg.QuerySamples.TopN = -1
+	require.EqualError(t, cfg.Validate(), "query_samples.top_n must not be negative")
+}
+
+func TestValidateIAMAuthConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		modifier func(cfg *Config)
+		expected string
+	}{
+		{
+			desc: "unknown provider",
+			modifier: func(cfg *Config) {
+				cfg.IAMAuth.Provider = "azure"
+			},
+			expected: "iam_auth.provider must be aws or gcp",
+		},
+		{
+			desc: "password",
+			modifier: func(cfg *Config) {
+				cfg.Password = "otel"
+			},
+			expected: "password must not be set with iam_auth",
+		},
+		{
+			desc: "no TLS",
+			modifier: func(cfg *Config) {
+				cfg.Insecure = true
+			},
+			expected: "iam_auth requires tls to be enabled",
+		},
+		{
+			desc: "unix socket",
+			modifier: func(cfg *Config) {
+				cfg.Transport = "unix"
+				cfg.Endpoint = "/var/run/mysqld/mysqld.sock"
+			},
+			expected: "iam_auth with the aws provider requires the tcp transport",
+		},
+		{
+			desc:     "valid",
+			modifier: func(cfg *Config) {},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Insecure = false
+			cfg.IAMAuth = IAMAuthConfig{Provider: "aws", Region: "us-east-1"}
+			tc.modifier(cfg)
+
+			err := cfg.Validate()
+			if tc.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+}
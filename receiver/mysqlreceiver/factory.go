@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
@@ -28,15 +29,17 @@ import (
 )
 
 const (
-	typeStr   = "mysql"
-	stability = component.StabilityLevelBeta
+	typeStr       = "mysql"
+	stability     = component.StabilityLevelBeta
+	logsStability = component.StabilityLevelInDevelopment
 )
 
 func NewFactory() component.ReceiverFactory {
 	return component.NewReceiverFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithMetricsReceiver(createMetricsReceiver, stability))
+		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, logsStability))
 }
 
 func createDefaultConfig() config.Receiver {
@@ -51,7 +54,13 @@ func createDefaultConfig() config.Receiver {
 			Endpoint:  "localhost:3306",
 			Transport: "tcp",
 		},
+		TLSClientSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
 		Metrics: metadata.DefaultMetricsSettings(),
+		QuerySamples: QuerySamplesConfig{
+			TopN: 10,
+		},
 	}
 }
 
@@ -76,3 +85,13 @@ func createMetricsReceiver(
 		scraperhelper.AddScraper(scraper),
 	)
 }
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	rConf config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	cfg := rConf.(*Config)
+	return newQuerySamplesReceiver(params, cfg, consumer), nil
+}
@@ -0,0 +1,168 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+const picosecondsInSecond = 1e12
+
+// querySamplesReceiver emits the statement digests with the longest execution
+// time of every collection interval as query event log records.
+type querySamplesReceiver struct {
+	logger   *zap.Logger
+	config   *Config
+	consumer consumer.Logs
+
+	sqlclient client
+	// previous holds the statistics of the previous collection, keyed by
+	// schema and digest. It is nil until the first collection.
+	previous map[string]QueryStats
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newQuerySamplesReceiver(settings component.ReceiverCreateSettings, config *Config, consumer consumer.Logs) *querySamplesReceiver {
+	return &querySamplesReceiver{
+		logger:   settings.Logger,
+		config:   config,
+		consumer: consumer,
+	}
+}
+
+// Start connects to the db and starts collecting the query samples.
+func (r *querySamplesReceiver) Start(_ context.Context, _ component.Host) error {
+	sqlclient := newMySQLClient(r.config)
+	if err := sqlclient.Connect(); err != nil {
+		return err
+	}
+	r.sqlclient = sqlclient
+
+	var ctx context.Context
+	ctx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.config.CollectionInterval)
+		defer ticker.Stop()
+		for {
+			r.collect(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the collection and closes the db connection.
+func (r *querySamplesReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	if r.sqlclient == nil {
+		return nil
+	}
+	return r.sqlclient.Close()
+}
+
+func (r *querySamplesReceiver) collect(ctx context.Context) {
+	logs, err := r.sample(pcommon.NewTimestampFromTime(time.Now()))
+	if err != nil {
+		r.logger.Error("Failed to fetch query stats", zap.Error(err))
+		return
+	}
+	if logs.LogRecordCount() == 0 {
+		return
+	}
+	if err = r.consumer.ConsumeLogs(ctx, logs); err != nil {
+		r.logger.Error("Failed to consume query samples", zap.Error(err))
+	}
+}
+
+// sample returns the top statement digests by execution time since the
+// previous collection. The first collection only records the statistics.
+func (r *querySamplesReceiver) sample(now pcommon.Timestamp) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	stats, err := r.sqlclient.getQueryStats()
+	if err != nil {
+		return logs, err
+	}
+
+	current := make(map[string]QueryStats, len(stats))
+	var deltas []QueryStats
+	for _, s := range stats {
+		key := s.schema + "|" + s.digest
+		current[key] = s
+		if r.previous == nil {
+			continue
+		}
+		// A digest missing from the previous collection, or whose statistics
+		// were reset, is sampled with its whole statistics.
+		if prev, ok := r.previous[key]; ok && s.count >= prev.count {
+			s.count -= prev.count
+			s.sumTimerWait -= prev.sumTimerWait
+			s.sumLockTime -= prev.sumLockTime
+			s.sumRowsSent -= prev.sumRowsSent
+		}
+		if s.count > 0 {
+			deltas = append(deltas, s)
+		}
+	}
+	r.previous = current
+
+	sort.SliceStable(deltas, func(i, j int) bool {
+		return deltas[i].sumTimerWait > deltas[j].sumTimerWait
+	})
+	if len(deltas) > r.config.QuerySamples.TopN {
+		deltas = deltas[:r.config.QuerySamples.TopN]
+	}
+	if len(deltas) == 0 {
+		return logs, nil
+	}
+
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("db.system", "mysql")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, s := range deltas {
+		lr := lrs.AppendEmpty()
+		lr.SetTimestamp(now)
+		lr.SetObservedTimestamp(now)
+		lr.Body().SetStr(s.digestText)
+		attrs := lr.Attributes()
+		attrs.PutStr("event.name", "db.query.sample")
+		attrs.PutStr("db.name", s.schema)
+		attrs.PutStr("db.query.digest", s.digest)
+		attrs.PutInt("db.query.calls", s.count)
+		attrs.PutDouble("db.query.duration", float64(s.sumTimerWait)/picosecondsInSecond)
+		attrs.PutInt("db.query.rows", s.sumRowsSent)
+		attrs.PutDouble("db.query.wait_time", float64(s.sumLockTime)/picosecondsInSecond)
+	}
+	return logs, nil
+}
@@ -0,0 +1,88 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestQuerySamples(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.QuerySamples.TopN = 2
+
+	sqlclient := &mockClient{queryStats: []QueryStats{
+		{schema: "otel", digest: "a", digestText: "SELECT * FROM a", count: 10, sumTimerWait: 10e12, sumLockTime: 1e12, sumRowsSent: 100},
+		{schema: "otel", digest: "b", digestText: "SELECT * FROM b", count: 5, sumTimerWait: 5e12, sumRowsSent: 5},
+		{schema: "otel", digest: "c", digestText: "SELECT * FROM c", count: 1, sumTimerWait: 1e12, sumRowsSent: 1},
+	}}
+	r := newQuerySamplesReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	r.sqlclient = sqlclient
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	logs, err := r.sample(now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, logs.LogRecordCount(), "the first collection only records the statistics")
+
+	sqlclient.queryStats = []QueryStats{
+		{schema: "otel", digest: "a", digestText: "SELECT * FROM a", count: 11, sumTimerWait: 11e12, sumLockTime: 1e12, sumRowsSent: 110},
+		{schema: "otel", digest: "b", digestText: "SELECT * FROM b", count: 7, sumTimerWait: 9e12, sumLockTime: 2e12, sumRowsSent: 7},
+		{schema: "otel", digest: "c", digestText: "SELECT * FROM c", count: 1, sumTimerWait: 1e12, sumRowsSent: 1},
+		{schema: "otel", digest: "d", digestText: "SELECT * FROM d", count: 1, sumTimerWait: 3e12, sumRowsSent: 1},
+	}
+	logs, err = r.sample(now)
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.LogRecordCount())
+
+	rl := logs.ResourceLogs().At(0)
+	dbSystem, _ := rl.Resource().Attributes().Get("db.system")
+	assert.Equal(t, "mysql", dbSystem.Str())
+
+	lrs := rl.ScopeLogs().At(0).LogRecords()
+	expected := []struct {
+		text     string
+		calls    int64
+		duration float64
+		rows     int64
+		wait     float64
+	}{
+		{text: "SELECT * FROM b", calls: 2, duration: 4, rows: 2, wait: 2},
+		{text: "SELECT * FROM d", calls: 1, duration: 3, rows: 1, wait: 0},
+	}
+	for i, e := range expected {
+		lr := lrs.At(i)
+		assert.Equal(t, now, lr.Timestamp())
+		assert.Equal(t, e.text, lr.Body().Str())
+		attrs := lr.Attributes()
+		name, _ := attrs.Get("event.name")
+		assert.Equal(t, "db.query.sample", name.Str())
+		db, _ := attrs.Get("db.name")
+		assert.Equal(t, "otel", db.Str())
+		calls, _ := attrs.Get("db.query.calls")
+		assert.Equal(t, e.calls, calls.Int())
+		duration, _ := attrs.Get("db.query.duration")
+		assert.Equal(t, e.duration, duration.Double())
+		rows, _ := attrs.Get("db.query.rows")
+		assert.Equal(t, e.rows, rows.Int())
+		wait, _ := attrs.Get("db.query.wait_time")
+		assert.Equal(t, e.wait, wait.Double())
+	}
+}
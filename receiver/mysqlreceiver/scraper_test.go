@@ -96,6 +96,7 @@ type mockClient struct {
 	innodbStatsFile  string
 	tableIoWaitsFile string
 	indexIoWaitsFile string
+	queryStats       []QueryStats
 }
 
 func readFile(fname string) (map[string]string, error) {
@@ -185,6 +186,10 @@ func (c *mockClient) getIndexIoWaitsStats() ([]IndexIoWaitsStats, error) {
 	return stats, nil
 }
 
+func (c *mockClient) getQueryStats() ([]QueryStats, error) {
+	return c.queryStats, nil
+}
+
 func (c *mockClient) Close() error {
 	return nil
 }
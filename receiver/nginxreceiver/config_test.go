@@ -22,6 +22,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -36,3 +38,26 @@ func TestLoadConfig(t *testing.T) {
 
 	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
 }
+
+func TestLoadAccessLogConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "access_log").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, &accesslog.Config{
+		Path:      "/var/log/nginx/access.log",
+		Routes:    []accesslog.RouteConfig{{Match: "^/static/", Route: "/static"}},
+		MaxRoutes: 50,
+	}, cfg.AccessLog)
+
+	cfg = factory.CreateDefaultConfig().(*Config)
+	sub, err = cm.Sub(config.NewComponentIDWithName(typeStr, "invalid_access_log").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+	assert.EqualError(t, cfg.Validate(), "access_log: path must be set")
+}
@@ -17,6 +17,8 @@ type MetricSettings struct {
 
 // MetricsSettings provides settings for nginxreceiver metrics.
 type MetricsSettings struct {
+	NginxAccessDuration      MetricSettings `mapstructure:"nginx.access.duration"`
+	NginxAccessRequests      MetricSettings `mapstructure:"nginx.access.requests"`
 	NginxConnectionsAccepted MetricSettings `mapstructure:"nginx.connections_accepted"`
 	NginxConnectionsCurrent  MetricSettings `mapstructure:"nginx.connections_current"`
 	NginxConnectionsHandled  MetricSettings `mapstructure:"nginx.connections_handled"`
@@ -25,6 +27,12 @@ type MetricsSettings struct {
 
 func DefaultMetricsSettings() MetricsSettings {
 	return MetricsSettings{
+		NginxAccessDuration: MetricSettings{
+			Enabled: true,
+		},
+		NginxAccessRequests: MetricSettings{
+			Enabled: true,
+		},
 		NginxConnectionsAccepted: MetricSettings{
 			Enabled: true,
 		},
@@ -74,6 +82,117 @@ var MapAttributeState = map[string]AttributeState{
 	"waiting": AttributeStateWaiting,
 }
 
+type metricNginxAccessDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills nginx.access.duration metric with initial data.
+func (m *metricNginxAccessDuration) init() {
+	m.data.SetName("nginx.access.duration")
+	m.data.SetDescription("The duration of the requests, derived from the access log. Only recorded when access_log is set and the access log has the duration of the requests.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyHistogram()
+	m.data.Histogram().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Histogram().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNginxAccessDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, count uint64, sum float64, bounds []float64, bucketCounts []uint64, routeAttributeValue string, methodAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Histogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.ExplicitBounds().FromRaw(bounds)
+	dp.BucketCounts().FromRaw(bucketCounts)
+	dp.Attributes().PutStr("http.route", routeAttributeValue)
+	dp.Attributes().PutStr("http.method", methodAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNginxAccessDuration) updateCapacity() {
+	if m.data.Histogram().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Histogram().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNginxAccessDuration) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Histogram().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNginxAccessDuration(settings MetricSettings) metricNginxAccessDuration {
+	m := metricNginxAccessDuration{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricNginxAccessRequests struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills nginx.access.requests metric with initial data.
+func (m *metricNginxAccessRequests) init() {
+	m.data.SetName("nginx.access.requests")
+	m.data.SetDescription("The number of requests, derived from the access log. Only recorded when access_log is set.")
+	m.data.SetUnit("{requests}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNginxAccessRequests) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, routeAttributeValue string, methodAttributeValue string, statusClassAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.route", routeAttributeValue)
+	dp.Attributes().PutStr("http.method", methodAttributeValue)
+	dp.Attributes().PutStr("http.status_class", statusClassAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNginxAccessRequests) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNginxAccessRequests) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNginxAccessRequests(settings MetricSettings) metricNginxAccessRequests {
+	m := metricNginxAccessRequests{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricNginxConnectionsAccepted struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	settings MetricSettings // metric settings provided by user.
@@ -286,6 +405,8 @@ type MetricsBuilder struct {
 	resourceCapacity               int                 // maximum observed number of resource attributes.
 	metricsBuffer                  pmetric.Metrics     // accumulates metrics data before emitting.
 	buildInfo                      component.BuildInfo // contains version information
+	metricNginxAccessDuration      metricNginxAccessDuration
+	metricNginxAccessRequests      metricNginxAccessRequests
 	metricNginxConnectionsAccepted metricNginxConnectionsAccepted
 	metricNginxConnectionsCurrent  metricNginxConnectionsCurrent
 	metricNginxConnectionsHandled  metricNginxConnectionsHandled
@@ -307,6 +428,8 @@ func NewMetricsBuilder(settings MetricsSettings, buildInfo component.BuildInfo,
 		startTime:                      pcommon.NewTimestampFromTime(time.Now()),
 		metricsBuffer:                  pmetric.NewMetrics(),
 		buildInfo:                      buildInfo,
+		metricNginxAccessDuration:      newMetricNginxAccessDuration(settings.NginxAccessDuration),
+		metricNginxAccessRequests:      newMetricNginxAccessRequests(settings.NginxAccessRequests),
 		metricNginxConnectionsAccepted: newMetricNginxConnectionsAccepted(settings.NginxConnectionsAccepted),
 		metricNginxConnectionsCurrent:  newMetricNginxConnectionsCurrent(settings.NginxConnectionsCurrent),
 		metricNginxConnectionsHandled:  newMetricNginxConnectionsHandled(settings.NginxConnectionsHandled),
@@ -343,6 +466,12 @@ func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
 				dps = metrics.At(i).Gauge().DataPoints()
 			case pmetric.MetricTypeSum:
 				dps = metrics.At(i).Sum().DataPoints()
+			case pmetric.MetricTypeHistogram:
+				hdps := metrics.At(i).Histogram().DataPoints()
+				for j := 0; j < hdps.Len(); j++ {
+					hdps.At(j).SetStartTimestamp(start)
+				}
+				continue
 			}
 			for j := 0; j < dps.Len(); j++ {
 				dps.At(j).SetStartTimestamp(start)
@@ -363,6 +492,8 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	ils.Scope().SetName("otelcol/nginxreceiver")
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricNginxAccessDuration.emit(ils.Metrics())
+	mb.metricNginxAccessRequests.emit(ils.Metrics())
 	mb.metricNginxConnectionsAccepted.emit(ils.Metrics())
 	mb.metricNginxConnectionsCurrent.emit(ils.Metrics())
 	mb.metricNginxConnectionsHandled.emit(ils.Metrics())
@@ -386,6 +517,16 @@ func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
 	return metrics
 }
 
+// RecordNginxAccessDurationDataPoint adds a data point to nginx.access.duration metric.
+func (mb *MetricsBuilder) RecordNginxAccessDurationDataPoint(ts pcommon.Timestamp, count uint64, sum float64, bounds []float64, bucketCounts []uint64, routeAttributeValue string, methodAttributeValue string) {
+	mb.metricNginxAccessDuration.recordDataPoint(mb.startTime, ts, count, sum, bounds, bucketCounts, routeAttributeValue, methodAttributeValue)
+}
+
+// RecordNginxAccessRequestsDataPoint adds a data point to nginx.access.requests metric.
+func (mb *MetricsBuilder) RecordNginxAccessRequestsDataPoint(ts pcommon.Timestamp, val int64, routeAttributeValue string, methodAttributeValue string, statusClassAttributeValue string) {
+	mb.metricNginxAccessRequests.recordDataPoint(mb.startTime, ts, val, routeAttributeValue, methodAttributeValue, statusClassAttributeValue)
+}
+
 // RecordNginxConnectionsAcceptedDataPoint adds a data point to nginx.connections_accepted metric.
 func (mb *MetricsBuilder) RecordNginxConnectionsAcceptedDataPoint(ts pcommon.Timestamp, val int64) {
 	mb.metricNginxConnectionsAccepted.recordDataPoint(mb.startTime, ts, val)
@@ -23,8 +23,10 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nginxreceiver/internal/metadata"
 )
 
@@ -32,9 +34,10 @@ type nginxScraper struct {
 	httpClient *http.Client
 	client     *client.NginxClient
 
-	settings component.TelemetrySettings
-	cfg      *Config
-	mb       *metadata.MetricsBuilder
+	settings  component.TelemetrySettings
+	cfg       *Config
+	mb        *metadata.MetricsBuilder
+	accessLog *accesslog.Deriver
 }
 
 func newNginxScraper(
@@ -55,28 +58,67 @@ func (r *nginxScraper) start(_ context.Context, host component.Host) error {
 	}
 	r.httpClient = httpClient
 
+	if r.cfg.AccessLog != nil {
+		r.accessLog, err = accesslog.NewDeriver(r.cfg.AccessLog, r.settings.Logger)
+		if err != nil {
+			return err
+		}
+		return r.accessLog.Start()
+	}
+	return nil
+}
+
+func (r *nginxScraper) shutdown(context.Context) error {
+	if r.accessLog != nil {
+		return r.accessLog.Shutdown()
+	}
 	return nil
 }
 
 func (r *nginxScraper) scrape(context.Context) (pmetric.Metrics, error) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	// The access log metrics are recorded even when the stub status can't be fetched.
+	if r.accessLog != nil {
+		err := r.accessLog.Record(func(route, method, statusClass string, count int64) {
+			r.mb.RecordNginxAccessRequestsDataPoint(now, count, route, method, statusClass)
+		}, func(route, method string, count uint64, sum float64, bounds []float64, bucketCounts []uint64) {
+			r.mb.RecordNginxAccessDurationDataPoint(now, count, sum, bounds, bucketCounts, route, method)
+		})
+		if err != nil {
+			r.settings.Logger.Error("Failed to read the nginx access log", zap.Error(err))
+		}
+	}
+
+	if err := r.recordStubStats(now); err != nil {
+		if r.accessLog == nil {
+			return pmetric.Metrics{}, err
+		}
+		return r.mb.Emit(), scrapererror.NewPartialScrapeError(err, stubStatsMetricsCount)
+	}
+	return r.mb.Emit(), nil
+}
+
+// stubStatsMetricsCount is the number of metrics recorded from the stub status.
+const stubStatsMetricsCount = 4
+
+func (r *nginxScraper) recordStubStats(now pcommon.Timestamp) error {
 	// Init client in scrape method in case there are transient errors in the constructor.
 	if r.client == nil {
 		var err error
 		r.client, err = client.NewNginxClient(r.httpClient, r.cfg.HTTPClientSettings.Endpoint)
 		if err != nil {
 			r.client = nil
-			return pmetric.Metrics{}, err
+			return err
 		}
 	}
 
 	stats, err := r.client.GetStubStats()
 	if err != nil {
 		r.settings.Logger.Error("Failed to fetch nginx stats", zap.Error(err))
-		return pmetric.Metrics{}, err
+		return err
 	}
 
-	now := pcommon.NewTimestampFromTime(time.Now())
-
 	r.mb.RecordNginxRequestsDataPoint(now, stats.Requests)
 	r.mb.RecordNginxConnectionsAcceptedDataPoint(now, stats.Connections.Accepted)
 	r.mb.RecordNginxConnectionsHandledDataPoint(now, stats.Connections.Handled)
@@ -84,6 +126,5 @@ func (r *nginxScraper) scrape(context.Context) (pmetric.Metrics, error) {
 	r.mb.RecordNginxConnectionsCurrentDataPoint(now, stats.Connections.Reading, metadata.AttributeStateReading)
 	r.mb.RecordNginxConnectionsCurrentDataPoint(now, stats.Connections.Writing, metadata.AttributeStateWriting)
 	r.mb.RecordNginxConnectionsCurrentDataPoint(now, stats.Connections.Waiting, metadata.AttributeStateWaiting)
-
-	return r.mb.Emit(), nil
+	return nil
 }
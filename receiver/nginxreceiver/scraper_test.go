@@ -19,6 +19,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -26,7 +27,10 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/accesslog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest/golden"
 )
@@ -52,6 +56,72 @@ func TestScraper(t *testing.T) {
 	require.NoError(t, scrapertest.CompareMetrics(expectedMetrics, actualMetrics))
 }
 
+func TestScraperAccessLog(t *testing.T) {
+	nginxMock := newMockServer(t)
+	path := filepath.Join(t.TempDir(), "access.log")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = nginxMock.URL + "/status"
+	cfg.AccessLog = &accesslog.Config{Path: path}
+	require.NoError(t, cfg.Validate())
+
+	scraper := newNginxScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`127.0.0.1 - - [10/Oct/2022:13:55:36 +0000] "GET /orders/12 HTTP/1.1" 200 612 "-" "curl/7.79.1" 0.012` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	metrics := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	names := map[string]pmetric.Metric{}
+	for i := 0; i < metrics.Len(); i++ {
+		names[metrics.At(i).Name()] = metrics.At(i)
+	}
+	require.Contains(t, names, "nginx.requests")
+	require.Contains(t, names, "nginx.access.requests")
+	require.Contains(t, names, "nginx.access.duration")
+
+	dp := names["nginx.access.requests"].Sum().DataPoints().At(0)
+	require.Equal(t, int64(1), dp.IntValue())
+	require.Equal(t, map[string]interface{}{
+		"http.route":        "/orders/{id}",
+		"http.method":       "GET",
+		"http.status_class": "2xx",
+	}, dp.Attributes().AsRaw())
+}
+
+func TestScraperAccessLogStatusError(t *testing.T) {
+	nginxMock := newMockServer(t)
+	path := filepath.Join(t.TempDir(), "access.log")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = nginxMock.URL + "/badpath"
+	cfg.AccessLog = &accesslog.Config{Path: path}
+
+	scraper := newNginxScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, scraper.shutdown(context.Background())) }()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`127.0.0.1 - - [10/Oct/2022:13:55:36 +0000] "GET /orders/12 HTTP/1.1" 200 612` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.Equal(t, 1, actualMetrics.MetricCount())
+	require.Equal(t, "nginx.access.requests", actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
 func TestScraperError(t *testing.T) {
 	nginxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.Path == "/status" {
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxtreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	dm "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver/internal/model"
+)
+
+const defaultAlarmsPollInterval = time.Minute
+
+var _ component.LogsReceiver = (*alarmsReceiver)(nil)
+
+// alarmsReceiver reads the alarms modified since it started and converts them
+// to logs.
+type alarmsReceiver struct {
+	config   *Config
+	settings component.TelemetrySettings
+	consumer consumer.Logs
+	client   Client
+	// since is the last modification time of the alarms already read, in
+	// milliseconds since the epoch
+	since  int64
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newAlarmsReceiver(cfg *Config, settings component.ReceiverCreateSettings, consumer consumer.Logs) *alarmsReceiver {
+	return &alarmsReceiver{
+		config:   cfg,
+		settings: settings.TelemetrySettings,
+		consumer: consumer,
+	}
+}
+
+func (a *alarmsReceiver) Start(_ context.Context, host component.Host) error {
+	client, err := newClient(a.config, a.settings, host, a.settings.Logger)
+	if err != nil {
+		return fmt.Errorf("unable to construct http client: %w", err)
+	}
+	a.client = client
+	a.since = time.Now().UnixMilli()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		ticker := time.NewTicker(a.config.Alarms.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := a.poll(ctx); err != nil {
+					a.settings.Logger.Error("unable to read NSX alarms", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (a *alarmsReceiver) Shutdown(context.Context) error {
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.wg.Wait()
+	return nil
+}
+
+// poll reads the alarms modified since the previous poll and sends them to the
+// next consumer.
+func (a *alarmsReceiver) poll(ctx context.Context) error {
+	alarms, err := a.client.Alarms(ctx, a.since)
+	if err != nil {
+		return err
+	}
+
+	logs := plog.NewLogs()
+	observed := pcommon.NewTimestampFromTime(time.Now())
+	since := a.since
+	for _, alarm := range alarms {
+		// the modification time of the alarms already read may be repeated
+		if alarm.LastModifiedTime <= a.since {
+			continue
+		}
+		alarmToLogRecord(logs.ResourceLogs().AppendEmpty(), alarm, observed)
+		if alarm.LastModifiedTime > since {
+			since = alarm.LastModifiedTime
+		}
+	}
+	if logs.LogRecordCount() == 0 {
+		return nil
+	}
+	if err = a.consumer.ConsumeLogs(ctx, logs); err != nil {
+		return err
+	}
+	a.since = since
+	return nil
+}
+
+// alarmToLogRecord converts the alarm to a log record, with the node raising it
+// as resource.
+func alarmToLogRecord(rl plog.ResourceLogs, alarm dm.Alarm, observed pcommon.Timestamp) {
+	rl.Resource().Attributes().PutStr("nsxt.node.id", alarm.NodeID)
+	rl.Resource().Attributes().PutStr("nsxt.node.name", alarm.NodeDisplayName)
+
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(alarm.LastModifiedTime)))
+	lr.SetObservedTimestamp(observed)
+	lr.SetSeverityNumber(alarmSeverityNumber(alarm.Severity))
+	lr.SetSeverityText(alarm.Severity)
+	lr.Body().SetStr(alarm.Description)
+
+	attrs := lr.Attributes()
+	attrs.PutStr("nsxt.alarm.id", alarm.ID)
+	attrs.PutStr("nsxt.alarm.feature", alarm.FeatureName)
+	attrs.PutStr("nsxt.alarm.event_type", alarm.EventType)
+	attrs.PutStr("nsxt.alarm.status", alarm.Status)
+	attrs.PutStr("nsxt.alarm.summary", alarm.Summary)
+	if alarm.EntityID != "" {
+		attrs.PutStr("nsxt.alarm.entity_id", alarm.EntityID)
+	}
+	if alarm.RecommendedAction != "" {
+		attrs.PutStr("nsxt.alarm.recommended_action", alarm.RecommendedAction)
+	}
+}
+
+// alarmSeverityNumber maps the severity of an NSX alarm.
+func alarmSeverityNumber(severity string) plog.SeverityNumber {
+	switch severity {
+	case "CRITICAL":
+		return plog.SeverityNumberFatal
+	case "HIGH":
+		return plog.SeverityNumberError
+	case "MEDIUM":
+		return plog.SeverityNumberWarn
+	default:
+		return plog.SeverityNumberInfo
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsxtreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver"
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	dm "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver/internal/model"
+)
+
+func TestAlarmsPoll(t *testing.T) {
+	mockClient := NewMockClient(t)
+	mockClient.On("Alarms", mock.Anything, int64(1000)).Return([]dm.Alarm{
+		{
+			ID:               "alarm-0",
+			Severity:         "HIGH",
+			LastModifiedTime: 1000,
+		},
+		{
+			ID:                "alarm-1",
+			FeatureName:       "edge_health",
+			EventType:         "edge_datapath_configuration_failure",
+			NodeID:            transportNode1,
+			NodeDisplayName:   "nsx-edge-1",
+			Status:            "OPEN",
+			Severity:          "CRITICAL",
+			Description:       "Failed to enable the datapath on the Edge node after three attempts.",
+			RecommendedAction: "Check the Edge node.",
+			LastModifiedTime:  2000,
+		},
+	}, nil).Once()
+	mockClient.On("Alarms", mock.Anything, int64(2000)).Return([]dm.Alarm{}, nil).Once()
+
+	sink := &consumertest.LogsSink{}
+	a := newAlarmsReceiver(createDefaultConfig().(*Config), componenttest.NewNopReceiverCreateSettings(), sink)
+	a.client = mockClient
+	a.since = 1000
+
+	require.NoError(t, a.poll(context.Background()))
+	// the alarm modified at the time of the previous poll was already read
+	require.Equal(t, 1, sink.LogRecordCount())
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	nodeName, _ := rl.Resource().Attributes().Get("nsxt.node.name")
+	require.Equal(t, "nsx-edge-1", nodeName.Str())
+
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	require.Equal(t, plog.SeverityNumberFatal, lr.SeverityNumber())
+	require.Equal(t, "CRITICAL", lr.SeverityText())
+	require.Equal(t, "Failed to enable the datapath on the Edge node after three attempts.", lr.Body().Str())
+	require.EqualValues(t, 2000*1e6, lr.Timestamp())
+	eventType, _ := lr.Attributes().Get("nsxt.alarm.event_type")
+	require.Equal(t, "edge_datapath_configuration_failure", eventType.Str())
+	_, ok := lr.Attributes().Get("nsxt.alarm.entity_id")
+	require.False(t, ok)
+
+	sink.Reset()
+	require.NoError(t, a.poll(context.Background()))
+	require.Equal(t, 0, sink.LogRecordCount())
+}
+
+func TestAlarmsPollErrors(t *testing.T) {
+	t.Run("client error", func(t *testing.T) {
+		mockClient := NewMockClient(t)
+		mockClient.On("Alarms", mock.Anything, int64(0)).Return(nil, errUnauthorized)
+
+		a := newAlarmsReceiver(createDefaultConfig().(*Config), componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+		a.client = mockClient
+		require.ErrorIs(t, a.poll(context.Background()), errUnauthorized)
+	})
+
+	t.Run("consumer error", func(t *testing.T) {
+		mockClient := NewMockClient(t)
+		mockClient.On("Alarms", mock.Anything, int64(0)).Return([]dm.Alarm{{ID: "alarm-0", LastModifiedTime: 1000}}, nil)
+
+		consumeErr := errors.New("consumer error")
+		a := newAlarmsReceiver(createDefaultConfig().(*Config), componenttest.NewNopReceiverCreateSettings(), consumertest.NewErr(consumeErr))
+		a.client = mockClient
+		require.ErrorIs(t, a.poll(context.Background()), consumeErr)
+		// the alarms are read again on the next poll
+		require.Equal(t, int64(0), a.since)
+	})
+}
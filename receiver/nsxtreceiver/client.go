@@ -21,6 +21,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
@@ -37,6 +38,7 @@ type Client interface {
 	NodeStatus(ctx context.Context, nodeID string, class nodeClass) (*dm.NodeStatus, error)
 	Interfaces(ctx context.Context, nodeID string, class nodeClass) ([]dm.NetworkInterface, error)
 	InterfaceStatus(ctx context.Context, nodeID, interfaceID string, class nodeClass) (*dm.NetworkInterfaceStats, error)
+	Alarms(ctx context.Context, after int64) ([]dm.Alarm, error)
 }
 
 type nsxClient struct {
@@ -154,6 +156,29 @@ func (c *nsxClient) InterfaceStatus(
 	return &interfaceStats, err
 }
 
+// Alarms returns the alarms modified after the given time, in milliseconds
+// since the epoch
+func (c *nsxClient) Alarms(ctx context.Context, after int64) ([]dm.Alarm, error) {
+	var alarms []dm.Alarm
+	query := url.Values{}
+	query.Set("after", strconv.FormatInt(after, 10))
+	for {
+		body, err := c.doRequest(ctx, "/api/v1/alarms?"+query.Encode())
+		if err != nil {
+			return nil, fmt.Errorf("unable to get alarms: %w", err)
+		}
+		var page dm.AlarmList
+		if err = json.Unmarshal(body, &page); err != nil {
+			return nil, err
+		}
+		alarms = append(alarms, page.Results...)
+		if page.Cursor == "" || len(page.Results) == 0 {
+			return alarms, nil
+		}
+		query.Set("cursor", page.Cursor)
+	}
+}
+
 func (c *nsxClient) doRequest(ctx context.Context, path string) ([]byte, error) {
 	endpoint, err := c.endpoint.Parse(path)
 	if err != nil {
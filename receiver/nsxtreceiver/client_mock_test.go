@@ -37,6 +37,27 @@ type MockClient struct {
 	mock.Mock
 }
 
+// Alarms provides a mock function with given fields: ctx, after
+func (m *MockClient) Alarms(ctx context.Context, after int64) ([]model.Alarm, error) {
+	ret := m.Called(ctx, after)
+
+	var r0 []model.Alarm
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []model.Alarm); ok {
+		r0 = rf(ctx, after)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]model.Alarm)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ClusterNodes provides a mock function with given fields: ctx
 func (m *MockClient) ClusterNodes(ctx context.Context) ([]model.ClusterNode, error) {
 	ret := m.Called(ctx)
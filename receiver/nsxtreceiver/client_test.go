@@ -148,6 +148,21 @@ func TestManagerNodeInterfaceStatus(t *testing.T) {
 	require.NotZero(t, iStats.RxBytes)
 }
 
+func TestAlarms(t *testing.T) {
+	nsxMock := mockServer(t)
+	client, err := newClient(&Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: nsxMock.URL,
+		},
+	}, componenttest.NewNopTelemetrySettings(), componenttest.NewNopHost(), zap.NewNop())
+	require.NoError(t, err)
+	alarms, err := client.Alarms(context.Background(), 1665399999000)
+	require.NoError(t, err)
+	require.Len(t, alarms, 2)
+	require.Equal(t, "manager_disk_usage_high", alarms[0].EventType)
+	require.Equal(t, int64(1665400060000), alarms[1].LastModifiedTime)
+}
+
 func TestDoRequestBadUrl(t *testing.T) {
 	nsxMock := mockServer(t)
 	client, err := newClient(&Config{
@@ -272,6 +287,9 @@ func mockServer(t *testing.T) *httptest.Server {
 	mNodeInterfaceStats, err := os.ReadFile(filepath.Join("testdata", "metrics", "nodes", "cluster", managerNode1, "interfaces", managerNodeNic1, "stats.json"))
 	require.NoError(t, err)
 
+	alarms, err := os.ReadFile(filepath.Join("testdata", "logs", "alarms.json"))
+	require.NoError(t, err)
+
 	nsxMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		authUser, authPass, ok := req.BasicAuth()
 		switch {
@@ -342,6 +360,14 @@ func mockServer(t *testing.T) *httptest.Server {
 			return
 		}
 
+		if req.URL.Path == "/api/v1/alarms" {
+			require.Equal(t, "1665399999000", req.URL.Query().Get("after"))
+			rw.WriteHeader(200)
+			_, err = rw.Write(alarms)
+			require.NoError(t, err)
+			return
+		}
+
 		rw.WriteHeader(404)
 	}))
 
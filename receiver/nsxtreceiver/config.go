@@ -17,6 +17,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -32,6 +33,14 @@ type Config struct {
 	Metrics                                 metadata.MetricsSettings `mapstructure:"metrics"`
 	Username                                string                   `mapstructure:"username"`
 	Password                                string                   `mapstructure:"password"`
+	Alarms                                  AlarmsConfig             `mapstructure:"alarms"`
+}
+
+// AlarmsConfig is the configuration of the alarms collected by the logs receiver
+type AlarmsConfig struct {
+	// PollInterval is the interval at which the alarms modified since the
+	// previous poll are read.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
 }
 
 // Validate returns if the NSX configuration is valid
@@ -59,5 +68,9 @@ func (c *Config) Validate() error {
 	if c.Password == "" {
 		err = multierr.Append(err, errors.New("password not provided and is required"))
 	}
+
+	if c.Alarms.PollInterval <= 0 {
+		err = multierr.Append(err, errors.New("alarms poll_interval must be positive"))
+	}
 	return err
 }
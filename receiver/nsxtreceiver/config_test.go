@@ -76,6 +76,17 @@ func TestMetricValidation(t *testing.T) {
 			},
 			expectedError: errors.New("password not provided"),
 		},
+		{
+			desc: "alarms poll interval not positive",
+			cfg: &Config{
+				Username: "otelu",
+				Password: "password",
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost",
+				},
+			},
+			expectedError: errors.New("alarms poll_interval must be positive"),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -106,6 +117,7 @@ func TestLoadConfig(t *testing.T) {
 	expected.Password = "$NSXT_PASSWORD"
 	expected.TLSSetting.Insecure = true
 	expected.CollectionInterval = time.Minute
+	expected.Alarms.PollInterval = 5 * time.Minute
 
 	require.Equal(t, expected, cfg)
 }
@@ -40,6 +40,7 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, stability),
 	)
 }
 
@@ -50,6 +51,9 @@ func createDefaultConfig() config.Receiver {
 			CollectionInterval: time.Minute,
 		},
 		Metrics: metadata.DefaultMetricsSettings(),
+		Alarms: AlarmsConfig{
+			PollInterval: defaultAlarmsPollInterval,
+		},
 	}
 }
 
@@ -76,3 +80,14 @@ func createMetricsReceiver(ctx context.Context, params component.ReceiverCreateS
 		scraperhelper.AddScraper(scraper),
 	)
 }
+
+func createLogsReceiver(ctx context.Context, params component.ReceiverCreateSettings, rConf config.Receiver, consumer consumer.Logs) (component.LogsReceiver, error) {
+	cfg, ok := rConf.(*Config)
+	if !ok {
+		return nil, errConfigNotNSX
+	}
+	if consumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	return newAlarmsReceiver(cfg, params, consumer), nil
+}
@@ -64,3 +64,25 @@ func TestCreateMetricsReceiverNotNSX(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorContains(t, err, errConfigNotNSX.Error())
 }
+
+func TestCreateLogsReceiver(t *testing.T) {
+	factory := NewFactory()
+	_, err := factory.CreateLogsReceiver(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		factory.CreateDefaultConfig(),
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+}
+
+func TestCreateLogsReceiverNotNSX(t *testing.T) {
+	factory := NewFactory()
+	_, err := factory.CreateLogsReceiver(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		componenttest.NewNopReceiverFactory().CreateDefaultConfig(),
+		consumertest.NewNop(),
+	)
+	require.ErrorIs(t, err, errConfigNotNSX)
+}
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/nsxtreceiver/internal/model"
+
+// AlarmList is a page of the alarms of the NSX Manager
+type AlarmList struct {
+	Results []Alarm `json:"results"`
+	Cursor  string  `json:"cursor,omitempty"`
+}
+
+// Alarm is an alarm raised by a feature of NSX
+type Alarm struct {
+	ID                string `json:"id"`
+	FeatureName       string `json:"feature_name"`
+	EventType         string `json:"event_type"`
+	NodeID            string `json:"node_id"`
+	NodeDisplayName   string `json:"node_display_name"`
+	EntityID          string `json:"entity_id"`
+	Status            string `json:"status"`
+	Severity          string `json:"severity"`
+	Summary           string `json:"summary"`
+	Description       string `json:"description"`
+	RecommendedAction string `json:"recommended_action"`
+	LastModifiedTime  int64  `json:"_last_modified_time"`
+}
@@ -0,0 +1,262 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/objectstorereceiver"
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/Azure/azure-storage-queue-go/azqueue"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+)
+
+const (
+	attributeStorageAccount   = "azure.storage.account"
+	attributeStorageContainer = "azure.storage.container"
+	attributeStorageBlob      = "azure.storage.blob"
+
+	eventTypeBlobCreated = "Microsoft.Storage.BlobCreated"
+
+	// maxBlobRetryRequests is the number of times the download of a blob is
+	// resumed after a failure.
+	maxBlobRetryRequests = 3
+)
+
+var errMissingAccount = errors.New("missing AccountName or AccountKey")
+
+// storageAccount holds the settings of a storage account connection string.
+type storageAccount struct {
+	name          string
+	key           string
+	blobEndpoint  string
+	queueEndpoint string
+}
+
+// parseConnectionString parses a connection string of the form
+// DefaultEndpointsProtocol=https;AccountName=<name>;AccountKey=<key>;EndpointSuffix=core.windows.net.
+// The endpoints of the services default to the ones of the account in the
+// Azure cloud, and can be set with the BlobEndpoint and QueueEndpoint settings.
+func parseConnectionString(connectionString string) (storageAccount, error) {
+	settings := map[string]string{}
+	for _, setting := range strings.Split(connectionString, ";") {
+		if setting = strings.TrimSpace(setting); setting == "" {
+			continue
+		}
+		// the setting isn't part of the error, as it could be the account key
+		name, value, ok := strings.Cut(setting, "=")
+		if !ok {
+			return storageAccount{}, errors.New("settings must be of the form name=value")
+		}
+		settings[name] = value
+	}
+
+	account := storageAccount{
+		name:          settings["AccountName"],
+		key:           settings["AccountKey"],
+		blobEndpoint:  settings["BlobEndpoint"],
+		queueEndpoint: settings["QueueEndpoint"],
+	}
+	if account.name == "" || account.key == "" {
+		return storageAccount{}, errMissingAccount
+	}
+
+	protocol := settings["DefaultEndpointsProtocol"]
+	if protocol == "" {
+		protocol = "https"
+	}
+	suffix := settings["EndpointSuffix"]
+	if suffix == "" {
+		suffix = "core.windows.net"
+	}
+	if account.blobEndpoint == "" {
+		account.blobEndpoint = fmt.Sprintf("%s://%s.blob.%s", protocol, account.name, suffix)
+	}
+	if account.queueEndpoint == "" {
+		account.queueEndpoint = fmt.Sprintf("%s://%s.queue.%s", protocol, account.name, suffix)
+	}
+	return account, nil
+}
+
+// blobEvent is an Event Grid event of Azure Blob Storage, in either the Event
+// Grid or the CloudEvents schema. See
+// https://learn.microsoft.com/en-us/azure/event-grid/event-schema-blob-storage
+type blobEvent struct {
+	// Topic and EventType are the fields of the Event Grid schema.
+	Topic     string `json:"topic"`
+	EventType string `json:"eventType"`
+	// Source and Type are the fields of the CloudEvents schema.
+	Source string `json:"source"`
+	Type   string `json:"type"`
+
+	// Subject is the path of the blob, of the form
+	// /blobServices/default/containers/<container>/blobs/<blob>.
+	Subject string `json:"subject"`
+	Data    struct {
+		ETag string `json:"eTag"`
+	} `json:"data"`
+}
+
+// parseBlobEvents returns the blobs created according to the Event Grid events
+// of a storage queue message, which is base64 encoded by default.
+func parseBlobEvents(text string) ([]object, error) {
+	data := []byte(text)
+	if decoded, err := base64.StdEncoding.DecodeString(text); err == nil {
+		data = decoded
+	}
+
+	var events []blobEvent
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &events); err != nil {
+			return nil, err
+		}
+	} else {
+		var event blobEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	var objects []object
+	for _, event := range events {
+		if event.EventType != eventTypeBlobCreated && event.Type != eventTypeBlobCreated {
+			continue
+		}
+		path := strings.TrimPrefix(event.Subject, "/blobServices/default/containers/")
+		container, blob, ok := strings.Cut(path, "/blobs/")
+		if !ok || path == event.Subject {
+			return nil, fmt.Errorf("invalid blob event subject %q", event.Subject)
+		}
+
+		topic := event.Topic
+		if topic == "" {
+			topic = event.Source
+		}
+		objects = append(objects, object{
+			account: topic[strings.LastIndexByte(topic, '/')+1:],
+			bucket:  container,
+			key:     blob,
+			etag:    event.Data.ETag,
+		})
+	}
+	return objects, nil
+}
+
+// azureBlobSource reads the blobs of a storage account, as notified by Event
+// Grid through a storage queue.
+type azureBlobSource struct {
+	logger            *zap.Logger
+	maxMessages       int32
+	pollInterval      time.Duration
+	visibilityTimeout time.Duration
+
+	messages azqueue.MessagesURL
+	blobs    azblob.ServiceURL
+}
+
+func newAzureBlobSource(cfg *Config, logger *zap.Logger) (*azureBlobSource, error) {
+	account, err := parseConnectionString(cfg.AzureBlob.ConnectionString)
+	if err != nil {
+		return nil, err
+	}
+	queueURL, err := url.Parse(account.queueEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	blobURL, err := url.Parse(account.blobEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	queueCredential, err := azqueue.NewSharedKeyCredential(account.name, account.key)
+	if err != nil {
+		return nil, err
+	}
+	blobCredential, err := azblob.NewSharedKeyCredential(account.name, account.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azureBlobSource{
+		logger:            logger,
+		maxMessages:       int32(cfg.MaxMessages),
+		pollInterval:      cfg.PollInterval,
+		visibilityTimeout: cfg.VisibilityTimeout,
+		messages: azqueue.NewServiceURL(*queueURL, azqueue.NewPipeline(queueCredential, azqueue.PipelineOptions{})).
+			NewQueueURL(cfg.AzureBlob.QueueName).
+			NewMessagesURL(),
+		blobs: azblob.NewServiceURL(*blobURL, azblob.NewPipeline(blobCredential, azblob.PipelineOptions{})),
+	}, nil
+}
+
+func (s *azureBlobSource) receive(ctx context.Context) ([]notification, error) {
+	resp, err := s.messages.Dequeue(ctx, s.maxMessages, s.visibilityTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if resp.NumMessages() == 0 {
+		// storage queues can't be long polled
+		wait(ctx, s.pollInterval)
+		return nil, nil
+	}
+
+	notifications := make([]notification, 0, resp.NumMessages())
+	for i := int32(0); i < resp.NumMessages(); i++ {
+		msg := resp.Message(i)
+		objects, err := parseBlobEvents(msg.Text)
+		if err != nil {
+			// the message is left in the queue, to be moved to its poison queue
+			s.logger.Warn("Skipping an invalid blob event", zap.String("message_id", msg.ID.String()), zap.Error(err))
+			continue
+		}
+		notifications = append(notifications, notification{
+			objects: objects,
+			ack: func(ctx context.Context) error {
+				_, err := s.messages.NewMessageIDURL(msg.ID).Delete(ctx, msg.PopReceipt)
+				return err
+			},
+		})
+	}
+	return notifications, nil
+}
+
+func (s *azureBlobSource) open(ctx context.Context, obj object) (io.ReadCloser, error) {
+	resp, err := s.blobs.NewContainerURL(obj.bucket).NewBlobURL(obj.key).
+		Download(ctx, 0, azblob.CountToEnd, azblob.BlobAccessConditions{}, false, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(azblob.RetryReaderOptions{MaxRetryRequests: maxBlobRetryRequests}), nil
+}
+
+func (s *azureBlobSource) resource(obj object, attrs pcommon.Map) {
+	attrs.PutStr(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAzure)
+	if obj.account != "" {
+		attrs.PutStr(attributeStorageAccount, obj.account)
+	}
+	attrs.PutStr(attributeStorageContainer, obj.bucket)
+	attrs.PutStr(attributeStorageBlob, obj.key)
+}
@@ -0,0 +1,163 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+// testAccountKey is the well-known key of the storage emulator.
+const testAccountKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+
+const testBlobEvent = `{
+  "topic": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/logs/providers/Microsoft.Storage/storageAccounts/logsaccount",
+  "subject": "/blobServices/default/containers/insights-logs/blobs/resourceId=/SUBSCRIPTIONS/00000000/y=2022/PT1H.json",
+  "eventType": "Microsoft.Storage.BlobCreated",
+  "id": "831e1650-001e-001b-66ab-eeb76e069631",
+  "data": {"eTag": "0x8D4BCC2E4835CD0", "contentLength": 524288},
+  "dataVersion": "",
+  "metadataVersion": "1",
+  "eventTime": "2022-10-01T20:41:00.9584103Z"
+}`
+
+func TestParseConnectionString(t *testing.T) {
+	account, err := parseConnectionString("DefaultEndpointsProtocol=https;AccountName=logsaccount;AccountKey=" + testAccountKey + ";EndpointSuffix=core.windows.net")
+	require.NoError(t, err)
+	assert.Equal(t, storageAccount{
+		name:          "logsaccount",
+		key:           testAccountKey,
+		blobEndpoint:  "https://logsaccount.blob.core.windows.net",
+		queueEndpoint: "https://logsaccount.queue.core.windows.net",
+	}, account)
+
+	account, err = parseConnectionString("AccountName=devstoreaccount1;AccountKey=key;BlobEndpoint=http://127.0.0.1:10000/devstoreaccount1;QueueEndpoint=http://127.0.0.1:10001/devstoreaccount1;")
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:10000/devstoreaccount1", account.blobEndpoint)
+	assert.Equal(t, "http://127.0.0.1:10001/devstoreaccount1", account.queueEndpoint)
+
+	_, err = parseConnectionString("AccountName=logsaccount")
+	assert.ErrorIs(t, err, errMissingAccount)
+	_, err = parseConnectionString("AccountName=logsaccount;AccountKey")
+	assert.EqualError(t, err, "settings must be of the form name=value")
+}
+
+func TestParseBlobEvents(t *testing.T) {
+	expected := []object{{
+		account: "logsaccount",
+		bucket:  "insights-logs",
+		key:     "resourceId=/SUBSCRIPTIONS/00000000/y=2022/PT1H.json",
+		etag:    "0x8D4BCC2E4835CD0",
+	}}
+
+	objects, err := parseBlobEvents(base64.StdEncoding.EncodeToString([]byte(testBlobEvent)))
+	require.NoError(t, err)
+	assert.Equal(t, expected, objects)
+
+	objects, err = parseBlobEvents("[" + testBlobEvent + "]")
+	require.NoError(t, err)
+	assert.Equal(t, expected, objects)
+
+	// CloudEvents schema
+	objects, err = parseBlobEvents(`{
+  "source": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/logs/providers/Microsoft.Storage/storageAccounts/logsaccount",
+  "subject": "/blobServices/default/containers/insights-logs/blobs/resourceId=/SUBSCRIPTIONS/00000000/y=2022/PT1H.json",
+  "type": "Microsoft.Storage.BlobCreated",
+  "data": {"eTag": "0x8D4BCC2E4835CD0"}
+}`)
+	require.NoError(t, err)
+	assert.Equal(t, expected, objects)
+
+	objects, err = parseBlobEvents(`{"eventType": "Microsoft.Storage.BlobDeleted", "subject": "/blobServices/default/containers/logs/blobs/a.json"}`)
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+
+	_, err = parseBlobEvents(`{"eventType": "Microsoft.Storage.BlobCreated", "subject": "/containers/logs"}`)
+	assert.EqualError(t, err, `invalid blob event subject "/containers/logs"`)
+}
+
+func TestAzureBlobSource(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/queue/devstoreaccount1/blob-events/messages":
+			assert.Equal(t, "32", r.URL.Query().Get("numofmessages"))
+			assert.Equal(t, "300", r.URL.Query().Get("visibilitytimeout"))
+			now := time.Now().UTC().Format(http.TimeFormat)
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><QueueMessagesList>`+
+				`<QueueMessage><MessageId>message-1</MessageId><InsertionTime>%[1]s</InsertionTime><ExpirationTime>%[1]s</ExpirationTime>`+
+				`<PopReceipt>receipt-1</PopReceipt><TimeNextVisible>%[1]s</TimeNextVisible><DequeueCount>1</DequeueCount>`+
+				`<MessageText>%[2]s</MessageText></QueueMessage>`+
+				`<QueueMessage><MessageId>message-2</MessageId><InsertionTime>%[1]s</InsertionTime><ExpirationTime>%[1]s</ExpirationTime>`+
+				`<PopReceipt>receipt-2</PopReceipt><TimeNextVisible>%[1]s</TimeNextVisible><DequeueCount>1</DequeueCount>`+
+				`<MessageText>not json</MessageText></QueueMessage>`+
+				`</QueueMessagesList>`, now, base64.StdEncoding.EncodeToString([]byte(testBlobEvent)))
+		case r.Method == http.MethodDelete && r.URL.Path == "/queue/devstoreaccount1/blob-events/messages/message-1":
+			deleted = append(deleted, r.URL.Query().Get("popreceipt"))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/blob/devstoreaccount1/insights-logs/resourceId=/SUBSCRIPTIONS/00000000/y=2022/PT1H.json":
+			_, _ = w.Write([]byte("content"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxMessages = maxStorageQueueMessages
+	cfg.AzureBlob = &AzureBlobConfig{
+		ConnectionString: fmt.Sprintf("AccountName=devstoreaccount1;AccountKey=%s;BlobEndpoint=%[2]s/blob/devstoreaccount1;QueueEndpoint=%[2]s/queue/devstoreaccount1",
+			testAccountKey, server.URL),
+		QueueName: "blob-events",
+	}
+	src, err := newAzureBlobSource(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	notifications, err := src.receive(context.Background())
+	require.NoError(t, err)
+	// the invalid message is left in the queue
+	require.Len(t, notifications, 1)
+	require.Len(t, notifications[0].objects, 1)
+	require.NoError(t, notifications[0].ack(context.Background()))
+	assert.Equal(t, []string{"receipt-1"}, deleted)
+
+	obj := notifications[0].objects[0]
+	content, err := src.open(context.Background(), obj)
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+
+	attrs := pcommon.NewMap()
+	src.resource(obj, attrs)
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":          "azure",
+		"azure.storage.account":   "logsaccount",
+		"azure.storage.container": "insights-logs",
+		"azure.storage.blob":      "resourceId=/SUBSCRIPTIONS/00000000/y=2022/PT1H.json",
+	}, attrs.AsRaw())
+}
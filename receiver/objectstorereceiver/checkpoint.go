@@ -0,0 +1,87 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/objectstorereceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+// checkpoint is the position in an object of the lines already sent to the pipeline.
+type checkpoint struct {
+	// ETag of the object, the checkpoint being ignored when the object was overwritten.
+	ETag string `json:"etag"`
+	// Lines is the number of lines already read.
+	Lines int64 `json:"lines"`
+}
+
+// checkpoints persists the position of the objects being read.
+type checkpoints struct {
+	client storage.Client
+}
+
+// load returns the checkpoint of an object, or a zero checkpoint when the
+// object wasn't read yet.
+func (c checkpoints) load(ctx context.Context, obj object) (checkpoint, error) {
+	var cp checkpoint
+	data, err := c.client.Get(ctx, obj.id())
+	if err != nil || data == nil {
+		return cp, err
+	}
+	if err = json.Unmarshal(data, &cp); err != nil {
+		return cp, fmt.Errorf("invalid checkpoint of %s: %w", obj.id(), err)
+	}
+	if cp.ETag != obj.etag {
+		return checkpoint{}, nil
+	}
+	return cp, nil
+}
+
+func (c checkpoints) save(ctx context.Context, obj object, lines int64) error {
+	data, err := json.Marshal(checkpoint{ETag: obj.etag, Lines: lines})
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, obj.id(), data)
+}
+
+func (c checkpoints) remove(ctx context.Context, obj object) error {
+	return c.client.Delete(ctx, obj.id())
+}
+
+// getStorageClient returns a client of the configured storage extension, or a
+// no-op client when checkpointing is disabled.
+func getStorageClient(ctx context.Context, host component.Host, storageID *config.ComponentID, componentID config.ComponentID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, "")
+}
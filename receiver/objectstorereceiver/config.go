@@ -0,0 +1,144 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/objectstorereceiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	formatJSONLines    = "json_lines"
+	formatVPCFlowLog   = "vpc_flow_log"
+	formatALBAccessLog = "alb_access_log"
+
+	// SQS returns at most 10 messages per request, and storage queues 32.
+	maxSQSMessages          = 10
+	maxStorageQueueMessages = 32
+)
+
+// Config defines configuration for the object store receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+
+	// S3 reads the objects created in S3 buckets, as notified through an SQS queue.
+	S3 *S3Config `mapstructure:"s3"`
+
+	// AzureBlob reads the blobs created in Azure Blob Storage, as notified by
+	// Event Grid through a storage queue.
+	AzureBlob *AzureBlobConfig `mapstructure:"azure_blob"`
+
+	// Format of the objects: "json_lines", "vpc_flow_log" or "alb_access_log".
+	// Gzip compressed objects are detected and decompressed.
+	Format string `mapstructure:"format"`
+
+	// MaxMessages is the maximum number of notifications read from the queue at once.
+	MaxMessages int `mapstructure:"max_messages"`
+
+	// PollInterval is the time waited for notifications when the queue is empty.
+	// SQS queues are long polled, for up to 20 seconds.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// VisibilityTimeout is the time a notification is hidden from the other
+	// consumers of the queue while its objects are read. A notification is
+	// read again when its objects could not be read within this time.
+	VisibilityTimeout time.Duration `mapstructure:"visibility_timeout"`
+
+	// BatchSize is the maximum number of log records sent at once to the
+	// pipeline. The position in the object is checkpointed after each batch.
+	BatchSize int `mapstructure:"batch_size"`
+
+	// StorageID is the ID of the storage extension used to persist the
+	// position of the objects being read, so that a notification read again
+	// after a failure or a restart doesn't send its logs twice.
+	StorageID *config.ComponentID `mapstructure:"storage"`
+}
+
+// S3Config defines how the objects of S3 buckets are read.
+type S3Config struct {
+	// QueueURL is the URL of the SQS queue the S3 event notifications are sent to,
+	// either directly or through an SNS topic.
+	QueueURL string `mapstructure:"queue_url"`
+
+	// Region of the queue and the buckets. Read from the queue URL when not set.
+	Region string `mapstructure:"region"`
+
+	// Endpoint overrides the endpoint of the SQS and S3 APIs,
+	// e.g. for S3 compatible object stores.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// RoleARN is the IAM role assumed to read the queue and the objects.
+	RoleARN string `mapstructure:"role_arn"`
+}
+
+// AzureBlobConfig defines how the blobs of a storage account are read.
+type AzureBlobConfig struct {
+	// ConnectionString of the storage account holding the blobs and the queue.
+	ConnectionString string `mapstructure:"connection_string"`
+
+	// QueueName is the name of the storage queue Event Grid sends the
+	// Microsoft.Storage.BlobCreated events to.
+	QueueName string `mapstructure:"queue_name"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	maxMessages := maxSQSMessages
+	switch {
+	case cfg.S3 == nil && cfg.AzureBlob == nil:
+		return errors.New("one of 's3' or 'azure_blob' must be set")
+	case cfg.S3 != nil && cfg.AzureBlob != nil:
+		return errors.New("only one of 's3' or 'azure_blob' can be set")
+	case cfg.S3 != nil:
+		if cfg.S3.QueueURL == "" {
+			return errors.New("'s3.queue_url' must be set")
+		}
+	default:
+		if cfg.AzureBlob.ConnectionString == "" {
+			return errors.New("'azure_blob.connection_string' must be set")
+		}
+		if cfg.AzureBlob.QueueName == "" {
+			return errors.New("'azure_blob.queue_name' must be set")
+		}
+		if _, err := parseConnectionString(cfg.AzureBlob.ConnectionString); err != nil {
+			return fmt.Errorf("invalid 'azure_blob.connection_string': %w", err)
+		}
+		maxMessages = maxStorageQueueMessages
+	}
+
+	switch cfg.Format {
+	case formatJSONLines, formatVPCFlowLog, formatALBAccessLog:
+	default:
+		return fmt.Errorf("unsupported format %q, must be one of %q, %q or %q", cfg.Format, formatJSONLines, formatVPCFlowLog, formatALBAccessLog)
+	}
+	if cfg.MaxMessages < 1 || cfg.MaxMessages > maxMessages {
+		return fmt.Errorf("'max_messages' must be between 1 and %d", maxMessages)
+	}
+	if cfg.PollInterval <= 0 {
+		return errors.New("'poll_interval' must be positive")
+	}
+	if cfg.VisibilityTimeout < time.Second {
+		return errors.New("'visibility_timeout' must be at least 1s")
+	}
+	if cfg.BatchSize < 1 {
+		return errors.New("'batch_size' must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	storageID := config.NewComponentID("file_storage")
+	tests := []struct {
+		id       config.ComponentID
+		expected config.Receiver
+	}{
+		{
+			id:       config.NewComponentID(typeStr),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "s3"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				S3: &S3Config{
+					QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/alb-logs",
+					Region:   "us-east-1",
+					RoleARN:  "arn:aws:iam::123456789012:role/logs-reader",
+				},
+				Format:            formatALBAccessLog,
+				MaxMessages:       5,
+				PollInterval:      10 * time.Second,
+				VisibilityTimeout: 10 * time.Minute,
+				BatchSize:         500,
+				StorageID:         &storageID,
+			},
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "azure_blob"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				AzureBlob: &AzureBlobConfig{
+					ConnectionString: "DefaultEndpointsProtocol=https;AccountName=logsaccount;AccountKey=" + testAccountKey + ";EndpointSuffix=core.windows.net",
+					QueueName:        "blob-events",
+				},
+				Format:            formatJSONLines,
+				MaxMessages:       32,
+				PollInterval:      defaultPollInterval,
+				VisibilityTimeout: defaultVisibilityTimeout,
+				BatchSize:         defaultBatchSize,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+// useAzureBlob replaces the S3 settings of a configuration with valid Azure Blob settings.
+func useAzureBlob(cfg *Config) {
+	cfg.S3 = nil
+	cfg.AzureBlob = &AzureBlobConfig{
+		ConnectionString: "AccountName=logsaccount;AccountKey=" + testAccountKey,
+		QueueName:        "blob-events",
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:   "valid s3",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name: "valid azure blob",
+			modify: func(cfg *Config) {
+				useAzureBlob(cfg)
+				cfg.MaxMessages = 32
+			},
+		},
+		{
+			name:        "no source",
+			modify:      func(cfg *Config) { cfg.S3 = nil },
+			expectedErr: "one of 's3' or 'azure_blob' must be set",
+		},
+		{
+			name:        "both sources",
+			modify:      func(cfg *Config) { cfg.AzureBlob = &AzureBlobConfig{} },
+			expectedErr: "only one of 's3' or 'azure_blob' can be set",
+		},
+		{
+			name:        "missing queue url",
+			modify:      func(cfg *Config) { cfg.S3.QueueURL = "" },
+			expectedErr: "'s3.queue_url' must be set",
+		},
+		{
+			name: "missing connection string",
+			modify: func(cfg *Config) {
+				useAzureBlob(cfg)
+				cfg.AzureBlob.ConnectionString = ""
+			},
+			expectedErr: "'azure_blob.connection_string' must be set",
+		},
+		{
+			name: "missing queue name",
+			modify: func(cfg *Config) {
+				useAzureBlob(cfg)
+				cfg.AzureBlob.QueueName = ""
+			},
+			expectedErr: "'azure_blob.queue_name' must be set",
+		},
+		{
+			name: "invalid connection string",
+			modify: func(cfg *Config) {
+				useAzureBlob(cfg)
+				cfg.AzureBlob.ConnectionString = "AccountName=logsaccount"
+			},
+			expectedErr: "invalid 'azure_blob.connection_string': missing AccountName or AccountKey",
+		},
+		{
+			name:        "unsupported format",
+			modify:      func(cfg *Config) { cfg.Format = "csv" },
+			expectedErr: `unsupported format "csv", must be one of "json_lines", "vpc_flow_log" or "alb_access_log"`,
+		},
+		{
+			name:        "too many sqs messages",
+			modify:      func(cfg *Config) { cfg.MaxMessages = 11 },
+			expectedErr: "'max_messages' must be between 1 and 10",
+		},
+		{
+			name: "too many storage queue messages",
+			modify: func(cfg *Config) {
+				useAzureBlob(cfg)
+				cfg.MaxMessages = 33
+			},
+			expectedErr: "'max_messages' must be between 1 and 32",
+		},
+		{
+			name:        "invalid poll interval",
+			modify:      func(cfg *Config) { cfg.PollInterval = 0 },
+			expectedErr: "'poll_interval' must be positive",
+		},
+		{
+			name:        "invalid visibility timeout",
+			modify:      func(cfg *Config) { cfg.VisibilityTimeout = time.Millisecond },
+			expectedErr: "'visibility_timeout' must be at least 1s",
+		},
+		{
+			name:        "invalid batch size",
+			modify:      func(cfg *Config) { cfg.BatchSize = 0 },
+			expectedErr: "'batch_size' must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.S3 = &S3Config{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/logs"}
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/objectstorereceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	// maxLineSize is the maximum size of a line of an object.
+	maxLineSize = 1024 * 1024
+
+	vpcFlowLogAttributePrefix   = "aws.vpc.flow."
+	albAccessLogAttributePrefix = "aws.alb."
+)
+
+var (
+	errMissingHeader   = errors.New("missing VPC flow log header")
+	errUnexpectedQuote = errors.New("unexpected quote")
+)
+
+// vpcFlowLogIntegerFields are the fields of the VPC flow logs holding integers.
+var vpcFlowLogIntegerFields = map[string]bool{
+	"version":      true,
+	"srcport":      true,
+	"dstport":      true,
+	"protocol":     true,
+	"packets":      true,
+	"bytes":        true,
+	"start":        true,
+	"end":          true,
+	"tcp-flags":    true,
+	"traffic-path": true,
+}
+
+// albAccessLogFields are the fields of the ALB access logs, in order. See
+// https://docs.aws.amazon.com/elasticloadbalancing/latest/application/load-balancer-access-logs.html#access-log-entry-syntax
+var albAccessLogFields = []string{
+	"type",
+	"time",
+	"elb",
+	"client_port",
+	"target_port",
+	"request_processing_time",
+	"target_processing_time",
+	"response_processing_time",
+	"elb_status_code",
+	"target_status_code",
+	"received_bytes",
+	"sent_bytes",
+	"request",
+	"user_agent",
+	"ssl_cipher",
+	"ssl_protocol",
+	"target_group_arn",
+	"trace_id",
+	"domain_name",
+	"chosen_cert_arn",
+	"matched_rule_priority",
+	"request_creation_time",
+	"actions_executed",
+	"redirect_url",
+	"error_reason",
+	"target_port_list",
+	"target_status_code_list",
+	"classification",
+	"classification_reason",
+}
+
+// albAccessLogNumberFields are the fields of the ALB access logs holding
+// integers, or doubles for the processing times.
+var albAccessLogNumberFields = map[string]pcommon.ValueType{
+	"request_processing_time":  pcommon.ValueTypeDouble,
+	"target_processing_time":   pcommon.ValueTypeDouble,
+	"response_processing_time": pcommon.ValueTypeDouble,
+	"elb_status_code":          pcommon.ValueTypeInt,
+	"target_status_code":       pcommon.ValueTypeInt,
+	"received_bytes":           pcommon.ValueTypeInt,
+	"sent_bytes":               pcommon.ValueTypeInt,
+}
+
+// decoder converts the lines of an object into log records.
+type decoder interface {
+	// decode converts a line into a log record. It returns false for the
+	// lines holding no log record, such as empty lines or headers.
+	decode(line []byte, lr plog.LogRecord) (bool, error)
+}
+
+// newDecoder returns a decoder of the lines of an object, in the given format.
+func newDecoder(format string) decoder {
+	switch format {
+	case formatVPCFlowLog:
+		return &vpcFlowLogDecoder{}
+	case formatALBAccessLog:
+		return albAccessLogDecoder{}
+	default:
+		return jsonLinesDecoder{}
+	}
+}
+
+// newLineScanner returns a scanner of the lines of an object, decompressing
+// the gzip compressed objects.
+func newLineScanner(r io.Reader) (*bufio.Scanner, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var lines io.Reader = br
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		if lines, err = gzip.NewReader(br); err != nil {
+			return nil, err
+		}
+	}
+	scanner := bufio.NewScanner(lines)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	return scanner, nil
+}
+
+// jsonLinesDecoder decodes objects holding a JSON value per line, used as
+// the body of the log records.
+type jsonLinesDecoder struct{}
+
+func (jsonLinesDecoder) decode(line []byte, lr plog.LogRecord) (bool, error) {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return false, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(line, &v); err != nil {
+		return false, err
+	}
+	lr.Body().FromRaw(v)
+	return true, nil
+}
+
+// vpcFlowLogDecoder decodes the VPC flow logs published to S3, whose first
+// line is a header naming the fields of the following lines.
+type vpcFlowLogDecoder struct {
+	fields []string
+}
+
+func (d *vpcFlowLogDecoder) decode(line []byte, lr plog.LogRecord) (bool, error) {
+	values := strings.Fields(string(line))
+	if len(values) == 0 {
+		return false, nil
+	}
+	if d.fields == nil {
+		if values[0] != "version" {
+			return false, errMissingHeader
+		}
+		d.fields = values
+		return false, nil
+	}
+	if len(values) != len(d.fields) {
+		return false, fmt.Errorf("expected %d fields, got %d", len(d.fields), len(values))
+	}
+
+	lr.Body().SetStr(string(line))
+	attrs := lr.Attributes()
+	for i, field := range d.fields {
+		value := values[i]
+		// fields without value, e.g. for the NODATA records, are set to "-"
+		if value == "-" {
+			continue
+		}
+		name := vpcFlowLogAttributePrefix + strings.ReplaceAll(field, "-", "_")
+		if !vpcFlowLogIntegerFields[field] {
+			attrs.PutStr(name, value)
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s: %w", field, err)
+		}
+		attrs.PutInt(name, n)
+		if field == "start" {
+			lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(n, 0)))
+		}
+	}
+	return true, nil
+}
+
+// albAccessLogDecoder decodes the access logs of the Application Load Balancers.
+type albAccessLogDecoder struct{}
+
+func (albAccessLogDecoder) decode(line []byte, lr plog.LogRecord) (bool, error) {
+	values, err := splitQuoted(string(line))
+	if err != nil || len(values) == 0 {
+		return false, err
+	}
+	if len(values) < 12 {
+		return false, fmt.Errorf("expected at least 12 fields, got %d", len(values))
+	}
+
+	lr.Body().SetStr(string(line))
+	attrs := lr.Attributes()
+	// the fields added to the format over time are ignored
+	for i, field := range albAccessLogFields[:min(len(values), len(albAccessLogFields))] {
+		value := values[i]
+		if value == "-" || value == "" {
+			continue
+		}
+		name := albAccessLogAttributePrefix + field
+		switch albAccessLogNumberFields[field] {
+		case pcommon.ValueTypeInt:
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return false, fmt.Errorf("invalid %s: %w", field, err)
+			}
+			attrs.PutInt(name, n)
+		case pcommon.ValueTypeDouble:
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return false, fmt.Errorf("invalid %s: %w", field, err)
+			}
+			attrs.PutDouble(name, f)
+		default:
+			attrs.PutStr(name, value)
+		}
+		if field == "time" {
+			ts, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return false, fmt.Errorf("invalid time: %w", err)
+			}
+			lr.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+		}
+	}
+	return true, nil
+}
+
+// splitQuoted splits a line on spaces, the values holding spaces being
+// between double quotes.
+func splitQuoted(line string) ([]string, error) {
+	var values []string
+	for i := 0; i < len(line); {
+		switch {
+		case line[i] == ' ':
+			i++
+		case line[i] == '"':
+			var value strings.Builder
+			j := i + 1
+			for ; j < len(line) && line[j] != '"'; j++ {
+				if line[j] == '\\' && j+1 < len(line) {
+					j++
+				}
+				value.WriteByte(line[j])
+			}
+			if j == len(line) {
+				return nil, errUnexpectedQuote
+			}
+			values = append(values, value.String())
+			i = j + 1
+		default:
+			j := strings.IndexByte(line[i:], ' ')
+			if j < 0 {
+				j = len(line) - i
+			}
+			value := line[i : i+j]
+			if strings.Contains(value, `"`) {
+				return nil, errUnexpectedQuote
+			}
+			values = append(values, value)
+			i += j
+		}
+	}
+	return values, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,173 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// decodeFile decodes the lines of a test file, returning the log records.
+func decodeFile(t *testing.T, format string, name string) plog.LogRecordSlice {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	scanner, err := newLineScanner(bytes.NewReader(data))
+	require.NoError(t, err)
+	dec := newDecoder(format)
+	records := plog.NewLogRecordSlice()
+	for scanner.Scan() {
+		lr := plog.NewLogRecord()
+		ok, err := dec.decode(scanner.Bytes(), lr)
+		require.NoError(t, err)
+		if ok {
+			lr.MoveTo(records.AppendEmpty())
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func TestDecodeJSONLines(t *testing.T) {
+	records := decodeFile(t, formatJSONLines, "json_lines.json")
+	require.Equal(t, 2, records.Len())
+
+	body := records.At(0).Body().Map()
+	level, _ := body.Get("level")
+	assert.Equal(t, "info", level.Str())
+	status, _ := body.Get("status")
+	assert.Equal(t, float64(200), status.Double())
+	tags, _ := body.Get("tags")
+	assert.Equal(t, 2, tags.Slice().Len())
+
+	assert.Equal(t, "plain string", records.At(1).Body().Str())
+}
+
+func TestDecodeGzipJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(`{"message":"compressed"}` + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	scanner, err := newLineScanner(&buf)
+	require.NoError(t, err)
+	require.True(t, scanner.Scan())
+	assert.Equal(t, `{"message":"compressed"}`, scanner.Text())
+	assert.False(t, scanner.Scan())
+}
+
+func TestDecodeEmptyObject(t *testing.T) {
+	scanner, err := newLineScanner(bytes.NewReader(nil))
+	require.NoError(t, err)
+	assert.False(t, scanner.Scan())
+	assert.NoError(t, scanner.Err())
+}
+
+func TestDecodeVPCFlowLog(t *testing.T) {
+	records := decodeFile(t, formatVPCFlowLog, "vpc_flow_log.log")
+	require.Equal(t, 2, records.Len())
+
+	lr := records.At(0)
+	assert.Equal(t, "2 123456789010 eni-1235b8ca123456789 172.31.16.139 172.31.16.21 20641 22 6 20 4249 1418530010 1418530070 ACCEPT OK", lr.Body().Str())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(1418530010, 0)), lr.Timestamp())
+	assert.Equal(t, map[string]interface{}{
+		"aws.vpc.flow.version":      int64(2),
+		"aws.vpc.flow.account_id":   "123456789010",
+		"aws.vpc.flow.interface_id": "eni-1235b8ca123456789",
+		"aws.vpc.flow.srcaddr":      "172.31.16.139",
+		"aws.vpc.flow.dstaddr":      "172.31.16.21",
+		"aws.vpc.flow.srcport":      int64(20641),
+		"aws.vpc.flow.dstport":      int64(22),
+		"aws.vpc.flow.protocol":     int64(6),
+		"aws.vpc.flow.packets":      int64(20),
+		"aws.vpc.flow.bytes":        int64(4249),
+		"aws.vpc.flow.start":        int64(1418530010),
+		"aws.vpc.flow.end":          int64(1418530070),
+		"aws.vpc.flow.action":       "ACCEPT",
+		"aws.vpc.flow.log_status":   "OK",
+	}, lr.Attributes().AsRaw())
+
+	// the fields without value are not set
+	nodata := records.At(1).Attributes()
+	assert.Equal(t, 6, nodata.Len())
+	status, _ := nodata.Get("aws.vpc.flow.log_status")
+	assert.Equal(t, "NODATA", status.Str())
+}
+
+func TestDecodeVPCFlowLogErrors(t *testing.T) {
+	dec := newDecoder(formatVPCFlowLog)
+	_, err := dec.decode([]byte("2 123456789010 eni-1235b8ca123456789"), plog.NewLogRecord())
+	assert.ErrorIs(t, err, errMissingHeader)
+
+	ok, err := dec.decode([]byte("version account-id srcport"), plog.NewLogRecord())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = dec.decode([]byte("2 123456789010"), plog.NewLogRecord())
+	assert.EqualError(t, err, "expected 3 fields, got 2")
+	_, err = dec.decode([]byte("2 123456789010 http"), plog.NewLogRecord())
+	assert.ErrorContains(t, err, "invalid srcport")
+}
+
+func TestDecodeALBAccessLog(t *testing.T) {
+	records := decodeFile(t, formatALBAccessLog, "alb_access_log.log")
+	require.Equal(t, 2, records.Len())
+
+	lr := records.At(0)
+	ts, err := time.Parse(time.RFC3339Nano, "2018-07-02T22:23:00.186641Z")
+	require.NoError(t, err)
+	assert.Equal(t, pcommon.NewTimestampFromTime(ts), lr.Timestamp())
+
+	attrs := lr.Attributes().AsRaw()
+	assert.Equal(t, "http", attrs["aws.alb.type"])
+	assert.Equal(t, "app/my-loadbalancer/50dc6c495c0c9188", attrs["aws.alb.elb"])
+	assert.Equal(t, "192.168.131.39:2817", attrs["aws.alb.client_port"])
+	assert.Equal(t, 0.001, attrs["aws.alb.target_processing_time"])
+	assert.Equal(t, int64(200), attrs["aws.alb.elb_status_code"])
+	assert.Equal(t, int64(34), attrs["aws.alb.received_bytes"])
+	assert.Equal(t, "GET http://www.example.com:80/ HTTP/1.1", attrs["aws.alb.request"])
+	assert.Equal(t, "curl/7.46.0", attrs["aws.alb.user_agent"])
+	assert.Equal(t, "Root=1-58337262-36d228ad5d99923122bbe354", attrs["aws.alb.trace_id"])
+	assert.Equal(t, "forward", attrs["aws.alb.actions_executed"])
+	assert.NotContains(t, attrs, "aws.alb.ssl_cipher")
+	assert.NotContains(t, attrs, "aws.alb.redirect_url")
+
+	// the requests refused by the load balancer have no target
+	refused := records.At(1).Attributes().AsRaw()
+	assert.Equal(t, int64(460), refused["aws.alb.elb_status_code"])
+	assert.NotContains(t, refused, "aws.alb.target_status_code")
+	assert.Equal(t, float64(-1), refused["aws.alb.target_processing_time"])
+}
+
+func TestSplitQuoted(t *testing.T) {
+	values, err := splitQuoted(`h2 "GET / HTTP/1.1" "say \"hi\"" "" -`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"h2", "GET / HTTP/1.1", `say "hi"`, "", "-"}, values)
+
+	_, err = splitQuoted(`h2 "GET / HTTP/1.1`)
+	assert.ErrorIs(t, err, errUnexpectedQuote)
+	_, err = splitQuoted(`h2 GET"`)
+	assert.ErrorIs(t, err, errUnexpectedQuote)
+}
@@ -0,0 +1,19 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package objectstorereceiver implements a receiver reading the logs exported
+// to S3 buckets or Azure Blob Storage containers. The objects are read when
+// their creation is notified through an SQS queue or a storage queue, and
+// their position is checkpointed while their lines are converted to log records.
+package objectstorereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/objectstorereceiver"
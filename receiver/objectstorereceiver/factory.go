@@ -0,0 +1,64 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/objectstorereceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// Value of "type" key in configuration.
+	typeStr = "objectstore"
+	// The stability level of the receiver.
+	stability = component.StabilityLevelInDevelopment
+
+	defaultMaxMessages       = 10
+	defaultPollInterval      = 20 * time.Second
+	defaultVisibilityTimeout = 5 * time.Minute
+	defaultBatchSize         = 1000
+)
+
+// NewFactory creates a factory for the object store receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithLogsReceiver(createLogsReceiver, stability))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings:  config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		Format:            formatJSONLines,
+		MaxMessages:       defaultMaxMessages,
+		PollInterval:      defaultPollInterval,
+		VisibilityTimeout: defaultVisibilityTimeout,
+		BatchSize:         defaultBatchSize,
+	}
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	nextConsumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	return newLogsReceiver(cfg.(*Config), set, nextConsumer), nil
+}
@@ -0,0 +1,223 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/objectstorereceiver"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const (
+	obsreportTransportSQS          = "sqs"
+	obsreportTransportStorageQueue = "storage_queue"
+)
+
+type logsReceiver struct {
+	config   *Config
+	settings component.ReceiverCreateSettings
+	consumer consumer.Logs
+	obsrecv  *obsreport.Receiver
+
+	source      source
+	checkpoints checkpoints
+
+	cancel     context.CancelFunc
+	shutdownWG sync.WaitGroup
+}
+
+func newLogsReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Logs) *logsReceiver {
+	transport := obsreportTransportSQS
+	if cfg.AzureBlob != nil {
+		transport = obsreportTransportStorageQueue
+	}
+	return &logsReceiver{
+		config:   cfg,
+		settings: set,
+		consumer: nextConsumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              transport,
+			ReceiverCreateSettings: set,
+		}),
+	}
+}
+
+// Start reads the notifications of the queue until the receiver is shut down.
+func (r *logsReceiver) Start(ctx context.Context, host component.Host) error {
+	// the source is only set beforehand by the tests
+	if r.source == nil {
+		var err error
+		if r.config.S3 != nil {
+			r.source, err = newS3Source(r.config, r.settings.Logger)
+		} else {
+			r.source, err = newAzureBlobSource(r.config, r.settings.Logger)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := getStorageClient(ctx, host, r.config.StorageID, r.config.ID())
+	if err != nil {
+		return err
+	}
+	r.checkpoints = checkpoints{client: client}
+
+	var runCtx context.Context
+	runCtx, r.cancel = context.WithCancel(context.Background())
+	r.shutdownWG.Add(1)
+	go r.run(runCtx)
+	return nil
+}
+
+// Shutdown stops reading the notifications.
+func (r *logsReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	r.shutdownWG.Wait()
+	return r.checkpoints.client.Close(ctx)
+}
+
+func (r *logsReceiver) run(ctx context.Context) {
+	defer r.shutdownWG.Done()
+
+	for ctx.Err() == nil {
+		notifications, err := r.source.receive(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.settings.Logger.Error("Failed to receive the notifications", zap.Error(err))
+				wait(ctx, r.config.PollInterval)
+			}
+			continue
+		}
+
+		for _, n := range notifications {
+			if err = r.process(ctx, n); err != nil && ctx.Err() == nil {
+				r.settings.Logger.Error("Failed to read the objects of a notification, they are read again once it is visible in the queue again", zap.Error(err))
+			}
+		}
+	}
+}
+
+// process reads the objects of a notification, then deletes it from the queue.
+func (r *logsReceiver) process(ctx context.Context, n notification) error {
+	for _, obj := range n.objects {
+		if err := r.read(ctx, obj); err != nil {
+			return fmt.Errorf("failed to read %s: %w", obj.id(), err)
+		}
+	}
+	if err := n.ack(ctx); err != nil {
+		return fmt.Errorf("failed to delete the notification: %w", err)
+	}
+
+	var errs error
+	for _, obj := range n.objects {
+		errs = multierr.Append(errs, r.checkpoints.remove(ctx, obj))
+	}
+	return errs
+}
+
+// read sends the log records of an object to the pipeline, in batches,
+// skipping the lines sent before the checkpoint of the object.
+func (r *logsReceiver) read(ctx context.Context, obj object) error {
+	cp, err := r.checkpoints.load(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	content, err := r.source.open(ctx, obj)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	scanner, err := newLineScanner(content)
+	if err != nil {
+		return err
+	}
+
+	dec := newDecoder(r.config.Format)
+	ld, records := r.newLogs(obj)
+	var lines int64
+	for scanner.Scan() {
+		lines++
+		lr := plog.NewLogRecord()
+		ok, err := dec.decode(scanner.Bytes(), lr)
+		if err != nil {
+			r.settings.Logger.Warn("Skipping an invalid line", zap.String("object", obj.id()), zap.Int64("line", lines), zap.Error(err))
+			continue
+		}
+		// the lines before the checkpoint are still decoded, for the headers
+		if !ok || lines <= cp.Lines {
+			continue
+		}
+
+		lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+		lr.MoveTo(records.AppendEmpty())
+		if records.Len() >= r.config.BatchSize {
+			if err = r.flush(ctx, obj, ld, lines); err != nil {
+				return err
+			}
+			ld, records = r.newLogs(obj)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+	return r.flush(ctx, obj, ld, lines)
+}
+
+// newLogs returns the logs of an object, and the slice its records are added to.
+func (r *logsReceiver) newLogs(obj object) (plog.Logs, plog.LogRecordSlice) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	r.source.resource(obj, rl.Resource().Attributes())
+	return ld, rl.ScopeLogs().AppendEmpty().LogRecords()
+}
+
+// flush sends the log records to the pipeline, then checkpoints the lines read.
+func (r *logsReceiver) flush(ctx context.Context, obj object, ld plog.Logs, lines int64) error {
+	if count := ld.LogRecordCount(); count > 0 {
+		obsCtx := r.obsrecv.StartLogsOp(ctx)
+		err := r.consumer.ConsumeLogs(obsCtx, ld)
+		r.obsrecv.EndLogsOp(obsCtx, r.config.Format, count, err)
+		if err != nil {
+			return err
+		}
+	}
+	return r.checkpoints.save(ctx, obj, lines)
+}
+
+// wait waits for the given duration, or until the context is done.
+func wait(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+// fakeSource serves the objects of its notifications from memory.
+type fakeSource struct {
+	mu            sync.Mutex
+	notifications []notification
+	contents      map[string]string
+	acked         int
+}
+
+func (s *fakeSource) notify(objects ...object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifications = append(s.notifications, notification{
+		objects: objects,
+		ack: func(context.Context) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.acked++
+			return nil
+		},
+	})
+}
+
+func (s *fakeSource) receive(ctx context.Context) ([]notification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.notifications) == 0 {
+		wait(ctx, 10*time.Millisecond)
+		return nil, nil
+	}
+	notifications := s.notifications
+	s.notifications = nil
+	return notifications, nil
+}
+
+func (s *fakeSource) open(_ context.Context, obj object) (io.ReadCloser, error) {
+	content, ok := s.contents[obj.key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func (s *fakeSource) resource(obj object, attrs pcommon.Map) {
+	attrs.PutStr("key", obj.key)
+}
+
+func (s *fakeSource) ackCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.acked
+}
+
+func newTestReceiver(nextConsumer consumer.Logs, src source) *logsReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.S3 = &S3Config{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/logs"}
+	cfg.BatchSize = 2
+	r := newLogsReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), nextConsumer)
+	r.source = src
+	r.checkpoints = checkpoints{client: storagetest.NewInMemoryClient(component.KindReceiver, cfg.ID(), "")}
+	return r
+}
+
+func TestReceiverReadsNotifiedObjects(t *testing.T) {
+	src := &fakeSource{contents: map[string]string{
+		"a.json": `{"message":"1"}` + "\n" + `{"message":"2"}` + "\n" + `{"message":"3"}`,
+		"b.json": `{"message":"4"}`,
+	}}
+	sink := &consumertest.LogsSink{}
+	r := newTestReceiver(sink, src)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	src.notify(object{bucket: "bucket", key: "a.json"}, object{bucket: "bucket", key: "b.json"})
+	require.Eventually(t, func() bool { return src.ackCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// the records are sent in batches of 2
+	logs := sink.AllLogs()
+	require.Len(t, logs, 3)
+	assert.Equal(t, 4, sink.LogRecordCount())
+
+	key, _ := logs[2].ResourceLogs().At(0).Resource().Attributes().Get("key")
+	assert.Equal(t, "b.json", key.Str())
+	body := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Body()
+	message, _ := body.Map().Get("message")
+	assert.Equal(t, "2", message.Str())
+}
+
+func TestReceiverResumesFromCheckpoint(t *testing.T) {
+	content := `{"message":"1"}` + "\n" + `{"message":"2"}` + "\n" + `{"message":"3"}`
+	src := &fakeSource{contents: map[string]string{"a.json": content}}
+	obj := object{bucket: "bucket", key: "a.json", etag: "v1"}
+
+	// the pipeline refuses the second batch
+	var calls int
+	consumeErr := errors.New("pipeline error")
+	sink := &consumertest.LogsSink{}
+	failing, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if calls++; calls == 2 {
+			return consumeErr
+		}
+		return sink.ConsumeLogs(ctx, ld)
+	})
+	require.NoError(t, err)
+
+	r := newTestReceiver(failing, src)
+	acked := false
+	n := notification{objects: []object{obj}, ack: func(context.Context) error {
+		acked = true
+		return nil
+	}}
+	require.ErrorIs(t, r.process(context.Background(), n), consumeErr)
+	assert.False(t, acked, "the notification must be left in the queue")
+	assert.Equal(t, 2, sink.LogRecordCount())
+
+	cp, err := r.checkpoints.load(context.Background(), obj)
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint{ETag: "v1", Lines: 2}, cp)
+
+	// the notification read again only sends the remaining lines
+	require.NoError(t, r.process(context.Background(), n))
+	assert.True(t, acked)
+	assert.Equal(t, 3, sink.LogRecordCount())
+
+	// the checkpoint is removed once the notification is deleted
+	cp, err = r.checkpoints.load(context.Background(), obj)
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint{}, cp)
+}
+
+func TestReceiverIgnoresCheckpointOfOverwrittenObject(t *testing.T) {
+	src := &fakeSource{contents: map[string]string{"a.json": `{"message":"1"}`}}
+	sink := &consumertest.LogsSink{}
+	r := newTestReceiver(sink, src)
+
+	obj := object{bucket: "bucket", key: "a.json", etag: "v2"}
+	require.NoError(t, r.checkpoints.save(context.Background(), object{bucket: "bucket", key: "a.json", etag: "v1"}, 1))
+	require.NoError(t, r.read(context.Background(), obj))
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestReceiverSkipsInvalidLines(t *testing.T) {
+	src := &fakeSource{contents: map[string]string{"a.json": "{\"message\":\"1\"}\nnot json\n\n{\"message\":\"2\"}"}}
+	sink := &consumertest.LogsSink{}
+	r := newTestReceiver(sink, src)
+
+	require.NoError(t, r.read(context.Background(), object{bucket: "bucket", key: "a.json"}))
+	assert.Equal(t, 2, sink.LogRecordCount())
+}
+
+func TestReceiverOpenError(t *testing.T) {
+	src := &fakeSource{contents: map[string]string{}}
+	r := newTestReceiver(consumertest.NewNop(), src)
+
+	n := notification{objects: []object{{bucket: "bucket", key: "missing.json"}}, ack: func(context.Context) error {
+		t.Fatal("the notification must be left in the queue")
+		return nil
+	}}
+	require.ErrorContains(t, r.process(context.Background(), n), "not found")
+}
+
+func TestStartWithMissingStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	r := newLogsReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+	r.source = &fakeSource{}
+	require.ErrorContains(t, r.Start(context.Background(), storagetest.NewStorageHost()), "not found")
+}
@@ -0,0 +1,214 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/objectstorereceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+)
+
+const (
+	attributeS3Bucket = "aws.s3.bucket"
+	attributeS3Key    = "aws.s3.key"
+
+	// maxSQSWaitTime is the longest time SQS waits for messages.
+	maxSQSWaitTime = 20 * time.Second
+)
+
+// s3Event is an S3 event notification, sent either directly to the queue or
+// wrapped in the message of an SNS notification. See
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/notification-content-structure.html
+type s3Event struct {
+	Records []s3EventRecord `json:"Records"`
+
+	Type    string `json:"Type"`
+	Message string `json:"Message"`
+}
+
+type s3EventRecord struct {
+	EventName string `json:"eventName"`
+	AWSRegion string `json:"awsRegion"`
+	S3        struct {
+		Bucket struct {
+			Name string `json:"name"`
+		} `json:"bucket"`
+		Object struct {
+			Key  string `json:"key"`
+			ETag string `json:"eTag"`
+		} `json:"object"`
+	} `json:"s3"`
+}
+
+// parseS3Event returns the objects created according to an S3 event
+// notification. The test events sent when the notifications are configured
+// hold no object.
+func parseS3Event(body string) ([]object, error) {
+	var event s3Event
+	if err := json.Unmarshal([]byte(body), &event); err != nil {
+		return nil, err
+	}
+	if event.Type == "Notification" {
+		return parseS3Event(event.Message)
+	}
+
+	var objects []object
+	for _, record := range event.Records {
+		if !strings.HasPrefix(record.EventName, "ObjectCreated:") {
+			continue
+		}
+		// the keys are URL encoded, with spaces replaced by '+'
+		key, err := url.QueryUnescape(record.S3.Object.Key)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, object{
+			account: record.AWSRegion,
+			bucket:  record.S3.Bucket.Name,
+			key:     key,
+			etag:    record.S3.Object.ETag,
+		})
+	}
+	return objects, nil
+}
+
+// regionFromQueueURL returns the region of a queue URL of the form
+// https://sqs.<region>.amazonaws.com/<account>/<queue>.
+func regionFromQueueURL(queueURL string) string {
+	u, err := url.Parse(queueURL)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(u.Hostname(), ".")
+	if len(parts) < 3 || parts[0] != "sqs" {
+		return ""
+	}
+	return parts[1]
+}
+
+// s3Source reads the objects of S3 buckets, as notified through an SQS queue.
+type s3Source struct {
+	logger            *zap.Logger
+	queueURL          string
+	maxMessages       int64
+	waitTime          int64
+	visibilityTimeout int64
+
+	sqs sqsiface.SQSAPI
+	s3  s3iface.S3API
+}
+
+func newS3Source(cfg *Config, logger *zap.Logger) (*s3Source, error) {
+	region := cfg.S3.Region
+	if region == "" {
+		region = regionFromQueueURL(cfg.S3.QueueURL)
+	}
+	awsConfig := aws.NewConfig()
+	if region != "" {
+		awsConfig = awsConfig.WithRegion(region)
+	}
+	if cfg.S3.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(cfg.S3.Endpoint).WithS3ForcePathStyle(true)
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.S3.RoleARN != "" {
+		awsConfig = awsConfig.Copy().WithCredentials(stscreds.NewCredentials(sess, cfg.S3.RoleARN))
+	}
+
+	waitTime := cfg.PollInterval
+	if waitTime > maxSQSWaitTime {
+		waitTime = maxSQSWaitTime
+	}
+	return &s3Source{
+		logger:            logger,
+		queueURL:          cfg.S3.QueueURL,
+		maxMessages:       int64(cfg.MaxMessages),
+		waitTime:          int64(waitTime.Seconds()),
+		visibilityTimeout: int64(cfg.VisibilityTimeout.Seconds()),
+		sqs:               sqs.New(sess, awsConfig),
+		s3:                s3.New(sess, awsConfig),
+	}, nil
+}
+
+func (s *s3Source) receive(ctx context.Context) ([]notification, error) {
+	out, err := s.sqs.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(s.queueURL),
+		MaxNumberOfMessages: aws.Int64(s.maxMessages),
+		WaitTimeSeconds:     aws.Int64(s.waitTime),
+		VisibilityTimeout:   aws.Int64(s.visibilityTimeout),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make([]notification, 0, len(out.Messages))
+	for _, msg := range out.Messages {
+		objects, err := parseS3Event(aws.StringValue(msg.Body))
+		if err != nil {
+			// the message is left in the queue, to be moved to its dead-letter queue
+			s.logger.Warn("Skipping an invalid S3 event notification", zap.String("message_id", aws.StringValue(msg.MessageId)), zap.Error(err))
+			continue
+		}
+		receiptHandle := msg.ReceiptHandle
+		notifications = append(notifications, notification{
+			objects: objects,
+			ack: func(ctx context.Context) error {
+				_, err := s.sqs.DeleteMessageWithContext(ctx, &sqs.DeleteMessageInput{
+					QueueUrl:      aws.String(s.queueURL),
+					ReceiptHandle: receiptHandle,
+				})
+				return err
+			},
+		})
+	}
+	return notifications, nil
+}
+
+func (s *s3Source) open(ctx context.Context, obj object) (io.ReadCloser, error) {
+	out, err := s.s3.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(obj.bucket),
+		Key:    aws.String(obj.key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+func (s *s3Source) resource(obj object, attrs pcommon.Map) {
+	attrs.PutStr(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	if obj.account != "" {
+		attrs.PutStr(conventions.AttributeCloudRegion, obj.account)
+	}
+	attrs.PutStr(attributeS3Bucket, obj.bucket)
+	attrs.PutStr(attributeS3Key, obj.key)
+}
@@ -0,0 +1,153 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+const testS3Event = `{
+  "Records": [
+    {
+      "eventName": "ObjectCreated:Put",
+      "awsRegion": "us-east-1",
+      "s3": {
+        "bucket": {"name": "logs-bucket"},
+        "object": {"key": "AWSLogs/elasticloadbalancing/my+logs%3A1.log.gz", "eTag": "0123456789abcdef"}
+      }
+    },
+    {
+      "eventName": "ObjectRemoved:Delete",
+      "awsRegion": "us-east-1",
+      "s3": {
+        "bucket": {"name": "logs-bucket"},
+        "object": {"key": "removed.log.gz"}
+      }
+    }
+  ]
+}`
+
+type fakeSQS struct {
+	sqsiface.SQSAPI
+	input    *sqs.ReceiveMessageInput
+	messages []*sqs.Message
+	deleted  []string
+}
+
+func (f *fakeSQS) ReceiveMessageWithContext(_ aws.Context, input *sqs.ReceiveMessageInput, _ ...request.Option) (*sqs.ReceiveMessageOutput, error) {
+	f.input = input
+	return &sqs.ReceiveMessageOutput{Messages: f.messages}, nil
+}
+
+func (f *fakeSQS) DeleteMessageWithContext(_ aws.Context, input *sqs.DeleteMessageInput, _ ...request.Option) (*sqs.DeleteMessageOutput, error) {
+	f.deleted = append(f.deleted, *input.ReceiptHandle)
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+type fakeS3 struct {
+	s3iface.S3API
+	input *s3.GetObjectInput
+}
+
+func (f *fakeS3) GetObjectWithContext(_ aws.Context, input *s3.GetObjectInput, _ ...request.Option) (*s3.GetObjectOutput, error) {
+	f.input = input
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader("content"))}, nil
+}
+
+func TestParseS3Event(t *testing.T) {
+	expected := []object{{account: "us-east-1", bucket: "logs-bucket", key: "AWSLogs/elasticloadbalancing/my logs:1.log.gz", etag: "0123456789abcdef"}}
+
+	objects, err := parseS3Event(testS3Event)
+	require.NoError(t, err)
+	assert.Equal(t, expected, objects)
+
+	// the event published to an SNS topic is in the message of the notification
+	sns, err := json.Marshal(map[string]string{"Type": "Notification", "Message": testS3Event})
+	require.NoError(t, err)
+	objects, err = parseS3Event(string(sns))
+	require.NoError(t, err)
+	assert.Equal(t, expected, objects)
+
+	objects, err = parseS3Event(`{"Service":"Amazon S3","Event":"s3:TestEvent","Bucket":"logs-bucket"}`)
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+
+	_, err = parseS3Event("not json")
+	assert.Error(t, err)
+}
+
+func TestRegionFromQueueURL(t *testing.T) {
+	assert.Equal(t, "eu-west-3", regionFromQueueURL("https://sqs.eu-west-3.amazonaws.com/123456789012/logs"))
+	assert.Equal(t, "", regionFromQueueURL("http://localhost:4566/000000000000/logs"))
+}
+
+func TestS3Source(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.S3 = &S3Config{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/logs"}
+	src, err := newS3Source(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	fakeQueue := &fakeSQS{messages: []*sqs.Message{
+		{MessageId: aws.String("1"), ReceiptHandle: aws.String("receipt-1"), Body: aws.String(testS3Event)},
+		{MessageId: aws.String("2"), ReceiptHandle: aws.String("receipt-2"), Body: aws.String("not json")},
+	}}
+	fakeStore := &fakeS3{}
+	src.sqs = fakeQueue
+	src.s3 = fakeStore
+
+	notifications, err := src.receive(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), *fakeQueue.input.MaxNumberOfMessages)
+	assert.Equal(t, int64(20), *fakeQueue.input.WaitTimeSeconds)
+	assert.Equal(t, int64(300), *fakeQueue.input.VisibilityTimeout)
+
+	// the invalid message is left in the queue
+	require.Len(t, notifications, 1)
+	require.Len(t, notifications[0].objects, 1)
+	require.NoError(t, notifications[0].ack(context.Background()))
+	assert.Equal(t, []string{"receipt-1"}, fakeQueue.deleted)
+
+	obj := notifications[0].objects[0]
+	content, err := src.open(context.Background(), obj)
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+	assert.Equal(t, "AWSLogs/elasticloadbalancing/my logs:1.log.gz", *fakeStore.input.Key)
+
+	attrs := pcommon.NewMap()
+	src.resource(obj, attrs)
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider": "aws",
+		"cloud.region":   "us-east-1",
+		"aws.s3.bucket":  "logs-bucket",
+		"aws.s3.key":     "AWSLogs/elasticloadbalancing/my logs:1.log.gz",
+	}, attrs.AsRaw())
+}
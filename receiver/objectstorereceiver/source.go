@@ -0,0 +1,54 @@
+// Copyright  OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstorereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/objectstorereceiver"
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// object is an object created in a bucket, or a blob created in a container.
+type object struct {
+	// account is the storage account of a blob, or the region of an S3 object.
+	account string
+	bucket  string
+	key     string
+	etag    string
+}
+
+// id identifies the object in the checkpoints.
+func (o object) id() string {
+	return o.account + "/" + o.bucket + "/" + o.key
+}
+
+// notification is a message of the queue notifying the creation of objects.
+type notification struct {
+	objects []object
+	// ack deletes the message from the queue, once its objects were read.
+	ack func(ctx context.Context) error
+}
+
+// source reads the notifications of created objects and their content.
+type source interface {
+	// receive returns the next notifications, or none when no notification
+	// was received within the poll interval.
+	receive(ctx context.Context) ([]notification, error)
+	// open returns the content of an object.
+	open(ctx context.Context, obj object) (io.ReadCloser, error)
+	// resource sets the attributes of the resource of the logs of an object.
+	resource(obj object, attrs pcommon.Map)
+}
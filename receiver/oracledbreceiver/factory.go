@@ -15,11 +15,16 @@
 package oracledbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/oracledbreceiver"
 
 import (
-	"context"
+	"database/sql"
+	"time"
 
+	// register the go-ora driver
+	_ "github.com/sijms/go-ora/v2"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
-	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 )
 
 const (
@@ -27,18 +32,18 @@ const (
 	stability = component.StabilityLevelInDevelopment
 )
 
-// NewFactory creates a new Oracle receiver factory.
 func NewFactory() component.ReceiverFactory {
 	return component.NewReceiverFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithMetricsReceiver(createMetricsReceiver, stability))
-}
-
-func createMetricsReceiver(ctx context.Context, settings component.ReceiverCreateSettings, receiver config.Receiver, metrics consumer.Metrics) (component.MetricsReceiver, error) {
-	return &oracledbreceiver{}, nil
+		component.WithMetricsReceiver(createReceiverFunc(sql.Open, sqlquery.NewDbClient), stability))
 }
 
 func createDefaultConfig() config.Receiver {
-	return &Config{ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr))}
+	return &Config{
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			ReceiverSettings:   config.NewReceiverSettings(config.NewComponentID(typeStr)),
+			CollectionInterval: 10 * time.Second,
+		},
+	}
 }
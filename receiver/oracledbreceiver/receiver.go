@@ -13,19 +13,56 @@
 // limitations under the License.
 
 package oracledbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/oracledbreceiver"
+
 import (
 	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 )
 
-type oracledbreceiver struct {
-}
+const driverName = "oracle"
 
-func (o oracledbreceiver) Start(_ context.Context, host component.Host) error {
-	return nil
-}
+//go:embed queries.yaml
+var builtinQueries []byte
+
+type sqlOpenerFunc func(driverName, dataSourceName string) (*sql.DB, error)
+
+func createReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc sqlquery.ClientProviderFunc) component.CreateMetricsReceiverFunc {
+	return func(
+		ctx context.Context,
+		settings component.ReceiverCreateSettings,
+		cfg config.Receiver,
+		consumer consumer.Metrics,
+	) (component.MetricsReceiver, error) {
+		oracleCfg := cfg.(*Config)
+		queries, err := sqlquery.LoadQueries(builtinQueries)
+		if err != nil {
+			return nil, fmt.Errorf("invalid built-in queries: %w", err)
+		}
+		queries = append(queries, oracleCfg.Queries...)
 
-func (o oracledbreceiver) Shutdown(_ context.Context) error {
-	return nil
+		dbProviderFunc := func() (*sql.DB, error) {
+			return sqlOpenerFunc(driverName, oracleCfg.DataSource)
+		}
+		var opts []scraperhelper.ScraperControllerOption
+		for i, query := range queries {
+			id := config.NewComponentIDWithName("oracledbreceiver", fmt.Sprintf("query-%d: %s", i, query.SQL))
+			mp := sqlquery.NewScraper(id, query, oracleCfg.ScraperControllerSettings, settings.TelemetrySettings.Logger, dbProviderFunc, clientProviderFunc)
+			opts = append(opts, scraperhelper.AddScraper(mp))
+		}
+		return scraperhelper.NewScraperControllerReceiver(
+			&oracleCfg.ScraperControllerSettings,
+			settings,
+			consumer,
+			opts...,
+		)
+	}
 }
@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+)
+
+// readFilesKey is the storage key of the files already read.
+const readFilesKey = "read_files"
+
+const (
+	compressionNone = "none"
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+	compressionAuto = "auto"
+)
+
+// batchReader periodically reads the files matching the include patterns in
+// their entirety. Unlike the fileconsumer, files are expected to be complete
+// when they are found: they are read once, from the beginning, and can be
+// compressed or deleted once read.
+type batchReader struct {
+	finder          fileconsumer.Finder
+	pollInterval    time.Duration
+	compression     string
+	deleteAfterRead bool
+	maxLogSize      int
+	consume         consumeFunc
+	logger          *zap.Logger
+
+	// read holds the files already read, when they are not deleted, and the
+	// files partially read, with the offset reached. It is persisted through
+	// the storage client so that files are not read again after a restart.
+	read   map[string]fileState
+	client storage.Client
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// consumeFunc sends the payload of a line to the next consumer, returning
+// the error of the next consumer so that the line is read again.
+type consumeFunc func(ctx context.Context, token []byte) error
+
+type fileState struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	// Partial is set when the file was read up to Offset only, in its
+	// decompressed content, because the next consumer failed or the
+	// reading was interrupted.
+	Partial bool  `json:"partial,omitempty"`
+	Offset  int64 `json:"offset,omitempty"`
+}
+
+func (s fileState) equal(other fileState) bool {
+	return s.Size == other.Size && s.ModTime.Equal(other.ModTime)
+}
+
+func newBatchReader(cfg *Config, logger *zap.Logger, consume consumeFunc) *batchReader {
+	return &batchReader{
+		finder:          cfg.Finder,
+		pollInterval:    cfg.PollInterval,
+		compression:     cfg.Compression,
+		deleteAfterRead: cfg.DeleteAfterRead,
+		maxLogSize:      int(cfg.MaxLogSize),
+		consume:         consume,
+		logger:          logger,
+		read:            map[string]fileState{},
+		client:          storage.NewNopClient(),
+	}
+}
+
+func (r *batchReader) start(ctx context.Context, client storage.Client) error {
+	r.client = client
+	if err := r.loadReadFiles(ctx); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.pollInterval)
+		defer ticker.Stop()
+		for {
+			r.poll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *batchReader) stop(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return r.client.Close(ctx)
+}
+
+func (r *batchReader) loadReadFiles(ctx context.Context) error {
+	data, err := r.client.Get(ctx, readFilesKey)
+	if err != nil {
+		return fmt.Errorf("failed to load the files already read: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+	read := map[string]fileState{}
+	if err = json.Unmarshal(data, &read); err != nil {
+		r.logger.Warn("Ignoring invalid list of files already read", zap.Error(err))
+		return nil
+	}
+	r.read = read
+	return nil
+}
+
+func (r *batchReader) saveReadFiles(ctx context.Context) {
+	data, err := json.Marshal(r.read)
+	if err != nil {
+		r.logger.Error("Failed to encode the files already read", zap.Error(err))
+		return
+	}
+	if err = r.client.Set(ctx, readFilesKey, data); err != nil {
+		r.logger.Error("Failed to save the files already read", zap.Error(err))
+	}
+}
+
+// poll reads the files that were not read yet, in lexical order so that
+// files named after their capture time are replayed in order.
+func (r *batchReader) poll(ctx context.Context) {
+	paths := r.finder.FindFiles()
+	sort.Strings(paths)
+	changed := false
+	defer func() {
+		if changed {
+			// Saved even when ctx is done, for the files read before.
+			r.saveReadFiles(context.Background())
+		}
+	}()
+	for _, path := range paths {
+		if ctx.Err() != nil {
+			return
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		state := fileState{Size: info.Size(), ModTime: info.ModTime()}
+		var offset int64
+		if previous, ok := r.read[path]; ok && previous.equal(state) {
+			if !previous.Partial {
+				continue
+			}
+			offset = previous.Offset
+		}
+		if offset, err = r.readFile(ctx, path, offset); err != nil || ctx.Err() != nil {
+			// The file is kept, and read again from the offset reached.
+			if err != nil {
+				r.logger.Error("Failed to read file", zap.String("path", path), zap.Error(err))
+			}
+			r.read[path] = fileState{Size: state.Size, ModTime: state.ModTime, Partial: true, Offset: offset}
+			changed = true
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		if !r.deleteAfterRead {
+			r.read[path] = state
+			changed = true
+			continue
+		}
+		if err = os.Remove(path); err != nil {
+			r.logger.Error("Failed to delete file", zap.String("path", path), zap.Error(err))
+			r.read[path] = state
+			changed = true
+		}
+	}
+	for path := range r.read {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			delete(r.read, path)
+			changed = true
+		}
+	}
+}
+
+// readFile sends the lines of the file from the offset in its decompressed
+// content, and returns the offset reached: the end of the file, or the
+// beginning of the line whose consumption failed.
+func (r *batchReader) readFile(ctx context.Context, path string, offset int64) (int64, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return offset, err
+	}
+	defer f.Close()
+
+	reader, err := decompress(f, compressionOf(r.compression, path))
+	if err != nil {
+		return offset, err
+	}
+	defer reader.Close()
+
+	if offset > 0 {
+		if _, err = io.CopyN(io.Discard, reader, offset); err != nil {
+			return offset, fmt.Errorf("failed to skip to offset %d: %w", offset, err)
+		}
+	}
+
+	end := offset
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), r.maxLogSize)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		end += int64(advance)
+		return advance, token, err
+	})
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return offset, nil
+		}
+		line := scanner.Bytes()
+		if len(line) > 0 {
+			token := make([]byte, len(line))
+			copy(token, line)
+			if err = r.consume(ctx, token); err != nil {
+				return offset, err
+			}
+		}
+		offset = end
+	}
+	return offset, scanner.Err()
+}
+
+// compressionOf returns the compression of a file, detecting it from the
+// extension of the file when configured as "auto".
+func compressionOf(compression string, path string) string {
+	if compression != compressionAuto {
+		return compression
+	}
+	switch filepath.Ext(path) {
+	case ".gz", ".gzip":
+		return compressionGzip
+	case ".zst", ".zstd":
+		return compressionZstd
+	default:
+		return compressionNone
+	}
+}
+
+func decompress(reader io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case compressionGzip:
+		return gzip.NewReader(reader)
+	case compressionZstd:
+		decoder, err := zstd.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	case compressionNone, "":
+		return io.NopCloser(reader), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+}
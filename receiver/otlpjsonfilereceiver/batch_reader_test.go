@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpjsonfilereceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+func writeGzipFile(t *testing.T, path string, data []byte) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+}
+
+func writeZstdFile(t *testing.T, path string, data []byte) {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+}
+
+type tokenSink struct {
+	mu     sync.Mutex
+	tokens []string
+	// err is returned for the tokens equal to failing
+	err     error
+	failing string
+}
+
+func (s *tokenSink) consume(_ context.Context, token []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil && string(token) == s.failing {
+		return s.err
+	}
+	s.tokens = append(s.tokens, string(token))
+	return nil
+}
+
+func newTestBatchReader(t *testing.T, dir string, compression string, deleteAfterRead bool, sink *tokenSink) *batchReader {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(dir, "**", "*")}
+	cfg.Compression = compression
+	cfg.DeleteAfterRead = deleteAfterRead
+	return newBatchReader(cfg, zap.NewNop(), sink.consume)
+}
+
+func TestBatchReaderCompression(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte("a1\n\na2\n"), 0600))
+	writeGzipFile(t, filepath.Join(dir, "b.json.gz"), []byte("b1\nb2"))
+	writeZstdFile(t, filepath.Join(dir, "nested", "c.json.zst"), []byte("c1\n"))
+
+	sink := &tokenSink{}
+	r := newTestBatchReader(t, dir, compressionAuto, false, sink)
+	r.poll(context.Background())
+	assert.Equal(t, []string{"a1", "a2", "b1", "b2", "c1"}, sink.tokens)
+
+	// Files already read are not read again, unless they change.
+	r.poll(context.Background())
+	assert.Len(t, sink.tokens, 5)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte("a1\na2\na3\n"), 0600))
+	r.poll(context.Background())
+	assert.Equal(t, []string{"a1", "a2", "b1", "b2", "c1", "a1", "a2", "a3"}, sink.tokens)
+}
+
+func TestBatchReaderDeleteAfterRead(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.json")
+	require.NoError(t, os.WriteFile(path, []byte("a1\n"), 0600))
+
+	sink := &tokenSink{}
+	r := newTestBatchReader(t, dir, compressionNone, true, sink)
+	require.NoError(t, r.start(context.Background(), storage.NewNopClient()))
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return os.IsNotExist(err)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.stop(context.Background()))
+
+	assert.Equal(t, []string{"a1"}, sink.tokens)
+	assert.Empty(t, r.read)
+}
+
+func TestBatchReaderConsumeError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.json.gz")
+	writeGzipFile(t, path, []byte("a1\na2\na3\n"))
+
+	sink := &tokenSink{err: errors.New("consumer failed"), failing: "a2"}
+	r := newTestBatchReader(t, dir, compressionAuto, true, sink)
+	r.poll(context.Background())
+	assert.Equal(t, []string{"a1"}, sink.tokens)
+
+	// The file is kept, and read again from the line which failed.
+	_, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.True(t, r.read[path].Partial)
+	assert.Equal(t, int64(len("a1\n")), r.read[path].Offset)
+	r.poll(context.Background())
+	assert.Equal(t, []string{"a1"}, sink.tokens)
+
+	sink.err = nil
+	r.poll(context.Background())
+	assert.Equal(t, []string{"a1", "a2", "a3"}, sink.tokens)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+	assert.Empty(t, r.read)
+}
+
+func TestBatchReaderReadError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.json.gz")
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte("a1\na2\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	// The gzip stream is corrupted after its first block.
+	require.NoError(t, os.WriteFile(path, append(buf.Bytes(), 0xff, 0xff, 0xff), 0600))
+
+	sink := &tokenSink{}
+	r := newTestBatchReader(t, dir, compressionAuto, false, sink)
+	r.poll(context.Background())
+	assert.Equal(t, []string{"a1", "a2"}, sink.tokens)
+	assert.True(t, r.read[path].Partial)
+
+	// The lines already read are not sent again.
+	r.poll(context.Background())
+	assert.Equal(t, []string{"a1", "a2"}, sink.tokens)
+}
+
+func TestBatchReaderPersistsReadFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte("a1\n"), 0600))
+
+	ctx := context.Background()
+	client := newMapClient()
+	sink := &tokenSink{}
+	r := newTestBatchReader(t, dir, compressionAuto, false, sink)
+	r.client = client
+	r.poll(ctx)
+	assert.Equal(t, []string{"a1"}, sink.tokens)
+
+	// A restarted reader does not read the same files again.
+	r = newTestBatchReader(t, dir, compressionAuto, false, sink)
+	r.client = client
+	require.NoError(t, r.loadReadFiles(ctx))
+	r.poll(ctx)
+	assert.Equal(t, []string{"a1"}, sink.tokens)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.json"), []byte("b1\n"), 0600))
+	r.poll(ctx)
+	assert.Equal(t, []string{"a1", "b1"}, sink.tokens)
+}
+
+// mapClient is an in-memory storage.Client.
+type mapClient struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newMapClient() *mapClient {
+	return &mapClient{data: map[string][]byte{}}
+}
+
+func (c *mapClient) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data[key], nil
+}
+
+func (c *mapClient) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = value
+	return nil
+}
+
+func (c *mapClient) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *mapClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storage.Get:
+			op.Value, err = c.Get(ctx, op.Key)
+		case storage.Set:
+			err = c.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			err = c.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *mapClient) Close(context.Context) error {
+	return nil
+}
+
+func TestCompressionOf(t *testing.T) {
+	assert.Equal(t, compressionGzip, compressionOf(compressionAuto, "traces.json.gz"))
+	assert.Equal(t, compressionZstd, compressionOf(compressionAuto, "traces.json.zst"))
+	assert.Equal(t, compressionNone, compressionOf(compressionAuto, "traces.json"))
+	assert.Equal(t, compressionGzip, compressionOf(compressionGzip, "traces.json"))
+}
@@ -16,6 +16,8 @@ package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -49,30 +51,97 @@ type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 	fileconsumer.Config     `mapstructure:",squash"`
 	StorageID               *config.ComponentID `mapstructure:"storage"`
+	// Compression of the files: "none" (default), "gzip", "zstd", or "auto" to
+	// detect it from the file extension. Compressed files are read in their
+	// entirety once they are found, instead of being followed.
+	Compression string `mapstructure:"compression"`
+	// DeleteAfterRead deletes the files once they are read in their entirety.
+	// Files are then read once they are found, instead of being followed.
+	DeleteAfterRead bool         `mapstructure:"delete_after_read"`
+	Replay          ReplayConfig `mapstructure:"replay"`
+}
+
+func (c *Config) Validate() error {
+	if err := c.ReceiverSettings.Validate(); err != nil {
+		return err
+	}
+	switch c.Compression {
+	case compressionNone, compressionGzip, compressionZstd, compressionAuto:
+	default:
+		return fmt.Errorf("invalid compression %q, must be one of %q, %q, %q or %q", c.Compression, compressionNone, compressionGzip, compressionZstd, compressionAuto)
+	}
+	switch c.Replay.Pacing {
+	case pacingNone, pacingOriginal:
+	default:
+		return fmt.Errorf("invalid replay.pacing %q, must be one of %q or %q", c.Replay.Pacing, pacingNone, pacingOriginal)
+	}
+	if c.Replay.Speed <= 0 {
+		return errors.New("replay.speed must be greater than 0")
+	}
+	return nil
+}
+
+// readsWholeFiles returns whether the files are read by a batchReader rather
+// than followed by the fileconsumer.
+func (c *Config) readsWholeFiles() bool {
+	return c.Compression != compressionNone || c.DeleteAfterRead
 }
 
 func createDefaultConfig() config.Receiver {
 	return &Config{
 		Config:           *fileconsumer.NewConfig(),
 		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		Compression:      compressionNone,
+		Replay: ReplayConfig{
+			Pacing: pacingNone,
+			Speed:  1,
+		},
 	}
 }
 
 type receiver struct {
 	input     *fileconsumer.Manager
+	batch     *batchReader
 	id        config.ComponentID
 	storageID *config.ComponentID
 }
 
+func newReceiver(cfg *Config, settings component.ReceiverCreateSettings, consume consumeFunc) (component.Receiver, error) {
+	r := &receiver{id: cfg.ID(), storageID: cfg.StorageID}
+	// The fileconsumer has no way to read a line again, so the errors of the
+	// next consumer are only reported through obsreport when following files.
+	emit := func(ctx context.Context, _ *fileconsumer.FileAttributes, token []byte) {
+		_ = consume(ctx, token)
+	}
+	// The fileconsumer is built in both modes, so that the configuration is
+	// validated the same way.
+	input, err := cfg.Config.Build(settings.Logger.Sugar(), emit)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.readsWholeFiles() {
+		r.batch = newBatchReader(cfg, settings.Logger, consume)
+		return r, nil
+	}
+	r.input = input
+	return r, nil
+}
+
 func (f *receiver) Start(ctx context.Context, host component.Host) error {
 	storageClient, err := adapter.GetStorageClient(ctx, host, f.storageID, f.id)
 	if err != nil {
 		return err
 	}
+	if f.batch != nil {
+		return f.batch.start(ctx, storageClient)
+	}
 	return f.input.Start(storageClient)
 }
 
 func (f *receiver) Shutdown(ctx context.Context) error {
+	if f.batch != nil {
+		return f.batch.stop(ctx)
+	}
 	return f.input.Stop()
 }
 
@@ -84,21 +153,25 @@ func createLogsReceiver(_ context.Context, settings component.ReceiverCreateSett
 		ReceiverCreateSettings: settings,
 	})
 	cfg := configuration.(*Config)
-	input, err := cfg.Config.Build(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
+	replay := newReplayer(cfg.Replay)
+	return newReceiver(cfg, settings, func(ctx context.Context, token []byte) error {
 		ctx = obsrecv.StartLogsOp(ctx)
 		l, err := logsUnmarshaler.UnmarshalLogs(token)
 		if err != nil {
+			// The line is skipped, reading it again would fail the same way.
 			obsrecv.EndLogsOp(ctx, typeStr, 0, err)
-		} else {
-			err = logs.ConsumeLogs(ctx, l)
-			obsrecv.EndLogsOp(ctx, typeStr, l.LogRecordCount(), err)
+			return nil
 		}
+		if replay != nil {
+			if err = replay.replay(ctx, logsTimestampMapper(l)); err != nil {
+				obsrecv.EndLogsOp(ctx, typeStr, 0, err)
+				return err
+			}
+		}
+		err = logs.ConsumeLogs(ctx, l)
+		obsrecv.EndLogsOp(ctx, typeStr, l.LogRecordCount(), err)
+		return err
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	return &receiver{input: input, id: cfg.ID(), storageID: cfg.StorageID}, nil
 }
 
 func createMetricsReceiver(_ context.Context, settings component.ReceiverCreateSettings, configuration config.Receiver, metrics consumer.Metrics) (component.MetricsReceiver, error) {
@@ -109,21 +182,25 @@ func createMetricsReceiver(_ context.Context, settings component.ReceiverCreateS
 		ReceiverCreateSettings: settings,
 	})
 	cfg := configuration.(*Config)
-	input, err := cfg.Config.Build(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
+	replay := newReplayer(cfg.Replay)
+	return newReceiver(cfg, settings, func(ctx context.Context, token []byte) error {
 		ctx = obsrecv.StartMetricsOp(ctx)
 		m, err := metricsUnmarshaler.UnmarshalMetrics(token)
 		if err != nil {
+			// The line is skipped, reading it again would fail the same way.
 			obsrecv.EndMetricsOp(ctx, typeStr, 0, err)
-		} else {
-			err = metrics.ConsumeMetrics(ctx, m)
-			obsrecv.EndMetricsOp(ctx, typeStr, m.MetricCount(), err)
+			return nil
+		}
+		if replay != nil {
+			if err = replay.replay(ctx, metricsTimestampMapper(m)); err != nil {
+				obsrecv.EndMetricsOp(ctx, typeStr, 0, err)
+				return err
+			}
 		}
+		err = metrics.ConsumeMetrics(ctx, m)
+		obsrecv.EndMetricsOp(ctx, typeStr, m.MetricCount(), err)
+		return err
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	return &receiver{input: input, id: cfg.ID(), storageID: cfg.StorageID}, nil
 }
 
 func createTracesReceiver(ctx context.Context, settings component.ReceiverCreateSettings, configuration config.Receiver, traces consumer.Traces) (component.TracesReceiver, error) {
@@ -134,19 +211,23 @@ func createTracesReceiver(ctx context.Context, settings component.ReceiverCreate
 		ReceiverCreateSettings: settings,
 	})
 	cfg := configuration.(*Config)
-	input, err := cfg.Config.Build(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
+	replay := newReplayer(cfg.Replay)
+	return newReceiver(cfg, settings, func(ctx context.Context, token []byte) error {
 		ctx = obsrecv.StartTracesOp(ctx)
 		t, err := tracesUnmarshaler.UnmarshalTraces(token)
 		if err != nil {
+			// The line is skipped, reading it again would fail the same way.
 			obsrecv.EndTracesOp(ctx, typeStr, 0, err)
-		} else {
-			err = traces.ConsumeTraces(ctx, t)
-			obsrecv.EndTracesOp(ctx, typeStr, t.SpanCount(), err)
+			return nil
 		}
+		if replay != nil {
+			if err = replay.replay(ctx, tracesTimestampMapper(t)); err != nil {
+				obsrecv.EndTracesOp(ctx, typeStr, 0, err)
+				return err
+			}
+		}
+		err = traces.ConsumeTraces(ctx, t)
+		obsrecv.EndTracesOp(ctx, typeStr, t.SpanCount(), err)
+		return err
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	return &receiver{input: input, id: cfg.ID(), storageID: cfg.StorageID}, nil
 }
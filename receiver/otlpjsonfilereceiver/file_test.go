@@ -141,6 +141,11 @@ func testdataConfigYamlAsMap() *Config {
 				Exclude: []string{"/var/log/example.log"},
 			},
 		},
+		Compression: compressionNone,
+		Replay: ReplayConfig{
+			Pacing: pacingNone,
+			Speed:  1,
+		},
 	}
 }
 
@@ -156,3 +161,57 @@ func TestLoadConfig(t *testing.T) {
 
 	assert.Equal(t, testdataConfigYamlAsMap(), cfg)
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Compression = "lzma"
+	assert.EqualError(t, cfg.Validate(), `invalid compression "lzma", must be one of "none", "gzip", "zstd" or "auto"`)
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Replay.Pacing = "fast"
+	assert.EqualError(t, cfg.Validate(), `invalid replay.pacing "fast", must be one of "none" or "original"`)
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Replay.Speed = 0
+	assert.EqualError(t, cfg.Validate(), "replay.speed must be greater than 0")
+}
+
+func TestCreateReceiverInvalidInclude(t *testing.T) {
+	for _, compression := range []string{compressionNone, compressionGzip} {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Config.Include = []string{"[a-"}
+		cfg.Compression = compression
+		_, err := NewFactory().CreateTracesReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+		assert.ErrorContains(t, err, "parse include glob", compression)
+	}
+}
+
+func TestFileTracesReceiverDeleteAfterRead(t *testing.T) {
+	tempFolder := t.TempDir()
+	factory := NewFactory()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Config.Include = []string{filepath.Join(tempFolder, "**", "*.json.gz")}
+	cfg.Compression = compressionAuto
+	cfg.DeleteAfterRead = true
+	sink := new(consumertest.TracesSink)
+	receiver, err := factory.CreateTracesReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), nil))
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	b, err := ptrace.NewJSONMarshaler().MarshalTraces(td)
+	require.NoError(t, err)
+	path := filepath.Join(tempFolder, "2022", "10", "traces.json.gz")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	writeGzipFile(t, path, b)
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return os.IsNotExist(err)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, sink.AllTraces(), 1)
+	assert.EqualValues(t, td, sink.AllTraces()[0])
+	assert.NoError(t, receiver.Shutdown(context.Background()))
+}
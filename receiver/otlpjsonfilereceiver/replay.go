@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	pacingNone     = "none"
+	pacingOriginal = "original"
+)
+
+// ReplayConfig controls how the payloads read from the files are replayed.
+type ReplayConfig struct {
+	// Pacing is either "none" (default) to send payloads as soon as they are
+	// read, or "original" to send them with the same time gaps as their
+	// timestamps, so that captured traffic is replayed at its original pace.
+	Pacing string `mapstructure:"pacing"`
+	// Speed divides the gaps between payloads when pacing is "original",
+	// e.g. 2 replays twice as fast as the original traffic (default 1).
+	Speed float64 `mapstructure:"speed"`
+	// RewriteTimestamps shifts the timestamps of every payload so that its
+	// earliest timestamp is the time it is sent.
+	RewriteTimestamps bool `mapstructure:"rewrite_timestamps"`
+}
+
+// timestampMapper applies a function to every timestamp of a payload.
+type timestampMapper func(f func(pcommon.Timestamp) pcommon.Timestamp)
+
+// replayer paces the payloads according to their timestamps and rewrites
+// their timestamps relative to now.
+type replayer struct {
+	pacing  bool
+	speed   float64
+	rewrite bool
+
+	mu sync.Mutex
+	// firstTimestamp is the earliest timestamp of the first payload, sent at firstSent.
+	firstTimestamp time.Time
+	firstSent      time.Time
+}
+
+func newReplayer(cfg ReplayConfig) *replayer {
+	if cfg.Pacing != pacingOriginal && !cfg.RewriteTimestamps {
+		return nil
+	}
+	speed := cfg.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+	return &replayer{
+		pacing:  cfg.Pacing == pacingOriginal,
+		speed:   speed,
+		rewrite: cfg.RewriteTimestamps,
+	}
+}
+
+// replay waits until the payload must be sent and rewrites its timestamps.
+// It returns the context error if ctx is done while waiting.
+func (r *replayer) replay(ctx context.Context, mapTimestamps timestampMapper) error {
+	var start pcommon.Timestamp
+	mapTimestamps(func(ts pcommon.Timestamp) pcommon.Timestamp {
+		if ts != 0 && (start == 0 || ts < start) {
+			start = ts
+		}
+		return ts
+	})
+	if start == 0 {
+		return nil
+	}
+
+	if r.pacing {
+		if err := r.wait(ctx, start.AsTime()); err != nil {
+			return err
+		}
+	}
+	if r.rewrite {
+		offset := pcommon.NewTimestampFromTime(time.Now()) - start
+		mapTimestamps(func(ts pcommon.Timestamp) pcommon.Timestamp {
+			if ts == 0 {
+				return 0
+			}
+			return ts + offset
+		})
+	}
+	return nil
+}
+
+func (r *replayer) wait(ctx context.Context, start time.Time) error {
+	r.mu.Lock()
+	if r.firstSent.IsZero() {
+		r.firstTimestamp = start
+		r.firstSent = time.Now()
+	}
+	delay := time.Duration(float64(start.Sub(r.firstTimestamp))/r.speed) - time.Since(r.firstSent)
+	r.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func tracesTimestampMapper(td ptrace.Traces) timestampMapper {
+	return func(f func(pcommon.Timestamp) pcommon.Timestamp) {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					span := spans.At(k)
+					span.SetStartTimestamp(f(span.StartTimestamp()))
+					span.SetEndTimestamp(f(span.EndTimestamp()))
+					events := span.Events()
+					for l := 0; l < events.Len(); l++ {
+						event := events.At(l)
+						event.SetTimestamp(f(event.Timestamp()))
+					}
+				}
+			}
+		}
+	}
+}
+
+func logsTimestampMapper(ld plog.Logs) timestampMapper {
+	return func(f func(pcommon.Timestamp) pcommon.Timestamp) {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			sls := rls.At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				records := sls.At(j).LogRecords()
+				for k := 0; k < records.Len(); k++ {
+					record := records.At(k)
+					record.SetTimestamp(f(record.Timestamp()))
+					record.SetObservedTimestamp(f(record.ObservedTimestamp()))
+				}
+			}
+		}
+	}
+}
+
+func metricsTimestampMapper(md pmetric.Metrics) timestampMapper {
+	return func(f func(pcommon.Timestamp) pcommon.Timestamp) {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			sms := rms.At(i).ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				metrics := sms.At(j).Metrics()
+				for k := 0; k < metrics.Len(); k++ {
+					mapMetricTimestamps(metrics.At(k), f)
+				}
+			}
+		}
+	}
+}
+
+func mapMetricTimestamps(metric pmetric.Metric, f func(pcommon.Timestamp) pcommon.Timestamp) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		mapNumberDataPointsTimestamps(metric.Gauge().DataPoints(), f)
+	case pmetric.MetricTypeSum:
+		mapNumberDataPointsTimestamps(metric.Sum().DataPoints(), f)
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(f(dp.StartTimestamp()))
+			dp.SetTimestamp(f(dp.Timestamp()))
+			mapExemplarsTimestamps(dp.Exemplars(), f)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(f(dp.StartTimestamp()))
+			dp.SetTimestamp(f(dp.Timestamp()))
+			mapExemplarsTimestamps(dp.Exemplars(), f)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(f(dp.StartTimestamp()))
+			dp.SetTimestamp(f(dp.Timestamp()))
+		}
+	}
+}
+
+func mapNumberDataPointsTimestamps(dps pmetric.NumberDataPointSlice, f func(pcommon.Timestamp) pcommon.Timestamp) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetStartTimestamp(f(dp.StartTimestamp()))
+		dp.SetTimestamp(f(dp.Timestamp()))
+		mapExemplarsTimestamps(dp.Exemplars(), f)
+	}
+}
+
+func mapExemplarsTimestamps(exemplars pmetric.ExemplarSlice, f func(pcommon.Timestamp) pcommon.Timestamp) {
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
+		exemplar.SetTimestamp(f(exemplar.Timestamp()))
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpjsonfilereceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestNewReplayer(t *testing.T) {
+	assert.Nil(t, newReplayer(ReplayConfig{Pacing: pacingNone, Speed: 1}))
+	assert.NotNil(t, newReplayer(ReplayConfig{Pacing: pacingOriginal, Speed: 1}))
+	assert.NotNil(t, newReplayer(ReplayConfig{Pacing: pacingNone, Speed: 1, RewriteTimestamps: true}))
+}
+
+func testTraces(start time.Time) ptrace.Traces {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Second)))
+	span.Events().AppendEmpty().SetTimestamp(pcommon.NewTimestampFromTime(start.Add(500 * time.Millisecond)))
+	return td
+}
+
+func TestReplayerRewriteTimestamps(t *testing.T) {
+	r := newReplayer(ReplayConfig{Pacing: pacingNone, Speed: 1, RewriteTimestamps: true})
+	td := testTraces(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))
+
+	before := time.Now()
+	require.NoError(t, r.replay(context.Background(), tracesTimestampMapper(td)))
+	span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.False(t, span.StartTimestamp().AsTime().Before(before))
+	assert.Equal(t, time.Second, span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime()))
+	assert.Equal(t, 500*time.Millisecond, span.Events().At(0).Timestamp().AsTime().Sub(span.StartTimestamp().AsTime()))
+}
+
+func TestReplayerPacing(t *testing.T) {
+	r := newReplayer(ReplayConfig{Pacing: pacingOriginal, Speed: 10})
+	start := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	begin := time.Now()
+	require.NoError(t, r.replay(context.Background(), tracesTimestampMapper(testTraces(start))))
+	// Sent 2s after the first payload in the original traffic, i.e. 200ms at speed 10.
+	require.NoError(t, r.replay(context.Background(), tracesTimestampMapper(testTraces(start.Add(2*time.Second)))))
+	assert.GreaterOrEqual(t, time.Since(begin), 200*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, r.replay(ctx, tracesTimestampMapper(testTraces(start.Add(time.Hour)))), context.Canceled)
+}
+
+func TestLogsTimestampMapper(t *testing.T) {
+	ld := plog.NewLogs()
+	record := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.SetTimestamp(10)
+	record.SetObservedTimestamp(20)
+
+	logsTimestampMapper(ld)(func(ts pcommon.Timestamp) pcommon.Timestamp { return ts + 1 })
+	assert.Equal(t, pcommon.Timestamp(11), record.Timestamp())
+	assert.Equal(t, pcommon.Timestamp(21), record.ObservedTimestamp())
+}
+
+func TestMetricsTimestampMapper(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty()
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetTimestamp(10)
+	histogram := metrics.AppendEmpty()
+	dp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(5)
+	dp.SetTimestamp(10)
+	dp.Exemplars().AppendEmpty().SetTimestamp(7)
+
+	metricsTimestampMapper(md)(func(ts pcommon.Timestamp) pcommon.Timestamp { return ts + 1 })
+	assert.Equal(t, pcommon.Timestamp(11), gauge.Gauge().DataPoints().At(0).Timestamp())
+	assert.Equal(t, pcommon.Timestamp(6), dp.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(11), dp.Timestamp())
+	assert.Equal(t, pcommon.Timestamp(8), dp.Exemplars().At(0).Timestamp())
+}
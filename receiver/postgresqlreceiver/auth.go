@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresqlreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/postgresqlreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds/rdsutils"
+	"golang.org/x/oauth2/google"
+)
+
+// cloudSQLLoginScope is the OAuth2 scope of the tokens authenticating to Cloud SQL.
+const cloudSQLLoginScope = "https://www.googleapis.com/auth/sqlservice.login"
+
+// passwordProvider returns the password of a new connection to the database.
+type passwordProvider func(ctx context.Context) (string, error)
+
+// newPasswordProvider returns the configured password, or generates a fresh IAM database
+// authentication token for each connection when IAM authentication is configured.
+func newPasswordProvider(cfg *Config) (passwordProvider, error) {
+	switch cfg.IAMAuth.Provider {
+	case iamProviderAWS:
+		awsConfig := &aws.Config{}
+		if cfg.IAMAuth.Region != "" {
+			awsConfig.Region = aws.String(cfg.IAMAuth.Region)
+		}
+		sess, err := session.NewSession(awsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the AWS session: %w", err)
+		}
+		region := aws.StringValue(sess.Config.Region)
+		if region == "" {
+			return nil, errors.New("failed to determine the region of the RDS instance")
+		}
+		return func(context.Context) (string, error) {
+			return rdsutils.BuildAuthToken(cfg.Endpoint, region, cfg.Username, sess.Config.Credentials)
+		}, nil
+	case iamProviderGCP:
+		tokenSource, err := google.DefaultTokenSource(context.Background(), cloudSQLLoginScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the Google credentials: %w", err)
+		}
+		return func(context.Context) (string, error) {
+			token, err := tokenSource.Token()
+			if err != nil {
+				return "", fmt.Errorf("failed to get the Cloud SQL token: %w", err)
+			}
+			return token.AccessToken, nil
+		}, nil
+	default:
+		return func(context.Context) (string, error) {
+			return cfg.Password, nil
+		}, nil
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresqlreceiver
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordProvider(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Password = "otel"
+
+	password, err := newPasswordProvider(cfg)
+	require.NoError(t, err)
+
+	actual, err := password(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "otel", actual)
+}
+
+func TestPasswordProviderAWS(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "mydb.123456789012.us-east-1.rds.amazonaws.com:5432"
+	cfg.Username = "otel"
+	cfg.IAMAuth = IAMAuthConfig{Provider: iamProviderAWS, Region: "us-east-1"}
+
+	password, err := newPasswordProvider(cfg)
+	require.NoError(t, err)
+
+	token, err := password(context.Background())
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token, "mydb.123456789012.us-east-1.rds.amazonaws.com:5432?"))
+
+	query, err := url.ParseQuery(strings.SplitN(token, "?", 2)[1])
+	require.NoError(t, err)
+	assert.Equal(t, "connect", query.Get("Action"))
+	assert.Equal(t, "otel", query.Get("DBUser"))
+	assert.Contains(t, query.Get("X-Amz-Credential"), "AKIDEXAMPLE/")
+	assert.NotEmpty(t, query.Get("X-Amz-Signature"))
+}
+
+func TestPasswordProviderAWSNoRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.IAMAuth = IAMAuthConfig{Provider: iamProviderAWS}
+
+	_, err := newPasswordProvider(cfg)
+	assert.EqualError(t, err, "failed to determine the region of the RDS instance")
+}
+
+func TestQuoteConnValue(t *testing.T) {
+	assert.Equal(t, `'it\'s a \\ password'`, quoteConnValue(`it's a \ password`))
+}
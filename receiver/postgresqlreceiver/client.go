@@ -57,6 +57,7 @@ type client interface {
 	getMaxConnections(ctx context.Context) (int64, error)
 	getIndexStats(ctx context.Context, database string) (map[indexIdentifer]indexStat, error)
 	listDatabases(ctx context.Context) ([]string, error)
+	getQueryStats(ctx context.Context) ([]queryStats, error)
 }
 
 type postgreSQLClient struct {
@@ -102,6 +103,14 @@ func sslConnectionString(tls configtls.TLSClientSetting) string {
 	return conn
 }
 
+// quoteConnValue quotes a value of a connection string, as described at
+// https://www.postgresql.org/docs/current/libpq-connect.html#LIBPQ-CONNSTRING
+func quoteConnValue(value string) string {
+	return "'" + connValueEscaper.Replace(value) + "'"
+}
+
+var connValueEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
 func newPostgreSQLClient(conf postgreSQLConfig) (*postgreSQLClient, error) {
 	// postgres will assume the supplied user as the database name if none is provided,
 	// so we must specify a databse name even when we are just collecting the list of databases.
@@ -120,7 +129,8 @@ func newPostgreSQLClient(conf postgreSQLConfig) (*postgreSQLClient, error) {
 		host = fmt.Sprintf("/%s", host)
 	}
 
-	connStr := fmt.Sprintf("port=%s host=%s user=%s password=%s %s %s", port, host, conf.username, conf.password, dbField, sslConnectionString(conf.tls))
+	// the password is quoted, as the IAM authentication tokens contain special characters
+	connStr := fmt.Sprintf("port=%s host=%s user=%s password=%s %s %s", port, host, conf.username, quoteConnValue(conf.password), dbField, sslConnectionString(conf.tls))
 
 	conn, err := pq.NewConnector(connStr)
 	if err != nil {
@@ -511,6 +521,43 @@ func (c *postgreSQLClient) getLatestWalAgeSeconds(ctx context.Context) (int64, e
 	return age, nil
 }
 
+// queryStats are the cumulative statistics of a normalized statement.
+type queryStats struct {
+	database      string
+	queryID       string
+	query         string
+	calls         int64
+	totalExecTime float64
+	rows          int64
+	blockIOTime   float64
+}
+
+// getQueryStats returns the statistics tracked by the pg_stat_statements extension.
+// The times are in milliseconds.
+func (c *postgreSQLClient) getQueryStats(ctx context.Context) ([]queryStats, error) {
+	query := `SELECT d.datname, s.queryid::text, s.query, s.calls, s.total_exec_time, s.rows,
+	s.blk_read_time + s.blk_write_time
+	FROM pg_stat_statements s JOIN pg_database d ON d.oid = s.dbid
+	WHERE s.queryid IS NOT NULL;`
+	rows, err := c.client.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var errs error
+	var stats []queryStats
+	for rows.Next() {
+		var s queryStats
+		err = rows.Scan(&s.database, &s.queryID, &s.query, &s.calls, &s.totalExecTime, &s.rows, &s.blockIOTime)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		stats = append(stats, s)
+	}
+	return stats, errs
+}
+
 func (c *postgreSQLClient) listDatabases(ctx context.Context) ([]string, error) {
 	query := `SELECT datname FROM pg_database
 	WHERE datistemplate = false;`
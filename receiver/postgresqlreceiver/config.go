@@ -34,6 +34,17 @@ const (
 	ErrNotSupported        = "invalid config: field '%s' not supported"
 	ErrTransportsSupported = "invalid config: 'transport' must be 'tcp' or 'unix'"
 	ErrHostPort            = "invalid config: 'endpoint' must be in the form <host>:<port> no matter what 'transport' is configured"
+	ErrTopN                = "invalid config: 'query_samples.top_n' must not be negative"
+	ErrIAMProvider         = "invalid config: 'iam_auth.provider' must be 'aws' or 'gcp'"
+	ErrIAMPassword         = "invalid config: 'password' must not be set with 'iam_auth'" // #nosec G101 - not hardcoded credentials
+	ErrIAMTLS              = "invalid config: 'iam_auth' requires 'tls' to be enabled"
+	ErrIAMTransport        = "invalid config: 'iam_auth' with the 'aws' provider requires the 'tcp' transport"
+)
+
+// The providers of IAM database authentication tokens.
+const (
+	iamProviderAWS = "aws"
+	iamProviderGCP = "gcp"
 )
 
 type Config struct {
@@ -44,6 +55,28 @@ type Config struct {
 	confignet.NetAddr                       `mapstructure:",squash"`       // provides Endpoint and Transport
 	configtls.TLSClientSetting              `mapstructure:"tls,omitempty"` // provides SSL details
 	Metrics                                 metadata.MetricsSettings       `mapstructure:"metrics"`
+	QuerySamples                            QuerySamplesConfig             `mapstructure:"query_samples"`
+	IAMAuth                                 IAMAuthConfig                  `mapstructure:"iam_auth"`
+}
+
+// IAMAuthConfig configures the authentication with IAM database authentication
+// tokens instead of a password, for the managed databases prohibiting passwords.
+type IAMAuthConfig struct {
+	// Provider is the cloud provider issuing the tokens: "aws" for Amazon RDS, or
+	// "gcp" for Cloud SQL. The password is used when empty.
+	Provider string `mapstructure:"provider"`
+
+	// Region is the AWS region of the RDS instance, defaulting to the region of the
+	// AWS SDK configuration. Only used with the "aws" provider.
+	Region string `mapstructure:"region"`
+}
+
+// QuerySamplesConfig configures the query samples emitted as log records when
+// the receiver is used in a logs pipeline.
+type QuerySamplesConfig struct {
+	// TopN is the number of statements with the longest execution time emitted
+	// every collection interval.
+	TopN int `mapstructure:"top_n"`
 }
 
 func (cfg *Config) Validate() error {
@@ -51,8 +84,23 @@ func (cfg *Config) Validate() error {
 	if cfg.Username == "" {
 		err = multierr.Append(err, errors.New(ErrNoUsername))
 	}
-	if cfg.Password == "" {
-		err = multierr.Append(err, errors.New(ErrNoPassword))
+	switch cfg.IAMAuth.Provider {
+	case "":
+		if cfg.Password == "" {
+			err = multierr.Append(err, errors.New(ErrNoPassword))
+		}
+	case iamProviderAWS, iamProviderGCP:
+		if cfg.Password != "" {
+			err = multierr.Append(err, errors.New(ErrIAMPassword))
+		}
+		if cfg.Insecure {
+			err = multierr.Append(err, errors.New(ErrIAMTLS))
+		}
+		if cfg.IAMAuth.Provider == iamProviderAWS && cfg.Transport != "tcp" {
+			err = multierr.Append(err, errors.New(ErrIAMTransport))
+		}
+	default:
+		err = multierr.Append(err, errors.New(ErrIAMProvider))
 	}
 
 	// The lib/pq module does not support overriding ServerName or specifying supported TLS versions
@@ -76,5 +124,9 @@ func (cfg *Config) Validate() error {
 		err = multierr.Append(err, errors.New(ErrTransportsSupported))
 	}
 
+	if cfg.QuerySamples.TopN < 0 {
+		err = multierr.Append(err, errors.New(ErrTopN))
+	}
+
 	return err
 }
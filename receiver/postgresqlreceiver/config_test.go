@@ -97,6 +97,42 @@ func TestValidate(t *testing.T) {
 				fmt.Errorf(ErrNotSupported, "MinVersion"),
 			),
 		},
+		{
+			desc: "negative top_n",
+			defaultConfigModifier: func(cfg *Config) {
+				cfg.Username = "otel"
+				cfg.Password = "otel"
+				cfg.QuerySamples.TopN = -1
+			},
+			expected: multierr.Combine(
+				errors.New(ErrTopN),
+			),
+		},
+		{
+			desc: "unknown IAM provider",
+			defaultConfigModifier: func(cfg *Config) {
+				cfg.Username = "otel"
+				cfg.IAMAuth.Provider = "azure"
+			},
+			expected: multierr.Combine(
+				errors.New(ErrIAMProvider),
+			),
+		},
+		{
+			desc: "IAM auth with password, without TLS nor TCP",
+			defaultConfigModifier: func(cfg *Config) {
+				cfg.Username = "otel"
+				cfg.Password = "otel"
+				cfg.Insecure = true
+				cfg.Transport = "unix"
+				cfg.IAMAuth.Provider = "aws"
+			},
+			expected: multierr.Combine(
+				errors.New(ErrIAMPassword),
+				errors.New(ErrIAMTLS),
+				errors.New(ErrIAMTransport),
+			),
+		},
 		{
 			desc: "no error",
 			defaultConfigModifier: func(cfg *Config) {
@@ -105,6 +141,14 @@ func TestValidate(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			desc: "no error with IAM auth",
+			defaultConfigModifier: func(cfg *Config) {
+				cfg.Username = "otel"
+				cfg.IAMAuth.Provider = "gcp"
+			},
+			expected: nil,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -149,6 +193,7 @@ func TestLoadConfig(t *testing.T) {
 		expected.Password = "$POSTGRESQL_PASSWORD"
 		expected.Databases = []string{"otel"}
 		expected.CollectionInterval = 10 * time.Second
+		expected.QuerySamples.TopN = 5
 		expected.TLSClientSetting = configtls.TLSClientSetting{
 			Insecure:           false,
 			InsecureSkipVerify: false,
@@ -29,15 +29,17 @@ import (
 )
 
 const (
-	typeStr   = "postgresql"
-	stability = component.StabilityLevelBeta
+	typeStr       = "postgresql"
+	stability     = component.StabilityLevelBeta
+	logsStability = component.StabilityLevelInDevelopment
 )
 
 func NewFactory() component.ReceiverFactory {
 	return component.NewReceiverFactory(
 		typeStr,
 		createDefaultConfig,
-		component.WithMetricsReceiver(createMetricsReceiver, stability))
+		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, logsStability))
 }
 
 func createDefaultConfig() config.Receiver {
@@ -55,6 +57,9 @@ func createDefaultConfig() config.Receiver {
 			InsecureSkipVerify: true,
 		},
 		Metrics: metadata.DefaultMetricsSettings(),
+		QuerySamples: QuerySamplesConfig{
+			TopN: 10,
+		},
 	}
 }
 
@@ -77,3 +82,13 @@ func createMetricsReceiver(
 		scraperhelper.AddScraper(scraper),
 	)
 }
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	rConf config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	cfg := rConf.(*Config)
+	return newQuerySamplesReceiver(params, cfg, &defaultClientFactory{}, consumer), nil
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresqlreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/postgresqlreceiver"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+const millisecondsInSecond = 1e3
+
+// querySamplesReceiver emits the statements with the longest execution time of
+// every collection interval as query event log records.
+type querySamplesReceiver struct {
+	logger        *zap.Logger
+	config        *Config
+	clientFactory postgreSQLClientFactory
+	consumer      consumer.Logs
+
+	// previous holds the statistics of the previous collection, keyed by
+	// database and query id. It is nil until the first collection.
+	previous map[string]queryStats
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newQuerySamplesReceiver(
+	settings component.ReceiverCreateSettings,
+	config *Config,
+	clientFactory postgreSQLClientFactory,
+	consumer consumer.Logs,
+) *querySamplesReceiver {
+	return &querySamplesReceiver{
+		logger:        settings.Logger,
+		config:        config,
+		clientFactory: clientFactory,
+		consumer:      consumer,
+	}
+}
+
+// Start starts collecting the query samples.
+func (r *querySamplesReceiver) Start(_ context.Context, _ component.Host) error {
+	var ctx context.Context
+	ctx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.config.CollectionInterval)
+		defer ticker.Stop()
+		for {
+			r.collect(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the collection.
+func (r *querySamplesReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *querySamplesReceiver) collect(ctx context.Context) {
+	logs, err := r.sample(ctx, pcommon.NewTimestampFromTime(time.Now()))
+	if err != nil {
+		r.logger.Error("Failed to fetch query stats", zap.Error(err))
+		return
+	}
+	if logs.LogRecordCount() == 0 {
+		return
+	}
+	if err = r.consumer.ConsumeLogs(ctx, logs); err != nil {
+		r.logger.Error("Failed to consume query samples", zap.Error(err))
+	}
+}
+
+// sample returns the top statements by execution time since the previous
+// collection. The first collection only records the statistics.
+func (r *querySamplesReceiver) sample(ctx context.Context, now pcommon.Timestamp) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	dbClient, err := r.clientFactory.getClient(r.config, "")
+	if err != nil {
+		return logs, err
+	}
+	defer dbClient.Close()
+
+	stats, err := dbClient.getQueryStats(ctx)
+	if err != nil {
+		return logs, err
+	}
+
+	current := make(map[string]queryStats, len(stats))
+	var deltas []queryStats
+	for _, s := range stats {
+		key := s.database + "|" + s.queryID
+		current[key] = s
+		if r.previous == nil {
+			continue
+		}
+		// A statement missing from the previous collection, or whose statistics
+		// were reset, is sampled with its whole statistics.
+		if prev, ok := r.previous[key]; ok && s.calls >= prev.calls {
+			s.calls -= prev.calls
+			s.totalExecTime -= prev.totalExecTime
+			s.rows -= prev.rows
+			s.blockIOTime -= prev.blockIOTime
+		}
+		if s.calls > 0 {
+			deltas = append(deltas, s)
+		}
+	}
+	r.previous = current
+
+	sort.SliceStable(deltas, func(i, j int) bool {
+		return deltas[i].totalExecTime > deltas[j].totalExecTime
+	})
+	if len(deltas) > r.config.QuerySamples.TopN {
+		deltas = deltas[:r.config.QuerySamples.TopN]
+	}
+	if len(deltas) == 0 {
+		return logs, nil
+	}
+
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("db.system", "postgresql")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, s := range deltas {
+		lr := lrs.AppendEmpty()
+		lr.SetTimestamp(now)
+		lr.SetObservedTimestamp(now)
+		lr.Body().SetStr(s.query)
+		attrs := lr.Attributes()
+		attrs.PutStr("event.name", "db.query.sample")
+		attrs.PutStr("db.name", s.database)
+		attrs.PutStr("db.query.digest", s.queryID)
+		attrs.PutInt("db.query.calls", s.calls)
+		attrs.PutDouble("db.query.duration", s.totalExecTime/millisecondsInSecond)
+		attrs.PutInt("db.query.rows", s.rows)
+		attrs.PutDouble("db.query.wait_time", s.blockIOTime/millisecondsInSecond)
+	}
+	return logs, nil
+}
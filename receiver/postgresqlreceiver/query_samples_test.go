@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresqlreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestQuerySamples(t *testing.T) {
+	client := new(mockClient)
+	client.On("Close").Return(nil)
+	client.On("getQueryStats").Return([]queryStats{
+		{database: "otel", queryID: "1", query: "SELECT * FROM a", calls: 10, totalExecTime: 10000, rows: 100},
+		{database: "otel", queryID: "2", query: "SELECT * FROM b", calls: 5, totalExecTime: 5000, rows: 5},
+		{database: "otel", queryID: "3", query: "SELECT * FROM c", calls: 1, totalExecTime: 1000, rows: 1},
+	}, nil).Once()
+	client.On("getQueryStats").Return([]queryStats{
+		{database: "otel", queryID: "1", query: "SELECT * FROM a", calls: 11, totalExecTime: 11000, rows: 110},
+		{database: "otel", queryID: "2", query: "SELECT * FROM b", calls: 7, totalExecTime: 9000, rows: 7, blockIOTime: 2000},
+		{database: "otel", queryID: "3", query: "SELECT * FROM c", calls: 1, totalExecTime: 1000, rows: 1},
+		{database: "otel", queryID: "4", query: "SELECT * FROM d", calls: 1, totalExecTime: 3000, rows: 1},
+	}, nil).Once()
+	factory := &mockClientFactory{}
+	factory.On("getClient", "").Return(client, nil)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.QuerySamples.TopN = 2
+	r := newQuerySamplesReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, factory, consumertest.NewNop())
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	logs, err := r.sample(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, logs.LogRecordCount(), "the first collection only records the statistics")
+
+	logs, err = r.sample(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.LogRecordCount())
+
+	rl := logs.ResourceLogs().At(0)
+	dbSystem, _ := rl.Resource().Attributes().Get("db.system")
+	assert.Equal(t, "postgresql", dbSystem.Str())
+
+	lrs := rl.ScopeLogs().At(0).LogRecords()
+	expected := []struct {
+		text     string
+		queryID  string
+		calls    int64
+		duration float64
+		rows     int64
+		wait     float64
+	}{
+		{text: "SELECT * FROM b", queryID: "2", calls: 2, duration: 4, rows: 2, wait: 2},
+		{text: "SELECT * FROM d", queryID: "4", calls: 1, duration: 3, rows: 1, wait: 0},
+	}
+	for i, e := range expected {
+		lr := lrs.At(i)
+		assert.Equal(t, now, lr.Timestamp())
+		assert.Equal(t, e.text, lr.Body().Str())
+		attrs := lr.Attributes()
+		name, _ := attrs.Get("event.name")
+		assert.Equal(t, "db.query.sample", name.Str())
+		db, _ := attrs.Get("db.name")
+		assert.Equal(t, "otel", db.Str())
+		digest, _ := attrs.Get("db.query.digest")
+		assert.Equal(t, e.queryID, digest.Str())
+		calls, _ := attrs.Get("db.query.calls")
+		assert.Equal(t, e.calls, calls.Int())
+		duration, _ := attrs.Get("db.query.duration")
+		assert.Equal(t, e.duration, duration.Double())
+		rows, _ := attrs.Get("db.query.rows")
+		assert.Equal(t, e.rows, rows.Int())
+		wait, _ := attrs.Get("db.query.wait_time")
+		assert.Equal(t, e.wait, wait.Double())
+	}
+	client.AssertExpectations(t)
+}
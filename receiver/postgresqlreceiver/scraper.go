@@ -74,12 +74,28 @@ type postgreSQLClientFactory interface {
 	getClient(c *Config, database string) (client, error)
 }
 
-type defaultClientFactory struct{}
+type defaultClientFactory struct {
+	// the password provider is created once, so that the IAM tokens can be cached
+	passwordOnce sync.Once
+	password     passwordProvider
+	passwordErr  error
+}
 
 func (d *defaultClientFactory) getClient(c *Config, database string) (client, error) {
+	d.passwordOnce.Do(func() {
+		d.password, d.passwordErr = newPasswordProvider(c)
+	})
+	if d.passwordErr != nil {
+		return nil, d.passwordErr
+	}
+	password, err := d.password(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
 	return newPostgreSQLClient(postgreSQLConfig{
 		username: c.Username,
-		password: c.Password,
+		password: password,
 		database: database,
 		tls:      c.TLSClientSetting,
 		address:  c.NetAddr,
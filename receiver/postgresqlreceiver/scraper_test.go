@@ -201,6 +201,11 @@ func (m *mockClient) listDatabases(_ context.Context) ([]string, error) {
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *mockClient) getQueryStats(_ context.Context) ([]queryStats, error) {
+	args := m.Called()
+	return args.Get(0).([]queryStats), args.Error(1)
+}
+
 func (m *mockClientFactory) getClient(c *Config, database string) (client, error) {
 	args := m.Called(database)
 	return args.Get(0).(client), args.Error(1)
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -26,14 +27,18 @@ import (
 	"time"
 
 	commonconfig "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
 	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery/file"
 	promHTTP "github.com/prometheus/prometheus/discovery/http"
 	"github.com/prometheus/prometheus/discovery/kubernetes"
 	"github.com/prometheus/prometheus/discovery/targetgroup"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap"
 	"gopkg.in/yaml.v2"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver/internal"
 )
 
 const (
@@ -60,8 +65,37 @@ type Config struct {
 	UseStartTimeMetric   bool   `mapstructure:"use_start_time_metric"`
 	StartTimeMetricRegex string `mapstructure:"start_time_metric_regex"`
 
+	// OutOfOrderTolerance is the maximum difference between the timestamps of the samples of a
+	// metric, such as the buckets of a histogram, for them to be grouped in the same data point.
+	// The jittery timestamps exposed behind a federation endpoint are tolerated this way, instead of
+	// failing the whole scrape.
+	OutOfOrderTolerance time.Duration `mapstructure:"out_of_order_tolerance"`
+	// DuplicateSamples defines which sample is kept when a series has several samples in a scrape,
+	// either "keep_last", which is the default, or "keep_first".
+	DuplicateSamples string `mapstructure:"duplicate_samples"`
+	// JobInstanceLabels defines where the job and instance labels of the series are set, either
+	// "resource", which is the default, or "datapoint". The series federated from several jobs and
+	// instances keep their own job and instance labels as data point attributes with "datapoint".
+	JobInstanceLabels string `mapstructure:"job_instance_labels"`
+	// CreateTargetInfo enables the generation of a target_info gauge for each scrape, with the job
+	// and instance labels of the target, and the labels of the target_info metric it exposes.
+	CreateTargetInfo bool `mapstructure:"create_target_info"`
+
 	TargetAllocator *targetAllocator `mapstructure:"target_allocator"`
 
+	// ScrapeConfigFile is a file in the Prometheus configuration format, whose scrape_configs are added
+	// to the ones of the config, and reloaded at runtime whenever the file changes.
+	ScrapeConfigFile *scrapeConfigFile `mapstructure:"scrape_config_file"`
+
+	// APIServer serves a read-only API reporting the scrape targets, in the format of the
+	// /api/v1/targets API of Prometheus. The API is disabled when not set.
+	APIServer *confighttp.HTTPServerSettings `mapstructure:"api_server"`
+
+	// UnixSockets maps the addresses of scrape targets, such as "node-exporter:9100", to the Unix
+	// domain sockets they are scraped through, such as "/run/node-exporter.sock" or
+	// "unix:///run/node-exporter.sock". The other targets are scraped over the network.
+	UnixSockets map[string]string `mapstructure:"unix_sockets"`
+
 	// ConfigPlaceholder is just an entry to make the configuration pass a check
 	// that requires that all keys present in the config actually exist on the
 	// structure, ie.: it will error if an unknown key is present.
@@ -72,6 +106,12 @@ type targetAllocator struct {
 	Endpoint    string        `mapstructure:"endpoint"`
 	Interval    time.Duration `mapstructure:"interval"`
 	CollectorID string        `mapstructure:"collector_id"`
+	// Jitter is the maximum random delay added to every poll of the target allocator,
+	// so that a fleet of collectors started at the same time does not re-sync all at once.
+	Jitter time.Duration `mapstructure:"jitter"`
+	// ShardLabel is the name of a target label set to the collector_id on every target
+	// retrieved from the target allocator, identifying the collector scraping it.
+	ShardLabel string `mapstructure:"shard_label"`
 	// ConfigPlaceholder is just an entry to make the configuration pass a check
 	// that requires that all keys present in the config actually exist on the
 	// structure, ie.: it will error if an unknown key is present.
@@ -79,6 +119,12 @@ type targetAllocator struct {
 	HTTPSDConfig      *promHTTP.SDConfig `mapstructure:"-"`
 }
 
+type scrapeConfigFile struct {
+	Path string `mapstructure:"path"`
+	// ReloadInterval is how often the file is checked for changes, every 30 seconds by default.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
 var _ config.Receiver = (*Config)(nil)
 var _ confmap.Unmarshaler = (*Config)(nil)
 
@@ -154,13 +200,53 @@ func (cfg *Config) Validate() error {
 			return err
 		}
 	}
+
+	if cfg.ScrapeConfigFile != nil {
+		err := cfg.validateScrapeConfigFile()
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.APIServer != nil && cfg.APIServer.Endpoint == "" {
+		return errors.New("api_server endpoint must be set")
+	}
+
+	for address, socket := range cfg.UnixSockets {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return fmt.Errorf("unix_sockets address %q must be a host and a port: %w", address, err)
+		}
+		if unixSocketPath(socket) == "" {
+			return fmt.Errorf("unix_sockets socket of address %q must be set", address)
+		}
+	}
+
+	if cfg.OutOfOrderTolerance < 0 {
+		return errors.New("out_of_order_tolerance must not be negative")
+	}
+	switch internal.DuplicateSamplePolicy(cfg.DuplicateSamples) {
+	case "", internal.KeepLast, internal.KeepFirst:
+	default:
+		return fmt.Errorf("duplicate_samples must be either %q or %q: %q", internal.KeepLast, internal.KeepFirst, cfg.DuplicateSamples)
+	}
+	switch internal.JobInstanceLabels(cfg.JobInstanceLabels) {
+	case "", internal.JobInstanceResource, internal.JobInstanceDataPoint:
+	default:
+		return fmt.Errorf("job_instance_labels must be either %q or %q: %q", internal.JobInstanceResource, internal.JobInstanceDataPoint, cfg.JobInstanceLabels)
+	}
 	return nil
 }
 
 func (cfg *Config) validatePromConfig(promConfig *promconfig.Config) error {
-	if len(promConfig.ScrapeConfigs) == 0 && cfg.TargetAllocator == nil {
-		return errors.New("no Prometheus scrape_configs or target_allocator set")
+	if len(promConfig.ScrapeConfigs) == 0 && cfg.TargetAllocator == nil && cfg.ScrapeConfigFile == nil {
+		return errors.New("no Prometheus scrape_configs, scrape_config_file or target_allocator set")
 	}
+	return validateScrapeConfigs(promConfig)
+}
+
+// validateScrapeConfigs checks that the Prometheus configuration only uses the features supported
+// by the receiver, and that the files referenced by its scrape configs exist.
+func validateScrapeConfigs(promConfig *promconfig.Config) error {
 
 	// Reject features that Prometheus supports but that the receiver doesn't support:
 	// See:
@@ -188,7 +274,7 @@ func (cfg *Config) validatePromConfig(promConfig *promconfig.Config) error {
 		return fmt.Errorf("unsupported features:\n\t%s", strings.Join(unsupportedFeatures, "\n\t"))
 	}
 
-	for _, sc := range cfg.PrometheusConfig.ScrapeConfigs {
+	for _, sc := range promConfig.ScrapeConfigs {
 		for _, rc := range sc.MetricRelabelConfigs {
 			if rc.TargetLabel == "__name__" {
 				// TODO(#2297): Remove validation after renaming is fixed
@@ -249,7 +335,63 @@ func (cfg *Config) validateTargetAllocatorConfig() error {
 	if targetAllocatorConfig.CollectorID == "" || strings.Contains(targetAllocatorConfig.CollectorID, "${") {
 		return fmt.Errorf("CollectorID is not a valid ID")
 	}
+	if targetAllocatorConfig.Jitter < 0 {
+		return fmt.Errorf("TargetAllocator jitter must not be negative")
+	}
+	if targetAllocatorConfig.ShardLabel != "" && !model.LabelName(targetAllocatorConfig.ShardLabel).IsValid() {
+		return fmt.Errorf("TargetAllocator shard_label is not a valid label name: %s", targetAllocatorConfig.ShardLabel)
+	}
+
+	return nil
+}
+
+func (cfg *Config) validateScrapeConfigFile() error {
+	if cfg.ScrapeConfigFile.Path == "" {
+		return errors.New("scrape_config_file path must be set")
+	}
+	if cfg.ScrapeConfigFile.ReloadInterval < 0 {
+		return errors.New("scrape_config_file reload_interval must not be negative")
+	}
+	_, fileConfig, err := loadScrapeConfigFile(cfg.ScrapeConfigFile.Path)
+	if err != nil {
+		return err
+	}
+	if err = validateScrapeConfigs(fileConfig); err != nil {
+		return fmt.Errorf("error validating scrape_config_file %q: %w", cfg.ScrapeConfigFile.Path, err)
+	}
+	if cfg.PrometheusConfig != nil {
+		return checkDuplicateJobs(cfg.PrometheusConfig.ScrapeConfigs, fileConfig.ScrapeConfigs)
+	}
+	return nil
+}
 
+// loadScrapeConfigFile reads the scrape config file, and parses its content as a Prometheus configuration.
+// The paths in the configuration are relative to the directory of the file.
+func loadScrapeConfigFile(path string) ([]byte, *promconfig.Config, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading scrape_config_file %q: %w", path, err)
+	}
+	promConfig, err := promconfig.Load(string(content), false, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing scrape_config_file %q: %w", path, err)
+	}
+	promConfig.SetDirectory(filepath.Dir(path))
+	return content, promConfig, nil
+}
+
+// checkDuplicateJobs returns an error if a job of the scrape config file has the same name as a job
+// of the config.
+func checkDuplicateJobs(scrapeConfigs, fileScrapeConfigs []*promconfig.ScrapeConfig) error {
+	jobNames := make(map[string]struct{}, len(scrapeConfigs))
+	for _, sc := range scrapeConfigs {
+		jobNames[sc.JobName] = struct{}{}
+	}
+	for _, sc := range fileScrapeConfigs {
+		if _, ok := jobNames[sc.JobName]; ok {
+			return fmt.Errorf("job %q of scrape_config_file is already defined in config", sc.JobName)
+		}
+	}
 	return nil
 }
 
@@ -266,6 +408,12 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 		return fmt.Errorf("prometheus receiver failed to parse config: %w", err)
 	}
 
+	// The scrape configs may all come from the scrape config file, without any config.
+	if cfg.ScrapeConfigFile != nil {
+		defaultConfig := promconfig.DefaultConfig
+		cfg.PrometheusConfig = &defaultConfig
+	}
+
 	// Unmarshal prometheus's config values. Since prometheus uses `yaml` tags, so use `yaml`.
 	promCfg, err := componentParser.Sub(prometheusConfigKey)
 	if err != nil || len(promCfg.ToStringMap()) == 0 {
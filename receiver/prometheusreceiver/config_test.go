@@ -15,6 +15,8 @@
 package prometheusreceiver
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -22,9 +24,11 @@ import (
 
 	promConfig "github.com/prometheus/common/config"
 	promModel "github.com/prometheus/common/model"
+	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 )
 
@@ -51,6 +55,10 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, time.Duration(r1.PrometheusConfig.ScrapeConfigs[0].ScrapeInterval), 5*time.Second)
 	assert.Equal(t, r1.UseStartTimeMetric, true)
 	assert.Equal(t, r1.StartTimeMetricRegex, "^(.+_)*process_start_time_seconds$")
+	assert.Equal(t, 100*time.Millisecond, r1.OutOfOrderTolerance)
+	assert.Equal(t, "keep_first", r1.DuplicateSamples)
+	assert.Equal(t, "datapoint", r1.JobInstanceLabels)
+	assert.True(t, r1.CreateTargetInfo)
 
 	assert.Equal(t, "http://my-targetallocator-service", r1.TargetAllocator.Endpoint)
 	assert.Equal(t, 30*time.Second, r1.TargetAllocator.Interval)
@@ -75,6 +83,9 @@ func TestLoadTargetAllocatorConfig(t *testing.T) {
 	assert.Equal(t, "http://localhost:8080", r0.TargetAllocator.Endpoint)
 	assert.Equal(t, 30*time.Second, r0.TargetAllocator.Interval)
 	assert.Equal(t, "collector-1", r0.TargetAllocator.CollectorID)
+	assert.Equal(t, 5*time.Second, r0.TargetAllocator.Jitter)
+	assert.Equal(t, "collector_shard", r0.TargetAllocator.ShardLabel)
+	assert.NoError(t, r0.Validate())
 
 	sub, err = cm.Sub(config.NewComponentIDWithName(typeStr, "withScrape").String())
 	require.NoError(t, err)
@@ -102,6 +113,35 @@ func TestLoadTargetAllocatorConfig(t *testing.T) {
 	assert.Equal(t, promModel.Duration(5*time.Second), r2.PrometheusConfig.ScrapeConfigs[0].ScrapeInterval)
 }
 
+func TestLoadScrapeConfigFileConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_scrape_config_file.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "").String())
+	require.NoError(t, err)
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+	r0 := cfg.(*Config)
+	assert.Equal(t, "./testdata/scrape_configs.yaml", r0.ScrapeConfigFile.Path)
+	assert.Equal(t, time.Duration(0), r0.ScrapeConfigFile.ReloadInterval)
+	require.NotNil(t, r0.PrometheusConfig)
+	assert.Empty(t, r0.PrometheusConfig.ScrapeConfigs)
+	assert.NoError(t, r0.Validate())
+
+	sub, err = cm.Sub(config.NewComponentIDWithName(typeStr, "withScrape").String())
+	require.NoError(t, err)
+	cfg = factory.CreateDefaultConfig()
+	require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+	r1 := cfg.(*Config)
+	assert.Equal(t, 10*time.Second, r1.ScrapeConfigFile.ReloadInterval)
+	assert.Equal(t, 1, len(r1.PrometheusConfig.ScrapeConfigs))
+	assert.Equal(t, "demo", r1.PrometheusConfig.ScrapeConfigs[0].JobName)
+	assert.NoError(t, r1.Validate())
+}
+
 func TestLoadConfigFailsOnUnknownSection(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "invalid-config-section.yaml"))
 	require.NoError(t, err)
@@ -317,3 +357,208 @@ func TestFileSDConfigYamlNilTargetGroup(t *testing.T) {
 	gotErrMsg := err.Error()
 	require.Equal(t, wantErrMsg, gotErrMsg)
 }
+
+func TestTargetAllocatorConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		modify     func(ta *targetAllocator)
+		wantErrMsg string
+	}{
+		{
+			desc:   "valid",
+			modify: func(ta *targetAllocator) {},
+		},
+		{
+			desc:       "negative jitter",
+			modify:     func(ta *targetAllocator) { ta.Jitter = -time.Second },
+			wantErrMsg: "TargetAllocator jitter must not be negative",
+		},
+		{
+			desc:       "invalid shard label",
+			modify:     func(ta *targetAllocator) { ta.ShardLabel = "collector-shard" },
+			wantErrMsg: "TargetAllocator shard_label is not a valid label name: collector-shard",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &Config{
+				TargetAllocator: &targetAllocator{
+					Endpoint:    "http://localhost:8080",
+					Interval:    30 * time.Second,
+					CollectorID: "collector-1",
+					ShardLabel:  "collector_shard",
+				},
+			}
+			tc.modify(cfg.TargetAllocator)
+			err := cfg.Validate()
+			if tc.wantErrMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.wantErrMsg)
+		})
+	}
+}
+
+func TestSampleHandlingConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		modify     func(cfg *Config)
+		wantErrMsg string
+	}{
+		{
+			desc:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			desc: "valid",
+			modify: func(cfg *Config) {
+				cfg.OutOfOrderTolerance = 100 * time.Millisecond
+				cfg.DuplicateSamples = "keep_first"
+				cfg.JobInstanceLabels = "datapoint"
+			},
+		},
+		{
+			desc:       "negative out of order tolerance",
+			modify:     func(cfg *Config) { cfg.OutOfOrderTolerance = -time.Second },
+			wantErrMsg: "out_of_order_tolerance must not be negative",
+		},
+		{
+			desc:       "invalid duplicate samples",
+			modify:     func(cfg *Config) { cfg.DuplicateSamples = "keep_all" },
+			wantErrMsg: `duplicate_samples must be either "keep_last" or "keep_first": "keep_all"`,
+		},
+		{
+			desc:       "invalid job and instance labels",
+			modify:     func(cfg *Config) { cfg.JobInstanceLabels = "scope" },
+			wantErrMsg: `job_instance_labels must be either "resource" or "datapoint": "scope"`,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &Config{}
+			tc.modify(cfg)
+			err := cfg.Validate()
+			if tc.wantErrMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.wantErrMsg)
+		})
+	}
+}
+
+func TestAPIServerConfigValidation(t *testing.T) {
+	cfg := &Config{
+		PrometheusConfig: &promconfig.Config{ScrapeConfigs: []*promconfig.ScrapeConfig{{JobName: "demo"}}},
+		APIServer:        &confighttp.HTTPServerSettings{},
+	}
+	require.EqualError(t, cfg.Validate(), "api_server endpoint must be set")
+
+	cfg.APIServer.Endpoint = "localhost:9090"
+	require.NoError(t, cfg.Validate())
+}
+
+func TestUnixSocketsConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		unixSockets map[string]string
+		wantErrMsg  string
+	}{
+		{
+			desc:        "valid",
+			unixSockets: map[string]string{"exporter:9100": "/run/exporter.sock", "other:9100": "unix:///run/other.sock"},
+		},
+		{
+			desc:        "address without port",
+			unixSockets: map[string]string{"exporter": "/run/exporter.sock"},
+			wantErrMsg:  `unix_sockets address "exporter" must be a host and a port: address exporter: missing port in address`,
+		},
+		{
+			desc:        "missing socket",
+			unixSockets: map[string]string{"exporter:9100": "unix://"},
+			wantErrMsg:  `unix_sockets socket of address "exporter:9100" must be set`,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &Config{
+				PrometheusConfig: &promconfig.Config{ScrapeConfigs: []*promconfig.ScrapeConfig{{JobName: "demo"}}},
+				UnixSockets:      tc.unixSockets,
+			}
+			err := cfg.Validate()
+			if tc.wantErrMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.wantErrMsg)
+		})
+	}
+}
+
+func TestScrapeConfigFileConfigValidation(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+	unsupported := writeFile("unsupported.yaml", "rule_files: [rules.yaml]\n")
+	invalid := writeFile("invalid.yaml", "scrape_configs: [{job_name: file1, unknown: true}]\n")
+	duplicate := writeFile("duplicate.yaml", "scrape_configs: [{job_name: demo}]\n")
+
+	for _, tc := range []struct {
+		desc       string
+		modify     func(file *scrapeConfigFile)
+		wantErrMsg string
+	}{
+		{
+			desc:   "valid",
+			modify: func(file *scrapeConfigFile) {},
+		},
+		{
+			desc:       "missing path",
+			modify:     func(file *scrapeConfigFile) { file.Path = "" },
+			wantErrMsg: "scrape_config_file path must be set",
+		},
+		{
+			desc:       "negative reload interval",
+			modify:     func(file *scrapeConfigFile) { file.ReloadInterval = -time.Second },
+			wantErrMsg: "scrape_config_file reload_interval must not be negative",
+		},
+		{
+			desc:       "non existent file",
+			modify:     func(file *scrapeConfigFile) { file.Path = filepath.Join(dir, "missing.yaml") },
+			wantErrMsg: fmt.Sprintf("error reading scrape_config_file %q: open %s: no such file or directory", filepath.Join(dir, "missing.yaml"), filepath.Join(dir, "missing.yaml")),
+		},
+		{
+			desc:       "invalid file",
+			modify:     func(file *scrapeConfigFile) { file.Path = invalid },
+			wantErrMsg: fmt.Sprintf("error parsing scrape_config_file %q: yaml: unmarshal errors:\n  line 1: field unknown not found in type config.ScrapeConfig", invalid),
+		},
+		{
+			desc:       "unsupported features",
+			modify:     func(file *scrapeConfigFile) { file.Path = unsupported },
+			wantErrMsg: fmt.Sprintf("error validating scrape_config_file %q: unsupported features:\n\trule_files", unsupported),
+		},
+		{
+			desc:       "duplicate job",
+			modify:     func(file *scrapeConfigFile) { file.Path = duplicate },
+			wantErrMsg: `job "demo" of scrape_config_file is already defined in config`,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &Config{
+				PrometheusConfig: &promconfig.Config{ScrapeConfigs: []*promconfig.ScrapeConfig{{JobName: "demo"}}},
+				ScrapeConfigFile: &scrapeConfigFile{
+					Path:           filepath.Join("testdata", "scrape_configs.yaml"),
+					ReloadInterval: time.Minute,
+				},
+			}
+			tc.modify(cfg.ScrapeConfigFile)
+			err := cfg.Validate()
+			if tc.wantErrMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.wantErrMsg)
+		})
+	}
+}
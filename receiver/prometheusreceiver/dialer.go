@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	commonconfig "github.com/prometheus/common/config"
+)
+
+const unixSocketScheme = "unix://"
+
+// unixSocketPath returns the path of a Unix domain socket, set either as a path or as a unix:// URL.
+func unixSocketPath(socket string) string {
+	return strings.TrimPrefix(socket, unixSocketScheme)
+}
+
+// newUnixSocketDialContext returns a dial function connecting to the Unix domain socket mapped to the
+// address of a target, if any, and over the network otherwise.
+func newUnixSocketDialContext(sockets map[string]string) commonconfig.DialContextFunc {
+	paths := make(map[string]string, len(sockets))
+	for address, socket := range sockets {
+		paths[address] = unixSocketPath(socket)
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		if path, ok := paths[address]; ok {
+			return dialer.DialContext(ctx, "unix", path)
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+}
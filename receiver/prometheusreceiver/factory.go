@@ -19,6 +19,7 @@ import (
 	"errors"
 
 	_ "github.com/prometheus/prometheus/discovery/install" // init() of this package registers service discovery impl.
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
@@ -35,6 +36,8 @@ var errRenamingDisallowed = errors.New("metric renaming using metric_relabel_con
 
 // NewFactory creates a new Prometheus receiver factory.
 func NewFactory() component.ReceiverFactory {
+	_ = view.Register(MetricViews()...)
+
 	return component.NewReceiverFactory(
 		typeStr,
 		createDefaultConfig,
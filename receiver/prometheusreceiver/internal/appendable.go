@@ -34,6 +34,10 @@ type appendable struct {
 	useStartTimeMetric   bool
 	startTimeMetricRegex *regexp.Regexp
 	externalLabels       labels.Labels
+	outOfOrderTolerance  time.Duration
+	duplicateSamples     DuplicateSamplePolicy
+	jobInstanceLabels    JobInstanceLabels
+	createTargetInfo     bool
 
 	settings component.ReceiverCreateSettings
 	obsrecv  *obsreport.Receiver
@@ -47,7 +51,11 @@ func NewAppendable(
 	useStartTimeMetric bool,
 	startTimeMetricRegex *regexp.Regexp,
 	receiverID config.ComponentID,
-	externalLabels labels.Labels) storage.Appendable {
+	externalLabels labels.Labels,
+	outOfOrderTolerance time.Duration,
+	duplicateSamples DuplicateSamplePolicy,
+	jobInstanceLabels JobInstanceLabels,
+	createTargetInfo bool) storage.Appendable {
 	var metricAdjuster MetricsAdjuster
 	if !useStartTimeMetric {
 		metricAdjuster = NewInitialPointAdjuster(set.Logger, gcInterval)
@@ -62,10 +70,14 @@ func NewAppendable(
 		useStartTimeMetric:   useStartTimeMetric,
 		startTimeMetricRegex: startTimeMetricRegex,
 		externalLabels:       externalLabels,
+		outOfOrderTolerance:  outOfOrderTolerance,
+		duplicateSamples:     duplicateSamples,
+		jobInstanceLabels:    jobInstanceLabels,
+		createTargetInfo:     createTargetInfo,
 		obsrecv:              obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverID: receiverID, Transport: transport, ReceiverCreateSettings: set}),
 	}
 }
 
 func (o *appendable) Appender(ctx context.Context) storage.Appender {
-	return newTransaction(ctx, o.metricAdjuster, o.sink, o.externalLabels, o.settings, o.obsrecv)
+	return newTransaction(ctx, o.metricAdjuster, o.sink, o.externalLabels, o.outOfOrderTolerance, o.duplicateSamples, o.jobInstanceLabels, o.createTargetInfo, o.settings, o.obsrecv)
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
@@ -35,6 +36,11 @@ type metricFamily struct {
 	name        string
 	metadata    *scrape.MetricMetadata
 	groupOrders []*metricGroup
+	// outOfOrderTolerance is the maximum difference, in milliseconds, between the timestamps
+	// of the points of a metric group.
+	outOfOrderTolerance int64
+	// jobInstanceLabels tells whether the job and instance labels are attributes of the data points.
+	jobInstanceLabels JobInstanceLabels
 }
 
 // metricGroup, represents a single metric of a metric family. for example a histogram metric is usually represent by
@@ -52,7 +58,7 @@ type metricGroup struct {
 	complexValue []*dataPoint
 }
 
-func newMetricFamily(metricName string, mc scrape.MetricMetadataStore, logger *zap.Logger) *metricFamily {
+func newMetricFamily(metricName string, mc scrape.MetricMetadataStore, outOfOrderTolerance time.Duration, jobInstanceLabels JobInstanceLabels, logger *zap.Logger) *metricFamily {
 	metadata, familyName := metadataForMetric(metricName, mc)
 	mtype, isMonotonic := convToMetricType(metadata.Type)
 	if mtype == pmetric.MetricTypeNone {
@@ -65,6 +71,9 @@ func newMetricFamily(metricName string, mc scrape.MetricMetadataStore, logger *z
 		groups:      make(map[uint64]*metricGroup),
 		name:        familyName,
 		metadata:    metadata,
+
+		outOfOrderTolerance: outOfOrderTolerance.Milliseconds(),
+		jobInstanceLabels:   jobInstanceLabels,
 	}
 }
 
@@ -81,7 +90,7 @@ func (mf *metricFamily) includesMetric(metricName string) bool {
 
 func (mf *metricFamily) getGroupKey(ls labels.Labels) uint64 {
 	bytes := make([]byte, 0, 2048)
-	hash, _ := ls.HashWithoutLabels(bytes, getSortedNotUsefulLabels(mf.mtype)...)
+	hash, _ := ls.HashWithoutLabels(bytes, getSortedNotUsefulLabels(mf.mtype, mf.jobInstanceLabels)...)
 	return hash
 }
 
@@ -140,7 +149,7 @@ func (mg *metricGroup) toDistributionPoint(dest pmetric.HistogramDataPointSlice)
 	tsNanos := timestampFromMs(mg.ts)
 	point.SetStartTimestamp(tsNanos) // metrics_adjuster adjusts the startTimestamp to the initial scrape timestamp
 	point.SetTimestamp(tsNanos)
-	populateAttributes(pmetric.MetricTypeHistogram, mg.ls, point.Attributes())
+	populateAttributes(getSortedNotUsefulLabels(pmetric.MetricTypeHistogram, mg.family.jobInstanceLabels), mg.ls, point.Attributes())
 }
 
 func (mg *metricGroup) toSummaryPoint(dest pmetric.SummaryDataPointSlice) {
@@ -184,7 +193,7 @@ func (mg *metricGroup) toSummaryPoint(dest pmetric.SummaryDataPointSlice) {
 	tsNanos := timestampFromMs(mg.ts)
 	point.SetTimestamp(tsNanos)
 	point.SetStartTimestamp(tsNanos) // metrics_adjuster adjusts the startTimestamp to the initial scrape timestamp
-	populateAttributes(pmetric.MetricTypeSummary, mg.ls, point.Attributes())
+	populateAttributes(getSortedNotUsefulLabels(pmetric.MetricTypeSummary, mg.family.jobInstanceLabels), mg.ls, point.Attributes())
 }
 
 func (mg *metricGroup) toNumberDataPoint(dest pmetric.NumberDataPointSlice) {
@@ -200,12 +209,12 @@ func (mg *metricGroup) toNumberDataPoint(dest pmetric.NumberDataPointSlice) {
 	} else {
 		point.SetDoubleValue(mg.value)
 	}
-	populateAttributes(pmetric.MetricTypeGauge, mg.ls, point.Attributes())
+	populateAttributes(getSortedNotUsefulLabels(pmetric.MetricTypeGauge, mg.family.jobInstanceLabels), mg.ls, point.Attributes())
 }
 
-func populateAttributes(mType pmetric.MetricType, ls labels.Labels, dest pcommon.Map) {
+// populateAttributes sets the labels as attributes, except the given sorted not useful labels.
+func populateAttributes(names []string, ls labels.Labels, dest pcommon.Map) {
 	dest.EnsureCapacity(ls.Len())
-	names := getSortedNotUsefulLabels(mType)
 	j := 0
 	for i := range ls {
 		for j < len(names) && names[j] < ls[i].Name {
@@ -240,7 +249,7 @@ func (mf *metricFamily) loadMetricGroupOrCreate(groupKey uint64, ls labels.Label
 func (mf *metricFamily) Add(metricName string, ls labels.Labels, t int64, v float64) error {
 	groupKey := mf.getGroupKey(ls)
 	mg := mf.loadMetricGroupOrCreate(groupKey, ls, t)
-	if mg.ts != t {
+	if diff := mg.ts - t; diff > mf.outOfOrderTolerance || -diff > mf.outOfOrderTolerance {
 		return fmt.Errorf("inconsistent timestamps on metric points for metric %v", metricName)
 	}
 	switch mf.mtype {
@@ -259,9 +268,17 @@ func (mf *metricFamily) Add(metricName string, ls labels.Labels, t int64, v floa
 			if err != nil {
 				return err
 			}
+			// a duplicate sample of a bucket or a quantile replaces the previous one
+			for _, dp := range mg.complexValue {
+				if dp.boundary == boundary {
+					dp.value = v
+					return nil
+				}
+			}
 			mg.complexValue = append(mg.complexValue, &dataPoint{value: v, boundary: boundary})
 		}
 	default:
+		mg.ts = t
 		mg.value = v
 	}
 
@@ -179,7 +179,7 @@ func TestMetricGroupData_toDistributionUnitTest(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			mp := newMetricFamily(tt.metricName, mc, zap.NewNop())
+			mp := newMetricFamily(tt.metricName, mc, 0, JobInstanceResource, zap.NewNop())
 			for i, tv := range tt.scrapes {
 				var lbls labels.Labels
 				if tv.extraLabel.Name != "" {
@@ -222,6 +222,51 @@ func TestMetricGroupData_toDistributionUnitTest(t *testing.T) {
 	}
 }
 
+func TestMetricGroupData_toDistributionOutOfOrderTolerance(t *testing.T) {
+	type scrape struct {
+		at         int64
+		value      float64
+		metric     string
+		extraLabel labels.Label
+	}
+	ls := labels.FromMap(map[string]string{"a": "A"})
+	scrapes := []*scrape{
+		{at: 11, value: 66, metric: "histogram_count"},
+		{at: 12, value: 1004.78, metric: "histogram_sum"},
+		{at: 10, value: 33, metric: "histogram_bucket", extraLabel: labels.Label{Name: "le", Value: "0.75"}},
+		{at: 13, value: 66, metric: "histogram_bucket", extraLabel: labels.Label{Name: "le", Value: "+Inf"}},
+		// the duplicate sample of a bucket replaces the previous one
+		{at: 11, value: 44, metric: "histogram_bucket", extraLabel: labels.Label{Name: "le", Value: "0.75"}},
+	}
+
+	mp := newMetricFamily("histogram", mc, 2*time.Millisecond, JobInstanceResource, zap.NewNop())
+	for _, tv := range scrapes {
+		lbls := ls.Copy()
+		if tv.extraLabel.Name != "" {
+			lbls = labels.NewBuilder(ls).Set(tv.extraLabel.Name, tv.extraLabel.Value).Labels()
+		}
+		require.NoError(t, mp.Add(tv.metric, lbls, tv.at, tv.value))
+	}
+	// the timestamp of the sample is too far from the timestamp of the count
+	require.Error(t, mp.Add("histogram_sum", ls.Copy(), 14, 1004.78))
+
+	sl := pmetric.NewMetricSlice()
+	mp.appendMetric(sl)
+	require.Equal(t, 1, sl.Len())
+	hdpL := sl.At(0).Histogram().DataPoints()
+	require.Equal(t, 1, hdpL.Len())
+
+	want := pmetric.NewHistogramDataPoint()
+	want.SetCount(66)
+	want.SetSum(1004.78)
+	want.SetTimestamp(pcommon.Timestamp(11 * time.Millisecond))
+	want.SetStartTimestamp(pcommon.Timestamp(11 * time.Millisecond))
+	want.ExplicitBounds().FromRaw([]float64{0.75})
+	want.BucketCounts().FromRaw([]uint64{44, 22})
+	want.Attributes().PutStr("a", "A")
+	require.Equal(t, want, hdpL.At(0))
+}
+
 func TestMetricGroupData_toSummaryUnitTest(t *testing.T) {
 	type scrape struct {
 		at     int64
@@ -397,7 +442,7 @@ func TestMetricGroupData_toSummaryUnitTest(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			mp := newMetricFamily(tt.name, mc, zap.NewNop())
+			mp := newMetricFamily(tt.name, mc, 0, JobInstanceResource, zap.NewNop())
 			for _, lbs := range tt.labelsScrapes {
 				for i, scrape := range lbs.scrapes {
 					err := mp.Add(scrape.metric, lbs.labels.Copy(), scrape.at, scrape.value)
@@ -494,7 +539,7 @@ func TestMetricGroupData_toNumberDataUnitTest(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			mp := newMetricFamily(tt.metricKind, mc, zap.NewNop())
+			mp := newMetricFamily(tt.metricKind, mc, 0, JobInstanceResource, zap.NewNop())
 			for _, tv := range tt.scrapes {
 				require.NoError(t, mp.Add(tv.metric, tt.labels.Copy(), tv.at, tv.value))
 			}
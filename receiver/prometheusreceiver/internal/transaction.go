@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
@@ -39,17 +40,50 @@ const (
 	targetMetricName = "target_info"
 )
 
+// DuplicateSamplePolicy defines which sample is kept when a series has several samples in a scrape,
+// as exposed by the federation endpoints of Prometheus servers running in pairs.
+type DuplicateSamplePolicy string
+
+const (
+	// KeepLast keeps the last sample of the series, which is the default.
+	KeepLast DuplicateSamplePolicy = "keep_last"
+	// KeepFirst keeps the first sample of the series, and rejects the following ones.
+	KeepFirst DuplicateSamplePolicy = "keep_first"
+)
+
+// JobInstanceLabels defines where the job and instance labels of the series are set.
+type JobInstanceLabels string
+
+const (
+	// JobInstanceResource sets the job and instance labels of the first series of a scrape as the
+	// service.name and service.instance.id resource attributes, which is the default.
+	JobInstanceResource JobInstanceLabels = "resource"
+	// JobInstanceDataPoint keeps the job and instance labels of each series as data point attributes,
+	// and creates the resource from the job and instance of the scrape target, so that the series
+	// federated from several jobs and instances aren't attributed to the first one.
+	JobInstanceDataPoint JobInstanceLabels = "datapoint"
+)
+
 type transaction struct {
-	isNew          bool
-	ctx            context.Context
-	families       map[string]*metricFamily
-	mc             scrape.MetricMetadataStore
-	sink           consumer.Metrics
-	externalLabels labels.Labels
-	nodeResource   pcommon.Resource
-	logger         *zap.Logger
-	metricAdjuster MetricsAdjuster
-	obsrecv        *obsreport.Receiver
+	isNew               bool
+	ctx                 context.Context
+	families            map[string]*metricFamily
+	mc                  scrape.MetricMetadataStore
+	sink                consumer.Metrics
+	externalLabels      labels.Labels
+	nodeResource        pcommon.Resource
+	logger              *zap.Logger
+	metricAdjuster      MetricsAdjuster
+	obsrecv             *obsreport.Receiver
+	outOfOrderTolerance time.Duration
+	duplicateSamples    DuplicateSamplePolicy
+	seenSeries          map[uint64]struct{}
+	jobInstanceLabels   JobInstanceLabels
+	createTargetInfo    bool
+	job                 string
+	instance            string
+	targetInfoLabels    labels.Labels
+	scrapeTimestamp     int64
 }
 
 func newTransaction(
@@ -57,17 +91,26 @@ func newTransaction(
 	metricAdjuster MetricsAdjuster,
 	sink consumer.Metrics,
 	externalLabels labels.Labels,
+	outOfOrderTolerance time.Duration,
+	duplicateSamples DuplicateSamplePolicy,
+	jobInstanceLabels JobInstanceLabels,
+	createTargetInfo bool,
 	settings component.ReceiverCreateSettings,
 	obsrecv *obsreport.Receiver) *transaction {
 	return &transaction{
-		ctx:            ctx,
-		families:       make(map[string]*metricFamily),
-		isNew:          true,
-		sink:           sink,
-		metricAdjuster: metricAdjuster,
-		externalLabels: externalLabels,
-		logger:         settings.Logger,
-		obsrecv:        obsrecv,
+		ctx:                 ctx,
+		families:            make(map[string]*metricFamily),
+		isNew:               true,
+		sink:                sink,
+		metricAdjuster:      metricAdjuster,
+		externalLabels:      externalLabels,
+		logger:              settings.Logger,
+		obsrecv:             obsrecv,
+		outOfOrderTolerance: outOfOrderTolerance,
+		duplicateSamples:    duplicateSamples,
+		seenSeries:          make(map[uint64]struct{}),
+		jobInstanceLabels:   jobInstanceLabels,
+		createTargetInfo:    createTargetInfo,
 	}
 }
 
@@ -88,6 +131,7 @@ func (t *transaction) Append(ref storage.SeriesRef, ls labels.Labels, atMs int64
 		if err := t.initTransaction(ls); err != nil {
 			return 0, err
 		}
+		t.scrapeTimestamp = atMs
 	}
 
 	// Any datapoint with duplicate labels MUST be rejected per:
@@ -124,13 +168,23 @@ func (t *transaction) Append(ref storage.SeriesRef, ls labels.Labels, atMs int64
 		return 0, t.AddTargetInfo(ls)
 	}
 
+	// The following samples of a series replace the previous ones, unless the first sample is kept.
+	// The scrape loop counts the rejected samples and keeps on appending the other ones.
+	if t.duplicateSamples == KeepFirst {
+		hash := ls.Hash()
+		if _, ok := t.seenSeries[hash]; ok {
+			return 0, storage.ErrDuplicateSampleForTimestamp
+		}
+		t.seenSeries[hash] = struct{}{}
+	}
+
 	curMF, ok := t.families[metricName]
 	if !ok {
 		familyName := normalizeMetricName(metricName)
 		if mf, ok := t.families[familyName]; ok && mf.includesMetric(metricName) {
 			curMF = mf
 		} else {
-			curMF = newMetricFamily(metricName, t.mc, t.logger)
+			curMF = newMetricFamily(metricName, t.mc, t.outOfOrderTolerance, t.jobInstanceLabels, t.logger)
 			t.families[curMF.name] = curMF
 		}
 	}
@@ -157,10 +211,31 @@ func (t *transaction) getMetrics(resource pcommon.Resource) (pmetric.Metrics, er
 	for _, mf := range t.families {
 		mf.appendMetric(metrics)
 	}
+	if t.createTargetInfo {
+		t.appendTargetInfo(metrics)
+	}
 
 	return md, nil
 }
 
+// appendTargetInfo appends the target_info gauge describing the target of the scrape, with the job
+// and instance labels, and the labels of the target_info metric exposed by the target, if any.
+func (t *transaction) appendTargetInfo(metrics pmetric.MetricSlice) {
+	metric := metrics.AppendEmpty()
+	metric.SetName(targetMetricName)
+	metric.SetDescription("Target metadata")
+	point := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+	point.SetTimestamp(timestampFromMs(t.scrapeTimestamp))
+	point.SetDoubleValue(1)
+
+	attrs := point.Attributes()
+	attrs.PutStr(model.JobLabel, t.job)
+	attrs.PutStr(model.InstanceLabel, t.instance)
+	for _, lbl := range t.targetInfoLabels {
+		attrs.PutStr(lbl.Name, lbl.Value)
+	}
+}
+
 func (t *transaction) initTransaction(labels labels.Labels) error {
 	target, ok := scrape.TargetFromContext(t.ctx)
 	if !ok {
@@ -172,9 +247,14 @@ func (t *transaction) initTransaction(labels labels.Labels) error {
 	}
 
 	job, instance := labels.Get(model.JobLabel), labels.Get(model.InstanceLabel)
+	if t.jobInstanceLabels == JobInstanceDataPoint {
+		targetLabels := target.Labels()
+		job, instance = targetLabels.Get(model.JobLabel), targetLabels.Get(model.InstanceLabel)
+	}
 	if job == "" || instance == "" {
 		return errNoJobInstance
 	}
+	t.job, t.instance = job, instance
 	t.nodeResource = CreateResource(job, instance, target.DiscoveredLabels())
 	t.isNew = false
 	return nil
@@ -225,6 +305,7 @@ func (t *transaction) AddTargetInfo(labels labels.Labels) error {
 		}
 
 		attrs.PutStr(lbl.Name, lbl.Value)
+		t.targetInfoLabels = append(t.targetInfoLabels, lbl)
 	}
 
 	return nil
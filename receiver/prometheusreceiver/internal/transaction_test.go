@@ -24,6 +24,7 @@ import (
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/metadata"
 	"github.com/prometheus/prometheus/scrape"
+	"github.com/prometheus/prometheus/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -61,24 +62,24 @@ var (
 )
 
 func TestTransactionCommitWithoutAdding(t *testing.T) {
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 	assert.NoError(t, tr.Commit())
 }
 
 func TestTransactionRollbackDoesNothing(t *testing.T) {
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 	assert.NoError(t, tr.Rollback())
 }
 
 func TestTransactionUpdateMetadataDoesNothing(t *testing.T) {
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 	_, err := tr.UpdateMetadata(0, labels.New(), metadata.Metadata{})
 	assert.NoError(t, err)
 }
 
 func TestTransactionAppendNoTarget(t *testing.T) {
 	badLabels := labels.FromStrings(model.MetricNameLabel, "counter_test")
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 	_, err := tr.Append(0, badLabels, time.Now().Unix()*1000, 1.0)
 	assert.Error(t, err)
 }
@@ -88,7 +89,7 @@ func TestTransactionAppendNoMetricName(t *testing.T) {
 		model.InstanceLabel: "localhost:8080",
 		model.JobLabel:      "test2",
 	})
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 	_, err := tr.Append(0, jobNotFoundLb, time.Now().Unix()*1000, 1.0)
 	assert.ErrorIs(t, err, errMetricNameNotFound)
 
@@ -96,7 +97,7 @@ func TestTransactionAppendNoMetricName(t *testing.T) {
 }
 
 func TestTransactionAppendEmptyMetricName(t *testing.T) {
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, consumertest.NewNop(), nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 	_, err := tr.Append(0, labels.FromMap(map[string]string{
 		model.InstanceLabel:   "localhost:8080",
 		model.JobLabel:        "test2",
@@ -107,7 +108,7 @@ func TestTransactionAppendEmptyMetricName(t *testing.T) {
 
 func TestTransactionAppendResource(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 	_, err := tr.Append(0, labels.FromMap(map[string]string{
 		model.InstanceLabel:   "localhost:8080",
 		model.JobLabel:        "test",
@@ -128,6 +129,122 @@ func TestTransactionAppendResource(t *testing.T) {
 	require.Equal(t, expectedResource, gotResource)
 }
 
+func TestTransactionAppendDuplicateSamples(t *testing.T) {
+	for _, tc := range []struct {
+		policy    DuplicateSamplePolicy
+		wantErr   error
+		wantValue float64
+	}{
+		{policy: KeepLast, wantValue: 2.0},
+		{policy: KeepFirst, wantErr: storage.ErrDuplicateSampleForTimestamp, wantValue: 1.0},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, 0, tc.policy, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+			ls := labels.FromMap(map[string]string{
+				model.InstanceLabel:   "localhost:8080",
+				model.JobLabel:        "test",
+				model.MetricNameLabel: "counter_test",
+			})
+			_, err := tr.Append(0, ls, ts, 1.0)
+			require.NoError(t, err)
+			_, err = tr.Append(0, ls, ts, 2.0)
+			require.ErrorIs(t, err, tc.wantErr)
+			require.NoError(t, tr.Commit())
+
+			mds := sink.AllMetrics()
+			require.Len(t, mds, 1)
+			metrics := mds[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			require.Equal(t, 1, metrics.Len())
+			dps := metrics.At(0).Sum().DataPoints()
+			require.Equal(t, 1, dps.Len())
+			assert.Equal(t, tc.wantValue, dps.At(0).DoubleValue())
+		})
+	}
+}
+
+func TestTransactionAppendJobInstanceDataPoint(t *testing.T) {
+	federateTarget := scrape.NewTarget(
+		labels.FromMap(map[string]string{
+			model.JobLabel:      "federate",
+			model.InstanceLabel: "prometheus:9090",
+		}),
+		labels.FromMap(map[string]string{
+			model.AddressLabel: "prometheus:9090",
+			model.SchemeLabel:  "http",
+		}),
+		nil)
+	federateCtx := scrape.ContextWithMetricMetadataStore(
+		scrape.ContextWithTarget(context.Background(), federateTarget),
+		testMetadataStore(testMetadata))
+
+	sink := new(consumertest.MetricsSink)
+	tr := newTransaction(federateCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, 0, KeepLast, JobInstanceDataPoint, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	for i, instance := range []string{"node-1:9100", "node-2:9100"} {
+		_, err := tr.Append(0, labels.FromMap(map[string]string{
+			model.InstanceLabel:   instance,
+			model.JobLabel:        "node",
+			model.MetricNameLabel: "counter_test",
+		}), ts, float64(i))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tr.Commit())
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 1)
+	rm := mds[0].ResourceMetrics().At(0)
+	assert.Equal(t, CreateResource("federate", "prometheus:9090", federateTarget.DiscoveredLabels()), rm.Resource())
+	dps := rm.ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	for i, instance := range []string{"node-1:9100", "node-2:9100"} {
+		assert.Equal(t, map[string]interface{}{
+			model.JobLabel:      "node",
+			model.InstanceLabel: instance,
+		}, dps.At(i).Attributes().AsRaw())
+		assert.Equal(t, float64(i), dps.At(i).DoubleValue())
+	}
+}
+
+func TestTransactionCreateTargetInfo(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, 0, KeepLast, JobInstanceResource, true, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	_, err := tr.Append(0, labels.FromMap(map[string]string{
+		model.InstanceLabel:   "localhost:8080",
+		model.JobLabel:        "test",
+		model.MetricNameLabel: "counter_test",
+	}), ts, 1.0)
+	require.NoError(t, err)
+	_, err = tr.Append(0, labels.FromMap(map[string]string{
+		model.InstanceLabel:   "localhost:8080",
+		model.JobLabel:        "test",
+		model.MetricNameLabel: targetMetricName,
+		"version":             "1.2.3",
+	}), ts, 1.0)
+	require.NoError(t, err)
+	require.NoError(t, tr.Commit())
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 1)
+	rm := mds[0].ResourceMetrics().At(0)
+	version, ok := rm.Resource().Attributes().Get("version")
+	require.True(t, ok)
+	assert.Equal(t, "1.2.3", version.Str())
+
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	targetInfo := metrics.At(1)
+	assert.Equal(t, targetMetricName, targetInfo.Name())
+	require.Equal(t, pmetric.MetricTypeGauge, targetInfo.Type())
+	dp := targetInfo.Gauge().DataPoints().At(0)
+	assert.Equal(t, 1.0, dp.DoubleValue())
+	assert.Equal(t, tsNanos, dp.Timestamp())
+	assert.Equal(t, map[string]interface{}{
+		model.JobLabel:      "test",
+		model.InstanceLabel: "localhost:8080",
+		"version":           "1.2.3",
+	}, dp.Attributes().AsRaw())
+}
+
 func TestTransactionCommitErrorWhenAdjusterError(t *testing.T) {
 	goodLabels := labels.FromMap(map[string]string{
 		model.InstanceLabel:   "localhost:8080",
@@ -136,7 +253,7 @@ func TestTransactionCommitErrorWhenAdjusterError(t *testing.T) {
 	})
 	sink := new(consumertest.MetricsSink)
 	adjusterErr := errors.New("adjuster error")
-	tr := newTransaction(scrapeCtx, &errorAdjuster{err: adjusterErr}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &errorAdjuster{err: adjusterErr}, sink, nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 	_, err := tr.Append(0, goodLabels, time.Now().Unix()*1000, 1.0)
 	assert.NoError(t, err)
 	assert.ErrorIs(t, tr.Commit(), adjusterErr)
@@ -145,7 +262,7 @@ func TestTransactionCommitErrorWhenAdjusterError(t *testing.T) {
 // Ensure that we reject duplicate label keys. See https://github.com/open-telemetry/wg-prometheus/issues/44.
 func TestTransactionAppendDuplicateLabels(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 
 	dupLabels := labels.FromStrings(
 		model.InstanceLabel, "0.0.0.0:8855",
@@ -163,7 +280,7 @@ func TestTransactionAppendDuplicateLabels(t *testing.T) {
 
 func TestTransactionAppendHistogramNoLe(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 
 	goodLabels := labels.FromStrings(
 		model.InstanceLabel, "0.0.0.0:8855",
@@ -177,7 +294,7 @@ func TestTransactionAppendHistogramNoLe(t *testing.T) {
 
 func TestTransactionAppendSummaryNoQuantile(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
-	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 
 	goodLabels := labels.FromStrings(
 		model.InstanceLabel, "0.0.0.0:8855",
@@ -1026,7 +1143,7 @@ func (tt buildTestData) run(t *testing.T) {
 	st := ts
 	for i, page := range tt.inputs {
 		sink := new(consumertest.MetricsSink)
-		tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
+		tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, 0, KeepLast, JobInstanceResource, false, componenttest.NewNopReceiverCreateSettings(), nopObsRecv())
 		for _, pt := range page.pts {
 			// set ts for testing
 			pt.t = st
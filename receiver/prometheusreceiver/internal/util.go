@@ -53,6 +53,10 @@ var (
 	notUsefulLabelsHistogram = sortString([]string{model.MetricNameLabel, model.InstanceLabel, model.SchemeLabel, model.MetricsPathLabel, model.JobLabel, model.BucketLabel})
 	notUsefulLabelsSummary   = sortString([]string{model.MetricNameLabel, model.InstanceLabel, model.SchemeLabel, model.MetricsPathLabel, model.JobLabel, model.QuantileLabel})
 	notUsefulLabelsOther     = sortString([]string{model.MetricNameLabel, model.InstanceLabel, model.SchemeLabel, model.MetricsPathLabel, model.JobLabel})
+
+	notUsefulLabelsHistogramWithJobInstance = withoutJobInstance(notUsefulLabelsHistogram)
+	notUsefulLabelsSummaryWithJobInstance   = withoutJobInstance(notUsefulLabelsSummary)
+	notUsefulLabelsOtherWithJobInstance     = withoutJobInstance(notUsefulLabelsOther)
 )
 
 func sortString(strs []string) []string {
@@ -60,13 +64,36 @@ func sortString(strs []string) []string {
 	return strs
 }
 
-func getSortedNotUsefulLabels(mType pmetric.MetricType) []string {
+// withoutJobInstance returns the labels except the job and instance labels.
+func withoutJobInstance(names []string) []string {
+	var out []string
+	for _, name := range names {
+		if name != model.JobLabel && name != model.InstanceLabel {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// getSortedNotUsefulLabels returns the labels which aren't attributes of the data points, including
+// the job and instance labels unless they're set on the data points.
+func getSortedNotUsefulLabels(mType pmetric.MetricType, jobInstanceLabels JobInstanceLabels) []string {
+	withJobInstance := jobInstanceLabels == JobInstanceDataPoint
 	switch mType {
 	case pmetric.MetricTypeHistogram:
+		if withJobInstance {
+			return notUsefulLabelsHistogramWithJobInstance
+		}
 		return notUsefulLabelsHistogram
 	case pmetric.MetricTypeSummary:
+		if withJobInstance {
+			return notUsefulLabelsSummaryWithJobInstance
+		}
 		return notUsefulLabelsSummary
 	default:
+		if withJobInstance {
+			return notUsefulLabelsOtherWithJobInstance
+		}
 		return notUsefulLabelsOther
 	}
 }
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	mTargets        = stats.Int64("prometheus_receiver_targets", "Number of active scrape targets, by scrape pool and health", stats.UnitDimensionless)
+	mDroppedTargets = stats.Int64("prometheus_receiver_dropped_targets", "Number of targets dropped by relabeling, by scrape pool", stats.UnitDimensionless)
+
+	receiverTagKey   = tag.MustNewKey("receiver")
+	scrapePoolTagKey = tag.MustNewKey("scrape_pool")
+	healthTagKey     = tag.MustNewKey("health")
+)
+
+// MetricViews returns the metrics views of the Prometheus receiver.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mTargets.Name(),
+			Measure:     mTargets,
+			Description: mTargets.Description(),
+			Aggregation: view.LastValue(),
+			TagKeys: []tag.Key{
+				receiverTagKey,
+				scrapePoolTagKey,
+				healthTagKey,
+			},
+		},
+		{
+			Name:        mDroppedTargets.Name(),
+			Measure:     mDroppedTargets,
+			Description: mDroppedTargets.Description(),
+			Aggregation: view.LastValue(),
+			TagKeys: []tag.Key{
+				receiverTagKey,
+				scrapePoolTagKey,
+			},
+		},
+	}
+}
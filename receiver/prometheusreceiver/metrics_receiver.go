@@ -19,19 +19,23 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"os"
 	"regexp"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/go-kit/log"
 	"github.com/mitchellh/hashstructure/v2"
+	commonconfig "github.com/prometheus/common/config"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery"
 	promHTTP "github.com/prometheus/prometheus/discovery/http"
+	"github.com/prometheus/prometheus/model/relabel"
 	"github.com/prometheus/prometheus/scrape"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -44,6 +48,8 @@ import (
 const (
 	defaultGCInterval = 2 * time.Minute
 	gcIntervalDelta   = 1 * time.Minute
+
+	defaultScrapeConfigFileReloadInterval = 30 * time.Second
 )
 
 // pReceiver is the type that provides Prometheus scraper/receiver functionality.
@@ -52,9 +58,21 @@ type pReceiver struct {
 	consumer            consumer.Metrics
 	cancelFunc          context.CancelFunc
 	targetAllocatorStop chan struct{}
+	targetAllocatorJobs map[string]*targetAllocatorJob
+	baseScrapeConfigs   []*config.ScrapeConfig
 	configLoaded        chan struct{}
 	loadConfigOnce      sync.Once
 
+	// scrapeConfigsMu serializes the updates of the scrape configs by the target allocator
+	// and by the reloads of the scrape config file.
+	scrapeConfigsMu         sync.Mutex
+	scrapeConfigFileStop    chan struct{}
+	scrapeConfigFileContent []byte
+	fileScrapeConfigs       []*config.ScrapeConfig
+
+	apiServer   *http.Server
+	apiServerWG sync.WaitGroup
+
 	settings         component.ReceiverCreateSettings
 	scrapeManager    *scrape.Manager
 	discoveryManager *discovery.Manager
@@ -63,11 +81,12 @@ type pReceiver struct {
 // New creates a new prometheus.Receiver reference.
 func newPrometheusReceiver(set component.ReceiverCreateSettings, cfg *Config, next consumer.Metrics) *pReceiver {
 	pr := &pReceiver{
-		cfg:                 cfg,
-		consumer:            next,
-		settings:            set,
-		configLoaded:        make(chan struct{}),
-		targetAllocatorStop: make(chan struct{}),
+		cfg:                  cfg,
+		consumer:             next,
+		settings:             set,
+		configLoaded:         make(chan struct{}),
+		targetAllocatorStop:  make(chan struct{}),
+		scrapeConfigFileStop: make(chan struct{}),
 	}
 	return pr
 }
@@ -82,6 +101,17 @@ func (r *pReceiver) Start(_ context.Context, host component.Host) error {
 
 	// add scrape configs defined by the collector configs
 	baseCfg := r.cfg.PrometheusConfig
+	// the scrape configs defined in the collector configuration are kept along the ones
+	// of the scrape config file and the jobs retrieved from the target allocator
+	r.baseScrapeConfigs = baseCfg.ScrapeConfigs
+
+	fileConf := r.cfg.ScrapeConfigFile
+	if fileConf != nil {
+		if _, err := r.loadScrapeConfigFile(fileConf.Path); err != nil {
+			return err
+		}
+		baseCfg.ScrapeConfigs = r.scrapeConfigs(nil)
+	}
 
 	err := r.initPrometheusComponents(discoveryCtx, host, logger)
 	if err != nil {
@@ -103,6 +133,18 @@ func (r *pReceiver) Start(_ context.Context, host component.Host) error {
 		}
 	}
 
+	if fileConf != nil {
+		r.startScrapeConfigFileReload(fileConf, baseCfg)
+	}
+
+	go r.reportTargetMetrics(discoveryCtx)
+
+	if r.cfg.APIServer != nil {
+		if err = r.startAPIServer(host); err != nil {
+			return err
+		}
+	}
+
 	r.loadConfigOnce.Do(func() {
 		close(r.configLoaded)
 	})
@@ -113,23 +155,20 @@ func (r *pReceiver) Start(_ context.Context, host component.Host) error {
 func (r *pReceiver) startTargetAllocator(allocConf *targetAllocator, baseCfg *config.Config) error {
 	r.settings.Logger.Info("Starting target allocator discovery")
 	// immediately sync jobs, not waiting for the first tick
-	savedHash, err := r.syncTargetAllocator(uint64(0), allocConf, baseCfg)
-	if err != nil {
+	if err := r.syncTargetAllocator(allocConf, baseCfg); err != nil {
 		return err
 	}
 	go func() {
-		targetAllocatorIntervalTicker := time.NewTicker(allocConf.Interval)
+		targetAllocatorTimer := time.NewTimer(allocConf.Interval + jitter(allocConf.Jitter))
 		for {
 			select {
-			case <-targetAllocatorIntervalTicker.C:
-				hash, newErr := r.syncTargetAllocator(savedHash, allocConf, baseCfg)
-				if newErr != nil {
-					r.settings.Logger.Error(newErr.Error())
-					continue
+			case <-targetAllocatorTimer.C:
+				if err := r.syncTargetAllocator(allocConf, baseCfg); err != nil {
+					r.settings.Logger.Error(err.Error())
 				}
-				savedHash = hash
+				targetAllocatorTimer.Reset(allocConf.Interval + jitter(allocConf.Jitter))
 			case <-r.targetAllocatorStop:
-				targetAllocatorIntervalTicker.Stop()
+				targetAllocatorTimer.Stop()
 				r.settings.Logger.Info("Stopping target allocator")
 				return
 			}
@@ -138,55 +177,198 @@ func (r *pReceiver) startTargetAllocator(allocConf *targetAllocator, baseCfg *co
 	return nil
 }
 
-// syncTargetAllocator request jobs from targetAllocator and update underlying receiver, if the response does not match the provided compareHash.
-// baseDiscoveryCfg can be used to provide additional ScrapeConfigs which will be added to the retrieved jobs.
-func (r *pReceiver) syncTargetAllocator(compareHash uint64, allocConf *targetAllocator, baseCfg *config.Config) (uint64, error) {
+// jitter returns a random duration in [0, max).
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
+
+// targetAllocatorJob is a scrape job retrieved from the target allocator.
+type targetAllocatorJob struct {
+	// hash is the hash of the scrape config as returned by the target allocator.
+	hash         uint64
+	scrapeConfig *config.ScrapeConfig
+}
+
+// syncTargetAllocator request jobs from targetAllocator and update underlying receiver with the jobs that were
+// added, updated or removed since the last sync. Unchanged jobs keep the same scrape config, so that their
+// scrape loops are not restarted. The discovery manager restarts the service discovery of every job whenever
+// a configuration is applied, so nothing is applied when no job was added, updated or removed.
+// The base scrape configs defined in the collector configuration are added to the retrieved jobs.
+func (r *pReceiver) syncTargetAllocator(allocConf *targetAllocator, baseCfg *config.Config) error {
 	r.settings.Logger.Debug("Syncing target allocator jobs")
 	scrapeConfigsResponse, err := r.getScrapeConfigsResponse(allocConf.Endpoint)
 	if err != nil {
 		r.settings.Logger.Error("Failed to retrieve job list", zap.Error(err))
-		return 0, err
+		return err
 	}
 
-	hash, err := hashstructure.Hash(scrapeConfigsResponse, hashstructure.FormatV2, nil)
-	if err != nil {
-		r.settings.Logger.Error("Failed to hash job list", zap.Error(err))
-		return 0, err
+	r.scrapeConfigsMu.Lock()
+	defer r.scrapeConfigsMu.Unlock()
+
+	jobs := make(map[string]*targetAllocatorJob, len(scrapeConfigsResponse))
+	var added, updated, removed []string
+	for jobName, scrapeConfig := range scrapeConfigsResponse {
+		hash, hashErr := hashstructure.Hash(scrapeConfig, hashstructure.FormatV2, nil)
+		if hashErr != nil {
+			r.settings.Logger.Error("Failed to hash job", zap.String("jobName", jobName), zap.Error(hashErr))
+			return hashErr
+		}
+		if current, ok := r.targetAllocatorJobs[jobName]; ok && current.hash == hash {
+			jobs[jobName] = current
+			continue
+		}
+		if _, ok := r.targetAllocatorJobs[jobName]; ok {
+			updated = append(updated, jobName)
+		} else {
+			added = append(added, jobName)
+		}
+		jobs[jobName] = &targetAllocatorJob{
+			hash:         hash,
+			scrapeConfig: newTargetAllocatorScrapeConfig(allocConf, jobName, scrapeConfig),
+		}
+	}
+	for jobName := range r.targetAllocatorJobs {
+		if _, ok := jobs[jobName]; !ok {
+			removed = append(removed, jobName)
+		}
 	}
-	if hash == compareHash {
+	if r.targetAllocatorJobs != nil && len(added)+len(updated)+len(removed) == 0 {
 		// no update needed
-		return hash, nil
+		return nil
 	}
 
-	// Clear out the current configurations
-	baseCfg.ScrapeConfigs = []*config.ScrapeConfig{}
+	baseCfg.ScrapeConfigs = r.scrapeConfigs(jobs)
+	err = r.applyCfg(baseCfg)
+	if err != nil {
+		r.settings.Logger.Error("Failed to apply new scrape configuration", zap.Error(err))
+		return err
+	}
+	r.targetAllocatorJobs = jobs
+	sort.Strings(added)
+	sort.Strings(updated)
+	sort.Strings(removed)
+	r.settings.Logger.Info("Applied target allocator jobs",
+		zap.Strings("added", added),
+		zap.Strings("updated", updated),
+		zap.Strings("removed", removed))
+	return nil
+}
 
-	for jobName, scrapeConfig := range scrapeConfigsResponse {
-		var httpSD promHTTP.SDConfig
-		if allocConf.HTTPSDConfig == nil {
-			httpSD = promHTTP.SDConfig{
-				RefreshInterval: model.Duration(30 * time.Second),
+// scrapeConfigs returns the scrape configs defined in the collector configuration, followed by the ones
+// of the scrape config file and by the given target allocator jobs, sorted by name.
+func (r *pReceiver) scrapeConfigs(jobs map[string]*targetAllocatorJob) []*config.ScrapeConfig {
+	jobNames := make([]string, 0, len(jobs))
+	for jobName := range jobs {
+		jobNames = append(jobNames, jobName)
+	}
+	sort.Strings(jobNames)
+	scrapeConfigs := make([]*config.ScrapeConfig, 0, len(r.baseScrapeConfigs)+len(r.fileScrapeConfigs)+len(jobs))
+	scrapeConfigs = append(scrapeConfigs, r.baseScrapeConfigs...)
+	scrapeConfigs = append(scrapeConfigs, r.fileScrapeConfigs...)
+	for _, jobName := range jobNames {
+		scrapeConfigs = append(scrapeConfigs, jobs[jobName].scrapeConfig)
+	}
+	return scrapeConfigs
+}
+
+// startScrapeConfigFileReload checks the scrape config file for changes every reload interval, and
+// applies the new scrape configs when it changed.
+func (r *pReceiver) startScrapeConfigFileReload(fileConf *scrapeConfigFile, baseCfg *config.Config) {
+	interval := fileConf.ReloadInterval
+	if interval == 0 {
+		interval = defaultScrapeConfigFileReloadInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := r.reloadScrapeConfigFile(fileConf.Path, baseCfg); err != nil {
+					r.settings.Logger.Error("Failed to reload scrape config file, keeping the previous scrape configs",
+						zap.String("path", fileConf.Path), zap.Error(err))
+				}
+			case <-r.scrapeConfigFileStop:
+				return
 			}
-		} else {
-			httpSD = *allocConf.HTTPSDConfig
-		}
-		escapedJob := url.QueryEscape(jobName)
-		httpSD.URL = fmt.Sprintf("%s/jobs/%s/targets?collector_id=%s", allocConf.Endpoint, escapedJob, allocConf.CollectorID)
-		httpSD.HTTPClientConfig.FollowRedirects = false
-		scrapeConfig.ServiceDiscoveryConfigs = discovery.Configs{
-			&httpSD,
 		}
+	}()
+}
 
-		baseCfg.ScrapeConfigs = append(baseCfg.ScrapeConfigs, scrapeConfig)
+// reloadScrapeConfigFile applies the scrape configs of the scrape config file if it changed. The scrape manager
+// only restarts the scrape loops of the jobs whose scrape config changed, so that the other jobs keep their
+// targets and staleness tracking.
+func (r *pReceiver) reloadScrapeConfigFile(path string, baseCfg *config.Config) error {
+	r.scrapeConfigsMu.Lock()
+	defer r.scrapeConfigsMu.Unlock()
+
+	previous := r.fileScrapeConfigs
+	changed, err := r.loadScrapeConfigFile(path)
+	if err != nil || !changed {
+		return err
 	}
 
-	err = r.applyCfg(baseCfg)
+	baseCfg.ScrapeConfigs = r.scrapeConfigs(r.targetAllocatorJobs)
+	if err = r.applyCfg(baseCfg); err != nil {
+		// retry at the next reload, even if the file doesn't change
+		r.fileScrapeConfigs = previous
+		r.scrapeConfigFileContent = nil
+		baseCfg.ScrapeConfigs = r.scrapeConfigs(r.targetAllocatorJobs)
+		return err
+	}
+	r.settings.Logger.Info("Reloaded scrape config file", zap.String("path", path))
+	return nil
+}
+
+// loadScrapeConfigFile loads the scrape configs of the scrape config file, and reports whether its
+// content changed since the previous load.
+func (r *pReceiver) loadScrapeConfigFile(path string) (bool, error) {
+	content, fileCfg, err := loadScrapeConfigFile(path)
 	if err != nil {
-		r.settings.Logger.Error("Failed to apply new scrape configuration", zap.Error(err))
-		return 0, err
+		return false, err
+	}
+	if r.scrapeConfigFileContent != nil && bytes.Equal(content, r.scrapeConfigFileContent) {
+		return false, nil
+	}
+	if err = validateScrapeConfigs(fileCfg); err != nil {
+		return false, err
+	}
+	if err = checkDuplicateJobs(r.baseScrapeConfigs, fileCfg.ScrapeConfigs); err != nil {
+		return false, err
+	}
+	r.fileScrapeConfigs = fileCfg.ScrapeConfigs
+	r.scrapeConfigFileContent = content
+	return true, nil
+}
+
+// newTargetAllocatorScrapeConfig completes a scrape config retrieved from the target allocator
+// with the discovery of the targets assigned to this collector.
+func newTargetAllocatorScrapeConfig(allocConf *targetAllocator, jobName string, scrapeConfig *config.ScrapeConfig) *config.ScrapeConfig {
+	var httpSD promHTTP.SDConfig
+	if allocConf.HTTPSDConfig == nil {
+		httpSD = promHTTP.SDConfig{
+			RefreshInterval: model.Duration(30 * time.Second),
+		}
+	} else {
+		httpSD = *allocConf.HTTPSDConfig
+	}
+	escapedJob := url.QueryEscape(jobName)
+	httpSD.URL = fmt.Sprintf("%s/jobs/%s/targets?collector_id=%s", allocConf.Endpoint, escapedJob, allocConf.CollectorID)
+	httpSD.HTTPClientConfig.FollowRedirects = false
+	scrapeConfig.ServiceDiscoveryConfigs = discovery.Configs{
+		&httpSD,
 	}
 
-	return hash, nil
+	if allocConf.ShardLabel != "" {
+		shardRelabelConfig := relabel.DefaultRelabelConfig
+		shardRelabelConfig.TargetLabel = allocConf.ShardLabel
+		shardRelabelConfig.Replacement = allocConf.CollectorID
+		scrapeConfig.RelabelConfigs = append(scrapeConfig.RelabelConfigs, &shardRelabelConfig)
+	}
+	return scrapeConfig
 }
 
 // instantiateShard inserts the SHARD environment variable in the returned configuration
@@ -272,8 +454,16 @@ func (r *pReceiver) initPrometheusComponents(ctx context.Context, host component
 		startTimeMetricRegex,
 		r.cfg.ID(),
 		r.cfg.PrometheusConfig.GlobalConfig.ExternalLabels,
+		r.cfg.OutOfOrderTolerance,
+		duplicateSamplePolicy(r.cfg.DuplicateSamples),
+		jobInstanceLabels(r.cfg.JobInstanceLabels),
+		r.cfg.CreateTargetInfo,
 	)
-	r.scrapeManager = scrape.NewManager(&scrape.Options{PassMetadataInContext: true}, logger, store)
+	opts := &scrape.Options{PassMetadataInContext: true}
+	if len(r.cfg.UnixSockets) > 0 {
+		opts.HTTPClientOptions = append(opts.HTTPClientOptions, commonconfig.WithDialContextFunc(newUnixSocketDialContext(r.cfg.UnixSockets)))
+	}
+	r.scrapeManager = scrape.NewManager(opts, logger, store)
 
 	go func() {
 		// The scrape manager needs to wait for the configuration to be loaded before beginning
@@ -287,6 +477,22 @@ func (r *pReceiver) initPrometheusComponents(ctx context.Context, host component
 	return nil
 }
 
+// duplicateSamplePolicy returns the configured policy for the duplicate samples, keeping the last sample by default.
+func duplicateSamplePolicy(policy string) internal.DuplicateSamplePolicy {
+	if policy == "" {
+		return internal.KeepLast
+	}
+	return internal.DuplicateSamplePolicy(policy)
+}
+
+// jobInstanceLabels returns where the job and instance labels are set, on the resource by default.
+func jobInstanceLabels(setting string) internal.JobInstanceLabels {
+	if setting == "" {
+		return internal.JobInstanceResource
+	}
+	return internal.JobInstanceLabels(setting)
+}
+
 // gcInterval returns the longest scrape interval used by a scrape config,
 // plus a delta to prevent race conditions.
 // This ensures jobs are not garbage collected between scrapes.
@@ -308,5 +514,11 @@ func (r *pReceiver) Shutdown(context.Context) error {
 	r.cancelFunc()
 	r.scrapeManager.Stop()
 	close(r.targetAllocatorStop)
+	close(r.scrapeConfigFileStop)
+	if r.apiServer != nil {
+		err := r.apiServer.Close()
+		r.apiServerWG.Wait()
+		return err
+	}
 	return nil
 }
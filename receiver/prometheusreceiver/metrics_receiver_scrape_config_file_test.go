@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	promConfig "github.com/prometheus/prometheus/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestScrapeConfigFileReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scrape_configs.yaml")
+	writeScrapeConfigs := func(content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	writeScrapeConfigs(`
+scrape_configs:
+  - job_name: file1
+    scrape_interval: 30s
+  - job_name: file2
+    scrape_interval: 30s
+`)
+
+	baseScrapeConfig := &promConfig.ScrapeConfig{
+		JobName:        "demo",
+		ScrapeInterval: model.Duration(30 * time.Second),
+		ScrapeTimeout:  model.Duration(30 * time.Second),
+		MetricsPath:    "/metrics",
+		Scheme:         "http",
+	}
+	cfg := &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		PrometheusConfig: &promConfig.Config{ScrapeConfigs: []*promConfig.ScrapeConfig{baseScrapeConfig}},
+		ScrapeConfigFile: &scrapeConfigFile{
+			Path:           path,
+			ReloadInterval: time.Hour,
+		},
+	}
+	receiver := newPrometheusReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, new(consumertest.MetricsSink))
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	})
+
+	jobNames := func() []string {
+		var names []string
+		for _, sc := range cfg.PrometheusConfig.ScrapeConfigs {
+			names = append(names, sc.JobName)
+		}
+		return names
+	}
+	require.Equal(t, []string{"demo", "file1", "file2"}, jobNames())
+
+	// an unchanged file is not applied again
+	file1 := cfg.PrometheusConfig.ScrapeConfigs[1]
+	require.NoError(t, receiver.reloadScrapeConfigFile(path, cfg.PrometheusConfig))
+	require.Same(t, file1, cfg.PrometheusConfig.ScrapeConfigs[1])
+
+	// file2 is removed and file3 is added
+	writeScrapeConfigs(`
+scrape_configs:
+  - job_name: file1
+    scrape_interval: 30s
+  - job_name: file3
+    scrape_interval: 10s
+`)
+	require.NoError(t, receiver.reloadScrapeConfigFile(path, cfg.PrometheusConfig))
+	require.Equal(t, []string{"demo", "file1", "file3"}, jobNames())
+	require.Same(t, baseScrapeConfig, cfg.PrometheusConfig.ScrapeConfigs[0], "base scrape configs must be kept")
+
+	// invalid files and jobs already defined in the config are rejected, keeping the previous scrape configs
+	writeScrapeConfigs("scrape_configs: [{job_name: file1, unknown: true}]\n")
+	require.Error(t, receiver.reloadScrapeConfigFile(path, cfg.PrometheusConfig))
+	require.Equal(t, []string{"demo", "file1", "file3"}, jobNames())
+
+	writeScrapeConfigs("scrape_configs: [{job_name: demo}]\n")
+	require.EqualError(t, receiver.reloadScrapeConfigFile(path, cfg.PrometheusConfig), `job "demo" of scrape_config_file is already defined in config`)
+	require.Equal(t, []string{"demo", "file1", "file3"}, jobNames())
+
+	// the file may remove all its jobs
+	writeScrapeConfigs("scrape_configs: []\n")
+	require.NoError(t, receiver.reloadScrapeConfigFile(path, cfg.PrometheusConfig))
+	require.Equal(t, []string{"demo"}, jobNames())
+}
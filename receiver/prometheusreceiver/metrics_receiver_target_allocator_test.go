@@ -555,3 +555,88 @@ func TestTargetAllocatorJobRetrieval(t *testing.T) {
 		})
 	}
 }
+
+func TestTargetAllocatorIncrementalSync(t *testing.T) {
+	var mu sync.Mutex
+	scrapeConfigs := map[string]map[string]interface{}{
+		"job1": {"job_name": "job1", "scrape_interval": "30s", "scrape_timeout": "30s", "metrics_path": "/metrics", "scheme": "http"},
+		"job2": {"job_name": "job2", "scrape_interval": "30s", "scrape_timeout": "30s", "metrics_path": "/metrics", "scheme": "http"},
+	}
+	allocator := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		rw.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/scrape_configs" {
+			require.NoError(t, json.NewEncoder(rw).Encode(scrapeConfigs))
+			return
+		}
+		_, _ = rw.Write([]byte("[]"))
+	}))
+	defer allocator.Close()
+
+	baseScrapeConfig := &promConfig.ScrapeConfig{
+		JobName:        "demo",
+		ScrapeInterval: model.Duration(30 * time.Second),
+		ScrapeTimeout:  model.Duration(30 * time.Second),
+		MetricsPath:    "/metrics",
+		Scheme:         "http",
+	}
+	cfg := &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		PrometheusConfig: &promConfig.Config{ScrapeConfigs: []*promConfig.ScrapeConfig{baseScrapeConfig}},
+		TargetAllocator: &targetAllocator{
+			Endpoint:    allocator.URL,
+			Interval:    time.Hour,
+			CollectorID: "collector-1",
+			ShardLabel:  "collector_shard",
+		},
+	}
+	receiver := newPrometheusReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, new(consumertest.MetricsSink))
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	})
+
+	jobNames := func() []string {
+		var names []string
+		for _, sc := range cfg.PrometheusConfig.ScrapeConfigs {
+			names = append(names, sc.JobName)
+		}
+		return names
+	}
+	require.Equal(t, []string{"demo", "job1", "job2"}, jobNames())
+
+	job1 := receiver.targetAllocatorJobs["job1"].scrapeConfig
+	require.Len(t, job1.RelabelConfigs, 1)
+	require.Equal(t, "collector_shard", job1.RelabelConfigs[0].TargetLabel)
+	require.Equal(t, "collector-1", job1.RelabelConfigs[0].Replacement)
+
+	// job1 is unchanged, job2 is removed and job3 is added
+	mu.Lock()
+	delete(scrapeConfigs, "job2")
+	scrapeConfigs["job3"] = map[string]interface{}{"job_name": "job3", "scrape_interval": "10s", "scrape_timeout": "10s", "metrics_path": "/metrics", "scheme": "http"}
+	mu.Unlock()
+	require.NoError(t, receiver.syncTargetAllocator(cfg.TargetAllocator, cfg.PrometheusConfig))
+
+	require.Equal(t, []string{"demo", "job1", "job3"}, jobNames())
+	require.Same(t, job1, receiver.targetAllocatorJobs["job1"].scrapeConfig, "unchanged jobs must keep their scrape config")
+	require.Same(t, baseScrapeConfig, cfg.PrometheusConfig.ScrapeConfigs[0], "base scrape configs must be kept")
+
+	// job1 is updated
+	mu.Lock()
+	scrapeConfigs["job1"]["scrape_interval"] = "15s"
+	scrapeConfigs["job1"]["scrape_timeout"] = "15s"
+	mu.Unlock()
+	require.NoError(t, receiver.syncTargetAllocator(cfg.TargetAllocator, cfg.PrometheusConfig))
+	require.NotSame(t, job1, receiver.targetAllocatorJobs["job1"].scrapeConfig)
+	require.Equal(t, model.Duration(15*time.Second), receiver.targetAllocatorJobs["job1"].scrapeConfig.ScrapeInterval)
+}
+
+func TestJitter(t *testing.T) {
+	require.Equal(t, time.Duration(0), jitter(0))
+	for i := 0; i < 100; i++ {
+		d := jitter(time.Second)
+		require.GreaterOrEqual(t, d, time.Duration(0))
+		require.Less(t, d, time.Second)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	promConfig "github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestTargetsAPI(t *testing.T) {
+	// the views of the metrics are registered by the factory
+	NewFactory()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("# TYPE foo gauge\nfoo 1\n"))
+	}))
+	defer target.Close()
+	targetURL, err := url.Parse(target.URL)
+	require.NoError(t, err)
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL, err := url.Parse(closed.URL)
+	require.NoError(t, err)
+	closed.Close()
+
+	scrapeConfig := func(job string, addresses ...string) *promConfig.ScrapeConfig {
+		group := &targetgroup.Group{}
+		for _, address := range addresses {
+			group.Targets = append(group.Targets, model.LabelSet{model.AddressLabel: model.LabelValue(address)})
+		}
+		return &promConfig.ScrapeConfig{
+			JobName:                 job,
+			ScrapeInterval:          model.Duration(100 * time.Millisecond),
+			ScrapeTimeout:           model.Duration(100 * time.Millisecond),
+			MetricsPath:             "/metrics",
+			Scheme:                  "http",
+			ServiceDiscoveryConfigs: discovery.Configs{discovery.StaticConfig{group}},
+			RelabelConfigs: []*relabel.Config{{
+				SourceLabels: model.LabelNames{model.AddressLabel},
+				Regex:        relabel.MustNewRegexp("dropped:.*"),
+				Action:       relabel.Drop,
+			}},
+		}
+	}
+	cfg := &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "targets")),
+		PrometheusConfig: &promConfig.Config{ScrapeConfigs: []*promConfig.ScrapeConfig{
+			scrapeConfig("good", targetURL.Host, "dropped:1234"),
+			scrapeConfig("bad", closedURL.Host),
+		}},
+		APIServer: &confighttp.HTTPServerSettings{Endpoint: "localhost:0"},
+	}
+	receiver := newPrometheusReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, new(consumertest.MetricsSink))
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	})
+
+	getTargets := func(query string) targetsResponse {
+		rec := httptest.NewRecorder()
+		receiver.handleTargets(rec, httptest.NewRequest(http.MethodGet, targetsAPIPath+query, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var resp targetsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp
+	}
+
+	require.Eventually(t, func() bool {
+		targets := getTargets("").Data.ActiveTargets
+		return len(targets) == 2 && targets[0].Health != "unknown" && targets[1].Health != "unknown"
+	}, 10*time.Second, 50*time.Millisecond)
+
+	resp := getTargets("")
+	assert.Equal(t, "success", resp.Status)
+	require.Len(t, resp.Data.ActiveTargets, 2)
+	bad, good := resp.Data.ActiveTargets[0], resp.Data.ActiveTargets[1]
+	assert.Equal(t, "bad", bad.ScrapePool)
+	assert.Equal(t, "http://"+closedURL.Host+"/metrics", bad.ScrapeURL)
+	assert.Equal(t, "down", string(bad.Health))
+	assert.NotEmpty(t, bad.LastError)
+	assert.Equal(t, "good", good.ScrapePool)
+	assert.Equal(t, "http://"+targetURL.Host+"/metrics", good.ScrapeURL)
+	assert.Equal(t, "up", string(good.Health))
+	assert.Empty(t, good.LastError)
+	assert.Equal(t, "good", good.Labels["job"])
+	assert.Equal(t, targetURL.Host, good.Labels["instance"])
+	assert.Equal(t, targetURL.Host, good.DiscoveredLabels[model.AddressLabel])
+	assert.False(t, good.LastScrape.IsZero())
+	require.Len(t, resp.Data.DroppedTargets, 1)
+	assert.Equal(t, "dropped:1234", resp.Data.DroppedTargets[0].DiscoveredLabels[model.AddressLabel])
+
+	resp = getTargets("?state=active&scrapePool=good")
+	require.Len(t, resp.Data.ActiveTargets, 1)
+	assert.Equal(t, "good", resp.Data.ActiveTargets[0].ScrapePool)
+	assert.Empty(t, resp.Data.DroppedTargets)
+
+	resp = getTargets("?state=dropped")
+	assert.Empty(t, resp.Data.ActiveTargets)
+	assert.Len(t, resp.Data.DroppedTargets, 1)
+
+	rec := httptest.NewRecorder()
+	receiver.handleTargets(rec, httptest.NewRequest(http.MethodGet, targetsAPIPath+"?state=unknown", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = httptest.NewRecorder()
+	receiver.handleTargets(rec, httptest.NewRequest(http.MethodPost, targetsAPIPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	// the metrics report the targets by scrape pool and health, ignoring the ones of the other tests
+	reported := receiver.recordTargetMetrics(context.Background(), map[string]struct{}{"removed": {}})
+	assert.Equal(t, map[string]struct{}{"good": {}, "bad": {}}, reported)
+	targetCounts := map[string]float64{}
+	rows, err := view.RetrieveData(mTargets.Name())
+	require.NoError(t, err)
+	for _, row := range rows {
+		var receiverID, pool, health string
+		for _, tg := range row.Tags {
+			switch tg.Key {
+			case receiverTagKey:
+				receiverID = tg.Value
+			case scrapePoolTagKey:
+				pool = tg.Value
+			case healthTagKey:
+				health = tg.Value
+			}
+		}
+		if receiverID == cfg.ID().String() {
+			targetCounts[pool+"/"+health] = row.Data.(*view.LastValueData).Value
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"good/up": 1, "good/down": 0, "good/unknown": 0,
+		"bad/up": 0, "bad/down": 1, "bad/unknown": 0,
+		"removed/up": 0, "removed/down": 0, "removed/unknown": 0,
+	}, targetCounts)
+	rows, err = view.RetrieveData(mDroppedTargets.Name())
+	require.NoError(t, err)
+	droppedCounts := map[string]float64{}
+	for _, row := range rows {
+		var receiverID, pool string
+		for _, tg := range row.Tags {
+			switch tg.Key {
+			case receiverTagKey:
+				receiverID = tg.Value
+			case scrapePoolTagKey:
+				pool = tg.Value
+			}
+		}
+		if receiverID == cfg.ID().String() {
+			droppedCounts[pool] = row.Data.(*view.LastValueData).Value
+		}
+	}
+	assert.Equal(t, map[string]float64{"good": 1, "bad": 0, "removed": 0}, droppedCounts)
+}
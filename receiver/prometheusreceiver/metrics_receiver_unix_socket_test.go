@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	promConfig "github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestScrapeUnixSocket(t *testing.T) {
+	// the path of a socket is limited to about a hundred bytes, which the test temporary directory may exceed
+	dir, err := os.MkdirTemp("", "prom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "exporter.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("# TYPE foo gauge\nfoo 1\n"))
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	cfg := &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		PrometheusConfig: &promConfig.Config{ScrapeConfigs: []*promConfig.ScrapeConfig{{
+			JobName:        "exporter",
+			ScrapeInterval: model.Duration(100 * time.Millisecond),
+			ScrapeTimeout:  model.Duration(100 * time.Millisecond),
+			MetricsPath:    "/metrics",
+			Scheme:         "http",
+			ServiceDiscoveryConfigs: discovery.Configs{discovery.StaticConfig{&targetgroup.Group{
+				Targets: []model.LabelSet{{model.AddressLabel: "exporter:9100"}},
+			}}},
+		}}},
+		UnixSockets: map[string]string{"exporter:9100": "unix://" + socket},
+	}
+	sink := new(consumertest.MetricsSink)
+	receiver := newPrometheusReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	})
+
+	hasMetric := func(name string) bool {
+		for _, md := range sink.AllMetrics() {
+			rms := md.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				sms := rms.At(i).ScopeMetrics()
+				for j := 0; j < sms.Len(); j++ {
+					ms := sms.At(j).Metrics()
+					for k := 0; k < ms.Len(); k++ {
+						if ms.At(k).Name() == name {
+							return true
+						}
+					}
+				}
+			}
+		}
+		return false
+	}
+	require.Eventually(t, func() bool { return hasMetric("foo") }, 10*time.Second, 50*time.Millisecond)
+
+	var up pmetric.Metric
+	md := sink.AllMetrics()[0]
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() == "up" {
+			up = ms.At(i)
+		}
+	}
+	require.Equal(t, 1.0, up.Gauge().DataPoints().At(0).DoubleValue())
+	instance, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("service.instance.id")
+	require.True(t, ok)
+	require.Equal(t, "exporter:9100", instance.Str())
+}
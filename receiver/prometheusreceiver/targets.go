@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/prometheus/prometheus/scrape"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+const (
+	targetsAPIPath = "/api/v1/targets"
+
+	// targetMetricsInterval is how often the metrics of the scrape targets are recorded.
+	targetMetricsInterval = 15 * time.Second
+)
+
+var targetHealths = []scrape.TargetHealth{scrape.HealthGood, scrape.HealthBad, scrape.HealthUnknown}
+
+// targetsResponse is the response of the targets API, in the format of the targets API of Prometheus.
+type targetsResponse struct {
+	Status string      `json:"status"`
+	Data   targetsData `json:"data"`
+}
+
+type targetsData struct {
+	ActiveTargets  []activeTarget  `json:"activeTargets"`
+	DroppedTargets []droppedTarget `json:"droppedTargets"`
+}
+
+type activeTarget struct {
+	DiscoveredLabels map[string]string `json:"discoveredLabels"`
+	Labels           map[string]string `json:"labels"`
+	ScrapePool       string            `json:"scrapePool"`
+	ScrapeURL        string            `json:"scrapeUrl"`
+	LastError        string            `json:"lastError"`
+	LastScrape       time.Time         `json:"lastScrape"`
+	// LastScrapeDuration is the duration of the last scrape, in seconds.
+	LastScrapeDuration float64             `json:"lastScrapeDuration"`
+	Health             scrape.TargetHealth `json:"health"`
+}
+
+type droppedTarget struct {
+	DiscoveredLabels map[string]string `json:"discoveredLabels"`
+}
+
+// startAPIServer starts the HTTP server of the targets API.
+func (r *pReceiver) startAPIServer(host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(targetsAPIPath, r.handleTargets)
+
+	var err error
+	r.apiServer, err = r.cfg.APIServer.ToServer(host, r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	var listener net.Listener
+	listener, err = r.cfg.APIServer.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.cfg.APIServer.Endpoint, err)
+	}
+	r.apiServerWG.Add(1)
+	go func() {
+		defer r.apiServerWG.Done()
+
+		if errHTTP := r.apiServer.Serve(listener); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// handleTargets reports the scrape targets. As with Prometheus, the state query parameter selects the
+// active, dropped or any targets, and the scrapePool query parameter the targets of a scrape pool.
+func (r *pReceiver) handleTargets(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	state := req.URL.Query().Get("state")
+	switch state {
+	case "":
+		state = "any"
+	case "active", "dropped", "any":
+	default:
+		http.Error(w, fmt.Sprintf("invalid state %q", state), http.StatusBadRequest)
+		return
+	}
+	scrapePool := req.URL.Query().Get("scrapePool")
+
+	data := targetsData{
+		ActiveTargets:  []activeTarget{},
+		DroppedTargets: []droppedTarget{},
+	}
+	if state != "dropped" {
+		forEachTarget(r.scrapeManager.TargetsActive(), scrapePool, func(pool string, t *scrape.Target) {
+			target := activeTarget{
+				DiscoveredLabels:   t.DiscoveredLabels().Map(),
+				Labels:             t.Labels().Map(),
+				ScrapePool:         pool,
+				ScrapeURL:          t.URL().String(),
+				LastScrape:         t.LastScrape(),
+				LastScrapeDuration: t.LastScrapeDuration().Seconds(),
+				Health:             t.Health(),
+			}
+			if err := t.LastError(); err != nil {
+				target.LastError = err.Error()
+			}
+			data.ActiveTargets = append(data.ActiveTargets, target)
+		})
+	}
+	if state != "active" {
+		forEachTarget(r.scrapeManager.TargetsDropped(), scrapePool, func(_ string, t *scrape.Target) {
+			data.DroppedTargets = append(data.DroppedTargets, droppedTarget{DiscoveredLabels: t.DiscoveredLabels().Map()})
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(targetsResponse{Status: "success", Data: data}); err != nil {
+		r.settings.Logger.Debug("Failed to write the targets response", zap.Error(err))
+	}
+}
+
+// forEachTarget calls fn for the targets of every scrape pool, or of the given scrape pool if any,
+// sorted by scrape pool.
+func forEachTarget(targets map[string][]*scrape.Target, scrapePool string, fn func(string, *scrape.Target)) {
+	pools := make([]string, 0, len(targets))
+	for pool := range targets {
+		if scrapePool == "" || pool == scrapePool {
+			pools = append(pools, pool)
+		}
+	}
+	sort.Strings(pools)
+	for _, pool := range pools {
+		for _, t := range targets[pool] {
+			fn(pool, t)
+		}
+	}
+}
+
+// reportTargetMetrics records the number of targets of each scrape pool every targetMetricsInterval,
+// until the context is done.
+func (r *pReceiver) reportTargetMetrics(ctx context.Context) {
+	ticker := time.NewTicker(targetMetricsInterval)
+	defer ticker.Stop()
+	// the scrape pools reported by the previous recording, which are reported as empty once removed
+	reported := map[string]struct{}{}
+	for {
+		select {
+		case <-ticker.C:
+			reported = r.recordTargetMetrics(ctx, reported)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// recordTargetMetrics records the number of targets of the current scrape pools, and of the given
+// previously reported ones, which are reported as empty once removed. It returns the current scrape pools.
+func (r *pReceiver) recordTargetMetrics(ctx context.Context, previous map[string]struct{}) map[string]struct{} {
+	counts := map[string]map[scrape.TargetHealth]int64{}
+	for pool, targets := range r.scrapeManager.TargetsActive() {
+		counts[pool] = map[scrape.TargetHealth]int64{}
+		for _, t := range targets {
+			counts[pool][t.Health()]++
+		}
+	}
+	dropped := map[string]int64{}
+	for pool, targets := range r.scrapeManager.TargetsDropped() {
+		dropped[pool] = int64(len(targets))
+	}
+
+	current := make(map[string]struct{}, len(counts)+len(dropped))
+	for pool := range counts {
+		current[pool] = struct{}{}
+	}
+	for pool := range dropped {
+		current[pool] = struct{}{}
+	}
+	pools := make(map[string]struct{}, len(current)+len(previous))
+	for pool := range current {
+		pools[pool] = struct{}{}
+	}
+	for pool := range previous {
+		pools[pool] = struct{}{}
+	}
+
+	receiverMutator := tag.Upsert(receiverTagKey, r.cfg.ID().String())
+	for pool := range pools {
+		poolMutator := tag.Upsert(scrapePoolTagKey, pool)
+		for _, health := range targetHealths {
+			_ = stats.RecordWithTags(ctx,
+				[]tag.Mutator{receiverMutator, poolMutator, tag.Upsert(healthTagKey, string(health))},
+				mTargets.M(counts[pool][health]))
+		}
+		_ = stats.RecordWithTags(ctx, []tag.Mutator{receiverMutator, poolMutator}, mDroppedTargets.M(dropped[pool]))
+	}
+	return current
+}
@@ -62,6 +62,10 @@ type receiverTemplate struct {
 // resourceAttributes holds a map of default resource attributes for each Endpoint type.
 type resourceAttributes map[observer.EndpointType]map[string]string
 
+// labelsAsResourceAttributes holds the prefix of the resource attributes set from the labels
+// of the endpoints, for each Endpoint type.
+type labelsAsResourceAttributes map[observer.EndpointType]string
+
 // newReceiverTemplate creates a receiverTemplate instance from the full name of a subreceiver
 // and its arbitrary config map values.
 func newReceiverTemplate(name string, cfg userConfigMap) (receiverTemplate, error) {
@@ -90,6 +94,9 @@ type Config struct {
 	// ResourceAttributes is a map of default resource attributes to add to each resource
 	// object received by this receiver from dynamically created receivers.
 	ResourceAttributes resourceAttributes `mapstructure:"resource_attributes"`
+	// LabelsAsResourceAttributes sets every label of the endpoints, such as the labels of a pod, as
+	// a resource attribute named after the label, prefixed by the prefix of the endpoint type.
+	LabelsAsResourceAttributes labelsAsResourceAttributes `mapstructure:"labels_as_resource_attributes"`
 }
 
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
@@ -110,6 +117,17 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 		}
 	}
 
+	for endpointType, prefix := range cfg.LabelsAsResourceAttributes {
+		switch endpointType {
+		case observer.ContainerType, observer.K8sNodeType, observer.PodType, observer.PortType:
+		default:
+			return fmt.Errorf("labels as resource attributes for unsupported endpoint type %q", endpointType)
+		}
+		if prefix == "" {
+			return fmt.Errorf("labels as resource attributes for endpoint type %q must have a prefix", endpointType)
+		}
+	}
+
 	receiversCfg, err := componentParser.Sub(receiversConfigKey)
 	if err != nil {
 		return fmt.Errorf("unable to extract key %v: %w", receiversConfigKey, err)
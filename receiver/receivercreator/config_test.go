@@ -111,6 +111,10 @@ func TestLoadConfig(t *testing.T) {
 					observer.HostPortType:  {"hostport.key": "hostport.value"},
 					observer.K8sNodeType:   {"k8s.node.key": "k8s.node.value"},
 				},
+				LabelsAsResourceAttributes: labelsAsResourceAttributes{
+					observer.PodType:  "k8s.pod.labels.",
+					observer.PortType: "k8s.pod.labels.",
+				},
 			},
 		},
 	}
@@ -143,6 +147,19 @@ func TestInvalidResourceAttributeEndpointType(t *testing.T) {
 	require.Nil(t, cfg)
 }
 
+func TestInvalidLabelsAsResourceAttributesEndpointType(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.Nil(t, err)
+
+	factories.Receivers[("nop")] = &nopWithEndpointFactory{ReceiverFactory: componenttest.NewNopReceiverFactory()}
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "invalid-labels-as-resource-attributes.yaml"), factories)
+	require.EqualError(t, err, "error reading receivers configuration for \"receiver_creator\": labels as resource attributes for unsupported endpoint type \"hostport\"")
+	require.Nil(t, cfg)
+}
+
 func TestInvalidReceiverResourceAttributeValueType(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	require.Nil(t, err)
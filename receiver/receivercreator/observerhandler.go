@@ -129,6 +129,7 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 			// as telemetry is emitted.
 			resourceEnhancer, err := newResourceEnhancer(
 				obs.config.ResourceAttributes,
+				obs.config.LabelsAsResourceAttributes,
 				resAttrs,
 				env,
 				e,
@@ -36,6 +36,7 @@ type resourceEnhancer struct {
 
 func newResourceEnhancer(
 	resources resourceAttributes,
+	labelsPrefixes labelsAsResourceAttributes,
 	receiverAttributes map[string]string,
 	env observer.EndpointEnv,
 	endpoint observer.Endpoint,
@@ -43,6 +44,15 @@ func newResourceEnhancer(
 ) (*resourceEnhancer, error) {
 	attrs := map[string]string{}
 
+	// The labels have the lowest precedence, and are overridden by the configured resource attributes.
+	if prefix, ok := labelsPrefixes[endpoint.Details.Type()]; ok {
+		for label, val := range endpointLabels(endpoint.Details) {
+			if val != "" {
+				attrs[prefix+label] = val
+			}
+		}
+	}
+
 	for _, resource := range []map[string]string{resources[endpoint.Details.Type()], receiverAttributes} {
 		// Precompute values that will be inserted for each resource object passed through.
 		for attr, expr := range resource {
@@ -70,6 +80,21 @@ func newResourceEnhancer(
 	}, nil
 }
 
+// endpointLabels returns the labels of the endpoint, which are the labels of the pod of a port.
+func endpointLabels(details observer.EndpointDetails) map[string]string {
+	switch d := details.(type) {
+	case *observer.Pod:
+		return d.Labels
+	case *observer.Port:
+		return d.Pod.Labels
+	case *observer.Container:
+		return d.Labels
+	case *observer.K8sNode:
+		return d.Labels
+	}
+	return nil
+}
+
 func (r *resourceEnhancer) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
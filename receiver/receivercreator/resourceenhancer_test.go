@@ -45,6 +45,7 @@ func Test_newResourceEnhancer(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	type args struct {
 		resources          resourceAttributes
+		labelsPrefixes     labelsAsResourceAttributes
 		resourceAttributes map[string]string
 		env                observer.EndpointEnv
 		endpoint           observer.Endpoint
@@ -109,6 +110,73 @@ func Test_newResourceEnhancer(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "pod labels",
+			args: args{
+				resources:      cfg.ResourceAttributes,
+				labelsPrefixes: labelsAsResourceAttributes{observer.PodType: "k8s.pod.labels."},
+				env:            podEnv,
+				endpoint:       podEndpoint,
+				nextConsumer:   &consumertest.MetricsSink{},
+			},
+			want: &resourceEnhancer{
+				nextConsumer: &consumertest.MetricsSink{},
+				attrs: map[string]string{
+					"k8s.pod.uid":           "uid-1",
+					"k8s.pod.name":          "pod-1",
+					"k8s.namespace.name":    "default",
+					"k8s.pod.labels.app":    "redis",
+					"k8s.pod.labels.region": "west-1",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "port labels and metadata",
+			args: args{
+				resources:      cfg.ResourceAttributes,
+				labelsPrefixes: labelsAsResourceAttributes{observer.PodType: "ignored.", observer.PortType: "k8s.pod.labels."},
+				resourceAttributes: map[string]string{
+					"k8s.pod.labels.app": "`pod.annotations['scrape'] == 'true' ? pod.labels['app'] + '-scraped' : pod.labels['app']`",
+					"port.name":          "`name`",
+				},
+				env:          portEnv,
+				endpoint:     portEndpoint,
+				nextConsumer: &consumertest.MetricsSink{},
+			},
+			want: &resourceEnhancer{
+				nextConsumer: &consumertest.MetricsSink{},
+				attrs: map[string]string{
+					"k8s.pod.uid":           "uid-1",
+					"k8s.pod.name":          "pod-1",
+					"k8s.namespace.name":    "default",
+					"k8s.pod.labels.app":    "redis-scraped",
+					"k8s.pod.labels.region": "west-1",
+					"port.name":             "http",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "container labels",
+			args: args{
+				resources:      cfg.ResourceAttributes,
+				labelsPrefixes: labelsAsResourceAttributes{observer.ContainerType: "container.labels."},
+				env:            cntrEnv,
+				endpoint:       containerEndpoint,
+				nextConsumer:   &consumertest.MetricsSink{},
+			},
+			want: &resourceEnhancer{
+				nextConsumer: &consumertest.MetricsSink{},
+				attrs: map[string]string{
+					"container.name":          "otel-agent",
+					"container.image.name":    "otelcol",
+					"container.labels.env":    "prod",
+					"container.labels.region": "east-1",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			// If the configured attribute value is empty it should not touch that
 			// attribute.
@@ -183,7 +251,7 @@ func Test_newResourceEnhancer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := newResourceEnhancer(tt.args.resources, tt.args.resourceAttributes, tt.args.env, tt.args.endpoint, tt.args.nextConsumer)
+			got, err := newResourceEnhancer(tt.args.resources, tt.args.labelsPrefixes, tt.args.resourceAttributes, tt.args.env, tt.args.endpoint, tt.args.nextConsumer)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("newResourceEnhancer() error = %v, wantErr %v", err, tt.wantErr)
 				return
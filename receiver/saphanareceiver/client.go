@@ -23,17 +23,21 @@ import (
 
 	sapdriver "github.com/SAP/go-hdb/driver"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 )
 
 // Interface for a SAP HANA client. Implementation can be faked for testing.
 type client interface {
 	Connect(ctx context.Context) error
 	collectDataFromQuery(ctx context.Context, query *monitoringQuery) ([]map[string]string, error)
+	collectRows(ctx context.Context, query string) ([]sqlquery.MetricRow, error)
 	Close() error
 }
 
 // Wraps the result of a query so that it can be mocked in tests
 type resultWrapper interface {
+	Columns() ([]string, error)
 	Scan(dest ...interface{}) error
 	Close() error
 	Next() bool
@@ -54,6 +58,10 @@ func (w *standardResultWrapper) Next() bool {
 	return w.rows.Next()
 }
 
+func (w *standardResultWrapper) Columns() ([]string, error) {
+	return w.rows.Columns()
+}
+
 func (w *standardResultWrapper) Scan(dest ...interface{}) error {
 	return w.rows.Scan(dest...)
 }
@@ -217,6 +225,41 @@ ROW_ITERATOR:
 	return data, errors.Combine()
 }
 
+// collectRows runs a supplemental query, returning its rows by column name.
+// The NULL values are left out of the rows.
+func (c *sapHanaClient) collectRows(ctx context.Context, query string) ([]sqlquery.MetricRow, error) {
+	rows, err := c.client.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var data []sqlquery.MetricRow
+	for rows.Next() {
+		rowFields := make([]interface{}, len(columns))
+		for i := range rowFields {
+			rowFields[i] = new(sql.NullString)
+		}
+		if err := rows.Scan(rowFields...); err != nil {
+			return nil, err
+		}
+
+		row := sqlquery.MetricRow{}
+		for i, column := range columns {
+			if v := rowFields[i].(*sql.NullString); v.Valid {
+				row[column] = v.String
+			}
+		}
+		data = append(data, row)
+	}
+	return data, nil
+}
+
 func convertInterfaceToString(input interface{}) (sql.NullString, error) {
 	if val, ok := input.(*sql.NullString); ok {
 		return *val, nil
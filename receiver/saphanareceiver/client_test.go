@@ -25,14 +25,20 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/saphanareceiver/internal/metadata"
 )
 
 type testResultWrapper struct {
+	columns  []string
 	contents [][]sql.NullString
 	current  int
 }
 
+func (m *testResultWrapper) Columns() ([]string, error) {
+	return m.columns, nil
+}
+
 func (m *testResultWrapper) Next() bool {
 	return m.current < len(m.contents)
 }
@@ -71,6 +77,10 @@ func (m *testDBWrapper) QueryContext(ctx context.Context, query string) (resultW
 }
 
 func (m *testDBWrapper) mockQueryResult(query string, results [][]*string, err error) {
+	m.mockQueryColumnsResult(query, nil, results, err)
+}
+
+func (m *testDBWrapper) mockQueryColumnsResult(query string, columns []string, results [][]*string, err error) {
 	var nullableResult [][]sql.NullString
 	for _, row := range results {
 		var nullableRow []sql.NullString
@@ -85,6 +95,7 @@ func (m *testDBWrapper) mockQueryResult(query string, results [][]*string, err e
 		nullableResult = append(nullableResult, nullableRow)
 	}
 	resultWrapper := &testResultWrapper{
+		columns:  columns,
 		contents: nullableResult,
 		current:  0,
 	}
@@ -233,3 +244,27 @@ func TestNullOutput(t *testing.T) {
 
 	require.NoError(t, client.Close())
 }
+
+func TestCollectRows(t *testing.T) {
+	dbWrapper := &testDBWrapper{}
+	dbWrapper.On("PingContext").Return(nil)
+	dbWrapper.On("Close").Return(nil)
+
+	dbWrapper.mockQueryColumnsResult("SELECT SCHEMA_NAME, COUNT(*) AS TABLES FROM M_TABLES GROUP BY SCHEMA_NAME", []string{"SCHEMA_NAME", "TABLES"}, [][]*string{
+		{str("SYS"), str("42")},
+		{nil, str("3")},
+	}, nil)
+
+	client := newSapHanaClient(createDefaultConfig().(*Config), &testConnectionFactory{dbWrapper})
+	require.NoError(t, client.Connect(context.TODO()))
+
+	results, err := client.collectRows(context.TODO(), "SELECT SCHEMA_NAME, COUNT(*) AS TABLES FROM M_TABLES GROUP BY SCHEMA_NAME")
+	require.NoError(t, err)
+	// the NULL values are left out
+	require.Equal(t, []sqlquery.MetricRow{
+		{"SCHEMA_NAME": "SYS", "TABLES": "42"},
+		{"TABLES": "3"},
+	}, results)
+
+	require.NoError(t, client.Close())
+}
@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 	"go.uber.org/multierr"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/saphanareceiver/internal/metadata"
 )
 
@@ -40,6 +41,10 @@ type Config struct {
 
 	Username string `mapstructure:"username"`
 	Password string `mapstructure:"password"`
+
+	// Queries are the supplemental queries run in addition to the monitoring
+	// queries, with the definitions of the metrics of their rows.
+	Queries []sqlquery.Query `mapstructure:"queries"`
 }
 
 func (cfg *Config) Validate() error {
@@ -50,6 +55,7 @@ func (cfg *Config) Validate() error {
 	if cfg.Password == "" {
 		err = multierr.Append(err, errors.New(ErrNoPassword))
 	}
+	err = multierr.Append(err, sqlquery.ValidateQueries(cfg.Queries))
 
 	return err
 }
@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.uber.org/multierr"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/saphanareceiver/internal/metadata"
 )
 
@@ -60,6 +61,15 @@ func TestValidate(t *testing.T) {
 				errors.New(ErrNoUsername),
 			),
 		},
+		{
+			desc: "invalid supplemental query",
+			defaultConfigModifier: func(cfg *Config) {
+				cfg.Username = "otel"
+				cfg.Password = "otel"
+				cfg.Queries = []sqlquery.Query{{SQL: "SELECT 1 FROM DUMMY"}}
+			},
+			expected: errors.New("'query.metrics' cannot be empty"),
+		},
 		{
 			desc: "no error",
 			defaultConfigModifier: func(cfg *Config) {
@@ -98,6 +108,15 @@ func TestLoadConfig(t *testing.T) {
 	expected.Username = "otel"
 	expected.Password = "password"
 	expected.CollectionInterval = 2 * time.Minute
+	expected.Queries = []sqlquery.Query{{
+		SQL: "SELECT SCHEMA_NAME, COUNT(*) AS TABLES FROM M_TABLES GROUP BY SCHEMA_NAME",
+		Metrics: []sqlquery.MetricCfg{{
+			MetricName:       "saphana.schema.table.count",
+			ValueColumn:      "TABLES",
+			AttributeColumns: []string{"SCHEMA_NAME"},
+			Unit:             "{tables}",
+		}},
+	}}
 
 	require.Equal(t, expected, cfg)
 }
@@ -5,6 +5,7 @@ go 1.18
 require (
 	github.com/SAP/go-hdb v0.108.2
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest v0.61.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery v0.61.0
 	github.com/stretchr/testify v1.8.0
 	go.opentelemetry.io/collector v0.61.1-0.20221004012633-7cb544d3be36
 	go.opentelemetry.io/collector/pdata v0.61.1-0.20221004012633-7cb544d3be36
@@ -46,3 +47,5 @@ require (
 )
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest => ../../internal/scrapertest
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery => ../../internal/sqlquery
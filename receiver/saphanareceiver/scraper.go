@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/saphanareceiver/internal/metadata"
 )
 
@@ -36,14 +37,17 @@ type sapHanaScraper struct {
 	cfg      *Config
 	mbs      map[string]*metadata.MetricsBuilder
 	factory  sapHanaConnectionFactory
+	// startTime is the start time of the cumulative sums of the supplemental queries.
+	startTime pcommon.Timestamp
 }
 
 func newSapHanaScraper(settings component.ReceiverCreateSettings, cfg *Config, factory sapHanaConnectionFactory) (scraperhelper.Scraper, error) {
 	rs := &sapHanaScraper{
-		settings: settings,
-		cfg:      cfg,
-		mbs:      make(map[string]*metadata.MetricsBuilder),
-		factory:  factory,
+		settings:  settings,
+		cfg:       cfg,
+		mbs:       make(map[string]*metadata.MetricsBuilder),
+		factory:   factory,
+		startTime: pcommon.NewTimestampFromTime(time.Now()),
 	}
 	return scraperhelper.NewScraper(typeStr, rs.scrape)
 }
@@ -104,5 +108,35 @@ func (s *sapHanaScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 	}
 
 	s.mbs = make(map[string]*metadata.MetricsBuilder)
+
+	s.collectSupplementalMetrics(ctx, client, now, metrics, errs)
 	return metrics, errs.Combine()
 }
+
+// collectSupplementalMetrics runs the supplemental queries of the configuration,
+// appending the metrics of their rows to metrics.
+func (s *sapHanaScraper) collectSupplementalMetrics(ctx context.Context, client client, now pcommon.Timestamp, metrics pmetric.Metrics, errs *scrapererror.ScrapeErrors) {
+	if len(s.cfg.Queries) == 0 {
+		return
+	}
+
+	rm := pmetric.NewResourceMetrics()
+	rm.Resource().Attributes().PutStr("db.system", "saphana")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("otelcol/saphanareceiver")
+	sm.Scope().SetVersion(s.settings.BuildInfo.Version)
+
+	for _, query := range s.cfg.Queries {
+		rows, err := client.collectRows(ctx, query.SQL)
+		if err != nil {
+			errs.AddPartial(len(query.Metrics), fmt.Errorf("error running supplemental query %q: %w", query.SQL, err))
+			continue
+		}
+		if err = sqlquery.RowsToMetrics(rows, query, sm.Metrics(), s.startTime, now, s.cfg.CollectionInterval); err != nil {
+			errs.AddPartial(len(query.Metrics), fmt.Errorf("error converting the rows of supplemental query %q: %w", query.SQL, err))
+		}
+	}
+	if sm.Metrics().Len() > 0 {
+		rm.MoveTo(metrics.ResourceMetrics().AppendEmpty())
+	}
+}
@@ -17,14 +17,17 @@ package saphanareceiver // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 )
 
 const fullExpectedMetricsPath = "./testdata/expected_metrics/full.json"
@@ -115,6 +118,74 @@ func TestDisabledMetrics(t *testing.T) {
 	require.NoError(t, scrapertest.CompareMetrics(expectedMetrics, actualMetrics))
 }
 
+func TestSupplementalQueries(t *testing.T) {
+	t.Parallel()
+
+	dbWrapper := &testDBWrapper{}
+	initializeWrapper(t, dbWrapper, allQueryMetrics)
+	dbWrapper.mockQueryColumnsResult("SELECT SCHEMA_NAME, COUNT(*) AS TABLES FROM M_TABLES GROUP BY SCHEMA_NAME", []string{"SCHEMA_NAME", "TABLES"}, [][]*string{
+		{str("SYS"), str("42")},
+		{str("OTEL"), str("3")},
+	}, nil)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Queries = []sqlquery.Query{{
+		SQL: "SELECT SCHEMA_NAME, COUNT(*) AS TABLES FROM M_TABLES GROUP BY SCHEMA_NAME",
+		Metrics: []sqlquery.MetricCfg{{
+			MetricName:       "saphana.schema.table.count",
+			ValueColumn:      "TABLES",
+			AttributeColumns: []string{"SCHEMA_NAME"},
+			Unit:             "{tables}",
+		}},
+	}}
+	sc, err := newSapHanaScraper(componenttest.NewNopReceiverCreateSettings(), cfg, &testConnectionFactory{dbWrapper})
+	require.NoError(t, err)
+
+	actualMetrics, err := sc.Scrape(context.Background())
+	require.NoError(t, err)
+
+	// the metrics of the supplemental queries follow the ones of the monitoring queries
+	rms := actualMetrics.ResourceMetrics()
+	rm := rms.At(rms.Len() - 1)
+	assert.Equal(t, map[string]interface{}{"db.system": "saphana"}, rm.Resource().Attributes().AsRaw())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	for i, expected := range []struct {
+		schema string
+		tables int64
+	}{{"SYS", 42}, {"OTEL", 3}} {
+		assert.Equal(t, "saphana.schema.table.count", ms.At(i).Name())
+		assert.Equal(t, "{tables}", ms.At(i).Unit())
+		dp := ms.At(i).Gauge().DataPoints().At(0)
+		assert.Equal(t, expected.tables, dp.IntValue())
+		assert.Equal(t, map[string]interface{}{"SCHEMA_NAME": expected.schema}, dp.Attributes().AsRaw())
+	}
+}
+
+func TestSupplementalQueryError(t *testing.T) {
+	t.Parallel()
+
+	dbWrapper := &testDBWrapper{}
+	initializeWrapper(t, dbWrapper, allQueryMetrics)
+	dbWrapper.mockQueryColumnsResult("SELECT 1 AS ONE FROM DUMMY", nil, nil, errors.New("insufficient privilege"))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Queries = []sqlquery.Query{{
+		SQL:     "SELECT 1 AS ONE FROM DUMMY",
+		Metrics: []sqlquery.MetricCfg{{MetricName: "one", ValueColumn: "ONE"}},
+	}}
+	sc, err := newSapHanaScraper(componenttest.NewNopReceiverCreateSettings(), cfg, &testConnectionFactory{dbWrapper})
+	require.NoError(t, err)
+
+	expectedMetrics, err := golden.ReadMetrics(fullExpectedMetricsPath)
+	require.NoError(t, err)
+
+	// the metrics of the monitoring queries are still sent
+	actualMetrics, err := sc.Scrape(context.Background())
+	require.EqualError(t, err, `error running supplemental query "SELECT 1 AS ONE FROM DUMMY": insufficient privilege`)
+	require.NoError(t, scrapertest.CompareMetrics(expectedMetrics, actualMetrics))
+}
+
 type queryJSON struct {
 	Query  string
 	Result [][]string
@@ -0,0 +1,146 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpleprometheusreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/simpleprometheusreceiver"
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+
+	configutil "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// authProxy forwards the scrapes of the Prometheus receiver to the target through
+// the HTTP client built from the confighttp settings, so that the requests are
+// authenticated by the configured client authenticator extension. The Prometheus
+// scrape manager builds its own HTTP clients and can't use these extensions.
+//
+// Only the scrapes of the metrics path are forwarded, and only when they carry
+// the random bearer token generated for this proxy, so that other local
+// processes can't use the proxy to send authenticated requests to the target.
+type authProxy struct {
+	client      *http.Client
+	target      string
+	metricsPath string
+	token       string
+	listener    net.Listener
+	server      *http.Server
+	logger      *zap.Logger
+}
+
+// startAuthProxy starts a proxy listening on the loopback interface that forwards
+// the requests to the endpoint with the given scheme.
+func startAuthProxy(cfg *Config, scheme string, host component.Host, settings component.TelemetrySettings) (*authProxy, error) {
+	client, err := cfg.HTTPClientSettings.ToClient(host, settings)
+	if err != nil {
+		return nil, err
+	}
+	token := make([]byte, 32)
+	if _, err = rand.Read(token); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	p := &authProxy{
+		client:      client,
+		target:      scheme + "://" + cfg.Endpoint,
+		metricsPath: cfg.MetricsPath,
+		token:       hex.EncodeToString(token),
+		listener:    listener,
+		logger:      settings.Logger,
+	}
+	p.server = &http.Server{Handler: p}
+	go func() {
+		if serveErr := p.server.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			p.logger.Error("Authentication proxy stopped", zap.Error(serveErr))
+		}
+	}()
+	return p, nil
+}
+
+func (p *authProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+p.token)) != 1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Path != p.metricsPath {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, p.target+r.URL.RequestURI(), nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	req.Header = r.Header.Clone()
+	req.Header.Del("Authorization")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(resp.StatusCode)
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		p.logger.Debug("Failed to forward the scrape response", zap.Error(err))
+	}
+}
+
+// redirect points the scrape config to the proxy and sets the token of the
+// proxy as bearer token. The instance label is kept set to the endpoint of the
+// target.
+func (p *authProxy) redirect(scrapeConfig *config.ScrapeConfig, endpoint string) {
+	scrapeConfig.Scheme = "http"
+	scrapeConfig.HTTPClientConfig.TLSConfig = configutil.TLSConfig{}
+	scrapeConfig.HTTPClientConfig.BearerToken = configutil.Secret(p.token)
+	for _, sdc := range scrapeConfig.ServiceDiscoveryConfigs {
+		staticConfig, ok := sdc.(*discovery.StaticConfig)
+		if !ok {
+			continue
+		}
+		for _, group := range *staticConfig {
+			for _, labels := range group.Targets {
+				labels[model.AddressLabel] = model.LabelValue(p.listener.Addr().String())
+				labels[model.InstanceLabel] = model.LabelValue(endpoint)
+			}
+		}
+	}
+}
+
+func (p *authProxy) shutdown(ctx context.Context) error {
+	return p.server.Shutdown(ctx)
+}
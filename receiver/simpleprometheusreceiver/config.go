@@ -15,6 +15,7 @@
 package simpleprometheusreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/simpleprometheusreceiver"
 
 import (
+	"errors"
 	"net/url"
 	"time"
 
@@ -40,6 +41,14 @@ type Config struct {
 	UseServiceAccount bool `mapstructure:"use_service_account"`
 }
 
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.UseServiceAccount && cfg.Auth != nil {
+		return errors.New("use_service_account and auth can't be used together")
+	}
+	return nil
+}
+
 // TODO: Move to a common package for use by other receivers and also pull
 // in other utilities from
 // https://github.com/signalfx/signalfx-agent/blob/main/pkg/core/common/httpclient/http.go.
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -88,6 +89,21 @@ func TestLoadConfig(t *testing.T) {
 				MetricsPath:        "/metrics",
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "auth"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "localhost:1234",
+					TLSSetting: configtls.TLSClientSetting{
+						Insecure: true,
+					},
+					Auth: &configauth.Authentication{AuthenticatorID: config.NewComponentID("oauth2client")},
+				},
+				CollectionInterval: 30 * time.Second,
+				MetricsPath:        "/metrics",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -104,3 +120,12 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.UseServiceAccount = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Auth = &configauth.Authentication{AuthenticatorID: config.NewComponentID("oauth2client")}
+	assert.EqualError(t, cfg.Validate(), "use_service_account and auth can't be used together")
+}
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
+	"go.uber.org/multierr"
 	"k8s.io/client-go/rest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
@@ -36,6 +37,7 @@ type prometheusReceiverWrapper struct {
 	config            *Config
 	consumer          consumer.Metrics
 	prometheusRecever component.MetricsReceiver
+	authProxy         *authProxy
 }
 
 // new returns a prometheusReceiverWrapper
@@ -52,8 +54,20 @@ func (prw *prometheusReceiverWrapper) Start(ctx context.Context, host component.
 		return fmt.Errorf("failed to create prometheus receiver config: %w", err)
 	}
 
+	if prw.config.Auth != nil {
+		scrapeConfig := pConfig.PrometheusConfig.ScrapeConfigs[0]
+		prw.authProxy, err = startAuthProxy(prw.config, scrapeConfig.Scheme, host, prw.params.TelemetrySettings)
+		if err != nil {
+			return fmt.Errorf("failed to start authentication proxy: %w", err)
+		}
+		prw.authProxy.redirect(scrapeConfig, prw.config.Endpoint)
+	}
+
 	pr, err := pFactory.CreateMetricsReceiver(ctx, prw.params, pConfig, prw.consumer)
 	if err != nil {
+		if prw.authProxy != nil {
+			err = multierr.Append(err, prw.authProxy.shutdown(ctx))
+		}
 		return fmt.Errorf("failed to create prometheus receiver: %w", err)
 	}
 
@@ -147,5 +161,9 @@ func getPrometheusConfig(cfg *Config) (*prometheusreceiver.Config, error) {
 
 // Shutdown stops the underlying Prometheus receiver.
 func (prw *prometheusReceiverWrapper) Shutdown(ctx context.Context) error {
-	return prw.prometheusRecever.Shutdown(ctx)
+	err := prw.prometheusRecever.Shutdown(ctx)
+	if prw.authProxy != nil {
+		err = multierr.Append(err, prw.authProxy.shutdown(ctx))
+	}
+	return err
 }
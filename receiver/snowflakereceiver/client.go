@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflakereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snowflakereceiver"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sf "github.com/snowflakedb/gosnowflake"
+)
+
+const (
+	warehouseCreditsQuery = `SELECT WAREHOUSE_NAME, SUM(CREDITS_USED_COMPUTE), SUM(CREDITS_USED_CLOUD_SERVICES), MAX(END_TIME)
+FROM WAREHOUSE_METERING_HISTORY
+WHERE END_TIME > ? AND END_TIME <= ?
+GROUP BY WAREHOUSE_NAME`
+
+	storageUsageQuery = `SELECT STORAGE_BYTES, STAGE_BYTES, FAILSAFE_BYTES
+FROM STORAGE_USAGE
+ORDER BY USAGE_DATE DESC
+LIMIT 1`
+
+	queryStatsQuery = `SELECT WAREHOUSE_NAME, EXECUTION_STATUS, COUNT(*),
+APPROX_PERCENTILE(TOTAL_ELAPSED_TIME, 0.5), APPROX_PERCENTILE(TOTAL_ELAPSED_TIME, 0.9), APPROX_PERCENTILE(TOTAL_ELAPSED_TIME, 0.99),
+MAX(END_TIME)
+FROM QUERY_HISTORY
+WHERE END_TIME > ? AND END_TIME <= ? AND WAREHOUSE_NAME IS NOT NULL
+GROUP BY WAREHOUSE_NAME, EXECUTION_STATUS`
+
+	failedLoginsQuery = `SELECT REPORTED_CLIENT_TYPE, COUNT(*), MAX(EVENT_TIMESTAMP)
+FROM LOGIN_HISTORY
+WHERE IS_SUCCESS = 'NO' AND EVENT_TIMESTAMP > ? AND EVENT_TIMESTAMP <= ?
+GROUP BY REPORTED_CLIENT_TYPE`
+)
+
+// client reads the usage history of the account. The methods taking a time
+// range only return the rows recorded in (since, until], the end of the range
+// being used as the start of the next one.
+type client interface {
+	Close() error
+	getWarehouseCredits(ctx context.Context, since, until time.Time) ([]warehouseCredits, error)
+	getStorageUsage(ctx context.Context) (*storageUsage, error)
+	getQueryStats(ctx context.Context, since, until time.Time) ([]queryStats, error)
+	getFailedLogins(ctx context.Context, since, until time.Time) ([]failedLogins, error)
+}
+
+type warehouseCredits struct {
+	warehouse     string
+	compute       float64
+	cloudServices float64
+	latest        time.Time
+}
+
+type storageUsage struct {
+	database int64
+	stage    int64
+	failsafe int64
+}
+
+type queryStats struct {
+	warehouse string
+	status    string
+	count     int64
+	// Elapsed time percentiles, in milliseconds.
+	p50    float64
+	p90    float64
+	p99    float64
+	latest time.Time
+}
+
+type failedLogins struct {
+	clientType string
+	count      int64
+	latest     time.Time
+}
+
+type snowflakeClient struct {
+	db *sql.DB
+}
+
+var _ client = (*snowflakeClient)(nil)
+
+func newSnowflakeClient(cfg *Config) (*snowflakeClient, error) {
+	dsn, err := sf.DSN(&sf.Config{
+		Account:   cfg.Account,
+		User:      cfg.Username,
+		Password:  cfg.Password,
+		Warehouse: cfg.Warehouse,
+		Role:      cfg.Role,
+		Database:  cfg.Database,
+		Schema:    cfg.Schema,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build data source name: %w", err)
+	}
+
+	db, err := sql.Open("snowflake", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &snowflakeClient{db: db}, nil
+}
+
+func (c *snowflakeClient) Close() error {
+	return c.db.Close()
+}
+
+func (c *snowflakeClient) getWarehouseCredits(ctx context.Context, since, until time.Time) ([]warehouseCredits, error) {
+	rows, err := c.db.QueryContext(ctx, warehouseCreditsQuery, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var credits []warehouseCredits
+	for rows.Next() {
+		var wc warehouseCredits
+		if err = rows.Scan(&wc.warehouse, &wc.compute, &wc.cloudServices, &wc.latest); err != nil {
+			return nil, err
+		}
+		credits = append(credits, wc)
+	}
+	return credits, rows.Err()
+}
+
+func (c *snowflakeClient) getStorageUsage(ctx context.Context) (*storageUsage, error) {
+	var su storageUsage
+	var database, stage, failsafe sql.NullInt64
+	err := c.db.QueryRowContext(ctx, storageUsageQuery).Scan(&database, &stage, &failsafe)
+	if err != nil {
+		return nil, err
+	}
+	su.database, su.stage, su.failsafe = database.Int64, stage.Int64, failsafe.Int64
+	return &su, nil
+}
+
+func (c *snowflakeClient) getQueryStats(ctx context.Context, since, until time.Time) ([]queryStats, error) {
+	rows, err := c.db.QueryContext(ctx, queryStatsQuery, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []queryStats
+	for rows.Next() {
+		var qs queryStats
+		if err = rows.Scan(&qs.warehouse, &qs.status, &qs.count, &qs.p50, &qs.p90, &qs.p99, &qs.latest); err != nil {
+			return nil, err
+		}
+		stats = append(stats, qs)
+	}
+	return stats, rows.Err()
+}
+
+func (c *snowflakeClient) getFailedLogins(ctx context.Context, since, until time.Time) ([]failedLogins, error) {
+	rows, err := c.db.QueryContext(ctx, failedLoginsQuery, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logins []failedLogins
+	for rows.Next() {
+		var fl failedLogins
+		var clientType sql.NullString
+		if err = rows.Scan(&clientType, &fl.count, &fl.latest); err != nil {
+			return nil, err
+		}
+		fl.clientType = clientType.String
+		logins = append(logins, fl)
+	}
+	return logins, rows.Err()
+}
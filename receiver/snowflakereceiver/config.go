@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflakereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snowflakereceiver"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snowflakereceiver/internal/metadata"
+)
+
+// Errors for missing required config parameters.
+const (
+	ErrNoAccount   = "invalid config: missing account"
+	ErrNoUsername  = "invalid config: missing username"
+	ErrNoPassword  = "invalid config: missing password" // #nosec G101 - not hardcoded credentials
+	ErrNoWarehouse = "invalid config: missing warehouse"
+	ErrLookback    = "invalid config: 'initial_lookback' must be positive"
+	ErrLatency     = "invalid config: 'latency' must not be negative"
+)
+
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	// Account is the Snowflake account identifier, e.g. "xy12345.us-east-2.aws".
+	Account string `mapstructure:"account"`
+	// Username and Password of a user granted access to the ACCOUNT_USAGE views.
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// Warehouse used to run the queries.
+	Warehouse string `mapstructure:"warehouse"`
+	// Role used to run the queries. The default role of the user is used when empty.
+	Role string `mapstructure:"role"`
+	// Database and Schema holding the usage views.
+	Database string `mapstructure:"database"`
+	Schema   string `mapstructure:"schema"`
+	// InitialLookback is how far back the first scrape reads the usage history.
+	// Later scrapes only read the rows newer than the last ones seen.
+	InitialLookback time.Duration `mapstructure:"initial_lookback"`
+	// Latency is the delay after which the rows of the usage views are considered
+	// complete. The rows more recent than that are left to the next scrapes.
+	Latency time.Duration            `mapstructure:"latency"`
+	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
+}
+
+func (cfg *Config) Validate() error {
+	var err error
+	if cfg.Account == "" {
+		err = multierr.Append(err, errors.New(ErrNoAccount))
+	}
+	if cfg.Username == "" {
+		err = multierr.Append(err, errors.New(ErrNoUsername))
+	}
+	if cfg.Password == "" {
+		err = multierr.Append(err, errors.New(ErrNoPassword))
+	}
+	if cfg.Warehouse == "" {
+		err = multierr.Append(err, errors.New(ErrNoWarehouse))
+	}
+	if cfg.InitialLookback <= 0 {
+		err = multierr.Append(err, errors.New(ErrLookback))
+	}
+	if cfg.Latency < 0 {
+		err = multierr.Append(err, errors.New(ErrLatency))
+	}
+	return err
+}
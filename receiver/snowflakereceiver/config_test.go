@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflakereceiver
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.uber.org/multierr"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc                  string
+		defaultConfigModifier func(cfg *Config)
+		expected              error
+	}{
+		{
+			desc:                  "missing all required fields",
+			defaultConfigModifier: func(cfg *Config) {},
+			expected: multierr.Combine(
+				errors.New(ErrNoAccount),
+				errors.New(ErrNoUsername),
+				errors.New(ErrNoPassword),
+				errors.New(ErrNoWarehouse),
+			),
+		},
+		{
+			desc: "missing warehouse",
+			defaultConfigModifier: func(cfg *Config) {
+				cfg.Account = "xy12345"
+				cfg.Username = "otel"
+				cfg.Password = "otel"
+			},
+			expected: multierr.Combine(
+				errors.New(ErrNoWarehouse),
+			),
+		},
+		{
+			desc: "negative lookback",
+			defaultConfigModifier: func(cfg *Config) {
+				cfg.Account = "xy12345"
+				cfg.Username = "otel"
+				cfg.Password = "otel"
+				cfg.Warehouse = "metrics"
+				cfg.InitialLookback = -time.Hour
+			},
+			expected: multierr.Combine(
+				errors.New(ErrLookback),
+			),
+		},
+		{
+			desc: "negative latency",
+			defaultConfigModifier: func(cfg *Config) {
+				cfg.Account = "xy12345"
+				cfg.Username = "otel"
+				cfg.Password = "otel"
+				cfg.Warehouse = "metrics"
+				cfg.Latency = -time.Hour
+			},
+			expected: multierr.Combine(
+				errors.New(ErrLatency),
+			),
+		},
+		{
+			desc: "no error",
+			defaultConfigModifier: func(cfg *Config) {
+				cfg.Account = "xy12345"
+				cfg.Username = "otel"
+				cfg.Password = "otel"
+				cfg.Warehouse = "metrics"
+			},
+			expected: nil,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			tC.defaultConfigModifier(cfg)
+			actual := cfg.Validate()
+			require.Equal(t, tC.expected, actual)
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+
+	t.Run("snowflake", func(t *testing.T) {
+		cfg := factory.CreateDefaultConfig()
+		sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "").String())
+		require.NoError(t, err)
+		require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+		expected := factory.CreateDefaultConfig().(*Config)
+		expected.Account = "xy12345.us-east-2.aws"
+		expected.Username = "otel"
+		expected.Password = "$SNOWFLAKE_PASSWORD"
+		expected.Warehouse = "metrics"
+
+		require.Equal(t, expected, cfg)
+	})
+
+	t.Run("snowflake/all", func(t *testing.T) {
+		cfg := factory.CreateDefaultConfig()
+		sub, err := cm.Sub(config.NewComponentIDWithName(typeStr, "all").String())
+		require.NoError(t, err)
+		require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+		expected := factory.CreateDefaultConfig().(*Config)
+		expected.Account = "xy12345.us-east-2.aws"
+		expected.Username = "otel"
+		expected.Password = "$SNOWFLAKE_PASSWORD"
+		expected.Warehouse = "metrics"
+		expected.Role = "monitoring"
+		expected.InitialLookback = 24 * time.Hour
+		expected.Latency = time.Hour
+		expected.CollectionInterval = time.Hour
+		expected.Metrics.SnowflakeQueryDuration.Enabled = false
+
+		require.Equal(t, expected, cfg)
+	})
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflakereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snowflakereceiver"
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snowflakereceiver/internal/metadata"
+)
+
+var errClientNotInit = errors.New("client not initialized")
+
+type snowflakeClientFactory interface {
+	getClient(c *Config) (client, error)
+}
+
+type defaultClientFactory struct{}
+
+func (d *defaultClientFactory) getClient(c *Config) (client, error) {
+	return newSnowflakeClient(c)
+}
+
+// window is the part of an incrementally read view already turned into
+// metrics: the rows up to until, and for every series the time of its latest
+// row, used as the start of its next delta.
+type window struct {
+	until  time.Time
+	series map[string]time.Time
+}
+
+func newWindow(until time.Time) *window {
+	return &window{until: until, series: map[string]time.Time{}}
+}
+
+// start returns the start of the next delta of the given series.
+func (w *window) start(key string) pcommon.Timestamp {
+	if t, ok := w.series[key]; ok {
+		return pcommon.NewTimestampFromTime(t)
+	}
+	return pcommon.NewTimestampFromTime(w.until)
+}
+
+// advance records the latest row of the given series.
+func (w *window) advance(key string, latest time.Time) {
+	if latest.After(w.series[key]) {
+		w.series[key] = latest
+	}
+}
+
+// watermarks holds the windows of the incrementally read views. The credits
+// and queries are tracked per warehouse, the failed logins per client type.
+type watermarks struct {
+	credits *window
+	queries *window
+	logins  *window
+}
+
+type snowflakeScraper struct {
+	logger        *zap.Logger
+	config        *Config
+	clientFactory snowflakeClientFactory
+	client        client
+	mb            *metadata.MetricsBuilder
+	watermarks    watermarks
+}
+
+func newSnowflakeScraper(
+	settings component.ReceiverCreateSettings,
+	config *Config,
+	clientFactory snowflakeClientFactory,
+) *snowflakeScraper {
+	return &snowflakeScraper{
+		logger:        settings.Logger,
+		config:        config,
+		clientFactory: clientFactory,
+		mb:            metadata.NewMetricsBuilder(config.Metrics, settings.BuildInfo),
+	}
+}
+
+func (s *snowflakeScraper) start(_ context.Context, _ component.Host) error {
+	c, err := s.clientFactory.getClient(s.config)
+	if err != nil {
+		return err
+	}
+	s.client = c
+
+	since := time.Now().Add(-s.config.Latency - s.config.InitialLookback)
+	s.watermarks = watermarks{credits: newWindow(since), queries: newWindow(since), logins: newWindow(since)}
+	return nil
+}
+
+func (s *snowflakeScraper) shutdown(context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+	return s.client.Close()
+}
+
+// scrape reads the usage views and turns them into metrics. Only the rows
+// older than the configured latency are read, as the views are not complete
+// before. A view that cannot be read is reported as a partial scrape error,
+// and its watermark is left unchanged so that the rows are read again by the
+// next scrape.
+func (s *snowflakeScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if s.client == nil {
+		return pmetric.NewMetrics(), errClientNotInit
+	}
+
+	now := time.Now()
+	until := now.Add(-s.config.Latency)
+	var errs scrapererror.ScrapeErrors
+
+	s.scrapeWarehouseCredits(ctx, until, &errs)
+	s.scrapeStorageUsage(ctx, pcommon.NewTimestampFromTime(now), &errs)
+	s.scrapeQueryStats(ctx, until, &errs)
+	s.scrapeFailedLogins(ctx, until, &errs)
+
+	return s.mb.Emit(metadata.WithSnowflakeAccountName(s.config.Account)), errs.Combine()
+}
+
+func (s *snowflakeScraper) scrapeWarehouseCredits(ctx context.Context, until time.Time, errs *scrapererror.ScrapeErrors) {
+	w := s.watermarks.credits
+	if !until.After(w.until) {
+		return
+	}
+	credits, err := s.client.getWarehouseCredits(ctx, w.until, until)
+	if err != nil {
+		s.logger.Error("Failed to read warehouse metering history", zap.Error(err))
+		errs.AddPartial(1, err)
+		return
+	}
+	for _, wc := range credits {
+		// The builder applies its start time to the data points it records.
+		s.mb.Reset(metadata.WithStartTime(w.start(wc.warehouse)))
+		ts := pcommon.NewTimestampFromTime(wc.latest)
+		s.mb.RecordSnowflakeWarehouseCreditsUsedDataPoint(ts, wc.compute, wc.warehouse, metadata.AttributeCreditTypeCompute)
+		s.mb.RecordSnowflakeWarehouseCreditsUsedDataPoint(ts, wc.cloudServices, wc.warehouse, metadata.AttributeCreditTypeCloudServices)
+		w.advance(wc.warehouse, wc.latest)
+	}
+	w.until = until
+}
+
+func (s *snowflakeScraper) scrapeStorageUsage(ctx context.Context, now pcommon.Timestamp, errs *scrapererror.ScrapeErrors) {
+	su, err := s.client.getStorageUsage(ctx)
+	if err != nil {
+		s.logger.Error("Failed to read storage usage", zap.Error(err))
+		errs.AddPartial(1, err)
+		return
+	}
+	s.mb.Reset(metadata.WithStartTime(now))
+	s.mb.RecordSnowflakeStorageUsageDataPoint(now, su.database, metadata.AttributeStorageTypeDatabase)
+	s.mb.RecordSnowflakeStorageUsageDataPoint(now, su.stage, metadata.AttributeStorageTypeStage)
+	s.mb.RecordSnowflakeStorageUsageDataPoint(now, su.failsafe, metadata.AttributeStorageTypeFailsafe)
+}
+
+func (s *snowflakeScraper) scrapeQueryStats(ctx context.Context, until time.Time, errs *scrapererror.ScrapeErrors) {
+	w := s.watermarks.queries
+	if !until.After(w.until) {
+		return
+	}
+	stats, err := s.client.getQueryStats(ctx, w.until, until)
+	if err != nil {
+		s.logger.Error("Failed to read query history", zap.Error(err))
+		errs.AddPartial(1, err)
+		return
+	}
+	// The data points of a warehouse share the same interval, ending with its
+	// latest query whatever the status.
+	latest := map[string]time.Time{}
+	for _, qs := range stats {
+		if qs.latest.After(latest[qs.warehouse]) {
+			latest[qs.warehouse] = qs.latest
+		}
+	}
+	for _, qs := range stats {
+		status, ok := metadata.MapAttributeExecutionStatus[strings.ToLower(qs.status)]
+		if !ok {
+			s.logger.Debug("Ignoring queries with unknown execution status", zap.String("status", qs.status))
+			continue
+		}
+		s.mb.Reset(metadata.WithStartTime(w.start(qs.warehouse)))
+		ts := pcommon.NewTimestampFromTime(latest[qs.warehouse])
+		s.mb.RecordSnowflakeQueryCountDataPoint(ts, qs.count, qs.warehouse, status)
+		s.mb.RecordSnowflakeQueryDurationDataPoint(ts, qs.p50/1000, qs.warehouse, status, metadata.AttributePercentileP50)
+		s.mb.RecordSnowflakeQueryDurationDataPoint(ts, qs.p90/1000, qs.warehouse, status, metadata.AttributePercentileP90)
+		s.mb.RecordSnowflakeQueryDurationDataPoint(ts, qs.p99/1000, qs.warehouse, status, metadata.AttributePercentileP99)
+	}
+	for warehouse, t := range latest {
+		w.advance(warehouse, t)
+	}
+	w.until = until
+}
+
+func (s *snowflakeScraper) scrapeFailedLogins(ctx context.Context, until time.Time, errs *scrapererror.ScrapeErrors) {
+	w := s.watermarks.logins
+	if !until.After(w.until) {
+		return
+	}
+	logins, err := s.client.getFailedLogins(ctx, w.until, until)
+	if err != nil {
+		s.logger.Error("Failed to read login history", zap.Error(err))
+		errs.AddPartial(1, err)
+		return
+	}
+	for _, fl := range logins {
+		s.mb.Reset(metadata.WithStartTime(w.start(fl.clientType)))
+		s.mb.RecordSnowflakeLoginFailedCountDataPoint(pcommon.NewTimestampFromTime(fl.latest), fl.count, fl.clientType)
+		w.advance(fl.clientType, fl.latest)
+	}
+	w.until = until
+}
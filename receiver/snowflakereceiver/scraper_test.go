@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snowflakereceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+type fakeClient struct {
+	credits      []warehouseCredits
+	storage      *storageUsage
+	queries      []queryStats
+	logins       []failedLogins
+	queriesErr   error
+	creditsSince []time.Time
+	creditsUntil []time.Time
+	queriesSince []time.Time
+	loginsSince  []time.Time
+	closed       bool
+}
+
+func (c *fakeClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *fakeClient) getWarehouseCredits(_ context.Context, since, until time.Time) ([]warehouseCredits, error) {
+	c.creditsSince = append(c.creditsSince, since)
+	c.creditsUntil = append(c.creditsUntil, until)
+	return c.credits, nil
+}
+
+func (c *fakeClient) getStorageUsage(context.Context) (*storageUsage, error) {
+	return c.storage, nil
+}
+
+func (c *fakeClient) getQueryStats(_ context.Context, since, _ time.Time) ([]queryStats, error) {
+	c.queriesSince = append(c.queriesSince, since)
+	return c.queries, c.queriesErr
+}
+
+func (c *fakeClient) getFailedLogins(_ context.Context, since, _ time.Time) ([]failedLogins, error) {
+	c.loginsSince = append(c.loginsSince, since)
+	return c.logins, nil
+}
+
+type fakeClientFactory struct {
+	client *fakeClient
+}
+
+func (f *fakeClientFactory) getClient(*Config) (client, error) {
+	return f.client, nil
+}
+
+func newTestScraper(t *testing.T, c *fakeClient) *snowflakeScraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Account = "xy12345"
+	cfg.Latency = 0
+	s := newSnowflakeScraper(componenttest.NewNopReceiverCreateSettings(), cfg, &fakeClientFactory{client: c})
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func metricsByName(md pmetric.Metrics) map[string]pmetric.Metric {
+	out := map[string]pmetric.Metric{}
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		out[ms.At(i).Name()] = ms.At(i)
+	}
+	return out
+}
+
+func TestScrape(t *testing.T) {
+	creditsTime := time.Date(2022, 10, 1, 10, 0, 0, 0, time.UTC)
+	queriesTime := time.Date(2022, 10, 1, 10, 12, 0, 0, time.UTC)
+	c := &fakeClient{
+		credits: []warehouseCredits{
+			{warehouse: "COMPUTE_WH", compute: 1.5, cloudServices: 0.25, latest: creditsTime},
+		},
+		storage: &storageUsage{database: 1024, stage: 512, failsafe: 256},
+		queries: []queryStats{
+			{warehouse: "COMPUTE_WH", status: "SUCCESS", count: 42, p50: 500, p90: 2000, p99: 12000, latest: queriesTime},
+			{warehouse: "COMPUTE_WH", status: "RUNNING", count: 1, latest: queriesTime},
+		},
+		logins: []failedLogins{
+			{clientType: "JDBC_DRIVER", count: 3, latest: queriesTime},
+		},
+	}
+	s := newTestScraper(t, c)
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+
+	account, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("snowflake.account.name")
+	require.True(t, ok)
+	assert.Equal(t, "xy12345", account.Str())
+
+	metrics := metricsByName(md)
+	require.Len(t, metrics, 5)
+
+	credits := metrics["snowflake.warehouse.credits.used"].Sum().DataPoints()
+	require.Equal(t, 2, credits.Len())
+	assert.Equal(t, 1.5, credits.At(0).DoubleValue())
+	assert.Equal(t, "compute", credits.At(0).Attributes().AsRaw()["type"])
+	assert.Equal(t, 0.25, credits.At(1).DoubleValue())
+	// The first delta starts with the initial lookback and ends with the latest row.
+	assert.WithinDuration(t, c.creditsSince[0], credits.At(0).StartTimestamp().AsTime(), 0)
+	assert.Equal(t, creditsTime, credits.At(0).Timestamp().AsTime())
+
+	assert.Equal(t, 3, metrics["snowflake.storage.usage"].Gauge().DataPoints().Len())
+
+	// Queries with an unknown status are ignored.
+	queries := metrics["snowflake.query.count"].Sum().DataPoints()
+	require.Equal(t, 1, queries.Len())
+	assert.Equal(t, int64(42), queries.At(0).IntValue())
+	assert.Equal(t, map[string]interface{}{"warehouse.name": "COMPUTE_WH", "status": "success"}, queries.At(0).Attributes().AsRaw())
+
+	durations := metrics["snowflake.query.duration"].Gauge().DataPoints()
+	require.Equal(t, 3, durations.Len())
+	assert.Equal(t, 0.5, durations.At(0).DoubleValue())
+	assert.Equal(t, 12.0, durations.At(2).DoubleValue())
+
+	logins := metrics["snowflake.login.failed.count"].Sum().DataPoints()
+	require.Equal(t, 1, logins.Len())
+	assert.Equal(t, int64(3), logins.At(0).IntValue())
+
+	// The next scrape only reads the rows newer than the ones already read,
+	// and the deltas start where the previous ones ended.
+	c.credits[0].latest = creditsTime.Add(time.Hour)
+	md, err = s.scrape(context.Background())
+	require.NoError(t, err)
+	require.Len(t, c.creditsSince, 2)
+	assert.Equal(t, c.creditsUntil[0], c.creditsSince[1])
+	assert.True(t, c.queriesSince[1].After(c.queriesSince[0]))
+	assert.True(t, c.loginsSince[1].After(c.loginsSince[0]))
+
+	credits = metricsByName(md)["snowflake.warehouse.credits.used"].Sum().DataPoints()
+	require.Equal(t, 2, credits.Len())
+	assert.Equal(t, creditsTime, credits.At(0).StartTimestamp().AsTime())
+	assert.Equal(t, creditsTime.Add(time.Hour), credits.At(0).Timestamp().AsTime())
+	logins = metricsByName(md)["snowflake.login.failed.count"].Sum().DataPoints()
+	require.Equal(t, 1, logins.Len())
+	assert.Equal(t, queriesTime, logins.At(0).StartTimestamp().AsTime())
+
+	require.NoError(t, s.shutdown(context.Background()))
+	assert.True(t, c.closed)
+}
+
+func TestScrapePartialError(t *testing.T) {
+	c := &fakeClient{
+		storage:    &storageUsage{database: 1024},
+		queriesErr: errors.New("query history unavailable"),
+	}
+	s := newTestScraper(t, c)
+
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	// Only the storage usage could be read.
+	assert.Equal(t, 1, md.MetricCount())
+
+	// The watermark of a failed view is not moved.
+	_, err = s.scrape(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, c.queriesSince[0], c.queriesSince[1])
+}
+
+func TestScrapeLatency(t *testing.T) {
+	c := &fakeClient{storage: &storageUsage{}}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Latency = 3 * time.Hour
+	s := newSnowflakeScraper(componenttest.NewNopReceiverCreateSettings(), cfg, &fakeClientFactory{client: c})
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+
+	before := time.Now()
+	_, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	// The rows more recent than the latency are not read yet.
+	require.Len(t, c.creditsUntil, 1)
+	assert.False(t, c.creditsUntil[0].After(before.Add(-cfg.Latency).Add(time.Minute)))
+	assert.Equal(t, cfg.InitialLookback, c.creditsUntil[0].Sub(c.creditsSince[0]).Round(time.Minute))
+}
+
+func TestScrapeWithoutClient(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	s := newSnowflakeScraper(componenttest.NewNopReceiverCreateSettings(), cfg, &defaultClientFactory{})
+
+	_, err := s.scrape(context.Background())
+	require.ErrorIs(t, err, errClientNotInit)
+}
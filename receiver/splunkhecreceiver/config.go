@@ -33,4 +33,6 @@ type Config struct {
 	RawPath string `mapstructure:"raw_path"`
 	// HecToOtelAttrs creates a mapping from HEC metadata to attributes.
 	HecToOtelAttrs splunk.HecToOtelAttrs `mapstructure:"hec_metadata_to_otel_attrs"`
+	// RateLimiter is the ID of the ratelimiter extension limiting the requests and events accepted.
+	RateLimiter *config.ComponentID `mapstructure:"rate_limiter"`
 }
@@ -36,6 +36,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ratelimiterextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
 
@@ -50,6 +51,7 @@ const (
 	responseErrInternalServerError    = "Internal Server Error"
 	responseErrUnsupportedMetricEvent = "Unsupported metric event"
 	responseErrUnsupportedLogEvent    = "Unsupported log event"
+	responseErrRateLimited            = "Rate limit exceeded"
 
 	// Centralizing some HTTP and related string constants.
 	gzipEncoding              = "gzip"
@@ -71,6 +73,7 @@ var (
 	errInternalServerError    = initJSONResponse(responseErrInternalServerError)
 	errUnsupportedMetricEvent = initJSONResponse(responseErrUnsupportedMetricEvent)
 	errUnsupportedLogEvent    = initJSONResponse(responseErrUnsupportedLogEvent)
+	errRateLimitedRespBody    = initJSONResponse(responseErrRateLimited)
 )
 
 // splunkReceiver implements the component.MetricsReceiver for Splunk HEC metric protocol.
@@ -83,6 +86,7 @@ type splunkReceiver struct {
 	shutdownWG      sync.WaitGroup
 	obsrecv         *obsreport.Receiver
 	gzipReaderPool  *sync.Pool
+	limiter         ratelimiterextension.Limiter
 }
 
 var _ component.MetricsReceiver = (*splunkReceiver)(nil)
@@ -177,6 +181,14 @@ func (r *splunkReceiver) Start(_ context.Context, host component.Host) error {
 		return nil
 	}
 
+	if r.config.RateLimiter != nil {
+		limiter, err := ratelimiterextension.GetLimiter(host.GetExtensions(), *r.config.RateLimiter)
+		if err != nil {
+			return err
+		}
+		r.limiter = limiter
+	}
+
 	var ln net.Listener
 	// set up the listener
 	ln, err := r.config.HTTPServerSettings.ToListener()
@@ -269,6 +281,10 @@ func (r *splunkReceiver) handleRawReq(resp http.ResponseWriter, req *http.Reques
 		logLine := sc.Text()
 		logRecord.Body().SetStr(logLine)
 	}
+	if !r.allowRequest(ctx, resp, req, sl.LogRecords().Len()) {
+		_ = bodyReader.Close()
+		return
+	}
 	consumerErr := r.logsConsumer.ConsumeLogs(ctx, ld)
 
 	_ = bodyReader.Close()
@@ -342,6 +358,9 @@ func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request)
 
 		events = append(events, &msg)
 	}
+	if !r.allowRequest(ctx, resp, req, len(events)) {
+		return
+	}
 	if r.logsConsumer != nil {
 		r.consumeLogs(ctx, events, resp, req)
 	} else {
@@ -400,6 +419,21 @@ func (r *splunkReceiver) createResourceCustomizer(req *http.Request) func(resour
 	return nil
 }
 
+// allowRequest returns whether the request holding the given number of events is within the limits
+// of the rate limiter, failing the request with a Retry-After header otherwise.
+func (r *splunkReceiver) allowRequest(ctx context.Context, resp http.ResponseWriter, req *http.Request, numRecordsReceived int) bool {
+	if r.limiter == nil {
+		return true
+	}
+	err := r.limiter.Limit(ctx, req.Header, numRecordsReceived)
+	if err == nil {
+		return true
+	}
+	ratelimiterextension.SetRetryAfter(resp.Header(), err)
+	r.failRequest(ctx, resp, http.StatusTooManyRequests, errRateLimitedRespBody, numRecordsReceived, err)
+	return false
+}
+
 func (r *splunkReceiver) failRequest(
 	ctx context.Context,
 	resp http.ResponseWriter,
@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
@@ -40,6 +41,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ratelimiterextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
 )
@@ -371,6 +373,57 @@ func Test_consumer_err_metrics(t *testing.T) {
 	assert.Equal(t, "Internal Server Error", bodyStr)
 }
 
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func Test_splunkhecReceiver_RateLimiter(t *testing.T) {
+	limiterID := config.NewComponentID("ratelimiter")
+	limiterFactory := ratelimiterextension.NewFactory()
+	limiterCfg := limiterFactory.CreateDefaultConfig().(*ratelimiterextension.Config)
+	limiterCfg.Global = &ratelimiterextension.LimitSettings{ItemsPerSecond: 1.0 / 3600}
+	limiter, err := limiterFactory.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), limiterCfg)
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.RateLimiter = &limiterID
+	sink := new(consumertest.LogsSink)
+	rcv, err := newLogsReceiver(componenttest.NewNopReceiverCreateSettings(), *cfg, sink)
+	require.NoError(t, err)
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{limiterID: limiter},
+	}
+	require.NoError(t, rcv.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, rcv.Shutdown(context.Background())) })
+
+	r := rcv.(*splunkReceiver)
+	var responses []*http.Response
+	for i := 0; i < 2; i++ {
+		msgBytes, err := json.Marshal(buildSplunkHecMsg(float64(time.Now().UnixNano())/1e6, 3))
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r.handleReq(w, httptest.NewRequest("POST", "http://localhost", bytes.NewReader(msgBytes)))
+		responses = append(responses, w.Result())
+	}
+
+	assert.Equal(t, http.StatusOK, responses[0].StatusCode)
+	assert.Equal(t, http.StatusTooManyRequests, responses[1].StatusCode)
+	assert.NotEmpty(t, responses[1].Header.Get("Retry-After"))
+	respBytes, err := io.ReadAll(responses[1].Body)
+	require.NoError(t, err)
+	var bodyStr string
+	require.NoError(t, json.Unmarshal(respBytes, &bodyStr))
+	assert.Equal(t, responseErrRateLimited, bodyStr)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
 func Test_splunkhecReceiver_TLS(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	cfg := createDefaultConfig().(*Config)
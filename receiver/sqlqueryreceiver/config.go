@@ -16,12 +16,36 @@ package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"errors"
-	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
-	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
+)
+
+// The query definitions are shared with the other database receivers, the
+// aliases keep them available from this package.
+type (
+	Query             = sqlquery.Query
+	MetricCfg         = sqlquery.MetricCfg
+	MetricType        = sqlquery.MetricType
+	MetricValueType   = sqlquery.MetricValueType
+	MetricAggregation = sqlquery.MetricAggregation
+)
+
+const (
+	MetricTypeUnspecified = sqlquery.MetricTypeUnspecified
+	MetricTypeGauge       = sqlquery.MetricTypeGauge
+	MetricTypeSum         = sqlquery.MetricTypeSum
+
+	MetricValueTypeUnspecified = sqlquery.MetricValueTypeUnspecified
+	MetricValueTypeInt         = sqlquery.MetricValueTypeInt
+	MetricValueTypeDouble      = sqlquery.MetricValueTypeDouble
+
+	MetricAggregationUnspecified = sqlquery.MetricAggregationUnspecified
+	MetricAggregationCumulative  = sqlquery.MetricAggregationCumulative
+	MetricAggregationDelta       = sqlquery.MetricAggregationDelta
 )
 
 type Config struct {
@@ -49,114 +73,6 @@ func (c Config) Validate() error {
 	return nil
 }
 
-type Query struct {
-	SQL     string      `mapstructure:"sql"`
-	Metrics []MetricCfg `mapstructure:"metrics"`
-}
-
-func (q Query) Validate() error {
-	var errs error
-	if q.SQL == "" {
-		errs = multierr.Append(errs, errors.New("'query.sql' cannot be empty"))
-	}
-	if len(q.Metrics) == 0 {
-		errs = multierr.Append(errs, errors.New("'query.metrics' cannot be empty"))
-	}
-	for _, metric := range q.Metrics {
-		if err := metric.Validate(); err != nil {
-			errs = multierr.Append(errs, err)
-		}
-	}
-	return errs
-}
-
-type MetricCfg struct {
-	MetricName       string            `mapstructure:"metric_name"`
-	ValueColumn      string            `mapstructure:"value_column"`
-	AttributeColumns []string          `mapstructure:"attribute_columns"`
-	Monotonic        bool              `mapstructure:"monotonic"`
-	ValueType        MetricValueType   `mapstructure:"value_type"`
-	DataType         MetricType        `mapstructure:"data_type"`
-	Aggregation      MetricAggregation `mapstructure:"aggregation"`
-	Unit             string            `mapstructure:"unit"`
-	Description      string            `mapstructure:"description"`
-	StaticAttributes map[string]string `mapstructure:"static_attributes"`
-}
-
-func (c MetricCfg) Validate() error {
-	var errs error
-	if c.MetricName == "" {
-		errs = multierr.Append(errs, errors.New("'metric_name' cannot be empty"))
-	}
-	if c.ValueColumn == "" {
-		errs = multierr.Append(errs, errors.New("'value_column' cannot be empty"))
-	}
-	if err := c.ValueType.Validate(); err != nil {
-		errs = multierr.Append(errs, err)
-	}
-	if err := c.DataType.Validate(); err != nil {
-		errs = multierr.Append(errs, err)
-	}
-	if err := c.Aggregation.Validate(); err != nil {
-		errs = multierr.Append(errs, err)
-	}
-	if c.DataType == MetricTypeGauge && c.Aggregation != "" {
-		errs = multierr.Append(errs, fmt.Errorf("aggregation=%s but data_type=%s does not support aggregation", c.Aggregation, c.DataType))
-	}
-	if errs != nil && c.MetricName != "" {
-		errs = multierr.Append(fmt.Errorf("invalid metric config with metric_name '%s'", c.MetricName), errs)
-	}
-	return errs
-}
-
-type MetricType string
-
-const (
-	MetricTypeUnspecified MetricType = ""
-	MetricTypeGauge       MetricType = "gauge"
-	MetricTypeSum         MetricType = "sum"
-)
-
-func (t MetricType) Validate() error {
-	switch t {
-	case MetricTypeUnspecified, MetricTypeGauge, MetricTypeSum:
-		return nil
-	}
-	return fmt.Errorf("metric config has unsupported data_type: '%s'", t)
-}
-
-type MetricValueType string
-
-const (
-	MetricValueTypeUnspecified MetricValueType = ""
-	MetricValueTypeInt         MetricValueType = "int"
-	MetricValueTypeDouble      MetricValueType = "double"
-)
-
-func (t MetricValueType) Validate() error {
-	switch t {
-	case MetricValueTypeUnspecified, MetricValueTypeInt, MetricValueTypeDouble:
-		return nil
-	}
-	return fmt.Errorf("metric config has unsupported value_type: '%s'", t)
-}
-
-type MetricAggregation string
-
-const (
-	MetricAggregationUnspecified MetricAggregation = ""
-	MetricAggregationCumulative  MetricAggregation = "cumulative"
-	MetricAggregationDelta       MetricAggregation = "delta"
-)
-
-func (a MetricAggregation) Validate() error {
-	switch a {
-	case MetricAggregationUnspecified, MetricAggregationCumulative, MetricAggregationDelta:
-		return nil
-	}
-	return fmt.Errorf("metric config has unsupported aggregation: '%s'", a)
-}
-
 func createDefaultConfig() config.Receiver {
 	return &Config{
 		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
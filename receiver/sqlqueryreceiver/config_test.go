@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -45,18 +47,18 @@ func TestLoadConfig(t *testing.T) {
 				},
 				Driver:     "mydriver",
 				DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
-				Queries: []Query{
+				Queries: []sqlquery.Query{
 					{
 						SQL: "select count(*) as count, type from mytable group by type",
-						Metrics: []MetricCfg{
+						Metrics: []sqlquery.MetricCfg{
 							{
 								MetricName:       "val.count",
 								ValueColumn:      "count",
 								AttributeColumns: []string{"type"},
 								Monotonic:        false,
-								ValueType:        MetricValueTypeInt,
-								DataType:         MetricTypeSum,
-								Aggregation:      MetricAggregationCumulative,
+								ValueType:        sqlquery.MetricValueTypeInt,
+								DataType:         sqlquery.MetricTypeSum,
+								Aggregation:      sqlquery.MetricAggregationCumulative,
 								StaticAttributes: map[string]string{"foo": "bar"},
 							},
 						},
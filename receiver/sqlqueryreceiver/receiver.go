@@ -23,16 +23,13 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
-	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 )
 
 type sqlOpenerFunc func(driverName, dataSourceName string) (*sql.DB, error)
 
-type dbProviderFunc func() (*sql.DB, error)
-
-type clientProviderFunc func(*sql.DB, string, *zap.Logger) dbClient
-
-func createReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientProviderFunc) component.CreateMetricsReceiverFunc {
+func createReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc sqlquery.ClientProviderFunc) component.CreateMetricsReceiverFunc {
 	return func(
 		ctx context.Context,
 		settings component.ReceiverCreateSettings,
@@ -43,16 +40,10 @@ func createReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientPr
 		var opts []scraperhelper.ScraperControllerOption
 		for i, query := range sqlCfg.Queries {
 			id := config.NewComponentIDWithName("sqlqueryreceiver", fmt.Sprintf("query-%d: %s", i, query.SQL))
-			mp := &scraper{
-				id:        id,
-				query:     query,
-				scrapeCfg: sqlCfg.ScraperControllerSettings,
-				logger:    settings.TelemetrySettings.Logger,
-				dbProviderFunc: func() (*sql.DB, error) {
-					return sqlOpenerFunc(sqlCfg.Driver, sqlCfg.DataSource)
-				},
-				clientProviderFunc: clientProviderFunc,
+			dbProviderFunc := func() (*sql.DB, error) {
+				return sqlOpenerFunc(sqlCfg.Driver, sqlCfg.DataSource)
 			}
+			mp := sqlquery.NewScraper(id, query, sqlCfg.ScraperControllerSettings, settings.TelemetrySettings.Logger, dbProviderFunc, clientProviderFunc)
 			opt := scraperhelper.AddScraper(mp)
 			opts = append(opts, opt)
 		}
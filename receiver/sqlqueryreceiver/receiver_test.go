@@ -27,6 +27,8 @@ import (
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
 )
 
 func TestCreateReceiver(t *testing.T) {
@@ -45,9 +47,9 @@ func TestCreateReceiver(t *testing.T) {
 			},
 			Driver:     "mydriver",
 			DataSource: "my-datasource",
-			Queries: []Query{{
+			Queries: []sqlquery.Query{{
 				SQL: "select * from foo",
-				Metrics: []MetricCfg{{
+				Metrics: []sqlquery.MetricCfg{{
 					MetricName:  "my-metric",
 					ValueColumn: "my-column",
 				}},
@@ -64,6 +66,17 @@ func fakeDBConnect(string, string) (*sql.DB, error) {
 	return nil, nil
 }
 
-func mkFakeClient(db *sql.DB, s string, logger *zap.Logger) dbClient {
-	return &fakeDBClient{responses: [][]metricRow{{{"foo": "111"}}}}
+func mkFakeClient(db *sql.DB, s string, logger *zap.Logger) sqlquery.DbClient {
+	return &fakeDBClient{responses: [][]sqlquery.MetricRow{{{"foo": "111"}}}}
+}
+
+type fakeDBClient struct {
+	requestCounter int
+	responses      [][]sqlquery.MetricRow
+}
+
+func (c *fakeDBClient) MetricRows(context.Context) ([]sqlquery.MetricRow, error) {
+	idx := c.requestCounter
+	c.requestCounter++
+	return c.responses[idx], nil
 }
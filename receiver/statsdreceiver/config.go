@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.uber.org/multierr"
@@ -63,10 +64,37 @@ func (c *Config) validate() error {
 		}
 
 		switch eachMap.ObserverType {
-		case protocol.GaugeObserver, protocol.SummaryObserver:
+		case protocol.GaugeObserver, protocol.SummaryObserver, protocol.HistogramObserver:
 		default:
 			errs = multierr.Append(errs, fmt.Errorf("observer_type is not supported: %s", eachMap.ObserverType))
 		}
+
+		switch eachMap.Unit {
+		case "":
+		case protocol.MillisecondsUnit, protocol.SecondsUnit:
+			if eachMap.StatsdType == protocol.HistogramTypeName {
+				errs = multierr.Append(errs, fmt.Errorf("unit is only supported for timing mappings"))
+			}
+		default:
+			errs = multierr.Append(errs, fmt.Errorf("unit is not supported: %s", eachMap.Unit))
+		}
+
+		if maxSize := eachMap.Histogram.MaxSize; maxSize != 0 && (maxSize < structure.MinSize || maxSize > structure.MaximumMaxSize) {
+			errs = multierr.Append(errs, fmt.Errorf("histogram max_size must be between %d and %d: %d", structure.MinSize, structure.MaximumMaxSize, maxSize))
+		}
+
+		for i := 1; i < len(eachMap.Histogram.ExplicitBounds); i++ {
+			if eachMap.Histogram.ExplicitBounds[i] <= eachMap.Histogram.ExplicitBounds[i-1] {
+				errs = multierr.Append(errs, fmt.Errorf("histogram explicit_bounds must be in increasing order: %v", eachMap.Histogram.ExplicitBounds))
+				break
+			}
+		}
+
+		for _, percentile := range eachMap.Summary.Percentiles {
+			if percentile < 0 || percentile > 100 {
+				errs = multierr.Append(errs, fmt.Errorf("summary percentiles must be between 0 and 100: %v", percentile))
+			}
+		}
 	}
 
 	if TimerHistogramMappingMissingObjectName {
@@ -64,6 +64,34 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "histograms"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "localhost:8125",
+					Transport: "udp",
+				},
+				AggregationInterval: 60 * time.Second,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{
+						StatsdType:   "histogram",
+						ObserverType: "histogram",
+						Histogram: protocol.HistogramConfig{
+							ExplicitBounds: []float64{10, 100, 1000},
+						},
+					},
+					{
+						StatsdType:   "timing",
+						ObserverType: "summary",
+						Summary: protocol.SummaryConfig{
+							Percentiles: []float64{50, 90, 99},
+						},
+						Unit: "s",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -93,6 +121,11 @@ func TestValidate(t *testing.T) {
 		noObjectNameErr                = "must specify object id for all TimerHistogramMappings"
 		statsdTypeNotSupportErr        = "statsd_type is not a supported mapping: %s"
 		observerTypeNotSupportErr      = "observer_type is not supported: %s"
+		unitNotSupportErr              = "unit is not supported: %s"
+		histogramUnitErr               = "unit is only supported for timing mappings"
+		maxSizeErr                     = "histogram max_size must be between 2 and 16384: %d"
+		explicitBoundsErr              = "histogram explicit_bounds must be in increasing order: %v"
+		percentileErr                  = "summary percentiles must be between 0 and 100: %v"
 	)
 
 	tests := []test{
@@ -146,6 +179,56 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Sprintf(observerTypeNotSupportErr, "gauge1"),
 		},
+		{
+			name: "UnitNotSupport",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "timer", ObserverType: "gauge", Unit: "us"},
+				},
+			},
+			expectedErr: fmt.Sprintf(unitNotSupportErr, "us"),
+		},
+		{
+			name: "HistogramUnit",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "histogram", ObserverType: "gauge", Unit: "s"},
+				},
+			},
+			expectedErr: histogramUnitErr,
+		},
+		{
+			name: "InvalidMaxSize",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "histogram", ObserverType: "histogram", Histogram: protocol.HistogramConfig{MaxSize: 1}},
+				},
+			},
+			expectedErr: fmt.Sprintf(maxSizeErr, 1),
+		},
+		{
+			name: "UnsortedExplicitBounds",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "histogram", ObserverType: "histogram", Histogram: protocol.HistogramConfig{ExplicitBounds: []float64{10, 1}}},
+				},
+			},
+			expectedErr: fmt.Sprintf(explicitBoundsErr, []float64{10, 1}),
+		},
+		{
+			name: "InvalidPercentile",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "timer", ObserverType: "summary", Summary: protocol.SummaryConfig{Percentiles: []float64{50, 101}}},
+				},
+			},
+			expectedErr: fmt.Sprintf(percentileErr, 101),
+		},
 	}
 
 	for _, test := range tests {
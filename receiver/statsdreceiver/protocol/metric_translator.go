@@ -18,6 +18,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"gonum.org/v1/gonum/stat"
@@ -66,9 +67,12 @@ func buildGaugeMetric(parsedMetric statsDMetric, timeNow time.Time) pmetric.Scop
 	return ilm
 }
 
-func buildSummaryMetric(desc statsDMetricDescription, summary summaryMetric, startTime, timeNow time.Time, percentiles []float64, ilm pmetric.ScopeMetrics) {
+func buildSummaryMetric(desc statsDMetricDescription, summary summaryMetric, startTime, timeNow time.Time, percentiles []float64, unit string, ilm pmetric.ScopeMetrics) {
 	nm := ilm.Metrics().AppendEmpty()
 	nm.SetName(desc.name)
+	if unit != "" {
+		nm.SetUnit(unit)
+	}
 	dp := nm.SetEmptySummary().DataPoints().AppendEmpty()
 
 	count := float64(0)
@@ -98,6 +102,98 @@ func buildSummaryMetric(desc statsDMetricDescription, summary summaryMetric, sta
 	}
 }
 
+func newHistogramMetric(cfg HistogramConfig) histogramMetric {
+	if len(cfg.ExplicitBounds) > 0 {
+		return histogramMetric{explicit: &explicitHistogram{
+			bounds: cfg.ExplicitBounds,
+			counts: make([]uint64, len(cfg.ExplicitBounds)+1),
+		}}
+	}
+	var opts []structure.Option
+	if cfg.MaxSize > 0 {
+		opts = append(opts, structure.WithMaxSize(cfg.MaxSize))
+	}
+	exponential := new(structure.Histogram[float64])
+	exponential.Init(structure.NewConfig(opts...))
+	return histogramMetric{exponential: exponential}
+}
+
+func (h histogramMetric) update(value float64, incr uint64) {
+	if h.exponential != nil {
+		h.exponential.UpdateByIncr(value, incr)
+		return
+	}
+	e := h.explicit
+	if e.count == 0 || value < e.min {
+		e.min = value
+	}
+	if e.count == 0 || value > e.max {
+		e.max = value
+	}
+	e.count += incr
+	e.sum += value * float64(incr)
+	// The buckets are inclusive of their upper bound.
+	e.counts[sort.SearchFloat64s(e.bounds, value)] += incr
+}
+
+func buildHistogramMetric(desc statsDMetricDescription, histogram histogramMetric, startTime, timeNow time.Time, unit string, ilm pmetric.ScopeMetrics) {
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(desc.name)
+	if unit != "" {
+		nm.SetUnit(unit)
+	}
+
+	if histogram.explicit != nil {
+		h := histogram.explicit
+		hist := nm.SetEmptyHistogram()
+		hist.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(startTime))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+		dp.SetCount(h.count)
+		dp.SetSum(h.sum)
+		if h.count != 0 {
+			dp.SetMin(h.min)
+			dp.SetMax(h.max)
+		}
+		dp.ExplicitBounds().FromRaw(h.bounds)
+		dp.BucketCounts().FromRaw(h.counts)
+		for i := desc.attrs.Iter(); i.Next(); {
+			dp.Attributes().PutStr(string(i.Attribute().Key), i.Attribute().Value.AsString())
+		}
+		return
+	}
+
+	h := histogram.exponential
+	expo := nm.SetEmptyExponentialHistogram()
+	expo.SetAggregationTemporality(pmetric.MetricAggregationTemporalityDelta)
+	dp := expo.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(startTime))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	dp.SetCount(h.Count())
+	dp.SetSum(h.Sum())
+	if h.Count() != 0 {
+		dp.SetMin(h.Min())
+		dp.SetMax(h.Max())
+	}
+	dp.SetZeroCount(h.ZeroCount())
+	dp.SetScale(h.Scale())
+	copyBuckets(h.Positive(), dp.Positive())
+	copyBuckets(h.Negative(), dp.Negative())
+	for i := desc.attrs.Iter(); i.Next(); {
+		dp.Attributes().PutStr(string(i.Attribute().Key), i.Attribute().Value.AsString())
+	}
+}
+
+func copyBuckets(src *structure.Buckets, dest pmetric.Buckets) {
+	dest.SetOffset(src.Offset())
+	counts := dest.BucketCounts()
+	counts.EnsureCapacity(int(src.Len()))
+	for i := uint32(0); i < src.Len(); i++ {
+		counts.Append(src.At(i))
+	}
+}
+
 func (s statsDMetric) counterValue() int64 {
 	x := s.asFloat
 	// Note statds counters are always represented as integers.
@@ -100,7 +100,7 @@ func TestBuildSummaryMetricUnsampled(t *testing.T) {
 	}
 
 	metric := pmetric.NewScopeMetrics()
-	buildSummaryMetric(desc, unsampledMetric, timeNow.Add(-time.Minute), timeNow, statsDDefaultPercentiles, metric)
+	buildSummaryMetric(desc, unsampledMetric, timeNow.Add(-time.Minute), timeNow, statsDDefaultPercentiles, "", metric)
 
 	expectedMetric := pmetric.NewScopeMetrics()
 	m := expectedMetric.Metrics().AppendEmpty()
@@ -180,7 +180,7 @@ func TestBuildSummaryMetricSampled(t *testing.T) {
 		}
 
 		metric := pmetric.NewScopeMetrics()
-		buildSummaryMetric(desc, sampledMetric, timeNow.Add(-time.Minute), timeNow, test.percentiles, metric)
+		buildSummaryMetric(desc, sampledMetric, timeNow.Add(-time.Minute), timeNow, test.percentiles, "", metric)
 
 		expectedMetric := pmetric.NewScopeMetrics()
 		m := expectedMetric.Metrics().AppendEmpty()
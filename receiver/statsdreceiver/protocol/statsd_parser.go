@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -33,7 +34,8 @@ var (
 type (
 	MetricType   string // From the statsd line e.g., "c", "g", "h"
 	TypeName     string // How humans describe the MetricTypes ("counter", "gauge")
-	ObserverType string // How the server will aggregate histogram and timings ("gauge", "summary")
+	ObserverType string // How the server will aggregate histogram and timings ("gauge", "summary", "histogram")
+	TimerUnit    string // The unit the timings are reported in ("ms", "s")
 )
 
 const (
@@ -50,16 +52,44 @@ const (
 	TimingTypeName    TypeName = "timing"
 	TimingAltTypeName TypeName = "timer"
 
-	GaugeObserver   ObserverType = "gauge"
-	SummaryObserver ObserverType = "summary"
-	DisableObserver ObserverType = "disabled"
+	GaugeObserver     ObserverType = "gauge"
+	SummaryObserver   ObserverType = "summary"
+	HistogramObserver ObserverType = "histogram"
+	DisableObserver   ObserverType = "disabled"
 
 	DefaultObserverType = DisableObserver
+
+	MillisecondsUnit TimerUnit = "ms"
+	SecondsUnit      TimerUnit = "s"
+
+	DefaultTimerUnit = MillisecondsUnit
 )
 
 type TimerHistogramMapping struct {
-	StatsdType   TypeName     `mapstructure:"statsd_type"`
-	ObserverType ObserverType `mapstructure:"observer_type"`
+	StatsdType   TypeName        `mapstructure:"statsd_type"`
+	ObserverType ObserverType    `mapstructure:"observer_type"`
+	Histogram    HistogramConfig `mapstructure:"histogram"`
+	Summary      SummaryConfig   `mapstructure:"summary"`
+	// Unit is the unit the timings are converted to, "ms" by default or "s".
+	// Only valid for the timing mappings.
+	Unit TimerUnit `mapstructure:"unit"`
+}
+
+// HistogramConfig configures the histograms built by the "histogram" observer.
+type HistogramConfig struct {
+	// MaxSize is the maximum number of buckets of the exponential histograms,
+	// 160 by default.
+	MaxSize int32 `mapstructure:"max_size"`
+	// ExplicitBounds are the bucket boundaries of the histograms. When set,
+	// histograms with explicit buckets are built instead of exponential ones.
+	ExplicitBounds []float64 `mapstructure:"explicit_bounds"`
+}
+
+// SummaryConfig configures the summaries built by the "summary" observer.
+type SummaryConfig struct {
+	// Percentiles are the percentiles reported by the summaries,
+	// 0, 10, 50, 90, 95 and 100 by default.
+	Percentiles []float64 `mapstructure:"percentiles"`
 }
 
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
@@ -67,11 +97,12 @@ type StatsDParser struct {
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
+	histograms             map[statsDMetricDescription]histogramMetric
 	timersAndDistributions []pmetric.ScopeMetrics
 	enableMetricType       bool
 	isMonotonicCounter     bool
-	observeTimer           ObserverType
-	observeHistogram       ObserverType
+	observeTimer           TimerHistogramMapping
+	observeHistogram       TimerHistogramMapping
 	lastIntervalTime       time.Time
 }
 
@@ -85,6 +116,22 @@ type summaryMetric struct {
 	weights []float64
 }
 
+// histogramMetric holds either an exponential histogram or, when explicit
+// bounds are configured, a histogram with explicit buckets.
+type histogramMetric struct {
+	exponential *structure.Histogram[float64]
+	explicit    *explicitHistogram
+}
+
+type explicitHistogram struct {
+	bounds []float64
+	counts []uint64
+	count  uint64
+	sum    float64
+	min    float64
+	max    float64
+}
+
 type statsDMetric struct {
 	description statsDMetricDescription
 	asFloat     float64
@@ -118,18 +165,22 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 	p.gauges = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.counters = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
+	p.histograms = make(map[statsDMetricDescription]histogramMetric)
 
-	p.observeHistogram = DefaultObserverType
-	p.observeTimer = DefaultObserverType
+	p.observeHistogram = TimerHistogramMapping{StatsdType: HistogramTypeName, ObserverType: DefaultObserverType}
+	p.observeTimer = TimerHistogramMapping{StatsdType: TimingTypeName, ObserverType: DefaultObserverType, Unit: DefaultTimerUnit}
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
 	// Note: validation occurs in ("../".Config).vaidate()
 	for _, eachMap := range sendTimerHistogram {
 		switch eachMap.StatsdType {
 		case HistogramTypeName:
-			p.observeHistogram = eachMap.ObserverType
+			p.observeHistogram = eachMap
 		case TimingTypeName, TimingAltTypeName:
-			p.observeTimer = eachMap.ObserverType
+			if eachMap.Unit == "" {
+				eachMap.Unit = DefaultTimerUnit
+			}
+			p.observeTimer = eachMap
 		}
 	}
 	return nil
@@ -153,12 +204,28 @@ func (p *StatsDParser) GetMetrics() pmetric.Metrics {
 	}
 
 	for desc, summaryMetric := range p.summaries {
+		percentiles := p.observerFor(desc.metricType).Summary.Percentiles
+		if len(percentiles) == 0 {
+			percentiles = statsDDefaultPercentiles
+		}
 		buildSummaryMetric(
 			desc,
 			summaryMetric,
 			p.lastIntervalTime,
 			timeNowFunc(),
-			statsDDefaultPercentiles,
+			percentiles,
+			p.unitFor(desc.metricType),
+			rm.ScopeMetrics().AppendEmpty(),
+		)
+	}
+
+	for desc, histogramMetric := range p.histograms {
+		buildHistogramMetric(
+			desc,
+			histogramMetric,
+			p.lastIntervalTime,
+			timeNowFunc(),
+			p.unitFor(desc.metricType),
 			rm.ScopeMetrics().AppendEmpty(),
 		)
 	}
@@ -167,19 +234,29 @@ func (p *StatsDParser) GetMetrics() pmetric.Metrics {
 	p.counters = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.timersAndDistributions = nil
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
+	p.histograms = make(map[statsDMetricDescription]histogramMetric)
 	return metrics
 }
 
 var timeNowFunc = time.Now
 
-func (p *StatsDParser) observerTypeFor(t MetricType) ObserverType {
+func (p *StatsDParser) observerFor(t MetricType) TimerHistogramMapping {
 	switch t {
 	case HistogramType:
 		return p.observeHistogram
 	case TimingType:
 		return p.observeTimer
 	}
-	return DisableObserver
+	return TimerHistogramMapping{ObserverType: DisableObserver}
+}
+
+// unitFor returns the unit of the metrics observed for the type, which is only
+// known for the timings.
+func (p *StatsDParser) unitFor(t MetricType) string {
+	if t == TimingType {
+		return string(p.observeTimer.Unit)
+	}
+	return ""
 }
 
 // Aggregate for each metric line.
@@ -214,7 +291,11 @@ func (p *StatsDParser) Aggregate(line string) error {
 		}
 
 	case TimingType, HistogramType:
-		switch p.observerTypeFor(parsedMetric.description.metricType) {
+		if parsedMetric.description.metricType == TimingType && p.observeTimer.Unit == SecondsUnit {
+			parsedMetric.asFloat /= 1000
+		}
+		observer := p.observerFor(parsedMetric.description.metricType)
+		switch observer.ObserverType {
 		case GaugeObserver:
 			p.timersAndDistributions = append(p.timersAndDistributions, buildGaugeMetric(parsedMetric, timeNowFunc()))
 		case SummaryObserver:
@@ -230,6 +311,15 @@ func (p *StatsDParser) Aggregate(line string) error {
 					weights: append(existing.weights, raw.count),
 				}
 			}
+		case HistogramObserver:
+			raw := parsedMetric.summaryValue()
+			existing, ok := p.histograms[parsedMetric.description]
+			if !ok {
+				existing = newHistogramMetric(observer.Histogram)
+				p.histograms[parsedMetric.description] = existing
+			}
+			// Note: the count is rounded here, see note in counterValue().
+			existing.update(raw.value, uint64(raw.count))
 		case DisableObserver:
 			// No action.
 		}
@@ -20,6 +20,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -896,8 +898,8 @@ func TestStatsDParser_Initialize(t *testing.T) {
 		attrs:      *attribute.EmptySet()}
 	p.gauges[teststatsdDMetricdescription] = pmetric.ScopeMetrics{}
 	assert.Equal(t, 1, len(p.gauges))
-	assert.Equal(t, GaugeObserver, p.observeTimer)
-	assert.Equal(t, GaugeObserver, p.observeHistogram)
+	assert.Equal(t, GaugeObserver, p.observeTimer.ObserverType)
+	assert.Equal(t, GaugeObserver, p.observeHistogram.ObserverType)
 }
 
 func TestStatsDParser_GetMetricsWithMetricType(t *testing.T) {
@@ -971,6 +973,17 @@ func TestStatsDParser_Mappings(t *testing.T) {
 				"Gauge": "T",
 			},
 		},
+		{
+			name: "timer-histogram-histo-explicit-histogram",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "timer", ObserverType: "histogram"},
+				{StatsdType: "histogram", ObserverType: "histogram", Histogram: HistogramConfig{ExplicitBounds: []float64{1, 10, 100}}},
+			},
+			expect: map[string]string{
+				"ExponentialHistogram": "T",
+				"Histogram":            "H",
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			p := &StatsDParser{}
@@ -997,6 +1010,132 @@ func TestStatsDParser_Mappings(t *testing.T) {
 	}
 }
 
+func TestStatsDParser_AggregateHistogram(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, []TimerHistogramMapping{
+		{StatsdType: "timer", ObserverType: "histogram", Histogram: HistogramConfig{MaxSize: 10}},
+		{StatsdType: "histogram", ObserverType: "histogram", Histogram: HistogramConfig{ExplicitBounds: []float64{10, 100}}},
+	}))
+	p.lastIntervalTime = time.Unix(611, 0)
+	for _, line := range []string{
+		"statsdTestMetric1:1|ms",
+		"statsdTestMetric1:2|ms",
+		"statsdTestMetric1:0|ms|@0.5",
+		"statsdTestMetric2:5|h",
+		"statsdTestMetric2:10|h",
+		"statsdTestMetric2:50|h|@0.5",
+		"statsdTestMetric2:500|h",
+	} {
+		assert.NoError(t, p.Aggregate(line))
+	}
+
+	metrics := p.GetMetrics()
+	ilms := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	require.Equal(t, 2, ilms.Len())
+	for i := 0; i < ilms.Len(); i++ {
+		m := ilms.At(i).Metrics().At(0)
+		switch m.Name() {
+		case "statsdTestMetric1":
+			assert.Equal(t, "ms", m.Unit())
+			require.Equal(t, pmetric.MetricTypeExponentialHistogram, m.Type())
+			assert.Equal(t, pmetric.MetricAggregationTemporalityDelta, m.ExponentialHistogram().AggregationTemporality())
+			dp := m.ExponentialHistogram().DataPoints().At(0)
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(611, 0)), dp.StartTimestamp())
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), dp.Timestamp())
+			assert.Equal(t, uint64(4), dp.Count())
+			assert.Equal(t, uint64(2), dp.ZeroCount())
+			assert.Equal(t, 3.0, dp.Sum())
+			assert.Equal(t, 0.0, dp.Min())
+			assert.Equal(t, 2.0, dp.Max())
+			var total uint64
+			for _, c := range dp.Positive().BucketCounts().AsRaw() {
+				total += c
+			}
+			assert.Equal(t, uint64(2), total)
+			assert.Equal(t, 0, dp.Negative().BucketCounts().Len())
+		case "statsdTestMetric2":
+			assert.Equal(t, "", m.Unit())
+			require.Equal(t, pmetric.MetricTypeHistogram, m.Type())
+			dp := m.Histogram().DataPoints().At(0)
+			assert.Equal(t, uint64(5), dp.Count())
+			assert.Equal(t, 615.0, dp.Sum())
+			assert.Equal(t, 5.0, dp.Min())
+			assert.Equal(t, 500.0, dp.Max())
+			assert.Equal(t, []float64{10, 100}, dp.ExplicitBounds().AsRaw())
+			assert.Equal(t, []uint64{2, 2, 1}, dp.BucketCounts().AsRaw())
+		default:
+			t.Errorf("unexpected metric %s", m.Name())
+		}
+	}
+}
+
+func TestStatsDParser_TimerUnit(t *testing.T) {
+	tests := []struct {
+		name          string
+		mapping       []TimerHistogramMapping
+		expectedValue float64
+	}{
+		{
+			name:          "default milliseconds",
+			mapping:       []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}},
+			expectedValue: 1500,
+		},
+		{
+			name:          "seconds",
+			mapping:       []TimerHistogramMapping{{StatsdType: "timing", ObserverType: "gauge", Unit: "s"}},
+			expectedValue: 1.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &StatsDParser{}
+			assert.NoError(t, p.Initialize(false, false, tt.mapping))
+			assert.NoError(t, p.Aggregate("statsdTestMetric1:1500|ms"))
+			require.Len(t, p.timersAndDistributions, 1)
+			m := p.timersAndDistributions[0].Metrics().At(0)
+			// the gauges of the timings are left without a unit, which only the summaries
+			// and histograms observing them have
+			assert.Equal(t, "", m.Unit())
+			assert.Equal(t, tt.expectedValue, m.Gauge().DataPoints().At(0).DoubleValue())
+		})
+	}
+}
+
+func TestStatsDParser_SummaryPercentiles(t *testing.T) {
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, []TimerHistogramMapping{
+		{StatsdType: "timer", ObserverType: "summary", Unit: "s", Summary: SummaryConfig{Percentiles: []float64{50, 99}}},
+		{StatsdType: "histogram", ObserverType: "summary"},
+	}))
+	assert.NoError(t, p.Aggregate("statsdTestMetric1:1000|ms"))
+	assert.NoError(t, p.Aggregate("statsdTestMetric2:10|h"))
+
+	metrics := p.GetMetrics()
+	ilms := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	require.Equal(t, 2, ilms.Len())
+	for i := 0; i < ilms.Len(); i++ {
+		m := ilms.At(i).Metrics().At(0)
+		quantiles := m.Summary().DataPoints().At(0).QuantileValues()
+		switch m.Name() {
+		case "statsdTestMetric1":
+			assert.Equal(t, "s", m.Unit())
+			require.Equal(t, 2, quantiles.Len())
+			assert.Equal(t, 0.5, quantiles.At(0).Quantile())
+			assert.Equal(t, 0.99, quantiles.At(1).Quantile())
+			assert.Equal(t, 1.0, quantiles.At(1).Value())
+		case "statsdTestMetric2":
+			assert.Equal(t, "", m.Unit())
+			assert.Equal(t, len(statsDDefaultPercentiles), quantiles.Len())
+		default:
+			t.Errorf("unexpected metric %s", m.Name())
+		}
+	}
+}
+
 func TestTimeNowFunc(t *testing.T) {
 	timeNow := timeNowFunc()
 	assert.NotNil(t, timeNow)
@@ -15,6 +15,7 @@
 package udplogreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/udplogreceiver"
 
 import (
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 
@@ -30,6 +31,7 @@ const (
 
 // NewFactory creates a factory for udp receiver
 func NewFactory() component.ReceiverFactory {
+	_ = view.Register(udp.MetricViews()...)
 	return adapter.NewFactory(ReceiverType{}, stability)
 }
 
@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/event"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/performance"
@@ -123,6 +125,19 @@ func (vc *vcenterClient) VMs(ctx context.Context) ([]*object.VirtualMachine, err
 	return vms, err
 }
 
+// EventCollector returns a collector of the events created after begin
+func (vc *vcenterClient) EventCollector(ctx context.Context, begin time.Time) (*event.HistoryCollector, error) {
+	collector, err := event.NewManager(vc.vimDriver).CreateCollectorForEvents(ctx, vt.EventFilterSpec{
+		Time: &vt.EventFilterSpecByTime{
+			BeginTime: vt.NewTime(begin),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create an event collector: %w", err)
+	}
+	return collector, nil
+}
+
 type perfSampleResult struct {
 	counters map[string]*vt.PerfCounterInfo
 	results  []performance.EntityMetric
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -34,6 +35,16 @@ type Config struct {
 	Endpoint                                string                   `mapstructure:"endpoint"`
 	Username                                string                   `mapstructure:"username"`
 	Password                                string                   `mapstructure:"password"`
+	Events                                  EventsConfig             `mapstructure:"events"`
+}
+
+// EventsConfig is the configuration of the events and alarms collected by the
+// logs receiver.
+type EventsConfig struct {
+	// PollInterval is the interval at which new events are read.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	// PageSize is the maximum number of events read in a single request.
+	PageSize int32 `mapstructure:"page_size"`
 }
 
 // Validate checks to see if the supplied config will work for the receiver
@@ -61,6 +72,14 @@ func (c *Config) Validate() error {
 		err = multierr.Append(err, errors.New("password not provided and is required"))
 	}
 
+	if c.Events.PollInterval <= 0 {
+		err = multierr.Append(err, errors.New("events poll_interval must be positive"))
+	}
+
+	if c.Events.PageSize <= 0 || c.Events.PageSize > maxEventsPageSize {
+		err = multierr.Append(err, fmt.Errorf("events page_size must be between 1 and %d", maxEventsPageSize))
+	}
+
 	if _, tlsErr := c.LoadTLSConfig(); err != nil {
 		err = multierr.Append(err, fmt.Errorf("error loading tls configuration: %w", tlsErr))
 	}
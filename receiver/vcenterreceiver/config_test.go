@@ -79,6 +79,27 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectedErr: errors.New("password not provided"),
 		},
+		{
+			desc: "events poll interval not positive",
+			cfg: Config{
+				Endpoint: "https://vcsa.some-host",
+				Events: EventsConfig{
+					PageSize: 100,
+				},
+			},
+			expectedErr: errors.New("events poll_interval must be positive"),
+		},
+		{
+			desc: "events page size too large",
+			cfg: Config{
+				Endpoint: "https://vcsa.some-host",
+				Events: EventsConfig{
+					PollInterval: time.Minute,
+					PageSize:     1001,
+				},
+			},
+			expectedErr: errors.New("events page_size must be between 1 and 1000"),
+		},
 	}
 
 	for _, tc := range cases {
@@ -109,6 +130,8 @@ func TestLoadConfig(t *testing.T) {
 	expected.Metrics = metadata.DefaultMetricsSettings()
 	expected.Metrics.VcenterHostCPUUtilization.Enabled = false
 	expected.CollectionInterval = 5 * time.Minute
+	expected.Events.PollInterval = 30 * time.Second
+	expected.Events.PageSize = 500
 
 	require.Equal(t, expected, cfg)
 }
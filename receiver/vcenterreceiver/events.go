@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/vcenterreceiver"
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/vmware/govmomi/event"
+	vt "github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultEventsPollInterval = time.Minute
+	defaultEventsPageSize     = 100
+	// maxEventsPageSize is the maximum number of events the vSphere API
+	// returns in a single page.
+	maxEventsPageSize = 1000
+)
+
+var _ component.LogsReceiver = (*vcenterEventsReceiver)(nil)
+
+// vcenterEventsReceiver reads the events, including the alarm status changes,
+// created since it started and converts them to logs.
+type vcenterEventsReceiver struct {
+	client    *vcenterClient
+	config    *Config
+	consumer  consumer.Logs
+	logger    *zap.Logger
+	collector *event.HistoryCollector
+	begin     time.Time
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+func newEventsReceiver(logger *zap.Logger, config *Config, consumer consumer.Logs) *vcenterEventsReceiver {
+	return &vcenterEventsReceiver{
+		client:   newVcenterClient(config),
+		config:   config,
+		consumer: consumer,
+		logger:   logger,
+	}
+}
+
+func (r *vcenterEventsReceiver) Start(_ context.Context, _ component.Host) error {
+	r.begin = time.Now()
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	// don't fail to start if we cannot establish connection, the collector is
+	// created on the next poll instead
+	if err := r.ensureCollector(ctx); err != nil {
+		r.logger.Error("unable to start collecting vCenter events", zap.Error(err))
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.config.Events.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := r.poll(ctx); err != nil {
+					r.logger.Error("unable to read vCenter events", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *vcenterEventsReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	if r.collector != nil {
+		// the collector is also destroyed when the session ends
+		_ = r.collector.Destroy(ctx)
+	}
+	return r.client.Disconnect(ctx)
+}
+
+// ensureCollector connects to the vSphere SDK and creates the event collector
+// if needed. The collector is recreated after a reconnection, as it belongs to
+// the previous session.
+func (r *vcenterEventsReceiver) ensureCollector(ctx context.Context) error {
+	if r.collector != nil {
+		if sessionActive, _ := r.client.moClient.SessionManager.SessionIsActive(ctx); sessionActive {
+			return nil
+		}
+		r.collector = nil
+	}
+	if err := r.client.EnsureConnection(ctx); err != nil {
+		return err
+	}
+	collector, err := r.client.EventCollector(ctx, r.begin)
+	if err != nil {
+		return err
+	}
+	r.collector = collector
+	return nil
+}
+
+// poll reads the events created since the previous poll, page by page, and
+// sends them to the next consumer.
+func (r *vcenterEventsReceiver) poll(ctx context.Context) error {
+	if err := r.ensureCollector(ctx); err != nil {
+		return err
+	}
+	for {
+		events, err := r.collector.ReadNextEvents(ctx, r.config.Events.PageSize)
+		if err != nil {
+			return fmt.Errorf("unable to read the next events: %w", err)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		if err = r.consumer.ConsumeLogs(ctx, eventsToLogs(events, time.Now())); err != nil {
+			return err
+		}
+		// resume after the newest event if the connection is lost
+		r.begin = events[len(events)-1].GetEvent().CreatedTime.Add(time.Nanosecond)
+	}
+}
+
+// eventsToLogs converts the events to log records, each with the attributes
+// of the entities involved in its resource.
+func eventsToLogs(events []vt.BaseEvent, observed time.Time) plog.Logs {
+	logs := plog.NewLogs()
+	observedTimestamp := pcommon.NewTimestampFromTime(observed)
+	for _, baseEvent := range events {
+		e := baseEvent.GetEvent()
+
+		rl := logs.ResourceLogs().AppendEmpty()
+		putEntityAttributes(rl.Resource().Attributes(), e)
+
+		lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(e.CreatedTime))
+		lr.SetObservedTimestamp(observedTimestamp)
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.SetSeverityText("info")
+		lr.Body().SetStr(e.FullFormattedMessage)
+
+		attrs := lr.Attributes()
+		attrs.PutStr("vcenter.event.type", reflect.Indirect(reflect.ValueOf(baseEvent)).Type().Name())
+		attrs.PutInt("vcenter.event.key", int64(e.Key))
+		attrs.PutInt("vcenter.event.chain_id", int64(e.ChainId))
+		if e.UserName != "" {
+			attrs.PutStr("vcenter.user.name", e.UserName)
+		}
+
+		switch typed := baseEvent.(type) {
+		case *vt.EventEx:
+			attrs.PutStr("vcenter.event.type", typed.EventTypeId)
+			if typed.Severity != "" {
+				lr.SetSeverityNumber(eventSeverityNumber(typed.Severity))
+				lr.SetSeverityText(typed.Severity)
+			}
+		case *vt.AlarmStatusChangedEvent:
+			attrs.PutStr("vcenter.alarm.name", typed.Alarm.Name)
+			attrs.PutStr("vcenter.alarm.status", typed.To)
+			attrs.PutStr("vcenter.alarm.previous_status", typed.From)
+			attrs.PutStr("vcenter.alarm.entity.name", typed.Entity.Name)
+			lr.SetSeverityNumber(alarmStatusSeverityNumber(typed.To))
+			lr.SetSeverityText(typed.To)
+		case vt.BaseAlarmEvent:
+			attrs.PutStr("vcenter.alarm.name", typed.GetAlarmEvent().Alarm.Name)
+		}
+	}
+	return logs
+}
+
+// putEntityAttributes sets the resource attributes of the entities the event
+// is about.
+func putEntityAttributes(attrs pcommon.Map, e *vt.Event) {
+	if e.Datacenter != nil {
+		attrs.PutStr("vcenter.datacenter.name", e.Datacenter.Name)
+	}
+	if e.ComputeResource != nil {
+		attrs.PutStr("vcenter.cluster.name", e.ComputeResource.Name)
+	}
+	if e.Host != nil {
+		attrs.PutStr("vcenter.host.name", e.Host.Name)
+	}
+	if e.Vm != nil {
+		attrs.PutStr("vcenter.vm.name", e.Vm.Name)
+	}
+	if e.Ds != nil {
+		attrs.PutStr("vcenter.datastore.name", e.Ds.Name)
+	}
+}
+
+// eventSeverityNumber maps the severity of an extended event.
+func eventSeverityNumber(severity string) plog.SeverityNumber {
+	switch severity {
+	case string(vt.EventEventSeverityError):
+		return plog.SeverityNumberError
+	case string(vt.EventEventSeverityWarning):
+		return plog.SeverityNumberWarn
+	default:
+		return plog.SeverityNumberInfo
+	}
+}
+
+// alarmStatusSeverityNumber maps the status an alarm changed to.
+func alarmStatusSeverityNumber(status string) plog.SeverityNumber {
+	switch status {
+	case string(vt.ManagedEntityStatusRed):
+		return plog.SeverityNumberError
+	case string(vt.ManagedEntityStatusYellow):
+		return plog.SeverityNumberWarn
+	default:
+		return plog.SeverityNumberInfo
+	}
+}
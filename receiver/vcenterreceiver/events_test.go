@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vcenterreceiver // import github.com/open-telemetry/opentelemetry-collector-contrib/receiver/vcenterreceiver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/event"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	vt "github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+func TestEventsReceiverPoll(t *testing.T) {
+	simulator.Test(func(ctx context.Context, c *vim25.Client) {
+		pw, _ := simulator.DefaultLogin.Password()
+		cfg := createDefaultConfig().(*Config)
+		cfg.Endpoint = fmt.Sprintf("%s://%s", c.URL().Scheme, c.URL().Host)
+		cfg.Username = simulator.DefaultLogin.Username()
+		cfg.Password = pw
+		cfg.TLSClientSetting = configtls.TLSClientSetting{Insecure: true}
+		cfg.Events.PageSize = 2
+
+		sink := &consumertest.LogsSink{}
+		r := newEventsReceiver(zap.NewNop(), cfg, sink)
+		r.begin = time.Now()
+		defer func() {
+			require.NoError(t, r.Shutdown(ctx))
+		}()
+
+		manager := event.NewManager(c)
+		require.NoError(t, manager.PostEvent(ctx, &vt.VmMigratedEvent{
+			VmEvent: vt.VmEvent{
+				Event: vt.Event{
+					ComputeResource: &vt.ComputeResourceEventArgument{EntityEventArgument: vt.EntityEventArgument{Name: "cluster-1"}},
+					Host:            &vt.HostEventArgument{EntityEventArgument: vt.EntityEventArgument{Name: "host-2"}},
+					Vm:              &vt.VmEventArgument{EntityEventArgument: vt.EntityEventArgument{Name: "vm-1"}},
+				},
+			},
+			SourceHost:      vt.HostEventArgument{EntityEventArgument: vt.EntityEventArgument{Name: "host-1"}},
+			SourceDatastore: &vt.DatastoreEventArgument{EntityEventArgument: vt.EntityEventArgument{Name: "datastore-1"}},
+		}))
+		require.NoError(t, manager.PostEvent(ctx, &vt.AlarmStatusChangedEvent{
+			AlarmEvent: vt.AlarmEvent{
+				Alarm: vt.AlarmEventArgument{EntityEventArgument: vt.EntityEventArgument{Name: "Host hardware fan status"}},
+			},
+			Entity: vt.ManagedEntityEventArgument{EntityEventArgument: vt.EntityEventArgument{Name: "host-2"}},
+			From:   "green",
+			To:     "red",
+		}))
+		require.NoError(t, manager.PostEvent(ctx, &vt.EventEx{
+			EventTypeId: "com.vmware.vc.HA.ClusterFailoverInProgressEvent",
+			Severity:    "warning",
+		}))
+
+		require.NoError(t, r.poll(ctx))
+		logs := sink.AllLogs()
+		// the events are read in pages of 2, and the login of the receiver
+		// is the fourth event
+		require.Len(t, logs, 2)
+		require.Equal(t, 4, sink.LogRecordCount())
+
+		migrated := logs[0].ResourceLogs().At(0)
+		cluster, _ := migrated.Resource().Attributes().Get("vcenter.cluster.name")
+		require.Equal(t, "cluster-1", cluster.Str())
+		host, _ := migrated.Resource().Attributes().Get("vcenter.host.name")
+		require.Equal(t, "host-2", host.Str())
+		vm, _ := migrated.Resource().Attributes().Get("vcenter.vm.name")
+		require.Equal(t, "vm-1", vm.Str())
+		eventType, _ := migrated.ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("vcenter.event.type")
+		require.Equal(t, "VmMigratedEvent", eventType.Str())
+
+		alarm := logs[0].ResourceLogs().At(1).ScopeLogs().At(0).LogRecords().At(0)
+		require.Equal(t, plog.SeverityNumberError, alarm.SeverityNumber())
+		alarmName, _ := alarm.Attributes().Get("vcenter.alarm.name")
+		require.Equal(t, "Host hardware fan status", alarmName.Str())
+		previousStatus, _ := alarm.Attributes().Get("vcenter.alarm.previous_status")
+		require.Equal(t, "green", previousStatus.Str())
+
+		extended := logs[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		require.Equal(t, plog.SeverityNumberWarn, extended.SeverityNumber())
+		eventType, _ = extended.Attributes().Get("vcenter.event.type")
+		require.Equal(t, "com.vmware.vc.HA.ClusterFailoverInProgressEvent", eventType.Str())
+
+		// only the events created since the previous poll are read
+		sink.Reset()
+		require.NoError(t, r.poll(ctx))
+		require.Equal(t, 0, sink.LogRecordCount())
+
+		require.NoError(t, manager.PostEvent(ctx, &vt.GeneralUserEvent{
+			GeneralEvent: vt.GeneralEvent{Message: "maintenance"},
+		}))
+		require.NoError(t, r.poll(ctx))
+		require.Equal(t, 1, sink.LogRecordCount())
+	})
+}
+
+func TestEventsReceiverPollConnectionError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "http://\x00"
+	r := newEventsReceiver(zap.NewNop(), cfg, consumertest.NewNop())
+	require.Error(t, r.poll(context.Background()))
+}
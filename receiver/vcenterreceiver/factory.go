@@ -41,6 +41,7 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, stability),
 	)
 }
 
@@ -52,6 +53,10 @@ func createDefaultConfig() config.Receiver {
 		},
 		TLSClientSetting: configtls.TLSClientSetting{},
 		Metrics:          metadata.DefaultMetricsSettings(),
+		Events: EventsConfig{
+			PollInterval: defaultEventsPollInterval,
+			PageSize:     defaultEventsPageSize,
+		},
 	}
 }
 
@@ -99,3 +104,19 @@ func createMetricsReceiver(
 		scraperhelper.AddScraper(scraper),
 	)
 }
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	rConf config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	cfg, ok := rConf.(*Config)
+	if !ok {
+		return nil, errConfigNotVcenter
+	}
+	if consumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	return newEventsReceiver(params.Logger, cfg, consumer), nil
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+const (
+	schemeGitHub = "github"
+	schemeStripe = "stripe"
+	schemeHMAC   = "hmac"
+
+	algorithmSHA1   = "sha1"
+	algorithmSHA256 = "sha256"
+	algorithmSHA512 = "sha512"
+
+	encodingHex    = "hex"
+	encodingBase64 = "base64"
+)
+
+// Config defines configuration for the webhook event receiver.
+type Config struct {
+	config.ReceiverSettings       `mapstructure:",squash"`
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Paths lists the HTTP paths accepting webhook events, each with its own parsing rules.
+	Paths []PathConfig `mapstructure:"paths"`
+}
+
+// PathConfig defines how the events posted to a path are verified and converted to log records.
+type PathConfig struct {
+	// Path is the HTTP path accepting the events, e.g. "/github".
+	Path string `mapstructure:"path"`
+
+	// Signature configures the verification of the signature of the events.
+	// Events are not verified when not set.
+	Signature *SignatureConfig `mapstructure:"signature"`
+
+	// Attributes maps attribute names to the JSON path of the payload field
+	// they are extracted from, e.g. "$.repository.full_name".
+	Attributes map[string]string `mapstructure:"attributes"`
+
+	// Headers lists the request headers added as "webhook.header.<name>" attributes.
+	Headers []string `mapstructure:"headers"`
+
+	// Timestamp is the JSON path of the payload field holding the time of the event,
+	// either as seconds since the epoch or as an RFC3339 string.
+	Timestamp string `mapstructure:"timestamp"`
+
+	// Body is the JSON path of the payload field used as the log record body.
+	// The whole payload is used when not set.
+	Body string `mapstructure:"body"`
+}
+
+// SignatureConfig defines how the signature of the events is verified.
+type SignatureConfig struct {
+	// Scheme is the signature scheme: "github" (X-Hub-Signature-256 header),
+	// "stripe" (Stripe-Signature header) or "hmac" for a generic HMAC of the body.
+	Scheme string `mapstructure:"scheme"`
+
+	// Secret is the shared secret the signature is computed with.
+	Secret string `mapstructure:"secret"`
+
+	// Header is the request header holding the signature. Only used by the "hmac" scheme.
+	Header string `mapstructure:"header"`
+
+	// Algorithm is the hash function of the HMAC: "sha1", "sha256" (default) or "sha512".
+	// Only used by the "hmac" scheme.
+	Algorithm string `mapstructure:"algorithm"`
+
+	// Encoding is the encoding of the signature: "hex" (default) or "base64".
+	// Only used by the "hmac" scheme.
+	Encoding string `mapstructure:"encoding"`
+
+	// Prefix is removed from the header value before decoding the signature, e.g. "sha256=".
+	// Only used by the "hmac" scheme.
+	Prefix string `mapstructure:"prefix"`
+
+	// Tolerance is the maximum age of a signed event, protecting against replays.
+	// Only used by the "stripe" scheme, where the signature includes the time of the event.
+	Tolerance time.Duration `mapstructure:"tolerance"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	if cfg.MaxRequestBodySize <= 0 {
+		return errors.New("max_request_body_size must be greater than 0")
+	}
+	if len(cfg.Paths) == 0 {
+		return errors.New("at least one path is required")
+	}
+
+	seen := map[string]bool{}
+	for _, p := range cfg.Paths {
+		if !strings.HasPrefix(p.Path, "/") {
+			return fmt.Errorf("invalid path %q, must start with /", p.Path)
+		}
+		if seen[p.Path] {
+			return fmt.Errorf("duplicate path %q", p.Path)
+		}
+		seen[p.Path] = true
+
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("path %q: %w", p.Path, err)
+		}
+	}
+	return nil
+}
+
+func (p *PathConfig) validate() error {
+	for name, path := range p.Attributes {
+		if _, err := parseJSONPath(path); err != nil {
+			return fmt.Errorf("attribute %q: %w", name, err)
+		}
+	}
+	if p.Timestamp != "" {
+		if _, err := parseJSONPath(p.Timestamp); err != nil {
+			return fmt.Errorf("timestamp: %w", err)
+		}
+	}
+	if p.Body != "" {
+		if _, err := parseJSONPath(p.Body); err != nil {
+			return fmt.Errorf("body: %w", err)
+		}
+	}
+	if p.Signature != nil {
+		return p.Signature.validate()
+	}
+	return nil
+}
+
+func (s *SignatureConfig) validate() error {
+	if s.Secret == "" {
+		return errors.New("signature secret is required")
+	}
+
+	switch s.Scheme {
+	case schemeGitHub:
+	case schemeStripe:
+		if s.Tolerance < 0 {
+			return errors.New("signature tolerance must not be negative")
+		}
+	case schemeHMAC:
+		if s.Header == "" {
+			return errors.New("signature header is required by the hmac scheme")
+		}
+		switch s.Algorithm {
+		case "", algorithmSHA1, algorithmSHA256, algorithmSHA512:
+		default:
+			return fmt.Errorf("invalid signature algorithm %q", s.Algorithm)
+		}
+		switch s.Encoding {
+		case "", encodingHex, encodingBase64:
+		default:
+			return fmt.Errorf("invalid signature encoding %q", s.Encoding)
+		}
+	default:
+		return fmt.Errorf("invalid signature scheme %q, must be one of %q, %q or %q", s.Scheme, schemeGitHub, schemeStripe, schemeHMAC)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookeventreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       config.ComponentID
+		expected config.Receiver
+	}{
+		{
+			id:       config.NewComponentID(typeStr),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "custom"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint:           "0.0.0.0:9443",
+					MaxRequestBodySize: 2097152,
+				},
+				Paths: []PathConfig{
+					{
+						Path: "/github",
+						Signature: &SignatureConfig{
+							Scheme: schemeGitHub,
+							Secret: "github_secret",
+						},
+						Headers: []string{"X-GitHub-Event"},
+						Attributes: map[string]string{
+							"repository": "$.repository.full_name",
+							"sender":     "$.sender.login",
+						},
+					},
+					{
+						Path: "/stripe",
+						Signature: &SignatureConfig{
+							Scheme:    schemeStripe,
+							Secret:    "stripe_secret",
+							Tolerance: 10 * time.Minute,
+						},
+						Attributes: map[string]string{
+							"event.type": "$.type",
+						},
+						Timestamp: "$.created",
+						Body:      "$.data.object",
+					},
+					{
+						Path: "/custom",
+						Signature: &SignatureConfig{
+							Scheme:    schemeHMAC,
+							Secret:    "custom_secret",
+							Header:    "X-Signature",
+							Algorithm: algorithmSHA512,
+							Encoding:  encodingBase64,
+							Prefix:    "sha512=",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, config.UnmarshalReceiver(sub, cfg))
+
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	validPaths := []PathConfig{{Path: "/events"}}
+
+	tests := []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:        "missing endpoint",
+			modify:      func(cfg *Config) { cfg.Endpoint = "" },
+			expectedErr: "endpoint is required",
+		},
+		{
+			name:        "invalid max request body size",
+			modify:      func(cfg *Config) { cfg.MaxRequestBodySize = 0 },
+			expectedErr: "max_request_body_size must be greater than 0",
+		},
+		{
+			name:        "no path",
+			modify:      func(cfg *Config) { cfg.Paths = nil },
+			expectedErr: "at least one path is required",
+		},
+		{
+			name:        "relative path",
+			modify:      func(cfg *Config) { cfg.Paths = []PathConfig{{Path: "events"}} },
+			expectedErr: `invalid path "events", must start with /`,
+		},
+		{
+			name:        "duplicate path",
+			modify:      func(cfg *Config) { cfg.Paths = append(cfg.Paths, PathConfig{Path: "/events"}) },
+			expectedErr: `duplicate path "/events"`,
+		},
+		{
+			name:        "invalid attribute path",
+			modify:      func(cfg *Config) { cfg.Paths[0].Attributes = map[string]string{"id": "$.items["} },
+			expectedErr: `path "/events": attribute "id": invalid JSON path "$.items[": missing ]`,
+		},
+		{
+			name:        "invalid timestamp path",
+			modify:      func(cfg *Config) { cfg.Paths[0].Timestamp = "$..created" },
+			expectedErr: `path "/events": timestamp: invalid JSON path "$..created": empty key`,
+		},
+		{
+			name:        "invalid body path",
+			modify:      func(cfg *Config) { cfg.Paths[0].Body = "$[x]" },
+			expectedErr: `path "/events": body: invalid JSON path "$[x]": invalid index "x"`,
+		},
+		{
+			name: "missing secret",
+			modify: func(cfg *Config) {
+				cfg.Paths[0].Signature = &SignatureConfig{Scheme: schemeGitHub}
+			},
+			expectedErr: `path "/events": signature secret is required`,
+		},
+		{
+			name: "invalid scheme",
+			modify: func(cfg *Config) {
+				cfg.Paths[0].Signature = &SignatureConfig{Scheme: "basic", Secret: "secret"}
+			},
+			expectedErr: `path "/events": invalid signature scheme "basic", must be one of "github", "stripe" or "hmac"`,
+		},
+		{
+			name: "negative tolerance",
+			modify: func(cfg *Config) {
+				cfg.Paths[0].Signature = &SignatureConfig{Scheme: schemeStripe, Secret: "secret", Tolerance: -time.Second}
+			},
+			expectedErr: `path "/events": signature tolerance must not be negative`,
+		},
+		{
+			name: "hmac without header",
+			modify: func(cfg *Config) {
+				cfg.Paths[0].Signature = &SignatureConfig{Scheme: schemeHMAC, Secret: "secret"}
+			},
+			expectedErr: `path "/events": signature header is required by the hmac scheme`,
+		},
+		{
+			name: "invalid algorithm",
+			modify: func(cfg *Config) {
+				cfg.Paths[0].Signature = &SignatureConfig{Scheme: schemeHMAC, Secret: "secret", Header: "X-Signature", Algorithm: "md5"}
+			},
+			expectedErr: `path "/events": invalid signature algorithm "md5"`,
+		},
+		{
+			name: "invalid encoding",
+			modify: func(cfg *Config) {
+				cfg.Paths[0].Signature = &SignatureConfig{Scheme: schemeHMAC, Secret: "secret", Header: "X-Signature", Encoding: "base32"}
+			},
+			expectedErr: `path "/events": invalid signature encoding "base32"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Paths = append([]PathConfig{}, validPaths...)
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is the path to a field of a JSON document, e.g. "$.items[0].name".
+// Only child keys, array indexes and quoted keys (e.g. "$['user.name']") are supported.
+type jsonPath []pathStep
+
+type pathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseJSONPath parses a JSON path. The leading "$" is optional: "user.name" and
+// "$.user.name" are equivalent, and "$" refers to the whole document.
+func parseJSONPath(s string) (jsonPath, error) {
+	rest := s
+	if strings.HasPrefix(rest, "$") {
+		rest = rest[1:]
+	} else if rest != "" && rest[0] != '[' {
+		rest = "." + rest
+	}
+
+	path := jsonPath{}
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid JSON path %q: empty key", s)
+			}
+			path = append(path, pathStep{key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q: missing ]", s)
+			}
+			inner := rest[1:end]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				path = append(path, pathStep{key: inner[1 : len(inner)-1]})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid JSON path %q: invalid index %q", s, inner)
+				}
+				path = append(path, pathStep{index: index, isIndex: true})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid JSON path %q: unexpected character %q", s, rest[0])
+		}
+	}
+	return path, nil
+}
+
+// get returns the field of the decoded JSON document at the path, or false if there is none.
+func (p jsonPath) get(doc interface{}) (interface{}, bool) {
+	current := doc
+	for _, step := range p {
+		if step.isIndex {
+			array, ok := current.([]interface{})
+			if !ok || step.index >= len(array) {
+				return nil, false
+			}
+			current = array[step.index]
+			continue
+		}
+
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[step.key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookeventreceiver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		path        string
+		expected    jsonPath
+		expectedErr string
+	}{
+		{path: "$", expected: jsonPath{}},
+		{path: "", expected: jsonPath{}},
+		{path: "$.user.name", expected: jsonPath{{key: "user"}, {key: "name"}}},
+		{path: "user.name", expected: jsonPath{{key: "user"}, {key: "name"}}},
+		{path: "$.items[1].id", expected: jsonPath{{key: "items"}, {index: 1, isIndex: true}, {key: "id"}}},
+		{path: "[0]", expected: jsonPath{{index: 0, isIndex: true}}},
+		{path: "$['user.name']", expected: jsonPath{{key: "user.name"}}},
+		{path: `$["user"]["name"]`, expected: jsonPath{{key: "user"}, {key: "name"}}},
+		{path: "$.", expectedErr: `invalid JSON path "$.": empty key`},
+		{path: "$.items[0", expectedErr: `invalid JSON path "$.items[0": missing ]`},
+		{path: "$.items[-1]", expectedErr: `invalid JSON path "$.items[-1]": invalid index "-1"`},
+		{path: "$user", expectedErr: `invalid JSON path "$user": unexpected character 'u'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := parseJSONPath(tt.path)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+}
+
+func TestJSONPathGet(t *testing.T) {
+	var doc interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"user": {"name": "alice", "user.id": 42},
+		"items": [{"id": "a"}, {"id": "b"}],
+		"empty": null
+	}`), &doc))
+
+	tests := []struct {
+		path     string
+		expected interface{}
+		found    bool
+	}{
+		{path: "$.user.name", expected: "alice", found: true},
+		{path: "$.user['user.id']", expected: float64(42), found: true},
+		{path: "$.items[1].id", expected: "b", found: true},
+		{path: "$.empty", expected: nil, found: true},
+		{path: "$.user.email", found: false},
+		{path: "$.items[2].id", found: false},
+		{path: "$.user[0]", found: false},
+		{path: "$.items.id", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := parseJSONPath(tt.path)
+			require.NoError(t, err)
+
+			value, found := path.get(doc)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	root, err := parseJSONPath("$")
+	require.NoError(t, err)
+	value, found := root.get(doc)
+	assert.True(t, found)
+	assert.Equal(t, doc, value)
+}
@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+const (
+	attributeWebhookPath   = "webhook.path"
+	attributeHeaderPrefix  = "webhook.header."
+	obsreportTransportHTTP = "http"
+)
+
+var (
+	errMissingHost     = errors.New("nil host")
+	errNilNextConsumer = errors.New("nil next consumer")
+	errBodyTooLarge    = errors.New("request body too large")
+)
+
+// pathHandler converts the events posted to a path into log records.
+type pathHandler struct {
+	path       string
+	verifier   verifier
+	attributes map[string]jsonPath
+	headers    []string
+	timestamp  jsonPath
+	body       jsonPath
+}
+
+func newPathHandler(cfg PathConfig) (*pathHandler, error) {
+	h := &pathHandler{
+		path:       cfg.Path,
+		attributes: map[string]jsonPath{},
+		headers:    cfg.Headers,
+	}
+	if cfg.Signature != nil {
+		h.verifier = newVerifier(cfg.Signature)
+	}
+
+	var err error
+	for name, path := range cfg.Attributes {
+		if h.attributes[name], err = parseJSONPath(path); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Timestamp != "" {
+		if h.timestamp, err = parseJSONPath(cfg.Timestamp); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Body != "" {
+		if h.body, err = parseJSONPath(cfg.Body); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// toLogs converts a decoded event into a log record.
+func (h *pathHandler) toLogs(header http.Header, payload interface{}, observed time.Time) plog.Logs {
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+
+	body := payload
+	if h.body != nil {
+		body, _ = h.body.get(payload)
+	}
+	lr.Body().FromRaw(body)
+
+	if h.timestamp != nil {
+		if v, ok := h.timestamp.get(payload); ok {
+			if ts, ok := parseTimestamp(v); ok {
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+			}
+		}
+	}
+
+	attrs := lr.Attributes()
+	attrs.PutStr(attributeWebhookPath, h.path)
+	for _, name := range h.headers {
+		if v := header.Get(name); v != "" {
+			attrs.PutStr(attributeHeaderPrefix+strings.ToLower(name), v)
+		}
+	}
+	for name, path := range h.attributes {
+		if v, ok := path.get(payload); ok && v != nil {
+			putValue(attrs.PutEmpty(name), v)
+		}
+	}
+	return ld
+}
+
+// putValue sets the decoded JSON value, using an integer for integral numbers.
+func putValue(dest pcommon.Value, v interface{}) {
+	if f, ok := v.(float64); ok && f == math.Trunc(f) && math.Abs(f) <= 1<<53 {
+		dest.SetInt(int64(f))
+		return
+	}
+	dest.FromRaw(v)
+}
+
+// parseTimestamp parses seconds since the epoch or an RFC3339 string.
+func parseTimestamp(v interface{}) (time.Time, bool) {
+	switch tv := v.(type) {
+	case float64:
+		sec, frac := math.Modf(tv)
+		return time.Unix(int64(sec), int64(frac*1e9)), true
+	case string:
+		ts, err := time.Parse(time.RFC3339Nano, tv)
+		return ts, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
+type logsReceiver struct {
+	config       *Config
+	settings     component.ReceiverCreateSettings
+	nextConsumer consumer.Logs
+	obsrecv      *obsreport.Receiver
+	handlers     map[string]*pathHandler
+
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+func newLogsReceiver(cfg *Config, set component.ReceiverCreateSettings, nextConsumer consumer.Logs) (*logsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, errNilNextConsumer
+	}
+
+	handlers := make(map[string]*pathHandler, len(cfg.Paths))
+	for _, p := range cfg.Paths {
+		h, err := newPathHandler(p)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %w", p.Path, err)
+		}
+		handlers[p.Path] = h
+	}
+
+	return &logsReceiver{
+		config:       cfg,
+		settings:     set,
+		nextConsumer: nextConsumer,
+		handlers:     handlers,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              obsreportTransportHTTP,
+			ReceiverCreateSettings: set,
+		}),
+	}, nil
+}
+
+// Start starts the HTTP server receiving the webhook events.
+func (r *logsReceiver) Start(_ context.Context, host component.Host) error {
+	if host == nil {
+		return errMissingHost
+	}
+
+	mux := http.NewServeMux()
+	for path, h := range r.handlers {
+		h := h
+		mux.HandleFunc(path, func(w http.ResponseWriter, req *http.Request) {
+			r.handleEvent(w, req, h)
+		})
+	}
+
+	var err error
+	r.server, err = r.config.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, mux)
+	if err != nil {
+		return err
+	}
+
+	var listener net.Listener
+	listener, err = r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+
+		if errHTTP := r.server.Serve(listener); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *logsReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Close()
+	r.shutdownWG.Wait()
+	return err
+}
+
+func (r *logsReceiver) handleEvent(w http.ResponseWriter, req *http.Request, h *pathHandler) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := readBody(req.Body, r.config.MaxRequestBodySize)
+	_ = req.Body.Close()
+	switch {
+	case errors.Is(err, errBodyTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	case err != nil:
+		http.Error(w, "failed to read the request body", http.StatusBadRequest)
+		return
+	}
+
+	if h.verifier != nil {
+		if err = h.verifier.verify(req.Header, body); err != nil {
+			r.settings.Logger.Debug("Refusing webhook event", zap.String("path", h.path), zap.Error(err))
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	var payload interface{}
+	if err = json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.obsrecv.StartLogsOp(req.Context())
+	err = r.nextConsumer.ConsumeLogs(ctx, h.toLogs(req.Header, payload, time.Now()))
+	r.obsrecv.EndLogsOp(ctx, typeStr, 1, err)
+	if err != nil {
+		r.settings.Logger.Error("Failed to consume webhook event", zap.String("path", h.path), zap.Error(err))
+		status := http.StatusServiceUnavailable
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, "failed to process the event", status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// readBody reads the whole body, failing with errBodyTooLarge when it exceeds
+// the given size.
+func readBody(body io.Reader, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, errBodyTooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookeventreceiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const githubEvent = `{
+	"action": "opened",
+	"number": 7,
+	"repository": {"full_name": "octo/hello", "private": false},
+	"sender": {"login": "octocat"},
+	"labels": ["bug", "p1"],
+	"created_at": "2022-10-04T10:20:30Z"
+}`
+
+func newTestReceiver(t *testing.T, paths []PathConfig, next consumer.Logs) *logsReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.Paths = paths
+	r, err := newLogsReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), next)
+	require.NoError(t, err)
+	return r
+}
+
+func post(r *logsReceiver, path string, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	got := httptest.NewRecorder()
+	r.handleEvent(got, req, r.handlers[path])
+	return got
+}
+
+func TestStart(t *testing.T) {
+	r := newTestReceiver(t, []PathConfig{{Path: "/events"}}, consumertest.NewNop())
+	assert.Equal(t, errMissingHost, r.Start(context.Background(), nil))
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	t.Run("WithPortTaken", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "localhost:")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, listener.Close())
+		})
+
+		r := newTestReceiver(t, []PathConfig{{Path: "/events"}}, consumertest.NewNop())
+		r.config.Endpoint = listener.Addr().String()
+		require.Error(t, r.Start(context.Background(), componenttest.NewNopHost()))
+		require.NoError(t, r.Shutdown(context.Background()))
+	})
+}
+
+func TestNewLogsReceiverErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	_, err := newLogsReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), nil)
+	assert.Equal(t, errNilNextConsumer, err)
+
+	cfg.Paths = []PathConfig{{Path: "/events", Body: "$["}}
+	_, err = newLogsReceiver(cfg, componenttest.NewNopReceiverCreateSettings(), consumertest.NewNop())
+	assert.EqualError(t, err, `path "/events": invalid JSON path "$[": missing ]`)
+}
+
+func TestHandleEvent(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, []PathConfig{{
+		Path:      "/github",
+		Headers:   []string{"X-GitHub-Event"},
+		Timestamp: "$.created_at",
+		Attributes: map[string]string{
+			"action":     "$.action",
+			"number":     "$.number",
+			"repository": "$.repository.full_name",
+			"private":    "$.repository.private",
+			"labels":     "$.labels",
+			"missing":    "$.pull_request.id",
+		},
+	}}, sink)
+
+	header := http.Header{}
+	header.Set("X-GitHub-Event", "pull_request")
+	before := time.Now()
+	got := post(r, "/github", githubEvent, header)
+	require.Equal(t, http.StatusOK, got.Code)
+
+	require.Equal(t, 1, sink.LogRecordCount())
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.Date(2022, 10, 4, 10, 20, 30, 0, time.UTC), lr.Timestamp().AsTime())
+	assert.False(t, lr.ObservedTimestamp().AsTime().Before(before))
+	assert.Equal(t, pcommon.ValueTypeMap, lr.Body().Type())
+	assert.Equal(t, "octo/hello", lr.Body().Map().AsRaw()["repository"].(map[string]interface{})["full_name"])
+
+	assert.Equal(t, map[string]interface{}{
+		"webhook.path":                  "/github",
+		"webhook.header.x-github-event": "pull_request",
+		"action":                        "opened",
+		"number":                        int64(7),
+		"repository":                    "octo/hello",
+		"private":                       false,
+		"labels":                        []interface{}{"bug", "p1"},
+	}, lr.Attributes().AsRaw())
+}
+
+func TestHandleEventBody(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, []PathConfig{{
+		Path:      "/stripe",
+		Timestamp: "$.created",
+		Body:      "$.data.object.description",
+	}}, sink)
+
+	got := post(r, "/stripe", `{"created": 1492774577.5, "data": {"object": {"description": "charge"}}}`, nil)
+	require.Equal(t, http.StatusOK, got.Code)
+
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.Unix(1492774577, 5e8).UnixNano(), lr.Timestamp().AsTime().UnixNano())
+	assert.Equal(t, "charge", lr.Body().Str())
+}
+
+func TestHandleEventErrors(t *testing.T) {
+	secret := "secret"
+	body := `{"action":"opened"}`
+	validHeader := http.Header{}
+	validHeader.Set(headerGitHubSignature, "sha256="+hex.EncodeToString(sign(sha256.New, secret, body)))
+
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		header       http.Header
+		consumerErr  error
+		expectedCode int
+	}{
+		{
+			name:         "valid",
+			body:         body,
+			header:       validHeader,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "method not allowed",
+			method:       http.MethodGet,
+			header:       validHeader,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "missing signature",
+			body:         body,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "invalid signature",
+			body:         `{"action":"closed"}`,
+			header:       validHeader,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "too large",
+			body:         body + strings.Repeat(" ", defaultMaxRequestBodySize),
+			header:       validHeader,
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:         "consumer error",
+			body:         body,
+			header:       validHeader,
+			consumerErr:  errors.New("consumer error"),
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:         "permanent consumer error",
+			body:         body,
+			header:       validHeader,
+			consumerErr:  consumererror.NewPermanent(errors.New("consumer error")),
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := consumertest.NewErr(tt.consumerErr)
+			if tt.consumerErr == nil {
+				next = consumertest.NewNop()
+			}
+			r := newTestReceiver(t, []PathConfig{{
+				Path:      "/github",
+				Signature: &SignatureConfig{Scheme: schemeGitHub, Secret: secret},
+			}}, next)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, "/github", strings.NewReader(tt.body))
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			got := httptest.NewRecorder()
+			r.handleEvent(got, req, r.handlers["/github"])
+			assert.Equal(t, tt.expectedCode, got.Code)
+		})
+	}
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		r := newTestReceiver(t, []PathConfig{{Path: "/events"}}, consumertest.NewNop())
+		got := post(r, "/events", `{"action":`, nil)
+		assert.Equal(t, http.StatusBadRequest, got.Code)
+	})
+
+	t.Run("body read error", func(t *testing.T) {
+		r := newTestReceiver(t, []PathConfig{{Path: "/events"}}, consumertest.NewNop())
+		req := httptest.NewRequest(http.MethodPost, "/events", iotest.ErrReader(errors.New("connection reset")))
+		got := httptest.NewRecorder()
+		r.handleEvent(got, req, r.handlers["/events"])
+		assert.Equal(t, http.StatusBadRequest, got.Code)
+	})
+}
+
+func TestReceiverServesPaths(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	r := newTestReceiver(t, []PathConfig{{Path: "/a"}, {Path: "/b"}}, sink)
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	r.config.Endpoint = ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	for _, path := range []string{"/a", "/b", "/c"} {
+		resp, err := http.Post("http://"+r.config.Endpoint+path, "application/json", strings.NewReader(`{"id": 1}`))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		if path == "/c" {
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		} else {
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+	}
+
+	require.Equal(t, 2, sink.LogRecordCount())
+	var paths []string
+	for _, ld := range sink.AllLogs() {
+		v, _ := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get(attributeWebhookPath)
+		paths = append(paths, v.Str())
+	}
+	assert.Equal(t, []string{"/a", "/b"}, paths)
+}
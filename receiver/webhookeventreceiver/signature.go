@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- SHA1 is only used to verify signatures of services which still rely on it
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	headerGitHubSignature = "X-Hub-Signature-256"
+	headerStripeSignature = "Stripe-Signature"
+
+	defaultStripeTolerance = 5 * time.Minute
+)
+
+var (
+	errMissingSignature = errors.New("missing signature")
+	errInvalidSignature = errors.New("invalid signature")
+	errExpiredSignature = errors.New("signature timestamp out of tolerance")
+)
+
+// verifier checks the signature of a webhook event.
+type verifier interface {
+	verify(header http.Header, body []byte) error
+}
+
+func newVerifier(cfg *SignatureConfig) verifier {
+	switch cfg.Scheme {
+	case schemeGitHub:
+		return &hmacVerifier{
+			secret:   []byte(cfg.Secret),
+			header:   headerGitHubSignature,
+			hash:     sha256.New,
+			prefix:   "sha256=",
+			decodeFn: hex.DecodeString,
+		}
+	case schemeStripe:
+		tolerance := cfg.Tolerance
+		if tolerance == 0 {
+			tolerance = defaultStripeTolerance
+		}
+		return &stripeVerifier{
+			secret:    []byte(cfg.Secret),
+			tolerance: tolerance,
+			now:       time.Now,
+		}
+	default:
+		v := &hmacVerifier{
+			secret:   []byte(cfg.Secret),
+			header:   cfg.Header,
+			hash:     sha256.New,
+			prefix:   cfg.Prefix,
+			decodeFn: hex.DecodeString,
+		}
+		switch cfg.Algorithm {
+		case algorithmSHA1:
+			v.hash = sha1.New
+		case algorithmSHA512:
+			v.hash = sha512.New
+		}
+		if cfg.Encoding == encodingBase64 {
+			v.decodeFn = base64.StdEncoding.DecodeString
+		}
+		return v
+	}
+}
+
+// hmacVerifier checks a header holding the HMAC of the body.
+type hmacVerifier struct {
+	secret   []byte
+	header   string
+	hash     func() hash.Hash
+	prefix   string
+	decodeFn func(string) ([]byte, error)
+}
+
+func (v *hmacVerifier) verify(header http.Header, body []byte) error {
+	value := header.Get(v.header)
+	if value == "" {
+		return errMissingSignature
+	}
+	signature, err := v.decodeFn(strings.TrimPrefix(value, v.prefix))
+	if err != nil {
+		return errInvalidSignature
+	}
+
+	mac := hmac.New(v.hash, v.secret)
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// stripeVerifier checks the Stripe-Signature header, made of the timestamp of the event
+// and of one or more HMAC-SHA256 signatures of the timestamp and the body:
+// "t=1492774577,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd".
+type stripeVerifier struct {
+	secret    []byte
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+func (v *stripeVerifier) verify(header http.Header, body []byte) error {
+	value := header.Get(headerStripeSignature)
+	if value == "" {
+		return errMissingSignature
+	}
+
+	var timestamp string
+	var signatures [][]byte
+	for _, part := range strings.Split(value, ",") {
+		key, val, found := strings.Cut(part, "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = val
+		case "v1":
+			if signature, err := hex.DecodeString(val); err == nil {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return errInvalidSignature
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errInvalidSignature
+	}
+	if age := v.now().Sub(time.Unix(seconds, 0)); age > v.tolerance || age < -v.tolerance {
+		return errExpiredSignature
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	for _, signature := range signatures {
+		if hmac.Equal(signature, expected) {
+			return nil
+		}
+	}
+	return errInvalidSignature
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookeventreceiver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sign(h func() hash.Hash, secret string, parts ...string) []byte {
+	mac := hmac.New(h, []byte(secret))
+	for _, part := range parts {
+		mac.Write([]byte(part))
+	}
+	return mac.Sum(nil)
+}
+
+func TestGitHubVerifier(t *testing.T) {
+	body := `{"action":"opened"}`
+	v := newVerifier(&SignatureConfig{Scheme: schemeGitHub, Secret: "secret"})
+
+	tests := []struct {
+		name        string
+		signature   string
+		expectedErr error
+	}{
+		{
+			name:      "valid",
+			signature: "sha256=" + hex.EncodeToString(sign(sha256.New, "secret", body)),
+		},
+		{
+			name:        "missing",
+			expectedErr: errMissingSignature,
+		},
+		{
+			name:        "wrong secret",
+			signature:   "sha256=" + hex.EncodeToString(sign(sha256.New, "other", body)),
+			expectedErr: errInvalidSignature,
+		},
+		{
+			name:        "not hex",
+			signature:   "sha256=zz",
+			expectedErr: errInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.signature != "" {
+				header.Set(headerGitHubSignature, tt.signature)
+			}
+			assert.Equal(t, tt.expectedErr, v.verify(header, []byte(body)))
+		})
+	}
+}
+
+func TestHMACVerifier(t *testing.T) {
+	body := `{"id":1}`
+	v := newVerifier(&SignatureConfig{
+		Scheme:    schemeHMAC,
+		Secret:    "secret",
+		Header:    "X-Signature",
+		Algorithm: algorithmSHA512,
+		Encoding:  encodingBase64,
+		Prefix:    "sha512=",
+	})
+
+	header := http.Header{}
+	header.Set("X-Signature", "sha512="+base64.StdEncoding.EncodeToString(sign(sha512.New, "secret", body)))
+	assert.NoError(t, v.verify(header, []byte(body)))
+	assert.Equal(t, errInvalidSignature, v.verify(header, []byte(`{"id":2}`)))
+
+	header.Set("X-Signature", "sha512="+hex.EncodeToString(sign(sha512.New, "secret", body)))
+	assert.Equal(t, errInvalidSignature, v.verify(header, []byte(body)))
+}
+
+func TestStripeVerifier(t *testing.T) {
+	body := `{"type":"charge.succeeded"}`
+	now := time.Unix(1492774577, 0)
+	v := newVerifier(&SignatureConfig{Scheme: schemeStripe, Secret: "secret"}).(*stripeVerifier)
+	v.now = func() time.Time { return now }
+	assert.Equal(t, defaultStripeTolerance, v.tolerance)
+
+	signature := func(ts time.Time, secret string) string {
+		timestamp := fmt.Sprint(ts.Unix())
+		return hex.EncodeToString(sign(sha256.New, secret, timestamp, ".", body))
+	}
+
+	tests := []struct {
+		name        string
+		header      string
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			header: fmt.Sprintf("t=%d,v1=%s", now.Unix(), signature(now, "secret")),
+		},
+		{
+			name:   "valid among several signatures",
+			header: fmt.Sprintf("t=%d,v1=%s,v1=%s,v0=abc", now.Unix(), signature(now, "rolled"), signature(now, "secret")),
+		},
+		{
+			name:        "missing",
+			expectedErr: errMissingSignature,
+		},
+		{
+			name:        "missing timestamp",
+			header:      "v1=" + signature(now, "secret"),
+			expectedErr: errInvalidSignature,
+		},
+		{
+			name:        "wrong secret",
+			header:      fmt.Sprintf("t=%d,v1=%s", now.Unix(), signature(now, "other")),
+			expectedErr: errInvalidSignature,
+		},
+		{
+			name:        "expired",
+			header:      fmt.Sprintf("t=%d,v1=%s", now.Add(-time.Hour).Unix(), signature(now.Add(-time.Hour), "secret")),
+			expectedErr: errExpiredSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.header != "" {
+				header.Set(headerStripeSignature, tt.header)
+			}
+			assert.Equal(t, tt.expectedErr, v.verify(header, []byte(body)))
+		})
+	}
+}
@@ -16,6 +16,7 @@ package windowsperfcountersreceiver // import "github.com/open-telemetry/opentel
 
 import (
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 	"go.uber.org/multierr"
@@ -25,8 +26,14 @@ import (
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 
-	MetricMetaData map[string]MetricConfig `mapstructure:"metrics"`
-	PerfCounters   []ObjectConfig          `mapstructure:"perfcounters"`
+	MetricMetaData  map[string]MetricConfig `mapstructure:"metrics"`
+	PerfCounters    []ObjectConfig          `mapstructure:"perfcounters"`
+	ComputedMetrics []ComputedMetricConfig  `mapstructure:"computed_metrics"`
+
+	// InstanceRefreshInterval is the interval at which counters with wildcard
+	// instances are enumerated again, and counters that could not be
+	// initialized are retried. Zero disables the refresh.
+	InstanceRefreshInterval time.Duration `mapstructure:"instance_refresh_interval"`
 }
 
 // MetricsConfig defines the configuration for a metric to be created.
@@ -58,6 +65,21 @@ type CounterConfig struct {
 	MetricRep `mapstructure:",squash"`
 }
 
+// ComputedMetricConfig defines a metric computed from the values of two
+// counters for each of their common instances.
+type ComputedMetricConfig struct {
+	MetricRep `mapstructure:",squash"`
+	Operation string   `mapstructure:"operation"`
+	Operands  []string `mapstructure:"operands"`
+}
+
+const (
+	operationRatio      = "ratio"
+	operationSum        = "sum"
+	operationDifference = "difference"
+	operationProduct    = "product"
+)
+
 type MetricRep struct {
 	Name       string            `mapstructure:"metric"`
 	Attributes map[string]string `mapstructure:"attributes"`
@@ -129,5 +151,50 @@ func (c *Config) Validate() error {
 		errs = multierr.Append(errs, fmt.Errorf("must specify object name for all perf counters"))
 	}
 
+	if c.InstanceRefreshInterval < 0 {
+		errs = multierr.Append(errs, fmt.Errorf("instance_refresh_interval must not be negative"))
+	}
+
+	for _, computed := range c.ComputedMetrics {
+		errs = multierr.Append(errs, c.validateComputedMetric(computed))
+	}
+
 	return errs
 }
+
+func (c *Config) validateComputedMetric(computed ComputedMetricConfig) error {
+	if computed.MetricRep.Name == "" {
+		return fmt.Errorf("must specify metric name for all computed metrics")
+	}
+	if _, ok := c.MetricMetaData[computed.MetricRep.Name]; !ok {
+		return fmt.Errorf("computed metric %q is undefined", computed.MetricRep.Name)
+	}
+
+	var errs error
+	switch computed.Operation {
+	case operationRatio, operationSum, operationDifference, operationProduct:
+	default:
+		errs = multierr.Append(errs, fmt.Errorf("computed metric %q includes an invalid operation", computed.MetricRep.Name))
+	}
+
+	if len(computed.Operands) != 2 {
+		return multierr.Append(errs, fmt.Errorf("computed metric %q must have two operands", computed.MetricRep.Name))
+	}
+	for _, operand := range computed.Operands {
+		if !c.hasCounterMetric(operand) {
+			errs = multierr.Append(errs, fmt.Errorf("computed metric %q includes operand %q which is not the metric of a counter", computed.MetricRep.Name, operand))
+		}
+	}
+	return errs
+}
+
+func (c *Config) hasCounterMetric(name string) bool {
+	for _, pc := range c.PerfCounters {
+		for _, counter := range pc.Counters {
+			if counter.MetricRep.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
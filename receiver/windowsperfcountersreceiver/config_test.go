@@ -33,6 +33,7 @@ const (
 	noObjectNameErr               = "must specify object name for all perf counters"
 	noCountersErr                 = `perf counter for object "%s" does not specify any counters`
 	emptyInstanceErr              = `perf counter for object "%s" includes an empty instance`
+	negativeRefreshIntervalErr    = "instance_refresh_interval must not be negative"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -189,6 +190,62 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "computedmetric"),
+			expected: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					ReceiverSettings:   config.NewReceiverSettings(config.NewComponentID(typeStr)),
+					CollectionInterval: 60 * time.Second,
+				},
+				InstanceRefreshInterval: 5 * time.Minute,
+				PerfCounters: []ObjectConfig{
+					{
+						Object:    "object",
+						Instances: []string{"*"},
+						Counters: []CounterConfig{
+							counterConfig,
+							{Name: "counter2", MetricRep: MetricRep{Name: "metric2"}},
+						},
+					},
+				},
+				ComputedMetrics: []ComputedMetricConfig{
+					{
+						MetricRep: MetricRep{Name: "ratio"},
+						Operation: "ratio",
+						Operands:  []string{"metric", "metric2"},
+					},
+				},
+				MetricMetaData: map[string]MetricConfig{
+					"metric": {
+						Description: "desc",
+						Unit:        "1",
+						Gauge:       GaugeMetric{},
+					},
+					"metric2": {
+						Description: "desc",
+						Unit:        "1",
+						Gauge:       GaugeMetric{},
+					},
+					"ratio": {
+						Description: "desc",
+						Unit:        "1",
+						Gauge:       GaugeMetric{},
+					},
+				},
+			},
+		},
+		{
+			id:          config.NewComponentIDWithName(typeStr, "negative-instance-refresh-interval"),
+			expectedErr: negativeRefreshIntervalErr,
+		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "invalidcomputedmetric"),
+			expectedErr: `computed metric "ratio" includes an invalid operation; ` +
+				`computed metric "ratio" includes operand "other" which is not the metric of a counter; ` +
+				`computed metric "undefined" is undefined; ` +
+				`must specify metric name for all computed metrics; ` +
+				`computed metric "ratio" must have two operands`,
+		},
 		{
 			id:          config.NewComponentIDWithName(typeStr, "negative-collection-interval"),
 			expectedErr: negativeCollectionIntervalErr,
@@ -19,6 +19,8 @@ package windowsperfcountersreceiver // import "github.com/open-telemetry/opentel
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -30,11 +32,37 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/winperfcounters"
 )
 
-const instanceLabelName = "instance"
+const (
+	instanceLabelName = "instance"
+
+	unavailableMetricName = "windows.perfcounter.unavailable"
+	pathLabelName         = "path"
+)
 
 type perfCounterMetricWatcher struct {
 	winperfcounters.PerfCounterWatcher
 	MetricRep
+	spec counterSpec
+}
+
+// counterSpec is a counter of the configuration for an instance.
+type counterSpec struct {
+	object   string
+	instance string
+	counter  CounterConfig
+}
+
+// path returns the path of the counter, as built by winperfcounters.
+func (c counterSpec) path() string {
+	instance := c.instance
+	if instance != "" {
+		instance = fmt.Sprintf("(%s)", instance)
+	}
+	return fmt.Sprintf("\\%s%s\\%s", c.object, instance, c.counter.Name)
+}
+
+func (c counterSpec) hasWildcard() bool {
+	return strings.Contains(c.instance, "*")
 }
 
 type newWatcherFunc func(string, string, string) (winperfcounters.PerfCounterWatcher, error)
@@ -45,6 +73,10 @@ type scraper struct {
 	settings component.TelemetrySettings
 	watchers []perfCounterMetricWatcher
 
+	// missing are the counters that could not be initialized
+	missing     []counterSpec
+	lastRefresh time.Time
+
 	// for mocking
 	newWatcher newWatcherFunc
 }
@@ -54,44 +86,90 @@ func newScraper(cfg *Config, settings component.TelemetrySettings) *scraper {
 }
 
 func (s *scraper) start(context.Context, component.Host) error {
-	watchers, err := s.initWatchers()
+	watchers, missing, err := s.createWatchers(s.counterSpecs())
 	if err != nil {
 		s.settings.Logger.Warn("some performance counters could not be initialized", zap.Error(err))
 	}
 	s.watchers = watchers
+	s.missing = missing
+	s.lastRefresh = time.Now()
 	return nil
 }
 
+func (s *scraper) counterSpecs() []counterSpec {
+	var specs []counterSpec
+	for _, objCfg := range s.cfg.PerfCounters {
+		for _, instance := range instancesFromConfig(objCfg) {
+			for _, counterCfg := range objCfg.Counters {
+				specs = append(specs, counterSpec{object: objCfg.Object, instance: instance, counter: counterCfg})
+			}
+		}
+	}
+	return specs
+}
+
 func (s *scraper) initWatchers() ([]perfCounterMetricWatcher, error) {
+	watchers, _, err := s.createWatchers(s.counterSpecs())
+	return watchers, err
+}
+
+// createWatchers creates the watchers of the counters, returning the counters
+// that could not be initialized.
+func (s *scraper) createWatchers(specs []counterSpec) ([]perfCounterMetricWatcher, []counterSpec, error) {
 	var errs error
 	var watchers []perfCounterMetricWatcher
+	var missing []counterSpec
 
-	for _, objCfg := range s.cfg.PerfCounters {
-		for _, instance := range instancesFromConfig(objCfg) {
-			for _, counterCfg := range objCfg.Counters {
-				pcw, err := s.newWatcher(objCfg.Object, instance, counterCfg.Name)
-				if err != nil {
-					errs = multierr.Append(errs, err)
-					continue
-				}
-
-				watcher := perfCounterMetricWatcher{
-					PerfCounterWatcher: pcw,
-					MetricRep:          MetricRep{Name: pcw.Path()},
-				}
-				if counterCfg.MetricRep.Name != "" {
-					watcher.MetricRep.Name = counterCfg.MetricRep.Name
-					if counterCfg.MetricRep.Attributes != nil {
-						watcher.MetricRep.Attributes = counterCfg.MetricRep.Attributes
-					}
-				}
-
-				watchers = append(watchers, watcher)
+	for _, spec := range specs {
+		pcw, err := s.newWatcher(spec.object, spec.instance, spec.counter.Name)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			missing = append(missing, spec)
+			continue
+		}
+
+		watcher := perfCounterMetricWatcher{
+			PerfCounterWatcher: pcw,
+			MetricRep:          MetricRep{Name: pcw.Path()},
+			spec:               spec,
+		}
+		if spec.counter.MetricRep.Name != "" {
+			watcher.MetricRep.Name = spec.counter.MetricRep.Name
+			if spec.counter.MetricRep.Attributes != nil {
+				watcher.MetricRep.Attributes = spec.counter.MetricRep.Attributes
 			}
 		}
+
+		watchers = append(watchers, watcher)
 	}
 
-	return watchers, errs
+	return watchers, missing, errs
+}
+
+// refreshWatchers creates the watchers of counters with wildcard instances
+// again, so that new instances are collected, and retries the counters that
+// could not be initialized.
+func (s *scraper) refreshWatchers() {
+	var watchers []perfCounterMetricWatcher
+	specs := s.missing
+	for _, watcher := range s.watchers {
+		if !watcher.spec.hasWildcard() {
+			watchers = append(watchers, watcher)
+			continue
+		}
+		if err := watcher.Close(); err != nil {
+			s.settings.Logger.Debug("failed to close performance counter", zap.String("path", watcher.Path()), zap.Error(err))
+		}
+		specs = append(specs, watcher.spec)
+	}
+
+	refreshed, missing, err := s.createWatchers(specs)
+	if err != nil {
+		s.settings.Logger.Debug("some performance counters could not be initialized", zap.Error(err))
+	}
+	s.watchers = append(watchers, refreshed...)
+	s.missing = missing
+	s.lastRefresh = time.Now()
 }
 
 func (s *scraper) shutdown(context.Context) error {
@@ -106,6 +184,10 @@ func (s *scraper) shutdown(context.Context) error {
 }
 
 func (s *scraper) scrape(context.Context) (pmetric.Metrics, error) {
+	if s.cfg.InstanceRefreshInterval > 0 && time.Since(s.lastRefresh) >= s.cfg.InstanceRefreshInterval {
+		s.refreshWatchers()
+	}
+
 	md := pmetric.NewMetrics()
 	metricSlice := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
 	now := pcommon.NewTimestampFromTime(time.Now())
@@ -136,6 +218,19 @@ func (s *scraper) scrape(context.Context) (pmetric.Metrics, error) {
 		metrics[name] = builtMetric
 	}
 
+	getMetric := func(name string) pmetric.Metric {
+		if builtmetric, ok := metrics[name]; ok {
+			return builtmetric
+		}
+		metric := metricSlice.AppendEmpty()
+		metric.SetName(name)
+		metric.SetUnit("1")
+		metric.SetEmptyGauge()
+		return metric
+	}
+
+	// values of the counters by metric name and instance, for computed metrics
+	values := map[string]map[string]float64{}
 	for _, watcher := range s.watchers {
 		counterVals, err := watcher.ScrapeData()
 		if err != nil {
@@ -144,22 +239,73 @@ func (s *scraper) scrape(context.Context) (pmetric.Metrics, error) {
 		}
 
 		for _, val := range counterVals {
-			var metric pmetric.Metric
-			if builtmetric, ok := metrics[watcher.MetricRep.Name]; ok {
-				metric = builtmetric
-			} else {
-				metric = metricSlice.AppendEmpty()
-				metric.SetName(watcher.MetricRep.Name)
-				metric.SetUnit("1")
-				metric.SetEmptyGauge()
+			initializeMetricDps(getMetric(watcher.MetricRep.Name), now, val, watcher.MetricRep.Attributes)
+
+			if values[watcher.MetricRep.Name] == nil {
+				values[watcher.MetricRep.Name] = map[string]float64{}
 			}
+			values[watcher.MetricRep.Name][val.InstanceName] = val.Value
+		}
+	}
 
-			initializeMetricDps(metric, now, val, watcher.MetricRep.Attributes)
+	for _, computed := range s.cfg.ComputedMetrics {
+		for instance, value := range computeValues(computed, values) {
+			initializeMetricDps(getMetric(computed.MetricRep.Name), now, winperfcounters.CounterValue{InstanceName: instance, Value: value}, computed.MetricRep.Attributes)
 		}
 	}
+
+	if len(s.missing) > 0 {
+		recordUnavailableCounters(metricSlice.AppendEmpty(), now, s.missing)
+	}
 	return md, errs
 }
 
+// computeValues applies the operation of the computed metric to the values of
+// its operands for each of their common instances.
+func computeValues(computed ComputedMetricConfig, values map[string]map[string]float64) map[string]float64 {
+	if len(computed.Operands) != 2 {
+		return nil
+	}
+	left, right := values[computed.Operands[0]], values[computed.Operands[1]]
+
+	result := map[string]float64{}
+	for instance, l := range left {
+		r, ok := right[instance]
+		if !ok {
+			continue
+		}
+		switch computed.Operation {
+		case operationRatio:
+			if r == 0 {
+				continue
+			}
+			result[instance] = l / r
+		case operationSum:
+			result[instance] = l + r
+		case operationDifference:
+			result[instance] = l - r
+		case operationProduct:
+			result[instance] = l * r
+		}
+	}
+	return result
+}
+
+// recordUnavailableCounters records a data point for each configured counter
+// that could not be initialized.
+func recordUnavailableCounters(metric pmetric.Metric, now pcommon.Timestamp, missing []counterSpec) {
+	metric.SetName(unavailableMetricName)
+	metric.SetDescription("Configured performance counters that could not be initialized.")
+	metric.SetUnit("1")
+	dps := metric.SetEmptyGauge().DataPoints()
+	for _, spec := range missing {
+		dp := dps.AppendEmpty()
+		dp.Attributes().PutStr(pathLabelName, spec.path())
+		dp.SetTimestamp(now)
+		dp.SetIntValue(1)
+	}
+}
+
 func initializeMetricDps(metric pmetric.Metric, now pcommon.Timestamp, counterValue winperfcounters.CounterValue,
 	attributes map[string]string) {
 	var dps pmetric.NumberDataPointSlice
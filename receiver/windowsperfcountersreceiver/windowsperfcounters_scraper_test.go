@@ -19,6 +19,7 @@ package windowsperfcountersreceiver
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 	"testing"
 	"time"
@@ -397,3 +398,147 @@ func TestScrape(t *testing.T) {
 		})
 	}
 }
+
+func TestScrapeComputedMetrics(t *testing.T) {
+	cfg := &Config{
+		PerfCounters: []ObjectConfig{
+			{
+				Object: "object",
+				Counters: []CounterConfig{
+					{Name: "used", MetricRep: MetricRep{Name: "used"}},
+					{Name: "total", MetricRep: MetricRep{Name: "total"}},
+				},
+			},
+		},
+		ComputedMetrics: []ComputedMetricConfig{
+			{MetricRep: MetricRep{Name: "ratio"}, Operation: operationRatio, Operands: []string{"used", "total"}},
+		},
+		MetricMetaData: map[string]MetricConfig{
+			"used":  {Description: "used", Unit: "1"},
+			"total": {Description: "total", Unit: "1"},
+			"ratio": {Description: "ratio", Unit: "1"},
+		},
+	}
+	values := map[string][]winperfcounters.CounterValue{
+		"used":  {{InstanceName: "a", Value: 1}, {InstanceName: "b", Value: 3}, {InstanceName: "c", Value: 2}},
+		"total": {{InstanceName: "a", Value: 4}, {InstanceName: "b", Value: 0}},
+	}
+	newWatcher := func(_, _, counter string) (winperfcounters.PerfCounterWatcher, error) {
+		return &mockPerfCounter{path: counter, counterValues: values[counter]}, nil
+	}
+	s := &scraper{cfg: cfg, settings: componenttest.NewNopTelemetrySettings(), newWatcher: newWatcher}
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+
+	m, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	metrics := m.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	var ratio pmetric.Metric
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == "ratio" {
+			ratio = metrics.At(i)
+		}
+	}
+	require.Equal(t, "ratio", ratio.Name())
+	assert.Equal(t, "ratio", ratio.Description())
+
+	// instance "b" has a zero denominator and "c" has no denominator
+	dps := ratio.Gauge().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, 0.25, dps.At(0).DoubleValue())
+	instance, ok := dps.At(0).Attributes().Get(instanceLabelName)
+	require.True(t, ok)
+	assert.Equal(t, "a", instance.Str())
+}
+
+func TestComputeValues(t *testing.T) {
+	values := map[string]map[string]float64{
+		"left":  {"": 6},
+		"right": {"": 3},
+	}
+	testCases := []struct {
+		operation string
+		expected  float64
+	}{
+		{operation: operationRatio, expected: 2},
+		{operation: operationSum, expected: 9},
+		{operation: operationDifference, expected: 3},
+		{operation: operationProduct, expected: 18},
+	}
+	for _, test := range testCases {
+		t.Run(test.operation, func(t *testing.T) {
+			computed := ComputedMetricConfig{Operation: test.operation, Operands: []string{"left", "right"}}
+			assert.Equal(t, map[string]float64{"": test.expected}, computeValues(computed, values))
+		})
+	}
+}
+
+func TestScrapeUnavailableCounters(t *testing.T) {
+	cfg := &Config{
+		InstanceRefreshInterval: time.Nanosecond,
+		PerfCounters: []ObjectConfig{
+			{Object: "Memory", Counters: []CounterConfig{{Name: "Committed Bytes"}}},
+			{Object: "Missing", Instances: []string{"*"}, Counters: []CounterConfig{{Name: "Missing Counter"}}},
+		},
+	}
+	available := false
+	newWatcher := func(object, instance, counter string) (winperfcounters.PerfCounterWatcher, error) {
+		if object == "Missing" && !available {
+			return nil, errors.New("not found")
+		}
+		return &mockPerfCounter{path: object + counter, counterValues: []winperfcounters.CounterValue{{Value: 1}}}, nil
+	}
+	core, obs := observer.New(zapcore.WarnLevel)
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.Logger = zap.New(core)
+	s := &scraper{cfg: cfg, settings: settings, newWatcher: newWatcher}
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	require.Equal(t, 1, obs.Len())
+	require.Len(t, s.watchers, 1)
+
+	m, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	metrics := m.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	unavailable := metrics.At(1)
+	assert.Equal(t, unavailableMetricName, unavailable.Name())
+	require.Equal(t, 1, unavailable.Gauge().DataPoints().Len())
+	dp := unavailable.Gauge().DataPoints().At(0)
+	assert.Equal(t, int64(1), dp.IntValue())
+	path, ok := dp.Attributes().Get(pathLabelName)
+	require.True(t, ok)
+	assert.Equal(t, "\\Missing(*)\\Missing Counter", path.Str())
+
+	// the counter is created once it becomes available
+	available = true
+	m, err = s.scrape(context.Background())
+	require.NoError(t, err)
+	require.Len(t, s.watchers, 2)
+	require.Empty(t, s.missing)
+	metrics = m.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		assert.NotEqual(t, unavailableMetricName, metrics.At(i).Name())
+	}
+}
+
+func TestRefreshWatchers(t *testing.T) {
+	cfg := &Config{
+		InstanceRefreshInterval: time.Nanosecond,
+		PerfCounters: []ObjectConfig{
+			{Object: "Memory", Counters: []CounterConfig{{Name: "Committed Bytes"}}},
+			{Object: "Process", Instances: []string{"*"}, Counters: []CounterConfig{{Name: "Working Set"}}},
+		},
+	}
+	created := map[string]int{}
+	newWatcher := func(object, instance, counter string) (winperfcounters.PerfCounterWatcher, error) {
+		created[object]++
+		return &mockPerfCounter{path: object + counter}, nil
+	}
+	s := &scraper{cfg: cfg, settings: componenttest.NewNopTelemetrySettings(), newWatcher: newWatcher}
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+
+	_, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"Memory": 1, "Process": 2}, created)
+	assert.Len(t, s.watchers, 2)
+}
@@ -15,8 +15,13 @@
 package zipkinreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zipkinreceiver"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/ingestlimit"
 )
 
 // Config defines configuration for Zipkin receiver.
@@ -27,11 +32,29 @@ type Config struct {
 	// If enabled the zipkin receiver will attempt to parse string tags/binary annotations into int/bool/float.
 	// Disabled by default
 	ParseStringTags bool `mapstructure:"parse_string_tags"`
+	// MaxDecompressedBodySize is the maximum size in bytes of a request body after
+	// decompression. Larger requests are rejected with 413. Disabled by default.
+	MaxDecompressedBodySize int64 `mapstructure:"max_decompressed_body_size"`
+	// Quota limits the requests and spans accepted from every client identity.
+	// Requests over the quota are rejected with 429. Disabled by default.
+	Quota *ingestlimit.QuotaSettings `mapstructure:"quota"`
+	// RateLimiter is the ID of the ratelimiter extension limiting the requests and
+	// spans accepted. Requests over the limit are rejected with 429 and a
+	// Retry-After header. Disabled by default.
+	RateLimiter *config.ComponentID `mapstructure:"rate_limiter"`
 }
 
 var _ config.Receiver = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.MaxDecompressedBodySize < 0 {
+		return errors.New("max_decompressed_body_size must not be negative")
+	}
+	if cfg.Quota != nil {
+		if err := cfg.Quota.Validate(); err != nil {
+			return fmt.Errorf("invalid quota: %w", err)
+		}
+	}
 	return nil
 }
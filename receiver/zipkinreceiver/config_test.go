@@ -23,6 +23,8 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/ingestlimit"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -59,6 +61,21 @@ func TestLoadConfig(t *testing.T) {
 				ParseStringTags: true,
 			},
 		},
+		{
+			id: config.NewComponentIDWithName(typeStr, "limits"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: defaultBindEndpoint,
+				},
+				MaxDecompressedBodySize: 20971520,
+				Quota: &ingestlimit.QuotaSettings{
+					IdentityAttribute: "tenant",
+					RequestsPerSecond: 100,
+					SpansPerSecond:    10000,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -75,3 +92,27 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		errMsg string
+	}{
+		{
+			name:   "negative max decompressed body size",
+			cfg:    &Config{MaxDecompressedBodySize: -1},
+			errMsg: "max_decompressed_body_size must not be negative",
+		},
+		{
+			name:   "empty quota",
+			cfg:    &Config{Quota: &ingestlimit.QuotaSettings{}},
+			errMsg: "invalid quota: at least one of requests_per_second or spans_per_second must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.errMsg)
+		})
+	}
+}
@@ -31,6 +31,8 @@ import (
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ratelimiterextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/ingestlimit"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/zipkin/zipkinv1"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/zipkin/zipkinv2"
 )
@@ -42,7 +44,10 @@ const (
 	receiverTransportV2PROTO  = "http_v2_proto"
 )
 
-var errNextConsumerRespBody = []byte(`"Internal Server Error"`)
+var (
+	errNextConsumerRespBody = []byte(`"Internal Server Error"`)
+	errQuotaExceeded        = errors.New("quota exceeded")
+)
 
 // zipkinReceiver type is used to handle spans received in the Zipkin format.
 type zipkinReceiver struct {
@@ -59,6 +64,9 @@ type zipkinReceiver struct {
 	protobufUnmarshaler      ptrace.Unmarshaler
 	protobufDebugUnmarshaler ptrace.Unmarshaler
 
+	quota   *ingestlimit.Quota
+	limiter ratelimiterextension.Limiter
+
 	settings component.ReceiverCreateSettings
 }
 
@@ -70,6 +78,11 @@ func newReceiver(config *Config, nextConsumer consumer.Traces, settings componen
 		return nil, component.ErrNilNextConsumer
 	}
 
+	var quota *ingestlimit.Quota
+	if config.Quota != nil {
+		quota = ingestlimit.NewQuota(*config.Quota)
+	}
+
 	zr := &zipkinReceiver{
 		nextConsumer:             nextConsumer,
 		id:                       config.ID(),
@@ -78,6 +91,7 @@ func newReceiver(config *Config, nextConsumer consumer.Traces, settings componen
 		v1JSONUnmarshaler:        zipkinv1.NewJSONTracesUnmarshaler(config.ParseStringTags),
 		jsonUnmarshaler:          zipkinv2.NewJSONTracesUnmarshaler(config.ParseStringTags),
 		protobufUnmarshaler:      zipkinv2.NewProtobufTracesUnmarshaler(false, config.ParseStringTags),
+		quota:                    quota,
 		protobufDebugUnmarshaler: zipkinv2.NewProtobufTracesUnmarshaler(true, config.ParseStringTags),
 		settings:                 settings,
 	}
@@ -91,6 +105,12 @@ func (zr *zipkinReceiver) Start(_ context.Context, host component.Host) error {
 	}
 
 	var err error
+	if zr.config.RateLimiter != nil {
+		if zr.limiter, err = ratelimiterextension.GetLimiter(host.GetExtensions(), *zr.config.RateLimiter); err != nil {
+			return err
+		}
+	}
+
 	zr.server, err = zr.config.HTTPServerSettings.ToServer(host, zr.settings.TelemetrySettings, zr)
 	if err != nil {
 		return err
@@ -199,6 +219,11 @@ const (
 func (zr *zipkinReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	if !zr.quota.AllowRequest(ctx) {
+		http.Error(w, errQuotaExceeded.Error(), http.StatusTooManyRequests)
+		return
+	}
+
 	// Now deserialize and process the spans.
 	asZipkinv1 := r.URL != nil && strings.Contains(r.URL.Path, "api/v1/spans")
 
@@ -211,11 +236,23 @@ func (zr *zipkinReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx = obsrecv.StartTracesOp(ctx)
 
 	pr := processBodyIfNecessary(r)
-	slurp, _ := io.ReadAll(pr)
+	// The size is checked after decompression so that a small compressed
+	// payload can not expand into an arbitrarily large one.
+	slurp, readErr := io.ReadAll(ingestlimit.NewLimitedReadCloser(io.NopCloser(pr), zr.config.MaxDecompressedBodySize))
 	if c, ok := pr.(io.Closer); ok {
 		_ = c.Close()
 	}
 	_ = r.Body.Close()
+	receiverTagValue := zipkinV2TagValue
+	if asZipkinv1 {
+		receiverTagValue = zipkinV1TagValue
+	}
+
+	if errors.Is(readErr, ingestlimit.ErrBodyTooLarge) {
+		obsrecv.EndTracesOp(ctx, receiverTagValue, 0, readErr)
+		http.Error(w, readErr.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
 
 	var td ptrace.Traces
 	var err error
@@ -230,12 +267,21 @@ func (zr *zipkinReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	consumerErr := zr.nextConsumer.ConsumeTraces(ctx, td)
+	if !zr.quota.AllowSpans(ctx, td.SpanCount()) {
+		obsrecv.EndTracesOp(ctx, receiverTagValue, td.SpanCount(), errQuotaExceeded)
+		http.Error(w, errQuotaExceeded.Error(), http.StatusTooManyRequests)
+		return
+	}
 
-	receiverTagValue := zipkinV2TagValue
-	if asZipkinv1 {
-		receiverTagValue = zipkinV1TagValue
+	if zr.limiter != nil {
+		if err = zr.limiter.Limit(ctx, r.Header, td.SpanCount()); err != nil {
+			obsrecv.EndTracesOp(ctx, receiverTagValue, td.SpanCount(), err)
+			ratelimiterextension.WriteHTTPRejection(w, err)
+			return
+		}
 	}
+
+	consumerErr := zr.nextConsumer.ConsumeTraces(ctx, td)
 	obsrecv.EndTracesOp(ctx, receiverTagValue, td.SpanCount(), consumerErr)
 
 	if consumerErr != nil {
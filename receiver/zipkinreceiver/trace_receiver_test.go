@@ -39,6 +39,9 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/ratelimiterextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/ingestlimit"
 )
 
 const (
@@ -306,6 +309,165 @@ func TestReceiverConsumerError(t *testing.T) {
 	require.Equal(t, "\"Internal Server Error\"", req.Body.String())
 }
 
+func TestReceiverMaxDecompressedBodySize(t *testing.T) {
+	body, err := os.ReadFile(zipkinV2Single)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		limit    int64
+		wantCode int
+	}{
+		{
+			name:     "disabled",
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name:     "within limit",
+			limit:    int64(len(body)),
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name:     "over limit",
+			limit:    int64(len(body)) - 1,
+			wantCode: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compressed, err := compressGzip(body)
+			require.NoError(t, err)
+			require.Less(t, int64(compressed.Len()), int64(len(body))-1)
+
+			r := httptest.NewRequest("POST", "/api/v2/spans", compressed)
+			r.Header.Add("content-type", "application/json")
+			r.Header.Add("content-encoding", "gzip")
+
+			cfg := &Config{
+				ReceiverSettings:        config.NewReceiverSettings(zipkinReceiverID),
+				MaxDecompressedBodySize: tt.limit,
+			}
+			sink := new(consumertest.TracesSink)
+			zr, err := newReceiver(cfg, sink, componenttest.NewNopReceiverCreateSettings())
+			require.NoError(t, err)
+
+			req := httptest.NewRecorder()
+			zr.ServeHTTP(req, r)
+
+			assert.Equal(t, tt.wantCode, req.Code)
+			if tt.wantCode == http.StatusAccepted {
+				assert.Len(t, sink.AllTraces(), 1)
+			} else {
+				assert.Empty(t, sink.AllTraces())
+			}
+		})
+	}
+}
+
+func TestReceiverQuota(t *testing.T) {
+	body, err := os.ReadFile(zipkinV2Single)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		quota ingestlimit.QuotaSettings
+	}{
+		{
+			name:  "requests",
+			quota: ingestlimit.QuotaSettings{RequestsPerSecond: 1},
+		},
+		{
+			name:  "spans",
+			quota: ingestlimit.QuotaSettings{SpansPerSecond: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quota := tt.quota
+			cfg := &Config{
+				ReceiverSettings: config.NewReceiverSettings(zipkinReceiverID),
+				Quota:            &quota,
+			}
+			sink := new(consumertest.TracesSink)
+			zr, err := newReceiver(cfg, sink, componenttest.NewNopReceiverCreateSettings())
+			require.NoError(t, err)
+
+			codes := make([]int, 2)
+			for i := range codes {
+				r := httptest.NewRequest("POST", "/api/v2/spans", bytes.NewBuffer(body))
+				r.Header.Add("content-type", "application/json")
+				req := httptest.NewRecorder()
+				zr.ServeHTTP(req, r)
+				codes[i] = req.Code
+			}
+
+			assert.Equal(t, []int{http.StatusAccepted, http.StatusTooManyRequests}, codes)
+			assert.Len(t, sink.AllTraces(), 1)
+		})
+	}
+}
+
+type extensionsHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h *extensionsHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestReceiverRateLimiter(t *testing.T) {
+	body, err := os.ReadFile(zipkinV2Single)
+	require.NoError(t, err)
+
+	limiterID := config.NewComponentID("ratelimiter")
+	limiterFactory := ratelimiterextension.NewFactory()
+	limiterCfg := limiterFactory.CreateDefaultConfig().(*ratelimiterextension.Config)
+	limiterCfg.Global = &ratelimiterextension.LimitSettings{ItemsPerSecond: 1.0 / 3600}
+	limiter, err := limiterFactory.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), limiterCfg)
+	require.NoError(t, err)
+
+	cfg := &Config{
+		ReceiverSettings:   config.NewReceiverSettings(zipkinReceiverID),
+		HTTPServerSettings: confighttp.HTTPServerSettings{Endpoint: "localhost:0"},
+		RateLimiter:        &limiterID,
+	}
+	sink := new(consumertest.TracesSink)
+	zr, err := newReceiver(cfg, sink, componenttest.NewNopReceiverCreateSettings())
+	require.NoError(t, err)
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{limiterID: limiter},
+	}
+	require.NoError(t, zr.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, zr.Shutdown(context.Background())) })
+
+	var responses []*httptest.ResponseRecorder
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest("POST", "/api/v2/spans", bytes.NewBuffer(body))
+		r.Header.Add("content-type", "application/json")
+		resp := httptest.NewRecorder()
+		zr.ServeHTTP(resp, r)
+		responses = append(responses, resp)
+	}
+
+	assert.Equal(t, http.StatusAccepted, responses[0].Code)
+	assert.Equal(t, http.StatusTooManyRequests, responses[1].Code)
+	assert.NotEmpty(t, responses[1].Header().Get("Retry-After"))
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestReceiverUnknownRateLimiter(t *testing.T) {
+	limiterID := config.NewComponentID("ratelimiter")
+	cfg := &Config{
+		ReceiverSettings: config.NewReceiverSettings(zipkinReceiverID),
+		RateLimiter:      &limiterID,
+	}
+	zr, err := newReceiver(cfg, consumertest.NewNop(), componenttest.NewNopReceiverCreateSettings())
+	require.NoError(t, err)
+	assert.EqualError(t, zr.Start(context.Background(), componenttest.NewNopHost()), `rate limiter "ratelimiter" not found`)
+}
+
 func thriftExample() []byte {
 	now := time.Now().Unix()
 	zSpans := []*zipkincore.Span{